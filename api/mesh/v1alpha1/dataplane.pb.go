@@ -80,10 +80,16 @@ type Dataplane_Networking struct {
 	// Outbound describes a list of outbound interfaces of the dataplane.
 	Outbound []*Dataplane_Networking_Outbound `protobuf:"bytes,2,rep,name=outbound,proto3" json:"outbound,omitempty"`
 	// TransparentProxying describes configuration for transparent proxying.
-	TransparentProxying  *Dataplane_Networking_TransparentProxying `protobuf:"bytes,3,opt,name=transparent_proxying,json=transparentProxying,proto3" json:"transparent_proxying,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                                  `json:"-"`
-	XXX_unrecognized     []byte                                    `json:"-"`
-	XXX_sizecache        int32                                     `json:"-"`
+	TransparentProxying *Dataplane_Networking_TransparentProxying `protobuf:"bytes,3,opt,name=transparent_proxying,json=transparentProxying,proto3" json:"transparent_proxying,omitempty"`
+	// Gateway turns the dataplane into a gateway.
+	// It cannot be combined with inbound interfaces or with ingress.
+	Gateway *Dataplane_Networking_Gateway `protobuf:"bytes,4,opt,name=gateway,proto3" json:"gateway,omitempty"`
+	// Ingress turns the dataplane into an ingress.
+	// It cannot be combined with inbound interfaces or with gateway.
+	Ingress              *Dataplane_Networking_Ingress `protobuf:"bytes,5,opt,name=ingress,proto3" json:"ingress,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
 }
 
 func (m *Dataplane_Networking) Reset()         { *m = Dataplane_Networking{} }
@@ -140,6 +146,20 @@ func (m *Dataplane_Networking) GetTransparentProxying() *Dataplane_Networking_Tr
 	return nil
 }
 
+func (m *Dataplane_Networking) GetGateway() *Dataplane_Networking_Gateway {
+	if m != nil {
+		return m.Gateway
+	}
+	return nil
+}
+
+func (m *Dataplane_Networking) GetIngress() *Dataplane_Networking_Ingress {
+	if m != nil {
+		return m.Ingress
+	}
+	return nil
+}
+
 // Inbound describes a service implemented by the dataplane.
 type Dataplane_Networking_Inbound struct {
 	// Interface describes networking rules for incoming traffic.
@@ -147,14 +167,31 @@ type Dataplane_Networking_Inbound struct {
 	// <DATAPLANE_IP>:<DATAPLANE_PORT>:<WORKLOAD_PORT>, which means
 	// that dataplane must listen on <DATAPLANE_IP>:<DATAPLANE_PORT>
 	// and must dispatch to 127.0.0.1:<WORKLOAD_PORT>.
+	// IPv6 address must be enclosed in square brackets,
+	// e.g. [fd00::1]:8080:8080.
 	Interface string `protobuf:"bytes,1,opt,name=interface,proto3" json:"interface,omitempty"`
 	// Tags associated with an application this dataplane is deployed next to,
 	// e.g. service=web, version=1.0.
 	// `service` tag is mandatory.
-	Tags                 map[string]string `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	Tags map[string]string `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// BindAddress is an IP address the dataplane must listen on instead of
+	// <DATAPLANE_IP>, e.g. 0.0.0.0 or a private IP address of a host
+	// in the current availability zone.
+	// Optional, <DATAPLANE_IP> is used by default.
+	BindAddress string `protobuf:"bytes,3,opt,name=bind_address,json=bindAddress,proto3" json:"bind_address,omitempty"`
+	// AdvertisedAddress is an IP address other dataplanes must connect to
+	// instead of <DATAPLANE_IP>, e.g. a public IP address of a NAT gateway
+	// or an IP address of a VPN tunnel.
+	// Optional, <DATAPLANE_IP> is used by default.
+	AdvertisedAddress string `protobuf:"bytes,4,opt,name=advertised_address,json=advertisedAddress,proto3" json:"advertised_address,omitempty"`
+	// ServiceAddress is an IP address the dataplane must dispatch to
+	// instead of 127.0.0.1, e.g. if the workload does not listen on
+	// the loopback interface.
+	// Optional, 127.0.0.1 is used by default.
+	ServiceAddress       string   `protobuf:"bytes,5,opt,name=service_address,json=serviceAddress,proto3" json:"service_address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Dataplane_Networking_Inbound) Reset()         { *m = Dataplane_Networking_Inbound{} }
@@ -204,12 +241,34 @@ func (m *Dataplane_Networking_Inbound) GetTags() map[string]string {
 	return nil
 }
 
+func (m *Dataplane_Networking_Inbound) GetBindAddress() string {
+	if m != nil {
+		return m.BindAddress
+	}
+	return ""
+}
+
+func (m *Dataplane_Networking_Inbound) GetAdvertisedAddress() string {
+	if m != nil {
+		return m.AdvertisedAddress
+	}
+	return ""
+}
+
+func (m *Dataplane_Networking_Inbound) GetServiceAddress() string {
+	if m != nil {
+		return m.ServiceAddress
+	}
+	return ""
+}
+
 // Outbound describes a service consumed by the dataplane.
 type Dataplane_Networking_Outbound struct {
 	// Interface describes networking rules for outgoing traffic.
 	// The value is a string formatted as <IP_ADDRESS>:<PORT>,
 	// which means that dataplane must listen on <IP_ADDRESS>:<PORT>
 	// and must be dispatch to <SERVICE>:<SERVICE_PORT>.
+	// IPv6 address must be enclosed in square brackets, e.g. [::1]:10001.
 	Interface string `protobuf:"bytes,1,opt,name=interface,proto3" json:"interface,omitempty"`
 	// Service name.
 	Service string `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`
@@ -325,6 +384,123 @@ func (m *Dataplane_Networking_TransparentProxying) GetRedirectPort() uint32 {
 	return 0
 }
 
+// Gateway describes a dataplane that proxies traffic entering the mesh,
+// e.g. an API gateway, rather than sits next to a service.
+//
+// Incoming traffic of a gateway is not intercepted by the dataplane,
+// it is handled by the gateway itself.
+type Dataplane_Networking_Gateway struct {
+	// Tags associated with the gateway, e.g. service=edge.
+	// `service` tag is mandatory.
+	Tags                 map[string]string `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Dataplane_Networking_Gateway) Reset()         { *m = Dataplane_Networking_Gateway{} }
+func (m *Dataplane_Networking_Gateway) String() string { return proto.CompactTextString(m) }
+func (*Dataplane_Networking_Gateway) ProtoMessage()    {}
+func (*Dataplane_Networking_Gateway) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7608682fd5ea84a4, []int{0, 0, 3}
+}
+func (m *Dataplane_Networking_Gateway) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Dataplane_Networking_Gateway) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Dataplane_Networking_Gateway.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Dataplane_Networking_Gateway) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Dataplane_Networking_Gateway.Merge(m, src)
+}
+func (m *Dataplane_Networking_Gateway) XXX_Size() int {
+	return m.Size()
+}
+func (m *Dataplane_Networking_Gateway) XXX_DiscardUnknown() {
+	xxx_messageInfo_Dataplane_Networking_Gateway.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Dataplane_Networking_Gateway proto.InternalMessageInfo
+
+func (m *Dataplane_Networking_Gateway) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+// Ingress describes a dataplane that accepts traffic destined to
+// services of the mesh from outside of a zone.
+type Dataplane_Networking_Ingress struct {
+	// Interface describes a dedicated listener for incoming traffic.
+	// The value is a string formatted as <DATAPLANE_IP>:<DATAPLANE_PORT>,
+	// which means that dataplane must listen on <DATAPLANE_IP>:<DATAPLANE_PORT>.
+	// IPv6 address must be enclosed in square brackets, e.g. [fd00::1]:10001.
+	Interface string `protobuf:"bytes,1,opt,name=interface,proto3" json:"interface,omitempty"`
+	// Tags associated with the ingress, e.g. service=ingress.
+	// `service` tag is mandatory.
+	Tags                 map[string]string `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Dataplane_Networking_Ingress) Reset()         { *m = Dataplane_Networking_Ingress{} }
+func (m *Dataplane_Networking_Ingress) String() string { return proto.CompactTextString(m) }
+func (*Dataplane_Networking_Ingress) ProtoMessage()    {}
+func (*Dataplane_Networking_Ingress) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7608682fd5ea84a4, []int{0, 0, 4}
+}
+func (m *Dataplane_Networking_Ingress) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Dataplane_Networking_Ingress) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Dataplane_Networking_Ingress.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Dataplane_Networking_Ingress) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Dataplane_Networking_Ingress.Merge(m, src)
+}
+func (m *Dataplane_Networking_Ingress) XXX_Size() int {
+	return m.Size()
+}
+func (m *Dataplane_Networking_Ingress) XXX_DiscardUnknown() {
+	xxx_messageInfo_Dataplane_Networking_Ingress.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Dataplane_Networking_Ingress proto.InternalMessageInfo
+
+func (m *Dataplane_Networking_Ingress) GetInterface() string {
+	if m != nil {
+		return m.Interface
+	}
+	return ""
+}
+
+func (m *Dataplane_Networking_Ingress) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Dataplane)(nil), "kuma.mesh.v1alpha1.Dataplane")
 	proto.RegisterType((*Dataplane_Networking)(nil), "kuma.mesh.v1alpha1.Dataplane.Networking")
@@ -332,41 +508,53 @@ func init() {
 	proto.RegisterMapType((map[string]string)(nil), "kuma.mesh.v1alpha1.Dataplane.Networking.Inbound.TagsEntry")
 	proto.RegisterType((*Dataplane_Networking_Outbound)(nil), "kuma.mesh.v1alpha1.Dataplane.Networking.Outbound")
 	proto.RegisterType((*Dataplane_Networking_TransparentProxying)(nil), "kuma.mesh.v1alpha1.Dataplane.Networking.TransparentProxying")
+	proto.RegisterType((*Dataplane_Networking_Gateway)(nil), "kuma.mesh.v1alpha1.Dataplane.Networking.Gateway")
+	proto.RegisterMapType((map[string]string)(nil), "kuma.mesh.v1alpha1.Dataplane.Networking.Gateway.TagsEntry")
+	proto.RegisterType((*Dataplane_Networking_Ingress)(nil), "kuma.mesh.v1alpha1.Dataplane.Networking.Ingress")
+	proto.RegisterMapType((map[string]string)(nil), "kuma.mesh.v1alpha1.Dataplane.Networking.Ingress.TagsEntry")
 }
 
 func init() { proto.RegisterFile("mesh/v1alpha1/dataplane.proto", fileDescriptor_7608682fd5ea84a4) }
 
 var fileDescriptor_7608682fd5ea84a4 = []byte{
-	// 463 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x93, 0x4f, 0x6f, 0xd3, 0x30,
-	0x18, 0xc6, 0xe5, 0xa4, 0x7f, 0x92, 0xb7, 0xab, 0x34, 0x79, 0x95, 0xa8, 0x2c, 0x51, 0x4d, 0x70,
-	0xa0, 0xda, 0xc1, 0x6d, 0xc7, 0x01, 0x34, 0x71, 0xaa, 0x40, 0xfc, 0x91, 0x80, 0xc9, 0xda, 0x69,
-	0x97, 0xc9, 0x6b, 0x4d, 0x1a, 0xb5, 0xb3, 0x23, 0xc7, 0x2d, 0xf4, 0x2b, 0x70, 0xe2, 0xcc, 0x27,
-	0xe0, 0x23, 0x20, 0x4e, 0xdc, 0xe0, 0xc8, 0x47, 0x40, 0xbd, 0xf1, 0x29, 0x86, 0xec, 0xc4, 0x29,
-	0xd2, 0x38, 0x74, 0xb7, 0x37, 0x7e, 0x9e, 0xf7, 0x97, 0x3c, 0x8f, 0x15, 0xb8, 0x7b, 0x25, 0xf2,
-	0xd9, 0x60, 0x35, 0xe2, 0x8b, 0x6c, 0xc6, 0x47, 0x83, 0x29, 0x37, 0x3c, 0x5b, 0x70, 0x29, 0x68,
-	0xa6, 0x95, 0x51, 0x18, 0xcf, 0x97, 0x57, 0x9c, 0x5a, 0x0f, 0xf5, 0x1e, 0xd2, 0x49, 0x54, 0xa2,
-	0x9c, 0x3c, 0xb0, 0x53, 0xe1, 0x24, 0x77, 0x56, 0x7c, 0x91, 0x4e, 0xb9, 0x11, 0x03, 0x3f, 0x14,
-	0xc2, 0xbd, 0x4f, 0x0d, 0x88, 0x9f, 0x7a, 0x2c, 0x7e, 0x01, 0x20, 0x85, 0x79, 0xaf, 0xf4, 0x3c,
-	0x95, 0x49, 0x17, 0x1d, 0xa2, 0x7e, 0xeb, 0xb8, 0x4f, 0x6f, 0xbe, 0x85, 0x56, 0x2b, 0xf4, 0x4d,
-	0xe5, 0x67, 0xff, 0xec, 0x92, 0xaf, 0x75, 0x80, 0xad, 0x84, 0x5f, 0x41, 0x33, 0x95, 0x97, 0x6a,
-	0x29, 0xa7, 0x5d, 0x74, 0x18, 0xf6, 0x5b, 0xc7, 0xc3, 0x5d, 0xa9, 0xf4, 0x65, 0xb1, 0xc7, 0x3c,
-	0x00, 0xbf, 0x86, 0x48, 0x2d, 0x4d, 0x01, 0x0b, 0x1c, 0x6c, 0xb4, 0x33, 0xec, 0x6d, 0xb9, 0xc8,
-	0x2a, 0x04, 0x56, 0xd0, 0x31, 0x9a, 0xcb, 0x3c, 0xe3, 0x5a, 0x48, 0x73, 0x91, 0x69, 0xf5, 0x61,
-	0x6d, 0xd3, 0x87, 0x2e, 0xfd, 0x93, 0x9d, 0xd1, 0x67, 0x5b, 0xc8, 0x69, 0xc9, 0x60, 0x07, 0xe6,
-	0xe6, 0x21, 0xf9, 0x81, 0xa0, 0x59, 0x86, 0xc2, 0x0f, 0x20, 0x4e, 0xa5, 0x11, 0xfa, 0x1d, 0x9f,
-	0x08, 0xd7, 0x77, 0x3c, 0x8e, 0xbf, 0xfd, 0xf9, 0x1e, 0xd6, 0x74, 0xb0, 0x1f, 0xb0, 0xad, 0x86,
-	0xcf, 0xa1, 0x66, 0x78, 0x92, 0x97, 0x81, 0x4f, 0x6e, 0xdb, 0x1e, 0x3d, 0xe3, 0x49, 0xfe, 0x4c,
-	0x1a, 0xbd, 0x1e, 0x83, 0xe5, 0xd7, 0x3f, 0xa3, 0x20, 0x42, 0xcc, 0x31, 0xc9, 0x23, 0x88, 0x2b,
-	0x19, 0xef, 0x43, 0x38, 0x17, 0xeb, 0xe2, 0x5b, 0x98, 0x1d, 0x71, 0x07, 0xea, 0x2b, 0xbe, 0x58,
-	0x8a, 0x6e, 0xe0, 0xce, 0x8a, 0x87, 0x93, 0xe0, 0x31, 0x22, 0x1f, 0x11, 0x44, 0xbe, 0xd1, 0xdd,
-	0xa3, 0xdc, 0x87, 0x66, 0x2e, 0xf4, 0x2a, 0x9d, 0x94, 0xc4, 0xca, 0x36, 0x43, 0xcc, 0x2b, 0x78,
-	0x08, 0x7b, 0xe5, 0x78, 0x91, 0x29, 0x6d, 0xdc, 0x6d, 0xb4, 0xc7, 0x6d, 0xeb, 0x8c, 0x8e, 0x1a,
-	0xdd, 0xeb, 0xeb, 0xb0, 0x8f, 0x58, 0xab, 0xb4, 0x9c, 0x2a, 0x6d, 0xc8, 0x73, 0x38, 0xf8, 0xcf,
-	0x15, 0xe0, 0x21, 0xb4, 0xb5, 0x98, 0xa6, 0x5a, 0x4c, 0x4c, 0x41, 0x42, 0x8e, 0xd4, 0xb2, 0xa4,
-	0xc6, 0x51, 0xcd, 0x92, 0xd8, 0x9e, 0x77, 0x58, 0xd0, 0x98, 0x7c, 0xd9, 0xf4, 0xd0, 0xcf, 0x4d,
-	0x0f, 0xfd, 0xda, 0xf4, 0xd0, 0xef, 0x4d, 0x0f, 0x9d, 0x47, 0xbe, 0xe3, 0xcb, 0x86, 0xfb, 0x6b,
-	0x1e, 0xfe, 0x0d, 0x00, 0x00, 0xff, 0xff, 0x56, 0x4a, 0x25, 0x55, 0x99, 0x03, 0x00, 0x00,
+	// 584 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x94, 0xcf, 0x6e, 0xd3, 0x4e,
+	0x10, 0xc7, 0xb5, 0x4e, 0xd2, 0xc4, 0xe3, 0xe6, 0xf7, 0x2b, 0xdb, 0x48, 0x58, 0x96, 0x88, 0x02,
+	0x1c, 0x1a, 0x55, 0xc2, 0x49, 0xca, 0x01, 0x54, 0x71, 0x21, 0x02, 0x95, 0x22, 0x01, 0xd5, 0xaa,
+	0xa7, 0x5e, 0xaa, 0x4d, 0xbc, 0x38, 0x56, 0x52, 0xdb, 0x5a, 0x6f, 0x52, 0xf2, 0x0a, 0x1c, 0x78,
+	0x00, 0x0e, 0x9c, 0x79, 0x02, 0x0e, 0x9c, 0x10, 0x17, 0x38, 0xf2, 0x08, 0x28, 0x37, 0x9e, 0xa2,
+	0xc8, 0xfb, 0xc7, 0x01, 0x15, 0xa1, 0x04, 0xa9, 0xb7, 0xcd, 0xcc, 0x77, 0x3e, 0x9e, 0xf9, 0xce,
+	0x28, 0x70, 0xe3, 0x8c, 0x65, 0xa3, 0xce, 0xac, 0x47, 0x27, 0xe9, 0x88, 0xf6, 0x3a, 0x01, 0x15,
+	0x34, 0x9d, 0xd0, 0x98, 0xf9, 0x29, 0x4f, 0x44, 0x82, 0xf1, 0x78, 0x7a, 0x46, 0xfd, 0x5c, 0xe3,
+	0x1b, 0x8d, 0xd7, 0x08, 0x93, 0x30, 0x91, 0xe9, 0x4e, 0xfe, 0x52, 0x4a, 0xef, 0xfa, 0x8c, 0x4e,
+	0xa2, 0x80, 0x0a, 0xd6, 0x31, 0x0f, 0x95, 0xb8, 0xf5, 0xc6, 0x01, 0xfb, 0x91, 0xc1, 0xe2, 0x27,
+	0x00, 0x31, 0x13, 0xe7, 0x09, 0x1f, 0x47, 0x71, 0xe8, 0xa2, 0x16, 0x6a, 0x3b, 0x7b, 0x6d, 0xff,
+	0xf2, 0x57, 0xfc, 0xa2, 0xc4, 0x7f, 0x5e, 0xe8, 0xc9, 0x2f, 0xb5, 0xde, 0x07, 0x00, 0x58, 0xa6,
+	0xf0, 0x53, 0xa8, 0x46, 0xf1, 0x20, 0x99, 0xc6, 0x81, 0x8b, 0x5a, 0xa5, 0xb6, 0xb3, 0xd7, 0x5d,
+	0x95, 0xea, 0x1f, 0xaa, 0x3a, 0x62, 0x00, 0xf8, 0x19, 0xd4, 0x92, 0xa9, 0x50, 0x30, 0x4b, 0xc2,
+	0x7a, 0x2b, 0xc3, 0x5e, 0xe8, 0x42, 0x52, 0x20, 0x70, 0x02, 0x0d, 0xc1, 0x69, 0x9c, 0xa5, 0x94,
+	0xb3, 0x58, 0x9c, 0xa6, 0x3c, 0x79, 0x35, 0xcf, 0xa7, 0x2f, 0xc9, 0xe9, 0x1f, 0xac, 0x8c, 0x3e,
+	0x5e, 0x42, 0x8e, 0x34, 0x83, 0x6c, 0x8b, 0xcb, 0xc1, 0xdc, 0x8b, 0x90, 0x0a, 0x76, 0x4e, 0xe7,
+	0x6e, 0xb9, 0x85, 0xd6, 0xf2, 0xe2, 0x40, 0xd5, 0x11, 0x03, 0x50, 0xbe, 0x86, 0x9c, 0x65, 0x99,
+	0x5b, 0x59, 0x93, 0x75, 0xa8, 0xea, 0x88, 0x01, 0x78, 0x9f, 0x2d, 0xa8, 0x6a, 0xb3, 0xf1, 0x0e,
+	0xd8, 0x51, 0x2c, 0x18, 0x7f, 0x49, 0x87, 0x4c, 0xde, 0x81, 0xdd, 0xb7, 0x3f, 0xfe, 0xf8, 0x54,
+	0x2a, 0x73, 0x6b, 0xcb, 0x22, 0xcb, 0x1c, 0x3e, 0x81, 0xb2, 0xa0, 0x61, 0xa6, 0x17, 0xb1, 0xbf,
+	0xee, 0x56, 0xfd, 0x63, 0x1a, 0x66, 0x8f, 0x63, 0xc1, 0xe7, 0x7d, 0xc8, 0xf9, 0x95, 0xb7, 0xc8,
+	0xaa, 0x21, 0x22, 0x99, 0xf8, 0x26, 0x6c, 0x0e, 0xa2, 0x38, 0x38, 0xa5, 0x41, 0x20, 0x27, 0xcc,
+	0x37, 0x62, 0x13, 0x27, 0x8f, 0x3d, 0x54, 0x21, 0x7c, 0x07, 0x30, 0x0d, 0x66, 0x8c, 0x8b, 0x28,
+	0x63, 0x4b, 0x61, 0x59, 0x0a, 0xaf, 0x2d, 0x33, 0x46, 0xbe, 0x03, 0xff, 0x67, 0x8c, 0xcf, 0xa2,
+	0x21, 0x2b, 0xb4, 0x15, 0xa9, 0xfd, 0x4f, 0x87, 0xb5, 0xd0, 0xbb, 0x07, 0x76, 0xd1, 0x19, 0xde,
+	0x82, 0xd2, 0x98, 0xcd, 0x95, 0x0d, 0x24, 0x7f, 0xe2, 0x06, 0x54, 0x66, 0x74, 0x32, 0x65, 0xae,
+	0x25, 0x63, 0xea, 0xc7, 0xbe, 0x75, 0x1f, 0x79, 0xaf, 0x11, 0xd4, 0xcc, 0x91, 0xad, 0xee, 0xe2,
+	0x6d, 0xa8, 0xea, 0x06, 0x5c, 0xeb, 0x37, 0xd9, 0x08, 0x11, 0x93, 0xc1, 0x5d, 0xd8, 0x34, 0xcd,
+	0xa7, 0x09, 0x17, 0xd2, 0x8e, 0x7a, 0xbf, 0x9e, 0x2b, 0x6b, 0xbb, 0x1b, 0x6d, 0xe4, 0x5e, 0x5c,
+	0x94, 0x88, 0xa3, 0x25, 0x47, 0x09, 0x17, 0xde, 0x01, 0x6c, 0xff, 0xe1, 0x2a, 0x71, 0x17, 0xea,
+	0x9c, 0x05, 0x11, 0x67, 0x43, 0xa1, 0x48, 0x48, 0x92, 0x9c, 0x9c, 0xb4, 0xb1, 0x5b, 0x96, 0x9c,
+	0x4d, 0xa3, 0x90, 0xa0, 0x77, 0x08, 0xaa, 0xfa, 0xf6, 0x8a, 0x8d, 0xa3, 0x35, 0x37, 0xae, 0xeb,
+	0xff, 0xb6, 0xf1, 0x7f, 0xb7, 0xfd, 0x0b, 0xca, 0x6f, 0x37, 0xd4, 0x4b, 0xbe, 0xf2, 0xdb, 0x95,
+	0x1f, 0xba, 0x92, 0x49, 0xfa, 0xde, 0xfb, 0x45, 0x13, 0x7d, 0x5d, 0x34, 0xd1, 0xb7, 0x45, 0x13,
+	0x7d, 0x5f, 0x34, 0xd1, 0x49, 0xcd, 0x74, 0x33, 0xd8, 0x90, 0xff, 0xd9, 0x77, 0x7f, 0x0e, 0x00,
+	0xc9, 0x55, 0x62, 0x71, 0x17, 0x06, 0x00, 0x00,
 }
 
 func (this *Dataplane) Equal(that interface{}) bool {
@@ -434,6 +622,12 @@ func (this *Dataplane_Networking) Equal(that interface{}) bool {
 	if !this.TransparentProxying.Equal(that1.TransparentProxying) {
 		return false
 	}
+	if !this.Gateway.Equal(that1.Gateway) {
+		return false
+	}
+	if !this.Ingress.Equal(that1.Ingress) {
+		return false
+	}
 	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
 		return false
 	}
@@ -469,6 +663,15 @@ func (this *Dataplane_Networking_Inbound) Equal(that interface{}) bool {
 			return false
 		}
 	}
+	if this.BindAddress != that1.BindAddress {
+		return false
+	}
+	if this.AdvertisedAddress != that1.AdvertisedAddress {
+		return false
+	}
+	if this.ServiceAddress != that1.ServiceAddress {
+		return false
+	}
 	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
 		return false
 	}
@@ -534,6 +737,73 @@ func (this *Dataplane_Networking_TransparentProxying) Equal(that interface{}) bo
 	}
 	return true
 }
+func (this *Dataplane_Networking_Gateway) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Dataplane_Networking_Gateway)
+	if !ok {
+		that2, ok := that.(Dataplane_Networking_Gateway)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Tags) != len(that1.Tags) {
+		return false
+	}
+	for i := range this.Tags {
+		if this.Tags[i] != that1.Tags[i] {
+			return false
+		}
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
+}
+func (this *Dataplane_Networking_Ingress) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Dataplane_Networking_Ingress)
+	if !ok {
+		that2, ok := that.(Dataplane_Networking_Ingress)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Interface != that1.Interface {
+		return false
+	}
+	if len(this.Tags) != len(that1.Tags) {
+		return false
+	}
+	for i := range this.Tags {
+		if this.Tags[i] != that1.Tags[i] {
+			return false
+		}
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
+}
 func (m *Dataplane) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -614,6 +884,26 @@ func (m *Dataplane_Networking) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n2
 	}
+	if m.Gateway != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintDataplane(dAtA, i, uint64(m.Gateway.Size()))
+		n3, err := m.Gateway.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n3
+	}
+	if m.Ingress != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintDataplane(dAtA, i, uint64(m.Ingress.Size()))
+		n4, err := m.Ingress.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n4
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -658,6 +948,24 @@ func (m *Dataplane_Networking_Inbound) MarshalTo(dAtA []byte) (int, error) {
 			i += copy(dAtA[i:], v)
 		}
 	}
+	if len(m.BindAddress) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintDataplane(dAtA, i, uint64(len(m.BindAddress)))
+		i += copy(dAtA[i:], m.BindAddress)
+	}
+	if len(m.AdvertisedAddress) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintDataplane(dAtA, i, uint64(len(m.AdvertisedAddress)))
+		i += copy(dAtA[i:], m.AdvertisedAddress)
+	}
+	if len(m.ServiceAddress) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintDataplane(dAtA, i, uint64(len(m.ServiceAddress)))
+		i += copy(dAtA[i:], m.ServiceAddress)
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -728,42 +1036,124 @@ func (m *Dataplane_Networking_TransparentProxying) MarshalTo(dAtA []byte) (int,
 	return i, nil
 }
 
-func encodeVarintDataplane(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *Dataplane_Networking_Gateway) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
+	return dAtA[:n], nil
 }
-func (m *Dataplane) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+
+func (m *Dataplane_Networking_Gateway) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.Networking != nil {
-		l = m.Networking.Size()
-		n += 1 + l + sovDataplane(uint64(l))
+	if len(m.Tags) > 0 {
+		for k, _ := range m.Tags {
+			dAtA[i] = 0xa
+			i++
+			v := m.Tags[k]
+			mapSize := 1 + len(k) + sovDataplane(uint64(len(k))) + 1 + len(v) + sovDataplane(uint64(len(v)))
+			i = encodeVarintDataplane(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintDataplane(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintDataplane(dAtA, i, uint64(len(v)))
+			i += copy(dAtA[i:], v)
+		}
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *Dataplane_Networking) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *Dataplane_Networking_Ingress) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *Dataplane_Networking_Ingress) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if len(m.Inbound) > 0 {
-		for _, e := range m.Inbound {
-			l = e.Size()
-			n += 1 + l + sovDataplane(uint64(l))
-		}
+	if len(m.Interface) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDataplane(dAtA, i, uint64(len(m.Interface)))
+		i += copy(dAtA[i:], m.Interface)
+	}
+	if len(m.Tags) > 0 {
+		for k, _ := range m.Tags {
+			dAtA[i] = 0x12
+			i++
+			v := m.Tags[k]
+			mapSize := 1 + len(k) + sovDataplane(uint64(len(k))) + 1 + len(v) + sovDataplane(uint64(len(v)))
+			i = encodeVarintDataplane(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintDataplane(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintDataplane(dAtA, i, uint64(len(v)))
+			i += copy(dAtA[i:], v)
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func encodeVarintDataplane(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *Dataplane) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Networking != nil {
+		l = m.Networking.Size()
+		n += 1 + l + sovDataplane(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Dataplane_Networking) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Inbound) > 0 {
+		for _, e := range m.Inbound {
+			l = e.Size()
+			n += 1 + l + sovDataplane(uint64(l))
+		}
 	}
 	if len(m.Outbound) > 0 {
 		for _, e := range m.Outbound {
@@ -775,6 +1165,14 @@ func (m *Dataplane_Networking) Size() (n int) {
 		l = m.TransparentProxying.Size()
 		n += 1 + l + sovDataplane(uint64(l))
 	}
+	if m.Gateway != nil {
+		l = m.Gateway.Size()
+		n += 1 + l + sovDataplane(uint64(l))
+	}
+	if m.Ingress != nil {
+		l = m.Ingress.Size()
+		n += 1 + l + sovDataplane(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -799,6 +1197,18 @@ func (m *Dataplane_Networking_Inbound) Size() (n int) {
 			n += mapEntrySize + 1 + sovDataplane(uint64(mapEntrySize))
 		}
 	}
+	l = len(m.BindAddress)
+	if l > 0 {
+		n += 1 + l + sovDataplane(uint64(l))
+	}
+	l = len(m.AdvertisedAddress)
+	if l > 0 {
+		n += 1 + l + sovDataplane(uint64(l))
+	}
+	l = len(m.ServiceAddress)
+	if l > 0 {
+		n += 1 + l + sovDataplane(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -843,6 +1253,50 @@ func (m *Dataplane_Networking_TransparentProxying) Size() (n int) {
 	return n
 }
 
+func (m *Dataplane_Networking_Gateway) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Tags) > 0 {
+		for k, v := range m.Tags {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovDataplane(uint64(len(k))) + 1 + len(v) + sovDataplane(uint64(len(v)))
+			n += mapEntrySize + 1 + sovDataplane(uint64(mapEntrySize))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Dataplane_Networking_Ingress) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Interface)
+	if l > 0 {
+		n += 1 + l + sovDataplane(uint64(l))
+	}
+	if len(m.Tags) > 0 {
+		for k, v := range m.Tags {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovDataplane(uint64(len(k))) + 1 + len(v) + sovDataplane(uint64(len(v)))
+			n += mapEntrySize + 1 + sovDataplane(uint64(mapEntrySize))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func sovDataplane(x uint64) (n int) {
 	for {
 		n++
@@ -1079,6 +1533,78 @@ func (m *Dataplane_Networking) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Gateway", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDataplane
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDataplane
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDataplane
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Gateway == nil {
+				m.Gateway = &Dataplane_Networking_Gateway{}
+			}
+			if err := m.Gateway.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ingress", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDataplane
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDataplane
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDataplane
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Ingress == nil {
+				m.Ingress = &Dataplane_Networking_Ingress{}
+			}
+			if err := m.Ingress.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDataplane(dAtA[iNdEx:])
@@ -1292,63 +1818,9 @@ func (m *Dataplane_Networking_Inbound) Unmarshal(dAtA []byte) error {
 			}
 			m.Tags[mapkey] = mapvalue
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipDataplane(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthDataplane
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthDataplane
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *Dataplane_Networking_Outbound) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowDataplane
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Outbound: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Outbound: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Interface", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BindAddress", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1376,11 +1848,11 @@ func (m *Dataplane_Networking_Outbound) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Interface = string(dAtA[iNdEx:postIndex])
+			m.BindAddress = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Service", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AdvertisedAddress", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1408,13 +1880,13 @@ func (m *Dataplane_Networking_Outbound) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Service = string(dAtA[iNdEx:postIndex])
+			m.AdvertisedAddress = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ServicePort", wireType)
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ServiceAddress", wireType)
 			}
-			m.ServicePort = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDataplane
@@ -1424,17 +1896,167 @@ func (m *Dataplane_Networking_Outbound) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ServicePort |= uint32(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipDataplane(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDataplane
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDataplane
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ServiceAddress = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDataplane(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDataplane
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDataplane
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Dataplane_Networking_Outbound) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDataplane
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Outbound: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Outbound: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Interface", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDataplane
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDataplane
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDataplane
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Interface = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Service", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDataplane
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDataplane
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDataplane
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Service = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ServicePort", wireType)
+			}
+			m.ServicePort = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDataplane
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ServicePort |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDataplane(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
 			if skippy < 0 {
 				return ErrInvalidLengthDataplane
 			}
@@ -1527,6 +2149,400 @@ func (m *Dataplane_Networking_TransparentProxying) Unmarshal(dAtA []byte) error
 	}
 	return nil
 }
+func (m *Dataplane_Networking_Gateway) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDataplane
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Gateway: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Gateway: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDataplane
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDataplane
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDataplane
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Tags == nil {
+				m.Tags = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowDataplane
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowDataplane
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthDataplane
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthDataplane
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowDataplane
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthDataplane
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthDataplane
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipDataplane(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthDataplane
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Tags[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDataplane(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDataplane
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDataplane
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Dataplane_Networking_Ingress) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDataplane
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Ingress: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Ingress: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Interface", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDataplane
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDataplane
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDataplane
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Interface = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDataplane
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDataplane
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDataplane
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Tags == nil {
+				m.Tags = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowDataplane
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowDataplane
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthDataplane
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthDataplane
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowDataplane
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthDataplane
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthDataplane
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipDataplane(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthDataplane
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Tags[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDataplane(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDataplane
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDataplane
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipDataplane(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
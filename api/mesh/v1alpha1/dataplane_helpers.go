@@ -42,10 +42,20 @@ type InboundInterface struct {
 	DataplaneIP   string
 	DataplanePort uint32
 	WorkloadPort  uint32
+
+	// addresses below are not a part of the text representation of an interface,
+	// they are only set by Dataplane_Networking_Inbound.GetInboundInterface()
+
+	// BindIP is an IP address the dataplane listens on.
+	BindIP string
+	// AdvertisedIP is an IP address other dataplanes connect to.
+	AdvertisedIP string
+	// WorkloadIP is an IP address the dataplane dispatches to.
+	WorkloadIP string
 }
 
 func (i InboundInterface) String() string {
-	return fmt.Sprintf("%s:%d:%d", i.DataplaneIP, i.DataplanePort, i.WorkloadPort)
+	return fmt.Sprintf("%s:%d", net.JoinHostPort(i.DataplaneIP, strconv.FormatUint(uint64(i.DataplanePort), 10)), i.WorkloadPort)
 }
 
 type OutboundInterface struct {
@@ -54,10 +64,19 @@ type OutboundInterface struct {
 }
 
 func (i OutboundInterface) String() string {
-	return fmt.Sprintf("%s:%d", i.DataplaneIP, i.DataplanePort)
+	return net.JoinHostPort(i.DataplaneIP, strconv.FormatUint(uint64(i.DataplanePort), 10))
 }
 
-const inboundInterfacePattern = `^(?P<dataplane_ip>(?:(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.){3}(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)):(?P<dataplane_port>[0-9]{1,5}):(?P<workload_port>[0-9]{1,5})$`
+type IngressInterface struct {
+	DataplaneIP   string
+	DataplanePort uint32
+}
+
+func (i IngressInterface) String() string {
+	return net.JoinHostPort(i.DataplaneIP, strconv.FormatUint(uint64(i.DataplanePort), 10))
+}
+
+const inboundInterfacePattern = `^(?P<dataplane_ip>(?:(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.){3}(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)|\[[0-9a-fA-F:.]+\]):(?P<dataplane_port>[0-9]{1,5}):(?P<workload_port>[0-9]{1,5})$`
 
 var inboundInterfaceRegexp = regexp.MustCompile(inboundInterfacePattern)
 
@@ -66,7 +85,7 @@ func ParseInboundInterface(text string) (InboundInterface, error) {
 	if groups == nil {
 		return InboundInterface{}, errors.Errorf("invalid format: expected %s, got %q", inboundInterfacePattern, text)
 	}
-	dataplaneIP, err := ParseIP(groups[1])
+	dataplaneIP, err := parseInterfaceIP(groups[1])
 	if err != nil {
 		return InboundInterface{}, errors.Wrapf(err, "invalid <DATAPLANE_IP> in %q", text)
 	}
@@ -85,7 +104,7 @@ func ParseInboundInterface(text string) (InboundInterface, error) {
 	}, nil
 }
 
-const outboundInterfacePattern = `^(?P<dataplane_ip>(?:(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.){3}(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)|):(?P<dataplane_port>[0-9]{1,5})$`
+const outboundInterfacePattern = `^(?P<dataplane_ip>(?:(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.){3}(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)|\[[0-9a-fA-F:.]+\]|):(?P<dataplane_port>[0-9]{1,5})$`
 
 var outboundInterfaceRegexp = regexp.MustCompile(outboundInterfacePattern)
 
@@ -99,7 +118,7 @@ func ParseOutboundInterface(text string) (OutboundInterface, error) {
 		dataplaneIP = "127.0.0.1"
 	} else {
 		var err error
-		dataplaneIP, err = ParseIP(groups[1])
+		dataplaneIP, err = parseInterfaceIP(groups[1])
 		if err != nil {
 			return OutboundInterface{}, errors.Wrapf(err, "invalid <DATAPLANE_IP> in %q", text)
 		}
@@ -114,6 +133,30 @@ func ParseOutboundInterface(text string) (OutboundInterface, error) {
 	}, nil
 }
 
+const ingressInterfacePattern = `^(?P<dataplane_ip>(?:(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.){3}(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)|\[[0-9a-fA-F:.]+\]):(?P<dataplane_port>[0-9]{1,5})$`
+
+var ingressInterfaceRegexp = regexp.MustCompile(ingressInterfacePattern)
+
+// ParseIngressInterface parses an interface of an ingress, which unlike an outbound interface must include an IP address.
+func ParseIngressInterface(text string) (IngressInterface, error) {
+	groups := ingressInterfaceRegexp.FindStringSubmatch(text)
+	if groups == nil {
+		return IngressInterface{}, errors.Errorf("invalid format: expected %s, got %q", ingressInterfacePattern, text)
+	}
+	dataplaneIP, err := parseInterfaceIP(groups[1])
+	if err != nil {
+		return IngressInterface{}, errors.Wrapf(err, "invalid <DATAPLANE_IP> in %q", text)
+	}
+	dataplanePort, err := ParsePort(groups[2])
+	if err != nil {
+		return IngressInterface{}, errors.Wrapf(err, "invalid <DATAPLANE_PORT> in %q", text)
+	}
+	return IngressInterface{
+		DataplaneIP:   dataplaneIP,
+		DataplanePort: dataplanePort,
+	}, nil
+}
+
 func ParsePort(text string) (uint32, error) {
 	port, err := strconv.ParseUint(text, 10, 32)
 	if err != nil {
@@ -132,13 +175,53 @@ func ParseIP(text string) (string, error) {
 	return text, nil
 }
 
+// parseInterfaceIP parses IP address of an interface, where IPv6 address must be enclosed in square brackets,
+// e.g. "192.168.0.1" or "[fd00::1]".
+func parseInterfaceIP(text string) (string, error) {
+	if !strings.HasPrefix(text, "[") {
+		return ParseIP(text)
+	}
+	ip := strings.TrimSuffix(strings.TrimPrefix(text, "["), "]")
+	if parsed := net.ParseIP(ip); parsed == nil || parsed.To4() != nil {
+		return "", errors.Errorf("%q is not a valid IPv6 address", ip)
+	}
+	return ip, nil
+}
+
+// GetInboundInterface parses the interface of an inbound and applies overrides of its addresses.
+func (i *Dataplane_Networking_Inbound) GetInboundInterface() (InboundInterface, error) {
+	iface, err := ParseInboundInterface(i.GetInterface())
+	if err != nil {
+		return InboundInterface{}, err
+	}
+	iface.BindIP = iface.DataplaneIP
+	if i.GetBindAddress() != "" {
+		if iface.BindIP, err = ParseIP(i.GetBindAddress()); err != nil {
+			return InboundInterface{}, errors.Wrap(err, "invalid bind address")
+		}
+	}
+	iface.AdvertisedIP = iface.DataplaneIP
+	if i.GetAdvertisedAddress() != "" {
+		if iface.AdvertisedIP, err = ParseIP(i.GetAdvertisedAddress()); err != nil {
+			return InboundInterface{}, errors.Wrap(err, "invalid advertised address")
+		}
+	}
+	iface.WorkloadIP = "127.0.0.1"
+	if i.GetServiceAddress() != "" {
+		if iface.WorkloadIP, err = ParseIP(i.GetServiceAddress()); err != nil {
+			return InboundInterface{}, errors.Wrap(err, "invalid service address")
+		}
+	}
+	return iface, nil
+}
+
 func (n *Dataplane_Networking) GetInboundInterfaces() ([]InboundInterface, error) {
 	if n == nil {
 		return nil, nil
 	}
 	ifaces := make([]InboundInterface, len(n.Inbound))
 	for i, inbound := range n.Inbound {
-		iface, err := ParseInboundInterface(inbound.Interface)
+		iface, err := inbound.GetInboundInterface()
 		if err != nil {
 			return nil, err
 		}
@@ -147,9 +230,34 @@ func (n *Dataplane_Networking) GetInboundInterfaces() ([]InboundInterface, error
 	return ifaces, nil
 }
 
-func (d *Dataplane) MatchTags(selector TagSelector) bool {
+// IsGateway returns true if a dataplane proxies traffic entering the mesh rather than sits next to a service.
+func (d *Dataplane) IsGateway() bool {
+	return d.GetNetworking().GetGateway() != nil
+}
+
+// IsIngress returns true if a dataplane accepts traffic destined to services of the mesh from outside of a zone.
+func (d *Dataplane) IsIngress() bool {
+	return d.GetNetworking().GetIngress() != nil
+}
+
+// taggedInterfaces returns tags of every inbound interface, of a gateway and of an ingress.
+func (d *Dataplane) taggedInterfaces() []map[string]string {
+	var result []map[string]string
 	for _, inbound := range d.GetNetworking().GetInbound() {
-		if selector.Matches(inbound.Tags) {
+		result = append(result, inbound.Tags)
+	}
+	if gateway := d.GetNetworking().GetGateway(); gateway != nil {
+		result = append(result, gateway.Tags)
+	}
+	if ingress := d.GetNetworking().GetIngress(); ingress != nil {
+		result = append(result, ingress.Tags)
+	}
+	return result
+}
+
+func (d *Dataplane) MatchTags(selector TagSelector) bool {
+	for _, tags := range d.taggedInterfaces() {
+		if selector.Matches(tags) {
 			return true
 		}
 	}
@@ -189,8 +297,8 @@ func (t Tags) Values(key string) []string {
 
 func (d *Dataplane) Tags() Tags {
 	tags := Tags{}
-	for _, inbound := range d.GetNetworking().GetInbound() {
-		for tag, value := range inbound.Tags {
+	for _, ifaceTags := range d.taggedInterfaces() {
+		for tag, value := range ifaceTags {
 			_, exists := tags[tag]
 			if !exists {
 				tags[tag] = map[string]bool{}
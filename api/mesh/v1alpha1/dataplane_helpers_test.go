@@ -99,6 +99,44 @@ var _ = Describe("InboundInterface", func() {
 				},
 				expected: "1.2.3.4:80:8080",
 			}),
+			Entry("IPv6 address", testCase{
+				iface: InboundInterface{
+					DataplaneIP:   "fd00::1",
+					DataplanePort: 80,
+					WorkloadPort:  8080,
+				},
+				expected: "[fd00::1]:80:8080",
+			}),
+		)
+	})
+})
+
+var _ = Describe("OutboundInterface", func() {
+
+	Describe("String()", func() {
+		type testCase struct {
+			oface    OutboundInterface
+			expected string
+		}
+
+		DescribeTable("should format properly",
+			func(given testCase) {
+				Expect(given.oface.String()).To(Equal(given.expected))
+			},
+			Entry("IPv4 address", testCase{
+				oface: OutboundInterface{
+					DataplaneIP:   "127.0.0.1",
+					DataplanePort: 18080,
+				},
+				expected: "127.0.0.1:18080",
+			}),
+			Entry("IPv6 address", testCase{
+				oface: OutboundInterface{
+					DataplaneIP:   "::1",
+					DataplanePort: 18080,
+				},
+				expected: "[::1]:18080",
+			}),
 		)
 	})
 })
@@ -128,6 +166,14 @@ var _ = Describe("ParseInboundInterface(..)", func() {
 					WorkloadPort:  8080,
 				},
 			}),
+			Entry("IPv6 address", testCase{
+				input: "[fd00::1]:80:8080",
+				expected: InboundInterface{
+					DataplaneIP:   "fd00::1",
+					DataplanePort: 80,
+					WorkloadPort:  8080,
+				},
+			}),
 		)
 	})
 
@@ -154,6 +200,18 @@ var _ = Describe("ParseInboundInterface(..)", func() {
 				input:       "localhost:80:65536",
 				expectedErr: MatchRegexp(`invalid format: expected .*, got "localhost:80:65536"`),
 			}),
+			Entry("IPv6 address without brackets", testCase{
+				input:       "fd00::1:80:8080",
+				expectedErr: MatchRegexp(`invalid format: expected .*, got "fd00::1:80:8080"`),
+			}),
+			Entry("IPv6 address is not valid", testCase{
+				input:       "[fd00:::1]:80:8080",
+				expectedErr: Equal(`invalid <DATAPLANE_IP> in "[fd00:::1]:80:8080": "fd00:::1" is not a valid IPv6 address`),
+			}),
+			Entry("IPv4 address in brackets", testCase{
+				input:       "[1.2.3.4]:80:8080",
+				expectedErr: Equal(`invalid <DATAPLANE_IP> in "[1.2.3.4]:80:8080": "1.2.3.4" is not a valid IPv6 address`),
+			}),
 			Entry("service port is missing", testCase{
 				input:       "1.2.3.4::8080",
 				expectedErr: MatchRegexp(`invalid format: expected .*, got "1.2.3.4::8080"`),
@@ -205,6 +263,13 @@ var _ = Describe("ParseOutboundInterface(..)", func() {
 					DataplanePort: 18080,
 				},
 			}),
+			Entry("IPv6 address", testCase{
+				input: "[::1]:18080",
+				expected: OutboundInterface{
+					DataplaneIP:   "::1",
+					DataplanePort: 18080,
+				},
+			}),
 		)
 	})
 
@@ -235,6 +300,30 @@ var _ = Describe("ParseOutboundInterface(..)", func() {
 	})
 })
 
+var _ = Describe("ParseIngressInterface(..)", func() {
+
+	It("should parse valid input values", func() {
+		// when
+		iface, err := ParseIngressInterface("[fd00::1]:10001")
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		// and
+		Expect(iface).To(Equal(IngressInterface{
+			DataplaneIP:   "fd00::1",
+			DataplanePort: 10001,
+		}))
+	})
+
+	It("should fail if dataplane IP address is missing", func() {
+		// when
+		iface, err := ParseIngressInterface(":10001")
+		// then
+		Expect(err.Error()).To(MatchRegexp(`invalid format: expected .*, got ":10001"`))
+		// and
+		Expect(iface).To(BeZero())
+	})
+})
+
 var _ = Describe("Dataplane_Networking", func() {
 
 	Describe("GetInboundInterfaces()", func() {
@@ -270,8 +359,23 @@ var _ = Describe("Dataplane_Networking", func() {
 						},
 					},
 					expected: []InboundInterface{
-						{DataplaneIP: "192.168.0.1", DataplanePort: 80, WorkloadPort: 8080},
-						{DataplaneIP: "192.168.0.1", DataplanePort: 443, WorkloadPort: 8443},
+						{DataplaneIP: "192.168.0.1", DataplanePort: 80, WorkloadPort: 8080, BindIP: "192.168.0.1", AdvertisedIP: "192.168.0.1", WorkloadIP: "127.0.0.1"},
+						{DataplaneIP: "192.168.0.1", DataplanePort: 443, WorkloadPort: 8443, BindIP: "192.168.0.1", AdvertisedIP: "192.168.0.1", WorkloadIP: "127.0.0.1"},
+					},
+				}),
+				Entry("inbound interface with overridden addresses", testCase{
+					input: &Dataplane_Networking{
+						Inbound: []*Dataplane_Networking_Inbound{
+							{
+								Interface:         "192.168.0.1:80:8080",
+								BindAddress:       "0.0.0.0",
+								AdvertisedAddress: "203.0.113.1",
+								ServiceAddress:    "192.168.0.2",
+							},
+						},
+					},
+					expected: []InboundInterface{
+						{DataplaneIP: "192.168.0.1", DataplanePort: 80, WorkloadPort: 8080, BindIP: "0.0.0.0", AdvertisedIP: "203.0.113.1", WorkloadIP: "192.168.0.2"},
 					},
 				}),
 			)
@@ -301,6 +405,30 @@ var _ = Describe("Dataplane_Networking", func() {
 					},
 					expectedErr: MatchRegexp(`invalid format: expected .*, got ":443:8443"`),
 				}),
+				Entry("bind address is not valid", testCase{
+					input: &Dataplane_Networking{
+						Inbound: []*Dataplane_Networking_Inbound{
+							{Interface: "192.168.0.1:80:8080", BindAddress: "eth0"},
+						},
+					},
+					expectedErr: Equal(`invalid bind address: "eth0" is not a valid IP address`),
+				}),
+				Entry("advertised address is not valid", testCase{
+					input: &Dataplane_Networking{
+						Inbound: []*Dataplane_Networking_Inbound{
+							{Interface: "192.168.0.1:80:8080", AdvertisedAddress: "example.com"},
+						},
+					},
+					expectedErr: Equal(`invalid advertised address: "example.com" is not a valid IP address`),
+				}),
+				Entry("service address is not valid", testCase{
+					input: &Dataplane_Networking{
+						Inbound: []*Dataplane_Networking_Inbound{
+							{Interface: "192.168.0.1:80:8080", ServiceAddress: "localhost"},
+						},
+					},
+					expectedErr: Equal(`invalid service address: "localhost" is not a valid IP address`),
+				}),
 			)
 		})
 	})
@@ -360,6 +488,25 @@ var _ = Describe("Dataplane", func() {
 			// then
 			Expect(d.MatchTags(selector)).To(BeFalse())
 		})
+
+		It("should match a gateway", func() {
+			// given
+			gateway := Dataplane{
+				Networking: &Dataplane_Networking{
+					Gateway: &Dataplane_Networking_Gateway{
+						Tags: map[string]string{
+							"service": "edge",
+						},
+					},
+				},
+			}
+
+			// expect
+			Expect(gateway.IsGateway()).To(BeTrue())
+			Expect(gateway.IsIngress()).To(BeFalse())
+			Expect(gateway.MatchTags(TagSelector{"service": "edge"})).To(BeTrue())
+			Expect(gateway.Tags().Values("service")).To(Equal([]string{"edge"}))
+		})
 	})
 })
 
@@ -28,10 +28,21 @@ const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 // DataplaneInsight defines the observed state of a Dataplane.
 type DataplaneInsight struct {
 	// List of ADS subscriptions created by a given Dataplane.
-	Subscriptions        []*DiscoverySubscription `protobuf:"bytes,1,rep,name=subscriptions,proto3" json:"subscriptions,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
-	XXX_unrecognized     []byte                   `json:"-"`
-	XXX_sizecache        int32                    `json:"-"`
+	Subscriptions []*DiscoverySubscription `protobuf:"bytes,1,rep,name=subscriptions,proto3" json:"subscriptions,omitempty"`
+	// Most recent ejections of endpoints by outlier detection of a given
+	// Dataplane, the oldest first.
+	// Endpoints that get ejected over and over again are likely to be flapping.
+	OutlierEjections []*OutlierEjection `protobuf:"bytes,2,rep,name=outlier_ejections,json=outlierEjections,proto3" json:"outlier_ejections,omitempty"`
+	// Most recent rejection of an xDS stream of a given Dataplane by the
+	// Control Plane, e.g. because of an expired token.
+	LastRejection *XdsRejection `protobuf:"bytes,3,opt,name=last_rejection,json=lastRejection,proto3" json:"last_rejection,omitempty"`
+	// Time when a given Dataplane connected to the Control Plane for the first
+	// time. Unlike subscriptions, it is kept when the Dataplane reconnects,
+	// e.g. to tell a new endpoint from one that is warmed up already.
+	FirstConnectTime     *types.Timestamp `protobuf:"bytes,4,opt,name=first_connect_time,json=firstConnectTime,proto3" json:"first_connect_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
 func (m *DataplaneInsight) Reset()         { *m = DataplaneInsight{} }
@@ -74,6 +85,174 @@ func (m *DataplaneInsight) GetSubscriptions() []*DiscoverySubscription {
 	return nil
 }
 
+func (m *DataplaneInsight) GetOutlierEjections() []*OutlierEjection {
+	if m != nil {
+		return m.OutlierEjections
+	}
+	return nil
+}
+
+func (m *DataplaneInsight) GetLastRejection() *XdsRejection {
+	if m != nil {
+		return m.LastRejection
+	}
+	return nil
+}
+
+func (m *DataplaneInsight) GetFirstConnectTime() *types.Timestamp {
+	if m != nil {
+		return m.FirstConnectTime
+	}
+	return nil
+}
+
+// XdsRejection describes why the Control Plane has refused to serve an xDS
+// stream of a Dataplane.
+type XdsRejection struct {
+	// Machine-readable code of the reason, e.g. TOKEN_EXPIRED.
+	Code string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	// Message that describes the reason to a human.
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// Time when the stream has been rejected.
+	Time                 *types.Timestamp `protobuf:"bytes,3,opt,name=time,proto3" json:"time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *XdsRejection) Reset()         { *m = XdsRejection{} }
+func (m *XdsRejection) String() string { return proto.CompactTextString(m) }
+func (*XdsRejection) ProtoMessage()    {}
+func (*XdsRejection) Descriptor() ([]byte, []int) {
+	return fileDescriptor_35794f05b529b342, []int{1}
+}
+func (m *XdsRejection) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *XdsRejection) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_XdsRejection.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *XdsRejection) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_XdsRejection.Merge(m, src)
+}
+func (m *XdsRejection) XXX_Size() int {
+	return m.Size()
+}
+func (m *XdsRejection) XXX_DiscardUnknown() {
+	xxx_messageInfo_XdsRejection.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_XdsRejection proto.InternalMessageInfo
+
+func (m *XdsRejection) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+func (m *XdsRejection) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *XdsRejection) GetTime() *types.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+// OutlierEjection describes ejection of an endpoint from a load balancing
+// pool by outlier detection of Envoy or the return of the endpoint to the
+// pool.
+type OutlierEjection struct {
+	// Name of the Envoy cluster that a given endpoint belongs to.
+	Cluster string `protobuf:"bytes,1,opt,name=cluster,proto3" json:"cluster,omitempty"`
+	// Address of the endpoint, e.g. 192.168.0.1:8080.
+	Host string `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
+	// Whether the endpoint has been ejected (true) or returned to the pool
+	// (false).
+	Ejected bool `protobuf:"varint,3,opt,name=ejected,proto3" json:"ejected,omitempty"`
+	// Time when the change has been observed by the Dataplane.
+	Time                 *types.Timestamp `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *OutlierEjection) Reset()         { *m = OutlierEjection{} }
+func (m *OutlierEjection) String() string { return proto.CompactTextString(m) }
+func (*OutlierEjection) ProtoMessage()    {}
+func (*OutlierEjection) Descriptor() ([]byte, []int) {
+	return fileDescriptor_35794f05b529b342, []int{2}
+}
+func (m *OutlierEjection) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *OutlierEjection) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_OutlierEjection.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *OutlierEjection) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OutlierEjection.Merge(m, src)
+}
+func (m *OutlierEjection) XXX_Size() int {
+	return m.Size()
+}
+func (m *OutlierEjection) XXX_DiscardUnknown() {
+	xxx_messageInfo_OutlierEjection.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OutlierEjection proto.InternalMessageInfo
+
+func (m *OutlierEjection) GetCluster() string {
+	if m != nil {
+		return m.Cluster
+	}
+	return ""
+}
+
+func (m *OutlierEjection) GetHost() string {
+	if m != nil {
+		return m.Host
+	}
+	return ""
+}
+
+func (m *OutlierEjection) GetEjected() bool {
+	if m != nil {
+		return m.Ejected
+	}
+	return false
+}
+
+func (m *OutlierEjection) GetTime() *types.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
 // DiscoverySubscription describes a single ADS subscription
 // created by a Dataplane to the Control Plane.
 // Ideally, there should be only one such subscription per Dataplane lifecycle.
@@ -103,7 +282,7 @@ func (m *DiscoverySubscription) Reset()         { *m = DiscoverySubscription{} }
 func (m *DiscoverySubscription) String() string { return proto.CompactTextString(m) }
 func (*DiscoverySubscription) ProtoMessage()    {}
 func (*DiscoverySubscription) Descriptor() ([]byte, []int) {
-	return fileDescriptor_35794f05b529b342, []int{1}
+	return fileDescriptor_35794f05b529b342, []int{3}
 }
 func (m *DiscoverySubscription) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -190,7 +369,7 @@ func (m *DiscoverySubscriptionStatus) Reset()         { *m = DiscoverySubscripti
 func (m *DiscoverySubscriptionStatus) String() string { return proto.CompactTextString(m) }
 func (*DiscoverySubscriptionStatus) ProtoMessage()    {}
 func (*DiscoverySubscriptionStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_35794f05b529b342, []int{2}
+	return fileDescriptor_35794f05b529b342, []int{4}
 }
 func (m *DiscoverySubscriptionStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -278,7 +457,7 @@ func (m *DiscoveryServiceStats) Reset()         { *m = DiscoveryServiceStats{} }
 func (m *DiscoveryServiceStats) String() string { return proto.CompactTextString(m) }
 func (*DiscoveryServiceStats) ProtoMessage()    {}
 func (*DiscoveryServiceStats) Descriptor() ([]byte, []int) {
-	return fileDescriptor_35794f05b529b342, []int{3}
+	return fileDescriptor_35794f05b529b342, []int{5}
 }
 func (m *DiscoveryServiceStats) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -330,6 +509,8 @@ func (m *DiscoveryServiceStats) GetResponsesRejected() uint64 {
 
 func init() {
 	proto.RegisterType((*DataplaneInsight)(nil), "kuma.mesh.v1alpha1.DataplaneInsight")
+	proto.RegisterType((*XdsRejection)(nil), "kuma.mesh.v1alpha1.XdsRejection")
+	proto.RegisterType((*OutlierEjection)(nil), "kuma.mesh.v1alpha1.OutlierEjection")
 	proto.RegisterType((*DiscoverySubscription)(nil), "kuma.mesh.v1alpha1.DiscoverySubscription")
 	proto.RegisterType((*DiscoverySubscriptionStatus)(nil), "kuma.mesh.v1alpha1.DiscoverySubscriptionStatus")
 	proto.RegisterType((*DiscoveryServiceStats)(nil), "kuma.mesh.v1alpha1.DiscoveryServiceStats")
@@ -340,41 +521,50 @@ func init() {
 }
 
 var fileDescriptor_35794f05b529b342 = []byte{
-	// 539 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x93, 0x3f, 0x6f, 0x13, 0x31,
-	0x18, 0xc6, 0xf1, 0xe5, 0x1a, 0xb5, 0x0e, 0x2d, 0xc1, 0xa2, 0xe5, 0x1a, 0xa4, 0x34, 0x8a, 0x54,
-	0x29, 0x0c, 0xdc, 0xa9, 0x45, 0x7c, 0x80, 0x86, 0x30, 0x64, 0x40, 0xa0, 0x0b, 0x2c, 0x2c, 0x27,
-	0xe7, 0xfc, 0x72, 0x31, 0xbd, 0xd8, 0xa7, 0xb3, 0x13, 0xc4, 0xf7, 0x61, 0x60, 0x66, 0x60, 0x60,
-	0x62, 0xec, 0xc8, 0xc6, 0x86, 0x50, 0x36, 0xbe, 0x05, 0xb2, 0xef, 0x2e, 0x09, 0x7f, 0x44, 0x08,
-	0x9b, 0xe5, 0xf7, 0xf9, 0x3d, 0x7e, 0xfd, 0xbc, 0x36, 0x3e, 0x9d, 0x82, 0x9a, 0x04, 0xf3, 0x33,
-	0x9a, 0x66, 0x13, 0x7a, 0x16, 0x30, 0xaa, 0x69, 0x96, 0x52, 0x01, 0x11, 0x17, 0x8a, 0x27, 0x13,
-	0xed, 0x67, 0xb9, 0xd4, 0x92, 0x90, 0xcb, 0xd9, 0x94, 0xfa, 0x46, 0xeb, 0x57, 0xda, 0xd6, 0x49,
-	0x22, 0x65, 0x92, 0x42, 0x60, 0x15, 0xe3, 0xd9, 0xcb, 0x40, 0xf3, 0x29, 0x28, 0x4d, 0xa7, 0x59,
-	0x01, 0xb5, 0x6e, 0x25, 0x32, 0x91, 0x76, 0x19, 0x98, 0x55, 0xb9, 0x7b, 0x7b, 0x4e, 0x53, 0xce,
-	0xa8, 0x86, 0xa0, 0x5a, 0x14, 0x85, 0x6e, 0x8c, 0x9b, 0x83, 0xea, 0xf8, 0x61, 0x71, 0x3a, 0x79,
-	0x82, 0xf7, 0xd5, 0x6c, 0xac, 0xe2, 0x9c, 0x67, 0x9a, 0x4b, 0xa1, 0x3c, 0xd4, 0xa9, 0xf5, 0x1a,
-	0xe7, 0x77, 0xfd, 0xdf, 0xfb, 0xf1, 0x07, 0x5c, 0xc5, 0x72, 0x0e, 0xf9, 0x9b, 0xd1, 0x1a, 0x11,
-	0xfe, 0xcc, 0x77, 0xbf, 0x38, 0xf8, 0xf0, 0x8f, 0x42, 0x72, 0x8c, 0x1d, 0xce, 0x3c, 0xd4, 0x41,
-	0xbd, 0xbd, 0xfe, 0xde, 0xc7, 0xef, 0x9f, 0x6a, 0x6e, 0xee, 0x34, 0x51, 0xe8, 0x70, 0x46, 0x06,
-	0xf8, 0x38, 0x96, 0x42, 0xe7, 0x32, 0x8d, 0x96, 0xe1, 0x68, 0x2a, 0x62, 0x88, 0x38, 0xf3, 0x9c,
-	0x5f, 0x89, 0xa3, 0x52, 0xfb, 0xb4, 0xbc, 0x88, 0x55, 0x0e, 0x19, 0x19, 0xe2, 0xeb, 0xb1, 0x14,
-	0x02, 0x62, 0x1d, 0x99, 0xa4, 0xbc, 0x5a, 0x07, 0xf5, 0x1a, 0xe7, 0x2d, 0xbf, 0x88, 0xd1, 0xaf,
-	0x62, 0xf4, 0x9f, 0x55, 0x31, 0xf6, 0xb1, 0x31, 0xdd, 0x79, 0x8f, 0x9c, 0x5d, 0x14, 0x36, 0x4a,
-	0xd6, 0x54, 0xc9, 0x43, 0x7c, 0x83, 0x99, 0x4b, 0xac, 0xb9, 0xb9, 0x9b, 0xdc, 0xc2, 0x83, 0x15,
-	0x62, 0x4d, 0x1e, 0xe3, 0xba, 0xd2, 0x54, 0xcf, 0x94, 0xb7, 0x63, 0xd9, 0xe0, 0x9f, 0x43, 0x1d,
-	0x59, 0xac, 0xef, 0x5e, 0x7d, 0x3d, 0xb9, 0x16, 0x96, 0x26, 0xdd, 0x0f, 0x35, 0x7c, 0xe7, 0x2f,
-	0x6a, 0x32, 0xc0, 0xcd, 0x94, 0x2a, 0x1d, 0xcd, 0x32, 0x33, 0xf3, 0xa2, 0x69, 0xb4, 0xb9, 0x69,
-	0xc3, 0x3c, 0xb7, 0x88, 0x6d, 0xfa, 0x11, 0xde, 0xd1, 0x52, 0xd3, 0xd4, 0xc6, 0xbe, 0xf1, 0x21,
-	0x40, 0x3e, 0xe7, 0x31, 0x98, 0x06, 0xaa, 0x6e, 0x0b, 0x9a, 0x5c, 0xe0, 0x5a, 0xcc, 0x54, 0x39,
-	0x82, 0xad, 0x4d, 0x0c, 0x6b, 0x2c, 0x80, 0xa9, 0x32, 0xf7, 0xed, 0x2d, 0xa0, 0xb0, 0x48, 0x59,
-	0x15, 0xff, 0xf6, 0x16, 0x69, 0x61, 0x91, 0x33, 0xe5, 0xd5, 0xff, 0xd3, 0x22, 0x67, 0xaa, 0xfb,
-	0x16, 0xad, 0x7f, 0x89, 0x35, 0x11, 0x39, 0xc5, 0x07, 0x39, 0xa8, 0x4c, 0x0a, 0x05, 0x2a, 0x52,
-	0x20, 0xb4, 0x1d, 0x98, 0x1b, 0xee, 0x2f, 0x77, 0x47, 0x20, 0x34, 0x79, 0x80, 0x8f, 0x56, 0x32,
-	0x1a, 0x5f, 0x0a, 0xf9, 0x3a, 0x05, 0x96, 0x40, 0xf1, 0x37, 0xdc, 0xf0, 0x70, 0x59, 0xbd, 0x58,
-	0x2b, 0x92, 0x7b, 0x98, 0xac, 0xb0, 0x1c, 0x5e, 0x41, 0xac, 0x81, 0xd9, 0x91, 0xb8, 0xe1, 0xcd,
-	0x65, 0x25, 0x2c, 0x0b, 0xfd, 0xd6, 0xbb, 0x45, 0x1b, 0x5d, 0x2d, 0xda, 0xe8, 0xf3, 0xa2, 0x8d,
-	0xbe, 0x2d, 0xda, 0xe8, 0xc5, 0x6e, 0x75, 0xc7, 0x71, 0xdd, 0xbe, 0x9c, 0xfb, 0x3f, 0x02, 0x00,
-	0x00, 0xff, 0xff, 0x27, 0x93, 0x4b, 0x37, 0xce, 0x04, 0x00, 0x00,
+	// 685 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x53, 0xcd, 0x6e, 0xd3, 0x4a,
+	0x14, 0xbe, 0xe3, 0xb8, 0xbd, 0xed, 0xf4, 0x2f, 0x1d, 0xdd, 0xf6, 0xba, 0x41, 0x4a, 0xa3, 0xa0,
+	0x4a, 0x65, 0x81, 0xad, 0x16, 0xf1, 0x00, 0x4d, 0x53, 0x41, 0x17, 0xa8, 0xd5, 0x14, 0x24, 0xc4,
+	0xc6, 0x9a, 0x7a, 0xa6, 0xce, 0x50, 0xc7, 0x63, 0xcd, 0x8c, 0x83, 0x78, 0x04, 0xde, 0x83, 0x05,
+	0x6b, 0x16, 0x2c, 0x58, 0xb1, 0xec, 0x92, 0x1d, 0x3b, 0x84, 0xb2, 0xe3, 0x2d, 0xd0, 0x8c, 0xed,
+	0x24, 0x2d, 0x11, 0x29, 0xdd, 0x9d, 0x39, 0xe7, 0xfb, 0xbe, 0xf3, 0xf9, 0xf8, 0x1c, 0xb8, 0xd3,
+	0x67, 0xaa, 0x17, 0x0c, 0xf6, 0x48, 0x92, 0xf5, 0xc8, 0x5e, 0x40, 0x89, 0x26, 0x59, 0x42, 0x52,
+	0x16, 0xf2, 0x54, 0xf1, 0xb8, 0xa7, 0xfd, 0x4c, 0x0a, 0x2d, 0x10, 0xba, 0xcc, 0xfb, 0xc4, 0x37,
+	0x58, 0xbf, 0xc2, 0x36, 0xb6, 0x63, 0x21, 0xe2, 0x84, 0x05, 0x16, 0x71, 0x9e, 0x5f, 0x04, 0x9a,
+	0xf7, 0x99, 0xd2, 0xa4, 0x9f, 0x15, 0xa4, 0xc6, 0x7f, 0xb1, 0x88, 0x85, 0x0d, 0x03, 0x13, 0x95,
+	0xd9, 0xff, 0x07, 0x24, 0xe1, 0x94, 0x68, 0x16, 0x54, 0x41, 0x51, 0x68, 0x5f, 0x39, 0xb0, 0xde,
+	0xad, 0xfa, 0x1f, 0x17, 0xed, 0xd1, 0x09, 0x5c, 0x51, 0xf9, 0xb9, 0x8a, 0x24, 0xcf, 0x34, 0x17,
+	0xa9, 0xf2, 0x40, 0xab, 0xb6, 0xbb, 0xb4, 0xff, 0xc0, 0xff, 0xdd, 0x90, 0xdf, 0xe5, 0x2a, 0x12,
+	0x03, 0x26, 0xdf, 0x9e, 0x4d, 0x30, 0xf0, 0x75, 0x3e, 0x3a, 0x85, 0xeb, 0x22, 0xd7, 0x09, 0x67,
+	0x32, 0x64, 0xaf, 0x59, 0x54, 0x88, 0x3a, 0x56, 0xf4, 0xfe, 0x34, 0xd1, 0x93, 0x02, 0x7c, 0x54,
+	0x62, 0x71, 0x5d, 0x5c, 0x4f, 0x28, 0xf4, 0x04, 0xae, 0x26, 0x44, 0xe9, 0x50, 0x56, 0x7a, 0x5e,
+	0xad, 0x05, 0x76, 0x97, 0xf6, 0x5b, 0xd3, 0xe4, 0x5e, 0x52, 0x85, 0x2b, 0x1c, 0x5e, 0x31, 0xbc,
+	0xd1, 0x13, 0x3d, 0x85, 0xe8, 0x82, 0x4b, 0xa5, 0xc3, 0x48, 0xa4, 0x29, 0x8b, 0x74, 0x68, 0x06,
+	0xea, 0xb9, 0x56, 0xac, 0xe1, 0x17, 0xd3, 0xf6, 0xab, 0x69, 0xfb, 0xcf, 0xab, 0x69, 0xe3, 0xba,
+	0x65, 0x1d, 0x16, 0x24, 0x93, 0x6e, 0x27, 0x70, 0x79, 0xb2, 0x11, 0x42, 0xd0, 0x8d, 0x04, 0x65,
+	0x1e, 0x68, 0x81, 0xdd, 0x45, 0x6c, 0x63, 0xe4, 0xc1, 0x7f, 0xfb, 0x4c, 0x29, 0x12, 0x33, 0xcf,
+	0xb1, 0xe9, 0xea, 0x89, 0x7c, 0xe8, 0xda, 0xce, 0xb5, 0x99, 0x9d, 0x2d, 0xae, 0xfd, 0x0e, 0xc0,
+	0xb5, 0x1b, 0x63, 0x32, 0xea, 0x51, 0x92, 0x2b, 0xcd, 0x64, 0xd9, 0xb4, 0x7a, 0x1a, 0x2f, 0x3d,
+	0xa1, 0x74, 0xd9, 0xd4, 0xc6, 0x06, 0x6d, 0xbd, 0x32, 0x6a, 0x9b, 0x2e, 0xe0, 0xea, 0x39, 0xf2,
+	0xe2, 0xde, 0xd2, 0xcb, 0x37, 0x07, 0x6e, 0x4c, 0xdd, 0x03, 0xb4, 0x05, 0x1d, 0x4e, 0x0b, 0x33,
+	0x9d, 0xc5, 0xcf, 0x3f, 0xbf, 0xd4, 0x5c, 0xe9, 0xd4, 0x01, 0x76, 0x38, 0x45, 0x5d, 0xb8, 0x15,
+	0x89, 0x54, 0x4b, 0x91, 0x84, 0xa3, 0xe5, 0xd7, 0x24, 0x8d, 0x58, 0xc8, 0xa9, 0xe7, 0xdc, 0x64,
+	0x6c, 0x96, 0xd8, 0xd3, 0x72, 0x4f, 0x2d, 0xf2, 0x98, 0xa2, 0x63, 0xb8, 0x7c, 0xed, 0xc7, 0xcd,
+	0x1c, 0x5f, 0x07, 0x1a, 0xd1, 0xb9, 0x8f, 0xc0, 0x59, 0x00, 0x78, 0x29, 0x1a, 0xff, 0x3f, 0x74,
+	0x08, 0xd7, 0x28, 0x57, 0x65, 0xe6, 0xb6, 0x6b, 0xb0, 0x3a, 0xa6, 0x58, 0x91, 0x67, 0x70, 0x5e,
+	0x69, 0xa2, 0x73, 0xe5, 0xcd, 0x59, 0x6e, 0x70, 0xeb, 0x9b, 0x39, 0xb3, 0xb4, 0x8e, 0x7b, 0xf5,
+	0x7d, 0xfb, 0x1f, 0x5c, 0x8a, 0xb4, 0x3f, 0xd5, 0xe0, 0xbd, 0x3f, 0xa0, 0x51, 0x17, 0xd6, 0xed,
+	0x19, 0xe4, 0x99, 0xb9, 0xe9, 0xc2, 0x34, 0x98, 0x6d, 0xda, 0x70, 0x5e, 0x58, 0x8a, 0x35, 0x7d,
+	0x04, 0xe7, 0xb4, 0xd0, 0x24, 0xb1, 0x63, 0x9f, 0x79, 0xe7, 0x4c, 0x0e, 0x78, 0xc4, 0x8c, 0x81,
+	0xca, 0x6d, 0xc1, 0x46, 0x07, 0xb0, 0x16, 0x51, 0xe5, 0xd5, 0xee, 0x26, 0x62, 0xb8, 0x46, 0x82,
+	0x51, 0xe5, 0xb9, 0x77, 0x94, 0x60, 0x85, 0x44, 0x42, 0xab, 0xf1, 0xff, 0xbd, 0x44, 0x52, 0x48,
+	0x48, 0xaa, 0xbc, 0xf9, 0x3b, 0x4a, 0x48, 0xaa, 0xda, 0xef, 0x01, 0xdc, 0x98, 0x0a, 0x42, 0x3b,
+	0x70, 0x55, 0x32, 0x95, 0x89, 0x54, 0x31, 0x15, 0x2a, 0x96, 0x6a, 0xfb, 0xc3, 0x5c, 0xbc, 0x32,
+	0xca, 0x9e, 0xb1, 0x54, 0xa3, 0xc7, 0x70, 0x73, 0x0c, 0x23, 0xd1, 0x65, 0x2a, 0xde, 0x24, 0x8c,
+	0xc6, 0xac, 0xb8, 0x0d, 0x17, 0x6f, 0x8c, 0xaa, 0x07, 0x13, 0x45, 0xf4, 0x10, 0xa2, 0x31, 0x4d,
+	0x4e, 0xde, 0xb7, 0x8b, 0xd7, 0x47, 0x15, 0x5c, 0x16, 0x3a, 0x8d, 0x0f, 0xc3, 0x26, 0xb8, 0x1a,
+	0x36, 0xc1, 0xd7, 0x61, 0x13, 0xfc, 0x18, 0x36, 0xc1, 0xab, 0x85, 0xea, 0x1b, 0xcf, 0xe7, 0xed,
+	0xe6, 0x3c, 0xfa, 0x35, 0x00, 0x11, 0x85, 0xd2, 0xfc, 0xae, 0x06, 0x00, 0x00,
 }
 
 func (this *DataplaneInsight) Equal(that interface{}) bool {
@@ -404,6 +594,89 @@ func (this *DataplaneInsight) Equal(that interface{}) bool {
 			return false
 		}
 	}
+	if len(this.OutlierEjections) != len(that1.OutlierEjections) {
+		return false
+	}
+	for i := range this.OutlierEjections {
+		if !this.OutlierEjections[i].Equal(that1.OutlierEjections[i]) {
+			return false
+		}
+	}
+	if !this.LastRejection.Equal(that1.LastRejection) {
+		return false
+	}
+	if !this.FirstConnectTime.Equal(that1.FirstConnectTime) {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
+}
+func (this *XdsRejection) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*XdsRejection)
+	if !ok {
+		that2, ok := that.(XdsRejection)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Code != that1.Code {
+		return false
+	}
+	if this.Message != that1.Message {
+		return false
+	}
+	if !this.Time.Equal(that1.Time) {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
+}
+func (this *OutlierEjection) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*OutlierEjection)
+	if !ok {
+		that2, ok := that.(OutlierEjection)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Cluster != that1.Cluster {
+		return false
+	}
+	if this.Host != that1.Host {
+		return false
+	}
+	if this.Ejected != that1.Ejected {
+		return false
+	}
+	if !this.Time.Equal(that1.Time) {
+		return false
+	}
 	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
 		return false
 	}
@@ -550,13 +823,45 @@ func (m *DataplaneInsight) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
+	if len(m.OutlierEjections) > 0 {
+		for _, msg := range m.OutlierEjections {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintDataplaneInsight(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.LastRejection != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintDataplaneInsight(dAtA, i, uint64(m.LastRejection.Size()))
+		n1, err := m.LastRejection.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n1
+	}
+	if m.FirstConnectTime != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintDataplaneInsight(dAtA, i, uint64(m.FirstConnectTime.Size()))
+		n2, err := m.FirstConnectTime.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n2
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *DiscoverySubscription) Marshal() (dAtA []byte, err error) {
+func (m *XdsRejection) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -566,58 +871,40 @@ func (m *DiscoverySubscription) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *DiscoverySubscription) MarshalTo(dAtA []byte) (int, error) {
+func (m *XdsRejection) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Id) > 0 {
+	if len(m.Code) > 0 {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintDataplaneInsight(dAtA, i, uint64(len(m.Id)))
-		i += copy(dAtA[i:], m.Id)
+		i = encodeVarintDataplaneInsight(dAtA, i, uint64(len(m.Code)))
+		i += copy(dAtA[i:], m.Code)
 	}
-	if len(m.ControlPlaneInstanceId) > 0 {
+	if len(m.Message) > 0 {
 		dAtA[i] = 0x12
 		i++
-		i = encodeVarintDataplaneInsight(dAtA, i, uint64(len(m.ControlPlaneInstanceId)))
-		i += copy(dAtA[i:], m.ControlPlaneInstanceId)
+		i = encodeVarintDataplaneInsight(dAtA, i, uint64(len(m.Message)))
+		i += copy(dAtA[i:], m.Message)
 	}
-	if m.ConnectTime != nil {
+	if m.Time != nil {
 		dAtA[i] = 0x1a
 		i++
-		i = encodeVarintDataplaneInsight(dAtA, i, uint64(m.ConnectTime.Size()))
-		n1, err := m.ConnectTime.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n1
-	}
-	if m.DisconnectTime != nil {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintDataplaneInsight(dAtA, i, uint64(m.DisconnectTime.Size()))
-		n2, err := m.DisconnectTime.MarshalTo(dAtA[i:])
+		i = encodeVarintDataplaneInsight(dAtA, i, uint64(m.Time.Size()))
+		n3, err := m.Time.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n2
-	}
-	dAtA[i] = 0x2a
-	i++
-	i = encodeVarintDataplaneInsight(dAtA, i, uint64(m.Status.Size()))
-	n3, err := m.Status.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+		i += n3
 	}
-	i += n3
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *DiscoverySubscriptionStatus) Marshal() (dAtA []byte, err error) {
+func (m *OutlierEjection) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -627,61 +914,175 @@ func (m *DiscoverySubscriptionStatus) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *DiscoverySubscriptionStatus) MarshalTo(dAtA []byte) (int, error) {
+func (m *OutlierEjection) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.LastUpdateTime != nil {
+	if len(m.Cluster) > 0 {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintDataplaneInsight(dAtA, i, uint64(m.LastUpdateTime.Size()))
-		n4, err := m.LastUpdateTime.MarshalTo(dAtA[i:])
+		i = encodeVarintDataplaneInsight(dAtA, i, uint64(len(m.Cluster)))
+		i += copy(dAtA[i:], m.Cluster)
+	}
+	if len(m.Host) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintDataplaneInsight(dAtA, i, uint64(len(m.Host)))
+		i += copy(dAtA[i:], m.Host)
+	}
+	if m.Ejected {
+		dAtA[i] = 0x18
+		i++
+		if m.Ejected {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.Time != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintDataplaneInsight(dAtA, i, uint64(m.Time.Size()))
+		n4, err := m.Time.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
 		i += n4
 	}
-	dAtA[i] = 0x12
-	i++
-	i = encodeVarintDataplaneInsight(dAtA, i, uint64(m.Total.Size()))
-	n5, err := m.Total.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n5
-	dAtA[i] = 0x1a
-	i++
-	i = encodeVarintDataplaneInsight(dAtA, i, uint64(m.Cds.Size()))
-	n6, err := m.Cds.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	i += n6
-	dAtA[i] = 0x22
-	i++
-	i = encodeVarintDataplaneInsight(dAtA, i, uint64(m.Eds.Size()))
-	n7, err := m.Eds.MarshalTo(dAtA[i:])
+	return i, nil
+}
+
+func (m *DiscoverySubscription) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	i += n7
-	dAtA[i] = 0x2a
-	i++
-	i = encodeVarintDataplaneInsight(dAtA, i, uint64(m.Lds.Size()))
-	n8, err := m.Lds.MarshalTo(dAtA[i:])
+	return dAtA[:n], nil
+}
+
+func (m *DiscoverySubscription) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Id) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDataplaneInsight(dAtA, i, uint64(len(m.Id)))
+		i += copy(dAtA[i:], m.Id)
+	}
+	if len(m.ControlPlaneInstanceId) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintDataplaneInsight(dAtA, i, uint64(len(m.ControlPlaneInstanceId)))
+		i += copy(dAtA[i:], m.ControlPlaneInstanceId)
+	}
+	if m.ConnectTime != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintDataplaneInsight(dAtA, i, uint64(m.ConnectTime.Size()))
+		n5, err := m.ConnectTime.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n5
+	}
+	if m.DisconnectTime != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintDataplaneInsight(dAtA, i, uint64(m.DisconnectTime.Size()))
+		n6, err := m.DisconnectTime.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n6
+	}
+	dAtA[i] = 0x2a
+	i++
+	i = encodeVarintDataplaneInsight(dAtA, i, uint64(m.Status.Size()))
+	n7, err := m.Status.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n7
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *DiscoverySubscriptionStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DiscoverySubscriptionStatus) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.LastUpdateTime != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDataplaneInsight(dAtA, i, uint64(m.LastUpdateTime.Size()))
+		n8, err := m.LastUpdateTime.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n8
+	}
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintDataplaneInsight(dAtA, i, uint64(m.Total.Size()))
+	n9, err := m.Total.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n9
+	dAtA[i] = 0x1a
+	i++
+	i = encodeVarintDataplaneInsight(dAtA, i, uint64(m.Cds.Size()))
+	n10, err := m.Cds.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n10
+	dAtA[i] = 0x22
+	i++
+	i = encodeVarintDataplaneInsight(dAtA, i, uint64(m.Eds.Size()))
+	n11, err := m.Eds.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n8
+	i += n11
+	dAtA[i] = 0x2a
+	i++
+	i = encodeVarintDataplaneInsight(dAtA, i, uint64(m.Lds.Size()))
+	n12, err := m.Lds.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n12
 	dAtA[i] = 0x32
 	i++
 	i = encodeVarintDataplaneInsight(dAtA, i, uint64(m.Rds.Size()))
-	n9, err := m.Rds.MarshalTo(dAtA[i:])
+	n13, err := m.Rds.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n9
+	i += n13
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -745,6 +1146,71 @@ func (m *DataplaneInsight) Size() (n int) {
 			n += 1 + l + sovDataplaneInsight(uint64(l))
 		}
 	}
+	if len(m.OutlierEjections) > 0 {
+		for _, e := range m.OutlierEjections {
+			l = e.Size()
+			n += 1 + l + sovDataplaneInsight(uint64(l))
+		}
+	}
+	if m.LastRejection != nil {
+		l = m.LastRejection.Size()
+		n += 1 + l + sovDataplaneInsight(uint64(l))
+	}
+	if m.FirstConnectTime != nil {
+		l = m.FirstConnectTime.Size()
+		n += 1 + l + sovDataplaneInsight(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *XdsRejection) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Code)
+	if l > 0 {
+		n += 1 + l + sovDataplaneInsight(uint64(l))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovDataplaneInsight(uint64(l))
+	}
+	if m.Time != nil {
+		l = m.Time.Size()
+		n += 1 + l + sovDataplaneInsight(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *OutlierEjection) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Cluster)
+	if l > 0 {
+		n += 1 + l + sovDataplaneInsight(uint64(l))
+	}
+	l = len(m.Host)
+	if l > 0 {
+		n += 1 + l + sovDataplaneInsight(uint64(l))
+	}
+	if m.Ejected {
+		n += 2
+	}
+	if m.Time != nil {
+		l = m.Time.Size()
+		n += 1 + l + sovDataplaneInsight(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -904,6 +1370,440 @@ func (m *DataplaneInsight) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OutlierEjections", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDataplaneInsight
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDataplaneInsight
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDataplaneInsight
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OutlierEjections = append(m.OutlierEjections, &OutlierEjection{})
+			if err := m.OutlierEjections[len(m.OutlierEjections)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastRejection", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDataplaneInsight
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDataplaneInsight
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDataplaneInsight
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LastRejection == nil {
+				m.LastRejection = &XdsRejection{}
+			}
+			if err := m.LastRejection.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FirstConnectTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDataplaneInsight
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDataplaneInsight
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDataplaneInsight
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.FirstConnectTime == nil {
+				m.FirstConnectTime = &types.Timestamp{}
+			}
+			if err := m.FirstConnectTime.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDataplaneInsight(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDataplaneInsight
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDataplaneInsight
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *XdsRejection) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDataplaneInsight
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: XdsRejection: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: XdsRejection: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDataplaneInsight
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDataplaneInsight
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDataplaneInsight
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Code = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDataplaneInsight
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDataplaneInsight
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDataplaneInsight
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Message = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDataplaneInsight
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDataplaneInsight
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDataplaneInsight
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Time == nil {
+				m.Time = &types.Timestamp{}
+			}
+			if err := m.Time.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDataplaneInsight(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDataplaneInsight
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDataplaneInsight
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *OutlierEjection) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDataplaneInsight
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: OutlierEjection: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: OutlierEjection: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cluster", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDataplaneInsight
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDataplaneInsight
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDataplaneInsight
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Cluster = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Host", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDataplaneInsight
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDataplaneInsight
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDataplaneInsight
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Host = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ejected", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDataplaneInsight
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Ejected = bool(v != 0)
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDataplaneInsight
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDataplaneInsight
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDataplaneInsight
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Time == nil {
+				m.Time = &types.Timestamp{}
+			}
+			if err := m.Time.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDataplaneInsight(dAtA[iNdEx:])
@@ -32,6 +32,11 @@ func (ds *DataplaneInsight) UpdateSubscription(s *DiscoverySubscription) {
 	} else {
 		ds.Subscriptions = append(ds.Subscriptions, s)
 	}
+	if ds.FirstConnectTime == nil {
+		if firstConnectTime := ds.GetEarliestConnectTime(); firstConnectTime != nil {
+			ds.FirstConnectTime, _ = types.TimestampProto(*firstConnectTime)
+		}
+	}
 }
 
 func (ds *DataplaneInsight) GetLatestSubscription() (*DiscoverySubscription, *time.Time) {
@@ -53,6 +58,28 @@ func (ds *DataplaneInsight) GetLatestSubscription() (*DiscoverySubscription, *ti
 	return ds.Subscriptions[idx], latest
 }
 
+// GetEarliestConnectTime returns when a given Dataplane connected to the Control Plane for the first time.
+//
+// Insights saved before the time has been recorded fall back to the earliest subscription that is still remembered.
+func (ds *DataplaneInsight) GetEarliestConnectTime() *time.Time {
+	if ds.FirstConnectTime != nil {
+		if t, err := types.TimestampFromProto(ds.FirstConnectTime); err == nil {
+			return &t
+		}
+	}
+	var first *time.Time
+	for _, s := range ds.Subscriptions {
+		t, err := types.TimestampFromProto(s.ConnectTime)
+		if err != nil {
+			continue
+		}
+		if first == nil || t.Before(*first) {
+			first = &t
+		}
+	}
+	return first
+}
+
 func (ds *DataplaneInsight) Sum(v func(*DiscoverySubscription) uint64) uint64 {
 	var result uint64 = 0
 	for _, s := range ds.Subscriptions {
@@ -75,3 +102,11 @@ func (s *DiscoverySubscriptionStatus) StatsOf(typeUrl string) *DiscoveryServiceS
 		return &DiscoveryServiceStats{}
 	}
 }
+
+// RecordOutlierEjections appends given ejections and keeps no more than a given number of the most recent ones.
+func (ds *DataplaneInsight) RecordOutlierEjections(limit int, ejections ...*OutlierEjection) {
+	ds.OutlierEjections = append(ds.OutlierEjections, ejections...)
+	if len(ds.OutlierEjections) > limit {
+		ds.OutlierEjections = ds.OutlierEjections[len(ds.OutlierEjections)-limit:]
+	}
+}
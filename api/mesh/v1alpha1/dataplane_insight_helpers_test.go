@@ -94,6 +94,25 @@ var _ = Describe("DataplaneHelpers", func() {
                     total: {}
 `))
 			})
+
+			It("should keep the time of the first connection", func() {
+				// given
+				status.UpdateSubscription(&DiscoverySubscription{
+					Id:          "1",
+					ConnectTime: util_proto.MustTimestampProto(t1),
+				})
+
+				// when
+				status.UpdateSubscription(&DiscoverySubscription{
+					Id:          "2",
+					ConnectTime: util_proto.MustTimestampProto(t2),
+				})
+				// and
+				status.Subscriptions = status.Subscriptions[1:]
+
+				// then
+				Expect(*status.GetEarliestConnectTime()).To(BeTemporally("==", t1))
+			})
 		})
 
 		Describe("GetLatestSubscription()", func() {
@@ -136,6 +155,37 @@ var _ = Describe("DataplaneHelpers", func() {
 			})
 		})
 
+		Describe("GetEarliestConnectTime()", func() {
+
+			It("should return `nil` when a Dataplane has never connected", func() {
+				// expect
+				Expect(status.GetEarliestConnectTime()).To(BeNil())
+			})
+
+			It("should fall back to subscription with the earliest `ConnectTime`", func() {
+				// given
+				status.Subscriptions = []*DiscoverySubscription{
+					{
+						Id:          "3",
+						ConnectTime: util_proto.MustTimestampProto(t3),
+					},
+					{
+						Id:          "2",
+						ConnectTime: util_proto.MustTimestampProto(t2),
+					},
+				}
+
+				// expect
+				Expect(*status.GetEarliestConnectTime()).To(BeTemporally("==", t2))
+
+				// when
+				status.FirstConnectTime = util_proto.MustTimestampProto(t1)
+
+				// then
+				Expect(*status.GetEarliestConnectTime()).To(BeTemporally("==", t1))
+			})
+		})
+
 		Describe("Sum()", func() {
 
 			It("should return `0` when there are no subscriptions", func() {
@@ -181,6 +231,30 @@ var _ = Describe("DataplaneHelpers", func() {
 				Expect(sum).To(Equal(uint64(3)))
 			})
 		})
+
+		Describe("RecordOutlierEjections()", func() {
+
+			It("should keep only the most recent ejections", func() {
+				// given
+				status.OutlierEjections = []*OutlierEjection{
+					{Cluster: "backend", Host: "192.168.0.1:8080", Ejected: true},
+					{Cluster: "backend", Host: "192.168.0.1:8080", Ejected: false},
+				}
+
+				// when
+				status.RecordOutlierEjections(3,
+					&OutlierEjection{Cluster: "backend", Host: "192.168.0.2:8080", Ejected: true},
+					&OutlierEjection{Cluster: "web", Host: "192.168.0.3:8080", Ejected: true},
+				)
+
+				// then
+				Expect(status.OutlierEjections).To(Equal([]*OutlierEjection{
+					{Cluster: "backend", Host: "192.168.0.1:8080", Ejected: false},
+					{Cluster: "backend", Host: "192.168.0.2:8080", Ejected: true},
+					{Cluster: "web", Host: "192.168.0.3:8080", Ejected: true},
+				}))
+			})
+		})
 	})
 
 	Describe("DiscoverySubscriptionStatus", func() {
@@ -6,6 +6,7 @@ package v1alpha1
 import (
 	fmt "fmt"
 	proto "github.com/gogo/protobuf/proto"
+	types "github.com/gogo/protobuf/types"
 	io "io"
 	math "math"
 )
@@ -31,10 +32,22 @@ type Mesh struct {
 	Tracing *Tracing `protobuf:"bytes,2,opt,name=tracing,proto3" json:"tracing,omitempty"`
 	// Logging settings.
 	// +optional
-	Logging              *Logging `protobuf:"bytes,3,opt,name=logging,proto3" json:"logging,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Logging *Logging `protobuf:"bytes,3,opt,name=logging,proto3" json:"logging,omitempty"`
+	// Networking settings.
+	// +optional
+	Networking *Networking `protobuf:"bytes,4,opt,name=networking,proto3" json:"networking,omitempty"`
+	// Metrics settings.
+	// +optional
+	Metrics *Metrics `protobuf:"bytes,5,opt,name=metrics,proto3" json:"metrics,omitempty"`
+	// Notifications settings.
+	// +optional
+	Notifications *Notifications `protobuf:"bytes,6,opt,name=notifications,proto3" json:"notifications,omitempty"`
+	// Constraints on resources of the mesh.
+	// +optional
+	Constraints          *Constraints `protobuf:"bytes,7,opt,name=constraints,proto3" json:"constraints,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
 func (m *Mesh) Reset()         { *m = Mesh{} }
@@ -91,16 +104,50 @@ func (m *Mesh) GetLogging() *Logging {
 	return nil
 }
 
+func (m *Mesh) GetNetworking() *Networking {
+	if m != nil {
+		return m.Networking
+	}
+	return nil
+}
+
+func (m *Mesh) GetMetrics() *Metrics {
+	if m != nil {
+		return m.Metrics
+	}
+	return nil
+}
+
+func (m *Mesh) GetNotifications() *Notifications {
+	if m != nil {
+		return m.Notifications
+	}
+	return nil
+}
+
+func (m *Mesh) GetConstraints() *Constraints {
+	if m != nil {
+		return m.Constraints
+	}
+	return nil
+}
+
 // mTLS settings of a Mesh.
 type Mesh_Mtls struct {
 	// Certificate Authority of a Mesh.
 	// +optional
 	Ca *CertificateAuthority `protobuf:"bytes,1,opt,name=ca,proto3" json:"ca,omitempty"`
 	// If true, then mTLS will be enabled for given mesh
-	Enabled              bool     `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Enabled bool `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// Ports that accept plaintext connections even though mTLS is enabled,
+	// e.g. a port that a legacy appliance without a Dataplane connects to.
+	// Plaintext connections are not subject to TrafficPermissions, since their
+	// source cannot be identified.
+	// +optional
+	PermissivePorts      []*Mesh_Mtls_PermissivePort `protobuf:"bytes,3,rep,name=permissivePorts,proto3" json:"permissivePorts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
 }
 
 func (m *Mesh_Mtls) Reset()         { *m = Mesh_Mtls{} }
@@ -150,10 +197,79 @@ func (m *Mesh_Mtls) GetEnabled() bool {
 	return false
 }
 
+func (m *Mesh_Mtls) GetPermissivePorts() []*Mesh_Mtls_PermissivePort {
+	if m != nil {
+		return m.PermissivePorts
+	}
+	return nil
+}
+
+// PermissivePort defines a port of a service that accepts plaintext
+// connections in addition to mTLS ones.
+type Mesh_Mtls_PermissivePort struct {
+	// Service that the port belongs to, i.e. value of the `service` tag of
+	// an inbound interface.
+	Service string `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	// Port of the service, i.e. port of an inbound interface that clients
+	// connect to.
+	Port                 uint32   `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Mesh_Mtls_PermissivePort) Reset()         { *m = Mesh_Mtls_PermissivePort{} }
+func (m *Mesh_Mtls_PermissivePort) String() string { return proto.CompactTextString(m) }
+func (*Mesh_Mtls_PermissivePort) ProtoMessage()    {}
+func (*Mesh_Mtls_PermissivePort) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae9b3cd8c92bbf6a, []int{0, 0, 0}
+}
+func (m *Mesh_Mtls_PermissivePort) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Mesh_Mtls_PermissivePort) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Mesh_Mtls_PermissivePort.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Mesh_Mtls_PermissivePort) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Mesh_Mtls_PermissivePort.Merge(m, src)
+}
+func (m *Mesh_Mtls_PermissivePort) XXX_Size() int {
+	return m.Size()
+}
+func (m *Mesh_Mtls_PermissivePort) XXX_DiscardUnknown() {
+	xxx_messageInfo_Mesh_Mtls_PermissivePort.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Mesh_Mtls_PermissivePort proto.InternalMessageInfo
+
+func (m *Mesh_Mtls_PermissivePort) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *Mesh_Mtls_PermissivePort) GetPort() uint32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
 // CertificateAuthority defines configuration of a CA.
 type CertificateAuthority struct {
 	// Types that are valid to be assigned to Type:
 	//	*CertificateAuthority_Builtin_
+	//	*CertificateAuthority_Provided_
 	Type                 isCertificateAuthority_Type `protobuf_oneof:"type"`
 	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
 	XXX_unrecognized     []byte                      `json:"-"`
@@ -202,8 +318,12 @@ type isCertificateAuthority_Type interface {
 type CertificateAuthority_Builtin_ struct {
 	Builtin *CertificateAuthority_Builtin `protobuf:"bytes,1,opt,name=builtin,proto3,oneof"`
 }
+type CertificateAuthority_Provided_ struct {
+	Provided *CertificateAuthority_Provided `protobuf:"bytes,2,opt,name=provided,proto3,oneof"`
+}
 
-func (*CertificateAuthority_Builtin_) isCertificateAuthority_Type() {}
+func (*CertificateAuthority_Builtin_) isCertificateAuthority_Type()  {}
+func (*CertificateAuthority_Provided_) isCertificateAuthority_Type() {}
 
 func (m *CertificateAuthority) GetType() isCertificateAuthority_Type {
 	if m != nil {
@@ -219,10 +339,18 @@ func (m *CertificateAuthority) GetBuiltin() *CertificateAuthority_Builtin {
 	return nil
 }
 
+func (m *CertificateAuthority) GetProvided() *CertificateAuthority_Provided {
+	if x, ok := m.GetType().(*CertificateAuthority_Provided_); ok {
+		return x.Provided
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*CertificateAuthority) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _CertificateAuthority_OneofMarshaler, _CertificateAuthority_OneofUnmarshaler, _CertificateAuthority_OneofSizer, []interface{}{
 		(*CertificateAuthority_Builtin_)(nil),
+		(*CertificateAuthority_Provided_)(nil),
 	}
 }
 
@@ -235,6 +363,11 @@ func _CertificateAuthority_OneofMarshaler(msg proto.Message, b *proto.Buffer) er
 		if err := b.EncodeMessage(x.Builtin); err != nil {
 			return err
 		}
+	case *CertificateAuthority_Provided_:
+		_ = b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Provided); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("CertificateAuthority.Type has unexpected type %T", x)
@@ -253,6 +386,14 @@ func _CertificateAuthority_OneofUnmarshaler(msg proto.Message, tag, wire int, b
 		err := b.DecodeMessage(msg)
 		m.Type = &CertificateAuthority_Builtin_{msg}
 		return true, err
+	case 2: // type.provided
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(CertificateAuthority_Provided)
+		err := b.DecodeMessage(msg)
+		m.Type = &CertificateAuthority_Provided_{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -267,6 +408,11 @@ func _CertificateAuthority_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *CertificateAuthority_Provided_:
+		s := proto.Size(x.Provided)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -314,10 +460,73 @@ func (m *CertificateAuthority_Builtin) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_CertificateAuthority_Builtin proto.InternalMessageInfo
 
+// Provided defines configuration of a CA provided by the user.
+type CertificateAuthority_Provided struct {
+	// Name of a CA in the configuration of the Control Plane
+	// (sdsServer.providedCas) that holds paths to certificate and private key
+	// of the CA. Used in universal mode.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Name of a Secret of type kubernetes.io/tls in the namespace of the
+	// Control Plane that holds certificate and private key of the CA.
+	// Used in Kubernetes mode.
+	Secret               string   `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CertificateAuthority_Provided) Reset()         { *m = CertificateAuthority_Provided{} }
+func (m *CertificateAuthority_Provided) String() string { return proto.CompactTextString(m) }
+func (*CertificateAuthority_Provided) ProtoMessage()    {}
+func (*CertificateAuthority_Provided) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae9b3cd8c92bbf6a, []int{1, 1}
+}
+func (m *CertificateAuthority_Provided) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CertificateAuthority_Provided) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CertificateAuthority_Provided.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CertificateAuthority_Provided) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CertificateAuthority_Provided.Merge(m, src)
+}
+func (m *CertificateAuthority_Provided) XXX_Size() int {
+	return m.Size()
+}
+func (m *CertificateAuthority_Provided) XXX_DiscardUnknown() {
+	xxx_messageInfo_CertificateAuthority_Provided.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CertificateAuthority_Provided proto.InternalMessageInfo
+
+func (m *CertificateAuthority_Provided) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CertificateAuthority_Provided) GetSecret() string {
+	if m != nil {
+		return m.Secret
+	}
+	return ""
+}
+
 // Tracing defines tracing configuration of the mesh.
 type Tracing struct {
 	// Types that are valid to be assigned to Type:
 	//	*Tracing_Zipkin_
+	//	*Tracing_Datadog_
 	Type                 isTracing_Type `protobuf_oneof:"type"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
 	XXX_unrecognized     []byte         `json:"-"`
@@ -366,8 +575,12 @@ type isTracing_Type interface {
 type Tracing_Zipkin_ struct {
 	Zipkin *Tracing_Zipkin `protobuf:"bytes,1,opt,name=zipkin,proto3,oneof"`
 }
+type Tracing_Datadog_ struct {
+	Datadog *Tracing_Datadog `protobuf:"bytes,2,opt,name=datadog,proto3,oneof"`
+}
 
-func (*Tracing_Zipkin_) isTracing_Type() {}
+func (*Tracing_Zipkin_) isTracing_Type()  {}
+func (*Tracing_Datadog_) isTracing_Type() {}
 
 func (m *Tracing) GetType() isTracing_Type {
 	if m != nil {
@@ -383,10 +596,18 @@ func (m *Tracing) GetZipkin() *Tracing_Zipkin {
 	return nil
 }
 
+func (m *Tracing) GetDatadog() *Tracing_Datadog {
+	if x, ok := m.GetType().(*Tracing_Datadog_); ok {
+		return x.Datadog
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*Tracing) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _Tracing_OneofMarshaler, _Tracing_OneofUnmarshaler, _Tracing_OneofSizer, []interface{}{
 		(*Tracing_Zipkin_)(nil),
+		(*Tracing_Datadog_)(nil),
 	}
 }
 
@@ -399,6 +620,11 @@ func _Tracing_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.Zipkin); err != nil {
 			return err
 		}
+	case *Tracing_Datadog_:
+		_ = b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Datadog); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("Tracing.Type has unexpected type %T", x)
@@ -417,6 +643,14 @@ func _Tracing_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer
 		err := b.DecodeMessage(msg)
 		m.Type = &Tracing_Zipkin_{msg}
 		return true, err
+	case 2: // type.datadog
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Tracing_Datadog)
+		err := b.DecodeMessage(msg)
+		m.Type = &Tracing_Datadog_{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -431,6 +665,11 @@ func _Tracing_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *Tracing_Datadog_:
+		s := proto.Size(x.Datadog)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -487,6 +726,71 @@ func (m *Tracing_Zipkin) GetAddress() string {
 	return ""
 }
 
+// Datadog defines configuration of Datadog APM tracer.
+//
+// The tracer is configured in the bootstrap config of Dataplanes.
+// Spans are produced only by HTTP listeners of Envoy, while traffic between
+// services is proxied over TCP, so only HTTP traffic that Envoy terminates
+// itself is traced.
+type Tracing_Datadog struct {
+	// Address of Datadog agent in the format <HOST>:<PORT>, e.g. datadog-agent:8126
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// Name of the service that traces are reported under.
+	// Defaults to the service of a Dataplane.
+	// +optional
+	Service              string   `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Tracing_Datadog) Reset()         { *m = Tracing_Datadog{} }
+func (m *Tracing_Datadog) String() string { return proto.CompactTextString(m) }
+func (*Tracing_Datadog) ProtoMessage()    {}
+func (*Tracing_Datadog) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae9b3cd8c92bbf6a, []int{2, 1}
+}
+func (m *Tracing_Datadog) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Tracing_Datadog) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Tracing_Datadog.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Tracing_Datadog) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Tracing_Datadog.Merge(m, src)
+}
+func (m *Tracing_Datadog) XXX_Size() int {
+	return m.Size()
+}
+func (m *Tracing_Datadog) XXX_DiscardUnknown() {
+	xxx_messageInfo_Tracing_Datadog.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Tracing_Datadog proto.InternalMessageInfo
+
+func (m *Tracing_Datadog) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *Tracing_Datadog) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
 type Logging struct {
 	AccessLogs           *Logging_AccessLogs `protobuf:"bytes,1,opt,name=accessLogs,proto3" json:"accessLogs,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
@@ -534,26 +838,37 @@ func (m *Logging) GetAccessLogs() *Logging_AccessLogs {
 	return nil
 }
 
-type Logging_AccessLogs struct {
-	Enabled              bool     `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
-	FilePath             string   `protobuf:"bytes,2,opt,name=filePath,proto3" json:"filePath,omitempty"`
+// SplunkHec defines configuration of Splunk HTTP Event Collector.
+type Logging_SplunkHec struct {
+	// URL of the collector, e.g.
+	// https://splunk.example.com:8088/services/collector/event
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// Name of a Secret in the mesh that holds the HEC token that authorizes
+	// access to the collector.
+	TokenSecret string `protobuf:"bytes,2,opt,name=token_secret,json=tokenSecret,proto3" json:"token_secret,omitempty"`
+	// Index that events are put into.
+	// +optional
+	Index string `protobuf:"bytes,3,opt,name=index,proto3" json:"index,omitempty"`
+	// Source type of events.
+	// +optional
+	Sourcetype           string   `protobuf:"bytes,4,opt,name=sourcetype,proto3" json:"sourcetype,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Logging_AccessLogs) Reset()         { *m = Logging_AccessLogs{} }
-func (m *Logging_AccessLogs) String() string { return proto.CompactTextString(m) }
-func (*Logging_AccessLogs) ProtoMessage()    {}
-func (*Logging_AccessLogs) Descriptor() ([]byte, []int) {
+func (m *Logging_SplunkHec) Reset()         { *m = Logging_SplunkHec{} }
+func (m *Logging_SplunkHec) String() string { return proto.CompactTextString(m) }
+func (*Logging_SplunkHec) ProtoMessage()    {}
+func (*Logging_SplunkHec) Descriptor() ([]byte, []int) {
 	return fileDescriptor_ae9b3cd8c92bbf6a, []int{3, 0}
 }
-func (m *Logging_AccessLogs) XXX_Unmarshal(b []byte) error {
+func (m *Logging_SplunkHec) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Logging_AccessLogs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *Logging_SplunkHec) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Logging_AccessLogs.Marshal(b, m, deterministic)
+		return xxx_messageInfo_Logging_SplunkHec.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -563,547 +878,5571 @@ func (m *Logging_AccessLogs) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *Logging_AccessLogs) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Logging_AccessLogs.Merge(m, src)
+func (m *Logging_SplunkHec) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Logging_SplunkHec.Merge(m, src)
 }
-func (m *Logging_AccessLogs) XXX_Size() int {
+func (m *Logging_SplunkHec) XXX_Size() int {
 	return m.Size()
 }
-func (m *Logging_AccessLogs) XXX_DiscardUnknown() {
-	xxx_messageInfo_Logging_AccessLogs.DiscardUnknown(m)
+func (m *Logging_SplunkHec) XXX_DiscardUnknown() {
+	xxx_messageInfo_Logging_SplunkHec.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Logging_AccessLogs proto.InternalMessageInfo
+var xxx_messageInfo_Logging_SplunkHec proto.InternalMessageInfo
 
-func (m *Logging_AccessLogs) GetEnabled() bool {
+func (m *Logging_SplunkHec) GetUrl() string {
 	if m != nil {
-		return m.Enabled
+		return m.Url
 	}
-	return false
+	return ""
 }
 
-func (m *Logging_AccessLogs) GetFilePath() string {
+func (m *Logging_SplunkHec) GetTokenSecret() string {
 	if m != nil {
-		return m.FilePath
+		return m.TokenSecret
 	}
 	return ""
 }
 
-func init() {
-	proto.RegisterType((*Mesh)(nil), "kuma.mesh.v1alpha1.Mesh")
-	proto.RegisterType((*Mesh_Mtls)(nil), "kuma.mesh.v1alpha1.Mesh.Mtls")
-	proto.RegisterType((*CertificateAuthority)(nil), "kuma.mesh.v1alpha1.CertificateAuthority")
-	proto.RegisterType((*CertificateAuthority_Builtin)(nil), "kuma.mesh.v1alpha1.CertificateAuthority.Builtin")
-	proto.RegisterType((*Tracing)(nil), "kuma.mesh.v1alpha1.Tracing")
-	proto.RegisterType((*Tracing_Zipkin)(nil), "kuma.mesh.v1alpha1.Tracing.Zipkin")
-	proto.RegisterType((*Logging)(nil), "kuma.mesh.v1alpha1.Logging")
-	proto.RegisterType((*Logging_AccessLogs)(nil), "kuma.mesh.v1alpha1.Logging.AccessLogs")
+func (m *Logging_SplunkHec) GetIndex() string {
+	if m != nil {
+		return m.Index
+	}
+	return ""
 }
 
-func init() { proto.RegisterFile("mesh/v1alpha1/mesh.proto", fileDescriptor_ae9b3cd8c92bbf6a) }
-
-var fileDescriptor_ae9b3cd8c92bbf6a = []byte{
-	// 367 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0x41, 0x4b, 0xc3, 0x30,
-	0x14, 0xc7, 0xd7, 0x5a, 0xda, 0xed, 0x79, 0x0b, 0x22, 0xa5, 0xe2, 0x90, 0x1e, 0x64, 0xa7, 0xcc,
-	0x29, 0x82, 0x07, 0x2f, 0xab, 0x20, 0x3b, 0x6c, 0x20, 0xc1, 0xd3, 0x6e, 0x59, 0x97, 0xb5, 0x61,
-	0xdd, 0x5a, 0x9a, 0x4c, 0x99, 0x57, 0x3f, 0x83, 0xdf, 0xc9, 0xa3, 0x1f, 0x41, 0xf6, 0x49, 0xa4,
-	0x69, 0x53, 0x27, 0xce, 0xe1, 0xf1, 0xbd, 0xfc, 0x7e, 0xfd, 0xa7, 0xef, 0x05, 0xdc, 0x05, 0x13,
-	0x71, 0xf7, 0xa9, 0x47, 0x93, 0x2c, 0xa6, 0xbd, 0x6e, 0x51, 0xe1, 0x2c, 0x4f, 0x65, 0x8a, 0xd0,
-	0x7c, 0xb5, 0xa0, 0x58, 0x35, 0xf4, 0xb1, 0xff, 0x6a, 0x82, 0x35, 0x62, 0x22, 0x46, 0x3d, 0xb0,
-	0x16, 0x32, 0x11, 0xae, 0x71, 0x66, 0x74, 0x0e, 0x2f, 0x4f, 0xf1, 0x6f, 0x16, 0x17, 0x1c, 0x1e,
-	0xc9, 0x44, 0x10, 0x85, 0xa2, 0x6b, 0x70, 0x64, 0x4e, 0x43, 0xbe, 0x8c, 0x5c, 0x53, 0x59, 0x27,
-	0xbb, 0xac, 0xc7, 0x12, 0x21, 0x9a, 0x2d, 0xb4, 0x24, 0x8d, 0xa2, 0x42, 0x3b, 0xf8, 0x5b, 0x1b,
-	0x96, 0x08, 0xd1, 0xac, 0x37, 0x06, 0xab, 0xc8, 0x46, 0x37, 0x60, 0x86, 0xb4, 0xba, 0x66, 0x67,
-	0x97, 0x79, 0xc7, 0x72, 0xc9, 0x67, 0x3c, 0xa4, 0x92, 0xf5, 0x57, 0x32, 0x4e, 0x73, 0x2e, 0xd7,
-	0xc4, 0x0c, 0x29, 0x72, 0xc1, 0x61, 0x4b, 0x3a, 0x49, 0xd8, 0x54, 0xdd, 0xb7, 0x49, 0x74, 0xe9,
-	0x3f, 0xc3, 0xd1, 0x2e, 0x0b, 0x0d, 0xc1, 0x99, 0xac, 0x78, 0x22, 0xf9, 0xb2, 0x0a, 0xbc, 0xf8,
-	0x6f, 0x20, 0x0e, 0x4a, 0x6f, 0xd0, 0x20, 0xfa, 0x13, 0x5e, 0x0b, 0x9c, 0xaa, 0x1b, 0xd8, 0x60,
-	0xc9, 0x75, 0xc6, 0x7c, 0x01, 0x4e, 0x35, 0x1f, 0x74, 0x0b, 0xf6, 0x0b, 0xcf, 0xe6, 0x75, 0x94,
-	0xbf, 0x67, 0x98, 0x78, 0xac, 0xc8, 0x41, 0x83, 0x54, 0x8e, 0xe7, 0x83, 0x5d, 0xf6, 0x8a, 0xbf,
-	0xa4, 0xd3, 0x69, 0xce, 0x44, 0xb9, 0xcb, 0x16, 0xd1, 0x65, 0x1d, 0xfa, 0x66, 0x80, 0x53, 0x8d,
-	0x17, 0xdd, 0x03, 0xd0, 0x30, 0x64, 0x42, 0x0c, 0xd3, 0x48, 0x2f, 0xff, 0x7c, 0xcf, 0x3e, 0x70,
-	0xbf, 0xa6, 0xc9, 0x96, 0xe9, 0x05, 0x00, 0xdf, 0x27, 0xdb, 0x93, 0x36, 0x7e, 0x4c, 0x1a, 0x79,
-	0xd0, 0x9c, 0xf1, 0x84, 0x3d, 0x50, 0x19, 0xab, 0x25, 0xb4, 0x48, 0x5d, 0x07, 0xc7, 0xef, 0x9b,
-	0xb6, 0xf1, 0xb1, 0x69, 0x1b, 0x9f, 0x9b, 0xb6, 0x31, 0x6e, 0xea, 0xe8, 0x89, 0xad, 0x9e, 0xef,
-	0xd5, 0x57, 0x00, 0x00, 0x00, 0xff, 0xff, 0x05, 0x13, 0xca, 0x3d, 0xda, 0x02, 0x00, 0x00,
+func (m *Logging_SplunkHec) GetSourcetype() string {
+	if m != nil {
+		return m.Sourcetype
+	}
+	return ""
 }
 
-func (m *Mesh) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+// Elasticsearch defines configuration of an Elasticsearch cluster that
+// entries are indexed into with the bulk API.
+type Logging_Elasticsearch struct {
+	// URL of the cluster, e.g. https://elasticsearch.example.com:9200
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// Index that entries are put into.
+	Index string `protobuf:"bytes,2,opt,name=index,proto3" json:"index,omitempty"`
+	// Username for basic authentication.
+	// +optional
+	Username string `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	// Name of a Secret in the mesh that holds the password for basic
+	// authentication.
+	// +optional
+	PasswordSecret       string   `protobuf:"bytes,4,opt,name=password_secret,json=passwordSecret,proto3" json:"password_secret,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Mesh) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Mtls != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintMesh(dAtA, i, uint64(m.Mtls.Size()))
-		n1, err := m.Mtls.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n1
-	}
-	if m.Tracing != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintMesh(dAtA, i, uint64(m.Tracing.Size()))
-		n2, err := m.Tracing.MarshalTo(dAtA[i:])
+func (m *Logging_Elasticsearch) Reset()         { *m = Logging_Elasticsearch{} }
+func (m *Logging_Elasticsearch) String() string { return proto.CompactTextString(m) }
+func (*Logging_Elasticsearch) ProtoMessage()    {}
+func (*Logging_Elasticsearch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae9b3cd8c92bbf6a, []int{3, 1}
+}
+func (m *Logging_Elasticsearch) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Logging_Elasticsearch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Logging_Elasticsearch.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i += n2
+		return b[:n], nil
 	}
-	if m.Logging != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintMesh(dAtA, i, uint64(m.Logging.Size()))
-		n3, err := m.Logging.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n3
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return i, nil
+}
+func (m *Logging_Elasticsearch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Logging_Elasticsearch.Merge(m, src)
+}
+func (m *Logging_Elasticsearch) XXX_Size() int {
+	return m.Size()
+}
+func (m *Logging_Elasticsearch) XXX_DiscardUnknown() {
+	xxx_messageInfo_Logging_Elasticsearch.DiscardUnknown(m)
 }
 
-func (m *Mesh_Mtls) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_Logging_Elasticsearch proto.InternalMessageInfo
+
+func (m *Logging_Elasticsearch) GetUrl() string {
+	if m != nil {
+		return m.Url
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *Mesh_Mtls) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Ca != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintMesh(dAtA, i, uint64(m.Ca.Size()))
-		n4, err := m.Ca.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n4
-	}
-	if m.Enabled {
-		dAtA[i] = 0x10
-		i++
-		if m.Enabled {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i++
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+func (m *Logging_Elasticsearch) GetIndex() string {
+	if m != nil {
+		return m.Index
 	}
-	return i, nil
+	return ""
 }
 
-func (m *CertificateAuthority) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *Logging_Elasticsearch) GetUsername() string {
+	if m != nil {
+		return m.Username
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *CertificateAuthority) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Type != nil {
-		nn5, err := m.Type.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += nn5
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+func (m *Logging_Elasticsearch) GetPasswordSecret() string {
+	if m != nil {
+		return m.PasswordSecret
 	}
-	return i, nil
+	return ""
 }
 
-func (m *CertificateAuthority_Builtin_) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.Builtin != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintMesh(dAtA, i, uint64(m.Builtin.Size()))
-		n6, err := m.Builtin.MarshalTo(dAtA[i:])
+// Backend defines a system that access logs are forwarded to by the
+// Control Plane.
+type Logging_Backend struct {
+	// Name of the backend, unique within the mesh.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Types that are valid to be assigned to Type:
+	//	*Logging_Backend_Splunk
+	//	*Logging_Backend_Elasticsearch
+	Type                 isLogging_Backend_Type `protobuf_oneof:"type"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *Logging_Backend) Reset()         { *m = Logging_Backend{} }
+func (m *Logging_Backend) String() string { return proto.CompactTextString(m) }
+func (*Logging_Backend) ProtoMessage()    {}
+func (*Logging_Backend) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae9b3cd8c92bbf6a, []int{3, 2}
+}
+func (m *Logging_Backend) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Logging_Backend) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Logging_Backend.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i += n6
+		return b[:n], nil
 	}
-	return i, nil
 }
-func (m *CertificateAuthority_Builtin) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *Logging_Backend) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Logging_Backend.Merge(m, src)
+}
+func (m *Logging_Backend) XXX_Size() int {
+	return m.Size()
+}
+func (m *Logging_Backend) XXX_DiscardUnknown() {
+	xxx_messageInfo_Logging_Backend.DiscardUnknown(m)
 }
 
-func (m *CertificateAuthority_Builtin) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return i, nil
+var xxx_messageInfo_Logging_Backend proto.InternalMessageInfo
+
+type isLogging_Backend_Type interface {
+	isLogging_Backend_Type()
+	MarshalTo([]byte) (int, error)
+	Size() int
 }
 
-func (m *Tracing) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+type Logging_Backend_Splunk struct {
+	Splunk *Logging_SplunkHec `protobuf:"bytes,2,opt,name=splunk,proto3,oneof"`
+}
+type Logging_Backend_Elasticsearch struct {
+	Elasticsearch *Logging_Elasticsearch `protobuf:"bytes,3,opt,name=elasticsearch,proto3,oneof"`
 }
 
-func (m *Tracing) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Type != nil {
-		nn7, err := m.Type.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += nn7
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+func (*Logging_Backend_Splunk) isLogging_Backend_Type()        {}
+func (*Logging_Backend_Elasticsearch) isLogging_Backend_Type() {}
+
+func (m *Logging_Backend) GetType() isLogging_Backend_Type {
+	if m != nil {
+		return m.Type
 	}
-	return i, nil
+	return nil
 }
 
-func (m *Tracing_Zipkin_) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.Zipkin != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintMesh(dAtA, i, uint64(m.Zipkin.Size()))
-		n8, err := m.Zipkin.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n8
+func (m *Logging_Backend) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	return i, nil
+	return ""
 }
-func (m *Tracing_Zipkin) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+
+func (m *Logging_Backend) GetSplunk() *Logging_SplunkHec {
+	if x, ok := m.GetType().(*Logging_Backend_Splunk); ok {
+		return x.Splunk
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *Tracing_Zipkin) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Address) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintMesh(dAtA, i, uint64(len(m.Address)))
-		i += copy(dAtA[i:], m.Address)
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+func (m *Logging_Backend) GetElasticsearch() *Logging_Elasticsearch {
+	if x, ok := m.GetType().(*Logging_Backend_Elasticsearch); ok {
+		return x.Elasticsearch
 	}
-	return i, nil
+	return nil
 }
 
-func (m *Logging) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*Logging_Backend) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _Logging_Backend_OneofMarshaler, _Logging_Backend_OneofUnmarshaler, _Logging_Backend_OneofSizer, []interface{}{
+		(*Logging_Backend_Splunk)(nil),
+		(*Logging_Backend_Elasticsearch)(nil),
 	}
-	return dAtA[:n], nil
 }
 
-func (m *Logging) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.AccessLogs != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintMesh(dAtA, i, uint64(m.AccessLogs.Size()))
-		n9, err := m.AccessLogs.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+func _Logging_Backend_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*Logging_Backend)
+	// type
+	switch x := m.Type.(type) {
+	case *Logging_Backend_Splunk:
+		_ = b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Splunk); err != nil {
+			return err
+		}
+	case *Logging_Backend_Elasticsearch:
+		_ = b.EncodeVarint(3<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Elasticsearch); err != nil {
+			return err
 		}
-		i += n9
+	case nil:
+	default:
+		return fmt.Errorf("Logging_Backend.Type has unexpected type %T", x)
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	return nil
+}
+
+func _Logging_Backend_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*Logging_Backend)
+	switch tag {
+	case 2: // type.splunk
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Logging_SplunkHec)
+		err := b.DecodeMessage(msg)
+		m.Type = &Logging_Backend_Splunk{msg}
+		return true, err
+	case 3: // type.elasticsearch
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Logging_Elasticsearch)
+		err := b.DecodeMessage(msg)
+		m.Type = &Logging_Backend_Elasticsearch{msg}
+		return true, err
+	default:
+		return false, nil
 	}
-	return i, nil
 }
 
-func (m *Logging_AccessLogs) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func _Logging_Backend_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*Logging_Backend)
+	// type
+	switch x := m.Type.(type) {
+	case *Logging_Backend_Splunk:
+		s := proto.Size(x.Splunk)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Logging_Backend_Elasticsearch:
+		s := proto.Size(x.Elasticsearch)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
 	}
-	return dAtA[:n], nil
+	return n
 }
 
-func (m *Logging_AccessLogs) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Enabled {
-		dAtA[i] = 0x8
-		i++
-		if m.Enabled {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
+type Logging_AccessLogs struct {
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// Path of a file that Dataplanes write access logs to.
+	// +optional
+	FilePath string `protobuf:"bytes,2,opt,name=filePath,proto3" json:"filePath,omitempty"`
+	// Backends that access logs are forwarded to, in addition to the file.
+	// +optional
+	Backends             []*Logging_Backend `protobuf:"bytes,3,rep,name=backends,proto3" json:"backends,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *Logging_AccessLogs) Reset()         { *m = Logging_AccessLogs{} }
+func (m *Logging_AccessLogs) String() string { return proto.CompactTextString(m) }
+func (*Logging_AccessLogs) ProtoMessage()    {}
+func (*Logging_AccessLogs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae9b3cd8c92bbf6a, []int{3, 3}
+}
+func (m *Logging_AccessLogs) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Logging_AccessLogs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Logging_AccessLogs.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
-		i++
-	}
-	if len(m.FilePath) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintMesh(dAtA, i, uint64(len(m.FilePath)))
-		i += copy(dAtA[i:], m.FilePath)
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+		return b[:n], nil
 	}
-	return i, nil
+}
+func (m *Logging_AccessLogs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Logging_AccessLogs.Merge(m, src)
+}
+func (m *Logging_AccessLogs) XXX_Size() int {
+	return m.Size()
+}
+func (m *Logging_AccessLogs) XXX_DiscardUnknown() {
+	xxx_messageInfo_Logging_AccessLogs.DiscardUnknown(m)
 }
 
-func encodeVarintMesh(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+var xxx_messageInfo_Logging_AccessLogs proto.InternalMessageInfo
+
+func (m *Logging_AccessLogs) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
+	return false
 }
-func (m *Mesh) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Mtls != nil {
-		l = m.Mtls.Size()
-		n += 1 + l + sovMesh(uint64(l))
-	}
-	if m.Tracing != nil {
-		l = m.Tracing.Size()
-		n += 1 + l + sovMesh(uint64(l))
-	}
-	if m.Logging != nil {
-		l = m.Logging.Size()
-		n += 1 + l + sovMesh(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+
+func (m *Logging_AccessLogs) GetFilePath() string {
+	if m != nil {
+		return m.FilePath
 	}
-	return n
+	return ""
 }
 
-func (m *Mesh_Mtls) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Ca != nil {
-		l = m.Ca.Size()
-		n += 1 + l + sovMesh(uint64(l))
-	}
-	if m.Enabled {
-		n += 2
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *Logging_AccessLogs) GetBackends() []*Logging_Backend {
+	if m != nil {
+		return m.Backends
 	}
-	return n
+	return nil
 }
 
-func (m *CertificateAuthority) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Type != nil {
-		n += m.Type.Size()
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+// Networking defines networking settings of the mesh.
+type Networking struct {
+	Outbound             *Networking_Outbound `protobuf:"bytes,1,opt,name=outbound,proto3" json:"outbound,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-func (m *CertificateAuthority_Builtin_) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Builtin != nil {
-		l = m.Builtin.Size()
-		n += 1 + l + sovMesh(uint64(l))
-	}
-	return n
+func (m *Networking) Reset()         { *m = Networking{} }
+func (m *Networking) String() string { return proto.CompactTextString(m) }
+func (*Networking) ProtoMessage()    {}
+func (*Networking) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae9b3cd8c92bbf6a, []int{4}
 }
-func (m *CertificateAuthority_Builtin) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *Networking) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Networking) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Networking.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return n
+}
+func (m *Networking) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Networking.Merge(m, src)
+}
+func (m *Networking) XXX_Size() int {
+	return m.Size()
+}
+func (m *Networking) XXX_DiscardUnknown() {
+	xxx_messageInfo_Networking.DiscardUnknown(m)
 }
 
-func (m *Tracing) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Type != nil {
-		n += m.Type.Size()
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+var xxx_messageInfo_Networking proto.InternalMessageInfo
+
+func (m *Networking) GetOutbound() *Networking_Outbound {
+	if m != nil {
+		return m.Outbound
 	}
-	return n
+	return nil
 }
 
-func (m *Tracing_Zipkin_) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Zipkin != nil {
-		l = m.Zipkin.Size()
-		n += 1 + l + sovMesh(uint64(l))
-	}
-	return n
+// Outbound defines settings of outbound traffic of Dataplanes.
+type Networking_Outbound struct {
+	// If false, then traffic to destinations that are not known to the mesh
+	// is blocked by Dataplanes with transparent proxying.
+	// Defaults to true.
+	// +optional
+	Passthrough *types.BoolValue `protobuf:"bytes,1,opt,name=passthrough,proto3" json:"passthrough,omitempty"`
+	// Limits of connections and requests to destination services, e.g. to
+	// tune clients of a service with high QPS.
+	// +optional
+	ConnectionPools      []*ConnectionPool `protobuf:"bytes,2,rep,name=connectionPools,proto3" json:"connectionPools,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
-func (m *Tracing_Zipkin) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Address)
-	if l > 0 {
-		n += 1 + l + sovMesh(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+
+func (m *Networking_Outbound) Reset()         { *m = Networking_Outbound{} }
+func (m *Networking_Outbound) String() string { return proto.CompactTextString(m) }
+func (*Networking_Outbound) ProtoMessage()    {}
+func (*Networking_Outbound) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae9b3cd8c92bbf6a, []int{4, 0}
+}
+func (m *Networking_Outbound) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Networking_Outbound) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Networking_Outbound.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return n
+}
+func (m *Networking_Outbound) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Networking_Outbound.Merge(m, src)
+}
+func (m *Networking_Outbound) XXX_Size() int {
+	return m.Size()
+}
+func (m *Networking_Outbound) XXX_DiscardUnknown() {
+	xxx_messageInfo_Networking_Outbound.DiscardUnknown(m)
 }
 
-func (m *Logging) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.AccessLogs != nil {
-		l = m.AccessLogs.Size()
-		n += 1 + l + sovMesh(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+var xxx_messageInfo_Networking_Outbound proto.InternalMessageInfo
+
+func (m *Networking_Outbound) GetPassthrough() *types.BoolValue {
+	if m != nil {
+		return m.Passthrough
 	}
-	return n
+	return nil
 }
 
-func (m *Logging_AccessLogs) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Enabled {
-		n += 2
-	}
-	l = len(m.FilePath)
-	if l > 0 {
-		n += 1 + l + sovMesh(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *Networking_Outbound) GetConnectionPools() []*ConnectionPool {
+	if m != nil {
+		return m.ConnectionPools
 	}
-	return n
+	return nil
 }
 
-func sovMesh(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
+// ConnectionPool defines limits of connections and requests that each
+// Dataplane opens to a destination service.
+// Envoy's defaults are used for limits that are not set.
+type ConnectionPool struct {
+	// Service that the limits apply to, i.e. value of the `service` tag.
+	// A service can have only one connection pool.
+	Service string `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	// TCP limits.
+	// +optional
+	Tcp *ConnectionPool_Tcp `protobuf:"bytes,2,opt,name=tcp,proto3" json:"tcp,omitempty"`
+	// HTTP/1.1 and HTTP/2 limits.
+	// +optional
+	Http                 *ConnectionPool_Http `protobuf:"bytes,3,opt,name=http,proto3" json:"http,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ConnectionPool) Reset()         { *m = ConnectionPool{} }
+func (m *ConnectionPool) String() string { return proto.CompactTextString(m) }
+func (*ConnectionPool) ProtoMessage()    {}
+func (*ConnectionPool) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae9b3cd8c92bbf6a, []int{5}
+}
+func (m *ConnectionPool) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ConnectionPool) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ConnectionPool.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ConnectionPool) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnectionPool.Merge(m, src)
+}
+func (m *ConnectionPool) XXX_Size() int {
+	return m.Size()
+}
+func (m *ConnectionPool) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnectionPool.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConnectionPool proto.InternalMessageInfo
+
+func (m *ConnectionPool) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *ConnectionPool) GetTcp() *ConnectionPool_Tcp {
+	if m != nil {
+		return m.Tcp
+	}
+	return nil
+}
+
+func (m *ConnectionPool) GetHttp() *ConnectionPool_Http {
+	if m != nil {
+		return m.Http
+	}
+	return nil
+}
+
+// Tcp defines limits that apply to all traffic.
+type ConnectionPool_Tcp struct {
+	// Maximum number of connections to all endpoints of the service.
+	// +optional
+	MaxConnections *types.UInt32Value `protobuf:"bytes,1,opt,name=maxConnections,proto3" json:"maxConnections,omitempty"`
+	// Time after which a connection without any traffic in either direction
+	// is closed.
+	// +optional
+	IdleTimeout          *types.Duration `protobuf:"bytes,2,opt,name=idleTimeout,proto3" json:"idleTimeout,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ConnectionPool_Tcp) Reset()         { *m = ConnectionPool_Tcp{} }
+func (m *ConnectionPool_Tcp) String() string { return proto.CompactTextString(m) }
+func (*ConnectionPool_Tcp) ProtoMessage()    {}
+func (*ConnectionPool_Tcp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae9b3cd8c92bbf6a, []int{5, 0}
+}
+func (m *ConnectionPool_Tcp) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ConnectionPool_Tcp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ConnectionPool_Tcp.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ConnectionPool_Tcp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnectionPool_Tcp.Merge(m, src)
+}
+func (m *ConnectionPool_Tcp) XXX_Size() int {
+	return m.Size()
+}
+func (m *ConnectionPool_Tcp) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnectionPool_Tcp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConnectionPool_Tcp proto.InternalMessageInfo
+
+func (m *ConnectionPool_Tcp) GetMaxConnections() *types.UInt32Value {
+	if m != nil {
+		return m.MaxConnections
+	}
+	return nil
+}
+
+func (m *ConnectionPool_Tcp) GetIdleTimeout() *types.Duration {
+	if m != nil {
+		return m.IdleTimeout
+	}
+	return nil
+}
+
+// Http defines limits that apply to traffic proxied as HTTP.
+//
+// Outbound listeners generated by Kuma proxy traffic as TCP, so these
+// limits only take effect once a listener of a ProxyTemplate proxies
+// traffic to the service as HTTP.
+type ConnectionPool_Http struct {
+	// Maximum number of HTTP/1.1 requests waiting for a connection to the
+	// service.
+	// +optional
+	MaxPendingRequests *types.UInt32Value `protobuf:"bytes,1,opt,name=maxPendingRequests,proto3" json:"maxPendingRequests,omitempty"`
+	// Maximum number of concurrent HTTP/2 requests to all endpoints of the
+	// service.
+	// +optional
+	MaxRequests *types.UInt32Value `protobuf:"bytes,2,opt,name=maxRequests,proto3" json:"maxRequests,omitempty"`
+	// Maximum number of requests sent over a single connection.
+	// 1 disables keep-alive.
+	// +optional
+	MaxRequestsPerConnection *types.UInt32Value `protobuf:"bytes,3,opt,name=maxRequestsPerConnection,proto3" json:"maxRequestsPerConnection,omitempty"`
+	// Time after which a connection without active HTTP requests is
+	// closed.
+	// +optional
+	IdleTimeout          *types.Duration `protobuf:"bytes,4,opt,name=idleTimeout,proto3" json:"idleTimeout,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ConnectionPool_Http) Reset()         { *m = ConnectionPool_Http{} }
+func (m *ConnectionPool_Http) String() string { return proto.CompactTextString(m) }
+func (*ConnectionPool_Http) ProtoMessage()    {}
+func (*ConnectionPool_Http) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae9b3cd8c92bbf6a, []int{5, 1}
+}
+func (m *ConnectionPool_Http) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ConnectionPool_Http) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ConnectionPool_Http.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ConnectionPool_Http) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnectionPool_Http.Merge(m, src)
+}
+func (m *ConnectionPool_Http) XXX_Size() int {
+	return m.Size()
+}
+func (m *ConnectionPool_Http) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnectionPool_Http.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConnectionPool_Http proto.InternalMessageInfo
+
+func (m *ConnectionPool_Http) GetMaxPendingRequests() *types.UInt32Value {
+	if m != nil {
+		return m.MaxPendingRequests
+	}
+	return nil
+}
+
+func (m *ConnectionPool_Http) GetMaxRequests() *types.UInt32Value {
+	if m != nil {
+		return m.MaxRequests
+	}
+	return nil
+}
+
+func (m *ConnectionPool_Http) GetMaxRequestsPerConnection() *types.UInt32Value {
+	if m != nil {
+		return m.MaxRequestsPerConnection
+	}
+	return nil
+}
+
+func (m *ConnectionPool_Http) GetIdleTimeout() *types.Duration {
+	if m != nil {
+		return m.IdleTimeout
+	}
+	return nil
+}
+
+// Metrics defines metrics configuration of the mesh.
+type Metrics struct {
+	// If set, Dataplanes send their metrics to a StatsD server.
+	// +optional
+	Statsd *Metrics_StatsD `protobuf:"bytes,1,opt,name=statsd,proto3" json:"statsd,omitempty"`
+	// If set, Dataplanes send their metrics to a DogStatsD server.
+	// +optional
+	Dogstatsd *Metrics_DogStatsD `protobuf:"bytes,2,opt,name=dogstatsd,proto3" json:"dogstatsd,omitempty"`
+	// If set, Dataplanes expose their metrics to be scraped by Prometheus.
+	// +optional
+	Prometheus           *Metrics_Prometheus `protobuf:"bytes,3,opt,name=prometheus,proto3" json:"prometheus,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *Metrics) Reset()         { *m = Metrics{} }
+func (m *Metrics) String() string { return proto.CompactTextString(m) }
+func (*Metrics) ProtoMessage()    {}
+func (*Metrics) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae9b3cd8c92bbf6a, []int{6}
+}
+func (m *Metrics) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Metrics) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Metrics.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Metrics) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Metrics.Merge(m, src)
+}
+func (m *Metrics) XXX_Size() int {
+	return m.Size()
+}
+func (m *Metrics) XXX_DiscardUnknown() {
+	xxx_messageInfo_Metrics.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Metrics proto.InternalMessageInfo
+
+func (m *Metrics) GetStatsd() *Metrics_StatsD {
+	if m != nil {
+		return m.Statsd
+	}
+	return nil
+}
+
+func (m *Metrics) GetDogstatsd() *Metrics_DogStatsD {
+	if m != nil {
+		return m.Dogstatsd
+	}
+	return nil
+}
+
+func (m *Metrics) GetPrometheus() *Metrics_Prometheus {
+	if m != nil {
+		return m.Prometheus
+	}
+	return nil
+}
+
+// StatsD defines configuration of a StatsD sink.
+type Metrics_StatsD struct {
+	// Address of StatsD server that metrics are sent to over UDP,
+	// e.g. 127.0.0.1:8125.
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// Prefix of names of metrics.
+	// +optional
+	Prefix               string   `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Metrics_StatsD) Reset()         { *m = Metrics_StatsD{} }
+func (m *Metrics_StatsD) String() string { return proto.CompactTextString(m) }
+func (*Metrics_StatsD) ProtoMessage()    {}
+func (*Metrics_StatsD) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae9b3cd8c92bbf6a, []int{6, 0}
+}
+func (m *Metrics_StatsD) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Metrics_StatsD) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Metrics_StatsD.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Metrics_StatsD) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Metrics_StatsD.Merge(m, src)
+}
+func (m *Metrics_StatsD) XXX_Size() int {
+	return m.Size()
+}
+func (m *Metrics_StatsD) XXX_DiscardUnknown() {
+	xxx_messageInfo_Metrics_StatsD.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Metrics_StatsD proto.InternalMessageInfo
+
+func (m *Metrics_StatsD) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *Metrics_StatsD) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+// DogStatsD defines configuration of a DogStatsD sink.
+type Metrics_DogStatsD struct {
+	// Address of DogStatsD server (e.g. Datadog Agent) that metrics are sent
+	// to over UDP, e.g. 127.0.0.1:8125.
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// Prefix of names of metrics.
+	// +optional
+	Prefix               string   `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Metrics_DogStatsD) Reset()         { *m = Metrics_DogStatsD{} }
+func (m *Metrics_DogStatsD) String() string { return proto.CompactTextString(m) }
+func (*Metrics_DogStatsD) ProtoMessage()    {}
+func (*Metrics_DogStatsD) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae9b3cd8c92bbf6a, []int{6, 1}
+}
+func (m *Metrics_DogStatsD) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Metrics_DogStatsD) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Metrics_DogStatsD.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Metrics_DogStatsD) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Metrics_DogStatsD.Merge(m, src)
+}
+func (m *Metrics_DogStatsD) XXX_Size() int {
+	return m.Size()
+}
+func (m *Metrics_DogStatsD) XXX_DiscardUnknown() {
+	xxx_messageInfo_Metrics_DogStatsD.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Metrics_DogStatsD proto.InternalMessageInfo
+
+func (m *Metrics_DogStatsD) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *Metrics_DogStatsD) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+// Prometheus defines configuration of an endpoint that Prometheus scrapes
+// metrics from.
+type Metrics_Prometheus struct {
+	// Port on which Dataplanes expose metrics on their IP address.
+	// Defaults to 5670.
+	// +optional
+	Port uint32 `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"`
+	// Path on which Dataplanes expose metrics.
+	// Defaults to /metrics.
+	// +optional
+	Path                 string   `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Metrics_Prometheus) Reset()         { *m = Metrics_Prometheus{} }
+func (m *Metrics_Prometheus) String() string { return proto.CompactTextString(m) }
+func (*Metrics_Prometheus) ProtoMessage()    {}
+func (*Metrics_Prometheus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae9b3cd8c92bbf6a, []int{6, 2}
+}
+func (m *Metrics_Prometheus) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Metrics_Prometheus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Metrics_Prometheus.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Metrics_Prometheus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Metrics_Prometheus.Merge(m, src)
+}
+func (m *Metrics_Prometheus) XXX_Size() int {
+	return m.Size()
+}
+func (m *Metrics_Prometheus) XXX_DiscardUnknown() {
+	xxx_messageInfo_Metrics_Prometheus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Metrics_Prometheus proto.InternalMessageInfo
+
+func (m *Metrics_Prometheus) GetPort() uint32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *Metrics_Prometheus) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+// Notifications defines how changes in the mesh are reported to external
+// systems.
+type Notifications struct {
+	// If set, policy changes are reported to a webhook.
+	// +optional
+	Webhook              *Notifications_Webhook `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *Notifications) Reset()         { *m = Notifications{} }
+func (m *Notifications) String() string { return proto.CompactTextString(m) }
+func (*Notifications) ProtoMessage()    {}
+func (*Notifications) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae9b3cd8c92bbf6a, []int{7}
+}
+func (m *Notifications) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Notifications) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Notifications.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Notifications) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Notifications.Merge(m, src)
+}
+func (m *Notifications) XXX_Size() int {
+	return m.Size()
+}
+func (m *Notifications) XXX_DiscardUnknown() {
+	xxx_messageInfo_Notifications.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Notifications proto.InternalMessageInfo
+
+func (m *Notifications) GetWebhook() *Notifications_Webhook {
+	if m != nil {
+		return m.Webhook
+	}
+	return nil
+}
+
+// Webhook defines configuration of an HTTP endpoint.
+type Notifications_Webhook struct {
+	// URL that a JSON notification is POSTed to whenever a policy in the mesh
+	// is created, updated or deleted.
+	Url                  string   `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Notifications_Webhook) Reset()         { *m = Notifications_Webhook{} }
+func (m *Notifications_Webhook) String() string { return proto.CompactTextString(m) }
+func (*Notifications_Webhook) ProtoMessage()    {}
+func (*Notifications_Webhook) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae9b3cd8c92bbf6a, []int{7, 0}
+}
+func (m *Notifications_Webhook) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Notifications_Webhook) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Notifications_Webhook.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Notifications_Webhook) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Notifications_Webhook.Merge(m, src)
+}
+func (m *Notifications_Webhook) XXX_Size() int {
+	return m.Size()
+}
+func (m *Notifications_Webhook) XXX_DiscardUnknown() {
+	xxx_messageInfo_Notifications_Webhook.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Notifications_Webhook proto.InternalMessageInfo
+
+func (m *Notifications_Webhook) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+// Constraints restrict what users can do in the mesh, e.g. to keep teams
+// that share the mesh from affecting each other. Changing them through the
+// API Server requires the admin token.
+type Constraints struct {
+	// Types of policies, e.g. TrafficPermission, that can be created or updated
+	// in the mesh. If empty, then policies of all types are allowed. Unknown
+	// types are rejected.
+	// +optional
+	AllowedPolicyTypes   []string `protobuf:"bytes,1,rep,name=allowedPolicyTypes,proto3" json:"allowedPolicyTypes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Constraints) Reset()         { *m = Constraints{} }
+func (m *Constraints) String() string { return proto.CompactTextString(m) }
+func (*Constraints) ProtoMessage()    {}
+func (*Constraints) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ae9b3cd8c92bbf6a, []int{8}
+}
+func (m *Constraints) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Constraints) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Constraints.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Constraints) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Constraints.Merge(m, src)
+}
+func (m *Constraints) XXX_Size() int {
+	return m.Size()
+}
+func (m *Constraints) XXX_DiscardUnknown() {
+	xxx_messageInfo_Constraints.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Constraints proto.InternalMessageInfo
+
+func (m *Constraints) GetAllowedPolicyTypes() []string {
+	if m != nil {
+		return m.AllowedPolicyTypes
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Mesh)(nil), "kuma.mesh.v1alpha1.Mesh")
+	proto.RegisterType((*Mesh_Mtls)(nil), "kuma.mesh.v1alpha1.Mesh.Mtls")
+	proto.RegisterType((*Mesh_Mtls_PermissivePort)(nil), "kuma.mesh.v1alpha1.Mesh.Mtls.PermissivePort")
+	proto.RegisterType((*CertificateAuthority)(nil), "kuma.mesh.v1alpha1.CertificateAuthority")
+	proto.RegisterType((*CertificateAuthority_Builtin)(nil), "kuma.mesh.v1alpha1.CertificateAuthority.Builtin")
+	proto.RegisterType((*CertificateAuthority_Provided)(nil), "kuma.mesh.v1alpha1.CertificateAuthority.Provided")
+	proto.RegisterType((*Tracing)(nil), "kuma.mesh.v1alpha1.Tracing")
+	proto.RegisterType((*Tracing_Zipkin)(nil), "kuma.mesh.v1alpha1.Tracing.Zipkin")
+	proto.RegisterType((*Tracing_Datadog)(nil), "kuma.mesh.v1alpha1.Tracing.Datadog")
+	proto.RegisterType((*Logging)(nil), "kuma.mesh.v1alpha1.Logging")
+	proto.RegisterType((*Logging_SplunkHec)(nil), "kuma.mesh.v1alpha1.Logging.SplunkHec")
+	proto.RegisterType((*Logging_Elasticsearch)(nil), "kuma.mesh.v1alpha1.Logging.Elasticsearch")
+	proto.RegisterType((*Logging_Backend)(nil), "kuma.mesh.v1alpha1.Logging.Backend")
+	proto.RegisterType((*Logging_AccessLogs)(nil), "kuma.mesh.v1alpha1.Logging.AccessLogs")
+	proto.RegisterType((*Networking)(nil), "kuma.mesh.v1alpha1.Networking")
+	proto.RegisterType((*Networking_Outbound)(nil), "kuma.mesh.v1alpha1.Networking.Outbound")
+	proto.RegisterType((*ConnectionPool)(nil), "kuma.mesh.v1alpha1.ConnectionPool")
+	proto.RegisterType((*ConnectionPool_Tcp)(nil), "kuma.mesh.v1alpha1.ConnectionPool.Tcp")
+	proto.RegisterType((*ConnectionPool_Http)(nil), "kuma.mesh.v1alpha1.ConnectionPool.Http")
+	proto.RegisterType((*Metrics)(nil), "kuma.mesh.v1alpha1.Metrics")
+	proto.RegisterType((*Metrics_StatsD)(nil), "kuma.mesh.v1alpha1.Metrics.StatsD")
+	proto.RegisterType((*Metrics_DogStatsD)(nil), "kuma.mesh.v1alpha1.Metrics.DogStatsD")
+	proto.RegisterType((*Metrics_Prometheus)(nil), "kuma.mesh.v1alpha1.Metrics.Prometheus")
+	proto.RegisterType((*Notifications)(nil), "kuma.mesh.v1alpha1.Notifications")
+	proto.RegisterType((*Notifications_Webhook)(nil), "kuma.mesh.v1alpha1.Notifications.Webhook")
+	proto.RegisterType((*Constraints)(nil), "kuma.mesh.v1alpha1.Constraints")
+}
+
+func init() { proto.RegisterFile("mesh/v1alpha1/mesh.proto", fileDescriptor_ae9b3cd8c92bbf6a) }
+
+var fileDescriptor_ae9b3cd8c92bbf6a = []byte{
+	// 1232 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x96, 0xdf, 0x6e, 0xdc, 0xc4,
+	0x17, 0xc7, 0xe3, 0xdd, 0xcd, 0x7a, 0xf7, 0xec, 0x2f, 0xe9, 0x4f, 0xa3, 0xaa, 0x32, 0x2e, 0x84,
+	0x76, 0x2b, 0x68, 0x90, 0x90, 0x43, 0x52, 0x40, 0x55, 0xff, 0xaa, 0x49, 0x80, 0x20, 0xa5, 0x74,
+	0x99, 0x86, 0x82, 0x7a, 0x83, 0x66, 0xed, 0xc9, 0xae, 0xb5, 0x5e, 0x8f, 0x3b, 0x33, 0xce, 0x1f,
+	0xc4, 0x05, 0x52, 0x6f, 0xe0, 0x05, 0xb8, 0xe5, 0x01, 0x78, 0x02, 0xde, 0x80, 0x4b, 0xae, 0xb9,
+	0x42, 0xbd, 0xe4, 0x12, 0x5e, 0x00, 0x79, 0x3c, 0xe3, 0xb5, 0x93, 0x5d, 0x2b, 0x70, 0xe7, 0x19,
+	0x7f, 0x3f, 0x67, 0xce, 0x39, 0x73, 0xe6, 0xcc, 0x80, 0x33, 0xa5, 0x62, 0xbc, 0x71, 0xb4, 0x49,
+	0xa2, 0x64, 0x4c, 0x36, 0x37, 0xb2, 0x91, 0x97, 0x70, 0x26, 0x19, 0x42, 0x93, 0x74, 0x4a, 0x3c,
+	0x35, 0x61, 0x7e, 0xbb, 0x6b, 0x23, 0xc6, 0x46, 0x11, 0xdd, 0x50, 0x8a, 0x61, 0x7a, 0xb8, 0x11,
+	0xa4, 0x9c, 0xc8, 0x90, 0xc5, 0x39, 0x73, 0xfe, 0xff, 0x31, 0x27, 0x49, 0x42, 0xb9, 0xc8, 0xff,
+	0xf7, 0x7f, 0x5a, 0x86, 0xd6, 0x63, 0x2a, 0xc6, 0x68, 0x13, 0x5a, 0x53, 0x19, 0x09, 0xc7, 0xba,
+	0x66, 0xad, 0xf7, 0xb6, 0xde, 0xf0, 0xce, 0xaf, 0xe5, 0x65, 0x3a, 0xef, 0xb1, 0x8c, 0x04, 0x56,
+	0x52, 0xf4, 0x01, 0xd8, 0x92, 0x13, 0x3f, 0x8c, 0x47, 0x4e, 0x43, 0x51, 0x57, 0xe7, 0x51, 0x07,
+	0xb9, 0x04, 0x1b, 0x6d, 0x86, 0x45, 0x6c, 0x34, 0xca, 0xb0, 0xe6, 0x62, 0x6c, 0x3f, 0x97, 0x60,
+	0xa3, 0x45, 0x0f, 0x00, 0x62, 0x2a, 0x8f, 0x19, 0x9f, 0x64, 0x64, 0x4b, 0x91, 0x6b, 0xf3, 0xc8,
+	0xcf, 0x0a, 0x15, 0x2e, 0x11, 0xd9, 0xb2, 0x53, 0x2a, 0x79, 0xe8, 0x0b, 0x67, 0x79, 0xf1, 0xb2,
+	0x8f, 0x73, 0x09, 0x36, 0x5a, 0xf4, 0x09, 0xac, 0xc4, 0x4c, 0x86, 0x87, 0xa1, 0xaf, 0xd2, 0x2a,
+	0x9c, 0xb6, 0x82, 0xaf, 0xcf, 0x5d, 0xb9, 0x2c, 0xc4, 0x55, 0x0e, 0x3d, 0x82, 0x9e, 0xcf, 0x62,
+	0x21, 0x39, 0x09, 0x63, 0x29, 0x1c, 0x5b, 0x99, 0x79, 0x73, 0x9e, 0x99, 0x9d, 0x99, 0x0c, 0x97,
+	0x19, 0xf7, 0x2f, 0x0b, 0x5a, 0x59, 0xfe, 0xd1, 0x6d, 0x68, 0xf8, 0x44, 0x6f, 0xd5, 0xfa, 0x5c,
+	0x13, 0x94, 0xeb, 0xb5, 0xe9, 0xa3, 0x54, 0x8e, 0x19, 0x0f, 0xe5, 0x29, 0x6e, 0xf8, 0x04, 0x39,
+	0x60, 0xd3, 0x98, 0x0c, 0x23, 0x1a, 0xa8, 0x3d, 0xeb, 0x60, 0x33, 0x44, 0xcf, 0xe0, 0x52, 0x42,
+	0xf9, 0x34, 0x14, 0x22, 0x3c, 0xa2, 0x03, 0xc6, 0xa5, 0x70, 0x9a, 0xd7, 0x9a, 0xeb, 0xbd, 0xad,
+	0x77, 0x6b, 0x6b, 0xc1, 0x1b, 0x54, 0x20, 0x7c, 0xd6, 0x88, 0xfb, 0x00, 0x56, 0xab, 0x92, 0xcc,
+	0x07, 0x41, 0xf9, 0x51, 0xe8, 0x53, 0x15, 0x42, 0x17, 0x9b, 0x21, 0x42, 0xd0, 0x4a, 0x18, 0x97,
+	0xca, 0xb5, 0x15, 0xac, 0xbe, 0xfb, 0xdf, 0x35, 0xe0, 0xf2, 0xbc, 0x70, 0xd0, 0x3e, 0xd8, 0xc3,
+	0x34, 0x8c, 0x64, 0x18, 0xeb, 0x4c, 0xbc, 0x77, 0xd1, 0x4c, 0x78, 0xdb, 0x39, 0xb7, 0xb7, 0x84,
+	0x8d, 0x09, 0xf4, 0x04, 0x3a, 0x09, 0x67, 0x47, 0x61, 0xa0, 0x33, 0xd3, 0xdb, 0xda, 0xbc, 0xb0,
+	0xb9, 0x81, 0x06, 0xf7, 0x96, 0x70, 0x61, 0xc4, 0xed, 0x82, 0xad, 0x97, 0x71, 0x3f, 0x84, 0x8e,
+	0x91, 0x64, 0x21, 0xc6, 0x64, 0x6a, 0x22, 0x57, 0xdf, 0xe8, 0x0a, 0xb4, 0x05, 0xf5, 0x39, 0xcd,
+	0x03, 0xef, 0x62, 0x3d, 0xda, 0x6e, 0x43, 0x4b, 0x9e, 0x26, 0xb4, 0xff, 0xb7, 0x05, 0xb6, 0x3e,
+	0x46, 0xe8, 0x1e, 0xb4, 0xbf, 0x09, 0x93, 0x49, 0x11, 0x74, 0xbf, 0xe6, 0xcc, 0x79, 0xcf, 0x95,
+	0x72, 0x6f, 0x09, 0x6b, 0x06, 0x3d, 0x04, 0x3b, 0x20, 0x92, 0x04, 0xcc, 0x1c, 0xd9, 0x1b, 0x75,
+	0xf8, 0x6e, 0x2e, 0xcd, 0xd2, 0xa4, 0x29, 0xb7, 0x0f, 0xed, 0xdc, 0x68, 0xb6, 0x8b, 0x24, 0x08,
+	0x38, 0x15, 0xc2, 0xec, 0xa2, 0x1e, 0xba, 0xf7, 0xc1, 0xd6, 0xe4, 0x62, 0x51, 0xb9, 0x08, 0x1a,
+	0x95, 0x22, 0x28, 0xa2, 0xfe, 0x61, 0x19, 0x6c, 0xdd, 0x05, 0xd0, 0xc7, 0x00, 0xc4, 0xf7, 0xa9,
+	0x10, 0xfb, 0x6c, 0x64, 0x7a, 0xd4, 0xdb, 0x35, 0x6d, 0xc3, 0x7b, 0x54, 0xa8, 0x71, 0x89, 0x74,
+	0x8f, 0xa0, 0xfb, 0x34, 0x89, 0xd2, 0x78, 0xb2, 0x47, 0x7d, 0xf4, 0x7f, 0x68, 0xa6, 0x3c, 0xd2,
+	0x8e, 0x65, 0x9f, 0xe8, 0x3a, 0xfc, 0x4f, 0xb2, 0x09, 0x8d, 0xbf, 0xae, 0x6c, 0x47, 0x4f, 0xcd,
+	0x3d, 0x55, 0x53, 0xe8, 0x32, 0x2c, 0x87, 0x71, 0x40, 0x4f, 0x54, 0xef, 0xea, 0xe2, 0x7c, 0x80,
+	0xd6, 0x00, 0x04, 0x4b, 0xb9, 0x4f, 0x33, 0xcf, 0x55, 0x73, 0xea, 0xe2, 0xd2, 0x8c, 0xfb, 0x2d,
+	0xac, 0x7c, 0x14, 0x11, 0x21, 0x43, 0x5f, 0x50, 0xc2, 0xfd, 0xf1, 0x9c, 0xb5, 0x0b, 0xc3, 0x8d,
+	0xb2, 0x61, 0x17, 0x3a, 0xa9, 0xa0, 0x5c, 0x95, 0x4c, 0xbe, 0x62, 0x31, 0x46, 0x37, 0xe1, 0x52,
+	0x42, 0x84, 0x38, 0x66, 0x3c, 0x30, 0x0e, 0xe7, 0x2b, 0xaf, 0x9a, 0xe9, 0xdc, 0x67, 0xf7, 0x17,
+	0x0b, 0xec, 0x6d, 0xe2, 0x4f, 0x68, 0x3c, 0xbf, 0xfe, 0x1e, 0x42, 0x5b, 0xa8, 0xac, 0xe8, 0xa2,
+	0x78, 0xab, 0x2e, 0xb3, 0x45, 0xfe, 0xb2, 0xb2, 0xca, 0x31, 0xf4, 0x39, 0xac, 0xd0, 0x72, 0x78,
+	0xba, 0xb1, 0xbf, 0x53, 0x67, 0xa7, 0x92, 0x8f, 0xbd, 0x25, 0x5c, 0xb5, 0x60, 0xaa, 0xc0, 0x7d,
+	0x69, 0x01, 0xcc, 0x36, 0xb3, 0xdc, 0xbf, 0xac, 0x6a, 0xff, 0x72, 0xa1, 0x73, 0x18, 0x46, 0x74,
+	0x40, 0xe4, 0x58, 0xa7, 0xb0, 0x18, 0xa3, 0x87, 0xd0, 0x19, 0xe6, 0xf1, 0x9b, 0xa6, 0x76, 0xa3,
+	0xce, 0x35, 0x9d, 0x2b, 0x5c, 0x40, 0xfd, 0x3f, 0x2d, 0x80, 0xd9, 0xbd, 0x82, 0x76, 0xa0, 0xc3,
+	0x52, 0x39, 0x64, 0x69, 0x1c, 0xe8, 0x62, 0xbc, 0x59, 0x7f, 0x13, 0x79, 0x4f, 0xb4, 0x1c, 0x17,
+	0xa0, 0xfb, 0xa3, 0x05, 0x1d, 0x33, 0x8d, 0xee, 0x41, 0x2f, 0xdb, 0x34, 0x39, 0xe6, 0x2c, 0x1d,
+	0x8d, 0xb5, 0x51, 0xd7, 0xcb, 0x6f, 0x6f, 0xcf, 0xdc, 0xde, 0xde, 0x36, 0x63, 0xd1, 0x33, 0x12,
+	0xa5, 0x14, 0x97, 0xe5, 0x68, 0x1f, 0x2e, 0xf9, 0x2c, 0x8e, 0xa9, 0x9f, 0x5d, 0x35, 0x03, 0xc6,
+	0x22, 0xe1, 0x34, 0xae, 0x35, 0x17, 0x75, 0x87, 0x9d, 0x8a, 0x14, 0x9f, 0x45, 0xfb, 0xbf, 0xb7,
+	0x60, 0xb5, 0xaa, 0xa9, 0x69, 0xd9, 0xb7, 0xa1, 0x29, 0xfd, 0xc4, 0x69, 0x2c, 0x3e, 0x92, 0x55,
+	0x53, 0xde, 0x81, 0x9f, 0xe0, 0x0c, 0x41, 0x77, 0xa1, 0x35, 0x96, 0x32, 0x71, 0x9a, 0x8b, 0x13,
+	0x78, 0x06, 0xdd, 0x93, 0x32, 0xc1, 0x0a, 0x72, 0xbf, 0xb7, 0xa0, 0x79, 0xe0, 0x27, 0x68, 0x17,
+	0x56, 0xa7, 0xe4, 0x64, 0xa6, 0x33, 0xcd, 0xe1, 0xf5, 0x73, 0xa9, 0xfb, 0xe2, 0xd3, 0x58, 0xde,
+	0xda, 0xca, 0x93, 0x77, 0x86, 0x41, 0x77, 0xa1, 0x17, 0x06, 0x11, 0x3d, 0x08, 0xa7, 0x94, 0xa5,
+	0x52, 0x07, 0xf3, 0xda, 0x39, 0x13, 0xbb, 0xfa, 0x6d, 0x85, 0xcb, 0x6a, 0xf7, 0xe7, 0x06, 0xb4,
+	0x32, 0xcf, 0xd0, 0x3e, 0xa0, 0x29, 0x39, 0x19, 0xd0, 0x38, 0xc8, 0xde, 0x1e, 0xf4, 0x45, 0x4a,
+	0x85, 0xbc, 0x98, 0x3f, 0x73, 0x38, 0xf4, 0x00, 0x7a, 0x53, 0x72, 0x52, 0x98, 0x69, 0x5c, 0xc0,
+	0x4c, 0x19, 0x40, 0x5f, 0x81, 0x53, 0x1a, 0x0e, 0x28, 0x9f, 0x05, 0xec, 0x34, 0x2f, 0x60, 0x6c,
+	0x21, 0x7d, 0x36, 0x5b, 0xad, 0x7f, 0x93, 0xad, 0xfe, 0xcb, 0x26, 0xd8, 0xfa, 0x91, 0x85, 0xee,
+	0x40, 0x5b, 0x48, 0x22, 0x45, 0x50, 0x77, 0x97, 0x69, 0xb1, 0xf7, 0x34, 0x53, 0xee, 0x62, 0x4d,
+	0xa0, 0x1d, 0xe8, 0x06, 0x6c, 0xa4, 0xf1, 0x9a, 0xb6, 0x65, 0xf0, 0x5d, 0x36, 0xd2, 0x16, 0x66,
+	0x5c, 0x76, 0xad, 0x24, 0x9c, 0x4d, 0xa9, 0x1c, 0xd3, 0x54, 0x38, 0xcd, 0xc5, 0x35, 0x6c, 0xac,
+	0x0c, 0x0a, 0x35, 0x2e, 0x91, 0xee, 0x1d, 0x68, 0xe7, 0xc6, 0x6b, 0x2e, 0xbc, 0x2b, 0xd0, 0x4e,
+	0x38, 0x3d, 0x0c, 0x4d, 0x83, 0xd7, 0x23, 0xf7, 0x3e, 0x74, 0x0b, 0xdf, 0xfe, 0x03, 0xfe, 0x3e,
+	0xc0, 0xcc, 0xa9, 0xe2, 0x01, 0x65, 0xcd, 0x1e, 0x50, 0x6a, 0x6e, 0xd6, 0x14, 0xd5, 0x77, 0xff,
+	0x05, 0xac, 0x54, 0x1e, 0xab, 0x68, 0x07, 0xec, 0x63, 0x3a, 0x1c, 0x33, 0x36, 0x71, 0xac, 0xc5,
+	0xbd, 0xbb, 0xc2, 0x78, 0x5f, 0xe6, 0x00, 0x36, 0xa4, 0x7b, 0x15, 0x6c, 0x3d, 0x77, 0xfe, 0x7e,
+	0xeb, 0xdf, 0x87, 0x5e, 0xe9, 0x61, 0x8b, 0x3c, 0x40, 0x24, 0x8a, 0xd8, 0x31, 0x0d, 0x06, 0x2c,
+	0x0a, 0xfd, 0xd3, 0x83, 0xd3, 0x84, 0x66, 0x41, 0x37, 0xd7, 0xbb, 0x78, 0xce, 0x9f, 0xed, 0x2b,
+	0xbf, 0xbe, 0x5a, 0xb3, 0x7e, 0x7b, 0xb5, 0x66, 0xfd, 0xf1, 0x6a, 0xcd, 0x7a, 0xde, 0x31, 0x2e,
+	0x0d, 0xdb, 0xaa, 0xde, 0x6e, 0xfd, 0x33, 0x00, 0x4e, 0x92, 0x70, 0xc1, 0x37, 0x0d, 0x00, 0x00,
+}
+
+func (m *Mesh) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Mesh) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Mtls != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(m.Mtls.Size()))
+		n1, err := m.Mtls.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n1
+	}
+	if m.Tracing != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(m.Tracing.Size()))
+		n2, err := m.Tracing.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n2
+	}
+	if m.Logging != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(m.Logging.Size()))
+		n3, err := m.Logging.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n3
+	}
+	if m.Networking != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(m.Networking.Size()))
+		n4, err := m.Networking.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n4
+	}
+	if m.Metrics != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(m.Metrics.Size()))
+		n5, err := m.Metrics.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n5
+	}
+	if m.Notifications != nil {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(m.Notifications.Size()))
+		n6, err := m.Notifications.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n6
+	}
+	if m.Constraints != nil {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(m.Constraints.Size()))
+		n7, err := m.Constraints.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n7
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Mesh_Mtls) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Mesh_Mtls) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Ca != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(m.Ca.Size()))
+		n8, err := m.Ca.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n8
+	}
+	if m.Enabled {
+		dAtA[i] = 0x10
+		i++
+		if m.Enabled {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.PermissivePorts) > 0 {
+		for _, msg := range m.PermissivePorts {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintMesh(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Mesh_Mtls_PermissivePort) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Mesh_Mtls_PermissivePort) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Service) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(len(m.Service)))
+		i += copy(dAtA[i:], m.Service)
+	}
+	if m.Port != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(m.Port))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *CertificateAuthority) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CertificateAuthority) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Type != nil {
+		nn9, err := m.Type.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += nn9
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *CertificateAuthority_Builtin_) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.Builtin != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(m.Builtin.Size()))
+		n10, err := m.Builtin.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n10
+	}
+	return i, nil
+}
+func (m *CertificateAuthority_Provided_) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.Provided != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(m.Provided.Size()))
+		n11, err := m.Provided.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n11
+	}
+	return i, nil
+}
+func (m *CertificateAuthority_Builtin) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CertificateAuthority_Builtin) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *CertificateAuthority_Provided) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CertificateAuthority_Provided) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Name) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if len(m.Secret) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(len(m.Secret)))
+		i += copy(dAtA[i:], m.Secret)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Tracing) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Tracing) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Type != nil {
+		nn12, err := m.Type.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += nn12
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Tracing_Zipkin_) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.Zipkin != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(m.Zipkin.Size()))
+		n13, err := m.Zipkin.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n13
+	}
+	return i, nil
+}
+func (m *Tracing_Datadog_) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.Datadog != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(m.Datadog.Size()))
+		n14, err := m.Datadog.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n14
+	}
+	return i, nil
+}
+func (m *Tracing_Zipkin) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Tracing_Zipkin) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Address) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(len(m.Address)))
+		i += copy(dAtA[i:], m.Address)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Tracing_Datadog) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Tracing_Datadog) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Address) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(len(m.Address)))
+		i += copy(dAtA[i:], m.Address)
+	}
+	if len(m.Service) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(len(m.Service)))
+		i += copy(dAtA[i:], m.Service)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Logging) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Logging) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.AccessLogs != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(m.AccessLogs.Size()))
+		n15, err := m.AccessLogs.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n15
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Logging_SplunkHec) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Logging_SplunkHec) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Url) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(len(m.Url)))
+		i += copy(dAtA[i:], m.Url)
+	}
+	if len(m.TokenSecret) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(len(m.TokenSecret)))
+		i += copy(dAtA[i:], m.TokenSecret)
+	}
+	if len(m.Index) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(len(m.Index)))
+		i += copy(dAtA[i:], m.Index)
+	}
+	if len(m.Sourcetype) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(len(m.Sourcetype)))
+		i += copy(dAtA[i:], m.Sourcetype)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Logging_Elasticsearch) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Logging_Elasticsearch) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Url) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(len(m.Url)))
+		i += copy(dAtA[i:], m.Url)
+	}
+	if len(m.Index) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(len(m.Index)))
+		i += copy(dAtA[i:], m.Index)
+	}
+	if len(m.Username) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(len(m.Username)))
+		i += copy(dAtA[i:], m.Username)
+	}
+	if len(m.PasswordSecret) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(len(m.PasswordSecret)))
+		i += copy(dAtA[i:], m.PasswordSecret)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Logging_Backend) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Logging_Backend) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Name) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if m.Type != nil {
+		nn16, err := m.Type.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += nn16
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Logging_Backend_Splunk) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.Splunk != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(m.Splunk.Size()))
+		n17, err := m.Splunk.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n17
+	}
+	return i, nil
+}
+func (m *Logging_Backend_Elasticsearch) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.Elasticsearch != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(m.Elasticsearch.Size()))
+		n18, err := m.Elasticsearch.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n18
+	}
+	return i, nil
+}
+func (m *Logging_AccessLogs) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Logging_AccessLogs) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Enabled {
+		dAtA[i] = 0x8
+		i++
+		if m.Enabled {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.FilePath) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(len(m.FilePath)))
+		i += copy(dAtA[i:], m.FilePath)
+	}
+	if len(m.Backends) > 0 {
+		for _, msg := range m.Backends {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintMesh(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Networking) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Networking) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Outbound != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(m.Outbound.Size()))
+		n19, err := m.Outbound.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n19
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Networking_Outbound) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Networking_Outbound) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Passthrough != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(m.Passthrough.Size()))
+		n20, err := m.Passthrough.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n20
+	}
+	if len(m.ConnectionPools) > 0 {
+		for _, msg := range m.ConnectionPools {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintMesh(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ConnectionPool) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConnectionPool) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Service) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(len(m.Service)))
+		i += copy(dAtA[i:], m.Service)
+	}
+	if m.Tcp != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(m.Tcp.Size()))
+		n21, err := m.Tcp.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n21
+	}
+	if m.Http != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(m.Http.Size()))
+		n22, err := m.Http.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n22
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ConnectionPool_Tcp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConnectionPool_Tcp) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.MaxConnections != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(m.MaxConnections.Size()))
+		n23, err := m.MaxConnections.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n23
+	}
+	if m.IdleTimeout != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(m.IdleTimeout.Size()))
+		n24, err := m.IdleTimeout.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n24
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ConnectionPool_Http) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConnectionPool_Http) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.MaxPendingRequests != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(m.MaxPendingRequests.Size()))
+		n25, err := m.MaxPendingRequests.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n25
+	}
+	if m.MaxRequests != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(m.MaxRequests.Size()))
+		n26, err := m.MaxRequests.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n26
+	}
+	if m.MaxRequestsPerConnection != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(m.MaxRequestsPerConnection.Size()))
+		n27, err := m.MaxRequestsPerConnection.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n27
+	}
+	if m.IdleTimeout != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(m.IdleTimeout.Size()))
+		n28, err := m.IdleTimeout.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n28
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Metrics) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Metrics) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Statsd != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(m.Statsd.Size()))
+		n29, err := m.Statsd.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n29
+	}
+	if m.Dogstatsd != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(m.Dogstatsd.Size()))
+		n30, err := m.Dogstatsd.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n30
+	}
+	if m.Prometheus != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(m.Prometheus.Size()))
+		n31, err := m.Prometheus.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n31
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Metrics_StatsD) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Metrics_StatsD) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Address) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(len(m.Address)))
+		i += copy(dAtA[i:], m.Address)
+	}
+	if len(m.Prefix) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(len(m.Prefix)))
+		i += copy(dAtA[i:], m.Prefix)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Metrics_DogStatsD) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Metrics_DogStatsD) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Address) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(len(m.Address)))
+		i += copy(dAtA[i:], m.Address)
+	}
+	if len(m.Prefix) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(len(m.Prefix)))
+		i += copy(dAtA[i:], m.Prefix)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Metrics_Prometheus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Metrics_Prometheus) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Port != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(m.Port))
+	}
+	if len(m.Path) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(len(m.Path)))
+		i += copy(dAtA[i:], m.Path)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Notifications) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Notifications) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Webhook != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(m.Webhook.Size()))
+		n32, err := m.Webhook.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n32
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Notifications_Webhook) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Notifications_Webhook) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Url) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintMesh(dAtA, i, uint64(len(m.Url)))
+		i += copy(dAtA[i:], m.Url)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Constraints) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Constraints) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.AllowedPolicyTypes) > 0 {
+		for _, s := range m.AllowedPolicyTypes {
+			dAtA[i] = 0xa
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func encodeVarintMesh(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *Mesh) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Mtls != nil {
+		l = m.Mtls.Size()
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	if m.Tracing != nil {
+		l = m.Tracing.Size()
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	if m.Logging != nil {
+		l = m.Logging.Size()
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	if m.Networking != nil {
+		l = m.Networking.Size()
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	if m.Metrics != nil {
+		l = m.Metrics.Size()
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	if m.Notifications != nil {
+		l = m.Notifications.Size()
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	if m.Constraints != nil {
+		l = m.Constraints.Size()
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Mesh_Mtls) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Ca != nil {
+		l = m.Ca.Size()
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	if m.Enabled {
+		n += 2
+	}
+	if len(m.PermissivePorts) > 0 {
+		for _, e := range m.PermissivePorts {
+			l = e.Size()
+			n += 1 + l + sovMesh(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Mesh_Mtls_PermissivePort) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Service)
+	if l > 0 {
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	if m.Port != 0 {
+		n += 1 + sovMesh(uint64(m.Port))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CertificateAuthority) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != nil {
+		n += m.Type.Size()
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CertificateAuthority_Builtin_) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Builtin != nil {
+		l = m.Builtin.Size()
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	return n
+}
+func (m *CertificateAuthority_Provided_) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Provided != nil {
+		l = m.Provided.Size()
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	return n
+}
+func (m *CertificateAuthority_Builtin) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CertificateAuthority_Provided) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	l = len(m.Secret)
+	if l > 0 {
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Tracing) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != nil {
+		n += m.Type.Size()
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Tracing_Zipkin_) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Zipkin != nil {
+		l = m.Zipkin.Size()
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	return n
+}
+func (m *Tracing_Datadog_) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Datadog != nil {
+		l = m.Datadog.Size()
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	return n
+}
+func (m *Tracing_Zipkin) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Tracing_Datadog) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	l = len(m.Service)
+	if l > 0 {
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Logging) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.AccessLogs != nil {
+		l = m.AccessLogs.Size()
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Logging_SplunkHec) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Url)
+	if l > 0 {
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	l = len(m.TokenSecret)
+	if l > 0 {
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	l = len(m.Index)
+	if l > 0 {
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	l = len(m.Sourcetype)
+	if l > 0 {
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Logging_Elasticsearch) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Url)
+	if l > 0 {
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	l = len(m.Index)
+	if l > 0 {
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	l = len(m.Username)
+	if l > 0 {
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	l = len(m.PasswordSecret)
+	if l > 0 {
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Logging_Backend) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	if m.Type != nil {
+		n += m.Type.Size()
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Logging_Backend_Splunk) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Splunk != nil {
+		l = m.Splunk.Size()
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	return n
+}
+func (m *Logging_Backend_Elasticsearch) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Elasticsearch != nil {
+		l = m.Elasticsearch.Size()
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	return n
+}
+func (m *Logging_AccessLogs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Enabled {
+		n += 2
+	}
+	l = len(m.FilePath)
+	if l > 0 {
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	if len(m.Backends) > 0 {
+		for _, e := range m.Backends {
+			l = e.Size()
+			n += 1 + l + sovMesh(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Networking) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Outbound != nil {
+		l = m.Outbound.Size()
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Networking_Outbound) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Passthrough != nil {
+		l = m.Passthrough.Size()
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	if len(m.ConnectionPools) > 0 {
+		for _, e := range m.ConnectionPools {
+			l = e.Size()
+			n += 1 + l + sovMesh(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ConnectionPool) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Service)
+	if l > 0 {
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	if m.Tcp != nil {
+		l = m.Tcp.Size()
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	if m.Http != nil {
+		l = m.Http.Size()
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ConnectionPool_Tcp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MaxConnections != nil {
+		l = m.MaxConnections.Size()
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	if m.IdleTimeout != nil {
+		l = m.IdleTimeout.Size()
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ConnectionPool_Http) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MaxPendingRequests != nil {
+		l = m.MaxPendingRequests.Size()
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	if m.MaxRequests != nil {
+		l = m.MaxRequests.Size()
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	if m.MaxRequestsPerConnection != nil {
+		l = m.MaxRequestsPerConnection.Size()
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	if m.IdleTimeout != nil {
+		l = m.IdleTimeout.Size()
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Metrics) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Statsd != nil {
+		l = m.Statsd.Size()
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	if m.Dogstatsd != nil {
+		l = m.Dogstatsd.Size()
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	if m.Prometheus != nil {
+		l = m.Prometheus.Size()
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Metrics_StatsD) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	l = len(m.Prefix)
+	if l > 0 {
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Metrics_DogStatsD) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	l = len(m.Prefix)
+	if l > 0 {
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Metrics_Prometheus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Port != 0 {
+		n += 1 + sovMesh(uint64(m.Port))
+	}
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Notifications) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Webhook != nil {
+		l = m.Webhook.Size()
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Notifications_Webhook) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Url)
+	if l > 0 {
+		n += 1 + l + sovMesh(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Constraints) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.AllowedPolicyTypes) > 0 {
+		for _, s := range m.AllowedPolicyTypes {
+			l = len(s)
+			n += 1 + l + sovMesh(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovMesh(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozMesh(x uint64) (n int) {
+	return sovMesh(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Mesh) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMesh
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Mesh: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Mesh: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Mtls", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Mtls == nil {
+				m.Mtls = &Mesh_Mtls{}
+			}
+			if err := m.Mtls.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tracing", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Tracing == nil {
+				m.Tracing = &Tracing{}
+			}
+			if err := m.Tracing.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Logging", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Logging == nil {
+				m.Logging = &Logging{}
+			}
+			if err := m.Logging.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Networking", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Networking == nil {
+				m.Networking = &Networking{}
+			}
+			if err := m.Networking.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metrics", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Metrics == nil {
+				m.Metrics = &Metrics{}
+			}
+			if err := m.Metrics.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Notifications", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Notifications == nil {
+				m.Notifications = &Notifications{}
+			}
+			if err := m.Notifications.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Constraints", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Constraints == nil {
+				m.Constraints = &Constraints{}
+			}
+			if err := m.Constraints.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMesh(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Mesh_Mtls) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMesh
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Mtls: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Mtls: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ca", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Ca == nil {
+				m.Ca = &CertificateAuthority{}
+			}
+			if err := m.Ca.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Enabled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Enabled = bool(v != 0)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PermissivePorts", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PermissivePorts = append(m.PermissivePorts, &Mesh_Mtls_PermissivePort{})
+			if err := m.PermissivePorts[len(m.PermissivePorts)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMesh(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Mesh_Mtls_PermissivePort) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMesh
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PermissivePort: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PermissivePort: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Service", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Service = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Port", wireType)
+			}
+			m.Port = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Port |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMesh(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CertificateAuthority) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMesh
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CertificateAuthority: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CertificateAuthority: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Builtin", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &CertificateAuthority_Builtin{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Type = &CertificateAuthority_Builtin_{v}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Provided", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &CertificateAuthority_Provided{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Type = &CertificateAuthority_Provided_{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMesh(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CertificateAuthority_Builtin) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMesh
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Builtin: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Builtin: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMesh(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CertificateAuthority_Provided) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMesh
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Provided: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Provided: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Secret", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Secret = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMesh(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Tracing) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMesh
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Tracing: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Tracing: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Zipkin", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &Tracing_Zipkin{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Type = &Tracing_Zipkin_{v}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Datadog", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &Tracing_Datadog{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Type = &Tracing_Datadog_{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMesh(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Tracing_Zipkin) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMesh
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Zipkin: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Zipkin: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Address = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMesh(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Tracing_Datadog) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMesh
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Datadog: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Datadog: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Address = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Service", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Service = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMesh(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Logging) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMesh
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Logging: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Logging: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AccessLogs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.AccessLogs == nil {
+				m.AccessLogs = &Logging_AccessLogs{}
+			}
+			if err := m.AccessLogs.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMesh(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Logging_SplunkHec) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMesh
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SplunkHec: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SplunkHec: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Url", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Url = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenSecret", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TokenSecret = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Index = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sourcetype", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sourcetype = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMesh(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Logging_Elasticsearch) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMesh
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Elasticsearch: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Elasticsearch: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Url", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Url = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Index = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Username", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Username = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PasswordSecret", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PasswordSecret = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMesh(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Logging_Backend) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMesh
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Backend: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Backend: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Splunk", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &Logging_SplunkHec{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Type = &Logging_Backend_Splunk{v}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Elasticsearch", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &Logging_Elasticsearch{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Type = &Logging_Backend_Elasticsearch{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMesh(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Logging_AccessLogs) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMesh
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AccessLogs: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AccessLogs: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Enabled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Enabled = bool(v != 0)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FilePath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FilePath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Backends", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Backends = append(m.Backends, &Logging_Backend{})
+			if err := m.Backends[len(m.Backends)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMesh(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Networking) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMesh
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Networking: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Networking: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Outbound", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Outbound == nil {
+				m.Outbound = &Networking_Outbound{}
+			}
+			if err := m.Outbound.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMesh(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Networking_Outbound) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMesh
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Outbound: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Outbound: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Passthrough", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Passthrough == nil {
+				m.Passthrough = &types.BoolValue{}
+			}
+			if err := m.Passthrough.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConnectionPools", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ConnectionPools = append(m.ConnectionPools, &ConnectionPool{})
+			if err := m.ConnectionPools[len(m.ConnectionPools)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMesh(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConnectionPool) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMesh
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConnectionPool: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConnectionPool: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Service", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Service = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tcp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Tcp == nil {
+				m.Tcp = &ConnectionPool_Tcp{}
+			}
+			if err := m.Tcp.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Http", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Http == nil {
+				m.Http = &ConnectionPool_Http{}
+			}
+			if err := m.Http.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMesh(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConnectionPool_Tcp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMesh
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Tcp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Tcp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxConnections", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.MaxConnections == nil {
+				m.MaxConnections = &types.UInt32Value{}
+			}
+			if err := m.MaxConnections.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IdleTimeout", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.IdleTimeout == nil {
+				m.IdleTimeout = &types.Duration{}
+			}
+			if err := m.IdleTimeout.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMesh(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConnectionPool_Http) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMesh
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Http: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Http: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxPendingRequests", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.MaxPendingRequests == nil {
+				m.MaxPendingRequests = &types.UInt32Value{}
+			}
+			if err := m.MaxPendingRequests.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxRequests", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.MaxRequests == nil {
+				m.MaxRequests = &types.UInt32Value{}
+			}
+			if err := m.MaxRequests.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxRequestsPerConnection", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.MaxRequestsPerConnection == nil {
+				m.MaxRequestsPerConnection = &types.UInt32Value{}
+			}
+			if err := m.MaxRequestsPerConnection.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IdleTimeout", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.IdleTimeout == nil {
+				m.IdleTimeout = &types.Duration{}
+			}
+			if err := m.IdleTimeout.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMesh(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
 		}
 	}
-	return n
-}
-func sozMesh(x uint64) (n int) {
-	return sovMesh(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
-func (m *Mesh) Unmarshal(dAtA []byte) error {
+func (m *Metrics) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1126,15 +6465,15 @@ func (m *Mesh) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Mesh: wiretype end group for non-group")
+			return fmt.Errorf("proto: Metrics: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Mesh: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Metrics: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Mtls", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Statsd", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1161,16 +6500,16 @@ func (m *Mesh) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Mtls == nil {
-				m.Mtls = &Mesh_Mtls{}
+			if m.Statsd == nil {
+				m.Statsd = &Metrics_StatsD{}
 			}
-			if err := m.Mtls.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Statsd.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Tracing", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Dogstatsd", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1197,16 +6536,16 @@ func (m *Mesh) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Tracing == nil {
-				m.Tracing = &Tracing{}
+			if m.Dogstatsd == nil {
+				m.Dogstatsd = &Metrics_DogStatsD{}
 			}
-			if err := m.Tracing.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Dogstatsd.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Logging", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Prometheus", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1233,10 +6572,10 @@ func (m *Mesh) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Logging == nil {
-				m.Logging = &Logging{}
+			if m.Prometheus == nil {
+				m.Prometheus = &Metrics_Prometheus{}
 			}
-			if err := m.Logging.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Prometheus.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -1265,7 +6604,7 @@ func (m *Mesh) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Mesh_Mtls) Unmarshal(dAtA []byte) error {
+func (m *Metrics_StatsD) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1288,17 +6627,17 @@ func (m *Mesh_Mtls) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Mtls: wiretype end group for non-group")
+			return fmt.Errorf("proto: StatsD: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Mtls: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: StatsD: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Ca", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMesh
@@ -1308,33 +6647,29 @@ func (m *Mesh_Mtls) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthMesh
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthMesh
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Ca == nil {
-				m.Ca = &CertificateAuthority{}
-			}
-			if err := m.Ca.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Address = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Enabled", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Prefix", wireType)
 			}
-			var v int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMesh
@@ -1344,12 +6679,24 @@ func (m *Mesh_Mtls) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Enabled = bool(v != 0)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMesh
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Prefix = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMesh(dAtA[iNdEx:])
@@ -1375,7 +6722,7 @@ func (m *Mesh_Mtls) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CertificateAuthority) Unmarshal(dAtA []byte) error {
+func (m *Metrics_DogStatsD) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1398,17 +6745,17 @@ func (m *CertificateAuthority) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CertificateAuthority: wiretype end group for non-group")
+			return fmt.Errorf("proto: DogStatsD: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CertificateAuthority: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DogStatsD: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Builtin", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMesh
@@ -1418,81 +6765,56 @@ func (m *CertificateAuthority) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthMesh
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthMesh
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &CertificateAuthority_Builtin{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Type = &CertificateAuthority_Builtin_{v}
+			m.Address = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipMesh(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Prefix", wireType)
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthMesh
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if (iNdEx + skippy) < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthMesh
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *CertificateAuthority_Builtin) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowMesh
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMesh
 			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Builtin: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Builtin: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
+			m.Prefix = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMesh(dAtA[iNdEx:])
@@ -1518,7 +6840,7 @@ func (m *CertificateAuthority_Builtin) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Tracing) Unmarshal(dAtA []byte) error {
+func (m *Metrics_Prometheus) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1541,17 +6863,36 @@ func (m *Tracing) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Tracing: wiretype end group for non-group")
+			return fmt.Errorf("proto: Prometheus: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Tracing: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Prometheus: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Port", wireType)
+			}
+			m.Port = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMesh
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Port |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Zipkin", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMesh
@@ -1561,26 +6902,23 @@ func (m *Tracing) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthMesh
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthMesh
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &Tracing_Zipkin{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Type = &Tracing_Zipkin_{v}
+			m.Path = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -1607,7 +6945,7 @@ func (m *Tracing) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Tracing_Zipkin) Unmarshal(dAtA []byte) error {
+func (m *Notifications) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1630,17 +6968,17 @@ func (m *Tracing_Zipkin) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Zipkin: wiretype end group for non-group")
+			return fmt.Errorf("proto: Notifications: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Zipkin: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Notifications: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Webhook", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMesh
@@ -1650,23 +6988,27 @@ func (m *Tracing_Zipkin) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthMesh
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthMesh
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Address = string(dAtA[iNdEx:postIndex])
+			if m.Webhook == nil {
+				m.Webhook = &Notifications_Webhook{}
+			}
+			if err := m.Webhook.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -1693,7 +7035,7 @@ func (m *Tracing_Zipkin) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Logging) Unmarshal(dAtA []byte) error {
+func (m *Notifications_Webhook) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1716,17 +7058,17 @@ func (m *Logging) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Logging: wiretype end group for non-group")
+			return fmt.Errorf("proto: Webhook: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Logging: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Webhook: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AccessLogs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Url", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMesh
@@ -1736,27 +7078,23 @@ func (m *Logging) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthMesh
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthMesh
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.AccessLogs == nil {
-				m.AccessLogs = &Logging_AccessLogs{}
-			}
-			if err := m.AccessLogs.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Url = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -1783,7 +7121,7 @@ func (m *Logging) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Logging_AccessLogs) Unmarshal(dAtA []byte) error {
+func (m *Constraints) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1806,35 +7144,15 @@ func (m *Logging_AccessLogs) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AccessLogs: wiretype end group for non-group")
+			return fmt.Errorf("proto: Constraints: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AccessLogs: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Constraints: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Enabled", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowMesh
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.Enabled = bool(v != 0)
-		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field FilePath", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AllowedPolicyTypes", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1862,7 +7180,7 @@ func (m *Logging_AccessLogs) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.FilePath = string(dAtA[iNdEx:postIndex])
+			m.AllowedPolicyTypes = append(m.AllowedPolicyTypes, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
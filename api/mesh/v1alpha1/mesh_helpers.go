@@ -0,0 +1,42 @@
+package v1alpha1
+
+const (
+	DefaultPrometheusPort = 5670
+	DefaultPrometheusPath = "/metrics"
+)
+
+// GetPortOrDefault returns the port on which Dataplanes expose metrics to Prometheus.
+func (p *Metrics_Prometheus) GetPortOrDefault() uint32 {
+	if port := p.GetPort(); port != 0 {
+		return port
+	}
+	return DefaultPrometheusPort
+}
+
+// GetPathOrDefault returns the path on which Dataplanes expose metrics to Prometheus.
+func (p *Metrics_Prometheus) GetPathOrDefault() string {
+	if path := p.GetPath(); path != "" {
+		return path
+	}
+	return DefaultPrometheusPath
+}
+
+// IsPermissive returns true if a given port of a given service accepts plaintext connections in addition to mTLS ones.
+func (m *Mesh_Mtls) IsPermissive(service string, port uint32) bool {
+	for _, permissive := range m.GetPermissivePorts() {
+		if permissive.GetService() == service && permissive.GetPort() == port {
+			return true
+		}
+	}
+	return false
+}
+
+// GetConnectionPoolFor returns limits of connections and requests to a given service or nil if there are none.
+func (o *Networking_Outbound) GetConnectionPoolFor(service string) *ConnectionPool {
+	for _, pool := range o.GetConnectionPools() {
+		if pool.GetService() == service {
+			return pool
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package v1alpha1_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/gogo/protobuf/types"
+
+	. "github.com/Kong/kuma/api/mesh/v1alpha1"
+)
+
+var _ = Describe("MeshHelpers", func() {
+
+	Describe("Metrics_Prometheus", func() {
+
+		It("should fall back to defaults", func() {
+			// given
+			prometheus := &Metrics_Prometheus{}
+
+			// expect
+			Expect(prometheus.GetPortOrDefault()).To(Equal(uint32(5670)))
+			Expect(prometheus.GetPathOrDefault()).To(Equal("/metrics"))
+		})
+
+		It("should prefer explicit settings", func() {
+			// given
+			prometheus := &Metrics_Prometheus{Port: 1234, Path: "/stats"}
+
+			// expect
+			Expect(prometheus.GetPortOrDefault()).To(Equal(uint32(1234)))
+			Expect(prometheus.GetPathOrDefault()).To(Equal("/stats"))
+		})
+	})
+	Describe("Mesh_Mtls", func() {
+
+		It("should tell whether a port of a service is permissive", func() {
+			// given
+			mtls := &Mesh_Mtls{
+				Enabled: true,
+				PermissivePorts: []*Mesh_Mtls_PermissivePort{
+					{Service: "legacy", Port: 8080},
+				},
+			}
+
+			// expect
+			Expect(mtls.IsPermissive("legacy", 8080)).To(BeTrue())
+			Expect(mtls.IsPermissive("legacy", 8443)).To(BeFalse())
+			Expect(mtls.IsPermissive("backend", 8080)).To(BeFalse())
+		})
+
+		It("should not consider any port permissive by default", func() {
+			// given
+			var mtls *Mesh_Mtls
+
+			// expect
+			Expect(mtls.IsPermissive("legacy", 8080)).To(BeFalse())
+		})
+	})
+
+	Describe("Networking_Outbound", func() {
+
+		It("should return connection pool of a service", func() {
+			// given
+			outbound := &Networking_Outbound{
+				ConnectionPools: []*ConnectionPool{
+					{Service: "backend", Tcp: &ConnectionPool_Tcp{MaxConnections: &types.UInt32Value{Value: 100}}},
+				},
+			}
+
+			// expect
+			Expect(outbound.GetConnectionPoolFor("backend").GetTcp().GetMaxConnections().GetValue()).To(Equal(uint32(100)))
+			Expect(outbound.GetConnectionPoolFor("web")).To(BeNil())
+		})
+
+		It("should not return any connection pool by default", func() {
+			// given
+			var outbound *Networking_Outbound
+
+			// expect
+			Expect(outbound.GetConnectionPoolFor("backend")).To(BeNil())
+		})
+	})
+})
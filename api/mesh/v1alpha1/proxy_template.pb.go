@@ -6,6 +6,7 @@ package v1alpha1
 import (
 	fmt "fmt"
 	proto "github.com/gogo/protobuf/proto"
+	types "github.com/gogo/protobuf/types"
 	io "io"
 	math "math"
 )
@@ -21,6 +22,44 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 
+// Strategy defines how a raw resource is combined with a resource of the
+// same type and name generated by an imported profile.
+type ProxyTemplateRawResource_Strategy int32
+
+const (
+	// Generated resource is replaced with the raw one.
+	ProxyTemplateRawResource_REPLACE ProxyTemplateRawResource_Strategy = 0
+	// Raw resource is applied as a patch on top of the generated one.
+	// Fields set in the raw resource override those of the generated one,
+	// nested messages are patched recursively, lists of messages with a
+	// `name` are patched element by element matched by name, other lists are
+	// replaced.
+	// Fields of the raw resource that have default values, e.g. 0, false or
+	// an empty string, are indistinguishable from unset ones, so a patch
+	// cannot reset a field to its default value. Use REPLACE instead.
+	// There must be a generated resource to patch, since a patch is usually
+	// not a complete resource.
+	ProxyTemplateRawResource_PATCH ProxyTemplateRawResource_Strategy = 1
+)
+
+var ProxyTemplateRawResource_Strategy_name = map[int32]string{
+	0: "REPLACE",
+	1: "PATCH",
+}
+
+var ProxyTemplateRawResource_Strategy_value = map[string]int32{
+	"REPLACE": 0,
+	"PATCH":   1,
+}
+
+func (x ProxyTemplateRawResource_Strategy) String() string {
+	return proto.EnumName(ProxyTemplateRawResource_Strategy_name, int32(x))
+}
+
+func (ProxyTemplateRawResource_Strategy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_129e53d675ac14f4, []int{4, 0}
+}
+
 // ProxyTemplate defines the desired state of ProxyTemplate
 type ProxyTemplate struct {
 	// List of Dataplane selectors.
@@ -31,10 +70,14 @@ type ProxyTemplate struct {
 	Imports []string `protobuf:"bytes,2,rep,name=imports,proto3" json:"imports,omitempty"`
 	// List of raw xDS resources.
 	// +optional
-	Resources            []*ProxyTemplateRawResource `protobuf:"bytes,3,rep,name=resources,proto3" json:"resources,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
-	XXX_unrecognized     []byte                      `json:"-"`
-	XXX_sizecache        int32                       `json:"-"`
+	Resources []*ProxyTemplateRawResource `protobuf:"bytes,3,rep,name=resources,proto3" json:"resources,omitempty"`
+	// Time after which the policy is removed automatically,
+	// e.g. to make sure that a temporary change of config does not stay forever.
+	// +optional
+	ExpiresAt            *types.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
 func (m *ProxyTemplate) Reset()         { *m = ProxyTemplate{} }
@@ -91,6 +134,13 @@ func (m *ProxyTemplate) GetResources() []*ProxyTemplateRawResource {
 	return nil
 }
 
+func (m *ProxyTemplate) GetExpiresAt() *types.Timestamp {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return nil
+}
+
 // Selector defines a tag-based selector of Dataplanes.
 type ProxyTemplate_Selector struct {
 	// Match Dataplanes with the following key-value pairs.
@@ -420,10 +470,15 @@ type ProxyTemplateRawResource struct {
 	// resources.
 	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
 	// xDS resource.
-	Resource             string   `protobuf:"bytes,3,opt,name=resource,proto3" json:"resource,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Resource string `protobuf:"bytes,3,opt,name=resource,proto3" json:"resource,omitempty"`
+	// Strategy of combining with a generated resource. Raw resources always
+	// take precedence over generated ones.
+	// Defaults to REPLACE.
+	// +optional
+	Strategy             ProxyTemplateRawResource_Strategy `protobuf:"varint,4,opt,name=strategy,proto3,enum=kuma.mesh.v1alpha1.ProxyTemplateRawResource_Strategy" json:"strategy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                          `json:"-"`
+	XXX_unrecognized     []byte                            `json:"-"`
+	XXX_sizecache        int32                             `json:"-"`
 }
 
 func (m *ProxyTemplateRawResource) Reset()         { *m = ProxyTemplateRawResource{} }
@@ -480,7 +535,15 @@ func (m *ProxyTemplateRawResource) GetResource() string {
 	return ""
 }
 
+func (m *ProxyTemplateRawResource) GetStrategy() ProxyTemplateRawResource_Strategy {
+	if m != nil {
+		return m.Strategy
+	}
+	return ProxyTemplateRawResource_REPLACE
+}
+
 func init() {
+	proto.RegisterEnum("kuma.mesh.v1alpha1.ProxyTemplateRawResource_Strategy", ProxyTemplateRawResource_Strategy_name, ProxyTemplateRawResource_Strategy_value)
 	proto.RegisterType((*ProxyTemplate)(nil), "kuma.mesh.v1alpha1.ProxyTemplate")
 	proto.RegisterType((*ProxyTemplate_Selector)(nil), "kuma.mesh.v1alpha1.ProxyTemplate.Selector")
 	proto.RegisterMapType((map[string]string)(nil), "kuma.mesh.v1alpha1.ProxyTemplate.Selector.MatchEntry")
@@ -491,37 +554,45 @@ func init() {
 	proto.RegisterType((*ProxyTemplateRawResource)(nil), "kuma.mesh.v1alpha1.ProxyTemplateRawResource")
 }
 
-func init() { proto.RegisterFile("mesh/v1alpha1/proxy_template.proto", fileDescriptor_129e53d675ac14f4) }
+func init() {
+	proto.RegisterFile("mesh/v1alpha1/proxy_template.proto", fileDescriptor_129e53d675ac14f4)
+}
 
 var fileDescriptor_129e53d675ac14f4 = []byte{
-	// 417 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x53, 0xcf, 0xca, 0xda, 0x40,
-	0x10, 0xef, 0x26, 0xdf, 0xa7, 0x66, 0x42, 0xa1, 0x6c, 0xcb, 0xc7, 0x92, 0x83, 0x48, 0x4e, 0x22,
-	0x65, 0x45, 0x4b, 0xc1, 0x7a, 0xe8, 0x41, 0x28, 0x88, 0xa5, 0x20, 0x6b, 0x4f, 0xbd, 0x94, 0xad,
-	0x6e, 0x51, 0x4c, 0xdc, 0x65, 0xb3, 0x6a, 0xf3, 0x18, 0x7d, 0xa0, 0xd2, 0x6b, 0x8f, 0x85, 0xbe,
-	0x40, 0xf1, 0x49, 0x4a, 0x36, 0x89, 0x56, 0xaa, 0xa8, 0xf4, 0x96, 0x19, 0x7e, 0xff, 0x66, 0x66,
-	0x03, 0x61, 0x2c, 0x92, 0x79, 0x7b, 0xd3, 0xe1, 0x91, 0x9a, 0xf3, 0x4e, 0x5b, 0x69, 0xf9, 0x25,
-	0xfd, 0x68, 0x44, 0xac, 0x22, 0x6e, 0x04, 0x55, 0x5a, 0x1a, 0x89, 0xf1, 0x72, 0x1d, 0x73, 0x9a,
-	0x01, 0x69, 0x09, 0x0c, 0x7f, 0x39, 0xf0, 0x78, 0x9c, 0x81, 0xdf, 0x17, 0x58, 0x3c, 0x04, 0x2f,
-	0x11, 0x91, 0x98, 0x1a, 0xa9, 0x13, 0x82, 0x1a, 0x6e, 0xd3, 0xef, 0xb6, 0xe8, 0xbf, 0x4c, 0x7a,
-	0xc4, 0xa2, 0x93, 0x82, 0xc2, 0x0e, 0x64, 0x4c, 0xa0, 0xba, 0x88, 0x95, 0xd4, 0x26, 0x21, 0x4e,
-	0xc3, 0x6d, 0x7a, 0xac, 0x2c, 0xf1, 0x08, 0x3c, 0x2d, 0x12, 0xb9, 0xd6, 0x53, 0x91, 0x10, 0xd7,
-	0x7a, 0x3c, 0xbf, 0xe8, 0xc1, 0xf8, 0x96, 0x15, 0x24, 0x76, 0xa0, 0x07, 0x5f, 0x11, 0xd4, 0x4a,
-	0x77, 0xfc, 0x16, 0xee, 0x63, 0x6e, 0xa6, 0xf3, 0x22, 0xf8, 0xcb, 0xeb, 0x83, 0xd3, 0x77, 0x19,
-	0xef, 0xcd, 0xca, 0xe8, 0x94, 0xe5, 0x1a, 0x41, 0x0f, 0xe0, 0xd0, 0xc4, 0x4f, 0xc0, 0x5d, 0x8a,
-	0x94, 0xa0, 0x06, 0x6a, 0x7a, 0x2c, 0xfb, 0xc4, 0xcf, 0xe0, 0x7e, 0xc3, 0xa3, 0xb5, 0x20, 0x8e,
-	0xed, 0xe5, 0x45, 0xdf, 0xe9, 0xa1, 0xf0, 0x1b, 0x82, 0xa7, 0x47, 0x36, 0x13, 0x1b, 0x16, 0x63,
-	0xb8, 0x5b, 0xf1, 0x58, 0x14, 0x22, 0xf6, 0x1b, 0x8f, 0xa0, 0xaa, 0xb4, 0xfc, 0xbc, 0x88, 0x72,
-	0x1d, 0xbf, 0x4b, 0x2f, 0x86, 0x1e, 0xe7, 0xf8, 0x5c, 0x74, 0xf8, 0x88, 0x95, 0x02, 0xf8, 0x35,
-	0xb8, 0x9a, 0x6f, 0x89, 0x6b, 0x75, 0x5a, 0xd7, 0x6c, 0x74, 0xaf, 0x91, 0x11, 0x07, 0x15, 0xb8,
-	0x33, 0xa9, 0x12, 0xe1, 0x77, 0x04, 0xc1, 0x79, 0xc7, 0x93, 0x63, 0x30, 0xa8, 0x28, 0xae, 0x79,
-	0x9c, 0xdf, 0xda, 0xef, 0xf6, 0x6f, 0x9b, 0x82, 0x8e, 0x2d, 0x39, 0xdf, 0x7f, 0xa1, 0x14, 0xbc,
-	0x02, 0xff, 0xaf, 0xf6, 0x4d, 0x17, 0x98, 0xc1, 0xc3, 0xe9, 0x51, 0x8f, 0xdf, 0x1e, 0xfa, 0xaf,
-	0xb7, 0x17, 0xce, 0x80, 0x9c, 0x83, 0x9d, 0x5c, 0x12, 0x81, 0xea, 0x46, 0xe8, 0x64, 0x21, 0x57,
-	0x45, 0xe2, 0xb2, 0xc4, 0x01, 0xd4, 0x4a, 0x59, 0x7b, 0x3e, 0x8f, 0xed, 0xeb, 0xc1, 0xc3, 0x8f,
-	0x5d, 0x1d, 0xfd, 0xdc, 0xd5, 0xd1, 0xef, 0x5d, 0x1d, 0x7d, 0xa8, 0x95, 0x09, 0x3f, 0x55, 0xec,
-	0x6f, 0xfd, 0xe2, 0x4f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x26, 0x91, 0x29, 0x17, 0xfc, 0x03, 0x00,
-	0x00,
+	// 520 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x93, 0xcf, 0x6a, 0x1b, 0x31,
+	0x10, 0xc6, 0x23, 0xaf, 0xff, 0xed, 0x2c, 0x2d, 0x46, 0x2d, 0x61, 0xd9, 0x83, 0x6b, 0xf6, 0x64,
+	0x4a, 0x91, 0x89, 0x4b, 0x21, 0xc9, 0xa1, 0xe0, 0x04, 0x83, 0x49, 0x5b, 0x70, 0x65, 0x9f, 0x7a,
+	0x09, 0x8a, 0xab, 0xd8, 0x26, 0xbb, 0x96, 0x90, 0x64, 0x3b, 0x7e, 0x89, 0x42, 0x1f, 0xa8, 0xf4,
+	0xda, 0x63, 0xef, 0xbd, 0x14, 0x3f, 0x49, 0xf1, 0xee, 0xca, 0x8e, 0xa9, 0x43, 0x1c, 0x72, 0xd3,
+	0x0c, 0xf3, 0xfd, 0x66, 0x3e, 0x69, 0x04, 0x61, 0xcc, 0xf5, 0xa8, 0x31, 0x3b, 0x62, 0x91, 0x1c,
+	0xb1, 0xa3, 0x86, 0x54, 0xe2, 0x76, 0x71, 0x69, 0x78, 0x2c, 0x23, 0x66, 0x38, 0x91, 0x4a, 0x18,
+	0x81, 0xf1, 0xcd, 0x34, 0x66, 0x64, 0x55, 0x48, 0x6c, 0x61, 0xf0, 0x6a, 0x28, 0xc4, 0x30, 0xe2,
+	0x8d, 0xa4, 0xe2, 0x6a, 0x7a, 0xdd, 0x30, 0xe3, 0x98, 0x6b, 0xc3, 0x62, 0x99, 0x8a, 0xc2, 0x6f,
+	0x0e, 0x3c, 0xeb, 0xae, 0x68, 0xfd, 0x0c, 0x86, 0x3b, 0xe0, 0x6a, 0x1e, 0xf1, 0x81, 0x11, 0x4a,
+	0xfb, 0xa8, 0xe6, 0xd4, 0xbd, 0xe6, 0x6b, 0xf2, 0x3f, 0x9a, 0x6c, 0xa9, 0x48, 0x2f, 0x93, 0xd0,
+	0x8d, 0x18, 0xfb, 0x50, 0x1a, 0xc7, 0x52, 0x28, 0xa3, 0xfd, 0x5c, 0xcd, 0xa9, 0xbb, 0xd4, 0x86,
+	0xf8, 0x02, 0x5c, 0xc5, 0xb5, 0x98, 0xaa, 0x01, 0xd7, 0xbe, 0x93, 0xf4, 0x78, 0xf3, 0x60, 0x0f,
+	0xca, 0xe6, 0x34, 0x13, 0xd1, 0x8d, 0x1c, 0x9f, 0x00, 0xf0, 0x5b, 0x39, 0x56, 0x5c, 0x5f, 0x32,
+	0xe3, 0xe7, 0x6b, 0xa8, 0xee, 0x35, 0x03, 0x92, 0xfa, 0x26, 0xd6, 0x37, 0xe9, 0x5b, 0xdf, 0xd4,
+	0xcd, 0xaa, 0x5b, 0x26, 0xf8, 0x8e, 0xa0, 0x6c, 0x07, 0xc7, 0x1f, 0xa0, 0x10, 0x33, 0x33, 0x18,
+	0x65, 0x9e, 0xdf, 0xed, 0xef, 0x99, 0x7c, 0x5a, 0xe9, 0xda, 0x13, 0xa3, 0x16, 0x34, 0x65, 0x04,
+	0xc7, 0x00, 0x9b, 0x24, 0xae, 0x80, 0x73, 0xc3, 0x17, 0x3e, 0xaa, 0xa1, 0xba, 0x4b, 0x57, 0x47,
+	0xfc, 0x12, 0x0a, 0x33, 0x16, 0x4d, 0xb9, 0x9f, 0x4b, 0x72, 0x69, 0x70, 0x9a, 0x3b, 0x46, 0xe1,
+	0x0f, 0x04, 0x2f, 0xb6, 0xda, 0xf4, 0x12, 0x9f, 0x18, 0x43, 0x7e, 0xc2, 0x62, 0x9e, 0x41, 0x92,
+	0x33, 0xbe, 0x80, 0x92, 0x54, 0xe2, 0x7a, 0x1c, 0xa5, 0x1c, 0xaf, 0x49, 0x1e, 0x1c, 0xba, 0x9b,
+	0xd6, 0xa7, 0xd0, 0xce, 0x01, 0xb5, 0x00, 0xfc, 0x1e, 0x1c, 0xc5, 0xe6, 0xbe, 0x53, 0x43, 0x7b,
+	0x3d, 0x38, 0x65, 0xf3, 0x35, 0x63, 0x25, 0x3c, 0x2b, 0x42, 0xde, 0x2c, 0x24, 0x0f, 0x7f, 0x22,
+	0x08, 0xee, 0xef, 0xb8, 0xd3, 0x06, 0x85, 0xa2, 0x64, 0x8a, 0xc5, 0xe9, 0x9a, 0x78, 0xcd, 0xd3,
+	0xc7, 0xb9, 0x20, 0xdd, 0x44, 0x9c, 0xde, 0x7f, 0x46, 0x0a, 0x4e, 0xc0, 0xbb, 0x93, 0x7e, 0xd4,
+	0x0b, 0x7c, 0x85, 0xc3, 0xdd, 0x56, 0xb7, 0xd7, 0x16, 0x3d, 0x69, 0x6d, 0xc3, 0x3f, 0x08, 0xfc,
+	0xfb, 0xea, 0x76, 0xde, 0x92, 0x0f, 0xa5, 0x19, 0x57, 0x7a, 0x2c, 0x26, 0xd9, 0xc8, 0x36, 0xc4,
+	0x01, 0x94, 0x2d, 0x37, 0x79, 0x3f, 0x97, 0xae, 0x63, 0xfc, 0x19, 0xca, 0xda, 0x28, 0x66, 0xf8,
+	0x70, 0x91, 0xfc, 0x8d, 0xe7, 0x7b, 0x2c, 0xf6, 0x9d, 0x49, 0x48, 0x2f, 0x13, 0xd3, 0x35, 0x26,
+	0x0c, 0xa1, 0x6c, 0xb3, 0xd8, 0x83, 0x12, 0x6d, 0x77, 0x3f, 0xb6, 0xce, 0xdb, 0x95, 0x03, 0xec,
+	0x42, 0xa1, 0xdb, 0xea, 0x9f, 0x77, 0x2a, 0xe8, 0xec, 0xf0, 0xd7, 0xb2, 0x8a, 0x7e, 0x2f, 0xab,
+	0xe8, 0xef, 0xb2, 0x8a, 0xbe, 0x94, 0x6d, 0x9f, 0xab, 0x62, 0xf2, 0x21, 0xdf, 0xfe, 0x1b, 0x00,
+	0x94, 0xae, 0x9b, 0x62, 0xd0, 0x04, 0x00, 0x00,
 }
 
 func (m *ProxyTemplate) Marshal() (dAtA []byte, err error) {
@@ -578,6 +649,16 @@ func (m *ProxyTemplate) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
+	if m.ExpiresAt != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintProxyTemplate(dAtA, i, uint64(m.ExpiresAt.Size()))
+		n1, err := m.ExpiresAt.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n1
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -644,11 +725,11 @@ func (m *ProxyTemplateSource) MarshalTo(dAtA []byte) (int, error) {
 		i += copy(dAtA[i:], m.Name)
 	}
 	if m.Type != nil {
-		nn1, err := m.Type.MarshalTo(dAtA[i:])
+		nn2, err := m.Type.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += nn1
+		i += nn2
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -662,11 +743,11 @@ func (m *ProxyTemplateSource_Profile) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintProxyTemplate(dAtA, i, uint64(m.Profile.Size()))
-		n2, err := m.Profile.MarshalTo(dAtA[i:])
+		n3, err := m.Profile.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n2
+		i += n3
 	}
 	return i, nil
 }
@@ -676,11 +757,11 @@ func (m *ProxyTemplateSource_Raw) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x1a
 		i++
 		i = encodeVarintProxyTemplate(dAtA, i, uint64(m.Raw.Size()))
-		n3, err := m.Raw.MarshalTo(dAtA[i:])
+		n4, err := m.Raw.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n3
+		i += n4
 	}
 	return i, nil
 }
@@ -794,6 +875,11 @@ func (m *ProxyTemplateRawResource) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintProxyTemplate(dAtA, i, uint64(len(m.Resource)))
 		i += copy(dAtA[i:], m.Resource)
 	}
+	if m.Strategy != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintProxyTemplate(dAtA, i, uint64(m.Strategy))
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -833,6 +919,10 @@ func (m *ProxyTemplate) Size() (n int) {
 			n += 1 + l + sovProxyTemplate(uint64(l))
 		}
 	}
+	if m.ExpiresAt != nil {
+		l = m.ExpiresAt.Size()
+		n += 1 + l + sovProxyTemplate(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -962,6 +1052,9 @@ func (m *ProxyTemplateRawResource) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovProxyTemplate(uint64(l))
 	}
+	if m.Strategy != 0 {
+		n += 1 + sovProxyTemplate(uint64(m.Strategy))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1110,6 +1203,42 @@ func (m *ProxyTemplate) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpiresAt", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProxyTemplate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProxyTemplate
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProxyTemplate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ExpiresAt == nil {
+				m.ExpiresAt = &types.Timestamp{}
+			}
+			if err := m.ExpiresAt.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProxyTemplate(dAtA[iNdEx:])
@@ -1898,6 +2027,25 @@ func (m *ProxyTemplateRawResource) Unmarshal(dAtA []byte) error {
 			}
 			m.Resource = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Strategy", wireType)
+			}
+			m.Strategy = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProxyTemplate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Strategy |= ProxyTemplateRawResource_Strategy(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProxyTemplate(dAtA[iNdEx:])
@@ -6,6 +6,7 @@ package v1alpha1
 import (
 	fmt "fmt"
 	proto "github.com/gogo/protobuf/proto"
+	types "github.com/gogo/protobuf/types"
 	io "io"
 	math "math"
 )
@@ -22,10 +23,14 @@ var _ = math.Inf
 const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 
 type TrafficPermission struct {
-	Rules                []*TrafficPermission_Rule `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
-	XXX_unrecognized     []byte                    `json:"-"`
-	XXX_sizecache        int32                     `json:"-"`
+	Rules []*TrafficPermission_Rule `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	// Time after which the policy is removed automatically,
+	// e.g. to make sure that an emergency permission does not stay forever.
+	// +optional
+	ExpiresAt            *types.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
 func (m *TrafficPermission) Reset()         { *m = TrafficPermission{} }
@@ -68,6 +73,13 @@ func (m *TrafficPermission) GetRules() []*TrafficPermission_Rule {
 	return nil
 }
 
+func (m *TrafficPermission) GetExpiresAt() *types.Timestamp {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return nil
+}
+
 type TrafficPermission_Rule struct {
 	Sources              []*TrafficPermission_Rule_Selector `protobuf:"bytes,1,rep,name=sources,proto3" json:"sources,omitempty"`
 	Destinations         []*TrafficPermission_Rule_Selector `protobuf:"bytes,2,rep,name=destinations,proto3" json:"destinations,omitempty"`
@@ -182,25 +194,28 @@ func init() {
 }
 
 var fileDescriptor_7871a84a653f4288 = []byte{
-	// 273 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x52, 0xcb, 0x4d, 0x2d, 0xce,
-	0xd0, 0x2f, 0x33, 0x4c, 0xcc, 0x29, 0xc8, 0x48, 0x34, 0xd4, 0x2f, 0x29, 0x4a, 0x4c, 0x4b, 0xcb,
-	0x4c, 0x8e, 0x2f, 0x48, 0x2d, 0xca, 0xcd, 0x2c, 0x2e, 0xce, 0xcc, 0xcf, 0xd3, 0x2b, 0x28, 0xca,
-	0x2f, 0xc9, 0x17, 0x12, 0xca, 0x2e, 0xcd, 0x4d, 0xd4, 0x03, 0x29, 0xd6, 0x83, 0x29, 0x56, 0x5a,
-	0xcc, 0xcc, 0x25, 0x18, 0x02, 0xd1, 0x10, 0x00, 0x57, 0x2f, 0xe4, 0xc0, 0xc5, 0x5a, 0x54, 0x9a,
-	0x93, 0x5a, 0x2c, 0xc1, 0xa8, 0xc0, 0xac, 0xc1, 0x6d, 0xa4, 0xa5, 0x87, 0xa9, 0x53, 0x0f, 0x43,
-	0x97, 0x5e, 0x50, 0x69, 0x4e, 0x6a, 0x10, 0x44, 0xa3, 0xd4, 0x69, 0x26, 0x2e, 0x16, 0x10, 0x5f,
-	0xc8, 0x97, 0x8b, 0xbd, 0x38, 0xbf, 0xb4, 0x28, 0x19, 0x6e, 0x98, 0x31, 0xf1, 0x86, 0xe9, 0x05,
-	0xa7, 0xe6, 0xa4, 0x26, 0x97, 0xe4, 0x17, 0x05, 0xc1, 0xcc, 0x10, 0x0a, 0xe7, 0xe2, 0x49, 0x49,
-	0x2d, 0x2e, 0xc9, 0xcc, 0x4b, 0x2c, 0xc9, 0xcc, 0xcf, 0x2b, 0x96, 0x60, 0x22, 0xdf, 0x4c, 0x14,
-	0x83, 0xa4, 0x66, 0x31, 0x72, 0x71, 0xc0, 0xa4, 0x84, 0x42, 0xb8, 0x58, 0x73, 0x13, 0x4b, 0x92,
-	0x33, 0xa0, 0x4e, 0xb6, 0x23, 0xc3, 0x78, 0x3d, 0x5f, 0x90, 0x01, 0xae, 0x79, 0x25, 0x45, 0x95,
-	0x41, 0x10, 0xc3, 0xa4, 0x2c, 0xb8, 0xb8, 0x10, 0x82, 0x42, 0x02, 0x5c, 0xcc, 0xd9, 0xa9, 0x95,
-	0x12, 0x8c, 0x0a, 0x8c, 0x1a, 0x9c, 0x41, 0x20, 0xa6, 0x90, 0x08, 0x17, 0x6b, 0x59, 0x62, 0x4e,
-	0x69, 0xaa, 0x04, 0x13, 0x58, 0x0c, 0xc2, 0xb1, 0x62, 0xb2, 0x60, 0x74, 0x12, 0x3b, 0xf1, 0x48,
-	0x8e, 0xf1, 0xc2, 0x23, 0x39, 0xc6, 0x07, 0x8f, 0xe4, 0x18, 0xa3, 0x38, 0x60, 0x6e, 0x48, 0x62,
-	0x03, 0x47, 0xac, 0x31, 0x20, 0x00, 0x00, 0xff, 0xff, 0x82, 0x1b, 0xe4, 0xa4, 0x02, 0x02, 0x00,
-	0x00,
+	// 329 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x91, 0xcd, 0x4a, 0xf3, 0x40,
+	0x14, 0x86, 0x99, 0xf4, 0xeb, 0x67, 0x7b, 0xea, 0x42, 0x07, 0x91, 0x90, 0x45, 0x2d, 0x2e, 0xa4,
+	0xb8, 0x98, 0xd0, 0x76, 0x53, 0x5d, 0x88, 0x0a, 0x2e, 0x0b, 0x32, 0x16, 0x04, 0x37, 0x65, 0x1a,
+	0x4f, 0xdb, 0xa1, 0x49, 0x26, 0xcc, 0x4c, 0x8a, 0xbd, 0x15, 0xaf, 0x48, 0x70, 0xe3, 0x15, 0x88,
+	0xf4, 0x4a, 0x24, 0x49, 0x53, 0x91, 0x6e, 0xa4, 0xbb, 0xf9, 0x79, 0xdf, 0x87, 0x87, 0x73, 0xe0,
+	0x2c, 0x42, 0x33, 0xf3, 0x17, 0x1d, 0x11, 0x26, 0x33, 0xd1, 0xf1, 0xad, 0x16, 0x93, 0x89, 0x0c,
+	0x46, 0x09, 0xea, 0x48, 0x1a, 0x23, 0x55, 0xcc, 0x12, 0xad, 0xac, 0xa2, 0x74, 0x9e, 0x46, 0x82,
+	0x65, 0x61, 0x56, 0x86, 0xbd, 0x93, 0xa9, 0x52, 0xd3, 0x10, 0xfd, 0x3c, 0x31, 0x4e, 0x27, 0xbe,
+	0x95, 0x11, 0x1a, 0x2b, 0xa2, 0xa4, 0x28, 0x9d, 0x7e, 0x56, 0xe0, 0x70, 0x58, 0x10, 0xef, 0x37,
+	0x40, 0x7a, 0x0d, 0x55, 0x9d, 0x86, 0x68, 0x5c, 0xd2, 0xaa, 0xb4, 0x1b, 0xdd, 0x73, 0xb6, 0x8d,
+	0x66, 0x5b, 0x2d, 0xc6, 0xd3, 0x10, 0x79, 0x51, 0xa4, 0x17, 0x00, 0xf8, 0x92, 0x48, 0x8d, 0x66,
+	0x24, 0xac, 0xeb, 0xb4, 0x48, 0xbb, 0xd1, 0xf5, 0x58, 0x61, 0xc3, 0x4a, 0x1b, 0x36, 0x2c, 0x6d,
+	0x78, 0x7d, 0x9d, 0xbe, 0xb1, 0xde, 0xbb, 0x03, 0xff, 0x32, 0x14, 0x1d, 0xc0, 0x9e, 0x51, 0xa9,
+	0x0e, 0x36, 0x1e, 0xbd, 0xbf, 0x7b, 0xb0, 0x07, 0x0c, 0x31, 0xb0, 0x4a, 0xf3, 0x92, 0x41, 0x1f,
+	0x61, 0xff, 0x19, 0x8d, 0x95, 0xb1, 0xb0, 0x52, 0xc5, 0xc6, 0x75, 0x76, 0x67, 0xfe, 0x02, 0x79,
+	0xaf, 0x04, 0x6a, 0xe5, 0x17, 0x1d, 0x42, 0x35, 0x12, 0x36, 0x98, 0xad, 0x95, 0xaf, 0x76, 0xc0,
+	0xb3, 0x41, 0x06, 0xb8, 0x8b, 0xad, 0x5e, 0xf2, 0x02, 0xe6, 0xf5, 0x01, 0x7e, 0x1e, 0xe9, 0x01,
+	0x54, 0xe6, 0xb8, 0x74, 0x49, 0x8b, 0xb4, 0xeb, 0x3c, 0x3b, 0xd2, 0x23, 0xa8, 0x2e, 0x44, 0x98,
+	0x62, 0x3e, 0xe9, 0x3a, 0x2f, 0x2e, 0x97, 0x4e, 0x9f, 0xdc, 0x1e, 0xbf, 0xad, 0x9a, 0xe4, 0x63,
+	0xd5, 0x24, 0x5f, 0xab, 0x26, 0x79, 0xaa, 0x95, 0x0e, 0xe3, 0xff, 0xf9, 0x12, 0x7a, 0xdf, 0x03,
+	0x00, 0x44, 0xca, 0xf4, 0x3d, 0x5e, 0x02, 0x00, 0x00,
 }
 
 func (m *TrafficPermission) Marshal() (dAtA []byte, err error) {
@@ -230,6 +245,16 @@ func (m *TrafficPermission) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
+	if m.ExpiresAt != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintTrafficPermission(dAtA, i, uint64(m.ExpiresAt.Size()))
+		n1, err := m.ExpiresAt.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n1
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -340,6 +365,10 @@ func (m *TrafficPermission) Size() (n int) {
 			n += 1 + l + sovTrafficPermission(uint64(l))
 		}
 	}
+	if m.ExpiresAt != nil {
+		l = m.ExpiresAt.Size()
+		n += 1 + l + sovTrafficPermission(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -466,6 +495,42 @@ func (m *TrafficPermission) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpiresAt", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTrafficPermission
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTrafficPermission
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTrafficPermission
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ExpiresAt == nil {
+				m.ExpiresAt = &types.Timestamp{}
+			}
+			if err := m.ExpiresAt.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTrafficPermission(dAtA[iNdEx:])
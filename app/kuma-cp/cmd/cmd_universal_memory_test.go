@@ -20,3 +20,33 @@ store:
   type: memory
 `)
 })
+
+var _ = Describe("Universal In-Memory test with split roles", func() {
+	config := `
+xdsServer:
+  grpcPort: 0
+  diagnosticsPort: %d
+bootstrapServer:
+  port: 0
+apiServer:
+  port: 0
+sdsServer:
+  grpcPort: 0
+environment: universal
+store:
+  type: memory
+`
+
+	Describe("api-only", func() {
+		RunSmokeTest(config, "--role=api-only")
+	})
+
+	Describe("xds-only", func() {
+		RunSmokeTest(config, "--role=xds-only")
+	})
+
+	Describe("controllers-only", func() {
+		RunSmokeTest(config + `role: controllers-only
+`)
+	})
+})
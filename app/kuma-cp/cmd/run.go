@@ -1,11 +1,13 @@
 package cmd
 
 import (
+	"github.com/Kong/kuma/app/kuma-cp/pkg/demo"
 	api_server "github.com/Kong/kuma/pkg/api-server"
 	"github.com/Kong/kuma/pkg/config"
 	kuma_cp "github.com/Kong/kuma/pkg/config/app/kuma-cp"
 	"github.com/Kong/kuma/pkg/core"
 	"github.com/Kong/kuma/pkg/core/bootstrap"
+	k8s_webhooks "github.com/Kong/kuma/pkg/runtime/k8s/webhooks"
 	sds_server "github.com/Kong/kuma/pkg/sds/server"
 	xds_server "github.com/Kong/kuma/pkg/xds/server"
 	"github.com/spf13/cobra"
@@ -28,6 +30,8 @@ type runCmdOpts struct {
 func newRunCmdWithOpts(opts runCmdOpts) *cobra.Command {
 	args := struct {
 		configPath string
+		role       string
+		demo       bool
 	}{}
 	cmd := &cobra.Command{
 		Use:   "run",
@@ -40,25 +44,57 @@ func newRunCmdWithOpts(opts runCmdOpts) *cobra.Command {
 				runLog.Error(err, "could not load the configuration")
 				return err
 			}
+			if args.role != "" {
+				cfg.Role = args.role
+				if err := cfg.Validate(); err != nil {
+					runLog.Error(err, "invalid role")
+					return err
+				}
+			}
+			if args.demo {
+				demo.Configure(&cfg)
+			}
 			rt, err := bootstrap.Bootstrap(cfg)
 			if err != nil {
 				runLog.Error(err, "unable to set up Control Plane runtime")
 				return err
 			}
-			if err := sds_server.SetupServer(rt); err != nil {
-				runLog.Error(err, "unable to set up SDS server")
-				return err
+			if args.demo && cfg.HasRole(kuma_cp.ControllersOnlyRole) {
+				if err := demo.Setup(rt); err != nil {
+					runLog.Error(err, "unable to set up example resources")
+					return err
+				}
 			}
-			if err := xds_server.SetupServer(rt); err != nil {
-				runLog.Error(err, "unable to set up xDS server")
-				return err
+			if cfg.HasRole(kuma_cp.XdsOnlyRole) {
+				if err := sds_server.SetupServer(rt); err != nil {
+					runLog.Error(err, "unable to set up SDS server")
+					return err
+				}
+				if err := xds_server.SetupServer(rt); err != nil {
+					runLog.Error(err, "unable to set up xDS server")
+					return err
+				}
 			}
-			if err := api_server.SetupServer(rt); err != nil {
-				runLog.Error(err, "unable to set up API server")
-				return err
+			if cfg.HasRole(kuma_cp.ApiOnlyRole) && cfg.ApiServer.Enabled {
+				if err := api_server.SetupServer(rt); err != nil {
+					runLog.Error(err, "unable to set up API server")
+					return err
+				}
+			}
+			if cfg.HasRole(kuma_cp.ApiOnlyRole) && cfg.Environment == kuma_cp.KubernetesEnvironment {
+				if err := k8s_webhooks.SetupServer(rt); err != nil {
+					runLog.Error(err, "unable to set up Admission WebHook Server")
+					return err
+				}
+			}
+			if cfg.XdsServer.DiagnosticsEnabled {
+				if err := xds_server.SetupDiagnosticsServer(rt); err != nil {
+					runLog.Error(err, "unable to set up diagnostics server")
+					return err
+				}
 			}
 
-			runLog.Info("starting Control Plane")
+			runLog.Info("starting Control Plane", "role", cfg.Role)
 			if err := rt.Start(opts.SetupSignalHandler()); err != nil {
 				runLog.Error(err, "problem running Control Plane")
 				return err
@@ -70,5 +106,7 @@ func newRunCmdWithOpts(opts runCmdOpts) *cobra.Command {
 	}
 	// flags
 	cmd.PersistentFlags().StringVarP(&args.configPath, "config-file", "c", "", "configuration file")
+	cmd.PersistentFlags().StringVar(&args.role, "role", "", `role of Control Plane, can be either "all", "api-only", "xds-only" or "controllers-only" (overrides configuration file)`)
+	cmd.PersistentFlags().BoolVar(&args.demo, "demo", false, "run a self-contained Control Plane with an in-memory store and an example Mesh (overrides configuration file)")
 	return cmd
 }
@@ -11,7 +11,7 @@ import (
 	"sigs.k8s.io/testing_frameworks/integration/addr"
 )
 
-func RunSmokeTest(config string) {
+func RunSmokeTest(config string, extraArgs ...string) {
 
 	Describe("run", func() {
 
@@ -52,7 +52,7 @@ func RunSmokeTest(config string) {
 					return stopCh
 				},
 			})
-			cmd.SetArgs([]string{"--config-file=" + configFile.Name()})
+			cmd.SetArgs(append([]string{"--config-file=" + configFile.Name()}, extraArgs...))
 
 			// when
 			By("starting the Control Plane")
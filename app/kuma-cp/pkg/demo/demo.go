@@ -0,0 +1,97 @@
+package demo
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	kuma_cp "github.com/Kong/kuma/pkg/config/app/kuma-cp"
+	store_config "github.com/Kong/kuma/pkg/config/core/resources/store"
+	"github.com/Kong/kuma/pkg/core"
+	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	core_runtime "github.com/Kong/kuma/pkg/core/runtime"
+)
+
+var log = core.Log.WithName("demo")
+
+const (
+	// Mesh is a name of the example Mesh.
+	Mesh = "demo"
+	// Dataplane is a name of the example Dataplane.
+	Dataplane = "demo-app"
+)
+
+// Configure turns a given configuration into the one of a self-contained Control Plane,
+// which keeps resources in memory and doesn't depend on Kubernetes.
+func Configure(cfg *kuma_cp.Config) {
+	cfg.Environment = kuma_cp.UniversalEnvironment
+	cfg.Store.Type = store_config.MemoryStore
+}
+
+// Setup makes a given runtime populate its store with example resources on start.
+func Setup(rt core_runtime.Runtime) error {
+	return rt.Add(core_runtime.ComponentFunc(func(stop <-chan struct{}) error {
+		if err := CreateResources(context.Background(), rt.ResourceManager()); err != nil {
+			return err
+		}
+		log.Info("example resources are ready, explore them with the API server", "mesh", Mesh)
+		<-stop // it has to block, otherwise the component manager stops all other components
+		return nil
+	}))
+}
+
+// CreateResources creates an example Mesh with a sample Dataplane that is allowed to talk to itself.
+func CreateResources(ctx context.Context, resManager core_manager.ResourceManager) error {
+	key := func(name string) core_store.CreateOptionsFunc {
+		return core_store.CreateByKey(core_model.DefaultNamespace, name, Mesh)
+	}
+	mesh := &core_mesh.MeshResource{}
+	if err := resManager.Create(ctx, mesh, key(Mesh)); err != nil {
+		return errors.Wrapf(err, "could not create Mesh %q", Mesh)
+	}
+	dataplane := &core_mesh.DataplaneResource{
+		Spec: mesh_proto.Dataplane{
+			Networking: &mesh_proto.Dataplane_Networking{
+				Inbound: []*mesh_proto.Dataplane_Networking_Inbound{
+					{
+						Interface: "127.0.0.1:10000:10001",
+						Tags: map[string]string{
+							mesh_proto.ServiceTag: Dataplane,
+						},
+					},
+				},
+				Outbound: []*mesh_proto.Dataplane_Networking_Outbound{
+					{
+						Interface: ":20001",
+						Service:   Dataplane,
+					},
+				},
+			},
+		},
+	}
+	if err := resManager.Create(ctx, dataplane, key(Dataplane)); err != nil {
+		return errors.Wrapf(err, "could not create Dataplane %q", Dataplane)
+	}
+	permission := &core_mesh.TrafficPermissionResource{
+		Spec: mesh_proto.TrafficPermission{
+			Rules: []*mesh_proto.TrafficPermission_Rule{
+				{
+					Sources: []*mesh_proto.TrafficPermission_Rule_Selector{
+						{Match: map[string]string{mesh_proto.ServiceTag: mesh_proto.MatchAllTag}},
+					},
+					Destinations: []*mesh_proto.TrafficPermission_Rule_Selector{
+						{Match: map[string]string{mesh_proto.ServiceTag: mesh_proto.MatchAllTag}},
+					},
+				},
+			},
+		},
+	}
+	if err := resManager.Create(ctx, permission, key("allow-all")); err != nil {
+		return errors.Wrap(err, `could not create TrafficPermission "allow-all"`)
+	}
+	return nil
+}
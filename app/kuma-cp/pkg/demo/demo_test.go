@@ -0,0 +1,63 @@
+package demo_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kong/kuma/app/kuma-cp/pkg/demo"
+	kuma_cp "github.com/Kong/kuma/pkg/config/app/kuma-cp"
+	store_config "github.com/Kong/kuma/pkg/config/core/resources/store"
+	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+)
+
+var _ = Describe("Demo", func() {
+
+	It("should configure a self-contained Control Plane", func() {
+		// given
+		cfg := kuma_cp.DefaultConfig()
+		cfg.Environment = kuma_cp.KubernetesEnvironment
+		cfg.Store.Type = store_config.KubernetesStore
+
+		// when
+		demo.Configure(&cfg)
+
+		// then
+		Expect(cfg.Environment).To(Equal(kuma_cp.UniversalEnvironment))
+		Expect(cfg.Store.Type).To(Equal(store_config.MemoryStore))
+	})
+
+	It("should create example resources", func() {
+		// given
+		ctx := context.Background()
+		resManager := core_manager.NewResourceManager(memory.NewStore())
+
+		// when
+		err := demo.CreateResources(ctx, resManager)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+
+		// and
+		meshes := &core_mesh.MeshResourceList{}
+		Expect(resManager.List(ctx, meshes)).To(Succeed())
+		Expect(meshes.Items).To(HaveLen(1))
+		Expect(meshes.Items[0].GetMeta().GetName()).To(Equal(demo.Mesh))
+
+		// and
+		dataplanes := &core_mesh.DataplaneResourceList{}
+		Expect(resManager.List(ctx, dataplanes, core_store.ListByMesh(demo.Mesh))).To(Succeed())
+		Expect(dataplanes.Items).To(HaveLen(1))
+		Expect(dataplanes.Items[0].GetMeta().GetName()).To(Equal(demo.Dataplane))
+
+		// and
+		permissions := &core_mesh.TrafficPermissionResourceList{}
+		Expect(resManager.List(ctx, permissions, core_store.ListByMesh(demo.Mesh))).To(Succeed())
+		Expect(permissions.Items).To(HaveLen(1))
+		Expect(permissions.Items[0].GetMeta().GetName()).To(Equal("allow-all"))
+	})
+})
@@ -1,23 +1,54 @@
 package cmd
 
 import (
+	"net"
 	"net/http"
+	"strconv"
 	"time"
 
+	"github.com/gogo/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/spf13/cobra"
 
 	"github.com/Kong/kuma/app/kuma-dp/pkg/dataplane/envoy"
+	"github.com/Kong/kuma/app/kuma-dp/pkg/dataplane/metrics"
+	"github.com/Kong/kuma/app/kuma-dp/pkg/dataplane/resiliency"
 	"github.com/Kong/kuma/pkg/config"
 	kuma_dp "github.com/Kong/kuma/pkg/config/app/kuma-dp"
 	"github.com/Kong/kuma/pkg/core"
+	util_http "github.com/Kong/kuma/pkg/util/http"
 )
 
 var (
 	runLog = dataplaneLog.WithName("run")
+	// shared by all requests to the Control Plane, so that retries stop once the Control Plane is down
+	retryBudget = util_http.NewRetryBudget(10, 0.1)
+	// exposed on --metrics-port
+	retryMetrics = util_http.NewRetryMetrics()
 	// overridable by tests
-	bootstrapGenerator = envoy.NewRemoteBootstrapGenerator(&http.Client{Timeout: 10 * time.Second})
+	bootstrapGenerator envoy.BootstrapConfigFactoryFunc = func(cfg kuma_dp.Config) (proto.Message, error) {
+		return envoy.NewRemoteBootstrapGenerator(newControlPlaneClient(cfg.ControlPlane.Retry))(cfg)
+	}
 )
 
+func newControlPlaneClient(cfg kuma_dp.Retry) util_http.Client {
+	return util_http.ClientWithRetryPolicy(&http.Client{Timeout: 10 * time.Second}, util_http.RetryPolicy{
+		MaxRetries: cfg.MaxRetries,
+		Backoff:    cfg.Backoff,
+		MaxBackoff: cfg.MaxBackoff,
+		Jitter:     0.2,
+		Budget:     retryBudget,
+		OnRetry: func(event util_http.RetryEvent) {
+			retryMetrics.OnRetry(event)
+			if event.Throttled {
+				runLog.Info("not retrying a request to the Control Plane since too many requests have failed recently", "url", event.Request.URL.String(), "cause", event.Cause)
+				return
+			}
+			runLog.Info("retrying a request to the Control Plane", "url", event.Request.URL.String(), "attempt", event.Attempt, "wait", event.Wait, "cause", event.Cause)
+		},
+	})
+}
+
 func newRunCmd() *cobra.Command {
 	cfg := kuma_dp.DefaultConfig()
 	cmd := &cobra.Command{
@@ -45,7 +76,38 @@ func newRunCmd() *cobra.Command {
 				Stdout:    cmd.OutOrStdout(),
 				Stderr:    cmd.OutOrStderr(),
 			})
-			if err := dataplane.Run(core.SetupSignalHandler()); err != nil {
+			stop := core.SetupSignalHandler()
+			if cfg.Dataplane.MetricsPort != 0 {
+				registry := prometheus.NewRegistry()
+				if err := registry.Register(retryMetrics); err != nil {
+					return err
+				}
+				server := &metrics.Server{Port: cfg.Dataplane.MetricsPort, Gatherer: registry}
+				go func() {
+					if err := server.Start(stop); err != nil {
+						runLog.Error(err, "unable to expose metrics")
+					}
+				}()
+			}
+			if cfg.Dataplane.ResiliencyEvents.Enabled {
+				watcher := resiliency.New(resiliency.Opts{
+					AdminAddress:  net.JoinHostPort("127.0.0.1", strconv.Itoa(int(cfg.Dataplane.AdminPort))),
+					CheckInterval: cfg.Dataplane.ResiliencyEvents.CheckInterval,
+					Report: resiliency.NewOutlierEjectionReporter(
+						newControlPlaneClient(cfg.ControlPlane.Retry),
+						cfg.ControlPlane.BootstrapServer.URL,
+						cfg.Dataplane.Mesh,
+						cfg.Dataplane.Name,
+						func() (string, error) {
+							return envoy.ReadToken(cfg)
+						},
+					),
+				})
+				go func() {
+					_ = watcher.Start(stop)
+				}()
+			}
+			if err := dataplane.Run(stop); err != nil {
 				runLog.Error(err, "problem running Dataplane (Envoy)")
 				return err
 			}
@@ -57,9 +119,13 @@ func newRunCmd() *cobra.Command {
 
 	cmd.PersistentFlags().StringVar(&cfg.Dataplane.Name, "name", cfg.Dataplane.Name, "Name of the Dataplane")
 	cmd.PersistentFlags().Uint32Var(&cfg.Dataplane.AdminPort, "admin-port", cfg.Dataplane.AdminPort, "Port for Envoy Admin")
+	cmd.PersistentFlags().Uint32Var(&cfg.Dataplane.MetricsPort, "metrics-port", cfg.Dataplane.MetricsPort, "Port on which metrics of kuma-dp, e.g. retries of requests to the Control Plane, are exposed")
 	cmd.PersistentFlags().StringVar(&cfg.Dataplane.Mesh, "mesh", cfg.Dataplane.Mesh, "Mesh that Dataplane belongs to")
 	cmd.PersistentFlags().StringVar(&cfg.ControlPlane.BootstrapServer.URL, "cp-address", cfg.ControlPlane.BootstrapServer.URL, "Mesh that Dataplane belongs to")
 	cmd.PersistentFlags().StringVar(&cfg.DataplaneRuntime.BinaryPath, "binary-path", cfg.DataplaneRuntime.BinaryPath, "Binary path of Envoy executable")
 	cmd.PersistentFlags().StringVar(&cfg.DataplaneRuntime.ConfigDir, "config-dir", cfg.DataplaneRuntime.ConfigDir, "Directory in which Envoy config will be generated")
+	cmd.PersistentFlags().StringVar(&cfg.DataplaneRuntime.TokenPath, "token-path", cfg.DataplaneRuntime.TokenPath, "Path to a file with a token that Dataplane presents to the Control Plane")
+	cmd.PersistentFlags().StringVar(&cfg.DataplaneRuntime.ClientCertPath, "client-cert-path", cfg.DataplaneRuntime.ClientCertPath, "Path to a file with a certificate that Dataplane presents to the XDS Server")
+	cmd.PersistentFlags().StringVar(&cfg.DataplaneRuntime.ClientKeyPath, "client-key-path", cfg.DataplaneRuntime.ClientKeyPath, "Path to a file with a key of the certificate that Dataplane presents to the XDS Server")
 	return cmd
 }
@@ -0,0 +1,123 @@
+package discovery
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	kuma_dp "github.com/Kong/kuma/pkg/config/app/kuma-dp"
+	"github.com/Kong/kuma/pkg/xds/bootstrap/rest"
+)
+
+// tcpListenState is the state of TCP sockets in the LISTEN state as shown in /proc/net/tcp.
+const tcpListenState = "0A"
+
+// ProcDir is the directory of the proc filesystem, overridable by tests.
+var ProcDir = "/proc"
+
+// Inbounds returns inbound interfaces of the dataplane, one per port of the workload.
+//
+// Unless ports of the workload are given explicitly, they are discovered out of TCP sockets in the LISTEN state.
+func Inbounds(cfg kuma_dp.Dataplane) ([]rest.Inbound, error) {
+	ports := cfg.InboundDiscovery.Ports
+	if len(ports) == 0 {
+		discovered, err := ListeningPorts(ProcDir)
+		if err != nil {
+			return nil, err
+		}
+		ports = discovered
+	}
+	var inbounds []rest.Inbound
+	for _, port := range ports {
+		if port == cfg.AdminPort {
+			continue
+		}
+		dataplanePort := port + cfg.InboundDiscovery.PortOffset
+		if dataplanePort > 65535 {
+			return nil, errors.Errorf("port %d of the workload with offset %d is out of the range [1, 65535]", port, cfg.InboundDiscovery.PortOffset)
+		}
+		iface := mesh_proto.InboundInterface{
+			DataplaneIP:   cfg.InboundDiscovery.Address,
+			DataplanePort: dataplanePort,
+			WorkloadPort:  port,
+		}
+		inbounds = append(inbounds, rest.Inbound{
+			Interface: iface.String(),
+			Tags:      cfg.InboundDiscovery.Tags,
+		})
+	}
+	if len(inbounds) == 0 {
+		return nil, errors.New("could not find any port the workload listens on")
+	}
+	return inbounds, nil
+}
+
+// ListeningPorts returns ports of TCP sockets in the LISTEN state.
+func ListeningPorts(procDir string) ([]uint32, error) {
+	unique := map[uint32]bool{}
+	for _, file := range []string{"tcp", "tcp6"} {
+		path := filepath.Join(procDir, "net", file)
+		f, err := os.Open(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				// e.g., IPv6 is disabled
+				continue
+			}
+			return nil, errors.Wrapf(err, "could not open %q", path)
+		}
+		ports, err := parseListeningPorts(f)
+		f.Close()
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not parse %q", path)
+		}
+		for _, port := range ports {
+			unique[port] = true
+		}
+	}
+	ports := make([]uint32, 0, len(unique))
+	for port := range unique {
+		ports = append(ports, port)
+	}
+	sort.Slice(ports, func(i, j int) bool { return ports[i] < ports[j] })
+	return ports, nil
+}
+
+// parseListeningPorts parses a table in the format of /proc/net/tcp, e.g.
+//
+//	sl  local_address rem_address   st tx_queue rx_queue ...
+//	 0: 00000000:1F90 00000000:0000 0A 00000000:00000000 ...
+func parseListeningPorts(r io.Reader) ([]uint32, error) {
+	var ports []uint32
+	scanner := bufio.NewScanner(r)
+	header := true
+	for scanner.Scan() {
+		if header {
+			header = false
+			continue
+		}
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 {
+			continue
+		}
+		if fields[3] != tcpListenState {
+			continue
+		}
+		colon := strings.LastIndex(fields[1], ":")
+		if colon < 0 {
+			return nil, errors.Errorf("invalid local address %q", fields[1])
+		}
+		port, err := strconv.ParseUint(fields[1][colon+1:], 16, 16)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid local address %q", fields[1])
+		}
+		ports = append(ports, uint32(port))
+	}
+	return ports, scanner.Err()
+}
@@ -0,0 +1,90 @@
+package discovery_test
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kong/kuma/app/kuma-dp/pkg/dataplane/discovery"
+	kuma_dp "github.com/Kong/kuma/pkg/config/app/kuma-dp"
+	"github.com/Kong/kuma/pkg/xds/bootstrap/rest"
+)
+
+var _ = Describe("Inbounds()", func() {
+
+	var backupProcDir string
+
+	BeforeEach(func() {
+		backupProcDir = discovery.ProcDir
+		discovery.ProcDir = filepath.Join("testdata", "proc")
+	})
+
+	AfterEach(func() {
+		discovery.ProcDir = backupProcDir
+	})
+
+	newConfig := func() kuma_dp.Dataplane {
+		cfg := kuma_dp.DefaultConfig().Dataplane
+		cfg.Name = "web-01"
+		cfg.InboundDiscovery.Enabled = true
+		cfg.InboundDiscovery.Address = "192.168.0.1"
+		cfg.InboundDiscovery.Tags = map[string]string{"service": "web"}
+		return cfg
+	}
+
+	It("should discover ports the workload listens on", func() {
+		// given
+		cfg := newConfig()
+		cfg.AdminPort = 9090
+
+		// when
+		inbounds, err := discovery.Inbounds(cfg)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(inbounds).To(Equal([]rest.Inbound{
+			{Interface: "192.168.0.1:13306:3306", Tags: map[string]string{"service": "web"}},
+			{Interface: "192.168.0.1:18080:8080", Tags: map[string]string{"service": "web"}},
+		}))
+	})
+
+	It("should use ports given explicitly", func() {
+		// given
+		cfg := newConfig()
+		cfg.InboundDiscovery.Ports = []uint32{5000}
+		cfg.InboundDiscovery.PortOffset = 100
+
+		// when
+		inbounds, err := discovery.Inbounds(cfg)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(inbounds).To(Equal([]rest.Inbound{
+			{Interface: "192.168.0.1:5100:5000", Tags: map[string]string{"service": "web"}},
+		}))
+	})
+
+	It("should fail when a port with offset is out of range", func() {
+		// given
+		cfg := newConfig()
+		cfg.InboundDiscovery.Ports = []uint32{60000}
+
+		// when
+		_, err := discovery.Inbounds(cfg)
+
+		// then
+		Expect(err).To(MatchError("port 60000 of the workload with offset 10000 is out of the range [1, 65535]"))
+	})
+
+	It("should fail when the workload doesn't listen on any port", func() {
+		// given
+		discovery.ProcDir = filepath.Join("testdata", "non-existing")
+
+		// when
+		_, err := discovery.Inbounds(newConfig())
+
+		// then
+		Expect(err).To(MatchError("could not find any port the workload listens on"))
+	})
+})
@@ -1,9 +1,12 @@
 package envoy
 
 import (
+	"bytes"
 	"context"
 	"io"
+	"io/ioutil"
 	"os/exec"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/pkg/errors"
@@ -19,6 +22,8 @@ var (
 var (
 	// overridable by unit tests
 	newConfigFile = GenerateBootstrapFile
+	// how often a token of the Dataplane is checked for rotation
+	tokenCheckInterval = 1 * time.Minute
 )
 
 type BootstrapConfigFactoryFunc func(cfg kuma_dp.Config) (proto.Message, error)
@@ -38,14 +43,40 @@ type Envoy struct {
 	opts Opts
 }
 
+// Run starts Envoy and keeps it running until stop is closed.
+//
+// Envoy presents the token of the Dataplane only when it connects to the Control Plane,
+// and it cannot re-read it on its own. That is why Envoy is restarted with a freshly
+// generated bootstrap config once the token is rotated, e.g. a bound ServiceAccount token on Kubernetes.
+//
+// If a bootstrap config with the rotated token cannot be generated, e.g. because the Control Plane
+// is unavailable even after retries, Envoy keeps running with the previous one until the next check.
 func (e *Envoy) Run(stop <-chan struct{}) error {
+	token := e.readToken()
 	bootstrapConfig, err := e.opts.Generator(e.opts.Config)
 	if err != nil {
 		return errors.Wrapf(err, "failed to generate Envoy bootstrap config")
 	}
+	for {
+		next, err := e.run(stop, token, bootstrapConfig)
+		if err != nil || next == nil {
+			return err
+		}
+		runLog.Info("token of the Dataplane has been rotated, restarting Envoy")
+		token, bootstrapConfig = next.token, next.bootstrapConfig
+	}
+}
+
+// restart holds a rotated token and a bootstrap config that Envoy is restarted with.
+type restart struct {
+	token           []byte
+	bootstrapConfig proto.Message
+}
+
+func (e *Envoy) run(stop <-chan struct{}, token []byte, bootstrapConfig proto.Message) (*restart, error) {
 	configFile, err := newConfigFile(e.opts.Config.DataplaneRuntime, bootstrapConfig)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -55,23 +86,54 @@ func (e *Envoy) Run(stop <-chan struct{}) error {
 	command.Stdout = e.opts.Stdout
 	command.Stderr = e.opts.Stderr
 	if err := command.Start(); err != nil {
-		return err
+		return nil, err
 	}
 	done := make(chan error, 1)
 	go func() {
 		done <- command.Wait()
 	}()
 
-	select {
-	case <-stop:
-		cancel()
-		return nil
-	case err := <-done:
-		if err != nil {
-			runLog.Error(err, "Envoy terminated with an error")
-		} else {
-			runLog.Info("Envoy terminated successfully")
+	ticker := time.NewTicker(tokenCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			cancel()
+			return nil, nil
+		case <-ticker.C:
+			current := e.readToken()
+			if token == nil || current == nil || bytes.Equal(token, current) {
+				continue
+			}
+			// the config is generated before Envoy is stopped, so that a failure doesn't take the Dataplane down
+			next, err := e.opts.Generator(e.opts.Config)
+			if err != nil {
+				runLog.Error(err, "failed to generate Envoy bootstrap config with the rotated token, Envoy keeps running with the previous one")
+				continue
+			}
+			cancel()
+			<-done
+			return &restart{token: current, bootstrapConfig: next}, nil
+		case err := <-done:
+			if err != nil {
+				runLog.Error(err, "Envoy terminated with an error")
+			} else {
+				runLog.Info("Envoy terminated successfully")
+			}
+			return nil, err
 		}
-		return err
 	}
 }
+
+// readToken returns the current token of the Dataplane or nil if there is none.
+func (e *Envoy) readToken() []byte {
+	if e.opts.Config.DataplaneRuntime.TokenPath == "" {
+		return nil
+	}
+	token, err := ioutil.ReadFile(e.opts.Config.DataplaneRuntime.TokenPath)
+	if err != nil {
+		// the error is reported by the bootstrap generator
+		return nil
+	}
+	return bytes.TrimSpace(token)
+}
@@ -10,6 +10,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 
@@ -170,5 +171,131 @@ var _ = Describe("Envoy", func() {
 			// complete
 			close(done)
 		}, 10)
+
+		It("should restart Envoy with a new bootstrap config once the token is rotated", func(done Done) {
+			// given
+			tokenFile := filepath.Join(configDir, "token")
+			Expect(ioutil.WriteFile(tokenFile, []byte("first-token"), 0600)).To(Succeed())
+			// and
+			cfg := kuma_dp.Config{
+				DataplaneRuntime: kuma_dp.DataplaneRuntime{
+					BinaryPath: filepath.Join("testdata", "envoy-mock.sleep.sh"),
+					ConfigDir:  configDir,
+					TokenPath:  tokenFile,
+				},
+			}
+			tokens := make(chan string, 2)
+			sampleConfig := func(cfg kuma_dp.Config) (proto.Message, error) {
+				token, err := ioutil.ReadFile(cfg.DataplaneRuntime.TokenPath)
+				if err != nil {
+					return nil, err
+				}
+				tokens <- string(token)
+				return &envoy_bootstrap.Bootstrap{}, nil
+			}
+			// and
+			previousInterval := tokenCheckInterval
+			tokenCheckInterval = 10 * time.Millisecond
+			defer func() {
+				tokenCheckInterval = previousInterval
+			}()
+
+			By("starting a mock dataplane")
+			// when
+			dataplane := New(Opts{
+				Config:    cfg,
+				Generator: sampleConfig,
+				Stdout:    &bytes.Buffer{},
+				Stderr:    &bytes.Buffer{},
+			})
+			// and
+			go func() {
+				errCh <- dataplane.Run(stopCh)
+			}()
+			// then
+			Expect(<-tokens).To(Equal("first-token"))
+
+			By("rotating the token")
+			// when
+			Expect(ioutil.WriteFile(tokenFile, []byte("second-token"), 0600)).To(Succeed())
+			// then
+			Expect(<-tokens).To(Equal("second-token"))
+
+			By("stopping the mock dataplane")
+			// when
+			close(stopCh)
+			// then
+			Expect(<-errCh).ToNot(HaveOccurred())
+
+			// complete
+			close(done)
+		}, 10)
+
+		It("should keep Envoy running when a bootstrap config with the rotated token cannot be generated", func(done Done) {
+			// given
+			tokenFile := filepath.Join(configDir, "token")
+			Expect(ioutil.WriteFile(tokenFile, []byte("first-token"), 0600)).To(Succeed())
+			// and
+			cfg := kuma_dp.Config{
+				DataplaneRuntime: kuma_dp.DataplaneRuntime{
+					BinaryPath: filepath.Join("testdata", "envoy-mock.sleep.sh"),
+					ConfigDir:  configDir,
+					TokenPath:  tokenFile,
+				},
+			}
+			tokens := make(chan string, 3)
+			failures := 1
+			sampleConfig := func(cfg kuma_dp.Config) (proto.Message, error) {
+				token, err := ioutil.ReadFile(cfg.DataplaneRuntime.TokenPath)
+				if err != nil {
+					return nil, err
+				}
+				tokens <- string(token)
+				if string(token) == "second-token" && failures > 0 {
+					failures--
+					return nil, fmt.Errorf("Control Plane is unavailable")
+				}
+				return &envoy_bootstrap.Bootstrap{}, nil
+			}
+			// and
+			previousInterval := tokenCheckInterval
+			tokenCheckInterval = 10 * time.Millisecond
+			defer func() {
+				tokenCheckInterval = previousInterval
+			}()
+
+			By("starting a mock dataplane")
+			// when
+			dataplane := New(Opts{
+				Config:    cfg,
+				Generator: sampleConfig,
+				Stdout:    &bytes.Buffer{},
+				Stderr:    &bytes.Buffer{},
+			})
+			// and
+			go func() {
+				errCh <- dataplane.Run(stopCh)
+			}()
+			// then
+			Expect(<-tokens).To(Equal("first-token"))
+
+			By("rotating the token while the Control Plane is unavailable")
+			// when
+			Expect(ioutil.WriteFile(tokenFile, []byte("second-token"), 0600)).To(Succeed())
+			// then a failed attempt is followed by another one
+			Expect(<-tokens).To(Equal("second-token"))
+			Expect(<-tokens).To(Equal("second-token"))
+			// and Envoy has kept running
+			Consistently(errCh).ShouldNot(Receive())
+
+			By("stopping the mock dataplane")
+			// when
+			close(stopCh)
+			// then
+			Expect(<-errCh).ToNot(HaveOccurred())
+
+			// complete
+			close(done)
+		}, 10)
 	})
 })
@@ -3,40 +3,70 @@ package envoy
 import (
 	"bytes"
 	"encoding/json"
+	"github.com/Kong/kuma/app/kuma-dp/pkg/dataplane/discovery"
 	"github.com/Kong/kuma/pkg/xds/bootstrap/rest"
 	"io/ioutil"
 	"net/http"
+	"strings"
 
 	kuma_dp "github.com/Kong/kuma/pkg/config/app/kuma-dp"
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
+	util_http "github.com/Kong/kuma/pkg/util/http"
 	util_proto "github.com/Kong/kuma/pkg/util/proto"
+	kuma_version "github.com/Kong/kuma/pkg/version"
+	envoy_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
 	envoy_bootstrap "github.com/envoyproxy/go-control-plane/envoy/config/bootstrap/v2"
 	"github.com/gogo/protobuf/proto"
 	"github.com/pkg/errors"
 )
 
 type remoteBootstrap struct {
-	client *http.Client
+	client util_http.Client
 }
 
-func NewRemoteBootstrapGenerator(client *http.Client) BootstrapConfigFactoryFunc {
+func NewRemoteBootstrapGenerator(client util_http.Client) BootstrapConfigFactoryFunc {
 	rb := remoteBootstrap{client: client}
 	return rb.Generate
 }
 
+// Generate requests a bootstrap config from the Control Plane.
+//
+// A token of the Dataplane is read anew on every call, so that a rotated token is picked up.
 func (b *remoteBootstrap) Generate(cfg kuma_dp.Config) (proto.Message, error) {
+	token, err := ReadToken(cfg)
+	if err != nil {
+		return nil, err
+	}
 	url := cfg.ControlPlane.BootstrapServer.URL + "/bootstrap"
 	request := rest.BootstrapRequest{
 		Mesh: cfg.Dataplane.Mesh,
 		Name: cfg.Dataplane.Name,
 		// if not set in config, the 0 will be sent which will result in providing default admin port
 		// that is set in the control plane bootstrap params
-		AdminPort: cfg.Dataplane.AdminPort,
+		AdminPort:      cfg.Dataplane.AdminPort,
+		ClientCertFile: cfg.DataplaneRuntime.ClientCertPath,
+		ClientKeyFile:  cfg.DataplaneRuntime.ClientKeyPath,
+	}
+	if cfg.Dataplane.InboundDiscovery.Enabled {
+		inbounds, err := discovery.Inbounds(cfg.Dataplane)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not discover inbound interfaces")
+		}
+		request.Inbounds = inbounds
 	}
 	jsonBytes, err := json.Marshal(request)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not marshal request to json")
 	}
-	resp, err := b.client.Post(url, "application/json", bytes.NewReader(jsonBytes))
+	req, err := http.NewRequest("POST", url, bytes.NewReader(jsonBytes))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create a request to bootstrap server")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", token)
+	}
+	resp, err := b.client.Do(req)
 	if err != nil {
 		return nil, errors.Wrap(err, "request to bootstrap server failed")
 	}
@@ -45,6 +75,10 @@ func (b *remoteBootstrap) Generate(cfg kuma_dp.Config) (proto.Message, error) {
 		if resp.StatusCode == 404 {
 			return nil, errors.New("status: 404. Did you first applied Dataplane resource?")
 		}
+		if resp.StatusCode == 400 || resp.StatusCode == 401 || resp.StatusCode == 403 {
+			body, _ := ioutil.ReadAll(resp.Body)
+			return nil, errors.Errorf("status: %d. %s", resp.StatusCode, body)
+		}
 		return nil, errors.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
 
@@ -57,5 +91,37 @@ func (b *remoteBootstrap) Generate(cfg kuma_dp.Config) (proto.Message, error) {
 		return nil, errors.Wrap(err, "could not parse the bootstrap configuration")
 	}
 
+	attachMetadata(&bootstrap, cfg, token)
+
 	return &bootstrap, nil
 }
+
+// attachMetadata puts metadata of the dataplane, including its token, into the node of Envoy,
+// so that the Control Plane could identify and authenticate the dataplane on every xDS stream.
+func attachMetadata(bootstrap *envoy_bootstrap.Bootstrap, cfg kuma_dp.Config, token string) {
+	metadata := core_xds.DataplaneMetadata{
+		Version:       core_xds.MetadataVersion,
+		Mesh:          cfg.Dataplane.Mesh,
+		Name:          cfg.Dataplane.Name,
+		KumaDpVersion: kuma_version.Build.Version,
+		Token:         token,
+		// the Control Plane needs to know whether Envoy Admin interface is available, e.g. to expose metrics
+		AdminPort: bootstrap.GetAdmin().GetAddress().GetSocketAddress().GetPortValue(),
+	}
+	if bootstrap.Node == nil {
+		bootstrap.Node = &envoy_core.Node{}
+	}
+	bootstrap.Node.Metadata = metadata.ToStruct()
+}
+
+// ReadToken returns the token that the dataplane presents to the Control Plane, or an empty string if there is none.
+func ReadToken(cfg kuma_dp.Config) (string, error) {
+	if cfg.DataplaneRuntime.TokenPath == "" {
+		return "", nil
+	}
+	token, err := ioutil.ReadFile(cfg.DataplaneRuntime.TokenPath)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not read the dataplane token from %q", cfg.DataplaneRuntime.TokenPath)
+	}
+	return strings.TrimSpace(string(token)), nil
+}
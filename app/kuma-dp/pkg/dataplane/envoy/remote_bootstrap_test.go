@@ -5,11 +5,14 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
 
 	kuma_dp "github.com/Kong/kuma/pkg/config/app/kuma-dp"
+	util_proto "github.com/Kong/kuma/pkg/util/proto"
+	envoy_bootstrap "github.com/envoyproxy/go-control-plane/envoy/config/bootstrap/v2"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -56,4 +59,190 @@ var _ = Describe("Remote Bootstrap", func() {
 		Expect(err).ToNot(HaveOccurred())
 		Expect(config).ToNot(BeNil())
 	})
+
+	It("should send inbound interfaces discovered by the dataplane", func() {
+		// given
+		mux := http.NewServeMux()
+		server := httptest.NewServer(mux)
+		defer server.Close()
+		mux.HandleFunc("/bootstrap", func(writer http.ResponseWriter, req *http.Request) {
+			defer GinkgoRecover()
+			body, err := ioutil.ReadAll(req.Body)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(body).To(MatchJSON(`
+			{
+				"mesh": "demo",
+				"name": "sample",
+				"inbounds": [
+					{
+						"interface": "192.168.0.1:18080:8080",
+						"tags": {
+							"service": "web"
+						}
+					}
+				]
+			}
+			`))
+
+			response, err := ioutil.ReadFile(filepath.Join("testdata", "remote-bootstrap-config.golden.yaml"))
+			Expect(err).ToNot(HaveOccurred())
+			_, err = writer.Write(response)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		// and
+		generator := NewRemoteBootstrapGenerator(http.DefaultClient)
+
+		cfg := kuma_dp.DefaultConfig()
+		cfg.Dataplane.Mesh = "demo"
+		cfg.Dataplane.Name = "sample"
+		cfg.Dataplane.InboundDiscovery.Enabled = true
+		cfg.Dataplane.InboundDiscovery.Address = "192.168.0.1"
+		cfg.Dataplane.InboundDiscovery.Ports = []uint32{8080}
+		cfg.Dataplane.InboundDiscovery.Tags = map[string]string{"service": "web"}
+		cfg.ControlPlane.BootstrapServer.URL = server.URL
+
+		// when
+		config, err := generator(cfg)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(config).ToNot(BeNil())
+	})
+
+	It("should send paths of the client certificate of the dataplane", func() {
+		// given
+		mux := http.NewServeMux()
+		server := httptest.NewServer(mux)
+		defer server.Close()
+		mux.HandleFunc("/bootstrap", func(writer http.ResponseWriter, req *http.Request) {
+			defer GinkgoRecover()
+			body, err := ioutil.ReadAll(req.Body)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(body).To(MatchJSON(`
+			{
+				"mesh": "demo",
+				"name": "sample",
+				"clientCertFile": "/var/run/secrets/tls.crt",
+				"clientKeyFile": "/var/run/secrets/tls.key"
+			}
+			`))
+
+			response, err := ioutil.ReadFile(filepath.Join("testdata", "remote-bootstrap-config.golden.yaml"))
+			Expect(err).ToNot(HaveOccurred())
+			_, err = writer.Write(response)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		// and
+		generator := NewRemoteBootstrapGenerator(http.DefaultClient)
+
+		cfg := kuma_dp.DefaultConfig()
+		cfg.Dataplane.Mesh = "demo"
+		cfg.Dataplane.Name = "sample"
+		cfg.DataplaneRuntime.ClientCertPath = "/var/run/secrets/tls.crt"
+		cfg.DataplaneRuntime.ClientKeyPath = "/var/run/secrets/tls.key"
+		cfg.ControlPlane.BootstrapServer.URL = server.URL
+
+		// when
+		config, err := generator(cfg)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(config).ToNot(BeNil())
+	})
+
+	It("should attach metadata of the dataplane to the node", func() {
+		// given
+		mux := http.NewServeMux()
+		server := httptest.NewServer(mux)
+		defer server.Close()
+		mux.HandleFunc("/bootstrap", func(writer http.ResponseWriter, req *http.Request) {
+			defer GinkgoRecover()
+			response, err := ioutil.ReadFile(filepath.Join("testdata", "remote-bootstrap-config.golden.yaml"))
+			Expect(err).ToNot(HaveOccurred())
+			_, err = writer.Write(response)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		// and
+		tokenFile, err := ioutil.TempFile("", "token")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.Remove(tokenFile.Name())
+		_, err = tokenFile.WriteString("sample-token\n")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tokenFile.Close()).To(Succeed())
+
+		// and
+		generator := NewRemoteBootstrapGenerator(http.DefaultClient)
+
+		cfg := kuma_dp.DefaultConfig()
+		cfg.Dataplane.Mesh = "demo"
+		cfg.Dataplane.Name = "sample"
+		cfg.ControlPlane.BootstrapServer.URL = server.URL
+		cfg.DataplaneRuntime.TokenPath = tokenFile.Name()
+
+		// when
+		config, err := generator(cfg)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		bootstrap := config.(*envoy_bootstrap.Bootstrap)
+		Expect(util_proto.ToYAML(bootstrap.Node.Metadata)).To(MatchYAML(`
+        version: "1"
+        dataplane.admin.port: "9901"
+        dataplane.mesh: demo
+        dataplane.name: sample
+        dataplane.token: sample-token
+        kumaDp.version: unknown
+`))
+	})
+
+	It("should present a token that is read anew on every request", func() {
+		// given
+		var authorization []string
+		mux := http.NewServeMux()
+		server := httptest.NewServer(mux)
+		defer server.Close()
+		mux.HandleFunc("/bootstrap", func(writer http.ResponseWriter, req *http.Request) {
+			defer GinkgoRecover()
+			authorization = append(authorization, req.Header.Get("Authorization"))
+			response, err := ioutil.ReadFile(filepath.Join("testdata", "remote-bootstrap-config.golden.yaml"))
+			Expect(err).ToNot(HaveOccurred())
+			_, err = writer.Write(response)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		// and
+		tokenFile, err := ioutil.TempFile("", "token")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.Remove(tokenFile.Name())
+		Expect(ioutil.WriteFile(tokenFile.Name(), []byte("first-token\n"), 0600)).To(Succeed())
+
+		// and
+		generator := NewRemoteBootstrapGenerator(http.DefaultClient)
+
+		cfg := kuma_dp.DefaultConfig()
+		cfg.Dataplane.Mesh = "demo"
+		cfg.Dataplane.Name = "sample"
+		cfg.ControlPlane.BootstrapServer.URL = server.URL
+		cfg.DataplaneRuntime.TokenPath = tokenFile.Name()
+
+		// when
+		_, err = generator(cfg)
+		// then
+		Expect(err).ToNot(HaveOccurred())
+
+		// when token is rotated
+		Expect(ioutil.WriteFile(tokenFile.Name(), []byte("second-token\n"), 0600)).To(Succeed())
+		// and
+		config, err := generator(cfg)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(authorization).To(Equal([]string{"first-token", "second-token"}))
+		// and
+		bootstrap := config.(*envoy_bootstrap.Bootstrap)
+		Expect(bootstrap.Node.Metadata.Fields["dataplane.token"].GetStringValue()).To(Equal("second-token"))
+	})
 })
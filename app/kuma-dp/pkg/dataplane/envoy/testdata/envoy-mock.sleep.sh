@@ -0,0 +1,4 @@
+#!/bin/sh
+
+# keep running until killed
+exec sleep 60
@@ -0,0 +1,49 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/Kong/kuma/pkg/core"
+)
+
+var serverLog = core.Log.WithName("kuma-dp").WithName("metrics")
+
+// Server exposes metrics of kuma-dp itself on /metrics, as opposed to metrics of Envoy.
+type Server struct {
+	Port     uint32
+	Gatherer prometheus.Gatherer
+}
+
+func (s *Server) Start(stop <-chan struct{}) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(s.Gatherer, promhttp.HandlerOpts{}))
+
+	httpServer := &http.Server{Addr: fmt.Sprintf(":%d", s.Port), Handler: mux}
+
+	errChan := make(chan error)
+	go func() {
+		defer close(errChan)
+		if err := httpServer.ListenAndServe(); err != nil {
+			if err != http.ErrServerClosed {
+				serverLog.Error(err, "terminated with an error")
+				errChan <- err
+				return
+			}
+		}
+		serverLog.Info("terminated normally")
+	}()
+	serverLog.Info("starting", "port", s.Port)
+
+	select {
+	case <-stop:
+		serverLog.Info("stopping")
+		return httpServer.Shutdown(context.Background())
+	case err := <-errChan:
+		return err
+	}
+}
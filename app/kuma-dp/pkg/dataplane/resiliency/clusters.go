@@ -0,0 +1,34 @@
+package resiliency
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// failedOutlierCheck is a health flag of an endpoint that has been ejected by outlier detection.
+const failedOutlierCheck = "/failed_outlier_check"
+
+// hostKey identifies an endpoint of a cluster, e.g. "192.168.0.1:8080" of the cluster "backend".
+type hostKey struct {
+	Cluster string
+	Host    string
+}
+
+// parseClusters parses the output of `/clusters` of Envoy Admin in the plain text format
+// and tells which endpoints are ejected by outlier detection, e.g.
+//
+//	backend::192.168.0.1:8080::health_flags::/failed_outlier_check
+//	backend::192.168.0.2:8080::health_flags::healthy
+func parseClusters(in io.Reader) (map[hostKey]bool, error) {
+	result := map[hostKey]bool{}
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		parts := strings.Split(scanner.Text(), "::")
+		if len(parts) != 4 || parts[2] != "health_flags" {
+			continue
+		}
+		result[hostKey{Cluster: parts[0], Host: parts[1]}] = strings.Contains(parts[3], failedOutlierCheck)
+	}
+	return result, scanner.Err()
+}
@@ -0,0 +1,87 @@
+package resiliency
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	util_http "github.com/Kong/kuma/pkg/util/http"
+	"github.com/Kong/kuma/pkg/xds/bootstrap/rest"
+)
+
+// NewOutlierEjectionReporter returns a function that sends ejections of endpoints to the Control Plane,
+// so that they become visible in DataplaneInsight of a given dataplane.
+// Received HTTP responses are sent along, so that the Control Plane could halt a rollout of policies
+// that makes the dataplane receive 5xx. So is activity of circuit breakers and retry budgets,
+// which the Control Plane exposes as metrics.
+//
+// Every request presents the current token of the dataplane, which is returned by a given function.
+func NewOutlierEjectionReporter(client util_http.Client, url string, mesh string, name string, token func() (string, error)) func([]Event) error {
+	return func(events []Event) error {
+		request := rest.OutlierEjectionsRequest{
+			Mesh: mesh,
+			Name: name,
+		}
+		now := time.Now()
+		for _, event := range events {
+			switch event.Type {
+			case ResponsesReceived:
+				request.Responses = &rest.ResponseStats{
+					Total:        event.Responses,
+					ServerErrors: event.ServerErrors,
+				}
+			case OutlierEjected, OutlierUnejected:
+				request.Ejections = append(request.Ejections, rest.OutlierEjection{
+					Cluster: event.Cluster,
+					Host:    event.Host,
+					Ejected: event.Type == OutlierEjected,
+					Time:    now,
+				})
+			case CircuitBreakerOpened, CircuitBreakerClosed:
+				request.CircuitBreakers = append(request.CircuitBreakers, rest.CircuitBreaker{
+					Cluster:  event.Cluster,
+					Priority: event.Priority,
+					Breaker:  event.Breaker,
+					Open:     event.Type == CircuitBreakerOpened,
+				})
+			case TrafficRejected:
+				request.Rejections = append(request.Rejections, rest.TrafficRejection{
+					Cluster:  event.Cluster,
+					Counter:  event.Counter,
+					Rejected: event.Rejected,
+				})
+			}
+		}
+		if len(request.Ejections) == 0 && request.Responses == nil && len(request.CircuitBreakers) == 0 && len(request.Rejections) == 0 {
+			return nil
+		}
+		body, err := json.Marshal(request)
+		if err != nil {
+			return errors.Wrap(err, "could not marshal request to json")
+		}
+		req, err := http.NewRequest("POST", url+"/outlier-ejections", bytes.NewReader(body))
+		if err != nil {
+			return errors.Wrap(err, "could not create a request to the Control Plane")
+		}
+		req.Header.Set("Content-Type", "application/json")
+		currentToken, err := token()
+		if err != nil {
+			return err
+		}
+		if currentToken != "" {
+			req.Header.Set("Authorization", currentToken)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return errors.Wrap(err, "failed to report outlier ejections to the Control Plane")
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusNoContent {
+			return errors.Errorf("Control Plane responded with status code %d", resp.StatusCode)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,108 @@
+package resiliency_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kong/kuma/app/kuma-dp/pkg/dataplane/resiliency"
+	"github.com/Kong/kuma/pkg/xds/bootstrap/rest"
+)
+
+var _ = Describe("OutlierEjectionReporter", func() {
+
+	var server *httptest.Server
+	var requests []rest.OutlierEjectionsRequest
+	var tokens []string
+	var report func([]resiliency.Event) error
+
+	BeforeEach(func() {
+		requests = nil
+		tokens = nil
+		server = httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+			defer GinkgoRecover()
+			Expect(req.URL.Path).To(Equal("/outlier-ejections"))
+			request := rest.OutlierEjectionsRequest{}
+			Expect(json.NewDecoder(req.Body).Decode(&request)).To(Succeed())
+			requests = append(requests, request)
+			tokens = append(tokens, req.Header.Get("Authorization"))
+			writer.WriteHeader(http.StatusNoContent)
+		}))
+		report = resiliency.NewOutlierEjectionReporter(server.Client(), server.URL, "default", "backend-01", func() (string, error) {
+			return "token-of-backend-01", nil
+		})
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should send ejections to the Control Plane", func() {
+		// when
+		err := report([]resiliency.Event{
+			{Type: resiliency.OutlierEjected, Cluster: "backend", Host: "192.168.0.2:8080"},
+			{Type: resiliency.CircuitBreakerOpened, Cluster: "backend", Priority: "default", Breaker: "rq_open"},
+			{Type: resiliency.OutlierUnejected, Cluster: "backend", Host: "192.168.0.1:8080"},
+		})
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		// and
+		Expect(requests).To(HaveLen(1))
+		Expect(requests[0].Mesh).To(Equal("default"))
+		Expect(requests[0].Name).To(Equal("backend-01"))
+		Expect(tokens).To(Equal([]string{"token-of-backend-01"}))
+		Expect(requests[0].Ejections).To(HaveLen(2))
+		Expect(requests[0].Ejections[0].Host).To(Equal("192.168.0.2:8080"))
+		Expect(requests[0].Ejections[0].Ejected).To(BeTrue())
+		Expect(requests[0].Ejections[1].Host).To(Equal("192.168.0.1:8080"))
+		Expect(requests[0].Ejections[1].Ejected).To(BeFalse())
+	})
+
+	It("should send received HTTP responses to the Control Plane", func() {
+		// when
+		err := report([]resiliency.Event{
+			{Type: resiliency.ResponsesReceived, Responses: 20, ServerErrors: 3},
+		})
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		// and
+		Expect(requests).To(HaveLen(1))
+		Expect(requests[0].Ejections).To(BeEmpty())
+		Expect(requests[0].Responses).To(Equal(&rest.ResponseStats{Total: 20, ServerErrors: 3}))
+	})
+
+	It("should send activity of circuit breakers and retry budgets to the Control Plane", func() {
+		// when
+		err := report([]resiliency.Event{
+			{Type: resiliency.CircuitBreakerOpened, Cluster: "backend", Priority: "default", Breaker: "rq_open"},
+			{Type: resiliency.TrafficRejected, Cluster: "backend", Counter: "upstream_rq_retry_overflow", Rejected: 3},
+		})
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		// and
+		Expect(requests).To(HaveLen(1))
+		Expect(requests[0].Ejections).To(BeEmpty())
+		Expect(requests[0].CircuitBreakers).To(Equal([]rest.CircuitBreaker{
+			{Cluster: "backend", Priority: "default", Breaker: "rq_open", Open: true},
+		}))
+		Expect(requests[0].Rejections).To(Equal([]rest.TrafficRejection{
+			{Cluster: "backend", Counter: "upstream_rq_retry_overflow", Rejected: 3},
+		}))
+	})
+
+	It("should not call the Control Plane without events", func() {
+		// when
+		err := report(nil)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		// and
+		Expect(requests).To(BeEmpty())
+	})
+})
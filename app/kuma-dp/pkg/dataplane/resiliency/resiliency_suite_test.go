@@ -0,0 +1,13 @@
+package resiliency_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestResiliency(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Resiliency Events Suite")
+}
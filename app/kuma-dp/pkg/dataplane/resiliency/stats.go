@@ -0,0 +1,96 @@
+package resiliency
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// statsFilter selects stats of Envoy that describe activity of circuit breakers and retry budgets,
+// as well as HTTP responses received from clusters.
+const statsFilter = `^cluster\..+\.(circuit_breakers\..+_open|upstream_cx_overflow|upstream_rq_pending_overflow|upstream_rq_retry_overflow|upstream_rq_completed|upstream_rq_5xx)$`
+
+// Overflow counters of Envoy, i.e. the number of connections, requests and retries
+// that have been rejected because of an open circuit breaker or an exhausted retry budget.
+const (
+	ConnectionOverflow     = "upstream_cx_overflow"
+	PendingRequestOverflow = "upstream_rq_pending_overflow"
+	RetryOverflow          = "upstream_rq_retry_overflow"
+)
+
+// Response counters of Envoy, i.e. the number of all HTTP responses and of 5xx responses received from a cluster.
+const (
+	completedRequests = "upstream_rq_completed"
+	serverErrors      = "upstream_rq_5xx"
+)
+
+// breakerKey identifies a circuit breaker, e.g. "rq_open" of the "default" priority of the cluster "backend".
+type breakerKey struct {
+	Cluster  string
+	Priority string
+	Breaker  string
+}
+
+// overflowKey identifies an overflow counter, e.g. "upstream_rq_retry_overflow" of the cluster "backend".
+type overflowKey struct {
+	Cluster string
+	Counter string
+}
+
+type stats struct {
+	breakers  map[breakerKey]bool
+	overflows map[overflowKey]uint64
+	// ejections tells which endpoints are ejected by outlier detection
+	ejections map[hostKey]bool
+	// responses and serverErrors are the numbers of HTTP responses and 5xx responses received from all clusters
+	responses    uint64
+	serverErrors uint64
+}
+
+// parseStats parses stats of Envoy in the plain text format, e.g.
+//
+//	cluster.backend.circuit_breakers.default.rq_open: 0
+//	cluster.backend.upstream_rq_retry_overflow: 3
+//
+// Names of clusters may contain dots, so stats are recognized by their suffixes.
+func parseStats(in io.Reader) (*stats, error) {
+	result := &stats{
+		breakers:  map[breakerKey]bool{},
+		overflows: map[overflowKey]uint64{},
+	}
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		parts := strings.SplitN(scanner.Text(), ": ", 2)
+		if len(parts) != 2 || !strings.HasPrefix(parts[0], "cluster.") {
+			continue
+		}
+		name := strings.TrimPrefix(parts[0], "cluster.")
+		value, err := strconv.ParseUint(strings.TrimSpace(parts[1]), 10, 64)
+		if err != nil {
+			continue // histograms are not of interest
+		}
+		if idx := strings.LastIndex(name, ".circuit_breakers."); idx > 0 {
+			segments := strings.Split(name[idx+len(".circuit_breakers."):], ".")
+			if len(segments) != 2 || !strings.HasSuffix(segments[1], "_open") {
+				continue
+			}
+			result.breakers[breakerKey{Cluster: name[:idx], Priority: segments[0], Breaker: segments[1]}] = value > 0
+			continue
+		}
+		if strings.HasSuffix(name, "."+completedRequests) {
+			result.responses += value
+			continue
+		}
+		if strings.HasSuffix(name, "."+serverErrors) {
+			result.serverErrors += value
+			continue
+		}
+		for _, counter := range []string{ConnectionOverflow, PendingRequestOverflow, RetryOverflow} {
+			if strings.HasSuffix(name, "."+counter) {
+				result.overflows[overflowKey{Cluster: strings.TrimSuffix(name, "."+counter), Counter: counter}] = value
+			}
+		}
+	}
+	return result, scanner.Err()
+}
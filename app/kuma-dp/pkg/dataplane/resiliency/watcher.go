@@ -0,0 +1,217 @@
+package resiliency
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/Kong/kuma/pkg/core"
+)
+
+var (
+	log = core.Log.WithName("kuma-dp").WithName("resiliency-events")
+)
+
+type EventType string
+
+const (
+	// CircuitBreakerOpened means that a circuit breaker has started to reject traffic to a cluster.
+	CircuitBreakerOpened EventType = "CircuitBreakerOpened"
+	// CircuitBreakerClosed means that a circuit breaker has stopped rejecting traffic to a cluster.
+	CircuitBreakerClosed EventType = "CircuitBreakerClosed"
+	// TrafficRejected means that connections, requests or retries to a cluster have been rejected
+	// since the previous check, e.g. because a retry budget has been exhausted.
+	TrafficRejected EventType = "TrafficRejected"
+	// OutlierEjected means that outlier detection has ejected an endpoint from a load balancing pool.
+	OutlierEjected EventType = "OutlierEjected"
+	// OutlierUnejected means that an endpoint ejected by outlier detection has returned to a load balancing pool.
+	OutlierUnejected EventType = "OutlierUnejected"
+	// ResponsesReceived means that HTTP responses have been received from clusters since the previous check.
+	ResponsesReceived EventType = "ResponsesReceived"
+)
+
+// Event describes activity of a circuit breaker, a retry budget or outlier detection of a cluster.
+type Event struct {
+	Type    EventType
+	Cluster string
+	// Priority and Breaker identify a circuit breaker, e.g. "default" and "rq_open".
+	Priority string
+	Breaker  string
+	// Counter and Rejected tell what kind of traffic and how much of it has been rejected,
+	// e.g. "upstream_rq_retry_overflow" and 3.
+	Counter  string
+	Rejected uint64
+	// Host is the address of an endpoint ejected by outlier detection, e.g. "192.168.0.1:8080".
+	Host string
+	// Responses and ServerErrors tell how many HTTP responses, and 5xx responses among them, have been received.
+	Responses    uint64
+	ServerErrors uint64
+}
+
+type Opts struct {
+	// AdminAddress is the address of Envoy Admin interface, e.g. 127.0.0.1:9901.
+	AdminAddress  string
+	CheckInterval time.Duration
+	// OnEvent is called for every event. By default, events are logged.
+	OnEvent func(Event)
+	// Report is called with all events of every check, e.g. to send them to the Control Plane.
+	Report func([]Event) error
+}
+
+// New returns a watcher that periodically checks stats of Envoy and reports activity
+// of circuit breakers, retry budgets and outlier detection as events.
+func New(opts Opts) *Watcher {
+	if opts.OnEvent == nil {
+		opts.OnEvent = logEvent
+	}
+	return &Watcher{
+		opts:     opts,
+		client:   &http.Client{Timeout: opts.CheckInterval},
+		previous: &stats{},
+	}
+}
+
+type Watcher struct {
+	opts     Opts
+	client   *http.Client
+	previous *stats
+}
+
+func (w *Watcher) Start(stop <-chan struct{}) error {
+	ticker := time.NewTicker(w.opts.CheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := w.Check(); err != nil {
+				log.Error(err, "unable to check stats of Envoy")
+			}
+		case <-stop:
+			return nil
+		}
+	}
+}
+
+// Check fetches current stats of Envoy and reports how they have changed since the previous check.
+func (w *Watcher) Check() error {
+	current, err := w.fetchStats()
+	if err != nil {
+		return err
+	}
+	events := diff(w.previous, current)
+	for _, event := range events {
+		w.opts.OnEvent(event)
+	}
+	w.previous = current
+	if w.opts.Report != nil && len(events) > 0 {
+		return w.opts.Report(events)
+	}
+	return nil
+}
+
+func (w *Watcher) fetchStats() (*stats, error) {
+	var result *stats
+	err := w.get("/stats", url.Values{"filter": []string{statsFilter}}, func(body io.Reader) error {
+		var err error
+		result, err = parseStats(body)
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch stats of Envoy")
+	}
+	err = w.get("/clusters", nil, func(body io.Reader) error {
+		var err error
+		result.ejections, err = parseClusters(body)
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch clusters of Envoy")
+	}
+	return result, nil
+}
+
+func (w *Watcher) get(path string, query url.Values, parse func(io.Reader) error) error {
+	reqUrl := url.URL{
+		Scheme:   "http",
+		Host:     w.opts.AdminAddress,
+		Path:     path,
+		RawQuery: query.Encode(),
+	}
+	resp, err := w.client.Get(reqUrl.String())
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("Envoy responded with status code %d", resp.StatusCode)
+	}
+	return parse(resp.Body)
+}
+
+func diff(previous, current *stats) []Event {
+	var events []Event
+	for key, open := range current.breakers {
+		if open == previous.breakers[key] {
+			continue
+		}
+		typ := CircuitBreakerClosed
+		if open {
+			typ = CircuitBreakerOpened
+		}
+		events = append(events, Event{Type: typ, Cluster: key.Cluster, Priority: key.Priority, Breaker: key.Breaker})
+	}
+	for key, value := range current.overflows {
+		// counters are reset once Envoy restarts
+		if last := previous.overflows[key]; value > last {
+			events = append(events, Event{Type: TrafficRejected, Cluster: key.Cluster, Counter: key.Counter, Rejected: value - last})
+		}
+	}
+	for key, ejected := range current.ejections {
+		// endpoints that have just been added to a cluster are not reported unless they are ejected
+		if ejected == previous.ejections[key] {
+			continue
+		}
+		typ := OutlierUnejected
+		if ejected {
+			typ = OutlierEjected
+		}
+		events = append(events, Event{Type: typ, Cluster: key.Cluster, Host: key.Host})
+	}
+	// counters are reset once Envoy restarts
+	if current.responses > previous.responses && current.serverErrors >= previous.serverErrors {
+		events = append(events, Event{
+			Type:         ResponsesReceived,
+			Responses:    current.responses - previous.responses,
+			ServerErrors: current.serverErrors - previous.serverErrors,
+		})
+	}
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].Cluster != events[j].Cluster {
+			return events[i].Cluster < events[j].Cluster
+		}
+		if events[i].Type != events[j].Type {
+			return events[i].Type < events[j].Type
+		}
+		return events[i].Breaker+events[i].Counter+events[i].Host < events[j].Breaker+events[j].Counter+events[j].Host
+	})
+	return events
+}
+
+func logEvent(event Event) {
+	switch event.Type {
+	case CircuitBreakerOpened:
+		log.Info("circuit breaker has opened", "cluster", event.Cluster, "priority", event.Priority, "breaker", event.Breaker)
+	case CircuitBreakerClosed:
+		log.Info("circuit breaker has closed", "cluster", event.Cluster, "priority", event.Priority, "breaker", event.Breaker)
+	case TrafficRejected:
+		log.Info("traffic has been rejected", "cluster", event.Cluster, "counter", event.Counter, "rejected", event.Rejected)
+	case OutlierEjected:
+		log.Info("endpoint has been ejected by outlier detection", "cluster", event.Cluster, "host", event.Host)
+	case OutlierUnejected:
+		log.Info("endpoint has returned to the load balancing pool", "cluster", event.Cluster, "host", event.Host)
+	}
+}
@@ -0,0 +1,215 @@
+package resiliency_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kong/kuma/app/kuma-dp/pkg/dataplane/resiliency"
+)
+
+var _ = Describe("Watcher", func() {
+
+	var server *httptest.Server
+	var stats string
+	var clusters string
+	var filter string
+	var events []resiliency.Event
+	var watcher *resiliency.Watcher
+
+	BeforeEach(func() {
+		events = nil
+		stats = ""
+		clusters = ""
+		mux := http.NewServeMux()
+		mux.HandleFunc("/stats", func(writer http.ResponseWriter, req *http.Request) {
+			filter = req.URL.Query().Get("filter")
+			_, _ = fmt.Fprint(writer, stats)
+		})
+		mux.HandleFunc("/clusters", func(writer http.ResponseWriter, req *http.Request) {
+			_, _ = fmt.Fprint(writer, clusters)
+		})
+		server = httptest.NewServer(mux)
+		watcher = resiliency.New(resiliency.Opts{
+			AdminAddress:  strings.TrimPrefix(server.URL, "http://"),
+			CheckInterval: time.Second,
+			OnEvent: func(event resiliency.Event) {
+				events = append(events, event)
+			},
+		})
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should report circuit breakers that open and close", func() {
+		// given
+		stats = `
+cluster.backend.demo.svc:80.circuit_breakers.default.rq_open: 1
+cluster.backend.demo.svc:80.circuit_breakers.default.cx_open: 0
+cluster.web.circuit_breakers.high.rq_pending_open: 0
+`
+		// when
+		err := watcher.Check()
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		// and
+		Expect(filter).ToNot(BeEmpty())
+		// and
+		Expect(events).To(Equal([]resiliency.Event{
+			{Type: resiliency.CircuitBreakerOpened, Cluster: "backend.demo.svc:80", Priority: "default", Breaker: "rq_open"},
+		}))
+
+		// given
+		events = nil
+		stats = `
+cluster.backend.demo.svc:80.circuit_breakers.default.rq_open: 0
+cluster.backend.demo.svc:80.circuit_breakers.default.cx_open: 0
+cluster.web.circuit_breakers.high.rq_pending_open: 1
+`
+		// when
+		err = watcher.Check()
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		// and
+		Expect(events).To(Equal([]resiliency.Event{
+			{Type: resiliency.CircuitBreakerClosed, Cluster: "backend.demo.svc:80", Priority: "default", Breaker: "rq_open"},
+			{Type: resiliency.CircuitBreakerOpened, Cluster: "web", Priority: "high", Breaker: "rq_pending_open"},
+		}))
+	})
+
+	It("should report HTTP responses received since the previous check", func() {
+		// given
+		stats = `
+cluster.backend.upstream_rq_completed: 10
+cluster.backend.upstream_rq_5xx: 1
+cluster.web.upstream_rq_completed: 5
+cluster.web.upstream_rq_5xx: 0
+`
+		// when
+		Expect(watcher.Check()).To(Succeed())
+
+		// then
+		Expect(events).To(Equal([]resiliency.Event{
+			{Type: resiliency.ResponsesReceived, Responses: 15, ServerErrors: 1},
+		}))
+
+		// given
+		events = nil
+		stats = `
+cluster.backend.upstream_rq_completed: 30
+cluster.backend.upstream_rq_5xx: 4
+cluster.web.upstream_rq_completed: 5
+cluster.web.upstream_rq_5xx: 0
+`
+		// when
+		Expect(watcher.Check()).To(Succeed())
+
+		// then
+		Expect(events).To(Equal([]resiliency.Event{
+			{Type: resiliency.ResponsesReceived, Responses: 20, ServerErrors: 3},
+		}))
+	})
+
+	It("should report rejected traffic since the previous check", func() {
+		// given
+		stats = `
+cluster.backend.upstream_rq_retry_overflow: 3
+cluster.backend.upstream_cx_overflow: 0
+`
+		// when
+		err := watcher.Check()
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		// and
+		Expect(events).To(Equal([]resiliency.Event{
+			{Type: resiliency.TrafficRejected, Cluster: "backend", Counter: resiliency.RetryOverflow, Rejected: 3},
+		}))
+
+		// given
+		events = nil
+		stats = `
+cluster.backend.upstream_rq_retry_overflow: 5
+cluster.backend.upstream_cx_overflow: 0
+`
+		// when
+		err = watcher.Check()
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		// and
+		Expect(events).To(Equal([]resiliency.Event{
+			{Type: resiliency.TrafficRejected, Cluster: "backend", Counter: resiliency.RetryOverflow, Rejected: 2},
+		}))
+
+		// given
+		events = nil
+
+		// when
+		err = watcher.Check()
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		// and
+		Expect(events).To(BeEmpty())
+	})
+
+	It("should report endpoints ejected by outlier detection", func() {
+		// given
+		clusters = `
+backend::default_priority::max_connections::1024
+backend::192.168.0.1:8080::cx_active::1
+backend::192.168.0.1:8080::health_flags::/failed_outlier_check
+backend::192.168.0.2:8080::health_flags::healthy
+`
+		// when
+		err := watcher.Check()
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		// and
+		Expect(events).To(Equal([]resiliency.Event{
+			{Type: resiliency.OutlierEjected, Cluster: "backend", Host: "192.168.0.1:8080"},
+		}))
+
+		// given
+		events = nil
+		clusters = `
+backend::192.168.0.1:8080::health_flags::healthy
+backend::192.168.0.2:8080::health_flags::/failed_active_hc/failed_outlier_check
+backend::192.168.0.3:8080::health_flags::healthy
+`
+		// when
+		err = watcher.Check()
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		// and
+		Expect(events).To(Equal([]resiliency.Event{
+			{Type: resiliency.OutlierEjected, Cluster: "backend", Host: "192.168.0.2:8080"},
+			{Type: resiliency.OutlierUnejected, Cluster: "backend", Host: "192.168.0.1:8080"},
+		}))
+	})
+
+	It("should fail when Envoy Admin is not available", func() {
+		// given
+		server.Close()
+
+		// when
+		err := watcher.Check()
+
+		// then
+		Expect(err).To(HaveOccurred())
+		// and
+		Expect(err.Error()).To(ContainSubstring("failed to fetch stats of Envoy"))
+	})
+})
@@ -0,0 +1,215 @@
+package certs
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	kube_admission "k8s.io/api/admissionregistration/v1beta1"
+	kube_core "k8s.io/api/core/v1"
+	kube_apierrs "k8s.io/apimachinery/pkg/api/errors"
+	kube_meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kube_types "k8s.io/apimachinery/pkg/types"
+	kube_client "sigs.k8s.io/controller-runtime/pkg/client"
+	kube_manager "sigs.k8s.io/controller-runtime/pkg/manager"
+
+	kuma_injector_conf "github.com/Kong/kuma/pkg/config/app/kuma-injector"
+	"github.com/Kong/kuma/pkg/core"
+	util_tls "github.com/Kong/kuma/pkg/tls"
+)
+
+const (
+	certFile = "tls.crt"
+	keyFile  = "tls.key"
+	// previousCertFile holds a certificate that has been replaced by the current one.
+	// It stays in CA bundle until it expires to let replicas, that still serve it, keep working.
+	previousCertFile = "previous.crt"
+)
+
+// CertRotator keeps TLS certificate of the Admission WebHook server valid.
+//
+// A self-signed certificate is kept in a Secret to be shared by all replicas of Kuma Injector.
+// The Secret is created if it doesn't exist yet, although deployments are expected to create it upfront,
+// so that access of Kuma Injector can be restricted to that very Secret.
+// Once the certificate comes close to expiration, it gets replaced by a new one.
+// The current certificate is written into CertDir and CA bundle of the Mutating WebHook Configuration
+// is updated to trust it.
+type CertRotator struct {
+	Client  kube_client.Client
+	Config  kuma_injector_conf.CertRotation
+	CertDir string
+	Log     logr.Logger
+}
+
+var _ kube_manager.Runnable = &CertRotator{}
+
+// Start periodically checks TLS certificate until a given channel is closed.
+func (r *CertRotator) Start(stop <-chan struct{}) error {
+	ticker := time.NewTicker(r.Config.CheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := r.Rotate(context.Background()); err != nil {
+				r.Log.Error(err, "unable to rotate TLS certificate")
+			}
+		case <-stop:
+			return nil
+		}
+	}
+}
+
+// Rotate renews TLS certificate if necessary and makes sure that CertDir
+// and the Mutating WebHook Configuration are up-to-date.
+func (r *CertRotator) Rotate(ctx context.Context) error {
+	secret, err := r.ensureSecret(ctx)
+	if err != nil {
+		return err
+	}
+	if err := r.writeCertDir(secret); err != nil {
+		return err
+	}
+	return r.updateCABundle(ctx, secret)
+}
+
+func (r *CertRotator) ensureSecret(ctx context.Context) (*kube_core.Secret, error) {
+	secret := &kube_core.Secret{}
+	key := kube_types.NamespacedName{Namespace: r.Config.SecretNamespace, Name: r.Config.SecretName}
+	err := r.Client.Get(ctx, key, secret)
+	exists := true
+	switch {
+	case kube_apierrs.IsNotFound(err):
+		exists = false
+		secret = &kube_core.Secret{
+			ObjectMeta: kube_meta.ObjectMeta{
+				Namespace: r.Config.SecretNamespace,
+				Name:      r.Config.SecretName,
+			},
+			Type: kube_core.SecretTypeTLS,
+		}
+	case err != nil:
+		return nil, errors.Wrapf(err, "could not get Secret %q", key)
+	}
+
+	if reason := r.renewalReason(secret); reason != "" {
+		r.Log.Info("generating a new TLS certificate", "reason", reason)
+		pair, err := r.newCert()
+		if err != nil {
+			return nil, err
+		}
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		if old := secret.Data[certFile]; len(old) > 0 {
+			secret.Data[previousCertFile] = old
+		}
+		secret.Data[certFile] = pair.CertPEM
+		secret.Data[keyFile] = pair.KeyPEM
+		if exists {
+			err = r.Client.Update(ctx, secret)
+		} else {
+			err = r.Client.Create(ctx, secret)
+		}
+		if err != nil {
+			// e.g., another replica has renewed the certificate at the same time,
+			// in which case its certificate will be picked up on the next check
+			return nil, errors.Wrapf(err, "could not save Secret %q", key)
+		}
+	}
+	return secret, nil
+}
+
+// renewalReason returns why TLS certificate in a given Secret has to be replaced
+// or an empty string if it is still good.
+func (r *CertRotator) renewalReason(secret *kube_core.Secret) string {
+	if len(secret.Data[certFile]) == 0 || len(secret.Data[keyFile]) == 0 {
+		return "certificate is missing"
+	}
+	cert, err := parseCert(secret.Data[certFile])
+	if err != nil {
+		return "certificate is not valid"
+	}
+	if !core.Now().Add(r.Config.RenewBefore).Before(cert.NotAfter) {
+		return fmt.Sprintf("certificate expires at %s", cert.NotAfter.Format(time.RFC3339))
+	}
+	return ""
+}
+
+func (r *CertRotator) newCert() (util_tls.KeyPair, error) {
+	svc := r.Config.ServiceName
+	ns := r.Config.SecretNamespace
+	fqdn := fmt.Sprintf("%s.%s.svc", svc, ns)
+	pair, err := util_tls.NewSelfSignedCertValidFor(r.Config.ValidityPeriod, fqdn, svc, fmt.Sprintf("%s.%s", svc, ns), fqdn)
+	if err != nil {
+		return util_tls.KeyPair{}, errors.Wrap(err, "could not generate TLS certificate")
+	}
+	return pair, nil
+}
+
+func (r *CertRotator) writeCertDir(secret *kube_core.Secret) error {
+	if err := os.MkdirAll(r.CertDir, 0755); err != nil {
+		return errors.Wrapf(err, "could not create directory %q", r.CertDir)
+	}
+	// key goes first to let the https server see a matching pair once certificate changes
+	for _, file := range []string{keyFile, certFile} {
+		path := filepath.Join(r.CertDir, file)
+		if actual, err := ioutil.ReadFile(path); err == nil && bytes.Equal(actual, secret.Data[file]) {
+			continue
+		}
+		if err := ioutil.WriteFile(path, secret.Data[file], 0600); err != nil {
+			return errors.Wrapf(err, "could not write file %q", path)
+		}
+	}
+	return nil
+}
+
+func (r *CertRotator) updateCABundle(ctx context.Context, secret *kube_core.Secret) error {
+	caBundle := r.caBundle(secret)
+	webhookConfig := &kube_admission.MutatingWebhookConfiguration{}
+	if err := r.Client.Get(ctx, kube_types.NamespacedName{Name: r.Config.WebhookConfigurationName}, webhookConfig); err != nil {
+		return errors.Wrapf(err, "could not get MutatingWebhookConfiguration %q", r.Config.WebhookConfigurationName)
+	}
+	changed := false
+	for i := range webhookConfig.Webhooks {
+		if !bytes.Equal(webhookConfig.Webhooks[i].ClientConfig.CABundle, caBundle) {
+			webhookConfig.Webhooks[i].ClientConfig.CABundle = caBundle
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	r.Log.Info("updating CA bundle", "webhookConfiguration", r.Config.WebhookConfigurationName)
+	if err := r.Client.Update(ctx, webhookConfig); err != nil {
+		return errors.Wrapf(err, "could not update MutatingWebhookConfiguration %q", r.Config.WebhookConfigurationName)
+	}
+	return nil
+}
+
+// caBundle returns the current certificate followed by the previous one, unless the latter has already expired.
+func (r *CertRotator) caBundle(secret *kube_core.Secret) []byte {
+	caBundle := append([]byte{}, secret.Data[certFile]...)
+	if previous := secret.Data[previousCertFile]; len(previous) > 0 {
+		if cert, err := parseCert(previous); err == nil && core.Now().Before(cert.NotAfter) {
+			caBundle = append(caBundle, previous...)
+		}
+	}
+	return caBundle
+}
+
+func parseCert(certPEM []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return nil, errors.New("not a PEM-encoded certificate")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
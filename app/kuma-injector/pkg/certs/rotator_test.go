@@ -0,0 +1,185 @@
+package certs_test
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	kube_admission "k8s.io/api/admissionregistration/v1beta1"
+	kube_core "k8s.io/api/core/v1"
+	kube_meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kube_types "k8s.io/apimachinery/pkg/types"
+	kube_scheme "k8s.io/client-go/kubernetes/scheme"
+	kube_client "sigs.k8s.io/controller-runtime/pkg/client"
+	kube_fake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/Kong/kuma/app/kuma-injector/pkg/certs"
+	kuma_injector_conf "github.com/Kong/kuma/pkg/config/app/kuma-injector"
+	"github.com/Kong/kuma/pkg/core"
+)
+
+var _ = Describe("CertRotator", func() {
+
+	var certDir string
+	var kubeClient kube_client.Client
+	var rotator *certs.CertRotator
+	var now time.Time
+
+	BeforeEach(func() {
+		dir, err := ioutil.TempDir("", "kuma-injector-certs")
+		Expect(err).ToNot(HaveOccurred())
+		certDir = filepath.Join(dir, "tls-cert")
+
+		now = time.Now()
+		core.Now = func() time.Time {
+			return now
+		}
+
+		kubeClient = kube_fake.NewFakeClientWithScheme(kube_scheme.Scheme, &kube_admission.MutatingWebhookConfiguration{
+			ObjectMeta: kube_meta.ObjectMeta{
+				Name: "kuma-injector-webhook-configuration",
+			},
+			Webhooks: []kube_admission.Webhook{
+				{Name: "kuma-injector.kuma.io"},
+			},
+		})
+
+		cfg := kuma_injector_conf.DefaultConfig().WebHookServer.CertRotation
+		cfg.Enabled = true
+		rotator = &certs.CertRotator{
+			Client:  kubeClient,
+			Config:  cfg,
+			CertDir: certDir,
+			Log:     core.Log,
+		}
+	})
+
+	AfterEach(func() {
+		core.Now = time.Now
+		Expect(os.RemoveAll(filepath.Dir(certDir))).To(Succeed())
+	})
+
+	secret := func() *kube_core.Secret {
+		secret := &kube_core.Secret{}
+		Expect(kubeClient.Get(context.Background(), kube_types.NamespacedName{Namespace: "kuma-system", Name: "kuma-injector-tls-cert"}, secret)).To(Succeed())
+		return secret
+	}
+
+	caBundle := func() []byte {
+		webhookConfig := &kube_admission.MutatingWebhookConfiguration{}
+		Expect(kubeClient.Get(context.Background(), kube_types.NamespacedName{Name: "kuma-injector-webhook-configuration"}, webhookConfig)).To(Succeed())
+		return webhookConfig.Webhooks[0].ClientConfig.CABundle
+	}
+
+	readFile := func(name string) []byte {
+		data, err := ioutil.ReadFile(filepath.Join(certDir, name))
+		Expect(err).ToNot(HaveOccurred())
+		return data
+	}
+
+	It("should generate TLS certificate if there is none", func() {
+		// when
+		err := rotator.Rotate(context.Background())
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+
+		// and
+		actual := secret()
+		Expect(actual.Type).To(Equal(kube_core.SecretTypeTLS))
+		Expect(actual.Data).To(HaveKey("tls.key"))
+		Expect(actual.Data).ToNot(HaveKey("previous.crt"))
+
+		// and
+		block, _ := pem.Decode(actual.Data["tls.crt"])
+		Expect(block).ToNot(BeNil())
+		cert, err := x509.ParseCertificate(block.Bytes)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cert.Subject.CommonName).To(Equal("kuma-injector.kuma-system.svc"))
+		Expect(cert.DNSNames).To(ConsistOf("kuma-injector", "kuma-injector.kuma-system", "kuma-injector.kuma-system.svc"))
+		Expect(cert.NotAfter).To(BeTemporally("~", now.Add(365*24*time.Hour), time.Minute))
+
+		// and
+		Expect(readFile("tls.crt")).To(Equal(actual.Data["tls.crt"]))
+		Expect(readFile("tls.key")).To(Equal(actual.Data["tls.key"]))
+		Expect(caBundle()).To(Equal(actual.Data["tls.crt"]))
+	})
+
+	It("should keep TLS certificate that is not about to expire", func() {
+		// given
+		Expect(rotator.Rotate(context.Background())).To(Succeed())
+		before := secret()
+
+		// when
+		now = now.Add(300 * 24 * time.Hour)
+		err := rotator.Rotate(context.Background())
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(secret().Data).To(Equal(before.Data))
+		Expect(caBundle()).To(Equal(before.Data["tls.crt"]))
+	})
+
+	It("should renew TLS certificate that is about to expire", func() {
+		// given
+		Expect(rotator.Rotate(context.Background())).To(Succeed())
+		before := secret()
+
+		// when
+		now = now.Add(340 * 24 * time.Hour)
+		err := rotator.Rotate(context.Background())
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+
+		// and
+		after := secret()
+		Expect(after.Data["tls.crt"]).ToNot(Equal(before.Data["tls.crt"]))
+		Expect(after.Data["tls.key"]).ToNot(Equal(before.Data["tls.key"]))
+		Expect(after.Data["previous.crt"]).To(Equal(before.Data["tls.crt"]))
+
+		// and
+		Expect(readFile("tls.crt")).To(Equal(after.Data["tls.crt"]))
+		Expect(readFile("tls.key")).To(Equal(after.Data["tls.key"]))
+
+		// and previous certificate is still trusted
+		Expect(caBundle()).To(Equal(append(append([]byte{}, after.Data["tls.crt"]...), before.Data["tls.crt"]...)))
+	})
+
+	It("should stop trusting previous TLS certificate once it has expired", func() {
+		// given
+		Expect(rotator.Rotate(context.Background())).To(Succeed())
+		now = now.Add(340 * 24 * time.Hour)
+		Expect(rotator.Rotate(context.Background())).To(Succeed())
+
+		// when
+		now = now.Add(30 * 24 * time.Hour)
+		err := rotator.Rotate(context.Background())
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(caBundle()).To(Equal(secret().Data["tls.crt"]))
+	})
+
+	It("should reuse TLS certificate generated by another replica", func() {
+		// given
+		Expect(rotator.Rotate(context.Background())).To(Succeed())
+		expected := secret()
+		Expect(os.RemoveAll(certDir)).To(Succeed())
+
+		// when
+		err := rotator.Rotate(context.Background())
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(readFile("tls.crt")).To(Equal(expected.Data["tls.crt"]))
+		Expect(readFile("tls.key")).To(Equal(expected.Data["tls.key"]))
+	})
+})
@@ -2,6 +2,9 @@ package injector
 
 import (
 	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
 
 	"github.com/Kong/kuma/app/kuma-injector/pkg/injector/metadata"
 	config "github.com/Kong/kuma/pkg/config/app/kuma-injector"
@@ -13,8 +16,18 @@ import (
 const (
 	KumaSidecarContainerName = "kuma-sidecar"
 	KumaInitContainerName    = "kuma-init"
+
+	// serviceAccountTokenMountPath is where Kubernetes mounts a token of the Pod's ServiceAccount.
+	serviceAccountTokenMountPath = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+	// KumaSidecarTmpVolumeName is a name of a volume that kuma-dp writes bootstrap config of Envoy to
+	// when the sidecar container has a read-only root filesystem.
+	KumaSidecarTmpVolumeName = "kuma-sidecar-tmp"
+	sidecarTmpMountPath      = "/tmp"
 )
 
+var runAsNonRoot = true
+
 func New(cfg config.Injector) *KumaInjector {
 	return &KumaInjector{
 		cfg: cfg,
@@ -30,13 +43,31 @@ func (i *KumaInjector) InjectKuma(pod *kube_core.Pod) error {
 	if pod.Spec.Containers == nil {
 		pod.Spec.Containers = []kube_core.Container{}
 	}
-	pod.Spec.Containers = append(pod.Spec.Containers, i.NewSidecarContainer(pod))
+	sidecar, err := i.NewSidecarContainer(pod)
+	if err != nil {
+		return err
+	}
+	pod.Spec.Containers = append(pod.Spec.Containers, sidecar)
+	for _, mount := range sidecar.VolumeMounts {
+		if mount.Name == KumaSidecarTmpVolumeName {
+			pod.Spec.Volumes = append(pod.Spec.Volumes, kube_core.Volume{
+				Name: KumaSidecarTmpVolumeName,
+				VolumeSource: kube_core.VolumeSource{
+					EmptyDir: &kube_core.EmptyDirVolumeSource{},
+				},
+			})
+		}
+	}
 
 	// init container
 	if pod.Spec.InitContainers == nil {
 		pod.Spec.InitContainers = []kube_core.Container{}
 	}
-	pod.Spec.InitContainers = append(pod.Spec.InitContainers, i.NewInitContainer())
+	init, err := i.NewInitContainer(pod)
+	if err != nil {
+		return err
+	}
+	pod.Spec.InitContainers = append(pod.Spec.InitContainers, init)
 
 	// annotations
 	if pod.Annotations == nil {
@@ -48,8 +79,50 @@ func (i *KumaInjector) InjectKuma(pod *kube_core.Pod) error {
 	return nil
 }
 
-func (i *KumaInjector) NewSidecarContainer(pod *kube_core.Pod) kube_core.Container {
+func (i *KumaInjector) NewSidecarContainer(pod *kube_core.Pod) (kube_core.Container, error) {
 	mesh := metadata.GetMesh(pod) // either user-defined value or default
+	container := i.newSidecarContainer(mesh)
+
+	resources, securityContext := i.cfg.NamespaceOverrides[pod.Namespace].SidecarContainer.Apply(i.cfg.SidecarContainer.Resources, i.cfg.SidecarContainer.SecurityContext)
+	requirements, err := newResourceRequirements(resources.Requests, resources.Limits)
+	if err != nil {
+		return kube_core.Container{}, err
+	}
+	container.Resources = requirements
+	container.SecurityContext.ReadOnlyRootFilesystem = securityContext.ReadOnlyRootFilesystem
+	container.SecurityContext.AllowPrivilegeEscalation = securityContext.AllowPrivilegeEscalation
+	// kuma-dp has to be able to write bootstrap config of Envoy somewhere
+	if readOnly := securityContext.ReadOnlyRootFilesystem; readOnly != nil && *readOnly {
+		container.VolumeMounts = append(container.VolumeMounts, kube_core.VolumeMount{
+			Name:      KumaSidecarTmpVolumeName,
+			MountPath: sidecarTmpMountPath,
+		})
+	}
+
+	// ServiceAccount token is mounted by Kubernetes only into containers that existed
+	// before the admission webhook was called, that is why we have to mount it ourselves
+	if mount := findServiceAccountTokenMount(pod); mount != nil {
+		container.VolumeMounts = append(container.VolumeMounts, *mount)
+		container.Env = append(container.Env, kube_core.EnvVar{
+			Name:  "KUMA_DATAPLANE_RUNTIME_TOKEN_PATH",
+			Value: serviceAccountTokenMountPath + "/token",
+		})
+	}
+	return container, nil
+}
+
+func findServiceAccountTokenMount(pod *kube_core.Pod) *kube_core.VolumeMount {
+	for _, container := range pod.Spec.Containers {
+		for _, mount := range container.VolumeMounts {
+			if mount.MountPath == serviceAccountTokenMountPath {
+				return &mount
+			}
+		}
+	}
+	return nil
+}
+
+func (i *KumaInjector) newSidecarContainer(mesh string) kube_core.Container {
 	return kube_core.Container{
 		Name:            KumaSidecarContainerName,
 		Image:           i.cfg.SidecarContainer.Image,
@@ -106,8 +179,15 @@ func (i *KumaInjector) NewSidecarContainer(pod *kube_core.Pod) kube_core.Contain
 			},
 		},
 		SecurityContext: &kube_core.SecurityContext{
-			RunAsUser:  &i.cfg.SidecarContainer.UID,
-			RunAsGroup: &i.cfg.SidecarContainer.GID,
+			RunAsUser:    &i.cfg.SidecarContainer.UID,
+			RunAsGroup:   &i.cfg.SidecarContainer.GID,
+			RunAsNonRoot: &runAsNonRoot,
+			// neither kuma-dp nor Envoy need any capabilities
+			Capabilities: &kube_core.Capabilities{
+				Drop: []kube_core.Capability{
+					kube_core.Capability("ALL"),
+				},
+			},
 		},
 		LivenessProbe: &kube_core.Probe{
 			Handler: kube_core.Handler{
@@ -131,52 +211,86 @@ func (i *KumaInjector) NewSidecarContainer(pod *kube_core.Pod) kube_core.Contain
 				},
 			},
 		},
-		Resources: kube_core.ResourceRequirements{
-			Limits: kube_core.ResourceList{
-				kube_core.ResourceCPU:    *kube_api.NewScaledQuantity(50, kube_api.Milli),
-				kube_core.ResourceMemory: *kube_api.NewScaledQuantity(64, kube_api.Mega),
-			},
-		},
 	}
 }
 
-func (i *KumaInjector) NewInitContainer() kube_core.Container {
+func (i *KumaInjector) NewInitContainer(pod *kube_core.Pod) (kube_core.Container, error) {
+	resources, securityContext := i.cfg.NamespaceOverrides[pod.Namespace].InitContainer.Apply(i.cfg.InitContainer.Resources, i.cfg.InitContainer.SecurityContext)
+	requirements, err := newResourceRequirements(resources.Requests, resources.Limits)
+	if err != nil {
+		return kube_core.Container{}, err
+	}
+	args := []string{
+		"-p",
+		fmt.Sprintf("%d", i.cfg.SidecarContainer.RedirectPort),
+		"-u",
+		fmt.Sprintf("%d", i.cfg.SidecarContainer.UID),
+		"-g",
+		fmt.Sprintf("%d", i.cfg.SidecarContainer.GID),
+		"-m",
+		"REDIRECT",
+		"-i",
+		"*",
+		"-b",
+		"*",
+	}
+	excludedInboundPorts, err := metadata.GetTransparentProxyingExcludedInboundPorts(pod)
+	if err != nil {
+		return kube_core.Container{}, err
+	}
+	if len(excludedInboundPorts) > 0 {
+		args = append(args, "-d", strings.Join(excludedInboundPorts, ","))
+	}
+	excludedOutboundCIDRs, err := metadata.GetTransparentProxyingExcludedOutboundCIDRs(pod)
+	if err != nil {
+		return kube_core.Container{}, err
+	}
+	if len(excludedOutboundCIDRs) > 0 {
+		args = append(args, "-x", strings.Join(excludedOutboundCIDRs, ","))
+	}
 	return kube_core.Container{
 		Name:            KumaInitContainerName,
 		Image:           i.cfg.InitContainer.Image,
 		ImagePullPolicy: kube_core.PullIfNotPresent,
-		Args: []string{
-			"-p",
-			fmt.Sprintf("%d", i.cfg.SidecarContainer.RedirectPort),
-			"-u",
-			fmt.Sprintf("%d", i.cfg.SidecarContainer.UID),
-			"-g",
-			fmt.Sprintf("%d", i.cfg.SidecarContainer.GID),
-			"-m",
-			"REDIRECT",
-			"-i",
-			"*",
-			"-b",
-			"*",
-		},
+		Args:            args,
 		SecurityContext: &kube_core.SecurityContext{
 			Capabilities: &kube_core.Capabilities{
 				Add: []kube_core.Capability{
 					kube_core.Capability("NET_ADMIN"),
 				},
 			},
+			ReadOnlyRootFilesystem:   securityContext.ReadOnlyRootFilesystem,
+			AllowPrivilegeEscalation: securityContext.AllowPrivilegeEscalation,
 		},
-		Resources: kube_core.ResourceRequirements{
-			Limits: kube_core.ResourceList{
-				kube_core.ResourceCPU:    *kube_api.NewScaledQuantity(100, kube_api.Milli),
-				kube_core.ResourceMemory: *kube_api.NewScaledQuantity(50, kube_api.Mega),
-			},
-			Requests: kube_core.ResourceList{
-				kube_core.ResourceCPU:    *kube_api.NewScaledQuantity(10, kube_api.Milli),
-				kube_core.ResourceMemory: *kube_api.NewScaledQuantity(10, kube_api.Mega),
-			},
-		},
+		Resources: requirements,
+	}, nil
+}
+
+func newResourceRequirements(requests, limits map[string]string) (kube_core.ResourceRequirements, error) {
+	requirements := kube_core.ResourceRequirements{}
+	var err error
+	if requirements.Requests, err = newResourceList(requests); err != nil {
+		return requirements, err
+	}
+	if requirements.Limits, err = newResourceList(limits); err != nil {
+		return requirements, err
+	}
+	return requirements, nil
+}
+
+func newResourceList(quantities map[string]string) (kube_core.ResourceList, error) {
+	if len(quantities) == 0 {
+		return nil, nil
+	}
+	list := kube_core.ResourceList{}
+	for name, value := range quantities {
+		quantity, err := kube_api.ParseQuantity(value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid quantity of %q", name)
+		}
+		list[kube_core.ResourceName(name)] = quantity
 	}
+	return list, nil
 }
 
 func (i *KumaInjector) NewAnnotations(pod *kube_core.Pod) map[string]string {
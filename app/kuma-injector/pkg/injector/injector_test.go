@@ -82,5 +82,33 @@ var _ = Describe("Injector", func() {
 		Entry("04. Pod with explicitly selected Mesh", testCase{
 			num: "04",
 		}),
+		Entry("05. Pod in a Namespace with overrides", testCase{
+			num: "05",
+		}),
+		Entry("06. Pod with ports and CIDRs excluded from transparent proxying", testCase{
+			num: "06",
+		}),
+	)
+
+	DescribeTable("should reject a Pod with invalid exclusions from transparent proxying",
+		func(annotation, value, expectedErr string) {
+			// given
+			pod := &kube_core.Pod{}
+			pod.Annotations = map[string]string{
+				annotation: value,
+			}
+
+			// when
+			err := injector.InjectKuma(pod)
+
+			// then
+			Expect(err).To(MatchError(expectedErr))
+		},
+		Entry("invalid port", "kuma.io/transparent-proxying-excluded-inbound-ports", "8081,http",
+			`annotation "kuma.io/transparent-proxying-excluded-inbound-ports" contains an invalid port "http"`),
+		Entry("port out of range", "kuma.io/transparent-proxying-excluded-inbound-ports", "65536",
+			`annotation "kuma.io/transparent-proxying-excluded-inbound-ports" contains an invalid port "65536"`),
+		Entry("invalid CIDR", "kuma.io/transparent-proxying-excluded-outbound-cidrs", "169.254.169.254",
+			`annotation "kuma.io/transparent-proxying-excluded-outbound-cidrs" contains an invalid CIDR "169.254.169.254"`),
 	)
 })
@@ -6,6 +6,17 @@ const (
 	// in order to associate them with a particular Mesh.
 	// Annotation value must be a name of a Mesh resource.
 	KumaMeshAnnotation = "kuma.io/mesh"
+
+	// KumaTransparentProxyingExcludedInboundPortsAnnotation defines an annotation that can be put on Pods
+	// in order to keep incoming traffic to particular ports away from Kuma Sidecar.
+	// Annotation value must be a comma-separated list of ports, e.g. "8081,9090".
+	KumaTransparentProxyingExcludedInboundPortsAnnotation = "kuma.io/transparent-proxying-excluded-inbound-ports"
+
+	// KumaTransparentProxyingExcludedOutboundCIDRsAnnotation defines an annotation that can be put on Pods
+	// in order to keep outgoing traffic to particular IP ranges away from Kuma Sidecar,
+	// e.g. traffic to node-local agents or cloud metadata endpoints.
+	// Annotation value must be a comma-separated list of CIDRs, e.g. "169.254.169.254/32,10.0.0.0/8".
+	KumaTransparentProxyingExcludedOutboundCIDRsAnnotation = "kuma.io/transparent-proxying-excluded-outbound-cidrs"
 )
 
 // Annotations that are being automatically set by the Kuma Sidecar Injector.
@@ -1,7 +1,11 @@
 package metadata
 
 import (
+	"net"
 	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
 
 	core_model "github.com/Kong/kuma/pkg/core/resources/model"
 
@@ -30,3 +34,33 @@ func GetTransparentProxyingPort(pod *kube_core.Pod) uint32 {
 	}
 	return uint32(port)
 }
+
+func GetTransparentProxyingExcludedInboundPorts(pod *kube_core.Pod) ([]string, error) {
+	ports := splitList(pod.Annotations[KumaTransparentProxyingExcludedInboundPortsAnnotation])
+	for _, port := range ports {
+		if num, err := strconv.ParseUint(port, 10, 16); err != nil || num == 0 {
+			return nil, errors.Errorf("annotation %q contains an invalid port %q", KumaTransparentProxyingExcludedInboundPortsAnnotation, port)
+		}
+	}
+	return ports, nil
+}
+
+func GetTransparentProxyingExcludedOutboundCIDRs(pod *kube_core.Pod) ([]string, error) {
+	cidrs := splitList(pod.Annotations[KumaTransparentProxyingExcludedOutboundCIDRsAnnotation])
+	for _, cidr := range cidrs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return nil, errors.Errorf("annotation %q contains an invalid CIDR %q", KumaTransparentProxyingExcludedOutboundCIDRsAnnotation, cidr)
+		}
+	}
+	return cidrs, nil
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
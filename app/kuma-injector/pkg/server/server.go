@@ -1,16 +1,27 @@
 package server
 
 import (
+	"context"
 	"net/http"
 
+	"github.com/pkg/errors"
+
+	"github.com/Kong/kuma/app/kuma-injector/pkg/certs"
 	"github.com/Kong/kuma/app/kuma-injector/pkg/injector"
 	kuma_injector_conf "github.com/Kong/kuma/pkg/config/app/kuma-injector"
+	"github.com/Kong/kuma/pkg/core"
 
+	kube_client "sigs.k8s.io/controller-runtime/pkg/client"
 	kube_manager "sigs.k8s.io/controller-runtime/pkg/manager"
 	kube_webhook "sigs.k8s.io/controller-runtime/pkg/webhook"
 )
 
 func Setup(mgr kube_manager.Manager, cfg *kuma_injector_conf.Config) error {
+	if cfg.WebHookServer.CertRotation.Enabled {
+		if err := setupCertRotation(mgr, cfg); err != nil {
+			return err
+		}
+	}
 	webhookServer := &kube_webhook.Server{
 		Host:    cfg.WebHookServer.Address,
 		Port:    int(cfg.WebHookServer.Port),
@@ -25,3 +36,21 @@ func Setup(mgr kube_manager.Manager, cfg *kuma_injector_conf.Config) error {
 	})
 	return mgr.Add(webhookServer)
 }
+
+func setupCertRotation(mgr kube_manager.Manager, cfg *kuma_injector_conf.Config) error {
+	// cache of the manager is not started yet, while TLS certificate must be in place before the https server starts
+	client, err := kube_client.New(mgr.GetConfig(), kube_client.Options{Scheme: mgr.GetScheme(), Mapper: mgr.GetRESTMapper()})
+	if err != nil {
+		return errors.Wrap(err, "could not create Kubernetes client")
+	}
+	rotator := &certs.CertRotator{
+		Client:  client,
+		Config:  cfg.WebHookServer.CertRotation,
+		CertDir: cfg.WebHookServer.CertDir,
+		Log:     core.Log.WithName("kuma-injector").WithName("cert-rotator"),
+	}
+	if err := rotator.Rotate(context.Background()); err != nil {
+		return errors.Wrap(err, "could not set up TLS certificate")
+	}
+	return mgr.Add(rotator)
+}
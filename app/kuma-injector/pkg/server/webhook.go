@@ -34,9 +34,16 @@ func (h *podMutatingHandler) Handle(ctx context.Context, req kube_webhook.Admiss
 	if err := json.Unmarshal(req.Object.Raw, &pod); err != nil {
 		return kube_admission.Errored(http.StatusBadRequest, err)
 	}
+	// Pods created by controllers, e.g. ReplicaSet, don't have a Namespace set at the time of admission.
+	// Namespace is set temporarily to let the mutator apply Namespace-specific settings
+	namespace := pod.Namespace
+	if namespace == "" {
+		pod.Namespace = req.Namespace
+	}
 	if err := h.mutator(&pod); err != nil {
 		return kube_admission.Errored(http.StatusInternalServerError, err)
 	}
+	pod.Namespace = namespace
 	mutatedRaw, err := json.Marshal(pod)
 	if err != nil {
 		return kube_admission.Errored(http.StatusInternalServerError, err)
@@ -84,7 +84,7 @@ func upsert(rs store.ResourceStore, res model.Resource) error {
 	meta := res.GetMeta()
 	if err := rs.Get(context.Background(), newRes, store.GetByKey(meta.GetNamespace(), meta.GetName(), meta.GetMesh())); err != nil {
 		if store.IsResourceNotFound(err) {
-			return rs.Create(context.Background(), res, store.CreateByKey(meta.GetNamespace(), meta.GetName(), meta.GetMesh()))
+			return rs.Create(context.Background(), res, store.CreateByKey(meta.GetNamespace(), meta.GetName(), meta.GetMesh()), store.CreateWithLabels(meta.GetLabels()))
 		} else {
 			return err
 		}
@@ -92,7 +92,12 @@ func upsert(rs store.ResourceStore, res model.Resource) error {
 	if err := newRes.SetSpec(res.GetSpec()); err != nil {
 		return err
 	}
-	return rs.Update(context.Background(), newRes)
+	// labels are replaced by the ones from the file, even if there are none
+	labels := meta.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	return rs.Update(context.Background(), newRes, store.UpdateWithLabels(labels))
 }
 
 func parseResource(bytes []byte) (model.Resource, error) {
@@ -114,8 +119,9 @@ func parseResource(bytes []byte) (model.Resource, error) {
 		return nil, err
 	}
 	resource.SetMeta(meta{
-		Name: resMeta.Name,
-		Mesh: resMeta.Mesh,
+		Name:   resMeta.Name,
+		Mesh:   resMeta.Mesh,
+		Labels: resMeta.Labels,
 	})
 	return resource, nil
 }
@@ -123,8 +129,9 @@ func parseResource(bytes []byte) (model.Resource, error) {
 var _ model.ResourceMeta = &meta{}
 
 type meta struct {
-	Name string
-	Mesh string
+	Name   string
+	Mesh   string
+	Labels map[string]string
 }
 
 func (m meta) GetName() string {
@@ -142,3 +149,7 @@ func (m meta) GetVersion() string {
 func (m meta) GetMesh() string {
 	return m.Mesh
 }
+
+func (m meta) GetLabels() map[string]string {
+	return m.Labels
+}
@@ -0,0 +1,221 @@
+package convert
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	kube_core "k8s.io/api/core/v1"
+	kube_meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kube_yaml "k8s.io/apimachinery/pkg/util/yaml"
+
+	kumactl_cmd "github.com/Kong/kuma/app/kumactl/pkg/cmd"
+	"github.com/Kong/kuma/pkg/core/resources/model"
+	rest_types "github.com/Kong/kuma/pkg/core/resources/model/rest"
+	k8s_controllers "github.com/Kong/kuma/pkg/plugins/discovery/k8s/controllers"
+	"github.com/Kong/kuma/pkg/smi"
+)
+
+const istioNetworkingGroup = "networking.istio.io"
+
+type convertContext struct {
+	*kumactl_cmd.RootContext
+
+	args struct {
+		file string
+	}
+}
+
+func NewConvertCmd(pctx *kumactl_cmd.RootContext) *cobra.Command {
+	ctx := &convertContext{RootContext: pctx}
+	cmd := &cobra.Command{
+		Use:   "convert",
+		Short: "Convert SMI and Istio objects into Kuma policies",
+		Long: `Convert SMI and Istio objects into Kuma policies.
+
+Supported objects:
+  * SMI TrafficTarget is converted into TrafficPermission
+
+ServiceAccounts referenced by SMI TrafficTarget are resolved into the "service" tags
+of the Pods that run under them. That is why Pods and Services have to be given
+along with TrafficTargets, e.g.
+
+  kubectl get pods,services,traffictargets --all-namespaces -o yaml | kumactl convert -f -
+
+Objects that have no equivalent in Kuma are skipped with a warning.
+
+Consul Connect intentions are converted by the "consul-intentions" sub-command.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			var in io.Reader
+			if ctx.args.file == "" || ctx.args.file == "-" {
+				in = cmd.InOrStdin()
+			} else {
+				file, err := os.Open(ctx.args.file)
+				if err != nil {
+					return errors.Wrap(err, "error while reading provided file")
+				}
+				defer file.Close()
+				in = file
+			}
+
+			resources, warnings, err := convert(in, pctx.CurrentMesh())
+			if err != nil {
+				return err
+			}
+			for _, warning := range warnings {
+				fmt.Fprintf(cmd.ErrOrStderr(), "WARNING: %s\n", warning)
+			}
+			return printResources(resources, cmd.OutOrStdout())
+		},
+	}
+	cmd.PersistentFlags().StringVarP(&ctx.args.file, "file", "f", "", "Path to a file with objects to convert")
+	// sub-commands
+	cmd.AddCommand(newConvertConsulIntentionsCmd(ctx))
+	return cmd
+}
+
+func convert(in io.Reader, mesh string) ([]model.Resource, []string, error) {
+	docs, err := readObjects(in)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Pods and Services are only used to resolve ServiceAccounts into `service` tags
+	resolver := &objectsResolver{
+		pods:     &kube_core.PodList{},
+		services: &kube_core.ServiceList{},
+	}
+	for _, doc := range docs {
+		switch gvk := doc.GroupVersionKind(); {
+		case gvk.Group == kube_core.GroupName && gvk.Kind == "Pod":
+			pod := kube_core.Pod{}
+			if err := yaml.Unmarshal(doc.raw, &pod); err != nil {
+				return nil, nil, errors.Wrapf(err, "%s is not valid", doc.id())
+			}
+			resolver.pods.Items = append(resolver.pods.Items, pod)
+		case gvk.Group == kube_core.GroupName && gvk.Kind == "Service":
+			svc := kube_core.Service{}
+			if err := yaml.Unmarshal(doc.raw, &svc); err != nil {
+				return nil, nil, errors.Wrapf(err, "%s is not valid", doc.id())
+			}
+			resolver.services.Items = append(resolver.services.Items, svc)
+		}
+	}
+
+	var resources []model.Resource
+	var warnings []string
+	for _, doc := range docs {
+		id := doc.id()
+		switch gvk := doc.GroupVersionKind(); {
+		case gvk.Group == kube_core.GroupName && (gvk.Kind == "Pod" || gvk.Kind == "Service"):
+			continue
+		case gvk.Group == smi.AccessGroup && gvk.Kind == smi.TrafficTargetKind:
+			target := &smi.TrafficTarget{}
+			if err := yaml.Unmarshal(doc.raw, target); err != nil {
+				return nil, nil, errors.Wrapf(err, "%s is not valid", id)
+			}
+			permission, targetWarnings, err := smi.ConvertTrafficTarget(target, mesh, resolver)
+			if err != nil {
+				return nil, nil, errors.Wrapf(err, "failed to convert %s", id)
+			}
+			for _, warning := range targetWarnings {
+				warnings = append(warnings, fmt.Sprintf("%s: %s", id, warning))
+			}
+			resources = append(resources, permission)
+		case gvk.Group == smi.SplitGroup, gvk.Group == istioNetworkingGroup:
+			warnings = append(warnings, fmt.Sprintf("%s: skipped since there is no equivalent policy in Kuma", id))
+		default:
+			warnings = append(warnings, fmt.Sprintf("%s: skipped since objects of apiVersion %q are not supported", id, doc.APIVersion))
+		}
+	}
+	return resources, warnings, nil
+}
+
+type object struct {
+	kube_meta.TypeMeta   `json:",inline"`
+	kube_meta.ObjectMeta `json:"metadata,omitempty"`
+
+	raw []byte
+}
+
+func (o *object) id() string {
+	return fmt.Sprintf("%s %q", o.Kind, o.Name)
+}
+
+// readObjects reads all objects from a multi-document YAML.
+// Items of a List, e.g. the one printed by `kubectl get -o yaml`, are read as separate objects.
+func readObjects(in io.Reader) ([]*object, error) {
+	var objects []*object
+	reader := kube_yaml.NewYAMLReader(bufio.NewReader(in))
+	for {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "error while reading provided objects")
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+		obj := &object{}
+		if err := yaml.Unmarshal(doc, obj); err != nil {
+			return nil, errors.Wrap(err, "YAML contains invalid object")
+		}
+		if obj.APIVersion != "v1" || obj.Kind != "List" {
+			obj.raw = doc
+			objects = append(objects, obj)
+			continue
+		}
+		list := struct {
+			Items []json.RawMessage `json:"items"`
+		}{}
+		if err := yaml.Unmarshal(doc, &list); err != nil {
+			return nil, errors.Wrap(err, "YAML contains invalid object")
+		}
+		for _, raw := range list.Items {
+			item := &object{raw: raw}
+			if err := yaml.Unmarshal(raw, item); err != nil {
+				return nil, errors.Wrap(err, "YAML contains invalid object")
+			}
+			objects = append(objects, item)
+		}
+	}
+	return objects, nil
+}
+
+// objectsResolver resolves ServiceAccounts using Pods and Services given along with objects to convert.
+type objectsResolver struct {
+	pods     *kube_core.PodList
+	services *kube_core.ServiceList
+}
+
+var _ smi.ServiceTagResolver = &objectsResolver{}
+
+func (r *objectsResolver) ServiceTags(namespace, serviceAccount string) ([]string, error) {
+	return k8s_controllers.ServiceTagsFor(namespace, serviceAccount, r.pods, r.services)
+}
+
+func printResources(resources []model.Resource, out io.Writer) error {
+	docs := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		content, err := json.Marshal(rest_types.From.Resource(resource))
+		if err != nil {
+			return err
+		}
+		doc, err := yaml.JSONToYAML(content)
+		if err != nil {
+			return err
+		}
+		docs = append(docs, string(doc))
+	}
+	_, err := io.WriteString(out, strings.Join(docs, "---\n"))
+	return err
+}
@@ -0,0 +1,94 @@
+package convert
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/Kong/kuma/pkg/consul"
+	"github.com/Kong/kuma/pkg/core/resources/model"
+)
+
+const consulRequestTimeout = 10 * time.Second
+
+func newConvertConsulIntentionsCmd(pctx *convertContext) *cobra.Command {
+	args := struct {
+		address             string
+		token               string
+		defaultPolicy       string
+		permitDeniedTraffic bool
+	}{}
+	cmd := &cobra.Command{
+		Use:   "consul-intentions",
+		Short: "Convert Consul Connect intentions into TrafficPermissions",
+		Long: `Convert Consul Connect intentions into TrafficPermissions.
+
+Intentions are retrieved from Consul unless a file with the output of its /v1/connect/intentions endpoint is given.
+
+Intentions that allow traffic are converted into TrafficPermissions, and so is the default ACL policy of Consul
+if it allows traffic that is not matched by any intention.
+
+Intentions that deny traffic are skipped with a warning, since Kuma denies traffic that is not permitted explicitly.
+However, if a TrafficPermission would permit traffic that is denied by an intention of higher precedence,
+the conversion fails unless --permit-denied-traffic is given.`,
+		Example: `kumactl convert consul-intentions --mesh demo --consul-address http://consul.example.com:8500`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			var intentions []consul.Intention
+			if pctx.args.file != "" {
+				var content []byte
+				var err error
+				if pctx.args.file == "-" {
+					content, err = ioutil.ReadAll(cmd.InOrStdin())
+				} else {
+					content, err = ioutil.ReadFile(pctx.args.file)
+				}
+				if err != nil {
+					return errors.Wrap(err, "error while reading provided file")
+				}
+				if err := json.Unmarshal(content, &intentions); err != nil {
+					return errors.Wrap(err, "provided file does not contain a JSON list of intentions")
+				}
+			} else {
+				ctx, cancel := context.WithTimeout(context.Background(), consulRequestTimeout)
+				defer cancel()
+				var err error
+				intentions, err = consul.ListIntentions(ctx, &http.Client{}, args.address, args.token)
+				if err != nil {
+					return err
+				}
+			}
+
+			permissions, warnings, err := consul.ConvertIntentions(intentions, pctx.CurrentMesh(), consul.ConversionOptions{
+				DefaultPolicy:       args.defaultPolicy,
+				PermitDeniedTraffic: args.permitDeniedTraffic,
+			})
+			if err != nil {
+				if consul.IsDeniedTrafficPermitted(err) {
+					return errors.Errorf("%s. Use --permit-denied-traffic to convert intentions anyway", err)
+				}
+				return err
+			}
+			for _, warning := range warnings {
+				fmt.Fprintf(cmd.ErrOrStderr(), "WARNING: %s\n", warning)
+			}
+			resources := make([]model.Resource, 0, len(permissions))
+			for _, permission := range permissions {
+				resources = append(resources, permission)
+			}
+			return printResources(resources, cmd.OutOrStdout())
+		},
+	}
+	// flags
+	cmd.Flags().StringVar(&args.address, "consul-address", "http://127.0.0.1:8500", "address of the Consul HTTP API")
+	cmd.Flags().StringVar(&args.token, "consul-token", os.Getenv("CONSUL_HTTP_TOKEN"), "ACL token to authenticate to Consul with (defaults to CONSUL_HTTP_TOKEN environment variable)")
+	cmd.Flags().StringVar(&args.defaultPolicy, "consul-default-policy", consul.IntentionActionAllow, "default ACL policy of Consul, which applies to traffic that is not matched by any intention: one of allow|deny")
+	cmd.Flags().BoolVar(&args.permitDeniedTraffic, "permit-denied-traffic", false, "convert intentions even if the resulting TrafficPermissions permit traffic that is denied by intentions")
+	return cmd
+}
@@ -0,0 +1,13 @@
+package convert_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestConvertCmd(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Convert Cmd Suite")
+}
@@ -0,0 +1,297 @@
+package convert_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/Kong/kuma/app/kumactl/cmd"
+	kumactl_cmd "github.com/Kong/kuma/app/kumactl/pkg/cmd"
+)
+
+var _ = Describe("kumactl convert", func() {
+
+	var rootCmd *cobra.Command
+	var stdout *bytes.Buffer
+	var stderr *bytes.Buffer
+
+	BeforeEach(func() {
+		rootCmd = cmd.NewRootCmd(&kumactl_cmd.RootContext{})
+		stdout = &bytes.Buffer{}
+		stderr = &bytes.Buffer{}
+		rootCmd.SetOut(stdout)
+		rootCmd.SetErr(stderr)
+	})
+
+	It("should convert SMI objects into Kuma policies", func() {
+		// given
+		rootCmd.SetArgs([]string{
+			"--config-file", filepath.Join("..", "testdata", "sample-kumactl.config.yaml"),
+			"--mesh", "demo",
+			"convert", "-f", filepath.Join("testdata", "convert.input.yaml"),
+		})
+
+		// when
+		err := rootCmd.Execute()
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		expected, err := ioutil.ReadFile(filepath.Join("testdata", "convert.golden.yaml"))
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		// and
+		Expect(stdout.String()).To(Equal(string(expected)))
+		// and
+		Expect(stderr.String()).To(Equal(`WARNING: TrafficTarget "redis-access": source ServiceAccount "cache-warmer" in Namespace "default" is not used by any Pod selected by a Service: it has been skipped
+WARNING: TrafficSplit "backend-rollout": skipped since there is no equivalent policy in Kuma
+WARNING: VirtualService "reviews": skipped since there is no equivalent policy in Kuma
+`))
+	})
+
+	It("should fail on TrafficTarget with a destination that is not used by any Pod", func() {
+		// given
+		rootCmd.SetArgs([]string{
+			"--config-file", filepath.Join("..", "testdata", "sample-kumactl.config.yaml"),
+			"convert", "-f", "-",
+		})
+		rootCmd.SetIn(bytes.NewBufferString(`
+apiVersion: access.smi-spec.io/v1alpha1
+kind: TrafficTarget
+metadata:
+  name: backend-access
+  namespace: kuma-demo
+destination:
+  kind: ServiceAccount
+  name: backend
+sources:
+- kind: ServiceAccount
+  name: web
+`))
+
+		// when
+		err := rootCmd.Execute()
+
+		// then
+		Expect(err).To(MatchError(`failed to convert TrafficTarget "backend-access": destination ServiceAccount "backend" in Namespace "kuma-demo" is not used by any Pod selected by a Service`))
+	})
+
+	DescribeTable("should fail on TrafficTarget that restricts traffic more than TrafficPermission can",
+		func(restriction string, expected string) {
+			// given
+			rootCmd.SetArgs([]string{
+				"--config-file", filepath.Join("..", "testdata", "sample-kumactl.config.yaml"),
+				"convert", "-f", "-",
+			})
+			rootCmd.SetIn(bytes.NewBufferString(`
+apiVersion: access.smi-spec.io/v1alpha1
+kind: TrafficTarget
+metadata:
+  name: backend-access
+  namespace: kuma-demo
+sources:
+- kind: ServiceAccount
+  name: web
+destination:
+  kind: ServiceAccount
+  name: backend
+` + restriction))
+
+			// when
+			err := rootCmd.Execute()
+
+			// then
+			Expect(err).To(MatchError(expected))
+		},
+		Entry("port", `
+  port: 8080
+`, `failed to convert TrafficTarget "backend-access": restriction of traffic to port 8080 is not supported`),
+		Entry("routes", `
+specs:
+- kind: HTTPRouteGroup
+  name: backend-routes
+  matches:
+  - api
+`, `failed to convert TrafficTarget "backend-access": restriction of traffic to routes of HTTPRouteGroup "backend-routes" is not supported, only TCPRoute that permits all traffic is`),
+	)
+
+	It("should fail on unsupported TrafficTarget", func() {
+		// given
+		rootCmd.SetArgs([]string{
+			"--config-file", filepath.Join("..", "testdata", "sample-kumactl.config.yaml"),
+			"convert", "-f", filepath.Join("testdata", "convert-group.input.yaml"),
+		})
+
+		// when
+		err := rootCmd.Execute()
+
+		// then
+		Expect(err).To(MatchError(`failed to convert TrafficTarget "admins": source of kind "Group" is not supported, only "ServiceAccount" is`))
+	})
+
+	Describe("consul-intentions", func() {
+
+		const warnings = `WARNING: intention "backend" => "db": namespaces are not supported: traffic will be permitted regardless of namespaces of services
+WARNING: intention "legacy" => "backend": there are no policies that deny traffic in Kuma: traffic will be permitted by TrafficPermission "any-to-backend"
+WARNING: intention "*" => "db": skipped since Kuma denies traffic that is not permitted by a TrafficPermission
+`
+
+		It("should convert intentions from a file into TrafficPermissions", func() {
+			// given
+			rootCmd.SetArgs([]string{
+				"--config-file", filepath.Join("..", "testdata", "sample-kumactl.config.yaml"),
+				"--mesh", "demo",
+				"convert", "consul-intentions", "-f", filepath.Join("testdata", "consul-intentions.input.json"),
+				"--consul-default-policy", "deny", "--permit-denied-traffic",
+			})
+
+			// when
+			err := rootCmd.Execute()
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+
+			// when
+			expected, err := ioutil.ReadFile(filepath.Join("testdata", "consul-intentions.golden.yaml"))
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			// and
+			Expect(stdout.String()).To(Equal(string(expected)))
+			// and
+			Expect(stderr.String()).To(Equal(warnings))
+		})
+
+		It("should convert intentions retrieved from Consul into TrafficPermissions", func() {
+			// setup
+			intentions, err := ioutil.ReadFile(filepath.Join("testdata", "consul-intentions.input.json"))
+			Expect(err).ToNot(HaveOccurred())
+			consul := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+				if req.URL.Path != "/v1/connect/intentions" || req.Header.Get("X-Consul-Token") != "s3cr3t" {
+					writer.WriteHeader(http.StatusForbidden)
+					return
+				}
+				_, _ = writer.Write(intentions)
+			}))
+			defer consul.Close()
+
+			// given
+			rootCmd.SetArgs([]string{
+				"--config-file", filepath.Join("..", "testdata", "sample-kumactl.config.yaml"),
+				"--mesh", "demo",
+				"convert", "consul-intentions", "--consul-address", consul.URL, "--consul-token", "s3cr3t",
+				"--consul-default-policy", "deny", "--permit-denied-traffic",
+			})
+
+			// when
+			err = rootCmd.Execute()
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+
+			// when
+			expected, err := ioutil.ReadFile(filepath.Join("testdata", "consul-intentions.golden.yaml"))
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			// and
+			Expect(stdout.String()).To(Equal(string(expected)))
+			// and
+			Expect(stderr.String()).To(Equal(warnings))
+		})
+
+		It("should fail on intentions that deny traffic permitted by TrafficPermissions", func() {
+			// given
+			rootCmd.SetArgs([]string{
+				"--config-file", filepath.Join("..", "testdata", "sample-kumactl.config.yaml"),
+				"convert", "consul-intentions", "-f", filepath.Join("testdata", "consul-intentions.input.json"),
+				"--consul-default-policy", "deny",
+			})
+
+			// when
+			err := rootCmd.Execute()
+
+			// then
+			Expect(err).To(MatchError(`denied traffic permitted: intention "legacy" => "backend" denies traffic that TrafficPermission "any-to-backend" would permit, since there are no policies that deny traffic in Kuma. Use --permit-denied-traffic to convert intentions anyway`))
+		})
+
+		It("should fail on intentions that deny traffic permitted by the default policy", func() {
+			// given
+			rootCmd.SetArgs([]string{
+				"--config-file", filepath.Join("..", "testdata", "sample-kumactl.config.yaml"),
+				"convert", "consul-intentions", "-f", "-",
+			})
+			rootCmd.SetIn(bytes.NewBufferString(`[{"ID": "1", "SourceName": "web", "DestinationName": "db", "Action": "deny", "Precedence": 9}]`))
+
+			// when
+			err := rootCmd.Execute()
+
+			// then
+			Expect(err).To(MatchError(`denied traffic permitted: intention "web" => "db" denies traffic that TrafficPermission "any-to-any" would permit, since there are no policies that deny traffic in Kuma. Use --permit-denied-traffic to convert intentions anyway`))
+		})
+
+		It("should convert the default policy that allows traffic into a TrafficPermission", func() {
+			// given
+			rootCmd.SetArgs([]string{
+				"--config-file", filepath.Join("..", "testdata", "sample-kumactl.config.yaml"),
+				"--mesh", "demo",
+				"convert", "consul-intentions", "-f", filepath.Join("testdata", "consul-intentions.input.json"),
+				"--permit-denied-traffic",
+			})
+
+			// when
+			err := rootCmd.Execute()
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+
+			// when
+			expected, err := ioutil.ReadFile(filepath.Join("testdata", "consul-intentions-default-allow.golden.yaml"))
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			// and
+			Expect(stdout.String()).To(Equal(string(expected)))
+			// and
+			Expect(stderr.String()).To(Equal(`WARNING: intention "backend" => "db": namespaces are not supported: traffic will be permitted regardless of namespaces of services
+WARNING: intention "legacy" => "backend": there are no policies that deny traffic in Kuma: traffic will be permitted by TrafficPermission "any-to-backend"
+WARNING: intention "*" => "db": there are no policies that deny traffic in Kuma: traffic will be permitted by TrafficPermission "any-to-any"
+`))
+		})
+
+		It("should fail on unsupported default policy", func() {
+			// given
+			rootCmd.SetArgs([]string{
+				"--config-file", filepath.Join("..", "testdata", "sample-kumactl.config.yaml"),
+				"convert", "consul-intentions", "-f", "-", "--consul-default-policy", "audit",
+			})
+			rootCmd.SetIn(bytes.NewBufferString(`[]`))
+
+			// when
+			err := rootCmd.Execute()
+
+			// then
+			Expect(err).To(MatchError(`default policy "audit" is not supported, only "allow" and "deny" are`))
+		})
+
+		It("should fail on intentions of unsupported action", func() {
+			// given
+			rootCmd.SetArgs([]string{
+				"--config-file", filepath.Join("..", "testdata", "sample-kumactl.config.yaml"),
+				"convert", "consul-intentions", "-f", "-",
+			})
+			rootCmd.SetIn(bytes.NewBufferString(`[{"ID": "1", "SourceName": "web", "DestinationName": "backend", "Action": "audit"}]`))
+
+			// when
+			err := rootCmd.Execute()
+
+			// then
+			Expect(err).To(MatchError(`intention "web" => "backend": action "audit" is not supported, only "allow" and "deny" are`))
+		})
+	})
+})
@@ -0,0 +1,117 @@
+package delete
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	kumactl_cmd "github.com/Kong/kuma/app/kumactl/pkg/cmd"
+	"github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/model"
+	"github.com/Kong/kuma/pkg/core/resources/registry"
+	"github.com/Kong/kuma/pkg/core/resources/store"
+)
+
+// irregularTypeNames are names of types on the command line that don't follow the naming of other types,
+// so that they match subcommands of `kumactl get`.
+var irregularTypeNames = map[model.ResourceType]string{
+	mesh.ProxyTemplateType: "proxytemplates",
+}
+
+func NewDeleteCmd(pctx *kumactl_cmd.RootContext) *cobra.Command {
+	args := struct {
+		force bool
+	}{}
+	cmd := &cobra.Command{
+		Use:   "delete TYPE NAME",
+		Short: "Delete Kuma resources",
+		Long: `Delete Kuma resources.
+
+Available types: ` + strings.Join(typeNames(), ", ") + `.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, cmdArgs []string) error {
+			resourceType, ok := resourceTypes()[cmdArgs[0]]
+			if !ok {
+				return errors.Errorf("unknown TYPE: %s. Allowed values: %s", cmdArgs[0], strings.Join(typeNames(), ", "))
+			}
+			name := cmdArgs[1]
+			meshName := pctx.CurrentMesh()
+			if resourceType == mesh.MeshType {
+				meshName = name
+			}
+
+			rs, err := pctx.CurrentResourceStore()
+			if err != nil {
+				return err
+			}
+			if err := deleteResource(rs, resourceType, name, meshName, args.force); err != nil {
+				return err
+			}
+			cmd.Printf("deleted %s %q\n", resourceType, name)
+			return nil
+		},
+	}
+	// flags
+	cmd.Flags().BoolVar(&args.force, "force", false, "delete resources that depend on the given one as well, e.g. Dataplanes and policies of a Mesh")
+	return cmd
+}
+
+// deleteResource deletes a resource. Deleting a resource that doesn't exist is not an error.
+func deleteResource(rs store.ResourceStore, resourceType model.ResourceType, name string, meshName string, force bool) error {
+	res, err := registry.Global().NewObject(resourceType)
+	if err != nil {
+		return err
+	}
+	opts := []store.DeleteOptionsFunc{store.DeleteByKey(model.DefaultNamespace, name, meshName)}
+	if force {
+		opts = append(opts, store.DeleteForce())
+	}
+	if err := rs.Delete(context.Background(), res, opts...); err != nil {
+		return errors.Wrapf(err, "failed to delete %s with name %q", resourceType, name)
+	}
+	return nil
+}
+
+// resourceTypes maps names of types accepted on the command line to types of resources.
+//
+// Meshes, Dataplanes and policies can be deleted. Names are plural and in kebab case, e.g. "traffic-permissions".
+func resourceTypes() map[string]model.ResourceType {
+	types := map[string]model.ResourceType{
+		typeName(mesh.MeshType):      mesh.MeshType,
+		typeName(mesh.DataplaneType): mesh.DataplaneType,
+	}
+	for _, policyType := range mesh.PolicyTypes() {
+		types[typeName(policyType)] = policyType
+	}
+	return types
+}
+
+func typeName(resourceType model.ResourceType) string {
+	if name, ok := irregularTypeNames[resourceType]; ok {
+		return name
+	}
+	var name strings.Builder
+	for i, r := range string(resourceType) {
+		if unicode.IsUpper(r) && i > 0 {
+			name.WriteRune('-')
+		}
+		name.WriteRune(unicode.ToLower(r))
+	}
+	if strings.HasSuffix(name.String(), "s") || strings.HasSuffix(name.String(), "sh") {
+		return name.String() + "es"
+	}
+	return name.String() + "s"
+}
+
+func typeNames() []string {
+	var names []string
+	for name := range resourceTypes() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,141 @@
+package delete_test
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	"github.com/Kong/kuma/app/kumactl/cmd"
+	kumactl_cmd "github.com/Kong/kuma/app/kumactl/pkg/cmd"
+	config_proto "github.com/Kong/kuma/pkg/config/app/kumactl/v1alpha1"
+	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	memory_resources "github.com/Kong/kuma/pkg/plugins/resources/memory"
+)
+
+var _ = Describe("kumactl delete", func() {
+
+	var rootCmd *cobra.Command
+	var buf *bytes.Buffer
+	var store core_store.ResourceStore
+
+	BeforeEach(func() {
+		// setup
+		rootCtx := &kumactl_cmd.RootContext{
+			Runtime: kumactl_cmd.RootRuntime{
+				NewResourceStore: func(*config_proto.ControlPlaneCoordinates_ApiServer) (core_store.ResourceStore, error) {
+					return store, nil
+				},
+			},
+		}
+		store = memory_resources.NewStore()
+
+		err := store.Create(context.Background(), &mesh_core.MeshResource{}, core_store.CreateByKey(core_model.DefaultNamespace, "demo", "demo"))
+		Expect(err).ToNot(HaveOccurred())
+		err = store.Create(context.Background(), &mesh_core.TrafficPermissionResource{
+			Spec: mesh_proto.TrafficPermission{},
+		}, core_store.CreateByKey(core_model.DefaultNamespace, "everyone", "demo"))
+		Expect(err).ToNot(HaveOccurred())
+
+		rootCmd = cmd.NewRootCmd(rootCtx)
+		buf = &bytes.Buffer{}
+		rootCmd.SetOut(buf)
+	})
+
+	It("should delete a resource of a Mesh", func() {
+		// given
+		rootCmd.SetArgs([]string{
+			"--config-file", filepath.Join("..", "testdata", "sample-kumactl.config.yaml"),
+			"--mesh", "demo",
+			"delete", "traffic-permissions", "everyone"})
+
+		// when
+		err := rootCmd.Execute()
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buf.String()).To(Equal(`deleted TrafficPermission "everyone"` + "\n"))
+
+		// and
+		err = store.Get(context.Background(), &mesh_core.TrafficPermissionResource{}, core_store.GetByKey(core_model.DefaultNamespace, "everyone", "demo"))
+		Expect(core_store.IsResourceNotFound(err)).To(BeTrue())
+	})
+
+	It("should delete a Mesh", func() {
+		// given
+		rootCmd.SetArgs([]string{
+			"--config-file", filepath.Join("..", "testdata", "sample-kumactl.config.yaml"),
+			"delete", "meshes", "demo"})
+
+		// when
+		err := rootCmd.Execute()
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buf.String()).To(Equal(`deleted Mesh "demo"` + "\n"))
+
+		// and
+		err = store.Get(context.Background(), &mesh_core.MeshResource{}, core_store.GetByKey(core_model.DefaultNamespace, "demo", "demo"))
+		Expect(core_store.IsResourceNotFound(err)).To(BeTrue())
+	})
+
+	It("should not fail when a resource does not exist", func() {
+		// given
+		rootCmd.SetArgs([]string{
+			"--config-file", filepath.Join("..", "testdata", "sample-kumactl.config.yaml"),
+			"--mesh", "demo",
+			"delete", "dataplanes", "web-01"})
+
+		// when
+		err := rootCmd.Execute()
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buf.String()).To(Equal(`deleted Dataplane "web-01"` + "\n"))
+	})
+
+	It("should delete a resource without fetching it first", func() {
+		// given
+		store = &deleteOnlyStore{ResourceStore: store}
+		rootCmd.SetArgs([]string{
+			"--config-file", filepath.Join("..", "testdata", "sample-kumactl.config.yaml"),
+			"--mesh", "demo",
+			"delete", "proxytemplates", "default"})
+
+		// when
+		err := rootCmd.Execute()
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should fail on unknown type", func() {
+		// given
+		rootCmd.SetArgs([]string{
+			"--config-file", filepath.Join("..", "testdata", "sample-kumactl.config.yaml"),
+			"delete", "secret", "web-01"})
+
+		// when
+		err := rootCmd.Execute()
+
+		// then
+		Expect(err).To(MatchError("unknown TYPE: secret. Allowed values: dataplanes, meshes, proxytemplates, slow-starts, traffic-permissions"))
+	})
+})
+
+// deleteOnlyStore fails any operation other than Delete.
+type deleteOnlyStore struct {
+	core_store.ResourceStore
+}
+
+func (s *deleteOnlyStore) Get(context.Context, core_model.Resource, ...core_store.GetOptionsFunc) error {
+	return errors.New("unexpected Get")
+}
@@ -0,0 +1,94 @@
+package export
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	kumactl_cmd "github.com/Kong/kuma/app/kumactl/pkg/cmd"
+	"github.com/Kong/kuma/app/kumactl/pkg/manifests"
+	"github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/model"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+)
+
+type exportContext struct {
+	*kumactl_cmd.RootContext
+
+	args struct {
+		format    string
+		namespace string
+	}
+}
+
+func NewExportCmd(pctx *kumactl_cmd.RootContext) *cobra.Command {
+	ctx := &exportContext{RootContext: pctx}
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export Meshes and policies of the current Control Plane",
+		Long: `Export Meshes and policies of the current Control Plane.
+
+Resources can be exported either in the format of Kuma API Server (universal)
+or as Kubernetes custom resources (kubernetes), e.g. to migrate a Mesh
+from universal to Kubernetes or the other way around.
+
+Dataplanes are not exported since they describe workloads of a particular environment.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			marshal, err := manifests.NewMarshaller(ctx.args.format, ctx.args.namespace)
+			if err != nil {
+				return err
+			}
+
+			rs, err := pctx.CurrentResourceStore()
+			if err != nil {
+				return err
+			}
+			resources, err := listResources(rs)
+			if err != nil {
+				return err
+			}
+
+			docs := make([]string, 0, len(resources))
+			for _, resource := range resources {
+				doc, err := marshal(resource)
+				if err != nil {
+					return errors.Wrapf(err, "failed to export %s %q", resource.GetType(), resource.GetMeta().GetName())
+				}
+				docs = append(docs, string(doc))
+			}
+			_, err = io.WriteString(cmd.OutOrStdout(), strings.Join(docs, "---\n"))
+			return err
+		},
+	}
+	cmd.PersistentFlags().StringVar(&ctx.args.format, "format", manifests.UniversalFormat, "format of exported resources: one of universal|kubernetes")
+	cmd.PersistentFlags().StringVar(&ctx.args.namespace, "namespace", "kuma-system", "Kubernetes namespace to put exported resources into (only for kubernetes format)")
+	return cmd
+}
+
+// listResources returns all Meshes followed by policies of every Mesh.
+func listResources(rs core_store.ResourceStore) ([]model.Resource, error) {
+	meshes := mesh.MeshResourceList{}
+	if err := rs.List(context.Background(), &meshes); err != nil {
+		return nil, errors.Wrap(err, "failed to list Meshes")
+	}
+	var resources []model.Resource
+	for _, m := range meshes.Items {
+		resources = append(resources, m)
+	}
+	for _, m := range meshes.Items {
+		lists := []model.ResourceList{
+			&mesh.TrafficPermissionResourceList{},
+			&mesh.ProxyTemplateResourceList{},
+		}
+		for _, list := range lists {
+			if err := rs.List(context.Background(), list, core_store.ListByMesh(m.GetMeta().GetName())); err != nil {
+				return nil, errors.Wrapf(err, "failed to list %ss", list.GetItemType())
+			}
+			resources = append(resources, list.GetItems()...)
+		}
+	}
+	return resources, nil
+}
@@ -0,0 +1,13 @@
+package export_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestExportCmd(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Export Cmd Suite")
+}
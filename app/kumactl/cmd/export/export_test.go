@@ -0,0 +1,154 @@
+package export_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/Kong/kuma/api/mesh/v1alpha1"
+	"github.com/Kong/kuma/app/kumactl/cmd"
+	kumactl_cmd "github.com/Kong/kuma/app/kumactl/pkg/cmd"
+	config_proto "github.com/Kong/kuma/pkg/config/app/kumactl/v1alpha1"
+	"github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	memory_resources "github.com/Kong/kuma/pkg/plugins/resources/memory"
+)
+
+var _ = Describe("kumactl export", func() {
+
+	var rootCmd *cobra.Command
+	var buf *bytes.Buffer
+	var store core_store.ResourceStore
+
+	BeforeEach(func() {
+		// setup
+		rootCtx := &kumactl_cmd.RootContext{
+			Runtime: kumactl_cmd.RootRuntime{
+				Now: func() time.Time { return time.Now() },
+				NewResourceStore: func(*config_proto.ControlPlaneCoordinates_ApiServer) (core_store.ResourceStore, error) {
+					return store, nil
+				},
+			},
+		}
+		store = memory_resources.NewStore()
+
+		resources := []struct {
+			resource core_model.Resource
+			mesh     string
+			name     string
+			labels   map[string]string
+		}{
+			{
+				resource: &mesh.MeshResource{
+					Spec: v1alpha1.Mesh{
+						Mtls: &v1alpha1.Mesh_Mtls{
+							Enabled: true,
+							Ca: &v1alpha1.CertificateAuthority{
+								Type: &v1alpha1.CertificateAuthority_Builtin_{
+									Builtin: &v1alpha1.CertificateAuthority_Builtin{},
+								},
+							},
+						},
+					},
+				},
+				mesh: "demo",
+				name: "demo",
+			},
+			{
+				resource: &mesh.TrafficPermissionResource{
+					Spec: v1alpha1.TrafficPermission{
+						Rules: []*v1alpha1.TrafficPermission_Rule{
+							{
+								Sources: []*v1alpha1.TrafficPermission_Rule_Selector{
+									{Match: map[string]string{"service": "web"}},
+								},
+								Destinations: []*v1alpha1.TrafficPermission_Rule_Selector{
+									{Match: map[string]string{"service": "backend"}},
+								},
+							},
+						},
+					},
+				},
+				mesh:   "demo",
+				name:   "web-to-backend",
+				labels: map[string]string{"team": "payments"},
+			},
+			{
+				resource: &mesh.ProxyTemplateResource{
+					Spec: v1alpha1.ProxyTemplate{
+						Selectors: []*v1alpha1.ProxyTemplate_Selector{
+							{Match: map[string]string{"service": "backend"}},
+						},
+						Imports: []string{"default-proxy"},
+					},
+				},
+				mesh: "demo",
+				name: "backend",
+			},
+		}
+		for _, r := range resources {
+			err := store.Create(context.Background(), r.resource,
+				core_store.CreateByKey("", r.name, r.mesh), core_store.CreateWithLabels(r.labels))
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		rootCmd = cmd.NewRootCmd(rootCtx)
+		buf = &bytes.Buffer{}
+		rootCmd.SetOut(buf)
+	})
+
+	type testCase struct {
+		args       []string
+		goldenFile string
+	}
+
+	DescribeTable("should export Meshes and policies",
+		func(given testCase) {
+			// given
+			rootCmd.SetArgs(append([]string{
+				"--config-file", filepath.Join("..", "testdata", "sample-kumactl.config.yaml"),
+				"export"}, given.args...))
+
+			// when
+			err := rootCmd.Execute()
+			// then
+			Expect(err).ToNot(HaveOccurred())
+
+			// when
+			expected, err := ioutil.ReadFile(filepath.Join("testdata", given.goldenFile))
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			// and
+			Expect(buf.String()).To(Equal(string(expected)))
+		},
+		Entry("in universal format by default", testCase{
+			goldenFile: "export.universal.golden.yaml",
+		}),
+		Entry("in kubernetes format", testCase{
+			args:       []string{"--format", "kubernetes", "--namespace", "kuma-demo"},
+			goldenFile: "export.kubernetes.golden.yaml",
+		}),
+	)
+
+	It("should reject unknown format", func() {
+		// given
+		rootCmd.SetArgs([]string{
+			"--config-file", filepath.Join("..", "testdata", "sample-kumactl.config.yaml"),
+			"export", "--format", "helm"})
+		rootCmd.SetErr(&bytes.Buffer{})
+
+		// when
+		err := rootCmd.Execute()
+
+		// then
+		Expect(err).To(MatchError(`unknown format "helm": must be one of "universal" or "kubernetes"`))
+	})
+})
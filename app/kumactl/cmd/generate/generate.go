@@ -0,0 +1,87 @@
+package generate
+
+import (
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	kumactl_cmd "github.com/Kong/kuma/app/kumactl/pkg/cmd"
+	"github.com/Kong/kuma/app/kumactl/pkg/manifests"
+	"github.com/Kong/kuma/pkg/core/resources/model"
+)
+
+type generateContext struct {
+	*kumactl_cmd.RootContext
+
+	args struct {
+		format    string
+		namespace string
+	}
+}
+
+func NewGenerateCmd(pctx *kumactl_cmd.RootContext) *cobra.Command {
+	ctx := &generateContext{RootContext: pctx}
+	cmd := &cobra.Command{
+		Use:   "generate",
+		Short: "Generate Kuma resources",
+		Long: `Generate Kuma resources.
+
+Generated resources are printed to stdout and are ready to be applied,
+e.g. with "kumactl apply -f -" or, in kubernetes format, with "kubectl apply -f -".`,
+	}
+	// flags
+	cmd.PersistentFlags().StringVar(&ctx.args.format, "format", manifests.UniversalFormat, "format of generated resources: one of universal|kubernetes")
+	cmd.PersistentFlags().StringVar(&ctx.args.namespace, "namespace", "kuma-system", "Kubernetes namespace to put generated resources into (only for kubernetes format)")
+	// sub-commands
+	cmd.AddCommand(newGenerateDataplaneCmd(ctx))
+	cmd.AddCommand(newGenerateMeshCmd(ctx))
+	cmd.AddCommand(newGenerateTrafficPermissionCmd(ctx))
+	return cmd
+}
+
+// print validates a generated resource and prints it in the requested format.
+func (ctx *generateContext) print(resource model.Resource, out io.Writer) error {
+	marshal, err := manifests.NewMarshaller(ctx.args.format, ctx.args.namespace)
+	if err != nil {
+		return err
+	}
+	if validator, ok := resource.(model.ResourceValidator); ok {
+		if err := validator.Validate(); err != nil {
+			return errors.Wrapf(err, "generated %s is not valid", resource.GetType())
+		}
+	}
+	content, err := marshal(resource)
+	if err != nil {
+		return errors.Wrapf(err, "failed to generate %s %q", resource.GetType(), resource.GetMeta().GetName())
+	}
+	_, err = out.Write(content)
+	return err
+}
+
+var _ model.ResourceMeta = &meta{}
+
+type meta struct {
+	Name string
+	Mesh string
+}
+
+func (m meta) GetName() string {
+	return m.Name
+}
+
+func (m meta) GetNamespace() string {
+	return ""
+}
+
+func (m meta) GetVersion() string {
+	return ""
+}
+
+func (m meta) GetMesh() string {
+	return m.Mesh
+}
+
+func (m meta) GetLabels() map[string]string {
+	return nil
+}
@@ -0,0 +1,132 @@
+package generate
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	"github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+)
+
+func newGenerateDataplaneCmd(pctx *generateContext) *cobra.Command {
+	args := struct {
+		name      string
+		address   string
+		service   string
+		tags      map[string]string
+		ports     []string
+		outbounds []string
+	}{}
+	cmd := &cobra.Command{
+		Use:   "dataplane",
+		Short: "Generate a Dataplane",
+		Long: `Generate a Dataplane deployed next to a service.
+
+Every port is either <DATAPLANE_PORT>:<WORKLOAD_PORT> or a single <PORT>
+if Dataplane and workload use the same port.
+
+Every outbound is <SERVICE>:<SERVICE_PORT>:<LOCAL_PORT>, which means that
+the service is reachable by the workload at 127.0.0.1:<LOCAL_PORT>.`,
+		Example: `kumactl generate dataplane --mesh demo --name web-01 --address 192.168.0.1 --service web --ports 10000:8080 --tags version=v1 --outbound backend:80:10001`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			address, err := mesh_proto.ParseIP(args.address)
+			if err != nil {
+				return errors.Wrap(err, "invalid --address")
+			}
+			if len(args.ports) == 0 {
+				return errors.New("at least one port must be given with --ports")
+			}
+
+			tags := map[string]string{}
+			for key, value := range args.tags {
+				tags[key] = value
+			}
+			tags[mesh_proto.ServiceTag] = args.service
+
+			networking := &mesh_proto.Dataplane_Networking{}
+			for _, port := range args.ports {
+				iface, err := parseInbound(address, port)
+				if err != nil {
+					return err
+				}
+				networking.Inbound = append(networking.Inbound, &mesh_proto.Dataplane_Networking_Inbound{
+					Interface: iface.String(),
+					Tags:      tags,
+				})
+			}
+			for _, outbound := range args.outbounds {
+				o, err := parseOutbound(outbound)
+				if err != nil {
+					return err
+				}
+				networking.Outbound = append(networking.Outbound, o)
+			}
+
+			dataplane := &mesh.DataplaneResource{
+				Meta: meta{Name: args.name, Mesh: pctx.CurrentMesh()},
+				Spec: mesh_proto.Dataplane{
+					Networking: networking,
+				},
+			}
+			return pctx.print(dataplane, cmd.OutOrStdout())
+		},
+	}
+	// flags
+	cmd.Flags().StringVar(&args.name, "name", "", "name of the Dataplane")
+	_ = cmd.MarkFlagRequired("name")
+	cmd.Flags().StringVar(&args.address, "address", "", "IP address of the Dataplane")
+	_ = cmd.MarkFlagRequired("address")
+	cmd.Flags().StringVar(&args.service, "service", "", "name of the service the Dataplane is deployed next to")
+	_ = cmd.MarkFlagRequired("service")
+	cmd.Flags().StringToStringVar(&args.tags, "tags", nil, "additional tags of the service, e.g. version=v1,region=us")
+	cmd.Flags().StringSliceVar(&args.ports, "ports", nil, "inbound ports of the Dataplane, e.g. 10000:8080")
+	cmd.Flags().StringSliceVar(&args.outbounds, "outbound", nil, "services consumed by the workload, e.g. backend:80:10001")
+	return cmd
+}
+
+// parseInbound parses either <DATAPLANE_PORT>:<WORKLOAD_PORT> or <PORT>.
+func parseInbound(address string, text string) (mesh_proto.InboundInterface, error) {
+	parts := strings.Split(text, ":")
+	if len(parts) > 2 {
+		return mesh_proto.InboundInterface{}, errors.Errorf("invalid port %q: expected <DATAPLANE_PORT>:<WORKLOAD_PORT> or <PORT>", text)
+	}
+	dataplanePort, err := mesh_proto.ParsePort(parts[0])
+	if err != nil {
+		return mesh_proto.InboundInterface{}, errors.Wrapf(err, "invalid port %q", text)
+	}
+	workloadPort := dataplanePort
+	if len(parts) == 2 {
+		workloadPort, err = mesh_proto.ParsePort(parts[1])
+		if err != nil {
+			return mesh_proto.InboundInterface{}, errors.Wrapf(err, "invalid port %q", text)
+		}
+	}
+	return mesh_proto.InboundInterface{
+		DataplaneIP:   address,
+		DataplanePort: dataplanePort,
+		WorkloadPort:  workloadPort,
+	}, nil
+}
+
+// parseOutbound parses <SERVICE>:<SERVICE_PORT>:<LOCAL_PORT>.
+func parseOutbound(text string) (*mesh_proto.Dataplane_Networking_Outbound, error) {
+	parts := strings.Split(text, ":")
+	if len(parts) != 3 || parts[0] == "" {
+		return nil, errors.Errorf("invalid outbound %q: expected <SERVICE>:<SERVICE_PORT>:<LOCAL_PORT>", text)
+	}
+	servicePort, err := mesh_proto.ParsePort(parts[1])
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid outbound %q", text)
+	}
+	localPort, err := mesh_proto.ParsePort(parts[2])
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid outbound %q", text)
+	}
+	return &mesh_proto.Dataplane_Networking_Outbound{
+		Interface:   mesh_proto.OutboundInterface{DataplaneIP: "127.0.0.1", DataplanePort: localPort}.String(),
+		Service:     parts[0],
+		ServicePort: servicePort,
+	}, nil
+}
@@ -0,0 +1,53 @@
+package generate
+
+import (
+	"github.com/spf13/cobra"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	"github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+)
+
+func newGenerateMeshCmd(pctx *generateContext) *cobra.Command {
+	args := struct {
+		name           string
+		mtls           bool
+		accessLogsPath string
+	}{}
+	cmd := &cobra.Command{
+		Use:     "mesh",
+		Short:   "Generate a Mesh",
+		Long:    `Generate a Mesh.`,
+		Example: `kumactl generate mesh --name demo --mtls --access-logs /tmp/access.log`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			spec := mesh_proto.Mesh{
+				Mtls: &mesh_proto.Mesh_Mtls{
+					Enabled: args.mtls,
+					Ca: &mesh_proto.CertificateAuthority{
+						Type: &mesh_proto.CertificateAuthority_Builtin_{
+							Builtin: &mesh_proto.CertificateAuthority_Builtin{},
+						},
+					},
+				},
+			}
+			if args.accessLogsPath != "" {
+				spec.Logging = &mesh_proto.Logging{
+					AccessLogs: &mesh_proto.Logging_AccessLogs{
+						Enabled:  true,
+						FilePath: args.accessLogsPath,
+					},
+				}
+			}
+			resource := &mesh.MeshResource{
+				Meta: meta{Name: args.name, Mesh: args.name},
+				Spec: spec,
+			}
+			return pctx.print(resource, cmd.OutOrStdout())
+		},
+	}
+	// flags
+	cmd.Flags().StringVar(&args.name, "name", "", "name of the Mesh")
+	_ = cmd.MarkFlagRequired("name")
+	cmd.Flags().BoolVar(&args.mtls, "mtls", false, "enable mTLS with the builtin CA")
+	cmd.Flags().StringVar(&args.accessLogsPath, "access-logs", "", "path of a file that Dataplanes write access logs to")
+	return cmd
+}
@@ -0,0 +1,13 @@
+package generate_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestGenerateCmd(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Generate Cmd Suite")
+}
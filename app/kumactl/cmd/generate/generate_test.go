@@ -0,0 +1,123 @@
+package generate_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/Kong/kuma/app/kumactl/cmd"
+	kumactl_cmd "github.com/Kong/kuma/app/kumactl/pkg/cmd"
+)
+
+var _ = Describe("kumactl generate", func() {
+
+	var rootCmd *cobra.Command
+	var buf *bytes.Buffer
+
+	BeforeEach(func() {
+		// setup
+		rootCtx := &kumactl_cmd.RootContext{
+			Runtime: kumactl_cmd.RootRuntime{
+				Now: func() time.Time { return time.Now() },
+			},
+		}
+		rootCmd = cmd.NewRootCmd(rootCtx)
+		buf = &bytes.Buffer{}
+		rootCmd.SetOut(buf)
+		rootCmd.SetErr(&bytes.Buffer{})
+	})
+
+	type testCase struct {
+		args       []string
+		goldenFile string
+	}
+
+	DescribeTable("should generate resources",
+		func(given testCase) {
+			// given
+			rootCmd.SetArgs(append([]string{
+				"--config-file", filepath.Join("..", "testdata", "sample-kumactl.config.yaml"),
+				"generate"}, given.args...))
+
+			// when
+			err := rootCmd.Execute()
+			// then
+			Expect(err).ToNot(HaveOccurred())
+
+			// when
+			expected, err := ioutil.ReadFile(filepath.Join("testdata", given.goldenFile))
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			// and
+			Expect(buf.String()).To(MatchYAML(expected))
+		},
+		Entry("a Dataplane", testCase{
+			args: []string{"dataplane", "--mesh", "demo", "--name", "web-01", "--address", "192.168.0.1",
+				"--service", "web", "--ports", "10000:8080", "--ports", "9090", "--tags", "version=v1",
+				"--outbound", "backend:80:10001"},
+			goldenFile: "generate-dataplane.golden.yaml",
+		}),
+		Entry("a Dataplane with IPv6 address", testCase{
+			args:       []string{"dataplane", "--name", "web-01", "--address", "fd00::1", "--service", "web", "--ports", "10000:8080"},
+			goldenFile: "generate-dataplane.ipv6.golden.yaml",
+		}),
+		Entry("a Mesh", testCase{
+			args:       []string{"mesh", "--name", "demo", "--mtls", "--access-logs", "/tmp/access.log"},
+			goldenFile: "generate-mesh.golden.yaml",
+		}),
+		Entry("a TrafficPermission", testCase{
+			args:       []string{"traffic-permission", "--mesh", "demo", "--name", "web-to-backend", "--source", "service=web", "--destination", "service=backend,version=v1"},
+			goldenFile: "generate-traffic-permission.golden.yaml",
+		}),
+		Entry("a TrafficPermission that allows all traffic in kubernetes format", testCase{
+			args:       []string{"traffic-permission", "--mesh", "demo", "--name", "everyone", "--format", "kubernetes", "--namespace", "kuma-demo"},
+			goldenFile: "generate-traffic-permission.kubernetes.golden.yaml",
+		}),
+	)
+
+	type errorTestCase struct {
+		args        []string
+		expectedErr string
+	}
+
+	DescribeTable("should reject invalid input",
+		func(given errorTestCase) {
+			// given
+			rootCmd.SetArgs(append([]string{
+				"--config-file", filepath.Join("..", "testdata", "sample-kumactl.config.yaml"),
+				"generate"}, given.args...))
+
+			// when
+			err := rootCmd.Execute()
+
+			// then
+			Expect(err).To(MatchError(given.expectedErr))
+		},
+		Entry("missing ports", errorTestCase{
+			args:        []string{"dataplane", "--name", "web-01", "--address", "192.168.0.1", "--service", "web"},
+			expectedErr: "at least one port must be given with --ports",
+		}),
+		Entry("invalid address", errorTestCase{
+			args:        []string{"dataplane", "--name", "web-01", "--address", "web", "--service", "web", "--ports", "8080"},
+			expectedErr: `invalid --address: "web" is not a valid IP address`,
+		}),
+		Entry("invalid port", errorTestCase{
+			args:        []string{"dataplane", "--name", "web-01", "--address", "192.168.0.1", "--service", "web", "--ports", "8080:0"},
+			expectedErr: `invalid port "8080:0": port number must be in the range [1, 65535] but got 0`,
+		}),
+		Entry("invalid outbound", errorTestCase{
+			args:        []string{"dataplane", "--name", "web-01", "--address", "192.168.0.1", "--service", "web", "--ports", "8080", "--outbound", "backend:80"},
+			expectedErr: `invalid outbound "backend:80": expected <SERVICE>:<SERVICE_PORT>:<LOCAL_PORT>`,
+		}),
+		Entry("unknown format", errorTestCase{
+			args:        []string{"mesh", "--name", "demo", "--format", "helm"},
+			expectedErr: `unknown format "helm": must be one of "universal" or "kubernetes"`,
+		}),
+	)
+})
@@ -0,0 +1,54 @@
+package generate
+
+import (
+	"github.com/spf13/cobra"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	"github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+)
+
+func newGenerateTrafficPermissionCmd(pctx *generateContext) *cobra.Command {
+	args := struct {
+		name        string
+		source      map[string]string
+		destination map[string]string
+	}{}
+	cmd := &cobra.Command{
+		Use:   "traffic-permission",
+		Short: "Generate a TrafficPermission",
+		Long: `Generate a TrafficPermission that allows traffic from sources to destinations.
+
+Sources and destinations match any service unless tags are given.`,
+		Example: `kumactl generate traffic-permission --mesh demo --name web-to-backend --source service=web --destination service=backend,version=v1`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			resource := &mesh.TrafficPermissionResource{
+				Meta: meta{Name: args.name, Mesh: pctx.CurrentMesh()},
+				Spec: mesh_proto.TrafficPermission{
+					Rules: []*mesh_proto.TrafficPermission_Rule{{
+						Sources: []*mesh_proto.TrafficPermission_Rule_Selector{
+							{Match: selector(args.source)},
+						},
+						Destinations: []*mesh_proto.TrafficPermission_Rule_Selector{
+							{Match: selector(args.destination)},
+						},
+					}},
+				},
+			}
+			return pctx.print(resource, cmd.OutOrStdout())
+		},
+	}
+	// flags
+	cmd.Flags().StringVar(&args.name, "name", "", "name of the TrafficPermission")
+	_ = cmd.MarkFlagRequired("name")
+	cmd.Flags().StringToStringVar(&args.source, "source", nil, "tags of sources, e.g. service=web")
+	cmd.Flags().StringToStringVar(&args.destination, "destination", nil, "tags of destinations, e.g. service=backend")
+	return cmd
+}
+
+// selector matches any service if no tags are given.
+func selector(tags map[string]string) map[string]string {
+	if len(tags) == 0 {
+		return map[string]string{mesh_proto.ServiceTag: "*"}
+	}
+	return tags
+}
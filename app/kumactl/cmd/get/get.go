@@ -29,5 +29,6 @@ func NewGetCmd(pctx *kumactl_cmd.RootContext) *cobra.Command {
 	cmd.AddCommand(newGetDataplanesCmd(ctx))
 	cmd.AddCommand(newGetProxyTemplatesCmd(ctx))
 	cmd.AddCommand(newGetTrafficPermissionsCmd(ctx))
+	cmd.AddCommand(newGetSlowStartsCmd(ctx))
 	return cmd
 }
@@ -2,12 +2,17 @@ package get
 
 import (
 	"context"
+	"fmt"
 	"io"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/Kong/kuma/app/kumactl/pkg/output/table"
 
 	"github.com/Kong/kuma/app/kumactl/pkg/output"
 	"github.com/Kong/kuma/app/kumactl/pkg/output/printers"
+	kumactl_resources "github.com/Kong/kuma/app/kumactl/pkg/resources"
 	"github.com/Kong/kuma/pkg/core/resources/apis/mesh"
 	rest_types "github.com/Kong/kuma/pkg/core/resources/model/rest"
 	"github.com/pkg/errors"
@@ -18,7 +23,9 @@ func newGetMeshesCmd(pctx *getContext) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "meshes",
 		Short: "Show Meshes",
-		Long:  `Show Meshes.`,
+		Long: `Show Meshes.
+
+Use "-o summary" to show online and offline Dataplanes, policies and mTLS status of each Mesh.`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			rs, err := pctx.CurrentResourceStore()
 			if err != nil {
@@ -33,6 +40,20 @@ func newGetMeshesCmd(pctx *getContext) *cobra.Command {
 			switch format := output.Format(pctx.args.outputFormat); format {
 			case output.TableFormat:
 				return printMeshes(&meshes, cmd.OutOrStdout())
+			case output.SummaryFormat:
+				client, err := pctx.CurrentMeshInsightClient()
+				if err != nil {
+					return err
+				}
+				var insights []*kumactl_resources.MeshInsight
+				for _, mesh := range meshes.Items {
+					insight, err := client.Get(context.Background(), mesh.GetMeta().GetName())
+					if err != nil {
+						return errors.Wrapf(err, "failed to get an insight of Mesh %q", mesh.GetMeta().GetName())
+					}
+					insights = append(insights, insight)
+				}
+				return printMeshInsights(insights, cmd.OutOrStdout())
 			default:
 				printer, err := printers.NewGenericPrinter(format)
 				if err != nil {
@@ -71,3 +92,55 @@ func printMeshes(meshes *mesh.MeshResourceList, out io.Writer) error {
 	}
 	return printers.NewTablePrinter().Print(data, out)
 }
+
+func printMeshInsights(insights []*kumactl_resources.MeshInsight, out io.Writer) error {
+	data := printers.Table{
+		Headers: []string{"NAME", "mTLS", "CA", "CA EXPIRES", "ONLINE DATAPLANES", "OFFLINE DATAPLANES", "POLICIES"},
+		NextRow: func() func() []string {
+			i := 0
+			return func() []string {
+				defer func() { i++ }()
+				if len(insights) <= i {
+					return nil
+				}
+				insight := insights[i]
+
+				ca := "-"
+				if insight.MTLS.CA != "" {
+					ca = insight.MTLS.CA
+				}
+				caExpires := "-"
+				if insight.MTLS.CAExpiresAt != nil {
+					caExpires = insight.MTLS.CAExpiresAt.UTC().Format(time.RFC3339)
+				}
+				return []string{
+					insight.Mesh,                             // NAME
+					table.OnOff(insight.MTLS.Enabled),        // mTLS
+					ca,                                       // CA
+					caExpires,                                // CA EXPIRES
+					table.Number(insight.Dataplanes.Online),  // ONLINE DATAPLANES
+					table.Number(insight.Dataplanes.Offline), // OFFLINE DATAPLANES
+					policyCounts(insight.Policies),           // POLICIES
+				}
+			}
+		}(),
+	}
+	return printers.NewTablePrinter().Print(data, out)
+}
+
+// policyCounts renders numbers of policies by type, e.g. "ProxyTemplate: 1, TrafficPermission: 2".
+func policyCounts(policies map[string]int) string {
+	var types []string
+	for policyType := range policies {
+		types = append(types, policyType)
+	}
+	sort.Strings(types)
+	var counts []string
+	for _, policyType := range types {
+		counts = append(counts, fmt.Sprintf("%s: %d", policyType, policies[policyType]))
+	}
+	if len(counts) == 0 {
+		return "-"
+	}
+	return strings.Join(counts, ", ")
+}
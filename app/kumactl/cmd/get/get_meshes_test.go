@@ -18,13 +18,25 @@ import (
 	test_model "github.com/Kong/kuma/pkg/test/resources/model"
 
 	kumactl_cmd "github.com/Kong/kuma/app/kumactl/pkg/cmd"
+	kumactl_resources "github.com/Kong/kuma/app/kumactl/pkg/resources"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 	gomega_types "github.com/onsi/gomega/types"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
+type staticMeshInsightClient map[string]*kumactl_resources.MeshInsight
+
+func (c staticMeshInsightClient) Get(_ context.Context, meshName string) (*kumactl_resources.MeshInsight, error) {
+	insight, ok := c[meshName]
+	if !ok {
+		return nil, errors.Errorf("(404): mesh %q not found", meshName)
+	}
+	return insight, nil
+}
+
 var _ = Describe("kumactl get meshes", func() {
 
 	sampleMeshes := []*mesh.MeshResource{
@@ -70,6 +82,34 @@ var _ = Describe("kumactl get meshes", func() {
 		},
 	}
 
+	caExpiresAt := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	sampleInsights := staticMeshInsightClient{
+		"mesh1": {
+			Mesh: "mesh1",
+			Dataplanes: kumactl_resources.MeshInsightDataplanes{
+				Total:   3,
+				Online:  2,
+				Offline: 1,
+			},
+			Policies: map[string]int{
+				"TrafficPermission": 2,
+				"ProxyTemplate":     1,
+			},
+			MTLS: kumactl_resources.MeshInsightMTLS{
+				Enabled:     true,
+				CA:          "builtin",
+				CAExpiresAt: &caExpiresAt,
+			},
+		},
+		"mesh2": {
+			Mesh: "mesh2",
+			Policies: map[string]int{
+				"TrafficPermission": 0,
+				"ProxyTemplate":     0,
+			},
+		},
+	}
+
 	Describe("GetMeshesCmd", func() {
 
 		var rootCtx *kumactl_cmd.RootContext
@@ -85,6 +125,9 @@ var _ = Describe("kumactl get meshes", func() {
 					NewResourceStore: func(*config_proto.ControlPlaneCoordinates_ApiServer) (core_store.ResourceStore, error) {
 						return store, nil
 					},
+					NewMeshInsightClient: func(*config_proto.ControlPlaneCoordinates_ApiServer) (kumactl_resources.MeshInsightClient, error) {
+						return sampleInsights, nil
+					},
 				},
 			}
 
@@ -111,7 +154,7 @@ var _ = Describe("kumactl get meshes", func() {
 			matcher      func(interface{}) gomega_types.GomegaMatcher
 		}
 
-		DescribeTable("kumactl get meshes -o table|json|yaml",
+		DescribeTable("kumactl get meshes -o table|json|yaml|summary",
 			func(given testCase) {
 				// given
 				rootCmd.SetArgs(append([]string{
@@ -154,6 +197,13 @@ var _ = Describe("kumactl get meshes", func() {
 				goldenFile:   "get-meshes.golden.yaml",
 				matcher:      MatchYAML,
 			}),
+			Entry("should support Summary output", testCase{
+				outputFormat: "-osummary",
+				goldenFile:   "get-meshes-summary.golden.txt",
+				matcher: func(expected interface{}) gomega_types.GomegaMatcher {
+					return WithTransform(strings.TrimSpace, Equal(strings.TrimSpace(string(expected.([]byte)))))
+				},
+			}),
 		)
 	})
 
@@ -0,0 +1,75 @@
+package get
+
+import (
+	"context"
+	"io"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/Kong/kuma/app/kumactl/pkg/output"
+	"github.com/Kong/kuma/app/kumactl/pkg/output/printers"
+	"github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	rest_types "github.com/Kong/kuma/pkg/core/resources/model/rest"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+)
+
+func newGetSlowStartsCmd(pctx *getContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "slow-starts",
+		Short: "Show SlowStarts",
+		Long:  `Show SlowStart entities.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			rs, err := pctx.CurrentResourceStore()
+			if err != nil {
+				return err
+			}
+
+			slowStarts := mesh.SlowStartResourceList{}
+			if err := rs.List(context.Background(), &slowStarts, core_store.ListByMesh(pctx.CurrentMesh())); err != nil {
+				return errors.Wrapf(err, "failed to list SlowStarts")
+			}
+
+			switch format := output.Format(pctx.args.outputFormat); format {
+			case output.TableFormat:
+				return printSlowStarts(&slowStarts, cmd.OutOrStdout())
+			default:
+				printer, err := printers.NewGenericPrinter(format)
+				if err != nil {
+					return err
+				}
+				return printer.Print(rest_types.From.ResourceList(&slowStarts), cmd.OutOrStdout())
+			}
+		},
+	}
+	return cmd
+}
+
+func printSlowStarts(slowStarts *mesh.SlowStartResourceList, out io.Writer) error {
+	data := printers.Table{
+		Headers: []string{"MESH", "NAME", "WINDOW"},
+		NextRow: func() func() []string {
+			i := 0
+			return func() []string {
+				defer func() { i++ }()
+				if len(slowStarts.Items) <= i {
+					return nil
+				}
+				slowStart := slowStarts.Items[i]
+
+				window := ""
+				if d, err := types.DurationFromProto(slowStart.Spec.GetWindow()); err == nil {
+					window = d.String()
+				}
+
+				return []string{
+					slowStart.GetMeta().GetMesh(), // MESH
+					slowStart.GetMeta().GetName(), // NAME
+					window,                        // WINDOW
+				}
+			}
+		}(),
+	}
+	return printers.NewTablePrinter().Print(data, out)
+}
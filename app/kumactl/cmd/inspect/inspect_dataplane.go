@@ -0,0 +1,123 @@
+package inspect
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+
+	"github.com/Kong/kuma/app/kumactl/pkg/resources"
+	kuma_cmd "github.com/Kong/kuma/pkg/cmd"
+)
+
+const (
+	configType     = "config"
+	configDiffType = "config-diff"
+)
+
+type inspectDataplaneContext struct {
+	*inspectContext
+
+	args struct {
+		inspectType string
+		from        uint64
+		to          uint64
+	}
+}
+
+func newInspectDataplaneCmd(pctx *inspectContext) *cobra.Command {
+	ctx := inspectDataplaneContext{
+		inspectContext: pctx,
+	}
+	cmd := &cobra.Command{
+		Use:   "dataplane NAME",
+		Short: "Inspect Envoy config of a Dataplane",
+		Long:  `Inspect Envoy config of a Dataplane and find out what has changed between its recent generations. Control Plane has to keep history of config, see KUMA_XDS_SERVER_DATAPLANE_CONFIGURATION_HISTORY_SIZE.`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := pctx.CurrentConfigGenerationsClient()
+			if err != nil {
+				return errors.Wrap(err, "failed to create a config generations client")
+			}
+			generations, err := client.List(context.Background(), pctx.CurrentMesh(), args[0])
+			if err != nil {
+				return err
+			}
+			if len(generations) == 0 {
+				return errors.Errorf("there is no config of Dataplane %q in Mesh %q. Make sure that the Dataplane is connected to the Control Plane and that history of config is turned on with KUMA_XDS_SERVER_DATAPLANE_CONFIGURATION_HISTORY_SIZE", args[0], pctx.CurrentMesh())
+			}
+
+			to := generations[len(generations)-1]
+			if ctx.args.to != 0 {
+				if to, err = findGeneration(generations, ctx.args.to); err != nil {
+					return err
+				}
+			}
+			switch ctx.args.inspectType {
+			case configType:
+				_, err := fmt.Fprint(cmd.OutOrStdout(), to.Config)
+				return err
+			case configDiffType:
+				from := resources.ConfigGeneration{}
+				if ctx.args.from != 0 {
+					if from, err = findGeneration(generations, ctx.args.from); err != nil {
+						return err
+					}
+				} else {
+					// compare with the preceding generation or with nothing if it's not available anymore
+					from, _ = findGeneration(generations, to.Generation-1)
+				}
+				return printConfigDiff(from, to, cmd.OutOrStdout())
+			default:
+				return errors.Errorf("unknown type %q. Available types: %s, %s", ctx.args.inspectType, configType, configDiffType)
+			}
+		},
+	}
+	// flags
+	cmd.Flags().StringVar(&ctx.args.inspectType, "type", configDiffType, kuma_cmd.UsageOptions("what to inspect", configType, configDiffType))
+	cmd.Flags().Uint64Var(&ctx.args.from, "from", 0, "generation of config to compare with (defaults to the generation preceding --to)")
+	cmd.Flags().Uint64Var(&ctx.args.to, "to", 0, "generation of config to inspect (defaults to the latest one)")
+	return cmd
+}
+
+func findGeneration(generations []resources.ConfigGeneration, generation uint64) (resources.ConfigGeneration, error) {
+	for _, g := range generations {
+		if g.Generation == generation {
+			return g, nil
+		}
+	}
+	return resources.ConfigGeneration{}, errors.Errorf("there is no generation %d of config. Available generations: %d-%d", generation, generations[0].Generation, generations[len(generations)-1].Generation)
+}
+
+func printConfigDiff(from, to resources.ConfigGeneration, out io.Writer) error {
+	diff := difflib.UnifiedDiff{
+		A:        splitLines(from.Config),
+		B:        splitLines(to.Config),
+		FromFile: generationLabel(from),
+		ToFile:   generationLabel(to),
+		Context:  3,
+	}
+	return difflib.WriteUnifiedDiff(out, diff)
+}
+
+// splitLines splits config into lines keeping line breaks, which is a format expected by difflib.
+func splitLines(config string) []string {
+	lines := strings.SplitAfter(config, "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+	lines[len(lines)-1] += "\n"
+	return lines
+}
+
+func generationLabel(generation resources.ConfigGeneration) string {
+	if generation.Generation == 0 {
+		return "generation 0 (none)"
+	}
+	return fmt.Sprintf("generation %d (%s)", generation.Generation, generation.Time.UTC().Format(time.RFC3339))
+}
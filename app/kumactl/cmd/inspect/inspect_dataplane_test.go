@@ -0,0 +1,163 @@
+package inspect_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Kong/kuma/app/kumactl/cmd"
+	kumactl_cmd "github.com/Kong/kuma/app/kumactl/pkg/cmd"
+	"github.com/Kong/kuma/app/kumactl/pkg/resources"
+	config_proto "github.com/Kong/kuma/pkg/config/app/kumactl/v1alpha1"
+)
+
+type testConfigGenerationsClient struct {
+	receivedMesh      string
+	receivedDataplane string
+	generations       []resources.ConfigGeneration
+}
+
+func (c *testConfigGenerationsClient) List(_ context.Context, meshName string, dataplaneName string) ([]resources.ConfigGeneration, error) {
+	c.receivedMesh = meshName
+	c.receivedDataplane = dataplaneName
+	return c.generations, nil
+}
+
+var _ resources.ConfigGenerationsClient = &testConfigGenerationsClient{}
+
+var _ = Describe("kumactl inspect dataplane", func() {
+
+	var rootCtx *kumactl_cmd.RootContext
+	var rootCmd *cobra.Command
+	var buf *bytes.Buffer
+
+	var testClient *testConfigGenerationsClient
+
+	BeforeEach(func() {
+		// setup
+		t1, _ := time.Parse(time.RFC3339, "2019-07-16T15:04:05Z")
+		testClient = &testConfigGenerationsClient{
+			generations: []resources.ConfigGeneration{
+				{
+					Generation: 4,
+					Time:       t1,
+					Config: `clusters:
+- connectTimeout: 5s
+  name: backend
+endpoints: []
+listeners: []
+routes: []
+secrets: []
+`,
+				},
+				{
+					Generation: 5,
+					Time:       t1.Add(time.Minute),
+					Config: `clusters:
+- connectTimeout: 5s
+  name: backend
+- connectTimeout: 5s
+  name: redis
+endpoints: []
+listeners: []
+routes: []
+secrets: []
+`,
+				},
+				{
+					Generation: 6,
+					Time:       t1.Add(2 * time.Minute),
+					Config: `clusters:
+- connectTimeout: 10s
+  name: backend
+- connectTimeout: 5s
+  name: redis
+endpoints: []
+listeners: []
+routes: []
+secrets: []
+`,
+				},
+			},
+		}
+
+		rootCtx = &kumactl_cmd.RootContext{
+			Runtime: kumactl_cmd.RootRuntime{
+				NewConfigGenerationsClient: func(*config_proto.ControlPlaneCoordinates_ApiServer) (resources.ConfigGenerationsClient, error) {
+					return testClient, nil
+				},
+			},
+		}
+
+		rootCmd = cmd.NewRootCmd(rootCtx)
+		buf = &bytes.Buffer{}
+		rootCmd.SetOut(buf)
+	})
+
+	type testCase struct {
+		args       []string
+		goldenFile string
+	}
+
+	DescribeTable("kumactl inspect dataplane NAME --type=config|config-diff",
+		func(given testCase) {
+			// given
+			rootCmd.SetArgs(append([]string{
+				"--config-file", filepath.Join("..", "testdata", "sample-kumactl.config.yaml"),
+				"inspect", "dataplane", "web-01", "--mesh", "demo"}, given.args...))
+
+			// when
+			err := rootCmd.Execute()
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			// and
+			Expect(testClient.receivedMesh).To(Equal("demo"))
+			Expect(testClient.receivedDataplane).To(Equal("web-01"))
+
+			// when
+			expected, err := ioutil.ReadFile(filepath.Join("testdata", given.goldenFile))
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			// and
+			Expect(buf.String()).To(Equal(string(expected)))
+		},
+		Entry("should diff the latest generation with the preceding one by default", testCase{
+			args:       nil,
+			goldenFile: "inspect-dataplane.config-diff.golden.txt",
+		}),
+		Entry("should diff given generations", testCase{
+			args:       []string{"--type", "config-diff", "--from", "4", "--to", "6"},
+			goldenFile: "inspect-dataplane.config-diff-4-6.golden.txt",
+		}),
+		Entry("should diff the oldest generation with an empty config", testCase{
+			args:       []string{"--to", "4"},
+			goldenFile: "inspect-dataplane.config-diff-4.golden.txt",
+		}),
+		Entry("should print config of a given generation", testCase{
+			args:       []string{"--type", "config", "--to", "5"},
+			goldenFile: "inspect-dataplane.config.golden.txt",
+		}),
+	)
+
+	It("should fail on unknown generation", func() {
+		// given
+		rootCmd.SetArgs([]string{
+			"--config-file", filepath.Join("..", "testdata", "sample-kumactl.config.yaml"),
+			"inspect", "dataplane", "web-01", "--from", "1"})
+		rootCmd.SetErr(&bytes.Buffer{})
+
+		// when
+		err := rootCmd.Execute()
+
+		// then
+		Expect(err).To(MatchError("there is no generation 1 of config. Available generations: 4-6"))
+	})
+})
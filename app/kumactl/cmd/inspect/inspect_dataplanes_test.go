@@ -21,6 +21,7 @@ import (
 	kumactl_cmd "github.com/Kong/kuma/app/kumactl/pkg/cmd"
 	config_proto "github.com/Kong/kuma/pkg/config/app/kumactl/v1alpha1"
 	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
 	test_model "github.com/Kong/kuma/pkg/test/resources/model"
 	util_proto "github.com/Kong/kuma/pkg/util/proto"
 )
@@ -33,7 +34,8 @@ type testDataplaneOverviewClient struct {
 func (c *testDataplaneOverviewClient) List(_ context.Context, _ string, tags map[string]string) (*mesh_core.DataplaneOverviewResourceList, error) {
 	c.receivedTags = tags
 	return &mesh_core.DataplaneOverviewResourceList{
-		Items: c.overviews,
+		Items:      c.overviews,
+		Pagination: core_model.Pagination{Total: uint32(len(c.overviews))},
 	}, nil
 }
 
@@ -184,7 +186,7 @@ var _ = Describe("kumactl inspect dataplanes", func() {
 				// given
 				rootCmd.SetArgs(append([]string{
 					"--config-file", filepath.Join("..", "testdata", "sample-kumactl.config.yaml"),
-					"get", "dataplanes"}, given.outputFormat))
+					"inspect", "dataplanes"}, given.outputFormat))
 
 				// when
 				err := rootCmd.Execute()
@@ -229,7 +231,7 @@ var _ = Describe("kumactl inspect dataplanes", func() {
 				// given
 				rootCmd.SetArgs([]string{
 					"--config-file", filepath.Join("..", "testdata", "sample-kumactl.config.yaml"),
-					"get", "dataplanes", "--tag", "service=mobile", "--tag", "version=v1"})
+					"inspect", "dataplanes", "--tag", "service=mobile", "--tag", "version=v1"})
 
 				// when
 				err := rootCmd.Execute()
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"text/template"
 
 	"github.com/Masterminds/sprig"
@@ -14,6 +15,7 @@ import (
 	"github.com/Kong/kuma/app/kumactl/pkg/install/data"
 	"github.com/Kong/kuma/app/kumactl/pkg/install/k8s"
 	controlplane "github.com/Kong/kuma/app/kumactl/pkg/install/k8s/control-plane"
+	kuma_cp "github.com/Kong/kuma/pkg/config/app/kuma-cp"
 	"github.com/Kong/kuma/pkg/tls"
 	kuma_version "github.com/Kong/kuma/pkg/version"
 )
@@ -30,6 +32,10 @@ func newInstallControlPlaneCmd(pctx *kumactl_cmd.RootContext) *cobra.Command {
 		ControlPlaneVersion     string
 		ControlPlaneImage       string
 		ControlPlaneServiceName string
+		ControlPlaneConfigFile  string
+		ControlPlane            kuma_cp.Config
+		ControlPlaneConfig      string
+		ControlPlaneSecrets     map[string]string
 		InjectorImage           string
 		InjectorFailurePolicy   string
 		InjectorServiceName     string
@@ -40,12 +46,15 @@ func newInstallControlPlaneCmd(pctx *kumactl_cmd.RootContext) *cobra.Command {
 		DataplaneInitVersion    string
 		SdsTlsCert              string
 		SdsTlsKey               string
+		AdmissionServerTlsCert  string
+		AdmissionServerTlsKey   string
 	}{
 		Namespace:               "kuma-system",
 		ImagePullPolicy:         "IfNotPresent",
 		ControlPlaneVersion:     kuma_version.Build.Version,
 		ControlPlaneImage:       "kong-docker-kuma-docker.bintray.io/kuma-cp",
 		ControlPlaneServiceName: "kuma-control-plane",
+		ControlPlaneConfigFile:  "",
 		InjectorImage:           "kong-docker-kuma-docker.bintray.io/kuma-injector",
 		InjectorFailurePolicy:   "Ignore",
 		InjectorServiceName:     "kuma-injector",
@@ -56,6 +65,8 @@ func newInstallControlPlaneCmd(pctx *kumactl_cmd.RootContext) *cobra.Command {
 		DataplaneInitVersion:    "1.1.2",
 		SdsTlsCert:              "",
 		SdsTlsKey:               "",
+		AdmissionServerTlsCert:  "",
+		AdmissionServerTlsKey:   "",
 	}
 	cmd := &cobra.Command{
 		Use:   "control-plane",
@@ -94,6 +105,39 @@ func newInstallControlPlaneCmd(pctx *kumactl_cmd.RootContext) *cobra.Command {
 				return errors.Errorf("SDS: both TLS Cert and TLS Key must be provided at the same time")
 			}
 
+			if args.AdmissionServerTlsCert == "" && args.AdmissionServerTlsKey == "" {
+				fqdn := fmt.Sprintf("%s.%s.svc", args.ControlPlaneServiceName, args.Namespace)
+				// notice that Kubernetes doesn't requires DNS SAN in a X509 cert of a WebHook
+				admissionCert, err := NewSelfSignedCert(fqdn)
+				if err != nil {
+					return errors.Wrapf(err, "Failed to generate TLS certificate for %q", fqdn)
+				}
+				args.AdmissionServerTlsCert = string(admissionCert.CertPEM)
+				args.AdmissionServerTlsKey = string(admissionCert.KeyPEM)
+			} else if args.AdmissionServerTlsCert == "" || args.AdmissionServerTlsKey == "" {
+				return errors.Errorf("Admission Server: both TLS Cert and TLS Key must be provided at the same time")
+			}
+
+			var overrides []byte
+			if args.ControlPlaneConfigFile != "" {
+				content, err := ioutil.ReadFile(args.ControlPlaneConfigFile)
+				if err != nil {
+					return errors.Wrapf(err, "Failed to read Control Plane configuration from file %q", args.ControlPlaneConfigFile)
+				}
+				overrides = content
+			}
+			controlPlaneConfig, err := controlplane.NewConfig(args.Namespace, args.ControlPlaneServiceName, overrides)
+			if err != nil {
+				return errors.Wrap(err, "Failed to generate Control Plane configuration")
+			}
+			renderedConfig, secrets, err := controlplane.RenderConfig(controlPlaneConfig)
+			if err != nil {
+				return err
+			}
+			args.ControlPlane = controlPlaneConfig
+			args.ControlPlaneConfig = renderedConfig
+			args.ControlPlaneSecrets = secrets
+
 			templateFiles, err := data.ReadFiles(controlplane.Templates)
 			if err != nil {
 				return errors.Wrap(err, "Failed to read template files")
@@ -121,6 +165,7 @@ func newInstallControlPlaneCmd(pctx *kumactl_cmd.RootContext) *cobra.Command {
 	cmd.Flags().StringVar(&args.ControlPlaneVersion, "control-plane-version", args.ControlPlaneVersion, "version shared by all components of the Kuma Control Plane")
 	cmd.Flags().StringVar(&args.ControlPlaneImage, "control-plane-image", args.ControlPlaneImage, "image of the Kuma Control Plane component")
 	cmd.Flags().StringVar(&args.ControlPlaneServiceName, "control-plane-service-name", args.ControlPlaneServiceName, "Service name of the Kuma Control Plane")
+	cmd.Flags().StringVar(&args.ControlPlaneConfigFile, "control-plane-config", args.ControlPlaneConfigFile, "path to a file with configuration of the Kuma Control Plane (in the format of kuma-cp config file) that overrides the defaults")
 	cmd.Flags().StringVar(&args.InjectorImage, "injector-image", args.InjectorImage, "image of the Kuma Injector component")
 	cmd.Flags().StringVar(&args.InjectorFailurePolicy, "injector-failure-policy", args.InjectorFailurePolicy, "failue policy of the mutating web hook implemented by the Kuma Injector component")
 	cmd.Flags().StringVar(&args.InjectorServiceName, "injector-service-name", args.InjectorServiceName, "Service name of the mutating web hook implemented by the Kuma Injector component")
@@ -131,6 +176,8 @@ func newInstallControlPlaneCmd(pctx *kumactl_cmd.RootContext) *cobra.Command {
 	cmd.Flags().StringVar(&args.DataplaneInitVersion, "dataplane-init-version", args.DataplaneInitVersion, "version of the init image of the Kuma Dataplane component")
 	cmd.Flags().StringVar(&args.SdsTlsCert, "sds-tls-cert", args.SdsTlsCert, "TLS certificate for the SDS server")
 	cmd.Flags().StringVar(&args.SdsTlsKey, "sds-tls-key", args.SdsTlsKey, "TLS key for the SDS server")
+	cmd.Flags().StringVar(&args.AdmissionServerTlsCert, "admission-server-tls-cert", args.AdmissionServerTlsCert, "TLS certificate for the admission web hooks implemented by the Kuma Control Plane")
+	cmd.Flags().StringVar(&args.AdmissionServerTlsKey, "admission-server-tls-key", args.AdmissionServerTlsKey, "TLS key for the admission web hooks implemented by the Kuma Control Plane")
 	return cmd
 }
 
@@ -123,8 +123,34 @@ var _ = Describe("kumactl install control-plane", func() {
 				"--dataplane-init-version", "dev",
 				"--sds-tls-cert", "SdsCert",
 				"--sds-tls-key", "SdsKey",
+				"--admission-server-tls-cert", "AdmissionCert",
+				"--admission-server-tls-key", "AdmissionKey",
 			},
 			goldenFile: "install-control-plane.overrides.golden.yaml",
 		}),
+		Entry("should generate Kubernetes resources with custom configuration of Control Plane", testCase{
+			extraArgs: []string{
+				"--control-plane-config", filepath.Join("testdata", "install-control-plane.config.input.yaml"),
+			},
+			goldenFile: "install-control-plane.config.golden.yaml",
+		}),
 	)
+
+	It("should fail on invalid configuration of Control Plane", func() {
+		// given
+		rootCmd := cmd.DefaultRootCmd()
+		rootCmd.SetArgs([]string{"install", "control-plane",
+			"--control-plane-config", filepath.Join("testdata", "install-control-plane.invalid-config.input.yaml"),
+		})
+		rootCmd.SetOut(stdout)
+		rootCmd.SetErr(stderr)
+
+		// when
+		err := rootCmd.Execute()
+
+		// then
+		Expect(err).To(HaveOccurred())
+		// and
+		Expect(err.Error()).To(ContainSubstring("Failed to generate Control Plane configuration"))
+	})
 })
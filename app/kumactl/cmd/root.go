@@ -6,6 +6,10 @@ import (
 
 	"github.com/Kong/kuma/app/kumactl/cmd/apply"
 	"github.com/Kong/kuma/app/kumactl/cmd/config"
+	"github.com/Kong/kuma/app/kumactl/cmd/convert"
+	"github.com/Kong/kuma/app/kumactl/cmd/delete"
+	"github.com/Kong/kuma/app/kumactl/cmd/export"
+	"github.com/Kong/kuma/app/kumactl/cmd/generate"
 	"github.com/Kong/kuma/app/kumactl/cmd/get"
 	"github.com/Kong/kuma/app/kumactl/cmd/inspect"
 	"github.com/Kong/kuma/app/kumactl/cmd/install"
@@ -61,6 +65,10 @@ func NewRootCmd(root *kumactl_cmd.RootContext) *cobra.Command {
 	cmd.AddCommand(get.NewGetCmd(root))
 	cmd.AddCommand(inspect.NewInspectCmd(root))
 	cmd.AddCommand(apply.NewApplyCmd(root))
+	cmd.AddCommand(delete.NewDeleteCmd(root))
+	cmd.AddCommand(convert.NewConvertCmd(root))
+	cmd.AddCommand(export.NewExportCmd(root))
+	cmd.AddCommand(generate.NewGenerateCmd(root))
 	cmd.AddCommand(version.NewVersionCmd())
 	return cmd
 }
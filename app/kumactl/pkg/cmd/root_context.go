@@ -22,6 +22,8 @@ type RootRuntime struct {
 	Now                        func() time.Time
 	NewResourceStore           func(*config_proto.ControlPlaneCoordinates_ApiServer) (core_store.ResourceStore, error)
 	NewDataplaneOverviewClient func(*config_proto.ControlPlaneCoordinates_ApiServer) (kumactl_resources.DataplaneOverviewClient, error)
+	NewConfigGenerationsClient func(*config_proto.ControlPlaneCoordinates_ApiServer) (kumactl_resources.ConfigGenerationsClient, error)
+	NewMeshInsightClient       func(*config_proto.ControlPlaneCoordinates_ApiServer) (kumactl_resources.MeshInsightClient, error)
 }
 
 type RootContext struct {
@@ -35,6 +37,8 @@ func DefaultRootContext() *RootContext {
 			Now:                        time.Now,
 			NewResourceStore:           kumactl_resources.NewResourceStore,
 			NewDataplaneOverviewClient: kumactl_resources.NewDataplaneOverviewClient,
+			NewConfigGenerationsClient: kumactl_resources.NewConfigGenerationsClient,
+			NewMeshInsightClient:       kumactl_resources.NewMeshInsightClient,
 		},
 	}
 }
@@ -105,6 +109,22 @@ func (rc *RootContext) CurrentDataplaneOverviewClient() (kumactl_resources.Datap
 	return rc.Runtime.NewDataplaneOverviewClient(controlPlane.Coordinates.ApiServer)
 }
 
+func (rc *RootContext) CurrentConfigGenerationsClient() (kumactl_resources.ConfigGenerationsClient, error) {
+	controlPlane, err := rc.CurrentControlPlane()
+	if err != nil {
+		return nil, err
+	}
+	return rc.Runtime.NewConfigGenerationsClient(controlPlane.Coordinates.ApiServer)
+}
+
+func (rc *RootContext) CurrentMeshInsightClient() (kumactl_resources.MeshInsightClient, error) {
+	controlPlane, err := rc.CurrentControlPlane()
+	if err != nil {
+		return nil, err
+	}
+	return rc.Runtime.NewMeshInsightClient(controlPlane.Coordinates.ApiServer)
+}
+
 func (rc *RootContext) IsFirstTimeUsage() bool {
 	return rc.Args.ConfigFile == "" && !config.FileExists(config.DefaultConfigFile)
 }
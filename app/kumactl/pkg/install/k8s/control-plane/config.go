@@ -0,0 +1,84 @@
+package controlplane
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	kuma_cp "github.com/Kong/kuma/pkg/config/app/kuma-cp"
+	"github.com/Kong/kuma/pkg/config/core/resources/store"
+	"github.com/Kong/kuma/pkg/config/xds"
+)
+
+const (
+	// sdsTlsCertDir must match a mount path of the Secret with TLS cert of SDS server.
+	sdsTlsCertDir = "/var/run/secrets/kuma.io/kuma-sds/tls-cert"
+	// admissionServerTlsCertDir must match a mount path of the Secret with TLS cert of Admission WebHook Server.
+	admissionServerTlsCertDir = "/var/run/secrets/kuma.io/kuma-admission-server/tls-cert"
+)
+
+// NewConfig returns configuration of Kuma Control Plane deployed on Kubernetes.
+//
+// Configuration is derived from the defaults of Kuma Control Plane and the settings
+// Kubernetes deployment depends on. Overrides given in YAML are applied on top of them,
+// which makes every option of Kuma Control Plane installable.
+func NewConfig(namespace, serviceName string, overrides []byte) (kuma_cp.Config, error) {
+	cfg := kuma_cp.DefaultConfig()
+	cfg.Environment = kuma_cp.KubernetesEnvironment
+	cfg.Store.Type = store.KubernetesStore
+	cfg.Store.Kubernetes.SystemNamespace = namespace
+	cfg.XdsServer.DataplaneAuth.Type = xds.ServiceAccountTokenDataplaneAuth
+	cfg.BootstrapServer.Params.XdsHost = fmt.Sprintf("%s.%s", serviceName, namespace)
+	cfg.SdsServer.TlsCertFile = sdsTlsCertDir + "/tls.crt"
+	cfg.SdsServer.TlsKeyFile = sdsTlsCertDir + "/tls.key"
+	cfg.Runtime.Kubernetes.AdmissionServer.CertDir = admissionServerTlsCertDir
+
+	if err := yaml.Unmarshal(overrides, &cfg); err != nil {
+		return cfg, errors.Wrap(err, "could not parse Control Plane configuration")
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, errors.Wrap(err, "invalid Control Plane configuration")
+	}
+	return cfg, nil
+}
+
+// RenderConfig renders configuration of Kuma Control Plane in the format of its config file.
+//
+// Secret settings, e.g. a password of Postgres or tokens of API Server, are left out of the config file,
+// which ends up in a ConfigMap. Instead, they are returned by the name of the environment variable
+// Kuma Control Plane reads them from, so that they can be put into a Secret.
+func RenderConfig(cfg kuma_cp.Config) (string, map[string]string, error) {
+	content, err := yaml.Marshal(&cfg)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "could not render Control Plane configuration")
+	}
+	// parts of configuration are referenced by pointers, that is why secrets are removed from a copy
+	withoutSecrets := kuma_cp.DefaultConfig()
+	if err := yaml.Unmarshal(content, &withoutSecrets); err != nil {
+		return "", nil, errors.Wrap(err, "could not render Control Plane configuration")
+	}
+	secrets := map[string]string{}
+	for env, value := range secretSettings(&withoutSecrets) {
+		if *value != "" {
+			secrets[env] = *value
+			*value = ""
+		}
+	}
+	content, err = yaml.Marshal(&withoutSecrets)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "could not render Control Plane configuration")
+	}
+	return string(content), secrets, nil
+}
+
+// secretSettings returns settings that must not be kept in a ConfigMap by the name of the environment variable.
+func secretSettings(cfg *kuma_cp.Config) map[string]*string {
+	return map[string]*string{
+		"KUMA_STORE_POSTGRES_PASSWORD": &cfg.Store.Postgres.Password,
+		"KUMA_API_SERVER_ADMIN_TOKEN":  &cfg.ApiServer.AdminToken,
+		"KUMA_API_SERVER_WRITE_TOKEN":  &cfg.ApiServer.WriteToken,
+		"KUMA_API_SERVER_READ_TOKEN":   &cfg.ApiServer.ReadToken,
+		"KUMA_DISCOVERY_CONSUL_TOKEN":  &cfg.Discovery.Consul.Token,
+	}
+}
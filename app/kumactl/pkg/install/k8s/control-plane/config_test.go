@@ -0,0 +1,97 @@
+package controlplane_test
+
+import (
+	"io/ioutil"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	controlplane "github.com/Kong/kuma/app/kumactl/pkg/install/k8s/control-plane"
+	"github.com/Kong/kuma/pkg/config"
+	kuma_cp "github.com/Kong/kuma/pkg/config/app/kuma-cp"
+)
+
+var _ = Describe("Config", func() {
+
+	It("should apply overrides on top of Kubernetes settings", func() {
+		// given
+		overrides := `
+xdsServer:
+  grpcPort: 15678
+apiServer:
+  readOnly: true
+`
+		// when
+		cfg, err := controlplane.NewConfig("kuma", "kuma-ctrl-plane", []byte(overrides))
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		// and
+		Expect(cfg.Environment).To(Equal(kuma_cp.KubernetesEnvironment))
+		Expect(cfg.Store.Kubernetes.SystemNamespace).To(Equal("kuma"))
+		Expect(cfg.BootstrapServer.Params.XdsHost).To(Equal("kuma-ctrl-plane.kuma"))
+		Expect(cfg.XdsServer.GrpcPort).To(Equal(15678))
+		Expect(cfg.ApiServer.ReadOnly).To(BeTrue())
+	})
+
+	It("should reject invalid configuration", func() {
+		// given
+		overrides := `
+environment: universal
+`
+		// when
+		_, err := controlplane.NewConfig("kuma", "kuma-ctrl-plane", []byte(overrides))
+
+		// then
+		Expect(err).To(MatchError(ContainSubstring("invalid Control Plane configuration")))
+	})
+
+	It("should render configuration that kuma-cp loads back unchanged", func() {
+		// given
+		overrides := `
+apiServer:
+  writeToken: s3cr3t
+store:
+  postgres:
+    password: passw0rd
+`
+		expected, err := controlplane.NewConfig("kuma", "kuma-ctrl-plane", []byte(overrides))
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		content, secrets, err := controlplane.RenderConfig(expected)
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		// and
+		Expect(content).ToNot(ContainSubstring("s3cr3t"))
+		Expect(content).ToNot(ContainSubstring("passw0rd"))
+		Expect(secrets).To(Equal(map[string]string{
+			"KUMA_API_SERVER_WRITE_TOKEN":  "s3cr3t",
+			"KUMA_STORE_POSTGRES_PASSWORD": "passw0rd",
+		}))
+		// and
+		Expect(expected.ApiServer.WriteToken).To(Equal("s3cr3t"))
+
+		// given
+		file, err := ioutil.TempFile("", "*")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.Remove(file.Name())
+		_, err = file.WriteString(content)
+		Expect(err).ToNot(HaveOccurred())
+		// and
+		for env, value := range secrets {
+			Expect(os.Setenv(env, value)).To(Succeed())
+			defer os.Unsetenv(env)
+		}
+
+		// when
+		actual := kuma_cp.DefaultConfig()
+		err = config.Load(file.Name(), &actual)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		// and
+		Expect(actual).To(Equal(expected))
+	})
+})
@@ -0,0 +1,85 @@
+package manifests
+
+import (
+	"encoding/json"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	kube_meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/Kong/kuma/pkg/core/resources/model"
+	rest_types "github.com/Kong/kuma/pkg/core/resources/model/rest"
+	mesh_k8s "github.com/Kong/kuma/pkg/plugins/resources/k8s/native/api/v1alpha1"
+	k8s_registry "github.com/Kong/kuma/pkg/plugins/resources/k8s/native/pkg/registry"
+	util_proto "github.com/Kong/kuma/pkg/util/proto"
+)
+
+const (
+	// UniversalFormat is the format of Kuma API Server.
+	UniversalFormat = "universal"
+	// KubernetesFormat is the format of Kubernetes custom resources.
+	KubernetesFormat = "kubernetes"
+)
+
+// Marshaller renders a resource as a YAML manifest.
+type Marshaller func(model.Resource) ([]byte, error)
+
+// NewMarshaller returns a Marshaller for a given format.
+// Namespace is only used by the kubernetes format.
+func NewMarshaller(format string, namespace string) (Marshaller, error) {
+	switch format {
+	case UniversalFormat:
+		return ToUniversal, nil
+	case KubernetesFormat:
+		return func(resource model.Resource) ([]byte, error) {
+			return ToKubernetes(resource, namespace)
+		}, nil
+	default:
+		return nil, errors.Errorf("unknown format %q: must be one of %q or %q", format, UniversalFormat, KubernetesFormat)
+	}
+}
+
+func ToUniversal(resource model.Resource) ([]byte, error) {
+	content, err := json.Marshal(rest_types.From.Resource(resource))
+	if err != nil {
+		return nil, err
+	}
+	return yaml.JSONToYAML(content)
+}
+
+func ToKubernetes(resource model.Resource, namespace string) ([]byte, error) {
+	obj, err := k8s_registry.Global().NewObject(resource.GetSpec())
+	if err != nil {
+		return nil, err
+	}
+	obj.GetObjectKind().SetGroupVersionKind(mesh_k8s.GroupVersion.WithKind(string(resource.GetType())))
+	obj.SetObjectMeta(&kube_meta.ObjectMeta{
+		Name:      resource.GetMeta().GetName(),
+		Namespace: namespace,
+		Labels:    resource.GetMeta().GetLabels(),
+	})
+	obj.SetMesh(resource.GetMeta().GetMesh())
+	spec, err := util_proto.ToMap(resource.GetSpec())
+	if err != nil {
+		return nil, err
+	}
+	obj.SetSpec(spec)
+
+	content, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	// ObjectMeta always carries a creation timestamp, which makes no sense in a manifest
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(content, &fields); err != nil {
+		return nil, err
+	}
+	if metadata, ok := fields["metadata"].(map[string]interface{}); ok {
+		delete(metadata, "creationTimestamp")
+	}
+	content, err = json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	return yaml.JSONToYAML(content)
+}
@@ -6,4 +6,6 @@ const (
 	TableFormat Format = "table"
 	YAMLFormat  Format = "yaml"
 	JSONFormat  Format = "json"
+	// SummaryFormat is a table with a summary of each resource, only supported by some commands
+	SummaryFormat Format = "summary"
 )
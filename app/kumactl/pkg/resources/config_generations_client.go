@@ -0,0 +1,67 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	config_proto "github.com/Kong/kuma/pkg/config/app/kumactl/v1alpha1"
+	kuma_http "github.com/Kong/kuma/pkg/util/http"
+)
+
+// ConfigGeneration is a generation of Envoy config of a Dataplane rendered by the Control Plane.
+type ConfigGeneration struct {
+	Generation uint64    `json:"generation"`
+	Time       time.Time `json:"time"`
+	Config     string    `json:"config"`
+}
+
+type ConfigGenerationsClient interface {
+	// List returns recent generations of Envoy config of a Dataplane, oldest first.
+	List(ctx context.Context, meshName string, dataplaneName string) ([]ConfigGeneration, error)
+}
+
+func NewConfigGenerationsClient(coordinates *config_proto.ControlPlaneCoordinates_ApiServer) (ConfigGenerationsClient, error) {
+	client, err := apiServerClient(coordinates.Url)
+	if err != nil {
+		return nil, err
+	}
+	return &httpConfigGenerationsClient{
+		Client: client,
+	}, nil
+}
+
+type httpConfigGenerationsClient struct {
+	Client kuma_http.Client
+}
+
+func (c *httpConfigGenerationsClient) List(ctx context.Context, meshName string, dataplaneName string) ([]ConfigGeneration, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("/meshes/%s/dataplanes/%s/config-generations", meshName, dataplaneName), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.Client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != 200 {
+		return nil, errors.Errorf("(%d): %s", resp.StatusCode, string(b))
+	}
+	list := struct {
+		Items []ConfigGeneration `json:"items"`
+	}{}
+	if err := json.Unmarshal(b, &list); err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
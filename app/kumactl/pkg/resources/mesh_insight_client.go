@@ -0,0 +1,77 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	config_proto "github.com/Kong/kuma/pkg/config/app/kumactl/v1alpha1"
+	kuma_http "github.com/Kong/kuma/pkg/util/http"
+)
+
+// MeshInsight is a summary of a Mesh rendered by the Control Plane.
+type MeshInsight struct {
+	Mesh       string                `json:"mesh"`
+	Dataplanes MeshInsightDataplanes `json:"dataplanes"`
+	Policies   map[string]int        `json:"policies"`
+	MTLS       MeshInsightMTLS       `json:"mtls"`
+}
+
+type MeshInsightDataplanes struct {
+	Total   int `json:"total"`
+	Online  int `json:"online"`
+	Offline int `json:"offline"`
+}
+
+type MeshInsightMTLS struct {
+	Enabled     bool       `json:"enabled"`
+	CA          string     `json:"ca,omitempty"`
+	CAExpiresAt *time.Time `json:"caExpiresAt,omitempty"`
+}
+
+type MeshInsightClient interface {
+	Get(ctx context.Context, meshName string) (*MeshInsight, error)
+}
+
+func NewMeshInsightClient(coordinates *config_proto.ControlPlaneCoordinates_ApiServer) (MeshInsightClient, error) {
+	client, err := apiServerClient(coordinates.Url)
+	if err != nil {
+		return nil, err
+	}
+	return &httpMeshInsightClient{
+		Client: client,
+	}, nil
+}
+
+type httpMeshInsightClient struct {
+	Client kuma_http.Client
+}
+
+func (c *httpMeshInsightClient) Get(ctx context.Context, meshName string) (*MeshInsight, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("/meshes/%s/insight", meshName), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.Client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != 200 {
+		return nil, errors.Errorf("(%d): %s", resp.StatusCode, string(b))
+	}
+	insight := &MeshInsight{}
+	if err := json.Unmarshal(b, insight); err != nil {
+		return nil, err
+	}
+	return insight, nil
+}
@@ -5,5 +5,33 @@ CREATE TABLE IF NOT EXISTS resources (
     type        varchar(100) NOT NULL,
     version     integer NOT NULL,
     spec        text,
+    labels      jsonb,
     PRIMARY KEY (name, namespace, mesh, type)
-);
\ No newline at end of file
+);
+
+-- upgrade of a table created by a previous version
+ALTER TABLE resources ADD COLUMN IF NOT EXISTS labels jsonb;
+
+-- notifications about changes of resources
+CREATE OR REPLACE FUNCTION notify_resource_event() RETURNS trigger AS $$
+DECLARE
+    changed resources;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        changed := OLD;
+    ELSE
+        changed := NEW;
+    END IF;
+    PERFORM pg_notify('resource_events', json_build_object(
+        'action', TG_OP,
+        'type', changed.type,
+        'namespace', changed.namespace,
+        'name', changed.name,
+        'mesh', changed.mesh
+    )::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+DROP TRIGGER IF EXISTS resource_events ON resources;
+CREATE TRIGGER resource_events AFTER INSERT OR UPDATE OR DELETE ON resources
+    FOR EACH ROW EXECUTE PROCEDURE notify_resource_event();
@@ -0,0 +1,13 @@
+package api_client_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestApiClient(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "API Client Suite")
+}
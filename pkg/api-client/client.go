@@ -0,0 +1,144 @@
+package api_client
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/Kong/kuma/pkg/api-server/definitions"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	"github.com/Kong/kuma/pkg/plugins/resources/remote"
+	util_http "github.com/Kong/kuma/pkg/util/http"
+)
+
+const (
+	// DefaultTimeout is a time limit for a single request to the API Server.
+	DefaultTimeout = 60 * time.Second
+	// DefaultMaxRetries is the number of times a failed request is retried before giving up.
+	DefaultMaxRetries = 3
+	// DefaultBackoff is how long to wait before the first retry of a failed request.
+	DefaultBackoff = 100 * time.Millisecond
+	// DefaultWatchInterval is how often resources are checked for changes while being watched.
+	DefaultWatchInterval = 5 * time.Second
+)
+
+// Client is a typed client of Kuma API Server, e.g. to build operators on top of Kuma.
+//
+// Requests that fail because of a network error or a temporary unavailability of the API Server
+// are retried with an exponential backoff.
+type Client interface {
+	Meshes() MeshClient
+	Dataplanes() DataplaneClient
+	DataplaneInsights() DataplaneInsightClient
+	ProxyTemplates() ProxyTemplateClient
+	TrafficPermissions() TrafficPermissionClient
+	SlowStarts() SlowStartClient
+}
+
+type clientOptions struct {
+	httpClient    util_http.Client
+	token         string
+	maxRetries    int
+	backoff       time.Duration
+	watchInterval time.Duration
+}
+
+type ClientOption func(*clientOptions)
+
+// WithHTTPClient makes requests go through a given HTTP client, e.g. to use custom TLS settings.
+func WithHTTPClient(httpClient util_http.Client) ClientOption {
+	return func(opts *clientOptions) {
+		opts.httpClient = httpClient
+	}
+}
+
+// WithToken makes requests present a given token of the API Server, i.e. its admin, write or read token,
+// as "Authorization: Bearer <token>".
+func WithToken(token string) ClientOption {
+	return func(opts *clientOptions) {
+		opts.token = token
+	}
+}
+
+// WithRetries changes how many times and how soon a failed request is retried.
+// Zero maxRetries disables retries.
+func WithRetries(maxRetries int, backoff time.Duration) ClientOption {
+	return func(opts *clientOptions) {
+		opts.maxRetries = maxRetries
+		opts.backoff = backoff
+	}
+}
+
+// WithWatchInterval changes how often watched resources are checked for changes.
+func WithWatchInterval(interval time.Duration) ClientOption {
+	return func(opts *clientOptions) {
+		opts.watchInterval = interval
+	}
+}
+
+// NewClient returns a client of the API Server available at a given URL.
+func NewClient(apiUrl string, fs ...ClientOption) (Client, error) {
+	baseURL, err := url.Parse(apiUrl)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to parse API Server URL")
+	}
+	opts := &clientOptions{
+		httpClient:    &http.Client{Timeout: DefaultTimeout},
+		maxRetries:    DefaultMaxRetries,
+		backoff:       DefaultBackoff,
+		watchInterval: DefaultWatchInterval,
+	}
+	for _, f := range fs {
+		f(opts)
+	}
+	// the remote store only sends GET, PUT and DELETE requests, which are safe to retry
+	httpClient := util_http.ClientWithRetries(opts.httpClient, opts.maxRetries, opts.backoff)
+	if opts.token != "" {
+		httpClient = clientWithToken(httpClient, opts.token)
+	}
+	httpClient = util_http.ClientWithBaseURL(httpClient, baseURL)
+	return &client{
+		store:         remote.NewStore(httpClient, definitions.AllApis()),
+		watchInterval: opts.watchInterval,
+	}, nil
+}
+
+var _ Client = &client{}
+
+type client struct {
+	store         core_store.ResourceStore
+	watchInterval time.Duration
+}
+
+func (c *client) Meshes() MeshClient {
+	return &meshClient{c}
+}
+
+func (c *client) Dataplanes() DataplaneClient {
+	return &dataplaneClient{c}
+}
+
+func (c *client) DataplaneInsights() DataplaneInsightClient {
+	return &dataplaneInsightClient{c}
+}
+
+func (c *client) ProxyTemplates() ProxyTemplateClient {
+	return &proxyTemplateClient{c}
+}
+
+func (c *client) TrafficPermissions() TrafficPermissionClient {
+	return &trafficPermissionClient{c}
+}
+
+func (c *client) SlowStarts() SlowStartClient {
+	return &slowStartClient{c}
+}
+
+func clientWithToken(delegate util_http.Client, token string) util_http.Client {
+	return util_http.ClientFunc(func(req *http.Request) (*http.Response, error) {
+		req.Header.Set("Authorization", "Bearer "+token)
+		return delegate.Do(req)
+	})
+}
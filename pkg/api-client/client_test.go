@@ -0,0 +1,184 @@
+package api_client_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	api_client "github.com/Kong/kuma/pkg/api-client"
+	api_server "github.com/Kong/kuma/pkg/api-server"
+	"github.com/Kong/kuma/pkg/api-server/definitions"
+	config "github.com/Kong/kuma/pkg/config/api-server"
+	builtin_ca "github.com/Kong/kuma/pkg/core/ca/builtin"
+	builtin_issuer "github.com/Kong/kuma/pkg/core/ca/builtin/issuer"
+	provided_ca "github.com/Kong/kuma/pkg/core/ca/provided"
+	dataplane_managers "github.com/Kong/kuma/pkg/core/managers/apis/dataplane"
+	freeze_managers "github.com/Kong/kuma/pkg/core/managers/freeze"
+	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	secret_cipher "github.com/Kong/kuma/pkg/core/secrets/cipher"
+	secret_manager "github.com/Kong/kuma/pkg/core/secrets/manager"
+	secret_store "github.com/Kong/kuma/pkg/core/secrets/store"
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+	"github.com/Kong/kuma/pkg/test"
+	"github.com/Kong/kuma/pkg/tokens/builtin/issuer"
+)
+
+var _ = Describe("Client", func() {
+
+	var client api_client.Client
+	var apiUrl string
+	var stop chan struct{}
+
+	BeforeEach(func() {
+		store := memory.NewStore()
+		cfg := *config.DefaultApiServerConfig()
+		port, err := test.GetFreePort()
+		Expect(err).ToNot(HaveOccurred())
+		cfg.Port = port
+		cfg.WriteToken = "wr1t3"
+		keyManager := issuer.NewSigningKeyManager(secret_manager.NewSecretManager(secret_store.NewSecretStore(store), secret_cipher.None()))
+		caManager := builtin_ca.NewBuiltinCaManager(secret_manager.NewSecretManager(secret_store.NewSecretStore(store), secret_cipher.None()))
+		resManager := core_manager.NewResourceManager(store)
+		apiServer := api_server.NewApiServer(resManager, keyManager, issuer.NewDataplaneTokenIssuer(keyManager), caManager, provided_ca.NewProvidedCaManager(nil, nil, builtin_issuer.DefaultWorkloadCertValidityPeriod, builtin_issuer.DefaultAllowedClockSkew), core_xds.NewConfigHistory(10), freeze_managers.NewConfigFreeze(secret_manager.NewSecretManager(secret_store.NewSecretStore(store), secret_cipher.None())), core_xds.NewStreamTracker(), dataplane_managers.NewRegistry(resManager), definitions.All, cfg)
+
+		stop = make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			Expect(apiServer.Start(stop)).To(Succeed())
+		}()
+
+		apiUrl = fmt.Sprintf("http://localhost:%d", port)
+		client, err = api_client.NewClient(apiUrl, api_client.WithToken("wr1t3"), api_client.WithWatchInterval(10*time.Millisecond))
+		Expect(err).ToNot(HaveOccurred())
+
+		// wait for the API Server
+		Eventually(func() error {
+			_, err := client.Meshes().List(context.Background())
+			return err
+		}, "5s", "100ms").ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		close(stop)
+	})
+
+	It("should manage resources", func() {
+		// given
+		ctx := context.Background()
+
+		// when
+		_, err := client.Meshes().Create(ctx, "demo", mesh_proto.Mesh{})
+		// then
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		_, err = client.TrafficPermissions().Create(ctx, "demo", "web-to-backend", mesh_proto.TrafficPermission{
+			Rules: []*mesh_proto.TrafficPermission_Rule{{
+				Sources: []*mesh_proto.TrafficPermission_Rule_Selector{
+					{Match: map[string]string{"service": "web"}},
+				},
+				Destinations: []*mesh_proto.TrafficPermission_Rule_Selector{
+					{Match: map[string]string{"service": "backend"}},
+				},
+			}},
+		}, core_store.CreateWithLabels(map[string]string{"team": "payments"}))
+		// then
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		permission, err := client.TrafficPermissions().Get(ctx, "demo", "web-to-backend")
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(permission.Spec.Rules[0].Sources[0].Match).To(Equal(map[string]string{"service": "web"}))
+		Expect(permission.GetMeta().GetLabels()).To(Equal(map[string]string{"team": "payments"}))
+
+		// when
+		permission.Spec.Rules[0].Sources[0].Match["service"] = "frontend"
+		err = client.TrafficPermissions().Update(ctx, permission)
+		// then
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		permissions, err := client.TrafficPermissions().List(ctx, "demo", core_store.ListByLabels(map[string]string{"team": "payments"}))
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(permissions.Items).To(HaveLen(1))
+		Expect(permissions.Items[0].Spec.Rules[0].Sources[0].Match).To(Equal(map[string]string{"service": "frontend"}))
+
+		// when
+		err = client.TrafficPermissions().Delete(ctx, "demo", "web-to-backend")
+		// then
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		_, err = client.TrafficPermissions().Get(ctx, "demo", "web-to-backend")
+		// then
+		Expect(core_store.IsResourceNotFound(err)).To(BeTrue())
+	})
+
+	It("should watch resources", func() {
+		// given
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		_, err := client.Meshes().Create(ctx, "demo", mesh_proto.Mesh{})
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		events := client.Meshes().Watch(ctx)
+
+		// then
+		event := <-events
+		Expect(event.Type).To(Equal(api_client.Added))
+		Expect(event.Resource.GetMeta().GetName()).To(Equal("demo"))
+
+		// when
+		mesh, err := client.Meshes().Get(ctx, "demo")
+		Expect(err).ToNot(HaveOccurred())
+		mesh.Spec.Mtls = &mesh_proto.Mesh_Mtls{Enabled: true}
+		Expect(client.Meshes().Update(ctx, mesh)).To(Succeed())
+
+		// then
+		event = <-events
+		Expect(event.Type).To(Equal(api_client.Modified))
+		Expect(event.Resource.(*core_mesh.MeshResource).Spec.GetMtls().GetEnabled()).To(BeTrue())
+
+		// when
+		Expect(client.Meshes().Delete(ctx, "demo")).To(Succeed())
+
+		// then
+		event = <-events
+		Expect(event.Type).To(Equal(api_client.Deleted))
+		Expect(event.Resource.GetMeta().GetName()).To(Equal("demo"))
+
+		// when
+		cancel()
+
+		// then
+		Eventually(events).Should(BeClosed())
+	})
+
+	It("should not be able to modify resources without a token", func() {
+		// given
+		clientWithoutToken, err := api_client.NewClient(apiUrl, api_client.WithRetries(0, 0))
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		_, err = clientWithoutToken.Meshes().Create(context.Background(), "demo", mesh_proto.Mesh{})
+
+		// then
+		Expect(err).To(HaveOccurred())
+
+		// when
+		_, err = clientWithoutToken.Meshes().List(context.Background())
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
@@ -0,0 +1,114 @@
+package generated
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+
+	core_errors "github.com/Kong/kuma/pkg/core/errors"
+	"github.com/Kong/kuma/pkg/core/resources/model/rest"
+	util_http "github.com/Kong/kuma/pkg/util/http"
+)
+
+// Client calls routes of the API Server. Its methods are generated from the OpenAPI specification.
+type Client struct {
+	httpClient util_http.Client
+}
+
+// NewClient returns a client of the API Server available at a given URL.
+func NewClient(apiUrl string, httpClient util_http.Client) (*Client, error) {
+	baseURL, err := url.Parse(apiUrl)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to parse API Server URL")
+	}
+	return &Client{
+		httpClient: util_http.ClientWithBaseURL(httpClient, baseURL),
+	}, nil
+}
+
+// RequestOption changes a single request, e.g. to set optional query parameters or headers.
+type RequestOption func(*http.Request)
+
+// WithQuery adds a query parameter to a request, e.g. WithQuery("tag", "service:web").
+func WithQuery(name, value string) RequestOption {
+	return func(req *http.Request) {
+		query := req.URL.Query()
+		query.Add(name, value)
+		req.URL.RawQuery = query.Encode()
+	}
+}
+
+// WithHeader sets a header of a request, e.g. WithHeader("Authorization", "Bearer <token>").
+func WithHeader(name, value string) RequestOption {
+	return func(req *http.Request) {
+		req.Header.Set(name, value)
+	}
+}
+
+// Error is returned when the API Server responds with a status other than 2xx.
+type Error struct {
+	StatusCode int
+	// Code is a machine-readable code of the error, e.g. MESH_NOT_FOUND. It is empty if the API Server hasn't provided one.
+	Code core_errors.Code
+	Body string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("(%d): %s", e.StatusCode, e.Body)
+}
+
+// CodeOf returns a machine-readable code of an error returned by the API Server, or an empty code if there is none.
+func CodeOf(err error) core_errors.Code {
+	if apiErr, ok := errors.Cause(err).(*Error); ok {
+		return apiErr.Code
+	}
+	return ""
+}
+
+// IsNotFound returns true if the API Server has responded with 404.
+func IsNotFound(err error) bool {
+	apiErr, ok := errors.Cause(err).(*Error)
+	return ok && apiErr.StatusCode == http.StatusNotFound
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body []byte, contentType string, opts []RequestOption) ([]byte, error) {
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+	req, err := http.NewRequest(method, path, reqBody)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to create a %s request to %s", method, path)
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	for _, opt := range opts {
+		opt(req)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to send a %s request to %s", method, path)
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to read a response to a %s request to %s", method, path)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, &Error{
+			StatusCode: resp.StatusCode,
+			Code:       core_errors.Code(resp.Header.Get(rest.ErrorCodeHeader)),
+			Body:       string(respBody),
+		}
+	}
+	return respBody, nil
+}
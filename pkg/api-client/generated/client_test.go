@@ -0,0 +1,120 @@
+package generated_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kong/kuma/pkg/api-client/generated"
+	api_server "github.com/Kong/kuma/pkg/api-server"
+	"github.com/Kong/kuma/pkg/api-server/definitions"
+	config "github.com/Kong/kuma/pkg/config/api-server"
+	builtin_ca "github.com/Kong/kuma/pkg/core/ca/builtin"
+	builtin_issuer "github.com/Kong/kuma/pkg/core/ca/builtin/issuer"
+	provided_ca "github.com/Kong/kuma/pkg/core/ca/provided"
+	core_errors "github.com/Kong/kuma/pkg/core/errors"
+	dataplane_managers "github.com/Kong/kuma/pkg/core/managers/apis/dataplane"
+	freeze_managers "github.com/Kong/kuma/pkg/core/managers/freeze"
+	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
+	secret_cipher "github.com/Kong/kuma/pkg/core/secrets/cipher"
+	secret_manager "github.com/Kong/kuma/pkg/core/secrets/manager"
+	secret_store "github.com/Kong/kuma/pkg/core/secrets/store"
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+	"github.com/Kong/kuma/pkg/test"
+	"github.com/Kong/kuma/pkg/tokens/builtin/issuer"
+)
+
+var _ = Describe("Generated Client", func() {
+
+	var client *generated.Client
+	var stop chan struct{}
+
+	BeforeEach(func() {
+		store := memory.NewStore()
+		cfg := *config.DefaultApiServerConfig()
+		port, err := test.GetFreePort()
+		Expect(err).ToNot(HaveOccurred())
+		cfg.Port = port
+		keyManager := issuer.NewSigningKeyManager(secret_manager.NewSecretManager(secret_store.NewSecretStore(store), secret_cipher.None()))
+		caManager := builtin_ca.NewBuiltinCaManager(secret_manager.NewSecretManager(secret_store.NewSecretStore(store), secret_cipher.None()))
+		resManager := core_manager.NewResourceManager(store)
+		apiServer := api_server.NewApiServer(resManager, keyManager, issuer.NewDataplaneTokenIssuer(keyManager), caManager, provided_ca.NewProvidedCaManager(nil, nil, builtin_issuer.DefaultWorkloadCertValidityPeriod, builtin_issuer.DefaultAllowedClockSkew), core_xds.NewConfigHistory(10), freeze_managers.NewConfigFreeze(secret_manager.NewSecretManager(secret_store.NewSecretStore(store), secret_cipher.None())), core_xds.NewStreamTracker(), dataplane_managers.NewRegistry(resManager), definitions.All, cfg)
+
+		stop = make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			Expect(apiServer.Start(stop)).To(Succeed())
+		}()
+
+		client, err = generated.NewClient(fmt.Sprintf("http://localhost:%d", port), http.DefaultClient)
+		Expect(err).ToNot(HaveOccurred())
+
+		// wait for the API Server
+		Eventually(func() error {
+			_, err := client.Index(context.Background())
+			return err
+		}, "5s", "100ms").ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		close(stop)
+	})
+
+	It("should create and get resources", func() {
+		// when
+		_, err := client.CreateMesh(context.Background(), []byte(`{"type": "Mesh", "name": "demo"}`))
+		Expect(err).ToNot(HaveOccurred())
+		_, err = client.CreateOrUpdateDataplane(context.Background(), "demo", "web-01", []byte(`
+		{
+			"type": "Dataplane",
+			"mesh": "demo",
+			"name": "web-01",
+			"networking": {
+				"inbound": [{"interface": "127.0.0.1:8080:80", "tags": {"service": "web"}}]
+			}
+		}`))
+		Expect(err).ToNot(HaveOccurred())
+
+		// then
+		body, err := client.GetDataplane(context.Background(), "demo", "web-01")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(body).To(MatchJSON(`
+		{
+			"type": "Dataplane",
+			"mesh": "demo",
+			"name": "web-01",
+			"networking": {
+				"inbound": [{"interface": "127.0.0.1:8080:80", "tags": {"service": "web"}}]
+			}
+		}`))
+	})
+
+	It("should pass optional query parameters", func() {
+		// given
+		_, err := client.CreateMesh(context.Background(), []byte(`{"type": "Mesh", "name": "demo"}`))
+		Expect(err).ToNot(HaveOccurred())
+		_, err = client.CreateMesh(context.Background(), []byte(`{"type": "Mesh", "name": "other"}`))
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		body, err := client.ListMeshes(context.Background(), generated.WithQuery("namePrefix", "dem"))
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(body).To(MatchJSON(`{"total": 1, "items": [{"type": "Mesh", "name": "demo"}]}`))
+	})
+
+	It("should return an error when a resource does not exist", func() {
+		// when
+		_, err := client.GetMesh(context.Background(), "non-existing")
+
+		// then
+		Expect(err).To(HaveOccurred())
+		Expect(generated.IsNotFound(err)).To(BeTrue())
+		Expect(generated.CodeOf(err)).To(Equal(core_errors.ResourceNotFound))
+	})
+})
@@ -0,0 +1,8 @@
+// Package generated is a low-level client of the API Server with a method per route,
+// generated from the OpenAPI specification that the API Server exposes on /openapi.json.
+//
+// Prefer the typed client in pkg/api-client to manage resources. Use this one to call routes
+// the typed client does not cover, e.g. inspecting Dataplanes or rotating signing keys.
+package generated
+
+//go:generate go run ./gen -output operations.go
@@ -0,0 +1,188 @@
+// Command gen generates methods of the API Server client from the OpenAPI specification of the API Server.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/go-openapi/spec"
+
+	api_server "github.com/Kong/kuma/pkg/api-server"
+	"github.com/Kong/kuma/pkg/api-server/definitions"
+)
+
+func main() {
+	output := flag.String("output", "operations.go", "file to write the generated code to")
+	flag.Parse()
+
+	code, err := render(api_server.OpenApiSpec(definitions.All))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if err := ioutil.WriteFile(*output, code, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+type param struct {
+	Name        string
+	Description string
+}
+
+type operation struct {
+	Name         string
+	Summary      string
+	Method       string
+	Path         string
+	PathParams   []string
+	QueryParams  []param
+	HeaderParams []param
+	HasBody      bool
+	ContentType  string
+}
+
+// PathExpr returns a Go expression that builds the path of an operation from its path parameters.
+func (o operation) PathExpr() string {
+	var parts []string
+	literal := ""
+	for _, segment := range strings.Split(strings.TrimPrefix(o.Path, "/"), "/") {
+		literal += "/"
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			parts = append(parts, fmt.Sprintf("%q", literal), fmt.Sprintf("url.PathEscape(%s)", goName(segment[1:len(segment)-1])))
+			literal = ""
+		} else {
+			literal += segment
+		}
+	}
+	if literal != "" || len(parts) == 0 {
+		parts = append(parts, fmt.Sprintf("%q", literal))
+	}
+	return strings.Join(parts, " + ")
+}
+
+func operations(swagger *spec.Swagger) []operation {
+	var ops []operation
+	for path, item := range swagger.Paths.Paths {
+		for method, op := range map[string]*spec.Operation{
+			"GET":    item.Get,
+			"PUT":    item.Put,
+			"POST":   item.Post,
+			"DELETE": item.Delete,
+			"PATCH":  item.Patch,
+		} {
+			if op == nil {
+				continue
+			}
+			o := operation{
+				Name:    exported(op.ID),
+				Summary: op.Summary,
+				Method:  method,
+				Path:    path,
+				HasBody: method == "PUT" || method == "POST" || method == "PATCH",
+			}
+			if len(op.Consumes) > 0 {
+				o.ContentType = op.Consumes[0]
+			}
+			// path parameters are taken in the order they appear in the path
+			for _, segment := range strings.Split(path, "/") {
+				if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+					o.PathParams = append(o.PathParams, goName(segment[1:len(segment)-1]))
+				}
+			}
+			for _, p := range op.Parameters {
+				switch p.In {
+				case "query":
+					o.QueryParams = append(o.QueryParams, param{Name: p.Name, Description: p.Description})
+				case "header":
+					o.HeaderParams = append(o.HeaderParams, param{Name: p.Name, Description: p.Description})
+				}
+			}
+			ops = append(ops, o)
+		}
+	}
+	sort.Slice(ops, func(i, j int) bool {
+		return ops[i].Name < ops[j].Name
+	})
+	return ops
+}
+
+func render(swagger *spec.Swagger) ([]byte, error) {
+	ops := operations(swagger)
+	names := map[string]bool{}
+	for _, op := range ops {
+		if names[op.Name] {
+			return nil, fmt.Errorf("operation %q is defined more than once", op.Name)
+		}
+		names[op.Name] = true
+	}
+	var buf bytes.Buffer
+	if err := operationsTemplate.Execute(&buf, ops); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+// exported turns an operation id, e.g. listDataplanes, into a name of an exported method.
+func exported(id string) string {
+	runes := []rune(goName(id))
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// goName turns a parameter name, e.g. service-name, into a Go identifier.
+func goName(name string) string {
+	var result []rune
+	upper := false
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = len(result) > 0
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		result = append(result, r)
+	}
+	return string(result)
+}
+
+var operationsTemplate = template.Must(template.New("operations").Parse(`// Code generated by gen. DO NOT EDIT.
+
+package generated
+
+import (
+	"context"
+	"net/url"
+)
+{{ range . }}
+// {{ .Name }} sends {{ .Method }} {{ .Path }}.
+// {{ .Summary }}.
+{{- if .QueryParams }}
+//
+// Query parameters:
+{{- range .QueryParams }}
+// {{ .Name }}: {{ .Description }}
+{{- end }}
+{{- end }}
+{{- if .HeaderParams }}
+//
+// Headers:
+{{- range .HeaderParams }}
+// {{ .Name }}: {{ .Description }}
+{{- end }}
+{{- end }}
+func (c *Client) {{ .Name }}(ctx context.Context, {{ range .PathParams }}{{ . }} string, {{ end }}{{ if .HasBody }}body []byte, {{ end }}opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "{{ .Method }}", {{ .PathExpr }}, {{ if .HasBody }}body{{ else }}nil{{ end }}, "{{ .ContentType }}", opts)
+}
+{{ end }}`))
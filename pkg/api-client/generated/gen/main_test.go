@@ -0,0 +1,28 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	api_server "github.com/Kong/kuma/pkg/api-server"
+	"github.com/Kong/kuma/pkg/api-server/definitions"
+)
+
+var _ = Describe("render()", func() {
+
+	It("should match the generated client", func() {
+		// given
+		expected, err := ioutil.ReadFile(filepath.Join("..", "operations.go"))
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		actual, err := render(api_server.OpenApiSpec(definitions.All))
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(actual)).To(Equal(string(expected)), "run `make generate/api-client` to regenerate the client")
+	})
+})
@@ -0,0 +1,13 @@
+package generated_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestGenerated(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Generated API Client Suite")
+}
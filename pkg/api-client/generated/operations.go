@@ -0,0 +1,448 @@
+// Code generated by gen. DO NOT EDIT.
+
+package generated
+
+import (
+	"context"
+	"net/url"
+)
+
+// CreateDataplane sends POST /meshes/{mesh}/dataplanes.
+// Creates a Dataplane named in the body.
+func (c *Client) CreateDataplane(ctx context.Context, mesh string, body []byte, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "POST", "/meshes/"+url.PathEscape(mesh)+"/dataplanes", body, "application/json", opts)
+}
+
+// CreateDataplaneInsight sends POST /meshes/{mesh}/dataplane-insights.
+// Creates a Dataplane Insight named in the body.
+func (c *Client) CreateDataplaneInsight(ctx context.Context, mesh string, body []byte, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "POST", "/meshes/"+url.PathEscape(mesh)+"/dataplane-insights", body, "application/json", opts)
+}
+
+// CreateMesh sends POST /meshes.
+// Creates a Mesh named in the body.
+func (c *Client) CreateMesh(ctx context.Context, body []byte, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "POST", "/meshes", body, "application/json", opts)
+}
+
+// CreateOrUpdateDataplane sends PUT /meshes/{mesh}/dataplanes/{name}.
+// Updates a Dataplane.
+//
+// Headers:
+// If-Match: If *, then only an existing resource is updated. If ETags, then only a resource of one of these versions is updated
+// If-None-Match: If *, then only a new resource is created
+func (c *Client) CreateOrUpdateDataplane(ctx context.Context, mesh string, name string, body []byte, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "PUT", "/meshes/"+url.PathEscape(mesh)+"/dataplanes/"+url.PathEscape(name), body, "application/json", opts)
+}
+
+// CreateOrUpdateDataplaneInsight sends PUT /meshes/{mesh}/dataplane-insights/{name}.
+// Updates a Dataplane Insight.
+//
+// Headers:
+// If-Match: If *, then only an existing resource is updated. If ETags, then only a resource of one of these versions is updated
+// If-None-Match: If *, then only a new resource is created
+func (c *Client) CreateOrUpdateDataplaneInsight(ctx context.Context, mesh string, name string, body []byte, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "PUT", "/meshes/"+url.PathEscape(mesh)+"/dataplane-insights/"+url.PathEscape(name), body, "application/json", opts)
+}
+
+// CreateOrUpdateMesh sends PUT /meshes/{name}.
+// Updates a Mesh.
+//
+// Headers:
+// If-Match: If *, then only an existing resource is updated. If ETags, then only a resource of one of these versions is updated
+// If-None-Match: If *, then only a new resource is created
+func (c *Client) CreateOrUpdateMesh(ctx context.Context, name string, body []byte, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "PUT", "/meshes/"+url.PathEscape(name), body, "application/json", opts)
+}
+
+// CreateOrUpdateProxyTemplate sends PUT /meshes/{mesh}/proxytemplates/{name}.
+// Updates a ProxyTemplate.
+//
+// Headers:
+// If-Match: If *, then only an existing resource is updated. If ETags, then only a resource of one of these versions is updated
+// If-None-Match: If *, then only a new resource is created
+func (c *Client) CreateOrUpdateProxyTemplate(ctx context.Context, mesh string, name string, body []byte, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "PUT", "/meshes/"+url.PathEscape(mesh)+"/proxytemplates/"+url.PathEscape(name), body, "application/json", opts)
+}
+
+// CreateOrUpdateSlowStart sends PUT /meshes/{mesh}/slow-starts/{name}.
+// Updates a Slow Start.
+//
+// Headers:
+// If-Match: If *, then only an existing resource is updated. If ETags, then only a resource of one of these versions is updated
+// If-None-Match: If *, then only a new resource is created
+func (c *Client) CreateOrUpdateSlowStart(ctx context.Context, mesh string, name string, body []byte, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "PUT", "/meshes/"+url.PathEscape(mesh)+"/slow-starts/"+url.PathEscape(name), body, "application/json", opts)
+}
+
+// CreateOrUpdateTrafficPermission sends PUT /meshes/{mesh}/traffic-permission/{name}.
+// Updates a Traffic Permission.
+//
+// Headers:
+// If-Match: If *, then only an existing resource is updated. If ETags, then only a resource of one of these versions is updated
+// If-None-Match: If *, then only a new resource is created
+func (c *Client) CreateOrUpdateTrafficPermission(ctx context.Context, mesh string, name string, body []byte, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "PUT", "/meshes/"+url.PathEscape(mesh)+"/traffic-permission/"+url.PathEscape(name), body, "application/json", opts)
+}
+
+// CreateProxyTemplate sends POST /meshes/{mesh}/proxytemplates.
+// Creates a ProxyTemplate named in the body.
+func (c *Client) CreateProxyTemplate(ctx context.Context, mesh string, body []byte, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "POST", "/meshes/"+url.PathEscape(mesh)+"/proxytemplates", body, "application/json", opts)
+}
+
+// CreateSlowStart sends POST /meshes/{mesh}/slow-starts.
+// Creates a Slow Start named in the body.
+func (c *Client) CreateSlowStart(ctx context.Context, mesh string, body []byte, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "POST", "/meshes/"+url.PathEscape(mesh)+"/slow-starts", body, "application/json", opts)
+}
+
+// CreateTrafficPermission sends POST /meshes/{mesh}/traffic-permission.
+// Creates a Traffic Permission named in the body.
+func (c *Client) CreateTrafficPermission(ctx context.Context, mesh string, body []byte, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "POST", "/meshes/"+url.PathEscape(mesh)+"/traffic-permission", body, "application/json", opts)
+}
+
+// DeleteDataplane sends DELETE /meshes/{mesh}/dataplanes/{name}.
+// Deletes a Dataplane.
+//
+// Query parameters:
+// force: Delete resources that depend on the deleted one as well
+//
+// Headers:
+// If-Match: If *, then only an existing resource is deleted. If ETags, then only a resource of one of these versions is deleted
+func (c *Client) DeleteDataplane(ctx context.Context, mesh string, name string, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "DELETE", "/meshes/"+url.PathEscape(mesh)+"/dataplanes/"+url.PathEscape(name), nil, "application/json", opts)
+}
+
+// DeleteDataplaneInsight sends DELETE /meshes/{mesh}/dataplane-insights/{name}.
+// Deletes a Dataplane Insight.
+//
+// Query parameters:
+// force: Delete resources that depend on the deleted one as well
+//
+// Headers:
+// If-Match: If *, then only an existing resource is deleted. If ETags, then only a resource of one of these versions is deleted
+func (c *Client) DeleteDataplaneInsight(ctx context.Context, mesh string, name string, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "DELETE", "/meshes/"+url.PathEscape(mesh)+"/dataplane-insights/"+url.PathEscape(name), nil, "application/json", opts)
+}
+
+// DeleteMesh sends DELETE /meshes/{name}.
+// Deletes a Mesh.
+//
+// Query parameters:
+// force: Delete resources that depend on the deleted one as well
+//
+// Headers:
+// If-Match: If *, then only an existing resource is deleted. If ETags, then only a resource of one of these versions is deleted
+func (c *Client) DeleteMesh(ctx context.Context, name string, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "DELETE", "/meshes/"+url.PathEscape(name), nil, "application/json", opts)
+}
+
+// DeleteProxyTemplate sends DELETE /meshes/{mesh}/proxytemplates/{name}.
+// Deletes a ProxyTemplate.
+//
+// Query parameters:
+// force: Delete resources that depend on the deleted one as well
+//
+// Headers:
+// If-Match: If *, then only an existing resource is deleted. If ETags, then only a resource of one of these versions is deleted
+func (c *Client) DeleteProxyTemplate(ctx context.Context, mesh string, name string, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "DELETE", "/meshes/"+url.PathEscape(mesh)+"/proxytemplates/"+url.PathEscape(name), nil, "application/json", opts)
+}
+
+// DeleteSlowStart sends DELETE /meshes/{mesh}/slow-starts/{name}.
+// Deletes a Slow Start.
+//
+// Query parameters:
+// force: Delete resources that depend on the deleted one as well
+//
+// Headers:
+// If-Match: If *, then only an existing resource is deleted. If ETags, then only a resource of one of these versions is deleted
+func (c *Client) DeleteSlowStart(ctx context.Context, mesh string, name string, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "DELETE", "/meshes/"+url.PathEscape(mesh)+"/slow-starts/"+url.PathEscape(name), nil, "application/json", opts)
+}
+
+// DeleteTrafficPermission sends DELETE /meshes/{mesh}/traffic-permission/{name}.
+// Deletes a Traffic Permission.
+//
+// Query parameters:
+// force: Delete resources that depend on the deleted one as well
+//
+// Headers:
+// If-Match: If *, then only an existing resource is deleted. If ETags, then only a resource of one of these versions is deleted
+func (c *Client) DeleteTrafficPermission(ctx context.Context, mesh string, name string, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "DELETE", "/meshes/"+url.PathEscape(mesh)+"/traffic-permission/"+url.PathEscape(name), nil, "application/json", opts)
+}
+
+// DeregisterDataplane sends DELETE /meshes/{mesh}/dataplanes/{name}/registration.
+// Deregister a dataplane. Deregistering a dataplane that doesn't exist is not an error. Requires a Dataplane token of the dataplane.
+func (c *Client) DeregisterDataplane(ctx context.Context, mesh string, name string, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "DELETE", "/meshes/"+url.PathEscape(mesh)+"/dataplanes/"+url.PathEscape(name)+"/registration", nil, "application/json", opts)
+}
+
+// DisconnectDataplane sends POST /meshes/{mesh}/dataplanes/{name}/disconnect.
+// Terminate xDS streams of a Dataplane, so that it reconnects and gets fresh configuration.
+func (c *Client) DisconnectDataplane(ctx context.Context, mesh string, name string, body []byte, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "POST", "/meshes/"+url.PathEscape(mesh)+"/dataplanes/"+url.PathEscape(name)+"/disconnect", body, "application/json", opts)
+}
+
+// GetConfigFreeze sends GET /config-freeze.
+// Get status of the configuration freeze.
+func (c *Client) GetConfigFreeze(ctx context.Context, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "GET", "/config-freeze", nil, "application/json", opts)
+}
+
+// GetDataplane sends GET /meshes/{mesh}/dataplanes/{name}.
+// Get a Dataplane.
+func (c *Client) GetDataplane(ctx context.Context, mesh string, name string, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "GET", "/meshes/"+url.PathEscape(mesh)+"/dataplanes/"+url.PathEscape(name), nil, "application/json", opts)
+}
+
+// GetDataplaneInsight sends GET /meshes/{mesh}/dataplane-insights/{name}.
+// Get a Dataplane Insight.
+func (c *Client) GetDataplaneInsight(ctx context.Context, mesh string, name string, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "GET", "/meshes/"+url.PathEscape(mesh)+"/dataplane-insights/"+url.PathEscape(name), nil, "application/json", opts)
+}
+
+// GetMesh sends GET /meshes/{name}.
+// Get a Mesh.
+func (c *Client) GetMesh(ctx context.Context, name string, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "GET", "/meshes/"+url.PathEscape(name), nil, "application/json", opts)
+}
+
+// GetPrometheusScrapeConfigs sends GET /meshes/{mesh}/prometheus/scrape-configs.
+// Render Prometheus scrape configs of all dataplanes in a mesh.
+func (c *Client) GetPrometheusScrapeConfigs(ctx context.Context, mesh string, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "GET", "/meshes/"+url.PathEscape(mesh)+"/prometheus/scrape-configs", nil, "application/json", opts)
+}
+
+// GetProxyTemplate sends GET /meshes/{mesh}/proxytemplates/{name}.
+// Get a ProxyTemplate.
+func (c *Client) GetProxyTemplate(ctx context.Context, mesh string, name string, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "GET", "/meshes/"+url.PathEscape(mesh)+"/proxytemplates/"+url.PathEscape(name), nil, "application/json", opts)
+}
+
+// GetSlowStart sends GET /meshes/{mesh}/slow-starts/{name}.
+// Get a Slow Start.
+func (c *Client) GetSlowStart(ctx context.Context, mesh string, name string, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "GET", "/meshes/"+url.PathEscape(mesh)+"/slow-starts/"+url.PathEscape(name), nil, "application/json", opts)
+}
+
+// GetTrafficPermission sends GET /meshes/{mesh}/traffic-permission/{name}.
+// Get a Traffic Permission.
+func (c *Client) GetTrafficPermission(ctx context.Context, mesh string, name string, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "GET", "/meshes/"+url.PathEscape(mesh)+"/traffic-permission/"+url.PathEscape(name), nil, "application/json", opts)
+}
+
+// Index sends GET /.
+// Get the version of Kuma Control Plane.
+func (c *Client) Index(ctx context.Context, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "GET", "/", nil, "", opts)
+}
+
+// InspectDataplane sends GET /meshes/{mesh}/dataplanes+insights/{name}.
+// Inspect a dataplane.
+func (c *Client) InspectDataplane(ctx context.Context, mesh string, name string, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "GET", "/meshes/"+url.PathEscape(mesh)+"/dataplanes+insights/"+url.PathEscape(name), nil, "application/json", opts)
+}
+
+// InspectDataplanes sends GET /meshes/{mesh}/dataplanes+insights.
+// Inspect all dataplanes.
+//
+// Query parameters:
+// tag: Tag to filter in key:value format
+// size: Maximum number of dataplanes on a page
+// offset: Offset of a page, taken from the link to the next page
+func (c *Client) InspectDataplanes(ctx context.Context, mesh string, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "GET", "/meshes/"+url.PathEscape(mesh)+"/dataplanes+insights", nil, "application/json", opts)
+}
+
+// InspectMesh sends GET /meshes/{mesh}/insight.
+// Summarize a mesh.
+func (c *Client) InspectMesh(ctx context.Context, mesh string, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "GET", "/meshes/"+url.PathEscape(mesh)+"/insight", nil, "application/json", opts)
+}
+
+// IssueToken sends POST /meshes/{mesh}/dataplane-tokens.
+// Issue a token that a Dataplane presents to the Control Plane to prove its identity.
+func (c *Client) IssueToken(ctx context.Context, mesh string, body []byte, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "POST", "/meshes/"+url.PathEscape(mesh)+"/dataplane-tokens", body, "application/json", opts)
+}
+
+// ListConfigGenerations sends GET /meshes/{mesh}/dataplanes/{name}/config-generations.
+// List recent generations of Envoy config of a dataplane. The list is empty unless history of config is turned on in the Control Plane.
+func (c *Client) ListConfigGenerations(ctx context.Context, mesh string, name string, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "GET", "/meshes/"+url.PathEscape(mesh)+"/dataplanes/"+url.PathEscape(name)+"/config-generations", nil, "application/json", opts)
+}
+
+// ListDataplaneInsights sends GET /meshes/{mesh}/dataplane-insights.
+// List of Dataplane Insight.
+//
+// Query parameters:
+// labels: Only resources with all of these labels, e.g. team=payments,env=prod
+// tag: Only resources with all of these tags in key:value format, e.g. ?tag=service:web&tag=version:v1
+// namePrefix: Only resources with names that start with this prefix
+// size: Maximum number of resources on a page
+// offset: Offset of a page, taken from the link to the next page
+func (c *Client) ListDataplaneInsights(ctx context.Context, mesh string, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "GET", "/meshes/"+url.PathEscape(mesh)+"/dataplane-insights", nil, "application/json", opts)
+}
+
+// ListDataplanes sends GET /meshes/{mesh}/dataplanes.
+// List of Dataplane.
+//
+// Query parameters:
+// labels: Only resources with all of these labels, e.g. team=payments,env=prod
+// tag: Only resources with all of these tags in key:value format, e.g. ?tag=service:web&tag=version:v1
+// namePrefix: Only resources with names that start with this prefix
+// size: Maximum number of resources on a page
+// offset: Offset of a page, taken from the link to the next page
+func (c *Client) ListDataplanes(ctx context.Context, mesh string, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "GET", "/meshes/"+url.PathEscape(mesh)+"/dataplanes", nil, "application/json", opts)
+}
+
+// ListMeshes sends GET /meshes.
+// List of Mesh.
+//
+// Query parameters:
+// labels: Only resources with all of these labels, e.g. team=payments,env=prod
+// tag: Only resources with all of these tags in key:value format, e.g. ?tag=service:web&tag=version:v1
+// namePrefix: Only resources with names that start with this prefix
+// size: Maximum number of resources on a page
+// offset: Offset of a page, taken from the link to the next page
+func (c *Client) ListMeshes(ctx context.Context, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "GET", "/meshes", nil, "application/json", opts)
+}
+
+// ListProxyTemplates sends GET /meshes/{mesh}/proxytemplates.
+// List of ProxyTemplate.
+//
+// Query parameters:
+// labels: Only resources with all of these labels, e.g. team=payments,env=prod
+// tag: Only resources with all of these tags in key:value format, e.g. ?tag=service:web&tag=version:v1
+// namePrefix: Only resources with names that start with this prefix
+// size: Maximum number of resources on a page
+// offset: Offset of a page, taken from the link to the next page
+func (c *Client) ListProxyTemplates(ctx context.Context, mesh string, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "GET", "/meshes/"+url.PathEscape(mesh)+"/proxytemplates", nil, "application/json", opts)
+}
+
+// ListRevokedCerts sends GET /meshes/{mesh}/revoked-dataplane-certificates.
+// List revoked Workload Identity certificates of Dataplanes.
+func (c *Client) ListRevokedCerts(ctx context.Context, mesh string, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "GET", "/meshes/"+url.PathEscape(mesh)+"/revoked-dataplane-certificates", nil, "application/json", opts)
+}
+
+// ListRevokedTokens sends GET /meshes/{mesh}/revoked-dataplane-tokens.
+// List revoked Dataplane tokens.
+func (c *Client) ListRevokedTokens(ctx context.Context, mesh string, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "GET", "/meshes/"+url.PathEscape(mesh)+"/revoked-dataplane-tokens", nil, "application/json", opts)
+}
+
+// ListSigningKeys sends GET /meshes/{mesh}/dataplane-token-signing-keys.
+// List keys that are used to sign Dataplane tokens.
+func (c *Client) ListSigningKeys(ctx context.Context, mesh string, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "GET", "/meshes/"+url.PathEscape(mesh)+"/dataplane-token-signing-keys", nil, "application/json", opts)
+}
+
+// ListSlowStarts sends GET /meshes/{mesh}/slow-starts.
+// List of Slow Start.
+//
+// Query parameters:
+// labels: Only resources with all of these labels, e.g. team=payments,env=prod
+// tag: Only resources with all of these tags in key:value format, e.g. ?tag=service:web&tag=version:v1
+// namePrefix: Only resources with names that start with this prefix
+// size: Maximum number of resources on a page
+// offset: Offset of a page, taken from the link to the next page
+func (c *Client) ListSlowStarts(ctx context.Context, mesh string, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "GET", "/meshes/"+url.PathEscape(mesh)+"/slow-starts", nil, "application/json", opts)
+}
+
+// ListTrafficPermissions sends GET /meshes/{mesh}/traffic-permission.
+// List of Traffic Permission.
+//
+// Query parameters:
+// labels: Only resources with all of these labels, e.g. team=payments,env=prod
+// tag: Only resources with all of these tags in key:value format, e.g. ?tag=service:web&tag=version:v1
+// namePrefix: Only resources with names that start with this prefix
+// size: Maximum number of resources on a page
+// offset: Offset of a page, taken from the link to the next page
+func (c *Client) ListTrafficPermissions(ctx context.Context, mesh string, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "GET", "/meshes/"+url.PathEscape(mesh)+"/traffic-permission", nil, "application/json", opts)
+}
+
+// PatchDataplane sends PATCH /meshes/{mesh}/dataplanes/{name}.
+// Updates a part of a Dataplane using JSON Merge Patch (RFC 7386) or, if sent as application/json-patch+json, JSON Patch (RFC 6902). JSON Merge Patch replaces arrays, e.g. inbound interfaces of a Dataplane, as a whole, while JSON Patch addresses their elements, e.g. a single tag of an inbound interface.
+func (c *Client) PatchDataplane(ctx context.Context, mesh string, name string, body []byte, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "PATCH", "/meshes/"+url.PathEscape(mesh)+"/dataplanes/"+url.PathEscape(name), body, "application/merge-patch+json", opts)
+}
+
+// PatchDataplaneInsight sends PATCH /meshes/{mesh}/dataplane-insights/{name}.
+// Updates a part of a Dataplane Insight using JSON Merge Patch (RFC 7386) or, if sent as application/json-patch+json, JSON Patch (RFC 6902). JSON Merge Patch replaces arrays, e.g. inbound interfaces of a Dataplane, as a whole, while JSON Patch addresses their elements, e.g. a single tag of an inbound interface.
+func (c *Client) PatchDataplaneInsight(ctx context.Context, mesh string, name string, body []byte, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "PATCH", "/meshes/"+url.PathEscape(mesh)+"/dataplane-insights/"+url.PathEscape(name), body, "application/merge-patch+json", opts)
+}
+
+// PatchMesh sends PATCH /meshes/{name}.
+// Updates a part of a Mesh using JSON Merge Patch (RFC 7386) or, if sent as application/json-patch+json, JSON Patch (RFC 6902). JSON Merge Patch replaces arrays, e.g. inbound interfaces of a Dataplane, as a whole, while JSON Patch addresses their elements, e.g. a single tag of an inbound interface.
+func (c *Client) PatchMesh(ctx context.Context, name string, body []byte, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "PATCH", "/meshes/"+url.PathEscape(name), body, "application/merge-patch+json", opts)
+}
+
+// PatchProxyTemplate sends PATCH /meshes/{mesh}/proxytemplates/{name}.
+// Updates a part of a ProxyTemplate using JSON Merge Patch (RFC 7386) or, if sent as application/json-patch+json, JSON Patch (RFC 6902). JSON Merge Patch replaces arrays, e.g. inbound interfaces of a Dataplane, as a whole, while JSON Patch addresses their elements, e.g. a single tag of an inbound interface.
+func (c *Client) PatchProxyTemplate(ctx context.Context, mesh string, name string, body []byte, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "PATCH", "/meshes/"+url.PathEscape(mesh)+"/proxytemplates/"+url.PathEscape(name), body, "application/merge-patch+json", opts)
+}
+
+// PatchSlowStart sends PATCH /meshes/{mesh}/slow-starts/{name}.
+// Updates a part of a Slow Start using JSON Merge Patch (RFC 7386) or, if sent as application/json-patch+json, JSON Patch (RFC 6902). JSON Merge Patch replaces arrays, e.g. inbound interfaces of a Dataplane, as a whole, while JSON Patch addresses their elements, e.g. a single tag of an inbound interface.
+func (c *Client) PatchSlowStart(ctx context.Context, mesh string, name string, body []byte, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "PATCH", "/meshes/"+url.PathEscape(mesh)+"/slow-starts/"+url.PathEscape(name), body, "application/merge-patch+json", opts)
+}
+
+// PatchTrafficPermission sends PATCH /meshes/{mesh}/traffic-permission/{name}.
+// Updates a part of a Traffic Permission using JSON Merge Patch (RFC 7386) or, if sent as application/json-patch+json, JSON Patch (RFC 6902). JSON Merge Patch replaces arrays, e.g. inbound interfaces of a Dataplane, as a whole, while JSON Patch addresses their elements, e.g. a single tag of an inbound interface.
+func (c *Client) PatchTrafficPermission(ctx context.Context, mesh string, name string, body []byte, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "PATCH", "/meshes/"+url.PathEscape(mesh)+"/traffic-permission/"+url.PathEscape(name), body, "application/merge-patch+json", opts)
+}
+
+// RegisterDataplane sends POST /meshes/{mesh}/dataplanes/{name}/registration.
+// Register a dataplane with its address and inbound and outbound interfaces. A Dataplane is created or its networking is replaced. Requires a Dataplane token of the dataplane.
+func (c *Client) RegisterDataplane(ctx context.Context, mesh string, name string, body []byte, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "POST", "/meshes/"+url.PathEscape(mesh)+"/dataplanes/"+url.PathEscape(name)+"/registration", body, "application/json", opts)
+}
+
+// RevokeCert sends POST /meshes/{mesh}/revoked-dataplane-certificates.
+// Revoke a Workload Identity certificate of a Dataplane. Requires the admin token.
+func (c *Client) RevokeCert(ctx context.Context, mesh string, body []byte, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "POST", "/meshes/"+url.PathEscape(mesh)+"/revoked-dataplane-certificates", body, "application/json", opts)
+}
+
+// RevokeSigningKey sends DELETE /meshes/{mesh}/dataplane-token-signing-keys/{id}.
+// Revoke a key that is used to sign Dataplane tokens. Requires the admin token.
+func (c *Client) RevokeSigningKey(ctx context.Context, mesh string, id string, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "DELETE", "/meshes/"+url.PathEscape(mesh)+"/dataplane-token-signing-keys/"+url.PathEscape(id), nil, "application/json", opts)
+}
+
+// RevokeToken sends POST /meshes/{mesh}/revoked-dataplane-tokens.
+// Revoke a Dataplane token. Requires the admin token.
+func (c *Client) RevokeToken(ctx context.Context, mesh string, body []byte, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "POST", "/meshes/"+url.PathEscape(mesh)+"/revoked-dataplane-tokens", body, "application/json", opts)
+}
+
+// RotateSigningKey sends POST /meshes/{mesh}/dataplane-token-signing-keys.
+// Rotate a key that is used to sign Dataplane tokens. Requires the admin token.
+//
+// Query parameters:
+// gracePeriod: How long tokens signed with the previous key are still accepted, e.g. 1h
+func (c *Client) RotateSigningKey(ctx context.Context, mesh string, body []byte, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "POST", "/meshes/"+url.PathEscape(mesh)+"/dataplane-token-signing-keys", body, "application/json", opts)
+}
+
+// SetConfigFreeze sends PUT /config-freeze.
+// Freeze or unfreeze changes of Meshes and policies. Requires the admin token.
+func (c *Client) SetConfigFreeze(ctx context.Context, body []byte, opts ...RequestOption) ([]byte, error) {
+	return c.do(ctx, "PUT", "/config-freeze", body, "application/json", opts)
+}
@@ -0,0 +1,327 @@
+package api_client
+
+import (
+	"context"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+)
+
+type MeshClient interface {
+	Get(ctx context.Context, name string) (*core_mesh.MeshResource, error)
+	List(ctx context.Context, fs ...core_store.ListOptionsFunc) (*core_mesh.MeshResourceList, error)
+	Create(ctx context.Context, name string, spec mesh_proto.Mesh, fs ...core_store.CreateOptionsFunc) (*core_mesh.MeshResource, error)
+	Update(ctx context.Context, mesh *core_mesh.MeshResource, fs ...core_store.UpdateOptionsFunc) error
+	// Delete deletes a Mesh. Use core_store.DeleteForce() to delete resources of that Mesh too.
+	Delete(ctx context.Context, name string, fs ...core_store.DeleteOptionsFunc) error
+	Watch(ctx context.Context, fs ...core_store.ListOptionsFunc) <-chan WatchEvent
+}
+
+type DataplaneClient interface {
+	Get(ctx context.Context, mesh, name string) (*core_mesh.DataplaneResource, error)
+	List(ctx context.Context, mesh string, fs ...core_store.ListOptionsFunc) (*core_mesh.DataplaneResourceList, error)
+	Create(ctx context.Context, mesh, name string, spec mesh_proto.Dataplane, fs ...core_store.CreateOptionsFunc) (*core_mesh.DataplaneResource, error)
+	Update(ctx context.Context, dataplane *core_mesh.DataplaneResource, fs ...core_store.UpdateOptionsFunc) error
+	Delete(ctx context.Context, mesh, name string) error
+	Watch(ctx context.Context, mesh string, fs ...core_store.ListOptionsFunc) <-chan WatchEvent
+}
+
+// DataplaneInsightClient is read-only since Dataplane Insights are maintained by Control Plane.
+type DataplaneInsightClient interface {
+	Get(ctx context.Context, mesh, name string) (*core_mesh.DataplaneInsightResource, error)
+	List(ctx context.Context, mesh string, fs ...core_store.ListOptionsFunc) (*core_mesh.DataplaneInsightResourceList, error)
+	Watch(ctx context.Context, mesh string, fs ...core_store.ListOptionsFunc) <-chan WatchEvent
+}
+
+type ProxyTemplateClient interface {
+	Get(ctx context.Context, mesh, name string) (*core_mesh.ProxyTemplateResource, error)
+	List(ctx context.Context, mesh string, fs ...core_store.ListOptionsFunc) (*core_mesh.ProxyTemplateResourceList, error)
+	Create(ctx context.Context, mesh, name string, spec mesh_proto.ProxyTemplate, fs ...core_store.CreateOptionsFunc) (*core_mesh.ProxyTemplateResource, error)
+	Update(ctx context.Context, template *core_mesh.ProxyTemplateResource, fs ...core_store.UpdateOptionsFunc) error
+	Delete(ctx context.Context, mesh, name string) error
+	Watch(ctx context.Context, mesh string, fs ...core_store.ListOptionsFunc) <-chan WatchEvent
+}
+
+type TrafficPermissionClient interface {
+	Get(ctx context.Context, mesh, name string) (*core_mesh.TrafficPermissionResource, error)
+	List(ctx context.Context, mesh string, fs ...core_store.ListOptionsFunc) (*core_mesh.TrafficPermissionResourceList, error)
+	Create(ctx context.Context, mesh, name string, spec mesh_proto.TrafficPermission, fs ...core_store.CreateOptionsFunc) (*core_mesh.TrafficPermissionResource, error)
+	Update(ctx context.Context, permission *core_mesh.TrafficPermissionResource, fs ...core_store.UpdateOptionsFunc) error
+	Delete(ctx context.Context, mesh, name string) error
+	Watch(ctx context.Context, mesh string, fs ...core_store.ListOptionsFunc) <-chan WatchEvent
+}
+
+type SlowStartClient interface {
+	Get(ctx context.Context, mesh, name string) (*core_mesh.SlowStartResource, error)
+	List(ctx context.Context, mesh string, fs ...core_store.ListOptionsFunc) (*core_mesh.SlowStartResourceList, error)
+	Create(ctx context.Context, mesh, name string, spec mesh_proto.SlowStart, fs ...core_store.CreateOptionsFunc) (*core_mesh.SlowStartResource, error)
+	Update(ctx context.Context, slowStart *core_mesh.SlowStartResource, fs ...core_store.UpdateOptionsFunc) error
+	Delete(ctx context.Context, mesh, name string) error
+	Watch(ctx context.Context, mesh string, fs ...core_store.ListOptionsFunc) <-chan WatchEvent
+}
+
+func (c *client) get(ctx context.Context, res core_model.Resource, mesh, name string) error {
+	return c.store.Get(ctx, res, core_store.GetByKey("", name, mesh))
+}
+
+func (c *client) list(ctx context.Context, list core_model.ResourceList, mesh string, fs ...core_store.ListOptionsFunc) error {
+	return c.store.List(ctx, list, append([]core_store.ListOptionsFunc{core_store.ListByMesh(mesh)}, fs...)...)
+}
+
+func (c *client) create(ctx context.Context, res core_model.Resource, mesh, name string, fs ...core_store.CreateOptionsFunc) error {
+	return c.store.Create(ctx, res, append([]core_store.CreateOptionsFunc{core_store.CreateByKey("", name, mesh)}, fs...)...)
+}
+
+func (c *client) delete(ctx context.Context, res core_model.Resource, mesh, name string, fs ...core_store.DeleteOptionsFunc) error {
+	return c.store.Delete(ctx, res, append([]core_store.DeleteOptionsFunc{core_store.DeleteByKey("", name, mesh)}, fs...)...)
+}
+
+var _ MeshClient = &meshClient{}
+
+type meshClient struct {
+	*client
+}
+
+func (c *meshClient) Get(ctx context.Context, name string) (*core_mesh.MeshResource, error) {
+	mesh := &core_mesh.MeshResource{}
+	if err := c.get(ctx, mesh, name, name); err != nil {
+		return nil, err
+	}
+	return mesh, nil
+}
+
+func (c *meshClient) List(ctx context.Context, fs ...core_store.ListOptionsFunc) (*core_mesh.MeshResourceList, error) {
+	meshes := &core_mesh.MeshResourceList{}
+	if err := c.list(ctx, meshes, "", fs...); err != nil {
+		return nil, err
+	}
+	return meshes, nil
+}
+
+func (c *meshClient) Create(ctx context.Context, name string, spec mesh_proto.Mesh, fs ...core_store.CreateOptionsFunc) (*core_mesh.MeshResource, error) {
+	mesh := &core_mesh.MeshResource{Spec: spec}
+	if err := c.create(ctx, mesh, name, name, fs...); err != nil {
+		return nil, err
+	}
+	return mesh, nil
+}
+
+func (c *meshClient) Update(ctx context.Context, mesh *core_mesh.MeshResource, fs ...core_store.UpdateOptionsFunc) error {
+	return c.store.Update(ctx, mesh, fs...)
+}
+
+func (c *meshClient) Delete(ctx context.Context, name string, fs ...core_store.DeleteOptionsFunc) error {
+	return c.delete(ctx, &core_mesh.MeshResource{}, name, name, fs...)
+}
+
+func (c *meshClient) Watch(ctx context.Context, fs ...core_store.ListOptionsFunc) <-chan WatchEvent {
+	return c.watch(ctx, func() core_model.ResourceList {
+		return &core_mesh.MeshResourceList{}
+	}, "", fs...)
+}
+
+var _ DataplaneClient = &dataplaneClient{}
+
+type dataplaneClient struct {
+	*client
+}
+
+func (c *dataplaneClient) Get(ctx context.Context, mesh, name string) (*core_mesh.DataplaneResource, error) {
+	dataplane := &core_mesh.DataplaneResource{}
+	if err := c.get(ctx, dataplane, mesh, name); err != nil {
+		return nil, err
+	}
+	return dataplane, nil
+}
+
+func (c *dataplaneClient) List(ctx context.Context, mesh string, fs ...core_store.ListOptionsFunc) (*core_mesh.DataplaneResourceList, error) {
+	dataplanes := &core_mesh.DataplaneResourceList{}
+	if err := c.list(ctx, dataplanes, mesh, fs...); err != nil {
+		return nil, err
+	}
+	return dataplanes, nil
+}
+
+func (c *dataplaneClient) Create(ctx context.Context, mesh, name string, spec mesh_proto.Dataplane, fs ...core_store.CreateOptionsFunc) (*core_mesh.DataplaneResource, error) {
+	dataplane := &core_mesh.DataplaneResource{Spec: spec}
+	if err := c.create(ctx, dataplane, mesh, name, fs...); err != nil {
+		return nil, err
+	}
+	return dataplane, nil
+}
+
+func (c *dataplaneClient) Update(ctx context.Context, dataplane *core_mesh.DataplaneResource, fs ...core_store.UpdateOptionsFunc) error {
+	return c.store.Update(ctx, dataplane, fs...)
+}
+
+func (c *dataplaneClient) Delete(ctx context.Context, mesh, name string) error {
+	return c.delete(ctx, &core_mesh.DataplaneResource{}, mesh, name)
+}
+
+func (c *dataplaneClient) Watch(ctx context.Context, mesh string, fs ...core_store.ListOptionsFunc) <-chan WatchEvent {
+	return c.watch(ctx, func() core_model.ResourceList {
+		return &core_mesh.DataplaneResourceList{}
+	}, mesh, fs...)
+}
+
+var _ DataplaneInsightClient = &dataplaneInsightClient{}
+
+type dataplaneInsightClient struct {
+	*client
+}
+
+func (c *dataplaneInsightClient) Get(ctx context.Context, mesh, name string) (*core_mesh.DataplaneInsightResource, error) {
+	insight := &core_mesh.DataplaneInsightResource{}
+	if err := c.get(ctx, insight, mesh, name); err != nil {
+		return nil, err
+	}
+	return insight, nil
+}
+
+func (c *dataplaneInsightClient) List(ctx context.Context, mesh string, fs ...core_store.ListOptionsFunc) (*core_mesh.DataplaneInsightResourceList, error) {
+	insights := &core_mesh.DataplaneInsightResourceList{}
+	if err := c.list(ctx, insights, mesh, fs...); err != nil {
+		return nil, err
+	}
+	return insights, nil
+}
+
+func (c *dataplaneInsightClient) Watch(ctx context.Context, mesh string, fs ...core_store.ListOptionsFunc) <-chan WatchEvent {
+	return c.watch(ctx, func() core_model.ResourceList {
+		return &core_mesh.DataplaneInsightResourceList{}
+	}, mesh, fs...)
+}
+
+var _ ProxyTemplateClient = &proxyTemplateClient{}
+
+type proxyTemplateClient struct {
+	*client
+}
+
+func (c *proxyTemplateClient) Get(ctx context.Context, mesh, name string) (*core_mesh.ProxyTemplateResource, error) {
+	template := &core_mesh.ProxyTemplateResource{}
+	if err := c.get(ctx, template, mesh, name); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+func (c *proxyTemplateClient) List(ctx context.Context, mesh string, fs ...core_store.ListOptionsFunc) (*core_mesh.ProxyTemplateResourceList, error) {
+	templates := &core_mesh.ProxyTemplateResourceList{}
+	if err := c.list(ctx, templates, mesh, fs...); err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+func (c *proxyTemplateClient) Create(ctx context.Context, mesh, name string, spec mesh_proto.ProxyTemplate, fs ...core_store.CreateOptionsFunc) (*core_mesh.ProxyTemplateResource, error) {
+	template := &core_mesh.ProxyTemplateResource{Spec: spec}
+	if err := c.create(ctx, template, mesh, name, fs...); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+func (c *proxyTemplateClient) Update(ctx context.Context, template *core_mesh.ProxyTemplateResource, fs ...core_store.UpdateOptionsFunc) error {
+	return c.store.Update(ctx, template, fs...)
+}
+
+func (c *proxyTemplateClient) Delete(ctx context.Context, mesh, name string) error {
+	return c.delete(ctx, &core_mesh.ProxyTemplateResource{}, mesh, name)
+}
+
+func (c *proxyTemplateClient) Watch(ctx context.Context, mesh string, fs ...core_store.ListOptionsFunc) <-chan WatchEvent {
+	return c.watch(ctx, func() core_model.ResourceList {
+		return &core_mesh.ProxyTemplateResourceList{}
+	}, mesh, fs...)
+}
+
+var _ TrafficPermissionClient = &trafficPermissionClient{}
+
+type trafficPermissionClient struct {
+	*client
+}
+
+func (c *trafficPermissionClient) Get(ctx context.Context, mesh, name string) (*core_mesh.TrafficPermissionResource, error) {
+	permission := &core_mesh.TrafficPermissionResource{}
+	if err := c.get(ctx, permission, mesh, name); err != nil {
+		return nil, err
+	}
+	return permission, nil
+}
+
+func (c *trafficPermissionClient) List(ctx context.Context, mesh string, fs ...core_store.ListOptionsFunc) (*core_mesh.TrafficPermissionResourceList, error) {
+	permissions := &core_mesh.TrafficPermissionResourceList{}
+	if err := c.list(ctx, permissions, mesh, fs...); err != nil {
+		return nil, err
+	}
+	return permissions, nil
+}
+
+func (c *trafficPermissionClient) Create(ctx context.Context, mesh, name string, spec mesh_proto.TrafficPermission, fs ...core_store.CreateOptionsFunc) (*core_mesh.TrafficPermissionResource, error) {
+	permission := &core_mesh.TrafficPermissionResource{Spec: spec}
+	if err := c.create(ctx, permission, mesh, name, fs...); err != nil {
+		return nil, err
+	}
+	return permission, nil
+}
+
+func (c *trafficPermissionClient) Update(ctx context.Context, permission *core_mesh.TrafficPermissionResource, fs ...core_store.UpdateOptionsFunc) error {
+	return c.store.Update(ctx, permission, fs...)
+}
+
+func (c *trafficPermissionClient) Delete(ctx context.Context, mesh, name string) error {
+	return c.delete(ctx, &core_mesh.TrafficPermissionResource{}, mesh, name)
+}
+
+func (c *trafficPermissionClient) Watch(ctx context.Context, mesh string, fs ...core_store.ListOptionsFunc) <-chan WatchEvent {
+	return c.watch(ctx, func() core_model.ResourceList {
+		return &core_mesh.TrafficPermissionResourceList{}
+	}, mesh, fs...)
+}
+
+var _ SlowStartClient = &slowStartClient{}
+
+type slowStartClient struct {
+	*client
+}
+
+func (c *slowStartClient) Get(ctx context.Context, mesh, name string) (*core_mesh.SlowStartResource, error) {
+	slowStart := &core_mesh.SlowStartResource{}
+	if err := c.get(ctx, slowStart, mesh, name); err != nil {
+		return nil, err
+	}
+	return slowStart, nil
+}
+
+func (c *slowStartClient) List(ctx context.Context, mesh string, fs ...core_store.ListOptionsFunc) (*core_mesh.SlowStartResourceList, error) {
+	slowStarts := &core_mesh.SlowStartResourceList{}
+	if err := c.list(ctx, slowStarts, mesh, fs...); err != nil {
+		return nil, err
+	}
+	return slowStarts, nil
+}
+
+func (c *slowStartClient) Create(ctx context.Context, mesh, name string, spec mesh_proto.SlowStart, fs ...core_store.CreateOptionsFunc) (*core_mesh.SlowStartResource, error) {
+	slowStart := &core_mesh.SlowStartResource{Spec: spec}
+	if err := c.create(ctx, slowStart, mesh, name, fs...); err != nil {
+		return nil, err
+	}
+	return slowStart, nil
+}
+
+func (c *slowStartClient) Update(ctx context.Context, slowStart *core_mesh.SlowStartResource, fs ...core_store.UpdateOptionsFunc) error {
+	return c.store.Update(ctx, slowStart, fs...)
+}
+
+func (c *slowStartClient) Delete(ctx context.Context, mesh, name string) error {
+	return c.delete(ctx, &core_mesh.SlowStartResource{}, mesh, name)
+}
+
+func (c *slowStartClient) Watch(ctx context.Context, mesh string, fs ...core_store.ListOptionsFunc) <-chan WatchEvent {
+	return c.watch(ctx, func() core_model.ResourceList {
+		return &core_mesh.SlowStartResourceList{}
+	}, mesh, fs...)
+}
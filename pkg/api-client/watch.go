@@ -0,0 +1,96 @@
+package api_client
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+)
+
+type WatchEventType string
+
+const (
+	Added    WatchEventType = "ADDED"
+	Modified WatchEventType = "MODIFIED"
+	Deleted  WatchEventType = "DELETED"
+	// Error means that resources could not be listed. Watch goes on regardless.
+	Error WatchEventType = "ERROR"
+)
+
+// WatchEvent describes a change of a resource.
+type WatchEvent struct {
+	Type WatchEventType
+	// Resource is the current state of a resource or the last known one if it has been deleted.
+	Resource core_model.Resource
+	Err      error
+}
+
+// watch periodically lists resources and reports how they have changed since the previous time.
+// Resources that exist when watch begins are reported as added.
+//
+// API Server has no streaming API, so changes that get reverted between two checks are not reported.
+// The returned channel is closed once a given context is done.
+func (c *client) watch(ctx context.Context, newList func() core_model.ResourceList, mesh string, fs ...core_store.ListOptionsFunc) <-chan WatchEvent {
+	events := make(chan WatchEvent)
+	go func() {
+		defer close(events)
+		ticker := time.NewTicker(c.watchInterval)
+		defer ticker.Stop()
+		known := map[string]core_model.Resource{}
+		for {
+			list := newList()
+			var changes []WatchEvent
+			if err := c.list(ctx, list, mesh, fs...); err != nil {
+				changes = []WatchEvent{{Type: Error, Err: err}}
+			} else {
+				changes, known = diff(known, list.GetItems())
+			}
+			for _, change := range changes {
+				select {
+				case events <- change:
+				case <-ctx.Done():
+					return
+				}
+			}
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events
+}
+
+func diff(known map[string]core_model.Resource, items []core_model.Resource) ([]WatchEvent, map[string]core_model.Resource) {
+	var changes []WatchEvent
+	current := make(map[string]core_model.Resource, len(items))
+	for _, item := range items {
+		key := item.GetMeta().GetMesh() + "/" + item.GetMeta().GetName()
+		current[key] = item
+		previous, exists := known[key]
+		switch {
+		case !exists:
+			changes = append(changes, WatchEvent{Type: Added, Resource: item})
+		case !proto.Equal(previous.GetSpec(), item.GetSpec()) || !labelsEqual(previous.GetMeta().GetLabels(), item.GetMeta().GetLabels()):
+			changes = append(changes, WatchEvent{Type: Modified, Resource: item})
+		}
+	}
+	for key, item := range known {
+		if _, exists := current[key]; !exists {
+			changes = append(changes, WatchEvent{Type: Deleted, Resource: item})
+		}
+	}
+	return changes, current
+}
+
+func labelsEqual(a, b map[string]string) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,113 @@
+package api_server
+
+import (
+	"time"
+
+	"github.com/emicklei/go-restful"
+
+	"github.com/Kong/kuma/pkg/api-server/filters"
+	"github.com/Kong/kuma/pkg/config"
+	"github.com/Kong/kuma/pkg/core"
+	core_errors "github.com/Kong/kuma/pkg/core/errors"
+	freeze_managers "github.com/Kong/kuma/pkg/core/managers/freeze"
+)
+
+type configFreezeWs struct {
+	configFreeze freeze_managers.ConfigFreeze
+	adminToken   config.Secret
+	readOnly     bool
+}
+
+type configFreezeStatus struct {
+	Frozen bool       `json:"frozen"`
+	By     string     `json:"by,omitempty"`
+	Reason string     `json:"reason,omitempty"`
+	Since  *time.Time `json:"since,omitempty"`
+}
+
+type configFreezeRequest struct {
+	Frozen bool   `json:"frozen"`
+	Reason string `json:"reason"`
+}
+
+func (c *configFreezeWs) AddToWs(ws *restful.WebService) {
+	ws.Route(ws.GET("").To(c.getStatus).
+		Operation("getConfigFreeze").
+		Doc("Get status of the configuration freeze").
+		Returns(200, "OK", nil))
+
+	if !c.readOnly {
+		ws.Route(ws.PUT("").To(c.setStatus).
+			Filter(filters.AdminToken(c.adminToken)).
+			Operation("setConfigFreeze").
+			Doc("Freeze or unfreeze changes of Meshes and policies. Requires the admin token").
+			Returns(200, "OK", nil).
+			Returns(400, "Bad request", nil).
+			Returns(401, "Unauthorized", nil).
+			Returns(403, "Forbidden", nil))
+	}
+}
+
+func (c *configFreezeWs) getStatus(request *restful.Request, response *restful.Response) {
+	status, err := c.configFreeze.Status(request.Request.Context())
+	if err != nil {
+		core.Log.Error(err, "Could not retrieve status of the configuration freeze")
+		writeError(response, 500, core_errors.Internal, "Could not retrieve status of the configuration freeze")
+		return
+	}
+	c.writeStatus(response, status)
+}
+
+func (c *configFreezeWs) setStatus(request *restful.Request, response *restful.Response) {
+	req := configFreezeRequest{}
+	if err := request.ReadEntity(&req); err != nil {
+		writeError(response, 400, core_errors.InvalidRequest, "Could not process the request")
+		return
+	}
+	status, err := c.configFreeze.Set(request.Request.Context(), req.Frozen, identityOf(request), req.Reason)
+	if err != nil {
+		core.Log.Error(err, "Could not change status of the configuration freeze")
+		writeError(response, 500, core_errors.Internal, "Could not change status of the configuration freeze")
+		return
+	}
+	c.writeStatus(response, status)
+}
+
+// identityOf returns who has made a request that presented the admin token.
+//
+// The admin token is shared, so a client certificate is the only way to tell admins apart.
+func identityOf(request *restful.Request) string {
+	if tls := request.Request.TLS; tls != nil && len(tls.VerifiedChains) > 0 {
+		return tls.VerifiedChains[0][0].Subject.CommonName
+	}
+	return "admin"
+}
+
+func (c *configFreezeWs) writeStatus(response *restful.Response, status freeze_managers.ConfigFreezeStatus) {
+	res := configFreezeStatus{
+		Frozen: status.Frozen,
+		By:     status.By,
+		Reason: status.Reason,
+	}
+	if !status.Since.IsZero() {
+		res.Since = &status.Since
+	}
+	if err := response.WriteAsJson(res); err != nil {
+		core.Log.Error(err, "Could not write the response")
+	}
+}
+
+func configFreezeWebService(configFreeze freeze_managers.ConfigFreeze, adminToken config.Secret, readOnly bool) *restful.WebService {
+	ws := new(restful.WebService)
+	ws.
+		Path("/config-freeze").
+		Consumes(restful.MIME_JSON).
+		Produces(restful.MIME_JSON)
+	configFreezeWs := configFreezeWs{
+		configFreeze: configFreeze,
+		adminToken:   adminToken,
+		readOnly:     readOnly,
+	}
+	configFreezeWs.AddToWs(ws)
+	return ws
+}
@@ -0,0 +1,144 @@
+package api_server_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	"github.com/Kong/kuma/pkg/api-server"
+	config "github.com/Kong/kuma/pkg/config/api-server"
+	"github.com/Kong/kuma/pkg/core"
+	freeze_managers "github.com/Kong/kuma/pkg/core/managers/freeze"
+	mesh_res "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/model/rest"
+	"github.com/Kong/kuma/pkg/core/resources/store"
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+)
+
+var _ = Describe("Config Freeze WS", func() {
+	var apiServer *api_server.ApiServer
+	var configFreeze freeze_managers.ConfigFreeze
+	var client resourceApiClient
+	var stop chan struct{}
+	var freezeUrl string
+
+	BeforeEach(func() {
+		core.Now = func() time.Time {
+			return time.Unix(1568000000, 0).UTC()
+		}
+		resourceStore := memory.NewStore()
+		err := resourceStore.Create(context.Background(), &mesh_res.MeshResource{}, store.CreateByKey("default", "default", "default"))
+		Expect(err).ToNot(HaveOccurred())
+
+		cfg := *config.DefaultApiServerConfig()
+		cfg.AdminToken = "s3cr3t"
+		configFreeze = newConfigFreeze(resourceStore)
+		apiServer = createTestApiServerWithConfigFreeze(resourceStore, core_xds.NewConfigHistory(10), configFreeze, cfg)
+		client = resourceApiClient{
+			address: apiServer.Address(),
+			path:    "/meshes",
+		}
+		freezeUrl = "http://" + apiServer.Address() + "/config-freeze"
+		stop = make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			err := apiServer.Start(stop)
+			Expect(err).ToNot(HaveOccurred())
+		}()
+		waitForServer(&client)
+	}, 5)
+
+	AfterEach(func() {
+		close(stop)
+		core.Now = time.Now
+	})
+
+	putFreeze := func(body string, token string) *http.Response {
+		request, err := http.NewRequest("PUT", freezeUrl, strings.NewReader(body))
+		Expect(err).ToNot(HaveOccurred())
+		request.Header.Add("content-type", "application/json")
+		if token != "" {
+			request.Header.Add("Authorization", "Bearer "+token)
+		}
+		response, err := http.DefaultClient.Do(request)
+		Expect(err).ToNot(HaveOccurred())
+		return response
+	}
+
+	It("should not be frozen by default", func() {
+		// when
+		response, err := http.Get(freezeUrl)
+		Expect(err).ToNot(HaveOccurred())
+
+		// then
+		Expect(response.StatusCode).To(Equal(200))
+		body, err := ioutil.ReadAll(response.Body)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(body).To(MatchJSON(`{"frozen": false}`))
+	})
+
+	It("should reject changes of Meshes while configuration is frozen", func() {
+		// when
+		response := putFreeze(`{"frozen": true, "reason": "Black Friday"}`, "s3cr3t")
+
+		// then
+		Expect(response.StatusCode).To(Equal(200))
+		body, err := ioutil.ReadAll(response.Body)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(body).To(MatchJSON(`{"frozen": true, "by": "admin", "reason": "Black Friday", "since": "2019-09-09T03:33:20Z"}`))
+
+		// when
+		response = client.put(rest.Resource{
+			Meta: rest.ResourceMeta{
+				Name: "demo",
+				Mesh: "demo",
+				Type: string(mesh_res.MeshType),
+			},
+			Spec: &mesh_proto.Mesh{},
+		})
+
+		// then
+		Expect(response.StatusCode).To(Equal(409))
+		body, err = ioutil.ReadAll(response.Body)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(body)).To(Equal(`configuration is frozen: frozen by "admin" since 2019-09-09T03:33:20Z, reason: "Black Friday"`))
+
+		// when
+		response = putFreeze(`{"frozen": false}`, "s3cr3t")
+
+		// then
+		Expect(response.StatusCode).To(Equal(200))
+		status, err := configFreeze.Status(context.Background())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status.Frozen).To(BeFalse())
+	})
+
+	It("should record who has frozen configuration rather than trust the request", func() {
+		// when
+		response := putFreeze(`{"frozen": true, "by": "somebody else"}`, "s3cr3t")
+
+		// then
+		Expect(response.StatusCode).To(Equal(200))
+		status, err := configFreeze.Status(context.Background())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status.By).To(Equal("admin"))
+	})
+
+	It("should require the admin token to freeze configuration", func() {
+		// when
+		response := putFreeze(`{"frozen": true}`, "")
+
+		// then
+		Expect(response.StatusCode).To(Equal(401))
+		status, err := configFreeze.Status(context.Background())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status.Frozen).To(BeFalse())
+	})
+})
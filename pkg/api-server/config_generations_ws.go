@@ -0,0 +1,63 @@
+package api_server
+
+import (
+	"time"
+
+	"github.com/emicklei/go-restful"
+
+	"github.com/Kong/kuma/pkg/core"
+	core_errors "github.com/Kong/kuma/pkg/core/errors"
+	"github.com/Kong/kuma/pkg/core/resources/model"
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
+)
+
+type configGenerationsWs struct {
+	configHistory core_xds.ConfigHistory
+}
+
+type configGenerationEntry struct {
+	Generation uint64    `json:"generation"`
+	Time       time.Time `json:"time"`
+	Config     string    `json:"config"`
+}
+
+type configGenerationList struct {
+	Items []configGenerationEntry `json:"items"`
+}
+
+func (c *configGenerationsWs) AddToWs(ws *restful.WebService) {
+	ws.Route(ws.GET("/{mesh}/dataplanes/{name}/config-generations").To(c.listConfigGenerations).
+		Doc("List recent generations of Envoy config of a dataplane. The list is empty unless history of config is turned on in the Control Plane").
+		Param(ws.PathParameter("name", "Name of a dataplane").DataType("string")).
+		Param(ws.PathParameter("mesh", "Name of a mesh").DataType("string")).
+		Returns(200, "OK", nil))
+}
+
+func (c *configGenerationsWs) listConfigGenerations(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	meshName := request.PathParameter("mesh")
+	proxyId := core_xds.FromResourceKey(model.ResourceKey{
+		Mesh:      meshName,
+		Namespace: namespace,
+		Name:      name,
+	})
+
+	list := configGenerationList{Items: []configGenerationEntry{}}
+	for _, generation := range c.configHistory.Get(proxyId.String()) {
+		config, err := core_xds.RenderSnapshot(generation.Snapshot)
+		if err != nil {
+			core.Log.Error(err, "Could not render a config generation", "name", name, "mesh", meshName, "generation", generation.Generation)
+			writeError(response, 500, core_errors.Internal, "Could not list config generations")
+			return
+		}
+		list.Items = append(list.Items, configGenerationEntry{
+			Generation: generation.Generation,
+			Time:       generation.Time,
+			Config:     string(config),
+		})
+	}
+	if err := response.WriteAsJson(list); err != nil {
+		core.Log.Error(err, "Could not write the response")
+		writeError(response, 500, core_errors.Internal, "Could not list config generations")
+	}
+}
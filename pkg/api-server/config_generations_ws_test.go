@@ -0,0 +1,119 @@
+package api_server_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	envoy "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	envoy_auth "github.com/envoyproxy/go-control-plane/envoy/api/v2/auth"
+	envoy_cache "github.com/envoyproxy/go-control-plane/pkg/cache"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kong/kuma/pkg/api-server"
+	config "github.com/Kong/kuma/pkg/config/api-server"
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+)
+
+var _ = Describe("Config Generations WS", func() {
+	var apiServer *api_server.ApiServer
+	var configHistory core_xds.ConfigHistory
+	var stop chan struct{}
+	var baseUrl string
+
+	BeforeEach(func() {
+		configHistory = core_xds.NewConfigHistory(10)
+		apiServer = createTestApiServerWithConfigHistory(memory.NewStore(), configHistory, *config.DefaultApiServerConfig())
+		client := resourceApiClient{
+			address: apiServer.Address(),
+			path:    "/meshes",
+		}
+		baseUrl = "http://" + apiServer.Address() + "/meshes/demo"
+		stop = make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			err := apiServer.Start(stop)
+			Expect(err).ToNot(HaveOccurred())
+		}()
+		waitForServer(&client)
+	}, 5)
+
+	AfterEach(func() {
+		close(stop)
+	})
+
+	type configGeneration struct {
+		Generation uint64 `json:"generation"`
+		Config     string `json:"config"`
+	}
+
+	list := func(name string) []configGeneration {
+		response, err := http.Get(baseUrl + "/dataplanes/" + name + "/config-generations")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(200))
+		body, err := ioutil.ReadAll(response.Body)
+		Expect(err).ToNot(HaveOccurred())
+		generations := struct {
+			Items []configGeneration `json:"items"`
+		}{}
+		Expect(json.Unmarshal(body, &generations)).To(Succeed())
+		return generations.Items
+	}
+
+	It("should list config generations of a dataplane", func() {
+		// given
+		configHistory.Record("demo.web-01.default", envoy_cache.Snapshot{
+			Clusters: envoy_cache.Resources{
+				Items: map[string]envoy_cache.Resource{
+					"backend": &envoy.Cluster{Name: "backend"},
+				},
+			},
+		})
+		configHistory.Record("demo.web-01.default", envoy_cache.Snapshot{
+			Clusters: envoy_cache.Resources{
+				Items: map[string]envoy_cache.Resource{
+					"backend": &envoy.Cluster{Name: "backend"},
+					"redis":   &envoy.Cluster{Name: "redis"},
+				},
+			},
+			Secrets: envoy_cache.Resources{
+				Items: map[string]envoy_cache.Resource{
+					"identity_cert": &envoy_auth.Secret{Name: "identity_cert"},
+				},
+			},
+		})
+
+		// when
+		generations := list("web-01")
+
+		// then
+		Expect(generations).To(HaveLen(2))
+		Expect(generations[0].Generation).To(Equal(uint64(1)))
+		Expect(generations[0].Config).To(MatchYAML(`
+clusters:
+- name: backend
+endpoints: []
+listeners: []
+routes: []
+secrets: []
+`))
+		Expect(generations[1].Generation).To(Equal(uint64(2)))
+		Expect(generations[1].Config).To(MatchYAML(`
+clusters:
+- name: backend
+- name: redis
+endpoints: []
+listeners: []
+routes: []
+secrets:
+- identity_cert
+`))
+	})
+
+	It("should return empty list for a dataplane without config", func() {
+		// expect
+		Expect(list("web-02")).To(BeEmpty())
+	})
+})
@@ -0,0 +1,56 @@
+package api_server
+
+import (
+	"github.com/emicklei/go-restful"
+
+	"github.com/Kong/kuma/pkg/api-server/filters"
+	"github.com/Kong/kuma/pkg/config"
+	"github.com/Kong/kuma/pkg/core"
+	core_errors "github.com/Kong/kuma/pkg/core/errors"
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
+)
+
+type dataplaneDisconnectWs struct {
+	streamTracker core_xds.StreamTracker
+	adminToken    config.Secret
+	readOnly      bool
+}
+
+type dataplaneDisconnectResult struct {
+	DisconnectedStreams int `json:"disconnectedStreams"`
+}
+
+func (d *dataplaneDisconnectWs) AddToWs(ws *restful.WebService) {
+	if d.readOnly {
+		return
+	}
+	ws.Route(ws.POST("/{mesh}/dataplanes/{name}/disconnect").To(d.disconnect).
+		Operation("disconnectDataplane").
+		Filter(filters.AdminToken(d.adminToken)).
+		Doc("Terminate xDS streams of a Dataplane, so that it reconnects and gets fresh configuration").
+		Param(ws.PathParameter("mesh", "Name of a mesh").DataType("string")).
+		Param(ws.PathParameter("name", "Name of a dataplane").DataType("string")).
+		Returns(200, "OK", nil).
+		Returns(401, "Unauthorized", nil).
+		Returns(404, "Not found", nil))
+}
+
+func (d *dataplaneDisconnectWs) disconnect(request *restful.Request, response *restful.Response) {
+	meshName := request.PathParameter("mesh")
+	name := request.PathParameter("name")
+	proxyId, err := core_xds.BuildProxyId(meshName, name)
+	if err != nil {
+		writeError(response, 400, core_errors.InvalidRequest, "Could not disconnect a dataplane: "+err.Error())
+		return
+	}
+	count := d.streamTracker.Disconnect(*proxyId)
+	if count == 0 {
+		// every replica of Control Plane has its own xDS streams
+		writeError(response, 404, core_errors.DataplaneOffline, "Dataplane is not connected to this instance of Control Plane")
+		return
+	}
+	core.Log.Info("disconnected a dataplane on request", "mesh", meshName, "name", name, "streams", count, "remoteAddr", request.Request.RemoteAddr)
+	if err := response.WriteAsJson(dataplaneDisconnectResult{DisconnectedStreams: count}); err != nil {
+		core.Log.Error(err, "Could not write the response")
+	}
+}
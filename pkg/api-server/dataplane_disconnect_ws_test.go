@@ -0,0 +1,132 @@
+package api_server_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kong/kuma/pkg/api-server"
+	config "github.com/Kong/kuma/pkg/config/api-server"
+	mesh_res "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/store"
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+)
+
+var _ = Describe("Dataplane Disconnect WS", func() {
+	var apiServer *api_server.ApiServer
+	var streamTracker core_xds.StreamTracker
+	var stop chan struct{}
+
+	startServer := func(cfg config.ApiServerConfig) {
+		resourceStore := memory.NewStore()
+		err := resourceStore.Create(context.Background(), &mesh_res.MeshResource{}, store.CreateByKey("default", "default", "default"))
+		Expect(err).ToNot(HaveOccurred())
+
+		streamTracker = core_xds.NewStreamTracker()
+		apiServer = createTestApiServerWithStreamTracker(resourceStore, core_xds.NewConfigHistory(10), newConfigFreeze(resourceStore), streamTracker, cfg)
+		stop = make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			err := apiServer.Start(stop)
+			Expect(err).ToNot(HaveOccurred())
+		}()
+		waitForServer(&resourceApiClient{
+			address: apiServer.Address(),
+			path:    "/meshes/default/dataplanes",
+		})
+	}
+
+	AfterEach(func() {
+		close(stop)
+	})
+
+	disconnect := func(name string, token string) (int, string) {
+		request, err := http.NewRequest("POST", "http://"+apiServer.Address()+"/meshes/default/dataplanes/"+name+"/disconnect", nil)
+		Expect(err).ToNot(HaveOccurred())
+		request.Header.Add("content-type", "application/json")
+		if token != "" {
+			request.Header.Add("Authorization", "Bearer "+token)
+		}
+		response, err := http.DefaultClient.Do(request)
+		Expect(err).ToNot(HaveOccurred())
+		body, err := ioutil.ReadAll(response.Body)
+		Expect(err).ToNot(HaveOccurred())
+		return response.StatusCode, string(body)
+	}
+
+	Context("with an admin token", func() {
+
+		BeforeEach(func() {
+			cfg := config.DefaultApiServerConfig()
+			cfg.AdminToken = "s3cr3t"
+			startServer(*cfg)
+		}, 5)
+
+		It("should disconnect xDS streams of a dataplane", func() {
+			// given
+			disconnected := 0
+			streamTracker.Track(core_xds.ProxyId{Mesh: "default", Name: "backend-01", Namespace: "default"}, func() {
+				disconnected++
+			})
+
+			// when
+			status, body := disconnect("backend-01", "s3cr3t")
+
+			// then
+			Expect(status).To(Equal(200))
+			Expect(body).To(MatchJSON(`{"disconnectedStreams": 1}`))
+			Expect(disconnected).To(Equal(1))
+		})
+
+		It("should report a dataplane that is not connected", func() {
+			// when
+			status, body := disconnect("backend-01", "s3cr3t")
+
+			// then
+			Expect(status).To(Equal(404))
+			Expect(body).To(Equal("Dataplane is not connected to this instance of Control Plane"))
+		})
+
+		It("should reject a request with an invalid token", func() {
+			// given
+			disconnected := 0
+			streamTracker.Track(core_xds.ProxyId{Mesh: "default", Name: "backend-01", Namespace: "default"}, func() {
+				disconnected++
+			})
+
+			// when
+			status, body := disconnect("backend-01", "guess")
+
+			// then
+			Expect(status).To(Equal(401))
+			Expect(body).To(Equal("Request has to present a valid admin token"))
+
+			// when
+			status, _ = disconnect("backend-01", "")
+
+			// then
+			Expect(status).To(Equal(401))
+			Expect(disconnected).To(Equal(0))
+		})
+	})
+
+	Context("without an admin token", func() {
+
+		BeforeEach(func() {
+			startServer(*config.DefaultApiServerConfig())
+		}, 5)
+
+		It("should refuse to disconnect a dataplane", func() {
+			// when
+			status, body := disconnect("backend-01", "")
+
+			// then
+			Expect(status).To(Equal(403))
+			Expect(body).To(Equal("Admin operations are disabled, set apiServer.adminToken to enable them"))
+		})
+	})
+})
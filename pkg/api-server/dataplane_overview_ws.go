@@ -4,6 +4,7 @@ import (
 	"context"
 	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
 	"github.com/Kong/kuma/pkg/core"
+	core_errors "github.com/Kong/kuma/pkg/core/errors"
 	"github.com/Kong/kuma/pkg/core/resources/apis/mesh"
 	"github.com/Kong/kuma/pkg/core/resources/manager"
 	"github.com/Kong/kuma/pkg/core/resources/model/rest"
@@ -28,7 +29,10 @@ func (r *overviewWs) AddToWs(ws *restful.WebService) {
 		Doc("Inspect all dataplanes").
 		Param(ws.PathParameter("mesh", "Name of a mesh").DataType("string")).
 		Param(ws.QueryParameter("tag", "Tag to filter in key:value format").DataType("string")).
-		Returns(200, "OK", nil))
+		Param(ws.QueryParameter(rest.SizeQueryParam, "Maximum number of dataplanes on a page").DataType("integer")).
+		Param(ws.QueryParameter(rest.OffsetQueryParam, "Offset of a page, taken from the link to the next page").DataType("string")).
+		Returns(200, "OK", nil).
+		Returns(400, "Bad request", nil))
 }
 
 func (r *overviewWs) inspectDataplane(request *restful.Request, response *restful.Response) {
@@ -38,17 +42,17 @@ func (r *overviewWs) inspectDataplane(request *restful.Request, response *restfu
 	overview, err := r.fetchOverview(request.Request.Context(), name, meshName)
 	if err != nil {
 		if store.IsResourceNotFound(err) {
-			writeError(response, 404, "")
+			writeError(response, 404, core_errors.ResourceNotFound, "")
 		} else {
 			core.Log.Error(err, "Could not retrieve a dataplane overview", "name", name)
-			writeError(response, 500, "Could not retrieve a dataplane overview")
+			writeError(response, 500, core_errors.Internal, "Could not retrieve a dataplane overview")
 		}
 	}
 
 	res := rest.From.Resource(overview)
 	if err := response.WriteAsJson(res); err != nil {
 		core.Log.Error(err, "Could not write the response")
-		writeError(response, 500, "Could not write the response")
+		writeError(response, 500, core_errors.Internal, "Could not write the response")
 	}
 }
 
@@ -75,26 +79,40 @@ func (r *overviewWs) fetchOverview(ctx context.Context, name string, meshName st
 
 func (r *overviewWs) inspectDataplanes(request *restful.Request, response *restful.Response) {
 	meshName := request.PathParameter("mesh")
-	overviews, err := r.fetchOverviews(request.Request.Context(), meshName)
+	size, err := rest.ParseSize(request.QueryParameter(rest.SizeQueryParam))
+	if err != nil {
+		writeError(response, 400, core_errors.InvalidRequest, err.Error())
+		return
+	}
+	overviews, err := r.fetchOverviews(request.Request.Context(), meshName,
+		store.ListByTags(parseTags(request.QueryParameters("tag"))),
+		store.ListByPage(size, request.QueryParameter(rest.OffsetQueryParam)),
+	)
 	if err != nil {
+		if store.IsInvalidOffset(err) {
+			writeError(response, 400, core_errors.InvalidRequest, err.Error())
+			return
+		}
 		core.Log.Error(err, "Could not retrieve dataplane overviews")
-		writeError(response, 500, "Could not list dataplane overviews")
+		writeError(response, 500, core_errors.Internal, "Could not list dataplane overviews")
 		return
 	}
 
-	tags := parseTags(request.QueryParameters("tag"))
-	overviews.RetainMatchingTags(tags)
-
 	restList := rest.From.ResourceList(&overviews)
+	if nextOffset := overviews.Pagination.NextOffset; nextOffset != "" {
+		next := rest.NextPage(requestURL(request), size, nextOffset)
+		restList.Next = &next
+	}
 	if err := response.WriteAsJson(restList); err != nil {
 		core.Log.Error(err, "Could not write DataplaneOverview as JSON")
-		writeError(response, 500, "Could not list dataplane overviews")
+		writeError(response, 500, core_errors.Internal, "Could not list dataplane overviews")
 	}
 }
 
-func (r *overviewWs) fetchOverviews(ctx context.Context, meshName string) (mesh.DataplaneOverviewResourceList, error) {
+// fetchOverviews lists overviews of Dataplanes that match given options, e.g. ones on a requested page.
+func (r *overviewWs) fetchOverviews(ctx context.Context, meshName string, fs ...store.ListOptionsFunc) (mesh.DataplaneOverviewResourceList, error) {
 	dataplanes := mesh.DataplaneResourceList{}
-	if err := r.resManager.List(ctx, &dataplanes, store.ListByMesh(meshName)); err != nil {
+	if err := r.resManager.List(ctx, &dataplanes, append([]store.ListOptionsFunc{store.ListByMesh(meshName)}, fs...)...); err != nil {
 		return mesh.DataplaneOverviewResourceList{}, err
 	}
 
@@ -103,7 +121,9 @@ func (r *overviewWs) fetchOverviews(ctx context.Context, meshName string) (mesh.
 		return mesh.DataplaneOverviewResourceList{}, err
 	}
 
-	return mesh.NewDataplaneOverviews(dataplanes, insights), nil
+	overviews := mesh.NewDataplaneOverviews(dataplanes, insights)
+	overviews.Pagination = dataplanes.Pagination
+	return overviews, nil
 }
 
 // Tags should be passed in form of ?tag=service:mobile&tag=version:v1
@@ -2,6 +2,7 @@ package api_server_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/Kong/kuma/api/mesh/v1alpha1"
 	"github.com/Kong/kuma/pkg/api-server"
@@ -135,6 +136,65 @@ var _ = Describe("Dataplane Overview WS", func() {
 			Expect(body).To(MatchJSON(sampleJson))
 		})
 
+		It("should list resources page by page", func() {
+			// given
+			dpResource := mesh_core.DataplaneResource{
+				Spec: v1alpha1.Dataplane{
+					Networking: &v1alpha1.Dataplane_Networking{
+						Inbound: []*v1alpha1.Dataplane_Networking_Inbound{
+							{
+								Interface: "127.0.0.2:9090:9091",
+								Tags: map[string]string{
+									"service": "sample",
+									"version": "v2",
+								},
+							},
+						},
+					},
+				},
+			}
+			err := resourceStore.Create(context.Background(), &dpResource, store.CreateByKey("default", "dp2", "mesh1"))
+			Expect(err).ToNot(HaveOccurred())
+
+			type page struct {
+				Total uint32            `json:"total"`
+				Items []json.RawMessage `json:"items"`
+				Next  *string           `json:"next"`
+			}
+
+			// when
+			first := page{}
+			response, err := http.Get("http://" + apiServer.Address() + "/meshes/mesh1/dataplanes+insights?size=1")
+			Expect(err).ToNot(HaveOccurred())
+
+			// then
+			Expect(response.StatusCode).To(Equal(200))
+			Expect(json.NewDecoder(response.Body).Decode(&first)).To(Succeed())
+			Expect(first.Items).To(HaveLen(1))
+			Expect(first.Items[0]).To(MatchJSON(sampleJson))
+			Expect(first.Next).ToNot(BeNil())
+
+			// when
+			second := page{}
+			response, err = http.Get(*first.Next)
+			Expect(err).ToNot(HaveOccurred())
+
+			// then
+			Expect(response.StatusCode).To(Equal(200))
+			Expect(json.NewDecoder(response.Body).Decode(&second)).To(Succeed())
+			Expect(second.Items).To(HaveLen(1))
+			Expect(second.Next).To(BeNil())
+		})
+
+		It("should return 400 on invalid offset", func() {
+			// when
+			response, err := http.Get("http://" + apiServer.Address() + "/meshes/mesh1/dataplanes+insights?size=1&offset=abc")
+			Expect(err).ToNot(HaveOccurred())
+
+			// then
+			Expect(response.StatusCode).To(Equal(400))
+		})
+
 		type testCase struct {
 			url          string
 			expectedJson string
@@ -155,19 +215,19 @@ var _ = Describe("Dataplane Overview WS", func() {
 			},
 			Entry("should list all when no tag is provided", testCase{
 				url:          "/meshes/mesh1/dataplanes+insights",
-				expectedJson: fmt.Sprintf(`{"items": [%s]}`, sampleJson),
+				expectedJson: fmt.Sprintf(`{"total": 1, "items": [%s]}`, sampleJson),
 			}),
 			Entry("should list with only one matching tag", testCase{
 				url:          "/meshes/mesh1/dataplanes+insights?tag=service:sample",
-				expectedJson: fmt.Sprintf(`{"items": [%s]}`, sampleJson),
+				expectedJson: fmt.Sprintf(`{"total": 1, "items": [%s]}`, sampleJson),
 			}),
 			Entry("should list all with all matching tags", testCase{
 				url:          "/meshes/mesh1/dataplanes+insights?tag=service:sample&tag=version:v1",
-				expectedJson: fmt.Sprintf(`{"items": [%s]}`, sampleJson),
+				expectedJson: fmt.Sprintf(`{"total": 1, "items": [%s]}`, sampleJson),
 			}),
 			Entry("should not list when any tag is not matching", testCase{
 				url:          "/meshes/mesh1/dataplanes+insights?tag=service:sample&tag=version:v2",
-				expectedJson: `{"items": []}`,
+				expectedJson: `{"total": 0, "items": []}`,
 			}),
 		)
 	})
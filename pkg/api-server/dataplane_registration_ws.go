@@ -0,0 +1,222 @@
+package api_server
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/emicklei/go-restful"
+	"github.com/pkg/errors"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	"github.com/Kong/kuma/pkg/core"
+	core_errors "github.com/Kong/kuma/pkg/core/errors"
+	dataplane_managers "github.com/Kong/kuma/pkg/core/managers/apis/dataplane"
+	freeze_managers "github.com/Kong/kuma/pkg/core/managers/freeze"
+	quota_managers "github.com/Kong/kuma/pkg/core/managers/quota"
+	validation_managers "github.com/Kong/kuma/pkg/core/managers/validation"
+	"github.com/Kong/kuma/pkg/core/resources/manager"
+	"github.com/Kong/kuma/pkg/core/resources/store"
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
+	"github.com/Kong/kuma/pkg/tokens/builtin/issuer"
+)
+
+const bearerPrefix = "Bearer "
+
+// dataplaneRegistrationWs lets dataplanes join and leave a Mesh on their own.
+//
+// A dataplane has to present a Dataplane token issued for its mesh and name,
+// so it can neither register nor deregister other dataplanes.
+// It is turned off if registry is nil, e.g. on Kubernetes, where Dataplanes are created out of Pods.
+type dataplaneRegistrationWs struct {
+	registry    *dataplane_managers.Registry
+	tokenIssuer issuer.DataplaneTokenIssuer
+	readOnly    bool
+}
+
+// dataplaneRegistration describes a dataplane that registers itself with the Control Plane.
+//
+// A Dataplane is created out of it or networking of an existing Dataplane is replaced.
+type dataplaneRegistration struct {
+	// Address is an IP address that other dataplanes can reach the dataplane at.
+	Address   string                          `json:"address"`
+	Inbounds  []dataplaneRegistrationInbound  `json:"inbounds,omitempty"`
+	Outbounds []dataplaneRegistrationOutbound `json:"outbounds,omitempty"`
+}
+
+// dataplaneRegistrationInbound describes a service implemented by the dataplane.
+type dataplaneRegistrationInbound struct {
+	// Port the dataplane listens on.
+	Port uint32 `json:"port"`
+	// Port of the workload. Defaults to Port.
+	ServicePort uint32            `json:"servicePort,omitempty"`
+	Tags        map[string]string `json:"tags"`
+}
+
+// dataplaneRegistrationOutbound describes a service consumed by the dataplane.
+type dataplaneRegistrationOutbound struct {
+	// Port on localhost the dataplane listens on.
+	Port        uint32 `json:"port"`
+	Service     string `json:"service"`
+	ServicePort uint32 `json:"servicePort"`
+}
+
+func (d *dataplaneRegistrationWs) AddToWs(ws *restful.WebService) {
+	if d.readOnly || d.registry == nil {
+		return
+	}
+	ws.Route(ws.POST("/{mesh}/dataplanes/{name}/registration").To(d.register).
+		Operation("registerDataplane").
+		Filter(d.authenticate).
+		Doc("Register a dataplane with its address and inbound and outbound interfaces. A Dataplane is created or its networking is replaced. Requires a Dataplane token of the dataplane").
+		Param(ws.PathParameter("mesh", "Name of a mesh").DataType("string")).
+		Param(ws.PathParameter("name", "Name of a dataplane").DataType("string")).
+		Returns(200, "OK", nil).
+		Returns(201, "Created", nil).
+		Returns(400, "Bad request", nil).
+		Returns(401, "Unauthorized", nil).
+		Returns(409, "Conflict", nil))
+
+	ws.Route(ws.DELETE("/{mesh}/dataplanes/{name}/registration").To(d.deregister).
+		Operation("deregisterDataplane").
+		Filter(d.authenticate).
+		Doc("Deregister a dataplane. Deregistering a dataplane that doesn't exist is not an error. Requires a Dataplane token of the dataplane").
+		Param(ws.PathParameter("mesh", "Name of a mesh").DataType("string")).
+		Param(ws.PathParameter("name", "Name of a dataplane").DataType("string")).
+		Returns(204, "No content", nil).
+		Returns(401, "Unauthorized", nil).
+		Returns(409, "Conflict", nil))
+}
+
+// authenticate only lets through requests that present a Dataplane token issued for the mesh and the name
+// of a dataplane in the path as "Authorization: Bearer <token>".
+func (d *dataplaneRegistrationWs) authenticate(request *restful.Request, response *restful.Response, chain *restful.FilterChain) {
+	token := ""
+	if header := request.HeaderParameter("Authorization"); strings.HasPrefix(header, bearerPrefix) {
+		token = strings.TrimPrefix(header, bearerPrefix)
+	}
+	if err := issuer.Authenticate(request.Request.Context(), d.tokenIssuer, issuer.Token(token), request.PathParameter("mesh"), request.PathParameter("name")); err != nil {
+		core.Log.V(1).Info("rejected a registration of a dataplane", "mesh", request.PathParameter("mesh"), "name", request.PathParameter("name"), "reason", err.Error())
+		writeError(response, 401, core_errors.Unauthenticated, "Request has to present a valid Dataplane token of the dataplane")
+		return
+	}
+	chain.ProcessFilter(request, response)
+}
+
+func (d *dataplaneRegistrationWs) register(request *restful.Request, response *restful.Response) {
+	proxyId, err := core_xds.BuildProxyId(request.PathParameter("mesh"), request.PathParameter("name"))
+	if err != nil {
+		writeError(response, 400, core_errors.InvalidRequest, "Could not register a dataplane: "+err.Error())
+		return
+	}
+	registration := dataplaneRegistration{}
+	if err := request.ReadEntity(&registration); err != nil {
+		writeError(response, 400, core_errors.InvalidRequest, "Could not parse a registration")
+		return
+	}
+	networking, err := registration.toNetworking()
+	if err != nil {
+		writeError(response, 400, core_errors.InvalidRequest, "Could not register a dataplane: "+err.Error())
+		return
+	}
+	created, err := d.registry.Register(request.Request.Context(), proxyId.ToResourceKey(), func(*mesh_proto.Dataplane_Networking) *mesh_proto.Dataplane_Networking {
+		return networking
+	})
+	switch {
+	case err == nil && created:
+		response.WriteHeader(201)
+	case err == nil:
+		response.WriteHeader(200)
+	case manager.IsMeshNotFound(err):
+		writeError(response, 400, core_errors.MeshNotFound, fmt.Sprintf("Mesh of name %v is not found", proxyId.Mesh))
+	case validation_managers.IsValidationRejected(err):
+		writeError(response, 400, core_errors.ValidationRejected, err.Error())
+	case quota_managers.IsQuotaExceeded(err):
+		writeError(response, 403, core_errors.QuotaExceeded, err.Error())
+	default:
+		d.writeWriteError(response, err, "Could not register a dataplane")
+	}
+}
+
+func (d *dataplaneRegistrationWs) deregister(request *restful.Request, response *restful.Response) {
+	proxyId, err := core_xds.BuildProxyId(request.PathParameter("mesh"), request.PathParameter("name"))
+	if err != nil {
+		writeError(response, 400, core_errors.InvalidRequest, "Could not deregister a dataplane: "+err.Error())
+		return
+	}
+	if err := d.registry.Deregister(request.Request.Context(), proxyId.ToResourceKey()); err != nil {
+		d.writeWriteError(response, err, "Could not deregister a dataplane")
+		return
+	}
+	response.WriteHeader(204)
+}
+
+func (d *dataplaneRegistrationWs) writeWriteError(response *restful.Response, err error, msg string) {
+	switch {
+	case freeze_managers.IsConfigFrozen(err):
+		writeError(response, 409, core_errors.ConfigFrozen, err.Error())
+	case store.IsResourceConflict(err) || store.IsResourceAlreadyExists(err):
+		writeError(response, 409, core_errors.ResourceConflict, "Dataplane is being modified concurrently, try again")
+	default:
+		core.Log.Error(err, msg)
+		writeError(response, 500, core_errors.Internal, msg)
+	}
+}
+
+func (r *dataplaneRegistration) toNetworking() (*mesh_proto.Dataplane_Networking, error) {
+	if net.ParseIP(r.Address) == nil {
+		return nil, errors.Errorf("address must be a valid IP address, got %q", r.Address)
+	}
+	if len(r.Inbounds) == 0 {
+		return nil, errors.New("at least one inbound interface is required")
+	}
+	networking := &mesh_proto.Dataplane_Networking{}
+	for i, inbound := range r.Inbounds {
+		if err := validPort(inbound.Port); err != nil {
+			return nil, errors.Errorf("inbounds[%d]: port %v", i, err)
+		}
+		servicePort := inbound.ServicePort
+		if servicePort == 0 {
+			servicePort = inbound.Port
+		}
+		if err := validPort(servicePort); err != nil {
+			return nil, errors.Errorf("inbounds[%d]: servicePort %v", i, err)
+		}
+		if inbound.Tags[mesh_proto.ServiceTag] == "" {
+			return nil, errors.Errorf("inbounds[%d]: tag %q is mandatory", i, mesh_proto.ServiceTag)
+		}
+		iface := mesh_proto.InboundInterface{
+			DataplaneIP:   r.Address,
+			DataplanePort: inbound.Port,
+			WorkloadPort:  servicePort,
+		}
+		networking.Inbound = append(networking.Inbound, &mesh_proto.Dataplane_Networking_Inbound{
+			Interface: iface.String(),
+			Tags:      inbound.Tags,
+		})
+	}
+	for i, outbound := range r.Outbounds {
+		if err := validPort(outbound.Port); err != nil {
+			return nil, errors.Errorf("outbounds[%d]: port %v", i, err)
+		}
+		if outbound.Service == "" {
+			return nil, errors.Errorf("outbounds[%d]: service must not be empty", i)
+		}
+		if err := validPort(outbound.ServicePort); err != nil {
+			return nil, errors.Errorf("outbounds[%d]: servicePort %v", i, err)
+		}
+		networking.Outbound = append(networking.Outbound, &mesh_proto.Dataplane_Networking_Outbound{
+			Interface:   fmt.Sprintf(":%d", outbound.Port),
+			Service:     outbound.Service,
+			ServicePort: outbound.ServicePort,
+		})
+	}
+	return networking, nil
+}
+
+func validPort(port uint32) error {
+	if port < 1 || 65535 < port {
+		return errors.Errorf("must be in the range [1, 65535], got %d", port)
+	}
+	return nil
+}
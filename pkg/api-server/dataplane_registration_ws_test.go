@@ -0,0 +1,248 @@
+package api_server_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kong/kuma/pkg/api-server"
+	config "github.com/Kong/kuma/pkg/config/api-server"
+	mesh_res "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/store"
+	secret_cipher "github.com/Kong/kuma/pkg/core/secrets/cipher"
+	secret_manager "github.com/Kong/kuma/pkg/core/secrets/manager"
+	secret_store "github.com/Kong/kuma/pkg/core/secrets/store"
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+	"github.com/Kong/kuma/pkg/tokens/builtin/issuer"
+	util_proto "github.com/Kong/kuma/pkg/util/proto"
+)
+
+var _ = Describe("Dataplane Registration WS", func() {
+	var apiServer *api_server.ApiServer
+	var resourceStore store.ResourceStore
+	var tokenIssuer issuer.DataplaneTokenIssuer
+	var stop chan struct{}
+
+	startServer := func(registration bool, cfg config.ApiServerConfig) {
+		resourceStore = memory.NewStore()
+		err := resourceStore.Create(context.Background(), &mesh_res.MeshResource{}, store.CreateByKey("default", "default", "default"))
+		Expect(err).ToNot(HaveOccurred())
+		tokenIssuer = issuer.NewDataplaneTokenIssuer(issuer.NewSigningKeyManager(secret_manager.NewSecretManager(secret_store.NewSecretStore(resourceStore), secret_cipher.None())))
+
+		apiServer = createTestApiServerWithRegistration(resourceStore, core_xds.NewConfigHistory(10), newConfigFreeze(resourceStore), core_xds.NewStreamTracker(), registration, cfg)
+		stop = make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			err := apiServer.Start(stop)
+			Expect(err).ToNot(HaveOccurred())
+		}()
+		waitForServer(&resourceApiClient{
+			address: apiServer.Address(),
+			path:    "/meshes/default/dataplanes",
+		})
+	}
+
+	AfterEach(func() {
+		close(stop)
+	})
+
+	send := func(method string, path string, body string, token string) (int, string) {
+		request, err := http.NewRequest(method, "http://"+apiServer.Address()+"/meshes/"+path+"/registration", strings.NewReader(body))
+		Expect(err).ToNot(HaveOccurred())
+		request.Header.Add("content-type", "application/json")
+		if token != "" {
+			request.Header.Add("Authorization", "Bearer "+token)
+		}
+		response, err := http.DefaultClient.Do(request)
+		Expect(err).ToNot(HaveOccurred())
+		respBody, err := ioutil.ReadAll(response.Body)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.Body.Close()).To(Succeed())
+		return response.StatusCode, string(respBody)
+	}
+
+	tokenOf := func(mesh, name string) string {
+		token, err := tokenIssuer.Generate(context.Background(), issuer.DataplaneIdentity{Mesh: mesh, Name: name}, 0)
+		Expect(err).ToNot(HaveOccurred())
+		return string(token)
+	}
+
+	registration := `
+	{
+	  "address": "192.168.0.1",
+	  "inbounds": [ { "port": 10000, "servicePort": 8080, "tags": { "service": "backend", "version": "v1" } } ],
+	  "outbounds": [ { "port": 10001, "service": "redis", "servicePort": 6379 } ]
+	}`
+
+	get := func() (*mesh_res.DataplaneResource, error) {
+		dataplane := &mesh_res.DataplaneResource{}
+		return dataplane, resourceStore.Get(context.Background(), dataplane, store.GetByKey("default", "backend-01", "default"))
+	}
+
+	Context("with registration turned on", func() {
+
+		var token string
+
+		BeforeEach(func() {
+			cfg := config.DefaultApiServerConfig()
+			cfg.WriteToken = "s3cr3t"
+			startServer(true, *cfg)
+			token = tokenOf("default", "backend-01")
+		}, 5)
+
+		It("should create, update and delete a Dataplane", func() {
+			// when
+			status, _ := send("POST", "default/dataplanes/backend-01", registration, token)
+
+			// then
+			Expect(status).To(Equal(201))
+			dataplane, err := get()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(util_proto.ToYAML(&dataplane.Spec)).To(MatchYAML(`
+            networking:
+              inbound:
+              - interface: 192.168.0.1:10000:8080
+                tags:
+                  service: backend
+                  version: v1
+              outbound:
+              - interface: :10001
+                service: redis
+                servicePort: 6379
+`))
+
+			// when registered again
+			status, _ = send("POST", "default/dataplanes/backend-01", registration, token)
+
+			// then
+			Expect(status).To(Equal(200))
+
+			// when
+			status, _ = send("POST", "default/dataplanes/backend-01", `{ "address": "192.168.0.2", "inbounds": [ { "port": 8080, "tags": { "service": "backend" } } ] }`, token)
+
+			// then
+			Expect(status).To(Equal(200))
+			dataplane, err = get()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(util_proto.ToYAML(&dataplane.Spec)).To(MatchYAML(`
+            networking:
+              inbound:
+              - interface: 192.168.0.2:8080:8080
+                tags:
+                  service: backend
+`))
+
+			// when
+			status, _ = send("DELETE", "default/dataplanes/backend-01", "", token)
+
+			// then
+			Expect(status).To(Equal(204))
+			_, err = get()
+			Expect(store.IsResourceNotFound(err)).To(BeTrue())
+
+			// when deregistered again
+			status, _ = send("DELETE", "default/dataplanes/backend-01", "", token)
+
+			// then
+			Expect(status).To(Equal(204))
+		})
+
+		DescribeTable("should reject a request without a Dataplane token of the dataplane",
+			func(given func() string) {
+				// when
+				status, body := send("POST", "default/dataplanes/backend-01", registration, given())
+
+				// then
+				Expect(status).To(Equal(401))
+				Expect(body).To(Equal("Request has to present a valid Dataplane token of the dataplane"))
+
+				// when
+				status, _ = send("DELETE", "default/dataplanes/backend-01", "", given())
+
+				// then
+				Expect(status).To(Equal(401))
+				_, err := get()
+				Expect(store.IsResourceNotFound(err)).To(BeTrue())
+			},
+			Entry("no token", func() string {
+				return ""
+			}),
+			Entry("write token", func() string {
+				return "s3cr3t"
+			}),
+			Entry("token of another dataplane", func() string {
+				return tokenOf("default", "backend-02")
+			}),
+			Entry("token of a dataplane in another mesh", func() string {
+				return tokenOf("demo", "backend-01")
+			}),
+		)
+
+		type testCase struct {
+			path        string
+			body        string
+			expectedErr string
+		}
+
+		DescribeTable("should reject invalid registrations",
+			func(given testCase) {
+				// when
+				mesh, name := path.Split(given.path)
+				status, body := send("POST", given.path, given.body, tokenOf(strings.Split(mesh, "/")[0], name))
+
+				// then
+				Expect(status).To(Equal(400))
+				Expect(body).To(Equal(given.expectedErr))
+			},
+			Entry("invalid address", testCase{
+				path:        "default/dataplanes/dp-1",
+				body:        `{ "address": "backend", "inbounds": [ { "port": 8080, "tags": { "service": "backend" } } ] }`,
+				expectedErr: `Could not register a dataplane: address must be a valid IP address, got "backend"`,
+			}),
+			Entry("no inbounds", testCase{
+				path:        "default/dataplanes/dp-1",
+				body:        `{ "address": "192.168.0.1" }`,
+				expectedErr: `Could not register a dataplane: at least one inbound interface is required`,
+			}),
+			Entry("inbound without service tag", testCase{
+				path:        "default/dataplanes/dp-1",
+				body:        `{ "address": "192.168.0.1", "inbounds": [ { "port": 8080, "tags": { "version": "v1" } } ] }`,
+				expectedErr: `Could not register a dataplane: inbounds[0]: tag "service" is mandatory`,
+			}),
+			Entry("outbound without service port", testCase{
+				path:        "default/dataplanes/dp-1",
+				body:        `{ "address": "192.168.0.1", "inbounds": [ { "port": 8080, "tags": { "service": "backend" } } ], "outbounds": [ { "port": 10001, "service": "redis" } ] }`,
+				expectedErr: `Could not register a dataplane: outbounds[0]: servicePort must be in the range [1, 65535], got 0`,
+			}),
+			Entry("unknown mesh", testCase{
+				path:        "demo/dataplanes/dp-1",
+				body:        `{ "address": "192.168.0.1", "inbounds": [ { "port": 8080, "tags": { "service": "backend" } } ] }`,
+				expectedErr: `Mesh of name demo is not found`,
+			}),
+		)
+	})
+
+	Context("when registration is turned off", func() {
+
+		BeforeEach(func() {
+			startServer(false, *config.DefaultApiServerConfig())
+		}, 5)
+
+		It("should not register a dataplane", func() {
+			// when
+			status, _ := send("POST", "default/dataplanes/backend-01", registration, "")
+
+			// then
+			Expect(status).To(Equal(404))
+			_, err := get()
+			Expect(store.IsResourceNotFound(err)).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,105 @@
+package api_server_test
+
+import (
+	"io/ioutil"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	api_server "github.com/Kong/kuma/pkg/api-server"
+	config "github.com/Kong/kuma/pkg/config/api-server"
+	"github.com/Kong/kuma/pkg/core/resources/store"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+)
+
+var _ = Describe("Dataplane WS", func() {
+	var apiServer *api_server.ApiServer
+	var resourceStore store.ResourceStore
+	var client resourceApiClient
+	var stop chan struct{}
+
+	BeforeEach(func() {
+		resourceStore = memory.NewStore()
+		apiServer = createTestApiServer(resourceStore, *config.DefaultApiServerConfig())
+		client = resourceApiClient{
+			apiServer.Address(),
+			"/meshes/default/dataplanes",
+		}
+		stop = make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			err := apiServer.Start(stop)
+			Expect(err).ToNot(HaveOccurred())
+		}()
+		waitForServer(&client)
+		putMeshIntoStore(resourceStore, "default")
+	}, 5)
+
+	AfterEach(func() {
+		close(stop)
+	})
+
+	It("should accept a gateway", func() {
+		// given
+		json := `
+		{
+			"type": "Dataplane",
+			"name": "edge",
+			"mesh": "default",
+			"networking": {
+				"gateway": {
+					"tags": {
+						"service": "edge"
+					}
+				},
+				"outbound": [
+					{
+						"interface": ":33033",
+						"service": "backend"
+					}
+				]
+			}
+		}`
+
+		// when
+		response := client.putJson("edge", []byte(json))
+
+		// then
+		Expect(response.StatusCode).To(Equal(201))
+	})
+
+	It("should reject a gateway with inbound interfaces", func() {
+		// given
+		json := `
+		{
+			"type": "Dataplane",
+			"name": "edge",
+			"mesh": "default",
+			"networking": {
+				"gateway": {
+					"tags": {
+						"service": "edge"
+					}
+				},
+				"inbound": [
+					{
+						"interface": "192.168.0.1:80:8080",
+						"tags": {
+							"service": "backend"
+						}
+					}
+				]
+			}
+		}`
+
+		// when
+		response := client.putJson("edge", []byte(json))
+
+		// then
+		Expect(response.StatusCode).To(Equal(400))
+		// and
+		body, err := ioutil.ReadAll(response.Body)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(body)).To(ContainSubstring("networking: inbound cannot be defined for a gateway"))
+	})
+})
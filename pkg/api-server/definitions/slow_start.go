@@ -0,0 +1,17 @@
+package definitions
+
+import (
+	"github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/model"
+)
+
+var SlowStartWsDefinition = ResourceWsDefinition{
+	Name: "Slow Start",
+	Path: "slow-starts",
+	ResourceFactory: func() model.Resource {
+		return &mesh.SlowStartResource{}
+	},
+	ResourceListFactory: func() model.ResourceList {
+		return &mesh.SlowStartResourceList{}
+	},
+}
@@ -0,0 +1,58 @@
+package filters
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/emicklei/go-restful"
+	"github.com/go-logr/logr"
+
+	config "github.com/Kong/kuma/pkg/config/api-server"
+	"github.com/Kong/kuma/pkg/core"
+)
+
+const anonymous = "anonymous"
+
+var (
+	// overridable by unit tests
+	Sample = rand.Float64
+)
+
+// AccessLog returns a filter that logs requests to the API Server.
+//
+// Only a sample of requests is logged, except for requests that failed because of a server error.
+func AccessLog(cfg config.ApiServerAccessLogConfig, log logr.Logger) restful.FilterFunction {
+	return func(request *restful.Request, response *restful.Response, chain *restful.FilterChain) {
+		start := core.Now()
+		chain.ProcessFilter(request, response)
+		if response.StatusCode() < 500 && Sample() >= cfg.SampleRate {
+			return
+		}
+		log.Info("request",
+			"method", request.Request.Method,
+			"path", request.Request.URL.Path,
+			"status", response.StatusCode(),
+			"latency", core.Now().Sub(start).Round(time.Microsecond).String(),
+			"principal", principal(request),
+			"remoteAddr", request.Request.RemoteAddr,
+		)
+	}
+}
+
+// principal identifies a client by the SPIFFE ID of its TLS certificate.
+//
+// Certificates without a SPIFFE ID, e.g. the ones of operators, are identified by the subject name.
+func principal(request *restful.Request) string {
+	if tls := request.Request.TLS; tls != nil && len(tls.PeerCertificates) > 0 {
+		cert := tls.PeerCertificates[0]
+		for _, uri := range cert.URIs {
+			if uri.Scheme == "spiffe" {
+				return uri.String()
+			}
+		}
+		if name := cert.Subject.CommonName; name != "" {
+			return name
+		}
+	}
+	return anonymous
+}
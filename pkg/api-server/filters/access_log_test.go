@@ -0,0 +1,171 @@
+package filters_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"time"
+
+	"github.com/emicklei/go-restful"
+	"github.com/go-logr/logr"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kong/kuma/pkg/api-server/filters"
+	config "github.com/Kong/kuma/pkg/config/api-server"
+	"github.com/Kong/kuma/pkg/core"
+)
+
+var _ = Describe("AccessLog", func() {
+
+	var log *recordingLogger
+	var now time.Time
+	var sample float64
+	var backupSample func() float64
+
+	BeforeEach(func() {
+		backupSample = filters.Sample
+		log = &recordingLogger{}
+		now = time.Unix(1568000000, 0)
+		core.Now = func() time.Time {
+			return now
+		}
+		sample = 0.0
+		filters.Sample = func() float64 {
+			return sample
+		}
+	})
+
+	AfterEach(func() {
+		core.Now = time.Now
+		filters.Sample = backupSample
+	})
+
+	serve := func(cfg config.ApiServerAccessLogConfig, status int, path string, certs ...*x509.Certificate) {
+		ws := new(restful.WebService)
+		ws.Route(ws.GET("/meshes").To(func(request *restful.Request, response *restful.Response) {
+			now = now.Add(15 * time.Millisecond)
+			response.WriteHeader(status)
+		}))
+		container := restful.NewContainer()
+		container.Filter(filters.AccessLog(cfg, log))
+		container.Add(ws)
+
+		req := httptest.NewRequest("GET", path, nil)
+		if len(certs) > 0 {
+			req.TLS = &tls.ConnectionState{PeerCertificates: certs}
+		}
+		container.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	It("should log method, path, status, latency and principal of a request", func() {
+		// when
+		serve(config.ApiServerAccessLogConfig{Enabled: true, SampleRate: 1.0}, http.StatusOK, "/meshes")
+
+		// then
+		Expect(log.entries).To(HaveLen(1))
+		Expect(log.entries[0]).To(And(
+			HaveKeyWithValue("method", "GET"),
+			HaveKeyWithValue("path", "/meshes"),
+			HaveKeyWithValue("status", http.StatusOK),
+			HaveKeyWithValue("latency", "15ms"),
+			HaveKeyWithValue("principal", "anonymous"),
+		))
+	})
+
+	It("should identify a client by the SPIFFE ID of its certificate", func() {
+		// given
+		cert := &x509.Certificate{
+			Subject: pkix.Name{CommonName: "backend"},
+			URIs:    []*url.URL{{Scheme: "spiffe", Host: "default", Path: "/backend"}},
+		}
+
+		// when
+		serve(config.ApiServerAccessLogConfig{Enabled: true, SampleRate: 1.0}, http.StatusOK, "/meshes", cert)
+
+		// then
+		Expect(log.entries).To(HaveLen(1))
+		Expect(log.entries[0]).To(HaveKeyWithValue("principal", "spiffe://default/backend"))
+	})
+
+	It("should identify a client without a SPIFFE ID by the subject name of its certificate", func() {
+		// given
+		cert := &x509.Certificate{
+			Subject: pkix.Name{CommonName: "admin"},
+			URIs:    []*url.URL{{Scheme: "https", Host: "example.com"}},
+		}
+
+		// when
+		serve(config.ApiServerAccessLogConfig{Enabled: true, SampleRate: 1.0}, http.StatusOK, "/meshes", cert)
+
+		// then
+		Expect(log.entries).To(HaveLen(1))
+		Expect(log.entries[0]).To(HaveKeyWithValue("principal", "admin"))
+	})
+
+	It("should log only a sample of requests", func() {
+		// given
+		cfg := config.ApiServerAccessLogConfig{Enabled: true, SampleRate: 0.25}
+
+		// when
+		sample = 0.5
+		serve(cfg, http.StatusOK, "/meshes")
+
+		// then
+		Expect(log.entries).To(BeEmpty())
+
+		// when
+		sample = 0.1
+		serve(cfg, http.StatusOK, "/meshes")
+
+		// then
+		Expect(log.entries).To(HaveLen(1))
+	})
+
+	It("should always log requests that failed because of a server error", func() {
+		// when
+		sample = 0.5
+		serve(config.ApiServerAccessLogConfig{Enabled: true, SampleRate: 0.0}, http.StatusInternalServerError, "/meshes")
+
+		// then
+		Expect(log.entries).To(HaveLen(1))
+		Expect(log.entries[0]).To(HaveKeyWithValue("status", http.StatusInternalServerError))
+	})
+})
+
+type recordingLogger struct {
+	entries []map[string]interface{}
+}
+
+var _ logr.Logger = &recordingLogger{}
+
+func (l *recordingLogger) Info(_ string, keysAndValues ...interface{}) {
+	entry := map[string]interface{}{}
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		entry[keysAndValues[i].(string)] = keysAndValues[i+1]
+	}
+	l.entries = append(l.entries, entry)
+}
+
+func (l *recordingLogger) Enabled() bool {
+	return true
+}
+
+func (l *recordingLogger) Error(_ error, _ string, _ ...interface{}) {
+}
+
+func (l *recordingLogger) V(_ int) logr.InfoLogger {
+	return l
+}
+
+func (l *recordingLogger) WithName(_ string) logr.Logger {
+	return l
+}
+
+func (l *recordingLogger) WithValues(_ ...interface{}) logr.Logger {
+	return l
+}
@@ -0,0 +1,154 @@
+package filters
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/emicklei/go-restful"
+
+	"github.com/Kong/kuma/pkg/config"
+	"github.com/Kong/kuma/pkg/core"
+	core_errors "github.com/Kong/kuma/pkg/core/errors"
+	"github.com/Kong/kuma/pkg/core/resources/model/rest"
+)
+
+const bearerPrefix = "Bearer "
+
+// AdminToken returns a filter that only lets through requests that present a given token
+// as "Authorization: Bearer <token>".
+//
+// All requests are rejected if the token is empty, i.e. admin operations have not been enabled.
+func AdminToken(token config.Secret) restful.FilterFunction {
+	return func(request *restful.Request, response *restful.Response, chain *restful.FilterChain) {
+		if HasAdminToken(token, request, response) {
+			chain.ProcessFilter(request, response)
+		}
+	}
+}
+
+// HasAdminToken returns true if a request presents a given admin token, e.g. to authorize a part of a request
+// that the route is not guarded by AdminToken for. Otherwise, it writes an error response and returns false.
+func HasAdminToken(token config.Secret, request *restful.Request, response *restful.Response) bool {
+	expected, ok := tokenValue(token, response)
+	if !ok {
+		return false
+	}
+	if expected == "" {
+		writeError(response, 403, core_errors.AdminDisabled, "Admin operations are disabled, set apiServer.adminToken to enable them")
+		return false
+	}
+	if !hasBearerToken(request, expected) {
+		writeError(response, 401, core_errors.Unauthenticated, "Request has to present a valid admin token")
+		return false
+	}
+	return true
+}
+
+// WriteToken returns a filter that only lets through requests that present one of given tokens
+// as "Authorization: Bearer <token>", e.g. the write token or the admin token.
+//
+// All requests are let through if the write token is empty, i.e. authentication of changes has not been enabled.
+func WriteToken(writeToken config.Secret, otherTokens ...config.Secret) restful.FilterFunction {
+	return func(request *restful.Request, response *restful.Response, chain *restful.FilterChain) {
+		value, ok := tokenValue(writeToken, response)
+		if !ok {
+			return
+		}
+		if value == "" {
+			chain.ProcessFilter(request, response)
+			return
+		}
+		accepted := []string{value}
+		for _, token := range otherTokens {
+			value, ok := tokenValue(token, response)
+			if !ok {
+				return
+			}
+			accepted = append(accepted, value)
+		}
+		if !hasAnyBearerToken(request, accepted) {
+			writeError(response, 401, core_errors.Unauthenticated, "Request has to present a valid write token")
+			return
+		}
+		chain.ProcessFilter(request, response)
+	}
+}
+
+// ReadToken returns a filter that only lets through reads that present one of given tokens
+// as "Authorization: Bearer <token>". Other requests are authenticated by AdminToken and WriteToken filters.
+//
+// All requests are let through if the read token is empty, i.e. authentication of reads has not been enabled.
+func ReadToken(readToken config.Secret, otherTokens ...config.Secret) restful.FilterFunction {
+	return func(request *restful.Request, response *restful.Response, chain *restful.FilterChain) {
+		if !isRead(request) {
+			chain.ProcessFilter(request, response)
+			return
+		}
+		value, ok := tokenValue(readToken, response)
+		if !ok {
+			return
+		}
+		if value == "" {
+			// reads are not authenticated, so other tokens don't matter
+			chain.ProcessFilter(request, response)
+			return
+		}
+		accepted := []string{value}
+		for _, token := range otherTokens {
+			value, ok := tokenValue(token, response)
+			if !ok {
+				return
+			}
+			accepted = append(accepted, value)
+		}
+		if !hasAnyBearerToken(request, accepted) {
+			writeError(response, 401, core_errors.Unauthenticated, "Request has to present a valid read token")
+			return
+		}
+		chain.ProcessFilter(request, response)
+	}
+}
+
+// tokenValue returns the current value of a token, e.g. one that has been rotated in a file.
+// If the token cannot be read, requests are rejected rather than let through.
+func tokenValue(token config.Secret, response *restful.Response) (string, bool) {
+	value, err := token.Value()
+	if err != nil {
+		core.Log.Error(err, "Could not read a token of the API Server")
+		writeError(response, 500, core_errors.Internal, "Could not authenticate the request")
+		return "", false
+	}
+	return value, true
+}
+
+func isRead(request *restful.Request) bool {
+	return request.Request.Method == http.MethodGet || request.Request.Method == http.MethodHead
+}
+
+func hasAnyBearerToken(request *restful.Request, tokens []string) bool {
+	for _, token := range tokens {
+		if token != "" && hasBearerToken(request, token) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasBearerToken(request *restful.Request, token string) bool {
+	return HasBearerToken(request.Request, token)
+}
+
+// HasBearerToken returns true if a given request presents a given token as "Authorization: Bearer <token>".
+func HasBearerToken(request *http.Request, token string) bool {
+	header := request.Header.Get("Authorization")
+	return strings.HasPrefix(header, bearerPrefix) &&
+		subtle.ConstantTimeCompare([]byte(strings.TrimPrefix(header, bearerPrefix)), []byte(token)) == 1
+}
+
+func writeError(response *restful.Response, httpStatus int, code core_errors.Code, msg string) {
+	response.AddHeader(rest.ErrorCodeHeader, string(code))
+	if err := response.WriteErrorString(httpStatus, msg); err != nil {
+		core.Log.Error(err, "Could not write the response")
+	}
+}
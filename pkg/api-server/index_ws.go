@@ -16,6 +16,10 @@ func indexWs() *restful.WebService {
 		if err := resp.WriteAsJson(info); err != nil {
 			log.Error(err, "Could not write the index response")
 		}
-	}))
+	}).
+		Operation("index").
+		Doc("Get the version of Kuma Control Plane").
+		Produces(restful.MIME_JSON).
+		Returns(200, "OK", nil))
 	return ws
 }
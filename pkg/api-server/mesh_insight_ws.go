@@ -0,0 +1,183 @@
+package api_server
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"time"
+
+	"github.com/emicklei/go-restful"
+	"github.com/pkg/errors"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	"github.com/Kong/kuma/pkg/core"
+	builtin_ca "github.com/Kong/kuma/pkg/core/ca/builtin"
+	provided_ca "github.com/Kong/kuma/pkg/core/ca/provided"
+	core_errors "github.com/Kong/kuma/pkg/core/errors"
+	"github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/manager"
+	"github.com/Kong/kuma/pkg/core/resources/model"
+	"github.com/Kong/kuma/pkg/core/resources/registry"
+	"github.com/Kong/kuma/pkg/core/resources/store"
+)
+
+type meshInsightWs struct {
+	resManager        manager.ResourceManager
+	builtinCaManager  builtin_ca.BuiltinCaManager
+	providedCaManager provided_ca.ProvidedCaManager
+}
+
+type meshInsight struct {
+	Mesh       string                     `json:"mesh"`
+	Dataplanes dataplanesInsight          `json:"dataplanes"`
+	Policies   map[model.ResourceType]int `json:"policies"`
+	MTLS       mtlsInsight                `json:"mtls"`
+}
+
+type dataplanesInsight struct {
+	Total   int `json:"total"`
+	Online  int `json:"online"`
+	Offline int `json:"offline"`
+}
+
+type mtlsInsight struct {
+	Enabled bool `json:"enabled"`
+	// CA is a type of Certificate Authority of a Mesh, either "builtin" or "provided"
+	CA string `json:"ca,omitempty"`
+	// CAExpiresAt is when the earliest expiring root certificate of the CA expires
+	CAExpiresAt *time.Time `json:"caExpiresAt,omitempty"`
+}
+
+// policyLists returns empty lists of all types of policies that are counted in a Mesh insight.
+func policyLists() ([]model.ResourceList, error) {
+	var lists []model.ResourceList
+	for _, typ := range mesh.PolicyTypes() {
+		list, err := registry.Global().NewList(typ)
+		if err != nil {
+			return nil, err
+		}
+		lists = append(lists, list)
+	}
+	return lists, nil
+}
+
+func (r *meshInsightWs) AddToWs(ws *restful.WebService) {
+	ws.Route(ws.GET("/{mesh}/insight").To(r.inspectMesh).
+		Doc("Summarize a mesh").
+		Param(ws.PathParameter("mesh", "Name of a mesh").DataType("string")).
+		Returns(200, "OK", nil).
+		Returns(404, "Not found", nil))
+}
+
+func (r *meshInsightWs) inspectMesh(request *restful.Request, response *restful.Response) {
+	meshName := request.PathParameter("mesh")
+	insight, err := r.fetchInsight(request.Request.Context(), meshName)
+	if err != nil {
+		if store.IsResourceNotFound(err) {
+			writeError(response, 404, core_errors.ResourceNotFound, "")
+		} else {
+			core.Log.Error(err, "Could not retrieve a mesh insight", "mesh", meshName)
+			writeError(response, 500, core_errors.Internal, "Could not retrieve a mesh insight")
+		}
+		return
+	}
+	if err := response.WriteAsJson(insight); err != nil {
+		core.Log.Error(err, "Could not write the response")
+		writeError(response, 500, core_errors.Internal, "Could not write the response")
+	}
+}
+
+func (r *meshInsightWs) fetchInsight(ctx context.Context, meshName string) (*meshInsight, error) {
+	meshRes := mesh.MeshResource{}
+	if err := r.resManager.Get(ctx, &meshRes, store.GetByKey(namespace, meshName, meshName)); err != nil {
+		return nil, err
+	}
+	insight := &meshInsight{
+		Mesh:     meshName,
+		Policies: map[model.ResourceType]int{},
+	}
+
+	dataplanes := mesh.DataplaneResourceList{}
+	if err := r.resManager.List(ctx, &dataplanes, store.ListByMesh(meshName)); err != nil {
+		return nil, err
+	}
+	dataplaneInsights := mesh.DataplaneInsightResourceList{}
+	if err := r.resManager.List(ctx, &dataplaneInsights, store.ListByMesh(meshName)); err != nil {
+		return nil, err
+	}
+	for _, overview := range mesh.NewDataplaneOverviews(dataplanes, dataplaneInsights).Items {
+		insight.Dataplanes.Total++
+		if overview.Spec.DataplaneInsight.IsOnline() {
+			insight.Dataplanes.Online++
+		} else {
+			insight.Dataplanes.Offline++
+		}
+	}
+
+	lists, err := policyLists()
+	if err != nil {
+		return nil, err
+	}
+	for _, list := range lists {
+		if err := r.resManager.List(ctx, list, store.ListByMesh(meshName)); err != nil {
+			return nil, err
+		}
+		insight.Policies[list.GetItemType()] = len(list.GetItems())
+	}
+
+	mtls, err := r.mtlsInsight(ctx, &meshRes)
+	if err != nil {
+		return nil, err
+	}
+	insight.MTLS = mtls
+	return insight, nil
+}
+
+func (r *meshInsightWs) mtlsInsight(ctx context.Context, meshRes *mesh.MeshResource) (mtlsInsight, error) {
+	insight := mtlsInsight{
+		Enabled: meshRes.Spec.GetMtls().GetEnabled(),
+	}
+	var rootCerts [][]byte
+	switch ca := meshRes.Spec.GetMtls().GetCa().GetType().(type) {
+	case *mesh_proto.CertificateAuthority_Builtin_:
+		insight.CA = "builtin"
+		certs, err := r.builtinCaManager.GetRootCerts(ctx, meshRes.GetMeta().GetName())
+		if err != nil && !store.IsResourceNotFound(err) { // Builtin CA is only created once mTLS is enabled
+			return insight, err
+		}
+		rootCerts = certs
+	case *mesh_proto.CertificateAuthority_Provided_:
+		insight.CA = "provided"
+		certs, err := r.providedCaManager.GetRootCerts(ctx, ca.Provided)
+		if err != nil {
+			// a provided CA that cannot be loaded is not a reason to fail the whole insight
+			core.Log.Error(err, "Could not load a provided CA", "mesh", meshRes.GetMeta().GetName())
+		}
+		rootCerts = certs
+	}
+	expiresAt, err := earliestExpiry(rootCerts)
+	if err != nil {
+		return insight, err
+	}
+	insight.CAExpiresAt = expiresAt
+	return insight, nil
+}
+
+func earliestExpiry(certsPEM [][]byte) (*time.Time, error) {
+	var expiresAt *time.Time
+	for _, certPEM := range certsPEM {
+		block, _ := pem.Decode(certPEM)
+		if block == nil {
+			return nil, errors.New("root certificate of CA is not PEM-encoded")
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not parse root certificate of CA")
+		}
+		if expiresAt == nil || cert.NotAfter.Before(*expiresAt) {
+			notAfter := cert.NotAfter
+			expiresAt = &notAfter
+		}
+	}
+	return expiresAt, nil
+}
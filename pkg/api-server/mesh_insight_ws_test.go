@@ -0,0 +1,164 @@
+package api_server_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kong/kuma/api/mesh/v1alpha1"
+	"github.com/Kong/kuma/pkg/api-server"
+	config "github.com/Kong/kuma/pkg/config/api-server"
+	builtin_ca "github.com/Kong/kuma/pkg/core/ca/builtin"
+	builtin_issuer "github.com/Kong/kuma/pkg/core/ca/builtin/issuer"
+	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/store"
+	secret_cipher "github.com/Kong/kuma/pkg/core/secrets/cipher"
+	secret_manager "github.com/Kong/kuma/pkg/core/secrets/manager"
+	secret_store "github.com/Kong/kuma/pkg/core/secrets/store"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+	"github.com/Kong/kuma/pkg/util/proto"
+)
+
+var _ = Describe("Mesh Insight WS", func() {
+	var apiServer *api_server.ApiServer
+	var resourceStore store.ResourceStore
+	var stop chan struct{}
+	var baseUrl string
+
+	BeforeEach(func() {
+		resourceStore = memory.NewStore()
+		apiServer = createTestApiServer(resourceStore, *config.DefaultApiServerConfig())
+		client := resourceApiClient{
+			address: apiServer.Address(),
+			path:    "/meshes",
+		}
+		baseUrl = "http://" + apiServer.Address() + "/meshes"
+		stop = make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			err := apiServer.Start(stop)
+			Expect(err).ToNot(HaveOccurred())
+		}()
+		waitForServer(&client)
+	}, 5)
+
+	AfterEach(func() {
+		close(stop)
+	})
+
+	BeforeEach(func() {
+		// given a mesh with Builtin CA
+		meshResource := mesh_core.MeshResource{
+			Spec: v1alpha1.Mesh{
+				Mtls: &v1alpha1.Mesh_Mtls{
+					Enabled: true,
+					Ca: &v1alpha1.CertificateAuthority{
+						Type: &v1alpha1.CertificateAuthority_Builtin_{
+							Builtin: &v1alpha1.CertificateAuthority_Builtin{},
+						},
+					},
+				},
+			},
+		}
+		err := resourceStore.Create(context.Background(), &meshResource, store.CreateByKey("default", "mesh1", "mesh1"))
+		Expect(err).ToNot(HaveOccurred())
+		caManager := builtin_ca.NewBuiltinCaManager(secret_manager.NewSecretManager(secret_store.NewSecretStore(resourceStore), secret_cipher.None()))
+		Expect(caManager.Create(context.Background(), "mesh1")).To(Succeed())
+
+		// and a mesh without mTLS
+		err = resourceStore.Create(context.Background(), &mesh_core.MeshResource{}, store.CreateByKey("default", "mesh2", "mesh2"))
+		Expect(err).ToNot(HaveOccurred())
+
+		// and Dataplanes, one of them online
+		for _, name := range []string{"dp1", "dp2", "dp3"} {
+			err := resourceStore.Create(context.Background(), &mesh_core.DataplaneResource{}, store.CreateByKey("default", name, "mesh1"))
+			Expect(err).ToNot(HaveOccurred())
+		}
+		insightResource := mesh_core.DataplaneInsightResource{
+			Spec: v1alpha1.DataplaneInsight{
+				Subscriptions: []*v1alpha1.DiscoverySubscription{
+					{
+						Id:                     "stream-id-1",
+						ControlPlaneInstanceId: "cp-1",
+						ConnectTime:            proto.MustTimestampProto(time.Now()),
+					},
+				},
+			},
+		}
+		err = resourceStore.Create(context.Background(), &insightResource, store.CreateByKey("default", "dp1", "mesh1"))
+		Expect(err).ToNot(HaveOccurred())
+
+		// and policies
+		err = resourceStore.Create(context.Background(), &mesh_core.TrafficPermissionResource{}, store.CreateByKey("default", "tp1", "mesh1"))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	type insight struct {
+		Mesh       string `json:"mesh"`
+		Dataplanes struct {
+			Total   int `json:"total"`
+			Online  int `json:"online"`
+			Offline int `json:"offline"`
+		} `json:"dataplanes"`
+		Policies map[string]int `json:"policies"`
+		MTLS     struct {
+			Enabled     bool       `json:"enabled"`
+			CA          string     `json:"ca"`
+			CAExpiresAt *time.Time `json:"caExpiresAt"`
+		} `json:"mtls"`
+	}
+
+	getInsight := func(mesh string) (int, insight) {
+		response, err := http.Get(baseUrl + "/" + mesh + "/insight")
+		Expect(err).ToNot(HaveOccurred())
+		body, err := ioutil.ReadAll(response.Body)
+		Expect(err).ToNot(HaveOccurred())
+		result := insight{}
+		if response.StatusCode == 200 {
+			Expect(json.Unmarshal(body, &result)).To(Succeed())
+		}
+		return response.StatusCode, result
+	}
+
+	It("should summarize a mesh", func() {
+		// when
+		status, result := getInsight("mesh1")
+
+		// then
+		Expect(status).To(Equal(200))
+		Expect(result.Mesh).To(Equal("mesh1"))
+		Expect(result.Dataplanes.Total).To(Equal(3))
+		Expect(result.Dataplanes.Online).To(Equal(1))
+		Expect(result.Dataplanes.Offline).To(Equal(2))
+		Expect(result.Policies).To(Equal(map[string]int{"ProxyTemplate": 0, "SlowStart": 0, "TrafficPermission": 1}))
+		Expect(result.MTLS.Enabled).To(BeTrue())
+		Expect(result.MTLS.CA).To(Equal("builtin"))
+		Expect(result.MTLS.CAExpiresAt).ToNot(BeNil())
+		Expect(*result.MTLS.CAExpiresAt).To(BeTemporally("~", time.Now().Add(builtin_issuer.DefaultCACertValidityPeriod), time.Minute))
+	})
+
+	It("should summarize a mesh without a CA", func() {
+		// when
+		status, result := getInsight("mesh2")
+
+		// then
+		Expect(status).To(Equal(200))
+		Expect(result.Dataplanes.Total).To(Equal(0))
+		Expect(result.MTLS.Enabled).To(BeFalse())
+		Expect(result.MTLS.CA).To(BeEmpty())
+		Expect(result.MTLS.CAExpiresAt).To(BeNil())
+	})
+
+	It("should return 404 for a mesh that doesn't exist", func() {
+		// when
+		status, _ := getInsight("other")
+
+		// then
+		Expect(status).To(Equal(404))
+	})
+})
@@ -95,8 +95,8 @@ var _ = Describe("Resource WS", func() {
 			Expect(err).ToNot(HaveOccurred())
 
 			Expect(string(body)).To(Or(
-				MatchJSON(fmt.Sprintf(`{"items": [%s,%s]}`, json1, json2)),
-				MatchJSON(fmt.Sprintf(`{"items": [%s,%s]}`, json2, json1)),
+				MatchJSON(fmt.Sprintf(`{"total": 2, "items": [%s,%s]}`, json1, json2)),
+				MatchJSON(fmt.Sprintf(`{"total": 2, "items": [%s,%s]}`, json2, json1)),
 			))
 		})
 	})
@@ -0,0 +1,56 @@
+package api_server
+
+import (
+	"github.com/emicklei/go-restful"
+	restfulspec "github.com/emicklei/go-restful-openapi"
+	"github.com/go-openapi/spec"
+
+	"github.com/Kong/kuma/pkg/api-server/definitions"
+	config "github.com/Kong/kuma/pkg/config/api-server"
+	dataplane_managers "github.com/Kong/kuma/pkg/core/managers/apis/dataplane"
+	kuma_version "github.com/Kong/kuma/pkg/version"
+)
+
+const openApiPath = "/openapi.json"
+
+// OpenApiSpec describes all routes of API Server that is not read-only, e.g. to generate a client of it.
+func OpenApiSpec(defs []definitions.ResourceWsDefinition) *spec.Swagger {
+	apiServer := NewApiServer(nil, nil, nil, nil, nil, nil, nil, nil, dataplane_managers.NewRegistry(nil), defs, *config.DefaultApiServerConfig())
+	return apiServer.openApi
+}
+
+func buildOpenApiSpec(wss []*restful.WebService) *spec.Swagger {
+	return restfulspec.BuildSwagger(restfulspec.Config{
+		WebServices: wss,
+		APIPath:     openApiPath,
+		PostBuildSwaggerObjectHandler: func(swagger *spec.Swagger) {
+			// routes of the root path are described under an empty path, which is omitted from the specification
+			if item, ok := swagger.Paths.Paths[""]; ok {
+				delete(swagger.Paths.Paths, "")
+				swagger.Paths.Paths["/"] = item
+			}
+			swagger.Info = &spec.Info{
+				InfoProps: spec.InfoProps{
+					Title:       "Kuma API",
+					Description: "API of Kuma Control Plane",
+					Version:     kuma_version.Build.Version,
+				},
+			}
+		},
+	})
+}
+
+func openApiWs(openApi *spec.Swagger) *restful.WebService {
+	ws := new(restful.WebService)
+	ws.Path(openApiPath)
+	ws.Route(ws.GET("").To(func(req *restful.Request, resp *restful.Response) {
+		if err := resp.WriteAsJson(openApi); err != nil {
+			log.Error(err, "Could not write the OpenAPI specification")
+		}
+	}).
+		Operation("getOpenApiSpec").
+		Doc("Get the OpenAPI specification of all routes").
+		Produces(restful.MIME_JSON).
+		Returns(200, "OK", nil))
+	return ws
+}
@@ -0,0 +1,60 @@
+package api_server_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	api_server "github.com/Kong/kuma/pkg/config/api-server"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+)
+
+var _ = Describe("OpenAPI WS", func() {
+
+	It("should describe all routes", func(done Done) {
+		// setup
+		apiServer := createTestApiServer(memory.NewStore(), *api_server.DefaultApiServerConfig())
+
+		stop := make(chan struct{})
+		defer close(stop)
+		go func() {
+			defer GinkgoRecover()
+			err := apiServer.Start(stop)
+			Expect(err).ToNot(HaveOccurred())
+		}()
+
+		// wait for the server
+		Eventually(func() error {
+			_, err := http.Get("http://localhost" + apiServer.Address())
+			return err
+		}, "3s").ShouldNot(HaveOccurred())
+
+		// when
+		resp, err := http.Get("http://localhost" + apiServer.Address() + "/openapi.json")
+		Expect(err).ToNot(HaveOccurred())
+
+		// then
+		Expect(resp.StatusCode).To(Equal(200))
+		body, err := ioutil.ReadAll(resp.Body)
+		Expect(err).ToNot(HaveOccurred())
+
+		openApi := struct {
+			Swagger string                                       `json:"swagger"`
+			Paths   map[string]map[string]map[string]interface{} `json:"paths"`
+		}{}
+		Expect(json.Unmarshal(body, &openApi)).To(Succeed())
+		Expect(openApi.Swagger).To(Equal("2.0"))
+		Expect(openApi.Paths).To(HaveKey("/"))
+		Expect(openApi.Paths).To(HaveKey("/config-freeze"))
+		Expect(openApi.Paths).To(HaveKey("/meshes/{mesh}/insight"))
+		Expect(openApi.Paths["/meshes/{mesh}/dataplanes/{name}"]["get"]["operationId"]).To(Equal("getDataplane"))
+		Expect(openApi.Paths["/meshes/{mesh}/dataplanes/{name}"]["patch"]["operationId"]).To(Equal("patchDataplane"))
+		Expect(openApi.Paths["/meshes"]["get"]["operationId"]).To(Equal("listMeshes"))
+		Expect(openApi.Paths["/meshes/{name}"]["delete"]["operationId"]).To(Equal("deleteMesh"))
+
+		close(done)
+	}, 5)
+})
@@ -0,0 +1,126 @@
+package api_server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+
+	"github.com/emicklei/go-restful"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	"github.com/Kong/kuma/pkg/core"
+	core_errors "github.com/Kong/kuma/pkg/core/errors"
+	"github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/manager"
+	"github.com/Kong/kuma/pkg/core/resources/store"
+)
+
+type prometheusWs struct {
+	resManager manager.ResourceManager
+}
+
+// prometheusConfig is a fragment of Prometheus configuration,
+// see https://prometheus.io/docs/prometheus/latest/configuration/configuration/#scrape_config
+type prometheusConfig struct {
+	ScrapeConfigs []prometheusScrapeConfig `json:"scrape_configs"`
+}
+
+type prometheusScrapeConfig struct {
+	JobName       string                   `json:"job_name"`
+	MetricsPath   string                   `json:"metrics_path"`
+	StaticConfigs []prometheusStaticConfig `json:"static_configs"`
+}
+
+type prometheusStaticConfig struct {
+	Targets []string          `json:"targets"`
+	Labels  map[string]string `json:"labels"`
+}
+
+func (p *prometheusWs) AddToWs(ws *restful.WebService) {
+	ws.Route(ws.GET("/{mesh}/prometheus/scrape-configs").To(p.scrapeConfigs).
+		Operation("getPrometheusScrapeConfigs").
+		Doc("Render Prometheus scrape configs of all dataplanes in a mesh").
+		Param(ws.PathParameter("mesh", "Name of a mesh").DataType("string")).
+		Returns(200, "OK", nil).
+		Returns(404, "Not found", nil))
+}
+
+func (p *prometheusWs) scrapeConfigs(request *restful.Request, response *restful.Response) {
+	meshName := request.PathParameter("mesh")
+	config, err := p.buildConfig(request.Request.Context(), meshName)
+	if err != nil {
+		if store.IsResourceNotFound(err) {
+			writeError(response, 404, core_errors.ResourceNotFound, "")
+		} else {
+			core.Log.Error(err, "Could not render Prometheus scrape configs", "mesh", meshName)
+			writeError(response, 500, core_errors.Internal, "Could not render Prometheus scrape configs")
+		}
+		return
+	}
+	if err := response.WriteAsJson(config); err != nil {
+		core.Log.Error(err, "Could not write the response")
+		writeError(response, 500, core_errors.Internal, "Could not write the response")
+	}
+}
+
+// buildConfig returns a single scrape config with a target per dataplane of a given mesh
+// or no scrape configs at all if dataplanes of the mesh don't expose metrics to Prometheus.
+func (p *prometheusWs) buildConfig(ctx context.Context, meshName string) (*prometheusConfig, error) {
+	meshRes := mesh.MeshResource{}
+	if err := p.resManager.Get(ctx, &meshRes, store.GetByKey(namespace, meshName, meshName)); err != nil {
+		return nil, err
+	}
+	config := &prometheusConfig{ScrapeConfigs: []prometheusScrapeConfig{}}
+	prometheus := meshRes.Spec.GetMetrics().GetPrometheus()
+	if prometheus == nil {
+		return config, nil
+	}
+
+	dataplanes := mesh.DataplaneResourceList{}
+	if err := p.resManager.List(ctx, &dataplanes, store.ListByMesh(meshName)); err != nil {
+		return nil, err
+	}
+	sort.Slice(dataplanes.Items, func(i, j int) bool {
+		return dataplanes.Items[i].Meta.GetName() < dataplanes.Items[j].Meta.GetName()
+	})
+	port := strconv.Itoa(int(prometheus.GetPortOrDefault()))
+	scrapeConfig := prometheusScrapeConfig{
+		JobName:       fmt.Sprintf("kuma-dataplanes-%s", meshName),
+		MetricsPath:   prometheus.GetPathOrDefault(),
+		StaticConfigs: []prometheusStaticConfig{},
+	}
+	for _, dataplane := range dataplanes.Items {
+		// metrics are exposed on the bind address of the first inbound interface, see PrometheusEndpointGenerator.
+		// Gateways expose metrics too, but they don't declare an IP address Prometheus could reach them at.
+		inbounds, err := dataplane.Spec.GetNetworking().GetInboundInterfaces()
+		if err != nil || len(inbounds) == 0 {
+			continue
+		}
+		labels := map[string]string{
+			"mesh":      meshName,
+			"dataplane": dataplane.Meta.GetName(),
+		}
+		if services := dataplane.Spec.Tags().Values(mesh_proto.ServiceTag); len(services) > 0 {
+			labels["service"] = services[0]
+		}
+		scrapeConfig.StaticConfigs = append(scrapeConfig.StaticConfigs, prometheusStaticConfig{
+			Targets: []string{net.JoinHostPort(scrapeAddress(inbounds[0]), port)},
+			Labels:  labels,
+		})
+	}
+	config.ScrapeConfigs = append(config.ScrapeConfigs, scrapeConfig)
+	return config, nil
+}
+
+// scrapeAddress returns an IP address Prometheus can reach the metrics endpoint of an inbound interface at.
+//
+// The endpoint listens on the bind address, which is unreachable if it is a wildcard one, e.g. 0.0.0.0,
+// in which case the advertised address is used instead.
+func scrapeAddress(iface mesh_proto.InboundInterface) string {
+	if ip := net.ParseIP(iface.BindIP); ip != nil && ip.IsUnspecified() {
+		return iface.AdvertisedIP
+	}
+	return iface.BindIP
+}
@@ -0,0 +1,152 @@
+package api_server_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kong/kuma/api/mesh/v1alpha1"
+	"github.com/Kong/kuma/pkg/api-server"
+	config "github.com/Kong/kuma/pkg/config/api-server"
+	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/store"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+)
+
+var _ = Describe("Prometheus WS", func() {
+	var apiServer *api_server.ApiServer
+	var resourceStore store.ResourceStore
+	var stop chan struct{}
+
+	BeforeEach(func() {
+		resourceStore = memory.NewStore()
+		apiServer = createTestApiServer(resourceStore, *config.DefaultApiServerConfig())
+		client := resourceApiClient{
+			address: apiServer.Address(),
+			path:    "/meshes",
+		}
+		stop = make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			err := apiServer.Start(stop)
+			Expect(err).ToNot(HaveOccurred())
+		}()
+		waitForServer(&client)
+	}, 5)
+
+	AfterEach(func() {
+		close(stop)
+	})
+
+	createDataplaneWithAddresses := func(name string, inbound string, bindAddress string, advertisedAddress string) {
+		dataplane := mesh_core.DataplaneResource{
+			Spec: v1alpha1.Dataplane{
+				Networking: &v1alpha1.Dataplane_Networking{
+					Inbound: []*v1alpha1.Dataplane_Networking_Inbound{
+						{
+							Interface:         inbound,
+							BindAddress:       bindAddress,
+							AdvertisedAddress: advertisedAddress,
+							Tags: map[string]string{
+								"service": "backend",
+							},
+						},
+					},
+				},
+			},
+		}
+		err := resourceStore.Create(context.Background(), &dataplane, store.CreateByKey("default", name, "mesh1"))
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	createDataplane := func(name string, inbound string) {
+		createDataplaneWithAddresses(name, inbound, "", "")
+	}
+
+	get := func(url string) (int, string) {
+		response, err := http.Get("http://" + apiServer.Address() + url)
+		Expect(err).ToNot(HaveOccurred())
+		body, err := ioutil.ReadAll(response.Body)
+		Expect(err).ToNot(HaveOccurred())
+		return response.StatusCode, string(body)
+	}
+
+	It("should render a target per dataplane", func() {
+		// given
+		meshRes := mesh_core.MeshResource{
+			Spec: v1alpha1.Mesh{
+				Metrics: &v1alpha1.Metrics{
+					Prometheus: &v1alpha1.Metrics_Prometheus{
+						Port: 1234,
+					},
+				},
+			},
+		}
+		err := resourceStore.Create(context.Background(), &meshRes, store.CreateByKey("default", "mesh1", "mesh1"))
+		Expect(err).ToNot(HaveOccurred())
+		// and
+		createDataplane("dp2", "192.168.0.2:80:8080")
+		createDataplane("dp1", "192.168.0.1:80:8080")
+		createDataplaneWithAddresses("dp3", "192.168.0.3:80:8080", "10.0.0.3", "")
+		createDataplaneWithAddresses("dp4", "192.168.0.4:80:8080", "0.0.0.0", "203.0.113.4")
+
+		// when
+		status, body := get("/meshes/mesh1/prometheus/scrape-configs")
+
+		// then
+		Expect(status).To(Equal(200))
+		Expect(body).To(MatchJSON(`
+		{
+			"scrape_configs": [
+				{
+					"job_name": "kuma-dataplanes-mesh1",
+					"metrics_path": "/metrics",
+					"static_configs": [
+						{
+							"targets": ["192.168.0.1:1234"],
+							"labels": {"mesh": "mesh1", "dataplane": "dp1", "service": "backend"}
+						},
+						{
+							"targets": ["192.168.0.2:1234"],
+							"labels": {"mesh": "mesh1", "dataplane": "dp2", "service": "backend"}
+						},
+						{
+							"targets": ["10.0.0.3:1234"],
+							"labels": {"mesh": "mesh1", "dataplane": "dp3", "service": "backend"}
+						},
+						{
+							"targets": ["203.0.113.4:1234"],
+							"labels": {"mesh": "mesh1", "dataplane": "dp4", "service": "backend"}
+						}
+					]
+				}
+			]
+		}`))
+	})
+
+	It("should render no scrape configs when Prometheus is not enabled in the mesh", func() {
+		// given
+		err := resourceStore.Create(context.Background(), &mesh_core.MeshResource{}, store.CreateByKey("default", "mesh1", "mesh1"))
+		Expect(err).ToNot(HaveOccurred())
+		// and
+		createDataplane("dp1", "192.168.0.1:80:8080")
+
+		// when
+		status, body := get("/meshes/mesh1/prometheus/scrape-configs")
+
+		// then
+		Expect(status).To(Equal(200))
+		Expect(body).To(MatchJSON(`{"scrape_configs": []}`))
+	})
+
+	It("should return 404 for a mesh that doesn't exist", func() {
+		// when
+		status, _ := get("/meshes/mesh1/prometheus/scrape-configs")
+
+		// then
+		Expect(status).To(Equal(404))
+	})
+})
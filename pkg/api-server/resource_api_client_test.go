@@ -6,11 +6,22 @@ import (
 	"github.com/Kong/kuma/pkg/api-server"
 	"github.com/Kong/kuma/pkg/api-server/definitions"
 	config "github.com/Kong/kuma/pkg/config/api-server"
+	builtin_ca "github.com/Kong/kuma/pkg/core/ca/builtin"
+	builtin_issuer "github.com/Kong/kuma/pkg/core/ca/builtin/issuer"
+	provided_ca "github.com/Kong/kuma/pkg/core/ca/provided"
+	dataplane_managers "github.com/Kong/kuma/pkg/core/managers/apis/dataplane"
+	freeze_managers "github.com/Kong/kuma/pkg/core/managers/freeze"
+	validation_managers "github.com/Kong/kuma/pkg/core/managers/validation"
 	"github.com/Kong/kuma/pkg/core/resources/manager"
 	"github.com/Kong/kuma/pkg/core/resources/store"
+	secret_cipher "github.com/Kong/kuma/pkg/core/secrets/cipher"
+	secret_manager "github.com/Kong/kuma/pkg/core/secrets/manager"
+	secret_store "github.com/Kong/kuma/pkg/core/secrets/store"
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
 	"github.com/Kong/kuma/pkg/test"
 	sample_proto "github.com/Kong/kuma/pkg/test/apis/sample/v1alpha1"
 	sample_model "github.com/Kong/kuma/pkg/test/resources/apis/sample"
+	"github.com/Kong/kuma/pkg/tokens/builtin/issuer"
 
 	. "github.com/onsi/gomega"
 	"net/http"
@@ -74,6 +85,42 @@ func (r *resourceApiClient) putJson(name string, json []byte) *http.Response {
 	return response
 }
 
+func (r *resourceApiClient) patchJson(name string, json []byte) *http.Response {
+	request, err := http.NewRequest(
+		"PATCH",
+		r.fullAddress()+"/"+name,
+		bytes.NewBuffer(json),
+	)
+	Expect(err).ToNot(HaveOccurred())
+	request.Header.Add("content-type", "application/merge-patch+json")
+	response, err := http.DefaultClient.Do(request)
+	Expect(err).ToNot(HaveOccurred())
+	return response
+}
+
+func (r *resourceApiClient) jsonPatch(name string, patch []byte) *http.Response {
+	return r.do("PATCH", name, "application/json-patch+json", patch, nil)
+}
+
+// do sends a request to a resource of a given name, or to the list of resources if the name is empty.
+func (r *resourceApiClient) do(method string, name string, contentType string, body []byte, header http.Header) *http.Response {
+	address := r.fullAddress()
+	if name != "" {
+		address += "/" + name
+	}
+	request, err := http.NewRequest(method, address, bytes.NewBuffer(body))
+	Expect(err).ToNot(HaveOccurred())
+	for key, values := range header {
+		request.Header[key] = values
+	}
+	if contentType != "" {
+		request.Header.Set("content-type", contentType)
+	}
+	response, err := http.DefaultClient.Do(request)
+	Expect(err).ToNot(HaveOccurred())
+	return response
+}
+
 func waitForServer(client *resourceApiClient) {
 	Eventually(func() bool {
 		response, err := client.listOrError()
@@ -92,6 +139,26 @@ func putSampleResourceIntoStore(resourceStore store.ResourceStore, name string,
 }
 
 func createTestApiServer(store store.ResourceStore, config config.ApiServerConfig) *api_server.ApiServer {
+	return createTestApiServerWithConfigHistory(store, core_xds.NewConfigHistory(10), config)
+}
+
+func createTestApiServerWithConfigHistory(store store.ResourceStore, configHistory core_xds.ConfigHistory, config config.ApiServerConfig) *api_server.ApiServer {
+	return createTestApiServerWithConfigFreeze(store, configHistory, newConfigFreeze(store), config)
+}
+
+func newConfigFreeze(store store.ResourceStore) freeze_managers.ConfigFreeze {
+	return freeze_managers.NewConfigFreeze(secret_manager.NewSecretManager(secret_store.NewSecretStore(store), secret_cipher.None()))
+}
+
+func createTestApiServerWithConfigFreeze(store store.ResourceStore, configHistory core_xds.ConfigHistory, configFreeze freeze_managers.ConfigFreeze, config config.ApiServerConfig) *api_server.ApiServer {
+	return createTestApiServerWithStreamTracker(store, configHistory, configFreeze, core_xds.NewStreamTracker(), config)
+}
+
+func createTestApiServerWithStreamTracker(store store.ResourceStore, configHistory core_xds.ConfigHistory, configFreeze freeze_managers.ConfigFreeze, streamTracker core_xds.StreamTracker, config config.ApiServerConfig) *api_server.ApiServer {
+	return createTestApiServerWithRegistration(store, configHistory, configFreeze, streamTracker, true, config)
+}
+
+func createTestApiServerWithRegistration(store store.ResourceStore, configHistory core_xds.ConfigHistory, configFreeze freeze_managers.ConfigFreeze, streamTracker core_xds.StreamTracker, registration bool, config config.ApiServerConfig) *api_server.ApiServer {
 	// we have to manually search for port and put it into config. There is no way to retrieve port of running
 	// http.Server and we need it later for the client
 	port, err := test.GetFreePort()
@@ -100,7 +167,17 @@ func createTestApiServer(store store.ResourceStore, config config.ApiServerConfi
 	defs := []definitions.ResourceWsDefinition{
 		TrafficRouteWsDefinition,
 		definitions.MeshWsDefinition,
+		definitions.DataplaneWsDefinition,
+	}
+	resources := freeze_managers.NewConfigFreezeManager(manager.NewResourceManager(store), configFreeze)
+	if len(config.ValidationWebhooks) > 0 {
+		resources = validation_managers.NewWebhookValidationManager(resources, config.ValidationWebhooks, http.DefaultTransport)
+	}
+	keyManager := issuer.NewSigningKeyManager(secret_manager.NewSecretManager(secret_store.NewSecretStore(store), secret_cipher.None()))
+	caManager := builtin_ca.NewBuiltinCaManager(secret_manager.NewSecretManager(secret_store.NewSecretStore(store), secret_cipher.None()))
+	var registry *dataplane_managers.Registry
+	if registration {
+		registry = dataplane_managers.NewRegistry(resources)
 	}
-	resources := manager.NewResourceManager(store)
-	return api_server.NewApiServer(resources, defs, config)
+	return api_server.NewApiServer(resources, keyManager, issuer.NewDataplaneTokenIssuer(keyManager), caManager, provided_ca.NewProvidedCaManager(nil, nil, builtin_issuer.DefaultWorkloadCertValidityPeriod, builtin_issuer.DefaultAllowedClockSkew), configHistory, configFreeze, streamTracker, registry, defs, config)
 }
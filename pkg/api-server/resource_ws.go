@@ -2,23 +2,56 @@ package api_server
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
 	"github.com/Kong/kuma/pkg/api-server/definitions"
+	"github.com/Kong/kuma/pkg/api-server/filters"
+	"github.com/Kong/kuma/pkg/config"
 	"github.com/Kong/kuma/pkg/core"
+	core_errors "github.com/Kong/kuma/pkg/core/errors"
+	dataplane_managers "github.com/Kong/kuma/pkg/core/managers/apis/dataplane"
+	mesh_managers "github.com/Kong/kuma/pkg/core/managers/apis/mesh"
+	constraint_managers "github.com/Kong/kuma/pkg/core/managers/constraint"
+	freeze_managers "github.com/Kong/kuma/pkg/core/managers/freeze"
+	quota_managers "github.com/Kong/kuma/pkg/core/managers/quota"
+	validation_managers "github.com/Kong/kuma/pkg/core/managers/validation"
 	"github.com/Kong/kuma/pkg/core/resources/apis/mesh"
 	"github.com/Kong/kuma/pkg/core/resources/manager"
 	"github.com/Kong/kuma/pkg/core/resources/model"
 	"github.com/Kong/kuma/pkg/core/resources/model/rest"
 	"github.com/Kong/kuma/pkg/core/resources/store"
 	"github.com/emicklei/go-restful"
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/gogo/protobuf/proto"
 	"github.com/pkg/errors"
+	"io/ioutil"
+	"mime"
+	"net/url"
+	"strconv"
+	"strings"
 )
 
 const namespace = "default"
 
+// reservedLabelPrefix is a prefix of labels that only Kuma itself can set.
+const reservedLabelPrefix = "kuma.io/"
+
+const (
+	mimeMergePatchJson = "application/merge-patch+json"
+	mimeJsonPatch      = "application/json-patch+json"
+	// maxPatchAttempts limits how many times a patch is re-applied when a resource is being modified concurrently.
+	maxPatchAttempts = 5
+
+	etagHeader    = "ETag"
+	ifMatchHeader = "If-Match"
+)
+
 type resourceWs struct {
 	resManager      manager.ResourceManager
 	readOnly        bool
+	writeToken      config.Secret
+	adminToken      config.Secret
 	nameFromRequest func(*restful.Request) string
 	meshFromRequest func(*restful.Request) string
 	definitions.ResourceWsDefinition
@@ -43,31 +76,93 @@ func (r *resourceWs) AddToWs(ws *restful.WebService) {
 		}
 	}
 
-	ws.Route(ws.GET(pathPrefix+"/{name}").To(r.findResource).
+	typeName := string(r.ResourceFactory().GetType())
+
+	r.route(ws, ws.GET(pathPrefix+"/{name}").To(r.findResource).
+		Operation("get"+typeName).
 		Doc(fmt.Sprintf("Get a %s", r.Name)).
 		Param(ws.PathParameter("name", fmt.Sprintf("Name of a %s", r.Name)).DataType("string")).
+		Produces(restful.MIME_JSON, mimeYaml).
 		//Writes(r.SpecFactory()).
-		Returns(200, "OK", nil). // todo(jakubdyszkiewicz) figure out how to expose the doc for ResourceReqResp
+		Returns(200, "OK, ETag header contains the version of the resource", nil). // todo(jakubdyszkiewicz) figure out how to expose the doc for ResourceReqResp
 		Returns(404, "Not found", nil))
 
-	ws.Route(ws.GET(pathPrefix).To(r.listResources).
+	r.route(ws, ws.GET(pathPrefix).To(r.listResources).
+		Operation("list"+plural(typeName)).
 		Doc(fmt.Sprintf("List of %s", r.Name)).
+		Param(ws.QueryParameter(rest.LabelsQueryParam, "Only resources with all of these labels, e.g. team=payments,env=prod").DataType("string")).
+		Param(ws.QueryParameter("tag", "Only resources with all of these tags in key:value format, e.g. ?tag=service:web&tag=version:v1").DataType("string")).
+		Param(ws.QueryParameter(rest.NamePrefixQueryParam, "Only resources with names that start with this prefix").DataType("string")).
+		Param(ws.QueryParameter(rest.SizeQueryParam, "Maximum number of resources on a page").DataType("integer")).
+		Param(ws.QueryParameter(rest.OffsetQueryParam, "Offset of a page, taken from the link to the next page").DataType("string")).
+		Produces(restful.MIME_JSON, mimeYaml).
 		//Writes(r.SampleListSpec).
 		Returns(200, "OK", nil)) // todo(jakubdyszkiewicz) figure out how to expose the doc for ResourceReqResp
 
 	if !r.readOnly {
-		ws.Route(ws.PUT(pathPrefix+"/{name}").To(r.createOrUpdateResource).
+		r.route(ws, ws.POST(pathPrefix).To(r.createNewResource).
+			Operation("create"+typeName).
+			Filter(filters.WriteToken(r.writeToken, r.adminToken)).
+			Doc(fmt.Sprintf("Creates a %s named in the body", r.Name)).
+			Consumes(restful.MIME_JSON, mimeYaml).
+			Returns(201, "Created", nil).
+			Returns(401, "Unauthorized", nil).
+			Returns(409, "Conflict", nil))
+
+		r.route(ws, ws.PUT(pathPrefix+"/{name}").To(r.createOrUpdateResource).
+			Operation("createOrUpdate"+typeName).
+			Filter(filters.WriteToken(r.writeToken, r.adminToken)).
 			Doc(fmt.Sprintf("Updates a %s", r.Name)).
 			Param(ws.PathParameter("name", fmt.Sprintf("Name of the %s", r.Name)).DataType("string")).
+			Param(ws.HeaderParameter("If-Match", "If *, then only an existing resource is updated. If ETags, then only a resource of one of these versions is updated").DataType("string")).
+			Param(ws.HeaderParameter("If-None-Match", "If *, then only a new resource is created").DataType("string")).
+			Consumes(restful.MIME_JSON, mimeYaml).
 			//Reads(r.SampleSpec). // todo(jakubdyszkiewicz) figure out how to expose the doc for ResourceReqResp
 			Returns(200, "OK", nil).
-			Returns(201, "Created", nil))
+			Returns(201, "Created", nil).
+			Returns(401, "Unauthorized", nil).
+			Returns(409, "Conflict", nil).
+			Returns(412, "Precondition failed", nil))
+
+		r.route(ws, ws.PATCH(pathPrefix+"/{name}").To(r.patchResource).
+			Operation("patch"+typeName).
+			Filter(filters.WriteToken(r.writeToken, r.adminToken)).
+			Doc(fmt.Sprintf("Updates a part of a %s using JSON Merge Patch (RFC 7386) or, if sent as %s, JSON Patch (RFC 6902). JSON Merge Patch replaces arrays, e.g. inbound interfaces of a Dataplane, as a whole, while JSON Patch addresses their elements, e.g. a single tag of an inbound interface", r.Name, mimeJsonPatch)).
+			Param(ws.PathParameter("name", fmt.Sprintf("Name of the %s", r.Name)).DataType("string")).
+			Consumes(mimeMergePatchJson, restful.MIME_JSON, mimeJsonPatch).
+			Returns(200, "OK", nil).
+			Returns(401, "Unauthorized", nil).
+			Returns(404, "Not found", nil).
+			Returns(409, "Conflict", nil))
 
-		ws.Route(ws.DELETE(pathPrefix+"/{name}").To(r.deleteResource).
+		r.route(ws, ws.DELETE(pathPrefix+"/{name}").To(r.deleteResource).
+			Operation("delete"+typeName).
+			Filter(filters.WriteToken(r.writeToken, r.adminToken)).
 			Doc(fmt.Sprintf("Deletes a %s", r.Name)).
 			Param(ws.PathParameter("name", fmt.Sprintf("Name of a %s", r.Name)).DataType("string")).
-			Returns(200, "OK", nil))
+			Param(ws.QueryParameter("force", "Delete resources that depend on the deleted one as well").DataType("boolean")).
+			Param(ws.HeaderParameter("If-Match", "If *, then only an existing resource is deleted. If ETags, then only a resource of one of these versions is deleted").DataType("string")).
+			Returns(200, "OK", nil).
+			Returns(401, "Unauthorized", nil).
+			Returns(409, "Conflict", nil).
+			Returns(412, "Precondition failed", nil))
+	}
+}
+
+// route adds a route to a web service, documenting the mesh in its path unless the resource is a Mesh itself.
+func (r *resourceWs) route(ws *restful.WebService, builder *restful.RouteBuilder) {
+	if r.ResourceFactory().GetType() != mesh.MeshType {
+		builder.Param(ws.PathParameter("mesh", "Name of a mesh").DataType("string"))
 	}
+	ws.Route(builder)
+}
+
+// plural returns a plural form of a resource type, e.g. to name an operation that lists resources.
+func plural(typeName string) string {
+	if strings.HasSuffix(typeName, "s") || strings.HasSuffix(typeName, "sh") {
+		return typeName + "es"
+	}
+	return typeName + "s"
 }
 
 func (r *resourceWs) findResource(request *restful.Request, response *restful.Response) {
@@ -78,14 +173,15 @@ func (r *resourceWs) findResource(request *restful.Request, response *restful.Re
 	err := r.resManager.Get(request.Request.Context(), resource, store.GetByKey(namespace, name, meshName))
 	if err != nil {
 		if err.Error() == store.ErrorResourceNotFound(resource.GetType(), namespace, name, meshName).Error() {
-			writeError(response, 404, "")
+			writeError(response, 404, core_errors.ResourceNotFound, "")
 		} else {
 			core.Log.Error(err, "Could not retrieve a resource", "name", name)
-			writeError(response, 500, "Could not retrieve a resource")
+			writeError(response, 500, core_errors.Internal, "Could not retrieve a resource")
 		}
 	} else {
 		res := rest.From.Resource(resource)
-		if err := response.WriteAsJson(res); err != nil {
+		response.AddHeader(etagHeader, etag(resource))
+		if err := response.WriteEntity(res); err != nil {
 			core.Log.Error(err, "Could not write the response")
 		}
 	}
@@ -93,16 +189,41 @@ func (r *resourceWs) findResource(request *restful.Request, response *restful.Re
 
 func (r *resourceWs) listResources(request *restful.Request, response *restful.Response) {
 	meshName := r.meshFromRequest(request)
+	labels, err := rest.ParseLabels(request.QueryParameter(rest.LabelsQueryParam))
+	if err != nil {
+		writeError(response, 400, core_errors.InvalidRequest, err.Error())
+		return
+	}
+	tags := parseTags(request.QueryParameters("tag"))
+	size, err := rest.ParseSize(request.QueryParameter(rest.SizeQueryParam))
+	if err != nil {
+		writeError(response, 400, core_errors.InvalidRequest, err.Error())
+		return
+	}
 
 	list := r.ResourceListFactory()
-	if err := r.resManager.List(request.Request.Context(), list, store.ListByMesh(meshName)); err != nil {
+	if err := r.resManager.List(request.Request.Context(), list,
+		store.ListByMesh(meshName),
+		store.ListByLabels(labels),
+		store.ListByTags(tags),
+		store.ListByNamePrefix(request.QueryParameter(rest.NamePrefixQueryParam)),
+		store.ListByPage(size, request.QueryParameter(rest.OffsetQueryParam)),
+	); err != nil {
+		if store.IsInvalidOffset(err) {
+			writeError(response, 400, core_errors.InvalidRequest, err.Error())
+			return
+		}
 		core.Log.Error(err, "Could not retrieve resources")
-		writeError(response, 500, "Could not list a resource")
+		writeError(response, 500, core_errors.Internal, "Could not list a resource")
 	} else {
 		restList := rest.From.ResourceList(list)
-		if err := response.WriteAsJson(restList); err != nil {
+		if nextOffset := list.GetPagination().NextOffset; nextOffset != "" {
+			next := rest.NextPage(requestURL(request), size, nextOffset)
+			restList.Next = &next
+		}
+		if err := response.WriteEntity(restList); err != nil {
 			core.Log.Error(err, "Could not write as JSON", "type", string(list.GetItemType()))
-			writeError(response, 500, "Could not list a resource")
+			writeError(response, 500, core_errors.Internal, "Could not list a resource")
 		}
 	}
 }
@@ -118,30 +239,101 @@ func (r *resourceWs) createOrUpdateResource(request *restful.Request, response *
 	err := request.ReadEntity(&resourceRes)
 	if err != nil {
 		core.Log.Error(err, "Could not read an entity")
-		writeError(response, 400, "Could not process the resource")
+		writeError(response, 400, core_errors.InvalidRequest, "Could not process the resource")
 		return
 	}
 
-	if err := r.validateResourceRequest(request, &resourceRes); err != nil {
-		writeError(response, 400, err.Error())
+	if err := r.validateResource(name, meshName, &resourceRes); err != nil {
+		writeError(response, 400, core_errors.InvalidResource, err.Error())
 	} else {
 		resource := r.ResourceFactory()
 		if err := r.resManager.Get(request.Request.Context(), resource, store.GetByKey(namespace, name, meshName)); err != nil {
 			if store.IsResourceNotFound(err) {
-				r.createResource(request.Request.Context(), name, meshName, resourceRes.Spec, response)
+				if request.HeaderParameter(ifMatchHeader) != "" {
+					writeError(response, 412, core_errors.PreconditionFailed, "Resource does not exist")
+					return
+				}
+				if !r.authorizeConstraints(request, response, nil, resourceRes.Spec) {
+					return
+				}
+				r.createResource(request.Request.Context(), name, meshName, resourceRes, response)
 			} else {
 				core.Log.Error(err, "Could get a resource from the store", "namespace", namespace, "name", name, "type", string(resource.GetType()))
-				writeError(response, 500, "Could not create a resource")
+				writeError(response, 500, core_errors.Internal, "Could not create a resource")
 			}
 		} else {
-			r.updateResource(request.Request.Context(), resource, resourceRes, response)
+			if request.HeaderParameter("If-None-Match") == "*" {
+				writeError(response, 412, core_errors.PreconditionFailed, "Resource already exists")
+				return
+			}
+			if !ifMatches(request, resource) {
+				writeError(response, 412, core_errors.PreconditionFailed, "Resource has been modified")
+				return
+			}
+			if !r.authorizeConstraints(request, response, resource.GetSpec(), resourceRes.Spec) {
+				return
+			}
+			// the store rejects the update if the resource is modified after it has been matched
+			r.updateResource(request.Request.Context(), resource, resourceRes, request.HeaderParameter(ifMatchHeader) != "", response)
 		}
 	}
 }
 
-func (r *resourceWs) validateResourceRequest(request *restful.Request, resource *rest.Resource) error {
-	name := r.nameFromRequest(request)
-	meshName := r.meshFromRequest(request)
+// createNewResource creates a resource named in the body. Unlike PUT, it never replaces an existing resource.
+func (r *resourceWs) createNewResource(request *restful.Request, response *restful.Response) {
+	resourceRes := rest.Resource{
+		Spec: r.ResourceFactory().GetSpec(),
+	}
+
+	if err := request.ReadEntity(&resourceRes); err != nil {
+		core.Log.Error(err, "Could not read an entity")
+		writeError(response, 400, core_errors.InvalidRequest, "Could not process the resource")
+		return
+	}
+
+	name := resourceRes.Meta.Name
+	meshName := name
+	if r.ResourceFactory().GetType() != mesh.MeshType {
+		meshName = r.meshFromRequest(request)
+	}
+	if name == "" {
+		writeError(response, 400, core_errors.InvalidResource, "Name cannot be empty")
+		return
+	}
+	if err := r.validateResource(name, meshName, &resourceRes); err != nil {
+		writeError(response, 400, core_errors.InvalidResource, err.Error())
+		return
+	}
+	if !r.authorizeConstraints(request, response, nil, resourceRes.Spec) {
+		return
+	}
+	r.createResource(request.Request.Context(), name, meshName, resourceRes, response)
+}
+
+// authorizeConstraints requires the admin token to change constraints of a Mesh, since they restrict
+// what holders of the write token can do. It writes an error response and returns false if the request is not authorized.
+//
+// The current spec is nil if a Mesh is created.
+func (r *resourceWs) authorizeConstraints(request *restful.Request, response *restful.Response, current model.ResourceSpec, changed model.ResourceSpec) bool {
+	if r.ResourceFactory().GetType() != mesh.MeshType {
+		return true
+	}
+	var currentConstraints *mesh_proto.Constraints
+	if current != nil {
+		currentConstraints = current.(*mesh_proto.Mesh).GetConstraints()
+	}
+	changedConstraints := changed.(*mesh_proto.Mesh).GetConstraints()
+	if len(currentConstraints.GetAllowedPolicyTypes()) == 0 && len(changedConstraints.GetAllowedPolicyTypes()) == 0 {
+		return true
+	}
+	if proto.Equal(currentConstraints, changedConstraints) {
+		return true
+	}
+	return filters.HasAdminToken(r.adminToken, request, response)
+}
+
+// validateResource checks a resource from the body of a request against its name and mesh from the URL.
+func (r *resourceWs) validateResource(name string, meshName string, resource *rest.Resource) error {
 	if name != resource.Meta.Name {
 		return errors.New("Name from the URL has to be the same as in body")
 	}
@@ -151,47 +343,328 @@ func (r *resourceWs) validateResourceRequest(request *restful.Request, resource
 	if meshName != resource.Meta.Mesh && r.ResourceFactory().GetType() != mesh.MeshType {
 		return errors.New("Mesh from the URL has to be the same as in body")
 	}
+	for key := range resource.Meta.Labels {
+		if key == "" {
+			return errors.New("Label key cannot be empty")
+		}
+	}
+	res := r.ResourceFactory()
+	if err := res.SetSpec(resource.Spec); err != nil {
+		return err
+	}
+	if validator, ok := res.(model.ResourceValidator); ok {
+		if err := validator.Validate(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-func (r *resourceWs) createResource(ctx context.Context, name string, meshName string, spec model.ResourceSpec, response *restful.Response) {
+func (r *resourceWs) createResource(ctx context.Context, name string, meshName string, restRes rest.Resource, response *restful.Response) {
+	if err := validateReservedLabels(nil, restRes.Meta.Labels); err != nil {
+		writeError(response, 400, core_errors.InvalidResource, err.Error())
+		return
+	}
 	res := r.ResourceFactory()
-	_ = res.SetSpec(spec)
-	if err := r.resManager.Create(ctx, res, store.CreateByKey(namespace, name, meshName)); err != nil {
+	_ = res.SetSpec(restRes.Spec)
+	if err := r.resManager.Create(ctx, res, store.CreateByKey(namespace, name, meshName), store.CreateWithLabels(restRes.Meta.Labels)); err != nil {
 		if manager.IsMeshNotFound(err) {
-			writeError(response, 400, fmt.Sprintf("Mesh of name %v is not found", meshName))
+			writeError(response, 400, core_errors.MeshNotFound, fmt.Sprintf("Mesh of name %v is not found", meshName))
+		} else if store.IsResourceAlreadyExists(err) {
+			writeError(response, 409, core_errors.ResourceAlreadyExists, "Resource already exists")
+		} else if validation_managers.IsValidationRejected(err) {
+			writeError(response, 400, core_errors.ValidationRejected, err.Error())
+		} else if dataplane_managers.IsInvalidServiceTag(err) {
+			writeError(response, 400, core_errors.InvalidResource, err.Error())
+		} else if quota_managers.IsQuotaExceeded(err) {
+			writeError(response, 403, core_errors.QuotaExceeded, err.Error())
+		} else if constraint_managers.IsPolicyTypeNotAllowed(err) {
+			writeError(response, 403, core_errors.PolicyTypeNotAllowed, err.Error())
+		} else if freeze_managers.IsConfigFrozen(err) {
+			writeError(response, 409, core_errors.ConfigFrozen, err.Error())
 		} else {
 			core.Log.Error(err, "Could not create a resource")
-			writeError(response, 500, "Could not create a resource")
+			writeError(response, 500, core_errors.Internal, "Could not create a resource")
 		}
 	} else {
+		response.AddHeader(etagHeader, etag(res))
 		response.WriteHeader(201)
 	}
 }
 
-func (r *resourceWs) updateResource(ctx context.Context, res model.Resource, restRes rest.Resource, response *restful.Response) {
+// updateResource replaces a spec of a resource that has been read from the store.
+// If a client has sent If-Match, a concurrent modification fails the precondition like it does on DELETE.
+func (r *resourceWs) updateResource(ctx context.Context, res model.Resource, restRes rest.Resource, ifMatch bool, response *restful.Response) {
+	if err := validateReservedLabels(res.GetMeta().GetLabels(), restRes.Meta.Labels); err != nil {
+		writeError(response, 400, core_errors.InvalidResource, err.Error())
+		return
+	}
+	labels := labelsOf(restRes, res)
 	_ = res.SetSpec(restRes.Spec)
-	if err := r.resManager.Update(ctx, res); err != nil {
+	if err := r.resManager.Update(ctx, res, store.UpdateWithLabels(labels)); err != nil {
+		if validation_managers.IsValidationRejected(err) {
+			writeError(response, 400, core_errors.ValidationRejected, err.Error())
+			return
+		}
+		if dataplane_managers.IsInvalidServiceTag(err) {
+			writeError(response, 400, core_errors.InvalidResource, err.Error())
+			return
+		}
+		if freeze_managers.IsConfigFrozen(err) {
+			writeError(response, 409, core_errors.ConfigFrozen, err.Error())
+			return
+		}
+		if constraint_managers.IsPolicyTypeNotAllowed(err) {
+			writeError(response, 403, core_errors.PolicyTypeNotAllowed, err.Error())
+			return
+		}
+		if store.IsResourceConflict(err) && ifMatch {
+			writeError(response, 412, core_errors.PreconditionFailed, "Resource has been modified")
+			return
+		}
+		if store.IsResourceConflict(err) {
+			writeError(response, 409, core_errors.ResourceConflict, "Resource is being modified concurrently, try again")
+			return
+		}
 		core.Log.Error(err, "Could not update a resource")
-		writeError(response, 500, "Could not update a resource")
+		writeError(response, 500, core_errors.Internal, "Could not update a resource")
 	} else {
+		response.AddHeader(etagHeader, etag(res))
 		response.WriteHeader(200)
 	}
 }
 
+// patchResource applies a patch to the current version of a resource,
+// so that a client can change e.g. a single field without replacing changes made by other clients.
+//
+// JSON Merge Patch (RFC 7386) merges objects key by key, while arrays are replaced as a whole.
+// E.g., to change a tag of a Dataplane, the patch has to list all its inbound interfaces with all their tags.
+// That is why JSON Patch (RFC 6902) is accepted as well, which addresses elements of arrays,
+// e.g. "/networking/inbound/0/tags/version", and lets a client guard a change with a "test" operation.
+func (r *resourceWs) patchResource(request *restful.Request, response *restful.Response) {
+	name := r.nameFromRequest(request)
+	meshName := r.meshFromRequest(request)
+
+	patch, err := ioutil.ReadAll(request.Request.Body)
+	if err != nil {
+		core.Log.Error(err, "Could not read a patch")
+		writeError(response, 400, core_errors.InvalidRequest, "Could not process the patch")
+		return
+	}
+	if !json.Valid(patch) {
+		writeError(response, 400, core_errors.InvalidRequest, "Patch must be a valid JSON document")
+		return
+	}
+	applyPatch := func(original []byte) ([]byte, error) {
+		return jsonpatch.MergePatch(original, patch)
+	}
+	if mediaTypeOf(request) == mimeJsonPatch {
+		operations, err := jsonpatch.DecodePatch(patch)
+		if err != nil {
+			writeError(response, 400, core_errors.InvalidRequest, fmt.Sprintf("Patch must be a valid JSON Patch: %s", err))
+			return
+		}
+		applyPatch = operations.Apply
+	}
+
+	for attempt := 1; ; attempt++ {
+		resource := r.ResourceFactory()
+		if err := r.resManager.Get(request.Request.Context(), resource, store.GetByKey(namespace, name, meshName)); err != nil {
+			if store.IsResourceNotFound(err) {
+				writeError(response, 404, core_errors.ResourceNotFound, "")
+			} else {
+				core.Log.Error(err, "Could not retrieve a resource", "name", name)
+				writeError(response, 500, core_errors.Internal, "Could not patch a resource")
+			}
+			return
+		}
+
+		original, err := json.Marshal(rest.From.Resource(resource))
+		if err != nil {
+			core.Log.Error(err, "Could not marshal a resource", "name", name)
+			writeError(response, 500, core_errors.Internal, "Could not patch a resource")
+			return
+		}
+		patched, err := applyPatch(original)
+		if err != nil {
+			writeError(response, 400, core_errors.InvalidRequest, fmt.Sprintf("Could not apply the patch: %s", err))
+			return
+		}
+		resourceRes := rest.Resource{
+			Spec: r.ResourceFactory().GetSpec(),
+		}
+		if err := json.Unmarshal(patched, &resourceRes); err != nil {
+			writeError(response, 400, core_errors.InvalidResource, fmt.Sprintf("Patched resource is not valid: %s", err))
+			return
+		}
+		if err := r.validateResource(name, meshName, &resourceRes); err != nil {
+			writeError(response, 400, core_errors.InvalidResource, err.Error())
+			return
+		}
+		if err := validateReservedLabels(resource.GetMeta().GetLabels(), resourceRes.Meta.Labels); err != nil {
+			writeError(response, 400, core_errors.InvalidResource, err.Error())
+			return
+		}
+		if !r.authorizeConstraints(request, response, resource.GetSpec(), resourceRes.Spec) {
+			return
+		}
+
+		labels := labelsOf(resourceRes, resource)
+		_ = resource.SetSpec(resourceRes.Spec)
+		err = r.resManager.Update(request.Request.Context(), resource, store.UpdateWithLabels(labels))
+		switch {
+		case err == nil:
+			response.AddHeader(etagHeader, etag(resource))
+			response.WriteHeader(200)
+			return
+		case store.IsResourceConflict(err) && attempt < maxPatchAttempts:
+			// resource has been modified in the meantime, apply the patch to the latest version
+			continue
+		case store.IsResourceConflict(err):
+			writeError(response, 409, core_errors.ResourceConflict, "Resource is being modified concurrently, try again")
+			return
+		case validation_managers.IsValidationRejected(err):
+			writeError(response, 400, core_errors.ValidationRejected, err.Error())
+			return
+		case dataplane_managers.IsInvalidServiceTag(err):
+			writeError(response, 400, core_errors.InvalidResource, err.Error())
+			return
+		case freeze_managers.IsConfigFrozen(err):
+			writeError(response, 409, core_errors.ConfigFrozen, err.Error())
+			return
+		case constraint_managers.IsPolicyTypeNotAllowed(err):
+			writeError(response, 403, core_errors.PolicyTypeNotAllowed, err.Error())
+			return
+		default:
+			core.Log.Error(err, "Could not update a resource")
+			writeError(response, 500, core_errors.Internal, "Could not patch a resource")
+			return
+		}
+	}
+}
+
 func (r *resourceWs) deleteResource(request *restful.Request, response *restful.Response) {
 	name := r.nameFromRequest(request)
 	meshName := r.meshFromRequest(request)
 
+	opts := []store.DeleteOptionsFunc{store.DeleteByKey(namespace, name, meshName)}
+	if request.QueryParameter("force") == "true" {
+		opts = append(opts, store.DeleteForce())
+	}
+
+	if request.HeaderParameter(ifMatchHeader) != "" {
+		current := r.ResourceFactory()
+		if err := r.resManager.Get(request.Request.Context(), current, store.GetByKey(namespace, name, meshName)); err != nil {
+			if store.IsResourceNotFound(err) {
+				writeError(response, 412, core_errors.PreconditionFailed, "Resource does not exist")
+			} else {
+				core.Log.Error(err, "Could not retrieve a resource", "name", name)
+				writeError(response, 500, core_errors.Internal, "Could not delete a resource")
+			}
+			return
+		}
+		if !ifMatches(request, current) {
+			writeError(response, 412, core_errors.PreconditionFailed, "Resource has been modified")
+			return
+		}
+		// the store rejects the delete if the resource is modified after it has been matched
+		opts = append(opts, store.DeleteWithVersion(current.GetMeta().GetVersion()))
+	}
+
 	resource := r.ResourceFactory()
-	err := r.resManager.Delete(request.Request.Context(), resource, store.DeleteByKey(namespace, name, meshName))
-	if err != nil {
-		writeError(response, 500, "Could not delete a resource")
+	err := r.resManager.Delete(request.Request.Context(), resource, opts...)
+	if mesh_managers.IsMeshInUse(err) {
+		writeError(response, 409, core_errors.MeshInUse, fmt.Sprintf("%s. Delete dependent resources first or use ?force=true", err))
+	} else if freeze_managers.IsConfigFrozen(err) {
+		writeError(response, 409, core_errors.ConfigFrozen, err.Error())
+	} else if store.IsResourceConflict(err) {
+		writeError(response, 412, core_errors.PreconditionFailed, "Resource has been modified")
+	} else if err != nil {
+		writeError(response, 500, core_errors.Internal, "Could not delete a resource")
 		core.Log.Error(err, "Could not delete a resource", "namespace", namespace, "name", name, "type", string(resource.GetType()))
 	}
 }
 
-func writeError(response *restful.Response, httpStatus int, msg string) {
+// etag returns an ETag of a resource, which is the version of the resource in the store.
+func etag(resource model.Resource) string {
+	if resource.GetMeta() == nil {
+		return ""
+	}
+	return strconv.Quote(resource.GetMeta().GetVersion())
+}
+
+// ifMatches checks a resource against the If-Match header of a request, which is either * or a list of ETags.
+// Weak ETags are compared as strong ones, since a version changes on every modification.
+func ifMatches(request *restful.Request, resource model.Resource) bool {
+	header := request.HeaderParameter(ifMatchHeader)
+	if header == "" || header == "*" {
+		return true
+	}
+	current := etag(resource)
+	for _, tag := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(tag), "W/") == current {
+			return true
+		}
+	}
+	return false
+}
+
+// labelsOf returns labels that replace the current labels of a resource.
+// A body without labels removes them all, except for reserved labels, which are always kept.
+func labelsOf(restRes rest.Resource, current model.Resource) map[string]string {
+	labels := map[string]string{}
+	for key, value := range restRes.Meta.Labels {
+		labels[key] = value
+	}
+	for key, value := range current.GetMeta().GetLabels() {
+		if strings.HasPrefix(key, reservedLabelPrefix) {
+			labels[key] = value
+		}
+	}
+	return labels
+}
+
+// validateReservedLabels makes sure that a client neither adds nor changes labels with the reserved prefix.
+// Kuma sets such labels itself, e.g. the Kubernetes store marks Dataplanes generated for Pods, and trusts them
+// to decide how a Dataplane is authenticated and validated.
+//
+// Current labels are nil if a resource is created.
+func validateReservedLabels(current map[string]string, requested map[string]string) error {
+	for key, value := range requested {
+		if !strings.HasPrefix(key, reservedLabelPrefix) {
+			continue
+		}
+		if currentValue, ok := current[key]; !ok || currentValue != value {
+			return errors.Errorf("Label %q cannot be set: labels with prefix %q are reserved for Kuma", key, reservedLabelPrefix)
+		}
+	}
+	return nil
+}
+
+// requestURL returns an absolute URL of a request.
+// mediaTypeOf returns a media type of the body of a request without parameters, e.g. application/json.
+func mediaTypeOf(request *restful.Request) string {
+	mediaType, _, err := mime.ParseMediaType(request.HeaderParameter(restful.HEADER_ContentType))
+	if err != nil {
+		return ""
+	}
+	return mediaType
+}
+
+func requestURL(request *restful.Request) *url.URL {
+	u := *request.Request.URL
+	u.Host = request.Request.Host
+	u.Scheme = "http"
+	if request.Request.TLS != nil {
+		u.Scheme = "https"
+	}
+	return &u
+}
+
+// writeError writes an error response with a machine-readable code in the ErrorCodeHeader header,
+// so that the body stays a message meant for humans.
+func writeError(response *restful.Response, httpStatus int, code core_errors.Code, msg string) {
+	response.AddHeader(rest.ErrorCodeHeader, string(code))
 	if err := response.WriteErrorString(httpStatus, msg); err != nil {
 		core.Log.Error(err, "Could not write the response")
 	}
@@ -0,0 +1,130 @@
+package api_server_test
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	"github.com/Kong/kuma/pkg/api-server"
+	config "github.com/Kong/kuma/pkg/config/api-server"
+	mesh_res "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/model/rest"
+	"github.com/Kong/kuma/pkg/core/resources/store"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+)
+
+var _ = Describe("Resource WS with constraints of a Mesh", func() {
+	var apiServer *api_server.ApiServer
+	var resourceStore store.ResourceStore
+	var client resourceApiClient
+	var stop chan struct{}
+
+	writeToken := http.Header{"Authorization": []string{"Bearer wr1t3"}}
+	adminToken := http.Header{"Authorization": []string{"Bearer 4dm1n"}}
+
+	BeforeEach(func() {
+		resourceStore = memory.NewStore()
+		err := resourceStore.Create(context.Background(), &mesh_res.MeshResource{}, store.CreateByKey("default", "default", "default"))
+		Expect(err).ToNot(HaveOccurred())
+
+		cfg := config.DefaultApiServerConfig()
+		cfg.WriteToken = "wr1t3"
+		cfg.AdminToken = "4dm1n"
+		apiServer = createTestApiServer(resourceStore, *cfg)
+		client = resourceApiClient{
+			address: apiServer.Address(),
+			path:    "/meshes",
+		}
+		stop = make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			err := apiServer.Start(stop)
+			Expect(err).ToNot(HaveOccurred())
+		}()
+		waitForServer(&client)
+	}, 5)
+
+	AfterEach(func() {
+		close(stop)
+	})
+
+	meshWith := func(name string, allowedPolicyTypes ...string) []byte {
+		res := rest.Resource{
+			Meta: rest.ResourceMeta{
+				Name: name,
+				Mesh: name,
+				Type: string(mesh_res.MeshType),
+			},
+			Spec: &mesh_proto.Mesh{},
+		}
+		if len(allowedPolicyTypes) > 0 {
+			res.Spec = &mesh_proto.Mesh{
+				Constraints: &mesh_proto.Constraints{
+					AllowedPolicyTypes: allowedPolicyTypes,
+				},
+			}
+		}
+		body, err := res.MarshalJSON()
+		Expect(err).ToNot(HaveOccurred())
+		return body
+	}
+
+	It("should require the admin token to set constraints", func() {
+		// when
+		response := client.do("PUT", "default", "application/json", meshWith("default", "TrafficPermission"), writeToken)
+
+		// then
+		Expect(response.StatusCode).To(Equal(401))
+		Expect(response.Header.Get("X-Kuma-Error-Code")).To(Equal("UNAUTHENTICATED"))
+
+		// when
+		response = client.do("POST", "", "application/json", meshWith("demo", "TrafficPermission"), writeToken)
+
+		// then
+		Expect(response.StatusCode).To(Equal(401))
+
+		// when
+		response = client.do("PUT", "default", "application/json", meshWith("default", "TrafficPermission"), adminToken)
+
+		// then
+		Expect(response.StatusCode).To(Equal(200))
+	})
+
+	It("should require the admin token to lift constraints", func() {
+		// given
+		response := client.do("PUT", "default", "application/json", meshWith("default", "TrafficPermission"), adminToken)
+		Expect(response.StatusCode).To(Equal(200))
+
+		// when
+		response = client.do("PATCH", "default", "application/merge-patch+json", []byte(`{"constraints": null}`), writeToken)
+
+		// then
+		Expect(response.StatusCode).To(Equal(401))
+
+		// when
+		response = client.do("PUT", "default", "application/json", meshWith("default"), writeToken)
+
+		// then
+		Expect(response.StatusCode).To(Equal(401))
+
+		// and
+		mesh := &mesh_res.MeshResource{}
+		Expect(resourceStore.Get(context.Background(), mesh, store.GetByKey("default", "default", "default"))).To(Succeed())
+		Expect(mesh.Spec.GetConstraints().GetAllowedPolicyTypes()).To(Equal([]string{"TrafficPermission"}))
+	})
+
+	It("should accept changes of a Mesh that don't touch its constraints with the write token", func() {
+		// given
+		response := client.do("PUT", "default", "application/json", meshWith("default", "TrafficPermission"), adminToken)
+		Expect(response.StatusCode).To(Equal(200))
+
+		// when
+		response = client.do("PATCH", "default", "application/merge-patch+json", []byte(`{"tracing": {"datadog": {"address": "127.0.0.1:8126"}}}`), writeToken)
+
+		// then
+		Expect(response.StatusCode).To(Equal(200))
+	})
+})
@@ -0,0 +1,134 @@
+package api_server_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kong/kuma/pkg/api-server"
+	config "github.com/Kong/kuma/pkg/config/api-server"
+	mesh_res "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/store"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+)
+
+var _ = Describe("Resource WS with a read token", func() {
+	var apiServer *api_server.ApiServer
+	var resourceStore store.ResourceStore
+	var client resourceApiClient
+	var stop chan struct{}
+
+	BeforeEach(func() {
+		resourceStore = memory.NewStore()
+		err := resourceStore.Create(context.Background(), &mesh_res.MeshResource{}, store.CreateByKey("default", "default", "default"))
+		Expect(err).ToNot(HaveOccurred())
+		putSampleResourceIntoStore(resourceStore, "tr-1", "default")
+
+		cfg := config.DefaultApiServerConfig()
+		cfg.ReadToken = "r34d"
+		cfg.WriteToken = "wr1t3"
+		apiServer = createTestApiServer(resourceStore, *cfg)
+		client = resourceApiClient{
+			address: apiServer.Address(),
+			path:    "/meshes/default/traffic-routes",
+		}
+		stop = make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			err := apiServer.Start(stop)
+			Expect(err).ToNot(HaveOccurred())
+		}()
+		Eventually(func() error {
+			_, err := client.listOrError()
+			return err
+		}, "5s", "100ms").ShouldNot(HaveOccurred())
+	}, 5)
+
+	AfterEach(func() {
+		close(stop)
+	})
+
+	It("should reject reads without a valid token", func() {
+		for _, header := range []http.Header{nil, {"Authorization": []string{"Bearer guess"}}} {
+			// when
+			responses := []*http.Response{
+				client.do("GET", "", "", nil, header),
+				client.do("GET", "tr-1", "", nil, header),
+			}
+
+			// then
+			for _, response := range responses {
+				Expect(response.StatusCode).To(Equal(401))
+				Expect(response.Header.Get("X-Kuma-Error-Code")).To(Equal("UNAUTHENTICATED"))
+				respBody, err := ioutil.ReadAll(response.Body)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(respBody)).To(Equal("Request has to present a valid read token"))
+			}
+		}
+	})
+
+	It("should accept reads with either a read or a write token", func() {
+		for _, token := range []string{"r34d", "wr1t3"} {
+			// when
+			response := client.do("GET", "tr-1", "", nil, http.Header{"Authorization": []string{"Bearer " + token}})
+
+			// then
+			Expect(response.StatusCode).To(Equal(200))
+		}
+	})
+
+	It("should not accept a read token for changes", func() {
+		// when
+		response := client.do("DELETE", "tr-1", "", nil, http.Header{"Authorization": []string{"Bearer r34d"}})
+
+		// then
+		Expect(response.StatusCode).To(Equal(401))
+	})
+})
+
+var _ = Describe("Resource WS without a read token", func() {
+	var apiServer *api_server.ApiServer
+	var client resourceApiClient
+	var stop chan struct{}
+
+	BeforeEach(func() {
+		resourceStore := memory.NewStore()
+		err := resourceStore.Create(context.Background(), &mesh_res.MeshResource{}, store.CreateByKey("default", "default", "default"))
+		Expect(err).ToNot(HaveOccurred())
+		putSampleResourceIntoStore(resourceStore, "tr-1", "default")
+
+		cfg := config.DefaultApiServerConfig()
+		cfg.WriteTokenFile = filepath.Join("testdata", "non-existing-token")
+		apiServer = createTestApiServer(resourceStore, *cfg)
+		client = resourceApiClient{
+			address: apiServer.Address(),
+			path:    "/meshes/default/traffic-routes",
+		}
+		stop = make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			err := apiServer.Start(stop)
+			Expect(err).ToNot(HaveOccurred())
+		}()
+		Eventually(func() error {
+			_, err := client.listOrError()
+			return err
+		}, "5s", "100ms").ShouldNot(HaveOccurred())
+	}, 5)
+
+	AfterEach(func() {
+		close(stop)
+	})
+
+	It("should not read other tokens to accept reads", func() {
+		// when
+		response := client.do("GET", "tr-1", "", nil, nil)
+
+		// then
+		Expect(response.StatusCode).To(Equal(200))
+	})
+})
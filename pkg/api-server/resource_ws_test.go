@@ -2,10 +2,13 @@ package api_server_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
 	"github.com/Kong/kuma/pkg/api-server"
 	config "github.com/Kong/kuma/pkg/config/api-server"
 	mesh_res "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/model"
 	"github.com/Kong/kuma/pkg/core/resources/model/rest"
 	"github.com/Kong/kuma/pkg/core/resources/store"
 	"github.com/Kong/kuma/pkg/plugins/resources/memory"
@@ -14,6 +17,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"io/ioutil"
+	"net/http"
 )
 
 var _ = Describe("Resource WS", func() {
@@ -73,12 +77,48 @@ var _ = Describe("Resource WS", func() {
 			Expect(body).To(MatchJSON(json))
 		})
 
+		It("should return the version of a resource in ETag", func() {
+			// given
+			putSampleResourceIntoStore(resourceStore, "tr-1", mesh)
+			resource := sample_model.TrafficRouteResource{}
+			err := resourceStore.Get(context.Background(), &resource, store.GetByKey(namespace, "tr-1", mesh))
+			Expect(err).ToNot(HaveOccurred())
+
+			// when
+			response := client.get("tr-1")
+
+			// then
+			Expect(response.StatusCode).To(Equal(200))
+			Expect(response.Header.Get("ETag")).To(Equal(`"` + resource.Meta.GetVersion() + `"`))
+		})
+
+		It("should return an existing resource in YAML", func() {
+			// given
+			putSampleResourceIntoStore(resourceStore, "tr-1", mesh)
+
+			// when
+			response := client.do("GET", "tr-1", "", nil, http.Header{"Accept": []string{"application/yaml"}})
+
+			// then
+			Expect(response.StatusCode).To(Equal(200))
+			Expect(response.Header.Get("content-type")).To(Equal("application/yaml"))
+			body, err := ioutil.ReadAll(response.Body)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(body).To(MatchYAML(`
+type: TrafficRoute
+name: tr-1
+mesh: default
+path: /sample-path
+`))
+		})
+
 		It("should return 404 for non existing resource", func() {
 			// when
 			response := client.get("non-existing-resource")
 
 			// then
 			Expect(response.StatusCode).To(Equal(404))
+			Expect(response.Header.Get("X-Kuma-Error-Code")).To(Equal("RESOURCE_NOT_FOUND"))
 		})
 
 		It("should list resources", func() {
@@ -108,10 +148,125 @@ var _ = Describe("Resource WS", func() {
 			body, err := ioutil.ReadAll(response.Body)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(body).To(Or(
-				MatchJSON(fmt.Sprintf(`{"items": [%s,%s]}`, json1, json2)),
-				MatchJSON(fmt.Sprintf(`{"items": [%s,%s]}`, json2, json1)),
+				MatchJSON(fmt.Sprintf(`{"total": 2, "items": [%s,%s]}`, json1, json2)),
+				MatchJSON(fmt.Sprintf(`{"total": 2, "items": [%s,%s]}`, json2, json1)),
 			))
 		})
+
+		It("should list resources with given labels", func() {
+			// given
+			putSampleResourceIntoStore(resourceStore, "tr-1", mesh)
+			err := resourceStore.Create(context.Background(), &sample_model.TrafficRouteResource{
+				Spec: sample_proto.TrafficRoute{Path: "/sample-path"},
+			}, store.CreateByKey(namespace, "tr-2", mesh), store.CreateWithLabels(map[string]string{"team": "payments"}))
+			Expect(err).ToNot(HaveOccurred())
+
+			// when
+			response, err := http.Get(client.fullAddress() + "?labels=team=payments")
+			Expect(err).ToNot(HaveOccurred())
+
+			// then
+			Expect(response.StatusCode).To(Equal(200))
+			body, err := ioutil.ReadAll(response.Body)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(body).To(MatchJSON(`
+			{
+				"total": 1,
+				"items": [
+					{
+						"type": "TrafficRoute",
+						"name": "tr-2",
+						"mesh": "default",
+						"labels": {
+							"team": "payments"
+						},
+						"path": "/sample-path"
+					}
+				]
+			}`))
+		})
+
+		It("should list resources with names that start with a given prefix", func() {
+			// given
+			putSampleResourceIntoStore(resourceStore, "tr-1", mesh)
+			putSampleResourceIntoStore(resourceStore, "other-1", mesh)
+
+			// when
+			response, err := http.Get(client.fullAddress() + "?namePrefix=tr-")
+			Expect(err).ToNot(HaveOccurred())
+
+			// then
+			Expect(response.StatusCode).To(Equal(200))
+			body, err := ioutil.ReadAll(response.Body)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(body).To(MatchJSON(`
+			{
+				"total": 1,
+				"items": [
+					{
+						"type": "TrafficRoute",
+						"name": "tr-1",
+						"mesh": "default",
+						"path": "/sample-path"
+					}
+				]
+			}`))
+		})
+
+		It("should list resources page by page", func() {
+			// given
+			putSampleResourceIntoStore(resourceStore, "tr-1", mesh)
+			putSampleResourceIntoStore(resourceStore, "tr-2", mesh)
+			putSampleResourceIntoStore(resourceStore, "tr-3", mesh)
+
+			type page struct {
+				Total uint32            `json:"total"`
+				Items []json.RawMessage `json:"items"`
+				Next  *string           `json:"next"`
+			}
+
+			// when
+			first := page{}
+			response, err := http.Get(client.fullAddress() + "?size=2")
+			Expect(err).ToNot(HaveOccurred())
+
+			// then
+			Expect(response.StatusCode).To(Equal(200))
+			Expect(json.NewDecoder(response.Body).Decode(&first)).To(Succeed())
+			Expect(first.Total).To(Equal(uint32(3)))
+			Expect(first.Items).To(HaveLen(2))
+			Expect(first.Next).ToNot(BeNil())
+
+			// when
+			second := page{}
+			response, err = http.Get(*first.Next)
+			Expect(err).ToNot(HaveOccurred())
+
+			// then
+			Expect(response.StatusCode).To(Equal(200))
+			Expect(json.NewDecoder(response.Body).Decode(&second)).To(Succeed())
+			Expect(second.Total).To(Equal(uint32(3)))
+			Expect(second.Items).To(HaveLen(1))
+			Expect(second.Next).To(BeNil())
+		})
+
+		It("should return 400 on invalid offset", func() {
+			// when
+			response, err := http.Get(client.fullAddress() + "?size=2&offset=abc")
+			Expect(err).ToNot(HaveOccurred())
+
+			// then
+			Expect(response.StatusCode).To(Equal(400))
+		})
+
+		It("should return 400 on malformed labels", func() {
+			// when
+			response, err := http.Get(client.fullAddress() + "?labels=team")
+			Expect(err).ToNot(HaveOccurred())
+
+			// then
+			Expect(response.StatusCode).To(Equal(400))
+		})
 	})
 
 	Describe("On PUT", func() {
@@ -135,6 +290,77 @@ var _ = Describe("Resource WS", func() {
 			Expect(response.StatusCode).To(Equal(201))
 		})
 
+		It("should create a resource from YAML", func() {
+			// given
+			body := `
+type: TrafficRoute
+name: new-resource
+mesh: default
+path: /sample-path
+`
+
+			// when
+			response := client.do("PUT", "new-resource", "application/yaml", []byte(body), nil)
+
+			// then
+			Expect(response.StatusCode).To(Equal(201))
+			resource := sample_model.TrafficRouteResource{}
+			err := resourceStore.Get(context.Background(), &resource, store.GetByKey(namespace, "new-resource", mesh))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resource.Spec.Path).To(Equal("/sample-path"))
+		})
+
+		It("should not create a resource when only an existing one is expected", func() {
+			// given
+			res := rest.Resource{
+				Meta: rest.ResourceMeta{
+					Name: "new-resource",
+					Mesh: mesh,
+					Type: string(sample_model.TrafficRouteType),
+				},
+				Spec: &sample_proto.TrafficRoute{
+					Path: "/sample-path",
+				},
+			}
+			body, err := res.MarshalJSON()
+			Expect(err).ToNot(HaveOccurred())
+
+			// when
+			response := client.do("PUT", "new-resource", "application/json", body, http.Header{"If-Match": []string{"*"}})
+
+			// then
+			Expect(response.StatusCode).To(Equal(412))
+			err = resourceStore.Get(context.Background(), &sample_model.TrafficRouteResource{}, store.GetByKey(namespace, "new-resource", mesh))
+			Expect(store.IsResourceNotFound(err)).To(BeTrue())
+		})
+
+		It("should not update a resource when only a new one is expected", func() {
+			// given
+			putSampleResourceIntoStore(resourceStore, "tr-1", mesh)
+			res := rest.Resource{
+				Meta: rest.ResourceMeta{
+					Name: "tr-1",
+					Mesh: mesh,
+					Type: string(sample_model.TrafficRouteType),
+				},
+				Spec: &sample_proto.TrafficRoute{
+					Path: "/update-sample-path",
+				},
+			}
+			body, err := res.MarshalJSON()
+			Expect(err).ToNot(HaveOccurred())
+
+			// when
+			response := client.do("PUT", "tr-1", "application/json", body, http.Header{"If-None-Match": []string{"*"}})
+
+			// then
+			Expect(response.StatusCode).To(Equal(412))
+			resource := sample_model.TrafficRouteResource{}
+			err = resourceStore.Get(context.Background(), &resource, store.GetByKey(namespace, "tr-1", mesh))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resource.Spec.Path).To(Equal("/sample-path"))
+		})
+
 		It("should update a resource when one already exist", func() {
 			// given
 			name := "tr-1"
@@ -161,6 +387,121 @@ var _ = Describe("Resource WS", func() {
 			Expect(resource.Spec.Path).To(Equal("/update-sample-path"))
 		})
 
+		It("should update a resource of a version given in If-Match", func() {
+			// given
+			putSampleResourceIntoStore(resourceStore, "tr-1", mesh)
+			etag := client.get("tr-1").Header.Get("ETag")
+			res := rest.Resource{
+				Meta: rest.ResourceMeta{
+					Name: "tr-1",
+					Mesh: mesh,
+					Type: string(sample_model.TrafficRouteType),
+				},
+				Spec: &sample_proto.TrafficRoute{
+					Path: "/update-sample-path",
+				},
+			}
+			body, err := res.MarshalJSON()
+			Expect(err).ToNot(HaveOccurred())
+
+			// when
+			response := client.do("PUT", "tr-1", "application/json", body, http.Header{"If-Match": []string{`"0", ` + etag}})
+
+			// then
+			Expect(response.StatusCode).To(Equal(200))
+			Expect(response.Header.Get("ETag")).ToNot(BeEmpty())
+			Expect(response.Header.Get("ETag")).ToNot(Equal(etag))
+
+			// when the same version is given again
+			response = client.do("PUT", "tr-1", "application/json", body, http.Header{"If-Match": []string{etag}})
+
+			// then
+			Expect(response.StatusCode).To(Equal(412))
+			Expect(response.Header.Get("X-Kuma-Error-Code")).To(Equal("PRECONDITION_FAILED"))
+		})
+
+		It("should replace labels of a resource", func() {
+			// given
+			name := "tr-1"
+			err := resourceStore.Create(context.Background(), &sample_model.TrafficRouteResource{}, store.CreateByKey(namespace, name, mesh), store.CreateWithLabels(map[string]string{"team": "payments"}))
+			Expect(err).ToNot(HaveOccurred())
+
+			// when
+			res := rest.Resource{
+				Meta: rest.ResourceMeta{
+					Name:   name,
+					Mesh:   mesh,
+					Type:   string(sample_model.TrafficRouteType),
+					Labels: map[string]string{"team": "billing", "env": "prod"},
+				},
+				Spec: &sample_proto.TrafficRoute{
+					Path: "/sample-path",
+				},
+			}
+			response := client.put(res)
+			Expect(response.StatusCode).To(Equal(200))
+
+			// then
+			resource := sample_model.TrafficRouteResource{}
+			err = resourceStore.Get(context.Background(), &resource, store.GetByKey(namespace, name, mesh))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resource.Meta.GetLabels()).To(Equal(map[string]string{"team": "billing", "env": "prod"}))
+		})
+
+		It("should not let a client set labels reserved for Kuma", func() {
+			// given
+			res := rest.Resource{
+				Meta: rest.ResourceMeta{
+					Name:   "new-resource",
+					Mesh:   mesh,
+					Type:   string(sample_model.TrafficRouteType),
+					Labels: map[string]string{"kuma.io/kubernetes-pod": "true"},
+				},
+				Spec: &sample_proto.TrafficRoute{
+					Path: "/sample-path",
+				},
+			}
+
+			// when
+			response := client.put(res)
+
+			// then
+			Expect(response.StatusCode).To(Equal(400))
+			Expect(response.Header.Get("X-Kuma-Error-Code")).To(Equal("INVALID_RESOURCE"))
+
+			// and
+			err := resourceStore.Get(context.Background(), &sample_model.TrafficRouteResource{}, store.GetByKey(namespace, "new-resource", mesh))
+			Expect(store.IsResourceNotFound(err)).To(BeTrue())
+		})
+
+		It("should keep labels reserved for Kuma when labels of a resource are replaced", func() {
+			// given
+			name := "tr-1"
+			err := resourceStore.Create(context.Background(), &sample_model.TrafficRouteResource{}, store.CreateByKey(namespace, name, mesh), store.CreateWithLabels(map[string]string{"team": "payments", "kuma.io/kubernetes-pod": "true"}))
+			Expect(err).ToNot(HaveOccurred())
+
+			// when
+			res := rest.Resource{
+				Meta: rest.ResourceMeta{
+					Name:   name,
+					Mesh:   mesh,
+					Type:   string(sample_model.TrafficRouteType),
+					Labels: map[string]string{"team": "billing"},
+				},
+				Spec: &sample_proto.TrafficRoute{
+					Path: "/sample-path",
+				},
+			}
+			response := client.put(res)
+			Expect(response.StatusCode).To(Equal(200))
+
+			// then
+			resource := sample_model.TrafficRouteResource{}
+			err = resourceStore.Get(context.Background(), &resource, store.GetByKey(namespace, name, mesh))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resource.Meta.GetLabels()).To(Equal(map[string]string{"team": "billing", "kuma.io/kubernetes-pod": "true"}))
+		})
+
 		It("should return 400 on the type in url that is different from request", func() {
 			// given
 			json := `
@@ -216,6 +557,274 @@ var _ = Describe("Resource WS", func() {
 		})
 	})
 
+	Describe("On PATCH", func() {
+		It("should update only the fields present in the patch", func() {
+			// given
+			name := "tr-1"
+			putSampleResourceIntoStore(resourceStore, name, mesh)
+
+			// when
+			response := client.patchJson(name, []byte(`{"path": "/patched-path"}`))
+
+			// then
+			Expect(response.StatusCode).To(Equal(200))
+
+			// and
+			resource := sample_model.TrafficRouteResource{}
+			err := resourceStore.Get(context.Background(), &resource, store.GetByKey(namespace, name, mesh))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resource.Spec.Path).To(Equal("/patched-path"))
+		})
+
+		It("should replace arrays as a whole, e.g. inbound interfaces of a Dataplane with all their tags", func() {
+			// given
+			dpClient := resourceApiClient{
+				address: apiServer.Address(),
+				path:    "/meshes/" + mesh + "/dataplanes",
+			}
+			dataplane := mesh_res.DataplaneResource{
+				Spec: mesh_proto.Dataplane{
+					Networking: &mesh_proto.Dataplane_Networking{
+						Inbound: []*mesh_proto.Dataplane_Networking_Inbound{
+							{
+								Interface: "127.0.0.1:8080:80",
+								Tags: map[string]string{
+									"service": "backend",
+									"version": "v1",
+									"env":     "dev",
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(resourceStore.Create(context.Background(), &dataplane, store.CreateByKey(namespace, "dp-1", mesh))).To(Succeed())
+
+			// when
+			response := dpClient.patchJson("dp-1", []byte(`
+			{
+				"networking": {
+					"inbound": [
+						{
+							"interface": "127.0.0.1:8080:80",
+							"tags": {
+								"service": "backend",
+								"version": "v2"
+							}
+						}
+					]
+				}
+			}`))
+
+			// then
+			Expect(response.StatusCode).To(Equal(200))
+
+			// and
+			actual := mesh_res.DataplaneResource{}
+			Expect(resourceStore.Get(context.Background(), &actual, store.GetByKey(namespace, "dp-1", mesh))).To(Succeed())
+			Expect(actual.Spec.Networking.Inbound[0].Tags).To(Equal(map[string]string{
+				"service": "backend",
+				"version": "v2",
+			}))
+		})
+
+		It("should change single tags of an inbound interface of a Dataplane with JSON Patch, keeping other changes", func() {
+			// given
+			dpClient := resourceApiClient{
+				address: apiServer.Address(),
+				path:    "/meshes/" + mesh + "/dataplanes",
+			}
+			dataplane := mesh_res.DataplaneResource{
+				Spec: mesh_proto.Dataplane{
+					Networking: &mesh_proto.Dataplane_Networking{
+						Inbound: []*mesh_proto.Dataplane_Networking_Inbound{
+							{
+								Interface: "127.0.0.1:8080:80",
+								Tags: map[string]string{
+									"service": "backend",
+									"version": "v1",
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(resourceStore.Create(context.Background(), &dataplane, store.CreateByKey(namespace, "dp-1", mesh))).To(Succeed())
+
+			// when two clients change different tags one after another
+			response := dpClient.jsonPatch("dp-1", []byte(`[
+				{"op": "test", "path": "/networking/inbound/0/tags/version", "value": "v1"},
+				{"op": "replace", "path": "/networking/inbound/0/tags/version", "value": "v2"}
+			]`))
+			// then
+			Expect(response.StatusCode).To(Equal(200))
+
+			// when
+			response = dpClient.jsonPatch("dp-1", []byte(`[
+				{"op": "add", "path": "/networking/inbound/0/tags/env", "value": "prod"}
+			]`))
+			// then
+			Expect(response.StatusCode).To(Equal(200))
+
+			// and
+			actual := mesh_res.DataplaneResource{}
+			Expect(resourceStore.Get(context.Background(), &actual, store.GetByKey(namespace, "dp-1", mesh))).To(Succeed())
+			Expect(actual.Spec.Networking.Inbound[0].Tags).To(Equal(map[string]string{
+				"service": "backend",
+				"version": "v2",
+				"env":     "prod",
+			}))
+		})
+
+		It("should return 400 on a JSON Patch with a test operation that fails", func() {
+			// given
+			name := "tr-1"
+			putSampleResourceIntoStore(resourceStore, name, mesh)
+
+			// when
+			response := client.jsonPatch(name, []byte(`[
+				{"op": "test", "path": "/path", "value": "/other-path"},
+				{"op": "replace", "path": "/path", "value": "/patched-path"}
+			]`))
+
+			// then
+			Expect(response.StatusCode).To(Equal(400))
+
+			// and
+			resource := sample_model.TrafficRouteResource{}
+			Expect(resourceStore.Get(context.Background(), &resource, store.GetByKey(namespace, name, mesh))).To(Succeed())
+			Expect(resource.Spec.Path).To(Equal("/sample-path"))
+		})
+
+		It("should return 400 on a JSON Patch that is not a list of operations", func() {
+			// given
+			putSampleResourceIntoStore(resourceStore, "tr-1", mesh)
+
+			// when
+			response := client.jsonPatch("tr-1", []byte(`{"path": "/patched-path"}`))
+
+			// then
+			Expect(response.StatusCode).To(Equal(400))
+		})
+
+		It("should return 400 on a patch that changes labels reserved for Kuma", func() {
+			// given
+			name := "tr-1"
+			err := resourceStore.Create(context.Background(), &sample_model.TrafficRouteResource{}, store.CreateByKey(namespace, name, mesh), store.CreateWithLabels(map[string]string{"kuma.io/kubernetes-pod": "false"}))
+			Expect(err).ToNot(HaveOccurred())
+
+			// when
+			response := client.patchJson(name, []byte(`{"labels": {"kuma.io/kubernetes-pod": "true"}}`))
+
+			// then
+			Expect(response.StatusCode).To(Equal(400))
+
+			// and
+			resource := sample_model.TrafficRouteResource{}
+			Expect(resourceStore.Get(context.Background(), &resource, store.GetByKey(namespace, name, mesh))).To(Succeed())
+			Expect(resource.Meta.GetLabels()).To(Equal(map[string]string{"kuma.io/kubernetes-pod": "false"}))
+		})
+
+		It("should return 404 for non existing resource", func() {
+			// when
+			response := client.patchJson("non-existing-resource", []byte(`{"path": "/patched-path"}`))
+
+			// then
+			Expect(response.StatusCode).To(Equal(404))
+		})
+
+		It("should return 400 on a patch that is not a valid JSON", func() {
+			// given
+			putSampleResourceIntoStore(resourceStore, "tr-1", mesh)
+
+			// when
+			response := client.patchJson("tr-1", []byte(`{"path": `))
+
+			// then
+			Expect(response.StatusCode).To(Equal(400))
+		})
+
+		It("should return 400 on a patch that changes the name", func() {
+			// given
+			putSampleResourceIntoStore(resourceStore, "tr-1", mesh)
+
+			// when
+			response := client.patchJson("tr-1", []byte(`{"name": "different-name"}`))
+
+			// then
+			Expect(response.StatusCode).To(Equal(400))
+		})
+
+		It("should return 400 on a patch that changes the mesh", func() {
+			// given
+			putSampleResourceIntoStore(resourceStore, "tr-1", mesh)
+
+			// when
+			response := client.patchJson("tr-1", []byte(`{"mesh": "different-mesh"}`))
+
+			// then
+			Expect(response.StatusCode).To(Equal(400))
+		})
+	})
+
+	Describe("On POST", func() {
+		It("should create a resource named in the body", func() {
+			// given
+			res := rest.Resource{
+				Meta: rest.ResourceMeta{
+					Name: "new-resource",
+					Mesh: mesh,
+					Type: string(sample_model.TrafficRouteType),
+				},
+				Spec: &sample_proto.TrafficRoute{
+					Path: "/sample-path",
+				},
+			}
+			body, err := res.MarshalJSON()
+			Expect(err).ToNot(HaveOccurred())
+
+			// when
+			response := client.do("POST", "", "application/json", body, nil)
+
+			// then
+			Expect(response.StatusCode).To(Equal(201))
+			resource := sample_model.TrafficRouteResource{}
+			err = resourceStore.Get(context.Background(), &resource, store.GetByKey(namespace, "new-resource", mesh))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resource.Spec.Path).To(Equal("/sample-path"))
+		})
+
+		It("should return 409 when a resource already exists", func() {
+			// given
+			putSampleResourceIntoStore(resourceStore, "tr-1", mesh)
+			body := `
+type: TrafficRoute
+name: tr-1
+mesh: default
+path: /update-sample-path
+`
+
+			// when
+			response := client.do("POST", "", "application/yaml", []byte(body), nil)
+
+			// then
+			Expect(response.StatusCode).To(Equal(409))
+			Expect(response.Header.Get("X-Kuma-Error-Code")).To(Equal("RESOURCE_ALREADY_EXISTS"))
+			resource := sample_model.TrafficRouteResource{}
+			err := resourceStore.Get(context.Background(), &resource, store.GetByKey(namespace, "tr-1", mesh))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resource.Spec.Path).To(Equal("/sample-path"))
+		})
+
+		It("should return 400 when a name is missing", func() {
+			// when
+			response := client.do("POST", "", "application/json", []byte(`{"type": "TrafficRoute", "mesh": "default", "path": "/sample-path"}`), nil)
+
+			// then
+			Expect(response.StatusCode).To(Equal(400))
+		})
+	})
+
 	Describe("On DELETE", func() {
 		It("should delete existing resource", func() {
 			// given
@@ -234,6 +843,36 @@ var _ = Describe("Resource WS", func() {
 			Expect(err).To(Equal(store.ErrorResourceNotFound(resource.GetType(), namespace, name, mesh)))
 		})
 
+		It("should delete a resource of a version given in If-Match", func() {
+			// given
+			name := "tr-1"
+			putSampleResourceIntoStore(resourceStore, name, mesh)
+			etag := client.get(name).Header.Get("ETag")
+
+			// when
+			response := client.do("DELETE", name, "", nil, http.Header{"If-Match": []string{etag}})
+
+			// then
+			Expect(response.StatusCode).To(Equal(200))
+			err := resourceStore.Get(context.Background(), &sample_model.TrafficRouteResource{}, store.GetByKey(namespace, name, mesh))
+			Expect(store.IsResourceNotFound(err)).To(BeTrue())
+		})
+
+		It("should not delete a resource of a version other than given in If-Match", func() {
+			// given
+			name := "tr-1"
+			putSampleResourceIntoStore(resourceStore, name, mesh)
+
+			// when
+			response := client.do("DELETE", name, "", nil, http.Header{"If-Match": []string{`"0"`}})
+
+			// then
+			Expect(response.StatusCode).To(Equal(412))
+			Expect(response.Header.Get("X-Kuma-Error-Code")).To(Equal("PRECONDITION_FAILED"))
+			err := resourceStore.Get(context.Background(), &sample_model.TrafficRouteResource{}, store.GetByKey(namespace, name, mesh))
+			Expect(err).ToNot(HaveOccurred())
+		})
+
 		It("should delete non-existing resource", func() {
 			// when
 			response := client.delete("non-existing-resource")
@@ -243,3 +882,95 @@ var _ = Describe("Resource WS", func() {
 		})
 	})
 })
+
+var _ = Describe("Resource WS with concurrent modifications", func() {
+	var apiServer *api_server.ApiServer
+	var resourceStore store.ResourceStore
+	var client resourceApiClient
+	var stop chan struct{}
+
+	BeforeEach(func() {
+		resourceStore = &modifyingStore{ResourceStore: memory.NewStore()}
+		err := resourceStore.Create(context.Background(), &mesh_res.MeshResource{}, store.CreateByKey("default", "default", "default"))
+		Expect(err).ToNot(HaveOccurred())
+		putSampleResourceIntoStore(resourceStore, "tr-1", "default")
+
+		apiServer = createTestApiServer(resourceStore, *config.DefaultApiServerConfig())
+		client = resourceApiClient{
+			address: apiServer.Address(),
+			path:    "/meshes/default/traffic-routes",
+		}
+		stop = make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			err := apiServer.Start(stop)
+			Expect(err).ToNot(HaveOccurred())
+		}()
+		Eventually(func() error {
+			_, err := client.listOrError()
+			return err
+		}, "5s", "100ms").ShouldNot(HaveOccurred())
+	}, 5)
+
+	AfterEach(func() {
+		close(stop)
+	})
+
+	body := func() []byte {
+		res := rest.Resource{
+			Meta: rest.ResourceMeta{
+				Name: "tr-1",
+				Mesh: "default",
+				Type: string(sample_model.TrafficRouteType),
+			},
+			Spec: &sample_proto.TrafficRoute{
+				Path: "/update-sample-path",
+			},
+		}
+		body, err := res.MarshalJSON()
+		Expect(err).ToNot(HaveOccurred())
+		return body
+	}
+
+	It("should fail the precondition of PUT if a resource has been modified after it has been matched", func() {
+		// given
+		current := sample_model.TrafficRouteResource{}
+		Expect(resourceStore.Get(context.Background(), &current, store.GetByKey("default", "tr-1", "default"))).To(Succeed())
+
+		// when
+		response := client.do("PUT", "tr-1", "application/json", body(), http.Header{"If-Match": []string{etagOf(&current)}})
+
+		// then
+		Expect(response.StatusCode).To(Equal(412))
+		Expect(response.Header.Get("X-Kuma-Error-Code")).To(Equal("PRECONDITION_FAILED"))
+	})
+
+	It("should report a conflict of PUT without a precondition", func() {
+		// when
+		response := client.do("PUT", "tr-1", "application/json", body(), nil)
+
+		// then
+		Expect(response.StatusCode).To(Equal(409))
+		Expect(response.Header.Get("X-Kuma-Error-Code")).To(Equal("RESOURCE_CONFLICT"))
+	})
+})
+
+func etagOf(resource *sample_model.TrafficRouteResource) string {
+	return fmt.Sprintf("%q", resource.GetMeta().GetVersion())
+}
+
+// modifyingStore modifies every resource right before it is updated, as another client would do concurrently.
+type modifyingStore struct {
+	store.ResourceStore
+}
+
+func (s *modifyingStore) Update(ctx context.Context, resource model.Resource, fs ...store.UpdateOptionsFunc) error {
+	current := sample_model.TrafficRouteResource{}
+	if err := s.ResourceStore.Get(ctx, &current, store.GetBy(model.MetaToResourceKey(resource.GetMeta()))); err != nil {
+		return err
+	}
+	if err := s.ResourceStore.Update(ctx, &current); err != nil {
+		return err
+	}
+	return s.ResourceStore.Update(ctx, resource, fs...)
+}
@@ -0,0 +1,170 @@
+package api_server_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kong/kuma/pkg/api-server"
+	config "github.com/Kong/kuma/pkg/config/api-server"
+	mesh_res "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/store"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+	sample_model "github.com/Kong/kuma/pkg/test/resources/apis/sample"
+)
+
+var _ = Describe("Resource WS with a write token", func() {
+	var apiServer *api_server.ApiServer
+	var resourceStore store.ResourceStore
+	var client resourceApiClient
+	var stop chan struct{}
+
+	BeforeEach(func() {
+		resourceStore = memory.NewStore()
+		err := resourceStore.Create(context.Background(), &mesh_res.MeshResource{}, store.CreateByKey("default", "default", "default"))
+		Expect(err).ToNot(HaveOccurred())
+
+		cfg := config.DefaultApiServerConfig()
+		cfg.WriteToken = "wr1t3"
+		apiServer = createTestApiServer(resourceStore, *cfg)
+		client = resourceApiClient{
+			address: apiServer.Address(),
+			path:    "/meshes/default/traffic-routes",
+		}
+		stop = make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			err := apiServer.Start(stop)
+			Expect(err).ToNot(HaveOccurred())
+		}()
+		waitForServer(&client)
+	}, 5)
+
+	AfterEach(func() {
+		close(stop)
+	})
+
+	body := []byte(`{"type": "TrafficRoute", "name": "tr-1", "mesh": "default", "path": "/sample-path"}`)
+
+	It("should create a resource when a valid token is presented", func() {
+		// when
+		response := client.do("PUT", "tr-1", "application/json", body, http.Header{"Authorization": []string{"Bearer wr1t3"}})
+
+		// then
+		Expect(response.StatusCode).To(Equal(201))
+	})
+
+	It("should reject changes without a valid token", func() {
+		// given
+		putSampleResourceIntoStore(resourceStore, "tr-2", "default")
+
+		for _, header := range []http.Header{nil, {"Authorization": []string{"Bearer guess"}}} {
+			// when
+			responses := []*http.Response{
+				client.do("POST", "", "application/json", body, header),
+				client.do("PUT", "tr-1", "application/json", body, header),
+				client.do("PATCH", "tr-2", "application/merge-patch+json", []byte(`{"path": "/other"}`), header),
+				client.do("DELETE", "tr-2", "", nil, header),
+			}
+
+			// then
+			for _, response := range responses {
+				Expect(response.StatusCode).To(Equal(401))
+				respBody, err := ioutil.ReadAll(response.Body)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(respBody)).To(Equal("Request has to present a valid write token"))
+			}
+		}
+		// and
+		err := resourceStore.Get(context.Background(), &sample_model.TrafficRouteResource{}, store.GetByKey("default", "tr-2", "default"))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should not require a token to read resources", func() {
+		// given
+		putSampleResourceIntoStore(resourceStore, "tr-1", "default")
+
+		// when
+		response := client.get("tr-1")
+
+		// then
+		Expect(response.StatusCode).To(Equal(200))
+	})
+})
+
+var _ = Describe("Resource WS with a write token in a file", func() {
+	var tokenFile string
+	var client resourceApiClient
+	var stop chan struct{}
+
+	BeforeEach(func() {
+		file, err := ioutil.TempFile("", "write-token")
+		Expect(err).ToNot(HaveOccurred())
+		tokenFile = file.Name()
+		Expect(file.Close()).To(Succeed())
+		Expect(ioutil.WriteFile(tokenFile, []byte("wr1t3\n"), 0600)).To(Succeed())
+
+		resourceStore := memory.NewStore()
+		err = resourceStore.Create(context.Background(), &mesh_res.MeshResource{}, store.CreateByKey("default", "default", "default"))
+		Expect(err).ToNot(HaveOccurred())
+
+		cfg := config.DefaultApiServerConfig()
+		cfg.WriteToken = "ignored"
+		cfg.WriteTokenFile = tokenFile
+		apiServer := createTestApiServer(resourceStore, *cfg)
+		client = resourceApiClient{
+			address: apiServer.Address(),
+			path:    "/meshes/default/traffic-routes",
+		}
+		stop = make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			err := apiServer.Start(stop)
+			Expect(err).ToNot(HaveOccurred())
+		}()
+		waitForServer(&client)
+	}, 5)
+
+	AfterEach(func() {
+		close(stop)
+		Expect(os.Remove(tokenFile)).To(Succeed())
+	})
+
+	body := []byte(`{"type": "TrafficRoute", "name": "tr-1", "mesh": "default", "path": "/sample-path"}`)
+
+	It("should accept a token once it has been rotated", func() {
+		// when
+		response := client.do("PUT", "tr-1", "application/json", body, http.Header{"Authorization": []string{"Bearer wr1t3"}})
+
+		// then
+		Expect(response.StatusCode).To(Equal(201))
+
+		// when
+		Expect(ioutil.WriteFile(tokenFile, []byte("r0t4t3d\n"), 0600)).To(Succeed())
+		later := time.Now().Add(time.Minute)
+		Expect(os.Chtimes(tokenFile, later, later)).To(Succeed())
+
+		// then
+		response = client.do("PUT", "tr-1", "application/json", body, http.Header{"Authorization": []string{"Bearer wr1t3"}})
+		Expect(response.StatusCode).To(Equal(401))
+		response = client.do("PUT", "tr-1", "application/json", body, http.Header{"Authorization": []string{"Bearer r0t4t3d"}})
+		Expect(response.StatusCode).To(Equal(200))
+	})
+
+	It("should reject changes when the token cannot be read", func() {
+		// given
+		Expect(ioutil.WriteFile(tokenFile, nil, 0600)).To(Succeed())
+
+		// when
+		response := client.do("PUT", "tr-1", "application/json", body, nil)
+
+		// then
+		Expect(response.StatusCode).To(Equal(500))
+		Expect(response.Header.Get("X-Kuma-Error-Code")).To(Equal("INTERNAL"))
+	})
+})
@@ -0,0 +1,82 @@
+package api_server
+
+import (
+	"github.com/emicklei/go-restful"
+
+	"github.com/Kong/kuma/pkg/api-server/filters"
+	"github.com/Kong/kuma/pkg/config"
+	"github.com/Kong/kuma/pkg/core"
+	builtin_ca "github.com/Kong/kuma/pkg/core/ca/builtin"
+	core_errors "github.com/Kong/kuma/pkg/core/errors"
+	"github.com/Kong/kuma/pkg/core/resources/store"
+)
+
+type revokedCertsWs struct {
+	caManager  builtin_ca.BuiltinCaManager
+	adminToken config.Secret
+	readOnly   bool
+}
+
+type revokedCertList struct {
+	Items []builtin_ca.RevokedCert `json:"items"`
+}
+
+type revokeCertRequest struct {
+	SerialNumber string `json:"serialNumber"`
+}
+
+func (s *revokedCertsWs) AddToWs(ws *restful.WebService) {
+	ws.Route(ws.GET("/{mesh}/revoked-dataplane-certificates").To(s.listRevokedCerts).
+		Doc("List revoked Workload Identity certificates of Dataplanes").
+		Param(ws.PathParameter("mesh", "Name of a mesh").DataType("string")).
+		Returns(200, "OK", nil))
+
+	if !s.readOnly {
+		ws.Route(ws.POST("/{mesh}/revoked-dataplane-certificates").To(s.revokeCert).
+			Filter(filters.AdminToken(s.adminToken)).
+			Doc("Revoke a Workload Identity certificate of a Dataplane. Requires the admin token").
+			Param(ws.PathParameter("mesh", "Name of a mesh").DataType("string")).
+			Returns(201, "Created", nil).
+			Returns(400, "Bad request", nil).
+			Returns(401, "Unauthorized", nil).
+			Returns(403, "Forbidden", nil))
+	}
+}
+
+func (s *revokedCertsWs) listRevokedCerts(request *restful.Request, response *restful.Response) {
+	meshName := request.PathParameter("mesh")
+	list := revokedCertList{Items: []builtin_ca.RevokedCert{}}
+	revokedCerts, err := s.caManager.GetRevokedCerts(request.Request.Context(), meshName)
+	if err != nil && !store.IsResourceNotFound(err) {
+		core.Log.Error(err, "Could not retrieve revoked certificates", "mesh", meshName)
+		writeError(response, 500, core_errors.Internal, "Could not list revoked certificates")
+		return
+	}
+	list.Items = append(list.Items, revokedCerts...)
+	if err := response.WriteAsJson(list); err != nil {
+		core.Log.Error(err, "Could not write the response")
+		writeError(response, 500, core_errors.Internal, "Could not list revoked certificates")
+	}
+}
+
+func (s *revokedCertsWs) revokeCert(request *restful.Request, response *restful.Response) {
+	meshName := request.PathParameter("mesh")
+	revokeRequest := revokeCertRequest{}
+	if err := request.ReadEntity(&revokeRequest); err != nil || revokeRequest.SerialNumber == "" {
+		writeError(response, 400, core_errors.InvalidRequest, "Request has to contain a serialNumber")
+		return
+	}
+	if err := s.caManager.RevokeCert(request.Request.Context(), meshName, revokeRequest.SerialNumber); err != nil {
+		switch {
+		case builtin_ca.IsInvalidSerialNumber(err):
+			writeError(response, 400, core_errors.InvalidRequest, "serialNumber has to be a hex number, e.g. 3a:8e:0f:1c")
+		case store.IsResourceNotFound(err):
+			writeError(response, 400, core_errors.ResourceNotFound, "There is no Builtin CA in the mesh")
+		default:
+			core.Log.Error(err, "Could not revoke a certificate", "mesh", meshName)
+			writeError(response, 500, core_errors.Internal, "Could not revoke a certificate")
+		}
+		return
+	}
+	response.WriteHeader(201)
+}
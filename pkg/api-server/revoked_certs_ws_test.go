@@ -0,0 +1,123 @@
+package api_server_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kong/kuma/pkg/api-server"
+	config "github.com/Kong/kuma/pkg/config/api-server"
+	builtin_ca "github.com/Kong/kuma/pkg/core/ca/builtin"
+	secret_cipher "github.com/Kong/kuma/pkg/core/secrets/cipher"
+	secret_manager "github.com/Kong/kuma/pkg/core/secrets/manager"
+	secret_store "github.com/Kong/kuma/pkg/core/secrets/store"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+)
+
+var _ = Describe("Revoked Certificates WS", func() {
+	var apiServer *api_server.ApiServer
+	var stop chan struct{}
+	var baseUrl string
+
+	BeforeEach(func() {
+		resourceStore := memory.NewStore()
+		caManager := builtin_ca.NewBuiltinCaManager(secret_manager.NewSecretManager(secret_store.NewSecretStore(resourceStore), secret_cipher.None()))
+		Expect(caManager.Create(context.Background(), "demo")).To(Succeed())
+
+		cfg := config.DefaultApiServerConfig()
+		cfg.AdminToken = "admin-secret"
+		apiServer = createTestApiServer(resourceStore, *cfg)
+		client := resourceApiClient{
+			address: apiServer.Address(),
+			path:    "/meshes",
+		}
+		baseUrl = "http://" + apiServer.Address() + "/meshes"
+		stop = make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			err := apiServer.Start(stop)
+			Expect(err).ToNot(HaveOccurred())
+		}()
+		waitForServer(&client)
+	}, 5)
+
+	AfterEach(func() {
+		close(stop)
+	})
+
+	listRevokedCerts := func(mesh string) []builtin_ca.RevokedCert {
+		response, err := http.Get(baseUrl + "/" + mesh + "/revoked-dataplane-certificates")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(200))
+		body, err := ioutil.ReadAll(response.Body)
+		Expect(err).ToNot(HaveOccurred())
+		list := struct {
+			Items []builtin_ca.RevokedCert `json:"items"`
+		}{}
+		Expect(json.Unmarshal(body, &list)).To(Succeed())
+		return list.Items
+	}
+
+	revokeWithToken := func(mesh string, body string, adminToken string) *http.Response {
+		request, err := http.NewRequest("POST", baseUrl+"/"+mesh+"/revoked-dataplane-certificates", bytes.NewBufferString(body))
+		Expect(err).ToNot(HaveOccurred())
+		request.Header.Set("Content-Type", "application/json")
+		if adminToken != "" {
+			request.Header.Set("Authorization", "Bearer "+adminToken)
+		}
+		response, err := http.DefaultClient.Do(request)
+		Expect(err).ToNot(HaveOccurred())
+		return response
+	}
+
+	revoke := func(mesh string, body string) *http.Response {
+		return revokeWithToken(mesh, body, "admin-secret")
+	}
+
+	It("should revoke a certificate", func() {
+		// given
+		Expect(listRevokedCerts("demo")).To(BeEmpty())
+
+		// when
+		response := revoke("demo", `{"serialNumber": "3a:8e:0f:1c"}`)
+
+		// then
+		Expect(response.StatusCode).To(Equal(201))
+		revokedCerts := listRevokedCerts("demo")
+		Expect(revokedCerts).To(HaveLen(1))
+		Expect(revokedCerts[0].SerialNumber).To(Equal("3a8e0f1c"))
+	})
+
+	It("should list no certificates of a mesh without Builtin CA", func() {
+		// expect
+		Expect(listRevokedCerts("other")).To(BeEmpty())
+	})
+
+	It("should reject invalid requests", func() {
+		// expect
+		Expect(revoke("demo", `{"serialNumber": "not-a-number"}`).StatusCode).To(Equal(400))
+		Expect(revoke("demo", `{}`).StatusCode).To(Equal(400))
+		Expect(revoke("other", `{"serialNumber": "3a:8e:0f:1c"}`).StatusCode).To(Equal(400))
+	})
+
+	It("should not revoke a certificate without an admin token", func() {
+		// when
+		response := revokeWithToken("demo", `{"serialNumber": "3a:8e:0f:1c"}`, "")
+
+		// then
+		Expect(response.StatusCode).To(Equal(401))
+		Expect(listRevokedCerts("demo")).To(BeEmpty())
+
+		// when
+		response = revokeWithToken("demo", `{"serialNumber": "3a:8e:0f:1c"}`, "not-a-secret")
+
+		// then
+		Expect(response.StatusCode).To(Equal(401))
+		Expect(listRevokedCerts("demo")).To(BeEmpty())
+	})
+})
@@ -7,10 +7,21 @@ import (
 	"net/http"
 
 	"github.com/Kong/kuma/pkg/api-server/definitions"
+	"github.com/Kong/kuma/pkg/api-server/filters"
 	config "github.com/Kong/kuma/pkg/config/api-server"
+	kuma_cp "github.com/Kong/kuma/pkg/config/app/kuma-cp"
 	"github.com/Kong/kuma/pkg/core"
+	builtin_ca "github.com/Kong/kuma/pkg/core/ca/builtin"
+	provided_ca "github.com/Kong/kuma/pkg/core/ca/provided"
+	dataplane_managers "github.com/Kong/kuma/pkg/core/managers/apis/dataplane"
+	freeze_managers "github.com/Kong/kuma/pkg/core/managers/freeze"
+	validation_managers "github.com/Kong/kuma/pkg/core/managers/validation"
 	"github.com/Kong/kuma/pkg/core/runtime"
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
+	util_tls "github.com/Kong/kuma/pkg/tls"
+	"github.com/Kong/kuma/pkg/tokens/builtin/issuer"
 	"github.com/emicklei/go-restful"
+	"github.com/go-openapi/spec"
 )
 
 var (
@@ -18,15 +29,21 @@ var (
 )
 
 type ApiServer struct {
-	server *http.Server
+	server  *http.Server
+	openApi *spec.Swagger
+	tls     config.ApiServerTlsConfig
 }
 
 func (a *ApiServer) Address() string {
 	return a.server.Addr
 }
 
-func NewApiServer(resManager manager.ResourceManager, defs []definitions.ResourceWsDefinition, config config.ApiServerConfig) *ApiServer {
+func NewApiServer(resManager manager.ResourceManager, keyManager issuer.SigningKeyManager, tokenIssuer issuer.DataplaneTokenIssuer, caManager builtin_ca.BuiltinCaManager, providedCaManager provided_ca.ProvidedCaManager, configHistory core_xds.ConfigHistory, configFreeze freeze_managers.ConfigFreeze, streamTracker core_xds.StreamTracker, registry *dataplane_managers.Registry, defs []definitions.ResourceWsDefinition, config config.ApiServerConfig) *ApiServer {
 	container := restful.NewContainer()
+	if config.AccessLog.Enabled {
+		container.Filter(filters.AccessLog(*config.AccessLog, log.WithName("access-log")))
+	}
+	container.Filter(filters.ReadToken(config.ReadTokenSecret(), config.WriteTokenSecret(), config.AdminTokenSecret()))
 	srv := &http.Server{
 		Addr:    fmt.Sprintf(":%d", config.Port),
 		Handler: container.ServeMux,
@@ -38,25 +55,78 @@ func NewApiServer(resManager manager.ResourceManager, defs []definitions.Resourc
 		Consumes(restful.MIME_JSON).
 		Produces(restful.MIME_JSON)
 
-	addToWs(ws, defs, resManager, config)
+	addToWs(ws, defs, resManager, keyManager, tokenIssuer, caManager, providedCaManager, configHistory, streamTracker, registry, config)
 	container.Add(ws)
+	container.Add(configFreezeWebService(configFreeze, config.AdminTokenSecret(), config.ReadOnly))
 	container.Add(indexWs())
+	openApi := buildOpenApiSpec(container.RegisteredWebServices())
+	container.Add(openApiWs(openApi))
 
 	return &ApiServer{
-		server: srv,
+		server:  srv,
+		openApi: openApi,
+		tls:     *config.Tls,
 	}
 }
 
-func addToWs(ws *restful.WebService, defs []definitions.ResourceWsDefinition, resManager manager.ResourceManager, config config.ApiServerConfig) {
+func addToWs(ws *restful.WebService, defs []definitions.ResourceWsDefinition, resManager manager.ResourceManager, keyManager issuer.SigningKeyManager, tokenIssuer issuer.DataplaneTokenIssuer, caManager builtin_ca.BuiltinCaManager, providedCaManager provided_ca.ProvidedCaManager, configHistory core_xds.ConfigHistory, streamTracker core_xds.StreamTracker, registry *dataplane_managers.Registry, config config.ApiServerConfig) {
 	overviewWs := overviewWs{
 		resManager: resManager,
 	}
 	overviewWs.AddToWs(ws)
 
+	prometheusWs := prometheusWs{
+		resManager: resManager,
+	}
+	prometheusWs.AddToWs(ws)
+
+	meshInsightWs := meshInsightWs{
+		resManager:        resManager,
+		builtinCaManager:  caManager,
+		providedCaManager: providedCaManager,
+	}
+	meshInsightWs.AddToWs(ws)
+
+	signingKeysWs := signingKeysWs{
+		keyManager:  keyManager,
+		tokenIssuer: tokenIssuer,
+		adminToken:  config.AdminTokenSecret(),
+		readOnly:    config.ReadOnly,
+	}
+	signingKeysWs.AddToWs(ws)
+
+	revokedCertsWs := revokedCertsWs{
+		caManager:  caManager,
+		adminToken: config.AdminTokenSecret(),
+		readOnly:   config.ReadOnly,
+	}
+	revokedCertsWs.AddToWs(ws)
+
+	configGenerationsWs := configGenerationsWs{
+		configHistory: configHistory,
+	}
+	configGenerationsWs.AddToWs(ws)
+
+	dataplaneDisconnectWs := dataplaneDisconnectWs{
+		streamTracker: streamTracker,
+		adminToken:    config.AdminTokenSecret(),
+		readOnly:      config.ReadOnly,
+	}
+	dataplaneDisconnectWs.AddToWs(ws)
+
+	dataplaneRegistrationWs := dataplaneRegistrationWs{
+		registry:    registry,
+		tokenIssuer: tokenIssuer,
+		readOnly:    config.ReadOnly,
+	}
+	dataplaneRegistrationWs.AddToWs(ws)
+
 	for _, definition := range defs {
 		resourceWs := resourceWs{
 			resManager:           resManager,
 			readOnly:             config.ReadOnly,
+			writeToken:           config.WriteTokenSecret(),
+			adminToken:           config.AdminTokenSecret(),
 			ResourceWsDefinition: definition,
 		}
 		resourceWs.AddToWs(ws)
@@ -64,9 +134,22 @@ func addToWs(ws *restful.WebService, defs []definitions.ResourceWsDefinition, re
 }
 
 func (a *ApiServer) Start(stop <-chan struct{}) error {
+	if a.tls.Enabled {
+		tlsConfig, err := util_tls.ServerConfig(a.tls.CertFile, a.tls.KeyFile, a.tls.ClientCaFile, a.tls.TlsMinVersion, a.tls.TlsCipherSuites)
+		if err != nil {
+			return err
+		}
+		a.server.TLSConfig = tlsConfig
+	}
 	errChan := make(chan error)
 	go func() {
-		err := a.server.ListenAndServe()
+		var err error
+		if a.tls.Enabled {
+			// certificate is already in TLSConfig
+			err = a.server.ListenAndServeTLS("", "")
+		} else {
+			err = a.server.ListenAndServe()
+		}
 		if err != nil {
 			switch err {
 			case http.ErrServerClosed:
@@ -77,7 +160,7 @@ func (a *ApiServer) Start(stop <-chan struct{}) error {
 			}
 		}
 	}()
-	log.Info("starting", "port", a.Address())
+	log.Info("starting", "port", a.Address(), "tls", a.tls.Enabled, "mtls", a.tls.ClientCaFile != "")
 	select {
 	case <-stop:
 		log.Info("Stopping down API Server")
@@ -88,6 +171,23 @@ func (a *ApiServer) Start(stop <-chan struct{}) error {
 }
 
 func SetupServer(rt runtime.Runtime) error {
-	apiServer := NewApiServer(rt.ResourceManager(), definitions.All, *rt.Config().ApiServer)
+	keyManager := issuer.NewSigningKeyManager(rt.SecretManager())
+	tokenIssuer := issuer.NewDataplaneTokenIssuerWithAllowedClockSkew(keyManager, rt.Config().SdsServer.CertRotation.AllowedClockSkew)
+	configFreeze := freeze_managers.NewConfigFreeze(rt.SecretManager())
+	// only changes made by users are subject to the freeze, Control Plane itself uses rt.ResourceManager()
+	resManager := freeze_managers.NewConfigFreezeManager(rt.ResourceManager(), configFreeze)
+	if webhooks := rt.Config().ApiServer.ValidationWebhooks; len(webhooks) > 0 {
+		resManager = validation_managers.NewWebhookValidationManager(resManager, webhooks, rt.EgressTransport())
+	}
+	// on Kubernetes Dataplanes are created out of Pods, not registered by dataplanes themselves
+	var registry *dataplane_managers.Registry
+	if rt.Config().Environment != kuma_cp.KubernetesEnvironment {
+		registry = dataplane_managers.NewRegistry(resManager)
+	}
+	if cfg := rt.Config().ApiServer; !cfg.ReadOnly && cfg.WriteToken == "" && cfg.WriteTokenFile == "" {
+		log.Info("changes of resources are NOT authenticated, anyone who can reach the API Server can create, update or delete resources. " +
+			"Set apiServer.writeToken or apiServer.writeTokenFile to authenticate them, or set apiServer.readOnly")
+	}
+	apiServer := NewApiServer(resManager, keyManager, tokenIssuer, rt.BuiltinCaManager(), rt.ProvidedCaManager(), rt.XDS().ConfigHistory(), configFreeze, rt.XDS().StreamTracker(), registry, definitions.All, *rt.Config().ApiServer)
 	return rt.Add(apiServer)
 }
@@ -0,0 +1,124 @@
+package api_server_test
+
+import (
+	"context"
+	gotls "crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	config "github.com/Kong/kuma/pkg/config/api-server"
+	"github.com/Kong/kuma/pkg/core/ca/builtin/issuer"
+	mesh_res "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/store"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+	"github.com/Kong/kuma/pkg/tls"
+)
+
+var _ = Describe("API Server with TLS", func() {
+
+	var dir string
+	var serverCert, clientCert tls.KeyPair
+	var clientCa []byte
+	var cfg *config.ApiServerConfig
+	var stop chan struct{}
+
+	writeFile := func(name string, content []byte) string {
+		file := filepath.Join(dir, name)
+		Expect(ioutil.WriteFile(file, content, 0600)).To(Succeed())
+		return file
+	}
+
+	httpsClient := func(clientCerts ...tls.KeyPair) *http.Client {
+		pool := x509.NewCertPool()
+		Expect(pool.AppendCertsFromPEM(serverCert.CertPEM)).To(BeTrue())
+		tlsConfig := &gotls.Config{RootCAs: pool}
+		for _, pair := range clientCerts {
+			cert, err := gotls.X509KeyPair(pair.CertPEM, pair.KeyPEM)
+			Expect(err).ToNot(HaveOccurred())
+			tlsConfig.Certificates = append(tlsConfig.Certificates, cert)
+		}
+		return &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "kuma-api-server")
+		Expect(err).ToNot(HaveOccurred())
+		serverCert, err = tls.NewSelfSignedCert("kuma-api-server", "localhost")
+		Expect(err).ToNot(HaveOccurred())
+		root, err := issuer.NewRootCA("clients")
+		Expect(err).ToNot(HaveOccurred())
+		clientCa = root.CertPEM
+		workloadCert, err := issuer.NewWorkloadCert(*root, "clients", "kumactl")
+		Expect(err).ToNot(HaveOccurred())
+		clientCert = *workloadCert
+
+		cfg = config.DefaultApiServerConfig()
+		cfg.Tls.Enabled = true
+		cfg.Tls.CertFile = writeFile("tls.crt", serverCert.CertPEM)
+		cfg.Tls.KeyFile = writeFile("tls.key", serverCert.KeyPEM)
+		stop = make(chan struct{})
+	})
+
+	AfterEach(func() {
+		close(stop)
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	start := func() string {
+		resourceStore := memory.NewStore()
+		err := resourceStore.Create(context.Background(), &mesh_res.MeshResource{}, store.CreateByKey("default", "default", "default"))
+		Expect(err).ToNot(HaveOccurred())
+		apiServer := createTestApiServer(resourceStore, *cfg)
+		go func() {
+			defer GinkgoRecover()
+			Expect(apiServer.Start(stop)).To(Succeed())
+		}()
+		return "https://localhost" + apiServer.Address() + "/meshes"
+	}
+
+	It("should serve over HTTPS", func() {
+		// given
+		url := start()
+
+		// when
+		var response *http.Response
+		Eventually(func() error {
+			var err error
+			response, err = httpsClient().Get(url)
+			return err
+		}, "5s", "100ms").ShouldNot(HaveOccurred())
+
+		// then
+		Expect(response.StatusCode).To(Equal(200))
+	})
+
+	It("should require a client certificate signed by client CA", func() {
+		// given
+		cfg.Tls.ClientCaFile = writeFile("ca.crt", clientCa)
+		url := start()
+
+		// when
+		var response *http.Response
+		Eventually(func() error {
+			var err error
+			response, err = httpsClient(clientCert).Get(url)
+			return err
+		}, "5s", "100ms").ShouldNot(HaveOccurred())
+
+		// then
+		Expect(response.StatusCode).To(Equal(200))
+
+		// when
+		_, err := httpsClient().Get(url)
+
+		// then
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,236 @@
+package api_server
+
+import (
+	"time"
+
+	"github.com/emicklei/go-restful"
+
+	"github.com/Kong/kuma/pkg/api-server/filters"
+	"github.com/Kong/kuma/pkg/config"
+	"github.com/Kong/kuma/pkg/core"
+	core_errors "github.com/Kong/kuma/pkg/core/errors"
+	"github.com/Kong/kuma/pkg/core/resources/store"
+	"github.com/Kong/kuma/pkg/tokens/builtin/issuer"
+)
+
+type signingKeysWs struct {
+	keyManager  issuer.SigningKeyManager
+	tokenIssuer issuer.DataplaneTokenIssuer
+	adminToken  config.Secret
+	readOnly    bool
+}
+
+type signingKeyEntry struct {
+	ID        string     `json:"id"`
+	Active    bool       `json:"active"`
+	CreatedAt time.Time  `json:"createdAt"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+type signingKeyList struct {
+	Items []signingKeyEntry `json:"items"`
+}
+
+type revokedTokenList struct {
+	Items []issuer.RevokedToken `json:"items"`
+}
+
+type revokeTokenRequest struct {
+	Token string `json:"token"`
+}
+
+type issueTokenRequest struct {
+	Name     string `json:"name"`
+	ValidFor string `json:"validFor,omitempty"`
+}
+
+type issueTokenResponse struct {
+	Token string `json:"token"`
+}
+
+func (s *signingKeysWs) AddToWs(ws *restful.WebService) {
+	ws.Route(ws.GET("/{mesh}/dataplane-token-signing-keys").To(s.listSigningKeys).
+		Doc("List keys that are used to sign Dataplane tokens").
+		Param(ws.PathParameter("mesh", "Name of a mesh").DataType("string")).
+		Returns(200, "OK", nil))
+
+	ws.Route(ws.GET("/{mesh}/revoked-dataplane-tokens").To(s.listRevokedTokens).
+		Doc("List revoked Dataplane tokens").
+		Param(ws.PathParameter("mesh", "Name of a mesh").DataType("string")).
+		Returns(200, "OK", nil))
+
+	if !s.readOnly {
+		ws.Route(ws.POST("/{mesh}/dataplane-token-signing-keys").To(s.rotateSigningKey).
+			Filter(filters.AdminToken(s.adminToken)).
+			Doc("Rotate a key that is used to sign Dataplane tokens. Requires the admin token").
+			Param(ws.PathParameter("mesh", "Name of a mesh").DataType("string")).
+			Param(ws.QueryParameter("gracePeriod", "How long tokens signed with the previous key are still accepted, e.g. 1h").DataType("string")).
+			Returns(201, "Created", nil).
+			Returns(400, "Bad request", nil).
+			Returns(401, "Unauthorized", nil).
+			Returns(403, "Forbidden", nil))
+
+		ws.Route(ws.DELETE("/{mesh}/dataplane-token-signing-keys/{id}").To(s.revokeSigningKey).
+			Filter(filters.AdminToken(s.adminToken)).
+			Doc("Revoke a key that is used to sign Dataplane tokens. Requires the admin token").
+			Param(ws.PathParameter("mesh", "Name of a mesh").DataType("string")).
+			Param(ws.PathParameter("id", "Id of a signing key").DataType("string")).
+			Returns(200, "OK", nil).
+			Returns(401, "Unauthorized", nil).
+			Returns(403, "Forbidden", nil).
+			Returns(404, "Not found", nil))
+
+		ws.Route(ws.POST("/{mesh}/dataplane-tokens").To(s.issueToken).
+			Filter(filters.AdminToken(s.adminToken)).
+			Doc("Issue a token that a Dataplane presents to the Control Plane to prove its identity").
+			Param(ws.PathParameter("mesh", "Name of a mesh").DataType("string")).
+			Returns(201, "Created", nil).
+			Returns(400, "Bad request", nil).
+			Returns(401, "Unauthorized", nil))
+
+		ws.Route(ws.POST("/{mesh}/revoked-dataplane-tokens").To(s.revokeToken).
+			Filter(filters.AdminToken(s.adminToken)).
+			Doc("Revoke a Dataplane token. Requires the admin token").
+			Param(ws.PathParameter("mesh", "Name of a mesh").DataType("string")).
+			Returns(201, "Created", nil).
+			Returns(400, "Bad request", nil).
+			Returns(401, "Unauthorized", nil).
+			Returns(403, "Forbidden", nil))
+	}
+}
+
+func (s *signingKeysWs) listSigningKeys(request *restful.Request, response *restful.Response) {
+	meshName := request.PathParameter("mesh")
+	list := signingKeyList{Items: []signingKeyEntry{}}
+	signingKeys, err := s.keyManager.GetSigningKeys(request.Request.Context(), meshName)
+	if err != nil && !store.IsResourceNotFound(err) {
+		core.Log.Error(err, "Could not retrieve signing keys", "mesh", meshName)
+		writeError(response, 500, core_errors.Internal, "Could not list signing keys")
+		return
+	}
+	if signingKeys != nil {
+		for _, key := range signingKeys.Keys {
+			list.Items = append(list.Items, toSigningKeyEntry(key))
+		}
+	}
+	if err := response.WriteAsJson(list); err != nil {
+		core.Log.Error(err, "Could not write the response")
+		writeError(response, 500, core_errors.Internal, "Could not list signing keys")
+	}
+}
+
+func (s *signingKeysWs) listRevokedTokens(request *restful.Request, response *restful.Response) {
+	meshName := request.PathParameter("mesh")
+	list := revokedTokenList{Items: []issuer.RevokedToken{}}
+	signingKeys, err := s.keyManager.GetSigningKeys(request.Request.Context(), meshName)
+	if err != nil && !store.IsResourceNotFound(err) {
+		core.Log.Error(err, "Could not retrieve signing keys", "mesh", meshName)
+		writeError(response, 500, core_errors.Internal, "Could not list revoked tokens")
+		return
+	}
+	if signingKeys != nil {
+		list.Items = append(list.Items, signingKeys.RevokedTokens...)
+	}
+	if err := response.WriteAsJson(list); err != nil {
+		core.Log.Error(err, "Could not write the response")
+		writeError(response, 500, core_errors.Internal, "Could not list revoked tokens")
+	}
+}
+
+func (s *signingKeysWs) rotateSigningKey(request *restful.Request, response *restful.Response) {
+	meshName := request.PathParameter("mesh")
+	gracePeriod := issuer.DefaultSigningKeyGracePeriod
+	if value := request.QueryParameter("gracePeriod"); value != "" {
+		duration, err := time.ParseDuration(value)
+		if err != nil || duration < 0 {
+			writeError(response, 400, core_errors.InvalidRequest, "gracePeriod has to be a non-negative duration, e.g. 1h")
+			return
+		}
+		gracePeriod = duration
+	}
+	key, err := s.keyManager.RotateSigningKey(request.Request.Context(), meshName, gracePeriod)
+	if err != nil {
+		core.Log.Error(err, "Could not rotate a signing key", "mesh", meshName)
+		writeError(response, 500, core_errors.Internal, "Could not rotate a signing key")
+		return
+	}
+	if err := response.WriteHeaderAndJson(201, toSigningKeyEntry(*key), restful.MIME_JSON); err != nil {
+		core.Log.Error(err, "Could not write the response")
+	}
+}
+
+func (s *signingKeysWs) revokeSigningKey(request *restful.Request, response *restful.Response) {
+	meshName := request.PathParameter("mesh")
+	id := request.PathParameter("id")
+	if err := s.keyManager.RevokeSigningKey(request.Request.Context(), meshName, id); err != nil {
+		if store.IsResourceNotFound(err) || issuer.IsSigningKeyNotFound(err) {
+			writeError(response, 404, core_errors.ResourceNotFound, "")
+		} else {
+			core.Log.Error(err, "Could not revoke a signing key", "mesh", meshName, "id", id)
+			writeError(response, 500, core_errors.Internal, "Could not revoke a signing key")
+		}
+	}
+}
+
+func (s *signingKeysWs) issueToken(request *restful.Request, response *restful.Response) {
+	meshName := request.PathParameter("mesh")
+	issueRequest := issueTokenRequest{}
+	if err := request.ReadEntity(&issueRequest); err != nil || issueRequest.Name == "" {
+		writeError(response, 400, core_errors.InvalidRequest, "Request has to contain a name of a dataplane")
+		return
+	}
+	var validFor time.Duration
+	if issueRequest.ValidFor != "" {
+		duration, err := time.ParseDuration(issueRequest.ValidFor)
+		if err != nil || duration <= 0 {
+			writeError(response, 400, core_errors.InvalidRequest, "validFor has to be a positive duration, e.g. 24h")
+			return
+		}
+		validFor = duration
+	}
+	identity := issuer.DataplaneIdentity{
+		Mesh: meshName,
+		Name: issueRequest.Name,
+	}
+	token, err := s.tokenIssuer.Generate(request.Request.Context(), identity, validFor)
+	if err != nil {
+		core.Log.Error(err, "Could not issue a token", "mesh", meshName, "name", issueRequest.Name)
+		writeError(response, 500, core_errors.Internal, "Could not issue a token")
+		return
+	}
+	core.Log.Info("issued a dataplane token", "mesh", meshName, "name", issueRequest.Name, "remoteAddr", request.Request.RemoteAddr)
+	if err := response.WriteHeaderAndJson(201, issueTokenResponse{Token: token}, restful.MIME_JSON); err != nil {
+		core.Log.Error(err, "Could not write the response")
+	}
+}
+
+func (s *signingKeysWs) revokeToken(request *restful.Request, response *restful.Response) {
+	meshName := request.PathParameter("mesh")
+	revokeRequest := revokeTokenRequest{}
+	if err := request.ReadEntity(&revokeRequest); err != nil || revokeRequest.Token == "" {
+		writeError(response, 400, core_errors.InvalidRequest, "Request has to contain a token")
+		return
+	}
+	if err := s.tokenIssuer.Revoke(request.Request.Context(), meshName, revokeRequest.Token); err != nil {
+		if store.IsResourceNotFound(err) {
+			writeError(response, 400, core_errors.ResourceNotFound, "There are no signing keys in the mesh")
+		} else if code := core_errors.CodeOf(err); code != "" {
+			// the token itself is invalid
+			writeError(response, 400, code, err.Error())
+		} else {
+			core.Log.Error(err, "Could not revoke a token", "mesh", meshName)
+			writeError(response, 500, core_errors.Internal, "Could not revoke a token")
+		}
+		return
+	}
+	response.WriteHeader(201)
+}
+
+func toSigningKeyEntry(key issuer.SigningKey) signingKeyEntry {
+	return signingKeyEntry{
+		ID:        key.ID,
+		Active:    key.IsActive(),
+		CreatedAt: key.CreatedAt,
+		ExpiresAt: key.ExpiresAt,
+	}
+}
@@ -0,0 +1,232 @@
+package api_server_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kong/kuma/pkg/api-server"
+	config "github.com/Kong/kuma/pkg/config/api-server"
+	"github.com/Kong/kuma/pkg/core/resources/model"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	secret_cipher "github.com/Kong/kuma/pkg/core/secrets/cipher"
+	secret_manager "github.com/Kong/kuma/pkg/core/secrets/manager"
+	secret_store "github.com/Kong/kuma/pkg/core/secrets/store"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+	"github.com/Kong/kuma/pkg/tokens/builtin/issuer"
+)
+
+var _ = Describe("Signing Keys WS", func() {
+	var apiServer *api_server.ApiServer
+	var stop chan struct{}
+	var baseUrl string
+	var store core_store.ResourceStore
+	var failUpdates bool
+
+	BeforeEach(func() {
+		failUpdates = false
+		store = &updateFailingStore{ResourceStore: memory.NewStore(), fail: &failUpdates}
+		cfg := config.DefaultApiServerConfig()
+		cfg.AdminToken = "admin-secret"
+		apiServer = createTestApiServer(store, *cfg)
+		client := resourceApiClient{
+			address: apiServer.Address(),
+			path:    "/meshes",
+		}
+		baseUrl = "http://" + apiServer.Address() + "/meshes/demo"
+		stop = make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			err := apiServer.Start(stop)
+			Expect(err).ToNot(HaveOccurred())
+		}()
+		waitForServer(&client)
+	}, 5)
+
+	AfterEach(func() {
+		close(stop)
+	})
+
+	type signingKey struct {
+		ID        string  `json:"id"`
+		Active    bool    `json:"active"`
+		ExpiresAt *string `json:"expiresAt"`
+	}
+
+	listKeys := func() []signingKey {
+		response, err := http.Get(baseUrl + "/dataplane-token-signing-keys")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(200))
+		body, err := ioutil.ReadAll(response.Body)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(body).ToNot(ContainSubstring("PRIVATE KEY"))
+		list := struct {
+			Items []signingKey `json:"items"`
+		}{}
+		Expect(json.Unmarshal(body, &list)).To(Succeed())
+		return list.Items
+	}
+
+	send := func(method string, url string, body string, adminToken string) *http.Response {
+		request, err := http.NewRequest(method, url, bytes.NewBufferString(body))
+		Expect(err).ToNot(HaveOccurred())
+		request.Header.Set("Content-Type", "application/json")
+		if adminToken != "" {
+			request.Header.Set("Authorization", "Bearer "+adminToken)
+		}
+		response, err := http.DefaultClient.Do(request)
+		Expect(err).ToNot(HaveOccurred())
+		return response
+	}
+
+	rotate := func(query string) *http.Response {
+		return send("POST", baseUrl+"/dataplane-token-signing-keys"+query, "", "admin-secret")
+	}
+
+	It("should rotate and revoke signing keys", func() {
+		// expect
+		Expect(listKeys()).To(BeEmpty())
+
+		// when
+		Expect(rotate("").StatusCode).To(Equal(201))
+		Expect(rotate("?gracePeriod=1h").StatusCode).To(Equal(201))
+
+		// then
+		keys := listKeys()
+		Expect(keys).To(HaveLen(2))
+		Expect(keys[0].Active).To(BeTrue())
+		Expect(keys[1].Active).To(BeFalse())
+		Expect(keys[1].ExpiresAt).ToNot(BeNil())
+
+		// when
+		url := fmt.Sprintf("%s/dataplane-token-signing-keys/%s", baseUrl, keys[1].ID)
+		response := send("DELETE", url, "", "admin-secret")
+
+		// then
+		Expect(response.StatusCode).To(Equal(200))
+		Expect(listKeys()).To(HaveLen(1))
+
+		// when
+		response = send("DELETE", url, "", "admin-secret")
+
+		// then
+		Expect(response.StatusCode).To(Equal(404))
+	})
+
+	It("should reject invalid grace period", func() {
+		// when
+		response := rotate("?gracePeriod=-1h")
+
+		// then
+		Expect(response.StatusCode).To(Equal(400))
+	})
+
+	It("should reject revocation of a malformed token", func() {
+		// given
+		Expect(rotate("").StatusCode).To(Equal(201))
+
+		// when
+		response := send("POST", baseUrl+"/revoked-dataplane-tokens", `{"token": "not-a-token"}`, "admin-secret")
+
+		// then
+		Expect(response.StatusCode).To(Equal(400))
+	})
+
+	It("should return 500 when a token could not be revoked because of the store", func() {
+		// given
+		Expect(rotate("").StatusCode).To(Equal(201))
+		token, err := issuer.NewDataplaneTokenIssuer(issuer.NewSigningKeyManager(secret_manager.NewSecretManager(secret_store.NewSecretStore(store), secret_cipher.None()))).
+			Generate(context.Background(), issuer.DataplaneIdentity{Mesh: "demo", Name: "backend-01"}, 0)
+		Expect(err).ToNot(HaveOccurred())
+
+		// and
+		failUpdates = true
+
+		// when
+		response := send("POST", baseUrl+"/revoked-dataplane-tokens", fmt.Sprintf(`{"token": %q}`, token), "admin-secret")
+
+		// then
+		Expect(response.StatusCode).To(Equal(500))
+	})
+
+	It("should not change signing keys or revoke tokens without an admin token", func() {
+		// given
+		Expect(rotate("").StatusCode).To(Equal(201))
+		keys := listKeys()
+		Expect(keys).To(HaveLen(1))
+
+		// expect
+		Expect(send("POST", baseUrl+"/dataplane-token-signing-keys", "", "").StatusCode).To(Equal(401))
+		Expect(send("DELETE", baseUrl+"/dataplane-token-signing-keys/"+keys[0].ID, "", "").StatusCode).To(Equal(401))
+		Expect(send("POST", baseUrl+"/revoked-dataplane-tokens", `{"token": "not-a-token"}`, "not-a-secret").StatusCode).To(Equal(401))
+
+		// and
+		Expect(listKeys()).To(Equal(keys))
+	})
+
+	issue := func(body string, adminToken string) *http.Response {
+		return send("POST", baseUrl+"/dataplane-tokens", body, adminToken)
+	}
+
+	It("should issue a token of a dataplane", func() {
+		// when
+		response := issue(`{"name": "backend-01", "validFor": "24h"}`, "admin-secret")
+
+		// then
+		Expect(response.StatusCode).To(Equal(201))
+		issued := struct {
+			Token string `json:"token"`
+		}{}
+		Expect(json.NewDecoder(response.Body).Decode(&issued)).To(Succeed())
+
+		// and
+		keyManager := issuer.NewSigningKeyManager(secret_manager.NewSecretManager(secret_store.NewSecretStore(store), secret_cipher.None()))
+		identity, err := issuer.NewDataplaneTokenIssuer(keyManager).Validate(context.Background(), issued.Token)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(identity).To(Equal(issuer.DataplaneIdentity{Mesh: "demo", Name: "backend-01"}))
+	})
+
+	It("should not issue a token without an admin token", func() {
+		// when
+		response := issue(`{"name": "backend-01"}`, "")
+
+		// then
+		Expect(response.StatusCode).To(Equal(401))
+	})
+
+	It("should reject a request without a name", func() {
+		// when
+		response := issue(`{"validFor": "24h"}`, "admin-secret")
+
+		// then
+		Expect(response.StatusCode).To(Equal(400))
+	})
+
+	It("should reject invalid validity period", func() {
+		// when
+		response := issue(`{"name": "backend-01", "validFor": "-1h"}`, "admin-secret")
+
+		// then
+		Expect(response.StatusCode).To(Equal(400))
+	})
+})
+
+type updateFailingStore struct {
+	core_store.ResourceStore
+	fail *bool
+}
+
+func (s *updateFailingStore) Update(ctx context.Context, resource model.Resource, fs ...core_store.UpdateOptionsFunc) error {
+	if *s.fail {
+		return errors.New("store is unavailable")
+	}
+	return s.ResourceStore.Update(ctx, resource, fs...)
+}
@@ -0,0 +1,116 @@
+package api_server_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kong/kuma/pkg/api-server"
+	config "github.com/Kong/kuma/pkg/config/api-server"
+	"github.com/Kong/kuma/pkg/core/managers/validation"
+	mesh_res "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/model/rest"
+	"github.com/Kong/kuma/pkg/core/resources/store"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+	sample_proto "github.com/Kong/kuma/pkg/test/apis/sample/v1alpha1"
+	sample_model "github.com/Kong/kuma/pkg/test/resources/apis/sample"
+)
+
+var _ = Describe("Validation webhooks", func() {
+	var apiServer *api_server.ApiServer
+	var webhook *httptest.Server
+	var client resourceApiClient
+	var stop chan struct{}
+
+	BeforeEach(func() {
+		// only paths under /api are allowed
+		webhook = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			defer GinkgoRecover()
+			request := map[string]interface{}{}
+			Expect(json.NewDecoder(req.Body).Decode(&request)).To(Succeed())
+			path := request["resource"].(map[string]interface{})["path"]
+			response := validation.ValidationResponse{Allowed: path == "/api"}
+			if !response.Allowed {
+				response.Reason = "path must be /api"
+			}
+			Expect(json.NewEncoder(w).Encode(response)).To(Succeed())
+		}))
+
+		resourceStore := memory.NewStore()
+		err := resourceStore.Create(context.Background(), &mesh_res.MeshResource{}, store.CreateByKey("default", "default", "default"))
+		Expect(err).ToNot(HaveOccurred())
+
+		cfg := *config.DefaultApiServerConfig()
+		cfg.ValidationWebhooks = []config.ValidationWebhookConfig{{
+			Name:          "paths",
+			Url:           webhook.URL,
+			ResourceTypes: []string{string(sample_model.TrafficRouteType)},
+		}}
+		apiServer = createTestApiServer(resourceStore, cfg)
+		client = resourceApiClient{
+			address: apiServer.Address(),
+			path:    "/meshes/default/traffic-routes",
+		}
+		stop = make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			err := apiServer.Start(stop)
+			Expect(err).ToNot(HaveOccurred())
+		}()
+		waitForServer(&client)
+	}, 5)
+
+	AfterEach(func() {
+		close(stop)
+		webhook.Close()
+	})
+
+	trafficRoute := func(path string) rest.Resource {
+		return rest.Resource{
+			Meta: rest.ResourceMeta{
+				Name: "tr-1",
+				Mesh: "default",
+				Type: string(sample_model.TrafficRouteType),
+			},
+			Spec: &sample_proto.TrafficRoute{
+				Path: path,
+			},
+		}
+	}
+
+	It("should persist resources approved by webhooks", func() {
+		// when
+		response := client.put(trafficRoute("/api"))
+
+		// then
+		Expect(response.StatusCode).To(Equal(201))
+	})
+
+	It("should reject resources rejected by webhooks", func() {
+		// when
+		response := client.put(trafficRoute("/admin"))
+
+		// then
+		Expect(response.StatusCode).To(Equal(400))
+		Expect(response.Header.Get("X-Kuma-Error-Code")).To(Equal("VALIDATION_REJECTED"))
+		body, err := ioutil.ReadAll(response.Body)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(body)).To(Equal(`rejected by validation webhook: webhook "paths" has rejected the change, reason: "path must be /api"`))
+
+		// when
+		response = client.put(trafficRoute("/api"))
+		// then
+		Expect(response.StatusCode).To(Equal(201))
+
+		// when
+		response = client.patchJson("tr-1", []byte(`{"path": "/admin"}`))
+
+		// then
+		Expect(response.StatusCode).To(Equal(400))
+	})
+})
@@ -0,0 +1,43 @@
+package api_server
+
+import (
+	"io/ioutil"
+
+	"github.com/emicklei/go-restful"
+	"github.com/ghodss/yaml"
+)
+
+// mimeYaml lets clients send and receive resources in the same format that `kumactl apply` accepts.
+const mimeYaml = "application/yaml"
+
+func init() {
+	restful.RegisterEntityAccessor(mimeYaml, yamlEntityAccessor{})
+}
+
+// yamlEntityAccessor goes through JSON, so that custom JSON (un)marshalling of resources is respected.
+type yamlEntityAccessor struct{}
+
+var _ restful.EntityReaderWriter = yamlEntityAccessor{}
+
+func (yamlEntityAccessor) Read(request *restful.Request, v interface{}) error {
+	data, err := ioutil.ReadAll(request.Request.Body)
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(data, v)
+}
+
+func (yamlEntityAccessor) Write(response *restful.Response, status int, v interface{}) error {
+	if v == nil {
+		response.WriteHeader(status)
+		return nil
+	}
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return err
+	}
+	response.Header().Set(restful.HEADER_ContentType, mimeYaml)
+	response.WriteHeader(status)
+	_, err = response.Write(data)
+	return err
+}
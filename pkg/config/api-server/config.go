@@ -1,31 +1,183 @@
 package api_server
 
 import (
-	"errors"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
 
 	"github.com/Kong/kuma/pkg/config"
+	util_tls "github.com/Kong/kuma/pkg/tls"
 )
 
 var _ config.Config = &ApiServerConfig{}
 
 // API Server configuration
 type ApiServerConfig struct {
+	// If false, then API Server is not started, e.g. in minimal deployments that are managed by other means
+	Enabled bool `yaml:"enabled" envconfig:"kuma_api_server_enabled"`
 	// Port of the API Server
 	Port int `yaml:"port" envconfig:"kuma_api_server_port"`
 	// If true, then API Server will operate in read only mode (serving GET requests)
 	ReadOnly bool `yaml:"readOnly" envconfig:"kuma_api_server_read_only"`
+	// Token that has to be presented as "Authorization: Bearer <token>" to perform admin operations,
+	// e.g. disconnecting Dataplanes. Admin operations are disabled if empty
+	AdminToken string `yaml:"adminToken" envconfig:"kuma_api_server_admin_token"`
+	// Path to a file with the admin token. If set, it takes precedence over AdminToken.
+	// The file is read again once it has changed, so the token can be rotated without a restart
+	AdminTokenFile string `yaml:"adminTokenFile" envconfig:"kuma_api_server_admin_token_file"`
+	// Token that has to be presented as "Authorization: Bearer <token>" to create, update or delete resources.
+	// Changes of resources are NOT authenticated if empty, so anyone who can reach the API Server can modify them.
+	// Either set it or set readOnly, unless the API Server is only reachable by trusted clients
+	WriteToken string `yaml:"writeToken" envconfig:"kuma_api_server_write_token"`
+	// Path to a file with the write token. If set, it takes precedence over WriteToken
+	WriteTokenFile string `yaml:"writeTokenFile" envconfig:"kuma_api_server_write_token_file"`
+	// Token that has to be presented as "Authorization: Bearer <token>" to read resources. Write and admin tokens are accepted as well.
+	// Reads are not authenticated if empty
+	ReadToken string `yaml:"readToken" envconfig:"kuma_api_server_read_token"`
+	// Path to a file with the read token. If set, it takes precedence over ReadToken
+	ReadTokenFile string `yaml:"readTokenFile" envconfig:"kuma_api_server_read_token_file"`
+	// TLS of the API Server
+	Tls *ApiServerTlsConfig `yaml:"tls"`
+	// Access log of requests to the API Server
+	AccessLog *ApiServerAccessLogConfig `yaml:"accessLog"`
+	// Webhooks that have to approve changes of resources before the API Server persists them
+	ValidationWebhooks []ValidationWebhookConfig `yaml:"validationWebhooks,omitempty" ignored:"true"`
 }
 
 func (a *ApiServerConfig) Validate() error {
 	if a.Port < 0 {
 		return errors.New("Port cannot be negative")
 	}
+	if err := a.AccessLog.Validate(); err != nil {
+		return err
+	}
+	if err := a.Tls.Validate(); err != nil {
+		return errors.Wrap(err, "Tls validation failed")
+	}
+	for i, webhook := range a.ValidationWebhooks {
+		if err := webhook.Validate(); err != nil {
+			return errors.Wrapf(err, "ValidationWebhooks[%d] is not valid", i)
+		}
+	}
+	return nil
+}
+
+// AdminTokenSecret returns the admin token, either inline or from the file.
+func (a *ApiServerConfig) AdminTokenSecret() config.Secret {
+	return config.NewSecret(a.AdminToken, a.AdminTokenFile)
+}
+
+// WriteTokenSecret returns the write token, either inline or from the file.
+func (a *ApiServerConfig) WriteTokenSecret() config.Secret {
+	return config.NewSecret(a.WriteToken, a.WriteTokenFile)
+}
+
+// ReadTokenSecret returns the read token, either inline or from the file.
+func (a *ApiServerConfig) ReadTokenSecret() config.Secret {
+	return config.NewSecret(a.ReadToken, a.ReadTokenFile)
+}
+
+var _ config.Config = &ApiServerAccessLogConfig{}
+
+// Access log configuration of the API Server
+type ApiServerAccessLogConfig struct {
+	// If true, then requests to the API Server will be logged
+	Enabled bool `yaml:"enabled" envconfig:"kuma_api_server_access_log_enabled"`
+	// Fraction of requests that will be logged, from 0.0 to 1.0. Requests that failed with 5xx are always logged
+	SampleRate float64 `yaml:"sampleRate" envconfig:"kuma_api_server_access_log_sample_rate"`
+}
+
+func (a *ApiServerAccessLogConfig) Validate() error {
+	if a.SampleRate < 0 || a.SampleRate > 1 {
+		return errors.New("SampleRate must be between 0.0 and 1.0")
+	}
+	return nil
+}
+
+var _ config.Config = &ApiServerTlsConfig{}
+
+// TLS configuration of the API Server
+type ApiServerTlsConfig struct {
+	// If true, then API Server is served over HTTPS instead of HTTP
+	Enabled bool `yaml:"enabled" envconfig:"kuma_api_server_tls_enabled"`
+	// Path to a file with PEM-encoded TLS cert. If empty, a self-signed cert is auto-generated. The cert is loaded again once the file changes, so it can be renewed without a restart
+	CertFile string `yaml:"certFile" envconfig:"kuma_api_server_tls_cert_file"`
+	// Path to a file with PEM-encoded TLS key
+	KeyFile string `yaml:"keyFile" envconfig:"kuma_api_server_tls_key_file"`
+	// Hostnames or IP addresses that clients reach API Server at. They are put into the auto-generated cert.
+	// Defaults to the hostname of the machine and localhost if empty
+	Hosts []string `yaml:"hosts,omitempty" envconfig:"kuma_api_server_tls_hosts"`
+	// Path to a file with PEM-encoded CA certs. If set, clients have to present a certificate signed by one of them (mTLS)
+	ClientCaFile string `yaml:"clientCaFile" envconfig:"kuma_api_server_tls_client_ca_file"`
+	// Minimum TLS version, can be either "TLSv1_0", "TLSv1_1", "TLSv1_2" or "TLSv1_3". Go's default is used if empty
+	TlsMinVersion string `yaml:"tlsMinVersion" envconfig:"kuma_api_server_tls_min_version"`
+	// Cipher suites allowed in TLS 1.2 and below, in IANA format, e.g. TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256. Go's defaults are used if empty
+	TlsCipherSuites []string `yaml:"tlsCipherSuites,omitempty" envconfig:"kuma_api_server_tls_cipher_suites"`
+}
+
+func (a *ApiServerTlsConfig) Validate() error {
+	if a.CertFile == "" && a.KeyFile != "" {
+		return errors.New("CertFile cannot be empty if KeyFile has been set")
+	}
+	if a.KeyFile == "" && a.CertFile != "" {
+		return errors.New("KeyFile cannot be empty if CertFile has been set")
+	}
+	if !a.Enabled && a.ClientCaFile != "" {
+		return errors.New("ClientCaFile cannot be set if TLS is disabled")
+	}
+	if a.TlsMinVersion != "" {
+		if _, err := util_tls.ParseTlsVersion(a.TlsMinVersion); err != nil {
+			return errors.Wrap(err, "TlsMinVersion is not valid")
+		}
+	}
+	if _, err := util_tls.ParseCipherSuites(a.TlsCipherSuites); err != nil {
+		return errors.Wrap(err, "TlsCipherSuites are not valid")
+	}
+	return nil
+}
+
+var _ config.Config = &ValidationWebhookConfig{}
+
+// Validation webhook is an HTTP endpoint that approves or rejects changes of resources,
+// e.g. to enforce naming conventions of an organization
+type ValidationWebhookConfig struct {
+	// Name of the webhook that is shown to users once it rejects a change
+	Name string `yaml:"name"`
+	// URL that changed resources are POSTed to
+	Url string `yaml:"url"`
+	// Types of resources validated by the webhook, e.g. TrafficPermission. All types are validated if empty
+	ResourceTypes []string `yaml:"resourceTypes,omitempty"`
+	// Time limit for a call to the webhook. Defaults to 10s
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+	// If true, then changes are accepted when the webhook cannot be called
+	IgnoreFailures bool `yaml:"ignoreFailures,omitempty"`
+}
+
+func (w *ValidationWebhookConfig) Validate() error {
+	if w.Name == "" {
+		return errors.New("Name must not be empty")
+	}
+	if u, err := url.Parse(w.Url); err != nil || !u.IsAbs() {
+		return errors.New("Url must be an absolute URL")
+	}
+	if w.Timeout < 0 {
+		return errors.New("Timeout cannot be negative")
+	}
 	return nil
 }
 
 func DefaultApiServerConfig() *ApiServerConfig {
 	return &ApiServerConfig{
+		Enabled:  true,
 		Port:     5681,
 		ReadOnly: false,
+		AccessLog: &ApiServerAccessLogConfig{
+			Enabled:    false,
+			SampleRate: 1.0,
+		},
+		Tls: &ApiServerTlsConfig{
+			Enabled: false,
+		},
 	}
 }
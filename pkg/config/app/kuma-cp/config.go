@@ -1,13 +1,20 @@
 package kuma_cp
 
 import (
+	"os"
+
 	"github.com/Kong/kuma/api/mesh/v1alpha1"
 	"github.com/Kong/kuma/pkg/config"
 	api_server "github.com/Kong/kuma/pkg/config/api-server"
 	"github.com/Kong/kuma/pkg/config/core/discovery"
+	"github.com/Kong/kuma/pkg/config/core/egress"
+	"github.com/Kong/kuma/pkg/config/core/resources/quota"
 	"github.com/Kong/kuma/pkg/config/core/resources/store"
+	"github.com/Kong/kuma/pkg/config/core/runtime"
+	"github.com/Kong/kuma/pkg/config/core/watchdog"
 	"github.com/Kong/kuma/pkg/config/sds"
 	"github.com/Kong/kuma/pkg/config/xds"
+	util_tls "github.com/Kong/kuma/pkg/tls"
 	"github.com/Kong/kuma/pkg/util/proto"
 
 	"github.com/pkg/errors"
@@ -22,6 +29,26 @@ const (
 	UniversalEnvironment  EnvironmentType = "universal"
 )
 
+// Role defines which components of Control Plane are run by a given instance,
+// so that large deployments can scale and isolate them independently.
+//
+// Instances share resources through the ResourceStore, while state of Dataplanes connected to an instance,
+// i.e. history of their config, rollouts of policies, config propagation metrics and their connections,
+// is kept in memory of that instance only. API Server of an api-only instance has no Dataplanes connected to it,
+// so listing config generations and disconnecting Dataplanes only works on instances of role "all".
+type Role = string
+
+const (
+	// AllRole runs all components of Control Plane.
+	AllRole Role = "all"
+	// ApiOnlyRole runs API Server only.
+	ApiOnlyRole Role = "api-only"
+	// XdsOnlyRole runs xDS, SDS and Bootstrap servers only.
+	XdsOnlyRole Role = "xds-only"
+	// ControllersOnlyRole runs discovery of Dataplanes and maintenance of default resources only.
+	ControllersOnlyRole Role = "controllers-only"
+)
+
 var _ config.Config = &Defaults{}
 
 type Defaults struct {
@@ -50,6 +77,14 @@ type Reports struct {
 type Config struct {
 	// Environment Type, can be either "kubernetes" or "universal"
 	Environment EnvironmentType `yaml:"environment" envconfig:"kuma_environment"`
+	// Role of Control Plane, can be either "all", "api-only", "xds-only" or "controllers-only"
+	Role Role `yaml:"role" envconfig:"kuma_role"`
+	// If true then only FIPS-approved TLS versions, cipher suites and keys are allowed.
+	// Settings of TLS that are left empty default to FIPS-approved values.
+	FipsMode bool `yaml:"fipsMode" envconfig:"kuma_fips_mode"`
+	// Directory that Control Plane writes generated files to, e.g. auto-generated TLS certificates and dumps of its state.
+	// Defaults to the temporary directory of the OS. Must be writable when the root filesystem is read-only
+	WorkDir string `yaml:"workDir" envconfig:"kuma_work_dir"`
 	// Resource Store configuration
 	Store *store.StoreConfig `yaml:"store"`
 	// Discovery configuration
@@ -66,11 +101,20 @@ type Config struct {
 	Defaults *Defaults `yaml:"defaults"`
 	// Reports configuration
 	Reports *Reports `yaml:"reports"`
+	// Quotas on the number of resources in a single Mesh
+	Quota *quota.QuotaConfig `yaml:"quota"`
+	// Watchdog that restarts stuck components of Control Plane
+	Watchdog *watchdog.WatchdogConfig `yaml:"watchdog"`
+	// Proxy that outbound connections of Control Plane go through
+	EgressProxy *egress.EgressProxyConfig `yaml:"egressProxy"`
+	// Configuration specific to the environment Control Plane runs in
+	Runtime *runtime.RuntimeConfig `yaml:"runtime"`
 }
 
 func DefaultConfig() Config {
 	return Config{
 		Environment:     UniversalEnvironment,
+		Role:            AllRole,
 		Store:           store.DefaultStoreConfig(),
 		XdsServer:       xds.DefaultXdsServerConfig(),
 		SdsServer:       sds.DefaultSdsServerConfig(),
@@ -88,6 +132,10 @@ mtls:
 		Reports: &Reports{
 			Enabled: true,
 		},
+		Quota:       quota.DefaultQuotaConfig(),
+		Watchdog:    watchdog.DefaultWatchdogConfig(),
+		EgressProxy: egress.DefaultEgressProxyConfig(),
+		Runtime:     runtime.DefaultRuntimeConfig(),
 	}
 }
 
@@ -104,6 +152,17 @@ func (c *Config) Validate() error {
 	if c.Environment != KubernetesEnvironment && c.Environment != UniversalEnvironment {
 		return errors.Errorf("Environment should be either %s or %s", KubernetesEnvironment, UniversalEnvironment)
 	}
+	switch c.Role {
+	case AllRole, ApiOnlyRole, XdsOnlyRole, ControllersOnlyRole:
+	default:
+		return errors.Errorf("Role should be one of %q, %q, %q or %q", AllRole, ApiOnlyRole, XdsOnlyRole, ControllersOnlyRole)
+	}
+	if c.Role == ApiOnlyRole && !c.ApiServer.Enabled {
+		return errors.Errorf("API Server cannot be disabled in %q role", ApiOnlyRole)
+	}
+	if c.XdsServer.DataplaneAuth.Type == xds.ServiceAccountTokenDataplaneAuth && c.Environment != KubernetesEnvironment {
+		return errors.Errorf("Dataplane authentication of type %q is only supported in %s environment", xds.ServiceAccountTokenDataplaneAuth, KubernetesEnvironment)
+	}
 	if err := c.Store.Validate(); err != nil {
 		return errors.Wrap(err, "Store validation failed")
 	}
@@ -116,5 +175,90 @@ func (c *Config) Validate() error {
 	if err := c.Defaults.Validate(); err != nil {
 		return errors.Wrap(err, "Defaults validation failed")
 	}
+	if err := c.Quota.Validate(); err != nil {
+		return errors.Wrap(err, "Quota validation failed")
+	}
+	if err := c.Watchdog.Validate(); err != nil {
+		return errors.Wrap(err, "Watchdog validation failed")
+	}
+	if err := c.EgressProxy.Validate(); err != nil {
+		return errors.Wrap(err, "EgressProxy validation failed")
+	}
+	if c.Environment == KubernetesEnvironment {
+		if err := c.Runtime.Kubernetes.Validate(); err != nil {
+			return errors.Wrap(err, "Runtime validation failed")
+		}
+	}
+	if c.FipsMode {
+		if err := c.validateFips(); err != nil {
+			return errors.Wrap(err, "FIPS mode validation failed")
+		}
+	}
 	return nil
 }
+
+func (c *Config) validateFips() error {
+	if err := util_tls.ValidateFIPS(fipsTlsMinVersion(c.SdsServer.TlsMinVersion), fipsCipherSuites(c.SdsServer.TlsCipherSuites)); err != nil {
+		return errors.Wrap(err, "SDS Server TLS settings are not valid")
+	}
+	if err := util_tls.ValidateFIPS(fipsTlsMinVersion(c.XdsServer.MeshMtls.TlsMinVersion), fipsCipherSuites(c.XdsServer.MeshMtls.TlsCipherSuites)); err != nil {
+		return errors.Wrap(err, "Xds Server Mesh mTLS settings are not valid")
+	}
+	if err := util_tls.ValidateFIPS(fipsTlsMinVersion(c.XdsServer.Tls.TlsMinVersion), fipsCipherSuites(c.XdsServer.Tls.TlsCipherSuites)); err != nil {
+		return errors.Wrap(err, "Xds Server TLS settings are not valid")
+	}
+	if err := util_tls.ValidateFIPS(fipsTlsMinVersion(c.ApiServer.Tls.TlsMinVersion), fipsCipherSuites(c.ApiServer.Tls.TlsCipherSuites)); err != nil {
+		return errors.Wrap(err, "API Server TLS settings are not valid")
+	}
+	return nil
+}
+
+// WorkDirOrTemp returns the directory that Control Plane writes generated files to.
+func (c Config) WorkDirOrTemp() string {
+	if c.WorkDir != "" {
+		return c.WorkDir
+	}
+	return os.TempDir()
+}
+
+// StoreType returns the type of the resource store, which depends on the environment unless it is set explicitly.
+func (c Config) StoreType() store.StoreType {
+	if c.Store.Type != "" {
+		return c.Store.Type
+	}
+	if c.Environment == KubernetesEnvironment {
+		return store.KubernetesStore
+	}
+	return store.MemoryStore
+}
+
+// ApplyFipsDefaults sets TLS settings that are left empty to FIPS-approved values.
+func (c *Config) ApplyFipsDefaults() {
+	c.SdsServer.TlsMinVersion = fipsTlsMinVersion(c.SdsServer.TlsMinVersion)
+	c.SdsServer.TlsCipherSuites = fipsCipherSuites(c.SdsServer.TlsCipherSuites)
+	c.XdsServer.MeshMtls.TlsMinVersion = fipsTlsMinVersion(c.XdsServer.MeshMtls.TlsMinVersion)
+	c.XdsServer.MeshMtls.TlsCipherSuites = fipsCipherSuites(c.XdsServer.MeshMtls.TlsCipherSuites)
+	c.XdsServer.Tls.TlsMinVersion = fipsTlsMinVersion(c.XdsServer.Tls.TlsMinVersion)
+	c.XdsServer.Tls.TlsCipherSuites = fipsCipherSuites(c.XdsServer.Tls.TlsCipherSuites)
+	c.ApiServer.Tls.TlsMinVersion = fipsTlsMinVersion(c.ApiServer.Tls.TlsMinVersion)
+	c.ApiServer.Tls.TlsCipherSuites = fipsCipherSuites(c.ApiServer.Tls.TlsCipherSuites)
+}
+
+func fipsTlsMinVersion(version string) string {
+	if version == "" {
+		return util_tls.FIPSTlsMinVersion
+	}
+	return version
+}
+
+func fipsCipherSuites(cipherSuites []string) []string {
+	if len(cipherSuites) == 0 {
+		return util_tls.FIPSCipherSuites()
+	}
+	return cipherSuites
+}
+
+// HasRole returns true if Control Plane runs components of a given role.
+func (c *Config) HasRole(role Role) bool {
+	return c.Role == AllRole || c.Role == role
+}
@@ -0,0 +1,92 @@
+package kuma_cp
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kong/kuma/pkg/config/core/resources/store"
+	"github.com/Kong/kuma/pkg/config/sds"
+)
+
+var _ = Describe("Config", func() {
+
+	type testCase struct {
+		environment EnvironmentType
+		storeType   store.StoreType
+		expected    store.StoreType
+	}
+
+	DescribeTable("should pick a resource store",
+		func(given testCase) {
+			// given
+			cfg := DefaultConfig()
+			cfg.Environment = given.environment
+			cfg.Store.Type = given.storeType
+
+			// expect
+			Expect(cfg.StoreType()).To(Equal(given.expected))
+		},
+		Entry("kubernetes environment", testCase{
+			environment: KubernetesEnvironment,
+			expected:    store.KubernetesStore,
+		}),
+		Entry("universal environment", testCase{
+			environment: UniversalEnvironment,
+			expected:    store.MemoryStore,
+		}),
+		Entry("store set explicitly", testCase{
+			environment: UniversalEnvironment,
+			storeType:   store.PostgresStore,
+			expected:    store.PostgresStore,
+		}),
+	)
+	It("should not allow to disable API Server in api-only role", func() {
+		// given
+		cfg := DefaultConfig()
+		cfg.Role = ApiOnlyRole
+		cfg.ApiServer.Enabled = false
+
+		// when
+		err := cfg.Validate()
+
+		// then
+		Expect(err).To(MatchError(`API Server cannot be disabled in "api-only" role`))
+	})
+
+	It("should allow to disable renewal of Workload Identity certificates", func() {
+		// given
+		cfg := DefaultConfig()
+		cfg.SdsServer.CertRotation.RenewalThreshold = 0
+
+		// expect
+		Expect(cfg.Validate()).To(Succeed())
+	})
+
+	It("should not allow provided CAs with the same name", func() {
+		// given
+		cfg := DefaultConfig()
+		cfg.SdsServer.ProvidedCas = []*sds.ProvidedCaConfig{
+			{Name: "corporate-ca", CertFile: "/etc/kuma/a.crt", KeyFile: "/etc/kuma/a.key"},
+			{Name: "corporate-ca", CertFile: "/etc/kuma/b.crt", KeyFile: "/etc/kuma/b.key"},
+		}
+
+		// when
+		err := cfg.Validate()
+
+		// then
+		Expect(err).To(MatchError(`SDS Server validation failed: ProvidedCas[1] validation failed: Name "corporate-ca" is not unique`))
+	})
+
+	It("should not allow universal discovery without a polling interval", func() {
+		// given
+		cfg := DefaultConfig()
+		cfg.Discovery.Universal.PollingInterval = 0
+
+		// when
+		err := cfg.Validate()
+
+		// then
+		Expect(err).To(MatchError(`Discovery validation failed: Discovery validation failed: PollingInterval must be positive`))
+	})
+})
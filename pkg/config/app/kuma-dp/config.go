@@ -1,7 +1,9 @@
 package kumadp
 
 import (
+	"net"
 	"net/url"
+	"time"
 
 	"github.com/Kong/kuma/pkg/config"
 
@@ -15,11 +17,25 @@ func DefaultConfig() Config {
 			BootstrapServer: BootstrapServer{
 				URL: "", // Address of the Bootstrap Server must be set explicitly
 			},
+			Retry: Retry{
+				MaxRetries: 5,
+				Backoff:    time.Second,
+				MaxBackoff: 30 * time.Second,
+			},
 		},
 		Dataplane: Dataplane{
-			Mesh:      "default",
-			Name:      "", // Dataplane name must be set explicitly
-			AdminPort: 0,  // by default, turn off Admin interface of Envoy
+			Mesh:        "default",
+			Name:        "", // Dataplane name must be set explicitly
+			AdminPort:   0,  // by default, turn off Admin interface of Envoy
+			MetricsPort: 0,  // by default, turn off metrics of kuma-dp
+			InboundDiscovery: InboundDiscovery{
+				Enabled:    false,
+				PortOffset: 10000,
+			},
+			ResiliencyEvents: ResiliencyEvents{
+				Enabled:       false,
+				CheckInterval: 5 * time.Second,
+			},
 		},
 		DataplaneRuntime: DataplaneRuntime{
 			BinaryPath: "envoy",
@@ -42,6 +58,25 @@ type Config struct {
 type ControlPlane struct {
 	// BootstrapServer defines coordinates of the Control Plane Bootstrap Server.
 	BootstrapServer BootstrapServer `yaml:"bootstrapServer,omitempty"`
+	// Retry defines how requests to the Control Plane that have failed temporarily are retried.
+	Retry Retry `yaml:"retry,omitempty"`
+}
+
+// Retry defines how requests to the Control Plane that have failed temporarily are retried.
+//
+// Backoff between retries grows exponentially and is randomized, and retries stop altogether
+// once most of requests fail, so that many dataplanes don't overwhelm a recovering Control Plane.
+//
+// It applies to requests of kuma-dp, i.e. fetching a bootstrap config, also when the token is rotated,
+// and reporting resiliency events. Envoy reconnects to the XDS Server on its own with a jittered backoff
+// of its own, which cannot be configured.
+type Retry struct {
+	// Maximum number of retries of a single request.
+	MaxRetries int `yaml:"maxRetries" envconfig:"kuma_control_plane_retry_max_retries"`
+	// How long to wait before the first retry. Every next retry waits twice as long.
+	Backoff time.Duration `yaml:"backoff,omitempty" envconfig:"kuma_control_plane_retry_backoff"`
+	// Maximum time between retries.
+	MaxBackoff time.Duration `yaml:"maxBackoff,omitempty" envconfig:"kuma_control_plane_retry_max_backoff"`
 }
 
 type BootstrapServer struct {
@@ -57,6 +92,45 @@ type Dataplane struct {
 	Name string `yaml:"name,omitempty" envconfig:"kuma_dataplane_name"`
 	// Envoy Admin port.
 	AdminPort uint32 `yaml:"adminPort,omitempty" envconfig:"kuma_dataplane_admin_port"`
+	// Port on which metrics of kuma-dp itself, e.g. retries of requests to the Control Plane, are exposed. Zero turns metrics off.
+	MetricsPort uint32 `yaml:"metricsPort,omitempty" envconfig:"kuma_dataplane_metrics_port"`
+	// InboundDiscovery defines how inbound interfaces of the dataplane are discovered (universal mode only).
+	InboundDiscovery InboundDiscovery `yaml:"inboundDiscovery,omitempty"`
+	// ResiliencyEvents defines how activity of circuit breakers, retry budgets and outlier detection of the dataplane (Envoy) is reported.
+	ResiliencyEvents ResiliencyEvents `yaml:"resiliencyEvents,omitempty"`
+}
+
+// ResiliencyEvents defines how activity of circuit breakers, retry budgets and outlier detection of the dataplane (Envoy) is reported.
+//
+// Once enabled, stats of Envoy are periodically checked through its Admin interface
+// and every circuit breaker that opens or closes and every rejected request or retry are logged as events.
+// They are also reported to the Control Plane, which exposes them as metrics
+// dataplane_circuit_breaker_open and dataplane_traffic_rejected_total.
+// Endpoints ejected by outlier detection become visible in DataplaneInsight.
+type ResiliencyEvents struct {
+	// If true, activity of circuit breakers, retry budgets and outlier detection is reported. Requires Envoy Admin port to be set.
+	Enabled bool `yaml:"enabled,omitempty" envconfig:"kuma_dataplane_resiliency_events_enabled"`
+	// How often stats of Envoy are checked.
+	CheckInterval time.Duration `yaml:"checkInterval,omitempty" envconfig:"kuma_dataplane_resiliency_events_check_interval"`
+}
+
+// InboundDiscovery defines how inbound interfaces of the dataplane are discovered.
+//
+// Once enabled, inbound interfaces are populated out of ports that the workload listens on,
+// which makes it unnecessary to apply a Dataplane resource in advance.
+// The Control Plane only accepts inbound interfaces from a dataplane that presents a Dataplane token
+// issued for its mesh and name, see DataplaneRuntime.TokenPath.
+type InboundDiscovery struct {
+	// If true, inbound interfaces of the Dataplane resource are populated automatically.
+	Enabled bool `yaml:"enabled,omitempty" envconfig:"kuma_dataplane_inbound_discovery_enabled"`
+	// IP address of the dataplane that other dataplanes can reach it at.
+	Address string `yaml:"address,omitempty" envconfig:"kuma_dataplane_inbound_discovery_address"`
+	// Ports of the workload. If empty, ports are discovered out of TCP sockets in the LISTEN state.
+	Ports []uint32 `yaml:"ports,omitempty" envconfig:"kuma_dataplane_inbound_discovery_ports"`
+	// Offset between a port of the workload and a port the dataplane listens on, e.g. 18080 for 8080.
+	PortOffset uint32 `yaml:"portOffset,omitempty" envconfig:"kuma_dataplane_inbound_discovery_port_offset"`
+	// Tags of inbound interfaces, e.g. service=web,version=1.0. `service` tag is mandatory.
+	Tags map[string]string `yaml:"tags,omitempty" envconfig:"kuma_dataplane_inbound_discovery_tags"`
 }
 
 // DataplaneRuntime defines the context in which dataplane (Envoy) runs.
@@ -65,6 +139,13 @@ type DataplaneRuntime struct {
 	BinaryPath string `yaml:"binaryPath,omitempty" envconfig:"kuma_dataplane_runtime_binary_path"`
 	// Dir to store auto-generated Envoy bootstrap config in.
 	ConfigDir string `yaml:"configDir,omitempty" envconfig:"kuma_dataplane_runtime_config_dir"`
+	// Path to a file with a token that dataplane presents to the Control Plane to prove its identity.
+	TokenPath string `yaml:"tokenPath,omitempty" envconfig:"kuma_dataplane_runtime_token_path"`
+	// Path to a file with a PEM-encoded certificate that dataplane (Envoy) presents to the XDS Server,
+	// e.g. if the XDS Server only accepts certificates signed by a CA of an organization.
+	ClientCertPath string `yaml:"clientCertPath,omitempty" envconfig:"kuma_dataplane_runtime_client_cert_path"`
+	// Path to a file with a PEM-encoded key of the client certificate.
+	ClientKeyPath string `yaml:"clientKeyPath,omitempty" envconfig:"kuma_dataplane_runtime_client_key_path"`
 }
 
 var _ config.Config = &Config{}
@@ -88,6 +169,24 @@ func (c *ControlPlane) Validate() (errs error) {
 	if err := c.BootstrapServer.Validate(); err != nil {
 		errs = multierr.Append(errs, errors.Wrapf(err, ".BootstrapServer is not valid"))
 	}
+	if err := c.Retry.Validate(); err != nil {
+		errs = multierr.Append(errs, errors.Wrapf(err, ".Retry is not valid"))
+	}
+	return
+}
+
+var _ config.Config = &Retry{}
+
+func (r *Retry) Validate() (errs error) {
+	if r.MaxRetries < 0 {
+		errs = multierr.Append(errs, errors.Errorf(".MaxRetries must not be negative"))
+	}
+	if r.Backoff <= 0 {
+		errs = multierr.Append(errs, errors.Errorf(".Backoff must be positive"))
+	}
+	if r.MaxBackoff < r.Backoff {
+		errs = multierr.Append(errs, errors.Errorf(".MaxBackoff must not be less than .Backoff"))
+	}
 	return
 }
 
@@ -103,6 +202,54 @@ func (d *Dataplane) Validate() (errs error) {
 	if 65535 < d.AdminPort {
 		errs = multierr.Append(errs, errors.Errorf(".AdminPort must be in the range [0, 65535]"))
 	}
+	if 65535 < d.MetricsPort {
+		errs = multierr.Append(errs, errors.Errorf(".MetricsPort must be in the range [0, 65535]"))
+	}
+	if err := d.InboundDiscovery.Validate(); err != nil {
+		errs = multierr.Append(errs, errors.Wrapf(err, ".InboundDiscovery is not valid"))
+	}
+	if err := d.ResiliencyEvents.Validate(); err != nil {
+		errs = multierr.Append(errs, errors.Wrapf(err, ".ResiliencyEvents is not valid"))
+	}
+	if d.ResiliencyEvents.Enabled && d.AdminPort == 0 {
+		errs = multierr.Append(errs, errors.Errorf(".AdminPort must be set when .ResiliencyEvents are enabled"))
+	}
+	return
+}
+
+var _ config.Config = &ResiliencyEvents{}
+
+func (r *ResiliencyEvents) Validate() (errs error) {
+	if !r.Enabled {
+		return
+	}
+	if r.CheckInterval <= 0 {
+		errs = multierr.Append(errs, errors.Errorf(".CheckInterval must be positive"))
+	}
+	return
+}
+
+var _ config.Config = &InboundDiscovery{}
+
+func (d *InboundDiscovery) Validate() (errs error) {
+	if !d.Enabled {
+		return
+	}
+	if net.ParseIP(d.Address) == nil {
+		errs = multierr.Append(errs, errors.Errorf(".Address must be a valid IP address"))
+	}
+	for _, port := range d.Ports {
+		if port < 1 || 65535 < port {
+			errs = multierr.Append(errs, errors.Errorf(".Ports must be in the range [1, 65535]"))
+			break
+		}
+	}
+	if d.PortOffset < 1 || 65535 < d.PortOffset {
+		errs = multierr.Append(errs, errors.Errorf(".PortOffset must be in the range [1, 65535]"))
+	}
+	if d.Tags["service"] == "" {
+		errs = multierr.Append(errs, errors.Errorf(".Tags must include \"service\" tag"))
+	}
 	return
 }
 
@@ -115,6 +262,9 @@ func (d *DataplaneRuntime) Validate() (errs error) {
 	if d.ConfigDir == "" {
 		errs = multierr.Append(errs, errors.Errorf(".ConfigDir must be non-empty"))
 	}
+	if (d.ClientCertPath == "") != (d.ClientKeyPath == "") {
+		errs = multierr.Append(errs, errors.Errorf(".ClientCertPath and .ClientKeyPath must be set together"))
+	}
 	return
 }
 
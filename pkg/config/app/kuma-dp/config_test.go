@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -26,7 +27,21 @@ var _ = Describe("Config", func() {
 
 		// and
 		Expect(cfg.ControlPlane.BootstrapServer.URL).To(Equal("https://kuma-control-plane.internal:5682"))
+		Expect(cfg.ControlPlane.Retry.MaxRetries).To(Equal(3))
+		Expect(cfg.ControlPlane.Retry.Backoff).To(Equal(2 * time.Second))
+		Expect(cfg.ControlPlane.Retry.MaxBackoff).To(Equal(time.Minute))
 		Expect(cfg.Dataplane.AdminPort).To(Equal(uint32(2345)))
+		Expect(cfg.Dataplane.MetricsPort).To(Equal(uint32(2346)))
+		Expect(cfg.DataplaneRuntime.TokenPath).To(Equal("/var/run/secrets/token"))
+		Expect(cfg.DataplaneRuntime.ClientCertPath).To(Equal("/var/run/secrets/tls.crt"))
+		Expect(cfg.DataplaneRuntime.ClientKeyPath).To(Equal("/var/run/secrets/tls.key"))
+		Expect(cfg.Dataplane.InboundDiscovery.Enabled).To(BeTrue())
+		Expect(cfg.Dataplane.InboundDiscovery.Address).To(Equal("192.168.0.1"))
+		Expect(cfg.Dataplane.InboundDiscovery.Ports).To(Equal([]uint32{8080, 9090}))
+		Expect(cfg.Dataplane.InboundDiscovery.PortOffset).To(Equal(uint32(20000)))
+		Expect(cfg.Dataplane.InboundDiscovery.Tags).To(Equal(map[string]string{"service": "web", "version": "v1"}))
+		Expect(cfg.Dataplane.ResiliencyEvents.Enabled).To(BeTrue())
+		Expect(cfg.Dataplane.ResiliencyEvents.CheckInterval).To(Equal(10 * time.Second))
 	})
 
 	Context("with modified environment variables", func() {
@@ -48,12 +63,26 @@ var _ = Describe("Config", func() {
 		It("should be loadable from environment variables", func() {
 			// setup
 			env := map[string]string{
-				"KUMA_CONTROL_PLANE_BOOTSTRAP_SERVER_URL": "https://kuma-control-plane.internal:5682",
-				"KUMA_DATAPLANE_MESH":                     "pilot",
-				"KUMA_DATAPLANE_NAME":                     "example",
-				"KUMA_DATAPLANE_ADMIN_PORT":               "2345",
-				"KUMA_DATAPLANE_RUNTIME_BINARY_PATH":      "envoy.sh",
-				"KUMA_DATAPLANE_RUNTIME_CONFIG_DIR":       "/var/run/envoy",
+				"KUMA_CONTROL_PLANE_BOOTSTRAP_SERVER_URL":         "https://kuma-control-plane.internal:5682",
+				"KUMA_CONTROL_PLANE_RETRY_MAX_RETRIES":            "3",
+				"KUMA_CONTROL_PLANE_RETRY_BACKOFF":                "2s",
+				"KUMA_CONTROL_PLANE_RETRY_MAX_BACKOFF":            "1m",
+				"KUMA_DATAPLANE_MESH":                             "pilot",
+				"KUMA_DATAPLANE_NAME":                             "example",
+				"KUMA_DATAPLANE_ADMIN_PORT":                       "2345",
+				"KUMA_DATAPLANE_METRICS_PORT":                     "2346",
+				"KUMA_DATAPLANE_RUNTIME_BINARY_PATH":              "envoy.sh",
+				"KUMA_DATAPLANE_RUNTIME_CONFIG_DIR":               "/var/run/envoy",
+				"KUMA_DATAPLANE_RUNTIME_TOKEN_PATH":               "/var/run/secrets/token",
+				"KUMA_DATAPLANE_RUNTIME_CLIENT_CERT_PATH":         "/var/run/secrets/tls.crt",
+				"KUMA_DATAPLANE_RUNTIME_CLIENT_KEY_PATH":          "/var/run/secrets/tls.key",
+				"KUMA_DATAPLANE_INBOUND_DISCOVERY_ENABLED":        "true",
+				"KUMA_DATAPLANE_INBOUND_DISCOVERY_ADDRESS":        "192.168.0.1",
+				"KUMA_DATAPLANE_INBOUND_DISCOVERY_PORTS":          "8080,9090",
+				"KUMA_DATAPLANE_INBOUND_DISCOVERY_PORT_OFFSET":    "20000",
+				"KUMA_DATAPLANE_INBOUND_DISCOVERY_TAGS":           "service:web,version:v1",
+				"KUMA_DATAPLANE_RESILIENCY_EVENTS_ENABLED":        "true",
+				"KUMA_DATAPLANE_RESILIENCY_EVENTS_CHECK_INTERVAL": "10s",
 			}
 			for key, value := range env {
 				os.Setenv(key, value)
@@ -70,11 +99,26 @@ var _ = Describe("Config", func() {
 
 			// and
 			Expect(cfg.ControlPlane.BootstrapServer.URL).To(Equal("https://kuma-control-plane.internal:5682"))
+			Expect(cfg.ControlPlane.Retry.MaxRetries).To(Equal(3))
+			Expect(cfg.ControlPlane.Retry.Backoff).To(Equal(2 * time.Second))
+			Expect(cfg.ControlPlane.Retry.MaxBackoff).To(Equal(time.Minute))
 			Expect(cfg.Dataplane.Mesh).To(Equal("pilot"))
 			Expect(cfg.Dataplane.Name).To(Equal("example"))
 			Expect(cfg.Dataplane.AdminPort).To(Equal(uint32(2345)))
+			Expect(cfg.Dataplane.MetricsPort).To(Equal(uint32(2346)))
+			Expect(cfg.Dataplane.MetricsPort).To(Equal(uint32(2346)))
 			Expect(cfg.DataplaneRuntime.BinaryPath).To(Equal("envoy.sh"))
 			Expect(cfg.DataplaneRuntime.ConfigDir).To(Equal("/var/run/envoy"))
+			Expect(cfg.DataplaneRuntime.TokenPath).To(Equal("/var/run/secrets/token"))
+			Expect(cfg.DataplaneRuntime.ClientCertPath).To(Equal("/var/run/secrets/tls.crt"))
+			Expect(cfg.DataplaneRuntime.ClientKeyPath).To(Equal("/var/run/secrets/tls.key"))
+			Expect(cfg.Dataplane.InboundDiscovery.Enabled).To(BeTrue())
+			Expect(cfg.Dataplane.InboundDiscovery.Address).To(Equal("192.168.0.1"))
+			Expect(cfg.Dataplane.InboundDiscovery.Ports).To(Equal([]uint32{8080, 9090}))
+			Expect(cfg.Dataplane.InboundDiscovery.PortOffset).To(Equal(uint32(20000)))
+			Expect(cfg.Dataplane.InboundDiscovery.Tags).To(Equal(map[string]string{"service": "web", "version": "v1"}))
+			Expect(cfg.Dataplane.ResiliencyEvents.Enabled).To(BeTrue())
+			Expect(cfg.Dataplane.ResiliencyEvents.CheckInterval).To(Equal(10 * time.Second))
 		})
 	})
 
@@ -103,6 +147,6 @@ var _ = Describe("Config", func() {
 		err := config.Load(filepath.Join("testdata", "invalid-config.input.yaml"), &cfg)
 
 		// then
-		Expect(err).To(MatchError(`Invalid configuration: .ControlPlane is not valid: .BootstrapServer is not valid: .URL must be a valid absolute URI; .Dataplane is not valid: .Mesh must be non-empty; .Name must be non-empty; .AdminPort must be in the range [0, 65535]; .DataplaneRuntime is not valid: .BinaryPath must be non-empty; .ConfigDir must be non-empty`))
+		Expect(err).To(MatchError(`Invalid configuration: .ControlPlane is not valid: .BootstrapServer is not valid: .URL must be a valid absolute URI; .Retry is not valid: .MaxRetries must not be negative; .Backoff must be positive; .Dataplane is not valid: .Mesh must be non-empty; .Name must be non-empty; .AdminPort must be in the range [0, 65535]; .MetricsPort must be in the range [0, 65535]; .InboundDiscovery is not valid: .Address must be a valid IP address; .PortOffset must be in the range [1, 65535]; .Tags must include "service" tag; .ResiliencyEvents is not valid: .CheckInterval must be positive; .DataplaneRuntime is not valid: .BinaryPath must be non-empty; .ConfigDir must be non-empty; .ClientCertPath and .ClientKeyPath must be set together`))
 	})
 })
@@ -3,11 +3,14 @@ package kumainjector
 import (
 	"net"
 	"net/url"
+	"sort"
+	"time"
 
 	"github.com/Kong/kuma/pkg/config"
 
 	"github.com/pkg/errors"
 	"go.uber.org/multierr"
+	kube_api "k8s.io/apimachinery/pkg/api/resource"
 )
 
 func DefaultConfig() Config {
@@ -19,6 +22,16 @@ func DefaultConfig() Config {
 			Port: 8443,
 			// CertDir has no default value and must always be set explicitly.
 			CertDir: "",
+			CertRotation: CertRotation{
+				Enabled:                  false,
+				SecretNamespace:          "kuma-system",
+				SecretName:               "kuma-injector-tls-cert",
+				ServiceName:              "kuma-injector",
+				WebhookConfigurationName: "kuma-injector-webhook-configuration",
+				ValidityPeriod:           365 * 24 * time.Hour,
+				RenewBefore:              30 * 24 * time.Hour,
+				CheckInterval:            1 * time.Hour,
+			},
 		},
 		Injector: Injector{
 			ControlPlane: ControlPlane{
@@ -35,9 +48,25 @@ func DefaultConfig() Config {
 				UID:          5678,
 				GID:          5678,
 				AdminPort:    9901,
+				Resources: ContainerResources{
+					Limits: map[string]string{
+						"cpu":    "50m",
+						"memory": "64M",
+					},
+				},
 			},
 			InitContainer: InitContainer{
 				Image: "docker.io/istio/proxy_init:1.1.2",
+				Resources: ContainerResources{
+					Limits: map[string]string{
+						"cpu":    "100m",
+						"memory": "50M",
+					},
+					Requests: map[string]string{
+						"cpu":    "10m",
+						"memory": "10M",
+					},
+				},
 			},
 		},
 	}
@@ -61,6 +90,31 @@ type WebHookServer struct {
 	// TLS certificate file must be named `tls.crt`.
 	// TLS key file must be named `tls.key`.
 	CertDir string `yaml:"certDir,omitempty" envconfig:"kuma_injector_webhook_server_cert_dir"`
+	// CertRotation defines configuration of automatic rotation of TLS certificate of the https server.
+	CertRotation CertRotation `yaml:"certRotation,omitempty"`
+}
+
+// CertRotation defines configuration of automatic rotation of TLS certificate of the https server.
+//
+// When enabled, a self-signed certificate is kept in a Kubernetes Secret, renewed before it expires,
+// written into CertDir and put into CA bundle of the Mutating WebHook Configuration.
+type CertRotation struct {
+	// Enabled defines whether TLS certificate should be managed by Kuma Injector itself.
+	Enabled bool `yaml:"enabled" envconfig:"kuma_injector_webhook_server_cert_rotation_enabled"`
+	// SecretNamespace defines Namespace of a Secret to keep TLS certificate in.
+	SecretNamespace string `yaml:"secretNamespace,omitempty" envconfig:"kuma_injector_webhook_server_cert_rotation_secret_namespace"`
+	// SecretName defines name of a Secret to keep TLS certificate in.
+	SecretName string `yaml:"secretName,omitempty" envconfig:"kuma_injector_webhook_server_cert_rotation_secret_name"`
+	// ServiceName defines name of a Service in front of Kuma Injector, TLS certificate is issued for.
+	ServiceName string `yaml:"serviceName,omitempty" envconfig:"kuma_injector_webhook_server_cert_rotation_service_name"`
+	// WebhookConfigurationName defines name of a Mutating WebHook Configuration to update CA bundle of.
+	WebhookConfigurationName string `yaml:"webhookConfigurationName,omitempty" envconfig:"kuma_injector_webhook_server_cert_rotation_webhook_configuration_name"`
+	// ValidityPeriod defines how long a newly generated TLS certificate is valid for.
+	ValidityPeriod time.Duration `yaml:"validityPeriod,omitempty" envconfig:"kuma_injector_webhook_server_cert_rotation_validity_period"`
+	// RenewBefore defines how long before expiration TLS certificate should be renewed.
+	RenewBefore time.Duration `yaml:"renewBefore,omitempty" envconfig:"kuma_injector_webhook_server_cert_rotation_renew_before"`
+	// CheckInterval defines how often TLS certificate should be checked for upcoming expiration.
+	CheckInterval time.Duration `yaml:"checkInterval,omitempty" envconfig:"kuma_injector_webhook_server_cert_rotation_check_interval"`
 }
 
 // Injector defines configuration of a Kuma Sidecar Injector.
@@ -71,6 +125,43 @@ type Injector struct {
 	SidecarContainer SidecarContainer `yaml:"sidecarContainer,omitempty"`
 	// InitContainer defines configuration of the Kuma init container.
 	InitContainer InitContainer `yaml:"initContainer,omitempty"`
+	// NamespaceOverrides defines configuration of containers injected into Pods of particular Namespaces, by Namespace name.
+	NamespaceOverrides map[string]NamespaceOverride `yaml:"namespaceOverrides,omitempty" ignored:"true"`
+}
+
+// NamespaceOverride defines configuration of containers injected into Pods of a particular Namespace.
+// Settings that are not defined fall back to the default configuration.
+type NamespaceOverride struct {
+	// SidecarContainer overrides configuration of the Kuma sidecar container.
+	SidecarContainer ContainerOverride `yaml:"sidecarContainer,omitempty"`
+	// InitContainer overrides configuration of the Kuma init container.
+	InitContainer ContainerOverride `yaml:"initContainer,omitempty"`
+}
+
+// ContainerOverride overrides configuration of an injected container.
+type ContainerOverride struct {
+	// Compute resources.
+	Resources ContainerResources `yaml:"resources,omitempty"`
+	// Security context.
+	SecurityContext ContainerSecurityContext `yaml:"securityContext,omitempty"`
+}
+
+// Apply returns given resources and security context with every setting
+// defined by the override put in place of the original one.
+func (o ContainerOverride) Apply(resources ContainerResources, securityContext ContainerSecurityContext) (ContainerResources, ContainerSecurityContext) {
+	if o.Resources.Requests != nil {
+		resources.Requests = o.Resources.Requests
+	}
+	if o.Resources.Limits != nil {
+		resources.Limits = o.Resources.Limits
+	}
+	if o.SecurityContext.ReadOnlyRootFilesystem != nil {
+		securityContext.ReadOnlyRootFilesystem = o.SecurityContext.ReadOnlyRootFilesystem
+	}
+	if o.SecurityContext.AllowPrivilegeEscalation != nil {
+		securityContext.AllowPrivilegeEscalation = o.SecurityContext.AllowPrivilegeEscalation
+	}
+	return resources, securityContext
 }
 
 // ControlPlane defines coordinates of the Control Plane.
@@ -104,12 +195,36 @@ type SidecarContainer struct {
 	GID int64 `yaml:"gid,omitempty" envconfig:"kuma_injector_sidecar_container_gui"`
 	// Admin port.
 	AdminPort uint32 `yaml:"adminPort,omitempty" envconfig:"kuma_injector_sidecar_container_admin_port"`
+	// Compute resources.
+	Resources ContainerResources `yaml:"resources,omitempty" ignored:"true"`
+	// Security context.
+	SecurityContext ContainerSecurityContext `yaml:"securityContext,omitempty" ignored:"true"`
+}
+
+// ContainerResources defines compute resources of an injected container.
+type ContainerResources struct {
+	// Minimum amount of compute resources by resource name, e.g. `cpu: 50m`.
+	Requests map[string]string `yaml:"requests,omitempty"`
+	// Maximum amount of compute resources by resource name, e.g. `memory: 64M`.
+	Limits map[string]string `yaml:"limits,omitempty"`
+}
+
+// ContainerSecurityContext defines security settings of an injected container.
+type ContainerSecurityContext struct {
+	// Whether the container has a read-only root filesystem.
+	ReadOnlyRootFilesystem *bool `yaml:"readOnlyRootFilesystem,omitempty"`
+	// Whether a process can gain more privileges than its parent process.
+	AllowPrivilegeEscalation *bool `yaml:"allowPrivilegeEscalation,omitempty"`
 }
 
 // InitContainer defines configuration of the Kuma init container.
 type InitContainer struct {
 	// Image name.
 	Image string `yaml:"image,omitempty" envconfig:"kuma_injector_init_container_image"`
+	// Compute resources.
+	Resources ContainerResources `yaml:"resources,omitempty" ignored:"true"`
+	// Security context.
+	SecurityContext ContainerSecurityContext `yaml:"securityContext,omitempty" ignored:"true"`
 }
 
 var _ config.Config = &Config{}
@@ -136,6 +251,39 @@ func (s *WebHookServer) Validate() (errs error) {
 	if s.CertDir == "" {
 		errs = multierr.Append(errs, errors.Errorf(".CertDir must be non-empty"))
 	}
+	if err := s.CertRotation.Validate(); err != nil {
+		errs = multierr.Append(errs, errors.Wrapf(err, ".CertRotation is not valid"))
+	}
+	return
+}
+
+var _ config.Config = &CertRotation{}
+
+func (r *CertRotation) Validate() (errs error) {
+	if !r.Enabled {
+		return
+	}
+	if r.SecretNamespace == "" {
+		errs = multierr.Append(errs, errors.Errorf(".SecretNamespace must be non-empty"))
+	}
+	if r.SecretName == "" {
+		errs = multierr.Append(errs, errors.Errorf(".SecretName must be non-empty"))
+	}
+	if r.ServiceName == "" {
+		errs = multierr.Append(errs, errors.Errorf(".ServiceName must be non-empty"))
+	}
+	if r.WebhookConfigurationName == "" {
+		errs = multierr.Append(errs, errors.Errorf(".WebhookConfigurationName must be non-empty"))
+	}
+	if r.ValidityPeriod <= 0 {
+		errs = multierr.Append(errs, errors.Errorf(".ValidityPeriod must be positive"))
+	}
+	if r.RenewBefore <= 0 || r.ValidityPeriod <= r.RenewBefore {
+		errs = multierr.Append(errs, errors.Errorf(".RenewBefore must be positive and less than .ValidityPeriod"))
+	}
+	if r.CheckInterval <= 0 {
+		errs = multierr.Append(errs, errors.Errorf(".CheckInterval must be positive"))
+	}
 	return
 }
 
@@ -151,6 +299,20 @@ func (i *Injector) Validate() (errs error) {
 	if err := i.InitContainer.Validate(); err != nil {
 		errs = multierr.Append(errs, errors.Wrapf(err, ".InitContainer is not valid"))
 	}
+	namespaces := make([]string, 0, len(i.NamespaceOverrides))
+	for namespace := range i.NamespaceOverrides {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	for _, namespace := range namespaces {
+		override := i.NamespaceOverrides[namespace]
+		if err := override.SidecarContainer.Resources.Validate(); err != nil {
+			errs = multierr.Append(errs, errors.Wrapf(err, ".NamespaceOverrides[%q].SidecarContainer.Resources is not valid", namespace))
+		}
+		if err := override.InitContainer.Resources.Validate(); err != nil {
+			errs = multierr.Append(errs, errors.Wrapf(err, ".NamespaceOverrides[%q].InitContainer.Resources is not valid", namespace))
+		}
+	}
 	return
 }
 
@@ -206,6 +368,9 @@ func (c *SidecarContainer) Validate() (errs error) {
 	if 65535 < c.AdminPort {
 		errs = multierr.Append(errs, errors.Errorf(".AdminPort must be in the range [0, 65535]"))
 	}
+	if err := c.Resources.Validate(); err != nil {
+		errs = multierr.Append(errs, errors.Wrapf(err, ".Resources is not valid"))
+	}
 	return
 }
 
@@ -215,5 +380,33 @@ func (c *InitContainer) Validate() (errs error) {
 	if c.Image == "" {
 		errs = multierr.Append(errs, errors.Errorf(".Image must be non-empty"))
 	}
+	if err := c.Resources.Validate(); err != nil {
+		errs = multierr.Append(errs, errors.Wrapf(err, ".Resources is not valid"))
+	}
 	return
 }
+
+var _ config.Config = &ContainerResources{}
+
+func (r *ContainerResources) Validate() (errs error) {
+	for _, name := range sortedKeys(r.Requests) {
+		if _, err := kube_api.ParseQuantity(r.Requests[name]); err != nil {
+			errs = multierr.Append(errs, errors.Errorf(".Requests[%q] must be a valid quantity", name))
+		}
+	}
+	for _, name := range sortedKeys(r.Limits) {
+		if _, err := kube_api.ParseQuantity(r.Limits[name]); err != nil {
+			errs = multierr.Append(errs, errors.Errorf(".Limits[%q] must be a valid quantity", name))
+		}
+	}
+	return
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
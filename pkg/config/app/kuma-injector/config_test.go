@@ -3,6 +3,7 @@ package kumainjector_test
 import (
 	"io/ioutil"
 	"path/filepath"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -27,6 +28,14 @@ var _ = Describe("Config", func() {
 		Expect(cfg.WebHookServer.Address).To(Equal("127.0.0.2"))
 		Expect(cfg.WebHookServer.Port).To(Equal(uint32(8442)))
 		Expect(cfg.WebHookServer.CertDir).To(Equal("/var/secret/kuma-injector"))
+		Expect(cfg.WebHookServer.CertRotation.Enabled).To(BeTrue())
+		Expect(cfg.WebHookServer.CertRotation.SecretNamespace).To(Equal("kuma"))
+		Expect(cfg.WebHookServer.CertRotation.SecretName).To(Equal("injector-cert"))
+		Expect(cfg.WebHookServer.CertRotation.ServiceName).To(Equal("injector"))
+		Expect(cfg.WebHookServer.CertRotation.WebhookConfigurationName).To(Equal("injector-webhook"))
+		Expect(cfg.WebHookServer.CertRotation.ValidityPeriod).To(Equal(240 * time.Hour))
+		Expect(cfg.WebHookServer.CertRotation.RenewBefore).To(Equal(48 * time.Hour))
+		Expect(cfg.WebHookServer.CertRotation.CheckInterval).To(Equal(10 * time.Minute))
 		// and
 		Expect(cfg.Injector.ControlPlane.ApiServer.URL).To(Equal("https://api-server:8765"))
 		// and
@@ -37,8 +46,17 @@ var _ = Describe("Config", func() {
 		Expect(cfg.Injector.SidecarContainer.UID).To(Equal(int64(2345)))
 		Expect(cfg.Injector.SidecarContainer.GID).To(Equal(int64(3456)))
 		Expect(cfg.Injector.SidecarContainer.AdminPort).To(Equal(uint32(45678)))
+		Expect(cfg.Injector.SidecarContainer.Resources.Requests).To(Equal(map[string]string{"cpu": "100m", "memory": "128Mi"}))
+		Expect(cfg.Injector.SidecarContainer.Resources.Limits).To(Equal(map[string]string{"cpu": "200m", "memory": "256Mi"}))
+		Expect(*cfg.Injector.SidecarContainer.SecurityContext.ReadOnlyRootFilesystem).To(BeTrue())
+		Expect(cfg.Injector.SidecarContainer.SecurityContext.AllowPrivilegeEscalation).To(BeNil())
 		// and
 		Expect(cfg.Injector.InitContainer.Image).To(Equal("kuma-init:latest"))
+		Expect(cfg.Injector.InitContainer.Resources.Limits).To(Equal(map[string]string{"cpu": "50m"}))
+		Expect(*cfg.Injector.InitContainer.SecurityContext.AllowPrivilegeEscalation).To(BeFalse())
+		// and
+		Expect(cfg.Injector.NamespaceOverrides).To(HaveKey("restricted"))
+		Expect(cfg.Injector.NamespaceOverrides["restricted"].SidecarContainer.Resources.Limits).To(Equal(map[string]string{"memory": "512Mi"}))
 	})
 
 	It("should have consistent defaults", func() {
@@ -66,6 +84,6 @@ var _ = Describe("Config", func() {
 		err := config.Load(filepath.Join("testdata", "invalid-config.input.yaml"), &cfg)
 
 		// then
-		Expect(err).To(MatchError(`Invalid configuration: .WebHookServer is not valid: .Address must be either empty or a valid IPv4/IPv6 address; .Port must be in the range [0, 65535]; .CertDir must be non-empty; .Injector is not valid: .ControlPlane is not valid: .BootstrapServer is not valid: .URL must be a valid absolute URI; .ApiServer is not valid: .URL must be a valid absolute URI; .SidecarContainer is not valid: .Image must be non-empty; .RedirectPort must be in the range [0, 65535]; .AdminPort must be in the range [0, 65535]; .InitContainer is not valid: .Image must be non-empty`))
+		Expect(err).To(MatchError(`Invalid configuration: .WebHookServer is not valid: .Address must be either empty or a valid IPv4/IPv6 address; .Port must be in the range [0, 65535]; .CertDir must be non-empty; .CertRotation is not valid: .SecretNamespace must be non-empty; .SecretName must be non-empty; .ServiceName must be non-empty; .WebhookConfigurationName must be non-empty; .RenewBefore must be positive and less than .ValidityPeriod; .CheckInterval must be positive; .Injector is not valid: .ControlPlane is not valid: .BootstrapServer is not valid: .URL must be a valid absolute URI; .ApiServer is not valid: .URL must be a valid absolute URI; .SidecarContainer is not valid: .Image must be non-empty; .RedirectPort must be in the range [0, 65535]; .AdminPort must be in the range [0, 65535]; .Resources is not valid: .Limits["cpu"] must be a valid quantity; .InitContainer is not valid: .Image must be non-empty`))
 	})
 })
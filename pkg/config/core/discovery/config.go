@@ -2,6 +2,8 @@ package discovery
 
 import (
 	"github.com/Kong/kuma/pkg/config"
+	"github.com/Kong/kuma/pkg/config/plugins/discovery/consul"
+	"github.com/Kong/kuma/pkg/config/plugins/discovery/k8s"
 	"github.com/Kong/kuma/pkg/config/plugins/discovery/universal"
 	"github.com/pkg/errors"
 )
@@ -9,15 +11,25 @@ import (
 var _ config.Config = &DiscoveryConfig{}
 
 type DiscoveryConfig struct {
-	Universal *universal.UniversalDiscoveryConfig `yaml:"universal"`
+	Universal  *universal.UniversalDiscoveryConfig `yaml:"universal"`
+	Kubernetes *k8s.KubernetesDiscoveryConfig      `yaml:"kubernetes"`
+	Consul     *consul.ConsulDiscoveryConfig       `yaml:"consul"`
 }
 
 func (d *DiscoveryConfig) Validate() error {
-	return errors.Wrap(d.Universal.Validate(), "Discovery validation failed")
+	if err := d.Universal.Validate(); err != nil {
+		return errors.Wrap(err, "Discovery validation failed")
+	}
+	if err := d.Kubernetes.Validate(); err != nil {
+		return errors.Wrap(err, "Discovery validation failed")
+	}
+	return errors.Wrap(d.Consul.Validate(), "Discovery validation failed")
 }
 
 func DefaultDiscoveryConfig() *DiscoveryConfig {
 	return &DiscoveryConfig{
-		Universal: universal.DefaultUniversalDiscoveryConfig(),
+		Universal:  universal.DefaultUniversalDiscoveryConfig(),
+		Kubernetes: k8s.DefaultKubernetesDiscoveryConfig(),
+		Consul:     consul.DefaultConsulDiscoveryConfig(),
 	}
 }
@@ -0,0 +1,44 @@
+package egress
+
+import (
+	"net/url"
+
+	"github.com/pkg/errors"
+
+	"github.com/Kong/kuma/pkg/config"
+)
+
+var _ config.Config = &EgressProxyConfig{}
+
+// Proxy that outbound connections of Control Plane go through, e.g. to webhooks, Consul or access log backends
+type EgressProxyConfig struct {
+	// URL of the proxy, e.g. http://proxy.example.com:3128 or socks5://proxy.example.com:1080.
+	// If empty, HTTPS_PROXY and HTTP_PROXY environment variables are used
+	URL string `yaml:"url" envconfig:"kuma_egress_proxy_url"`
+	// Destinations that are reached without the proxy, i.e. host names, domains (e.g. .example.com), IPs and CIDRs,
+	// each optionally followed by a port. If empty, NO_PROXY environment variable is used
+	NoProxy []string `yaml:"noProxy,omitempty" envconfig:"kuma_egress_proxy_no_proxy"`
+}
+
+func (c *EgressProxyConfig) Validate() error {
+	if c.URL == "" {
+		return nil
+	}
+	proxyURL, err := url.Parse(c.URL)
+	if err != nil {
+		return errors.Wrap(err, "URL is not valid")
+	}
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return errors.Errorf("URL must have either http, https or socks5 scheme, got %q", proxyURL.Scheme)
+	}
+	if proxyURL.Host == "" {
+		return errors.New("URL must have a host")
+	}
+	return nil
+}
+
+func DefaultEgressProxyConfig() *EgressProxyConfig {
+	return &EgressProxyConfig{}
+}
@@ -0,0 +1,41 @@
+package quota
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/Kong/kuma/pkg/config"
+)
+
+var _ config.Config = &QuotaConfig{}
+
+// Quotas on the number of resources in a single Mesh. 0 means there is no limit.
+type QuotaConfig struct {
+	// Maximum number of Dataplanes in a single Mesh
+	MaxDataplanesPerMesh int `yaml:"maxDataplanesPerMesh" envconfig:"kuma_quota_max_dataplanes_per_mesh"`
+	// Maximum number of policies (TrafficPermissions, ProxyTemplates, etc) in a single Mesh
+	MaxPoliciesPerMesh int `yaml:"maxPoliciesPerMesh" envconfig:"kuma_quota_max_policies_per_mesh"`
+	// Maximum number of instances of external services, i.e. Dataplanes discovered in a service catalog like Consul, in a single Mesh.
+	// They count towards MaxDataplanesPerMesh as well
+	MaxExternalServicesPerMesh int `yaml:"maxExternalServicesPerMesh" envconfig:"kuma_quota_max_external_services_per_mesh"`
+}
+
+func (q *QuotaConfig) Validate() error {
+	if q.MaxDataplanesPerMesh < 0 {
+		return errors.New("MaxDataplanesPerMesh cannot be negative")
+	}
+	if q.MaxPoliciesPerMesh < 0 {
+		return errors.New("MaxPoliciesPerMesh cannot be negative")
+	}
+	if q.MaxExternalServicesPerMesh < 0 {
+		return errors.New("MaxExternalServicesPerMesh cannot be negative")
+	}
+	return nil
+}
+
+func DefaultQuotaConfig() *QuotaConfig {
+	return &QuotaConfig{
+		MaxDataplanesPerMesh:       0,
+		MaxPoliciesPerMesh:         0,
+		MaxExternalServicesPerMesh: 0,
+	}
+}
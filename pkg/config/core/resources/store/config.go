@@ -20,7 +20,8 @@ const (
 
 // Resource Store configuration
 type StoreConfig struct {
-	// Type of Store used in the Control Plane. Can be either "kubernetes", "postgres" or "memory"
+	// Type of Store used in the Control Plane. Can be either "kubernetes", "postgres" or "memory".
+	// If empty, "kubernetes" is used in Kubernetes environment and "memory" otherwise.
 	Type StoreType `yaml:"type" envconfig:"kuma_store_type"`
 	// Postgres Store configuration
 	Postgres *postgres.PostgresStoreConfig `yaml:"postgres"`
@@ -30,7 +31,7 @@ type StoreConfig struct {
 
 func DefaultStoreConfig() *StoreConfig {
 	return &StoreConfig{
-		Type:       MemoryStore,
+		Type:       "",
 		Postgres:   postgres.DefaultPostgresStoreConfig(),
 		Kubernetes: k8s.DefaultKubernetesStoreConfig(),
 	}
@@ -47,7 +48,7 @@ func (s *StoreConfig) Validate() error {
 			return errors.Wrap(err, "Kubernetes validation failed")
 		}
 		return nil
-	case MemoryStore:
+	case MemoryStore, "":
 		return nil
 	default:
 		return errors.Errorf("Type should be either %s, %s or %s", PostgresStore, KubernetesStore, MemoryStore)
@@ -0,0 +1,17 @@
+package runtime
+
+import (
+	"github.com/Kong/kuma/pkg/config/plugins/runtime/k8s"
+)
+
+// Configuration specific to the environment Control Plane runs in
+type RuntimeConfig struct {
+	// Kubernetes-specific configuration (used when environment=kubernetes)
+	Kubernetes *k8s.KubernetesRuntimeConfig `yaml:"kubernetes"`
+}
+
+func DefaultRuntimeConfig() *RuntimeConfig {
+	return &RuntimeConfig{
+		Kubernetes: k8s.DefaultKubernetesRuntimeConfig(),
+	}
+}
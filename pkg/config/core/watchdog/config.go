@@ -0,0 +1,49 @@
+package watchdog
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/Kong/kuma/pkg/config"
+)
+
+var _ config.Config = &WatchdogConfig{}
+
+// Watchdog configuration
+type WatchdogConfig struct {
+	// Interval between checks of heartbeats of components
+	CheckInterval time.Duration `yaml:"checkInterval" envconfig:"kuma_watchdog_check_interval"`
+	// Component that hasn't reported progress for longer than this is considered to be stuck.
+	// Stuck components are restarted if possible, otherwise Control Plane is reported as not ready
+	HeartbeatTimeout time.Duration `yaml:"heartbeatTimeout" envconfig:"kuma_watchdog_heartbeat_timeout"`
+	// Interval between checks of connectivity to the Resource Store
+	StoreProbeInterval time.Duration `yaml:"storeProbeInterval" envconfig:"kuma_watchdog_store_probe_interval"`
+	// How long a stuck component is given to stop before a new instance of it is started anyway
+	RestartTimeout time.Duration `yaml:"restartTimeout" envconfig:"kuma_watchdog_restart_timeout"`
+}
+
+func (c *WatchdogConfig) Validate() error {
+	if c.CheckInterval <= 0 {
+		return errors.New("CheckInterval must be positive")
+	}
+	if c.HeartbeatTimeout <= c.CheckInterval {
+		return errors.New("HeartbeatTimeout must be longer than CheckInterval")
+	}
+	if c.StoreProbeInterval <= 0 || c.StoreProbeInterval >= c.HeartbeatTimeout {
+		return errors.New("StoreProbeInterval must be positive and shorter than HeartbeatTimeout")
+	}
+	if c.RestartTimeout <= 0 {
+		return errors.New("RestartTimeout must be positive")
+	}
+	return nil
+}
+
+func DefaultWatchdogConfig() *WatchdogConfig {
+	return &WatchdogConfig{
+		CheckInterval:      time.Second,
+		HeartbeatTimeout:   time.Minute,
+		StoreProbeInterval: 10 * time.Second,
+		RestartTimeout:     10 * time.Second,
+	}
+}
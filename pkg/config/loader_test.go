@@ -3,6 +3,7 @@ package config_test
 import (
 	"io/ioutil"
 	"os"
+	"time"
 
 	"github.com/Kong/kuma/pkg/config"
 	kuma_cp "github.com/Kong/kuma/pkg/config/app/kuma-cp"
@@ -31,6 +32,9 @@ var _ = Describe("Config loader", func() {
 
 	sampleConfigYaml := `
 environment: kubernetes
+role: xds-only
+fipsMode: true
+workDir: /var/lib/kuma
 store:
   type: postgres
   postgres:
@@ -38,22 +42,108 @@ store:
     port: 5432
     user: kuma
     password: kuma
+    passwordFile: /run/secrets/postgres-password
     dbName: kuma
     connectionTimeout: 10
+    sslMode: verify-full
+discovery:
+  kubernetes:
+    smiEnabled: true
+    universalDataplanesEnabled: true
+    bufferedDiscoveryEnabled: true
+  consul:
+    enabled: true
+    address: http://consul.local:8500
+    token: secret
+    tokenFile: /run/secrets/consul-token
+    datacenter: dc2
+    mesh: demo
+    waitTime: 1m
+    retryInterval: 2s
 xdsServer:
   grpcPort: 5000
+  diagnosticsEnabled: false
   diagnosticsPort: 5003
+  grpcMaxMessageSize: 1048576
+  dataplaneConfigurationDebounceWindow: 2s
+  dataplaneConfigurationHistorySize: 5
+  dataplaneAuth:
+    type: serviceAccountToken
+  policyRollout:
+    enabled: true
+    wavePercentage: 10
+  accessLogForwarding:
+    batchSize: 50
+    flushInterval: 5s
+  meshMtls:
+    tlsMinVersion: TLSv1_2
+    tlsCipherSuites:
+    - TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256
+    - TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384
+  tls:
+    enabled: true
+    certFile: /etc/kuma/xds/tls.crt
+    keyFile: /etc/kuma/xds/tls.key
+    clientCaFile: /etc/kuma/xds/ca.crt
 bootstrapServer:
+  enabled: false
   port: 5004
   params:
     adminPort: 1234
     xdsHost: kuma-control-plane
     xdsPort: 4321
+    xdsCaFile: /etc/kuma/xds/ca.crt
+    xdsDelta: true
 apiServer:
+  enabled: false
   port: 9090
   readOnly: true
+  adminToken: s3cr3t
+  adminTokenFile: /run/secrets/admin-token
+  writeToken: wr1t3
+  writeTokenFile: /run/secrets/write-token
+  readToken: r34d
+  readTokenFile: /run/secrets/read-token
+  tls:
+    enabled: true
+    certFile: /etc/kuma/api/tls.crt
+    keyFile: /etc/kuma/api/tls.key
+    hosts:
+    - kuma-control-plane.internal
+    - 10.0.0.1
+    clientCaFile: /etc/kuma/api/ca.crt
+  accessLog:
+    enabled: true
+    sampleRate: 0.1
+  validationWebhooks:
+  - name: naming-conventions
+    url: https://linter.example.com/validate
+    resourceTypes:
+    - TrafficPermission
+    timeout: 3s
+    ignoreFailures: true
+quota:
+  maxDataplanesPerMesh: 100
+  maxPoliciesPerMesh: 50
+  maxExternalServicesPerMesh: 20
 reports:
   enabled: false
+watchdog:
+  checkInterval: 2s
+  heartbeatTimeout: 30s
+  storeProbeInterval: 5s
+  restartTimeout: 20s
+egressProxy:
+  url: http://proxy.example.com:3128
+  noProxy:
+  - .internal
+  - 10.0.0.0/8
+runtime:
+  kubernetes:
+    admissionServer:
+      address: 127.0.0.1
+      port: 8443
+      certDir: /var/run/secrets/kuma.io/kuma-admission-server/tls-cert
 `
 
 	It("should load config from file", func() {
@@ -70,14 +160,35 @@ reports:
 
 		// then
 		Expect(cfg.XdsServer.GrpcPort).To(Equal(5000))
+		Expect(cfg.XdsServer.DiagnosticsEnabled).To(BeFalse())
 		Expect(cfg.XdsServer.DiagnosticsPort).To(Equal(5003))
+		Expect(cfg.XdsServer.GrpcMaxMessageSize).To(Equal(1048576))
+		Expect(cfg.XdsServer.DataplaneConfigurationDebounceWindow).To(Equal(2 * time.Second))
+		Expect(cfg.XdsServer.DataplaneConfigurationHistorySize).To(Equal(5))
+		Expect(cfg.XdsServer.DataplaneAuth.Type).To(Equal("serviceAccountToken"))
+		Expect(cfg.XdsServer.PolicyRollout.Enabled).To(BeTrue())
+		Expect(cfg.XdsServer.PolicyRollout.WavePercentage).To(Equal(uint32(10)))
+		Expect(cfg.XdsServer.AccessLogForwarding.BatchSize).To(Equal(50))
+		Expect(cfg.XdsServer.AccessLogForwarding.FlushInterval).To(Equal(5 * time.Second))
+		Expect(cfg.XdsServer.MeshMtls.TlsMinVersion).To(Equal("TLSv1_2"))
+		Expect(cfg.XdsServer.MeshMtls.TlsCipherSuites).To(Equal([]string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"}))
+		Expect(cfg.XdsServer.Tls.Enabled).To(BeTrue())
+		Expect(cfg.XdsServer.Tls.CertFile).To(Equal("/etc/kuma/xds/tls.crt"))
+		Expect(cfg.XdsServer.Tls.KeyFile).To(Equal("/etc/kuma/xds/tls.key"))
+		Expect(cfg.XdsServer.Tls.ClientCaFile).To(Equal("/etc/kuma/xds/ca.crt"))
 
+		Expect(cfg.BootstrapServer.Enabled).To(BeFalse())
 		Expect(cfg.BootstrapServer.Port).To(Equal(5004))
 		Expect(cfg.BootstrapServer.Params.AdminPort).To(Equal(uint32(1234)))
 		Expect(cfg.BootstrapServer.Params.XdsHost).To(Equal("kuma-control-plane"))
 		Expect(cfg.BootstrapServer.Params.XdsPort).To(Equal(uint32(4321)))
+		Expect(cfg.BootstrapServer.Params.XdsCaFile).To(Equal("/etc/kuma/xds/ca.crt"))
+		Expect(cfg.BootstrapServer.Params.XdsDelta).To(BeTrue())
 
 		Expect(cfg.Environment).To(Equal(kuma_cp.KubernetesEnvironment))
+		Expect(cfg.Role).To(Equal(kuma_cp.XdsOnlyRole))
+		Expect(cfg.FipsMode).To(BeTrue())
+		Expect(cfg.WorkDir).To(Equal("/var/lib/kuma"))
 
 		Expect(cfg.Store.Type).To(Equal(store.PostgresStore))
 
@@ -85,11 +196,59 @@ reports:
 		Expect(int(cfg.Store.Postgres.Port)).To(Equal(5432))
 		Expect(cfg.Store.Postgres.User).To(Equal("kuma"))
 		Expect(cfg.Store.Postgres.Password).To(Equal("kuma"))
+		Expect(cfg.Store.Postgres.PasswordFile).To(Equal("/run/secrets/postgres-password"))
 		Expect(cfg.Store.Postgres.DbName).To(Equal("kuma"))
 		Expect(cfg.Store.Postgres.ConnectionTimeout).To(Equal(10))
+		Expect(cfg.Store.Postgres.SSLMode).To(Equal("verify-full"))
 
+		Expect(cfg.Discovery.Kubernetes.SmiEnabled).To(BeTrue())
+		Expect(cfg.Discovery.Kubernetes.UniversalDataplanesEnabled).To(BeTrue())
+		Expect(cfg.Discovery.Kubernetes.BufferedDiscoveryEnabled).To(BeTrue())
+		Expect(cfg.Discovery.Consul.Enabled).To(BeTrue())
+		Expect(cfg.Discovery.Consul.Address).To(Equal("http://consul.local:8500"))
+		Expect(cfg.Discovery.Consul.Token).To(Equal("secret"))
+		Expect(cfg.Discovery.Consul.TokenFile).To(Equal("/run/secrets/consul-token"))
+		Expect(cfg.Discovery.Consul.Datacenter).To(Equal("dc2"))
+		Expect(cfg.Discovery.Consul.Mesh).To(Equal("demo"))
+		Expect(cfg.Discovery.Consul.WaitTime).To(Equal(time.Minute))
+		Expect(cfg.Discovery.Consul.RetryInterval).To(Equal(2 * time.Second))
+
+		Expect(cfg.ApiServer.Enabled).To(BeFalse())
 		Expect(cfg.ApiServer.Port).To(Equal(9090))
 		Expect(cfg.ApiServer.ReadOnly).To(Equal(true))
+		Expect(cfg.ApiServer.AdminToken).To(Equal("s3cr3t"))
+		Expect(cfg.ApiServer.WriteToken).To(Equal("wr1t3"))
+		Expect(cfg.ApiServer.ReadToken).To(Equal("r34d"))
+		Expect(cfg.ApiServer.AdminTokenFile).To(Equal("/run/secrets/admin-token"))
+		Expect(cfg.ApiServer.WriteTokenFile).To(Equal("/run/secrets/write-token"))
+		Expect(cfg.ApiServer.ReadTokenFile).To(Equal("/run/secrets/read-token"))
+		Expect(cfg.ApiServer.Tls.Enabled).To(BeTrue())
+		Expect(cfg.ApiServer.Tls.CertFile).To(Equal("/etc/kuma/api/tls.crt"))
+		Expect(cfg.ApiServer.Tls.KeyFile).To(Equal("/etc/kuma/api/tls.key"))
+		Expect(cfg.ApiServer.Tls.Hosts).To(Equal([]string{"kuma-control-plane.internal", "10.0.0.1"}))
+		Expect(cfg.ApiServer.Tls.ClientCaFile).To(Equal("/etc/kuma/api/ca.crt"))
+		Expect(cfg.ApiServer.AccessLog.Enabled).To(BeTrue())
+		Expect(cfg.ApiServer.AccessLog.SampleRate).To(Equal(0.1))
+		Expect(cfg.ApiServer.ValidationWebhooks).To(HaveLen(1))
+		Expect(cfg.ApiServer.ValidationWebhooks[0].Name).To(Equal("naming-conventions"))
+		Expect(cfg.ApiServer.ValidationWebhooks[0].Url).To(Equal("https://linter.example.com/validate"))
+		Expect(cfg.ApiServer.ValidationWebhooks[0].ResourceTypes).To(Equal([]string{"TrafficPermission"}))
+		Expect(cfg.ApiServer.ValidationWebhooks[0].Timeout).To(Equal(3 * time.Second))
+		Expect(cfg.ApiServer.ValidationWebhooks[0].IgnoreFailures).To(BeTrue())
+
+		Expect(cfg.Quota.MaxDataplanesPerMesh).To(Equal(100))
+		Expect(cfg.Quota.MaxPoliciesPerMesh).To(Equal(50))
+		Expect(cfg.Quota.MaxExternalServicesPerMesh).To(Equal(20))
+		Expect(cfg.Watchdog.CheckInterval).To(Equal(2 * time.Second))
+		Expect(cfg.Watchdog.HeartbeatTimeout).To(Equal(30 * time.Second))
+		Expect(cfg.Watchdog.StoreProbeInterval).To(Equal(5 * time.Second))
+		Expect(cfg.Watchdog.RestartTimeout).To(Equal(20 * time.Second))
+		Expect(cfg.EgressProxy.URL).To(Equal("http://proxy.example.com:3128"))
+		Expect(cfg.EgressProxy.NoProxy).To(Equal([]string{".internal", "10.0.0.0/8"}))
+
+		Expect(cfg.Runtime.Kubernetes.AdmissionServer.Address).To(Equal("127.0.0.1"))
+		Expect(cfg.Runtime.Kubernetes.AdmissionServer.Port).To(Equal(uint32(8443)))
+		Expect(cfg.Runtime.Kubernetes.AdmissionServer.CertDir).To(Equal("/var/run/secrets/kuma.io/kuma-admission-server/tls-cert"))
 
 		Expect(cfg.Reports.Enabled).To(BeFalse())
 	})
@@ -102,22 +261,82 @@ reports:
 	It("should load config from env vars", func() {
 		// given
 		setEnv("KUMA_XDS_SERVER_GRPC_PORT", "5000")
+		setEnv("KUMA_XDS_SERVER_DIAGNOSTICS_ENABLED", "false")
 		setEnv("KUMA_XDS_SERVER_DIAGNOSTICS_PORT", "5003")
+		setEnv("KUMA_XDS_SERVER_GRPC_MAX_MESSAGE_SIZE", "1048576")
+		setEnv("KUMA_XDS_SERVER_DATAPLANE_CONFIGURATION_DEBOUNCE_WINDOW", "2s")
+		setEnv("KUMA_XDS_SERVER_DATAPLANE_CONFIGURATION_HISTORY_SIZE", "5")
+		setEnv("KUMA_XDS_SERVER_DATAPLANE_AUTH_TYPE", "serviceAccountToken")
+		setEnv("KUMA_XDS_SERVER_POLICY_ROLLOUT_ENABLED", "true")
+		setEnv("KUMA_XDS_SERVER_POLICY_ROLLOUT_WAVE_PERCENTAGE", "10")
+		setEnv("KUMA_XDS_SERVER_ACCESS_LOG_FORWARDING_BATCH_SIZE", "50")
+		setEnv("KUMA_XDS_SERVER_ACCESS_LOG_FORWARDING_FLUSH_INTERVAL", "5s")
+		setEnv("KUMA_XDS_SERVER_MESH_MTLS_TLS_MIN_VERSION", "TLSv1_2")
+		setEnv("KUMA_XDS_SERVER_MESH_MTLS_TLS_CIPHER_SUITES", "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384")
+		setEnv("KUMA_XDS_SERVER_TLS_ENABLED", "true")
+		setEnv("KUMA_XDS_SERVER_TLS_CERT_FILE", "/etc/kuma/xds/tls.crt")
+		setEnv("KUMA_XDS_SERVER_TLS_KEY_FILE", "/etc/kuma/xds/tls.key")
+		setEnv("KUMA_XDS_SERVER_TLS_CLIENT_CA_FILE", "/etc/kuma/xds/ca.crt")
+		setEnv("KUMA_BOOTSTRAP_SERVER_ENABLED", "false")
 		setEnv("KUMA_BOOTSTRAP_SERVER_PORT", "5004")
 		setEnv("KUMA_BOOTSTRAP_SERVER_PARAMS_ADMIN_PORT", "1234")
 		setEnv("KUMA_BOOTSTRAP_SERVER_PARAMS_XDS_HOST", "kuma-control-plane")
 		setEnv("KUMA_BOOTSTRAP_SERVER_PARAMS_XDS_PORT", "4321")
+		setEnv("KUMA_BOOTSTRAP_SERVER_PARAMS_XDS_CA_FILE", "/etc/kuma/xds/ca.crt")
+		setEnv("KUMA_BOOTSTRAP_SERVER_PARAMS_XDS_DELTA", "true")
 		setEnv("KUMA_ENVIRONMENT", "kubernetes")
+		setEnv("KUMA_ROLE", "xds-only")
+		setEnv("KUMA_FIPS_MODE", "true")
+		setEnv("KUMA_WORK_DIR", "/var/lib/kuma")
 		setEnv("KUMA_STORE_TYPE", "postgres")
 		setEnv("KUMA_STORE_POSTGRES_HOST", "postgres.host")
 		setEnv("KUMA_STORE_POSTGRES_PORT", "5432")
 		setEnv("KUMA_STORE_POSTGRES_USER", "kuma")
 		setEnv("KUMA_STORE_POSTGRES_PASSWORD", "kuma")
+		setEnv("KUMA_STORE_POSTGRES_PASSWORD_FILE", "/run/secrets/postgres-password")
 		setEnv("KUMA_STORE_POSTGRES_DB_NAME", "kuma")
 		setEnv("KUMA_STORE_POSTGRES_CONNECTION_TIMEOUT", "10")
+		setEnv("KUMA_STORE_POSTGRES_SSL_MODE", "verify-full")
+		setEnv("KUMA_DISCOVERY_KUBERNETES_SMI_ENABLED", "true")
+		setEnv("KUMA_DISCOVERY_KUBERNETES_UNIVERSAL_DATAPLANES_ENABLED", "true")
+		setEnv("KUMA_DISCOVERY_KUBERNETES_BUFFERED_DISCOVERY_ENABLED", "true")
+		setEnv("KUMA_DISCOVERY_CONSUL_ENABLED", "true")
+		setEnv("KUMA_DISCOVERY_CONSUL_ADDRESS", "http://consul.local:8500")
+		setEnv("KUMA_DISCOVERY_CONSUL_TOKEN", "secret")
+		setEnv("KUMA_DISCOVERY_CONSUL_TOKEN_FILE", "/run/secrets/consul-token")
+		setEnv("KUMA_DISCOVERY_CONSUL_DATACENTER", "dc2")
+		setEnv("KUMA_DISCOVERY_CONSUL_MESH", "demo")
+		setEnv("KUMA_DISCOVERY_CONSUL_WAIT_TIME", "1m")
+		setEnv("KUMA_DISCOVERY_CONSUL_RETRY_INTERVAL", "2s")
 		setEnv("KUMA_API_SERVER_READ_ONLY", "true")
+		setEnv("KUMA_API_SERVER_ADMIN_TOKEN", "s3cr3t")
+		setEnv("KUMA_API_SERVER_ADMIN_TOKEN_FILE", "/run/secrets/admin-token")
+		setEnv("KUMA_API_SERVER_WRITE_TOKEN", "wr1t3")
+		setEnv("KUMA_API_SERVER_WRITE_TOKEN_FILE", "/run/secrets/write-token")
+		setEnv("KUMA_API_SERVER_READ_TOKEN", "r34d")
+		setEnv("KUMA_API_SERVER_READ_TOKEN_FILE", "/run/secrets/read-token")
+		setEnv("KUMA_API_SERVER_TLS_ENABLED", "true")
+		setEnv("KUMA_API_SERVER_TLS_CERT_FILE", "/etc/kuma/api/tls.crt")
+		setEnv("KUMA_API_SERVER_TLS_KEY_FILE", "/etc/kuma/api/tls.key")
+		setEnv("KUMA_API_SERVER_TLS_HOSTS", "kuma-control-plane.internal,10.0.0.1")
+		setEnv("KUMA_API_SERVER_TLS_CLIENT_CA_FILE", "/etc/kuma/api/ca.crt")
+		setEnv("KUMA_API_SERVER_ENABLED", "false")
 		setEnv("KUMA_API_SERVER_PORT", "9090")
+		setEnv("KUMA_API_SERVER_ACCESS_LOG_ENABLED", "true")
+		setEnv("KUMA_API_SERVER_ACCESS_LOG_SAMPLE_RATE", "0.1")
 		setEnv("KUMA_REPORTS_ENABLED", "false")
+		setEnv("KUMA_QUOTA_MAX_DATAPLANES_PER_MESH", "100")
+		setEnv("KUMA_QUOTA_MAX_POLICIES_PER_MESH", "50")
+		setEnv("KUMA_QUOTA_MAX_EXTERNAL_SERVICES_PER_MESH", "20")
+		setEnv("KUMA_WATCHDOG_CHECK_INTERVAL", "2s")
+		setEnv("KUMA_WATCHDOG_HEARTBEAT_TIMEOUT", "30s")
+		setEnv("KUMA_WATCHDOG_STORE_PROBE_INTERVAL", "5s")
+		setEnv("KUMA_WATCHDOG_RESTART_TIMEOUT", "20s")
+		setEnv("KUMA_EGRESS_PROXY_URL", "http://proxy.example.com:3128")
+		setEnv("KUMA_EGRESS_PROXY_NO_PROXY", ".internal,10.0.0.0/8")
+		setEnv("KUMA_RUNTIME_KUBERNETES_ADMISSION_SERVER_ADDRESS", "127.0.0.1")
+		setEnv("KUMA_RUNTIME_KUBERNETES_ADMISSION_SERVER_PORT", "8443")
+		setEnv("KUMA_RUNTIME_KUBERNETES_ADMISSION_SERVER_CERT_DIR", "/var/run/secrets/kuma.io/kuma-admission-server/tls-cert")
 
 		// when
 		cfg := kuma_cp.DefaultConfig()
@@ -126,25 +345,88 @@ reports:
 
 		// then
 		Expect(cfg.XdsServer.GrpcPort).To(Equal(5000))
+		Expect(cfg.XdsServer.DiagnosticsEnabled).To(BeFalse())
 		Expect(cfg.XdsServer.DiagnosticsPort).To(Equal(5003))
+		Expect(cfg.XdsServer.GrpcMaxMessageSize).To(Equal(1048576))
+		Expect(cfg.XdsServer.DataplaneConfigurationDebounceWindow).To(Equal(2 * time.Second))
+		Expect(cfg.XdsServer.DataplaneConfigurationHistorySize).To(Equal(5))
+		Expect(cfg.XdsServer.DataplaneAuth.Type).To(Equal("serviceAccountToken"))
+		Expect(cfg.XdsServer.PolicyRollout.Enabled).To(BeTrue())
+		Expect(cfg.XdsServer.PolicyRollout.WavePercentage).To(Equal(uint32(10)))
+		Expect(cfg.XdsServer.AccessLogForwarding.BatchSize).To(Equal(50))
+		Expect(cfg.XdsServer.AccessLogForwarding.FlushInterval).To(Equal(5 * time.Second))
+		Expect(cfg.XdsServer.MeshMtls.TlsMinVersion).To(Equal("TLSv1_2"))
+		Expect(cfg.XdsServer.MeshMtls.TlsCipherSuites).To(Equal([]string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"}))
+		Expect(cfg.XdsServer.Tls.Enabled).To(BeTrue())
+		Expect(cfg.XdsServer.Tls.CertFile).To(Equal("/etc/kuma/xds/tls.crt"))
+		Expect(cfg.XdsServer.Tls.KeyFile).To(Equal("/etc/kuma/xds/tls.key"))
+		Expect(cfg.XdsServer.Tls.ClientCaFile).To(Equal("/etc/kuma/xds/ca.crt"))
 
+		Expect(cfg.BootstrapServer.Enabled).To(BeFalse())
 		Expect(cfg.BootstrapServer.Port).To(Equal(5004))
 		Expect(cfg.BootstrapServer.Params.AdminPort).To(Equal(uint32(1234)))
 		Expect(cfg.BootstrapServer.Params.XdsHost).To(Equal("kuma-control-plane"))
 		Expect(cfg.BootstrapServer.Params.XdsPort).To(Equal(uint32(4321)))
+		Expect(cfg.BootstrapServer.Params.XdsCaFile).To(Equal("/etc/kuma/xds/ca.crt"))
+		Expect(cfg.BootstrapServer.Params.XdsDelta).To(BeTrue())
 
 		Expect(cfg.Environment).To(Equal(kuma_cp.KubernetesEnvironment))
+		Expect(cfg.Role).To(Equal(kuma_cp.XdsOnlyRole))
+		Expect(cfg.FipsMode).To(BeTrue())
+		Expect(cfg.WorkDir).To(Equal("/var/lib/kuma"))
 
 		Expect(cfg.Store.Type).To(Equal(store.PostgresStore))
 		Expect(cfg.Store.Postgres.Host).To(Equal("postgres.host"))
 		Expect(int(cfg.Store.Postgres.Port)).To(Equal(5432))
 		Expect(cfg.Store.Postgres.User).To(Equal("kuma"))
 		Expect(cfg.Store.Postgres.Password).To(Equal("kuma"))
+		Expect(cfg.Store.Postgres.PasswordFile).To(Equal("/run/secrets/postgres-password"))
 		Expect(cfg.Store.Postgres.DbName).To(Equal("kuma"))
 		Expect(cfg.Store.Postgres.ConnectionTimeout).To(Equal(10))
+		Expect(cfg.Store.Postgres.SSLMode).To(Equal("verify-full"))
+
+		Expect(cfg.Discovery.Kubernetes.SmiEnabled).To(BeTrue())
+		Expect(cfg.Discovery.Kubernetes.UniversalDataplanesEnabled).To(BeTrue())
+		Expect(cfg.Discovery.Kubernetes.BufferedDiscoveryEnabled).To(BeTrue())
+		Expect(cfg.Discovery.Consul.Enabled).To(BeTrue())
+		Expect(cfg.Discovery.Consul.Address).To(Equal("http://consul.local:8500"))
+		Expect(cfg.Discovery.Consul.Token).To(Equal("secret"))
+		Expect(cfg.Discovery.Consul.TokenFile).To(Equal("/run/secrets/consul-token"))
+		Expect(cfg.Discovery.Consul.Datacenter).To(Equal("dc2"))
+		Expect(cfg.Discovery.Consul.Mesh).To(Equal("demo"))
+		Expect(cfg.Discovery.Consul.WaitTime).To(Equal(time.Minute))
+		Expect(cfg.Discovery.Consul.RetryInterval).To(Equal(2 * time.Second))
 
+		Expect(cfg.ApiServer.Enabled).To(BeFalse())
 		Expect(cfg.ApiServer.Port).To(Equal(9090))
 		Expect(cfg.ApiServer.ReadOnly).To(Equal(true))
+		Expect(cfg.ApiServer.AdminToken).To(Equal("s3cr3t"))
+		Expect(cfg.ApiServer.WriteToken).To(Equal("wr1t3"))
+		Expect(cfg.ApiServer.ReadToken).To(Equal("r34d"))
+		Expect(cfg.ApiServer.AdminTokenFile).To(Equal("/run/secrets/admin-token"))
+		Expect(cfg.ApiServer.WriteTokenFile).To(Equal("/run/secrets/write-token"))
+		Expect(cfg.ApiServer.ReadTokenFile).To(Equal("/run/secrets/read-token"))
+		Expect(cfg.ApiServer.Tls.Enabled).To(BeTrue())
+		Expect(cfg.ApiServer.Tls.CertFile).To(Equal("/etc/kuma/api/tls.crt"))
+		Expect(cfg.ApiServer.Tls.KeyFile).To(Equal("/etc/kuma/api/tls.key"))
+		Expect(cfg.ApiServer.Tls.Hosts).To(Equal([]string{"kuma-control-plane.internal", "10.0.0.1"}))
+		Expect(cfg.ApiServer.Tls.ClientCaFile).To(Equal("/etc/kuma/api/ca.crt"))
+		Expect(cfg.ApiServer.AccessLog.Enabled).To(BeTrue())
+		Expect(cfg.ApiServer.AccessLog.SampleRate).To(Equal(0.1))
+
+		Expect(cfg.Quota.MaxDataplanesPerMesh).To(Equal(100))
+		Expect(cfg.Quota.MaxPoliciesPerMesh).To(Equal(50))
+		Expect(cfg.Quota.MaxExternalServicesPerMesh).To(Equal(20))
+		Expect(cfg.Watchdog.CheckInterval).To(Equal(2 * time.Second))
+		Expect(cfg.Watchdog.HeartbeatTimeout).To(Equal(30 * time.Second))
+		Expect(cfg.Watchdog.StoreProbeInterval).To(Equal(5 * time.Second))
+		Expect(cfg.Watchdog.RestartTimeout).To(Equal(20 * time.Second))
+		Expect(cfg.EgressProxy.URL).To(Equal("http://proxy.example.com:3128"))
+		Expect(cfg.EgressProxy.NoProxy).To(Equal([]string{".internal", "10.0.0.0/8"}))
+
+		Expect(cfg.Runtime.Kubernetes.AdmissionServer.Address).To(Equal("127.0.0.1"))
+		Expect(cfg.Runtime.Kubernetes.AdmissionServer.Port).To(Equal(uint32(8443)))
+		Expect(cfg.Runtime.Kubernetes.AdmissionServer.CertDir).To(Equal("/var/run/secrets/kuma.io/kuma-admission-server/tls-cert"))
 
 		Expect(cfg.Reports.Enabled).To(BeFalse())
 	})
@@ -0,0 +1,67 @@
+package consul
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/Kong/kuma/pkg/config"
+)
+
+var _ config.Config = &ConsulDiscoveryConfig{}
+
+// Consul Discovery configuration
+type ConsulDiscoveryConfig struct {
+	// If true then instances of services registered in the Consul catalog are discovered as Dataplanes
+	Enabled bool `yaml:"enabled" envconfig:"kuma_discovery_consul_enabled"`
+	// Address of the Consul HTTP API
+	Address string `yaml:"address" envconfig:"kuma_discovery_consul_address"`
+	// ACL token to access the Consul HTTP API
+	Token string `yaml:"token" envconfig:"kuma_discovery_consul_token"`
+	// Path to a file with the ACL token. If set, it takes precedence over Token.
+	// The file is read again once it has changed, so the token can be rotated without a restart
+	TokenFile string `yaml:"tokenFile" envconfig:"kuma_discovery_consul_token_file"`
+	// Datacenter to discover services in. Datacenter of the Consul agent is used if empty
+	Datacenter string `yaml:"datacenter" envconfig:"kuma_discovery_consul_datacenter"`
+	// Mesh that discovered Dataplanes belong to
+	Mesh string `yaml:"mesh" envconfig:"kuma_discovery_consul_mesh"`
+	// Maximum duration of a blocking query to the Consul catalog
+	WaitTime time.Duration `yaml:"waitTime" envconfig:"kuma_discovery_consul_wait_time"`
+	// Interval between retries after the Consul catalog could not be queried
+	RetryInterval time.Duration `yaml:"retryInterval" envconfig:"kuma_discovery_consul_retry_interval"`
+}
+
+func (c *ConsulDiscoveryConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if _, err := url.ParseRequestURI(c.Address); err != nil {
+		return errors.Wrap(err, "Address must be a valid URL")
+	}
+	if c.Mesh == "" {
+		return errors.New("Mesh should not be empty")
+	}
+	if c.WaitTime <= 0 {
+		return errors.New("WaitTime must be positive")
+	}
+	if c.RetryInterval <= 0 {
+		return errors.New("RetryInterval must be positive")
+	}
+	return nil
+}
+
+// TokenSecret returns the ACL token, either inline or from the file.
+func (c *ConsulDiscoveryConfig) TokenSecret() config.Secret {
+	return config.NewSecret(c.Token, c.TokenFile)
+}
+
+func DefaultConsulDiscoveryConfig() *ConsulDiscoveryConfig {
+	return &ConsulDiscoveryConfig{
+		Enabled:       false,
+		Address:       "http://127.0.0.1:8500",
+		Mesh:          "default",
+		WaitTime:      5 * time.Minute,
+		RetryInterval: 5 * time.Second,
+	}
+}
@@ -0,0 +1,32 @@
+package k8s
+
+import (
+	"github.com/Kong/kuma/pkg/config"
+)
+
+var _ config.Config = &KubernetesDiscoveryConfig{}
+
+type KubernetesDiscoveryConfig struct {
+	// If true then SMI TrafficTargets will be reconciled into TrafficPermissions.
+	// Synchronization is one-way: TrafficPermissions are never converted back into SMI objects.
+	SmiEnabled bool `yaml:"smiEnabled" envconfig:"kuma_discovery_kubernetes_smi_enabled"`
+	// If true then Dataplanes running outside of Kubernetes (e.g. on VMs) can join Meshes side by side with Pods.
+	// Such Dataplanes authenticate with Dataplane tokens and their service tags have to be <name>.<namespace>.svc:<port>.
+	UniversalDataplanesEnabled bool `yaml:"universalDataplanesEnabled" envconfig:"kuma_discovery_kubernetes_universal_dataplanes_enabled"`
+	// If true then changes of Dataplanes are queued and handed to discovery consumers in the background,
+	// so that a slow consumer doesn't hold back reconciliation of other Kubernetes resources.
+	// Changes that consumers fail to handle are reconciled again.
+	BufferedDiscoveryEnabled bool `yaml:"bufferedDiscoveryEnabled" envconfig:"kuma_discovery_kubernetes_buffered_discovery_enabled"`
+}
+
+func (k *KubernetesDiscoveryConfig) Validate() error {
+	return nil
+}
+
+func DefaultKubernetesDiscoveryConfig() *KubernetesDiscoveryConfig {
+	return &KubernetesDiscoveryConfig{
+		SmiEnabled:                 false,
+		UniversalDataplanesEnabled: false,
+		BufferedDiscoveryEnabled:   false,
+	}
+}
@@ -3,6 +3,8 @@ package universal
 import (
 	"time"
 
+	"github.com/pkg/errors"
+
 	"github.com/Kong/kuma/pkg/config"
 )
 
@@ -14,6 +16,9 @@ type UniversalDiscoveryConfig struct {
 }
 
 func (u UniversalDiscoveryConfig) Validate() error {
+	if u.PollingInterval <= 0 {
+		return errors.New("PollingInterval must be positive")
+	}
 	return nil
 }
 
@@ -18,10 +18,15 @@ type PostgresStoreConfig struct {
 	User string `yaml:"user" envconfig:"kuma_store_postgres_user"`
 	// Password of the Postgres DB
 	Password string `yaml:"password" envconfig:"kuma_store_postgres_password"`
+	// Path to a file with the password of the Postgres DB. If set, it takes precedence over Password.
+	// The file is read again once it has changed, so the password can be rotated without a restart
+	PasswordFile string `yaml:"passwordFile" envconfig:"kuma_store_postgres_password_file"`
 	// Database name of the Postgres DB
 	DbName string `yaml:"dbName" envconfig:"kuma_store_postgres_db_name"`
 	// Connection Timeout to the DB in seconds
 	ConnectionTimeout int `yaml:"connectionTimeout" envconfig:"kuma_store_postgres_connection_timeout"`
+	// SSL mode of the connection to the DB. Can be either "disable", "require", "verify-ca" or "verify-full"
+	SSLMode string `yaml:"sslMode" envconfig:"kuma_store_postgres_ssl_mode"`
 }
 
 func (p *PostgresStoreConfig) Validate() error {
@@ -34,15 +39,25 @@ func (p *PostgresStoreConfig) Validate() error {
 	if len(p.User) < 1 {
 		return errors.New("User should not be empty")
 	}
-	if len(p.Password) < 1 {
+	if len(p.Password) < 1 && len(p.PasswordFile) < 1 {
 		return errors.New("Password should not be empty")
 	}
 	if len(p.DbName) < 1 {
 		return errors.New("DbName should not be empty")
 	}
+	switch p.SSLMode {
+	case "disable", "require", "verify-ca", "verify-full":
+	default:
+		return errors.New(`SSLMode should be either "disable", "require", "verify-ca" or "verify-full"`)
+	}
 	return nil
 }
 
+// PasswordSecret returns the password of the Postgres DB, either inline or from the file.
+func (p *PostgresStoreConfig) PasswordSecret() config.Secret {
+	return config.NewSecret(p.Password, p.PasswordFile)
+}
+
 func DefaultPostgresStoreConfig() *PostgresStoreConfig {
 	return &PostgresStoreConfig{
 		Host:              "127.0.0.1",
@@ -51,5 +66,6 @@ func DefaultPostgresStoreConfig() *PostgresStoreConfig {
 		Password:          "kuma",
 		DbName:            "kuma",
 		ConnectionTimeout: 5,
+		SSLMode:           "disable",
 	}
 }
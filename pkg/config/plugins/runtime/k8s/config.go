@@ -0,0 +1,47 @@
+package k8s
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/Kong/kuma/pkg/config"
+)
+
+var _ config.Config = &KubernetesRuntimeConfig{}
+
+// Kubernetes-specific configuration of Control Plane
+type KubernetesRuntimeConfig struct {
+	// Admission WebHook Server that Kubernetes API Server calls to validate changes of Kuma resources
+	AdmissionServer *AdmissionServerConfig `yaml:"admissionServer"`
+}
+
+// Admission WebHook Server implemented by Control Plane
+type AdmissionServerConfig struct {
+	// Address the Admission WebHook Server listens on. All interfaces are used if empty
+	Address string `yaml:"address" envconfig:"kuma_runtime_kubernetes_admission_server_address"`
+	// Port the Admission WebHook Server listens on
+	Port uint32 `yaml:"port" envconfig:"kuma_runtime_kubernetes_admission_server_port"`
+	// Directory with a TLS cert and key of the Admission WebHook Server, named tls.crt and tls.key
+	CertDir string `yaml:"certDir" envconfig:"kuma_runtime_kubernetes_admission_server_cert_dir"`
+}
+
+func (c *KubernetesRuntimeConfig) Validate() error {
+	return errors.Wrap(c.AdmissionServer.Validate(), "Admission Server validation failed")
+}
+
+func (c *AdmissionServerConfig) Validate() error {
+	if c.Port == 0 || c.Port > 65535 {
+		return errors.New("Port must be in the range [1, 65535]")
+	}
+	if c.CertDir == "" {
+		return errors.New("CertDir should not be empty")
+	}
+	return nil
+}
+
+func DefaultKubernetesRuntimeConfig() *KubernetesRuntimeConfig {
+	return &KubernetesRuntimeConfig{
+		AdmissionServer: &AdmissionServerConfig{
+			Port: 5443,
+		},
+	}
+}
@@ -1,14 +1,19 @@
 package sds
 
 import (
+	"time"
+
 	"github.com/pkg/errors"
 
 	"github.com/Kong/kuma/pkg/config"
+	util_tls "github.com/Kong/kuma/pkg/tls"
 )
 
 func DefaultSdsServerConfig() *SdsServerConfig {
 	return &SdsServerConfig{
-		GrpcPort: 5677,
+		GrpcPort:          5677,
+		CertRotation:      DefaultCertRotationConfig(),
+		IssuanceRateLimit: DefaultIssuanceRateLimitConfig(),
 	}
 }
 
@@ -17,9 +22,20 @@ type SdsServerConfig struct {
 	// Port of GRPC server that Envoy connects to
 	GrpcPort int `yaml:"grpcPort" envconfig:"kuma_sds_server_grpc_port"`
 	// TlsCertFile defines a path to a file with PEM-encoded TLS cert.
+	// The cert is loaded again once the file changes, so it can be renewed without a restart.
 	TlsCertFile string `yaml:"tlsCertFile" envconfig:"kuma_sds_server_tls_cert_file"`
 	// TlsKeyFile defines a path to a file with PEM-encoded TLS key.
 	TlsKeyFile string `yaml:"tlsKeyFile" envconfig:"kuma_sds_server_tls_key_file"`
+	// Minimum TLS version, can be either "TLSv1_0", "TLSv1_1", "TLSv1_2" or "TLSv1_3". Go's default is used if empty
+	TlsMinVersion string `yaml:"tlsMinVersion" envconfig:"kuma_sds_server_tls_min_version"`
+	// Cipher suites allowed in TLS 1.2 and below, in IANA format, e.g. TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256. Go's defaults are used if empty
+	TlsCipherSuites []string `yaml:"tlsCipherSuites,omitempty" envconfig:"kuma_sds_server_tls_cipher_suites"`
+	// Rotation of Workload Identity certificates of Dataplanes
+	CertRotation *CertRotationConfig `yaml:"certRotation"`
+	// Limits of issuing Workload Identity certificates to Dataplanes of a single service. Certificates beyond the limit are delayed. Every instance of Control Plane enforces the limits separately
+	IssuanceRateLimit *IssuanceRateLimitConfig `yaml:"issuanceRateLimit"`
+	// CAs provided by the user that Meshes reference by name in mtls.ca.provided.name. Used in universal mode
+	ProvidedCas []*ProvidedCaConfig `yaml:"providedCas,omitempty"`
 }
 
 var _ config.Config = &SdsServerConfig{}
@@ -34,5 +50,123 @@ func (c *SdsServerConfig) Validate() error {
 	if c.TlsKeyFile == "" && c.TlsCertFile != "" {
 		return errors.New("TlsKeyFile cannot be empty if TlsCertFile has been set")
 	}
+	if c.TlsMinVersion != "" {
+		if _, err := util_tls.ParseTlsVersion(c.TlsMinVersion); err != nil {
+			return errors.Wrap(err, "TlsMinVersion is not valid")
+		}
+	}
+	if _, err := util_tls.ParseCipherSuites(c.TlsCipherSuites); err != nil {
+		return errors.Wrap(err, "TlsCipherSuites are not valid")
+	}
+	if err := c.CertRotation.Validate(); err != nil {
+		return errors.Wrap(err, "CertRotation validation failed")
+	}
+	if err := c.IssuanceRateLimit.Validate(); err != nil {
+		return errors.Wrap(err, "IssuanceRateLimit validation failed")
+	}
+	names := map[string]bool{}
+	for i, ca := range c.ProvidedCas {
+		if err := ca.Validate(); err != nil {
+			return errors.Wrapf(err, "ProvidedCas[%d] validation failed", i)
+		}
+		if names[ca.Name] {
+			return errors.Errorf("ProvidedCas[%d] validation failed: Name %q is not unique", i, ca.Name)
+		}
+		names[ca.Name] = true
+	}
+	return nil
+}
+
+func DefaultCertRotationConfig() *CertRotationConfig {
+	return &CertRotationConfig{
+		WorkloadCertValidityPeriod: 90 * 24 * time.Hour,
+		RenewalThreshold:           0.67,
+		CrlRefreshInterval:         1 * time.Minute,
+		AllowedClockSkew:           10 * time.Second,
+	}
+}
+
+// Rotation of Workload Identity certificates of Dataplanes
+type CertRotationConfig struct {
+	// Validity period of Workload Identity certificates issued to Dataplanes
+	WorkloadCertValidityPeriod time.Duration `yaml:"workloadCertValidityPeriod" envconfig:"kuma_sds_server_cert_rotation_workload_cert_validity_period"`
+	// Fraction of the remaining lifetime of a certificate after which a new one is issued and pushed to a Dataplane.
+	// 0 means certificates are not renewed
+	RenewalThreshold float64 `yaml:"renewalThreshold" envconfig:"kuma_sds_server_cert_rotation_renewal_threshold"`
+	// Interval of checking the Certificate Revocation List of a Mesh for changes and pushing it to Dataplanes, so that they stop trusting revoked certificates
+	CrlRefreshInterval time.Duration `yaml:"crlRefreshInterval" envconfig:"kuma_sds_server_cert_rotation_crl_refresh_interval"`
+	// Workload Identity certificates are valid from this long before they are issued,
+	// so that Dataplanes whose clocks are behind accept them right after rotation.
+	// Dataplane tokens that have expired or have been issued in the future by no more than this are accepted too
+	AllowedClockSkew time.Duration `yaml:"allowedClockSkew" envconfig:"kuma_sds_server_cert_rotation_allowed_clock_skew"`
+}
+
+var _ config.Config = &CertRotationConfig{}
+
+func (c *CertRotationConfig) Validate() error {
+	if c.WorkloadCertValidityPeriod <= 0 {
+		return errors.New("WorkloadCertValidityPeriod must be positive")
+	}
+	if c.RenewalThreshold < 0 || c.RenewalThreshold >= 1 {
+		return errors.New("RenewalThreshold must be at least 0 and less than 1")
+	}
+	if c.CrlRefreshInterval <= 0 {
+		return errors.New("CrlRefreshInterval must be positive")
+	}
+	if c.AllowedClockSkew < 0 {
+		return errors.New("AllowedClockSkew cannot be negative")
+	}
+	return nil
+}
+
+func DefaultIssuanceRateLimitConfig() *IssuanceRateLimitConfig {
+	return &IssuanceRateLimitConfig{
+		Burst:    10,
+		Interval: 1 * time.Minute,
+	}
+}
+
+// Limits of issuing Workload Identity certificates to Dataplanes of a single service. Certificates beyond the limit are delayed. Every instance of Control Plane enforces the limits separately
+type IssuanceRateLimitConfig struct {
+	// Maximum number of certificates that Dataplanes of a service can get at once. 0 means there is no limit
+	Burst int `yaml:"burst" envconfig:"kuma_sds_server_issuance_rate_limit_burst"`
+	// Interval after which Dataplanes of a service can get one more certificate
+	Interval time.Duration `yaml:"interval" envconfig:"kuma_sds_server_issuance_rate_limit_interval"`
+}
+
+var _ config.Config = &IssuanceRateLimitConfig{}
+
+func (c *IssuanceRateLimitConfig) Validate() error {
+	if c.Burst < 0 {
+		return errors.New("Burst cannot be negative")
+	}
+	if c.Burst > 0 && c.Interval <= 0 {
+		return errors.New("Interval must be positive")
+	}
+	return nil
+}
+
+// CA provided by the user
+type ProvidedCaConfig struct {
+	// Name that Meshes reference the CA by
+	Name string `yaml:"name"`
+	// Path to a file with PEM-encoded certificate of the CA
+	CertFile string `yaml:"certFile"`
+	// Path to a file with PEM-encoded private key of the CA
+	KeyFile string `yaml:"keyFile"`
+}
+
+var _ config.Config = &ProvidedCaConfig{}
+
+func (c *ProvidedCaConfig) Validate() error {
+	if c.Name == "" {
+		return errors.New("Name cannot be empty")
+	}
+	if c.CertFile == "" {
+		return errors.New("CertFile cannot be empty")
+	}
+	if c.KeyFile == "" {
+		return errors.New("KeyFile cannot be empty")
+	}
 	return nil
 }
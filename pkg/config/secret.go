@@ -0,0 +1,69 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Secret is a value of configuration, e.g. a password of the DB, that is either given inline
+// or read from a file that is kept up to date by a secret injector, e.g. Vault Agent or kubelet.
+type Secret interface {
+	// Value returns the current value of the secret.
+	Value() (string, error)
+}
+
+// NewSecret returns a secret that is read from a file if the path is not empty, otherwise the inline value is used.
+//
+// The file is read again once it has changed, so that a rotated secret is picked up without a restart of Control Plane.
+// Leading and trailing whitespaces, e.g. a trailing newline, are not part of the secret.
+func NewSecret(value string, file string) Secret {
+	if file == "" {
+		return inlineSecret(value)
+	}
+	return &fileSecret{path: file}
+}
+
+type inlineSecret string
+
+func (s inlineSecret) Value() (string, error) {
+	return string(s), nil
+}
+
+type fileSecret struct {
+	path string
+
+	mu      sync.Mutex // protects access to the fields below
+	modTime time.Time
+	size    int64
+	value   string
+}
+
+func (s *fileSecret) Value() (string, error) {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not access a secret file %q", s.path)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.value != "" && info.ModTime().Equal(s.modTime) && info.Size() == s.size {
+		return s.value, nil
+	}
+	content, err := ioutil.ReadFile(s.path)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not read a secret file %q", s.path)
+	}
+	value := strings.TrimSpace(string(content))
+	if value == "" {
+		// the file might be in the middle of being rewritten, an empty secret must never be taken for a disabled one
+		return "", errors.Errorf("secret file %q is empty", s.path)
+	}
+	s.value = value
+	s.modTime = info.ModTime()
+	s.size = info.Size()
+	return s.value, nil
+}
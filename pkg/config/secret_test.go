@@ -0,0 +1,79 @@
+package config_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kong/kuma/pkg/config"
+)
+
+var _ = Describe("Secret", func() {
+
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "secret")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	It("should return an inline value if there is no file", func() {
+		// when
+		value, err := config.NewSecret("kuma", "").Value()
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(Equal("kuma"))
+	})
+
+	It("should pick up a rotated secret from a file", func() {
+		// given
+		file := filepath.Join(dir, "password")
+		Expect(ioutil.WriteFile(file, []byte("s3cr3t\n"), 0600)).To(Succeed())
+		secret := config.NewSecret("kuma", file)
+
+		// when
+		value, err := secret.Value()
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(Equal("s3cr3t"))
+
+		// when
+		Expect(ioutil.WriteFile(file, []byte("r0t4t3d\n"), 0600)).To(Succeed())
+		later := time.Now().Add(time.Minute)
+		Expect(os.Chtimes(file, later, later)).To(Succeed())
+		value, err = secret.Value()
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(Equal("r0t4t3d"))
+	})
+
+	It("should not accept an empty or missing file", func() {
+		// given
+		file := filepath.Join(dir, "password")
+		Expect(ioutil.WriteFile(file, []byte("\n"), 0600)).To(Succeed())
+
+		// when
+		_, err := config.NewSecret("", file).Value()
+
+		// then
+		Expect(err).To(MatchError(`secret file "` + file + `" is empty`))
+
+		// when
+		_, err = config.NewSecret("", filepath.Join(dir, "missing")).Value()
+
+		// then
+		Expect(err).To(HaveOccurred())
+	})
+})
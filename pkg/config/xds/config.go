@@ -6,6 +6,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/Kong/kuma/pkg/config"
+	util_tls "github.com/Kong/kuma/pkg/tls"
 )
 
 var _ config.Config = &XdsServerConfig{}
@@ -14,13 +15,33 @@ var _ config.Config = &XdsServerConfig{}
 type XdsServerConfig struct {
 	// Port of GRPC server that Envoy connects to
 	GrpcPort int `yaml:"grpcPort" envconfig:"kuma_xds_server_grpc_port"`
+	// If false, then Diagnostic Server is not started. Health and readiness of the Control Plane cannot be checked then
+	DiagnosticsEnabled bool `yaml:"diagnosticsEnabled" envconfig:"kuma_xds_server_diagnostics_enabled"`
 	// Port of Diagnostic Server for checking health and readiness of the Control Plane
 	DiagnosticsPort int `yaml:"diagnosticsPort" envconfig:"kuma_xds_server_diagnostics_port"`
+	// Maximum size (in bytes) of a message that GRPC server can send or receive. Config of Dataplanes in large meshes may exceed the default limit of GRPC
+	GrpcMaxMessageSize int `yaml:"grpcMaxMessageSize" envconfig:"kuma_xds_server_grpc_max_message_size"`
 
 	// Interval for re-genarting configuration for Dataplanes connected to the Control Plane
 	DataplaneConfigurationRefreshInterval time.Duration `yaml:"dataplaneConfigurationRefreshInterval" envconfig:"kuma_xds_server_dataplane_configuration_refresh_interval"`
+	// Window for batching changes of configuration of a Dataplane. Configuration is regenerated and sent to the Dataplane once resources of its Mesh have not changed for the window, but no later than 10 windows after the first change. 0 means that every change is sent immediately
+	DataplaneConfigurationDebounceWindow time.Duration `yaml:"dataplaneConfigurationDebounceWindow" envconfig:"kuma_xds_server_dataplane_configuration_debounce_window"`
+	// Number of recent configurations kept in memory per Dataplane, so that `kumactl inspect dataplane` can show what has changed in its configuration. 0 turns history off
+	DataplaneConfigurationHistorySize int `yaml:"dataplaneConfigurationHistorySize" envconfig:"kuma_xds_server_dataplane_configuration_history_size"`
 	// Interval for flushing status of Dataplanes connected to the Control Plane
 	DataplaneStatusFlushInterval time.Duration `yaml:"dataplaneStatusFlushInterval" envconfig:"kuma_xds_server_dataplane_status_flush_interval"`
+	// If true, in transparent proxying mode outbound traffic of a Dataplane is handled by a single listener that selects a filter chain by the original destination, instead of a listener per outbound interface. Scales better to meshes with thousands of services
+	SingleOutboundListenerEnabled bool `yaml:"singleOutboundListenerEnabled" envconfig:"kuma_xds_server_single_outbound_listener_enabled"`
+	// Authentication of Dataplanes connecting to the Control Plane
+	DataplaneAuth *DataplaneAuthConfig `yaml:"dataplaneAuth"`
+	// Gradual rollout of changes of policies to Dataplanes
+	PolicyRollout *PolicyRolloutConfig `yaml:"policyRollout"`
+	// Forwarding of access logs of Dataplanes to backends configured in Meshes, e.g. Splunk or Elasticsearch
+	AccessLogForwarding *AccessLogForwardingConfig `yaml:"accessLogForwarding"`
+	// TLS settings of mTLS connections between Dataplanes in Meshes with mTLS enabled
+	MeshMtls *MeshMtlsConfig `yaml:"meshMtls"`
+	// TLS of connections of Dataplanes to the XDS Server
+	Tls *XdsServerTlsConfig `yaml:"tls"`
 }
 
 func (x *XdsServerConfig) Validate() error {
@@ -30,25 +51,242 @@ func (x *XdsServerConfig) Validate() error {
 	if x.DiagnosticsPort < 0 {
 		return errors.New("DiagnosticPort cannot be negative")
 	}
+	if x.GrpcMaxMessageSize <= 0 {
+		return errors.New("GrpcMaxMessageSize must be positive")
+	}
 	if x.DataplaneConfigurationRefreshInterval <= 0 {
 		return errors.New("DataplaneConfigurationRefreshInterval must be positive")
 	}
+	if x.DataplaneConfigurationDebounceWindow < 0 {
+		return errors.New("DataplaneConfigurationDebounceWindow cannot be negative")
+	}
+	if x.DataplaneConfigurationHistorySize < 0 {
+		return errors.New("DataplaneConfigurationHistorySize cannot be negative")
+	}
 	if x.DataplaneStatusFlushInterval <= 0 {
 		return errors.New("DataplaneStatusFlushInterval must be positive")
 	}
+	if err := x.DataplaneAuth.Validate(); err != nil {
+		return errors.Wrap(err, "DataplaneAuth validation failed")
+	}
+	if err := x.PolicyRollout.Validate(); err != nil {
+		return errors.Wrap(err, "PolicyRollout validation failed")
+	}
+	if err := x.AccessLogForwarding.Validate(); err != nil {
+		return errors.Wrap(err, "AccessLogForwarding validation failed")
+	}
+	if err := x.MeshMtls.Validate(); err != nil {
+		return errors.Wrap(err, "MeshMtls validation failed")
+	}
+	if err := x.Tls.Validate(); err != nil {
+		return errors.Wrap(err, "Tls validation failed")
+	}
+	if x.DataplaneAuth.Type == ClientCertDataplaneAuth && !x.Tls.Enabled {
+		return errors.Errorf("DataplaneAuth of type %q requires Tls to be enabled", ClientCertDataplaneAuth)
+	}
 	return nil
 }
 
 func DefaultXdsServerConfig() *XdsServerConfig {
 	return &XdsServerConfig{
 		GrpcPort:                              5678,
+		DiagnosticsEnabled:                    true,
 		DiagnosticsPort:                       5680,
+		GrpcMaxMessageSize:                    16 * 1024 * 1024,
 		DataplaneConfigurationRefreshInterval: 1 * time.Second,
+		DataplaneConfigurationDebounceWindow:  0,
+		DataplaneConfigurationHistorySize:     0,
 		DataplaneStatusFlushInterval:          1 * time.Second,
+		SingleOutboundListenerEnabled:         false,
+		DataplaneAuth:                         DefaultDataplaneAuthConfig(),
+		PolicyRollout:                         DefaultPolicyRolloutConfig(),
+		AccessLogForwarding:                   DefaultAccessLogForwardingConfig(),
+		MeshMtls:                              DefaultMeshMtlsConfig(),
+		Tls:                                   DefaultXdsServerTlsConfig(),
+	}
+}
+
+type DataplaneAuthType = string
+
+const (
+	NoneDataplaneAuth                DataplaneAuthType = "none"
+	ServiceAccountTokenDataplaneAuth DataplaneAuthType = "serviceAccountToken"
+	ClientCertDataplaneAuth          DataplaneAuthType = "clientCert"
+	DataplaneTokenDataplaneAuth      DataplaneAuthType = "dataplaneToken"
+)
+
+var _ config.Config = &DataplaneAuthConfig{}
+
+// Authentication of Dataplanes connecting to the Control Plane
+type DataplaneAuthConfig struct {
+	// Type of authentication, can be either "none", "serviceAccountToken" (Kubernetes only), "clientCert" or "dataplaneToken"
+	Type DataplaneAuthType `yaml:"type" envconfig:"kuma_xds_server_dataplane_auth_type"`
+}
+
+func (d *DataplaneAuthConfig) Validate() error {
+	switch d.Type {
+	case NoneDataplaneAuth, ServiceAccountTokenDataplaneAuth, ClientCertDataplaneAuth, DataplaneTokenDataplaneAuth:
+		return nil
+	default:
+		return errors.Errorf("Type should be one of %q, %q, %q or %q", NoneDataplaneAuth, ServiceAccountTokenDataplaneAuth, ClientCertDataplaneAuth, DataplaneTokenDataplaneAuth)
+	}
+}
+
+func DefaultDataplaneAuthConfig() *DataplaneAuthConfig {
+	return &DataplaneAuthConfig{
+		Type: NoneDataplaneAuth,
+	}
+}
+
+var _ config.Config = &XdsServerTlsConfig{}
+
+// TLS configuration of the XDS Server
+type XdsServerTlsConfig struct {
+	// If true, then Dataplanes connect to XDS Server over TLS
+	Enabled bool `yaml:"enabled" envconfig:"kuma_xds_server_tls_enabled"`
+	// Path to a file with PEM-encoded TLS cert. If empty, a self-signed cert is auto-generated. The cert is loaded again once the file changes, so it can be renewed without a restart
+	CertFile string `yaml:"certFile" envconfig:"kuma_xds_server_tls_cert_file"`
+	// Path to a file with PEM-encoded TLS key
+	KeyFile string `yaml:"keyFile" envconfig:"kuma_xds_server_tls_key_file"`
+	// Path to a file with PEM-encoded CA certs. If set, Dataplanes have to present a certificate signed by one of them (mTLS)
+	ClientCaFile string `yaml:"clientCaFile" envconfig:"kuma_xds_server_tls_client_ca_file"`
+	// Minimum TLS version, can be either "TLSv1_0", "TLSv1_1", "TLSv1_2" or "TLSv1_3". Go's default is used if empty
+	TlsMinVersion string `yaml:"tlsMinVersion" envconfig:"kuma_xds_server_tls_min_version"`
+	// Cipher suites allowed in TLS 1.2 and below, in IANA format, e.g. TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256. Go's defaults are used if empty
+	TlsCipherSuites []string `yaml:"tlsCipherSuites,omitempty" envconfig:"kuma_xds_server_tls_cipher_suites"`
+}
+
+func (x *XdsServerTlsConfig) Validate() error {
+	if x.CertFile == "" && x.KeyFile != "" {
+		return errors.New("CertFile cannot be empty if KeyFile has been set")
+	}
+	if x.KeyFile == "" && x.CertFile != "" {
+		return errors.New("KeyFile cannot be empty if CertFile has been set")
+	}
+	if !x.Enabled && x.ClientCaFile != "" {
+		return errors.New("ClientCaFile cannot be set if TLS is disabled")
+	}
+	if x.TlsMinVersion != "" {
+		if _, err := util_tls.ParseTlsVersion(x.TlsMinVersion); err != nil {
+			return errors.Wrap(err, "TlsMinVersion is not valid")
+		}
+	}
+	if _, err := util_tls.ParseCipherSuites(x.TlsCipherSuites); err != nil {
+		return errors.Wrap(err, "TlsCipherSuites are not valid")
+	}
+	return nil
+}
+
+func DefaultXdsServerTlsConfig() *XdsServerTlsConfig {
+	return &XdsServerTlsConfig{
+		Enabled: false,
+	}
+}
+
+var _ config.Config = &PolicyRolloutConfig{}
+
+// Gradual rollout of changes of policies to Dataplanes
+type PolicyRolloutConfig struct {
+	// If true, a change of policies in a Mesh is rolled out to Dataplanes in waves. Dataplanes that are not part of a rollout yet keep their previous configuration
+	Enabled bool `yaml:"enabled" envconfig:"kuma_xds_server_policy_rollout_enabled"`
+	// Percentage of Dataplanes in a Mesh that is added to a rollout with every wave. Dataplanes are assigned to waves in a stable order, so the same Dataplanes always get changes first
+	WavePercentage uint32 `yaml:"wavePercentage" envconfig:"kuma_xds_server_policy_rollout_wave_percentage"`
+	// Interval between waves of a rollout
+	WaveInterval time.Duration `yaml:"waveInterval" envconfig:"kuma_xds_server_policy_rollout_wave_interval"`
+	// If set, Dataplanes are assigned to waves by the value of this tag instead of one by one, e.g. "zone" rolls out a change to all Dataplanes of a zone at once
+	WaveTag string `yaml:"waveTag" envconfig:"kuma_xds_server_policy_rollout_wave_tag"`
+	// Rollout is halted once the ratio of rejected (NACKed) configs to all configs received by Dataplanes in the rollout exceeds this value
+	MaxNackRatio float64 `yaml:"maxNackRatio" envconfig:"kuma_xds_server_policy_rollout_max_nack_ratio"`
+	// Rollout is halted once the ratio of 5xx responses to all HTTP responses reported by Dataplanes in the rollout exceeds this value. Responses are reported by kuma-dp with resiliency events enabled
+	MaxErrorRatio float64 `yaml:"maxErrorRatio" envconfig:"kuma_xds_server_policy_rollout_max_error_ratio"`
+	// Halted rollout is aborted after this time, which means that Dataplanes outside of the rollout get the latest configuration
+	HaltTimeout time.Duration `yaml:"haltTimeout" envconfig:"kuma_xds_server_policy_rollout_halt_timeout"`
+}
+
+func (p *PolicyRolloutConfig) Validate() error {
+	if p.WavePercentage == 0 || p.WavePercentage > 100 {
+		return errors.New("WavePercentage must be in the range [1, 100]")
+	}
+	if p.WaveInterval <= 0 {
+		return errors.New("WaveInterval must be positive")
+	}
+	if p.MaxNackRatio < 0 || p.MaxNackRatio > 1 {
+		return errors.New("MaxNackRatio must be in the range [0, 1]")
+	}
+	if p.MaxErrorRatio < 0 || p.MaxErrorRatio > 1 {
+		return errors.New("MaxErrorRatio must be in the range [0, 1]")
+	}
+	if p.HaltTimeout <= 0 {
+		return errors.New("HaltTimeout must be positive")
+	}
+	return nil
+}
+
+func DefaultPolicyRolloutConfig() *PolicyRolloutConfig {
+	return &PolicyRolloutConfig{
+		Enabled:        false,
+		WavePercentage: 25,
+		WaveInterval:   30 * time.Second,
+		MaxNackRatio:   0,
+		MaxErrorRatio:  0.1,
+		HaltTimeout:    10 * time.Minute,
+	}
+}
+
+var _ config.Config = &AccessLogForwardingConfig{}
+
+// Forwarding of access logs of Dataplanes to backends configured in Meshes, e.g. Splunk or Elasticsearch
+type AccessLogForwardingConfig struct {
+	// Maximum number of entries sent to a backend in a single request
+	BatchSize int `yaml:"batchSize" envconfig:"kuma_xds_server_access_log_forwarding_batch_size"`
+	// Interval after which entries are sent to a backend even if a batch is not full
+	FlushInterval time.Duration `yaml:"flushInterval" envconfig:"kuma_xds_server_access_log_forwarding_flush_interval"`
+	// Maximum number of entries waiting to be sent to a backend. Once the buffer is full, new entries are dropped
+	BufferSize int `yaml:"bufferSize" envconfig:"kuma_xds_server_access_log_forwarding_buffer_size"`
+	// Maximum number of retries of a request that has failed because of a network error or unavailability of a backend
+	MaxRetries int `yaml:"maxRetries" envconfig:"kuma_xds_server_access_log_forwarding_max_retries"`
+	// Time to wait before the first retry. Every next retry waits twice as long
+	RetryBackoff time.Duration `yaml:"retryBackoff" envconfig:"kuma_xds_server_access_log_forwarding_retry_backoff"`
+	// Timeout of a single request to a backend
+	Timeout time.Duration `yaml:"timeout" envconfig:"kuma_xds_server_access_log_forwarding_timeout"`
+}
+
+func (a *AccessLogForwardingConfig) Validate() error {
+	if a.BatchSize <= 0 {
+		return errors.New("BatchSize must be positive")
+	}
+	if a.FlushInterval <= 0 {
+		return errors.New("FlushInterval must be positive")
+	}
+	if a.BufferSize < a.BatchSize {
+		return errors.New("BufferSize cannot be smaller than BatchSize")
+	}
+	if a.MaxRetries < 0 {
+		return errors.New("MaxRetries cannot be negative")
+	}
+	if a.RetryBackoff <= 0 {
+		return errors.New("RetryBackoff must be positive")
+	}
+	if a.Timeout <= 0 {
+		return errors.New("Timeout must be positive")
+	}
+	return nil
+}
+
+func DefaultAccessLogForwardingConfig() *AccessLogForwardingConfig {
+	return &AccessLogForwardingConfig{
+		BatchSize:     100,
+		FlushInterval: 1 * time.Second,
+		BufferSize:    10000,
+		MaxRetries:    3,
+		RetryBackoff:  1 * time.Second,
+		Timeout:       10 * time.Second,
 	}
 }
 
 type BootstrapServerConfig struct {
+	// If false, then Bootstrap Server is not started. Dataplanes have to be provided with bootstrap configuration by other means then
+	Enabled bool `yaml:"enabled" envconfig:"kuma_bootstrap_server_enabled"`
 	// Port of Server that provides bootstrap configuration for dataplanes
 	Port int `yaml:"port" envconfig:"kuma_bootstrap_server_port"`
 	// Parameters of bootstrap configuration
@@ -67,8 +305,9 @@ func (b *BootstrapServerConfig) Validate() error {
 
 func DefaultBootstrapServerConfig() *BootstrapServerConfig {
 	return &BootstrapServerConfig{
-		Port:   5682,
-		Params: DefaultBootstrapParamsConfig(),
+		Enabled: true,
+		Port:    5682,
+		Params:  DefaultBootstrapParamsConfig(),
 	}
 }
 
@@ -79,6 +318,14 @@ type BootstrapParamsConfig struct {
 	XdsHost string `yaml:"xdsHost" envconfig:"kuma_bootstrap_server_params_xds_host"`
 	// Port of XDS Server
 	XdsPort uint32 `yaml:"xdsPort" envconfig:"kuma_bootstrap_server_params_xds_port"`
+	// Path to a file with PEM-encoded CA certs that Dataplanes verify XDS Server with. Required if XDS Server is served over TLS.
+	// Defaults to the cert of XDS Server, which is enough for self-signed certs
+	XdsCaFile string `yaml:"xdsCaFile" envconfig:"kuma_bootstrap_server_params_xds_ca_file"`
+	// If true, Dataplanes subscribe to Clusters with the incremental (delta) xDS protocol, so that only Clusters that have changed are sent to them.
+	// Other resources are still served over the state-of-the-world ADS, since Envoy implements delta xDS for Clusters only.
+	// Clusters are then subscribed to outside of ADS, so their changes are no longer ordered with changes of Listeners.
+	// Config of Dataplanes is generated the same way either way, only the traffic to Dataplanes is reduced
+	XdsDelta bool `yaml:"xdsDelta" envconfig:"kuma_bootstrap_server_params_xds_delta"`
 }
 
 func (b *BootstrapParamsConfig) Validate() error {
@@ -101,3 +348,29 @@ func DefaultBootstrapParamsConfig() *BootstrapParamsConfig {
 		XdsPort:   5678,
 	}
 }
+
+var _ config.Config = &MeshMtlsConfig{}
+
+// TLS settings of mTLS connections between Dataplanes in Meshes with mTLS enabled
+type MeshMtlsConfig struct {
+	// Minimum TLS version, can be either "TLSv1_0", "TLSv1_1", "TLSv1_2" or "TLSv1_3". Envoy's default is used if empty
+	TlsMinVersion string `yaml:"tlsMinVersion" envconfig:"kuma_xds_server_mesh_mtls_tls_min_version"`
+	// Cipher suites allowed in TLS 1.2 and below, in IANA format, e.g. TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256. Envoy's defaults are used if empty
+	TlsCipherSuites []string `yaml:"tlsCipherSuites,omitempty" envconfig:"kuma_xds_server_mesh_mtls_tls_cipher_suites"`
+}
+
+func (m *MeshMtlsConfig) Validate() error {
+	if m.TlsMinVersion != "" {
+		if _, err := util_tls.ParseTlsVersion(m.TlsMinVersion); err != nil {
+			return errors.Wrap(err, "TlsMinVersion is not valid")
+		}
+	}
+	if _, err := util_tls.OpenSSLCipherSuiteNames(m.TlsCipherSuites); err != nil {
+		return errors.Wrap(err, "TlsCipherSuites are not valid")
+	}
+	return nil
+}
+
+func DefaultMeshMtlsConfig() *MeshMtlsConfig {
+	return &MeshMtlsConfig{}
+}
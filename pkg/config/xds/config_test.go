@@ -28,8 +28,34 @@ var _ = Describe("XdsServerConfig", func() {
 		// and
 		Expect(cfg.GrpcPort).To(Equal(1234))
 		Expect(cfg.DiagnosticsPort).To(Equal(3456))
+		Expect(cfg.GrpcMaxMessageSize).To(Equal(1048576))
 		Expect(cfg.DataplaneConfigurationRefreshInterval).To(Equal(3 * time.Second))
+		Expect(cfg.DataplaneConfigurationDebounceWindow).To(Equal(10 * time.Second))
+		Expect(cfg.DataplaneConfigurationHistorySize).To(Equal(5))
 		Expect(cfg.DataplaneStatusFlushInterval).To(Equal(5 * time.Second))
+		Expect(cfg.SingleOutboundListenerEnabled).To(BeTrue())
+		Expect(cfg.DataplaneAuth.Type).To(Equal(kuma_xds.ClientCertDataplaneAuth))
+		Expect(cfg.PolicyRollout.Enabled).To(BeTrue())
+		Expect(cfg.PolicyRollout.WavePercentage).To(Equal(uint32(10)))
+		Expect(cfg.PolicyRollout.WaveInterval).To(Equal(1 * time.Minute))
+		Expect(cfg.PolicyRollout.WaveTag).To(Equal("zone"))
+		Expect(cfg.PolicyRollout.MaxNackRatio).To(Equal(0.2))
+		Expect(cfg.PolicyRollout.MaxErrorRatio).To(Equal(0.05))
+		Expect(cfg.PolicyRollout.HaltTimeout).To(Equal(10 * time.Minute))
+		Expect(cfg.AccessLogForwarding.BatchSize).To(Equal(50))
+		Expect(cfg.AccessLogForwarding.FlushInterval).To(Equal(5 * time.Second))
+		Expect(cfg.AccessLogForwarding.BufferSize).To(Equal(500))
+		Expect(cfg.AccessLogForwarding.MaxRetries).To(Equal(5))
+		Expect(cfg.AccessLogForwarding.RetryBackoff).To(Equal(2 * time.Second))
+		Expect(cfg.AccessLogForwarding.Timeout).To(Equal(3 * time.Second))
+		Expect(cfg.MeshMtls.TlsMinVersion).To(Equal("TLSv1_3"))
+		Expect(cfg.MeshMtls.TlsCipherSuites).To(Equal([]string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"}))
+		Expect(cfg.Tls.Enabled).To(BeTrue())
+		Expect(cfg.Tls.CertFile).To(Equal("/etc/kuma/xds/tls.crt"))
+		Expect(cfg.Tls.KeyFile).To(Equal("/etc/kuma/xds/tls.key"))
+		Expect(cfg.Tls.ClientCaFile).To(Equal("/etc/kuma/xds/ca.crt"))
+		Expect(cfg.Tls.TlsMinVersion).To(Equal("TLSv1_2"))
+		Expect(cfg.Tls.TlsCipherSuites).To(Equal([]string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"}))
 	})
 
 	Context("with modified environment variables", func() {
@@ -53,8 +79,34 @@ var _ = Describe("XdsServerConfig", func() {
 			env := map[string]string{
 				"KUMA_XDS_SERVER_GRPC_PORT":                                "1234",
 				"KUMA_XDS_SERVER_DIAGNOSTICS_PORT":                         "3456",
+				"KUMA_XDS_SERVER_GRPC_MAX_MESSAGE_SIZE":                    "1048576",
 				"KUMA_XDS_SERVER_DATAPLANE_CONFIGURATION_REFRESH_INTERVAL": "3s",
+				"KUMA_XDS_SERVER_DATAPLANE_CONFIGURATION_DEBOUNCE_WINDOW":  "10s",
+				"KUMA_XDS_SERVER_DATAPLANE_CONFIGURATION_HISTORY_SIZE":     "5",
 				"KUMA_XDS_SERVER_DATAPLANE_STATUS_FLUSH_INTERVAL":          "5s",
+				"KUMA_XDS_SERVER_SINGLE_OUTBOUND_LISTENER_ENABLED":         "true",
+				"KUMA_XDS_SERVER_DATAPLANE_AUTH_TYPE":                      "clientCert",
+				"KUMA_XDS_SERVER_POLICY_ROLLOUT_ENABLED":                   "true",
+				"KUMA_XDS_SERVER_POLICY_ROLLOUT_WAVE_PERCENTAGE":           "10",
+				"KUMA_XDS_SERVER_POLICY_ROLLOUT_WAVE_INTERVAL":             "1m",
+				"KUMA_XDS_SERVER_POLICY_ROLLOUT_WAVE_TAG":                  "zone",
+				"KUMA_XDS_SERVER_POLICY_ROLLOUT_MAX_NACK_RATIO":            "0.2",
+				"KUMA_XDS_SERVER_POLICY_ROLLOUT_MAX_ERROR_RATIO":           "0.05",
+				"KUMA_XDS_SERVER_POLICY_ROLLOUT_HALT_TIMEOUT":              "10m",
+				"KUMA_XDS_SERVER_ACCESS_LOG_FORWARDING_BATCH_SIZE":         "50",
+				"KUMA_XDS_SERVER_ACCESS_LOG_FORWARDING_FLUSH_INTERVAL":     "5s",
+				"KUMA_XDS_SERVER_ACCESS_LOG_FORWARDING_BUFFER_SIZE":        "500",
+				"KUMA_XDS_SERVER_ACCESS_LOG_FORWARDING_MAX_RETRIES":        "5",
+				"KUMA_XDS_SERVER_ACCESS_LOG_FORWARDING_RETRY_BACKOFF":      "2s",
+				"KUMA_XDS_SERVER_ACCESS_LOG_FORWARDING_TIMEOUT":            "3s",
+				"KUMA_XDS_SERVER_MESH_MTLS_TLS_MIN_VERSION":                "TLSv1_3",
+				"KUMA_XDS_SERVER_MESH_MTLS_TLS_CIPHER_SUITES":              "TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+				"KUMA_XDS_SERVER_TLS_ENABLED":                              "true",
+				"KUMA_XDS_SERVER_TLS_CERT_FILE":                            "/etc/kuma/xds/tls.crt",
+				"KUMA_XDS_SERVER_TLS_KEY_FILE":                             "/etc/kuma/xds/tls.key",
+				"KUMA_XDS_SERVER_TLS_CLIENT_CA_FILE":                       "/etc/kuma/xds/ca.crt",
+				"KUMA_XDS_SERVER_TLS_MIN_VERSION":                          "TLSv1_2",
+				"KUMA_XDS_SERVER_TLS_CIPHER_SUITES":                        "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
 			}
 			for key, value := range env {
 				os.Setenv(key, value)
@@ -72,8 +124,36 @@ var _ = Describe("XdsServerConfig", func() {
 			// and
 			Expect(cfg.GrpcPort).To(Equal(1234))
 			Expect(cfg.DiagnosticsPort).To(Equal(3456))
+			Expect(cfg.GrpcMaxMessageSize).To(Equal(1048576))
 			Expect(cfg.DataplaneConfigurationRefreshInterval).To(Equal(3 * time.Second))
+			Expect(cfg.DataplaneConfigurationDebounceWindow).To(Equal(10 * time.Second))
+			Expect(cfg.DataplaneConfigurationHistorySize).To(Equal(5))
 			Expect(cfg.DataplaneStatusFlushInterval).To(Equal(5 * time.Second))
+			Expect(cfg.SingleOutboundListenerEnabled).To(BeTrue())
+			Expect(cfg.DataplaneAuth.Type).To(Equal(kuma_xds.ClientCertDataplaneAuth))
+			Expect(cfg.PolicyRollout.Enabled).To(BeTrue())
+			Expect(cfg.PolicyRollout.WavePercentage).To(Equal(uint32(10)))
+			Expect(cfg.PolicyRollout.WaveInterval).To(Equal(1 * time.Minute))
+			Expect(cfg.PolicyRollout.WaveTag).To(Equal("zone"))
+			Expect(cfg.PolicyRollout.MaxNackRatio).To(Equal(0.2))
+			Expect(cfg.PolicyRollout.MaxErrorRatio).To(Equal(0.05))
+			Expect(cfg.PolicyRollout.HaltTimeout).To(Equal(10 * time.Minute))
+			Expect(cfg.AccessLogForwarding.BatchSize).To(Equal(50))
+			Expect(cfg.AccessLogForwarding.FlushInterval).To(Equal(5 * time.Second))
+			Expect(cfg.AccessLogForwarding.BufferSize).To(Equal(500))
+			Expect(cfg.AccessLogForwarding.MaxRetries).To(Equal(5))
+			Expect(cfg.AccessLogForwarding.RetryBackoff).To(Equal(2 * time.Second))
+			Expect(cfg.AccessLogForwarding.Timeout).To(Equal(3 * time.Second))
+			Expect(cfg.MeshMtls.TlsMinVersion).To(Equal("TLSv1_3"))
+			Expect(cfg.MeshMtls.TlsCipherSuites).To(Equal([]string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"}))
+			Expect(cfg.Tls.Enabled).To(BeTrue())
+			Expect(cfg.Tls.CertFile).To(Equal("/etc/kuma/xds/tls.crt"))
+			Expect(cfg.Tls.KeyFile).To(Equal("/etc/kuma/xds/tls.key"))
+			Expect(cfg.Tls.ClientCaFile).To(Equal("/etc/kuma/xds/ca.crt"))
+			Expect(cfg.Tls.TlsMinVersion).To(Equal("TLSv1_2"))
+			Expect(cfg.Tls.TlsCipherSuites).To(Equal([]string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"}))
+			Expect(cfg.Tls.TlsMinVersion).To(Equal("TLSv1_2"))
+			Expect(cfg.Tls.TlsCipherSuites).To(Equal([]string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"}))
 		})
 	})
 
@@ -104,4 +184,27 @@ var _ = Describe("XdsServerConfig", func() {
 		// then
 		Expect(err).To(MatchError(`Invalid configuration: DataplaneConfigurationRefreshInterval must be positive`))
 	})
+
+	It("should require TLS for client certificate authentication", func() {
+		// given
+		cfg := kuma_xds.DefaultXdsServerConfig()
+		cfg.DataplaneAuth.Type = kuma_xds.ClientCertDataplaneAuth
+
+		// when
+		err := cfg.Validate()
+
+		// then
+		Expect(err).To(MatchError(`DataplaneAuth of type "clientCert" requires Tls to be enabled`))
+	})
+	It("should require a halted rollout of policies to be aborted eventually", func() {
+		// given
+		cfg := kuma_xds.DefaultXdsServerConfig()
+		cfg.PolicyRollout.HaltTimeout = 0
+
+		// when
+		err := cfg.Validate()
+
+		// then
+		Expect(err).To(MatchError(`PolicyRollout validation failed: HaltTimeout must be positive`))
+	})
 })
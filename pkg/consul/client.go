@@ -0,0 +1,38 @@
+package consul
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// ListIntentions retrieves all intentions from the Consul HTTP API at a given address, e.g. http://127.0.0.1:8500
+func ListIntentions(ctx context.Context, client *http.Client, address, token string) ([]Intention, error) {
+	req, err := http.NewRequest("GET", address+"/v1/connect/intentions", nil)
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		req.Header.Set("X-Consul-Token", token)
+	}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not reach Consul")
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("Consul responded with (%d): %s", resp.StatusCode, string(body))
+	}
+	var intentions []Intention
+	if err := json.Unmarshal(body, &intentions); err != nil {
+		return nil, errors.Wrap(err, "could not parse intentions")
+	}
+	return intentions, nil
+}
@@ -0,0 +1,190 @@
+package consul
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/model"
+)
+
+// ConversionOptions defines how intentions are converted into TrafficPermissions.
+type ConversionOptions struct {
+	// DefaultPolicy is the action that Consul takes on traffic that is not matched by any intention,
+	// i.e. the default ACL policy of Consul. It is either "allow" or "deny".
+	DefaultPolicy string
+	// PermitDeniedTraffic lets the conversion proceed even if the resulting TrafficPermissions permit traffic
+	// that is denied by intentions. Otherwise such intentions fail the conversion.
+	PermitDeniedTraffic bool
+}
+
+// ConvertIntentions converts Consul intentions into equivalent TrafficPermissions.
+//
+// Kuma identifies workloads by the `service` tag, which the Consul discovery source sets to the name of a Consul service.
+// Every intention that allows traffic becomes a TrafficPermission, and so does the default policy of Consul if it allows traffic.
+// Intentions that deny traffic have no equivalent, since Kuma denies any traffic that is not permitted explicitly.
+// They are skipped unless an allowing intention of lower precedence would permit some of the traffic they deny,
+// in which case the conversion fails, since access would be widened.
+//
+// Besides the resulting TrafficPermissions, it returns warnings about intentions that have been dropped
+// or cannot be represented faithfully.
+func ConvertIntentions(intentions []Intention, mesh string, opts ConversionOptions) ([]*mesh_core.TrafficPermissionResource, []string, error) {
+	if opts.DefaultPolicy != IntentionActionAllow && opts.DefaultPolicy != IntentionActionDeny {
+		return nil, nil, errors.Errorf("default policy %q is not supported, only %q and %q are", opts.DefaultPolicy, IntentionActionAllow, IntentionActionDeny)
+	}
+	sorted := make([]Intention, len(intentions))
+	copy(sorted, intentions)
+	// the same order in which Consul evaluates intentions
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Precedence != sorted[j].Precedence {
+			return sorted[i].Precedence > sorted[j].Precedence
+		}
+		if sorted[i].SourceName != sorted[j].SourceName {
+			return sorted[i].SourceName < sorted[j].SourceName
+		}
+		return sorted[i].DestinationName < sorted[j].DestinationName
+	})
+
+	matchesAll := false
+	for _, intention := range sorted {
+		if intention.SourceName == "" || intention.DestinationName == "" {
+			return nil, nil, errors.Errorf("intention %q must have both a source and a destination", intention.ID)
+		}
+		if intention.SourceType != "" && intention.SourceType != IntentionSourceTypeConsul {
+			return nil, nil, errors.Errorf("intention %s: source of type %q is not supported, only %q is", describe(intention), intention.SourceType, IntentionSourceTypeConsul)
+		}
+		if intention.Action != IntentionActionAllow && intention.Action != IntentionActionDeny {
+			return nil, nil, errors.Errorf("intention %s: action %q is not supported, only %q and %q are", describe(intention), intention.Action, IntentionActionAllow, IntentionActionDeny)
+		}
+		if intention.SourceName == IntentionWildcard && intention.DestinationName == IntentionWildcard {
+			matchesAll = true
+		}
+	}
+	// the default policy applies to traffic that is not matched by any intention, i.e. it acts as an intention of the lowest precedence
+	if opts.DefaultPolicy == IntentionActionAllow && !matchesAll {
+		sorted = append(sorted, Intention{
+			SourceName:      IntentionWildcard,
+			DestinationName: IntentionWildcard,
+			Action:          IntentionActionAllow,
+		})
+	}
+
+	var warnings []string
+	var permissions []*mesh_core.TrafficPermissionResource
+	for _, intention := range sorted {
+		if intention.Action != IntentionActionAllow {
+			continue
+		}
+		if hasNamespace(intention) {
+			warnings = append(warnings, fmt.Sprintf("intention %s: namespaces are not supported: traffic will be permitted regardless of namespaces of services", describe(intention)))
+		}
+		permissions = append(permissions, &mesh_core.TrafficPermissionResource{
+			Meta: &policyMeta{
+				Name:      policyName(intention),
+				Namespace: "default",
+				Mesh:      mesh,
+			},
+			Spec: mesh_proto.TrafficPermission{
+				Rules: []*mesh_proto.TrafficPermission_Rule{{
+					Sources: []*mesh_proto.TrafficPermission_Rule_Selector{
+						{Match: map[string]string{mesh_proto.ServiceTag: intention.SourceName}},
+					},
+					Destinations: []*mesh_proto.TrafficPermission_Rule_Selector{
+						{Match: map[string]string{mesh_proto.ServiceTag: intention.DestinationName}},
+					},
+				}},
+			},
+		})
+	}
+	for i, deny := range sorted {
+		if deny.Action != IntentionActionDeny {
+			continue
+		}
+		// only allowing intentions that Consul evaluates after the deny would permit traffic it denies
+		if allow, widened := findWidening(deny, sorted[i+1:]); widened {
+			if !opts.PermitDeniedTraffic {
+				return nil, nil, DeniedTrafficPermitted(deny, allow)
+			}
+			warnings = append(warnings, fmt.Sprintf("intention %s: there are no policies that deny traffic in Kuma: traffic will be permitted by TrafficPermission %q", describe(deny), policyName(allow)))
+		} else {
+			warnings = append(warnings, fmt.Sprintf("intention %s: skipped since Kuma denies traffic that is not permitted by a TrafficPermission", describe(deny)))
+		}
+	}
+	return permissions, warnings, nil
+}
+
+// findWidening finds the first allowing intention that permits some of the traffic denied by a given intention.
+func findWidening(deny Intention, next []Intention) (Intention, bool) {
+	for _, allow := range next {
+		if allow.Action == IntentionActionAllow && overlaps(allow.SourceName, deny.SourceName) && overlaps(allow.DestinationName, deny.DestinationName) {
+			return allow, true
+		}
+	}
+	return Intention{}, false
+}
+
+func DeniedTrafficPermitted(deny Intention, allow Intention) error {
+	return errors.Errorf("denied traffic permitted: intention %s denies traffic that TrafficPermission %q would permit, since there are no policies that deny traffic in Kuma", describe(deny), policyName(allow))
+}
+
+func IsDeniedTrafficPermitted(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), "denied traffic permitted:")
+}
+
+// overlaps tells whether service names of two intentions match any service in common.
+func overlaps(name, other string) bool {
+	return name == IntentionWildcard || other == IntentionWildcard || name == other
+}
+
+func hasNamespace(intention Intention) bool {
+	return (intention.SourceNS != "" && intention.SourceNS != "default") ||
+		(intention.DestinationNS != "" && intention.DestinationNS != "default")
+}
+
+func describe(intention Intention) string {
+	return fmt.Sprintf("%q => %q", intention.SourceName, intention.DestinationName)
+}
+
+// policyName names a policy after services of the intention it has been converted from.
+func policyName(intention Intention) string {
+	return fmt.Sprintf("%s-to-%s", serviceName(intention.SourceName), serviceName(intention.DestinationName))
+}
+
+func serviceName(name string) string {
+	if name == IntentionWildcard {
+		return "any"
+	}
+	return name
+}
+
+var _ model.ResourceMeta = &policyMeta{}
+
+type policyMeta struct {
+	Name      string
+	Namespace string
+	Mesh      string
+}
+
+func (m *policyMeta) GetName() string {
+	return m.Name
+}
+
+func (m *policyMeta) GetNamespace() string {
+	return m.Namespace
+}
+
+func (m *policyMeta) GetVersion() string {
+	return ""
+}
+
+func (m *policyMeta) GetMesh() string {
+	return m.Mesh
+}
+
+func (m *policyMeta) GetLabels() map[string]string {
+	return nil
+}
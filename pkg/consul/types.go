@@ -0,0 +1,27 @@
+package consul
+
+const (
+	IntentionActionAllow = "allow"
+	IntentionActionDeny  = "deny"
+
+	// IntentionSourceTypeConsul is the only type of source of Consul intentions, i.e. a Consul service.
+	IntentionSourceTypeConsul = "consul"
+
+	// IntentionWildcard matches any service.
+	IntentionWildcard = "*"
+)
+
+// Intention defines whether a service is allowed to connect to another service in Consul Connect.
+//
+// Only the fields that are relevant for conversion into Kuma policies are defined.
+// See https://www.consul.io/api/connect/intentions.html
+type Intention struct {
+	ID              string `json:"ID"`
+	SourceNS        string `json:"SourceNS,omitempty"`
+	SourceName      string `json:"SourceName"`
+	DestinationNS   string `json:"DestinationNS,omitempty"`
+	DestinationName string `json:"DestinationName"`
+	SourceType      string `json:"SourceType,omitempty"`
+	Action          string `json:"Action"`
+	Precedence      int    `json:"Precedence"`
+}
@@ -1,6 +1,8 @@
 package core
 
 import (
+	"time"
+
 	kube_uuid "k8s.io/apimachinery/pkg/util/uuid"
 	kube_log "sigs.k8s.io/controller-runtime/pkg/log"
 	kube_signals "sigs.k8s.io/controller-runtime/pkg/manager/signals"
@@ -18,4 +20,6 @@ var (
 	NewUUID = func() string {
 		return string(kube_uuid.NewUUID())
 	}
+
+	Now = time.Now
 )
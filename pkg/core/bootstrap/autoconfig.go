@@ -14,7 +14,16 @@ import (
 var autoconfigureLog = core.Log.WithName("bootstrap").WithName("auto-configure")
 
 func autoconfigure(cfg *kuma_cp.Config) error {
-	return autoconfigureSds(cfg)
+	if err := autoconfigureSds(cfg); err != nil {
+		return err
+	}
+	if err := autoconfigureXdsServerTls(cfg); err != nil {
+		return err
+	}
+	if err := autoconfigureApiServerTls(cfg); err != nil {
+		return err
+	}
+	return autoconfigureFips(cfg)
 }
 
 func autoconfigureSds(cfg *kuma_cp.Config) error {
@@ -30,7 +39,7 @@ func autoconfigureSds(cfg *kuma_cp.Config) error {
 			if err != nil {
 				return errors.Wrap(err, "failed to auto-generate TLS certificate for SDS server")
 			}
-			crtFile, keyFile, err := saveKeyPair(sdsCert)
+			crtFile, keyFile, err := saveKeyPair(cfg.WorkDirOrTemp(), sdsCert)
 			if err != nil {
 				return errors.Wrap(err, "failed to save auto-generated TLS certificate for SDS server")
 			}
@@ -43,8 +52,90 @@ func autoconfigureSds(cfg *kuma_cp.Config) error {
 	return nil
 }
 
-func saveKeyPair(pair tls.KeyPair) (string, string, error) {
-	crtFile, err := ioutil.TempFile("", "*.crt")
+func autoconfigureXdsServerTls(cfg *kuma_cp.Config) error {
+	if !cfg.XdsServer.Tls.Enabled {
+		return nil
+	}
+	if cfg.XdsServer.Tls.CertFile == "" {
+		// Dataplanes connect to XDS Server at the address from their bootstrap config
+		hosts := []string{
+			cfg.BootstrapServer.Params.XdsHost,
+			"localhost",
+		}
+		crtFile, keyFile, err := autoGenerateServerCert(cfg, "kuma-xds", hosts)
+		if err != nil {
+			return errors.Wrap(err, "failed to auto-generate TLS certificate for XDS server")
+		}
+		cfg.XdsServer.Tls.CertFile = crtFile
+		cfg.XdsServer.Tls.KeyFile = keyFile
+
+		autoconfigureLog.Info("auto-generated TLS certificate for XDS server", "crtFile", crtFile, "keyFile", keyFile)
+	}
+	if cfg.BootstrapServer.Params.XdsCaFile == "" {
+		// Dataplanes have to trust the cert of XDS server
+		cfg.BootstrapServer.Params.XdsCaFile = cfg.XdsServer.Tls.CertFile
+	}
+	return nil
+}
+
+func autoconfigureApiServerTls(cfg *kuma_cp.Config) error {
+	if !cfg.ApiServer.Tls.Enabled || cfg.ApiServer.Tls.CertFile != "" {
+		return nil
+	}
+	hosts := cfg.ApiServer.Tls.Hosts
+	if len(hosts) == 0 {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return errors.Wrap(err, "failed to determine hostname for TLS certificate of API server, set apiServer.tls.hosts explicitly")
+		}
+		hosts = []string{hostname, "localhost", "127.0.0.1"}
+	}
+	crtFile, keyFile, err := autoGenerateServerCert(cfg, "kuma-api-server", hosts)
+	if err != nil {
+		return errors.Wrap(err, "failed to auto-generate TLS certificate for API server")
+	}
+	cfg.ApiServer.Tls.CertFile = crtFile
+	cfg.ApiServer.Tls.KeyFile = keyFile
+
+	autoconfigureLog.Info("auto-generated TLS certificate for API server", "crtFile", crtFile, "keyFile", keyFile, "hosts", hosts)
+	return nil
+}
+
+func autoGenerateServerCert(cfg *kuma_cp.Config, commonName string, hosts []string) (string, string, error) {
+	cert, err := tls.NewSelfSignedCert(commonName, hosts...)
+	if err != nil {
+		return "", "", err
+	}
+	return saveKeyPair(cfg.WorkDirOrTemp(), cert)
+}
+
+func autoconfigureFips(cfg *kuma_cp.Config) error {
+	if !cfg.FipsMode {
+		return nil
+	}
+	cfg.ApplyFipsDefaults()
+	if cfg.SdsServer.TlsCertFile != "" {
+		certPEM, err := ioutil.ReadFile(cfg.SdsServer.TlsCertFile)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read TLS certificate for SDS server from %q", cfg.SdsServer.TlsCertFile)
+		}
+		if err := tls.ValidateFIPSCert(certPEM); err != nil {
+			return errors.Wrap(err, "TLS certificate for SDS server is not valid in FIPS mode")
+		}
+	}
+	if !tls.BoringCrypto {
+		autoconfigureLog.Info("FIPS mode is enabled, but Control Plane is not built with BoringCrypto. " +
+			"Only FIPS-approved TLS settings are allowed, but cryptographic operations are not performed by a FIPS-validated module")
+	}
+	autoconfigureLog.Info("FIPS mode is enabled", "sdsServerTlsCipherSuites", cfg.SdsServer.TlsCipherSuites, "meshMtlsTlsCipherSuites", cfg.XdsServer.MeshMtls.TlsCipherSuites)
+	return nil
+}
+
+func saveKeyPair(dir string, pair tls.KeyPair) (string, string, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", "", errors.Wrapf(err, "failed to create a directory %q for TLS cert", dir)
+	}
+	crtFile, err := ioutil.TempFile(dir, "*.crt")
 	if err != nil {
 		return "", "", errors.Wrap(err, "failed to create a temp file with TLS cert")
 	}
@@ -52,7 +143,7 @@ func saveKeyPair(pair tls.KeyPair) (string, string, error) {
 		return "", "", errors.Wrapf(err, "failed to save TLS cert into a temp file %q", crtFile.Name())
 	}
 
-	keyFile, err := ioutil.TempFile("", "*.key")
+	keyFile, err := ioutil.TempFile(dir, "*.key")
 	if err != nil {
 		return "", "", errors.Wrap(err, "failed to create a temp file with TLS key")
 	}
@@ -0,0 +1,220 @@
+package bootstrap
+
+import (
+	"crypto/elliptic"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	kuma_cp "github.com/Kong/kuma/pkg/config/app/kuma-cp"
+	"github.com/Kong/kuma/pkg/tls"
+)
+
+var _ = Describe("Auto configuration", func() {
+
+	Describe("SDS", func() {
+
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "kuma-cp")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			Expect(os.RemoveAll(dir)).To(Succeed())
+		})
+
+		It("should save an auto-generated TLS certificate into the work directory", func() {
+			// given
+			cfg := kuma_cp.DefaultConfig()
+			cfg.WorkDir = filepath.Join(dir, "work")
+
+			// when
+			err := autoconfigure(&cfg)
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(filepath.Dir(cfg.SdsServer.TlsCertFile)).To(Equal(cfg.WorkDir))
+			Expect(filepath.Dir(cfg.SdsServer.TlsKeyFile)).To(Equal(cfg.WorkDir))
+			Expect(cfg.SdsServer.TlsCertFile).To(BeARegularFile())
+			Expect(cfg.SdsServer.TlsKeyFile).To(BeARegularFile())
+		})
+	})
+
+	Describe("TLS of XDS and API servers", func() {
+
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "kuma-cp")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			Expect(os.RemoveAll(dir)).To(Succeed())
+		})
+
+		It("should not generate certificates when TLS is disabled", func() {
+			// given
+			cfg := kuma_cp.DefaultConfig()
+			cfg.WorkDir = dir
+
+			// when
+			err := autoconfigure(&cfg)
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cfg.XdsServer.Tls.CertFile).To(BeEmpty())
+			Expect(cfg.ApiServer.Tls.CertFile).To(BeEmpty())
+			Expect(cfg.BootstrapServer.Params.XdsCaFile).To(BeEmpty())
+		})
+
+		It("should auto-generate TLS certificates when TLS is enabled", func() {
+			// given
+			cfg := kuma_cp.DefaultConfig()
+			cfg.WorkDir = dir
+			cfg.XdsServer.Tls.Enabled = true
+			cfg.ApiServer.Tls.Enabled = true
+
+			// when
+			err := autoconfigure(&cfg)
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cfg.XdsServer.Tls.CertFile).To(BeARegularFile())
+			Expect(cfg.XdsServer.Tls.KeyFile).To(BeARegularFile())
+			Expect(cfg.ApiServer.Tls.CertFile).To(BeARegularFile())
+			Expect(cfg.ApiServer.Tls.KeyFile).To(BeARegularFile())
+			// and Dataplanes should trust the auto-generated certificate
+			Expect(cfg.BootstrapServer.Params.XdsCaFile).To(Equal(cfg.XdsServer.Tls.CertFile))
+		})
+
+		It("should auto-generate TLS certificate of API server for its hosts rather than for XDS server", func() {
+			// given
+			cfg := kuma_cp.DefaultConfig()
+			cfg.WorkDir = dir
+			cfg.BootstrapServer.Params.XdsHost = "kuma-xds.internal"
+			cfg.ApiServer.Tls.Enabled = true
+			cfg.ApiServer.Tls.Hosts = []string{"kuma-api.internal", "10.0.0.1"}
+
+			// when
+			err := autoconfigure(&cfg)
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			cert := loadCert(cfg.ApiServer.Tls.CertFile)
+			Expect(cert.DNSNames).To(Equal([]string{"kuma-api.internal"}))
+			Expect(cert.IPAddresses).To(HaveLen(1))
+			Expect(cert.IPAddresses[0].String()).To(Equal("10.0.0.1"))
+		})
+
+		It("should auto-generate TLS certificate of API server for the hostname of the machine by default", func() {
+			// given
+			cfg := kuma_cp.DefaultConfig()
+			cfg.WorkDir = dir
+			cfg.ApiServer.Tls.Enabled = true
+			hostname, err := os.Hostname()
+			Expect(err).ToNot(HaveOccurred())
+
+			// when
+			err = autoconfigure(&cfg)
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			cert := loadCert(cfg.ApiServer.Tls.CertFile)
+			Expect(cert.VerifyHostname(hostname)).To(Succeed())
+			Expect(cert.VerifyHostname("localhost")).To(Succeed())
+		})
+
+		It("should keep provided TLS certificates", func() {
+			// given
+			cfg := kuma_cp.DefaultConfig()
+			cfg.WorkDir = dir
+			cfg.XdsServer.Tls.Enabled = true
+			cfg.XdsServer.Tls.CertFile = "/etc/kuma/xds/tls.crt"
+			cfg.XdsServer.Tls.KeyFile = "/etc/kuma/xds/tls.key"
+			cfg.BootstrapServer.Params.XdsCaFile = "/etc/kuma/xds/ca.crt"
+
+			// when
+			err := autoconfigure(&cfg)
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cfg.XdsServer.Tls.CertFile).To(Equal("/etc/kuma/xds/tls.crt"))
+			Expect(cfg.XdsServer.Tls.KeyFile).To(Equal("/etc/kuma/xds/tls.key"))
+			Expect(cfg.BootstrapServer.Params.XdsCaFile).To(Equal("/etc/kuma/xds/ca.crt"))
+		})
+	})
+
+	Describe("FIPS mode", func() {
+
+		It("should default TLS settings to FIPS-approved values", func() {
+			// given
+			cfg := kuma_cp.DefaultConfig()
+			cfg.FipsMode = true
+			cfg.XdsServer.MeshMtls.TlsCipherSuites = []string{"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384"}
+
+			// when
+			err := autoconfigure(&cfg)
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cfg.SdsServer.TlsMinVersion).To(Equal("TLSv1_2"))
+			Expect(cfg.SdsServer.TlsCipherSuites).To(Equal(tls.FIPSCipherSuites()))
+			Expect(cfg.XdsServer.MeshMtls.TlsMinVersion).To(Equal("TLSv1_2"))
+			Expect(cfg.XdsServer.MeshMtls.TlsCipherSuites).To(Equal([]string{"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384"}))
+			// and
+			Expect(cfg.Validate()).To(Succeed())
+		})
+
+		It("should refuse a TLS certificate of SDS server with a key that is not FIPS-approved", func() {
+			// given
+			pair, err := tls.NewSelfSignedCertWithOptions("kuma-sds", tls.WithECDSAKey(elliptic.P224()))
+			Expect(err).ToNot(HaveOccurred())
+			crtFile, keyFile, err := saveKeyPair(os.TempDir(), pair)
+			Expect(err).ToNot(HaveOccurred())
+			// and
+			cfg := kuma_cp.DefaultConfig()
+			cfg.FipsMode = true
+			cfg.SdsServer.TlsCertFile = crtFile
+			cfg.SdsServer.TlsKeyFile = keyFile
+
+			// when
+			err = autoconfigure(&cfg)
+
+			// then
+			Expect(err).To(MatchError(`TLS certificate for SDS server is not valid in FIPS mode: certificate "kuma-sds": ECDSA key on curve P-224 is not FIPS-approved, must be one of P-256, P-384, P-521`))
+		})
+
+		It("should refuse cipher suites that are not FIPS-approved", func() {
+			// given
+			cfg := kuma_cp.DefaultConfig()
+			cfg.FipsMode = true
+			cfg.SdsServer.TlsCipherSuites = []string{"TLS_RSA_WITH_AES_128_CBC_SHA"}
+
+			// when
+			err := cfg.Validate()
+
+			// then
+			Expect(err).To(MatchError(HavePrefix(`FIPS mode validation failed: SDS Server TLS settings are not valid: cipher suite "TLS_RSA_WITH_AES_128_CBC_SHA" is not FIPS-approved`)))
+		})
+	})
+})
+
+func loadCert(file string) *x509.Certificate {
+	certPEM, err := ioutil.ReadFile(file)
+	Expect(err).ToNot(HaveOccurred())
+	block, _ := pem.Decode(certPEM)
+	Expect(block).ToNot(BeNil())
+	cert, err := x509.ParseCertificate(block.Bytes)
+	Expect(err).ToNot(HaveOccurred())
+	return cert
+}
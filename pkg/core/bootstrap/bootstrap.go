@@ -2,12 +2,19 @@ package bootstrap
 
 import (
 	"context"
+	"time"
 
 	kuma_cp "github.com/Kong/kuma/pkg/config/app/kuma-cp"
 	"github.com/Kong/kuma/pkg/config/core/resources/store"
 	"github.com/Kong/kuma/pkg/core"
 	builtin_ca "github.com/Kong/kuma/pkg/core/ca/builtin"
+	provided_ca "github.com/Kong/kuma/pkg/core/ca/provided"
+	"github.com/Kong/kuma/pkg/core/expiry"
+	dataplane_managers "github.com/Kong/kuma/pkg/core/managers/apis/dataplane"
 	mesh_managers "github.com/Kong/kuma/pkg/core/managers/apis/mesh"
+	constraint_managers "github.com/Kong/kuma/pkg/core/managers/constraint"
+	notification_managers "github.com/Kong/kuma/pkg/core/managers/notification"
+	quota_managers "github.com/Kong/kuma/pkg/core/managers/quota"
 	core_plugins "github.com/Kong/kuma/pkg/core/plugins"
 	"github.com/Kong/kuma/pkg/core/resources/apis/mesh"
 	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
@@ -18,14 +25,23 @@ import (
 	secret_cipher "github.com/Kong/kuma/pkg/core/secrets/cipher"
 	secret_manager "github.com/Kong/kuma/pkg/core/secrets/manager"
 	core_xds "github.com/Kong/kuma/pkg/core/xds"
+	"github.com/Kong/kuma/pkg/metrics"
+	secrets_k8s "github.com/Kong/kuma/pkg/plugins/secrets/k8s"
+	k8s_runtime "github.com/Kong/kuma/pkg/runtime/k8s"
+	util_http "github.com/Kong/kuma/pkg/util/http"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
+// expiredPolicyCheckInterval defines how long a policy can outlive its expiration time.
+const expiredPolicyCheckInterval = 10 * time.Second
+
 func buildRuntime(cfg kuma_cp.Config) (core_runtime.Runtime, error) {
 	if err := autoconfigure(&cfg); err != nil {
 		return nil, err
 	}
 	builder := core_runtime.BuilderFor(cfg)
+	initializeEgressProxy(cfg, builder)
 	if err := initializeBootstrap(cfg, builder); err != nil {
 		return nil, err
 	}
@@ -35,15 +51,33 @@ func buildRuntime(cfg kuma_cp.Config) (core_runtime.Runtime, error) {
 	if err := initializeSecretManager(cfg, builder); err != nil {
 		return nil, err
 	}
-	if err := initializeDiscovery(cfg, builder); err != nil {
+
+	initializeBuiltinCaManager(cfg, builder)
+
+	if err := initializeProvidedCaManager(cfg, builder); err != nil {
 		return nil, err
 	}
 
-	initializeBuiltinCaManager(builder)
+	initializeXds(cfg, builder)
+
+	if err := initializeMetrics(builder); err != nil {
+		return nil, err
+	}
 
-	initializeResourceManager(builder)
+	if err := initializeResourceManager(cfg, builder); err != nil {
+		return nil, err
+	}
 
-	initializeXds(builder)
+	// discovered Dataplanes might be kept in the ResourceStore through the ResourceManager
+	if cfg.HasRole(kuma_cp.ControllersOnlyRole) {
+		if err := initializeDiscovery(cfg, builder); err != nil {
+			return nil, err
+		}
+		// every instance that watches changes of policies would notify about them
+		if err := initializeNotifications(builder); err != nil {
+			return nil, err
+		}
+	}
 
 	rt, err := builder.Build()
 	if err != nil {
@@ -99,6 +133,10 @@ func Bootstrap(cfg kuma_cp.Config) (core_runtime.Runtime, error) {
 }
 
 func onStartup(runtime core_runtime.Runtime, cfg kuma_cp.Config) error {
+	// default resources are maintained and usage is reported by a single role only
+	if !cfg.HasRole(kuma_cp.ControllersOnlyRole) {
+		return nil
+	}
 	err := runtime.Add(core_runtime.ComponentFunc(func(stop <-chan struct{}) error {
 		if err := createDefaultMesh(runtime); err != nil {
 			return err
@@ -115,8 +153,11 @@ func onStartup(runtime core_runtime.Runtime, cfg kuma_cp.Config) error {
 		<-stop
 		return nil
 	}))
+	if err != nil {
+		return err
+	}
 
-	return nil
+	return runtime.Add(expiry.NewExpiredPolicyCleaner(runtime.ResourceManager(), expiredPolicyCheckInterval))
 }
 
 func initializeBootstrap(cfg kuma_cp.Config, builder *core_runtime.Builder) error {
@@ -139,10 +180,15 @@ func initializeBootstrap(cfg kuma_cp.Config, builder *core_runtime.Builder) erro
 	return nil
 }
 
+// initializeEgressProxy makes outbound HTTP clients of Control Plane, which use the egress transport, go through the egress proxy.
+func initializeEgressProxy(cfg kuma_cp.Config, builder *core_runtime.Builder) {
+	builder.WithEgressTransport(util_http.ProxyTransport(cfg.EgressProxy.URL, cfg.EgressProxy.NoProxy))
+}
+
 func initializeResourceStore(cfg kuma_cp.Config, builder *core_runtime.Builder) error {
 	var pluginName core_plugins.PluginName
 	var pluginConfig core_plugins.PluginConfig
-	switch cfg.Store.Type {
+	switch cfg.StoreType() {
 	case store.KubernetesStore:
 		pluginName = core_plugins.Kubernetes
 		pluginConfig = nil
@@ -153,7 +199,7 @@ func initializeResourceStore(cfg kuma_cp.Config, builder *core_runtime.Builder)
 		pluginName = core_plugins.Postgres
 		pluginConfig = cfg.Store.Postgres
 	default:
-		return errors.Errorf("unknown store type %s", cfg.Store.Type)
+		return errors.Errorf("unknown store type %s", cfg.StoreType())
 	}
 	plugin, err := core_plugins.Plugins().ResourceStore(pluginName)
 	if err != nil {
@@ -171,7 +217,7 @@ func initializeSecretManager(cfg kuma_cp.Config, builder *core_runtime.Builder)
 	var pluginName core_plugins.PluginName
 	var pluginConfig core_plugins.PluginConfig
 	var cipher secret_cipher.Cipher
-	switch cfg.Store.Type {
+	switch cfg.StoreType() {
 	case store.KubernetesStore:
 		pluginName = core_plugins.Kubernetes
 		cipher = secret_cipher.None() // deliberately turn encryption off on Kubernetes
@@ -179,7 +225,7 @@ func initializeSecretManager(cfg kuma_cp.Config, builder *core_runtime.Builder)
 		pluginName = core_plugins.Universal
 		cipher = secret_cipher.TODO() // get back to encryption in universal case
 	default:
-		return errors.Errorf("unknown store type %s", cfg.Store.Type)
+		return errors.Errorf("unknown store type %s", cfg.StoreType())
 	}
 	plugin, err := core_plugins.Plugins().SecretStore(pluginName)
 	if err != nil {
@@ -199,7 +245,7 @@ func initializeDiscovery(cfg kuma_cp.Config, builder *core_runtime.Builder) erro
 	switch cfg.Environment {
 	case kuma_cp.KubernetesEnvironment:
 		pluginName = core_plugins.Kubernetes
-		pluginConfig = nil
+		pluginConfig = cfg.Discovery.Kubernetes
 	case kuma_cp.UniversalEnvironment:
 		pluginName = core_plugins.Universal
 		pluginConfig = cfg.Discovery.Universal
@@ -215,23 +261,86 @@ func initializeDiscovery(cfg kuma_cp.Config, builder *core_runtime.Builder) erro
 	} else {
 		builder.AddDiscoverySource(source)
 	}
+	if cfg.Environment == kuma_cp.UniversalEnvironment && cfg.Discovery.Consul.Enabled {
+		plugin, err := core_plugins.Plugins().Discovery(core_plugins.Consul)
+		if err != nil {
+			return err
+		}
+		source, err := plugin.NewDiscoverySource(builder, cfg.Discovery.Consul)
+		if err != nil {
+			return err
+		}
+		builder.AddDiscoverySource(source)
+	}
 	return nil
 }
 
-func initializeXds(builder *core_runtime.Builder) {
-	builder.WithXdsContext(core_xds.NewXdsContext())
+func initializeXds(cfg kuma_cp.Config, builder *core_runtime.Builder) {
+	builder.WithXdsContext(core_xds.NewXdsContext(
+		core_xds.WithConfigHistory(core_xds.NewConfigHistory(cfg.XdsServer.DataplaneConfigurationHistorySize)),
+		core_xds.WithPolicyRollout(core_xds.NewPolicyRollout(*cfg.XdsServer.PolicyRollout)),
+	))
 }
 
-func initializeBuiltinCaManager(builder *core_runtime.Builder) {
-	builder.WithBuiltinCaManager(builtin_ca.NewBuiltinCaManager(builder.SecretManager()))
+func initializeBuiltinCaManager(cfg kuma_cp.Config, builder *core_runtime.Builder) {
+	builder.WithBuiltinCaManager(builtin_ca.NewBuiltinCaManagerWithCertValidity(builder.SecretManager(), cfg.SdsServer.CertRotation.WorkloadCertValidityPeriod, cfg.SdsServer.CertRotation.AllowedClockSkew))
 }
 
-func initializeResourceManager(builder *core_runtime.Builder) {
-	defaultManager := core_manager.NewResourceManager(builder.ResourceStore())
-	meshManager := mesh_managers.NewMeshManager(builder.ResourceStore(), builder.BuiltinCaManager())
+func initializeProvidedCaManager(cfg kuma_cp.Config, builder *core_runtime.Builder) error {
+	var secretLoader provided_ca.SecretLoader
+	if cfg.Environment == kuma_cp.KubernetesEnvironment {
+		mgr, ok := k8s_runtime.FromManagerContext(builder.Extensions())
+		if !ok {
+			return errors.Errorf("k8s controller runtime Manager hasn't been configured")
+		}
+		secretLoader = secrets_k8s.NewTLSSecretLoader(mgr.GetAPIReader(), cfg.Store.Kubernetes.SystemNamespace)
+	}
+	caFiles := map[string]provided_ca.CaFiles{}
+	for _, ca := range cfg.SdsServer.ProvidedCas {
+		caFiles[ca.Name] = provided_ca.CaFiles{CertFile: ca.CertFile, KeyFile: ca.KeyFile}
+	}
+	builder.WithProvidedCaManager(provided_ca.NewProvidedCaManager(secretLoader, caFiles, cfg.SdsServer.CertRotation.WorkloadCertValidityPeriod, cfg.SdsServer.CertRotation.AllowedClockSkew))
+	return nil
+}
+
+func initializeResourceManager(cfg kuma_cp.Config, builder *core_runtime.Builder) error {
+	quotaManager := quota_managers.NewQuotaManager(core_manager.NewResourceManager(builder.ResourceStore()), builder.ResourceStore(), *cfg.Quota)
+	defaultManager := constraint_managers.NewPolicyConstraintManager(quotaManager, builder.ResourceStore())
+	meshManager := mesh_managers.NewMeshManager(builder.ResourceStore(), builder.SecretManager(), builder.BuiltinCaManager(), builder.ProvidedCaManager())
 	customManagers := map[core_model.ResourceType]core_manager.ResourceManager{
 		mesh.MeshType: meshManager,
 	}
+	if cfg.Environment == kuma_cp.KubernetesEnvironment && cfg.Discovery.Kubernetes.UniversalDataplanesEnabled {
+		customManagers[mesh.DataplaneType] = dataplane_managers.NewKubernetesDataplaneManager(defaultManager)
+	}
 	customizableManager := core_manager.NewCustomizableResourceManager(defaultManager, customManagers)
 	builder.WithResourceManager(customizableManager)
+	return nil
+}
+
+func initializeNotifications(builder *core_runtime.Builder) error {
+	watcher, ok := builder.ResourceStore().(core_store.ResourceWatcher)
+	if !ok {
+		return errors.New("policy change notifications require a resource store that supports watching changes")
+	}
+	notifier := notification_managers.NewWebhookNotifier(builder.ResourceStore(), watcher, builder.EgressTransport())
+	if err := builder.ComponentManager().Add(notifier); err != nil {
+		return err
+	}
+	pending := prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "policy_change_notifications_pending",
+		Help: "Number of policy changes waiting to be delivered to webhooks",
+	}, func() float64 {
+		return float64(notifier.Pending())
+	})
+	return builder.Metrics().Register(pending)
+}
+
+func initializeMetrics(builder *core_runtime.Builder) error {
+	m, err := metrics.NewMetrics()
+	if err != nil {
+		return err
+	}
+	builder.WithMetrics(m)
+	return nil
 }
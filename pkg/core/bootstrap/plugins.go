@@ -13,6 +13,7 @@ import (
 	_ "github.com/Kong/kuma/pkg/plugins/secrets/k8s"
 	_ "github.com/Kong/kuma/pkg/plugins/secrets/universal"
 
+	_ "github.com/Kong/kuma/pkg/plugins/discovery/consul"
 	_ "github.com/Kong/kuma/pkg/plugins/discovery/k8s"
 	_ "github.com/Kong/kuma/pkg/plugins/discovery/universal"
 )
@@ -0,0 +1,13 @@
+package builtin_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestBuiltinCaManager(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Builtin CA Manager Suite")
+}
@@ -24,6 +24,7 @@ const (
 	DefaultAllowedClockSkew           = 10 * time.Second
 	DefaultCACertValidityPeriod       = 10 * 365 * 24 * time.Hour
 	DefaultWorkloadCertValidityPeriod = 90 * 24 * time.Hour
+	DefaultCRLValidityPeriod          = 7 * 24 * time.Hour
 )
 
 func NewRootCA(mesh string) (*util_tls.KeyPair, error) {
@@ -60,6 +61,17 @@ func newCACert(signer crypto.Signer, trustDomain string) ([]byte, error) {
 }
 
 func NewWorkloadCert(ca util_tls.KeyPair, mesh string, workload string) (*util_tls.KeyPair, error) {
+	return NewWorkloadCertValidFor(DefaultWorkloadCertValidityPeriod, ca, mesh, workload)
+}
+
+// NewWorkloadCertValidFor generates a Workload Identity certificate that expires after a given period of time.
+func NewWorkloadCertValidFor(validity time.Duration, ca util_tls.KeyPair, mesh string, workload string) (*util_tls.KeyPair, error) {
+	return NewWorkloadCertWithClockSkew(validity, DefaultAllowedClockSkew, ca, mesh, workload)
+}
+
+// NewWorkloadCertWithClockSkew generates a Workload Identity certificate that expires after a given period of time
+// and is backdated by allowedClockSkew, so that peers whose clocks are behind accept it right after it is issued.
+func NewWorkloadCertWithClockSkew(validity time.Duration, allowedClockSkew time.Duration, ca util_tls.KeyPair, mesh string, workload string) (*util_tls.KeyPair, error) {
 	caPrivateKey, caCert, err := loadKeyPair(ca)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to load CA key pair")
@@ -69,14 +81,14 @@ func NewWorkloadCert(ca util_tls.KeyPair, mesh string, workload string) (*util_t
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to generate a private key")
 	}
-	workloadCert, err := newWorkloadCert(caPrivateKey, caCert, mesh, workload, workloadKey.Public())
+	workloadCert, err := newWorkloadCert(caPrivateKey, caCert, mesh, workload, workloadKey.Public(), validity, allowedClockSkew)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to generate X509 certificate")
 	}
 	return keyPair(workloadKey, workloadCert)
 }
 
-func newWorkloadCert(signer crypto.PrivateKey, parent *x509.Certificate, trustDomain string, workload string, publicKey crypto.PublicKey) ([]byte, error) {
+func newWorkloadCert(signer crypto.PrivateKey, parent *x509.Certificate, trustDomain string, workload string, publicKey crypto.PublicKey, validity time.Duration, allowedClockSkew time.Duration) ([]byte, error) {
 	spiffeID := &url.URL{
 		Scheme: "spiffe",
 		Host:   trustDomain,
@@ -84,8 +96,8 @@ func newWorkloadCert(signer crypto.PrivateKey, parent *x509.Certificate, trustDo
 	}
 
 	now := time.Now()
-	notBefore := now.Add(-DefaultAllowedClockSkew)
-	notAfter := now.Add(DefaultWorkloadCertValidityPeriod)
+	notBefore := now.Add(-allowedClockSkew)
+	notAfter := now.Add(validity)
 
 	serialNumber, err := x509util.NewSerialNumber()
 	if err != nil {
@@ -100,6 +112,29 @@ func newWorkloadCert(signer crypto.PrivateKey, parent *x509.Certificate, trustDo
 	return x509.CreateCertificate(rand.Reader, template, parent, publicKey, signer)
 }
 
+// NewCRL generates a PEM-encoded Certificate Revocation List that is signed by a given CA
+// and is valid for a given period of time.
+func NewCRL(validity time.Duration, ca util_tls.KeyPair, revoked []pkix.RevokedCertificate) ([]byte, error) {
+	caPrivateKey, caCert, err := loadKeyPair(ca)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load CA key pair")
+	}
+	signer, ok := caPrivateKey.(crypto.Signer)
+	if !ok {
+		return nil, errors.Errorf("unsupported private key type %T", caPrivateKey)
+	}
+	now := time.Now()
+	crl, err := caCert.CreateCRL(rand.Reader, signer, revoked, now.Add(-DefaultAllowedClockSkew), now.Add(validity))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate a CRL")
+	}
+	var crlBuf bytes.Buffer
+	if err := pem.Encode(&crlBuf, &pem.Block{Type: "X509 CRL", Bytes: crl}); err != nil {
+		return nil, err
+	}
+	return crlBuf.Bytes(), nil
+}
+
 func loadKeyPair(pair util_tls.KeyPair) (crypto.PrivateKey, *x509.Certificate, error) {
 	root, err := tls.X509KeyPair(pair.CertPEM, pair.KeyPEM)
 	if err != nil {
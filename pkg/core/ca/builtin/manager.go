@@ -2,12 +2,17 @@ package builtin
 
 import (
 	"context"
+	"crypto/x509/pkix"
 	"encoding/json"
 	"fmt"
+	"math/big"
+	"strings"
+	"time"
 
 	"github.com/gogo/protobuf/types"
 	"github.com/pkg/errors"
 
+	"github.com/Kong/kuma/pkg/core"
 	builtin_issuer "github.com/Kong/kuma/pkg/core/ca/builtin/issuer"
 	core_system "github.com/Kong/kuma/pkg/core/resources/apis/system"
 	core_model "github.com/Kong/kuma/pkg/core/resources/model"
@@ -23,8 +28,38 @@ type CaRoot struct {
 	Key  []byte `json:"key"`
 }
 
+// RevokedCert is a Workload Identity certificate that must not be trusted anymore.
+type RevokedCert struct {
+	// SerialNumber of a certificate in hex, e.g. 3a8e0f1c.
+	SerialNumber string    `json:"serialNumber"`
+	RevokedAt    time.Time `json:"revokedAt"`
+	// ExpiresAt is a moment by which a certificate expires on its own and no longer has to be remembered.
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
 type BuiltinCa struct {
-	Roots []CaRoot `json:"roots"`
+	Roots        []CaRoot      `json:"roots"`
+	RevokedCerts []RevokedCert `json:"revokedCerts,omitempty"`
+}
+
+// prune removes revoked certificates that have expired on their own.
+func (c *BuiltinCa) prune(now time.Time) {
+	var revokedCerts []RevokedCert
+	for _, cert := range c.RevokedCerts {
+		if now.Before(cert.ExpiresAt) {
+			revokedCerts = append(revokedCerts, cert)
+		}
+	}
+	c.RevokedCerts = revokedCerts
+}
+
+func (c *BuiltinCa) isRevoked(serialNumber string) bool {
+	for _, cert := range c.RevokedCerts {
+		if cert.SerialNumber == serialNumber {
+			return true
+		}
+	}
+	return false
 }
 
 type BuiltinCaManager interface {
@@ -32,16 +67,39 @@ type BuiltinCaManager interface {
 	Delete(ctx context.Context, mesh string) error
 	GetRootCerts(ctx context.Context, mesh string) ([]CaRootCert, error)
 	GenerateWorkloadCert(ctx context.Context, mesh string, workload string) (*tls.KeyPair, error)
+	// RevokeCert revokes a Workload Identity certificate of a given serial number.
+	RevokeCert(ctx context.Context, mesh string, serialNumber string) error
+	GetRevokedCerts(ctx context.Context, mesh string) ([]RevokedCert, error)
+	// GetCRL returns a PEM-encoded Certificate Revocation List of a CA or nil if no certificates have been revoked.
+	GetCRL(ctx context.Context, mesh string) ([]byte, error)
+}
+
+func ErrorInvalidSerialNumber(serialNumber string) error {
+	return errors.Errorf("Invalid serial number: %q is not a hex number", serialNumber)
+}
+
+func IsInvalidSerialNumber(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), "Invalid serial number")
 }
 
 func NewBuiltinCaManager(secretManager secret_manager.SecretManager) BuiltinCaManager {
+	return NewBuiltinCaManagerWithCertValidity(secretManager, builtin_issuer.DefaultWorkloadCertValidityPeriod, builtin_issuer.DefaultAllowedClockSkew)
+}
+
+// NewBuiltinCaManagerWithCertValidity returns a manager that issues Workload Identity certificates
+// that expire after a given period of time and are backdated by allowedClockSkew.
+func NewBuiltinCaManagerWithCertValidity(secretManager secret_manager.SecretManager, workloadCertValidity time.Duration, allowedClockSkew time.Duration) BuiltinCaManager {
 	return &builtinCaManager{
-		secretManager: secretManager,
+		secretManager:        secretManager,
+		workloadCertValidity: workloadCertValidity,
+		allowedClockSkew:     allowedClockSkew,
 	}
 }
 
 type builtinCaManager struct {
-	secretManager secret_manager.SecretManager
+	secretManager        secret_manager.SecretManager
+	workloadCertValidity time.Duration
+	allowedClockSkew     time.Duration
 }
 
 func (m *builtinCaManager) Create(ctx context.Context, mesh string) error {
@@ -107,24 +165,102 @@ func (m *builtinCaManager) GenerateWorkloadCert(ctx context.Context, mesh string
 	}
 	active := meshCa.Roots[0]
 	signer := tls.KeyPair{CertPEM: active.Cert, KeyPEM: active.Key}
-	keyPair, err := builtin_issuer.NewWorkloadCert(signer, mesh, workload)
+	keyPair, err := builtin_issuer.NewWorkloadCertWithClockSkew(m.workloadCertValidity, m.allowedClockSkew, signer, mesh, workload)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to generate a Workload Identity cert for workload %q in Mesh %q", workload, mesh)
 	}
 	return keyPair, nil
 }
 
+func (m *builtinCaManager) RevokeCert(ctx context.Context, mesh string, serialNumber string) error {
+	serial, ok := parseSerialNumber(serialNumber)
+	if !ok {
+		return ErrorInvalidSerialNumber(serialNumber)
+	}
+	builtinCaSecret, meshCa, err := m.getMeshCaSecret(ctx, mesh)
+	if err != nil {
+		return err
+	}
+	now := core.Now()
+	if hex := serial.Text(16); !meshCa.isRevoked(hex) {
+		meshCa.RevokedCerts = append(meshCa.RevokedCerts, RevokedCert{
+			SerialNumber: hex,
+			RevokedAt:    now,
+			// a certificate is issued before it gets revoked, so it expires before that moment at the latest
+			ExpiresAt: now.Add(m.workloadCertValidity),
+		})
+	}
+	meshCa.prune(now)
+	data, err := json.Marshal(meshCa)
+	if err != nil {
+		return errors.Wrapf(err, "failed to serialize a Root CA cert for Mesh %q", mesh)
+	}
+	builtinCaSecret.Spec.Value = data
+	if err := m.secretManager.Update(ctx, builtinCaSecret); err != nil {
+		return errors.Wrapf(err, "failed to update Builtin CA for Mesh %q", mesh)
+	}
+	return nil
+}
+
+func (m *builtinCaManager) GetRevokedCerts(ctx context.Context, mesh string) ([]RevokedCert, error) {
+	meshCa, err := m.getMeshCa(ctx, mesh)
+	if err != nil {
+		return nil, err
+	}
+	meshCa.prune(core.Now())
+	return meshCa.RevokedCerts, nil
+}
+
+func (m *builtinCaManager) GetCRL(ctx context.Context, mesh string) ([]byte, error) {
+	meshCa, err := m.getMeshCa(ctx, mesh)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load CA key pair for Mesh %q", mesh)
+	}
+	meshCa.prune(core.Now())
+	if len(meshCa.RevokedCerts) == 0 || len(meshCa.Roots) < 1 {
+		return nil, nil
+	}
+	var revoked []pkix.RevokedCertificate
+	for _, cert := range meshCa.RevokedCerts {
+		serial, _ := parseSerialNumber(cert.SerialNumber)
+		revoked = append(revoked, pkix.RevokedCertificate{
+			SerialNumber:   serial,
+			RevocationTime: cert.RevokedAt,
+		})
+	}
+	active := meshCa.Roots[0]
+	crl, err := builtin_issuer.NewCRL(builtin_issuer.DefaultCRLValidityPeriod, tls.KeyPair{CertPEM: active.Cert, KeyPEM: active.Key}, revoked)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to generate a CRL for Mesh %q", mesh)
+	}
+	return crl, nil
+}
+
 func (m *builtinCaManager) getMeshCa(ctx context.Context, mesh string) (*BuiltinCa, error) {
+	_, builtinCa, err := m.getMeshCaSecret(ctx, mesh)
+	return builtinCa, err
+}
+
+func (m *builtinCaManager) getMeshCaSecret(ctx context.Context, mesh string) (*core_system.SecretResource, *BuiltinCa, error) {
 	secretKey := builtinCaSecretKey(mesh)
 	builtinCaSecret := &core_system.SecretResource{}
 	if err := m.secretManager.Get(ctx, builtinCaSecret, core_store.GetBy(secretKey)); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	builtinCa := BuiltinCa{}
 	if err := json.Unmarshal(builtinCaSecret.Spec.Value, &builtinCa); err != nil {
-		return nil, errors.Wrapf(err, "failed to deserialize a Root CA cert for Mesh %q", mesh)
+		return nil, nil, errors.Wrapf(err, "failed to deserialize a Root CA cert for Mesh %q", mesh)
+	}
+	return builtinCaSecret, &builtinCa, nil
+}
+
+// parseSerialNumber parses a serial number in hex, optionally with bytes separated by colons, e.g. 3a:8e:0f:1c.
+func parseSerialNumber(serialNumber string) (*big.Int, bool) {
+	hex := strings.Replace(strings.TrimPrefix(strings.ToLower(serialNumber), "0x"), ":", "", -1)
+	if hex == "" {
+		return nil, false
 	}
-	return &builtinCa, nil
+	return new(big.Int).SetString(hex, 16)
 }
 
 func builtinCaSecretKey(mesh string) core_model.ResourceKey {
@@ -0,0 +1,115 @@
+package builtin_test
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	builtin_ca "github.com/Kong/kuma/pkg/core/ca/builtin"
+	secret_cipher "github.com/Kong/kuma/pkg/core/secrets/cipher"
+	secret_manager "github.com/Kong/kuma/pkg/core/secrets/manager"
+	secret_store "github.com/Kong/kuma/pkg/core/secrets/store"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+)
+
+var _ = Describe("BuiltinCaManager", func() {
+
+	var secretManager secret_manager.SecretManager
+	var caManager builtin_ca.BuiltinCaManager
+
+	BeforeEach(func() {
+		secretManager = secret_manager.NewSecretManager(secret_store.NewSecretStore(memory.NewStore()), secret_cipher.None())
+		caManager = builtin_ca.NewBuiltinCaManager(secretManager)
+		Expect(caManager.Create(context.Background(), "demo")).To(Succeed())
+	})
+
+	It("should backdate Workload Identity certificates by the allowed clock skew", func() {
+		// given
+		caManager := builtin_ca.NewBuiltinCaManagerWithCertValidity(secretManager, time.Hour, 5*time.Minute)
+
+		// when
+		workloadCert, err := caManager.GenerateWorkloadCert(context.Background(), "demo", "backend")
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		block, _ := pem.Decode(workloadCert.CertPEM)
+		cert, err := x509.ParseCertificate(block.Bytes)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cert.NotAfter.Sub(cert.NotBefore)).To(Equal(time.Hour + 5*time.Minute))
+		Expect(cert.NotBefore).To(BeTemporally("<", time.Now().Add(-4*time.Minute)))
+	})
+
+	It("should not generate a CRL when no certificate is revoked", func() {
+		// when
+		crl, err := caManager.GetCRL(context.Background(), "demo")
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(crl).To(BeNil())
+	})
+
+	It("should generate a CRL signed by the CA with revoked certificates", func() {
+		// given
+		workloadCert, err := caManager.GenerateWorkloadCert(context.Background(), "demo", "backend")
+		Expect(err).ToNot(HaveOccurred())
+		block, _ := pem.Decode(workloadCert.CertPEM)
+		cert, err := x509.ParseCertificate(block.Bytes)
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		Expect(caManager.RevokeCert(context.Background(), "demo", cert.SerialNumber.Text(16))).To(Succeed())
+		// and revoking the same certificate again is a no-op
+		Expect(caManager.RevokeCert(context.Background(), "demo", "0x"+cert.SerialNumber.Text(16))).To(Succeed())
+
+		// then
+		revokedCerts, err := caManager.GetRevokedCerts(context.Background(), "demo")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(revokedCerts).To(HaveLen(1))
+		Expect(revokedCerts[0].SerialNumber).To(Equal(cert.SerialNumber.Text(16)))
+
+		// when
+		crlPEM, err := caManager.GetCRL(context.Background(), "demo")
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		block, _ = pem.Decode(crlPEM)
+		Expect(block.Type).To(Equal("X509 CRL"))
+		crl, err := x509.ParseCRL(block.Bytes)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(crl.TBSCertList.RevokedCertificates).To(HaveLen(1))
+		Expect(crl.TBSCertList.RevokedCertificates[0].SerialNumber).To(Equal(cert.SerialNumber))
+
+		// and
+		rootCerts, err := caManager.GetRootCerts(context.Background(), "demo")
+		Expect(err).ToNot(HaveOccurred())
+		block, _ = pem.Decode(rootCerts[0])
+		caCert, err := x509.ParseCertificate(block.Bytes)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(caCert.CheckCRLSignature(crl)).To(Succeed())
+	})
+
+	It("should accept serial numbers with bytes separated by colons", func() {
+		// when
+		err := caManager.RevokeCert(context.Background(), "demo", "3A:8E:0F:1C")
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		revokedCerts, err := caManager.GetRevokedCerts(context.Background(), "demo")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(revokedCerts).To(HaveLen(1))
+		Expect(revokedCerts[0].SerialNumber).To(Equal(big.NewInt(0x3a8e0f1c).Text(16)))
+	})
+
+	It("should refuse invalid serial numbers", func() {
+		// when
+		err := caManager.RevokeCert(context.Background(), "demo", "not-a-number")
+
+		// then
+		Expect(builtin_ca.IsInvalidSerialNumber(err)).To(BeTrue())
+	})
+})
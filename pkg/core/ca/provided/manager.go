@@ -0,0 +1,153 @@
+package provided
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"time"
+
+	"github.com/pkg/errors"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	builtin_issuer "github.com/Kong/kuma/pkg/core/ca/builtin/issuer"
+	util_tls "github.com/Kong/kuma/pkg/tls"
+)
+
+type CaRootCert = []byte
+
+// SecretLoader loads certificate and private key of a CA from a Secret of a given name.
+type SecretLoader interface {
+	LoadKeyPair(ctx context.Context, secret string) (*util_tls.KeyPair, error)
+}
+
+// CaFiles are paths to files with PEM-encoded certificate and private key of a CA.
+type CaFiles struct {
+	CertFile string
+	KeyFile  string
+}
+
+// ProvidedCaManager manages a CA that is provided by the user, i.e. a CA that Kuma doesn't generate
+// but loads either from files configured in Control Plane or from a Secret.
+type ProvidedCaManager interface {
+	ValidateCA(ctx context.Context, ca *mesh_proto.CertificateAuthority_Provided) error
+	GetRootCerts(ctx context.Context, ca *mesh_proto.CertificateAuthority_Provided) ([]CaRootCert, error)
+	GenerateWorkloadCert(ctx context.Context, mesh string, ca *mesh_proto.CertificateAuthority_Provided, workload string) (*util_tls.KeyPair, error)
+}
+
+// NewProvidedCaManager returns a manager that issues Workload Identity certificates that expire after a given period of time
+// and are backdated by allowedClockSkew.
+//
+// caFiles are CAs that Meshes reference by name. Paths to the files are part of the configuration of Control Plane
+// rather than of a Mesh, so that users who can modify a Mesh cannot make Control Plane read arbitrary files.
+//
+// secretLoader is optional. If it is nil, CAs can only be loaded from files.
+func NewProvidedCaManager(secretLoader SecretLoader, caFiles map[string]CaFiles, workloadCertValidity time.Duration, allowedClockSkew time.Duration) ProvidedCaManager {
+	return &providedCaManager{
+		secretLoader:         secretLoader,
+		caFiles:              caFiles,
+		workloadCertValidity: workloadCertValidity,
+		allowedClockSkew:     allowedClockSkew,
+	}
+}
+
+type providedCaManager struct {
+	secretLoader         SecretLoader
+	caFiles              map[string]CaFiles
+	workloadCertValidity time.Duration
+	allowedClockSkew     time.Duration
+}
+
+func (m *providedCaManager) ValidateCA(ctx context.Context, ca *mesh_proto.CertificateAuthority_Provided) error {
+	_, err := m.load(ctx, ca)
+	return err
+}
+
+func (m *providedCaManager) GetRootCerts(ctx context.Context, ca *mesh_proto.CertificateAuthority_Provided) ([]CaRootCert, error) {
+	keyPair, err := m.load(ctx, ca)
+	if err != nil {
+		return nil, err
+	}
+	return []CaRootCert{keyPair.CertPEM}, nil
+}
+
+func (m *providedCaManager) GenerateWorkloadCert(ctx context.Context, mesh string, ca *mesh_proto.CertificateAuthority_Provided, workload string) (*util_tls.KeyPair, error) {
+	keyPair, err := m.load(ctx, ca)
+	if err != nil {
+		return nil, err
+	}
+	workloadCert, err := builtin_issuer.NewWorkloadCertWithClockSkew(m.workloadCertValidity, m.allowedClockSkew, *keyPair, mesh, workload)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to generate a Workload Identity cert for workload %q in Mesh %q", workload, mesh)
+	}
+	return workloadCert, nil
+}
+
+// load loads a CA key pair and makes sure that it can be used to sign Workload Identity certificates.
+func (m *providedCaManager) load(ctx context.Context, ca *mesh_proto.CertificateAuthority_Provided) (*util_tls.KeyPair, error) {
+	keyPair, err := m.loadKeyPair(ctx, ca)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateKeyPair(keyPair); err != nil {
+		return nil, errors.Wrap(err, "provided CA is not valid")
+	}
+	return keyPair, nil
+}
+
+func (m *providedCaManager) loadKeyPair(ctx context.Context, ca *mesh_proto.CertificateAuthority_Provided) (*util_tls.KeyPair, error) {
+	switch {
+	case ca.GetSecret() != "":
+		if ca.GetName() != "" {
+			return nil, errors.New("provided CA must be referenced either by name or by a Secret, not both")
+		}
+		if m.secretLoader == nil {
+			return nil, errors.New("provided CA can only be loaded from a Secret in Kubernetes mode")
+		}
+		keyPair, err := m.secretLoader.LoadKeyPair(ctx, ca.GetSecret())
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to load provided CA from Secret %q", ca.GetSecret())
+		}
+		return keyPair, nil
+	case ca.GetName() != "":
+		files, ok := m.caFiles[ca.GetName()]
+		if !ok {
+			return nil, errors.Errorf("provided CA %q is not configured in Control Plane", ca.GetName())
+		}
+		certPEM, err := ioutil.ReadFile(files.CertFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read certificate of provided CA %q", ca.GetName())
+		}
+		keyPEM, err := ioutil.ReadFile(files.KeyFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read private key of provided CA %q", ca.GetName())
+		}
+		return &util_tls.KeyPair{
+			CertPEM: certPEM,
+			KeyPEM:  keyPEM,
+		}, nil
+	default:
+		return nil, errors.New("provided CA must have either a name or a secret")
+	}
+}
+
+func validateKeyPair(keyPair *util_tls.KeyPair) error {
+	pair, err := tls.X509KeyPair(keyPair.CertPEM, keyPair.KeyPEM)
+	if err != nil {
+		return errors.Wrap(err, "certificate and private key do not form a valid key pair")
+	}
+	cert, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return errors.Wrap(err, "failed to parse X509 certificate")
+	}
+	if !cert.BasicConstraintsValid || !cert.IsCA {
+		return errors.New("certificate must have basic constraint CA:TRUE")
+	}
+	if cert.KeyUsage&x509.KeyUsageCertSign == 0 {
+		return errors.New("certificate must have key usage keyCertSign")
+	}
+	if now := time.Now(); now.After(cert.NotAfter) || now.Before(cert.NotBefore) {
+		return errors.Errorf("certificate is only valid from %s to %s", cert.NotBefore.Format(time.RFC3339), cert.NotAfter.Format(time.RFC3339))
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package provided_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	builtin_issuer "github.com/Kong/kuma/pkg/core/ca/builtin/issuer"
+	provided_ca "github.com/Kong/kuma/pkg/core/ca/provided"
+	util_tls "github.com/Kong/kuma/pkg/tls"
+)
+
+type staticSecretLoader map[string]*util_tls.KeyPair
+
+func (l staticSecretLoader) LoadKeyPair(_ context.Context, secret string) (*util_tls.KeyPair, error) {
+	keyPair, ok := l[secret]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return keyPair, nil
+}
+
+var _ = Describe("ProvidedCaManager", func() {
+
+	var dir string
+	var caFiles map[string]provided_ca.CaFiles
+	var rootCA *util_tls.KeyPair
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "provided-ca")
+		Expect(err).ToNot(HaveOccurred())
+		caFiles = map[string]provided_ca.CaFiles{
+			"mesh-ca": {CertFile: filepath.Join(dir, "ca.crt"), KeyFile: filepath.Join(dir, "ca.key")},
+			"missing": {CertFile: "/non-existing/ca.crt", KeyFile: "/non-existing/ca.key"},
+		}
+		rootCA, err = builtin_issuer.NewRootCA("demo")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	writeFiles := func(keyPair *util_tls.KeyPair) *mesh_proto.CertificateAuthority_Provided {
+		Expect(ioutil.WriteFile(caFiles["mesh-ca"].CertFile, keyPair.CertPEM, 0600)).To(Succeed())
+		Expect(ioutil.WriteFile(caFiles["mesh-ca"].KeyFile, keyPair.KeyPEM, 0600)).To(Succeed())
+		return &mesh_proto.CertificateAuthority_Provided{
+			Name: "mesh-ca",
+		}
+	}
+
+	It("should issue Workload Identity certificates signed by a CA loaded from files", func() {
+		// given
+		manager := provided_ca.NewProvidedCaManager(nil, caFiles, time.Hour, time.Minute)
+		ca := writeFiles(rootCA)
+
+		// when
+		rootCerts, err := manager.GetRootCerts(context.Background(), ca)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rootCerts).To(Equal([][]byte{rootCA.CertPEM}))
+
+		// when
+		workloadCert, err := manager.GenerateWorkloadCert(context.Background(), "demo", ca, "backend")
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		block, _ := pem.Decode(workloadCert.CertPEM)
+		cert, err := x509.ParseCertificate(block.Bytes)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cert.URIs).To(HaveLen(1))
+		Expect(cert.URIs[0].String()).To(Equal("spiffe://demo/backend"))
+		Expect(cert.NotAfter.Sub(cert.NotBefore)).To(Equal(time.Hour + time.Minute))
+
+		// and
+		roots := x509.NewCertPool()
+		Expect(roots.AppendCertsFromPEM(rootCA.CertPEM)).To(BeTrue())
+		_, err = cert.Verify(x509.VerifyOptions{Roots: roots, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny}})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should load a CA from a Secret", func() {
+		// given
+		manager := provided_ca.NewProvidedCaManager(staticSecretLoader{"mesh-ca": rootCA}, nil, time.Hour, time.Minute)
+
+		// when
+		err := manager.ValidateCA(context.Background(), &mesh_proto.CertificateAuthority_Provided{Secret: "mesh-ca"})
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		err = manager.ValidateCA(context.Background(), &mesh_proto.CertificateAuthority_Provided{Secret: "unknown"})
+
+		// then
+		Expect(err).To(MatchError(`failed to load provided CA from Secret "unknown": not found`))
+	})
+
+	Describe("ValidateCA", func() {
+
+		newCert := func(template *x509.Certificate) *util_tls.KeyPair {
+			key, err := rsa.GenerateKey(rand.Reader, 2048)
+			Expect(err).ToNot(HaveOccurred())
+			template.SerialNumber = big.NewInt(1)
+			template.Subject = pkix.Name{CommonName: "demo"}
+			if template.NotAfter.IsZero() {
+				template.NotBefore = time.Now().Add(-time.Minute)
+				template.NotAfter = time.Now().Add(time.Hour)
+			}
+			der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+			Expect(err).ToNot(HaveOccurred())
+			return &util_tls.KeyPair{
+				CertPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+				KeyPEM:  pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+			}
+		}
+
+		type testCase struct {
+			ca          func() *mesh_proto.CertificateAuthority_Provided
+			expectedErr string
+		}
+
+		DescribeTable("should refuse a CA that cannot be used to sign certificates",
+			func(given testCase) {
+				// given
+				manager := provided_ca.NewProvidedCaManager(nil, caFiles, time.Hour, time.Minute)
+
+				// when
+				err := manager.ValidateCA(context.Background(), given.ca())
+
+				// then
+				Expect(err).To(MatchError(given.expectedErr))
+			},
+			Entry("certificate without CA:TRUE", testCase{
+				ca: func() *mesh_proto.CertificateAuthority_Provided {
+					return writeFiles(newCert(&x509.Certificate{
+						KeyUsage:              x509.KeyUsageCertSign,
+						BasicConstraintsValid: true,
+					}))
+				},
+				expectedErr: "provided CA is not valid: certificate must have basic constraint CA:TRUE",
+			}),
+			Entry("certificate without keyCertSign key usage", testCase{
+				ca: func() *mesh_proto.CertificateAuthority_Provided {
+					return writeFiles(newCert(&x509.Certificate{
+						KeyUsage:              x509.KeyUsageDigitalSignature,
+						IsCA:                  true,
+						BasicConstraintsValid: true,
+					}))
+				},
+				expectedErr: "provided CA is not valid: certificate must have key usage keyCertSign",
+			}),
+			Entry("certificate that has expired", testCase{
+				ca: func() *mesh_proto.CertificateAuthority_Provided {
+					return writeFiles(newCert(&x509.Certificate{
+						KeyUsage:              x509.KeyUsageCertSign,
+						IsCA:                  true,
+						BasicConstraintsValid: true,
+						NotBefore:             time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+						NotAfter:              time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC),
+					}))
+				},
+				expectedErr: "provided CA is not valid: certificate is only valid from 2019-01-01T00:00:00Z to 2019-02-01T00:00:00Z",
+			}),
+			Entry("key that doesn't match the certificate", testCase{
+				ca: func() *mesh_proto.CertificateAuthority_Provided {
+					otherCA, err := builtin_issuer.NewRootCA("other")
+					Expect(err).ToNot(HaveOccurred())
+					return writeFiles(&util_tls.KeyPair{CertPEM: rootCA.CertPEM, KeyPEM: otherCA.KeyPEM})
+				},
+				expectedErr: "provided CA is not valid: certificate and private key do not form a valid key pair: tls: private key does not match public key",
+			}),
+			Entry("missing files", testCase{
+				ca: func() *mesh_proto.CertificateAuthority_Provided {
+					return &mesh_proto.CertificateAuthority_Provided{Name: "missing"}
+				},
+				expectedErr: `failed to read certificate of provided CA "missing": open /non-existing/ca.crt: no such file or directory`,
+			}),
+			Entry("CA that is not configured in Control Plane", testCase{
+				ca: func() *mesh_proto.CertificateAuthority_Provided {
+					return &mesh_proto.CertificateAuthority_Provided{Name: "unknown"}
+				},
+				expectedErr: `provided CA "unknown" is not configured in Control Plane`,
+			}),
+			Entry("neither a name nor a Secret", testCase{
+				ca: func() *mesh_proto.CertificateAuthority_Provided {
+					return &mesh_proto.CertificateAuthority_Provided{}
+				},
+				expectedErr: "provided CA must have either a name or a secret",
+			}),
+			Entry("a Secret in universal mode", testCase{
+				ca: func() *mesh_proto.CertificateAuthority_Provided {
+					return &mesh_proto.CertificateAuthority_Provided{Secret: "mesh-ca"}
+				},
+				expectedErr: "provided CA can only be loaded from a Secret in Kubernetes mode",
+			}),
+		)
+	})
+})
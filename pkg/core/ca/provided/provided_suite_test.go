@@ -0,0 +1,13 @@
+package provided_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestProvidedCaManager(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Provided CA Manager Suite")
+}
@@ -0,0 +1,126 @@
+package discovery
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/Kong/kuma/pkg/core"
+	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+)
+
+var bufferedSinkLog = core.Log.WithName("discovery").WithName("buffered-sink")
+
+var _ DiscoverySource = &BufferedDiscoverySink{}
+var _ DiscoveryConsumer = &BufferedDiscoverySink{}
+
+// BufferedDiscoverySink queues events and delivers them to consumers from a separate goroutine,
+// so that a slow consumer doesn't block the source of events, e.g. Kubernetes informers.
+//
+// Events are delivered in the order they have been received. Once the queue is full,
+// the source of events is blocked until there is room again.
+// Errors of consumers cannot be returned to the source of events, so they are logged
+// and reported to a DeliveryFailureHandler, which lets the source of events retry.
+//
+// Events are accepted only while the sink is running. Otherwise, an error is returned,
+// so that the source of events can retry later instead of being blocked forever.
+type BufferedDiscoverySink struct {
+	DiscoverySink
+	events    chan dataplaneEvent
+	onFailure DeliveryFailureHandler
+
+	mu   sync.RWMutex // protects access to the fields below
+	stop <-chan struct{}
+}
+
+// ErrBufferedSinkNotRunning is returned when an event is received while the sink is not running.
+var ErrBufferedSinkNotRunning = errors.New("buffered discovery sink is not running")
+
+// DeliveryFailureHandler is called with a key of a Dataplane whose event consumers have failed to handle.
+type DeliveryFailureHandler func(key core_model.ResourceKey, err error)
+
+type dataplaneEvent struct {
+	update *mesh_core.DataplaneResource
+	delete core_model.ResourceKey
+}
+
+// NewBufferedDiscoverySink returns a sink that queues up to queueSize events.
+// Events are accepted only after the sink has been started. onFailure is optional.
+func NewBufferedDiscoverySink(queueSize int, onFailure DeliveryFailureHandler) *BufferedDiscoverySink {
+	return &BufferedDiscoverySink{
+		events:    make(chan dataplaneEvent, queueSize),
+		onFailure: onFailure,
+	}
+}
+
+func (s *BufferedDiscoverySink) OnDataplaneUpdate(dataplane *mesh_core.DataplaneResource) error {
+	return s.enqueue(dataplaneEvent{update: dataplane})
+}
+
+func (s *BufferedDiscoverySink) OnDataplaneDelete(key core_model.ResourceKey) error {
+	return s.enqueue(dataplaneEvent{delete: key})
+}
+
+func (s *BufferedDiscoverySink) enqueue(event dataplaneEvent) error {
+	s.mu.RLock()
+	stop := s.stop
+	s.mu.RUnlock()
+	if stop == nil {
+		return ErrBufferedSinkNotRunning
+	}
+	select {
+	case <-stop:
+		return ErrBufferedSinkNotRunning
+	default:
+	}
+	select {
+	case s.events <- event:
+		return nil
+	case <-stop:
+		return ErrBufferedSinkNotRunning
+	}
+}
+
+// Start delivers queued events to consumers until stop is closed.
+func (s *BufferedDiscoverySink) Start(stop <-chan struct{}) error {
+	s.mu.Lock()
+	if s.stop != nil {
+		s.mu.Unlock()
+		return errors.New("buffered discovery sink is already running")
+	}
+	s.stop = stop
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.stop = nil
+		s.mu.Unlock()
+	}()
+
+	for {
+		select {
+		case <-stop:
+			return nil
+		case event := <-s.events:
+			s.deliver(event)
+		}
+	}
+}
+
+func (s *BufferedDiscoverySink) deliver(event dataplaneEvent) {
+	key := event.delete
+	var err error
+	if event.update != nil {
+		key = core_model.MetaToResourceKey(event.update.GetMeta())
+		err = s.DiscoverySink.OnDataplaneUpdate(event.update)
+	} else {
+		err = s.DiscoverySink.OnDataplaneDelete(event.delete)
+	}
+	if err == nil {
+		return
+	}
+	bufferedSinkLog.Error(err, "failed to deliver a change of a Dataplane", "dataplane", key)
+	if s.onFailure != nil {
+		s.onFailure(key, err)
+	}
+}
@@ -0,0 +1,138 @@
+package discovery_test
+
+import (
+	"math/rand"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	core_discovery "github.com/Kong/kuma/pkg/core/discovery"
+	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	test_discovery "github.com/Kong/kuma/pkg/test/discovery"
+)
+
+var _ = Describe("BufferedDiscoverySink", func() {
+
+	var stop chan struct{}
+
+	BeforeEach(func() {
+		stop = make(chan struct{})
+	})
+
+	AfterEach(func() {
+		close(stop)
+	})
+
+	It("should not block the source of events on a slow consumer", func() {
+		// given
+		// the first event is retried until the sink is running
+		probe := test_discovery.Event{Delete: core_model.ResourceKey{Mesh: "demo", Name: "probe"}}
+		events := append([]test_discovery.Event{probe}, test_discovery.RandomEvents(rand.New(rand.NewSource(GinkgoRandomSeed())), test_discovery.DefaultFuzzOpts)...)
+		unblock := make(chan struct{})
+		consumer := &test_discovery.RecordingConsumer{}
+		sink := core_discovery.NewBufferedDiscoverySink(len(events), nil)
+		sink.AddConsumer(&blockingConsumer{Delegate: consumer, Unblock: unblock})
+		go func() {
+			defer GinkgoRecover()
+			Expect(sink.Start(stop)).To(Succeed())
+		}()
+
+		Eventually(func() error {
+			return test_discovery.Deliver(sink, events[:1])
+		}).Should(Succeed())
+
+		// when
+		err := test_discovery.Deliver(sink, events[1:])
+
+		// then events are queued even though the consumer is blocked
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		close(unblock)
+
+		// then events are delivered in the order they have been received
+		Eventually(consumer.Events).Should(Equal(events))
+	})
+
+	It("should report events that consumers have failed to handle", func() {
+		// given
+		failures := make(chan core_model.ResourceKey, 1)
+		sink := core_discovery.NewBufferedDiscoverySink(1, func(key core_model.ResourceKey, err error) {
+			defer GinkgoRecover()
+			Expect(err).To(MatchError("could not delete"))
+			failures <- key
+		})
+		sink.AddConsumer(failingConsumer{})
+		go func() {
+			defer GinkgoRecover()
+			Expect(sink.Start(stop)).To(Succeed())
+		}()
+		key := core_model.ResourceKey{Mesh: "demo", Name: "example"}
+
+		// when
+		Eventually(func() error {
+			return sink.OnDataplaneDelete(key)
+		}).Should(Succeed())
+
+		// then
+		Eventually(failures).Should(Receive(Equal(key)))
+	})
+
+	It("should refuse events before it has been started", func() {
+		// given
+		sink := core_discovery.NewBufferedDiscoverySink(1, nil)
+
+		// when
+		err := sink.OnDataplaneDelete(core_model.ResourceKey{Mesh: "demo", Name: "example"})
+
+		// then
+		Expect(err).To(Equal(core_discovery.ErrBufferedSinkNotRunning))
+	})
+
+	It("should refuse events once it has been stopped, even if the queue is full", func() {
+		// given
+		sink := core_discovery.NewBufferedDiscoverySink(1, nil)
+		unblock := make(chan struct{})
+		sink.AddConsumer(&blockingConsumer{Delegate: &test_discovery.RecordingConsumer{}, Unblock: unblock})
+		sinkStop := make(chan struct{})
+		done := make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			defer close(done)
+			Expect(sink.Start(sinkStop)).To(Succeed())
+		}()
+		key := core_model.ResourceKey{Mesh: "demo", Name: "example"}
+		Eventually(func() error {
+			return sink.OnDataplaneDelete(key)
+		}).Should(Succeed())
+
+		// when
+		close(sinkStop)
+
+		// then
+		Expect(sink.OnDataplaneDelete(key)).To(Equal(core_discovery.ErrBufferedSinkNotRunning))
+
+		// when
+		close(unblock)
+
+		// then
+		Eventually(done).Should(BeClosed())
+	})
+})
+
+// blockingConsumer waits until Unblock is closed before it passes an event to the delegate.
+type blockingConsumer struct {
+	Delegate core_discovery.DataplaneDiscoveryConsumer
+	Unblock  chan struct{}
+}
+
+func (c *blockingConsumer) OnDataplaneUpdate(dataplane *mesh_core.DataplaneResource) error {
+	<-c.Unblock
+	return c.Delegate.OnDataplaneUpdate(dataplane)
+}
+
+func (c *blockingConsumer) OnDataplaneDelete(key core_model.ResourceKey) error {
+	<-c.Unblock
+	return c.Delegate.OnDataplaneDelete(key)
+}
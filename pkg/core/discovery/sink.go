@@ -1,6 +1,10 @@
 package discovery
 
 import (
+	"sync"
+
+	"go.uber.org/multierr"
+
 	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
 	core_model "github.com/Kong/kuma/pkg/core/resources/model"
 )
@@ -9,23 +13,38 @@ var _ DiscoverySource = &DiscoverySink{}
 var _ DiscoveryConsumer = &DiscoverySink{}
 
 // DiscoverySink is both a source and a consumer of discovery information.
+//
+// Every event is delivered to all consumers, even if some of them fail to handle it.
+// DiscoverySink is safe for concurrent use.
 type DiscoverySink struct {
-	DataplaneConsumer DataplaneDiscoveryConsumer
+	mu        sync.RWMutex
+	consumers []DataplaneDiscoveryConsumer
 }
 
 func (s *DiscoverySink) AddConsumer(consumer DiscoveryConsumer) {
-	s.DataplaneConsumer = consumer
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.consumers = append(s.consumers, consumer)
 }
 
-func (s *DiscoverySink) OnDataplaneUpdate(dataplane *mesh_core.DataplaneResource) error {
-	if s.DataplaneConsumer != nil {
-		return s.DataplaneConsumer.OnDataplaneUpdate(dataplane)
+func (s *DiscoverySink) OnDataplaneUpdate(dataplane *mesh_core.DataplaneResource) (errs error) {
+	for _, consumer := range s.dataplaneConsumers() {
+		errs = multierr.Append(errs, consumer.OnDataplaneUpdate(dataplane))
 	}
-	return nil
+	return
 }
-func (s *DiscoverySink) OnDataplaneDelete(key core_model.ResourceKey) error {
-	if s.DataplaneConsumer != nil {
-		return s.DataplaneConsumer.OnDataplaneDelete(key)
+
+func (s *DiscoverySink) OnDataplaneDelete(key core_model.ResourceKey) (errs error) {
+	for _, consumer := range s.dataplaneConsumers() {
+		errs = multierr.Append(errs, consumer.OnDataplaneDelete(key))
 	}
-	return nil
+	return
+}
+
+// dataplaneConsumers returns consumers registered so far.
+// Events are delivered without holding the lock, so that a consumer can add other consumers.
+func (s *DiscoverySink) dataplaneConsumers() []DataplaneDiscoveryConsumer {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.consumers
 }
@@ -0,0 +1,125 @@
+package discovery_test
+
+import (
+	"math/rand"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	core_discovery "github.com/Kong/kuma/pkg/core/discovery"
+	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	test_discovery "github.com/Kong/kuma/pkg/test/discovery"
+)
+
+var _ = Describe("DiscoverySink", func() {
+
+	It("should tolerate events without a consumer", func() {
+		// given
+		sink := &core_discovery.DiscoverySink{}
+		events := test_discovery.RandomEvents(rand.New(rand.NewSource(GinkgoRandomSeed())), test_discovery.DefaultFuzzOpts)
+
+		// expect
+		Expect(test_discovery.Deliver(sink, events)).To(Succeed())
+	})
+
+	It("should deliver every event of random sequences to all consumers exactly once and in order", func() {
+		for i := 0; i < 200; i++ {
+			// given
+			seed := GinkgoRandomSeed() + int64(i)
+			events := test_discovery.RandomEvents(rand.New(rand.NewSource(seed)), test_discovery.DefaultFuzzOpts)
+
+			// and
+			consumers := []*test_discovery.RecordingConsumer{{}, {}, {}}
+			sink := &core_discovery.DiscoverySink{}
+			for _, consumer := range consumers {
+				sink.AddConsumer(consumer)
+			}
+
+			// when
+			err := test_discovery.Deliver(sink, events)
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			for _, consumer := range consumers {
+				Expect(consumer.Events()).To(Equal(events), "seed %d", seed)
+			}
+		}
+	})
+
+	It("should be safe for concurrent use", func() {
+		// given
+		events := test_discovery.RandomEvents(rand.New(rand.NewSource(GinkgoRandomSeed())), test_discovery.DefaultFuzzOpts)
+		sink := &core_discovery.DiscoverySink{}
+		consumers := make([]*test_discovery.RecordingConsumer, 10)
+		var delivered []test_discovery.Event
+
+		// when consumers are added while events are delivered
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer GinkgoRecover()
+			defer wg.Done()
+			for i := 0; i < 10; i++ {
+				Expect(test_discovery.Deliver(sink, events)).To(Succeed())
+			}
+		}()
+		for i := range consumers {
+			consumers[i] = &test_discovery.RecordingConsumer{}
+			sink.AddConsumer(consumers[i])
+		}
+		wg.Wait()
+
+		// and once more after all consumers have been added
+		Expect(test_discovery.Deliver(sink, events)).To(Succeed())
+		for i := 0; i < 11; i++ {
+			delivered = append(delivered, events...)
+		}
+
+		// then every consumer receives all events delivered since it has been added, without gaps
+		for _, consumer := range consumers {
+			received := consumer.Events()
+			Expect(len(received)).To(BeNumerically(">=", len(events)))
+			Expect(received).To(Equal(delivered[len(delivered)-len(received):]))
+		}
+	})
+
+	It("should deliver events to other consumers when one of them fails", func() {
+		// given
+		dataplane := test_discovery.NewDataplane(core_model.ResourceKey{Mesh: "demo", Namespace: "default", Name: "backend-01"}, "1")
+
+		// and
+		consumer := &test_discovery.StateConsumer{}
+		sink := &core_discovery.DiscoverySink{}
+		sink.AddConsumer(failingConsumer{})
+		sink.AddConsumer(consumer)
+		sink.AddConsumer(failingConsumer{})
+
+		// when
+		err := sink.OnDataplaneUpdate(dataplane)
+
+		// then
+		Expect(err).To(MatchError("could not update; could not update"))
+		Expect(consumer.State()).To(HaveLen(1))
+
+		// when
+		err = sink.OnDataplaneDelete(core_model.MetaToResourceKey(dataplane.GetMeta()))
+
+		// then
+		Expect(err).To(MatchError("could not delete; could not delete"))
+		Expect(consumer.State()).To(BeEmpty())
+	})
+})
+
+type failingConsumer struct{}
+
+func (failingConsumer) OnDataplaneUpdate(*mesh_core.DataplaneResource) error {
+	return errors.New("could not update")
+}
+
+func (failingConsumer) OnDataplaneDelete(core_model.ResourceKey) error {
+	return errors.New("could not delete")
+}
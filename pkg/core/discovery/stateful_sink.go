@@ -0,0 +1,72 @@
+package discovery
+
+import (
+	"sync"
+
+	"github.com/Kong/kuma/pkg/core"
+	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+)
+
+var statefulSinkLog = core.Log.WithName("discovery").WithName("stateful-sink")
+
+var _ DiscoverySource = &StatefulDiscoverySink{}
+var _ DiscoveryConsumer = &StatefulDiscoverySink{}
+
+// StatefulDiscoverySink remembers the latest state of every Dataplane it has been told about
+// and replays that state to consumers that are added later, so that consumers can be
+// wired up in any order, e.g. after discovery has already started.
+//
+// A consumer added by AddConsumer receives the current state before any further event.
+// Events are delivered while holding a lock, therefore a consumer must not call AddConsumer
+// from within OnDataplaneUpdate or OnDataplaneDelete.
+//
+// The zero value is ready to use.
+type StatefulDiscoverySink struct {
+	DiscoverySink
+
+	mu         sync.Mutex
+	dataplanes map[core_model.ResourceKey]*mesh_core.DataplaneResource
+}
+
+// AddConsumer replays the current state to a consumer and then registers it for further events.
+// The consumer is registered even if it fails to handle some of the replayed events.
+func (s *StatefulDiscoverySink) AddConsumer(consumer DiscoveryConsumer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, dataplane := range s.dataplanes {
+		if err := consumer.OnDataplaneUpdate(dataplane); err != nil {
+			// DiscoverySource interface doesn't allow to return an error
+			statefulSinkLog.Error(err, "failed to replay an update of a Dataplane", "dataplane", key)
+		}
+	}
+	s.DiscoverySink.AddConsumer(consumer)
+}
+
+func (s *StatefulDiscoverySink) OnDataplaneUpdate(dataplane *mesh_core.DataplaneResource) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.dataplanes == nil {
+		s.dataplanes = map[core_model.ResourceKey]*mesh_core.DataplaneResource{}
+	}
+	s.dataplanes[core_model.MetaToResourceKey(dataplane.GetMeta())] = dataplane
+	return s.DiscoverySink.OnDataplaneUpdate(dataplane)
+}
+
+func (s *StatefulDiscoverySink) OnDataplaneDelete(key core_model.ResourceKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.dataplanes, key)
+	return s.DiscoverySink.OnDataplaneDelete(key)
+}
+
+// Dataplanes returns a snapshot of the current state.
+func (s *StatefulDiscoverySink) Dataplanes() []*mesh_core.DataplaneResource {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	dataplanes := make([]*mesh_core.DataplaneResource, 0, len(s.dataplanes))
+	for _, dataplane := range s.dataplanes {
+		dataplanes = append(dataplanes, dataplane)
+	}
+	return dataplanes
+}
@@ -0,0 +1,78 @@
+package discovery_test
+
+import (
+	"math/rand"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	core_discovery "github.com/Kong/kuma/pkg/core/discovery"
+	test_discovery "github.com/Kong/kuma/pkg/test/discovery"
+)
+
+var _ = Describe("StatefulDiscoverySink", func() {
+
+	It("should replay the current state to a consumer added after discovery has started", func() {
+		// given
+		rnd := rand.New(rand.NewSource(GinkgoRandomSeed()))
+		events := test_discovery.RandomEvents(rnd, test_discovery.DefaultFuzzOpts)
+		half := len(events) / 2
+		early := &test_discovery.StateConsumer{}
+		late := &test_discovery.RecordingConsumer{}
+		sink := &core_discovery.StatefulDiscoverySink{}
+		sink.AddConsumer(early)
+
+		// when
+		Expect(test_discovery.Deliver(sink, events[:half])).To(Succeed())
+		// and
+		sink.AddConsumer(late)
+
+		// then the replay tells about every known Dataplane once
+		replayed := late.Events()
+		Expect(test_discovery.VerifyNoRedundantEvents(replayed)).To(Succeed())
+		Expect(replayed).To(HaveLen(len(early.State())))
+		for _, event := range replayed {
+			Expect(event.Update).ToNot(BeNil())
+			Expect(early.State()).To(HaveKeyWithValue(event.Key(), event.Update.Meta.GetVersion()))
+		}
+
+		// when
+		Expect(test_discovery.Deliver(sink, events[half:])).To(Succeed())
+
+		// then further events are delivered as they are
+		Expect(late.Events()[len(replayed):]).To(Equal(events[half:]))
+		// and
+		state := &test_discovery.StateConsumer{}
+		Expect(test_discovery.Deliver(state, late.Events())).To(Succeed())
+		Expect(state.State()).To(Equal(early.State()))
+		Expect(sink.Dataplanes()).To(HaveLen(len(early.State())))
+	})
+
+	It("should not lose events delivered concurrently with adding a consumer", func() {
+		// given
+		rnd := rand.New(rand.NewSource(GinkgoRandomSeed()))
+		events := test_discovery.RandomEvents(rnd, test_discovery.DefaultFuzzOpts)
+		sink := &core_discovery.StatefulDiscoverySink{}
+		reference := &test_discovery.StateConsumer{}
+		sink.AddConsumer(reference)
+		consumers := make([]*test_discovery.StateConsumer, 10)
+		done := make(chan struct{})
+
+		// when
+		go func() {
+			defer GinkgoRecover()
+			defer close(done)
+			Expect(test_discovery.Deliver(sink, events)).To(Succeed())
+		}()
+		for i := range consumers {
+			consumers[i] = &test_discovery.StateConsumer{}
+			sink.AddConsumer(consumers[i])
+		}
+		<-done
+
+		// then late consumers end up with the same state as the one that has seen all events
+		for _, consumer := range consumers {
+			Expect(consumer.State()).To(Equal(reference.State()))
+		}
+	})
+})
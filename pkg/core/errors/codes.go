@@ -0,0 +1,89 @@
+// Package errors defines machine-readable codes of errors that Control Plane reports to its users,
+// so that tools can handle errors without parsing messages meant for humans.
+//
+// Codes are returned by the API Server in the X-Kuma-Error-Code header and recorded in DataplaneInsight
+// when an xDS stream of a Dataplane is rejected.
+package errors
+
+// Code identifies a kind of an error. Codes are part of the API, so they must never be renamed.
+type Code string
+
+const (
+	// Internal means that Control Plane has failed for reasons that a user cannot fix, e.g. the store is unavailable.
+	Internal Code = "INTERNAL"
+
+	InvalidRequest        Code = "INVALID_REQUEST"
+	InvalidResource       Code = "INVALID_RESOURCE"
+	ResourceNotFound      Code = "RESOURCE_NOT_FOUND"
+	ResourceAlreadyExists Code = "RESOURCE_ALREADY_EXISTS"
+	// ResourceConflict means that a resource has been modified concurrently and the request can be retried.
+	ResourceConflict Code = "RESOURCE_CONFLICT"
+	// PreconditionFailed means that a condition of the request, e.g. If-Match, is not met.
+	PreconditionFailed Code = "PRECONDITION_FAILED"
+	MeshNotFound       Code = "MESH_NOT_FOUND"
+	// MeshInUse means that a Mesh cannot be deleted because there are resources in it.
+	MeshInUse Code = "MESH_IN_USE"
+	// ValidationRejected means that a change of a resource is rejected by one of the validation webhooks of the API Server.
+	ValidationRejected Code = "VALIDATION_REJECTED"
+	QuotaExceeded      Code = "QUOTA_EXCEEDED"
+	ConfigFrozen       Code = "CONFIG_FROZEN"
+	// PolicyTypeNotAllowed means that constraints of a Mesh don't allow policies of a given type.
+	PolicyTypeNotAllowed Code = "POLICY_TYPE_NOT_ALLOWED"
+
+	// AdminDisabled means that admin operations of the API Server are disabled.
+	AdminDisabled    Code = "ADMIN_DISABLED"
+	Unauthenticated  Code = "UNAUTHENTICATED"
+	DataplaneOffline Code = "DATAPLANE_OFFLINE"
+
+	// InvalidProxyId means that an xDS request doesn't identify a Dataplane, i.e. its node id is not "<mesh>.<name>".
+	InvalidProxyId    Code = "INVALID_PROXY_ID"
+	DataplaneNotFound Code = "DATAPLANE_NOT_FOUND"
+	// AuthenticationFailed means that a Dataplane has presented credentials that don't match its identity.
+	AuthenticationFailed Code = "AUTHENTICATION_FAILED"
+	TokenMissing         Code = "TOKEN_MISSING"
+	TokenMalformed       Code = "TOKEN_MALFORMED"
+	// TokenInvalid means that a token is not signed with a key that is accepted by its Mesh.
+	TokenInvalid Code = "TOKEN_INVALID"
+	TokenExpired Code = "TOKEN_EXPIRED"
+	TokenRevoked Code = "TOKEN_REVOKED"
+)
+
+type codedError struct {
+	err  error
+	code Code
+}
+
+func (e *codedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *codedError) Cause() error {
+	return e.err
+}
+
+// WithCode annotates an error with a code. The message of the error stays the same.
+func WithCode(err error, code Code) error {
+	if err == nil {
+		return nil
+	}
+	return &codedError{err: err, code: code}
+}
+
+// CodeOf returns the code of an error, or an empty code if the error hasn't been annotated with one.
+//
+// If an error has been annotated more than once, e.g. as it has been wrapped, the code closest to the cause wins,
+// since it is the most specific one.
+func CodeOf(err error) Code {
+	var code Code
+	for err != nil {
+		if coded, ok := err.(*codedError); ok {
+			code = coded.code
+		}
+		cause, ok := err.(interface{ Cause() error })
+		if !ok {
+			break
+		}
+		err = cause.Cause()
+	}
+	return code
+}
@@ -0,0 +1,38 @@
+package errors_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	core_errors "github.com/Kong/kuma/pkg/core/errors"
+)
+
+var _ = Describe("CodeOf(..)", func() {
+
+	It("should return empty code of an error without one", func() {
+		Expect(core_errors.CodeOf(errors.New("boom"))).To(BeEmpty())
+		Expect(core_errors.CodeOf(nil)).To(BeEmpty())
+	})
+
+	It("should return code of a wrapped error", func() {
+		// given
+		err := errors.Wrap(core_errors.WithCode(errors.New("token has expired"), core_errors.TokenExpired), "authentication failed")
+
+		// expect
+		Expect(core_errors.CodeOf(err)).To(Equal(core_errors.TokenExpired))
+		Expect(err.Error()).To(Equal("authentication failed: token has expired"))
+	})
+
+	It("should return the code closest to the cause", func() {
+		// given
+		err := core_errors.WithCode(errors.Wrap(core_errors.WithCode(errors.New("token has expired"), core_errors.TokenExpired), "authentication failed"), core_errors.AuthenticationFailed)
+
+		// expect
+		Expect(core_errors.CodeOf(err)).To(Equal(core_errors.TokenExpired))
+	})
+
+	It("should not annotate nil", func() {
+		Expect(core_errors.WithCode(nil, core_errors.Internal)).To(BeNil())
+	})
+})
@@ -0,0 +1,84 @@
+package expiry
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/multierr"
+
+	"github.com/Kong/kuma/pkg/core"
+	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	core_registry "github.com/Kong/kuma/pkg/core/resources/registry"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	core_runtime "github.com/Kong/kuma/pkg/core/runtime"
+)
+
+var log = core.Log.WithName("policy-expiry")
+
+// NewExpiredPolicyCleaner returns a component that periodically removes policies
+// with expiration time in the past, e.g. to make sure that break-glass rules are not forgotten.
+//
+// Policies are removed through a given manager, so that the change is propagated to Dataplanes
+// the same way as if a user removed them.
+func NewExpiredPolicyCleaner(resManager core_manager.ResourceManager, interval time.Duration) *ExpiredPolicyCleaner {
+	return &ExpiredPolicyCleaner{
+		resManager: resManager,
+		interval:   interval,
+	}
+}
+
+var _ core_runtime.Component = &ExpiredPolicyCleaner{}
+
+type ExpiredPolicyCleaner struct {
+	resManager core_manager.ResourceManager
+	interval   time.Duration
+}
+
+func (c *ExpiredPolicyCleaner) Start(stop <-chan struct{}) error {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.Cleanup(context.Background()); err != nil {
+				log.Error(err, "unable to remove expired policies")
+			}
+		case <-stop:
+			return nil
+		}
+	}
+}
+
+// Cleanup removes policies of all Meshes that have already expired.
+func (c *ExpiredPolicyCleaner) Cleanup(ctx context.Context) error {
+	var errs error
+	for _, typ := range core_mesh.PolicyTypes() {
+		errs = multierr.Append(errs, c.cleanup(ctx, typ))
+	}
+	return errs
+}
+
+func (c *ExpiredPolicyCleaner) cleanup(ctx context.Context, typ core_model.ResourceType) error {
+	list, err := core_registry.Global().NewList(typ)
+	if err != nil {
+		return err
+	}
+	if err := c.resManager.List(ctx, list); err != nil {
+		return err
+	}
+	var errs error
+	now := core.Now()
+	for _, policy := range list.GetItems() {
+		if !IsExpired(policy, now) {
+			continue
+		}
+		meta := policy.GetMeta()
+		log.Info("removing expired policy", "type", policy.GetType(), "mesh", meta.GetMesh(), "name", meta.GetName())
+		if err := c.resManager.Delete(ctx, policy, core_store.DeleteByKey(meta.GetNamespace(), meta.GetName(), meta.GetMesh())); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+	return errs
+}
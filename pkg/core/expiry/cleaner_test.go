@@ -0,0 +1,96 @@
+package expiry_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	"github.com/Kong/kuma/pkg/core"
+	"github.com/Kong/kuma/pkg/core/expiry"
+	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+)
+
+var _ = Describe("ExpiredPolicyCleaner", func() {
+
+	var now time.Time
+	var resManager core_manager.ResourceManager
+	var cleaner *expiry.ExpiredPolicyCleaner
+
+	BeforeEach(func() {
+		now = time.Unix(1568000000, 0)
+		core.Now = func() time.Time {
+			return now
+		}
+		resManager = core_manager.NewResourceManager(memory.NewStore())
+		for _, mesh := range []string{"demo", "another"} {
+			err := resManager.Create(context.Background(), &core_mesh.MeshResource{}, core_store.CreateByKey("default", mesh, mesh))
+			Expect(err).ToNot(HaveOccurred())
+		}
+		cleaner = expiry.NewExpiredPolicyCleaner(resManager, time.Second)
+	})
+
+	AfterEach(func() {
+		core.Now = time.Now
+	})
+
+	timestamp := func(t time.Time) *types.Timestamp {
+		ts, err := types.TimestampProto(t)
+		Expect(err).ToNot(HaveOccurred())
+		return ts
+	}
+
+	It("should remove policies that have expired", func() {
+		// given
+		policies := map[string]*types.Timestamp{
+			"expired":   timestamp(now.Add(-time.Minute)),
+			"expiring":  timestamp(now),
+			"temporary": timestamp(now.Add(time.Minute)),
+			"permanent": nil,
+		}
+		for name, expiresAt := range policies {
+			for _, mesh := range []string{"demo", "another"} {
+				permission := &core_mesh.TrafficPermissionResource{
+					Spec: mesh_proto.TrafficPermission{ExpiresAt: expiresAt},
+				}
+				err := resManager.Create(context.Background(), permission, core_store.CreateByKey("default", name, mesh))
+				Expect(err).ToNot(HaveOccurred())
+			}
+			template := &core_mesh.ProxyTemplateResource{
+				Spec: mesh_proto.ProxyTemplate{ExpiresAt: expiresAt},
+			}
+			err := resManager.Create(context.Background(), template, core_store.CreateByKey("default", name, "demo"))
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		// when
+		err := cleaner.Cleanup(context.Background())
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+
+		// and
+		permissions := &core_mesh.TrafficPermissionResourceList{}
+		Expect(resManager.List(context.Background(), permissions)).To(Succeed())
+		var permissionNames []string
+		for _, permission := range permissions.Items {
+			permissionNames = append(permissionNames, permission.GetMeta().GetMesh()+"/"+permission.GetMeta().GetName())
+		}
+		Expect(permissionNames).To(ConsistOf("demo/temporary", "demo/permanent", "another/temporary", "another/permanent"))
+
+		// and
+		templates := &core_mesh.ProxyTemplateResourceList{}
+		Expect(resManager.List(context.Background(), templates)).To(Succeed())
+		var templateNames []string
+		for _, template := range templates.Items {
+			templateNames = append(templateNames, template.GetMeta().GetName())
+		}
+		Expect(templateNames).To(ConsistOf("temporary", "permanent"))
+	})
+})
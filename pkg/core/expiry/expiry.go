@@ -0,0 +1,30 @@
+package expiry
+
+import (
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+)
+
+// expirablePolicy is a spec of a policy that can be removed automatically once it expires.
+type expirablePolicy interface {
+	GetExpiresAt() *types.Timestamp
+}
+
+// IsExpired returns true if a policy has already expired at a given time.
+//
+// Expired policies must not be enforced even before they are removed by ExpiredPolicyCleaner.
+// Expiration time that cannot be represented as time.Time is treated as no expiration at all.
+func IsExpired(policy core_model.Resource, now time.Time) bool {
+	spec, ok := policy.GetSpec().(expirablePolicy)
+	if !ok || spec.GetExpiresAt() == nil {
+		return false
+	}
+	expiresAt, err := types.TimestampFromProto(spec.GetExpiresAt())
+	if err != nil {
+		return false
+	}
+	return !now.Before(expiresAt)
+}
@@ -0,0 +1,13 @@
+package expiry_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestExpiry(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Expiry Suite")
+}
@@ -0,0 +1,76 @@
+package dataplane
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+)
+
+// NewKubernetesDataplaneManager returns a manager of Dataplanes for a Kubernetes Control Plane
+// that lets Dataplanes running outside of Kubernetes, e.g. on VMs, join Meshes side by side with Pods.
+//
+// Such Dataplanes must follow the naming model of Kubernetes, i.e. the `service` tag of their inbound interfaces
+// has to be <name>.<namespace>.svc:<port>, so that Pods reach them through a Kubernetes Service
+// together with the Pods behind that Service.
+func NewKubernetesDataplaneManager(delegate core_manager.ResourceManager) core_manager.ResourceManager {
+	return &kubernetesDataplaneManager{
+		ResourceManager: delegate,
+	}
+}
+
+type kubernetesDataplaneManager struct {
+	core_manager.ResourceManager
+}
+
+func (m *kubernetesDataplaneManager) Create(ctx context.Context, resource core_model.Resource, fs ...core_store.CreateOptionsFunc) error {
+	if err := validateServiceTags(resource, core_store.NewCreateOptions(fs...).Labels); err != nil {
+		return err
+	}
+	return m.ResourceManager.Create(ctx, resource, fs...)
+}
+
+func (m *kubernetesDataplaneManager) Update(ctx context.Context, resource core_model.Resource, fs ...core_store.UpdateOptionsFunc) error {
+	labels := core_store.NewUpdateOptions(fs...).Labels
+	if labels == nil && resource.GetMeta() != nil {
+		labels = resource.GetMeta().GetLabels()
+	}
+	if err := validateServiceTags(resource, labels); err != nil {
+		return err
+	}
+	return m.ResourceManager.Update(ctx, resource, fs...)
+}
+
+// validateServiceTags exempts Dataplanes generated for Pods. API Server does not let clients set
+// KubernetesPodLabel, so only the Kubernetes store can mark a Dataplane that way.
+func validateServiceTags(resource core_model.Resource, labels map[string]string) error {
+	dataplane, ok := resource.(*core_mesh.DataplaneResource)
+	if !ok || labels[core_mesh.KubernetesPodLabel] == "true" {
+		return nil
+	}
+	for _, inbound := range dataplane.Spec.GetNetworking().GetInbound() {
+		service := inbound.GetTags()[mesh_proto.ServiceTag]
+		host, _, err := mesh_proto.ServiceTagValue(service).HostAndPort()
+		if err != nil {
+			return InvalidServiceTag(service)
+		}
+		if segments := strings.Split(host, "."); len(segments) != 3 || segments[2] != "svc" {
+			return InvalidServiceTag(service)
+		}
+	}
+	return nil
+}
+
+func InvalidServiceTag(service string) error {
+	return errors.Errorf("service tag %q does not follow the naming model of Kubernetes: it has to be <name>.<namespace>.svc:<port> of a Kubernetes Service", service)
+}
+
+func IsInvalidServiceTag(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), "service tag") && strings.Contains(err.Error(), "does not follow the naming model of Kubernetes")
+}
@@ -0,0 +1,82 @@
+package dataplane_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	"github.com/Kong/kuma/pkg/core/managers/apis/dataplane"
+	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+)
+
+var _ = Describe("Kubernetes Dataplane Manager", func() {
+
+	var resManager core_manager.ResourceManager
+
+	BeforeEach(func() {
+		store := memory.NewStore()
+		err := store.Create(context.Background(), &core_mesh.MeshResource{}, core_store.CreateByKey("default", "demo", "demo"))
+		Expect(err).ToNot(HaveOccurred())
+
+		resManager = dataplane.NewKubernetesDataplaneManager(core_manager.NewResourceManager(store))
+	})
+
+	dataplaneWithService := func(service string) *core_mesh.DataplaneResource {
+		return &core_mesh.DataplaneResource{
+			Spec: mesh_proto.Dataplane{
+				Networking: &mesh_proto.Dataplane_Networking{
+					Inbound: []*mesh_proto.Dataplane_Networking_Inbound{{
+						Interface: "192.168.0.1:8080:80",
+						Tags:      map[string]string{mesh_proto.ServiceTag: service},
+					}},
+				},
+			},
+		}
+	}
+
+	It("should accept Dataplanes that follow the naming model of Kubernetes", func() {
+		// given
+		vm := dataplaneWithService("backend.payments.svc:8080")
+
+		// when
+		err := resManager.Create(context.Background(), vm, core_store.CreateByKey("default", "vm-01", "demo"))
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		vm.Spec.Networking.Inbound[0].Tags[mesh_proto.ServiceTag] = "backend"
+		err = resManager.Update(context.Background(), vm)
+
+		// then
+		Expect(err).To(MatchError(`service tag "backend" does not follow the naming model of Kubernetes: it has to be <name>.<namespace>.svc:<port> of a Kubernetes Service`))
+		Expect(dataplane.IsInvalidServiceTag(err)).To(BeTrue())
+	})
+
+	It("should reject Dataplanes that don't follow the naming model of Kubernetes", func() {
+		// given
+		vm := dataplaneWithService("backend.payments:8080")
+
+		// when
+		err := resManager.Create(context.Background(), vm, core_store.CreateByKey("default", "vm-01", "demo"))
+
+		// then
+		Expect(dataplane.IsInvalidServiceTag(err)).To(BeTrue())
+	})
+
+	It("should not validate Dataplanes of Kubernetes Pods", func() {
+		// given
+		pod := dataplaneWithService("backend")
+
+		// when
+		err := resManager.Create(context.Background(), pod, core_store.CreateByKey("default", "backend-01", "demo"), core_store.CreateWithLabels(map[string]string{core_mesh.KubernetesPodLabel: "true"}))
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
@@ -0,0 +1,78 @@
+package dataplane
+
+import (
+	"context"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	"github.com/Kong/kuma/pkg/core"
+	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+)
+
+var registryLog = core.Log.WithName("dataplane-registry")
+
+// maxRegistrationAttempts defines how many times a registration is retried if the Dataplane is changed concurrently.
+const maxRegistrationAttempts = 5
+
+// Registry lets dataplanes join and leave a Mesh on their own,
+// which gives Universal mode dynamic membership without applying Dataplane resources in advance.
+//
+// Registered Dataplanes are regular resources, so they are picked up by discovery
+// and get xDS configuration like any other Dataplane.
+type Registry struct {
+	resManager core_manager.ResourceManager
+}
+
+func NewRegistry(resManager core_manager.ResourceManager) *Registry {
+	return &Registry{
+		resManager: resManager,
+	}
+}
+
+// NetworkingUpdate returns networking of a Dataplane given its current networking,
+// which is nil if the Dataplane doesn't exist yet.
+type NetworkingUpdate func(current *mesh_proto.Dataplane_Networking) *mesh_proto.Dataplane_Networking
+
+// Register creates a Dataplane or changes networking of an existing one. It returns true if the Dataplane has been created.
+//
+// If the Dataplane is created or changed concurrently, the update is applied again to its latest version.
+func (r *Registry) Register(ctx context.Context, key core_model.ResourceKey, update NetworkingUpdate) (bool, error) {
+	for attempt := 1; ; attempt++ {
+		created, err := r.register(ctx, key, update)
+		if (core_store.IsResourceConflict(err) || core_store.IsResourceAlreadyExists(err)) && attempt < maxRegistrationAttempts {
+			continue
+		}
+		return created, err
+	}
+}
+
+func (r *Registry) register(ctx context.Context, key core_model.ResourceKey, update NetworkingUpdate) (bool, error) {
+	dataplane := &core_mesh.DataplaneResource{}
+	if err := r.resManager.Get(ctx, dataplane, core_store.GetBy(key)); err != nil {
+		if !core_store.IsResourceNotFound(err) {
+			return false, err
+		}
+		dataplane.Spec.Networking = update(nil)
+		registryLog.Info("registering Dataplane", "mesh", key.Mesh, "name", key.Name)
+		return true, r.resManager.Create(ctx, dataplane, core_store.CreateBy(key))
+	}
+	networking := update(dataplane.Spec.Networking)
+	if dataplane.Spec.Networking.Equal(networking) {
+		return false, nil
+	}
+	dataplane.Spec.Networking = networking
+	registryLog.Info("updating registration of Dataplane", "mesh", key.Mesh, "name", key.Name)
+	return false, r.resManager.Update(ctx, dataplane)
+}
+
+// Deregister deletes a Dataplane. Deregistering a Dataplane that doesn't exist is not an error.
+func (r *Registry) Deregister(ctx context.Context, key core_model.ResourceKey) error {
+	registryLog.Info("deregistering Dataplane", "mesh", key.Mesh, "name", key.Name)
+	err := r.resManager.Delete(ctx, &core_mesh.DataplaneResource{}, core_store.DeleteBy(key))
+	if core_store.IsResourceNotFound(err) {
+		return nil
+	}
+	return err
+}
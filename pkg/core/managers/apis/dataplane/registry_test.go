@@ -0,0 +1,172 @@
+package dataplane_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	"github.com/Kong/kuma/pkg/core/managers/apis/dataplane"
+	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+)
+
+var _ = Describe("Registry", func() {
+
+	var resManager core_manager.ResourceManager
+
+	key := core_model.ResourceKey{Mesh: "demo", Namespace: "default", Name: "backend-01"}
+
+	BeforeEach(func() {
+		store := memory.NewStore()
+		err := store.Create(context.Background(), &core_mesh.MeshResource{}, core_store.CreateByKey("default", "demo", "demo"))
+		Expect(err).ToNot(HaveOccurred())
+		resManager = core_manager.NewResourceManager(store)
+	})
+
+	inbound := func(iface string) *mesh_proto.Dataplane_Networking_Inbound {
+		return &mesh_proto.Dataplane_Networking_Inbound{
+			Interface: iface,
+			Tags:      map[string]string{mesh_proto.ServiceTag: "backend"},
+		}
+	}
+
+	// addInbound is an update that keeps inbound interfaces registered so far
+	addInbound := func(iface string) dataplane.NetworkingUpdate {
+		return func(current *mesh_proto.Dataplane_Networking) *mesh_proto.Dataplane_Networking {
+			networking := &mesh_proto.Dataplane_Networking{}
+			if current != nil {
+				networking.Inbound = append(networking.Inbound, current.Inbound...)
+			}
+			networking.Inbound = append(networking.Inbound, inbound(iface))
+			return networking
+		}
+	}
+
+	get := func() (*core_mesh.DataplaneResource, error) {
+		dp := &core_mesh.DataplaneResource{}
+		return dp, resManager.Get(context.Background(), dp, core_store.GetBy(key))
+	}
+
+	It("should create, update and delete a Dataplane", func() {
+		// given
+		registry := dataplane.NewRegistry(resManager)
+
+		// when
+		created, err := registry.Register(context.Background(), key, addInbound("192.168.0.1:80:8080"))
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(created).To(BeTrue())
+
+		// when
+		created, err = registry.Register(context.Background(), key, addInbound("192.168.0.1:81:8081"))
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(created).To(BeFalse())
+		dp, err := get()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dp.Spec.Networking.Inbound).To(HaveLen(2))
+
+		// when
+		Expect(registry.Deregister(context.Background(), key)).To(Succeed())
+
+		// then
+		_, err = get()
+		Expect(core_store.IsResourceNotFound(err)).To(BeTrue())
+
+		// and a repeated deregistration is not an error
+		Expect(registry.Deregister(context.Background(), key)).To(Succeed())
+	})
+
+	It("should apply an update again to a Dataplane that has been modified concurrently", func() {
+		// given
+		_, err := dataplane.NewRegistry(resManager).Register(context.Background(), key, addInbound("192.168.0.1:80:8080"))
+		Expect(err).ToNot(HaveOccurred())
+		registry := dataplane.NewRegistry(&concurrentlyModifyingManager{
+			ResourceManager: resManager,
+			modify: func(ctx context.Context) error {
+				dp, err := get()
+				if err != nil {
+					return err
+				}
+				dp.Spec.Networking.Inbound = append(dp.Spec.Networking.Inbound, inbound("192.168.0.1:82:8082"))
+				return resManager.Update(ctx, dp)
+			},
+		})
+
+		// when
+		_, err = registry.Register(context.Background(), key, addInbound("192.168.0.1:81:8081"))
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		dp, err := get()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dp.Spec.Networking.Inbound).To(Equal([]*mesh_proto.Dataplane_Networking_Inbound{
+			inbound("192.168.0.1:80:8080"),
+			inbound("192.168.0.1:82:8082"),
+			inbound("192.168.0.1:81:8081"),
+		}))
+	})
+
+	It("should create a Dataplane that has been created concurrently", func() {
+		// given
+		registry := dataplane.NewRegistry(&concurrentlyModifyingManager{
+			ResourceManager: resManager,
+			modify: func(ctx context.Context) error {
+				dp := &core_mesh.DataplaneResource{
+					Spec: mesh_proto.Dataplane{
+						Networking: &mesh_proto.Dataplane_Networking{
+							Inbound: []*mesh_proto.Dataplane_Networking_Inbound{inbound("192.168.0.1:80:8080")},
+						},
+					},
+				}
+				return resManager.Create(ctx, dp, core_store.CreateBy(key))
+			},
+		})
+
+		// when
+		created, err := registry.Register(context.Background(), key, addInbound("192.168.0.1:81:8081"))
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(created).To(BeFalse())
+		dp, err := get()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dp.Spec.Networking.Inbound).To(HaveLen(2))
+	})
+})
+
+// concurrentlyModifyingManager modifies a Dataplane right before its first write, as if it were modified concurrently.
+type concurrentlyModifyingManager struct {
+	core_manager.ResourceManager
+	modify   func(ctx context.Context) error
+	modified bool
+}
+
+func (m *concurrentlyModifyingManager) beforeWrite(ctx context.Context) error {
+	if m.modified {
+		return nil
+	}
+	m.modified = true
+	return m.modify(ctx)
+}
+
+func (m *concurrentlyModifyingManager) Create(ctx context.Context, resource core_model.Resource, fs ...core_store.CreateOptionsFunc) error {
+	if err := m.beforeWrite(ctx); err != nil {
+		return err
+	}
+	return m.ResourceManager.Create(ctx, resource, fs...)
+}
+
+func (m *concurrentlyModifyingManager) Update(ctx context.Context, resource core_model.Resource, fs ...core_store.UpdateOptionsFunc) error {
+	if err := m.beforeWrite(ctx); err != nil {
+		return err
+	}
+	return m.ResourceManager.Update(ctx, resource, fs...)
+}
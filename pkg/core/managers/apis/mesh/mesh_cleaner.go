@@ -0,0 +1,139 @@
+package mesh
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	builtin_ca "github.com/Kong/kuma/pkg/core/ca/builtin"
+	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_system "github.com/Kong/kuma/pkg/core/resources/apis/system"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	core_registry "github.com/Kong/kuma/pkg/core/resources/registry"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	secret_manager "github.com/Kong/kuma/pkg/core/secrets/manager"
+)
+
+// MeshCleaner deletes resources that belong to a Mesh.
+//
+// It is used both on delete of a Mesh through the API Server and
+// by the finalizer of a Mesh on Kubernetes.
+type MeshCleaner struct {
+	store            core_store.ResourceStore
+	secretManager    secret_manager.SecretManager
+	builtinCaManager builtin_ca.BuiltinCaManager
+	// resourceTypes are types of resources that belong to a Mesh, in order of deletion
+	resourceTypes []core_model.ResourceType
+}
+
+func NewMeshCleaner(store core_store.ResourceStore, secretManager secret_manager.SecretManager, builtinCaManager builtin_ca.BuiltinCaManager) *MeshCleaner {
+	return &MeshCleaner{
+		store:            store,
+		secretManager:    secretManager,
+		builtinCaManager: builtinCaManager,
+		resourceTypes:    meshResourceTypes(core_registry.Global()),
+	}
+}
+
+// generatedTypes are types of resources that are generated by the Control Plane itself.
+// They don't prevent a Mesh from being deleted.
+var generatedTypes = map[core_model.ResourceType]bool{
+	core_mesh.DataplaneInsightType: true,
+}
+
+// meshResourceTypes returns types of resources that belong to a Mesh and are kept in the ResourceStore.
+//
+// Policies go first, so that the remaining Dataplanes don't get any config regenerated on their removal.
+// Resources generated by the Control Plane go last.
+func meshResourceTypes(registry core_registry.TypeRegistry) []core_model.ResourceType {
+	var policies, dataplanes, generated []core_model.ResourceType
+	for _, typ := range registry.ListTypes() {
+		switch {
+		case typ == core_mesh.MeshType:
+			// Mesh itself is deleted by the caller
+		case typ == core_mesh.DataplaneOverviewType:
+			// DataplaneOverviews are not stored, they are composed of Dataplanes and DataplaneInsights
+		case typ == core_system.SecretType:
+			// Secrets are managed by SecretManager
+		case typ == core_mesh.DataplaneType:
+			dataplanes = append(dataplanes, typ)
+		case generatedTypes[typ]:
+			generated = append(generated, typ)
+		default:
+			policies = append(policies, typ)
+		}
+	}
+	return append(append(policies, dataplanes...), generated...)
+}
+
+// Dependents returns how many resources of each type prevent a given Mesh from being deleted.
+//
+// Resources that are generated by the Control Plane itself, e.g. DataplaneInsights, don't prevent deletion.
+func (c *MeshCleaner) Dependents(ctx context.Context, meshName string) (map[core_model.ResourceType]int, error) {
+	dependents := map[core_model.ResourceType]int{}
+	for _, typ := range c.resourceTypes {
+		if generatedTypes[typ] {
+			continue
+		}
+		list, err := c.list(ctx, typ, meshName)
+		if err != nil {
+			return nil, err
+		}
+		if count := len(list.GetItems()); count > 0 {
+			dependents[typ] = count
+		}
+	}
+	return dependents, nil
+}
+
+// DeleteResources deletes all resources that belong to a given Mesh except for its Secrets.
+func (c *MeshCleaner) DeleteResources(ctx context.Context, meshName string) error {
+	for _, typ := range c.resourceTypes {
+		list, err := c.list(ctx, typ, meshName)
+		if err != nil {
+			return err
+		}
+		for _, item := range list.GetItems() {
+			meta := item.GetMeta()
+			if err := c.store.Delete(ctx, item, core_store.DeleteByKey(meta.GetNamespace(), meta.GetName(), meta.GetMesh())); err != nil && !core_store.IsResourceNotFound(err) {
+				return errors.Wrapf(err, "failed to delete %s %q in a given mesh", typ, meta.GetName())
+			}
+		}
+	}
+	return nil
+}
+
+// DeleteSecrets deletes all Secrets of a given Mesh, including its Built-in CA.
+//
+// It is expected to be called once the Mesh itself has been deleted.
+func (c *MeshCleaner) DeleteSecrets(ctx context.Context, meshName string) error {
+	secrets := &core_system.SecretResourceList{}
+	if err := c.secretManager.List(ctx, secrets, core_store.ListByMesh(meshName)); err != nil {
+		return errors.Wrapf(err, "failed to list Secrets in a given mesh")
+	}
+	for _, secret := range secrets.Items {
+		meta := secret.GetMeta()
+		if meta.GetMesh() != meshName {
+			continue
+		}
+		if err := c.secretManager.Delete(ctx, secret, core_store.DeleteByKey(meta.GetNamespace(), meta.GetName(), meta.GetMesh())); err != nil && !core_store.IsResourceNotFound(err) {
+			return errors.Wrapf(err, "failed to delete Secret %q in a given mesh", meta.GetName())
+		}
+	}
+	// Built-in CA might have been created before Secrets on Kubernetes were labeled with a Mesh
+	if err := c.builtinCaManager.Delete(ctx, meshName); err != nil {
+		return errors.Wrapf(err, "failed to delete Builtin CA for a given mesh")
+	}
+	return nil
+}
+
+func (c *MeshCleaner) list(ctx context.Context, typ core_model.ResourceType, meshName string) (core_model.ResourceList, error) {
+	list, err := core_registry.Global().NewList(typ)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.store.List(ctx, list, core_store.ListByMesh(meshName)); err != nil {
+		return nil, errors.Wrapf(err, "failed to list %s resources in a given mesh", typ)
+	}
+	return list, nil
+}
@@ -2,28 +2,50 @@ package mesh
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/pkg/errors"
 	"go.uber.org/multierr"
 
 	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
 	builtin_ca "github.com/Kong/kuma/pkg/core/ca/builtin"
+	provided_ca "github.com/Kong/kuma/pkg/core/ca/provided"
 	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
 	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
 	core_model "github.com/Kong/kuma/pkg/core/resources/model"
 	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	secret_manager "github.com/Kong/kuma/pkg/core/secrets/manager"
 )
 
-func NewMeshManager(store core_store.ResourceStore, builtinCaManager builtin_ca.BuiltinCaManager) core_manager.ResourceManager {
+func NewMeshManager(store core_store.ResourceStore, secretManager secret_manager.SecretManager, builtinCaManager builtin_ca.BuiltinCaManager, providedCaManager provided_ca.ProvidedCaManager) core_manager.ResourceManager {
 	return &meshManager{
-		store:            store,
-		builtinCaManager: builtinCaManager,
+		store:             store,
+		cleaner:           NewMeshCleaner(store, secretManager, builtinCaManager),
+		builtinCaManager:  builtinCaManager,
+		providedCaManager: providedCaManager,
 	}
 }
 
 type meshManager struct {
-	store            core_store.ResourceStore
-	builtinCaManager builtin_ca.BuiltinCaManager
+	store             core_store.ResourceStore
+	cleaner           *MeshCleaner
+	builtinCaManager  builtin_ca.BuiltinCaManager
+	providedCaManager provided_ca.ProvidedCaManager
+}
+
+func MeshInUse(meshName string, dependents map[core_model.ResourceType]int) error {
+	var usage []string
+	for typ, count := range dependents {
+		usage = append(usage, fmt.Sprintf("%s=%d", typ, count))
+	}
+	sort.Strings(usage)
+	return errors.Errorf("mesh of name %v is still in use: %s", meshName, strings.Join(usage, " "))
+}
+
+func IsMeshInUse(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), "mesh of name") && strings.Contains(err.Error(), "is still in use")
 }
 
 func (m *meshManager) Get(ctx context.Context, resource core_model.Resource, fs ...core_store.GetOptionsFunc) error {
@@ -66,8 +88,8 @@ func (m *meshManager) Create(ctx context.Context, resource core_model.Resource,
 			errs = multierr.Append(errs, rollback())
 		}
 	}()
-	// create Built-in CA
-	switch mesh.Spec.GetMtls().GetCa().GetType().(type) {
+	// create Built-in CA or validate Provided CA
+	switch ca := mesh.Spec.GetMtls().GetCa().GetType().(type) {
 	case *mesh_proto.CertificateAuthority_Builtin_:
 		opts := core_store.NewCreateOptions(fs...)
 		if err := m.builtinCaManager.Create(ctx, opts.Name); err != nil {
@@ -76,6 +98,10 @@ func (m *meshManager) Create(ctx context.Context, resource core_model.Resource,
 		rollback = func() error {
 			return m.builtinCaManager.Delete(ctx, opts.Name)
 		}
+	case *mesh_proto.CertificateAuthority_Provided_:
+		if err := m.providedCaManager.ValidateCA(ctx, ca.Provided); err != nil {
+			return errors.Wrapf(err, "failed to validate Provided CA for a given mesh")
+		}
 	}
 	// persist Mesh
 	if err := m.store.Create(ctx, mesh, fs...); err != nil {
@@ -89,17 +115,41 @@ func (m *meshManager) Delete(ctx context.Context, resource core_model.Resource,
 	if err != nil {
 		return err
 	}
-	// delete Mesh first to avoid a state where a Mesh could exist without a Built-in CA.
-	// even if removal of Built-in CA fails later on, delete opration can be safely tried again.
-	if err := m.store.Delete(ctx, mesh, fs...); err != nil {
+	opts := core_store.NewDeleteOptions(fs...)
+	name := opts.Mesh
+	if opts.Version != "" {
+		// check the version before resources of the Mesh are deleted, the store will check it again on delete of the Mesh
+		current := &core_mesh.MeshResource{}
+		if err := m.store.Get(ctx, current, core_store.GetByKey(opts.Namespace, opts.Name, opts.Mesh)); err != nil {
+			if core_store.IsResourceNotFound(err) {
+				// a Mesh of the expected version doesn't exist anymore
+				return core_store.ErrorResourceConflict(mesh.GetType(), opts.Namespace, opts.Name, opts.Mesh)
+			}
+			return err
+		}
+		if current.GetMeta().GetVersion() != opts.Version {
+			return core_store.ErrorResourceConflict(mesh.GetType(), opts.Namespace, opts.Name, opts.Mesh)
+		}
+	}
+	if !opts.Force {
+		dependents, err := m.cleaner.Dependents(ctx, name)
+		if err != nil {
+			return err
+		}
+		if len(dependents) > 0 {
+			return MeshInUse(name, dependents)
+		}
+	}
+	// delete resources that belong to the Mesh first to avoid a state where they could exist without a Mesh.
+	// even if any of the steps fails, delete operation can be safely tried again.
+	if err := m.cleaner.DeleteResources(ctx, name); err != nil {
 		return err
 	}
-	// delete CA
-	name := core_store.NewDeleteOptions(fs...).Mesh
-	if err := m.builtinCaManager.Delete(ctx, name); err != nil {
-		return errors.Wrapf(err, "failed to delete Builtin CA for a given mesh")
+	// delete Mesh before its Secrets to avoid a state where a Mesh could exist without a Built-in CA.
+	if err := m.store.Delete(ctx, mesh, fs...); err != nil {
+		return err
 	}
-	return nil
+	return m.cleaner.DeleteSecrets(ctx, name)
 }
 
 func (m *meshManager) Update(ctx context.Context, resource core_model.Resource, fs ...core_store.UpdateOptionsFunc) error {
@@ -107,6 +157,11 @@ func (m *meshManager) Update(ctx context.Context, resource core_model.Resource,
 	if err != nil {
 		return err
 	}
+	if provided := mesh.Spec.GetMtls().GetCa().GetProvided(); provided != nil {
+		if err := m.providedCaManager.ValidateCA(ctx, provided); err != nil {
+			return errors.Wrapf(err, "failed to validate Provided CA for a given mesh")
+		}
+	}
 	return m.store.Update(ctx, mesh, fs...)
 }
 
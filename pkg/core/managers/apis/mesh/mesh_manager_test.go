@@ -0,0 +1,174 @@
+package mesh_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	builtin_ca "github.com/Kong/kuma/pkg/core/ca/builtin"
+	builtin_issuer "github.com/Kong/kuma/pkg/core/ca/builtin/issuer"
+	provided_ca "github.com/Kong/kuma/pkg/core/ca/provided"
+	mesh_managers "github.com/Kong/kuma/pkg/core/managers/apis/mesh"
+	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_system "github.com/Kong/kuma/pkg/core/resources/apis/system"
+	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	secret_cipher "github.com/Kong/kuma/pkg/core/secrets/cipher"
+	secret_manager "github.com/Kong/kuma/pkg/core/secrets/manager"
+	secret_store "github.com/Kong/kuma/pkg/core/secrets/store"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+)
+
+var _ = Describe("Mesh Manager", func() {
+
+	var store core_store.ResourceStore
+	var secretManager secret_manager.SecretManager
+	var caManager builtin_ca.BuiltinCaManager
+	var resManager core_manager.ResourceManager
+
+	BeforeEach(func() {
+		store = memory.NewStore()
+		secretManager = secret_manager.NewSecretManager(secret_store.NewSecretStore(store), secret_cipher.None())
+		caManager = builtin_ca.NewBuiltinCaManager(secretManager)
+		resManager = mesh_managers.NewMeshManager(store, secretManager, caManager, provided_ca.NewProvidedCaManager(nil, nil, builtin_issuer.DefaultWorkloadCertValidityPeriod, builtin_issuer.DefaultAllowedClockSkew))
+
+		for _, mesh := range []string{"demo", "other"} {
+			err := resManager.Create(context.Background(), &core_mesh.MeshResource{}, core_store.CreateByKey("default", mesh, mesh))
+			Expect(err).ToNot(HaveOccurred())
+		}
+	})
+
+	dataplanes := func(mesh string) int {
+		list := core_mesh.DataplaneResourceList{}
+		Expect(store.List(context.Background(), &list, core_store.ListByMesh(mesh))).To(Succeed())
+		return len(list.Items)
+	}
+
+	It("should refuse to create a Mesh with a Provided CA that cannot be loaded", func() {
+		// given
+		mesh := &core_mesh.MeshResource{
+			Spec: mesh_proto.Mesh{
+				Mtls: &mesh_proto.Mesh_Mtls{
+					Ca: &mesh_proto.CertificateAuthority{
+						Type: &mesh_proto.CertificateAuthority_Provided_{
+							Provided: &mesh_proto.CertificateAuthority_Provided{},
+						},
+					},
+				},
+			},
+		}
+
+		// when
+		err := resManager.Create(context.Background(), mesh, core_store.CreateByKey("default", "provided", "provided"))
+
+		// then
+		Expect(err).To(MatchError("failed to validate Provided CA for a given mesh: provided CA must have either a name or a secret"))
+
+		// and
+		err = store.Get(context.Background(), &core_mesh.MeshResource{}, core_store.GetByKey("default", "provided", "provided"))
+		Expect(core_store.IsResourceNotFound(err)).To(BeTrue())
+	})
+
+	It("should delete a Mesh that is not used along with its Built-in CA", func() {
+		// when
+		err := resManager.Delete(context.Background(), &core_mesh.MeshResource{}, core_store.DeleteByKey("default", "demo", "demo"))
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+
+		// and
+		err = store.Get(context.Background(), &core_mesh.MeshResource{}, core_store.GetByKey("default", "demo", "demo"))
+		Expect(core_store.IsResourceNotFound(err)).To(BeTrue())
+		_, err = caManager.GetRootCerts(context.Background(), "demo")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should not delete a Mesh of a given version that has disappeared", func() {
+		// when
+		err := resManager.Delete(context.Background(), &core_mesh.MeshResource{}, core_store.DeleteByKey("default", "gone", "gone"), core_store.DeleteWithVersion("1"))
+
+		// then
+		Expect(core_store.IsResourceConflict(err)).To(BeTrue())
+	})
+
+	It("should refuse to delete a Mesh that still has Dataplanes and policies", func() {
+		// given
+		for _, name := range []string{"dp-1", "dp-2"} {
+			Expect(store.Create(context.Background(), &core_mesh.DataplaneResource{}, core_store.CreateByKey("default", name, "demo"))).To(Succeed())
+		}
+		Expect(store.Create(context.Background(), &core_mesh.TrafficPermissionResource{}, core_store.CreateByKey("default", "tp-1", "demo"))).To(Succeed())
+
+		// when
+		err := resManager.Delete(context.Background(), &core_mesh.MeshResource{}, core_store.DeleteByKey("default", "demo", "demo"))
+
+		// then
+		Expect(mesh_managers.IsMeshInUse(err)).To(BeTrue())
+		Expect(err.Error()).To(Equal("mesh of name demo is still in use: Dataplane=2 TrafficPermission=1"))
+
+		// and nothing is deleted
+		Expect(store.Get(context.Background(), &core_mesh.MeshResource{}, core_store.GetByKey("default", "demo", "demo"))).To(Succeed())
+		Expect(dataplanes("demo")).To(Equal(2))
+		_, err = caManager.GetRootCerts(context.Background(), "demo")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should delete a Mesh along with all its resources when forced", func() {
+		// given
+		Expect(store.Create(context.Background(), &core_mesh.DataplaneResource{}, core_store.CreateByKey("default", "dp-1", "demo"))).To(Succeed())
+		Expect(store.Create(context.Background(), &core_mesh.DataplaneInsightResource{}, core_store.CreateByKey("default", "dp-1", "demo"))).To(Succeed())
+		Expect(store.Create(context.Background(), &core_mesh.ProxyTemplateResource{}, core_store.CreateByKey("default", "pt-1", "demo"))).To(Succeed())
+		Expect(store.Create(context.Background(), &core_mesh.TrafficPermissionResource{}, core_store.CreateByKey("default", "tp-1", "demo"))).To(Succeed())
+		Expect(secretManager.Create(context.Background(), &core_system.SecretResource{}, core_store.CreateByKey("default", "signing-key", "demo"))).To(Succeed())
+		// and resources of another mesh
+		Expect(store.Create(context.Background(), &core_mesh.DataplaneResource{}, core_store.CreateByKey("default", "dp-1", "other"))).To(Succeed())
+		Expect(secretManager.Create(context.Background(), &core_system.SecretResource{}, core_store.CreateByKey("default", "signing-key", "other"))).To(Succeed())
+
+		// when
+		err := resManager.Delete(context.Background(), &core_mesh.MeshResource{}, core_store.DeleteByKey("default", "demo", "demo"), core_store.DeleteForce())
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+
+		// and
+		err = store.Get(context.Background(), &core_mesh.MeshResource{}, core_store.GetByKey("default", "demo", "demo"))
+		Expect(core_store.IsResourceNotFound(err)).To(BeTrue())
+		Expect(dataplanes("demo")).To(Equal(0))
+		insights := core_mesh.DataplaneInsightResourceList{}
+		Expect(store.List(context.Background(), &insights, core_store.ListByMesh("demo"))).To(Succeed())
+		Expect(insights.Items).To(BeEmpty())
+		templates := core_mesh.ProxyTemplateResourceList{}
+		Expect(store.List(context.Background(), &templates, core_store.ListByMesh("demo"))).To(Succeed())
+		Expect(templates.Items).To(BeEmpty())
+		permissions := core_mesh.TrafficPermissionResourceList{}
+		Expect(store.List(context.Background(), &permissions, core_store.ListByMesh("demo"))).To(Succeed())
+		Expect(permissions.Items).To(BeEmpty())
+		_, err = caManager.GetRootCerts(context.Background(), "demo")
+		Expect(err).To(HaveOccurred())
+		err = secretManager.Get(context.Background(), &core_system.SecretResource{}, core_store.GetByKey("default", "signing-key", "demo"))
+		Expect(core_store.IsResourceNotFound(err)).To(BeTrue())
+
+		// and other meshes are not affected
+		Expect(dataplanes("other")).To(Equal(1))
+		Expect(secretManager.Get(context.Background(), &core_system.SecretResource{}, core_store.GetByKey("default", "signing-key", "other"))).To(Succeed())
+		_, err = caManager.GetRootCerts(context.Background(), "other")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should not be blocked by DataplaneInsights left behind", func() {
+		// given
+		Expect(store.Create(context.Background(), &core_mesh.DataplaneInsightResource{}, core_store.CreateByKey("default", "dp-1", "demo"))).To(Succeed())
+
+		// when
+		err := resManager.Delete(context.Background(), &core_mesh.MeshResource{}, core_store.DeleteByKey("default", "demo", "demo"))
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+
+		// and
+		insights := core_mesh.DataplaneInsightResourceList{}
+		Expect(store.List(context.Background(), &insights, core_store.ListByMesh("demo"))).To(Succeed())
+		Expect(insights.Items).To(BeEmpty())
+	})
+})
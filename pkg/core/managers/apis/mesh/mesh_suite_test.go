@@ -0,0 +1,13 @@
+package mesh_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestMeshManager(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Mesh Manager Suite")
+}
@@ -0,0 +1,78 @@
+package constraint
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+)
+
+// NewPolicyConstraintManager returns a manager that refuses to create or update policies
+// of types that are not allowed by the constraints of their Mesh.
+//
+// Deletion is never refused, so that policies created before a constraint has been set can be cleaned up.
+func NewPolicyConstraintManager(delegate core_manager.ResourceManager, store core_store.ResourceStore) core_manager.ResourceManager {
+	return &policyConstraintManager{
+		ResourceManager: delegate,
+		store:           store,
+	}
+}
+
+type policyConstraintManager struct {
+	core_manager.ResourceManager
+	store core_store.ResourceStore
+}
+
+func (m *policyConstraintManager) Create(ctx context.Context, resource core_model.Resource, fs ...core_store.CreateOptionsFunc) error {
+	opts := core_store.NewCreateOptions(fs...)
+	if err := CheckAllowed(ctx, m.store, resource.GetType(), opts.Namespace, opts.Mesh); err != nil {
+		return err
+	}
+	return m.ResourceManager.Create(ctx, resource, fs...)
+}
+
+func (m *policyConstraintManager) Update(ctx context.Context, resource core_model.Resource, fs ...core_store.UpdateOptionsFunc) error {
+	if err := CheckAllowed(ctx, m.store, resource.GetType(), resource.GetMeta().GetNamespace(), resource.GetMeta().GetMesh()); err != nil {
+		return err
+	}
+	return m.ResourceManager.Update(ctx, resource, fs...)
+}
+
+// CheckAllowed returns an error if a Mesh of a given name in a given namespace doesn't allow policies of a given type.
+//
+// Resources that are not policies and policies of a Mesh that doesn't exist are always allowed.
+func CheckAllowed(ctx context.Context, store core_store.ResourceStore, resourceType core_model.ResourceType, namespace string, meshName string) error {
+	if !core_mesh.IsPolicy(resourceType) {
+		return nil
+	}
+	mesh := &core_mesh.MeshResource{}
+	if err := store.Get(ctx, mesh, core_store.GetByKey(namespace, meshName, meshName)); err != nil {
+		if core_store.IsResourceNotFound(err) {
+			return nil // let the delegate report a missing Mesh
+		}
+		return errors.Wrapf(err, "could not retrieve constraints of mesh %q", meshName)
+	}
+	allowed := mesh.Spec.GetConstraints().GetAllowedPolicyTypes()
+	if len(allowed) == 0 {
+		return nil
+	}
+	for _, allowedType := range allowed {
+		if allowedType == string(resourceType) {
+			return nil
+		}
+	}
+	return PolicyTypeNotAllowed(meshName, resourceType)
+}
+
+func PolicyTypeNotAllowed(mesh string, resourceType core_model.ResourceType) error {
+	return errors.Errorf("policy type not allowed: mesh of name %v does not allow policies of type %s", mesh, resourceType)
+}
+
+func IsPolicyTypeNotAllowed(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), "policy type not allowed:")
+}
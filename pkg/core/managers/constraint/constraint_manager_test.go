@@ -0,0 +1,86 @@
+package constraint_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	"github.com/Kong/kuma/pkg/core/managers/constraint"
+	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+)
+
+var _ = Describe("Policy Constraint Manager", func() {
+
+	var store core_store.ResourceStore
+	var resManager core_manager.ResourceManager
+
+	BeforeEach(func() {
+		store = memory.NewStore()
+		resManager = constraint.NewPolicyConstraintManager(core_manager.NewResourceManager(store), store)
+
+		restricted := &core_mesh.MeshResource{
+			Spec: mesh_proto.Mesh{
+				Constraints: &mesh_proto.Constraints{
+					AllowedPolicyTypes: []string{"TrafficPermission"},
+				},
+			},
+		}
+		err := store.Create(context.Background(), restricted, core_store.CreateByKey("default", "demo", "demo"))
+		Expect(err).ToNot(HaveOccurred())
+		err = store.Create(context.Background(), &core_mesh.MeshResource{}, core_store.CreateByKey("default", "other", "other"))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should refuse to create a policy of a type that is not allowed", func() {
+		// when
+		err := resManager.Create(context.Background(), &core_mesh.ProxyTemplateResource{}, core_store.CreateByKey("default", "pt-1", "demo"))
+
+		// then
+		Expect(constraint.IsPolicyTypeNotAllowed(err)).To(BeTrue())
+		Expect(err.Error()).To(Equal("policy type not allowed: mesh of name demo does not allow policies of type ProxyTemplate"))
+
+		// and other meshes are not affected
+		err = resManager.Create(context.Background(), &core_mesh.ProxyTemplateResource{}, core_store.CreateByKey("default", "pt-1", "other"))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should create a policy of an allowed type and resources that are not policies", func() {
+		// when
+		err := resManager.Create(context.Background(), &core_mesh.TrafficPermissionResource{}, core_store.CreateByKey("default", "tp-1", "demo"))
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		err = resManager.Create(context.Background(), &core_mesh.DataplaneResource{}, core_store.CreateByKey("default", "dp-1", "demo"))
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should refuse to update but allow to delete a policy that is no longer allowed", func() {
+		// given a policy created before the constraint
+		err := store.Create(context.Background(), &core_mesh.ProxyTemplateResource{}, core_store.CreateByKey("default", "pt-1", "demo"))
+		Expect(err).ToNot(HaveOccurred())
+		policy := &core_mesh.ProxyTemplateResource{}
+		err = resManager.Get(context.Background(), policy, core_store.GetByKey("default", "pt-1", "demo"))
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		err = resManager.Update(context.Background(), policy)
+
+		// then
+		Expect(constraint.IsPolicyTypeNotAllowed(err)).To(BeTrue())
+
+		// when
+		err = resManager.Delete(context.Background(), &core_mesh.ProxyTemplateResource{}, core_store.DeleteByKey("default", "pt-1", "demo"))
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
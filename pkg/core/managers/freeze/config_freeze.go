@@ -0,0 +1,115 @@
+package freeze
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/pkg/errors"
+
+	"github.com/Kong/kuma/pkg/core"
+	core_system "github.com/Kong/kuma/pkg/core/resources/apis/system"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	secret_manager "github.com/Kong/kuma/pkg/core/secrets/manager"
+)
+
+var (
+	freezeLog = core.Log.WithName("config-freeze")
+)
+
+// ConfigFreeze stops changes of Meshes and policies from being made during change-freeze windows.
+//
+// The freeze is enforced where changes are made rather than where config of Dataplanes is generated:
+// by API Server and, on Kubernetes, by the validating webhook that kubectl goes through.
+// Changes that bypass both, e.g. those made directly in Postgres, are not held back and reach Dataplanes as usual.
+// Changes of Dataplanes themselves are never frozen.
+type ConfigFreeze interface {
+	// Status returns whether configuration is frozen at the moment.
+	Status(ctx context.Context) (ConfigFreezeStatus, error)
+	// Set freezes or unfreezes configuration on behalf of a given identity.
+	Set(ctx context.Context, frozen bool, by string, reason string) (ConfigFreezeStatus, error)
+}
+
+// ConfigFreezeStatus describes who has frozen or unfrozen configuration, when and why.
+type ConfigFreezeStatus struct {
+	Frozen bool      `json:"frozen"`
+	By     string    `json:"by,omitempty"`
+	Reason string    `json:"reason,omitempty"`
+	Since  time.Time `json:"since"`
+}
+
+// NewConfigFreeze returns a ConfigFreeze that is kept as a Secret,
+// so that it survives a restart and is shared by all instances of Control Plane.
+func NewConfigFreeze(secretManager secret_manager.SecretManager) ConfigFreeze {
+	return &configFreeze{
+		secretManager: secretManager,
+	}
+}
+
+var _ ConfigFreeze = &configFreeze{}
+
+type configFreeze struct {
+	secretManager secret_manager.SecretManager
+}
+
+func (f *configFreeze) Status(ctx context.Context) (ConfigFreezeStatus, error) {
+	_, status, err := f.get(ctx)
+	if err != nil {
+		if core_store.IsResourceNotFound(err) {
+			return ConfigFreezeStatus{}, nil
+		}
+		return ConfigFreezeStatus{}, err
+	}
+	return *status, nil
+}
+
+func (f *configFreeze) Set(ctx context.Context, frozen bool, by string, reason string) (ConfigFreezeStatus, error) {
+	status := ConfigFreezeStatus{
+		Frozen: frozen,
+		By:     by,
+		Reason: reason,
+		Since:  core.Now(),
+	}
+	data, err := json.Marshal(status)
+	if err != nil {
+		return ConfigFreezeStatus{}, errors.Wrap(err, "failed to serialize status of the configuration freeze")
+	}
+	secret, _, err := f.get(ctx)
+	switch {
+	case core_store.IsResourceNotFound(err):
+		secret = &core_system.SecretResource{
+			Spec: types.BytesValue{Value: data},
+		}
+		err = f.secretManager.Create(ctx, secret, core_store.CreateBy(configFreezeSecretKey))
+	case err == nil:
+		secret.Spec.Value = data
+		err = f.secretManager.Update(ctx, secret)
+	}
+	if err != nil {
+		return ConfigFreezeStatus{}, errors.Wrap(err, "failed to save status of the configuration freeze")
+	}
+	// keep an audit trail of who has toggled the freeze
+	freezeLog.Info("configuration freeze changed", "frozen", frozen, "by", by, "reason", reason)
+	return status, nil
+}
+
+func (f *configFreeze) get(ctx context.Context) (*core_system.SecretResource, *ConfigFreezeStatus, error) {
+	secret := &core_system.SecretResource{}
+	if err := f.secretManager.Get(ctx, secret, core_store.GetBy(configFreezeSecretKey)); err != nil {
+		return nil, nil, err
+	}
+	status := &ConfigFreezeStatus{}
+	if err := json.Unmarshal(secret.Spec.Value, status); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to deserialize status of the configuration freeze")
+	}
+	return secret, status, nil
+}
+
+// configFreezeSecretKey identifies the Secret with status of the configuration freeze.
+// It doesn't belong to any Mesh, so that it's not removed together with a Mesh.
+var configFreezeSecretKey = core_model.ResourceKey{
+	Namespace: core_model.DefaultNamespace,
+	Name:      "config-freeze",
+}
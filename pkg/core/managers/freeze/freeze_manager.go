@@ -0,0 +1,76 @@
+package freeze
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+)
+
+// NewConfigFreezeManager returns a manager that rejects changes of Meshes and policies while configuration is frozen.
+//
+// Changes of Dataplanes are not affected, since they reflect the state of workloads rather than configuration.
+// The manager is meant to wrap changes made by users only, so that Control Plane itself
+// can still e.g. remove expired policies.
+func NewConfigFreezeManager(delegate core_manager.ResourceManager, freeze ConfigFreeze) core_manager.ResourceManager {
+	return &configFreezeManager{
+		ResourceManager: delegate,
+		freeze:          freeze,
+	}
+}
+
+type configFreezeManager struct {
+	core_manager.ResourceManager
+	freeze ConfigFreeze
+}
+
+func (m *configFreezeManager) Create(ctx context.Context, resource core_model.Resource, fs ...core_store.CreateOptionsFunc) error {
+	if err := CheckFreeze(ctx, m.freeze, resource.GetType()); err != nil {
+		return err
+	}
+	return m.ResourceManager.Create(ctx, resource, fs...)
+}
+
+func (m *configFreezeManager) Update(ctx context.Context, resource core_model.Resource, fs ...core_store.UpdateOptionsFunc) error {
+	if err := CheckFreeze(ctx, m.freeze, resource.GetType()); err != nil {
+		return err
+	}
+	return m.ResourceManager.Update(ctx, resource, fs...)
+}
+
+func (m *configFreezeManager) Delete(ctx context.Context, resource core_model.Resource, fs ...core_store.DeleteOptionsFunc) error {
+	if err := CheckFreeze(ctx, m.freeze, resource.GetType()); err != nil {
+		return err
+	}
+	return m.ResourceManager.Delete(ctx, resource, fs...)
+}
+
+// CheckFreeze returns an error if configuration is frozen and resources of a given type are subject to the freeze,
+// i.e. they are Meshes or policies.
+func CheckFreeze(ctx context.Context, freeze ConfigFreeze, resourceType core_model.ResourceType) error {
+	if resourceType != core_mesh.MeshType && !core_mesh.IsPolicy(resourceType) {
+		return nil
+	}
+	status, err := freeze.Status(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve status of the configuration freeze")
+	}
+	if status.Frozen {
+		return ConfigFrozen(status)
+	}
+	return nil
+}
+
+func ConfigFrozen(status ConfigFreezeStatus) error {
+	return errors.Errorf("configuration is frozen: frozen by %q since %s, reason: %q", status.By, status.Since.Format(time.RFC3339), status.Reason)
+}
+
+func IsConfigFrozen(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), "configuration is frozen:")
+}
@@ -0,0 +1,118 @@
+package freeze_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kong/kuma/pkg/core"
+	"github.com/Kong/kuma/pkg/core/managers/freeze"
+	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	secret_cipher "github.com/Kong/kuma/pkg/core/secrets/cipher"
+	secret_manager "github.com/Kong/kuma/pkg/core/secrets/manager"
+	secret_store "github.com/Kong/kuma/pkg/core/secrets/store"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+)
+
+var _ = Describe("Config Freeze Manager", func() {
+
+	var store core_store.ResourceStore
+	var configFreeze freeze.ConfigFreeze
+	var resManager core_manager.ResourceManager
+
+	newConfigFreeze := func() freeze.ConfigFreeze {
+		return freeze.NewConfigFreeze(secret_manager.NewSecretManager(secret_store.NewSecretStore(store), secret_cipher.None()))
+	}
+
+	setFreeze := func(frozen bool, by string, reason string) {
+		_, err := configFreeze.Set(context.Background(), frozen, by, reason)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		core.Now = func() time.Time {
+			return time.Unix(1568000000, 0).UTC()
+		}
+		store = memory.NewStore()
+		err := store.Create(context.Background(), &core_mesh.MeshResource{}, core_store.CreateByKey("default", "demo", "demo"))
+		Expect(err).ToNot(HaveOccurred())
+
+		configFreeze = newConfigFreeze()
+		resManager = freeze.NewConfigFreezeManager(core_manager.NewResourceManager(store), configFreeze)
+	})
+
+	AfterEach(func() {
+		core.Now = time.Now
+	})
+
+	It("should reject changes of policies while configuration is frozen", func() {
+		// given
+		setFreeze(true, "john", "Black Friday")
+
+		// when
+		err := resManager.Create(context.Background(), &core_mesh.TrafficPermissionResource{}, core_store.CreateByKey("default", "tp-1", "demo"))
+
+		// then
+		Expect(err).To(MatchError(`configuration is frozen: frozen by "john" since 2019-09-09T03:33:20Z, reason: "Black Friday"`))
+		Expect(freeze.IsConfigFrozen(err)).To(BeTrue())
+
+		// when
+		err = resManager.Delete(context.Background(), &core_mesh.MeshResource{}, core_store.DeleteByKey("default", "demo", "demo"))
+
+		// then
+		Expect(freeze.IsConfigFrozen(err)).To(BeTrue())
+	})
+
+	It("should let changes of Dataplanes through while configuration is frozen", func() {
+		// given
+		setFreeze(true, "john", "Black Friday")
+
+		// when
+		err := resManager.Create(context.Background(), &core_mesh.DataplaneResource{}, core_store.CreateByKey("default", "dp-1", "demo"))
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should let changes of policies through once configuration is unfrozen", func() {
+		// given
+		setFreeze(true, "john", "Black Friday")
+		setFreeze(false, "jane", "")
+
+		// when
+		err := resManager.Create(context.Background(), &core_mesh.TrafficPermissionResource{}, core_store.CreateByKey("default", "tp-1", "demo"))
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should keep configuration frozen across instances of Control Plane", func() {
+		// given
+		setFreeze(true, "john", "Black Friday")
+
+		// when
+		status, err := newConfigFreeze().Status(context.Background())
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(freeze.ConfigFreezeStatus{
+			Frozen: true,
+			By:     "john",
+			Reason: "Black Friday",
+			Since:  time.Unix(1568000000, 0).UTC(),
+		}))
+	})
+
+	It("should not be frozen by default", func() {
+		// when
+		status, err := configFreeze.Status(context.Background())
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status.Frozen).To(BeFalse())
+	})
+})
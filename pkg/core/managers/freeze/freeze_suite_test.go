@@ -0,0 +1,13 @@
+package freeze_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestFreezeManager(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Freeze Manager Suite")
+}
@@ -0,0 +1,13 @@
+package notification_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestNotificationManager(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Notification Manager Suite")
+}
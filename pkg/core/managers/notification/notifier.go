@@ -0,0 +1,29 @@
+package notification
+
+import (
+	"time"
+)
+
+// Action is a kind of change that has been made to a policy.
+type Action string
+
+const (
+	CreateAction Action = "create"
+	UpdateAction Action = "update"
+	DeleteAction Action = "delete"
+)
+
+// PolicyChange describes a change that has been made to a policy in a Mesh.
+type PolicyChange struct {
+	Mesh   string    `json:"mesh"`
+	Type   string    `json:"type"`
+	Name   string    `json:"name"`
+	Action Action    `json:"action"`
+	Time   time.Time `json:"time"`
+}
+
+// Notifier lets external systems know about changes of policies.
+type Notifier interface {
+	// Notify must not block, since it is called on the path of every change of a policy observed in the ResourceStore.
+	Notify(change PolicyChange)
+}
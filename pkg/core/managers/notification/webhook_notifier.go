@@ -0,0 +1,205 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/Kong/kuma/pkg/core"
+	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_registry "github.com/Kong/kuma/pkg/core/resources/registry"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	core_runtime "github.com/Kong/kuma/pkg/core/runtime"
+)
+
+var webhookLog = core.Log.WithName("policy-change-webhook")
+
+const (
+	// webhookQueueSize limits the number of notifications waiting to be delivered to a webhook of a single Mesh.
+	webhookQueueSize = 100
+	webhookTimeout   = 5 * time.Second
+)
+
+// WebhookNotifier POSTs every change of a policy as JSON to a webhook configured in the Mesh of that policy.
+//
+// Changes are observed in the ResourceStore, so changes made past the ResourceManager, e.g. with kubectl,
+// are noticed as well. Every instance of Control Plane that runs the notifier sends notifications,
+// that is why it is run by controllers only.
+//
+// Notifications of every Mesh are delivered in order by a worker of their own,
+// so that a slow webhook of one Mesh doesn't hold back notifications of others.
+type WebhookNotifier interface {
+	Notifier
+	core_runtime.Component
+	// Pending returns the number of notifications that have not been delivered yet.
+	Pending() int
+}
+
+func NewWebhookNotifier(store core_store.ResourceStore, watcher core_store.ResourceWatcher, transport http.RoundTripper) WebhookNotifier {
+	return &webhookNotifier{
+		store:   store,
+		watcher: watcher,
+		client:  &http.Client{Transport: transport, Timeout: webhookTimeout},
+		queues:  map[string][]PolicyChange{},
+	}
+}
+
+type webhookNotifier struct {
+	store   core_store.ResourceStore
+	watcher core_store.ResourceWatcher
+	client  *http.Client
+
+	mu sync.Mutex // protects access to the fields below
+	// queues are indexed by Mesh. A Mesh has a worker delivering its notifications as long as it has a queue
+	queues map[string][]PolicyChange
+}
+
+func (n *webhookNotifier) Notify(change PolicyChange) {
+	if change.Time.IsZero() {
+		change.Time = core.Now()
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	queue, working := n.queues[change.Mesh]
+	if len(queue) >= webhookQueueSize {
+		webhookLog.Info("too many pending notifications, dropping a policy change", "change", change)
+		return
+	}
+	n.queues[change.Mesh] = append(queue, change)
+	if !working {
+		go n.deliver(change.Mesh)
+	}
+}
+
+func (n *webhookNotifier) Pending() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	pending := 0
+	for _, queue := range n.queues {
+		pending += len(queue)
+	}
+	return pending
+}
+
+func (n *webhookNotifier) Start(stop <-chan struct{}) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := n.watchPolicies(ctx)
+	if err != nil {
+		return err
+	}
+	for {
+		select {
+		case event := <-events:
+			if action, ok := actionOf(event.Type); ok {
+				n.Notify(PolicyChange{
+					Mesh:   event.Mesh,
+					Type:   string(event.ResourceType),
+					Name:   event.Name,
+					Action: action,
+				})
+			}
+		case <-stop:
+			return nil
+		}
+	}
+}
+
+// watchPolicies returns a single channel of events about policies of all types in all namespaces.
+func (n *webhookNotifier) watchPolicies(ctx context.Context) (<-chan core_store.Event, error) {
+	events := make(chan core_store.Event)
+	for _, typ := range core_registry.Global().ListTypes() {
+		if !core_mesh.IsPolicy(typ) {
+			continue
+		}
+		typeEvents, err := n.watcher.Watch(ctx, typ, "")
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not watch policies of type %q", typ)
+		}
+		go func() {
+			for event := range typeEvents {
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	return events, nil
+}
+
+func actionOf(eventType core_store.EventType) (Action, bool) {
+	switch eventType {
+	case core_store.CreatedEvent:
+		return CreateAction, true
+	case core_store.UpdatedEvent:
+		return UpdateAction, true
+	case core_store.DeletedEvent:
+		return DeleteAction, true
+	default:
+		// e.g., a resync, which is not a change of a policy
+		return "", false
+	}
+}
+
+// deliver sends notifications of a given Mesh one by one until there are none left.
+func (n *webhookNotifier) deliver(mesh string) {
+	for {
+		n.mu.Lock()
+		queue := n.queues[mesh]
+		if len(queue) == 0 {
+			delete(n.queues, mesh)
+			n.mu.Unlock()
+			return
+		}
+		change := queue[0]
+		n.queues[mesh] = queue[1:]
+		n.mu.Unlock()
+
+		if err := n.send(change); err != nil {
+			webhookLog.Error(err, "unable to notify about a policy change", "change", change)
+		}
+	}
+}
+
+func (n *webhookNotifier) send(change PolicyChange) error {
+	url, err := n.webhookURL(change.Mesh)
+	if err != nil {
+		return err
+	}
+	if url == "" {
+		return nil
+	}
+	body, err := json.Marshal(change)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal a policy change")
+	}
+	resp, err := n.client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrapf(err, "could not send a request to %q", url)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("webhook %q responded with status code %d", url, resp.StatusCode)
+	}
+	return nil
+}
+
+func (n *webhookNotifier) webhookURL(mesh string) (string, error) {
+	meshes := &core_mesh.MeshResourceList{}
+	if err := n.store.List(context.Background(), meshes, core_store.ListByMesh(mesh)); err != nil {
+		return "", errors.Wrapf(err, "could not retrieve Mesh %q", mesh)
+	}
+	if len(meshes.Items) != 1 {
+		// e.g., the Mesh has been deleted together with its policies
+		return "", nil
+	}
+	return meshes.Items[0].Spec.GetNotifications().GetWebhook().GetUrl(), nil
+}
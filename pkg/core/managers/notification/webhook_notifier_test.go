@@ -0,0 +1,161 @@
+package notification_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	"github.com/Kong/kuma/pkg/core/managers/notification"
+	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+)
+
+var _ = Describe("Webhook Notifier", func() {
+
+	var requests chan map[string]interface{}
+	var webhook *httptest.Server
+	var store core_store.ResourceStore
+	var stop chan struct{}
+	var notifier notification.WebhookNotifier
+	var slowWebhook *httptest.Server
+	var release chan struct{}
+
+	BeforeEach(func() {
+		requests = make(chan map[string]interface{}, 10)
+		webhook = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			defer GinkgoRecover()
+			Expect(req.Method).To(Equal("POST"))
+			Expect(req.Header.Get("Content-Type")).To(Equal("application/json"))
+			body, err := ioutil.ReadAll(req.Body)
+			Expect(err).ToNot(HaveOccurred())
+			payload := map[string]interface{}{}
+			Expect(json.Unmarshal(body, &payload)).To(Succeed())
+			requests <- payload
+		}))
+
+		store = memory.NewStore()
+		err := store.Create(context.Background(), &core_mesh.MeshResource{
+			Spec: mesh_proto.Mesh{
+				Notifications: &mesh_proto.Notifications{
+					Webhook: &mesh_proto.Notifications_Webhook{
+						Url: webhook.URL,
+					},
+				},
+			},
+		}, core_store.CreateByKey("default", "demo", "demo"))
+		Expect(err).ToNot(HaveOccurred())
+		err = store.Create(context.Background(), &core_mesh.MeshResource{}, core_store.CreateByKey("default", "silent", "silent"))
+		Expect(err).ToNot(HaveOccurred())
+
+		release = make(chan struct{})
+		slowWebhook = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			<-release
+		}))
+		err = store.Create(context.Background(), &core_mesh.MeshResource{
+			Spec: mesh_proto.Mesh{
+				Notifications: &mesh_proto.Notifications{
+					Webhook: &mesh_proto.Notifications_Webhook{
+						Url: slowWebhook.URL,
+					},
+				},
+			},
+		}, core_store.CreateByKey("default", "slow", "slow"))
+		Expect(err).ToNot(HaveOccurred())
+
+		stop = make(chan struct{})
+		notifier = notification.NewWebhookNotifier(store, store.(core_store.ResourceWatcher), http.DefaultTransport)
+		go func(notifier notification.WebhookNotifier, stop <-chan struct{}) {
+			defer GinkgoRecover()
+			Expect(notifier.Start(stop)).To(Succeed())
+		}(notifier, stop)
+	})
+
+	AfterEach(func() {
+		close(stop)
+		close(release)
+		webhook.Close()
+		slowWebhook.Close()
+	})
+
+	It("should POST policy changes to a webhook of the Mesh", func() {
+		// when
+		notifier.Notify(notification.PolicyChange{
+			Mesh:   "silent",
+			Type:   "ProxyTemplate",
+			Name:   "pt-1",
+			Action: notification.CreateAction,
+			Time:   time.Unix(1568000000, 0).UTC(),
+		})
+		notifier.Notify(notification.PolicyChange{
+			Mesh:   "demo",
+			Type:   "TrafficPermission",
+			Name:   "tp-1",
+			Action: notification.DeleteAction,
+			Time:   time.Unix(1568000000, 0).UTC(),
+		})
+
+		// then
+		Eventually(requests).Should(Receive(Equal(map[string]interface{}{
+			"mesh":   "demo",
+			"type":   "TrafficPermission",
+			"name":   "tp-1",
+			"action": "delete",
+			"time":   "2019-09-09T03:33:20Z",
+		})))
+		// and
+		Consistently(requests, "100ms").ShouldNot(Receive())
+	})
+
+	It("should notify about changes of policies made directly in the store", func() {
+		// expect the notifier, which starts watching asynchronously, to notice a change eventually
+		i := 0
+		Eventually(func() bool {
+			i++
+			err := store.Create(context.Background(), &core_mesh.TrafficPermissionResource{}, core_store.CreateByKey("default", fmt.Sprintf("tp-%d", i), "demo"))
+			Expect(err).ToNot(HaveOccurred())
+			select {
+			case payload := <-requests:
+				Expect(payload).To(HaveKeyWithValue("mesh", "demo"))
+				Expect(payload).To(HaveKeyWithValue("type", "TrafficPermission"))
+				Expect(payload).To(HaveKeyWithValue("action", "create"))
+				return true
+			case <-time.After(10 * time.Millisecond):
+				return false
+			}
+		}, "5s", "1ms").Should(BeTrue())
+	})
+
+	It("should not hold back notifications of a Mesh behind a slow webhook of another Mesh", func() {
+		// given
+		for i := 0; i < 3; i++ {
+			notifier.Notify(notification.PolicyChange{
+				Mesh:   "slow",
+				Type:   "TrafficPermission",
+				Name:   fmt.Sprintf("tp-%d", i),
+				Action: notification.CreateAction,
+			})
+		}
+
+		// when
+		notifier.Notify(notification.PolicyChange{
+			Mesh:   "demo",
+			Type:   "TrafficPermission",
+			Name:   "tp-1",
+			Action: notification.CreateAction,
+		})
+
+		// then
+		Eventually(requests).Should(Receive(HaveKeyWithValue("mesh", "demo")))
+		// and
+		Eventually(notifier.Pending).Should(Equal(2))
+	})
+})
@@ -0,0 +1,132 @@
+package quota
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	quota_config "github.com/Kong/kuma/pkg/config/core/resources/quota"
+	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	core_registry "github.com/Kong/kuma/pkg/core/resources/registry"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+)
+
+// NewQuotaManager returns a manager that refuses to create resources in a Mesh
+// once the Mesh has reached its quota.
+//
+// Creation of resources subject to quotas is serialized per Mesh, so that concurrent creation
+// through the manager cannot exceed a quota. Resources created by other instances of Control Plane
+// or past the manager, e.g. with kubectl, are only counted once they are stored.
+func NewQuotaManager(delegate core_manager.ResourceManager, store core_store.ResourceStore, config quota_config.QuotaConfig) core_manager.ResourceManager {
+	return &quotaManager{
+		ResourceManager: delegate,
+		store:           store,
+		config:          config,
+		meshLocks:       map[string]*sync.Mutex{},
+	}
+}
+
+type quotaManager struct {
+	core_manager.ResourceManager
+	store  core_store.ResourceStore
+	config quota_config.QuotaConfig
+
+	mu        sync.Mutex // protects meshLocks
+	meshLocks map[string]*sync.Mutex
+}
+
+func (m *quotaManager) Create(ctx context.Context, resource core_model.Resource, fs ...core_store.CreateOptionsFunc) error {
+	opts := core_store.NewCreateOptions(fs...)
+	if !HasQuota(m.config, resource.GetType()) {
+		return m.ResourceManager.Create(ctx, resource, fs...)
+	}
+	lock := m.meshLock(opts.Mesh)
+	lock.Lock()
+	defer lock.Unlock()
+	if err := CheckQuota(ctx, m.store, m.config, resource.GetType(), opts.Mesh, opts.Labels); err != nil {
+		return err
+	}
+	return m.ResourceManager.Create(ctx, resource, fs...)
+}
+
+func (m *quotaManager) meshLock(mesh string) *sync.Mutex {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	lock, ok := m.meshLocks[mesh]
+	if !ok {
+		lock = &sync.Mutex{}
+		m.meshLocks[mesh] = lock
+	}
+	return lock
+}
+
+// HasQuota returns true if creation of resources of a given type is limited by quotas.
+func HasQuota(config quota_config.QuotaConfig, resourceType core_model.ResourceType) bool {
+	switch {
+	case resourceType == core_mesh.DataplaneType:
+		return config.MaxDataplanesPerMesh > 0 || config.MaxExternalServicesPerMesh > 0
+	case core_mesh.IsPolicy(resourceType):
+		return config.MaxPoliciesPerMesh > 0
+	default:
+		return false
+	}
+}
+
+// CheckQuota returns an error if a Mesh has no room left for another resource of a given type with given labels.
+//
+// Resources are counted until the quota is reached, so that the cost of a check is limited by the quota
+// rather than by the number of resources in the Mesh.
+func CheckQuota(ctx context.Context, store core_store.ResourceStore, config quota_config.QuotaConfig, resourceType core_model.ResourceType, mesh string, labels map[string]string) error {
+	switch {
+	case resourceType == core_mesh.DataplaneType:
+		if catalog := labels[core_mesh.DiscoveredByLabel]; catalog != "" && config.MaxExternalServicesPerMesh > 0 {
+			discoveredBy := map[string]string{core_mesh.DiscoveredByLabel: catalog}
+			if err := checkLimit(ctx, store, mesh, "external services", config.MaxExternalServicesPerMesh, discoveredBy, core_mesh.DataplaneType); err != nil {
+				return err
+			}
+		}
+		if config.MaxDataplanesPerMesh > 0 {
+			return checkLimit(ctx, store, mesh, "dataplanes", config.MaxDataplanesPerMesh, nil, core_mesh.DataplaneType)
+		}
+		return nil
+	case core_mesh.IsPolicy(resourceType) && config.MaxPoliciesPerMesh > 0:
+		return checkLimit(ctx, store, mesh, "policies", config.MaxPoliciesPerMesh, nil, core_mesh.PolicyTypes()...)
+	default:
+		return nil
+	}
+}
+
+func checkLimit(ctx context.Context, store core_store.ResourceStore, mesh string, kind string, limit int, labels map[string]string, resourceTypes ...core_model.ResourceType) error {
+	count := 0
+	for _, resourceType := range resourceTypes {
+		list, err := core_registry.Global().NewList(resourceType)
+		if err != nil {
+			return err
+		}
+		// there is no need to count beyond the limit
+		opts := []core_store.ListOptionsFunc{core_store.ListByMesh(mesh), core_store.ListByPage(limit-count, "")}
+		if labels != nil {
+			opts = append(opts, core_store.ListByLabels(labels))
+		}
+		if err := store.List(ctx, list, opts...); err != nil {
+			return errors.Wrapf(err, "could not count resources of type %q in mesh %q", resourceType, mesh)
+		}
+		count += len(list.GetItems())
+		if count >= limit {
+			return QuotaExceeded(mesh, kind, limit)
+		}
+	}
+	return nil
+}
+
+func QuotaExceeded(mesh string, kind string, limit int) error {
+	return errors.Errorf("quota exceeded: mesh of name %v cannot have more than %d %s", mesh, limit, kind)
+}
+
+func IsQuotaExceeded(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), "quota exceeded:")
+}
@@ -0,0 +1,126 @@
+package quota_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	quota_config "github.com/Kong/kuma/pkg/config/core/resources/quota"
+	"github.com/Kong/kuma/pkg/core/managers/quota"
+	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+)
+
+var _ = Describe("Quota Manager", func() {
+
+	var store core_store.ResourceStore
+	var resManager core_manager.ResourceManager
+
+	BeforeEach(func() {
+		store = memory.NewStore()
+		resManager = quota.NewQuotaManager(core_manager.NewResourceManager(store), store, quota_config.QuotaConfig{
+			MaxDataplanesPerMesh:       2,
+			MaxPoliciesPerMesh:         1,
+			MaxExternalServicesPerMesh: 1,
+		})
+
+		for _, mesh := range []string{"demo", "other"} {
+			err := store.Create(context.Background(), &core_mesh.MeshResource{}, core_store.CreateByKey("default", mesh, mesh))
+			Expect(err).ToNot(HaveOccurred())
+		}
+	})
+
+	It("should refuse to create more dataplanes than allowed", func() {
+		// given
+		for _, name := range []string{"dp-1", "dp-2"} {
+			err := resManager.Create(context.Background(), &core_mesh.DataplaneResource{}, core_store.CreateByKey("default", name, "demo"))
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		// when
+		err := resManager.Create(context.Background(), &core_mesh.DataplaneResource{}, core_store.CreateByKey("default", "dp-3", "demo"))
+
+		// then
+		Expect(quota.IsQuotaExceeded(err)).To(BeTrue())
+		Expect(err.Error()).To(Equal("quota exceeded: mesh of name demo cannot have more than 2 dataplanes"))
+
+		// and other meshes are not affected
+		err = resManager.Create(context.Background(), &core_mesh.DataplaneResource{}, core_store.CreateByKey("default", "dp-3", "other"))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should count all policy types together", func() {
+		// given
+		err := resManager.Create(context.Background(), &core_mesh.TrafficPermissionResource{}, core_store.CreateByKey("default", "tp-1", "demo"))
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		err = resManager.Create(context.Background(), &core_mesh.ProxyTemplateResource{}, core_store.CreateByKey("default", "pt-1", "demo"))
+
+		// then
+		Expect(quota.IsQuotaExceeded(err)).To(BeTrue())
+		Expect(err.Error()).To(Equal("quota exceeded: mesh of name demo cannot have more than 1 policies"))
+	})
+
+	It("should refuse to create more dataplanes of external services than allowed", func() {
+		// given
+		discoveredByConsul := core_store.CreateWithLabels(map[string]string{core_mesh.DiscoveredByLabel: "consul"})
+		err := resManager.Create(context.Background(), &core_mesh.DataplaneResource{}, core_store.CreateByKey("default", "web-1", "demo"), discoveredByConsul)
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		err = resManager.Create(context.Background(), &core_mesh.DataplaneResource{}, core_store.CreateByKey("default", "web-2", "demo"), discoveredByConsul)
+
+		// then
+		Expect(quota.IsQuotaExceeded(err)).To(BeTrue())
+		Expect(err.Error()).To(Equal("quota exceeded: mesh of name demo cannot have more than 1 external services"))
+
+		// and other dataplanes are not affected
+		err = resManager.Create(context.Background(), &core_mesh.DataplaneResource{}, core_store.CreateByKey("default", "dp-1", "demo"))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should not exceed a quota when resources are created concurrently", func() {
+		// given
+		wg := sync.WaitGroup{}
+		errs := make(chan error, 10)
+
+		// when
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs <- resManager.Create(context.Background(), &core_mesh.DataplaneResource{}, core_store.CreateByKey("default", fmt.Sprintf("dp-%d", i), "demo"))
+			}(i)
+		}
+		wg.Wait()
+		close(errs)
+
+		// then
+		created := 0
+		for err := range errs {
+			if err == nil {
+				created++
+			} else {
+				Expect(quota.IsQuotaExceeded(err)).To(BeTrue())
+			}
+		}
+		Expect(created).To(Equal(2))
+	})
+
+	It("should not limit resources when quota is not set", func() {
+		// given
+		resManager = quota.NewQuotaManager(core_manager.NewResourceManager(store), store, *quota_config.DefaultQuotaConfig())
+
+		// expect
+		for _, name := range []string{"tp-1", "tp-2", "tp-3"} {
+			err := resManager.Create(context.Background(), &core_mesh.TrafficPermissionResource{}, core_store.CreateByKey("default", name, "demo"))
+			Expect(err).ToNot(HaveOccurred())
+		}
+	})
+})
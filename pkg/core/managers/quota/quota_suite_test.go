@@ -0,0 +1,13 @@
+package quota_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestQuotaManager(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Quota Manager Suite")
+}
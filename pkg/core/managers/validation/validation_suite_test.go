@@ -0,0 +1,13 @@
+package validation_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestValidationManager(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Validation Manager Suite")
+}
@@ -0,0 +1,176 @@
+package validation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	api_server_config "github.com/Kong/kuma/pkg/config/api-server"
+	"github.com/Kong/kuma/pkg/core"
+	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	"github.com/Kong/kuma/pkg/core/resources/model/rest"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+)
+
+var log = core.Log.WithName("validation-webhook")
+
+const defaultWebhookTimeout = 10 * time.Second
+
+type Operation string
+
+const (
+	CreateOperation Operation = "CREATE"
+	UpdateOperation Operation = "UPDATE"
+)
+
+// ValidationRequest is POSTed to a webhook to let it validate a change of a resource.
+type ValidationRequest struct {
+	Operation Operation      `json:"operation"`
+	Resource  *rest.Resource `json:"resource"`
+}
+
+// ValidationResponse is expected from a webhook in reply to ValidationRequest.
+type ValidationResponse struct {
+	Allowed bool   `json:"allowed"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// NewWebhookValidationManager returns a manager that lets external webhooks approve or reject
+// creation and update of resources before they get persisted.
+//
+// Webhooks are called one by one in the order they are configured.
+func NewWebhookValidationManager(delegate core_manager.ResourceManager, webhooks []api_server_config.ValidationWebhookConfig, transport http.RoundTripper) core_manager.ResourceManager {
+	manager := &webhookValidationManager{
+		ResourceManager: delegate,
+	}
+	for _, config := range webhooks {
+		timeout := config.Timeout
+		if timeout == 0 {
+			timeout = defaultWebhookTimeout
+		}
+		manager.webhooks = append(manager.webhooks, webhook{
+			config: config,
+			client: &http.Client{Transport: transport, Timeout: timeout},
+		})
+	}
+	return manager
+}
+
+type webhookValidationManager struct {
+	core_manager.ResourceManager
+	webhooks []webhook
+}
+
+type webhook struct {
+	config api_server_config.ValidationWebhookConfig
+	client *http.Client
+}
+
+func (m *webhookValidationManager) Create(ctx context.Context, resource core_model.Resource, fs ...core_store.CreateOptionsFunc) error {
+	opts := core_store.NewCreateOptions(fs...)
+	restRes := &rest.Resource{
+		Meta: rest.ResourceMeta{
+			Type:   string(resource.GetType()),
+			Name:   opts.Name,
+			Mesh:   opts.Mesh,
+			Labels: opts.Labels,
+		},
+		Spec: resource.GetSpec(),
+	}
+	if err := m.validate(ctx, CreateOperation, restRes); err != nil {
+		return err
+	}
+	return m.ResourceManager.Create(ctx, resource, fs...)
+}
+
+func (m *webhookValidationManager) Update(ctx context.Context, resource core_model.Resource, fs ...core_store.UpdateOptionsFunc) error {
+	opts := core_store.NewUpdateOptions(fs...)
+	labels := resource.GetMeta().GetLabels()
+	if opts.Labels != nil {
+		labels = opts.Labels
+	}
+	restRes := &rest.Resource{
+		Meta: rest.ResourceMeta{
+			Type:   string(resource.GetType()),
+			Name:   resource.GetMeta().GetName(),
+			Mesh:   resource.GetMeta().GetMesh(),
+			Labels: labels,
+		},
+		Spec: resource.GetSpec(),
+	}
+	if err := m.validate(ctx, UpdateOperation, restRes); err != nil {
+		return err
+	}
+	return m.ResourceManager.Update(ctx, resource, fs...)
+}
+
+func (m *webhookValidationManager) validate(ctx context.Context, operation Operation, resource *rest.Resource) error {
+	for _, webhook := range m.webhooks {
+		if !webhook.validates(resource.Meta.Type) {
+			continue
+		}
+		resp, err := webhook.call(ctx, ValidationRequest{Operation: operation, Resource: resource})
+		if err != nil {
+			if webhook.config.IgnoreFailures {
+				log.Error(err, "unable to call validation webhook, accepting the change", "webhook", webhook.config.Name)
+				continue
+			}
+			return errors.Wrapf(err, "could not call validation webhook %q", webhook.config.Name)
+		}
+		if !resp.Allowed {
+			return ValidationRejected(webhook.config.Name, resp.Reason)
+		}
+	}
+	return nil
+}
+
+func (w *webhook) validates(resourceType string) bool {
+	if len(w.config.ResourceTypes) == 0 {
+		return true
+	}
+	for _, typ := range w.config.ResourceTypes {
+		if typ == resourceType {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *webhook) call(ctx context.Context, request ValidationRequest) (*ValidationResponse, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal a validation request")
+	}
+	req, err := http.NewRequest("POST", w.config.Url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := w.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("webhook responded with status code %d", resp.StatusCode)
+	}
+	response := &ValidationResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return nil, errors.Wrap(err, "could not parse a validation response")
+	}
+	return response, nil
+}
+
+func ValidationRejected(webhook string, reason string) error {
+	return errors.Errorf("rejected by validation webhook: webhook %q has rejected the change, reason: %q", webhook, reason)
+}
+
+func IsValidationRejected(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), "rejected by validation webhook:")
+}
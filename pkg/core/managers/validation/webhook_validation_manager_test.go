@@ -0,0 +1,167 @@
+package validation_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	api_server_config "github.com/Kong/kuma/pkg/config/api-server"
+	"github.com/Kong/kuma/pkg/core/managers/validation"
+	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+)
+
+var _ = Describe("Webhook Validation Manager", func() {
+
+	var requests []map[string]interface{}
+	var webhook *httptest.Server
+	var store core_store.ResourceStore
+
+	BeforeEach(func() {
+		requests = nil
+		// rejects TrafficPermissions without the "team" label
+		webhook = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			defer GinkgoRecover()
+			Expect(req.Method).To(Equal("POST"))
+			Expect(req.Header.Get("Content-Type")).To(Equal("application/json"))
+			request := map[string]interface{}{}
+			Expect(json.NewDecoder(req.Body).Decode(&request)).To(Succeed())
+			requests = append(requests, request)
+
+			resource := request["resource"].(map[string]interface{})
+			labels, _ := resource["labels"].(map[string]interface{})
+			_, labelled := labels["team"]
+			response := validation.ValidationResponse{Allowed: labelled}
+			if !labelled {
+				response.Reason = `label "team" is required`
+			}
+			Expect(json.NewEncoder(w).Encode(response)).To(Succeed())
+		}))
+
+		store = memory.NewStore()
+		err := store.Create(context.Background(), &core_mesh.MeshResource{}, core_store.CreateByKey("default", "demo", "demo"))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		webhook.Close()
+	})
+
+	newManager := func(webhooks ...api_server_config.ValidationWebhookConfig) core_manager.ResourceManager {
+		return validation.NewWebhookValidationManager(core_manager.NewResourceManager(store), webhooks, http.DefaultTransport)
+	}
+
+	permission := func() *core_mesh.TrafficPermissionResource {
+		return &core_mesh.TrafficPermissionResource{
+			Spec: mesh_proto.TrafficPermission{
+				Rules: []*mesh_proto.TrafficPermission_Rule{{
+					Sources: []*mesh_proto.TrafficPermission_Rule_Selector{
+						{Match: map[string]string{"service": "web"}},
+					},
+					Destinations: []*mesh_proto.TrafficPermission_Rule_Selector{
+						{Match: map[string]string{"service": "backend"}},
+					},
+				}},
+			},
+		}
+	}
+
+	It("should persist resources approved by webhooks", func() {
+		// given
+		manager := newManager(api_server_config.ValidationWebhookConfig{Name: "team-label", Url: webhook.URL})
+
+		// when
+		err := manager.Create(context.Background(), permission(), core_store.CreateByKey("default", "web-to-backend", "demo"), core_store.CreateWithLabels(map[string]string{"team": "payments"}))
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		// and
+		Expect(requests).To(HaveLen(1))
+		Expect(requests[0]["operation"]).To(Equal("CREATE"))
+		Expect(requests[0]["resource"]).To(HaveKeyWithValue("type", "TrafficPermission"))
+		Expect(requests[0]["resource"]).To(HaveKeyWithValue("name", "web-to-backend"))
+		Expect(requests[0]["resource"]).To(HaveKeyWithValue("mesh", "demo"))
+		Expect(requests[0]["resource"]).To(HaveKey("rules"))
+		// and
+		actual := &core_mesh.TrafficPermissionResource{}
+		Expect(store.Get(context.Background(), actual, core_store.GetByKey("default", "web-to-backend", "demo"))).To(Succeed())
+	})
+
+	It("should not persist resources rejected by webhooks", func() {
+		// given
+		manager := newManager(api_server_config.ValidationWebhookConfig{Name: "team-label", Url: webhook.URL})
+
+		// when
+		err := manager.Create(context.Background(), permission(), core_store.CreateByKey("default", "web-to-backend", "demo"))
+
+		// then
+		Expect(validation.IsValidationRejected(err)).To(BeTrue())
+		Expect(err).To(MatchError(`rejected by validation webhook: webhook "team-label" has rejected the change, reason: "label \"team\" is required"`))
+		// and
+		actual := &core_mesh.TrafficPermissionResource{}
+		err = store.Get(context.Background(), actual, core_store.GetByKey("default", "web-to-backend", "demo"))
+		Expect(core_store.IsResourceNotFound(err)).To(BeTrue())
+	})
+
+	It("should validate updates", func() {
+		// given
+		manager := newManager(api_server_config.ValidationWebhookConfig{Name: "team-label", Url: webhook.URL})
+		err := store.Create(context.Background(), permission(), core_store.CreateByKey("default", "web-to-backend", "demo"), core_store.CreateWithLabels(map[string]string{"team": "payments"}))
+		Expect(err).ToNot(HaveOccurred())
+		actual := &core_mesh.TrafficPermissionResource{}
+		Expect(store.Get(context.Background(), actual, core_store.GetByKey("default", "web-to-backend", "demo"))).To(Succeed())
+
+		// when
+		err = manager.Update(context.Background(), actual, core_store.UpdateWithLabels(map[string]string{}))
+
+		// then
+		Expect(validation.IsValidationRejected(err)).To(BeTrue())
+		Expect(requests).To(HaveLen(1))
+		Expect(requests[0]["operation"]).To(Equal("UPDATE"))
+
+		// when
+		err = manager.Update(context.Background(), actual)
+
+		// then labels of the stored resource are validated
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should only call webhooks of a given resource type", func() {
+		// given
+		manager := newManager(api_server_config.ValidationWebhookConfig{Name: "team-label", Url: webhook.URL, ResourceTypes: []string{"ProxyTemplate"}})
+
+		// when
+		err := manager.Create(context.Background(), permission(), core_store.CreateByKey("default", "web-to-backend", "demo"))
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requests).To(BeEmpty())
+	})
+
+	It("should only ignore failures of a webhook if configured to", func() {
+		// given
+		webhook.Close()
+
+		// when
+		err := newManager(api_server_config.ValidationWebhookConfig{Name: "team-label", Url: webhook.URL}).
+			Create(context.Background(), permission(), core_store.CreateByKey("default", "web-to-backend", "demo"))
+
+		// then
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(HavePrefix(`could not call validation webhook "team-label"`))
+
+		// when
+		err = newManager(api_server_config.ValidationWebhookConfig{Name: "team-label", Url: webhook.URL, IgnoreFailures: true}).
+			Create(context.Background(), permission(), core_store.CreateByKey("default", "web-to-backend", "demo"))
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
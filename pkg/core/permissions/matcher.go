@@ -3,6 +3,8 @@ package permissions
 import (
 	"context"
 	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	"github.com/Kong/kuma/pkg/core"
+	"github.com/Kong/kuma/pkg/core/expiry"
 	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
 	"github.com/Kong/kuma/pkg/core/resources/manager"
 	"github.com/Kong/kuma/pkg/core/resources/store"
@@ -17,7 +19,19 @@ func (m *TrafficPermissionsMatcher) Match(ctx context.Context, dataplane *mesh_c
 	if err := m.ResourceManager.List(ctx, permissions, store.ListByMesh(dataplane.GetMeta().GetMesh())); err != nil {
 		return nil, err
 	}
-	return MatchDataplaneTrafficPermissions(&dataplane.Spec, permissions), nil
+	return MatchDataplaneTrafficPermissions(&dataplane.Spec, ActiveTrafficPermissions(permissions)), nil
+}
+
+// ActiveTrafficPermissions returns TrafficPermissions that have not expired yet.
+func ActiveTrafficPermissions(trafficPermissions *mesh_core.TrafficPermissionResourceList) *mesh_core.TrafficPermissionResourceList {
+	now := core.Now()
+	active := &mesh_core.TrafficPermissionResourceList{}
+	for _, perm := range trafficPermissions.Items {
+		if !expiry.IsExpired(perm, now) {
+			active.Items = append(active.Items, perm)
+		}
+	}
+	return active
 }
 
 func MatchDataplaneTrafficPermissions(dataplane *mesh_proto.Dataplane, trafficPermissions *mesh_core.TrafficPermissionResourceList) *mesh_core.TrafficPermissionResourceList {
@@ -20,6 +20,7 @@ const (
 	Universal  PluginName = "universal"
 	Memory     PluginName = "memory"
 	Postgres   PluginName = "postgres"
+	Consul     PluginName = "consul"
 )
 
 type Registry interface {
@@ -10,6 +10,15 @@ import (
 
 const (
 	DataplaneType model.ResourceType = "Dataplane"
+
+	// KubernetesPodLabel marks Dataplanes that have been generated for Kubernetes Pods.
+	// It tells them apart from Dataplanes of workloads that run outside of Kubernetes, e.g. on VMs,
+	// but belong to the same Mesh.
+	KubernetesPodLabel = "kuma.io/kubernetes-pod"
+
+	// DiscoveredByLabel marks Dataplanes of external services, i.e. Dataplanes that have been created
+	// from instances of services discovered in a service catalog, e.g. in Consul. Its value names the catalog.
+	DiscoveredByLabel = "kuma.io/discovered-by"
 )
 
 var _ model.Resource = &DataplaneResource{}
@@ -28,6 +37,11 @@ func (t *DataplaneResource) GetMeta() model.ResourceMeta {
 func (t *DataplaneResource) SetMeta(m model.ResourceMeta) {
 	t.Meta = m
 }
+
+// IsKubernetesPod returns true if a Dataplane has been generated for a Kubernetes Pod.
+func (t *DataplaneResource) IsKubernetesPod() bool {
+	return t.Meta != nil && t.Meta.GetLabels()[KubernetesPodLabel] == "true"
+}
 func (t *DataplaneResource) GetSpec() model.ResourceSpec {
 	return &t.Spec
 }
@@ -44,7 +58,8 @@ func (t *DataplaneResource) SetSpec(spec model.ResourceSpec) error {
 var _ model.ResourceList = &DataplaneResourceList{}
 
 type DataplaneResourceList struct {
-	Items []*DataplaneResource
+	Items      []*DataplaneResource
+	Pagination model.Pagination
 }
 
 func (l *DataplaneResourceList) GetItems() []model.Resource {
@@ -69,6 +84,10 @@ func (l *DataplaneResourceList) AddItem(r model.Resource) error {
 	}
 }
 
+func (l *DataplaneResourceList) GetPagination() *model.Pagination {
+	return &l.Pagination
+}
+
 func init() {
 	registry.RegisterType(&DataplaneResource{})
 	registry.RegistryListType(&DataplaneResourceList{})
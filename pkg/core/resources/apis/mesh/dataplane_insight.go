@@ -45,7 +45,8 @@ func (t *DataplaneInsightResource) SetSpec(spec model.ResourceSpec) error {
 var _ model.ResourceList = &DataplaneInsightResourceList{}
 
 type DataplaneInsightResourceList struct {
-	Items []*DataplaneInsightResource
+	Items      []*DataplaneInsightResource
+	Pagination model.Pagination
 }
 
 func (l *DataplaneInsightResourceList) GetItems() []model.Resource {
@@ -70,6 +71,10 @@ func (l *DataplaneInsightResourceList) AddItem(r model.Resource) error {
 	}
 }
 
+func (l *DataplaneInsightResourceList) GetPagination() *model.Pagination {
+	return &l.Pagination
+}
+
 func init() {
 	registry.RegisterType(&DataplaneInsightResource{})
 	registry.RegistryListType(&DataplaneInsightResourceList{})
@@ -48,7 +48,8 @@ func (t *DataplaneOverviewResource) SetSpec(spec model.ResourceSpec) error {
 var _ model.ResourceList = &DataplaneOverviewResourceList{}
 
 type DataplaneOverviewResourceList struct {
-	Items []*DataplaneOverviewResource
+	Items      []*DataplaneOverviewResource
+	Pagination model.Pagination
 }
 
 func (l *DataplaneOverviewResourceList) GetItems() []model.Resource {
@@ -74,6 +75,10 @@ func (l *DataplaneOverviewResourceList) AddItem(r model.Resource) error {
 	}
 }
 
+func (l *DataplaneOverviewResourceList) GetPagination() *model.Pagination {
+	return &l.Pagination
+}
+
 func init() {
 	registry.RegisterType(&DataplaneOverviewResource{})
 	registry.RegistryListType(&DataplaneOverviewResourceList{})
@@ -0,0 +1,65 @@
+package mesh
+
+import (
+	"github.com/pkg/errors"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	"github.com/Kong/kuma/pkg/core/resources/model"
+)
+
+var _ model.ResourceValidator = &DataplaneResource{}
+
+// Validate checks that a Dataplane is either deployed next to a service, or is a gateway, or is an ingress.
+func (t *DataplaneResource) Validate() error {
+	networking := t.Spec.GetNetworking()
+	gateway := networking.GetGateway()
+	ingress := networking.GetIngress()
+	switch {
+	case gateway != nil && ingress != nil:
+		return errors.New("networking: gateway and ingress cannot be defined together")
+	case gateway != nil:
+		if len(networking.GetInbound()) > 0 {
+			return errors.New("networking: inbound cannot be defined for a gateway")
+		}
+		if gateway.Tags[mesh_proto.ServiceTag] == "" {
+			return errors.Errorf("networking.gateway: tag %q is mandatory", mesh_proto.ServiceTag)
+		}
+	case ingress != nil:
+		if len(networking.GetInbound()) > 0 {
+			return errors.New("networking: inbound cannot be defined for an ingress")
+		}
+		if _, err := mesh_proto.ParseIngressInterface(ingress.Interface); err != nil {
+			return errors.Wrap(err, "networking.ingress: invalid interface")
+		}
+		if ingress.Tags[mesh_proto.ServiceTag] == "" {
+			return errors.Errorf("networking.ingress: tag %q is mandatory", mesh_proto.ServiceTag)
+		}
+	}
+	for i, inbound := range networking.GetInbound() {
+		if err := validateInboundAddresses(inbound); err != nil {
+			return errors.Wrapf(err, "networking.inbound[%d]", i)
+		}
+	}
+	return nil
+}
+
+// validateInboundAddresses checks addresses that override the IP address of an inbound interface.
+func validateInboundAddresses(inbound *mesh_proto.Dataplane_Networking_Inbound) error {
+	addresses := []struct {
+		field string
+		value string
+	}{
+		{"bindAddress", inbound.BindAddress},
+		{"advertisedAddress", inbound.AdvertisedAddress},
+		{"serviceAddress", inbound.ServiceAddress},
+	}
+	for _, address := range addresses {
+		if address.value == "" {
+			continue
+		}
+		if _, err := mesh_proto.ParseIP(address.value); err != nil {
+			return errors.Wrapf(err, "invalid %s", address.field)
+		}
+	}
+	return nil
+}
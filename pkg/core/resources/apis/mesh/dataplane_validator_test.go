@@ -0,0 +1,119 @@
+package mesh
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	util_proto "github.com/Kong/kuma/pkg/util/proto"
+)
+
+var _ = Describe("Dataplane", func() {
+
+	Describe("Validate()", func() {
+
+		DescribeTable("should accept valid Dataplanes",
+			func(spec string) {
+				// given
+				dataplane := &DataplaneResource{}
+				err := util_proto.FromYAML([]byte(spec), &dataplane.Spec)
+				Expect(err).ToNot(HaveOccurred())
+
+				// when
+				err = dataplane.Validate()
+
+				// then
+				Expect(err).ToNot(HaveOccurred())
+			},
+			Entry("dataplane next to a service", `
+            networking:
+              inbound:
+              - interface: 192.168.0.1:80:8080
+                tags:
+                  service: backend
+`),
+			Entry("dataplane with overridden addresses of an inbound interface", `
+            networking:
+              inbound:
+              - interface: 192.168.0.1:80:8080
+                bindAddress: 0.0.0.0
+                advertisedAddress: 203.0.113.1
+                serviceAddress: 192.168.0.2
+                tags:
+                  service: backend
+`),
+			Entry("gateway", `
+            networking:
+              gateway:
+                tags:
+                  service: edge
+              outbound:
+              - interface: :33033
+                service: backend
+`),
+			Entry("ingress", `
+            networking:
+              ingress:
+                interface: 192.168.0.1:10001
+                tags:
+                  service: ingress
+`),
+		)
+
+		DescribeTable("should reject invalid Dataplanes",
+			func(spec string, expected string) {
+				// given
+				dataplane := &DataplaneResource{}
+				err := util_proto.FromYAML([]byte(spec), &dataplane.Spec)
+				Expect(err).ToNot(HaveOccurred())
+
+				// when
+				err = dataplane.Validate()
+
+				// then
+				Expect(err).To(MatchError(expected))
+			},
+			Entry("gateway with inbound", `
+            networking:
+              gateway:
+                tags:
+                  service: edge
+              inbound:
+              - interface: 192.168.0.1:80:8080
+                tags:
+                  service: backend
+`, "networking: inbound cannot be defined for a gateway"),
+			Entry("gateway without service tag", `
+            networking:
+              gateway:
+                tags:
+                  version: v1
+`, `networking.gateway: tag "service" is mandatory`),
+			Entry("gateway and ingress", `
+            networking:
+              gateway:
+                tags:
+                  service: edge
+              ingress:
+                interface: 192.168.0.1:10001
+                tags:
+                  service: ingress
+`, "networking: gateway and ingress cannot be defined together"),
+			Entry("ingress without IP address", `
+            networking:
+              ingress:
+                interface: :10001
+                tags:
+                  service: ingress
+`, `networking.ingress: invalid interface: invalid format: expected ^(?P<dataplane_ip>(?:(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.){3}(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)|\[[0-9a-fA-F:.]+\]):(?P<dataplane_port>[0-9]{1,5})$, got ":10001"`),
+			Entry("inbound with invalid advertised address", `
+            networking:
+              inbound:
+              - interface: 192.168.0.1:80:8080
+                advertisedAddress: gateway.example.com
+                tags:
+                  service: backend
+`, `networking.inbound[0]: invalid advertisedAddress: "gateway.example.com" is not a valid IP address`),
+		)
+	})
+})
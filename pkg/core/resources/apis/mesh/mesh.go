@@ -43,7 +43,8 @@ func (t *MeshResource) SetSpec(spec model.ResourceSpec) error {
 var _ model.ResourceList = &MeshResourceList{}
 
 type MeshResourceList struct {
-	Items []*MeshResource
+	Items      []*MeshResource
+	Pagination model.Pagination
 }
 
 func (l *MeshResourceList) GetItems() []model.Resource {
@@ -68,6 +69,10 @@ func (l *MeshResourceList) AddItem(r model.Resource) error {
 	}
 }
 
+func (l *MeshResourceList) GetPagination() *model.Pagination {
+	return &l.Pagination
+}
+
 func init() {
 	registry.RegisterType(&MeshResource{})
 	registry.RegistryListType(&MeshResourceList{})
@@ -0,0 +1,118 @@
+package mesh
+
+import (
+	"net"
+	"strings"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/pkg/errors"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	"github.com/Kong/kuma/pkg/core/resources/model"
+)
+
+var _ model.ResourceValidator = &MeshResource{}
+
+// Validate checks that settings of a Mesh can be applied to the config of its Dataplanes.
+func (m *MeshResource) Validate() error {
+	for i, permissive := range m.Spec.GetMtls().GetPermissivePorts() {
+		if permissive.GetService() == "" {
+			return errors.Errorf("mtls.permissivePorts[%d]: service must be non-empty", i)
+		}
+		if permissive.GetPort() < 1 || 65535 < permissive.GetPort() {
+			return errors.Errorf("mtls.permissivePorts[%d]: port number must be in the range [1, 65535] but got %d", i, permissive.GetPort())
+		}
+	}
+	services := map[string]bool{}
+	for i, pool := range m.Spec.GetNetworking().GetOutbound().GetConnectionPools() {
+		if pool.GetService() == "" {
+			return errors.Errorf("networking.outbound.connectionPools[%d]: service must be non-empty", i)
+		}
+		if services[pool.GetService()] {
+			return errors.Errorf("networking.outbound.connectionPools[%d]: service %q already has a connection pool", i, pool.GetService())
+		}
+		services[pool.GetService()] = true
+		if err := validateTimeout(pool.GetTcp().GetIdleTimeout()); err != nil {
+			return errors.Wrapf(err, "networking.outbound.connectionPools[%d]: tcp.idleTimeout", i)
+		}
+		if err := validateTimeout(pool.GetHttp().GetIdleTimeout()); err != nil {
+			return errors.Wrapf(err, "networking.outbound.connectionPools[%d]: http.idleTimeout", i)
+		}
+	}
+	for i, allowed := range m.Spec.GetConstraints().GetAllowedPolicyTypes() {
+		if !IsPolicy(model.ResourceType(allowed)) {
+			return errors.Errorf("constraints.allowedPolicyTypes[%d]: %q is not a type of policy, expected one of %s", i, allowed, policyTypeNames())
+		}
+	}
+	metrics := m.Spec.GetMetrics()
+	if statsd := metrics.GetStatsd(); statsd != nil {
+		if err := validateUdpAddress(statsd.Address); err != nil {
+			return errors.Wrap(err, "metrics.statsd: invalid address")
+		}
+	}
+	if dogstatsd := metrics.GetDogstatsd(); dogstatsd != nil {
+		if err := validateUdpAddress(dogstatsd.Address); err != nil {
+			return errors.Wrap(err, "metrics.dogstatsd: invalid address")
+		}
+	}
+	if datadog := m.Spec.GetTracing().GetDatadog(); datadog != nil {
+		if err := validateTcpAddress(datadog.Address); err != nil {
+			return errors.Wrap(err, "tracing.datadog: invalid address")
+		}
+	}
+	return nil
+}
+
+func policyTypeNames() string {
+	var names []string
+	for _, typ := range PolicyTypes() {
+		names = append(names, string(typ))
+	}
+	return strings.Join(names, ", ")
+}
+
+// validateTimeout checks that a timeout, if set, can be converted into a non-negative duration.
+func validateTimeout(timeout *types.Duration) error {
+	if timeout == nil {
+		return nil
+	}
+	duration, err := types.DurationFromProto(timeout)
+	if err != nil {
+		return err
+	}
+	if duration < 0 {
+		return errors.Errorf("must not be negative but got %v", duration)
+	}
+	return nil
+}
+
+// validateTcpAddress checks that an address is in the HOST:PORT format.
+func validateTcpAddress(text string) error {
+	host, port, err := net.SplitHostPort(text)
+	if err != nil {
+		return err
+	}
+	if host == "" {
+		return errors.New("host must be non-empty")
+	}
+	if _, err := mesh_proto.ParsePort(port); err != nil {
+		return err
+	}
+	return nil
+}
+
+// validateUdpAddress checks that an address is in the IP:PORT format,
+// since Envoy doesn't resolve hostnames of UDP sinks.
+func validateUdpAddress(text string) error {
+	host, port, err := net.SplitHostPort(text)
+	if err != nil {
+		return err
+	}
+	if _, err := mesh_proto.ParseIP(host); err != nil {
+		return err
+	}
+	if _, err := mesh_proto.ParsePort(port); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package mesh
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	util_proto "github.com/Kong/kuma/pkg/util/proto"
+)
+
+var _ = Describe("Mesh", func() {
+
+	Describe("Validate()", func() {
+
+		DescribeTable("should accept valid Meshes",
+			func(spec string) {
+				// given
+				mesh := &MeshResource{}
+				err := util_proto.FromYAML([]byte(spec), &mesh.Spec)
+				Expect(err).ToNot(HaveOccurred())
+
+				// when
+				err = mesh.Validate()
+
+				// then
+				Expect(err).ToNot(HaveOccurred())
+			},
+			Entry("mesh without metrics", `
+            mtls:
+              enabled: true
+`),
+			Entry("mesh with StatsD and DogStatsD sinks", `
+            metrics:
+              statsd:
+                address: 127.0.0.1:8125
+              dogstatsd:
+                address: "[fd00::1]:8125"
+`),
+			Entry("mesh with Datadog tracer", `
+            tracing:
+              datadog:
+                address: datadog-agent:8126
+`),
+			Entry("mesh with permissive ports", `
+            mtls:
+              enabled: true
+              permissivePorts:
+              - service: legacy
+                port: 8080
+`),
+			Entry("mesh with connection pools", `
+            networking:
+              outbound:
+                connectionPools:
+                - service: backend
+                  tcp:
+                    maxConnections: 100
+                    idleTimeout: 0s
+                - service: web
+                  http:
+                    idleTimeout: 30s
+`),
+			Entry("mesh with constraints", `
+            constraints:
+              allowedPolicyTypes:
+              - TrafficPermission
+`),
+		)
+
+		DescribeTable("should reject invalid Meshes",
+			func(spec string, expected string) {
+				// given
+				mesh := &MeshResource{}
+				err := util_proto.FromYAML([]byte(spec), &mesh.Spec)
+				Expect(err).ToNot(HaveOccurred())
+
+				// when
+				err = mesh.Validate()
+
+				// then
+				Expect(err).To(MatchError(expected))
+			},
+			Entry("StatsD sink with a hostname", `
+            metrics:
+              statsd:
+                address: statsd.local:8125
+`, `metrics.statsd: invalid address: "statsd.local" is not a valid IP address`),
+			Entry("DogStatsD sink without a port", `
+            metrics:
+              dogstatsd:
+                address: 127.0.0.1
+`, `metrics.dogstatsd: invalid address: address 127.0.0.1: missing port in address`),
+			Entry("DogStatsD sink with a port out of range", `
+            metrics:
+              dogstatsd:
+                address: 127.0.0.1:70000
+`, `metrics.dogstatsd: invalid address: port number must be in the range [1, 65535] but got 70000`),
+			Entry("Datadog tracer without a port", `
+            tracing:
+              datadog:
+                address: datadog-agent
+`, `tracing.datadog: invalid address: address datadog-agent: missing port in address`),
+			Entry("Datadog tracer without a host", `
+            tracing:
+              datadog:
+                address: :8126
+`, `tracing.datadog: invalid address: host must be non-empty`),
+			Entry("permissive port without a service", `
+            mtls:
+              enabled: true
+              permissivePorts:
+              - port: 8080
+`, `mtls.permissivePorts[0]: service must be non-empty`),
+			Entry("permissive port without a port", `
+            mtls:
+              enabled: true
+              permissivePorts:
+              - service: legacy
+                port: 8080
+              - service: legacy
+`, `mtls.permissivePorts[1]: port number must be in the range [1, 65535] but got 0`),
+			Entry("permissive port with a port out of range", `
+            mtls:
+              enabled: true
+              permissivePorts:
+              - service: legacy
+                port: 70000
+`, `mtls.permissivePorts[0]: port number must be in the range [1, 65535] but got 70000`),
+			Entry("connection pool without a service", `
+            networking:
+              outbound:
+                connectionPools:
+                - tcp:
+                    maxConnections: 100
+`, `networking.outbound.connectionPools[0]: service must be non-empty`),
+			Entry("connection pools of the same service", `
+            networking:
+              outbound:
+                connectionPools:
+                - service: backend
+                - service: web
+                - service: backend
+`, `networking.outbound.connectionPools[2]: service "backend" already has a connection pool`),
+			Entry("connection pool with a negative idle timeout", `
+            networking:
+              outbound:
+                connectionPools:
+                - service: backend
+                  tcp:
+                    idleTimeout: -1s
+`, `networking.outbound.connectionPools[0]: tcp.idleTimeout: must not be negative but got -1s`),
+			Entry("constraints with an unknown policy type", `
+            constraints:
+              allowedPolicyTypes:
+              - TrafficPermission
+              - trafficpermission
+`, `constraints.allowedPolicyTypes[1]: "trafficpermission" is not a type of policy, expected one of ProxyTemplate, SlowStart, TrafficPermission`),
+			Entry("constraints with a type that is not a policy", `
+            constraints:
+              allowedPolicyTypes:
+              - Dataplane
+`, `constraints.allowedPolicyTypes[0]: "Dataplane" is not a type of policy, expected one of ProxyTemplate, SlowStart, TrafficPermission`),
+		)
+	})
+})
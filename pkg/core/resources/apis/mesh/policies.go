@@ -0,0 +1,40 @@
+package mesh
+
+import (
+	"github.com/Kong/kuma/pkg/core/resources/apis/system"
+	"github.com/Kong/kuma/pkg/core/resources/model"
+	"github.com/Kong/kuma/pkg/core/resources/registry"
+)
+
+// nonPolicyTypes are registered types of resources that are not policies.
+var nonPolicyTypes = map[model.ResourceType]bool{
+	MeshType:              true,
+	DataplaneType:         true,
+	DataplaneInsightType:  true,
+	DataplaneOverviewType: true,
+	system.SecretType:     true,
+}
+
+// PolicyTypes returns types of policies, i.e. resources that are applied to Dataplanes of a Mesh,
+// in alphabetical order.
+//
+// A type of resource added to the registry is considered a policy unless stated otherwise.
+func PolicyTypes() []model.ResourceType {
+	var types []model.ResourceType
+	for _, typ := range registry.Global().ListTypes() {
+		if !nonPolicyTypes[typ] {
+			types = append(types, typ)
+		}
+	}
+	return types
+}
+
+// IsPolicy returns true if resources of a given type are policies.
+func IsPolicy(resourceType model.ResourceType) bool {
+	for _, typ := range PolicyTypes() {
+		if typ == resourceType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+package mesh
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kong/kuma/pkg/core/resources/apis/system"
+	"github.com/Kong/kuma/pkg/core/resources/model"
+)
+
+var _ = Describe("PolicyTypes()", func() {
+
+	It("should return registered types of policies", func() {
+		// expect
+		Expect(PolicyTypes()).To(Equal([]model.ResourceType{ProxyTemplateType, SlowStartType, TrafficPermissionType}))
+	})
+
+	DescribeTable("IsPolicy()",
+		func(resourceType model.ResourceType, expected bool) {
+			// expect
+			Expect(IsPolicy(resourceType)).To(Equal(expected))
+		},
+		Entry("TrafficPermission", TrafficPermissionType, true),
+		Entry("ProxyTemplate", ProxyTemplateType, true),
+		Entry("Mesh", MeshType, false),
+		Entry("Dataplane", DataplaneType, false),
+		Entry("DataplaneInsight", DataplaneInsightType, false),
+		Entry("DataplaneOverview", DataplaneOverviewType, false),
+		Entry("Secret", system.SecretType, false),
+		Entry("unknown type", model.ResourceType("Unknown"), false),
+	)
+})
@@ -43,7 +43,8 @@ func (t *ProxyTemplateResource) SetSpec(spec model.ResourceSpec) error {
 var _ model.ResourceList = &ProxyTemplateResourceList{}
 
 type ProxyTemplateResourceList struct {
-	Items []*ProxyTemplateResource
+	Items      []*ProxyTemplateResource
+	Pagination model.Pagination
 }
 
 func (l *ProxyTemplateResourceList) GetItems() []model.Resource {
@@ -68,6 +69,10 @@ func (l *ProxyTemplateResourceList) AddItem(r model.Resource) error {
 	}
 }
 
+func (l *ProxyTemplateResourceList) GetPagination() *model.Pagination {
+	return &l.Pagination
+}
+
 func init() {
 	registry.RegisterType(&ProxyTemplateResource{})
 	registry.RegistryListType(&ProxyTemplateResourceList{})
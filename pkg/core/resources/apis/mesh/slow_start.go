@@ -0,0 +1,79 @@
+package mesh
+
+import (
+	"errors"
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	"github.com/Kong/kuma/pkg/core/resources/model"
+	"github.com/Kong/kuma/pkg/core/resources/registry"
+)
+
+const (
+	SlowStartType model.ResourceType = "SlowStart"
+)
+
+var _ model.Resource = &SlowStartResource{}
+
+type SlowStartResource struct {
+	Meta model.ResourceMeta
+	Spec mesh_proto.SlowStart
+}
+
+func (t *SlowStartResource) GetType() model.ResourceType {
+	return SlowStartType
+}
+func (t *SlowStartResource) GetMeta() model.ResourceMeta {
+	return t.Meta
+}
+func (t *SlowStartResource) SetMeta(m model.ResourceMeta) {
+	t.Meta = m
+}
+func (t *SlowStartResource) GetSpec() model.ResourceSpec {
+	return &t.Spec
+}
+func (t *SlowStartResource) SetSpec(spec model.ResourceSpec) error {
+	status, ok := spec.(*mesh_proto.SlowStart)
+	if !ok {
+		return errors.New("invalid type of spec")
+	} else {
+		t.Spec = *status
+		return nil
+	}
+}
+
+var _ model.ResourceList = &SlowStartResourceList{}
+
+type SlowStartResourceList struct {
+	Items      []*SlowStartResource
+	Pagination model.Pagination
+}
+
+func (l *SlowStartResourceList) GetItems() []model.Resource {
+	res := make([]model.Resource, len(l.Items))
+	for i, elem := range l.Items {
+		res[i] = elem
+	}
+	return res
+}
+func (l *SlowStartResourceList) GetItemType() model.ResourceType {
+	return SlowStartType
+}
+func (l *SlowStartResourceList) NewItem() model.Resource {
+	return &SlowStartResource{}
+}
+func (l *SlowStartResourceList) AddItem(r model.Resource) error {
+	if trr, ok := r.(*SlowStartResource); ok {
+		l.Items = append(l.Items, trr)
+		return nil
+	} else {
+		return model.ErrorInvalidItemType((*SlowStartResource)(nil), r)
+	}
+}
+
+func (l *SlowStartResourceList) GetPagination() *model.Pagination {
+	return &l.Pagination
+}
+
+func init() {
+	registry.RegisterType(&SlowStartResource{})
+	registry.RegistryListType(&SlowStartResourceList{})
+}
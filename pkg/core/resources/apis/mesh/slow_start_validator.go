@@ -0,0 +1,41 @@
+package mesh
+
+import (
+	"reflect"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/pkg/errors"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	"github.com/Kong/kuma/pkg/core/resources/model"
+)
+
+var _ model.ResourceValidator = &SlowStartResource{}
+
+// Validate checks that a SlowStart selects endpoints of services and ramps up traffic to them over a positive window.
+func (s *SlowStartResource) Validate() error {
+	if len(s.Spec.GetDestinations()) == 0 {
+		return errors.New("at least one destination is mandatory")
+	}
+	for i, destination := range s.Spec.GetDestinations() {
+		if destination.GetMatch()[mesh_proto.ServiceTag] == "" {
+			return errors.Errorf("destinations[%d]: tag %q is mandatory", i, mesh_proto.ServiceTag)
+		}
+		for j, other := range s.Spec.GetDestinations()[:i] {
+			if reflect.DeepEqual(destination.GetMatch(), other.GetMatch()) {
+				return errors.Errorf("destinations[%d]: duplicates destinations[%d]", i, j)
+			}
+		}
+	}
+	if s.Spec.GetWindow() == nil {
+		return errors.New("window is mandatory")
+	}
+	window, err := types.DurationFromProto(s.Spec.GetWindow())
+	if err != nil {
+		return errors.Wrap(err, "window")
+	}
+	if window <= 0 {
+		return errors.Errorf("window must be positive but got %v", window)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package mesh
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	util_proto "github.com/Kong/kuma/pkg/util/proto"
+)
+
+var _ = Describe("SlowStart", func() {
+
+	Describe("Validate()", func() {
+
+		DescribeTable("should accept valid SlowStarts",
+			func(spec string) {
+				// given
+				slowStart := &SlowStartResource{}
+				err := util_proto.FromYAML([]byte(spec), &slowStart.Spec)
+				Expect(err).ToNot(HaveOccurred())
+
+				// when
+				err = slowStart.Validate()
+
+				// then
+				Expect(err).ToNot(HaveOccurred())
+			},
+			Entry("slow start of a service", `
+            destinations:
+            - match:
+                service: backend
+            window: 30s
+`),
+			Entry("slow start of a version of a service", `
+            destinations:
+            - match:
+                service: backend
+                version: v2
+            - match:
+                service: web
+            window: 1m
+`),
+		)
+
+		DescribeTable("should reject invalid SlowStarts",
+			func(spec string, expected string) {
+				// given
+				slowStart := &SlowStartResource{}
+				err := util_proto.FromYAML([]byte(spec), &slowStart.Spec)
+				Expect(err).ToNot(HaveOccurred())
+
+				// when
+				err = slowStart.Validate()
+
+				// then
+				Expect(err).To(MatchError(expected))
+			},
+			Entry("without destinations", `
+            window: 30s
+`, "at least one destination is mandatory"),
+			Entry("destination without service tag", `
+            destinations:
+            - match:
+                version: v2
+            window: 30s
+`, `destinations[0]: tag "service" is mandatory`),
+			Entry("duplicate destinations", `
+            destinations:
+            - match:
+                service: backend
+            - match:
+                service: backend
+            window: 30s
+`, "destinations[1]: duplicates destinations[0]"),
+			Entry("without window", `
+            destinations:
+            - match:
+                service: backend
+`, "window is mandatory"),
+			Entry("zero window", `
+            destinations:
+            - match:
+                service: backend
+            window: 0s
+`, "window must be positive but got 0s"),
+			Entry("negative window", `
+            destinations:
+            - match:
+                service: backend
+            window: -5s
+`, "window must be positive but got -5s"),
+		)
+	})
+})
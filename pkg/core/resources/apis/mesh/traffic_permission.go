@@ -43,7 +43,8 @@ func (t *TrafficPermissionResource) SetSpec(spec model.ResourceSpec) error {
 var _ model.ResourceList = &TrafficPermissionResourceList{}
 
 type TrafficPermissionResourceList struct {
-	Items []*TrafficPermissionResource
+	Items      []*TrafficPermissionResource
+	Pagination model.Pagination
 }
 
 func (l *TrafficPermissionResourceList) GetItems() []model.Resource {
@@ -68,6 +69,10 @@ func (l *TrafficPermissionResourceList) AddItem(r model.Resource) error {
 	}
 }
 
+func (l *TrafficPermissionResourceList) GetPagination() *model.Pagination {
+	return &l.Pagination
+}
+
 func init() {
 	registry.RegisterType(&TrafficPermissionResource{})
 	registry.RegistryListType(&TrafficPermissionResourceList{})
@@ -0,0 +1,34 @@
+package mesh
+
+import (
+	"github.com/pkg/errors"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	"github.com/Kong/kuma/pkg/core/resources/model"
+)
+
+var _ model.ResourceValidator = &TrafficPermissionResource{}
+
+// Validate checks that every rule of a TrafficPermission can be translated into an Envoy RBAC policy,
+// which identifies a source by the certificate of its service.
+func (t *TrafficPermissionResource) Validate() error {
+	for i, rule := range t.Spec.GetRules() {
+		if len(rule.GetSources()) == 0 {
+			return errors.Errorf("rules[%d]: at least one source is mandatory", i)
+		}
+		if len(rule.GetDestinations()) == 0 {
+			return errors.Errorf("rules[%d]: at least one destination is mandatory", i)
+		}
+		for j, source := range rule.GetSources() {
+			if source.GetMatch()[mesh_proto.ServiceTag] == "" {
+				return errors.Errorf("rules[%d].sources[%d]: tag %q is mandatory", i, j, mesh_proto.ServiceTag)
+			}
+		}
+		for j, destination := range rule.GetDestinations() {
+			if len(destination.GetMatch()) == 0 {
+				return errors.Errorf("rules[%d].destinations[%d]: at least one tag is mandatory", i, j)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package mesh
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	util_proto "github.com/Kong/kuma/pkg/util/proto"
+)
+
+var _ = Describe("TrafficPermission", func() {
+
+	Describe("Validate()", func() {
+
+		DescribeTable("should accept valid TrafficPermissions",
+			func(spec string) {
+				// given
+				permission := &TrafficPermissionResource{}
+				err := util_proto.FromYAML([]byte(spec), &permission.Spec)
+				Expect(err).ToNot(HaveOccurred())
+
+				// when
+				err = permission.Validate()
+
+				// then
+				Expect(err).ToNot(HaveOccurred())
+			},
+			Entry("permission between services", `
+            rules:
+            - sources:
+              - match:
+                  service: web
+              destinations:
+              - match:
+                  service: backend
+                  version: v1
+`),
+			Entry("permission from any service", `
+            rules:
+            - sources:
+              - match:
+                  service: '*'
+              destinations:
+              - match:
+                  service: '*'
+`),
+		)
+
+		DescribeTable("should reject invalid TrafficPermissions",
+			func(spec string, expected string) {
+				// given
+				permission := &TrafficPermissionResource{}
+				err := util_proto.FromYAML([]byte(spec), &permission.Spec)
+				Expect(err).ToNot(HaveOccurred())
+
+				// when
+				err = permission.Validate()
+
+				// then
+				Expect(err).To(MatchError(expected))
+			},
+			Entry("rule without sources", `
+            rules:
+            - destinations:
+              - match:
+                  service: backend
+`, "rules[0]: at least one source is mandatory"),
+			Entry("rule without destinations", `
+            rules:
+            - sources:
+              - match:
+                  service: web
+`, "rules[0]: at least one destination is mandatory"),
+			Entry("source without service tag", `
+            rules:
+            - sources:
+              - match:
+                  version: v1
+              destinations:
+              - match:
+                  service: backend
+`, `rules[0].sources[0]: tag "service" is mandatory`),
+			Entry("destination without tags", `
+            rules:
+            - sources:
+              - match:
+                  service: web
+              destinations:
+              - match: {}
+`, "rules[0].destinations[0]: at least one tag is mandatory"),
+		)
+	})
+})
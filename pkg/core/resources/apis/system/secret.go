@@ -45,7 +45,8 @@ func (t *SecretResource) SetSpec(spec model.ResourceSpec) error {
 var _ model.ResourceList = &SecretResourceList{}
 
 type SecretResourceList struct {
-	Items []*SecretResource
+	Items      []*SecretResource
+	Pagination model.Pagination
 }
 
 func (l *SecretResourceList) GetItems() []model.Resource {
@@ -70,6 +71,10 @@ func (l *SecretResourceList) AddItem(r model.Resource) error {
 	}
 }
 
+func (l *SecretResourceList) GetPagination() *model.Pagination {
+	return &l.Pagination
+}
+
 func init() {
 	registry.RegisterType(&SecretResource{})
 	registry.RegistryListType(&SecretResourceList{})
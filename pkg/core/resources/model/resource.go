@@ -26,6 +26,12 @@ type Resource interface {
 	SetSpec(ResourceSpec) error
 }
 
+// ResourceValidator is implemented by resources that can tell whether their spec is consistent
+// beyond what can be expressed by the schema.
+type ResourceValidator interface {
+	Validate() error
+}
+
 type ResourceType string
 
 type ResourceMeta interface {
@@ -33,6 +39,8 @@ type ResourceMeta interface {
 	GetNamespace() string
 	GetVersion() string
 	GetMesh() string
+	// GetLabels returns arbitrary key-value pairs attached to a resource, e.g. its owner or cost center.
+	GetLabels() map[string]string
 }
 
 func MetaToResourceKey(meta ResourceMeta) ResourceKey {
@@ -56,6 +64,15 @@ type ResourceList interface {
 	GetItems() []Resource
 	NewItem() Resource
 	AddItem(Resource) error
+	GetPagination() *Pagination
+}
+
+// Pagination describes which part of all the resources that match a filter a list contains.
+type Pagination struct {
+	// Total is a number of resources that match the filter, on all pages.
+	Total uint32
+	// NextOffset is an offset of the next page, empty if there are no more pages.
+	NextOffset string
 }
 
 func ErrorInvalidItemType(expected, actual interface{}) error {
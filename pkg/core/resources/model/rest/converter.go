@@ -16,9 +16,10 @@ func (c *from) Resource(r model.Resource) *Resource {
 	}
 	return &Resource{
 		Meta: ResourceMeta{
-			Mesh: meshName,
-			Type: string(r.GetType()),
-			Name: r.GetMeta().GetName(),
+			Mesh:   meshName,
+			Type:   string(r.GetType()),
+			Name:   r.GetMeta().GetName(),
+			Labels: r.GetMeta().GetLabels(),
 		},
 		Spec: r.GetSpec(),
 	}
@@ -30,6 +31,7 @@ func (c *from) ResourceList(rs model.ResourceList) *ResourceList {
 		items[i] = c.Resource(r)
 	}
 	return &ResourceList{
+		Total: rs.GetPagination().Total,
 		Items: items,
 	}
 }
@@ -0,0 +1,5 @@
+package rest
+
+// ErrorCodeHeader is a name of the header of an error response that carries a machine-readable code of the error,
+// e.g. `X-Kuma-Error-Code: MESH_NOT_FOUND`. See pkg/core/errors for all codes.
+const ErrorCodeHeader = "X-Kuma-Error-Code"
@@ -0,0 +1,38 @@
+package rest
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// LabelsQueryParam is a name of the query parameter that limits a list of resources to the ones with given labels,
+// e.g. `?labels=team=payments,env=prod`.
+const LabelsQueryParam = "labels"
+
+// FormatLabels turns labels into a value of LabelsQueryParam.
+func FormatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for key, value := range labels {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// ParseLabels turns a value of LabelsQueryParam into labels.
+func ParseLabels(text string) (map[string]string, error) {
+	if text == "" {
+		return nil, nil
+	}
+	labels := map[string]string{}
+	for _, pair := range strings.Split(text, ",") {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, errors.Errorf("label %q must have format key=value", pair)
+		}
+		labels[parts[0]] = parts[1]
+	}
+	return labels, nil
+}
@@ -0,0 +1,37 @@
+package rest_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kong/kuma/pkg/core/resources/model/rest"
+)
+
+var _ = Describe("Labels", func() {
+
+	It("should be possible to parse formatted labels", func() {
+		// given
+		labels := map[string]string{"team": "payments", "env": "prod"}
+
+		// when
+		text := rest.FormatLabels(labels)
+
+		// then
+		Expect(text).To(Equal("env=prod,team=payments"))
+
+		// when
+		parsed, err := rest.ParseLabels(text)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(parsed).To(Equal(labels))
+	})
+
+	It("should reject malformed labels", func() {
+		// when
+		_, err := rest.ParseLabels("team=payments,env")
+
+		// then
+		Expect(err).To(MatchError(`label "env" must have format key=value`))
+	})
+})
@@ -0,0 +1,50 @@
+package rest
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// NamePrefixQueryParam is a name of the query parameter that limits a list of resources
+	// to the ones with names that start with a given prefix, e.g. `?namePrefix=web-`.
+	NamePrefixQueryParam = "namePrefix"
+	// SizeQueryParam is a name of the query parameter that limits the number of resources on a page, e.g. `?size=100`.
+	SizeQueryParam = "size"
+	// OffsetQueryParam is a name of the query parameter that selects a page, e.g. `?offset=100`.
+	// Offsets are opaque, they should be taken from the link to the next page.
+	OffsetQueryParam = "offset"
+)
+
+// ParseSize turns a value of SizeQueryParam into a page size, 0 if there is no limit.
+func ParseSize(text string) (int, error) {
+	if text == "" {
+		return 0, nil
+	}
+	size, err := strconv.Atoi(text)
+	if err != nil || size < 0 {
+		return 0, errors.Errorf("page size %q must be a non-negative integer", text)
+	}
+	return size, nil
+}
+
+// NextPage returns a link to the next page of a list of resources, given a link to the current one.
+func NextPage(current *url.URL, size int, offset string) string {
+	next := *current
+	query := next.Query()
+	query.Set(SizeQueryParam, strconv.Itoa(size))
+	query.Set(OffsetQueryParam, offset)
+	next.RawQuery = query.Encode()
+	return next.String()
+}
+
+// OffsetOf returns the offset of a page given a link to it.
+func OffsetOf(page string) (string, error) {
+	link, err := url.Parse(page)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid link to a page %q", page)
+	}
+	return link.Query().Get(OffsetQueryParam), nil
+}
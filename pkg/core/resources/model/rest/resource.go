@@ -11,9 +11,10 @@ import (
 )
 
 type ResourceMeta struct {
-	Type string `json:"type"`
-	Name string `json:"name"`
-	Mesh string `json:"mesh,omitempty"`
+	Type   string            `json:"type"`
+	Name   string            `json:"name"`
+	Mesh   string            `json:"mesh,omitempty"`
+	Labels map[string]string `json:"labels,omitempty"`
 }
 
 type Resource struct {
@@ -22,7 +23,11 @@ type Resource struct {
 }
 
 type ResourceList struct {
+	// Total is a number of resources that match the filter, on all pages.
+	Total uint32      `json:"total"`
 	Items []*Resource `json:"items"`
+	// Next is a link to the next page, nil if there are no more pages.
+	Next *string `json:"next,omitempty"`
 }
 
 var _ json.Marshaler = &Resource{}
@@ -78,12 +83,16 @@ func (rec *ResourceListReceiver) UnmarshalJSON(data []byte) error {
 		return errors.Errorf("NewResource must not be nil")
 	}
 	type List struct {
+		Total uint32             `json:"total"`
 		Items []*json.RawMessage `json:"items"`
+		Next  *string            `json:"next"`
 	}
 	list := List{}
 	if err := json.Unmarshal(data, &list); err != nil {
 		return err
 	}
+	rec.ResourceList.Total = list.Total
+	rec.ResourceList.Next = list.Next
 	rec.ResourceList.Items = make([]*Resource, len(list.Items))
 	for i, li := range list.Items {
 		b, err := json.Marshal(li)
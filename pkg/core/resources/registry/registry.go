@@ -4,6 +4,7 @@ import (
 	"github.com/Kong/kuma/pkg/core/resources/model"
 	"github.com/pkg/errors"
 	"reflect"
+	"sort"
 )
 
 type TypeRegistry interface {
@@ -12,6 +13,9 @@ type TypeRegistry interface {
 
 	NewObject(model.ResourceType) (model.Resource, error)
 	NewList(model.ResourceType) (model.ResourceList, error)
+
+	// ListTypes returns all registered types in alphabetical order.
+	ListTypes() []model.ResourceType
 }
 
 func NewTypeRegistry() TypeRegistry {
@@ -59,3 +63,14 @@ func (t *typeRegistry) NewList(resType model.ResourceType) (model.ResourceList,
 	}
 	return reflect.New(typ).Interface().(model.ResourceList), nil
 }
+
+func (t *typeRegistry) ListTypes() []model.ResourceType {
+	var types []model.ResourceType
+	for typ := range t.objectTypes {
+		types = append(types, typ)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		return types[i] < types[j]
+	})
+	return types
+}
@@ -0,0 +1,115 @@
+package store
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Kong/kuma/pkg/core"
+	"github.com/Kong/kuma/pkg/core/resources/model"
+)
+
+var eventsLog = core.Log.WithName("resource-store-events")
+
+type EventType string
+
+const (
+	CreatedEvent EventType = "Created"
+	UpdatedEvent EventType = "Updated"
+	DeletedEvent EventType = "Deleted"
+	// ResyncEvent means that changes of resources of a given type might have been missed,
+	// e.g. while a connection to the DB was down, so subscribers should resync all of them.
+	ResyncEvent EventType = "Resync"
+)
+
+// Event notifies about a change of a resource.
+// It only identifies the resource, subscribers are expected to Get() it if they need its content.
+//
+// A ResyncEvent only carries a type of resources.
+type Event struct {
+	Type         EventType
+	ResourceType model.ResourceType
+	Namespace    string
+	Name         string
+	Mesh         string
+}
+
+// ResourceWatcher is implemented by ResourceStores that can notify about changes of resources.
+type ResourceWatcher interface {
+	// Watch returns a channel of events about resources of a given type in a given namespace,
+	// or in all namespaces if the namespace is empty. The channel is closed once ctx is done.
+	//
+	// Events are delivered on a best-effort basis, i.e. they are dropped if a subscriber falls behind,
+	// that is why subscribers should still resync periodically.
+	Watch(ctx context.Context, resourceType model.ResourceType, namespace string) (<-chan Event, error)
+}
+
+// eventBufferSize is a number of events that can be waiting for a subscriber before new ones are dropped.
+const eventBufferSize = 128
+
+// EventBroadcaster delivers events to subscribers, which is the common part of ResourceWatchers.
+type EventBroadcaster struct {
+	mu          sync.Mutex
+	subscribers map[*subscriber]struct{}
+}
+
+type subscriber struct {
+	resourceType model.ResourceType
+	namespace    string
+	events       chan Event
+}
+
+func NewEventBroadcaster() *EventBroadcaster {
+	return &EventBroadcaster{
+		subscribers: map[*subscriber]struct{}{},
+	}
+}
+
+// Subscribe returns a channel of events about resources of a given type in a given namespace,
+// or in all namespaces if the namespace is empty. The channel is closed once ctx is done.
+func (b *EventBroadcaster) Subscribe(ctx context.Context, resourceType model.ResourceType, namespace string) <-chan Event {
+	sub := &subscriber{
+		resourceType: resourceType,
+		namespace:    namespace,
+		events:       make(chan Event, eventBufferSize),
+	}
+	b.mu.Lock()
+	b.subscribers[sub] = struct{}{}
+	b.mu.Unlock()
+	go func() {
+		<-ctx.Done()
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.subscribers, sub)
+		close(sub.events)
+	}()
+	return sub.events
+}
+
+// Send delivers an event to all subscribers that are interested in it. It never blocks.
+func (b *EventBroadcaster) Send(event Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for sub := range b.subscribers {
+		if sub.resourceType != event.ResourceType || (sub.namespace != "" && sub.namespace != event.Namespace) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			eventsLog.Info("subscriber falls behind, dropping an event", "event", event)
+		}
+	}
+}
+
+// SendResync tells all subscribers that changes of resources they are interested in might have been missed.
+func (b *EventBroadcaster) SendResync() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for sub := range b.subscribers {
+		select {
+		case sub.events <- Event{Type: ResyncEvent, ResourceType: sub.resourceType}:
+		default:
+			eventsLog.Info("subscriber falls behind, dropping an event", "event", ResyncEvent)
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package store
 
 import (
+	"strings"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
 	"github.com/Kong/kuma/pkg/core/resources/model"
 )
 
@@ -8,6 +11,7 @@ type CreateOptions struct {
 	Namespace string
 	Name      string
 	Mesh      string
+	Labels    map[string]string
 }
 
 type CreateOptionsFunc func(*CreateOptions)
@@ -32,7 +36,15 @@ func CreateByKey(ns, name, mesh string) CreateOptionsFunc {
 	}
 }
 
+func CreateWithLabels(labels map[string]string) CreateOptionsFunc {
+	return func(opts *CreateOptions) {
+		opts.Labels = labels
+	}
+}
+
 type UpdateOptions struct {
+	// Labels replace labels of a resource if not nil, otherwise the labels are kept as they are.
+	Labels map[string]string
 }
 
 type UpdateOptionsFunc func(*UpdateOptions)
@@ -45,10 +57,21 @@ func NewUpdateOptions(fs ...UpdateOptionsFunc) *UpdateOptions {
 	return opts
 }
 
+func UpdateWithLabels(labels map[string]string) UpdateOptionsFunc {
+	return func(opts *UpdateOptions) {
+		opts.Labels = labels
+	}
+}
+
 type DeleteOptions struct {
 	Namespace string
 	Name      string
 	Mesh      string
+	// Force makes deletion proceed even if there are other resources that depend on a given one,
+	// in which case the dependent resources are deleted too.
+	Force bool
+	// Version makes deletion fail with a conflict if the resource has been modified since it was read at a given version.
+	Version string
 }
 
 type DeleteOptionsFunc func(*DeleteOptions)
@@ -73,6 +96,18 @@ func DeleteByKey(ns, name, mesh string) DeleteOptionsFunc {
 	}
 }
 
+func DeleteForce() DeleteOptionsFunc {
+	return func(opts *DeleteOptions) {
+		opts.Force = true
+	}
+}
+
+func DeleteWithVersion(version string) DeleteOptionsFunc {
+	return func(opts *DeleteOptions) {
+		opts.Version = version
+	}
+}
+
 type GetOptions struct {
 	Namespace string
 	Name      string
@@ -104,6 +139,17 @@ func GetByKey(ns, name, mesh string) GetOptionsFunc {
 type ListOptions struct {
 	Namespace string
 	Mesh      string
+	// Labels limit the list to resources that have all of these labels.
+	Labels map[string]string
+	// NamePrefix limits the list to resources with names that start with it.
+	NamePrefix string
+	// Tags limit the list to resources that have all of these tags, e.g. Dataplanes.
+	// Resources that have no tags never match.
+	Tags map[string]string
+	// PageSize limits the list to a given number of resources, 0 means no limit.
+	PageSize int
+	// PageOffset is an offset of the page, i.e. Pagination.NextOffset of the previous page. Empty for the first page.
+	PageOffset string
 }
 
 type ListOptionsFunc func(*ListOptions)
@@ -127,3 +173,57 @@ func ListByMesh(mesh string) ListOptionsFunc {
 		opts.Mesh = mesh
 	}
 }
+
+func ListByLabels(labels map[string]string) ListOptionsFunc {
+	return func(opts *ListOptions) {
+		opts.Labels = labels
+	}
+}
+
+func ListByNamePrefix(prefix string) ListOptionsFunc {
+	return func(opts *ListOptions) {
+		opts.NamePrefix = prefix
+	}
+}
+
+func ListByTags(tags map[string]string) ListOptionsFunc {
+	return func(opts *ListOptions) {
+		opts.Tags = tags
+	}
+}
+
+func ListByPage(size int, offset string) ListOptionsFunc {
+	return func(opts *ListOptions) {
+		opts.PageSize = size
+		opts.PageOffset = offset
+	}
+}
+
+// MatchesLabels returns true if given labels satisfy the label filter of the list.
+func (opts *ListOptions) MatchesLabels(labels map[string]string) bool {
+	for key, value := range opts.Labels {
+		if actual, ok := labels[key]; !ok || actual != value {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchesName returns true if a given name satisfies the name filter of the list.
+func (opts *ListOptions) MatchesName(name string) bool {
+	return strings.HasPrefix(name, opts.NamePrefix)
+}
+
+// taggedSpec is implemented by specs of resources that have tags, e.g. Dataplanes.
+type taggedSpec interface {
+	MatchTags(selector mesh_proto.TagSelector) bool
+}
+
+// MatchesTags returns true if a given spec satisfies the tag filter of the list.
+func (opts *ListOptions) MatchesTags(spec model.ResourceSpec) bool {
+	if len(opts.Tags) == 0 {
+		return true
+	}
+	tagged, ok := spec.(taggedSpec)
+	return ok && tagged.MatchTags(opts.Tags)
+}
@@ -0,0 +1,68 @@
+package store
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Kong/kuma/pkg/core/resources/model"
+)
+
+func ErrorInvalidOffset(offset string) error {
+	return fmt.Errorf("Invalid offset: %q", offset)
+}
+
+func IsInvalidOffset(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), "Invalid offset")
+}
+
+// Paginator picks resources of a requested page out of all the resources that match a filter of a list.
+//
+// Resources must be visited in the same order on every call, otherwise pages would overlap.
+type Paginator struct {
+	Offset int
+	Size   int
+
+	matched int
+}
+
+func NewPaginator(opts *ListOptions) (*Paginator, error) {
+	if opts.PageSize < 0 {
+		return nil, fmt.Errorf("page size must not be negative")
+	}
+	offset := 0
+	if opts.PageOffset != "" {
+		var err error
+		offset, err = strconv.Atoi(opts.PageOffset)
+		if err != nil || offset < 0 {
+			return nil, ErrorInvalidOffset(opts.PageOffset)
+		}
+	}
+	return &Paginator{
+		Offset: offset,
+		Size:   opts.PageSize,
+	}, nil
+}
+
+// Next returns true if the next resource that matches the filter belongs to the page.
+func (p *Paginator) Next() bool {
+	index := p.matched
+	p.matched++
+	return index >= p.Offset && (p.Size == 0 || index < p.Offset+p.Size)
+}
+
+// Pagination describes the page assuming that every resource that matches the filter has been visited with Next().
+func (p *Paginator) Pagination() model.Pagination {
+	return p.PaginationOf(p.matched)
+}
+
+// PaginationOf describes the page given a total number of resources that match the filter.
+func (p *Paginator) PaginationOf(total int) model.Pagination {
+	pagination := model.Pagination{
+		Total: uint32(total),
+	}
+	if p.Size > 0 && p.Offset+p.Size < total {
+		pagination.NextOffset = strconv.Itoa(p.Offset + p.Size)
+	}
+	return pagination
+}
@@ -137,3 +137,11 @@ func ErrorResourceConflict(rt model.ResourceType, namespace, name, mesh string)
 func IsResourceNotFound(err error) bool {
 	return err != nil && strings.HasPrefix(err.Error(), "Resource not found")
 }
+
+func IsResourceAlreadyExists(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), "Resource already exists")
+}
+
+func IsResourceConflict(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), "Resource conflict")
+}
@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"fmt"
 	sample_proto "github.com/Kong/kuma/pkg/test/apis/sample/v1alpha1"
 	sample_model "github.com/Kong/kuma/pkg/test/resources/apis/sample"
 	. "github.com/onsi/ginkgo"
@@ -15,10 +16,13 @@ func ExecuteStoreTests(
 	var namespace string
 	const mesh = "default-mesh"
 	var s ClosableResourceStore
+	var watcher ResourceWatcher
 
 	BeforeEach(func() {
 		namespace = string(uuid.New())
-		s = NewStrictResourceStore(createStore())
+		raw := createStore()
+		watcher, _ = raw.(ResourceWatcher)
+		s = NewStrictResourceStore(raw)
 	})
 
 	AfterEach(func() {
@@ -72,6 +76,31 @@ func ExecuteStoreTests(
 			// then
 			Expect(err).To(MatchError(ErrorResourceAlreadyExists(resource.GetType(), namespace, name, mesh)))
 		})
+
+		It("should create a new resource with labels", func() {
+			// given
+			name := "resource-with-labels"
+			res := sample_model.TrafficRouteResource{
+				Spec: sample_proto.TrafficRoute{
+					Path: "demo",
+				},
+			}
+
+			// when
+			err := s.Create(context.Background(), &res, CreateByKey(namespace, name, mesh), CreateWithLabels(map[string]string{"team": "payments"}))
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.Meta.GetLabels()).To(Equal(map[string]string{"team": "payments"}))
+
+			// when retrieve created object
+			resource := sample_model.TrafficRouteResource{}
+			err = s.Get(context.Background(), &resource, GetByKey(namespace, name, mesh))
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resource.Meta.GetLabels()).To(Equal(map[string]string{"team": "payments"}))
+		})
 	})
 
 	Describe("Update()", func() {
@@ -120,6 +149,100 @@ func ExecuteStoreTests(
 			Expect(res.Spec.Path).To(Equal("new-path"))
 		})
 
+		It("should update a resource with a version returned by a previous update", func() {
+			// given a resources in storage
+			name := "to-be-updated-twice"
+			resource := createResource(name)
+			version := resource.GetMeta().GetVersion()
+
+			// when
+			resource.Spec.Path = "new-path"
+			err := s.Update(context.Background(), resource)
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resource.GetMeta().GetVersion()).ToNot(Equal(version))
+
+			// when
+			resource.Spec.Path = "newer-path"
+			err = s.Update(context.Background(), resource)
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+
+			// when retrieve the resource
+			res := sample_model.TrafficRouteResource{}
+			err = s.Get(context.Background(), &res, GetByKey(namespace, name, mesh))
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.Spec.Path).To(Equal("newer-path"))
+			Expect(res.GetMeta().GetVersion()).To(Equal(resource.GetMeta().GetVersion()))
+		})
+
+		It("should not update a resource of a stale version", func() {
+			// given a resources in storage
+			name := "to-be-updated-concurrently"
+			createResource(name)
+
+			// and two copies of it
+			first := sample_model.TrafficRouteResource{}
+			Expect(s.Get(context.Background(), &first, GetByKey(namespace, name, mesh))).To(Succeed())
+			second := sample_model.TrafficRouteResource{}
+			Expect(s.Get(context.Background(), &second, GetByKey(namespace, name, mesh))).To(Succeed())
+
+			// when
+			first.Spec.Path = "first-path"
+			err := s.Update(context.Background(), &first)
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+
+			// when
+			second.Spec.Path = "second-path"
+			err = s.Update(context.Background(), &second)
+
+			// then
+			Expect(err).To(MatchError(ErrorResourceConflict(second.GetType(), namespace, name, mesh)))
+		})
+
+		It("should keep labels unless they are replaced", func() {
+			// given a resources in storage
+			name := "to-be-relabeled"
+			resource := sample_model.TrafficRouteResource{}
+			err := s.Create(context.Background(), &resource, CreateByKey(namespace, name, mesh), CreateWithLabels(map[string]string{"team": "payments"}))
+			Expect(err).ToNot(HaveOccurred())
+
+			// when
+			resource.Spec.Path = "new-path"
+			err = s.Update(context.Background(), &resource)
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+
+			// when retrieve the resource
+			res := sample_model.TrafficRouteResource{}
+			err = s.Get(context.Background(), &res, GetByKey(namespace, name, mesh))
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.Meta.GetLabels()).To(Equal(map[string]string{"team": "payments"}))
+
+			// when
+			err = s.Update(context.Background(), &res, UpdateWithLabels(map[string]string{"team": "billing"}))
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+
+			// when retrieve the resource
+			res = sample_model.TrafficRouteResource{}
+			err = s.Get(context.Background(), &res, GetByKey(namespace, name, mesh))
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.Meta.GetLabels()).To(Equal(map[string]string{"team": "billing"}))
+		})
+
 		//todo(jakubdyszkiewicz) write tests for optimistic locking
 	})
 
@@ -174,6 +297,29 @@ func ExecuteStoreTests(
 			// then resource cannot be found
 			Expect(err).To(Equal(ErrorResourceNotFound(resource.GetType(), namespace, name, mesh)))
 		})
+
+		It("should not delete a resource of a stale version", func() {
+			// given a resources in storage
+			name := "to-be-deleted-concurrently"
+			created := createResource(name)
+			version := created.GetMeta().GetVersion()
+
+			// and it has been modified since
+			created.Spec.Path = "new-path"
+			Expect(s.Update(context.Background(), created)).To(Succeed())
+
+			// when
+			err := s.Delete(context.TODO(), &sample_model.TrafficRouteResource{}, DeleteByKey(namespace, name, mesh), DeleteWithVersion(version))
+
+			// then
+			Expect(err).To(MatchError(ErrorResourceConflict(sample_model.TrafficRouteType, namespace, name, mesh)))
+
+			// when
+			err = s.Delete(context.TODO(), &sample_model.TrafficRouteResource{}, DeleteByKey(namespace, name, mesh), DeleteWithVersion(created.GetMeta().GetVersion()))
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+		})
 	})
 
 	Describe("Get()", func() {
@@ -262,6 +408,102 @@ func ExecuteStoreTests(
 			Expect(list.Items[1].Spec.Path).To(Equal("demo"))
 		})
 
+		It("should return a list of resources with given labels", func() {
+			// given resources with different labels
+			for name, team := range map[string]string{"res-1": "payments", "res-2": "billing"} {
+				err := s.Create(context.Background(), &sample_model.TrafficRouteResource{}, CreateByKey(namespace, name, mesh), CreateWithLabels(map[string]string{"team": team}))
+				Expect(err).ToNot(HaveOccurred())
+			}
+			createResource("res-3")
+
+			list := sample_model.TrafficRouteResourceList{}
+
+			// when
+			err := s.List(context.Background(), &list, ListByNamespace(namespace), ListByLabels(map[string]string{"team": "payments"}))
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			// and
+			Expect(list.Items).To(HaveLen(1))
+			Expect(list.Items[0].Meta.GetName()).To(Equal("res-1"))
+		})
+
+		It("should return a list of resources with names that start with a given prefix", func() {
+			// given
+			createResource("web-1")
+			createResource("web-2")
+			createResource("backend-1")
+
+			list := sample_model.TrafficRouteResourceList{}
+
+			// when
+			err := s.List(context.Background(), &list, ListByNamespace(namespace), ListByNamePrefix("web-"))
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			// and
+			Expect(list.Items).To(HaveLen(2))
+			names := []string{list.Items[0].Meta.GetName(), list.Items[1].Meta.GetName()}
+			Expect(names).To(ConsistOf("web-1", "web-2"))
+		})
+
+		It("should not return resources without tags when filtering by tags", func() {
+			// given
+			createResource("res-1")
+
+			list := sample_model.TrafficRouteResourceList{}
+
+			// when
+			err := s.List(context.Background(), &list, ListByNamespace(namespace), ListByTags(map[string]string{"service": "web"}))
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			// and
+			Expect(list.Items).To(HaveLen(0))
+		})
+
+		It("should return a list of resources page by page", func() {
+			// given
+			for i := 1; i <= 5; i++ {
+				createResource(fmt.Sprintf("res-%d", i))
+			}
+
+			// when
+			var names []string
+			offset := ""
+			pages := 0
+			for {
+				list := sample_model.TrafficRouteResourceList{}
+				err := s.List(context.Background(), &list, ListByNamespace(namespace), ListByPage(2, offset))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(list.GetPagination().Total).To(Equal(uint32(5)))
+				Expect(len(list.Items)).To(BeNumerically("<=", 2))
+				for _, item := range list.Items {
+					names = append(names, item.Meta.GetName())
+				}
+				pages++
+				offset = list.GetPagination().NextOffset
+				if offset == "" {
+					break
+				}
+			}
+
+			// then
+			Expect(pages).To(Equal(3))
+			Expect(names).To(ConsistOf("res-1", "res-2", "res-3", "res-4", "res-5"))
+		})
+
+		It("should return an error on invalid offset", func() {
+			// given
+			list := sample_model.TrafficRouteResourceList{}
+
+			// when
+			err := s.List(context.Background(), &list, ListByNamespace(namespace), ListByPage(2, "not-an-offset"))
+
+			// then
+			Expect(IsInvalidOffset(err)).To(BeTrue())
+		})
+
 		It("should not return a list of resources in different namespace", func() {
 			// given two resources
 			createResource("res-1")
@@ -294,4 +536,77 @@ func ExecuteStoreTests(
 			Expect(list.Items).To(HaveLen(0))
 		})
 	})
+
+	Describe("Watch()", func() {
+		BeforeEach(func() {
+			if watcher == nil {
+				Skip("store does not support watching changes")
+			}
+		})
+
+		It("should notify about created, updated and deleted resources", func() {
+			// given
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			events, err := watcher.Watch(ctx, sample_model.TrafficRouteType, namespace)
+			Expect(err).ToNot(HaveOccurred())
+
+			// when
+			resource := createResource("to-be-watched")
+
+			// then
+			expected := Event{
+				ResourceType: sample_model.TrafficRouteType,
+				Namespace:    namespace,
+				Name:         "to-be-watched",
+				Mesh:         mesh,
+			}
+			expected.Type = CreatedEvent
+			Eventually(events, "5s").Should(Receive(Equal(expected)))
+
+			// when
+			resource.Spec.Path = "new-path"
+			err = s.Update(context.Background(), resource)
+			Expect(err).ToNot(HaveOccurred())
+
+			// then
+			expected.Type = UpdatedEvent
+			Eventually(events, "5s").Should(Receive(Equal(expected)))
+
+			// when
+			err = s.Delete(context.Background(), resource, DeleteByKey(namespace, "to-be-watched", mesh))
+			Expect(err).ToNot(HaveOccurred())
+
+			// then
+			expected.Type = DeletedEvent
+			Eventually(events, "5s").Should(Receive(Equal(expected)))
+		})
+
+		It("should not notify about resources in other namespaces", func() {
+			// given
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			events, err := watcher.Watch(ctx, sample_model.TrafficRouteType, "other-"+namespace)
+			Expect(err).ToNot(HaveOccurred())
+
+			// when
+			createResource("not-watched")
+
+			// then
+			Consistently(events, "100ms").ShouldNot(Receive())
+		})
+
+		It("should close the channel once the context is done", func() {
+			// given
+			ctx, cancel := context.WithCancel(context.Background())
+			events, err := watcher.Watch(ctx, sample_model.TrafficRouteType, namespace)
+			Expect(err).ToNot(HaveOccurred())
+
+			// when
+			cancel()
+
+			// then
+			Eventually(events).Should(BeClosed())
+		})
+	})
 }
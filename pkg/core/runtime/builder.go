@@ -2,15 +2,20 @@ package runtime
 
 import (
 	"context"
+	"net/http"
 
 	kuma_cp "github.com/Kong/kuma/pkg/config/app/kuma-cp"
+	watchdog_config "github.com/Kong/kuma/pkg/config/core/watchdog"
 	"github.com/Kong/kuma/pkg/core"
 	builtin_ca "github.com/Kong/kuma/pkg/core/ca/builtin"
+	provided_ca "github.com/Kong/kuma/pkg/core/ca/provided"
 	core_discovery "github.com/Kong/kuma/pkg/core/discovery"
 	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
 	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	"github.com/Kong/kuma/pkg/core/runtime/supervisor"
 	secret_manager "github.com/Kong/kuma/pkg/core/secrets/manager"
 	core_xds "github.com/Kong/kuma/pkg/core/xds"
+	"github.com/Kong/kuma/pkg/metrics"
 	"github.com/pkg/errors"
 )
 
@@ -18,9 +23,14 @@ import (
 type BuilderContext interface {
 	ComponentManager() ComponentManager
 	ResourceStore() core_store.ResourceStore
+	ResourceManager() core_manager.ResourceManager
+	SecretManager() secret_manager.SecretManager
+	BuiltinCaManager() builtin_ca.BuiltinCaManager
 	XdsContext() core_xds.XdsContext
 	Config() kuma_cp.Config
 	Extensions() context.Context
+	Supervisor() *supervisor.Supervisor
+	EgressTransport() http.RoundTripper
 }
 
 var _ BuilderContext = &Builder{}
@@ -33,13 +43,21 @@ type Builder struct {
 	rm  core_manager.ResourceManager
 	sm  secret_manager.SecretManager
 	bcm builtin_ca.BuiltinCaManager
+	pcm provided_ca.ProvidedCaManager
 	dss []core_discovery.DiscoverySource
 	xds core_xds.XdsContext
+	mtr metrics.Metrics
 	ext context.Context
+	sup *supervisor.Supervisor
+	egt http.RoundTripper
 }
 
 func BuilderFor(cfg kuma_cp.Config) *Builder {
-	return &Builder{cfg: cfg, ext: context.Background()}
+	watchdog := watchdog_config.DefaultWatchdogConfig()
+	if cfg.Watchdog != nil {
+		watchdog = cfg.Watchdog
+	}
+	return &Builder{cfg: cfg, ext: context.Background(), sup: supervisor.NewSupervisor(*watchdog), egt: http.DefaultTransport}
 }
 
 func (b *Builder) WithComponentManager(cm ComponentManager) *Builder {
@@ -67,6 +85,11 @@ func (b *Builder) WithBuiltinCaManager(bcm builtin_ca.BuiltinCaManager) *Builder
 	return b
 }
 
+func (b *Builder) WithProvidedCaManager(pcm provided_ca.ProvidedCaManager) *Builder {
+	b.pcm = pcm
+	return b
+}
+
 func (b *Builder) AddDiscoverySource(ds core_discovery.DiscoverySource) *Builder {
 	b.dss = append(b.dss, ds)
 	return b
@@ -77,11 +100,21 @@ func (b *Builder) WithXdsContext(xds core_xds.XdsContext) *Builder {
 	return b
 }
 
+func (b *Builder) WithMetrics(mtr metrics.Metrics) *Builder {
+	b.mtr = mtr
+	return b
+}
+
 func (b *Builder) WithExtensions(ext context.Context) *Builder {
 	b.ext = ext
 	return b
 }
 
+func (b *Builder) WithEgressTransport(egt http.RoundTripper) *Builder {
+	b.egt = egt
+	return b
+}
+
 func (b *Builder) Build() (Runtime, error) {
 	if b.cm == nil {
 		return nil, errors.Errorf("ComponentManager has not been configured")
@@ -98,6 +131,9 @@ func (b *Builder) Build() (Runtime, error) {
 	if b.bcm == nil {
 		return nil, errors.Errorf("BuiltinCaManager has not been configured")
 	}
+	if b.pcm == nil {
+		return nil, errors.Errorf("ProvidedCaManager has not been configured")
+	}
 	// todo(jakubdyszkiewicz) restore when we've got store based discovery source
 	//if len(b.dss) == 0 {
 	//	return nil, errors.Errorf("DiscoverySources have not been configured")
@@ -105,9 +141,15 @@ func (b *Builder) Build() (Runtime, error) {
 	if b.xds == nil {
 		return nil, errors.Errorf("xDS Context has not been configured")
 	}
+	if b.mtr == nil {
+		return nil, errors.Errorf("Metrics have not been configured")
+	}
 	if b.ext == nil {
 		return nil, errors.Errorf("Extensions have been misconfigured")
 	}
+	if b.egt == nil {
+		return nil, errors.Errorf("Egress transport has not been configured")
+	}
 	return &runtime{
 		RuntimeInfo: &runtimeInfo{
 			instanceId: core.NewUUID(),
@@ -115,11 +157,16 @@ func (b *Builder) Build() (Runtime, error) {
 		RuntimeContext: &runtimeContext{
 			cfg: b.cfg,
 			rm:  b.rm,
+			rs:  b.rs,
 			sm:  b.sm,
 			bcm: b.bcm,
+			pcm: b.pcm,
 			dss: b.dss,
 			xds: b.xds,
+			mtr: b.mtr,
 			ext: b.ext,
+			sup: b.sup,
+			egt: b.egt,
 		},
 		ComponentManager: b.cm,
 	}, nil
@@ -131,18 +178,33 @@ func (b *Builder) ComponentManager() ComponentManager {
 func (b *Builder) ResourceStore() core_store.ResourceStore {
 	return b.rs
 }
+func (b *Builder) ResourceManager() core_manager.ResourceManager {
+	return b.rm
+}
 func (b *Builder) SecretManager() secret_manager.SecretManager {
 	return b.sm
 }
 func (b *Builder) BuiltinCaManager() builtin_ca.BuiltinCaManager {
 	return b.bcm
 }
+func (b *Builder) ProvidedCaManager() provided_ca.ProvidedCaManager {
+	return b.pcm
+}
 func (b *Builder) XdsContext() core_xds.XdsContext {
 	return b.xds
 }
+func (b *Builder) Metrics() metrics.Metrics {
+	return b.mtr
+}
 func (b *Builder) Config() kuma_cp.Config {
 	return b.cfg
 }
 func (b *Builder) Extensions() context.Context {
 	return b.ext
 }
+func (b *Builder) Supervisor() *supervisor.Supervisor {
+	return b.sup
+}
+func (b *Builder) EgressTransport() http.RoundTripper {
+	return b.egt
+}
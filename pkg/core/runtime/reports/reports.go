@@ -109,7 +109,7 @@ func (b *reportsBuffer) dispatch(rt core_runtime.Runtime, host string, port int)
 		return err
 	}
 
-	conn, err := net.Dial("tcp", fmt.Sprintf("%s:%d", host, port))
+	conn, err := net.Dial("tcp", net.JoinHostPort(host, strconv.Itoa(port)))
 	if err != nil {
 		return err
 	}
@@ -136,7 +136,7 @@ func (b *reportsBuffer) initImmutable(rt core_runtime.Runtime) {
 	b.immutable["version"] = kuma_version.Build.Version
 	b.immutable["signal"] = "ping"
 	b.immutable["unique_id"] = rt.GetInstanceId()
-	b.immutable["backend"] = rt.Config().Store.Type
+	b.immutable["backend"] = rt.Config().StoreType()
 
 	hostname, err := os.Hostname()
 	if err == nil {
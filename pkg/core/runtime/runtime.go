@@ -2,13 +2,18 @@ package runtime
 
 import (
 	"context"
+	"net/http"
 
 	kuma_cp "github.com/Kong/kuma/pkg/config/app/kuma-cp"
 	builtin_ca "github.com/Kong/kuma/pkg/core/ca/builtin"
+	provided_ca "github.com/Kong/kuma/pkg/core/ca/provided"
 	core_discovery "github.com/Kong/kuma/pkg/core/discovery"
 	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	"github.com/Kong/kuma/pkg/core/runtime/supervisor"
 	secret_manager "github.com/Kong/kuma/pkg/core/secrets/manager"
 	core_xds "github.com/Kong/kuma/pkg/core/xds"
+	"github.com/Kong/kuma/pkg/metrics"
 )
 
 // Runtime represents initialized application state.
@@ -26,10 +31,16 @@ type RuntimeContext interface {
 	Config() kuma_cp.Config
 	DiscoverySources() []core_discovery.DiscoverySource
 	XDS() core_xds.XdsContext
+	Metrics() metrics.Metrics
 	ResourceManager() core_manager.ResourceManager
+	ResourceStore() core_store.ResourceStore
 	SecretManager() secret_manager.SecretManager
 	BuiltinCaManager() builtin_ca.BuiltinCaManager
+	ProvidedCaManager() provided_ca.ProvidedCaManager
 	Extensions() context.Context
+	Supervisor() *supervisor.Supervisor
+	// EgressTransport is used by HTTP clients of Control Plane that reach external services.
+	EgressTransport() http.RoundTripper
 }
 
 var _ Runtime = &runtime{}
@@ -55,11 +66,16 @@ var _ RuntimeContext = &runtimeContext{}
 type runtimeContext struct {
 	cfg kuma_cp.Config
 	rm  core_manager.ResourceManager
+	rs  core_store.ResourceStore
 	sm  secret_manager.SecretManager
 	bcm builtin_ca.BuiltinCaManager
+	pcm provided_ca.ProvidedCaManager
 	dss []core_discovery.DiscoverySource
 	xds core_xds.XdsContext
+	mtr metrics.Metrics
 	ext context.Context
+	sup *supervisor.Supervisor
+	egt http.RoundTripper
 }
 
 func (rc *runtimeContext) Config() kuma_cp.Config {
@@ -71,15 +87,30 @@ func (rc *runtimeContext) DiscoverySources() []core_discovery.DiscoverySource {
 func (rc *runtimeContext) XDS() core_xds.XdsContext {
 	return rc.xds
 }
+func (rc *runtimeContext) Metrics() metrics.Metrics {
+	return rc.mtr
+}
 func (rc *runtimeContext) ResourceManager() core_manager.ResourceManager {
 	return rc.rm
 }
+func (rc *runtimeContext) ResourceStore() core_store.ResourceStore {
+	return rc.rs
+}
 func (rc *runtimeContext) SecretManager() secret_manager.SecretManager {
 	return rc.sm
 }
 func (rc *runtimeContext) BuiltinCaManager() builtin_ca.BuiltinCaManager {
 	return rc.bcm
 }
+func (rc *runtimeContext) ProvidedCaManager() provided_ca.ProvidedCaManager {
+	return rc.pcm
+}
 func (rc *runtimeContext) Extensions() context.Context {
 	return rc.ext
 }
+func (rc *runtimeContext) Supervisor() *supervisor.Supervisor {
+	return rc.sup
+}
+func (rc *runtimeContext) EgressTransport() http.RoundTripper {
+	return rc.egt
+}
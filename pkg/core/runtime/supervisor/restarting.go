@@ -0,0 +1,75 @@
+package supervisor
+
+import (
+	"time"
+
+	"github.com/Kong/kuma/pkg/core"
+)
+
+// Restarting returns a component that restarts a given component once its heartbeat becomes stale.
+//
+// The component is stopped and started again once it has returned. A component that doesn't return
+// within the restart timeout, e.g. because it is blocked on a call that ignores its stop channel,
+// is abandoned and a new instance is started anyway, so that Control Plane can recover from it.
+// If the component returns on its own, the error is passed on without a restart.
+func (s *Supervisor) Restarting(heartbeat *Heartbeat, component Component) Component {
+	return &restartingComponent{
+		heartbeat:      heartbeat,
+		component:      component,
+		checkInterval:  s.cfg.CheckInterval,
+		restartTimeout: s.cfg.RestartTimeout,
+	}
+}
+
+type restartingComponent struct {
+	heartbeat      *Heartbeat
+	component      Component
+	checkInterval  time.Duration
+	restartTimeout time.Duration
+}
+
+func (r *restartingComponent) Start(stop <-chan struct{}) error {
+	log := log.WithValues("component", r.heartbeat.Name())
+	ticker := time.NewTicker(r.checkInterval)
+	defer ticker.Stop()
+	for {
+		r.heartbeat.Beat()
+		componentStop := make(chan struct{})
+		done := make(chan error, 1)
+		go func() {
+			done <- r.component.Start(componentStop)
+		}()
+
+	running:
+		for {
+			select {
+			case <-stop:
+				close(componentStop)
+				return <-done
+			case err := <-done:
+				return err
+			case <-ticker.C:
+				if !r.heartbeat.stale(core.Now()) {
+					continue
+				}
+				log.Info("component is stuck, restarting it")
+				close(componentStop)
+				timeout := time.NewTimer(r.restartTimeout)
+				select {
+				case err := <-done:
+					if err != nil {
+						log.Error(err, "component returned an error while being restarted")
+					}
+				case <-timeout.C:
+					log.Info("component has not stopped in time, starting a new instance anyway", "timeout", r.restartTimeout)
+				case <-stop:
+					// component is still stuck, there is no point in waiting for it
+					timeout.Stop()
+					return nil
+				}
+				timeout.Stop()
+				break running
+			}
+		}
+	}
+}
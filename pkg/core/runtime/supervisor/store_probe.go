@@ -0,0 +1,49 @@
+package supervisor
+
+import (
+	"context"
+	"time"
+
+	"github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+)
+
+// StoreProbe returns a component that periodically checks connectivity to a Resource Store.
+// Control Plane is reported as not ready once the store has been unreachable for longer than the heartbeat timeout.
+func (s *Supervisor) StoreProbe(store core_store.ResourceStore) Component {
+	return &storeProbe{
+		store:     store,
+		interval:  s.cfg.StoreProbeInterval,
+		heartbeat: s.Heartbeat("resource-store"),
+	}
+}
+
+type storeProbe struct {
+	store     core_store.ResourceStore
+	interval  time.Duration
+	heartbeat *Heartbeat
+}
+
+func (p *storeProbe) Start(stop <-chan struct{}) error {
+	log := log.WithValues("component", p.heartbeat.Name())
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		if err := p.probe(); err != nil {
+			log.Error(err, "Resource Store is unreachable")
+		} else {
+			p.heartbeat.Beat()
+		}
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return nil
+		}
+	}
+}
+
+func (p *storeProbe) probe() error {
+	ctx, cancel := context.WithTimeout(context.Background(), p.interval)
+	defer cancel()
+	return p.store.List(ctx, &mesh.MeshResourceList{})
+}
@@ -0,0 +1,93 @@
+package supervisor
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	watchdog_config "github.com/Kong/kuma/pkg/config/core/watchdog"
+	"github.com/Kong/kuma/pkg/core"
+)
+
+var log = core.Log.WithName("supervisor")
+
+// Component is a long-running part of Control Plane, e.g. a reconciliation loop.
+type Component interface {
+	Start(stop <-chan struct{}) error
+}
+
+// Supervisor keeps track of heartbeats of components to detect the ones that are stuck.
+//
+// Stuck components that are started by Restarting are restarted.
+// As long as any component is stuck, Control Plane is reported as not ready.
+type Supervisor struct {
+	cfg watchdog_config.WatchdogConfig
+
+	mu         sync.RWMutex
+	heartbeats []*Heartbeat
+}
+
+func NewSupervisor(cfg watchdog_config.WatchdogConfig) *Supervisor {
+	return &Supervisor{cfg: cfg}
+}
+
+// Heartbeat registers a heartbeat of a component of a given name.
+func (s *Supervisor) Heartbeat(name string) *Heartbeat {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	heartbeat := &Heartbeat{name: name, timeout: s.cfg.HeartbeatTimeout, last: core.Now()}
+	s.heartbeats = append(s.heartbeats, heartbeat)
+	return heartbeat
+}
+
+// Ready returns an error if any component is stuck.
+func (s *Supervisor) Ready() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	now := core.Now()
+	var stuck []string
+	for _, heartbeat := range s.heartbeats {
+		if heartbeat.stale(now) {
+			stuck = append(stuck, heartbeat.name)
+		}
+	}
+	if len(stuck) > 0 {
+		sort.Strings(stuck)
+		return errors.Errorf("components are stuck: %s", strings.Join(stuck, ", "))
+	}
+	return nil
+}
+
+// Heartbeat is reported by a component every time it makes progress.
+type Heartbeat struct {
+	name    string
+	timeout time.Duration
+
+	mu   sync.Mutex
+	last time.Time
+}
+
+// Beat reports that a component has made progress.
+func (h *Heartbeat) Beat() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.last = core.Now()
+}
+
+func (h *Heartbeat) Name() string {
+	return h.name
+}
+
+// Timeout returns how long a component may go without a beat before it is considered to be stuck.
+func (h *Heartbeat) Timeout() time.Duration {
+	return h.timeout
+}
+
+func (h *Heartbeat) stale(now time.Time) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return now.Sub(h.last) > h.timeout
+}
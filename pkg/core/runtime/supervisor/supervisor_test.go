@@ -0,0 +1,201 @@
+package supervisor_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	watchdog_config "github.com/Kong/kuma/pkg/config/core/watchdog"
+	"github.com/Kong/kuma/pkg/core"
+	"github.com/Kong/kuma/pkg/core/resources/model"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	"github.com/Kong/kuma/pkg/core/runtime/supervisor"
+	memory_resources "github.com/Kong/kuma/pkg/plugins/resources/memory"
+)
+
+var _ = Describe("Supervisor", func() {
+
+	cfg := watchdog_config.WatchdogConfig{
+		CheckInterval:      10 * time.Millisecond,
+		HeartbeatTimeout:   100 * time.Millisecond,
+		StoreProbeInterval: 10 * time.Millisecond,
+		RestartTimeout:     50 * time.Millisecond,
+	}
+
+	var stop chan struct{}
+	var done chan error
+
+	BeforeEach(func() {
+		stop = make(chan struct{})
+		done = nil
+	})
+
+	AfterEach(func() {
+		close(stop)
+		if done != nil {
+			Eventually(done).Should(Receive(BeNil()))
+		}
+		core.Now = time.Now
+	})
+
+	start := func(component supervisor.Component) {
+		done = make(chan error, 1)
+		go func() {
+			done <- component.Start(stop)
+		}()
+	}
+
+	It("should report components whose heartbeat is stale", func() {
+		// given
+		now := time.Now()
+		core.Now = func() time.Time { return now }
+		sv := supervisor.NewSupervisor(cfg)
+		discovery := sv.Heartbeat("discovery")
+		sv.Heartbeat("reconciler")
+
+		// then
+		Expect(sv.Ready()).To(Succeed())
+
+		// when
+		now = now.Add(time.Second)
+		// then
+		Expect(sv.Ready()).To(MatchError("components are stuck: discovery, reconciler"))
+
+		// when
+		discovery.Beat()
+		// then
+		Expect(sv.Ready()).To(MatchError("components are stuck: reconciler"))
+	})
+
+	It("should restart a component that is stuck", func() {
+		// given
+		sv := supervisor.NewSupervisor(cfg)
+		heartbeat := sv.Heartbeat("discovery")
+		var starts int32
+		component := componentFunc(func(stop <-chan struct{}) error {
+			// the first instance gets stuck, the next ones make progress
+			stuck := atomic.AddInt32(&starts, 1) == 1
+			ticker := time.NewTicker(10 * time.Millisecond)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					if !stuck {
+						heartbeat.Beat()
+					}
+				case <-stop:
+					return nil
+				}
+			}
+		})
+
+		// when
+		start(sv.Restarting(heartbeat, component))
+
+		// then
+		Eventually(func() int32 { return atomic.LoadInt32(&starts) }).Should(Equal(int32(2)))
+		Consistently(func() int32 { return atomic.LoadInt32(&starts) }, "300ms").Should(Equal(int32(2)))
+		Expect(sv.Ready()).To(Succeed())
+	})
+
+	It("should start a new instance of a component that doesn't stop in time", func() {
+		// given
+		sv := supervisor.NewSupervisor(cfg)
+		heartbeat := sv.Heartbeat("discovery")
+		unblock := make(chan struct{})
+		defer close(unblock)
+		var starts int32
+		component := componentFunc(func(stop <-chan struct{}) error {
+			if atomic.AddInt32(&starts, 1) == 1 {
+				<-unblock // the first instance ignores stop
+				return nil
+			}
+			ticker := time.NewTicker(10 * time.Millisecond)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					heartbeat.Beat()
+				case <-stop:
+					return nil
+				}
+			}
+		})
+		start(sv.Restarting(heartbeat, component))
+
+		// then
+		Eventually(sv.Ready).Should(MatchError("components are stuck: discovery"))
+
+		// and
+		Eventually(func() int32 { return atomic.LoadInt32(&starts) }).Should(Equal(int32(2)))
+		Consistently(sv.Ready, "300ms").Should(Succeed())
+	})
+
+	It("should pass on an error of a component", func() {
+		// given
+		sv := supervisor.NewSupervisor(cfg)
+		component := componentFunc(func(<-chan struct{}) error {
+			return errors.New("could not bind a port")
+		})
+
+		// when
+		err := sv.Restarting(sv.Heartbeat("server"), component).Start(stop)
+
+		// then
+		Expect(err).To(MatchError("could not bind a port"))
+	})
+
+	It("should report an unreachable Resource Store", func() {
+		// given
+		sv := supervisor.NewSupervisor(cfg)
+		store := &flakyStore{ResourceStore: memory_resources.NewStore()}
+		start(sv.StoreProbe(store))
+
+		// then
+		Consistently(sv.Ready, "200ms").Should(Succeed())
+
+		// when
+		store.SetFailing(true)
+
+		// then
+		Eventually(sv.Ready).Should(MatchError("components are stuck: resource-store"))
+
+		// when
+		store.SetFailing(false)
+
+		// then
+		Eventually(sv.Ready).Should(Succeed())
+	})
+})
+
+type componentFunc func(<-chan struct{}) error
+
+func (f componentFunc) Start(stop <-chan struct{}) error {
+	return f(stop)
+}
+
+type flakyStore struct {
+	core_store.ResourceStore
+	mu      sync.Mutex
+	failing bool
+}
+
+func (s *flakyStore) SetFailing(failing bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failing = failing
+}
+
+func (s *flakyStore) List(ctx context.Context, list model.ResourceList, fs ...core_store.ListOptionsFunc) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failing {
+		return errors.New("connection refused")
+	}
+	return s.ResourceStore.List(ctx, list, fs...)
+}
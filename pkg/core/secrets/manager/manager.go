@@ -3,7 +3,10 @@ package manager
 import (
 	"context"
 
+	"github.com/pkg/errors"
+
 	secret_model "github.com/Kong/kuma/pkg/core/resources/apis/system"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
 	core_store "github.com/Kong/kuma/pkg/core/resources/store"
 	secret_cipher "github.com/Kong/kuma/pkg/core/secrets/cipher"
 	secret_store "github.com/Kong/kuma/pkg/core/secrets/store"
@@ -64,6 +67,17 @@ func (s *secretManager) Update(ctx context.Context, secret *secret_model.SecretR
 	return s.secretStore.Update(ctx, secret, fs...)
 }
 
+var _ core_store.ResourceWatcher = &secretManager{}
+
+// Watch notifies about changes of Secrets if the underlying SecretStore can notify about them.
+func (s *secretManager) Watch(ctx context.Context, resourceType core_model.ResourceType, namespace string) (<-chan core_store.Event, error) {
+	watcher, ok := s.secretStore.(core_store.ResourceWatcher)
+	if !ok {
+		return nil, errors.New("secret store cannot notify about changes")
+	}
+	return watcher.Watch(ctx, resourceType, namespace)
+}
+
 func (s *secretManager) Delete(ctx context.Context, secret *secret_model.SecretResource, fs ...core_store.DeleteOptionsFunc) error {
 	return s.secretStore.Delete(ctx, secret, fs...)
 }
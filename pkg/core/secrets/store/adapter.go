@@ -3,7 +3,10 @@ package store
 import (
 	"context"
 
+	"github.com/pkg/errors"
+
 	secret_model "github.com/Kong/kuma/pkg/core/resources/apis/system"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
 	core_store "github.com/Kong/kuma/pkg/core/resources/store"
 )
 
@@ -38,3 +41,13 @@ func (r *secretStore) Delete(ctx context.Context, secret *secret_model.SecretRes
 func (r *secretStore) Update(ctx context.Context, secret *secret_model.SecretResource, fs ...core_store.UpdateOptionsFunc) error {
 	return r.resourceStore.Update(ctx, secret, fs...)
 }
+
+var _ core_store.ResourceWatcher = &secretStore{}
+
+func (r *secretStore) Watch(ctx context.Context, resourceType core_model.ResourceType, namespace string) (<-chan core_store.Event, error) {
+	watcher, ok := r.resourceStore.(core_store.ResourceWatcher)
+	if !ok {
+		return nil, errors.New("resource store that keeps Secrets cannot notify about changes")
+	}
+	return watcher.Watch(ctx, resourceType, namespace)
+}
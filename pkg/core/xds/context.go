@@ -13,22 +13,50 @@ import (
 type XdsContext interface {
 	Hasher() envoy_cache.NodeHash
 	Cache() envoy_cache.SnapshotCache
+	ConfigHistory() ConfigHistory
+	ConfigPropagationTracker() ConfigPropagationTracker
+	PolicyRollout() PolicyRollout
+	StreamTracker() StreamTracker
 }
 
-func NewXdsContext() XdsContext {
-	return newXdsContext("xds-server", true)
+type XdsContextOption func(*xdsContext)
+
+// WithConfigHistory makes recent generations of config of every Dataplane available for inspection.
+func WithConfigHistory(history ConfigHistory) XdsContextOption {
+	return func(c *xdsContext) {
+		c.history = history
+	}
+}
+
+// WithPolicyRollout makes changes of policies roll out to Dataplanes in waves.
+func WithPolicyRollout(rollout PolicyRollout) XdsContextOption {
+	return func(c *xdsContext) {
+		c.rollout = rollout
+	}
+}
+
+func NewXdsContext(opts ...XdsContextOption) XdsContext {
+	return newXdsContext("xds-server", true, opts...)
 }
 
-func newXdsContext(name string, ads bool) XdsContext {
+func newXdsContext(name string, ads bool, opts ...XdsContextOption) XdsContext {
 	log := core.Log.WithName(name)
 	hasher := hasher{log}
 	logger := logger{log}
 	cache := envoy_cache.NewSnapshotCache(ads, hasher, logger)
-	return &xdsContext{
+	ctx := &xdsContext{
 		NodeHash:      hasher,
 		Logger:        logger,
 		SnapshotCache: cache,
+		history:       NewConfigHistory(0),
+		propagation:   NewConfigPropagationTracker(),
+		rollout:       &noopPolicyRollout{},
+		streams:       NewStreamTracker(),
+	}
+	for _, opt := range opts {
+		opt(ctx)
 	}
+	return ctx
 }
 
 var _ XdsContext = &xdsContext{}
@@ -37,6 +65,10 @@ type xdsContext struct {
 	envoy_cache.NodeHash
 	envoy_log.Logger
 	envoy_cache.SnapshotCache
+	history     ConfigHistory
+	propagation ConfigPropagationTracker
+	rollout     PolicyRollout
+	streams     StreamTracker
 }
 
 func (c *xdsContext) Hasher() envoy_cache.NodeHash {
@@ -47,6 +79,22 @@ func (c *xdsContext) Cache() envoy_cache.SnapshotCache {
 	return c.SnapshotCache
 }
 
+func (c *xdsContext) ConfigHistory() ConfigHistory {
+	return c.history
+}
+
+func (c *xdsContext) ConfigPropagationTracker() ConfigPropagationTracker {
+	return c.propagation
+}
+
+func (c *xdsContext) PolicyRollout() PolicyRollout {
+	return c.rollout
+}
+
+func (c *xdsContext) StreamTracker() StreamTracker {
+	return c.streams
+}
+
 var _ envoy_cache.NodeHash = &hasher{}
 
 type hasher struct {
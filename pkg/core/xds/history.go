@@ -0,0 +1,85 @@
+package xds
+
+import (
+	"sync"
+	"time"
+
+	envoy_cache "github.com/envoyproxy/go-control-plane/pkg/cache"
+
+	"github.com/Kong/kuma/pkg/core"
+)
+
+// ConfigGeneration is a config that was generated for a Dataplane at some point in time.
+type ConfigGeneration struct {
+	// Generation is a sequence number of the config, starting from 1.
+	Generation uint64
+	Time       time.Time
+	Snapshot   envoy_cache.Snapshot
+}
+
+// ConfigHistory keeps a number of recent config generations per Dataplane,
+// so that it is possible to find out what has changed in the config of a Dataplane.
+//
+// Since every generation is a full config, history takes a lot of memory in large meshes
+// and is turned off unless a size is configured.
+type ConfigHistory interface {
+	// Record stores a new generation of config of a given proxy.
+	Record(proxyId string, snapshot envoy_cache.Snapshot)
+	// Get returns the recent generations of config of a given proxy, oldest first.
+	Get(proxyId string) []ConfigGeneration
+	Clear(proxyId string)
+}
+
+// NewConfigHistory returns a history that keeps a given number of generations per Dataplane.
+// Zero size means that nothing is kept.
+func NewConfigHistory(size int) ConfigHistory {
+	return &configHistory{
+		size:        size,
+		generations: make(map[string][]ConfigGeneration),
+	}
+}
+
+var _ ConfigHistory = &configHistory{}
+
+type configHistory struct {
+	size        int
+	mu          sync.RWMutex
+	generations map[string][]ConfigGeneration
+}
+
+func (h *configHistory) Record(proxyId string, snapshot envoy_cache.Snapshot) {
+	if h.size == 0 {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	generations := h.generations[proxyId]
+	next := uint64(1)
+	if len(generations) > 0 {
+		next = generations[len(generations)-1].Generation + 1
+	}
+	generations = append(generations, ConfigGeneration{
+		Generation: next,
+		Time:       core.Now(),
+		Snapshot:   snapshot,
+	})
+	if len(generations) > h.size {
+		generations = generations[len(generations)-h.size:]
+	}
+	h.generations[proxyId] = generations
+}
+
+func (h *configHistory) Get(proxyId string) []ConfigGeneration {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	generations := h.generations[proxyId]
+	result := make([]ConfigGeneration, len(generations))
+	copy(result, generations)
+	return result
+}
+
+func (h *configHistory) Clear(proxyId string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.generations, proxyId)
+}
@@ -0,0 +1,157 @@
+package xds
+
+import (
+	"sort"
+	"strconv"
+
+	envoy_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	"github.com/gogo/protobuf/types"
+	"github.com/pkg/errors"
+)
+
+// MetadataVersion is the current version of the schema of node metadata.
+const MetadataVersion = "1"
+
+// Fields of node metadata.
+const (
+	fieldVersion        = "version"
+	fieldDataplaneMesh  = "dataplane.mesh"
+	fieldDataplaneName  = "dataplane.name"
+	fieldDataplaneToken = "dataplane.token"
+	fieldKumaDpVersion  = "kumaDp.version"
+	fieldAdminPort      = "dataplane.admin.port"
+)
+
+// supportedMetadataVersions lists versions of the schema of node metadata
+// that the Control Plane understands.
+var supportedMetadataVersions = []string{MetadataVersion}
+
+// DataplaneMetadata describes metadata that kuma-dp puts into the node of Envoy,
+// so that the Control Plane receives it when Envoy opens an xDS stream.
+//
+// Metadata is a flat map of string values, e.g.
+//
+//	version: "1"
+//	dataplane.mesh: default
+//	dataplane.name: backend-01
+//	dataplane.token: eyJhbGciOiJSUzI1NiIsInR5cCI6IkpXVCJ9...
+//	kumaDp.version: 0.3.0
+//	dataplane.admin.port: "9901"
+type DataplaneMetadata struct {
+	// Version of the schema of metadata.
+	Version string
+	// Mesh and Name of the Dataplane resource, in the same format as in a bootstrap request.
+	Mesh string
+	Name string
+	// Token that identifies the dataplane (optional).
+	Token string
+	// KumaDpVersion is the version of kuma-dp that has launched Envoy (optional).
+	KumaDpVersion string
+	// AdminPort is the port of Envoy Admin interface on localhost, or 0 if it's turned off (optional).
+	AdminPort uint32
+}
+
+// GetAdminPort returns the port of Envoy Admin interface, or 0 if it's turned off or unknown.
+func (m *DataplaneMetadata) GetAdminPort() uint32 {
+	if m == nil {
+		return 0
+	}
+	return m.AdminPort
+}
+
+// ToStruct converts metadata into Envoy node metadata.
+func (m *DataplaneMetadata) ToStruct() *types.Struct {
+	fields := map[string]string{
+		fieldVersion:        m.Version,
+		fieldDataplaneMesh:  m.Mesh,
+		fieldDataplaneName:  m.Name,
+		fieldDataplaneToken: m.Token,
+		fieldKumaDpVersion:  m.KumaDpVersion,
+	}
+	if m.AdminPort != 0 {
+		fields[fieldAdminPort] = strconv.FormatUint(uint64(m.AdminPort), 10)
+	}
+	result := &types.Struct{Fields: map[string]*types.Value{}}
+	for key, value := range fields {
+		if value == "" {
+			continue
+		}
+		result.Fields[key] = &types.Value{Kind: &types.Value_StringValue{StringValue: value}}
+	}
+	return result
+}
+
+// ProxyId returns the id of a proxy that metadata has been sent by.
+func (m *DataplaneMetadata) ProxyId() (*ProxyId, error) {
+	return BuildProxyId(m.Mesh, m.Name)
+}
+
+// ParseDataplaneMetadata extracts metadata out of the node of Envoy and validates it
+// against the schema as well as against the id of the node.
+//
+// Nodes without metadata are accepted for compatibility with Envoys that have not been launched
+// by kuma-dp, in which case nil is returned.
+func ParseDataplaneMetadata(node *envoy_core.Node) (*DataplaneMetadata, error) {
+	if len(node.GetMetadata().GetFields()) == 0 {
+		return nil, nil
+	}
+	fields := node.Metadata.Fields
+	var invalid []string
+	for key, value := range fields {
+		if _, ok := value.GetKind().(*types.Value_StringValue); !ok {
+			invalid = append(invalid, key)
+		}
+	}
+	if len(invalid) > 0 {
+		sort.Strings(invalid)
+		return nil, errors.Errorf("invalid node metadata: fields %q must be strings", invalid)
+	}
+	metadata := &DataplaneMetadata{
+		Version:       fields[fieldVersion].GetStringValue(),
+		Mesh:          fields[fieldDataplaneMesh].GetStringValue(),
+		Name:          fields[fieldDataplaneName].GetStringValue(),
+		Token:         fields[fieldDataplaneToken].GetStringValue(),
+		KumaDpVersion: fields[fieldKumaDpVersion].GetStringValue(),
+	}
+	if value := fields[fieldAdminPort].GetStringValue(); value != "" {
+		port, err := strconv.ParseUint(value, 10, 16)
+		if err != nil {
+			return nil, errors.Errorf("invalid node metadata: %q field must be a port number", fieldAdminPort)
+		}
+		metadata.AdminPort = uint32(port)
+	}
+	if err := metadata.validate(node.Id); err != nil {
+		return nil, errors.Wrap(err, "invalid node metadata")
+	}
+	return metadata, nil
+}
+
+func (m *DataplaneMetadata) validate(nodeId string) error {
+	if m.Version == "" {
+		return errors.Errorf("%q field must be set", fieldVersion)
+	}
+	supported := false
+	for _, version := range supportedMetadataVersions {
+		if m.Version == version {
+			supported = true
+		}
+	}
+	if !supported {
+		return errors.Errorf("version %q is not supported, supported versions are %q", m.Version, supportedMetadataVersions)
+	}
+	if m.Mesh == "" {
+		return errors.Errorf("%q field must be set", fieldDataplaneMesh)
+	}
+	if m.Name == "" {
+		return errors.Errorf("%q field must be set", fieldDataplaneName)
+	}
+	proxyId, err := m.ProxyId()
+	if err != nil {
+		return errors.Wrapf(err, "%q and %q fields do not identify a Dataplane", fieldDataplaneMesh, fieldDataplaneName)
+	}
+	nodeProxyId, err := ParseProxyIdFromString(nodeId)
+	if err != nil || *proxyId != *nodeProxyId {
+		return errors.Errorf("Dataplane %q does not match node id %q", proxyId, nodeId)
+	}
+	return nil
+}
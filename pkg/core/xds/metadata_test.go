@@ -0,0 +1,130 @@
+package xds_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
+	envoy_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	"github.com/gogo/protobuf/types"
+)
+
+var _ = Describe("DataplaneMetadata", func() {
+
+	stringValue := func(value string) *types.Value {
+		return &types.Value{Kind: &types.Value_StringValue{StringValue: value}}
+	}
+
+	It("should be parsed back from Envoy node", func() {
+		// given
+		metadata := &core_xds.DataplaneMetadata{
+			Version:       core_xds.MetadataVersion,
+			Mesh:          "default",
+			Name:          "backend-01.demo",
+			Token:         "secret",
+			KumaDpVersion: "0.3.0",
+			AdminPort:     9901,
+		}
+		node := &envoy_core.Node{
+			Id:       "default.backend-01.demo",
+			Metadata: metadata.ToStruct(),
+		}
+
+		// when
+		parsed, err := core_xds.ParseDataplaneMetadata(node)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		// and
+		Expect(parsed).To(Equal(metadata))
+	})
+
+	It("should accept a node without metadata", func() {
+		// when
+		parsed, err := core_xds.ParseDataplaneMetadata(&envoy_core.Node{Id: "default.backend-01"})
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		// and
+		Expect(parsed).To(BeNil())
+	})
+
+	type testCase struct {
+		nodeId      string
+		fields      map[string]*types.Value
+		expectedErr string
+	}
+
+	DescribeTable("should reject invalid metadata",
+		func(given testCase) {
+			// given
+			node := &envoy_core.Node{
+				Id:       given.nodeId,
+				Metadata: &types.Struct{Fields: given.fields},
+			}
+
+			// when
+			_, err := core_xds.ParseDataplaneMetadata(node)
+
+			// then
+			Expect(err).To(HaveOccurred())
+			// and
+			Expect(err.Error()).To(Equal(given.expectedErr))
+		},
+		Entry("missing version", testCase{
+			nodeId: "default.backend-01",
+			fields: map[string]*types.Value{
+				"dataplane.mesh": stringValue("default"),
+				"dataplane.name": stringValue("backend-01"),
+			},
+			expectedErr: `invalid node metadata: "version" field must be set`,
+		}),
+		Entry("unsupported version", testCase{
+			nodeId: "default.backend-01",
+			fields: map[string]*types.Value{
+				"version":        stringValue("2"),
+				"dataplane.mesh": stringValue("default"),
+				"dataplane.name": stringValue("backend-01"),
+			},
+			expectedErr: `invalid node metadata: version "2" is not supported, supported versions are ["1"]`,
+		}),
+		Entry("missing name", testCase{
+			nodeId: "default.backend-01",
+			fields: map[string]*types.Value{
+				"version":        stringValue("1"),
+				"dataplane.mesh": stringValue("default"),
+			},
+			expectedErr: `invalid node metadata: "dataplane.name" field must be set`,
+		}),
+		Entry("not a string", testCase{
+			nodeId: "default.backend-01",
+			fields: map[string]*types.Value{
+				"version":         stringValue("1"),
+				"dataplane.mesh":  stringValue("default"),
+				"dataplane.name":  stringValue("backend-01"),
+				"dataplane.token": {Kind: &types.Value_NumberValue{NumberValue: 1}},
+			},
+			expectedErr: `invalid node metadata: fields ["dataplane.token"] must be strings`,
+		}),
+		Entry("admin port that is not a port number", testCase{
+			nodeId: "default.backend-01",
+			fields: map[string]*types.Value{
+				"version":              stringValue("1"),
+				"dataplane.mesh":       stringValue("default"),
+				"dataplane.name":       stringValue("backend-01"),
+				"dataplane.admin.port": stringValue("99999"),
+			},
+			expectedErr: `invalid node metadata: "dataplane.admin.port" field must be a port number`,
+		}),
+		Entry("Dataplane other than in node id", testCase{
+			nodeId: "default.backend-02",
+			fields: map[string]*types.Value{
+				"version":        stringValue("1"),
+				"dataplane.mesh": stringValue("default"),
+				"dataplane.name": stringValue("backend-01"),
+			},
+			expectedErr: `invalid node metadata: Dataplane "default.backend-01.default" does not match node id "default.backend-02"`,
+		}),
+	)
+})
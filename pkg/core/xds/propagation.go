@@ -0,0 +1,203 @@
+package xds
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Kong/kuma/pkg/core"
+)
+
+// maxPendingWrites limits memory used for tracking of changes in a single Mesh.
+const maxPendingWrites = 1000
+
+// ConfigPropagationTracker measures how long it takes until a change of resources in a Mesh
+// is propagated to all Dataplanes affected by the change.
+//
+// A change is considered propagated once every Dataplane, that was known at the time of the change,
+// has got its config regenerated and every Dataplane, that got a new config as a result, has ACKed it.
+//
+// Changes are observed in the ResourceStore by every instance of Control Plane that runs XDS Server,
+// so each instance measures propagation to Dataplanes connected to it, no matter where a change has been made.
+type ConfigPropagationTracker interface {
+	prometheus.Collector
+	// RecordWrite marks a change of resources in a given Mesh.
+	RecordWrite(mesh string)
+	// RecordReconcile marks that config of a given proxy has been regenerated.
+	// Versions of resources that have changed are given by type URL.
+	RecordReconcile(proxyId ProxyId, changed map[string]string)
+	// RecordAck marks that a given proxy has accepted a given version of resources of a given type.
+	RecordAck(proxyId ProxyId, typeUrl string, version string)
+	// Forget stops waiting for a given proxy, e.g. once it has disconnected.
+	Forget(proxyId ProxyId)
+}
+
+func NewConfigPropagationTracker() ConfigPropagationTracker {
+	return &configPropagationTracker{
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "xds_config_propagation_seconds",
+			Help:    "Time from a change of resources in a Mesh until the resulting config is ACKed by all affected Dataplanes",
+			Buckets: prometheus.ExponentialBuckets(0.1, 2, 12),
+		}, []string{"mesh"}),
+		meshes: make(map[string]*meshPropagation),
+	}
+}
+
+var _ ConfigPropagationTracker = &configPropagationTracker{}
+
+type configPropagationTracker struct {
+	latency *prometheus.HistogramVec
+
+	mu     sync.Mutex // protects access to the fields below
+	meshes map[string]*meshPropagation
+}
+
+type meshPropagation struct {
+	// proxies are indexed by proxy id
+	proxies map[string]*proxyPropagation
+	writes  []*pendingWrite
+}
+
+type proxyPropagation struct {
+	// versions of resources that have not been ACKed yet, by type URL
+	unacked map[string]string
+}
+
+type pendingWrite struct {
+	time time.Time
+	// proxies that have not been reconciled since the write
+	unreconciled map[string]bool
+	// proxies that got a new config since the write and have not ACKed it yet
+	unacked  map[string]bool
+	affected bool
+}
+
+func (t *configPropagationTracker) Describe(ch chan<- *prometheus.Desc) {
+	t.latency.Describe(ch)
+}
+
+func (t *configPropagationTracker) Collect(ch chan<- prometheus.Metric) {
+	t.latency.Collect(ch)
+}
+
+func (t *configPropagationTracker) RecordWrite(mesh string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	m, ok := t.meshes[mesh]
+	if !ok || len(m.proxies) == 0 {
+		// there is no Dataplane to propagate the change to
+		return
+	}
+	write := &pendingWrite{
+		time:         core.Now(),
+		unreconciled: make(map[string]bool, len(m.proxies)),
+		unacked:      make(map[string]bool),
+	}
+	for id := range m.proxies {
+		write.unreconciled[id] = true
+	}
+	m.writes = append(m.writes, write)
+	if len(m.writes) > maxPendingWrites {
+		m.writes = m.writes[len(m.writes)-maxPendingWrites:]
+	}
+}
+
+func (t *configPropagationTracker) RecordReconcile(proxyId ProxyId, changed map[string]string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	m, ok := t.meshes[proxyId.Mesh]
+	if !ok {
+		m = &meshPropagation{proxies: make(map[string]*proxyPropagation)}
+		t.meshes[proxyId.Mesh] = m
+	}
+	id := proxyId.String()
+	p, ok := m.proxies[id]
+	if !ok {
+		p = &proxyPropagation{unacked: make(map[string]string)}
+		m.proxies[id] = p
+	}
+	for typeUrl, version := range changed {
+		p.unacked[typeUrl] = version
+	}
+	for _, write := range m.writes {
+		if !write.unreconciled[id] {
+			continue
+		}
+		delete(write.unreconciled, id)
+		if len(changed) > 0 {
+			write.unacked[id] = true
+			write.affected = true
+		}
+	}
+	if len(p.unacked) == 0 {
+		m.acked(id)
+	}
+	t.observe(proxyId.Mesh, m)
+}
+
+func (t *configPropagationTracker) RecordAck(proxyId ProxyId, typeUrl string, version string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	m, ok := t.meshes[proxyId.Mesh]
+	if !ok {
+		return
+	}
+	id := proxyId.String()
+	p, ok := m.proxies[id]
+	if !ok {
+		return
+	}
+	if expected, ok := p.unacked[typeUrl]; !ok || expected != version {
+		return
+	}
+	delete(p.unacked, typeUrl)
+	if len(p.unacked) == 0 {
+		m.acked(id)
+		t.observe(proxyId.Mesh, m)
+	}
+}
+
+func (t *configPropagationTracker) Forget(proxyId ProxyId) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	m, ok := t.meshes[proxyId.Mesh]
+	if !ok {
+		return
+	}
+	id := proxyId.String()
+	delete(m.proxies, id)
+	for _, write := range m.writes {
+		delete(write.unreconciled, id)
+		delete(write.unacked, id)
+	}
+	t.observe(proxyId.Mesh, m)
+	if len(m.proxies) == 0 && len(m.writes) == 0 {
+		delete(t.meshes, proxyId.Mesh)
+	}
+}
+
+func (m *meshPropagation) acked(id string) {
+	for _, write := range m.writes {
+		delete(write.unacked, id)
+	}
+}
+
+// observe reports latency of writes that have been propagated to all affected proxies.
+func (t *configPropagationTracker) observe(mesh string, m *meshPropagation) {
+	now := core.Now()
+	pending := m.writes[:0]
+	for _, write := range m.writes {
+		if len(write.unreconciled) > 0 || len(write.unacked) > 0 {
+			pending = append(pending, write)
+			continue
+		}
+		if write.affected {
+			t.latency.WithLabelValues(mesh).Observe(now.Sub(write.time).Seconds())
+		}
+	}
+	for i := len(pending); i < len(m.writes); i++ {
+		m.writes[i] = nil
+	}
+	m.writes = pending
+}
@@ -0,0 +1,132 @@
+package xds_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	envoy_cache "github.com/envoyproxy/go-control-plane/pkg/cache"
+	"github.com/prometheus/client_golang/prometheus"
+	io_prometheus_client "github.com/prometheus/client_model/go"
+
+	"github.com/Kong/kuma/pkg/core"
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
+)
+
+var _ = Describe("ConfigPropagationTracker", func() {
+
+	var now time.Time
+	var tracker core_xds.ConfigPropagationTracker
+	var registry *prometheus.Registry
+
+	BeforeEach(func() {
+		now = time.Unix(1568000000, 0)
+		core.Now = func() time.Time {
+			return now
+		}
+		tracker = core_xds.NewConfigPropagationTracker()
+		registry = prometheus.NewRegistry()
+		Expect(registry.Register(tracker)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		core.Now = time.Now
+	})
+
+	histogram := func(mesh string) *io_prometheus_client.Histogram {
+		families, err := registry.Gather()
+		Expect(err).ToNot(HaveOccurred())
+		for _, family := range families {
+			for _, metric := range family.Metric {
+				for _, label := range metric.Label {
+					if label.GetName() == "mesh" && label.GetValue() == mesh {
+						return metric.Histogram
+					}
+				}
+			}
+		}
+		return nil
+	}
+
+	web := core_xds.ProxyId{Mesh: "demo", Name: "web", Namespace: "default"}
+	backend := core_xds.ProxyId{Mesh: "demo", Name: "backend", Namespace: "default"}
+
+	It("should measure time until all affected Dataplanes ACK new config", func() {
+		// given
+		tracker.RecordReconcile(web, nil)
+		tracker.RecordReconcile(backend, nil)
+
+		// when
+		tracker.RecordWrite("demo")
+		now = now.Add(1 * time.Second)
+		tracker.RecordReconcile(web, map[string]string{envoy_cache.ClusterType: "v2"})
+		tracker.RecordReconcile(backend, nil)
+		now = now.Add(2 * time.Second)
+		tracker.RecordAck(web, envoy_cache.ClusterType, "v1")
+
+		// then
+		Expect(histogram("demo")).To(BeNil())
+
+		// when
+		tracker.RecordAck(web, envoy_cache.ClusterType, "v2")
+
+		// then
+		Expect(histogram("demo").GetSampleCount()).To(Equal(uint64(1)))
+		Expect(histogram("demo").GetSampleSum()).To(Equal(3.0))
+	})
+
+	It("should wait until all Dataplanes are reconciled", func() {
+		// given
+		tracker.RecordReconcile(web, nil)
+		tracker.RecordReconcile(backend, nil)
+
+		// when
+		tracker.RecordWrite("demo")
+		tracker.RecordReconcile(web, map[string]string{envoy_cache.ListenerType: "v2"})
+		tracker.RecordAck(web, envoy_cache.ListenerType, "v2")
+
+		// then
+		Expect(histogram("demo")).To(BeNil())
+
+		// when
+		now = now.Add(5 * time.Second)
+		tracker.RecordReconcile(backend, nil)
+
+		// then
+		Expect(histogram("demo").GetSampleCount()).To(Equal(uint64(1)))
+		Expect(histogram("demo").GetSampleSum()).To(Equal(5.0))
+	})
+
+	It("should stop waiting for Dataplanes that have disconnected", func() {
+		// given
+		tracker.RecordReconcile(web, nil)
+		tracker.RecordReconcile(backend, nil)
+
+		// when
+		tracker.RecordWrite("demo")
+		tracker.RecordReconcile(web, map[string]string{envoy_cache.ListenerType: "v2"})
+		tracker.RecordReconcile(backend, map[string]string{envoy_cache.ListenerType: "v2"})
+		now = now.Add(1 * time.Second)
+		tracker.RecordAck(web, envoy_cache.ListenerType, "v2")
+		tracker.Forget(backend)
+
+		// then
+		Expect(histogram("demo").GetSampleCount()).To(Equal(uint64(1)))
+		Expect(histogram("demo").GetSampleSum()).To(Equal(1.0))
+	})
+
+	It("should not measure changes that do not affect any Dataplane", func() {
+		// given
+		tracker.RecordReconcile(web, nil)
+
+		// when
+		tracker.RecordWrite("demo")
+		tracker.RecordReconcile(web, nil)
+		tracker.RecordWrite("another")
+
+		// then
+		Expect(histogram("demo")).To(BeNil())
+		Expect(histogram("another")).To(BeNil())
+	})
+})
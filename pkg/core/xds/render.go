@@ -0,0 +1,47 @@
+package xds
+
+import (
+	"sort"
+
+	envoy_cache "github.com/envoyproxy/go-control-plane/pkg/cache"
+	"github.com/ghodss/yaml"
+
+	util_proto "github.com/Kong/kuma/pkg/util/proto"
+)
+
+// RenderSnapshot renders a snapshot into YAML in a stable order, so that 2 renderings can be compared line by line.
+//
+// Secrets are rendered by name only to avoid disclosure of private keys.
+func RenderSnapshot(snapshot envoy_cache.Snapshot) ([]byte, error) {
+	config := map[string]interface{}{}
+	for _, section := range []struct {
+		name      string
+		resources envoy_cache.Resources
+	}{
+		{"listeners", snapshot.Listeners},
+		{"routes", snapshot.Routes},
+		{"clusters", snapshot.Clusters},
+		{"endpoints", snapshot.Endpoints},
+	} {
+		items := []interface{}{}
+		for _, name := range sortedNames(section.resources) {
+			item, err := util_proto.ToMap(section.resources.Items[name])
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		config[section.name] = items
+	}
+	config["secrets"] = sortedNames(snapshot.Secrets)
+	return yaml.Marshal(config)
+}
+
+func sortedNames(resources envoy_cache.Resources) []string {
+	names := make([]string, 0, len(resources.Items))
+	for name := range resources.Items {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,240 @@
+package xds
+
+import (
+	"hash/fnv"
+	"sync"
+	"time"
+
+	envoy_cache "github.com/envoyproxy/go-control-plane/pkg/cache"
+
+	xds_config "github.com/Kong/kuma/pkg/config/xds"
+	"github.com/Kong/kuma/pkg/core"
+)
+
+var (
+	rolloutLog = core.Log.WithName("xds-server").WithName("policy-rollout")
+)
+
+// PolicyRollout rolls out a change of policies in a Mesh to Dataplanes in waves.
+//
+// Every Dataplane gets a stable position in the order of a rollout, so the same Dataplanes
+// always get changes first and act as canaries for the rest of a Mesh.
+// Dataplanes can also be grouped into waves by a tag, e.g. to roll out a change zone by zone.
+// Config of Dataplanes that are not part of a rollout yet is generated out of policies they have been
+// configured with before, while changes of Dataplanes themselves and of endpoints reach them without delay.
+//
+// A rollout is halted once Dataplanes that are part of it start rejecting their config
+// or responding with 5xx. It stays halted until the next change of policies in a Mesh, e.g. a fix or a revert,
+// or until it is aborted after a configured timeout.
+//
+// State of rollouts is kept in memory of a Control Plane instance, which means that every instance
+// rolls out changes to Dataplanes connected to it on its own.
+type PolicyRollout interface {
+	// Start begins a rollout of a change of policies in a given Mesh.
+	// If the previous rollout has not completed yet, it is restarted.
+	Start(mesh string)
+	// Admitted returns true if a given proxy should get a change of its policies now.
+	Admitted(proxy *Proxy) bool
+	// RecordAck marks that a given proxy has accepted its config of policies.
+	RecordAck(proxyId ProxyId)
+	// RecordNack marks that a given proxy has rejected its config of policies.
+	RecordNack(proxyId ProxyId)
+	// RecordResponses marks that a given proxy has observed a number of HTTP responses, some of which were 5xx.
+	RecordResponses(proxyId ProxyId, total uint64, errors uint64)
+	// Forget drops the state of a given proxy, e.g. once it has disconnected.
+	Forget(proxyId ProxyId)
+}
+
+// IsPolicyDerived returns true if resources of a given type carry policies of a Mesh,
+// and therefore reach Dataplanes in waves of a rollout.
+func IsPolicyDerived(typeUrl string) bool {
+	switch typeUrl {
+	case envoy_cache.ListenerType, envoy_cache.RouteType:
+		return true
+	default:
+		return false
+	}
+}
+
+// minResponses is the number of HTTP responses that have to be reported by Dataplanes in a rollout
+// before the ratio of 5xx responses is taken into account, so that a single error doesn't halt a rollout.
+const minResponses = 100
+
+func NewPolicyRollout(config xds_config.PolicyRolloutConfig) PolicyRollout {
+	if !config.Enabled {
+		return &noopPolicyRollout{}
+	}
+	return &policyRollout{
+		config:    config,
+		rollouts:  make(map[string]*meshRollout),
+		positions: make(map[ProxyId]uint32),
+	}
+}
+
+var _ PolicyRollout = &noopPolicyRollout{}
+
+type noopPolicyRollout struct{}
+
+func (noopPolicyRollout) Start(string) {}
+
+func (noopPolicyRollout) Admitted(*Proxy) bool {
+	return true
+}
+
+func (noopPolicyRollout) RecordAck(ProxyId) {}
+
+func (noopPolicyRollout) RecordNack(ProxyId) {}
+
+func (noopPolicyRollout) RecordResponses(ProxyId, uint64, uint64) {}
+
+func (noopPolicyRollout) Forget(ProxyId) {}
+
+var _ PolicyRollout = &policyRollout{}
+
+type policyRollout struct {
+	config xds_config.PolicyRolloutConfig
+
+	mu       sync.Mutex // protects access to the fields below
+	rollouts map[string]*meshRollout
+	// positions of proxies in the order of a rollout, remembered when a proxy is admitted
+	// since ACKs and NACKs don't carry tags
+	positions map[ProxyId]uint32
+}
+
+type meshRollout struct {
+	started   time.Time
+	acks      int
+	nacks     int
+	responses uint64
+	errors    uint64
+	halted    bool
+	haltedAt  time.Time
+	// percentage of Dataplanes that had been part of a rollout when it was halted
+	haltedPercentage uint32
+}
+
+func (r *policyRollout) Start(mesh string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if previous, ok := r.rollouts[mesh]; ok && previous.halted {
+		rolloutLog.Info("restarting halted rollout of policies", "mesh", mesh)
+	}
+	r.rollouts[mesh] = &meshRollout{started: core.Now()}
+}
+
+func (r *policyRollout) Admitted(proxy *Proxy) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	position := r.rolloutPosition(proxy)
+	r.positions[proxy.Id] = position
+	rollout := r.active(proxy.Id.Mesh)
+	if rollout == nil {
+		return true
+	}
+	return position < r.percentage(rollout)
+}
+
+func (r *policyRollout) RecordAck(proxyId ProxyId) {
+	r.record(proxyId, func(rollout *meshRollout) {
+		rollout.acks++
+	})
+}
+
+func (r *policyRollout) RecordNack(proxyId ProxyId) {
+	r.record(proxyId, func(rollout *meshRollout) {
+		rollout.nacks++
+	})
+}
+
+func (r *policyRollout) RecordResponses(proxyId ProxyId, total uint64, errors uint64) {
+	r.record(proxyId, func(rollout *meshRollout) {
+		rollout.responses += total
+		rollout.errors += errors
+	})
+}
+
+func (r *policyRollout) Forget(proxyId ProxyId) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.positions, proxyId)
+}
+
+func (r *policyRollout) record(proxyId ProxyId, update func(*meshRollout)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rollout := r.active(proxyId.Mesh)
+	if rollout == nil || rollout.halted {
+		return
+	}
+	position, ok := r.positions[proxyId]
+	if !ok {
+		position = hashPosition(proxyId.String())
+	}
+	if position >= r.percentage(rollout) {
+		return
+	}
+	update(rollout)
+	if ratio := float64(rollout.nacks) / float64(rollout.acks+rollout.nacks); rollout.nacks > 0 && ratio > r.config.MaxNackRatio {
+		r.halt(proxyId.Mesh, rollout, "Dataplanes reject their config")
+		return
+	}
+	if ratio := float64(rollout.errors) / float64(rollout.responses); rollout.responses >= minResponses && ratio > r.config.MaxErrorRatio {
+		r.halt(proxyId.Mesh, rollout, "Dataplanes respond with 5xx")
+	}
+}
+
+func (r *policyRollout) halt(mesh string, rollout *meshRollout, reason string) {
+	rollout.haltedPercentage = r.percentage(rollout)
+	rollout.halted = true
+	rollout.haltedAt = core.Now()
+	rolloutLog.Info("halting rollout of policies since "+reason, "mesh", mesh, "percentage", rollout.haltedPercentage,
+		"acks", rollout.acks, "nacks", rollout.nacks, "responses", rollout.responses, "errors", rollout.errors)
+}
+
+// active returns a rollout in a given Mesh unless it has completed or has been aborted.
+func (r *policyRollout) active(mesh string) *meshRollout {
+	rollout, ok := r.rollouts[mesh]
+	if !ok {
+		return nil
+	}
+	if !rollout.halted && r.percentage(rollout) >= 100 {
+		delete(r.rollouts, mesh)
+		return nil
+	}
+	if rollout.halted && r.config.HaltTimeout > 0 && core.Now().Sub(rollout.haltedAt) >= r.config.HaltTimeout {
+		rolloutLog.Info("aborting halted rollout of policies", "mesh", mesh, "haltedAt", rollout.haltedAt)
+		delete(r.rollouts, mesh)
+		return nil
+	}
+	return rollout
+}
+
+// percentage returns a percentage of Dataplanes that are part of a given rollout.
+func (r *policyRollout) percentage(rollout *meshRollout) uint32 {
+	if rollout.halted {
+		return rollout.haltedPercentage
+	}
+	waves := uint64(core.Now().Sub(rollout.started)/r.config.WaveInterval) + 1
+	if percentage := waves * uint64(r.config.WavePercentage); percentage < 100 {
+		return uint32(percentage)
+	}
+	return 100
+}
+
+// rolloutPosition returns a stable position of a given proxy in the order of a rollout, in the range [0, 100).
+//
+// If Dataplanes are grouped into waves by a tag, all Dataplanes with the same value of the tag share a position.
+func (r *policyRollout) rolloutPosition(proxy *Proxy) uint32 {
+	if r.config.WaveTag != "" && proxy.Dataplane != nil {
+		if values := proxy.Dataplane.Spec.Tags().Values(r.config.WaveTag); len(values) > 0 {
+			return hashPosition(values[0])
+		}
+	}
+	return hashPosition(proxy.Id.String())
+}
+
+func hashPosition(key string) uint32 {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(key))
+	return hash.Sum32() % 100
+}
@@ -0,0 +1,257 @@
+package xds_test
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	xds_config "github.com/Kong/kuma/pkg/config/xds"
+	"github.com/Kong/kuma/pkg/core"
+	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
+)
+
+var _ = Describe("PolicyRollout", func() {
+
+	var now time.Time
+	var rollout core_xds.PolicyRollout
+
+	BeforeEach(func() {
+		now = time.Unix(1568000000, 0)
+		core.Now = func() time.Time {
+			return now
+		}
+		rollout = core_xds.NewPolicyRollout(xds_config.PolicyRolloutConfig{
+			Enabled:        true,
+			WavePercentage: 25,
+			WaveInterval:   30 * time.Second,
+			MaxNackRatio:   0.1,
+		})
+	})
+
+	AfterEach(func() {
+		core.Now = time.Now
+	})
+
+	var proxies []core_xds.ProxyId
+	for i := 0; i < 200; i++ {
+		proxies = append(proxies, core_xds.ProxyId{Mesh: "demo", Name: fmt.Sprintf("dp-%d", i), Namespace: "default"})
+	}
+
+	admitted := func() []core_xds.ProxyId {
+		var ids []core_xds.ProxyId
+		for _, proxy := range proxies {
+			if rollout.Admitted(&core_xds.Proxy{Id: proxy}) {
+				ids = append(ids, proxy)
+			}
+		}
+		return ids
+	}
+
+	It("should admit all proxies when there is no rollout", func() {
+		Expect(admitted()).To(HaveLen(len(proxies)))
+	})
+
+	It("should admit all proxies when rollout is disabled", func() {
+		// given
+		rollout = core_xds.NewPolicyRollout(*xds_config.DefaultPolicyRolloutConfig())
+
+		// when
+		rollout.Start("demo")
+
+		// then
+		Expect(admitted()).To(HaveLen(len(proxies)))
+	})
+
+	It("should admit proxies in waves", func() {
+		// when
+		rollout.Start("demo")
+		first := admitted()
+
+		// then
+		Expect(len(first)).To(BeNumerically(">", 0))
+		Expect(len(first)).To(BeNumerically("<", len(proxies)/2))
+
+		// when
+		now = now.Add(30 * time.Second)
+		second := admitted()
+
+		// then
+		Expect(len(second)).To(BeNumerically(">", len(first)))
+		for _, proxy := range first {
+			Expect(second).To(ContainElement(proxy))
+		}
+
+		// when
+		now = now.Add(90 * time.Second)
+
+		// then
+		Expect(admitted()).To(HaveLen(len(proxies)))
+	})
+
+	It("should admit the same proxies first in every rollout", func() {
+		// given
+		rollout.Start("demo")
+		first := admitted()
+		now = now.Add(5 * time.Minute)
+
+		// when
+		rollout.Start("demo")
+
+		// then
+		Expect(admitted()).To(Equal(first))
+	})
+
+	It("should not affect other meshes", func() {
+		// when
+		rollout.Start("another")
+
+		// then
+		Expect(admitted()).To(HaveLen(len(proxies)))
+	})
+
+	It("should halt once proxies reject their config", func() {
+		// given
+		rollout.Start("demo")
+		first := admitted()
+
+		// when
+		rollout.RecordNack(first[0])
+		now = now.Add(5 * time.Minute)
+
+		// then
+		Expect(admitted()).To(Equal(first))
+
+		// when the policy gets fixed
+		rollout.Start("demo")
+		now = now.Add(5 * time.Minute)
+
+		// then
+		Expect(admitted()).To(HaveLen(len(proxies)))
+	})
+
+	It("should tolerate occasional rejections", func() {
+		// given
+		rollout.Start("demo")
+		first := admitted()
+		Expect(len(first)).To(BeNumerically(">=", 10))
+
+		// when
+		for _, proxy := range first[1:10] {
+			rollout.RecordAck(proxy)
+		}
+		rollout.RecordNack(first[0])
+		now = now.Add(30 * time.Second)
+
+		// then
+		Expect(len(admitted())).To(BeNumerically(">", len(first)))
+	})
+
+	It("should only count rejections of proxies that are part of a rollout", func() {
+		// given
+		rollout.Start("demo")
+		first := admitted()
+
+		// when
+		for _, proxy := range proxies {
+			if !rollout.Admitted(&core_xds.Proxy{Id: proxy}) {
+				rollout.RecordNack(proxy)
+			}
+		}
+		now = now.Add(30 * time.Second)
+
+		// then
+		Expect(len(admitted())).To(BeNumerically(">", len(first)))
+	})
+
+	It("should halt once proxies respond with 5xx", func() {
+		// given
+		rollout.Start("demo")
+		first := admitted()
+
+		// when
+		rollout.RecordResponses(first[0], 80, 1)
+		now = now.Add(30 * time.Second)
+
+		// then too few responses to judge
+		Expect(len(admitted())).To(BeNumerically(">", len(first)))
+
+		// when
+		second := admitted()
+		rollout.RecordResponses(first[1], 100, 50)
+		now = now.Add(5 * time.Minute)
+
+		// then
+		Expect(admitted()).To(Equal(second))
+	})
+
+	It("should abort a halted rollout after a timeout", func() {
+		// given
+		rollout = core_xds.NewPolicyRollout(xds_config.PolicyRolloutConfig{
+			Enabled:        true,
+			WavePercentage: 25,
+			WaveInterval:   30 * time.Second,
+			MaxNackRatio:   0,
+			HaltTimeout:    10 * time.Minute,
+		})
+		rollout.Start("demo")
+		first := admitted()
+
+		// when
+		rollout.RecordNack(first[0])
+		now = now.Add(9 * time.Minute)
+
+		// then
+		Expect(admitted()).To(Equal(first))
+
+		// when
+		now = now.Add(1 * time.Minute)
+
+		// then
+		Expect(admitted()).To(HaveLen(len(proxies)))
+	})
+
+	It("should roll out a change to all proxies with the same value of a wave tag at once", func() {
+		// given
+		rollout = core_xds.NewPolicyRollout(xds_config.PolicyRolloutConfig{
+			Enabled:        true,
+			WavePercentage: 25,
+			WaveInterval:   30 * time.Second,
+			WaveTag:        "zone",
+		})
+		inZone := func(zone string) []*core_xds.Proxy {
+			var result []*core_xds.Proxy
+			for i := 0; i < 20; i++ {
+				result = append(result, &core_xds.Proxy{
+					Id: core_xds.ProxyId{Mesh: "demo", Name: fmt.Sprintf("dp-%s-%d", zone, i)},
+					Dataplane: &mesh_core.DataplaneResource{
+						Spec: mesh_proto.Dataplane{
+							Networking: &mesh_proto.Dataplane_Networking{
+								Inbound: []*mesh_proto.Dataplane_Networking_Inbound{{
+									Interface: "192.168.0.1:80:8080",
+									Tags:      map[string]string{"service": "web", "zone": zone},
+								}},
+							},
+						},
+					},
+				})
+			}
+			return result
+		}
+
+		// when
+		rollout.Start("demo")
+
+		// then
+		for _, zone := range []string{"us-east", "us-west", "eu-central", "ap-south"} {
+			var states []bool
+			for _, proxy := range inZone(zone) {
+				states = append(states, rollout.Admitted(proxy))
+			}
+			Expect(states).To(Or(Not(ContainElement(true)), Not(ContainElement(false))), "zone %s", zone)
+		}
+	})
+})
@@ -0,0 +1,77 @@
+package xds
+
+import (
+	"sync"
+
+	"github.com/Kong/kuma/pkg/core"
+)
+
+var (
+	streamsLog = core.Log.WithName("xds-streams")
+)
+
+// StreamTracker keeps track of xDS streams open by Dataplanes.
+//
+// It makes it possible to terminate xDS streams of a proxy that is stuck with stale config.
+// Once disconnected, the proxy reconnects and gets a fresh snapshot.
+type StreamTracker interface {
+	// Track registers an xDS stream of a given proxy that is terminated by calling disconnect.
+	// Returned func has to be called once the stream is closed.
+	Track(proxyId ProxyId, disconnect func()) (untrack func())
+	// Disconnect terminates all xDS streams of a given proxy and returns their number.
+	Disconnect(proxyId ProxyId) int
+}
+
+func NewStreamTracker() StreamTracker {
+	return &streamTracker{
+		streams: make(map[string]map[*trackedStream]struct{}),
+	}
+}
+
+var _ StreamTracker = &streamTracker{}
+
+type streamTracker struct {
+	mu      sync.Mutex // protects access to the fields below
+	streams map[string]map[*trackedStream]struct{}
+}
+
+type trackedStream struct {
+	disconnect func()
+}
+
+func (t *streamTracker) Track(proxyId ProxyId, disconnect func()) func() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	id := proxyId.String()
+	stream := &trackedStream{disconnect: disconnect}
+	if t.streams[id] == nil {
+		t.streams[id] = make(map[*trackedStream]struct{})
+	}
+	t.streams[id][stream] = struct{}{}
+	return func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		delete(t.streams[id], stream)
+		if len(t.streams[id]) == 0 {
+			delete(t.streams, id)
+		}
+	}
+}
+
+func (t *streamTracker) Disconnect(proxyId ProxyId) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	id := proxyId.String()
+	streams := t.streams[id]
+	for stream := range streams {
+		stream.disconnect()
+	}
+	delete(t.streams, id)
+	if len(streams) > 0 {
+		streamsLog.Info("disconnected xDS streams", "proxyId", id, "streams", len(streams))
+	}
+	return len(streams)
+}
@@ -0,0 +1,59 @@
+package xds_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
+)
+
+var _ = Describe("StreamTracker", func() {
+
+	backend := core_xds.ProxyId{Mesh: "demo", Name: "backend-01", Namespace: "default"}
+	web := core_xds.ProxyId{Mesh: "demo", Name: "web-01", Namespace: "default"}
+
+	It("should disconnect all streams of a proxy", func() {
+		// given
+		tracker := core_xds.NewStreamTracker()
+		disconnected := map[string]int{}
+		disconnect := func(name string) func() {
+			return func() {
+				disconnected[name]++
+			}
+		}
+		tracker.Track(backend, disconnect("backend-ads"))
+		tracker.Track(backend, disconnect("backend-sds"))
+		tracker.Track(web, disconnect("web-ads"))
+
+		// when
+		count := tracker.Disconnect(backend)
+
+		// then
+		Expect(count).To(Equal(2))
+		Expect(disconnected).To(Equal(map[string]int{"backend-ads": 1, "backend-sds": 1}))
+
+		// when
+		count = tracker.Disconnect(backend)
+
+		// then
+		Expect(count).To(Equal(0))
+		Expect(disconnected).To(Equal(map[string]int{"backend-ads": 1, "backend-sds": 1}))
+	})
+
+	It("should not disconnect streams that have been closed", func() {
+		// given
+		tracker := core_xds.NewStreamTracker()
+		disconnected := false
+		untrack := tracker.Track(backend, func() {
+			disconnected = true
+		})
+
+		// when
+		untrack()
+		count := tracker.Disconnect(backend)
+
+		// then
+		Expect(count).To(Equal(0))
+		Expect(disconnected).To(BeFalse())
+	})
+})
@@ -5,6 +5,7 @@ import (
 	"net"
 	"strings"
 
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
 	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
 	core_model "github.com/Kong/kuma/pkg/core/resources/model"
 
@@ -28,6 +29,10 @@ type Proxy struct {
 	Dataplane          *mesh_core.DataplaneResource
 	TrafficPermissions *mesh_core.TrafficPermissionResourceList
 	OutboundTargets    map[string][]net.SRV
+	// ProxyTemplate that Envoy config is generated from, or nil if it has not been resolved yet.
+	ProxyTemplate *mesh_proto.ProxyTemplate
+	// Metadata that Envoy has sent on its xDS stream, or nil if there is none.
+	Metadata *DataplaneMetadata
 }
 
 func BuildProxyId(mesh, name string, more ...string) (*ProxyId, error) {
@@ -0,0 +1,22 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics is a registry of metrics exposed by the Control Plane.
+type Metrics interface {
+	prometheus.Registerer
+	prometheus.Gatherer
+}
+
+func NewMetrics() (Metrics, error) {
+	registry := prometheus.NewRegistry()
+	if err := registry.Register(prometheus.NewGoCollector()); err != nil {
+		return nil, err
+	}
+	if err := registry.Register(prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{})); err != nil {
+		return nil, err
+	}
+	return registry, nil
+}
@@ -0,0 +1,110 @@
+package consul
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/Kong/kuma/pkg/config"
+)
+
+// catalogClient queries the Consul HTTP API.
+//
+// Queries are blocking, i.e. they return once the result has changed since the given index
+// or once the wait time has elapsed, see https://www.consul.io/api/features/blocking.html
+type catalogClient struct {
+	client     *http.Client
+	address    string
+	token      config.Secret
+	datacenter string
+	waitTime   time.Duration
+}
+
+// serviceEntry is an instance of a service along with the node it is registered on.
+type serviceEntry struct {
+	Node struct {
+		Node    string `json:"Node"`
+		Address string `json:"Address"`
+	} `json:"Node"`
+	Service struct {
+		ID          string            `json:"ID"`
+		Service     string            `json:"Service"`
+		Tags        []string          `json:"Tags"`
+		Address     string            `json:"Address"`
+		Port        int               `json:"Port"`
+		Meta        map[string]string `json:"Meta"`
+		ModifyIndex uint64            `json:"ModifyIndex"`
+	} `json:"Service"`
+}
+
+// Services returns names of all services registered in the catalog.
+func (c *catalogClient) Services(ctx context.Context, index uint64) ([]string, uint64, error) {
+	services := map[string][]string{}
+	newIndex, err := c.get(ctx, "/v1/catalog/services", url.Values{}, index, &services)
+	if err != nil {
+		return nil, 0, err
+	}
+	names := make([]string, 0, len(services))
+	for name := range services {
+		names = append(names, name)
+	}
+	return names, newIndex, nil
+}
+
+// PassingInstances returns instances of a service whose health checks are all passing.
+func (c *catalogClient) PassingInstances(ctx context.Context, service string, index uint64) ([]serviceEntry, uint64, error) {
+	var entries []serviceEntry
+	newIndex, err := c.get(ctx, "/v1/health/service/"+url.PathEscape(service), url.Values{"passing": []string{"true"}}, index, &entries)
+	if err != nil {
+		return nil, 0, err
+	}
+	return entries, newIndex, nil
+}
+
+func (c *catalogClient) get(ctx context.Context, path string, query url.Values, index uint64, result interface{}) (uint64, error) {
+	if c.datacenter != "" {
+		query.Set("dc", c.datacenter)
+	}
+	if index > 0 {
+		query.Set("index", strconv.FormatUint(index, 10))
+		query.Set("wait", fmt.Sprintf("%dms", c.waitTime/time.Millisecond))
+	}
+	req, err := http.NewRequest("GET", c.address+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return 0, err
+	}
+	token, err := c.token.Value()
+	if err != nil {
+		return 0, errors.Wrap(err, "could not read the ACL token")
+	}
+	if token != "" {
+		req.Header.Set("X-Consul-Token", token)
+	}
+	resp, err := c.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, errors.Errorf("(%d): %s", resp.StatusCode, string(body))
+	}
+	newIndex, err := strconv.ParseUint(resp.Header.Get("X-Consul-Index"), 10, 64)
+	if err != nil {
+		return 0, errors.Wrap(err, "Consul responded without a valid X-Consul-Index header")
+	}
+	if err := json.Unmarshal(body, result); err != nil {
+		return 0, errors.Wrapf(err, "could not parse response of %s", path)
+	}
+	return newIndex, nil
+}
@@ -0,0 +1,89 @@
+package consul
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+)
+
+// toDataplane translates an instance of a Consul service into a Dataplane.
+//
+// Tags of the Dataplane are made of metadata of the instance and of Consul tags
+// in the format key=value. Consul tags without a value are ignored.
+func toDataplane(mesh string, entry serviceEntry) (*mesh_core.DataplaneResource, error) {
+	address := entry.Service.Address
+	if address == "" {
+		address = entry.Node.Address
+	}
+	ip, err := mesh_proto.ParseIP(address)
+	if err != nil {
+		return nil, errors.Wrapf(err, "instance %q of service %q has no IP address", entry.Service.ID, entry.Service.Service)
+	}
+	port, err := mesh_proto.ParsePort(strconv.Itoa(entry.Service.Port))
+	if err != nil {
+		return nil, errors.Wrapf(err, "instance %q of service %q has no valid port", entry.Service.ID, entry.Service.Service)
+	}
+
+	tags := map[string]string{}
+	for _, tag := range entry.Service.Tags {
+		if parts := strings.SplitN(tag, "=", 2); len(parts) == 2 && parts[0] != "" {
+			tags[parts[0]] = parts[1]
+		}
+	}
+	for key, value := range entry.Service.Meta {
+		tags[key] = value
+	}
+	tags[mesh_proto.ServiceTag] = entry.Service.Service
+
+	return &mesh_core.DataplaneResource{
+		Meta: &consulMeta{
+			Name:    fmt.Sprintf("%s.%s", entry.Service.ID, entry.Node.Node),
+			Mesh:    mesh,
+			Version: strconv.FormatUint(entry.Service.ModifyIndex, 10),
+		},
+		Spec: mesh_proto.Dataplane{
+			Networking: &mesh_proto.Dataplane_Networking{
+				Inbound: []*mesh_proto.Dataplane_Networking_Inbound{{
+					Interface: mesh_proto.InboundInterface{DataplaneIP: ip, DataplanePort: port, WorkloadPort: port}.String(),
+					Tags:      tags,
+				}},
+			},
+		},
+	}, nil
+}
+
+var _ core_model.ResourceMeta = &consulMeta{}
+
+type consulMeta struct {
+	Name    string
+	Mesh    string
+	Version string
+}
+
+func (m *consulMeta) GetName() string {
+	return m.Name
+}
+
+// GetNamespace returns the namespace of resources in Universal mode,
+// so that discovered Dataplanes can be looked up through the API Server like any other.
+func (m *consulMeta) GetNamespace() string {
+	return core_model.DefaultNamespace
+}
+
+func (m *consulMeta) GetVersion() string {
+	return m.Version
+}
+
+func (m *consulMeta) GetMesh() string {
+	return m.Mesh
+}
+
+func (m *consulMeta) GetLabels() map[string]string {
+	return nil
+}
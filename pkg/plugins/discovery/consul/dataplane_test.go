@@ -0,0 +1,81 @@
+package consul
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+)
+
+func newEntry(node, nodeAddress, id, service, address string, port int) serviceEntry {
+	entry := serviceEntry{}
+	entry.Node.Node = node
+	entry.Node.Address = nodeAddress
+	entry.Service.ID = id
+	entry.Service.Service = service
+	entry.Service.Address = address
+	entry.Service.Port = port
+	return entry
+}
+
+var _ = Describe("toDataplane", func() {
+
+	It("should translate an instance of a service into a Dataplane", func() {
+		// given
+		entry := newEntry("node-1", "10.0.0.1", "web-1", "web", "192.168.0.1", 8080)
+		entry.Service.Tags = []string{"version=v1", "primary", "region=eu"}
+		entry.Service.Meta = map[string]string{"region": "us"}
+		entry.Service.ModifyIndex = 42
+
+		// when
+		dataplane, err := toDataplane("demo", entry)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dataplane.GetMeta().GetName()).To(Equal("web-1.node-1"))
+		Expect(dataplane.GetMeta().GetMesh()).To(Equal("demo"))
+		Expect(dataplane.GetMeta().GetVersion()).To(Equal("42"))
+		Expect(dataplane.Spec.Networking.Inbound).To(Equal([]*mesh_proto.Dataplane_Networking_Inbound{{
+			Interface: "192.168.0.1:8080:8080",
+			Tags: map[string]string{
+				"service": "web",
+				"version": "v1",
+				"region":  "us", // metadata takes precedence over tags
+			},
+		}}))
+	})
+
+	It("should fall back to the address of the node", func() {
+		// given
+		entry := newEntry("node-1", "fd00::1", "web-1", "web", "", 8080)
+
+		// when
+		dataplane, err := toDataplane("demo", entry)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dataplane.Spec.Networking.Inbound[0].Interface).To(Equal("[fd00::1]:8080:8080"))
+	})
+
+	It("should refuse an instance without an IP address", func() {
+		// given
+		entry := newEntry("node-1", "node-1.local", "web-1", "web", "", 8080)
+
+		// when
+		_, err := toDataplane("demo", entry)
+
+		// then
+		Expect(err).To(MatchError(`instance "web-1" of service "web" has no IP address: "node-1.local" is not a valid IP address`))
+	})
+
+	It("should refuse an instance without a port", func() {
+		// given
+		entry := newEntry("node-1", "10.0.0.1", "web-1", "web", "", 0)
+
+		// when
+		_, err := toDataplane("demo", entry)
+
+		// then
+		Expect(err).To(MatchError(`instance "web-1" of service "web" has no valid port: port number must be in the range [1, 65535] but got 0`))
+	})
+})
@@ -0,0 +1,42 @@
+package consul
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	consul_config "github.com/Kong/kuma/pkg/config/plugins/discovery/consul"
+	core_discovery "github.com/Kong/kuma/pkg/core/discovery"
+	core_plugins "github.com/Kong/kuma/pkg/core/plugins"
+)
+
+var _ core_plugins.DiscoveryPlugin = &plugin{}
+
+type plugin struct{}
+
+func init() {
+	core_plugins.Register(core_plugins.Consul, &plugin{})
+}
+
+func (p *plugin) NewDiscoverySource(pc core_plugins.PluginContext, config core_plugins.PluginConfig) (core_discovery.DiscoverySource, error) {
+	cfg, ok := config.(*consul_config.ConsulDiscoveryConfig)
+	if !ok {
+		return nil, errors.Errorf("wrong type of configuration. Expected: *consul_config.ConsulDiscoveryConfig, got: %T", config)
+	}
+	client := &catalogClient{
+		// a blocking query may take up to the wait time plus a random jitter of up to 1/16 of it
+		client:     &http.Client{Transport: pc.EgressTransport(), Timeout: cfg.WaitTime + cfg.WaitTime/16 + cfg.RetryInterval},
+		address:    cfg.Address,
+		token:      cfg.TokenSecret(),
+		datacenter: cfg.Datacenter,
+		waitTime:   cfg.WaitTime,
+	}
+	heartbeat := pc.Supervisor().Heartbeat("consul-discovery")
+	source := newConsulSource(client, cfg.Mesh, cfg.RetryInterval, heartbeat)
+	if err := pc.ComponentManager().Add(pc.Supervisor().Restarting(heartbeat, source)); err != nil {
+		return nil, errors.Errorf("could not add Consul discovery source component to the component manager")
+	}
+	// instances of Consul services become Dataplanes only once they are in the ResourceStore
+	source.AddConsumer(newStoreConsumer(pc.ResourceManager()))
+	return source, nil
+}
@@ -0,0 +1,216 @@
+package consul
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Kong/kuma/pkg/core"
+	core_discovery "github.com/Kong/kuma/pkg/core/discovery"
+	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	core_runtime "github.com/Kong/kuma/pkg/core/runtime"
+	"github.com/Kong/kuma/pkg/core/runtime/supervisor"
+)
+
+var log = core.Log.WithName("discovery").WithName("consul")
+
+// consulServiceName is the name under which Consul registers its own servers.
+const consulServiceName = "consul"
+
+var _ core_runtime.Component = &consulSource{}
+
+// consulSource watches the Consul catalog and translates instances of services
+// whose health checks are passing into Dataplanes.
+//
+// The list of services and instances of every service are watched by separate blocking queries,
+// since changes of health checks do not change the index of the list of services.
+type consulSource struct {
+	client        *catalogClient
+	mesh          string
+	retryInterval time.Duration
+	// heartbeat is reported while the source keeps delivering changes, so that it is restarted once it gets stuck
+	heartbeat *supervisor.Heartbeat
+
+	// mu makes an instance that is abandoned on restart finish its delivery before a new instance starts one
+	mu sync.Mutex
+	// dataplanes maps services to Dataplanes that have been delivered to consumers
+	dataplanes map[string]map[core_model.ResourceKey]*mesh_core.DataplaneResource
+	core_discovery.StatefulDiscoverySink
+}
+
+type serviceUpdate struct {
+	watcher    *serviceWatcher
+	dataplanes map[core_model.ResourceKey]*mesh_core.DataplaneResource
+}
+
+type serviceWatcher struct {
+	service string
+	cancel  context.CancelFunc
+}
+
+func newConsulSource(client *catalogClient, mesh string, retryInterval time.Duration, heartbeat *supervisor.Heartbeat) *consulSource {
+	return &consulSource{
+		client:        client,
+		mesh:          mesh,
+		retryInterval: retryInterval,
+		heartbeat:     heartbeat,
+		dataplanes:    map[string]map[core_model.ResourceKey]*mesh_core.DataplaneResource{},
+	}
+}
+
+func (s *consulSource) Start(stop <-chan struct{}) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-stop
+		cancel()
+	}()
+
+	services := make(chan []string)
+	updates := make(chan serviceUpdate)
+	watchers := map[string]*serviceWatcher{}
+	go s.watchServices(ctx, services)
+	// blocking queries may take longer than the heartbeat timeout, so an idle source beats on its own
+	ticker := time.NewTicker(s.heartbeat.Timeout() / 2)
+	defer ticker.Stop()
+	for {
+		s.heartbeat.Beat()
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		case names := <-services:
+			registered := map[string]bool{}
+			for _, name := range names {
+				if name == consulServiceName {
+					continue
+				}
+				registered[name] = true
+				if _, ok := watchers[name]; !ok {
+					watcherCtx, cancelWatcher := context.WithCancel(ctx)
+					watcher := &serviceWatcher{service: name, cancel: cancelWatcher}
+					watchers[name] = watcher
+					go s.watchInstances(watcherCtx, watcher, updates)
+				}
+			}
+			for name, watcher := range watchers {
+				if !registered[name] {
+					watcher.cancel()
+					delete(watchers, name)
+					s.sync(name, nil)
+				}
+			}
+		case update := <-updates:
+			// ignore updates of watchers that have been cancelled in the meantime
+			if watchers[update.watcher.service] != update.watcher {
+				continue
+			}
+			s.sync(update.watcher.service, update.dataplanes)
+		}
+	}
+}
+
+// watchServices sends names of services every time the list of services might have changed.
+func (s *consulSource) watchServices(ctx context.Context, services chan<- []string) {
+	var index uint64
+	for {
+		names, newIndex, err := s.client.Services(ctx, index)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Error(err, "could not list services in the Consul catalog")
+			if !s.sleep(ctx) {
+				return
+			}
+			continue
+		}
+		index = nextIndex(index, newIndex)
+		select {
+		case services <- names:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// watchInstances sends Dataplanes of a service every time its instances might have changed.
+func (s *consulSource) watchInstances(ctx context.Context, watcher *serviceWatcher, updates chan<- serviceUpdate) {
+	var index uint64
+	for {
+		entries, newIndex, err := s.client.PassingInstances(ctx, watcher.service, index)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Error(err, "could not list instances of a service in the Consul catalog", "service", watcher.service)
+			if !s.sleep(ctx) {
+				return
+			}
+			continue
+		}
+		index = nextIndex(index, newIndex)
+		dataplanes := map[core_model.ResourceKey]*mesh_core.DataplaneResource{}
+		for _, entry := range entries {
+			dataplane, err := toDataplane(s.mesh, entry)
+			if err != nil {
+				log.Info("skipping an instance of a service", "reason", err.Error())
+				continue
+			}
+			dataplanes[core_model.MetaToResourceKey(dataplane.GetMeta())] = dataplane
+		}
+		select {
+		case updates <- serviceUpdate{watcher: watcher, dataplanes: dataplanes}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// sync delivers changes between Dataplanes of a service that have been delivered so far and the current ones.
+func (s *consulSource) sync(service string, dataplanes map[core_model.ResourceKey]*mesh_core.DataplaneResource) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	previous := s.dataplanes[service]
+	for key, dataplane := range dataplanes {
+		if old, ok := previous[key]; ok && old.Spec.Equal(&dataplane.Spec) {
+			continue
+		}
+		if err := s.OnDataplaneUpdate(dataplane); err != nil {
+			log.Error(err, "OnDataplaneUpdate callback returned an error", "dataplane", key)
+		}
+	}
+	for key := range previous {
+		if _, ok := dataplanes[key]; ok {
+			continue
+		}
+		if err := s.OnDataplaneDelete(key); err != nil {
+			log.Error(err, "OnDataplaneDelete callback returned an error", "dataplane", key)
+		}
+	}
+	if len(dataplanes) == 0 {
+		delete(s.dataplanes, service)
+	} else {
+		s.dataplanes[service] = dataplanes
+	}
+}
+
+// sleep waits before a retry and returns false if the source has been stopped in the meantime.
+func (s *consulSource) sleep(ctx context.Context) bool {
+	select {
+	case <-time.After(s.retryInterval):
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// nextIndex returns the index for the next blocking query.
+// Index is reset if it goes backwards, e.g. after the Consul cluster has been restored from a snapshot.
+func nextIndex(index, newIndex uint64) uint64 {
+	if newIndex < index {
+		return 0
+	}
+	return newIndex
+}
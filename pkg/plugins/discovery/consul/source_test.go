@@ -0,0 +1,183 @@
+package consul
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kong/kuma/pkg/config"
+	watchdog_config "github.com/Kong/kuma/pkg/config/core/watchdog"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	"github.com/Kong/kuma/pkg/core/runtime/supervisor"
+	test_discovery "github.com/Kong/kuma/pkg/test/discovery"
+)
+
+// fakeConsul serves the list of services and their passing instances with support for blocking queries.
+type fakeConsul struct {
+	mu        sync.Mutex
+	index     uint64
+	changed   chan struct{}
+	instances map[string][]serviceEntry
+	token     string
+}
+
+func newFakeConsul() *fakeConsul {
+	return &fakeConsul{
+		index:     1,
+		changed:   make(chan struct{}),
+		instances: map[string][]serviceEntry{},
+	}
+}
+
+func (f *fakeConsul) SetInstances(service string, entries ...serviceEntry) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if entries == nil {
+		delete(f.instances, service)
+	} else {
+		f.instances[service] = entries
+	}
+	f.index++
+	close(f.changed)
+	f.changed = make(chan struct{})
+}
+
+func (f *fakeConsul) ServeHTTP(writer http.ResponseWriter, req *http.Request) {
+	f.mu.Lock()
+	changed := f.changed
+	current := f.index
+	f.token = req.Header.Get("X-Consul-Token")
+	f.mu.Unlock()
+
+	if index, _ := strconv.ParseUint(req.URL.Query().Get("index"), 10, 64); index >= current {
+		wait, _ := time.ParseDuration(req.URL.Query().Get("wait"))
+		select {
+		case <-changed:
+		case <-time.After(wait):
+		case <-req.Context().Done():
+			return
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var result interface{}
+	switch {
+	case req.URL.Path == "/v1/catalog/services":
+		services := map[string][]string{consulServiceName: {}}
+		for name := range f.instances {
+			services[name] = []string{}
+		}
+		result = services
+	case strings.HasPrefix(req.URL.Path, "/v1/health/service/"):
+		Expect(req.URL.Query().Get("passing")).To(Equal("true"))
+		entries := f.instances[strings.TrimPrefix(req.URL.Path, "/v1/health/service/")]
+		if entries == nil {
+			entries = []serviceEntry{}
+		}
+		result = entries
+	default:
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	writer.Header().Set("X-Consul-Index", strconv.FormatUint(f.index, 10))
+	Expect(json.NewEncoder(writer).Encode(result)).To(Succeed())
+}
+
+func (f *fakeConsul) Token() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.token
+}
+
+var _ = Describe("Consul source", func() {
+
+	var consul *fakeConsul
+	var server *httptest.Server
+	var consumer *test_discovery.StateConsumer
+	var stop chan struct{}
+
+	BeforeEach(func() {
+		consul = newFakeConsul()
+		server = httptest.NewServer(consul)
+		source := newConsulSource(&catalogClient{
+			client:   server.Client(),
+			address:  server.URL,
+			token:    config.NewSecret("secret", ""),
+			waitTime: time.Second,
+		}, "demo", 10*time.Millisecond, supervisor.NewSupervisor(*watchdog_config.DefaultWatchdogConfig()).Heartbeat("consul-discovery"))
+		consumer = &test_discovery.StateConsumer{}
+		source.AddConsumer(consumer)
+
+		stop = make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			Expect(source.Start(stop)).To(Succeed())
+		}()
+	})
+
+	AfterEach(func() {
+		close(stop)
+		server.Close()
+	})
+
+	key := func(name string) core_model.ResourceKey {
+		return core_model.ResourceKey{Mesh: "demo", Namespace: core_model.DefaultNamespace, Name: name}
+	}
+
+	It("should follow instances of services as they come and go", func() {
+		// when
+		web1 := newEntry("node-1", "10.0.0.1", "web-1", "web", "", 8080)
+		web2 := newEntry("node-2", "10.0.0.2", "web-2", "web", "", 8080)
+		consul.SetInstances("web", web1, web2)
+
+		// then
+		Eventually(consumer.State).Should(Equal(test_discovery.State{
+			key("web-1.node-1"): "0",
+			key("web-2.node-2"): "0",
+		}))
+		Expect(consul.Token()).To(Equal("secret"))
+
+		// when an instance stops passing health checks
+		consul.SetInstances("web", web1)
+
+		// then
+		Eventually(consumer.State).Should(Equal(test_discovery.State{
+			key("web-1.node-1"): "0",
+		}))
+
+		// when an instance is re-registered with a different port
+		web1.Service.Port = 9090
+		web1.Service.ModifyIndex = 7
+		consul.SetInstances("web", web1)
+
+		// then
+		Eventually(consumer.State).Should(Equal(test_discovery.State{
+			key("web-1.node-1"): "7",
+		}))
+
+		// when another service is registered
+		consul.SetInstances("backend", newEntry("node-1", "10.0.0.1", "backend-1", "backend", "", 5000))
+
+		// then
+		Eventually(consumer.State).Should(Equal(test_discovery.State{
+			key("web-1.node-1"):     "7",
+			key("backend-1.node-1"): "0",
+		}))
+
+		// when a service is deregistered
+		consul.SetInstances("web")
+
+		// then
+		Eventually(consumer.State).Should(Equal(test_discovery.State{
+			key("backend-1.node-1"): "0",
+		}))
+	})
+})
@@ -0,0 +1,72 @@
+package consul
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	core_discovery "github.com/Kong/kuma/pkg/core/discovery"
+	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+)
+
+var discoveredByConsul = map[string]string{mesh_core.DiscoveredByLabel: "consul"}
+
+var _ core_discovery.DiscoveryConsumer = &storeConsumer{}
+
+// storeConsumer keeps Dataplanes discovered in the Consul catalog in the ResourceStore,
+// so that they take part in the Mesh like any other Dataplane.
+//
+// Dataplanes that have not been created by Consul discovery are never overwritten or deleted.
+type storeConsumer struct {
+	resManager core_manager.ResourceManager
+}
+
+func newStoreConsumer(resManager core_manager.ResourceManager) *storeConsumer {
+	return &storeConsumer{resManager: resManager}
+}
+
+func (c *storeConsumer) OnDataplaneUpdate(dataplane *mesh_core.DataplaneResource) error {
+	ctx := context.Background()
+	key := core_model.MetaToResourceKey(dataplane.GetMeta())
+	existing := &mesh_core.DataplaneResource{}
+	if err := c.resManager.Get(ctx, existing, core_store.GetBy(key)); err != nil {
+		if !core_store.IsResourceNotFound(err) {
+			return err
+		}
+		created := &mesh_core.DataplaneResource{Spec: dataplane.Spec}
+		return c.resManager.Create(ctx, created, core_store.CreateBy(key), core_store.CreateWithLabels(discoveredByConsul))
+	}
+	if !isDiscoveredByConsul(existing) {
+		return errors.Errorf("Dataplane %q in mesh %q has not been discovered in Consul and cannot be replaced", key.Name, key.Mesh)
+	}
+	if existing.Spec.Equal(&dataplane.Spec) {
+		return nil
+	}
+	existing.Spec = dataplane.Spec
+	return c.resManager.Update(ctx, existing)
+}
+
+func (c *storeConsumer) OnDataplaneDelete(key core_model.ResourceKey) error {
+	ctx := context.Background()
+	existing := &mesh_core.DataplaneResource{}
+	if err := c.resManager.Get(ctx, existing, core_store.GetBy(key)); err != nil {
+		if core_store.IsResourceNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if !isDiscoveredByConsul(existing) {
+		return nil
+	}
+	if err := c.resManager.Delete(ctx, existing, core_store.DeleteBy(key)); err != nil && !core_store.IsResourceNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func isDiscoveredByConsul(dataplane *mesh_core.DataplaneResource) bool {
+	return dataplane.GetMeta().GetLabels()[mesh_core.DiscoveredByLabel] == discoveredByConsul[mesh_core.DiscoveredByLabel]
+}
@@ -0,0 +1,99 @@
+package consul
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+)
+
+var _ = Describe("storeConsumer", func() {
+
+	var resManager core_manager.ResourceManager
+	var consumer *storeConsumer
+
+	key := core_model.ResourceKey{Mesh: "demo", Namespace: core_model.DefaultNamespace, Name: "web-1.node-1"}
+
+	BeforeEach(func() {
+		resManager = core_manager.NewResourceManager(memory.NewStore())
+		consumer = newStoreConsumer(resManager)
+		err := resManager.Create(context.Background(), &mesh_core.MeshResource{}, core_store.CreateByKey(core_model.DefaultNamespace, "demo", "demo"))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	discovered := func(address string) *mesh_core.DataplaneResource {
+		dataplane, err := toDataplane("demo", newEntry("node-1", address, "web-1", "web", "", 8080))
+		Expect(err).ToNot(HaveOccurred())
+		return dataplane
+	}
+
+	get := func() (*mesh_core.DataplaneResource, error) {
+		dataplane := &mesh_core.DataplaneResource{}
+		return dataplane, resManager.Get(context.Background(), dataplane, core_store.GetBy(key))
+	}
+
+	It("should create, update and delete a discovered Dataplane", func() {
+		// when
+		Expect(consumer.OnDataplaneUpdate(discovered("10.0.0.1"))).To(Succeed())
+
+		// then
+		dataplane, err := get()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dataplane.Spec.Networking.Inbound[0].Interface).To(Equal("10.0.0.1:8080:8080"))
+		Expect(dataplane.GetMeta().GetLabels()).To(Equal(map[string]string{mesh_core.DiscoveredByLabel: "consul"}))
+
+		// when
+		Expect(consumer.OnDataplaneUpdate(discovered("10.0.0.2"))).To(Succeed())
+
+		// then
+		dataplane, err = get()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dataplane.Spec.Networking.Inbound[0].Interface).To(Equal("10.0.0.2:8080:8080"))
+
+		// when
+		Expect(consumer.OnDataplaneDelete(key)).To(Succeed())
+
+		// then
+		_, err = get()
+		Expect(core_store.IsResourceNotFound(err)).To(BeTrue())
+
+		// and a repeated deletion is not an error
+		Expect(consumer.OnDataplaneDelete(key)).To(Succeed())
+	})
+
+	It("should not touch a Dataplane that has not been discovered in Consul", func() {
+		// given
+		existing := &mesh_core.DataplaneResource{
+			Spec: mesh_proto.Dataplane{
+				Networking: &mesh_proto.Dataplane_Networking{
+					Inbound: []*mesh_proto.Dataplane_Networking_Inbound{{
+						Interface: "192.168.0.1:80:8080",
+						Tags:      map[string]string{mesh_proto.ServiceTag: "web"},
+					}},
+				},
+			},
+		}
+		Expect(resManager.Create(context.Background(), existing, core_store.CreateBy(key))).To(Succeed())
+
+		// when
+		err := consumer.OnDataplaneUpdate(discovered("10.0.0.1"))
+
+		// then
+		Expect(err).To(MatchError(`Dataplane "web-1.node-1" in mesh "demo" has not been discovered in Consul and cannot be replaced`))
+
+		// when
+		Expect(consumer.OnDataplaneDelete(key)).To(Succeed())
+
+		// then
+		dataplane, err := get()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dataplane.Spec.Networking.Inbound[0].Interface).To(Equal("192.168.0.1:80:8080"))
+	})
+})
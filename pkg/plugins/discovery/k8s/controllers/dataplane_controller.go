@@ -8,6 +8,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
@@ -23,8 +24,11 @@ import (
 type DataplaneReconciler struct {
 	client.Client
 	Converter k8s_resources.Converter
-	core_discovery.DiscoverySink
-	Log logr.Logger
+	Sink      core_discovery.DataplaneDiscoveryConsumer
+	// Retries are Dataplanes to reconcile again, e.g. because Sink has failed to handle their changes in the background.
+	// Optional.
+	Retries <-chan event.GenericEvent
+	Log     logr.Logger
 }
 
 func (r *DataplaneReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
@@ -35,7 +39,7 @@ func (r *DataplaneReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	crd := &mesh_k8s.Dataplane{}
 	if err := r.Get(ctx, req.NamespacedName, crd); err != nil {
 		if apierrs.IsNotFound(err) {
-			return ctrl.Result{}, r.DiscoverySink.OnDataplaneDelete(core_model.ResourceKey{
+			return ctrl.Result{}, r.Sink.OnDataplaneDelete(core_model.ResourceKey{
 				Namespace: req.NamespacedName.Namespace,
 				Name:      req.NamespacedName.Name,
 			})
@@ -48,7 +52,7 @@ func (r *DataplaneReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	if err := r.Converter.ToCoreResource(crd, dataplane); err != nil {
 		return ctrl.Result{}, err
 	} else {
-		return ctrl.Result{}, r.DiscoverySink.OnDataplaneUpdate(dataplane)
+		return ctrl.Result{}, r.Sink.OnDataplaneUpdate(dataplane)
 	}
 }
 
@@ -56,13 +60,16 @@ func (r *DataplaneReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	if err := mesh_k8s.AddToScheme(mgr.GetScheme()); err != nil {
 		return err
 	}
-	return ctrl.NewControllerManagedBy(mgr).
+	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&mesh_k8s.Dataplane{}).
 		// on ProxyTemplate update reconcile affected Dataplanes
 		Watches(&source.Kind{Type: &mesh_k8s.ProxyTemplate{}}, &handler.EnqueueRequestsFromMapFunc{
 			ToRequests: &ProxyTemplateToDataplanesMapper{Client: mgr.GetClient()},
-		}).
-		Complete(r)
+		})
+	if r.Retries != nil {
+		builder = builder.Watches(&source.Channel{Source: r.Retries}, &handler.EnqueueRequestForObject{})
+	}
+	return builder.Complete(r)
 }
 
 type ProxyTemplateToDataplanesMapper struct {
@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mesh_k8s "github.com/Kong/kuma/pkg/plugins/resources/k8s/native/api/v1alpha1"
+)
+
+// MeshCleanupFinalizer makes Kubernetes keep a Mesh until resources that belong to it are deleted.
+const MeshCleanupFinalizer = "kuma.io/mesh-cleanup"
+
+// MeshCleaner deletes resources that belong to a Mesh.
+type MeshCleaner interface {
+	DeleteResources(ctx context.Context, mesh string) error
+	DeleteSecrets(ctx context.Context, mesh string) error
+}
+
+// MeshReconciler reconciles a Mesh object.
+//
+// It adds a finalizer to every Mesh, so that resources of a Mesh get deleted
+// even if the Mesh is deleted with kubectl rather than through the API Server.
+type MeshReconciler struct {
+	client.Client
+	Cleaner MeshCleaner
+	Log     logr.Logger
+}
+
+func (r *MeshReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.Background()
+	log := r.Log.WithValues("mesh", req.NamespacedName)
+
+	// Fetch the Mesh instance
+	mesh := &mesh_k8s.Mesh{}
+	if err := r.Get(ctx, req.NamespacedName, mesh); err != nil {
+		if apierrs.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "unable to fetch Mesh")
+		return ctrl.Result{}, err
+	}
+
+	if mesh.DeletionTimestamp.IsZero() {
+		if containsString(mesh.Finalizers, MeshCleanupFinalizer) {
+			return ctrl.Result{}, nil
+		}
+		mesh.Finalizers = append(mesh.Finalizers, MeshCleanupFinalizer)
+		return ctrl.Result{}, r.Update(ctx, mesh)
+	}
+
+	if !containsString(mesh.Finalizers, MeshCleanupFinalizer) {
+		return ctrl.Result{}, nil
+	}
+	if err := r.Cleaner.DeleteResources(ctx, mesh.Name); err != nil {
+		log.Error(err, "unable to delete resources of a Mesh")
+		return ctrl.Result{}, err
+	}
+	if err := r.Cleaner.DeleteSecrets(ctx, mesh.Name); err != nil {
+		log.Error(err, "unable to delete Secrets of a Mesh")
+		return ctrl.Result{}, err
+	}
+	mesh.Finalizers = removeString(mesh.Finalizers, MeshCleanupFinalizer)
+	return ctrl.Result{}, r.Update(ctx, mesh)
+}
+
+func (r *MeshReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mesh_k8s.AddToScheme(mgr.GetScheme()); err != nil {
+		return err
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&mesh_k8s.Mesh{}).
+		Complete(r)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func removeString(values []string, value string) []string {
+	var result []string
+	for _, v := range values {
+		if v != value {
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -0,0 +1,109 @@
+package controllers_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	kube_meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kube_runtime "k8s.io/apimachinery/pkg/runtime"
+	kube_types "k8s.io/apimachinery/pkg/types"
+	kube_ctrl "sigs.k8s.io/controller-runtime"
+	kube_client "sigs.k8s.io/controller-runtime/pkg/client"
+	kube_client_fake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/Kong/kuma/pkg/core"
+	. "github.com/Kong/kuma/pkg/plugins/discovery/k8s/controllers"
+	mesh_k8s "github.com/Kong/kuma/pkg/plugins/resources/k8s/native/api/v1alpha1"
+)
+
+type fakeMeshCleaner struct {
+	deleted []string
+}
+
+func (c *fakeMeshCleaner) DeleteResources(_ context.Context, mesh string) error {
+	c.deleted = append(c.deleted, "resources of "+mesh)
+	return nil
+}
+
+func (c *fakeMeshCleaner) DeleteSecrets(_ context.Context, mesh string) error {
+	c.deleted = append(c.deleted, "secrets of "+mesh)
+	return nil
+}
+
+var _ = Describe("MeshReconciler", func() {
+
+	var kubeClient kube_client.Client
+	var cleaner *fakeMeshCleaner
+	var reconciler *MeshReconciler
+
+	newReconciler := func(objs ...kube_runtime.Object) {
+		scheme := kube_runtime.NewScheme()
+		Expect(mesh_k8s.AddToScheme(scheme)).To(Succeed())
+		kubeClient = kube_client_fake.NewFakeClientWithScheme(scheme, objs...)
+		cleaner = &fakeMeshCleaner{}
+		reconciler = &MeshReconciler{
+			Client:  kubeClient,
+			Cleaner: cleaner,
+			Log:     core.Log.WithName("test"),
+		}
+	}
+
+	req := kube_ctrl.Request{NamespacedName: kube_types.NamespacedName{Name: "demo"}}
+
+	It("should add a finalizer to a Mesh", func() {
+		// given
+		newReconciler(&mesh_k8s.Mesh{
+			ObjectMeta: kube_meta.ObjectMeta{Name: "demo"},
+		})
+
+		// when
+		_, err := reconciler.Reconcile(req)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+
+		// and
+		mesh := &mesh_k8s.Mesh{}
+		Expect(kubeClient.Get(context.Background(), req.NamespacedName, mesh)).To(Succeed())
+		Expect(mesh.Finalizers).To(Equal([]string{MeshCleanupFinalizer}))
+		Expect(cleaner.deleted).To(BeEmpty())
+	})
+
+	It("should delete resources of a Mesh that is being deleted and remove the finalizer", func() {
+		// given
+		now := kube_meta.Now()
+		newReconciler(&mesh_k8s.Mesh{
+			ObjectMeta: kube_meta.ObjectMeta{
+				Name:              "demo",
+				DeletionTimestamp: &now,
+				Finalizers:        []string{"example.com/other", MeshCleanupFinalizer},
+			},
+		})
+
+		// when
+		_, err := reconciler.Reconcile(req)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cleaner.deleted).To(Equal([]string{"resources of demo", "secrets of demo"}))
+
+		// and
+		mesh := &mesh_k8s.Mesh{}
+		Expect(kubeClient.Get(context.Background(), req.NamespacedName, mesh)).To(Succeed())
+		Expect(mesh.Finalizers).To(Equal([]string{"example.com/other"}))
+	})
+
+	It("should ignore a Mesh that no longer exists", func() {
+		// given
+		newReconciler()
+
+		// when
+		_, err := reconciler.Reconcile(req)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cleaner.deleted).To(BeEmpty())
+	})
+})
@@ -20,6 +20,7 @@ import (
 	kube_meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	injector_metadata "github.com/Kong/kuma/app/kuma-injector/pkg/injector/metadata"
+	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
 	mesh_k8s "github.com/Kong/kuma/pkg/plugins/resources/k8s/native/api/v1alpha1"
 
 	util_k8s "github.com/Kong/kuma/pkg/plugins/discovery/k8s/util"
@@ -127,6 +128,10 @@ func (r *PodReconciler) createOrUpdateDataplane(pod *kube_core.Pod, services []*
 		if err := PodToDataplane(dataplane, pod, services, others, r.Client); err != nil {
 			return errors.Wrap(err, "unable to convert Pod to Dataplane")
 		}
+		if dataplane.Labels == nil {
+			dataplane.Labels = map[string]string{}
+		}
+		dataplane.Labels[mesh_core.KubernetesPodLabel] = "true"
 		if err := kube_controllerutil.SetControllerReference(pod, dataplane, r.Scheme); err != nil {
 			return errors.Wrap(err, "unable to set Dataplane's controller reference to Pod")
 		}
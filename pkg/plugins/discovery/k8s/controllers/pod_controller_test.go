@@ -229,6 +229,8 @@ var _ = Describe("PodReconciler", func() {
         mesh: pilot
         metadata:
           creationTimestamp: null
+          labels:
+            kuma.io/kubernetes-pod: "true"
           name: pod-with-kuma-sidecar-and-ip
           namespace: demo
           ownerReferences:
@@ -293,6 +295,8 @@ var _ = Describe("PodReconciler", func() {
         mesh: pilot
         metadata:
           creationTimestamp: null
+          labels:
+            kuma.io/kubernetes-pod: "true"
           name: pod-with-kuma-sidecar-and-ip
           namespace: demo
           ownerReferences:
@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"context"
+
+	kube_core "k8s.io/api/core/v1"
+	kube_client "sigs.k8s.io/controller-runtime/pkg/client"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	util_k8s "github.com/Kong/kuma/pkg/plugins/discovery/k8s/util"
+	"github.com/Kong/kuma/pkg/smi"
+)
+
+// ServiceAccountResolver resolves a ServiceAccount into values of the `service` tag
+// of the Pods that run under it by looking up Pods and Services in Kubernetes.
+type ServiceAccountResolver struct {
+	Client kube_client.Reader
+}
+
+var _ smi.ServiceTagResolver = &ServiceAccountResolver{}
+
+func (r *ServiceAccountResolver) ServiceTags(namespace, serviceAccount string) ([]string, error) {
+	ctx := context.Background()
+
+	pods := &kube_core.PodList{}
+	if err := r.Client.List(ctx, pods, kube_client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	services := &kube_core.ServiceList{}
+	if err := r.Client.List(ctx, services, kube_client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	return ServiceTagsFor(namespace, serviceAccount, pods, services)
+}
+
+// ServiceTagsFor returns values of the `service` tag of the Pods in a given Namespace
+// that run under a given ServiceAccount.
+//
+// Pods and Services from other Namespaces are ignored.
+func ServiceTagsFor(namespace, serviceAccount string, pods *kube_core.PodList, services *kube_core.ServiceList) ([]string, error) {
+	namespaceServices := &kube_core.ServiceList{}
+	for _, svc := range services.Items {
+		if svc.Namespace == namespace {
+			namespaceServices.Items = append(namespaceServices.Items, svc)
+		}
+	}
+	tags := make(map[string]bool)
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Namespace != namespace || ServiceAccountFor(pod) != serviceAccount {
+			continue
+		}
+		ifaces, err := InboundInterfacesFor(pod, util_k8s.FindServices(namespaceServices, util_k8s.MatchServiceThatSelectsPod(pod)))
+		if err != nil {
+			return nil, err
+		}
+		for _, iface := range ifaces {
+			tags[iface.Tags[mesh_proto.ServiceTag]] = true
+		}
+	}
+	return stringSetToSortedList(tags), nil
+}
+
+// ServiceAccountFor returns a name of the ServiceAccount a Pod runs under.
+func ServiceAccountFor(pod *kube_core.Pod) string {
+	if pod.Spec.ServiceAccountName != "" {
+		return pod.Spec.ServiceAccountName
+	}
+	// Kubernetes assigns the default ServiceAccount to Pods that do not specify one
+	return "default"
+}
@@ -0,0 +1,203 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	kube_core "k8s.io/api/core/v1"
+	kube_apierrs "k8s.io/apimachinery/pkg/api/errors"
+	kube_runtime "k8s.io/apimachinery/pkg/runtime"
+	kube_types "k8s.io/apimachinery/pkg/types"
+	kube_ctrl "sigs.k8s.io/controller-runtime"
+	kube_client "sigs.k8s.io/controller-runtime/pkg/client"
+	kube_controllerutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	kube_handler "sigs.k8s.io/controller-runtime/pkg/handler"
+	kube_reconile "sigs.k8s.io/controller-runtime/pkg/reconcile"
+	kube_source "sigs.k8s.io/controller-runtime/pkg/source"
+
+	kube_meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	injector_metadata "github.com/Kong/kuma/app/kuma-injector/pkg/injector/metadata"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	mesh_k8s "github.com/Kong/kuma/pkg/plugins/resources/k8s/native/api/v1alpha1"
+	"github.com/Kong/kuma/pkg/smi"
+	util_proto "github.com/Kong/kuma/pkg/util/proto"
+)
+
+// TrafficTargetReconciler reconciles SMI TrafficTarget objects into TrafficPermissions.
+//
+// ServiceAccounts of a TrafficTarget are resolved into `service` tags of the Pods that run under them.
+// Every TrafficTarget is backed by a TrafficPermission with the same name and Namespace,
+// which is owned by the TrafficTarget. Manual changes to that TrafficPermission are reverted
+// and it is garbage collected by Kubernetes once the TrafficTarget is deleted.
+//
+// Synchronization is one-way. TrafficPermissions are selected by `service` tags rather than
+// by ServiceAccounts, so they have no faithful SMI representation and are not converted back.
+type TrafficTargetReconciler struct {
+	kube_client.Client
+	Scheme *kube_runtime.Scheme
+	Log    logr.Logger
+}
+
+func (r *TrafficTargetReconciler) Reconcile(req kube_ctrl.Request) (kube_ctrl.Result, error) {
+	ctx := context.Background()
+	log := r.Log.WithValues("traffictarget", req.NamespacedName)
+
+	// Fetch the TrafficTarget instance
+	target := &smi.TrafficTarget{}
+	if err := r.Get(ctx, req.NamespacedName, target); err != nil {
+		if kube_apierrs.IsNotFound(err) {
+			// TrafficPermission will be garbage collected by Kubernetes
+			return kube_ctrl.Result{}, nil
+		}
+		log.Error(err, "unable to fetch TrafficTarget")
+		return kube_ctrl.Result{}, err
+	}
+
+	permission, warnings, err := smi.ConvertTrafficTarget(target, MeshForTrafficTarget(target), &ServiceAccountResolver{Client: r.Client})
+	if err != nil {
+		// there is no point in retrying until TrafficTarget is changed.
+		// TrafficPermission that is not up to date anymore must not permit any traffic
+		log.Error(err, "unable to convert TrafficTarget into TrafficPermission")
+		return kube_ctrl.Result{}, r.deleteTrafficPermission(target)
+	}
+	for _, warning := range warnings {
+		log.Info("TrafficTarget has been converted only partially", "reason", warning)
+	}
+
+	if err := r.createOrUpdateTrafficPermission(target, permission.Meta.GetMesh(), &permission.Spec); err != nil {
+		return kube_ctrl.Result{}, err
+	}
+	return kube_ctrl.Result{}, nil
+}
+
+// MeshForTrafficTarget returns a Mesh that TrafficPermission should be created in.
+func MeshForTrafficTarget(target *smi.TrafficTarget) string {
+	if mesh := target.Annotations[injector_metadata.KumaMeshAnnotation]; mesh != "" {
+		return mesh
+	}
+	return core_model.DefaultMesh
+}
+
+func (r *TrafficTargetReconciler) createOrUpdateTrafficPermission(target *smi.TrafficTarget, mesh string, spec *mesh_proto.TrafficPermission) error {
+	ctx := context.Background()
+
+	permission := &mesh_k8s.TrafficPermission{
+		ObjectMeta: kube_meta.ObjectMeta{
+			Namespace: target.Namespace,
+			Name:      target.Name,
+		},
+	}
+	operationResult, err := kube_controllerutil.CreateOrUpdate(ctx, r.Client, permission, func() error {
+		permission.Mesh = mesh
+		specMap, err := util_proto.ToMap(spec)
+		if err != nil {
+			return errors.Wrap(err, "unable to convert TrafficPermission")
+		}
+		permission.Spec = specMap
+		if err := kube_controllerutil.SetControllerReference(target, permission, r.Scheme); err != nil {
+			return errors.Wrap(err, "unable to set TrafficPermission's controller reference to TrafficTarget")
+		}
+		return nil
+	})
+	if err != nil {
+		log := r.Log.WithValues("traffictarget", kube_types.NamespacedName{Namespace: target.Namespace, Name: target.Name})
+		log.Error(err, "unable to create/update TrafficPermission", "operationResult", operationResult)
+		return err
+	}
+	return nil
+}
+
+func (r *TrafficTargetReconciler) deleteTrafficPermission(target *smi.TrafficTarget) error {
+	ctx := context.Background()
+
+	permission := &mesh_k8s.TrafficPermission{}
+	if err := r.Get(ctx, kube_types.NamespacedName{Namespace: target.Namespace, Name: target.Name}, permission); err != nil {
+		if kube_apierrs.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	// never delete TrafficPermissions that have not been created by this controller
+	if !kube_meta.IsControlledBy(permission, target) {
+		return nil
+	}
+	if err := r.Delete(ctx, permission); err != nil && !kube_apierrs.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func (r *TrafficTargetReconciler) SetupWithManager(mgr kube_ctrl.Manager) error {
+	for _, addToScheme := range []func(*kube_runtime.Scheme) error{smi.AddToScheme, mesh_k8s.AddToScheme} {
+		if err := addToScheme(mgr.GetScheme()); err != nil {
+			return err
+		}
+	}
+	return kube_ctrl.NewControllerManagedBy(mgr).
+		For(&smi.TrafficTarget{}).
+		// on TrafficPermission update revert manual changes
+		Owns(&mesh_k8s.TrafficPermission{}).
+		// on Dataplane update re-resolve ServiceAccounts of TrafficTargets that refer to its Pod, since its `service` tags might have changed
+		Watches(&kube_source.Kind{Type: &mesh_k8s.Dataplane{}}, &kube_handler.EnqueueRequestsFromMapFunc{
+			ToRequests: &DataplaneToTrafficTargetsMapper{Client: mgr.GetClient(), Log: r.Log.WithName("dataplane-to-traffic-targets-mapper")},
+		}).
+		Complete(r)
+}
+
+// DataplaneToTrafficTargetsMapper maps a Dataplane into TrafficTargets that refer to the ServiceAccount of its Pod,
+// since `service` tags of only those TrafficTargets might have changed.
+//
+// Once a Pod is gone, its ServiceAccount is unknown, so all TrafficTargets that refer to ServiceAccounts
+// in the Namespace of the Dataplane are mapped.
+type DataplaneToTrafficTargetsMapper struct {
+	kube_client.Client
+	Log logr.Logger
+}
+
+func (m *DataplaneToTrafficTargetsMapper) Map(obj kube_handler.MapObject) []kube_reconile.Request {
+	log := m.Log.WithValues("dataplane", obj.Meta)
+	namespace := obj.Meta.GetNamespace()
+	serviceAccount := ""
+	pod := &kube_core.Pod{}
+	if err := m.Client.Get(context.Background(), kube_types.NamespacedName{Namespace: namespace, Name: obj.Meta.GetName()}, pod); err != nil {
+		if !kube_apierrs.IsNotFound(err) {
+			log.Error(err, "failed to fetch Pod")
+		}
+	} else {
+		serviceAccount = ServiceAccountFor(pod)
+	}
+
+	targets := &smi.TrafficTargetList{}
+	if err := m.Client.List(context.Background(), targets); err != nil {
+		log.Error(err, "failed to fetch TrafficTargets")
+		return nil
+	}
+
+	var req []kube_reconile.Request
+	for i := range targets.Items {
+		target := &targets.Items[i]
+		if !refersTo(target, namespace, serviceAccount) {
+			continue
+		}
+		req = append(req, kube_reconile.Request{
+			NamespacedName: kube_types.NamespacedName{Namespace: target.Namespace, Name: target.Name},
+		})
+	}
+	return req
+}
+
+// refersTo checks whether a TrafficTarget refers to a ServiceAccount in a given Namespace.
+// An empty name of a ServiceAccount matches any ServiceAccount in the Namespace.
+func refersTo(target *smi.TrafficTarget, namespace string, serviceAccount string) bool {
+	subjects := append([]smi.IdentityBindingSubject{target.Destination}, target.Sources...)
+	for _, subject := range subjects {
+		if smi.SubjectNamespace(target, subject) == namespace && (serviceAccount == "" || subject.Name == serviceAccount) {
+			return true
+		}
+	}
+	return false
+}
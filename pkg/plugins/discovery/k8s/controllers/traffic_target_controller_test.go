@@ -0,0 +1,384 @@
+package controllers_test
+
+import (
+	"context"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	. "github.com/Kong/kuma/pkg/plugins/discovery/k8s/controllers"
+
+	"github.com/Kong/kuma/pkg/core"
+	"github.com/Kong/kuma/pkg/smi"
+
+	kube_core "k8s.io/api/core/v1"
+	kube_types "k8s.io/apimachinery/pkg/types"
+	kube_intstr "k8s.io/apimachinery/pkg/util/intstr"
+	kube_ctrl "sigs.k8s.io/controller-runtime"
+	kube_client "sigs.k8s.io/controller-runtime/pkg/client"
+	kube_client_fake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	kube_handler "sigs.k8s.io/controller-runtime/pkg/handler"
+	kube_reconcile "sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kube_meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mesh_k8s "github.com/Kong/kuma/pkg/plugins/resources/k8s/native/api/v1alpha1"
+)
+
+var _ = Describe("TrafficTargetReconciler", func() {
+
+	var kubeClient kube_client.Client
+	var reconciler kube_reconcile.Reconciler
+
+	BeforeEach(func() {
+		port := kube_intstr.FromInt(8080)
+		kubeClient = kube_client_fake.NewFakeClientWithScheme(
+			k8sClientScheme,
+			&smi.TrafficTarget{
+				ObjectMeta: kube_meta.ObjectMeta{
+					Namespace: "demo",
+					Name:      "web-to-backend",
+					Annotations: map[string]string{
+						"kuma.io/mesh": "pilot",
+					},
+				},
+				Destination: smi.IdentityBindingSubject{
+					Kind: "ServiceAccount",
+					Name: "backend",
+				},
+				Sources: []smi.IdentityBindingSubject{
+					{Kind: "ServiceAccount", Name: "web"},
+				},
+			},
+			&smi.TrafficTarget{
+				ObjectMeta: kube_meta.ObjectMeta{
+					Namespace: "demo",
+					Name:      "group-to-backend",
+				},
+				Destination: smi.IdentityBindingSubject{
+					Kind: "ServiceAccount",
+					Name: "backend",
+				},
+				Sources: []smi.IdentityBindingSubject{
+					{Kind: "Group", Name: "frontend"},
+				},
+			},
+			&smi.TrafficTarget{
+				ObjectMeta: kube_meta.ObjectMeta{
+					Namespace: "demo",
+					Name:      "web-to-backend-port",
+				},
+				Destination: smi.IdentityBindingSubject{
+					Kind: "ServiceAccount",
+					Name: "backend",
+					Port: &port,
+				},
+				Sources: []smi.IdentityBindingSubject{
+					{Kind: "ServiceAccount", Name: "web"},
+				},
+			},
+			&smi.TrafficTarget{
+				ObjectMeta: kube_meta.ObjectMeta{
+					Namespace: "other",
+					Name:      "other-web-to-demo-backend",
+				},
+				Destination: smi.IdentityBindingSubject{
+					Kind:      "ServiceAccount",
+					Name:      "backend",
+					Namespace: "demo",
+				},
+				Sources: []smi.IdentityBindingSubject{
+					{Kind: "ServiceAccount", Name: "web"},
+				},
+			},
+			&kube_core.Pod{
+				ObjectMeta: kube_meta.ObjectMeta{
+					Namespace: "demo",
+					Name:      "backend-1",
+					Labels:    map[string]string{"app": "backend"},
+				},
+				Spec: kube_core.PodSpec{
+					ServiceAccountName: "backend",
+					Containers: []kube_core.Container{{
+						Ports: []kube_core.ContainerPort{{ContainerPort: 8080}},
+					}},
+				},
+			},
+			&kube_core.Pod{
+				ObjectMeta: kube_meta.ObjectMeta{
+					Namespace: "demo",
+					Name:      "web-1",
+					Labels:    map[string]string{"app": "web"},
+				},
+				Spec: kube_core.PodSpec{
+					ServiceAccountName: "web",
+					Containers: []kube_core.Container{{
+						Ports: []kube_core.ContainerPort{{ContainerPort: 8080}},
+					}},
+				},
+			},
+			&kube_core.Pod{
+				ObjectMeta: kube_meta.ObjectMeta{
+					Namespace: "other",
+					Name:      "web-1",
+					Labels:    map[string]string{"app": "web"},
+				},
+				Spec: kube_core.PodSpec{
+					ServiceAccountName: "web",
+					Containers: []kube_core.Container{{
+						Ports: []kube_core.ContainerPort{{ContainerPort: 8080}},
+					}},
+				},
+			},
+			&kube_core.Service{
+				ObjectMeta: kube_meta.ObjectMeta{
+					Namespace: "demo",
+					Name:      "backend",
+				},
+				Spec: kube_core.ServiceSpec{
+					Selector: map[string]string{"app": "backend"},
+					Ports: []kube_core.ServicePort{{
+						Port:       80,
+						TargetPort: kube_intstr.FromInt(8080),
+					}},
+				},
+			},
+			&kube_core.Service{
+				ObjectMeta: kube_meta.ObjectMeta{
+					Namespace: "demo",
+					Name:      "web",
+				},
+				Spec: kube_core.ServiceSpec{
+					Selector: map[string]string{"app": "web"},
+					Ports: []kube_core.ServicePort{{
+						Port:       80,
+						TargetPort: kube_intstr.FromInt(8080),
+					}},
+				},
+			},
+			&kube_core.Service{
+				ObjectMeta: kube_meta.ObjectMeta{
+					Namespace: "other",
+					Name:      "web",
+				},
+				Spec: kube_core.ServiceSpec{
+					Selector: map[string]string{"app": "web"},
+					Ports: []kube_core.ServicePort{{
+						Port:       80,
+						TargetPort: kube_intstr.FromInt(8080),
+					}},
+				},
+			},
+		)
+
+		reconciler = &TrafficTargetReconciler{
+			Client: kubeClient,
+			Scheme: k8sClientScheme,
+			Log:    core.Log.WithName("test"),
+		}
+	})
+
+	It("should ignore deleted TrafficTargets", func() {
+		// given
+		req := kube_ctrl.Request{
+			NamespacedName: kube_types.NamespacedName{Namespace: "demo", Name: "non-existing-key"},
+		}
+
+		// when
+		result, err := reconciler.Reconcile(req)
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		// and
+		Expect(result).To(BeZero())
+
+		// when
+		permissions := &mesh_k8s.TrafficPermissionList{}
+		err = kubeClient.List(context.Background(), permissions)
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		// and
+		Expect(permissions.Items).To(HaveLen(0))
+	})
+
+	It("should generate TrafficPermission for a TrafficTarget", func() {
+		// given
+		req := kube_ctrl.Request{
+			NamespacedName: kube_types.NamespacedName{Namespace: "demo", Name: "web-to-backend"},
+		}
+
+		// when
+		result, err := reconciler.Reconcile(req)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		// and
+		Expect(result).To(BeZero())
+
+		// when
+		permissions := &mesh_k8s.TrafficPermissionList{}
+		err = kubeClient.List(context.Background(), permissions)
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		// and
+		Expect(permissions.Items).To(HaveLen(1))
+
+		// when
+		actual, err := json.Marshal(permissions.Items[0])
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		// and
+		Expect(actual).To(MatchYAML(`
+        mesh: pilot
+        metadata:
+          creationTimestamp: null
+          name: web-to-backend
+          namespace: demo
+          ownerReferences:
+          - apiVersion: access.smi-spec.io/v1alpha1
+            blockOwnerDeletion: true
+            controller: true
+            kind: TrafficTarget
+            name: web-to-backend
+            uid: ""
+        spec:
+          rules:
+          - sources:
+            - match:
+                service: web.demo.svc:80
+            destinations:
+            - match:
+                service: backend.demo.svc:80
+`))
+	})
+
+	It("should revert manual changes of a generated TrafficPermission", func() {
+		// setup
+		err := kubeClient.Create(context.Background(), &mesh_k8s.TrafficPermission{
+			ObjectMeta: kube_meta.ObjectMeta{
+				Namespace: "demo",
+				Name:      "web-to-backend",
+			},
+			Mesh: "default",
+			Spec: map[string]interface{}{},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		// given
+		req := kube_ctrl.Request{
+			NamespacedName: kube_types.NamespacedName{Namespace: "demo", Name: "web-to-backend"},
+		}
+
+		// when
+		_, err = reconciler.Reconcile(req)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		permission := &mesh_k8s.TrafficPermission{}
+		err = kubeClient.Get(context.Background(), req.NamespacedName, permission)
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		// and
+		Expect(permission.Mesh).To(Equal("pilot"))
+		// and
+		Expect(permission.Spec).To(HaveKey("rules"))
+	})
+
+	DescribeTable("should delete generated TrafficPermission once TrafficTarget cannot be converted anymore",
+		func(name string) {
+			// given
+			req := kube_ctrl.Request{
+				NamespacedName: kube_types.NamespacedName{Namespace: "demo", Name: name},
+			}
+			// and
+			target := &smi.TrafficTarget{}
+			Expect(kubeClient.Get(context.Background(), req.NamespacedName, target)).To(Succeed())
+			permission := &mesh_k8s.TrafficPermission{
+				ObjectMeta: kube_meta.ObjectMeta{
+					Namespace: "demo",
+					Name:      name,
+				},
+			}
+			Expect(kube_ctrl.SetControllerReference(target, permission, k8sClientScheme)).To(Succeed())
+			Expect(kubeClient.Create(context.Background(), permission)).To(Succeed())
+
+			// when
+			result, err := reconciler.Reconcile(req)
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			// and
+			Expect(result).To(BeZero())
+
+			// when
+			permissions := &mesh_k8s.TrafficPermissionList{}
+			err = kubeClient.List(context.Background(), permissions)
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			// and
+			Expect(permissions.Items).To(HaveLen(0))
+		},
+		Entry("source of unsupported kind", "group-to-backend"),
+		Entry("restriction to a port", "web-to-backend-port"),
+	)
+
+	Describe("DataplaneToTrafficTargetsMapper", func() {
+
+		var mapper *DataplaneToTrafficTargetsMapper
+
+		BeforeEach(func() {
+			mapper = &DataplaneToTrafficTargetsMapper{
+				Client: kubeClient,
+				Log:    core.Log.WithName("test"),
+			}
+		})
+
+		requestsOf := func(namespace, name string) []kube_reconcile.Request {
+			dataplane := &mesh_k8s.Dataplane{
+				ObjectMeta: kube_meta.ObjectMeta{
+					Namespace: namespace,
+					Name:      name,
+				},
+			}
+			return mapper.Map(kube_handler.MapObject{Meta: dataplane, Object: dataplane})
+		}
+
+		request := func(namespace, name string) kube_reconcile.Request {
+			return kube_reconcile.Request{NamespacedName: kube_types.NamespacedName{Namespace: namespace, Name: name}}
+		}
+
+		It("should map a Dataplane into TrafficTargets that refer to the ServiceAccount of its Pod", func() {
+			// when
+			requests := requestsOf("demo", "backend-1")
+
+			// then
+			Expect(requests).To(ConsistOf(
+				request("demo", "web-to-backend"),
+				request("demo", "group-to-backend"),
+				request("demo", "web-to-backend-port"),
+				request("other", "other-web-to-demo-backend"),
+			))
+
+			// when
+			requests = requestsOf("other", "web-1")
+
+			// then
+			Expect(requests).To(ConsistOf(
+				request("other", "other-web-to-demo-backend"),
+			))
+		})
+
+		It("should map a Dataplane without a Pod into TrafficTargets that refer to its Namespace", func() {
+			// when
+			requests := requestsOf("other", "deleted-1")
+
+			// then
+			Expect(requests).To(ConsistOf(
+				request("other", "other-web-to-demo-backend"),
+			))
+		})
+	})
+})
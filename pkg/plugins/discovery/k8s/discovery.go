@@ -1,21 +1,36 @@
 package k8s
 
 import (
+	k8s_config "github.com/Kong/kuma/pkg/config/plugins/discovery/k8s"
 	"github.com/Kong/kuma/pkg/core"
 	core_discovery "github.com/Kong/kuma/pkg/core/discovery"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
 	"github.com/Kong/kuma/pkg/plugins/discovery/k8s/controllers"
 	k8s_resources "github.com/Kong/kuma/pkg/plugins/resources/k8s"
+	mesh_k8s "github.com/Kong/kuma/pkg/plugins/resources/k8s/native/api/v1alpha1"
 
+	kube_meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kube_ctrl "sigs.k8s.io/controller-runtime"
+	kube_event "sigs.k8s.io/controller-runtime/pkg/event"
 )
 
-func NewDiscoverySource(mgr kube_ctrl.Manager) (core_discovery.DiscoverySource, error) {
+func NewDiscoverySource(mgr kube_ctrl.Manager, cfg *k8s_config.KubernetesDiscoveryConfig, cleaner controllers.MeshCleaner) (core_discovery.DiscoverySource, error) {
 	// convert Pods into Dataplanes
 	if err := addPodReconciler(mgr); err != nil {
 		return nil, err
 	}
+	// delete resources of a Mesh along with the Mesh
+	if err := addMeshReconciler(mgr, cleaner); err != nil {
+		return nil, err
+	}
+	// convert SMI TrafficTargets into TrafficPermissions
+	if cfg.SmiEnabled {
+		if err := addTrafficTargetReconciler(mgr); err != nil {
+			return nil, err
+		}
+	}
 	// discover Dataplanes
-	return addDataplaneReconciler(mgr)
+	return addDataplaneReconciler(mgr, cfg.BufferedDiscoveryEnabled)
 }
 
 func addPodReconciler(mgr kube_ctrl.Manager) error {
@@ -27,14 +42,60 @@ func addPodReconciler(mgr kube_ctrl.Manager) error {
 	return reconciler.SetupWithManager(mgr)
 }
 
-func addDataplaneReconciler(mgr kube_ctrl.Manager) (core_discovery.DiscoverySource, error) {
+func addMeshReconciler(mgr kube_ctrl.Manager, cleaner controllers.MeshCleaner) error {
+	reconciler := &controllers.MeshReconciler{
+		Client:  mgr.GetClient(),
+		Cleaner: cleaner,
+		Log:     core.Log.WithName("controllers").WithName("Mesh"),
+	}
+	return reconciler.SetupWithManager(mgr)
+}
+
+func addTrafficTargetReconciler(mgr kube_ctrl.Manager) error {
+	reconciler := &controllers.TrafficTargetReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		Log:    core.Log.WithName("controllers").WithName("TrafficTarget"),
+	}
+	return reconciler.SetupWithManager(mgr)
+}
+
+// dataplaneEventQueueSize is how many changes of Dataplanes can wait for discovery consumers
+// before reconciliation of Dataplanes is blocked. Only used if discovery is buffered.
+const dataplaneEventQueueSize = 1000
+
+func addDataplaneReconciler(mgr kube_ctrl.Manager, buffered bool) (core_discovery.DiscoverySource, error) {
 	reconciler := &controllers.DataplaneReconciler{
 		Client:    mgr.GetClient(),
 		Converter: k8s_resources.DefaultConverter(),
 		Log:       core.Log.WithName("controllers").WithName("Dataplane"),
 	}
+	var source core_discovery.DiscoverySource
+	if buffered {
+		// consumers don't block informers, so Dataplanes whose changes consumers have failed to handle
+		// are reconciled again instead of returning an error
+		retries := make(chan kube_event.GenericEvent, dataplaneEventQueueSize)
+		sink := core_discovery.NewBufferedDiscoverySink(dataplaneEventQueueSize, func(key core_model.ResourceKey, _ error) {
+			dataplane := &mesh_k8s.Dataplane{ObjectMeta: kube_meta.ObjectMeta{Namespace: key.Namespace, Name: key.Name}}
+			select {
+			case retries <- kube_event.GenericEvent{Meta: dataplane, Object: dataplane}:
+			default:
+				reconciler.Log.Info("too many Dataplanes to reconcile again, dropping a retry", "dataplane", key)
+			}
+		})
+		if err := mgr.Add(sink); err != nil {
+			return nil, err
+		}
+		reconciler.Sink = sink
+		reconciler.Retries = retries
+		source = sink
+	} else {
+		sink := &core_discovery.DiscoverySink{}
+		reconciler.Sink = sink
+		source = sink
+	}
 	if err := reconciler.SetupWithManager(mgr); err != nil {
 		return nil, err
 	}
-	return reconciler, nil
+	return source, nil
 }
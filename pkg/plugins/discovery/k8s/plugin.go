@@ -3,7 +3,9 @@ package k8s
 import (
 	"github.com/pkg/errors"
 
+	k8s_config "github.com/Kong/kuma/pkg/config/plugins/discovery/k8s"
 	core_discovery "github.com/Kong/kuma/pkg/core/discovery"
+	mesh_managers "github.com/Kong/kuma/pkg/core/managers/apis/mesh"
 	core_plugins "github.com/Kong/kuma/pkg/core/plugins"
 	k8s_runtime "github.com/Kong/kuma/pkg/runtime/k8s"
 )
@@ -16,10 +18,15 @@ func init() {
 	core_plugins.Register(core_plugins.Kubernetes, &plugin{})
 }
 
-func (p *plugin) NewDiscoverySource(pc core_plugins.PluginContext, _ core_plugins.PluginConfig) (core_discovery.DiscoverySource, error) {
+func (p *plugin) NewDiscoverySource(pc core_plugins.PluginContext, config core_plugins.PluginConfig) (core_discovery.DiscoverySource, error) {
 	mgr, ok := k8s_runtime.FromManagerContext(pc.Extensions())
 	if !ok {
 		return nil, errors.Errorf("k8s controller runtime Manager hasn't been configured")
 	}
-	return NewDiscoverySource(mgr)
+	cfg, ok := config.(*k8s_config.KubernetesDiscoveryConfig)
+	if !ok {
+		return nil, errors.Errorf("wrong type of configuration. Expected: *k8s_config.KubernetesDiscoveryConfig, got: %T", config)
+	}
+	cleaner := mesh_managers.NewMeshCleaner(pc.ResourceStore(), pc.SecretManager(), pc.BuiltinCaManager())
+	return NewDiscoverySource(mgr, cfg, cleaner)
 }
@@ -20,8 +20,9 @@ func (p *plugin) NewDiscoverySource(pc core_plugins.PluginContext, config core_p
 	if !ok {
 		return nil, errors.Errorf("wrong type of configuration. Expected: *universal_config.UniversalDiscoveryConfig, got: %T", config)
 	}
-	source := newStorePollingSource(pc.ResourceStore(), cfg.PollingInterval)
-	if err := pc.ComponentManager().Add(source); err != nil {
+	heartbeat := pc.Supervisor().Heartbeat("universal-discovery")
+	source := newStorePollingSource(pc.ResourceStore(), cfg.PollingInterval, heartbeat)
+	if err := pc.ComponentManager().Add(pc.Supervisor().Restarting(heartbeat, source)); err != nil {
 		return nil, errors.Errorf("could not add store polling source component to the component manager")
 	}
 	return source, nil
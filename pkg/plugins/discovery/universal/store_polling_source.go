@@ -8,6 +8,7 @@ import (
 	"github.com/Kong/kuma/pkg/core/resources/model"
 	"github.com/Kong/kuma/pkg/core/resources/store"
 	"github.com/Kong/kuma/pkg/core/runtime"
+	"github.com/Kong/kuma/pkg/core/runtime/supervisor"
 	"github.com/pkg/errors"
 	"go.uber.org/multierr"
 	"time"
@@ -24,38 +25,52 @@ var _ runtime.Component = &storePollingSource{}
 // It periodically polls the given store with given interval for the dataplanes.
 // When a new dataplane is added or old one is changed the DiscoverySink#OnDataplaneUpdate is called
 // When a dataplane is removed the DiscoverySink#OnDataplaneDelete is called
+// Consumers that are added after polling has started receive all dataplanes detected so far.
 type storePollingSource struct {
 	store             store.ResourceStore
 	currentDataplanes dataplanesByKey
 	interval          time.Duration
-	core_discovery.DiscoverySink
+	// heartbeat is reported on every poll, so that the loop is restarted once it gets stuck
+	heartbeat *supervisor.Heartbeat
+	core_discovery.StatefulDiscoverySink
 }
 
 func (s *storePollingSource) Start(stop <-chan struct{}) error {
+	// a poll that is stuck on the store is cancelled once the source is stopped, e.g. to be restarted
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-stop
+		cancel()
+	}()
+
 	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
 	for {
 		select {
 		case <-ticker.C:
-			if err := s.detectChanges(); err != nil {
+			if err := s.detectChanges(ctx); err != nil {
 				log.Error(err, "error while detecting changes")
 			}
+			s.heartbeat.Beat()
 		case <-stop:
 			return nil
 		}
 	}
 }
 
-func newStorePollingSource(store store.ResourceStore, interval time.Duration) *storePollingSource {
+func newStorePollingSource(store store.ResourceStore, interval time.Duration, heartbeat *supervisor.Heartbeat) *storePollingSource {
 	return &storePollingSource{
 		store,
 		make(dataplanesByKey),
 		interval,
-		core_discovery.DiscoverySink{},
+		heartbeat,
+		core_discovery.StatefulDiscoverySink{},
 	}
 }
 
-func (s *storePollingSource) detectChanges() error {
-	dataplanes, err := s.fetchDataplanes()
+func (s *storePollingSource) detectChanges(ctx context.Context) error {
+	dataplanes, err := s.fetchDataplanes(ctx)
 	if err != nil {
 		return errors.Wrap(err, "could not fetch dataplanes")
 	}
@@ -76,9 +91,9 @@ func (s *storePollingSource) detectChanges() error {
 	return multiErr
 }
 
-func (s *storePollingSource) fetchDataplanes() (dataplanesByKey, error) {
+func (s *storePollingSource) fetchDataplanes(ctx context.Context) (dataplanesByKey, error) {
 	dataplanesList := core_resource.DataplaneResourceList{}
-	if err := s.store.List(context.Background(), &dataplanesList); err != nil {
+	if err := s.store.List(ctx, &dataplanesList); err != nil {
 		return nil, err
 	}
 	dataplanes := make(dataplanesByKey)
@@ -0,0 +1,83 @@
+package universal
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/model"
+	"github.com/Kong/kuma/pkg/core/resources/store"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+	test_discovery "github.com/Kong/kuma/pkg/test/discovery"
+)
+
+var _ = Describe("Store Polling Source under random changes", func() {
+
+	apply := func(s store.ResourceStore, event test_discovery.Event) error {
+		ctx := context.Background()
+		if event.Update == nil {
+			err := s.Delete(ctx, &mesh.DataplaneResource{}, store.DeleteBy(event.Delete))
+			if store.IsResourceNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		key := model.MetaToResourceKey(event.Update.Meta)
+		existing := &mesh.DataplaneResource{}
+		err := s.Get(ctx, existing, store.GetBy(key))
+		if store.IsResourceNotFound(err) {
+			return s.Create(ctx, &mesh.DataplaneResource{Spec: event.Update.Spec}, store.CreateBy(key))
+		}
+		if err != nil {
+			return err
+		}
+		existing.Spec = event.Update.Spec
+		return s.Update(ctx, existing)
+	}
+
+	stateOf := func(s store.ResourceStore) test_discovery.State {
+		dataplanes := &mesh.DataplaneResourceList{}
+		Expect(s.List(context.Background(), dataplanes)).To(Succeed())
+		state := test_discovery.State{}
+		for _, dataplane := range dataplanes.Items {
+			state[model.MetaToResourceKey(dataplane.Meta)] = dataplane.Meta.GetVersion()
+		}
+		return state
+	}
+
+	It("should let a consumer converge on the contents of the store", func() {
+		for i := 0; i < 50; i++ {
+			// given
+			seed := GinkgoRandomSeed() + int64(i)
+			rnd := rand.New(rand.NewSource(seed))
+			events := test_discovery.RandomEvents(rnd, test_discovery.DefaultFuzzOpts)
+
+			// and
+			memoryStore := memory.NewStore()
+			source := newStorePollingSource(memoryStore, time.Second, newHeartbeat())
+			consumer := &test_discovery.RecordingConsumer{}
+			source.AddConsumer(consumer)
+
+			// when changes are detected at random moments
+			for _, event := range events {
+				Expect(apply(memoryStore, event)).To(Succeed())
+				if rnd.Float64() < 0.3 {
+					Expect(source.detectChanges(context.Background())).To(Succeed())
+				}
+			}
+			// and
+			Expect(source.detectChanges(context.Background())).To(Succeed())
+
+			// then the consumer is told only about actual changes
+			Expect(test_discovery.VerifyNoRedundantEvents(consumer.Events())).To(Succeed(), "seed %d", seed)
+			// and it ends up with the contents of the store
+			state := &test_discovery.StateConsumer{}
+			Expect(test_discovery.Deliver(state, consumer.Events())).To(Succeed())
+			Expect(state.State()).To(Equal(stateOf(memoryStore)), "seed %d", seed)
+		}
+	})
+})
@@ -6,10 +6,12 @@ import (
 	"time"
 
 	"github.com/Kong/kuma/api/mesh/v1alpha1"
+	watchdog_config "github.com/Kong/kuma/pkg/config/core/watchdog"
 	"github.com/Kong/kuma/pkg/core/discovery"
 	"github.com/Kong/kuma/pkg/core/resources/apis/mesh"
 	"github.com/Kong/kuma/pkg/core/resources/model"
 	"github.com/Kong/kuma/pkg/core/resources/store"
+	"github.com/Kong/kuma/pkg/core/runtime/supervisor"
 	"github.com/Kong/kuma/pkg/plugins/resources/memory"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -49,6 +51,10 @@ func (l *testDiscoveryConsumer) Removals() []model.ResourceKey {
 	return l.removals
 }
 
+func newHeartbeat() *supervisor.Heartbeat {
+	return supervisor.NewSupervisor(*watchdog_config.DefaultWatchdogConfig()).Heartbeat("universal-discovery")
+}
+
 var _ = Describe("Store Polling Source", func() {
 
 	var memoryStore store.ResourceStore
@@ -60,9 +66,12 @@ var _ = Describe("Store Polling Source", func() {
 		source = newStorePollingSource(
 			memoryStore,
 			10*time.Millisecond,
+			newHeartbeat(),
 		)
 		consumer = &testDiscoveryConsumer{}
-		source.AddConsumer(&discovery.DiscoverySink{DataplaneConsumer: consumer})
+		sink := &discovery.DiscoverySink{}
+		sink.AddConsumer(consumer)
+		source.AddConsumer(sink)
 	})
 
 	var resource *mesh.DataplaneResource
@@ -88,7 +97,7 @@ var _ = Describe("Store Polling Source", func() {
 
 	It("should notify about added dataplanes", func() {
 		// when
-		err := source.detectChanges()
+		err := source.detectChanges(context.Background())
 		Expect(err).ToNot(HaveOccurred())
 
 		// then
@@ -99,7 +108,7 @@ var _ = Describe("Store Polling Source", func() {
 
 	It("should notify about deleted dataplanes", func() {
 		// given detected created dataplane
-		err := source.detectChanges()
+		err := source.detectChanges(context.Background())
 		Expect(err).ToNot(HaveOccurred())
 		Expect(consumer.Updates()).To(HaveLen(1))
 
@@ -107,7 +116,7 @@ var _ = Describe("Store Polling Source", func() {
 		err = memoryStore.Delete(context.Background(), resource, store.DeleteByKey("sample-ns", "sample-mesh", "sample-name"))
 		Expect(err).ToNot(HaveOccurred())
 
-		err = source.detectChanges()
+		err = source.detectChanges(context.Background())
 		Expect(err).ToNot(HaveOccurred())
 
 		// then
@@ -118,7 +127,7 @@ var _ = Describe("Store Polling Source", func() {
 
 	It("should notify about modified dataplanes", func() {
 		// given detected created dataplane
-		err := source.detectChanges()
+		err := source.detectChanges(context.Background())
 		Expect(err).ToNot(HaveOccurred())
 		Expect(consumer.Updates()).To(HaveLen(1))
 
@@ -128,7 +137,7 @@ var _ = Describe("Store Polling Source", func() {
 		Expect(err).ToNot(HaveOccurred())
 
 		// when
-		err = source.detectChanges()
+		err = source.detectChanges(context.Background())
 		Expect(err).ToNot(HaveOccurred())
 
 		// then
@@ -137,6 +146,21 @@ var _ = Describe("Store Polling Source", func() {
 		Expect(consumer.Updates()[1].Spec.Networking.Inbound[0].Tags["some"]).To(Equal("updated"))
 	})
 
+	It("should replay detected dataplanes to a consumer added later", func() {
+		// given detected created dataplane
+		err := source.detectChanges(context.Background())
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		lateConsumer := &testDiscoveryConsumer{}
+		source.AddConsumer(lateConsumer)
+
+		// then
+		Expect(lateConsumer.Removals()).To(HaveLen(0))
+		Expect(lateConsumer.Updates()).To(HaveLen(1))
+		Expect(lateConsumer.Updates()[0]).To(Equal(resource))
+	})
+
 	It("should periodically detect changes", func() {
 		// when
 		go func() {
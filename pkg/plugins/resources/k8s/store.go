@@ -3,6 +3,8 @@ package k8s
 import (
 	"context"
 	"fmt"
+	"sort"
+	"sync"
 
 	core_model "github.com/Kong/kuma/pkg/core/resources/model"
 	"github.com/Kong/kuma/pkg/core/resources/store"
@@ -12,6 +14,7 @@ import (
 	"github.com/pkg/errors"
 	kube_apierrs "k8s.io/apimachinery/pkg/api/errors"
 	kube_meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kube_cache "sigs.k8s.io/controller-runtime/pkg/cache"
 	kube_client "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -20,12 +23,19 @@ var _ store.ResourceStore = &KubernetesStore{}
 type KubernetesStore struct {
 	Client    kube_client.Client
 	Converter Converter
+	// Informers notify about changes of k8s resources, it is optional unless the store is watched
+	Informers kube_cache.Informers
+
+	watchMu      sync.Mutex
+	events       *store.EventBroadcaster
+	watchedTypes map[core_model.ResourceType]bool
 }
 
-func NewStore(client kube_client.Client) (store.ResourceStore, error) {
+func NewStore(client kube_client.Client, informers kube_cache.Informers) (store.ResourceStore, error) {
 	return &KubernetesStore{
 		Client:    client,
 		Converter: DefaultConverter(),
+		Informers: informers,
 	}, nil
 }
 
@@ -37,6 +47,7 @@ func (s *KubernetesStore) Create(ctx context.Context, r core_model.Resource, fs
 	}
 	obj.GetObjectMeta().SetNamespace(opts.Namespace)
 	obj.GetObjectMeta().SetName(opts.Name)
+	obj.GetObjectMeta().SetLabels(opts.Labels)
 	obj.SetMesh(opts.Mesh)
 	if err := s.Client.Create(ctx, obj); err != nil {
 		if kube_apierrs.IsAlreadyExists(err) {
@@ -51,10 +62,14 @@ func (s *KubernetesStore) Create(ctx context.Context, r core_model.Resource, fs
 	return nil
 }
 func (s *KubernetesStore) Update(ctx context.Context, r core_model.Resource, fs ...store.UpdateOptionsFunc) error {
+	opts := store.NewUpdateOptions(fs...)
 	obj, err := s.Converter.ToKubernetesObject(r)
 	if err != nil {
 		return errors.Wrap(err, "failed to convert core model into k8s counterpart")
 	}
+	if opts.Labels != nil {
+		obj.GetObjectMeta().SetLabels(opts.Labels)
+	}
 	if err := s.Client.Update(ctx, obj); err != nil {
 		if kube_apierrs.IsConflict(err) {
 			return store.ErrorResourceConflict(r.GetType(), r.GetMeta().GetNamespace(), r.GetMeta().GetName(), r.GetMeta().GetMesh())
@@ -79,16 +94,28 @@ func (s *KubernetesStore) Delete(ctx context.Context, r core_model.Resource, fs
 		return err
 	}
 
+	if opts.Version != "" && opts.Version != r.GetMeta().GetVersion() {
+		return store.ErrorResourceConflict(r.GetType(), opts.Namespace, opts.Name, opts.Mesh)
+	}
+
 	obj, err := s.Converter.ToKubernetesObject(r)
 	if err != nil {
 		return errors.Wrap(err, "failed to convert core model into k8s counterpart")
 	}
 	obj.GetObjectMeta().SetNamespace(opts.Namespace)
 	obj.GetObjectMeta().SetName(opts.Name)
-	if err := s.Client.Delete(ctx, obj); err != nil {
+	var deleteOpts []kube_client.DeleteOption
+	if opts.Version != "" {
+		// the version might still change between Get() and Delete()
+		deleteOpts = append(deleteOpts, kube_client.Preconditions{ResourceVersion: &opts.Version})
+	}
+	if err := s.Client.Delete(ctx, obj, deleteOpts...); err != nil {
 		if kube_apierrs.IsNotFound(err) {
 			return nil
 		}
+		if kube_apierrs.IsConflict(err) {
+			return store.ErrorResourceConflict(r.GetType(), opts.Namespace, opts.Name, opts.Mesh)
+		}
 		return errors.Wrap(err, "failed to delete k8s resource")
 	}
 	return nil
@@ -115,22 +142,32 @@ func (s *KubernetesStore) Get(ctx context.Context, r core_model.Resource, fs ...
 }
 func (s *KubernetesStore) List(ctx context.Context, rs core_model.ResourceList, fs ...store.ListOptionsFunc) error {
 	opts := store.NewListOptions(fs...)
+	paginator, err := store.NewPaginator(opts)
+	if err != nil {
+		return err
+	}
 	obj, err := s.Converter.ToKubernetesList(rs)
 	if err != nil {
 		return errors.Wrap(err, "failed to convert core model into k8s counterpart")
 	}
-	if err := s.Client.List(ctx, obj, kube_client.InNamespace(opts.Namespace)); err != nil {
+	if err := s.Client.List(ctx, obj, kube_client.InNamespace(opts.Namespace), kube_client.MatchingLabels(opts.Labels)); err != nil {
 		return errors.Wrap(err, "failed to list k8s resources")
 	}
+	// objects are listed from the cache of informers in random order, ToCoreList visits them ordered by namespace and name,
+	// which makes pages stable
 	predicate := func(r core_model.Resource) bool {
-		if opts.Mesh != "" {
-			return r.GetMeta().GetMesh() == opts.Mesh
+		if opts.Mesh != "" && r.GetMeta().GetMesh() != opts.Mesh {
+			return false
+		}
+		if !opts.MatchesName(r.GetMeta().GetName()) || !opts.MatchesTags(r.GetSpec()) {
+			return false
 		}
-		return true
+		return paginator.Next()
 	}
 	if err := s.Converter.ToCoreList(obj, rs, predicate); err != nil {
 		return errors.Wrap(err, "failed to convert k8s model into core counterpart")
 	}
+	*rs.GetPagination() = paginator.Pagination()
 	return nil
 }
 
@@ -173,6 +210,7 @@ type Converter interface {
 	ToKubernetesObject(core_model.Resource) (k8s_model.KubernetesObject, error)
 	ToKubernetesList(core_model.ResourceList) (k8s_model.KubernetesList, error)
 	ToCoreResource(obj k8s_model.KubernetesObject, out core_model.Resource) error
+	// ToCoreList converts objects ordered by namespace and name, adding those that satisfy the predicate to the list.
 	ToCoreList(obj k8s_model.KubernetesList, out core_model.ResourceList, predicate ConverterPredicate) error
 }
 
@@ -221,7 +259,15 @@ func (c *SimpleConverter) ToCoreResource(obj k8s_model.KubernetesObject, out cor
 }
 
 func (c *SimpleConverter) ToCoreList(in k8s_model.KubernetesList, out core_model.ResourceList, predicate ConverterPredicate) error {
-	for _, o := range in.GetItems() {
+	items := in.GetItems()
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i].GetObjectMeta(), items[j].GetObjectMeta()
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	for _, o := range items {
 		r := out.NewItem()
 		if err := c.ToCoreResource(o, r); err != nil {
 			return err
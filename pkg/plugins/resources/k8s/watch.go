@@ -0,0 +1,84 @@
+package k8s
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	kube_toolscache "k8s.io/client-go/tools/cache"
+
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	core_registry "github.com/Kong/kuma/pkg/core/resources/registry"
+	"github.com/Kong/kuma/pkg/core/resources/store"
+	k8s_model "github.com/Kong/kuma/pkg/plugins/resources/k8s/native/pkg/model"
+)
+
+var _ store.ResourceWatcher = &KubernetesStore{}
+
+func (s *KubernetesStore) Watch(ctx context.Context, resourceType core_model.ResourceType, namespace string) (<-chan store.Event, error) {
+	if s.Informers == nil {
+		return nil, errors.New("watching k8s resources requires Informers to be configured")
+	}
+	s.watchMu.Lock()
+	defer s.watchMu.Unlock()
+	if s.events == nil {
+		s.events = store.NewEventBroadcaster()
+		s.watchedTypes = map[core_model.ResourceType]bool{}
+	}
+	// informers are shared and handlers cannot be removed from them, that is why there is a single handler per type
+	if !s.watchedTypes[resourceType] {
+		if err := s.addEventHandler(resourceType); err != nil {
+			return nil, err
+		}
+		s.watchedTypes[resourceType] = true
+	}
+	return s.events.Subscribe(ctx, resourceType, namespace), nil
+}
+
+func (s *KubernetesStore) addEventHandler(resourceType core_model.ResourceType) error {
+	resource, err := core_registry.Global().NewObject(resourceType)
+	if err != nil {
+		return err
+	}
+	obj, err := s.Converter.ToKubernetesObject(resource)
+	if err != nil {
+		return errors.Wrap(err, "failed to convert core model into k8s counterpart")
+	}
+	informer, err := s.Informers.GetInformer(obj)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get an informer of %s", resourceType)
+	}
+	send := func(eventType store.EventType, obj interface{}) {
+		if tombstone, ok := obj.(kube_toolscache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		kubeObj, ok := obj.(k8s_model.KubernetesObject)
+		if !ok {
+			return
+		}
+		s.events.Send(store.Event{
+			Type:         eventType,
+			ResourceType: resourceType,
+			Namespace:    kubeObj.GetObjectMeta().GetNamespace(),
+			Name:         kubeObj.GetObjectMeta().GetName(),
+			Mesh:         kubeObj.GetMesh(),
+		})
+	}
+	informer.AddEventHandler(kube_toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			send(store.CreatedEvent, obj)
+		},
+		UpdateFunc: func(oldObj, obj interface{}) {
+			// informers also call it on periodic resyncs, when nothing has changed
+			if oldKubeObj, ok := oldObj.(k8s_model.KubernetesObject); ok {
+				if kubeObj, ok := obj.(k8s_model.KubernetesObject); ok && oldKubeObj.GetObjectMeta().GetResourceVersion() == kubeObj.GetObjectMeta().GetResourceVersion() {
+					return
+				}
+			}
+			send(store.UpdatedEvent, obj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			send(store.DeletedEvent, obj)
+		},
+	})
+	return nil
+}
@@ -3,12 +3,14 @@ package memory
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 	"sync"
 
+	"github.com/gogo/protobuf/proto"
+
 	"github.com/Kong/kuma/pkg/core/resources/model"
 	"github.com/Kong/kuma/pkg/core/resources/store"
-	util_proto "github.com/Kong/kuma/pkg/util/proto"
 )
 
 type memoryStoreRecord struct {
@@ -17,7 +19,10 @@ type memoryStoreRecord struct {
 	Name         string
 	Mesh         string
 	Version      memoryVersion
-	Spec         string
+	Labels       map[string]string
+	// Spec is a private copy of a resource spec that is never modified once stored,
+	// which makes it safe to share its strings with copies handed out to readers.
+	Spec model.ResourceSpec
 }
 type memoryStoreRecords = []*memoryStoreRecord
 
@@ -28,6 +33,7 @@ type memoryMeta struct {
 	Name      string
 	Mesh      string
 	Version   memoryVersion
+	Labels    map[string]string
 }
 
 func (m memoryMeta) GetName() string {
@@ -42,6 +48,9 @@ func (m memoryMeta) GetMesh() string {
 func (m memoryMeta) GetVersion() string {
 	return m.Version.String()
 }
+func (m memoryMeta) GetLabels() map[string]string {
+	return m.Labels
+}
 
 type memoryVersion uint64
 
@@ -62,10 +71,15 @@ var _ store.ResourceStore = &memoryStore{}
 type memoryStore struct {
 	records memoryStoreRecords
 	mu      sync.RWMutex
+	events  *store.EventBroadcaster
 }
 
+var _ store.ResourceWatcher = &memoryStore{}
+
 func NewStore() store.ResourceStore {
-	return &memoryStore{}
+	return &memoryStore{
+		events: store.NewEventBroadcaster(),
+	}
 }
 
 func (c *memoryStore) Create(_ context.Context, r model.Resource, fs ...store.CreateOptionsFunc) error {
@@ -84,6 +98,7 @@ func (c *memoryStore) Create(_ context.Context, r model.Resource, fs ...store.Cr
 		Namespace: opts.Namespace,
 		Mesh:      opts.Mesh,
 		Version:   initialVersion(),
+		Labels:    copyLabels(opts.Labels),
 	}
 
 	// fill the meta
@@ -100,13 +115,14 @@ func (c *memoryStore) Create(_ context.Context, r model.Resource, fs ...store.Cr
 
 	// persist
 	c.records = append(c.records, record)
+	c.events.Send(store.Event{Type: store.CreatedEvent, ResourceType: r.GetType(), Namespace: opts.Namespace, Name: opts.Name, Mesh: opts.Mesh})
 	return nil
 }
 func (c *memoryStore) Update(_ context.Context, r model.Resource, fs ...store.UpdateOptionsFunc) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	_ = store.NewUpdateOptions(fs...)
+	opts := store.NewUpdateOptions(fs...)
 
 	meta, ok := (r.GetMeta()).(memoryMeta)
 	if !ok {
@@ -120,6 +136,11 @@ func (c *memoryStore) Update(_ context.Context, r model.Resource, fs ...store.Up
 		return store.ErrorResourceConflict(r.GetType(), r.GetMeta().GetNamespace(), r.GetMeta().GetName(), r.GetMeta().GetMesh())
 	}
 	meta.Version = meta.Version.Next()
+	if opts.Labels != nil {
+		meta.Labels = copyLabels(opts.Labels)
+	} else {
+		meta.Labels = record.Labels
+	}
 
 	record, err := c.marshalRecord(
 		string(r.GetType()),
@@ -131,6 +152,11 @@ func (c *memoryStore) Update(_ context.Context, r model.Resource, fs ...store.Up
 
 	// persist
 	c.records[idx] = record
+	c.events.Send(store.Event{Type: store.UpdatedEvent, ResourceType: r.GetType(), Namespace: meta.Namespace, Name: meta.Name, Mesh: meta.Mesh})
+
+	// refresh the meta
+	meta.Labels = copyLabels(meta.Labels)
+	r.SetMeta(meta)
 	return nil
 }
 func (c *memoryStore) Delete(_ context.Context, r model.Resource, fs ...store.DeleteOptionsFunc) error {
@@ -147,11 +173,19 @@ func (c *memoryStore) Delete(_ context.Context, r model.Resource, fs ...store.De
 	// Namespace and Name must be provided via DeleteOptions
 	idx, record := c.findRecord(string(r.GetType()), opts.Namespace, opts.Name, opts.Mesh)
 	if record != nil {
+		if opts.Version != "" && opts.Version != record.Version.String() {
+			return store.ErrorResourceConflict(r.GetType(), opts.Namespace, opts.Name, opts.Mesh)
+		}
 		c.records = append(c.records[:idx], c.records[idx+1:]...)
+		c.events.Send(store.Event{Type: store.DeletedEvent, ResourceType: r.GetType(), Namespace: opts.Namespace, Name: opts.Name, Mesh: opts.Mesh})
 	}
 	return nil
 }
 
+func (c *memoryStore) Watch(ctx context.Context, resourceType model.ResourceType, namespace string) (<-chan store.Event, error) {
+	return c.events.Subscribe(ctx, resourceType, namespace), nil
+}
+
 func (c *memoryStore) Get(_ context.Context, r model.Resource, fs ...store.GetOptionsFunc) error {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -170,19 +204,46 @@ func (c *memoryStore) List(_ context.Context, rs model.ResourceList, fs ...store
 	defer c.mu.RUnlock()
 
 	opts := store.NewListOptions(fs...)
+	paginator, err := store.NewPaginator(opts)
+	if err != nil {
+		return err
+	}
 
 	// Namespace must be provided via ListOptions
 	records := c.findRecords(string(rs.GetItemType()), opts.Namespace, opts.Mesh)
+	if opts.PageSize > 0 || opts.PageOffset != "" {
+		// records are kept in order of creation, which changes once a record is deleted
+		sortRecords(records)
+	}
 	for _, record := range records {
+		if !opts.MatchesLabels(record.Labels) || !opts.MatchesName(record.Name) {
+			continue
+		}
 		r := rs.NewItem()
 		if err := c.unmarshalRecord(record, r); err != nil {
 			return err
 		}
+		if !opts.MatchesTags(r.GetSpec()) || !paginator.Next() {
+			continue
+		}
 		_ = rs.AddItem(r)
 	}
+	*rs.GetPagination() = paginator.Pagination()
 	return nil
 }
 
+func sortRecords(records []*memoryStoreRecord) {
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Name != records[j].Name {
+			return records[i].Name < records[j].Name
+		}
+		if records[i].Namespace != records[j].Namespace {
+			return records[i].Namespace < records[j].Namespace
+		}
+		return records[i].Mesh < records[j].Mesh
+	})
+}
+
 func (c *memoryStore) findRecord(
 	resourceType string, namespace string, name string, mesh string) (int, *memoryStoreRecord) {
 	for idx, rec := range c.records {
@@ -211,10 +272,7 @@ func (c *memoryStore) findRecords(
 
 func (c *memoryStore) marshalRecord(resourceType string, meta memoryMeta, spec model.ResourceSpec) (*memoryStoreRecord, error) {
 	// convert spec into storage representation
-	content, err := util_proto.ToJSON(spec)
-	if err != nil {
-		return nil, err
-	}
+	content := proto.Clone(spec)
 	return &memoryStoreRecord{
 		ResourceType: resourceType,
 		// Namespace and Name must be provided via CreateOptions
@@ -222,7 +280,8 @@ func (c *memoryStore) marshalRecord(resourceType string, meta memoryMeta, spec m
 		Name:      meta.Name,
 		Mesh:      meta.Mesh,
 		Version:   meta.Version,
-		Spec:      string(content),
+		Labels:    meta.Labels,
+		Spec:      content,
 	}, nil
 }
 
@@ -232,6 +291,23 @@ func (c *memoryStore) unmarshalRecord(s *memoryStoreRecord, r model.Resource) er
 		Name:      s.Name,
 		Mesh:      s.Mesh,
 		Version:   s.Version,
+		Labels:    copyLabels(s.Labels),
 	})
-	return util_proto.FromJSON([]byte(s.Spec), r.GetSpec())
+	// proto.Merge makes a deep copy of messages, maps and lists, while strings are shared
+	spec := r.GetSpec()
+	spec.Reset()
+	proto.Merge(spec, s.Spec)
+	return nil
+}
+
+// copyLabels makes sure that labels of a stored record cannot be modified by callers.
+func copyLabels(labels map[string]string) map[string]string {
+	if len(labels) == 0 {
+		return nil
+	}
+	labelsCopy := make(map[string]string, len(labels))
+	for key, value := range labels {
+		labelsCopy[key] = value
+	}
+	return labelsCopy
 }
@@ -0,0 +1,78 @@
+package memory_test
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"testing"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/store"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+)
+
+// BenchmarkList measures the cost of listing Dataplanes and how much heap the store
+// and a listed copy of all Dataplanes, e.g. held by a cache, retain together.
+//
+// Run it with: go test ./pkg/plugins/resources/memory/ -run=^$ -bench=List -benchmem
+func BenchmarkList(b *testing.B) {
+	const dataplanes = 1000
+	ctx := context.Background()
+
+	load := func() (store.ResourceStore, *core_mesh.DataplaneResourceList) {
+		s := memory.NewStore()
+		for i := 0; i < dataplanes; i++ {
+			dataplane := &core_mesh.DataplaneResource{
+				Spec: mesh_proto.Dataplane{
+					Networking: &mesh_proto.Dataplane_Networking{
+						Inbound: []*mesh_proto.Dataplane_Networking_Inbound{{
+							Interface: fmt.Sprintf("10.0.%d.%d:8080:80", i/256, i%256),
+							Tags: map[string]string{
+								"service":           "backend.default.svc:80",
+								"version":           "v1",
+								"env":               "prod",
+								"team":              "payments",
+								"pod-template-hash": "5d7f9c6b8",
+							},
+						}},
+						Outbound: []*mesh_proto.Dataplane_Networking_Outbound{
+							{Interface: ":10001", Service: "web.default.svc:80"},
+							{Interface: ":10002", Service: "db.default.svc:5432"},
+							{Interface: ":10003", Service: "cache.default.svc:6379"},
+							{Interface: ":10004", Service: "billing.default.svc:80"},
+						},
+					},
+				},
+			}
+			if err := s.Create(ctx, dataplane, store.CreateByKey("default", fmt.Sprintf("dp-%d", i), "demo")); err != nil {
+				b.Fatal(err)
+			}
+		}
+		list := &core_mesh.DataplaneResourceList{}
+		if err := s.List(ctx, list, store.ListByMesh("demo")); err != nil {
+			b.Fatal(err)
+		}
+		return s, list
+	}
+
+	s, _ := load()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := s.List(ctx, &core_mesh.DataplaneResourceList{}, store.ListByMesh("demo")); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.StopTimer()
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	s, list := load()
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	runtime.KeepAlive(s)
+	runtime.KeepAlive(list)
+	b.Logf("%d bytes of heap retained per Dataplane", (after.HeapAlloc-before.HeapAlloc)/dataplanes)
+}
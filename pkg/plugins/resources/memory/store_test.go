@@ -1,9 +1,14 @@
 package memory_test
 
 import (
+	"context"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
 	"github.com/Kong/kuma/pkg/core/resources/store"
 	"github.com/Kong/kuma/pkg/plugins/resources/memory"
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
 )
 
 var _ = Describe("MemoryStore", func() {
@@ -12,4 +17,45 @@ var _ = Describe("MemoryStore", func() {
 	}
 
 	store.ExecuteStoreTests(createStore)
+
+	It("should not let modifications of resources leak into the store", func() {
+		// given
+		s := createStore()
+		dataplane := &core_mesh.DataplaneResource{
+			Spec: mesh_proto.Dataplane{
+				Networking: &mesh_proto.Dataplane_Networking{
+					Inbound: []*mesh_proto.Dataplane_Networking_Inbound{
+						{
+							Interface: "127.0.0.1:8080:80",
+							Tags: map[string]string{
+								"service": "backend",
+							},
+						},
+					},
+				},
+			},
+		}
+		err := s.Create(context.Background(), dataplane, store.CreateByKey("default", "dp1", "demo"))
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		dataplane.Spec.Networking.Inbound[0].Tags["service"] = "web"
+		fetched := &core_mesh.DataplaneResource{}
+		err = s.Get(context.Background(), fetched, store.GetByKey("default", "dp1", "demo"))
+		Expect(err).ToNot(HaveOccurred())
+
+		// then
+		Expect(fetched.Spec.Networking.Inbound[0].Tags).To(Equal(map[string]string{"service": "backend"}))
+
+		// when
+		fetched.Spec.Networking.Inbound[0].Tags["version"] = "v1"
+		fetched.Spec.Networking.Inbound = append(fetched.Spec.Networking.Inbound, &mesh_proto.Dataplane_Networking_Inbound{})
+		again := &core_mesh.DataplaneResource{}
+		err = s.Get(context.Background(), again, store.GetByKey("default", "dp1", "demo"))
+		Expect(err).ToNot(HaveOccurred())
+
+		// then
+		Expect(again.Spec.Networking.Inbound).To(HaveLen(1))
+		Expect(again.Spec.Networking.Inbound[0].Tags).To(Equal(map[string]string{"service": "backend"}))
+	})
 })
@@ -0,0 +1,106 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/pkg/errors"
+
+	"github.com/Kong/kuma/pkg/core"
+)
+
+var migrationsLog = core.Log.WithName("postgres-migrations")
+
+// migrationsLockId is an id of the advisory lock that serializes migrations
+// of Control Planes that are started at the same time.
+const migrationsLockId = 6173907
+
+// migrations of the DB schema, applied in order.
+// Once released, a migration must never be changed. A new one has to be appended instead.
+//
+// Migrations are idempotent, so that they can be applied to a DB that has been set up with install/postgres/resource.sql.
+var migrations = []string{
+	// 1: resources
+	`CREATE TABLE IF NOT EXISTS resources (
+		name        varchar(100) NOT NULL,
+		namespace   varchar(100) NOT NULL,
+		mesh        varchar(100) NOT NULL,
+		type        varchar(100) NOT NULL,
+		version     integer NOT NULL,
+		spec        text,
+		PRIMARY KEY (name, namespace, mesh, type)
+	);`,
+	// 2: labels of resources
+	`ALTER TABLE resources ADD COLUMN IF NOT EXISTS labels jsonb;`,
+	// 3: notifications about changes of resources, see watch.go
+	`CREATE OR REPLACE FUNCTION notify_resource_event() RETURNS trigger AS $$
+	DECLARE
+		changed resources;
+	BEGIN
+		IF TG_OP = 'DELETE' THEN
+			changed := OLD;
+		ELSE
+			changed := NEW;
+		END IF;
+		PERFORM pg_notify('resource_events', json_build_object(
+			'action', TG_OP,
+			'type', changed.type,
+			'namespace', changed.namespace,
+			'name', changed.name,
+			'mesh', changed.mesh
+		)::text);
+		RETURN NULL;
+	END;
+	$$ LANGUAGE plpgsql;
+	DROP TRIGGER IF EXISTS resource_events ON resources;
+	CREATE TRIGGER resource_events AFTER INSERT OR UPDATE OR DELETE ON resources
+		FOR EACH ROW EXECUTE PROCEDURE notify_resource_event();`,
+}
+
+// migrate brings the DB schema up to date with the latest migration.
+func migrate(db *sql.DB) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return errors.Wrap(err, "failed to begin a transaction")
+	}
+	if err := migrateInTx(tx); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			migrationsLog.Error(rollbackErr, "failed to rollback a transaction")
+		}
+		return err
+	}
+	return errors.Wrap(tx.Commit(), "failed to commit a transaction")
+}
+
+func migrateInTx(tx *sql.Tx) error {
+	// the lock is released once the transaction is over
+	if _, err := tx.Exec(`SELECT pg_advisory_xact_lock($1);`, migrationsLockId); err != nil {
+		return errors.Wrap(err, "failed to acquire a lock")
+	}
+	statement := `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version     integer PRIMARY KEY,
+		applied_at  timestamp NOT NULL DEFAULT now()
+	);`
+	if _, err := tx.Exec(statement); err != nil {
+		return errors.Wrapf(err, "failed to execute query: %s", statement)
+	}
+
+	var current int
+	statement = `SELECT COALESCE(MAX(version), 0) FROM schema_migrations;`
+	if err := tx.QueryRow(statement).Scan(&current); err != nil {
+		return errors.Wrapf(err, "failed to execute query: %s", statement)
+	}
+	if current > len(migrations) {
+		return errors.Errorf("DB schema is at version %d, which is newer than the latest version %d known to this Control Plane", current, len(migrations))
+	}
+
+	for version := current + 1; version <= len(migrations); version++ {
+		if _, err := tx.Exec(migrations[version-1]); err != nil {
+			return errors.Wrapf(err, "failed to apply migration %d", version)
+		}
+		if _, err := tx.Exec(`INSERT INTO schema_migrations (version) VALUES ($1);`, version); err != nil {
+			return errors.Wrapf(err, "failed to record migration %d", version)
+		}
+		migrationsLog.Info("applied migration", "version", version)
+	}
+	return nil
+}
@@ -3,21 +3,33 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
 	"fmt"
+	kuma_config "github.com/Kong/kuma/pkg/config"
 	config "github.com/Kong/kuma/pkg/config/plugins/resources/postgres"
 	"github.com/Kong/kuma/pkg/core/resources/model"
 	"github.com/Kong/kuma/pkg/core/resources/store"
 	"github.com/Kong/kuma/pkg/util/proto"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 	"strconv"
 	"strings"
+	"sync"
 )
 
 const duplicateKeyErrorMsg = "duplicate key value violates unique constraint"
 
 type postgresResourceStore struct {
 	db *sql.DB
+	// connector of the DB, which the listener of notifications opens a dedicated connection with
+	connector *connector
+	events    *store.EventBroadcaster
+
+	mu       sync.Mutex
+	listener *pq.Listener
+	// connection string that the listener has been created with
+	listenerConnStr string
 }
 
 var _ store.ResourceStore = &postgresResourceStore{}
@@ -28,20 +40,62 @@ func NewStore(config config.PostgresStoreConfig) (store.ResourceStore, error) {
 		return nil, err
 	}
 
+	if err := migrate(db); err != nil {
+		return nil, errors.Wrap(err, "failed to migrate DB schema")
+	}
+
 	return &postgresResourceStore{
-		db: db,
+		db:        db,
+		connector: newConnector(config),
+		events:    store.NewEventBroadcaster(),
 	}, nil
 }
 
-func connectToDb(config config.PostgresStoreConfig) (*sql.DB, error) {
-	connStr := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable connect_timeout=%d",
-		config.Host, config.Port, config.User, config.Password, config.DbName, config.ConnectionTimeout)
-	db, err := sql.Open("postgres", connStr)
+// connector opens connections to the DB with the current password,
+// so that once the password has been rotated in a file, new connections use the new one.
+type connector struct {
+	config   config.PostgresStoreConfig
+	password kuma_config.Secret
+}
+
+var _ driver.Connector = &connector{}
+
+func newConnector(config config.PostgresStoreConfig) *connector {
+	return &connector{
+		config:   config,
+		password: config.PasswordSecret(),
+	}
+}
+
+func (c *connector) connectionString() (string, error) {
+	password, err := c.password.Value()
 	if err != nil {
-		return nil, errors.Wrap(err, "cannot create connection to DB")
+		return "", errors.Wrap(err, "could not read the password of the DB")
 	}
+	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s connect_timeout=%d",
+		c.config.Host, c.config.Port, c.config.User, password, c.config.DbName, c.config.SSLMode, c.config.ConnectionTimeout), nil
+}
 
-	// check connection to DB, Open() does not check it.
+func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
+	connStr, err := c.connectionString()
+	if err != nil {
+		return nil, err
+	}
+	pqConnector, err := pq.NewConnector(connStr)
+	if err != nil {
+		return nil, err
+	}
+	return pqConnector.Connect(ctx)
+}
+
+func (c *connector) Driver() driver.Driver {
+	return &pq.Driver{}
+}
+
+func connectToDb(config config.PostgresStoreConfig) (*sql.DB, error) {
+	db := sql.OpenDB(newConnector(config))
+
+	// check connection to DB, OpenDB() does not check it.
 	if err := db.Ping(); err != nil {
 		return nil, errors.Wrap(err, "cannot connect to DB")
 	}
@@ -57,9 +111,14 @@ func (r *postgresResourceStore) Create(_ context.Context, resource model.Resourc
 		return errors.Wrap(err, "failed to convert spec to json")
 	}
 
+	labels, err := labelsToJSON(opts.Labels)
+	if err != nil {
+		return err
+	}
+
 	version := 0
-	statement := `INSERT INTO resources VALUES ($1, $2, $3, $4, $5, $6);`
-	_, err = r.db.Exec(statement, opts.Name, opts.Namespace, opts.Mesh, resource.GetType(), version, string(bytes))
+	statement := `INSERT INTO resources (name, namespace, mesh, type, version, spec, labels) VALUES ($1, $2, $3, $4, $5, $6, $7);`
+	_, err = r.db.Exec(statement, opts.Name, opts.Namespace, opts.Mesh, resource.GetType(), version, string(bytes), labels)
 	if err != nil {
 		if strings.Contains(err.Error(), duplicateKeyErrorMsg) {
 			return store.ErrorResourceAlreadyExists(resource.GetType(), opts.Namespace, opts.Name, opts.Mesh)
@@ -72,11 +131,14 @@ func (r *postgresResourceStore) Create(_ context.Context, resource model.Resourc
 		Namespace: opts.Namespace,
 		Mesh:      opts.Mesh,
 		Version:   strconv.Itoa(version),
+		Labels:    opts.Labels,
 	})
 	return nil
 }
 
 func (r *postgresResourceStore) Update(_ context.Context, resource model.Resource, fs ...store.UpdateOptionsFunc) error {
+	opts := store.NewUpdateOptions(fs...)
+
 	bytes, err := proto.ToJSON(resource.GetSpec())
 	if err != nil {
 		return err
@@ -86,11 +148,20 @@ func (r *postgresResourceStore) Update(_ context.Context, resource model.Resourc
 	if err != nil {
 		return errors.Wrap(err, "failed to convert meta version to int")
 	}
-	statement := `UPDATE resources SET spec=$1, version=$2 WHERE name=$3 AND namespace=$4 AND mesh=$5 AND type=$6 AND version=$7;`
+	labels := resource.GetMeta().GetLabels()
+	if opts.Labels != nil {
+		labels = opts.Labels
+	}
+	labelsJSON, err := labelsToJSON(labels)
+	if err != nil {
+		return err
+	}
+	statement := `UPDATE resources SET spec=$1, version=$2, labels=$3 WHERE name=$4 AND namespace=$5 AND mesh=$6 AND type=$7 AND version=$8;`
 	result, err := r.db.Exec(
 		statement,
 		string(bytes),
 		version+1,
+		labelsJSON,
 		resource.GetMeta().GetName(),
 		resource.GetMeta().GetNamespace(),
 		resource.GetMeta().GetMesh(),
@@ -109,7 +180,8 @@ func (r *postgresResourceStore) Update(_ context.Context, resource model.Resourc
 		Name:      resource.GetMeta().GetName(),
 		Namespace: resource.GetMeta().GetNamespace(),
 		Mesh:      resource.GetMeta().GetMesh(),
-		Version:   strconv.Itoa(version),
+		Version:   strconv.Itoa(version + 1),
+		Labels:    labels,
 	})
 
 	return nil
@@ -118,6 +190,10 @@ func (r *postgresResourceStore) Update(_ context.Context, resource model.Resourc
 func (r *postgresResourceStore) Delete(_ context.Context, resource model.Resource, fs ...store.DeleteOptionsFunc) error {
 	opts := store.NewDeleteOptions(fs...)
 
+	if opts.Version != "" {
+		return r.deleteVersion(resource, opts)
+	}
+
 	statement := `DELETE FROM resources WHERE name=$1 AND namespace=$2 AND type=$3 AND mesh=$4`
 	_, err := r.db.Exec(statement, opts.Name, opts.Namespace, resource.GetType(), opts.Mesh)
 	if err != nil {
@@ -127,15 +203,43 @@ func (r *postgresResourceStore) Delete(_ context.Context, resource model.Resourc
 	return nil
 }
 
+func (r *postgresResourceStore) deleteVersion(resource model.Resource, opts *store.DeleteOptions) error {
+	version, err := strconv.Atoi(opts.Version)
+	if err != nil {
+		// no resource can ever have such a version
+		return store.ErrorResourceConflict(resource.GetType(), opts.Namespace, opts.Name, opts.Mesh)
+	}
+	statement := `DELETE FROM resources WHERE name=$1 AND namespace=$2 AND type=$3 AND mesh=$4 AND version=$5`
+	result, err := r.db.Exec(statement, opts.Name, opts.Namespace, resource.GetType(), opts.Mesh, version)
+	if err != nil {
+		return errors.Wrapf(err, "failed to execute query: %s", statement)
+	}
+	if rows, _ := result.RowsAffected(); rows == 1 { // error ignored, postgres supports RowsAffected()
+		return nil
+	}
+	// distinguish a resource of another version from a resource that doesn't exist, which is not an error
+	statement = `SELECT 1 FROM resources WHERE name=$1 AND namespace=$2 AND type=$3 AND mesh=$4`
+	var exists int
+	err = r.db.QueryRow(statement, opts.Name, opts.Namespace, resource.GetType(), opts.Mesh).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to execute query: %s", statement)
+	}
+	return store.ErrorResourceConflict(resource.GetType(), opts.Namespace, opts.Name, opts.Mesh)
+}
+
 func (r *postgresResourceStore) Get(_ context.Context, resource model.Resource, fs ...store.GetOptionsFunc) error {
 	opts := store.NewGetOptions(fs...)
 
-	statement := `SELECT spec, version FROM resources WHERE name=$1 AND namespace=$2 AND mesh=$3 AND type=$4;`
+	statement := `SELECT spec, version, labels FROM resources WHERE name=$1 AND namespace=$2 AND mesh=$3 AND type=$4;`
 	row := r.db.QueryRow(statement, opts.Name, opts.Namespace, opts.Mesh, resource.GetType())
 
 	var spec string
 	var version int
-	err := row.Scan(&spec, &version)
+	var labelsJSON sql.NullString
+	err := row.Scan(&spec, &version, &labelsJSON)
 	if err == sql.ErrNoRows {
 		return store.ErrorResourceNotFound(resource.GetType(), opts.Namespace, opts.Name, opts.Mesh)
 	}
@@ -146,12 +250,17 @@ func (r *postgresResourceStore) Get(_ context.Context, resource model.Resource,
 	if err := proto.FromJSON([]byte(spec), resource.GetSpec()); err != nil {
 		return errors.Wrap(err, "failed to convert json to spec")
 	}
+	labels, err := labelsFromJSON(labelsJSON)
+	if err != nil {
+		return err
+	}
 
 	meta := &resourceMetaObject{
 		Name:      opts.Name,
 		Namespace: opts.Namespace,
 		Mesh:      opts.Mesh,
 		Version:   strconv.Itoa(version),
+		Labels:    labels,
 	}
 	resource.SetMeta(meta)
 	return nil
@@ -159,21 +268,54 @@ func (r *postgresResourceStore) Get(_ context.Context, resource model.Resource,
 
 func (r *postgresResourceStore) List(_ context.Context, resources model.ResourceList, args ...store.ListOptionsFunc) error {
 	opts := store.NewListOptions(args...)
+	paginator, err := store.NewPaginator(opts)
+	if err != nil {
+		return err
+	}
 
-	statement := `SELECT name, namespace, mesh, spec, version FROM resources WHERE type=$1`
+	condition := `type=$1`
 	var statementArgs []interface{}
 	statementArgs = append(statementArgs, resources.GetItemType())
 	argsIndex := 1
 	if opts.Namespace != "" {
 		argsIndex++
-		statement += fmt.Sprintf(" AND namespace=$%d", argsIndex)
+		condition += fmt.Sprintf(" AND namespace=$%d", argsIndex)
 		statementArgs = append(statementArgs, opts.Namespace)
 	}
 	if opts.Mesh != "" {
 		argsIndex++
-		statement += fmt.Sprintf(" AND mesh=$%d", argsIndex)
+		condition += fmt.Sprintf(" AND mesh=$%d", argsIndex)
 		statementArgs = append(statementArgs, opts.Mesh)
 	}
+	if len(opts.Labels) > 0 {
+		labels, err := labelsToJSON(opts.Labels)
+		if err != nil {
+			return err
+		}
+		argsIndex++
+		condition += fmt.Sprintf(" AND labels @> $%d::jsonb", argsIndex)
+		statementArgs = append(statementArgs, labels)
+	}
+	if opts.NamePrefix != "" {
+		argsIndex++
+		condition += fmt.Sprintf(" AND left(name, char_length($%d)) = $%d", argsIndex, argsIndex)
+		statementArgs = append(statementArgs, opts.NamePrefix)
+	}
+
+	statement := `SELECT name, namespace, mesh, spec, version, labels FROM resources WHERE ` + condition + ` ORDER BY name, namespace, mesh`
+	// tags are a part of the spec, so resources filtered by tags cannot be paginated by the DB
+	paginatedByDB := len(opts.Tags) == 0 && (paginator.Size > 0 || paginator.Offset > 0)
+	total := 0
+	if paginatedByDB {
+		countStatement := `SELECT count(*) FROM resources WHERE ` + condition
+		if err := r.db.QueryRow(countStatement, statementArgs...).Scan(&total); err != nil {
+			return errors.Wrapf(err, "failed to execute query: %s", countStatement)
+		}
+		if paginator.Size > 0 {
+			statement += fmt.Sprintf(" LIMIT %d", paginator.Size)
+		}
+		statement += fmt.Sprintf(" OFFSET %d", paginator.Offset)
+	}
 	rows, err := r.db.Query(statement, statementArgs...)
 	if err != nil {
 		return errors.Wrapf(err, "failed to execute query: %s", statement)
@@ -184,18 +326,27 @@ func (r *postgresResourceStore) List(_ context.Context, resources model.Resource
 		if err != nil {
 			return err
 		}
+		if !opts.MatchesTags(item.GetSpec()) || (!paginatedByDB && !paginator.Next()) {
+			continue
+		}
 		err = resources.AddItem(item)
 		if err != nil {
 			return err
 		}
 	}
+	if paginatedByDB {
+		*resources.GetPagination() = paginator.PaginationOf(total)
+	} else {
+		*resources.GetPagination() = paginator.Pagination()
+	}
 	return nil
 }
 
 func rowToItem(resources model.ResourceList, rows *sql.Rows) (model.Resource, error) {
 	var name, namespace, mesh, spec string
 	var version int
-	if err := rows.Scan(&name, &namespace, &mesh, &spec, &version); err != nil {
+	var labelsJSON sql.NullString
+	if err := rows.Scan(&name, &namespace, &mesh, &spec, &version, &labelsJSON); err != nil {
 		return nil, errors.Wrap(err, "failed to retrieve elements from query")
 	}
 
@@ -203,12 +354,17 @@ func rowToItem(resources model.ResourceList, rows *sql.Rows) (model.Resource, er
 	if err := proto.FromJSON([]byte(spec), item.GetSpec()); err != nil {
 		return nil, errors.Wrap(err, "failed to convert json to spec")
 	}
+	labels, err := labelsFromJSON(labelsJSON)
+	if err != nil {
+		return nil, err
+	}
 
 	meta := &resourceMetaObject{
 		Name:      name,
 		Namespace: namespace,
 		Mesh:      mesh,
 		Version:   strconv.Itoa(version),
+		Labels:    labels,
 	}
 	item.SetMeta(meta)
 
@@ -216,6 +372,14 @@ func rowToItem(resources model.ResourceList, rows *sql.Rows) (model.Resource, er
 }
 
 func (r *postgresResourceStore) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.listener != nil {
+		if err := r.listener.Close(); err != nil {
+			return errors.Wrap(err, "failed to close the listener of notifications")
+		}
+		r.listener = nil
+	}
 	return r.db.Close()
 }
 
@@ -224,6 +388,7 @@ type resourceMetaObject struct {
 	Namespace string
 	Version   string
 	Mesh      string
+	Labels    map[string]string
 }
 
 var _ model.ResourceMeta = &resourceMetaObject{}
@@ -243,3 +408,30 @@ func (r *resourceMetaObject) GetVersion() string {
 func (r *resourceMetaObject) GetMesh() string {
 	return r.Mesh
 }
+
+func (r *resourceMetaObject) GetLabels() map[string]string {
+	return r.Labels
+}
+
+// labelsToJSON converts labels into a value of the labels column, which is NULL for resources without labels.
+func labelsToJSON(labels map[string]string) (sql.NullString, error) {
+	if len(labels) == 0 {
+		return sql.NullString{}, nil
+	}
+	bytes, err := json.Marshal(labels)
+	if err != nil {
+		return sql.NullString{}, errors.Wrap(err, "failed to convert labels to json")
+	}
+	return sql.NullString{String: string(bytes), Valid: true}, nil
+}
+
+func labelsFromJSON(column sql.NullString) (map[string]string, error) {
+	if !column.Valid {
+		return nil, nil
+	}
+	labels := map[string]string{}
+	if err := json.Unmarshal([]byte(column.String), &labels); err != nil {
+		return nil, errors.Wrap(err, "failed to convert json to labels")
+	}
+	return labels, nil
+}
@@ -15,7 +15,7 @@ import (
 var _ = Describe("postgresResourceStore", func() {
 
 	createStore := func() store.ResourceStore {
-		cfg := postgres.PostgresStoreConfig{}
+		cfg := *postgres.DefaultPostgresStoreConfig()
 		err := config.Load("", &cfg)
 		Expect(err).ToNot(HaveOccurred())
 
@@ -56,22 +56,11 @@ func prepareDb(cfg postgres.PostgresStoreConfig) error {
 	if err != nil {
 		return err
 	}
-	statement := ` 
-			CREATE TABLE IF NOT EXISTS resources (
-			   name        varchar(100) NOT NULL,
-			   namespace   varchar(100) NOT NULL,
-			   mesh        varchar(100) NOT NULL,
-			   type        varchar(100) NOT NULL,
-			   version     integer NOT NULL,
-			   spec        text,
-			   PRIMARY KEY (name, namespace, mesh, type)
-			);
-			DELETE FROM resources;
-		`
-	_, err = db.Exec(statement)
-	if err != nil {
+	if err := migrate(db); err != nil {
+		return err
+	}
+	if _, err := db.Exec(`DELETE FROM resources;`); err != nil {
 		return err
 	}
-	err = db.Close()
-	return err
+	return db.Close()
 }
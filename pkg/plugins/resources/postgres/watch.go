@@ -0,0 +1,154 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+
+	"github.com/Kong/kuma/pkg/core"
+	"github.com/Kong/kuma/pkg/core/resources/model"
+	"github.com/Kong/kuma/pkg/core/resources/store"
+)
+
+var watchLog = core.Log.WithName("postgres-watch")
+
+// resourceEventsChannel is a channel that a trigger on the resources table notifies about changes on.
+const resourceEventsChannel = "resource_events"
+
+const (
+	minReconnectInterval = time.Second
+	maxReconnectInterval = time.Minute
+)
+
+var _ store.ResourceWatcher = &postgresResourceStore{}
+
+// resourceNotification is a payload of a notification sent by the trigger on the resources table.
+type resourceNotification struct {
+	Action    string `json:"action"`
+	Type      string `json:"type"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Mesh      string `json:"mesh"`
+}
+
+func (r *postgresResourceStore) Watch(ctx context.Context, resourceType model.ResourceType, namespace string) (<-chan store.Event, error) {
+	if err := r.listen(); err != nil {
+		return nil, err
+	}
+	return r.events.Subscribe(ctx, resourceType, namespace), nil
+}
+
+// listen starts listening to notifications about changes of resources, unless it has been started already.
+//
+// Notifications are sent by the DB, so changes made by other instances of Control Plane are delivered as well.
+func (r *postgresResourceStore) listen() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.listener != nil {
+		return nil
+	}
+	connStr, err := r.connector.connectionString()
+	if err != nil {
+		return err
+	}
+	listener := r.newListener(connStr)
+	if err := listener.Listen(resourceEventsChannel); err != nil {
+		_ = listener.Close()
+		return errors.Wrap(err, "failed to listen to notifications about changes of resources")
+	}
+	r.listener = listener
+	r.listenerConnStr = connStr
+	go r.dispatch(listener.Notify)
+	return nil
+}
+
+func (r *postgresResourceStore) newListener(connStr string) *pq.Listener {
+	return pq.NewListener(connStr, minReconnectInterval, maxReconnectInterval, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			watchLog.Error(err, "connection of the listener of notifications failed")
+		}
+		if event == pq.ListenerEventConnectionAttemptFailed {
+			go r.renewListener()
+		}
+	})
+}
+
+// renewListener replaces the listener of notifications once the password of the DB has been rotated,
+// since the listener reconnects with the connection string it has been created with.
+func (r *postgresResourceStore) renewListener() {
+	connStr, err := r.connector.connectionString()
+	if err != nil {
+		watchLog.Error(err, "could not renew the listener of notifications")
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.listener == nil || r.listenerConnStr == connStr {
+		return
+	}
+	_ = r.listener.Close()
+	listener := r.newListener(connStr)
+	r.listener = listener
+	r.listenerConnStr = connStr
+	go func() {
+		// blocks until the listener has connected
+		if err := listener.Listen(resourceEventsChannel); err != nil {
+			r.mu.Lock()
+			renewed := r.listener != listener
+			r.mu.Unlock()
+			// a listener that has been renewed in the meantime is expected to fail
+			if !renewed {
+				watchLog.Error(err, "failed to listen to notifications about changes of resources")
+			}
+			return
+		}
+		// notifications sent while there was no listener have been missed
+		r.events.SendResync()
+	}()
+	go r.dispatch(listener.Notify)
+	watchLog.Info("password of the DB has changed, the listener of notifications has been renewed")
+}
+
+func (r *postgresResourceStore) dispatch(notifications <-chan *pq.Notification) {
+	for notification := range notifications {
+		if notification == nil {
+			// pq sends nil once it has reconnected
+			watchLog.Info("listener of notifications has reconnected, changes made in the meantime might have been missed")
+			r.events.SendResync()
+			continue
+		}
+		event, err := toEvent(notification.Extra)
+		if err != nil {
+			watchLog.Error(err, "received invalid notification", "payload", notification.Extra)
+			continue
+		}
+		r.events.Send(event)
+	}
+}
+
+func toEvent(payload string) (store.Event, error) {
+	notification := resourceNotification{}
+	if err := json.Unmarshal([]byte(payload), &notification); err != nil {
+		return store.Event{}, errors.Wrap(err, "failed to parse the payload")
+	}
+	event := store.Event{
+		ResourceType: model.ResourceType(notification.Type),
+		Namespace:    notification.Namespace,
+		Name:         notification.Name,
+		Mesh:         notification.Mesh,
+	}
+	switch notification.Action {
+	case "INSERT":
+		event.Type = store.CreatedEvent
+	case "UPDATE":
+		event.Type = store.UpdatedEvent
+	case "DELETE":
+		event.Type = store.DeletedEvent
+	default:
+		return store.Event{}, errors.Errorf("unknown action %q", notification.Action)
+	}
+	return event, nil
+}
@@ -10,6 +10,8 @@ import (
 	util_http "github.com/Kong/kuma/pkg/util/http"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"strconv"
 
 	"github.com/pkg/errors"
 )
@@ -31,9 +33,10 @@ type remoteStore struct {
 func (s *remoteStore) Create(ctx context.Context, res model.Resource, fs ...store.CreateOptionsFunc) error {
 	opts := store.NewCreateOptions(fs...)
 	meta := rest.ResourceMeta{
-		Type: string(res.GetType()),
-		Name: opts.Name,
-		Mesh: opts.Mesh,
+		Type:   string(res.GetType()),
+		Name:   opts.Name,
+		Mesh:   opts.Mesh,
+		Labels: opts.Labels,
 	}
 	if err := s.upsert(ctx, res, meta); err != nil {
 		return err
@@ -41,11 +44,16 @@ func (s *remoteStore) Create(ctx context.Context, res model.Resource, fs ...stor
 	return nil
 }
 func (s *remoteStore) Update(ctx context.Context, res model.Resource, fs ...store.UpdateOptionsFunc) error {
-	_ = store.NewUpdateOptions(fs...)
+	opts := store.NewUpdateOptions(fs...)
+	labels := res.GetMeta().GetLabels()
+	if opts.Labels != nil {
+		labels = opts.Labels
+	}
 	meta := rest.ResourceMeta{
-		Type: string(res.GetType()),
-		Name: res.GetMeta().GetName(),
-		Mesh: res.GetMeta().GetMesh(),
+		Type:   string(res.GetType()),
+		Name:   res.GetMeta().GetName(),
+		Mesh:   res.GetMeta().GetMesh(),
+		Labels: labels,
 	}
 	if err := s.upsert(ctx, res, meta); err != nil {
 		return err
@@ -83,11 +91,37 @@ func (s *remoteStore) upsert(ctx context.Context, res model.Resource, meta rest.
 		Name:      meta.Name,
 		Mesh:      meta.Mesh,
 		Version:   "",
+		Labels:    meta.Labels,
 	})
 	return nil
 }
-func (s *remoteStore) Delete(context.Context, model.Resource, ...store.DeleteOptionsFunc) error {
-	return errors.Errorf("not implemented yet")
+func (s *remoteStore) Delete(ctx context.Context, res model.Resource, fs ...store.DeleteOptionsFunc) error {
+	resourceApi, err := s.api.GetResourceApi(res.GetType())
+	if err != nil {
+		return errors.Wrapf(err, "failed to construct URI to delete a %q", res.GetType())
+	}
+	opts := store.NewDeleteOptions(fs...)
+	req, err := http.NewRequest("DELETE", resourceApi.Item(opts.Mesh, opts.Name), nil)
+	if err != nil {
+		return err
+	}
+	if opts.Force {
+		req.URL.RawQuery = url.Values{"force": []string{"true"}}.Encode()
+	}
+	if opts.Version != "" {
+		req.Header.Set("If-Match", strconv.Quote(opts.Version))
+	}
+	statusCode, b, err := s.doRequest(ctx, req)
+	if err != nil {
+		return err
+	}
+	if statusCode == http.StatusPreconditionFailed {
+		return store.ErrorResourceConflict(res.GetType(), opts.Namespace, opts.Name, opts.Mesh)
+	}
+	if statusCode != http.StatusOK {
+		return errors.Errorf("(%d): %s", statusCode, string(b))
+	}
+	return nil
 }
 func (s *remoteStore) Get(ctx context.Context, res model.Resource, fs ...store.GetOptionsFunc) error {
 	resourceApi, err := s.api.GetResourceApi(res.GetType())
@@ -122,6 +156,23 @@ func (s *remoteStore) List(ctx context.Context, rs model.ResourceList, fs ...sto
 	if err != nil {
 		return err
 	}
+	query := url.Values{}
+	if len(opts.Labels) > 0 {
+		query.Set(rest.LabelsQueryParam, rest.FormatLabels(opts.Labels))
+	}
+	for tag, value := range opts.Tags {
+		query.Add("tag", tag+":"+value)
+	}
+	if opts.NamePrefix != "" {
+		query.Set(rest.NamePrefixQueryParam, opts.NamePrefix)
+	}
+	if opts.PageSize > 0 {
+		query.Set(rest.SizeQueryParam, strconv.Itoa(opts.PageSize))
+	}
+	if opts.PageOffset != "" {
+		query.Set(rest.OffsetQueryParam, opts.PageOffset)
+	}
+	req.URL.RawQuery = query.Encode()
 	statusCode, b, err := s.doRequest(ctx, req)
 	if err != nil {
 		return err
@@ -237,6 +237,49 @@ var _ = Describe("RemoteStore", func() {
 		})
 	})
 
+	Describe("Delete()", func() {
+		It("should delete resource", func() {
+			// given
+			store := setupStore("create_update.json", func(req *http.Request) {
+				Expect(req.Method).To(Equal("DELETE"))
+				Expect(req.URL.Path).To(Equal("/meshes/default/trafficroutes/res-1"))
+				Expect(req.URL.RawQuery).To(BeEmpty())
+			})
+
+			// when
+			err := store.Delete(context.Background(), &sample_core.TrafficRouteResource{}, core_store.DeleteByKey("", "res-1", "default"))
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should delete mesh with dependent resources", func() {
+			// given
+			store := setupStore("create_update.json", func(req *http.Request) {
+				Expect(req.Method).To(Equal("DELETE"))
+				Expect(req.URL.Path).To(Equal("/meshes/demo"))
+				Expect(req.URL.Query().Get("force")).To(Equal("true"))
+			})
+
+			// when
+			err := store.Delete(context.Background(), &mesh.MeshResource{}, core_store.DeleteByKey("", "demo", "demo"), core_store.DeleteForce())
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should return error from the api server", func() {
+			// given
+			store := setupErrorStore("some error from the server")
+
+			// when
+			err := store.Delete(context.Background(), &mesh.MeshResource{}, core_store.DeleteByKey("", "demo", "demo"))
+
+			// then
+			Expect(err).To(MatchError("(400): some error from the server"))
+		})
+	})
+
 	Describe("List()", func() {
 		It("should successfully list known resources", func() {
 			// given
@@ -265,6 +308,29 @@ var _ = Describe("RemoteStore", func() {
 			Expect(rs.Items[1].Spec.Path).To(Equal("/another"))
 		})
 
+		It("should list a page of resources", func() {
+			// given
+			store := setupStore("list-page.json", func(req *http.Request) {
+				Expect(req.URL.Path).To(Equal(fmt.Sprintf("/meshes/pilot/trafficroutes")))
+				Expect(req.URL.Query().Get("namePrefix")).To(Equal("web-"))
+				Expect(req.URL.Query().Get("size")).To(Equal("2"))
+				Expect(req.URL.Query().Get("offset")).To(Equal(""))
+			})
+
+			// when
+			rs := sample_core.TrafficRouteResourceList{}
+			err := store.List(context.Background(), &rs, core_store.ListByMesh("pilot"), core_store.ListByNamePrefix("web-"), core_store.ListByPage(2, ""))
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rs.Items).To(HaveLen(2))
+			Expect(rs.Items[0].Meta.GetName()).To(Equal("web-1"))
+			Expect(rs.Items[1].Meta.GetName()).To(Equal("web-2"))
+			// and
+			Expect(rs.GetPagination().Total).To(Equal(uint32(3)))
+			Expect(rs.GetPagination().NextOffset).To(Equal("2"))
+		})
+
 		It("should list meshes", func() {
 			// given
 			store := setupStore("list-meshes.json", func(req *http.Request) {
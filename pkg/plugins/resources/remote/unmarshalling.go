@@ -11,6 +11,7 @@ type remoteMeta struct {
 	Name      string
 	Mesh      string
 	Version   string
+	Labels    map[string]string
 }
 
 func (m remoteMeta) GetName() string {
@@ -25,6 +26,9 @@ func (m remoteMeta) GetMesh() string {
 func (m remoteMeta) GetVersion() string {
 	return m.Version
 }
+func (m remoteMeta) GetLabels() map[string]string {
+	return m.Labels
+}
 
 func Unmarshal(b []byte, res model.Resource) error {
 	restResource := rest.Resource{
@@ -38,6 +42,7 @@ func Unmarshal(b []byte, res model.Resource) error {
 		Name:      restResource.Meta.Name,
 		Mesh:      restResource.Meta.Mesh,
 		Version:   "",
+		Labels:    restResource.Meta.Labels,
 	})
 	return nil
 }
@@ -59,8 +64,20 @@ func UnmarshalList(b []byte, rs model.ResourceList) error {
 			Name:      ri.Meta.Name,
 			Mesh:      ri.Meta.Mesh,
 			Version:   "",
+			Labels:    ri.Meta.Labels,
 		})
 		_ = rs.AddItem(r)
 	}
+	pagination := model.Pagination{
+		Total: rsr.ResourceList.Total,
+	}
+	if rsr.ResourceList.Next != nil {
+		offset, err := rest.OffsetOf(*rsr.ResourceList.Next)
+		if err != nil {
+			return err
+		}
+		pagination.NextOffset = offset
+	}
+	*rs.GetPagination() = pagination
 	return nil
 }
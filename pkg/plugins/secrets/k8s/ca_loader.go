@@ -0,0 +1,43 @@
+package k8s
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	provided_ca "github.com/Kong/kuma/pkg/core/ca/provided"
+	util_tls "github.com/Kong/kuma/pkg/tls"
+
+	kube_core "k8s.io/api/core/v1"
+	kube_client "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ provided_ca.SecretLoader = &TLSSecretLoader{}
+
+// TLSSecretLoader loads a CA provided by the user from a Secret of type kubernetes.io/tls.
+type TLSSecretLoader struct {
+	reader kube_client.Reader
+	// Namespace to look up Secrets in, e.g. namespace where Control Plane is installed to
+	namespace string
+}
+
+func NewTLSSecretLoader(reader kube_client.Reader, namespace string) *TLSSecretLoader {
+	return &TLSSecretLoader{
+		reader:    reader,
+		namespace: namespace,
+	}
+}
+
+func (l *TLSSecretLoader) LoadKeyPair(ctx context.Context, name string) (*util_tls.KeyPair, error) {
+	secret := &kube_core.Secret{}
+	if err := l.reader.Get(ctx, kube_client.ObjectKey{Namespace: l.namespace, Name: name}, secret); err != nil {
+		return nil, errors.Wrap(err, "failed to get k8s Secret")
+	}
+	if secret.Type != kube_core.SecretTypeTLS {
+		return nil, errors.Errorf("k8s Secret must be of type %q, got %q", kube_core.SecretTypeTLS, secret.Type)
+	}
+	return &util_tls.KeyPair{
+		CertPEM: secret.Data[kube_core.TLSCertKey],
+		KeyPEM:  secret.Data[kube_core.TLSPrivateKeyKey],
+	}, nil
+}
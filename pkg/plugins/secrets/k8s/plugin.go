@@ -8,6 +8,7 @@ import (
 	k8s_runtime "github.com/Kong/kuma/pkg/runtime/k8s"
 
 	kube_core "k8s.io/api/core/v1"
+	kube_cache "sigs.k8s.io/controller-runtime/pkg/cache"
 )
 
 var _ core_plugins.SecretStorePlugin = &plugin{}
@@ -26,5 +27,18 @@ func (p *plugin) NewSecretStore(pc core_plugins.PluginContext, _ core_plugins.Pl
 	if err := kube_core.AddToScheme(mgr.GetScheme()); err != nil {
 		return nil, errors.Wrapf(err, "could not add %q to scheme", kube_core.SchemeGroupVersion)
 	}
-	return NewStore(mgr.GetAPIReader(), mgr.GetClient(), pc.Config().Store.Kubernetes.SystemNamespace)
+	namespace := pc.Config().Store.Kubernetes.SystemNamespace
+	// Control Plane is only allowed to access Secrets in its own namespace, that is why the shared cache of the Manager cannot be used
+	secrets, err := kube_cache.New(mgr.GetConfig(), kube_cache.Options{
+		Scheme:    mgr.GetScheme(),
+		Mapper:    mgr.GetRESTMapper(),
+		Namespace: namespace,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create a cache of k8s Secrets")
+	}
+	if err := mgr.Add(secrets); err != nil {
+		return nil, errors.Wrap(err, "could not add a cache of k8s Secrets to the Manager")
+	}
+	return NewStore(mgr.GetAPIReader(), mgr.GetClient(), secrets, namespace)
 }
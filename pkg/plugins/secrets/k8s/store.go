@@ -3,6 +3,7 @@ package k8s
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/pkg/errors"
 
@@ -14,11 +15,15 @@ import (
 	kube_core "k8s.io/api/core/v1"
 	kube_apierrs "k8s.io/apimachinery/pkg/api/errors"
 	kube_meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kube_toolscache "k8s.io/client-go/tools/cache"
+	kube_cache "sigs.k8s.io/controller-runtime/pkg/cache"
 	kube_client "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
 	noMesh = ""
+	// meshLabel is a label of a k8s Secret that holds a name of the Mesh the Secret belongs to
+	meshLabel = "kuma.io/mesh"
 )
 
 var _ secret_store.SecretStore = &KubernetesStore{}
@@ -27,15 +32,21 @@ type KubernetesStore struct {
 	reader    kube_client.Reader
 	writer    kube_client.Writer
 	converter Converter
+	// informers notify about changes of k8s Secrets, it is optional unless the store is watched
+	informers kube_cache.Informers
 	// Namespace to store Secrets in, e.g. namespace where Control Plane is installed to
 	namespace string
+
+	watchMu sync.Mutex
+	events  *core_store.EventBroadcaster
 }
 
-func NewStore(reader kube_client.Reader, writer kube_client.Writer, namespace string) (secret_store.SecretStore, error) {
+func NewStore(reader kube_client.Reader, writer kube_client.Writer, informers kube_cache.Informers, namespace string) (secret_store.SecretStore, error) {
 	return &KubernetesStore{
 		reader:    reader,
 		writer:    writer,
 		converter: DefaultConverter(),
+		informers: informers,
 		namespace: namespace,
 	}, nil
 }
@@ -48,6 +59,12 @@ func (s *KubernetesStore) Create(ctx context.Context, r *secret_model.SecretReso
 	}
 	secret.Namespace = s.namespace
 	secret.Name = opts.Name
+	if opts.Mesh != noMesh {
+		if secret.Labels == nil {
+			secret.Labels = map[string]string{}
+		}
+		secret.Labels[meshLabel] = opts.Mesh
+	}
 
 	if err := s.writer.Create(ctx, secret); err != nil {
 		if kube_apierrs.IsAlreadyExists(err) {
@@ -111,8 +128,13 @@ func (s *KubernetesStore) Get(ctx context.Context, r *secret_model.SecretResourc
 	return nil
 }
 func (s *KubernetesStore) List(ctx context.Context, rs *secret_model.SecretResourceList, fs ...core_store.ListOptionsFunc) error {
+	opts := core_store.NewListOptions(fs...)
 	secrets := &kube_core.SecretList{}
-	if err := s.reader.List(ctx, secrets, kube_client.InNamespace(s.namespace)); err != nil {
+	listOpts := []kube_client.ListOption{kube_client.InNamespace(s.namespace)}
+	if opts.Mesh != noMesh {
+		listOpts = append(listOpts, kube_client.MatchingLabels{meshLabel: opts.Mesh})
+	}
+	if err := s.reader.List(ctx, secrets, listOpts...); err != nil {
 		return errors.Wrap(err, "failed to list k8s Secrets")
 	}
 	if err := s.converter.ToCoreList(secrets, rs); err != nil {
@@ -121,6 +143,70 @@ func (s *KubernetesStore) List(ctx context.Context, rs *secret_model.SecretResou
 	return nil
 }
 
+var _ core_store.ResourceWatcher = &KubernetesStore{}
+
+// Watch notifies about changes of k8s Secrets in the namespace of the store, which is the only one Secrets are kept in.
+func (s *KubernetesStore) Watch(ctx context.Context, resourceType core_model.ResourceType, namespace string) (<-chan core_store.Event, error) {
+	if resourceType != secret_model.SecretType {
+		return nil, errors.Errorf("only resources of type %q are kept in k8s Secrets, got %q", secret_model.SecretType, resourceType)
+	}
+	if s.informers == nil {
+		return nil, errors.New("watching k8s Secrets requires Informers to be configured")
+	}
+	s.watchMu.Lock()
+	defer s.watchMu.Unlock()
+	// informers are shared and handlers cannot be removed from them, that is why there is a single handler
+	if s.events == nil {
+		events := core_store.NewEventBroadcaster()
+		if err := s.addEventHandler(events); err != nil {
+			return nil, err
+		}
+		s.events = events
+	}
+	return s.events.Subscribe(ctx, resourceType, namespace), nil
+}
+
+func (s *KubernetesStore) addEventHandler(events *core_store.EventBroadcaster) error {
+	informer, err := s.informers.GetInformer(&kube_core.Secret{})
+	if err != nil {
+		return errors.Wrap(err, "failed to get an informer of k8s Secrets")
+	}
+	send := func(eventType core_store.EventType, obj interface{}) {
+		if tombstone, ok := obj.(kube_toolscache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		secret, ok := obj.(*kube_core.Secret)
+		if !ok || secret.Namespace != s.namespace {
+			return
+		}
+		events.Send(core_store.Event{
+			Type:         eventType,
+			ResourceType: secret_model.SecretType,
+			Namespace:    secret.Namespace,
+			Name:         secret.Name,
+			Mesh:         secret.Labels[meshLabel],
+		})
+	}
+	informer.AddEventHandler(kube_toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			send(core_store.CreatedEvent, obj)
+		},
+		UpdateFunc: func(oldObj, obj interface{}) {
+			// informers also call it on periodic resyncs, when nothing has changed
+			if oldSecret, ok := oldObj.(*kube_core.Secret); ok {
+				if secret, ok := obj.(*kube_core.Secret); ok && oldSecret.ResourceVersion == secret.ResourceVersion {
+					return
+				}
+			}
+			send(core_store.UpdatedEvent, obj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			send(core_store.DeletedEvent, obj)
+		},
+	})
+	return nil
+}
+
 var _ core_model.ResourceMeta = &KubernetesMetaAdapter{}
 
 type KubernetesMetaAdapter struct {
@@ -132,7 +218,7 @@ func (m *KubernetesMetaAdapter) GetVersion() string {
 }
 
 func (m *KubernetesMetaAdapter) GetMesh() string {
-	return noMesh
+	return m.ObjectMeta.GetLabels()[meshLabel]
 }
 
 type Converter interface {
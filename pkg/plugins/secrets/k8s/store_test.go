@@ -76,7 +76,7 @@ var _ = Describe("KubernetesStore", func() {
 		ns = string(uuid.NewUUID())
 
 		var err error
-		s, err = k8s.NewStore(k8sClient, k8sClient, ns)
+		s, err = k8s.NewStore(k8sClient, k8sClient, nil, ns)
 		Expect(err).ToNot(HaveOccurred())
 	})
 
@@ -399,5 +399,25 @@ var _ = Describe("KubernetesStore", func() {
 			Expect(items["two"].Meta.GetNamespace()).To(Equal(ns))
 			Expect(items["two"].Spec.Value).To(Equal([]byte("another")))
 		})
+
+		It("should return only Secrets of a given Mesh", func() {
+			// setup
+			Expect(s.Create(context.Background(), &secret_model.SecretResource{}, store.CreateByKey("ignored", "one", "demo"))).To(Succeed())
+			Expect(s.Create(context.Background(), &secret_model.SecretResource{}, store.CreateByKey("ignored", "two", "other"))).To(Succeed())
+			Expect(s.Create(context.Background(), &secret_model.SecretResource{}, store.CreateByKey("ignored", "three", noMesh))).To(Succeed())
+
+			// given
+			secrets := &secret_model.SecretResourceList{}
+
+			// when
+			err := s.List(context.Background(), secrets, store.ListByNamespace("ignored"), store.ListByMesh("demo"))
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			// and
+			Expect(secrets.Items).To(HaveLen(1))
+			Expect(secrets.Items[0].Meta.GetName()).To(Equal("one"))
+			Expect(secrets.Items[0].Meta.GetMesh()).To(Equal("demo"))
+		})
 	})
 })
@@ -0,0 +1,28 @@
+package webhooks
+
+import (
+	"github.com/pkg/errors"
+
+	freeze_managers "github.com/Kong/kuma/pkg/core/managers/freeze"
+	core_runtime "github.com/Kong/kuma/pkg/core/runtime"
+	k8s_runtime "github.com/Kong/kuma/pkg/runtime/k8s"
+
+	kube_webhook "sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// SetupServer adds Admission WebHook Server that validates changes of Kuma resources made through Kubernetes API Server.
+func SetupServer(rt core_runtime.Runtime) error {
+	mgr, ok := k8s_runtime.FromManagerContext(rt.Extensions())
+	if !ok {
+		return errors.Errorf("k8s controller runtime Manager hasn't been configured")
+	}
+	cfg := rt.Config().Runtime.Kubernetes.AdmissionServer
+	webhookServer := &kube_webhook.Server{
+		Host:    cfg.Address,
+		Port:    int(cfg.Port),
+		CertDir: cfg.CertDir,
+	}
+	configFreeze := freeze_managers.NewConfigFreeze(rt.SecretManager())
+	webhookServer.Register("/validate-kuma-io-v1alpha1", ValidatingWebhook(rt.ResourceStore(), configFreeze, *rt.Config().Quota, rt.Config().Store.Kubernetes.SystemNamespace))
+	return mgr.Add(webhookServer)
+}
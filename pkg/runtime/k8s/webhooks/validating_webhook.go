@@ -0,0 +1,99 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	quota_config "github.com/Kong/kuma/pkg/config/core/resources/quota"
+	"github.com/Kong/kuma/pkg/core"
+	constraint_managers "github.com/Kong/kuma/pkg/core/managers/constraint"
+	freeze_managers "github.com/Kong/kuma/pkg/core/managers/freeze"
+	quota_managers "github.com/Kong/kuma/pkg/core/managers/quota"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+
+	kube_admission_v1beta1 "k8s.io/api/admission/v1beta1"
+	kube_meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kube_webhook "sigs.k8s.io/controller-runtime/pkg/webhook"
+	kube_admission "sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var (
+	webhookLog = core.Log.WithName("admission-server").WithName("webhook")
+)
+
+// ValidatingWebhook returns a webhook that holds changes of Kuma resources made through Kubernetes API Server,
+// e.g. by kubectl, to the same rules as API Server of Kuma:
+//   - Meshes and policies cannot be changed while configuration is frozen
+//   - policies of types not allowed by the constraints of their Mesh cannot be created or updated
+//   - Dataplanes and policies cannot be created once their Mesh has reached its quota
+//
+// Changes made by ServiceAccounts of the system namespace, i.e. by Kuma itself, are exempt from the freeze,
+// but not from quotas, since Dataplanes of Pods are created by Kuma.
+//
+// Unlike the manager of API Server, the webhook cannot serialize creation of resources in a Mesh,
+// because resources are stored by Kubernetes after they have been admitted. Quotas might be exceeded
+// by a small margin when many resources are created at once.
+func ValidatingWebhook(store core_store.ResourceStore, configFreeze freeze_managers.ConfigFreeze, quota quota_config.QuotaConfig, systemNamespace string) *kube_admission.Webhook {
+	return &kube_admission.Webhook{
+		Handler: &validatingHandler{
+			store:           store,
+			configFreeze:    configFreeze,
+			quota:           quota,
+			systemNamespace: systemNamespace,
+		},
+	}
+}
+
+type validatingHandler struct {
+	store        core_store.ResourceStore
+	configFreeze freeze_managers.ConfigFreeze
+	quota        quota_config.QuotaConfig
+	// namespace Kuma is installed to, Meshes live there
+	systemNamespace string
+}
+
+// kumaObject is a subset of fields shared by all Kuma resources on Kubernetes.
+type kumaObject struct {
+	Metadata kube_meta.ObjectMeta `json:"metadata,omitempty"`
+	Mesh     string               `json:"mesh,omitempty"`
+}
+
+func (h *validatingHandler) Handle(ctx context.Context, req kube_webhook.AdmissionRequest) kube_webhook.AdmissionResponse {
+	webhookLog.V(1).Info("received request", "request", req)
+	resourceType := core_model.ResourceType(req.Kind.Kind)
+	if !h.isSystemUser(req.UserInfo.Username) {
+		if err := freeze_managers.CheckFreeze(ctx, h.configFreeze, resourceType); err != nil {
+			return deniedOrErrored(err, freeze_managers.IsConfigFrozen(err))
+		}
+	}
+	if req.Operation == kube_admission_v1beta1.Delete {
+		return kube_admission.Allowed("")
+	}
+	var obj kumaObject
+	if err := json.Unmarshal(req.Object.Raw, &obj); err != nil {
+		return kube_admission.Errored(http.StatusBadRequest, err)
+	}
+	if err := constraint_managers.CheckAllowed(ctx, h.store, resourceType, h.systemNamespace, obj.Mesh); err != nil {
+		return deniedOrErrored(err, constraint_managers.IsPolicyTypeNotAllowed(err))
+	}
+	if req.Operation == kube_admission_v1beta1.Create {
+		if err := quota_managers.CheckQuota(ctx, h.store, h.quota, resourceType, obj.Mesh, obj.Metadata.Labels); err != nil {
+			return deniedOrErrored(err, quota_managers.IsQuotaExceeded(err))
+		}
+	}
+	return kube_admission.Allowed("")
+}
+
+func (h *validatingHandler) isSystemUser(username string) bool {
+	return strings.HasPrefix(username, "system:serviceaccount:"+h.systemNamespace+":")
+}
+
+func deniedOrErrored(err error, denied bool) kube_webhook.AdmissionResponse {
+	if denied {
+		return kube_admission.Denied(err.Error())
+	}
+	return kube_admission.Errored(http.StatusInternalServerError, err)
+}
@@ -0,0 +1,185 @@
+package webhooks_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	quota_config "github.com/Kong/kuma/pkg/config/core/resources/quota"
+	"github.com/Kong/kuma/pkg/core/managers/freeze"
+	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	secret_cipher "github.com/Kong/kuma/pkg/core/secrets/cipher"
+	secret_manager "github.com/Kong/kuma/pkg/core/secrets/manager"
+	secret_store "github.com/Kong/kuma/pkg/core/secrets/store"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+	"github.com/Kong/kuma/pkg/runtime/k8s/webhooks"
+
+	kube_admission_v1beta1 "k8s.io/api/admission/v1beta1"
+	kube_authn "k8s.io/api/authentication/v1"
+	kube_meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kube_runtime "k8s.io/apimachinery/pkg/runtime"
+	kube_webhook "sigs.k8s.io/controller-runtime/pkg/webhook"
+	kube_admission "sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var _ = Describe("ValidatingWebhook", func() {
+
+	var store core_store.ResourceStore
+	var configFreeze freeze.ConfigFreeze
+	var webhook *kube_admission.Webhook
+
+	BeforeEach(func() {
+		store = memory.NewStore()
+		restricted := &core_mesh.MeshResource{
+			Spec: mesh_proto.Mesh{
+				Constraints: &mesh_proto.Constraints{
+					AllowedPolicyTypes: []string{"TrafficPermission"},
+				},
+			},
+		}
+		err := store.Create(context.Background(), restricted, core_store.CreateByKey("kuma-system", "demo", "demo"))
+		Expect(err).ToNot(HaveOccurred())
+		err = store.Create(context.Background(), &core_mesh.MeshResource{}, core_store.CreateByKey("kuma-system", "other", "other"))
+		Expect(err).ToNot(HaveOccurred())
+
+		configFreeze = freeze.NewConfigFreeze(secret_manager.NewSecretManager(secret_store.NewSecretStore(store), secret_cipher.None()))
+		quota := quota_config.QuotaConfig{
+			MaxDataplanesPerMesh: 1,
+			MaxPoliciesPerMesh:   1,
+		}
+		webhook = webhooks.ValidatingWebhook(store, configFreeze, quota, "kuma-system")
+	})
+
+	request := func(operation kube_admission_v1beta1.Operation, kind string, object string) kube_webhook.AdmissionRequest {
+		return kube_webhook.AdmissionRequest{
+			AdmissionRequest: kube_admission_v1beta1.AdmissionRequest{
+				Kind:      kube_meta.GroupVersionKind{Group: "kuma.io", Version: "v1alpha1", Kind: kind},
+				Namespace: "example",
+				Operation: operation,
+				Object:    kube_runtime.RawExtension{Raw: []byte(object)},
+				UserInfo:  kube_authn.UserInfo{Username: "kubernetes-admin"},
+			},
+		}
+	}
+
+	It("should deny a policy of a type that is not allowed by its Mesh", func() {
+		// when
+		resp := webhook.Handle(context.Background(), request(kube_admission_v1beta1.Create, "ProxyTemplate", `{"apiVersion":"kuma.io/v1alpha1","kind":"ProxyTemplate","mesh":"demo","metadata":{"name":"pt-1"}}`))
+
+		// then
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Result.Reason).To(BeEquivalentTo("policy type not allowed: mesh of name demo does not allow policies of type ProxyTemplate"))
+	})
+
+	DescribeTable("should allow",
+		func(kind string, object string) {
+			// when
+			resp := webhook.Handle(context.Background(), request(kube_admission_v1beta1.Create, kind, object))
+
+			// then
+			Expect(resp.Allowed).To(BeTrue())
+		},
+		Entry("a policy of an allowed type", "TrafficPermission",
+			`{"apiVersion":"kuma.io/v1alpha1","kind":"TrafficPermission","mesh":"demo","metadata":{"name":"tp-1"}}`),
+		Entry("a policy of a Mesh without constraints", "ProxyTemplate",
+			`{"apiVersion":"kuma.io/v1alpha1","kind":"ProxyTemplate","mesh":"other","metadata":{"name":"pt-1"}}`),
+		Entry("a policy of a Mesh that doesn't exist", "ProxyTemplate",
+			`{"apiVersion":"kuma.io/v1alpha1","kind":"ProxyTemplate","mesh":"unknown","metadata":{"name":"pt-1"}}`),
+		Entry("a resource that is not a policy", "Dataplane",
+			`{"apiVersion":"kuma.io/v1alpha1","kind":"Dataplane","mesh":"demo","metadata":{"name":"dp-1"}}`),
+	)
+
+	Context("while configuration is frozen", func() {
+
+		BeforeEach(func() {
+			_, err := configFreeze.Set(context.Background(), true, "admin", "release")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		DescribeTable("should deny changes of Meshes and policies",
+			func(operation kube_admission_v1beta1.Operation, kind string, object string) {
+				// when
+				resp := webhook.Handle(context.Background(), request(operation, kind, object))
+
+				// then
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(string(resp.Result.Reason)).To(HavePrefix("configuration is frozen:"))
+			},
+			Entry("create of a policy", kube_admission_v1beta1.Create, "TrafficPermission",
+				`{"apiVersion":"kuma.io/v1alpha1","kind":"TrafficPermission","mesh":"demo","metadata":{"name":"tp-1"}}`),
+			Entry("update of a Mesh", kube_admission_v1beta1.Update, "Mesh",
+				`{"apiVersion":"kuma.io/v1alpha1","kind":"Mesh","metadata":{"name":"demo"}}`),
+			Entry("delete of a policy", kube_admission_v1beta1.Delete, "TrafficPermission", ``),
+		)
+
+		It("should allow changes of Dataplanes", func() {
+			// when
+			resp := webhook.Handle(context.Background(), request(kube_admission_v1beta1.Create, "Dataplane", `{"apiVersion":"kuma.io/v1alpha1","kind":"Dataplane","mesh":"demo","metadata":{"name":"dp-1"}}`))
+
+			// then
+			Expect(resp.Allowed).To(BeTrue())
+		})
+
+		It("should allow changes made by Kuma itself", func() {
+			// given
+			req := request(kube_admission_v1beta1.Create, "TrafficPermission", `{"apiVersion":"kuma.io/v1alpha1","kind":"TrafficPermission","mesh":"demo","metadata":{"name":"tp-1"}}`)
+			req.UserInfo.Username = "system:serviceaccount:kuma-system:kuma-control-plane"
+
+			// when
+			resp := webhook.Handle(context.Background(), req)
+
+			// then
+			Expect(resp.Allowed).To(BeTrue())
+		})
+	})
+
+	Context("when a Mesh has reached its quota", func() {
+
+		BeforeEach(func() {
+			err := store.Create(context.Background(), &core_mesh.TrafficPermissionResource{}, core_store.CreateByKey("example", "tp-0", "demo"))
+			Expect(err).ToNot(HaveOccurred())
+			err = store.Create(context.Background(), &core_mesh.DataplaneResource{}, core_store.CreateByKey("example", "dp-0", "demo"))
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		DescribeTable("should deny creation",
+			func(kind string, object string, reason string) {
+				// given
+				req := request(kube_admission_v1beta1.Create, kind, object)
+				// quotas apply to Kuma itself, since it creates Dataplanes of Pods
+				req.UserInfo.Username = "system:serviceaccount:kuma-system:kuma-control-plane"
+
+				// when
+				resp := webhook.Handle(context.Background(), req)
+
+				// then
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Reason).To(BeEquivalentTo(reason))
+			},
+			Entry("of a policy", "TrafficPermission",
+				`{"apiVersion":"kuma.io/v1alpha1","kind":"TrafficPermission","mesh":"demo","metadata":{"name":"tp-1"}}`,
+				"quota exceeded: mesh of name demo cannot have more than 1 policies"),
+			Entry("of a Dataplane", "Dataplane",
+				`{"apiVersion":"kuma.io/v1alpha1","kind":"Dataplane","mesh":"demo","metadata":{"name":"dp-1"}}`,
+				"quota exceeded: mesh of name demo cannot have more than 1 dataplanes"),
+		)
+
+		DescribeTable("should allow",
+			func(operation kube_admission_v1beta1.Operation, kind string, object string) {
+				// when
+				resp := webhook.Handle(context.Background(), request(operation, kind, object))
+
+				// then
+				Expect(resp.Allowed).To(BeTrue())
+			},
+			Entry("update of a policy", kube_admission_v1beta1.Update, "TrafficPermission",
+				`{"apiVersion":"kuma.io/v1alpha1","kind":"TrafficPermission","mesh":"demo","metadata":{"name":"tp-0"}}`),
+			Entry("creation of a policy in another Mesh", kube_admission_v1beta1.Create, "TrafficPermission",
+				`{"apiVersion":"kuma.io/v1alpha1","kind":"TrafficPermission","mesh":"other","metadata":{"name":"tp-1"}}`),
+		)
+	})
+})
@@ -0,0 +1,68 @@
+package clientcert
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
+	sds_auth "github.com/Kong/kuma/pkg/sds/auth"
+	common_auth "github.com/Kong/kuma/pkg/sds/auth/common"
+)
+
+// New returns an authenticator that verifies a client certificate
+// presented by a dataplane on a TLS connection to the xDS server.
+//
+// A certificate must have a SPIFFE ID of a form spiffe://<mesh>/<service>
+// that matches one of the services of a Dataplane.
+func New(dataplaneResolver common_auth.DataplaneResolver) sds_auth.Authenticator {
+	return &clientCertAuthenticator{
+		dataplaneResolver: dataplaneResolver,
+	}
+}
+
+type clientCertAuthenticator struct {
+	dataplaneResolver common_auth.DataplaneResolver
+}
+
+func (c *clientCertAuthenticator) Authenticate(ctx context.Context, proxyId core_xds.ProxyId, _ sds_auth.Credential) (sds_auth.Identity, error) {
+	cert, err := peerCertificate(ctx)
+	if err != nil {
+		return sds_auth.Identity{}, err
+	}
+	dataplane, err := c.dataplaneResolver(ctx, proxyId)
+	if err != nil {
+		return sds_auth.Identity{}, errors.Wrapf(err, "unable to find Dataplane for proxy %q", proxyId)
+	}
+	mesh := dataplane.Meta.GetMesh()
+	for _, service := range dataplane.Spec.Tags().Values(mesh_proto.ServiceTag) {
+		expected := fmt.Sprintf("spiffe://%s/%s", mesh, service)
+		for _, uri := range cert.URIs {
+			if uri.String() == expected {
+				return sds_auth.Identity{Mesh: mesh, Service: service}, nil
+			}
+		}
+	}
+	return sds_auth.Identity{}, errors.Errorf("authentication failed: client certificate doesn't have SPIFFE ID of any service of Dataplane in mesh %q", mesh)
+}
+
+func peerCertificate(ctx context.Context) (*x509.Certificate, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, errors.New("authentication failed: connection has no peer information")
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return nil, errors.New("authentication failed: connection is not secured by TLS")
+	}
+	if len(tlsInfo.State.PeerCertificates) == 0 {
+		return nil, errors.New("authentication failed: dataplane has not presented a client certificate")
+	}
+	return tlsInfo.State.PeerCertificates[0], nil
+}
@@ -0,0 +1,40 @@
+package hybrid
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
+	sds_auth "github.com/Kong/kuma/pkg/sds/auth"
+	common_auth "github.com/Kong/kuma/pkg/sds/auth/common"
+)
+
+// New returns an authenticator for a Mesh that spans both Kubernetes and universal environments.
+//
+// Dataplanes of Kubernetes Pods are authenticated by kubeAuthenticator,
+// all other Dataplanes are authenticated by universalAuthenticator.
+func New(kubeAuthenticator, universalAuthenticator sds_auth.Authenticator, dataplaneResolver common_auth.DataplaneResolver) sds_auth.Authenticator {
+	return &hybridAuthenticator{
+		kubeAuthenticator:      kubeAuthenticator,
+		universalAuthenticator: universalAuthenticator,
+		dataplaneResolver:      dataplaneResolver,
+	}
+}
+
+type hybridAuthenticator struct {
+	kubeAuthenticator      sds_auth.Authenticator
+	universalAuthenticator sds_auth.Authenticator
+	dataplaneResolver      common_auth.DataplaneResolver
+}
+
+func (h *hybridAuthenticator) Authenticate(ctx context.Context, proxyId core_xds.ProxyId, credential sds_auth.Credential) (sds_auth.Identity, error) {
+	dataplane, err := h.dataplaneResolver(ctx, proxyId)
+	if err != nil {
+		return sds_auth.Identity{}, errors.Wrapf(err, "unable to find Dataplane for proxy %q", proxyId)
+	}
+	if dataplane.IsKubernetesPod() {
+		return h.kubeAuthenticator.Authenticate(ctx, proxyId, credential)
+	}
+	return h.universalAuthenticator.Authenticate(ctx, proxyId, credential)
+}
@@ -0,0 +1,91 @@
+package hybrid_test
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
+	sds_auth "github.com/Kong/kuma/pkg/sds/auth"
+	hybrid_sds_auth "github.com/Kong/kuma/pkg/sds/auth/hybrid"
+	test_model "github.com/Kong/kuma/pkg/test/resources/model"
+)
+
+type testAuthenticator struct {
+	calls    int
+	expected sds_auth.Credential
+}
+
+func (t *testAuthenticator) Authenticate(ctx context.Context, proxyId core_xds.ProxyId, credential sds_auth.Credential) (sds_auth.Identity, error) {
+	t.calls++
+	if credential != t.expected {
+		return sds_auth.Identity{}, errors.New("invalid credential")
+	}
+	return sds_auth.Identity{Mesh: proxyId.Mesh}, nil
+}
+
+var _ = Describe("Hybrid", func() {
+
+	var kubeAuthenticator *testAuthenticator
+	var universalAuthenticator *testAuthenticator
+	var authenticator sds_auth.Authenticator
+
+	dataplanes := map[string]*core_mesh.DataplaneResource{
+		"web-01": {
+			Meta: &test_model.ResourceMeta{
+				Mesh:   "default",
+				Name:   "web-01",
+				Labels: map[string]string{core_mesh.KubernetesPodLabel: "true"},
+			},
+		},
+		"vm-01": {
+			Meta: &test_model.ResourceMeta{
+				Mesh: "default",
+				Name: "vm-01",
+			},
+		},
+	}
+
+	BeforeEach(func() {
+		kubeAuthenticator = &testAuthenticator{expected: "service-account-token"}
+		universalAuthenticator = &testAuthenticator{expected: "dataplane-token"}
+		authenticator = hybrid_sds_auth.New(kubeAuthenticator, universalAuthenticator, func(_ context.Context, proxyId core_xds.ProxyId) (*core_mesh.DataplaneResource, error) {
+			return dataplanes[proxyId.Name], nil
+		})
+	})
+
+	It("should authenticate Dataplanes of Kubernetes Pods with kube authenticator", func() {
+		// when
+		_, err := authenticator.Authenticate(context.Background(), core_xds.ProxyId{Mesh: "default", Name: "web-01"}, "dataplane-token")
+
+		// then
+		Expect(err).To(MatchError("invalid credential"))
+		// and
+		Expect(kubeAuthenticator.calls).To(Equal(1))
+		Expect(universalAuthenticator.calls).To(Equal(0))
+	})
+
+	It("should authenticate other Dataplanes with universal authenticator", func() {
+		// given
+		proxyId := core_xds.ProxyId{Mesh: "default", Name: "vm-01"}
+
+		// when
+		_, err := authenticator.Authenticate(context.Background(), proxyId, "")
+
+		// then
+		Expect(err).To(MatchError("invalid credential"))
+
+		// when
+		_, err = authenticator.Authenticate(context.Background(), proxyId, "dataplane-token")
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		// and
+		Expect(kubeAuthenticator.calls).To(Equal(0))
+		Expect(universalAuthenticator.calls).To(Equal(2))
+	})
+})
@@ -0,0 +1,13 @@
+package hybrid_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestHybridAuthenticator(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Hybrid Dataplane Authenticator Suite")
+}
@@ -6,14 +6,31 @@ import (
 
 	"github.com/pkg/errors"
 
+	core_errors "github.com/Kong/kuma/pkg/core/errors"
+	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
 	core_xds "github.com/Kong/kuma/pkg/core/xds"
 	sds_auth "github.com/Kong/kuma/pkg/sds/auth"
 	common_auth "github.com/Kong/kuma/pkg/sds/auth/common"
 
 	kube_auth "k8s.io/api/authentication/v1"
+	kube_core "k8s.io/api/core/v1"
+	kube_types "k8s.io/apimachinery/pkg/types"
 	kube_client "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const (
+	// Extra info that Kubernetes includes into TokenReview response for bound ServiceAccount tokens
+	podNameExtraKey = "authentication.kubernetes.io/pod-name"
+	podUIDExtraKey  = "authentication.kubernetes.io/pod-uid"
+
+	defaultServiceAccountName = "default"
+)
+
+// New returns an authenticator that verifies Kubernetes ServiceAccount tokens
+// presented by dataplanes using the TokenReview API.
+//
+// Once a token is verified, identity of a Pod behind a Dataplane is cross-checked
+// against a ServiceAccount the token belongs to.
 func New(client kube_client.Client, dataplaneResolver common_auth.DataplaneResolver) sds_auth.Authenticator {
 	return &kubeAuthenticator{
 		client:            client,
@@ -26,45 +43,91 @@ type kubeAuthenticator struct {
 	dataplaneResolver common_auth.DataplaneResolver
 }
 
+// serviceAccountIdentity is an identity of a ServiceAccount a token belongs to.
+type serviceAccountIdentity struct {
+	Namespace string
+	Name      string
+	// PodName and PodUID are only available in case of bound ServiceAccount tokens
+	PodName string
+	PodUID  string
+}
+
 func (k *kubeAuthenticator) Authenticate(ctx context.Context, proxyId core_xds.ProxyId, credential sds_auth.Credential) (sds_auth.Identity, error) {
-	if err := k.reviewToken(ctx, proxyId, credential); err != nil {
+	identity, err := k.reviewToken(ctx, string(credential))
+	if err != nil {
 		return sds_auth.Identity{}, err
 	}
-	// at this point we know that proxyId belongs to the same namespace as token.
-	// since legacy k8s tokens are not bound to a specific Pod,
-	// we have to rely on information included into proxyId.
 	dataplane, err := k.dataplaneResolver(ctx, proxyId)
 	if err != nil {
 		return sds_auth.Identity{}, errors.Wrapf(err, "unable to find Dataplane for proxy %q", proxyId)
 	}
+	if identity.Namespace != dataplane.Meta.GetNamespace() {
+		return sds_auth.Identity{}, errors.Errorf("authentication failed: token belongs to a namespace (%q) different from Dataplane (%q)", identity.Namespace, dataplane.Meta.GetNamespace())
+	}
+	if err := k.verifyPod(ctx, dataplane, identity); err != nil {
+		return sds_auth.Identity{}, err
+	}
 	return common_auth.GetDataplaneIdentity(dataplane)
 }
 
-func (k *kubeAuthenticator) reviewToken(ctx context.Context, proxyId core_xds.ProxyId, credential sds_auth.Credential) error {
-	if credential == "" {
-		return errors.New("authentication failed: k8s token is missing")
+// reviewToken verifies a Kubernetes ServiceAccount token using the TokenReview API.
+func (k *kubeAuthenticator) reviewToken(ctx context.Context, token string) (*serviceAccountIdentity, error) {
+	if token == "" {
+		return nil, core_errors.WithCode(errors.New("authentication failed: k8s token is missing"), core_errors.TokenMissing)
 	}
 	tokenReview := &kube_auth.TokenReview{
 		Spec: kube_auth.TokenReviewSpec{
-			Token: string(credential),
+			Token: token,
 		},
 	}
 	if err := k.client.Create(ctx, tokenReview); err != nil {
-		return errors.Wrap(err, "authentication failed: call to TokenReview API failed")
+		return nil, errors.Wrap(err, "authentication failed: call to TokenReview API failed")
 	}
 	if !tokenReview.Status.Authenticated {
-		return errors.Errorf("authentication failed: token doesn't belong to a valid user")
+		return nil, errors.Errorf("authentication failed: token doesn't belong to a valid user")
 	}
 	userInfo := strings.Split(tokenReview.Status.User.Username, ":")
 	if len(userInfo) != 4 {
-		return errors.Errorf("authentication failed: username inside TokenReview response has unexpected format: %q", tokenReview.Status.User.Username)
+		return nil, errors.Errorf("authentication failed: username inside TokenReview response has unexpected format: %q", tokenReview.Status.User.Username)
 	}
 	if !(userInfo[0] == "system" && userInfo[1] == "serviceaccount") {
-		return errors.Errorf("authentication failed: token must belong to a k8s system account, got %q", tokenReview.Status.User.Username)
+		return nil, errors.Errorf("authentication failed: token must belong to a k8s system account, got %q", tokenReview.Status.User.Username)
 	}
-	namespace := userInfo[2]
-	if namespace != proxyId.Namespace {
-		return errors.Errorf("authentication failed: token belongs to a namespace (%q) different from proxyId (%q)", namespace, proxyId.Namespace)
+	return &serviceAccountIdentity{
+		Namespace: userInfo[2],
+		Name:      userInfo[3],
+		PodName:   extraValue(tokenReview.Status.User.Extra, podNameExtraKey),
+		PodUID:    extraValue(tokenReview.Status.User.Extra, podUIDExtraKey),
+	}, nil
+}
+
+// verifyPod makes sure that a Pod behind a Dataplane runs under the ServiceAccount of a token.
+func (k *kubeAuthenticator) verifyPod(ctx context.Context, dataplane *core_mesh.DataplaneResource, identity *serviceAccountIdentity) error {
+	// Dataplane on Kubernetes has the same name and namespace as the Pod it corresponds to
+	if identity.PodName != "" && identity.PodName != dataplane.Meta.GetName() {
+		return errors.Errorf("authentication failed: token is bound to a Pod (%q) different from Dataplane (%q)", identity.PodName, dataplane.Meta.GetName())
+	}
+	pod := &kube_core.Pod{}
+	key := kube_types.NamespacedName{Namespace: dataplane.Meta.GetNamespace(), Name: dataplane.Meta.GetName()}
+	if err := k.client.Get(ctx, key, pod); err != nil {
+		return errors.Wrapf(err, "authentication failed: unable to find Pod %q that corresponds to Dataplane", key)
+	}
+	if identity.PodUID != "" && identity.PodUID != string(pod.UID) {
+		return errors.Errorf("authentication failed: token is bound to a Pod with UID (%q) different from Pod %q (%q)", identity.PodUID, key, pod.UID)
+	}
+	serviceAccountName := pod.Spec.ServiceAccountName
+	if serviceAccountName == "" {
+		serviceAccountName = defaultServiceAccountName
+	}
+	if serviceAccountName != identity.Name {
+		return errors.Errorf("authentication failed: token belongs to a ServiceAccount (%q) different from Pod %q (%q)", identity.Name, key, serviceAccountName)
 	}
 	return nil
 }
+
+func extraValue(extra map[string]kube_auth.ExtraValue, key string) string {
+	if values := extra[key]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
@@ -0,0 +1,173 @@
+package stub_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
+	sds_auth "github.com/Kong/kuma/pkg/sds/auth"
+	k8s_sds_auth "github.com/Kong/kuma/pkg/sds/auth/k8s"
+	test_model "github.com/Kong/kuma/pkg/test/resources/model"
+
+	kube_auth "k8s.io/api/authentication/v1"
+	kube_core "k8s.io/api/core/v1"
+	kube_meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kube_runtime "k8s.io/apimachinery/pkg/runtime"
+	kube_client "sigs.k8s.io/controller-runtime/pkg/client"
+	kube_fake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// tokenReviewClient answers TokenReview requests using a predefined set of tokens.
+type tokenReviewClient struct {
+	kube_client.Client
+	users map[string]kube_auth.UserInfo
+}
+
+func (c *tokenReviewClient) Create(ctx context.Context, obj kube_runtime.Object, opts ...kube_client.CreateOption) error {
+	if review, ok := obj.(*kube_auth.TokenReview); ok {
+		user, authenticated := c.users[review.Spec.Token]
+		review.Status = kube_auth.TokenReviewStatus{
+			Authenticated: authenticated,
+			User:          user,
+		}
+		return nil
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+var _ = Describe("Authenticator", func() {
+
+	var authenticator sds_auth.Authenticator
+
+	proxyId := core_xds.ProxyId{Mesh: "default", Name: "backend-1", Namespace: "demo"}
+	dataplane := &core_mesh.DataplaneResource{
+		Meta: &test_model.ResourceMeta{
+			Mesh:      "default",
+			Name:      "backend-1",
+			Namespace: "demo",
+		},
+		Spec: mesh_proto.Dataplane{
+			Networking: &mesh_proto.Dataplane_Networking{
+				Inbound: []*mesh_proto.Dataplane_Networking_Inbound{
+					{
+						Interface: "192.168.0.1:80:8080",
+						Tags:      map[string]string{"service": "backend"},
+					},
+				},
+			},
+		},
+	}
+
+	BeforeEach(func() {
+		scheme := kube_runtime.NewScheme()
+		Expect(kube_core.AddToScheme(scheme)).To(Succeed())
+
+		pod := &kube_core.Pod{
+			ObjectMeta: kube_meta.ObjectMeta{
+				Namespace: "demo",
+				Name:      "backend-1",
+				UID:       "9f1c1a2e",
+			},
+			Spec: kube_core.PodSpec{
+				ServiceAccountName: "backend",
+			},
+		}
+
+		client := &tokenReviewClient{
+			Client: kube_fake.NewFakeClientWithScheme(scheme, pod),
+			users: map[string]kube_auth.UserInfo{
+				"backend-token": {
+					Username: "system:serviceaccount:demo:backend",
+				},
+				"bound-backend-token": {
+					Username: "system:serviceaccount:demo:backend",
+					Extra: map[string]kube_auth.ExtraValue{
+						"authentication.kubernetes.io/pod-name": {"backend-1"},
+						"authentication.kubernetes.io/pod-uid":  {"9f1c1a2e"},
+					},
+				},
+				"stale-bound-backend-token": {
+					Username: "system:serviceaccount:demo:backend",
+					Extra: map[string]kube_auth.ExtraValue{
+						"authentication.kubernetes.io/pod-name": {"backend-1"},
+						"authentication.kubernetes.io/pod-uid":  {"0a2b3c4d"},
+					},
+				},
+				"web-token": {
+					Username: "system:serviceaccount:demo:web",
+				},
+				"other-namespace-token": {
+					Username: "system:serviceaccount:other:backend",
+				},
+				"user-token": {
+					Username: "john",
+				},
+			},
+		}
+		authenticator = k8s_sds_auth.New(client, func(context.Context, core_xds.ProxyId) (*core_mesh.DataplaneResource, error) {
+			return dataplane, nil
+		})
+	})
+
+	It("should accept a token of the Pod's ServiceAccount", func() {
+		// when
+		identity, err := authenticator.Authenticate(context.Background(), proxyId, "backend-token")
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(identity).To(Equal(sds_auth.Identity{Mesh: "default", Service: "backend"}))
+	})
+
+	It("should accept a token bound to the Pod", func() {
+		// when
+		_, err := authenticator.Authenticate(context.Background(), proxyId, "bound-backend-token")
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	type testCase struct {
+		credential  sds_auth.Credential
+		expectedErr string
+	}
+
+	DescribeTable("should reject invalid tokens",
+		func(given testCase) {
+			// when
+			_, err := authenticator.Authenticate(context.Background(), proxyId, given.credential)
+
+			// then
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal(given.expectedErr))
+		},
+		Entry("missing token", testCase{
+			credential:  "",
+			expectedErr: `authentication failed: k8s token is missing`,
+		}),
+		Entry("unknown token", testCase{
+			credential:  "unknown-token",
+			expectedErr: `authentication failed: token doesn't belong to a valid user`,
+		}),
+		Entry("token of a regular user", testCase{
+			credential:  "user-token",
+			expectedErr: `authentication failed: username inside TokenReview response has unexpected format: "john"`,
+		}),
+		Entry("token from another namespace", testCase{
+			credential:  "other-namespace-token",
+			expectedErr: `authentication failed: token belongs to a namespace ("other") different from Dataplane ("demo")`,
+		}),
+		Entry("token of another ServiceAccount", testCase{
+			credential:  "web-token",
+			expectedErr: `authentication failed: token belongs to a ServiceAccount ("web") different from Pod "demo/backend-1" ("backend")`,
+		}),
+		Entry("token bound to a previous Pod with the same name", testCase{
+			credential:  "stale-bound-backend-token",
+			expectedErr: `authentication failed: token is bound to a Pod with UID ("0a2b3c4d") different from Pod "demo/backend-1" ("9f1c1a2e")`,
+		}),
+	)
+})
@@ -8,6 +8,7 @@ import (
 	core_xds "github.com/Kong/kuma/pkg/core/xds"
 	sds_auth "github.com/Kong/kuma/pkg/sds/auth"
 	common_auth "github.com/Kong/kuma/pkg/sds/auth/common"
+	"github.com/Kong/kuma/pkg/tokens/builtin/issuer"
 )
 
 func New(dataplaneResolver common_auth.DataplaneResolver) sds_auth.Authenticator {
@@ -27,3 +28,30 @@ func (u *universalAuthenticator) Authenticate(ctx context.Context, proxyId core_
 	}
 	return common_auth.GetDataplaneIdentity(dataplane)
 }
+
+// NewDataplaneTokenAuthenticator returns an authenticator that, unlike the one returned by New,
+// requires a Dataplane to present a Dataplane token issued for the same mesh and name.
+//
+// The same authenticator is used on xDS, so that a Dataplane is let in by both or by neither.
+func NewDataplaneTokenAuthenticator(dataplaneResolver common_auth.DataplaneResolver, tokenIssuer issuer.DataplaneTokenIssuer) sds_auth.Authenticator {
+	return &dataplaneTokenAuthenticator{
+		dataplaneResolver: dataplaneResolver,
+		tokenIssuer:       tokenIssuer,
+	}
+}
+
+type dataplaneTokenAuthenticator struct {
+	dataplaneResolver common_auth.DataplaneResolver
+	tokenIssuer       issuer.DataplaneTokenIssuer
+}
+
+func (d *dataplaneTokenAuthenticator) Authenticate(ctx context.Context, proxyId core_xds.ProxyId, credential sds_auth.Credential) (sds_auth.Identity, error) {
+	dataplane, err := d.dataplaneResolver(ctx, proxyId)
+	if err != nil {
+		return sds_auth.Identity{}, errors.Wrapf(err, "unable to find Dataplane for proxy %q", proxyId)
+	}
+	if err := issuer.Authenticate(ctx, d.tokenIssuer, issuer.Token(credential), dataplane.Meta.GetMesh(), dataplane.Meta.GetName()); err != nil {
+		return sds_auth.Identity{}, err
+	}
+	return common_auth.GetDataplaneIdentity(dataplane)
+}
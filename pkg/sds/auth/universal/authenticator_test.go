@@ -0,0 +1,106 @@
+package universal_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	secret_cipher "github.com/Kong/kuma/pkg/core/secrets/cipher"
+	secret_manager "github.com/Kong/kuma/pkg/core/secrets/manager"
+	secret_store "github.com/Kong/kuma/pkg/core/secrets/store"
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+	sds_auth "github.com/Kong/kuma/pkg/sds/auth"
+	universal_sds_auth "github.com/Kong/kuma/pkg/sds/auth/universal"
+	test_model "github.com/Kong/kuma/pkg/test/resources/model"
+	"github.com/Kong/kuma/pkg/tokens/builtin/issuer"
+)
+
+var _ = Describe("Authenticator", func() {
+
+	var tokenIssuer issuer.DataplaneTokenIssuer
+	var authenticator sds_auth.Authenticator
+
+	proxyId := core_xds.ProxyId{Mesh: "demo", Name: "backend-01"}
+	dataplane := &core_mesh.DataplaneResource{
+		Meta: &test_model.ResourceMeta{
+			Mesh: "demo",
+			Name: "backend-01",
+		},
+		Spec: mesh_proto.Dataplane{
+			Networking: &mesh_proto.Dataplane_Networking{
+				Inbound: []*mesh_proto.Dataplane_Networking_Inbound{
+					{
+						Interface: "192.168.0.1:80:8080",
+						Tags:      map[string]string{"service": "backend"},
+					},
+				},
+			},
+		},
+	}
+
+	BeforeEach(func() {
+		secretManager := secret_manager.NewSecretManager(secret_store.NewSecretStore(memory.NewStore()), secret_cipher.None())
+		tokenIssuer = issuer.NewDataplaneTokenIssuer(issuer.NewSigningKeyManager(secretManager))
+		authenticator = universal_sds_auth.NewDataplaneTokenAuthenticator(func(context.Context, core_xds.ProxyId) (*core_mesh.DataplaneResource, error) {
+			return dataplane, nil
+		}, tokenIssuer)
+	})
+
+	It("should accept a token issued for the Dataplane", func() {
+		// given
+		token, err := tokenIssuer.Generate(context.Background(), issuer.DataplaneIdentity{Mesh: "demo", Name: "backend-01"}, 0)
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		identity, err := authenticator.Authenticate(context.Background(), proxyId, sds_auth.Credential(token))
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(identity).To(Equal(sds_auth.Identity{Mesh: "demo", Service: "backend"}))
+	})
+
+	type testCase struct {
+		identity    *issuer.DataplaneIdentity
+		credential  string
+		expectedErr string
+	}
+
+	DescribeTable("should reject invalid tokens",
+		func(given testCase) {
+			// given
+			credential := given.credential
+			if given.identity != nil {
+				token, err := tokenIssuer.Generate(context.Background(), *given.identity, 0)
+				Expect(err).ToNot(HaveOccurred())
+				credential = token
+			}
+
+			// when
+			_, err := authenticator.Authenticate(context.Background(), proxyId, sds_auth.Credential(credential))
+
+			// then
+			Expect(err).To(MatchError(given.expectedErr))
+		},
+		Entry("no token", testCase{
+			credential:  "",
+			expectedErr: "authentication failed: dataplane has not presented a token",
+		}),
+		Entry("malformed token", testCase{
+			credential:  "not-a-token",
+			expectedErr: "authentication failed: token is malformed: token must consist of 3 segments",
+		}),
+		Entry("token of another mesh", testCase{
+			identity:    &issuer.DataplaneIdentity{Mesh: "default", Name: "backend-01"},
+			expectedErr: `authentication failed: token belongs to a mesh ("default") different from Dataplane ("demo")`,
+		}),
+		Entry("token of another dataplane", testCase{
+			identity:    &issuer.DataplaneIdentity{Mesh: "demo", Name: "web-01"},
+			expectedErr: `authentication failed: token belongs to a dataplane ("web-01") different from Dataplane ("backend-01")`,
+		}),
+	)
+})
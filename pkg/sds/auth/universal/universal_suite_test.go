@@ -0,0 +1,13 @@
+package universal_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestUniversalAuthenticator(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Universal Dataplane Authenticator Suite")
+}
@@ -7,6 +7,7 @@ import (
 
 	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
 	builtin_ca "github.com/Kong/kuma/pkg/core/ca/builtin"
+	provided_ca "github.com/Kong/kuma/pkg/core/ca/provided"
 	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
 	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
 	core_store "github.com/Kong/kuma/pkg/core/resources/store"
@@ -14,16 +15,18 @@ import (
 	sds_provider "github.com/Kong/kuma/pkg/sds/provider"
 )
 
-func New(resourceManager core_manager.ResourceManager, builtinCaManager builtin_ca.BuiltinCaManager) sds_provider.SecretProvider {
+func New(resourceManager core_manager.ResourceManager, builtinCaManager builtin_ca.BuiltinCaManager, providedCaManager provided_ca.ProvidedCaManager) sds_provider.SecretProvider {
 	return &meshCaProvider{
-		resourceManager:  resourceManager,
-		builtinCaManager: builtinCaManager,
+		resourceManager:   resourceManager,
+		builtinCaManager:  builtinCaManager,
+		providedCaManager: providedCaManager,
 	}
 }
 
 type meshCaProvider struct {
-	resourceManager  core_manager.ResourceManager
-	builtinCaManager builtin_ca.BuiltinCaManager
+	resourceManager   core_manager.ResourceManager
+	builtinCaManager  builtin_ca.BuiltinCaManager
+	providedCaManager provided_ca.ProvidedCaManager
 }
 
 func (s *meshCaProvider) RequiresIdentity() bool {
@@ -43,12 +46,25 @@ func (s *meshCaProvider) Get(ctx context.Context, resource string, requestor sds
 		return nil, errors.Errorf("there are multiple Meshes named %q", meshName)
 	}
 	mesh := list.Items[0]
-	switch mesh.Spec.GetMtls().GetCa().GetType().(type) {
+	switch ca := mesh.Spec.GetMtls().GetCa().GetType().(type) {
 	case *mesh_proto.CertificateAuthority_Builtin_:
 		rootCerts, err := s.builtinCaManager.GetRootCerts(ctx, mesh.Meta.GetName())
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed to retrive Root Certificates of a given Builtin CA")
 		}
+		crl, err := s.builtinCaManager.GetCRL(ctx, mesh.Meta.GetName())
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to retrive Certificate Revocation List of a given Builtin CA")
+		}
+		return &MeshCaSecret{
+			PemCerts: rootCerts,
+			PemCrl:   crl,
+		}, nil
+	case *mesh_proto.CertificateAuthority_Provided_:
+		rootCerts, err := s.providedCaManager.GetRootCerts(ctx, ca.Provided)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to retrive Root Certificates of a given Provided CA")
+		}
 		return &MeshCaSecret{
 			PemCerts: rootCerts,
 		}, nil
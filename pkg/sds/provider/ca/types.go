@@ -11,21 +11,31 @@ import (
 
 type MeshCaSecret struct {
 	PemCerts [][]byte
+	// PemCrl is a Certificate Revocation List of a CA, if any certificates have been revoked.
+	PemCrl []byte
 }
 
 var _ sds_provider.Secret = &MeshCaSecret{}
 
 func (s *MeshCaSecret) ToResource(name string) *envoy_auth.Secret {
+	validationContext := &envoy_auth.CertificateValidationContext{
+		TrustedCa: &envoy_core.DataSource{
+			Specifier: &envoy_core.DataSource_InlineBytes{
+				InlineBytes: []byte(bytes.Join(s.PemCerts, []byte("\n"))),
+			},
+		},
+	}
+	if len(s.PemCrl) > 0 {
+		validationContext.Crl = &envoy_core.DataSource{
+			Specifier: &envoy_core.DataSource_InlineBytes{
+				InlineBytes: s.PemCrl,
+			},
+		}
+	}
 	return &envoy_auth.Secret{
 		Name: name,
 		Type: &envoy_auth.Secret_ValidationContext{
-			ValidationContext: &envoy_auth.CertificateValidationContext{
-				TrustedCa: &envoy_core.DataSource{
-					Specifier: &envoy_core.DataSource_InlineBytes{
-						InlineBytes: []byte(bytes.Join(s.PemCerts, []byte("\n"))),
-					},
-				},
-			},
+			ValidationContext: validationContext,
 		},
 	}
 }
@@ -7,6 +7,7 @@ import (
 
 	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
 	builtin_ca "github.com/Kong/kuma/pkg/core/ca/builtin"
+	provided_ca "github.com/Kong/kuma/pkg/core/ca/provided"
 	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
 	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
 	core_store "github.com/Kong/kuma/pkg/core/resources/store"
@@ -15,16 +16,18 @@ import (
 	sds_provider "github.com/Kong/kuma/pkg/sds/provider"
 )
 
-func New(resourceManager core_manager.ResourceManager, builtinCaManager builtin_ca.BuiltinCaManager) sds_provider.SecretProvider {
+func New(resourceManager core_manager.ResourceManager, builtinCaManager builtin_ca.BuiltinCaManager, providedCaManager provided_ca.ProvidedCaManager) sds_provider.SecretProvider {
 	return &identityCertProvider{
-		resourceManager:  resourceManager,
-		builtinCaManager: builtinCaManager,
+		resourceManager:   resourceManager,
+		builtinCaManager:  builtinCaManager,
+		providedCaManager: providedCaManager,
 	}
 }
 
 type identityCertProvider struct {
-	resourceManager  core_manager.ResourceManager
-	builtinCaManager builtin_ca.BuiltinCaManager
+	resourceManager   core_manager.ResourceManager
+	builtinCaManager  builtin_ca.BuiltinCaManager
+	providedCaManager provided_ca.ProvidedCaManager
 }
 
 func (s *identityCertProvider) RequiresIdentity() bool {
@@ -45,7 +48,7 @@ func (s *identityCertProvider) Get(ctx context.Context, name string, requestor s
 	}
 	mesh := list.Items[0]
 
-	switch mesh.Spec.GetMtls().GetCa().GetType().(type) {
+	switch ca := mesh.Spec.GetMtls().GetCa().GetType().(type) {
 	case *mesh_proto.CertificateAuthority_Builtin_:
 		workloadCert, err := s.builtinCaManager.GenerateWorkloadCert(ctx, mesh.Meta.GetName(), requestor.Service)
 		if err != nil {
@@ -55,6 +58,15 @@ func (s *identityCertProvider) Get(ctx context.Context, name string, requestor s
 			PemCerts: [][]byte{workloadCert.CertPEM},
 			PemKey:   []byte(workloadCert.KeyPEM),
 		}, nil
+	case *mesh_proto.CertificateAuthority_Provided_:
+		workloadCert, err := s.providedCaManager.GenerateWorkloadCert(ctx, mesh.Meta.GetName(), ca.Provided, requestor.Service)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to generate a Workload Identity Certificate for %+v", requestor)
+		}
+		return &IdentityCertSecret{
+			PemCerts: [][]byte{workloadCert.CertPEM},
+			PemKey:   []byte(workloadCert.KeyPEM),
+		}, nil
 	default:
 		return nil, errors.Errorf("Mesh %q has unsupported CA type", meshName)
 	}
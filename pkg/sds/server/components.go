@@ -2,10 +2,12 @@ package server
 
 import (
 	"context"
+	"time"
 
 	"github.com/pkg/errors"
 
 	kuma_cp "github.com/Kong/kuma/pkg/config/app/kuma-cp"
+	xds_config "github.com/Kong/kuma/pkg/config/xds"
 	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
 	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
 	core_store "github.com/Kong/kuma/pkg/core/resources/store"
@@ -13,11 +15,13 @@ import (
 	core_xds "github.com/Kong/kuma/pkg/core/xds"
 	k8s_runtime "github.com/Kong/kuma/pkg/runtime/k8s"
 	sds_auth "github.com/Kong/kuma/pkg/sds/auth"
+	hybrid_sds_auth "github.com/Kong/kuma/pkg/sds/auth/hybrid"
 	k8s_sds_auth "github.com/Kong/kuma/pkg/sds/auth/k8s"
 	universal_sds_auth "github.com/Kong/kuma/pkg/sds/auth/universal"
 	sds_provider "github.com/Kong/kuma/pkg/sds/provider"
 	ca_sds_provider "github.com/Kong/kuma/pkg/sds/provider/ca"
 	identity_sds_provider "github.com/Kong/kuma/pkg/sds/provider/identity"
+	"github.com/Kong/kuma/pkg/tokens/builtin/issuer"
 
 	envoy "github.com/envoyproxy/go-control-plane/envoy/api/v2"
 	envoy_auth "github.com/envoyproxy/go-control-plane/envoy/api/v2/auth"
@@ -42,12 +46,35 @@ func NewKubeAuthenticator(rt core_runtime.Runtime) (sds_auth.Authenticator, erro
 }
 
 func NewUniversalAuthenticator(rt core_runtime.Runtime) (sds_auth.Authenticator, error) {
+	if rt.Config().XdsServer.DataplaneAuth.Type == xds_config.DataplaneTokenDataplaneAuth {
+		tokenIssuer := newDataplaneTokenIssuer(rt)
+		return universal_sds_auth.NewDataplaneTokenAuthenticator(DefaultDataplaneResolver(rt.ResourceManager()), tokenIssuer), nil
+	}
 	return universal_sds_auth.New(DefaultDataplaneResolver(rt.ResourceManager())), nil
 }
 
+// NewHybridAuthenticator returns an authenticator that verifies ServiceAccount tokens of Dataplanes of Kubernetes Pods
+// and Dataplane tokens of other Dataplanes, e.g. those of VMs.
+func NewHybridAuthenticator(rt core_runtime.Runtime) (sds_auth.Authenticator, error) {
+	kubeAuthenticator, err := NewKubeAuthenticator(rt)
+	if err != nil {
+		return nil, err
+	}
+	tokenIssuer := newDataplaneTokenIssuer(rt)
+	universalAuthenticator := universal_sds_auth.NewDataplaneTokenAuthenticator(DefaultDataplaneResolver(rt.ResourceManager()), tokenIssuer)
+	return hybrid_sds_auth.New(kubeAuthenticator, universalAuthenticator, DefaultDataplaneResolver(rt.ResourceManager())), nil
+}
+
+func newDataplaneTokenIssuer(rt core_runtime.Runtime) issuer.DataplaneTokenIssuer {
+	return issuer.NewDataplaneTokenIssuerWithAllowedClockSkew(issuer.NewSigningKeyManager(rt.SecretManager()), rt.Config().SdsServer.CertRotation.AllowedClockSkew)
+}
+
 func DefaultAuthenticator(rt core_runtime.Runtime) (sds_auth.Authenticator, error) {
 	switch env := rt.Config().Environment; env {
 	case kuma_cp.KubernetesEnvironment:
+		if rt.Config().Discovery.Kubernetes.UniversalDataplanesEnabled {
+			return NewHybridAuthenticator(rt)
+		}
 		return NewKubeAuthenticator(rt)
 	case kuma_cp.UniversalEnvironment:
 		return NewUniversalAuthenticator(rt)
@@ -67,11 +94,11 @@ func DefaultDataplaneResolver(resourceManager core_manager.ResourceManager) func
 }
 
 func DefaultMeshCaProvider(rt core_runtime.Runtime) sds_provider.SecretProvider {
-	return ca_sds_provider.New(rt.ResourceManager(), rt.BuiltinCaManager())
+	return ca_sds_provider.New(rt.ResourceManager(), rt.BuiltinCaManager(), rt.ProvidedCaManager())
 }
 
 func DefaultIdentityCertProvider(rt core_runtime.Runtime) sds_provider.SecretProvider {
-	return identity_sds_provider.New(rt.ResourceManager(), rt.BuiltinCaManager())
+	return identity_sds_provider.New(rt.ResourceManager(), rt.BuiltinCaManager(), rt.ProvidedCaManager())
 }
 
 func DefaultSecretProviderSelector(rt core_runtime.Runtime) func(string) (sds_provider.SecretProvider, error) {
@@ -95,6 +122,8 @@ func DefaultSecretDiscoveryHandler(rt core_runtime.Runtime) (SecretDiscoveryHand
 		return nil, err
 	}
 	secretProviderSelector := DefaultSecretProviderSelector(rt)
+	rateLimit := rt.Config().SdsServer.IssuanceRateLimit
+	limiter := NewIssuanceRateLimiter(rateLimit.Burst, rateLimit.Interval)
 	return SecretDiscoveryHandlerFunc(func(ctx context.Context, req envoy.DiscoveryRequest) (*envoy_auth.Secret, error) {
 		resource := req.ResourceNames[0]
 		provider, err := secretProviderSelector(resource)
@@ -111,15 +140,40 @@ func DefaultSecretDiscoveryHandler(rt core_runtime.Runtime) (SecretDiscoveryHand
 			if err != nil {
 				return nil, err
 			}
+			// a token in node metadata takes precedence over the one in gRPC headers
+			metadata, err := core_xds.ParseDataplaneMetadata(req.Node)
+			if err != nil {
+				return nil, err
+			}
+			if metadata != nil && metadata.Token != "" {
+				credential = sds_auth.Credential(metadata.Token)
+			}
 			requestor, err = authenticator.Authenticate(ctx, *proxyId, credential)
 			if err != nil {
 				return nil, err
 			}
 		}
+		if resource == IdentityCertResource {
+			if delay := limiter.Reserve(requestor); delay > 0 {
+				auditDelayedIssuance(*proxyId, requestor, delay)
+				timer := time.NewTimer(delay)
+				select {
+				case <-timer.C:
+				case <-ctx.Done():
+					timer.Stop()
+					return nil, ctx.Err()
+				}
+			}
+		}
 		secret, err := provider.Get(ctx, resource, requestor)
 		if err != nil {
 			return nil, err
 		}
+		if certSecret, ok := secret.(*identity_sds_provider.IdentityCertSecret); ok {
+			if err := auditIssuance(*proxyId, requestor, certSecret); err != nil {
+				return nil, err
+			}
+		}
 		return secret.ToResource(resource), nil
 	}), nil
 }
@@ -1,10 +1,10 @@
 package server
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net"
 
-	"github.com/pkg/errors"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 
@@ -13,9 +13,15 @@ import (
 	sds_config "github.com/Kong/kuma/pkg/config/sds"
 	"github.com/Kong/kuma/pkg/core"
 	core_runtime "github.com/Kong/kuma/pkg/core/runtime"
+	util_tls "github.com/Kong/kuma/pkg/tls"
+	util_grpc "github.com/Kong/kuma/pkg/util/grpc"
 )
 
-const grpcMaxConcurrentStreams = 1000000
+const (
+	grpcMaxConcurrentStreams = 1000000
+	// grpcServiceName is a name of the service reported by gRPC Health Checking service.
+	grpcServiceName = "envoy.service.discovery.v2.SecretDiscoveryService"
+)
 
 var (
 	grpcServerLog = core.Log.WithName("sds-server").WithName("grpc")
@@ -36,11 +42,11 @@ func (s *grpcServer) Start(stop <-chan struct{}) error {
 	grpcOptions = append(grpcOptions, grpc.MaxConcurrentStreams(grpcMaxConcurrentStreams))
 	useTLS := s.config.TlsCertFile != ""
 	if useTLS {
-		creds, err := credentials.NewServerTLSFromFile(s.config.TlsCertFile, s.config.TlsKeyFile)
+		tlsConfig, err := s.tlsConfig()
 		if err != nil {
-			return errors.Wrap(err, "failed to load TLS certificate")
+			return err
 		}
-		grpcOptions = append(grpcOptions, grpc.Creds(creds))
+		grpcOptions = append(grpcOptions, grpc.Creds(credentials.NewTLS(tlsConfig)))
 	}
 	grpcServer := grpc.NewServer(grpcOptions...)
 
@@ -51,6 +57,7 @@ func (s *grpcServer) Start(stop <-chan struct{}) error {
 
 	// register services
 	envoy_discovery.RegisterSecretDiscoveryServiceServer(grpcServer, s.server)
+	healthServer := util_grpc.RegisterHealthServer(grpcServer, grpcServiceName)
 
 	errChan := make(chan error)
 	go func() {
@@ -67,9 +74,15 @@ func (s *grpcServer) Start(stop <-chan struct{}) error {
 	select {
 	case <-stop:
 		grpcServerLog.Info("stopping gracefully")
+		healthServer.Shutdown()
 		grpcServer.GracefulStop()
 		return nil
 	case err := <-errChan:
 		return err
 	}
 }
+
+func (s *grpcServer) tlsConfig() (*tls.Config, error) {
+	// Dataplanes are authenticated by their tokens rather than by client certificates
+	return util_tls.ServerConfig(s.config.TlsCertFile, s.config.TlsKeyFile, "", s.config.TlsMinVersion, s.config.TlsCipherSuites)
+}
@@ -0,0 +1,136 @@
+package server
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/Kong/kuma/pkg/core"
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
+	sds_auth "github.com/Kong/kuma/pkg/sds/auth"
+	identity_sds_provider "github.com/Kong/kuma/pkg/sds/provider/identity"
+)
+
+var (
+	// issuanceAuditLog records every Workload Identity certificate issued to a Dataplane
+	// as well as every request that was denied, so that issuance can be audited.
+	issuanceAuditLog = core.Log.WithName("sds-server").WithName("cert-issuance-audit")
+)
+
+// IssuanceRateLimiter limits how many Workload Identity certificates can be issued to a single identity,
+// i.e. a service in a Mesh that Dataplanes have authenticated as. Unlike a Proxy Id, an identity cannot be
+// chosen freely by a client, so the limit cannot be evaded.
+//
+// Every identity can get up to burst certificates at once and then one more every interval.
+// The limit is kept in memory, so every instance of Control Plane enforces it separately.
+type IssuanceRateLimiter struct {
+	burst    int
+	interval time.Duration
+
+	sync.Mutex
+	buckets   map[sds_auth.Identity]*issuanceBucket
+	lastPrune time.Time
+}
+
+type issuanceBucket struct {
+	// tokens is negative if certificates have been reserved ahead of time
+	tokens   int
+	lastFill time.Time
+}
+
+// NewIssuanceRateLimiter returns a rate limiter. There is no limit if burst is 0.
+func NewIssuanceRateLimiter(burst int, interval time.Duration) *IssuanceRateLimiter {
+	return &IssuanceRateLimiter{
+		burst:    burst,
+		interval: interval,
+		buckets:  map[sds_auth.Identity]*issuanceBucket{},
+	}
+}
+
+// Reserve reserves one more certificate for a given identity and returns
+// how long its issuance has to be delayed by to stay within the limit.
+func (l *IssuanceRateLimiter) Reserve(identity sds_auth.Identity) time.Duration {
+	if l.burst <= 0 {
+		return 0
+	}
+	l.Lock()
+	defer l.Unlock()
+
+	now := core.Now()
+	l.prune(now)
+	bucket, ok := l.buckets[identity]
+	if !ok {
+		bucket = &issuanceBucket{tokens: l.burst, lastFill: now}
+		l.buckets[identity] = bucket
+	}
+	l.fill(bucket, now)
+	bucket.tokens--
+	if bucket.tokens >= 0 {
+		return 0
+	}
+	return bucket.lastFill.Add(time.Duration(-bucket.tokens) * l.interval).Sub(now)
+}
+
+func (l *IssuanceRateLimiter) fill(bucket *issuanceBucket, now time.Time) {
+	refills := int(now.Sub(bucket.lastFill) / l.interval)
+	if refills <= 0 {
+		return
+	}
+	bucket.lastFill = bucket.lastFill.Add(time.Duration(refills) * l.interval)
+	if bucket.tokens+refills >= l.burst {
+		bucket.tokens = l.burst
+		bucket.lastFill = now
+		return
+	}
+	bucket.tokens += refills
+}
+
+// prune forgets identities that are back to the full burst, e.g. services that have been removed.
+func (l *IssuanceRateLimiter) prune(now time.Time) {
+	if now.Sub(l.lastPrune) < time.Duration(l.burst)*l.interval {
+		return
+	}
+	for identity, bucket := range l.buckets {
+		l.fill(bucket, now)
+		if bucket.tokens >= l.burst {
+			delete(l.buckets, identity)
+		}
+	}
+	l.lastPrune = now
+}
+
+func auditIssuance(proxyId core_xds.ProxyId, requestor sds_auth.Identity, secret *identity_sds_provider.IdentityCertSecret) error {
+	if len(secret.PemCerts) == 0 {
+		return errors.New("Workload Identity Certificate is empty")
+	}
+	block, _ := pem.Decode(secret.PemCerts[0])
+	if block == nil {
+		return errors.New("Workload Identity Certificate is not PEM-encoded")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse Workload Identity Certificate")
+	}
+	issuanceAuditLog.Info("issued a Workload Identity certificate",
+		"mesh", proxyId.Mesh,
+		"dataplane", proxyId.Name,
+		"service", requestor.Service,
+		"serialNumber", cert.SerialNumber.Text(16),
+		"notBefore", cert.NotBefore,
+		"notAfter", cert.NotAfter,
+		"ttl", cert.NotAfter.Sub(cert.NotBefore).String(),
+	)
+	return nil
+}
+
+func auditDelayedIssuance(proxyId core_xds.ProxyId, requestor sds_auth.Identity, delay time.Duration) {
+	issuanceAuditLog.Info("delayed a Workload Identity certificate, service exceeded the rate limit",
+		"mesh", proxyId.Mesh,
+		"dataplane", proxyId.Name,
+		"service", requestor.Service,
+		"delay", delay.String(),
+	)
+}
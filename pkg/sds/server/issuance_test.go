@@ -0,0 +1,69 @@
+package server_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kong/kuma/pkg/core"
+	sds_auth "github.com/Kong/kuma/pkg/sds/auth"
+	. "github.com/Kong/kuma/pkg/sds/server"
+)
+
+var _ = Describe("IssuanceRateLimiter", func() {
+
+	var now time.Time
+
+	BeforeEach(func() {
+		now = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		core.Now = func() time.Time {
+			return now
+		}
+	})
+
+	AfterEach(func() {
+		core.Now = time.Now
+	})
+
+	backend := sds_auth.Identity{Mesh: "demo", Service: "backend"}
+	web := sds_auth.Identity{Mesh: "demo", Service: "web"}
+
+	It("should delay certificates per identity", func() {
+		// given
+		limiter := NewIssuanceRateLimiter(2, time.Minute)
+
+		// expect burst not to be delayed
+		Expect(limiter.Reserve(backend)).To(Equal(time.Duration(0)))
+		Expect(limiter.Reserve(backend)).To(Equal(time.Duration(0)))
+		// and then certificates to be delayed one after another
+		Expect(limiter.Reserve(backend)).To(Equal(time.Minute))
+		Expect(limiter.Reserve(backend)).To(Equal(2 * time.Minute))
+		// and other identities not to be affected
+		Expect(limiter.Reserve(web)).To(Equal(time.Duration(0)))
+
+		// when
+		now = now.Add(30 * time.Second)
+
+		// then the next certificate is delayed after the ones that have been reserved
+		Expect(limiter.Reserve(backend)).To(Equal(150 * time.Second))
+
+		// when
+		now = now.Add(time.Hour)
+
+		// then the whole burst is available again
+		Expect(limiter.Reserve(backend)).To(Equal(time.Duration(0)))
+		Expect(limiter.Reserve(backend)).To(Equal(time.Duration(0)))
+		Expect(limiter.Reserve(backend)).To(Equal(time.Minute))
+	})
+
+	It("should not limit certificates if burst is 0", func() {
+		// given
+		limiter := NewIssuanceRateLimiter(0, 0)
+
+		// expect
+		for i := 0; i < 100; i++ {
+			Expect(limiter.Reserve(backend)).To(Equal(time.Duration(0)))
+		}
+	})
+})
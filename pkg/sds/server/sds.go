@@ -16,8 +16,11 @@ package server
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/pem"
 	"strconv"
 	"sync/atomic"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -47,8 +50,13 @@ type Server interface {
 	envoy_discovery.SecretDiscoveryServiceServer
 }
 
-func NewServer(source SecretDiscoveryHandler, callbacks envoy_server.Callbacks, log logr.Logger) Server {
-	return &server{source: source, callbacks: callbacks, log: log}
+// NewServer returns an SDS server that pushes a new secret to Envoy once a given fraction
+// of the remaining lifetime of the previous one has elapsed. Secrets are never renewed if renewalThreshold is 0.
+//
+// Validation contexts are also generated again every crlRefreshInterval and pushed if they have changed,
+// so that revocations reach Envoy in time, including the first one in a Mesh.
+func NewServer(source SecretDiscoveryHandler, callbacks envoy_server.Callbacks, renewalThreshold float64, crlRefreshInterval time.Duration, log logr.Logger) Server {
+	return &server{source: source, callbacks: callbacks, renewalThreshold: renewalThreshold, crlRefreshInterval: crlRefreshInterval, log: log}
 }
 
 // server is a simplified version of the original XDS server at
@@ -57,6 +65,9 @@ type server struct {
 	source    SecretDiscoveryHandler
 	callbacks envoy_server.Callbacks
 
+	renewalThreshold   float64
+	crlRefreshInterval time.Duration
+
 	// streamCount for counting bi-di streams
 	streamCount int64
 
@@ -74,6 +85,11 @@ type state struct {
 	resourceName string
 
 	secretNonce string
+
+	// lastRequest is the request that the current secret has been generated for
+	lastRequest *envoy.DiscoveryRequest
+	// lastSecret is the secret that has been pushed last time
+	lastSecret *envoy_auth.Secret
 }
 
 func createResponse(resp *envoy_cache.Response, typeURL string) (*envoy.DiscoveryResponse, error) {
@@ -152,9 +168,61 @@ func (s *server) process(stream stream, reqCh <-chan *envoy.DiscoveryRequest, de
 	// node may only be set on the first discovery request
 	var node = &envoy_core.Node{}
 
+	// renewal fires once the current secret has to be replaced
+	renewal := time.NewTimer(0)
+	if !renewal.Stop() {
+		<-renewal.C
+	}
+	defer renewal.Stop()
+
+	// schedule arms the renewal of a given secret
+	schedule := func(secret *envoy_auth.Secret) {
+		if renewAt, ok := s.renewalTime(secret); ok {
+			if !renewal.Stop() {
+				select {
+				case <-renewal.C:
+				default:
+				}
+			}
+			renewal.Reset(time.Until(renewAt))
+			log.V(1).Info("scheduled renewal of the secret", "resource", state.resourceName, "renewAt", renewAt)
+		}
+	}
+
+	// respond sends a secret generated for a given request and schedules its renewal
+	respond := func(req *envoy.DiscoveryRequest, secret *envoy_auth.Secret) error {
+		resp := s.toResponse(req, secret)
+
+		nonce, err := send(resp, envoy_cache.SecretType)
+		if err != nil {
+			return err
+		}
+		state.secretNonce = nonce
+		state.lastRequest = req
+		state.lastSecret = secret
+
+		schedule(secret)
+		return nil
+	}
+
 	for {
 		select {
 
+		case <-renewal.C:
+			secret, err := s.source.Handle(stream.Context(), *state.lastRequest)
+			if err != nil {
+				return err
+			}
+			if proto.Equal(secret, state.lastSecret) {
+				// e.g. no certificate has been revoked since the CRL was pushed last time
+				schedule(secret)
+				continue
+			}
+			log.Info("renewing the secret", "resource", state.resourceName)
+			if err := respond(state.lastRequest, secret); err != nil {
+				return err
+			}
+
 		case req, more := <-reqCh:
 			// input stream ended or errored out
 			if !more {
@@ -197,18 +265,61 @@ func (s *server) process(stream stream, reqCh <-chan *envoy.DiscoveryRequest, de
 			if err != nil {
 				return err
 			}
-
-			resp := s.toResponse(req, secret)
-
-			nonce, err = send(resp, envoy_cache.SecretType)
-			if err != nil {
+			if err := respond(req, secret); err != nil {
 				return err
 			}
-			state.secretNonce = nonce
 		}
 	}
 }
 
+// renewalTime returns the moment a given secret has to be replaced at.
+func (s *server) renewalTime(secret *envoy_auth.Secret) (time.Time, bool) {
+	renewAt, ok := s.certRenewalTime(secret)
+	// a CRL can appear in any validation context once the first certificate is revoked
+	if s.crlRefreshInterval > 0 && secret.GetValidationContext() != nil {
+		if refreshAt := time.Now().Add(s.crlRefreshInterval); !ok || refreshAt.Before(renewAt) {
+			return refreshAt, true
+		}
+	}
+	return renewAt, ok
+}
+
+// certRenewalTime returns the moment that is after renewalThreshold of the remaining lifetime
+// of the earliest expiring certificate of a given secret.
+func (s *server) certRenewalTime(secret *envoy_auth.Secret) (time.Time, bool) {
+	if s.renewalThreshold <= 0 {
+		return time.Time{}, false
+	}
+	var certsPEM []byte
+	switch {
+	case secret.GetTlsCertificate() != nil:
+		certsPEM = secret.GetTlsCertificate().GetCertificateChain().GetInlineBytes()
+	case secret.GetValidationContext() != nil:
+		certsPEM = secret.GetValidationContext().GetTrustedCa().GetInlineBytes()
+	}
+	var notAfter time.Time
+	for {
+		var block *pem.Block
+		block, certsPEM = pem.Decode(certsPEM)
+		if block == nil {
+			break
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			continue
+		}
+		if notAfter.IsZero() || cert.NotAfter.Before(notAfter) {
+			notAfter = cert.NotAfter
+		}
+	}
+	now := time.Now()
+	if notAfter.IsZero() || !now.Before(notAfter) {
+		return time.Time{}, false
+	}
+	remaining := notAfter.Sub(now)
+	return now.Add(time.Duration(float64(remaining) * s.renewalThreshold)), true
+}
+
 func (s *server) toResponse(req *envoy.DiscoveryRequest, secret *envoy_auth.Secret) envoy_cache.Response {
 	return envoy_cache.Response{
 		Request:   *req,
@@ -2,12 +2,16 @@ package server_test
 
 import (
 	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
 
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
 
 	envoy "github.com/envoyproxy/go-control-plane/envoy/api/v2"
 	envoy_auth "github.com/envoyproxy/go-control-plane/envoy/api/v2/auth"
+	envoy_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -15,6 +19,7 @@ import (
 	. "github.com/Kong/kuma/pkg/sds/server"
 
 	test_logr "github.com/Kong/kuma/pkg/test/logr"
+	"github.com/Kong/kuma/pkg/tls"
 )
 
 var _ = Describe("Sds", func() {
@@ -27,7 +32,7 @@ var _ = Describe("Sds", func() {
 
 	It("should support streams without a single SDS request", func(done Done) {
 		// given
-		sds := NewServer(nil, nil, test_logr.NewTestLogger(GinkgoT()))
+		sds := NewServer(nil, nil, 0, 0, test_logr.NewTestLogger(GinkgoT()))
 
 		// when
 		errCh := make(chan error)
@@ -52,7 +57,7 @@ var _ = Describe("Sds", func() {
 		handler := SecretDiscoveryHandlerFunc(func(ctx context.Context, req envoy.DiscoveryRequest) (*envoy_auth.Secret, error) {
 			return &envoy_auth.Secret{}, nil
 		})
-		sds := NewServer(handler, nil, test_logr.NewTestLogger(GinkgoT()))
+		sds := NewServer(handler, nil, 0, 0, test_logr.NewTestLogger(GinkgoT()))
 
 		// when
 		errCh := make(chan error)
@@ -79,6 +84,126 @@ var _ = Describe("Sds", func() {
 		// finally
 		close(done)
 	})
+
+	It("should push a new secret before the previous one expires", func(done Done) {
+		// given
+		handler := SecretDiscoveryHandlerFunc(func(ctx context.Context, req envoy.DiscoveryRequest) (*envoy_auth.Secret, error) {
+			pair, err := tls.NewSelfSignedCertValidFor(2*time.Second, "backend")
+			if err != nil {
+				return nil, err
+			}
+			return &envoy_auth.Secret{
+				Type: &envoy_auth.Secret_TlsCertificate{
+					TlsCertificate: &envoy_auth.TlsCertificate{
+						CertificateChain: &envoy_core.DataSource{
+							Specifier: &envoy_core.DataSource_InlineBytes{InlineBytes: pair.CertPEM},
+						},
+					},
+				},
+			}, nil
+		})
+		sds := NewServer(handler, nil, 0.25, 0, test_logr.NewTestLogger(GinkgoT()))
+
+		// when
+		errCh := make(chan error)
+		go func() {
+			defer GinkgoRecover()
+
+			errCh <- sds.StreamSecrets(stream)
+		}()
+
+		// when
+		stream.in <- &envoy.DiscoveryRequest{
+			ResourceNames: []string{"identity_cert"},
+		}
+		// then
+		first := <-stream.out
+		Expect(first).ToNot(BeNil())
+
+		// when
+		stream.in <- &envoy.DiscoveryRequest{
+			ResourceNames: []string{"identity_cert"},
+			ResponseNonce: first.Nonce,
+		}
+		// then a new secret is pushed without a request from Envoy
+		var second *envoy.DiscoveryResponse
+		Eventually(stream.out, "2s").Should(Receive(&second))
+		Expect(second.Nonce).ToNot(Equal(first.Nonce))
+		Expect(second.Resources[0].Value).ToNot(Equal(first.Resources[0].Value))
+
+		// when
+		close(stream.in)
+		// then
+		err := <-errCh
+		Expect(err).ToNot(HaveOccurred())
+
+		// finally
+		close(done)
+	}, 5)
+
+	It("should push a validation context once a CRL appears", func(done Done) {
+		// given
+		var generation int32
+		handler := SecretDiscoveryHandlerFunc(func(ctx context.Context, req envoy.DiscoveryRequest) (*envoy_auth.Secret, error) {
+			gen := atomic.AddInt32(&generation, 1)
+			if gen < 3 {
+				// no certificate has been revoked yet
+				return &envoy_auth.Secret{
+					Type: &envoy_auth.Secret_ValidationContext{
+						ValidationContext: &envoy_auth.CertificateValidationContext{},
+					},
+				}, nil
+			}
+			crl := fmt.Sprintf("crl-%d", gen)
+			return &envoy_auth.Secret{
+				Type: &envoy_auth.Secret_ValidationContext{
+					ValidationContext: &envoy_auth.CertificateValidationContext{
+						Crl: &envoy_core.DataSource{
+							Specifier: &envoy_core.DataSource_InlineBytes{InlineBytes: []byte(crl)},
+						},
+					},
+				},
+			}, nil
+		})
+		sds := NewServer(handler, nil, 0, 100*time.Millisecond, test_logr.NewTestLogger(GinkgoT()))
+
+		// when
+		errCh := make(chan error)
+		go func() {
+			defer GinkgoRecover()
+
+			errCh <- sds.StreamSecrets(stream)
+		}()
+
+		// when
+		stream.in <- &envoy.DiscoveryRequest{
+			ResourceNames: []string{"mesh_ca"},
+		}
+		// then
+		first := <-stream.out
+		Expect(first).ToNot(BeNil())
+
+		// when
+		stream.in <- &envoy.DiscoveryRequest{
+			ResourceNames: []string{"mesh_ca"},
+			ResponseNonce: first.Nonce,
+		}
+		// then a secret with a CRL is pushed without a request from Envoy
+		var second *envoy.DiscoveryResponse
+		Eventually(stream.out, "1s").Should(Receive(&second))
+		Expect(second.Nonce).ToNot(Equal(first.Nonce))
+		Expect(second.Resources[0].Value).ToNot(Equal(first.Resources[0].Value))
+		Expect(atomic.LoadInt32(&generation)).To(BeNumerically(">=", 3))
+
+		// when
+		close(stream.in)
+		// then
+		err := <-errCh
+		Expect(err).ToNot(HaveOccurred())
+
+		// finally
+		close(done)
+	}, 5)
 })
 
 func newMockStream() *mockStream {
@@ -18,6 +18,6 @@ func SetupServer(rt core_runtime.Runtime) error {
 	callbacks := util_xds.CallbacksChain{
 		util_xds.LoggingCallbacks{Log: sdsServerLog},
 	}
-	srv := NewServer(handler, callbacks, sdsServerLog)
+	srv := NewServer(handler, callbacks, rt.Config().SdsServer.CertRotation.RenewalThreshold, rt.Config().SdsServer.CertRotation.CrlRefreshInterval, sdsServerLog)
 	return core_runtime.Add(rt, &grpcServer{srv, *rt.Config().SdsServer})
 }
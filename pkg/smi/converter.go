@@ -0,0 +1,152 @@
+package smi
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/model"
+)
+
+// ServiceTagResolver resolves a ServiceAccount into values of the `service` tag
+// of the Pods that run under it.
+// +kubebuilder:object:generate=false
+type ServiceTagResolver interface {
+	ServiceTags(namespace, serviceAccount string) ([]string, error)
+}
+
+// ConvertTrafficTarget converts SMI TrafficTarget into an equivalent TrafficPermission.
+//
+// Kuma identifies workloads by the `service` tag rather than by a ServiceAccount,
+// that is why every ServiceAccount is resolved into the `service` tags of the Pods that run under it.
+// A ServiceAccount without a Namespace is looked up in the Namespace of TrafficTarget.
+//
+// TrafficTarget that restricts traffic to a port or to routes is rejected, since TrafficPermission
+// would permit all traffic between the services.
+//
+// Besides the resulting TrafficPermission, it returns warnings about parts of TrafficTarget
+// that have no equivalent in Kuma and therefore have been dropped.
+func ConvertTrafficTarget(target *TrafficTarget, mesh string, resolver ServiceTagResolver) (*mesh_core.TrafficPermissionResource, []string, error) {
+	var warnings []string
+	if target.Destination.Kind != ServiceAccountKind {
+		return nil, nil, errors.Errorf("destination of kind %q is not supported, only %q is", target.Destination.Kind, ServiceAccountKind)
+	}
+	// TrafficPermission cannot be narrower than TrafficTarget, since it would permit traffic that TrafficTarget denies
+	if target.Destination.Port != nil {
+		return nil, nil, errors.Errorf("restriction of traffic to port %s is not supported", target.Destination.Port.String())
+	}
+	for _, spec := range target.Specs {
+		if spec.Kind != TCPRouteKind {
+			return nil, nil, errors.Errorf("restriction of traffic to routes of %s %q is not supported, only %s that permits all traffic is", spec.Kind, spec.Name, TCPRouteKind)
+		}
+	}
+	if len(target.Sources) == 0 {
+		return nil, nil, errors.New("there must be at least one source")
+	}
+	for _, source := range target.Sources {
+		if source.Kind != ServiceAccountKind {
+			return nil, nil, errors.Errorf("source of kind %q is not supported, only %q is", source.Kind, ServiceAccountKind)
+		}
+	}
+
+	destinations, err := resolveSubject(target, target.Destination, resolver)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(destinations) == 0 {
+		return nil, nil, errors.Errorf("destination ServiceAccount %q in Namespace %q is not used by any Pod selected by a Service", target.Destination.Name, SubjectNamespace(target, target.Destination))
+	}
+	var sources []*mesh_proto.TrafficPermission_Rule_Selector
+	for _, source := range target.Sources {
+		selectors, err := resolveSubject(target, source, resolver)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(selectors) == 0 {
+			warnings = append(warnings, fmt.Sprintf("source ServiceAccount %q in Namespace %q is not used by any Pod selected by a Service: it has been skipped", source.Name, SubjectNamespace(target, source)))
+		}
+		sources = append(sources, selectors...)
+	}
+	if len(sources) == 0 {
+		return nil, nil, errors.New("none of the source ServiceAccounts is used by a Pod selected by a Service")
+	}
+	return &mesh_core.TrafficPermissionResource{
+		Meta: &policyMeta{
+			Name:      policyName(target.Name, target.Namespace),
+			Namespace: "default",
+			Mesh:      mesh,
+		},
+		Spec: mesh_proto.TrafficPermission{
+			Rules: []*mesh_proto.TrafficPermission_Rule{{
+				Sources:      sources,
+				Destinations: destinations,
+			}},
+		},
+	}, warnings, nil
+}
+
+func resolveSubject(target *TrafficTarget, subject IdentityBindingSubject, resolver ServiceTagResolver) ([]*mesh_proto.TrafficPermission_Rule_Selector, error) {
+	namespace := SubjectNamespace(target, subject)
+	services, err := resolver.ServiceTags(namespace, subject.Name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to resolve ServiceAccount %q in Namespace %q", subject.Name, namespace)
+	}
+	selectors := make([]*mesh_proto.TrafficPermission_Rule_Selector, 0, len(services))
+	for _, service := range services {
+		selectors = append(selectors, &mesh_proto.TrafficPermission_Rule_Selector{
+			Match: map[string]string{mesh_proto.ServiceTag: service},
+		})
+	}
+	return selectors, nil
+}
+
+// SubjectNamespace returns a Namespace of a ServiceAccount referenced by a TrafficTarget.
+// A ServiceAccount without a Namespace is looked up in the Namespace of TrafficTarget.
+func SubjectNamespace(target *TrafficTarget, subject IdentityBindingSubject) string {
+	switch {
+	case subject.Namespace != "":
+		return subject.Namespace
+	case target.Namespace != "":
+		return target.Namespace
+	default:
+		return "default"
+	}
+}
+
+// policyName keeps names of policies, converted from objects in different Namespaces, unique.
+func policyName(name, namespace string) string {
+	if namespace == "" {
+		return name
+	}
+	return fmt.Sprintf("%s.%s", name, namespace)
+}
+
+var _ model.ResourceMeta = &policyMeta{}
+
+type policyMeta struct {
+	Name      string
+	Namespace string
+	Mesh      string
+}
+
+func (m *policyMeta) GetName() string {
+	return m.Name
+}
+
+func (m *policyMeta) GetNamespace() string {
+	return m.Namespace
+}
+
+func (m *policyMeta) GetVersion() string {
+	return ""
+}
+
+func (m *policyMeta) GetMesh() string {
+	return m.Mesh
+}
+
+func (m *policyMeta) GetLabels() map[string]string {
+	return nil
+}
@@ -0,0 +1,34 @@
+// Package smi contains the subset of Service Mesh Interface (SMI) types Kuma understands.
+// +kubebuilder:object:generate=true
+// +groupName=access.smi-spec.io
+package smi
+
+import (
+	kube_meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/scheme"
+)
+
+var (
+	// AccessGroupVersion is a version of SMI Traffic Access Control API supported by Kuma.
+	AccessGroupVersion = schema.GroupVersion{Group: AccessGroup, Version: "v1alpha1"}
+
+	// AccessSchemeBuilder is used to add SMI Traffic Access Control types to a scheme.
+	AccessSchemeBuilder = &scheme.Builder{GroupVersion: AccessGroupVersion}
+
+	// AddToScheme adds SMI types supported by Kuma to a given scheme.
+	AddToScheme = AccessSchemeBuilder.AddToScheme
+)
+
+// +kubebuilder:object:root=true
+
+// TrafficTargetList contains a list of TrafficTarget.
+type TrafficTargetList struct {
+	kube_meta.TypeMeta `json:",inline"`
+	kube_meta.ListMeta `json:"metadata,omitempty"`
+	Items              []TrafficTarget `json:"items"`
+}
+
+func init() {
+	AccessSchemeBuilder.Register(&TrafficTarget{}, &TrafficTargetList{})
+}
@@ -0,0 +1,61 @@
+package smi
+
+import (
+	kube_meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kube_intstr "k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// AccessGroup is an API group of SMI Traffic Access Control.
+	AccessGroup = "access.smi-spec.io"
+	// SplitGroup is an API group of SMI Traffic Split.
+	SplitGroup = "split.smi-spec.io"
+
+	TrafficTargetKind = "TrafficTarget"
+	TrafficSplitKind  = "TrafficSplit"
+	// TCPRouteKind is a kind of TrafficSpec that matches all TCP traffic.
+	TCPRouteKind = "TCPRoute"
+
+	ServiceAccountKind = "ServiceAccount"
+)
+
+// +kubebuilder:object:root=true
+
+// TrafficTarget associates a set of traffic definitions (rules) with a service identity
+// which is allocated to a group of pods.
+//
+// Only the fields that are relevant for conversion into Kuma policies are defined.
+// See https://github.com/servicemeshinterface/smi-spec/blob/master/apis/traffic-access/v1alpha1/traffic-access.md
+type TrafficTarget struct {
+	kube_meta.TypeMeta   `json:",inline"`
+	kube_meta.ObjectMeta `json:"metadata,omitempty"`
+
+	// Destination is the pod or group of pods to allow ingress traffic.
+	Destination IdentityBindingSubject `json:"destination"`
+	// Sources are the pod or group of pods to allow ingress traffic.
+	Sources []IdentityBindingSubject `json:"sources,omitempty"`
+	// Specs are the traffic definitions (rules) to allow.
+	Specs []TrafficTargetSpec `json:"specs,omitempty"`
+}
+
+// IdentityBindingSubject is a Kubernetes objects which should be allowed access to the TrafficTarget.
+type IdentityBindingSubject struct {
+	// Kind is the type of Subject to allow ingress (ServiceAccount | Group).
+	Kind string `json:"kind"`
+	// Name of the Subject, i.e. ServiceAccountName.
+	Name string `json:"name"`
+	// Namespace where the Subject is deployed.
+	Namespace string `json:"namespace,omitempty"`
+	// Port defines a TCP port to apply the TrafficTarget to.
+	Port *kube_intstr.IntOrString `json:"port,omitempty"`
+}
+
+// TrafficTargetSpec is the TrafficSpec to allow for a TrafficTarget.
+type TrafficTargetSpec struct {
+	// Kind is the kind of TrafficSpec to allow.
+	Kind string `json:"kind"`
+	// Name of the TrafficSpec to use.
+	Name string `json:"name"`
+	// Matches is a list of TrafficSpec routes to allow traffic for.
+	Matches []string `json:"matches,omitempty"`
+}
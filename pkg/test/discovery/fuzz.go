@@ -0,0 +1,215 @@
+package discovery
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	core_discovery "github.com/Kong/kuma/pkg/core/discovery"
+	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	test_model "github.com/Kong/kuma/pkg/test/resources/model"
+)
+
+// Event is either an update or a deletion of a Dataplane.
+type Event struct {
+	Update *mesh_core.DataplaneResource
+	Delete core_model.ResourceKey
+}
+
+// Key returns the key of a Dataplane that an event refers to.
+func (e Event) Key() core_model.ResourceKey {
+	if e.Update != nil {
+		return core_model.MetaToResourceKey(e.Update.Meta)
+	}
+	return e.Delete
+}
+
+// State maps Dataplanes that a consumer knows about to their versions.
+type State = map[core_model.ResourceKey]string
+
+// FuzzOpts controls how random sequences of events are generated.
+type FuzzOpts struct {
+	// Dataplanes is the number of distinct Dataplanes that events refer to.
+	Dataplanes int
+	// Events is the number of events before duplicates are added.
+	Events int
+	// DeleteRate is the probability of an event being a deletion.
+	DeleteRate float64
+}
+
+var DefaultFuzzOpts = FuzzOpts{
+	Dataplanes: 5,
+	Events:     50,
+	DeleteRate: 0.3,
+}
+
+// RandomEvents generates a random history of changes of Dataplanes.
+//
+// Events of different Dataplanes are shuffled, while events of the same Dataplane
+// remain in order, since discovery sources deliver changes of a single Dataplane sequentially.
+// Deletions of Dataplanes that do not exist are included on purpose.
+func RandomEvents(rnd *rand.Rand, opts FuzzOpts) []Event {
+	queues := make([][]Event, opts.Dataplanes)
+	for i := 0; i < opts.Events; i++ {
+		idx := rnd.Intn(opts.Dataplanes)
+		key := core_model.ResourceKey{Mesh: "demo", Namespace: "default", Name: fmt.Sprintf("dp-%d", idx)}
+		if rnd.Float64() < opts.DeleteRate {
+			queues[idx] = append(queues[idx], Event{Delete: key})
+			continue
+		}
+		queues[idx] = append(queues[idx], Event{Update: NewDataplane(key, strconv.Itoa(i))})
+	}
+
+	events := make([]Event, 0, opts.Events)
+	for len(events) < opts.Events {
+		idx := rnd.Intn(len(queues))
+		if len(queues[idx]) == 0 {
+			continue
+		}
+		events = append(events, queues[idx][0])
+		queues[idx] = queues[idx][1:]
+	}
+	return events
+}
+
+// ByDataplane groups events by Dataplane they refer to, keeping the relative order of events of every Dataplane.
+//
+// Two sequences of events with equal groups differ at most in how events of different Dataplanes are interleaved.
+func ByDataplane(events []Event) map[core_model.ResourceKey][]Event {
+	groups := map[core_model.ResourceKey][]Event{}
+	for _, event := range events {
+		key := event.Key()
+		groups[key] = append(groups[key], event)
+	}
+	return groups
+}
+
+// VerifyNoRedundantEvents checks that a sequence of events neither repeats a version of a Dataplane
+// that is already known nor deletes a Dataplane that is not known.
+func VerifyNoRedundantEvents(events []Event) error {
+	known := State{}
+	for i, event := range events {
+		key := event.Key()
+		version, exists := known[key]
+		if event.Update == nil {
+			if !exists {
+				return errors.Errorf("event %d deletes Dataplane %v that is not known", i, key)
+			}
+			delete(known, key)
+			continue
+		}
+		if exists && version == event.Update.Meta.GetVersion() {
+			return errors.Errorf("event %d repeats version %q of Dataplane %v", i, version, key)
+		}
+		known[key] = event.Update.Meta.GetVersion()
+	}
+	return nil
+}
+
+// NewDataplane returns a Dataplane whose spec depends on its version.
+func NewDataplane(key core_model.ResourceKey, version string) *mesh_core.DataplaneResource {
+	return &mesh_core.DataplaneResource{
+		Meta: &test_model.ResourceMeta{
+			Mesh:      key.Mesh,
+			Namespace: key.Namespace,
+			Name:      key.Name,
+			Version:   version,
+		},
+		Spec: mesh_proto.Dataplane{
+			Networking: &mesh_proto.Dataplane_Networking{
+				Inbound: []*mesh_proto.Dataplane_Networking_Inbound{{
+					Interface: fmt.Sprintf("192.168.0.1:%s:8080", version),
+					Tags:      map[string]string{"service": key.Name},
+				}},
+			},
+		},
+	}
+}
+
+// Deliver passes events to a consumer one by one.
+func Deliver(consumer core_discovery.DataplaneDiscoveryConsumer, events []Event) error {
+	for _, event := range events {
+		var err error
+		if event.Update != nil {
+			err = consumer.OnDataplaneUpdate(event.Update)
+		} else {
+			err = consumer.OnDataplaneDelete(event.Delete)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var _ core_discovery.DataplaneDiscoveryConsumer = &StateConsumer{}
+
+// StateConsumer keeps track of Dataplanes that it has been told about.
+type StateConsumer struct {
+	mu    sync.RWMutex
+	state State
+}
+
+func (c *StateConsumer) OnDataplaneUpdate(dataplane *mesh_core.DataplaneResource) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.state == nil {
+		c.state = State{}
+	}
+	c.state[core_model.MetaToResourceKey(dataplane.Meta)] = dataplane.Meta.GetVersion()
+	return nil
+}
+
+func (c *StateConsumer) OnDataplaneDelete(key core_model.ResourceKey) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.state, key)
+	return nil
+}
+
+// State returns a copy of the current state.
+func (c *StateConsumer) State() State {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	state := State{}
+	for key, version := range c.state {
+		state[key] = version
+	}
+	return state
+}
+
+var _ core_discovery.DataplaneDiscoveryConsumer = &RecordingConsumer{}
+
+// RecordingConsumer keeps track of events it has received in the order of delivery.
+type RecordingConsumer struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (c *RecordingConsumer) OnDataplaneUpdate(dataplane *mesh_core.DataplaneResource) error {
+	c.record(Event{Update: dataplane})
+	return nil
+}
+
+func (c *RecordingConsumer) OnDataplaneDelete(key core_model.ResourceKey) error {
+	c.record(Event{Delete: key})
+	return nil
+}
+
+func (c *RecordingConsumer) record(event Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.events = append(c.events, event)
+}
+
+// Events returns a copy of events received so far.
+func (c *RecordingConsumer) Events() []Event {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Event{}, c.events...)
+}
@@ -42,7 +42,8 @@ func (t *TrafficRouteResource) SetSpec(spec model.ResourceSpec) error {
 var _ model.ResourceList = &TrafficRouteResourceList{}
 
 type TrafficRouteResourceList struct {
-	Items []*TrafficRouteResource
+	Items      []*TrafficRouteResource
+	Pagination model.Pagination
 }
 
 func (l *TrafficRouteResourceList) GetItems() []model.Resource {
@@ -67,3 +68,7 @@ func (l *TrafficRouteResourceList) AddItem(r model.Resource) error {
 		return model.ErrorInvalidItemType((*TrafficRouteResource)(nil), r)
 	}
 }
+
+func (l *TrafficRouteResourceList) GetPagination() *model.Pagination {
+	return &l.Pagination
+}
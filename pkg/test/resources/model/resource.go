@@ -11,6 +11,7 @@ type ResourceMeta struct {
 	Namespace string
 	Name      string
 	Version   string
+	Labels    map[string]string
 }
 
 func (m *ResourceMeta) GetMesh() string {
@@ -25,3 +26,6 @@ func (m *ResourceMeta) GetName() string {
 func (m *ResourceMeta) GetVersion() string {
 	return m.Version
 }
+func (m *ResourceMeta) GetLabels() map[string]string {
+	return m.Labels
+}
@@ -2,6 +2,8 @@ package runtime
 
 import (
 	builtin_ca "github.com/Kong/kuma/pkg/core/ca/builtin"
+	builtin_issuer "github.com/Kong/kuma/pkg/core/ca/builtin/issuer"
+	provided_ca "github.com/Kong/kuma/pkg/core/ca/provided"
 	mesh_managers "github.com/Kong/kuma/pkg/core/managers/apis/mesh"
 	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
 	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
@@ -13,6 +15,7 @@ import (
 
 	kuma_cp "github.com/Kong/kuma/pkg/config/app/kuma-cp"
 	core_xds "github.com/Kong/kuma/pkg/core/xds"
+	"github.com/Kong/kuma/pkg/metrics"
 	bootstrap_universal "github.com/Kong/kuma/pkg/plugins/bootstrap/universal"
 	resources_memory "github.com/Kong/kuma/pkg/plugins/resources/memory"
 )
@@ -33,9 +36,16 @@ func BuilderFor(cfg kuma_cp.Config) *core_runtime.Builder {
 		WithResourceStore(resources_memory.NewStore()).
 		WithXdsContext(core_xds.NewXdsContext())
 
+	m, err := metrics.NewMetrics()
+	if err != nil {
+		panic(err)
+	}
+	builder.WithMetrics(m)
+
 	builder.
 		WithSecretManager(newSecretManager(builder)).
 		WithBuiltinCaManager(newBuiltinCaManager(builder)).
+		WithProvidedCaManager(newProvidedCaManager()).
 		WithResourceManager(newResourceManager(builder))
 
 	return builder
@@ -51,9 +61,13 @@ func newBuiltinCaManager(builder *core_runtime.Builder) builtin_ca.BuiltinCaMana
 	return builtin_ca.NewBuiltinCaManager(builder.SecretManager())
 }
 
+func newProvidedCaManager() provided_ca.ProvidedCaManager {
+	return provided_ca.NewProvidedCaManager(nil, nil, builtin_issuer.DefaultWorkloadCertValidityPeriod, builtin_issuer.DefaultAllowedClockSkew)
+}
+
 func newResourceManager(builder *core_runtime.Builder) core_manager.ResourceManager {
 	defaultManager := core_manager.NewResourceManager(builder.ResourceStore())
-	meshManager := mesh_managers.NewMeshManager(builder.ResourceStore(), builder.BuiltinCaManager())
+	meshManager := mesh_managers.NewMeshManager(builder.ResourceStore(), builder.SecretManager(), builder.BuiltinCaManager(), builder.ProvidedCaManager())
 	customManagers := map[core_model.ResourceType]core_manager.ResourceManager{
 		core_mesh.MeshType: meshManager,
 	}
@@ -0,0 +1,11 @@
+// +build boringcrypto
+
+package tls
+
+import (
+	// restricts crypto/tls to FIPS-approved settings
+	_ "crypto/tls/fipsonly"
+)
+
+// BoringCrypto tells whether the binary is built with the FIPS-validated BoringCrypto module.
+const BoringCrypto = true
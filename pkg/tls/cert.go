@@ -3,6 +3,8 @@ package tls
 import (
 	"bytes"
 	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
@@ -26,12 +28,76 @@ type KeyPair struct {
 }
 
 func NewSelfSignedCert(commonName string, hosts ...string) (KeyPair, error) {
-	key, err := rsa.GenerateKey(rand.Reader, DefaultRsaBits)
+	return NewSelfSignedCertValidFor(DefaultValidityPeriod, commonName, hosts...)
+}
+
+// NewSelfSignedCertValidFor generates a self-signed certificate that expires after a given period of time.
+func NewSelfSignedCertValidFor(validity time.Duration, commonName string, hosts ...string) (KeyPair, error) {
+	return NewSelfSignedCertWithOptions(commonName, WithValidityPeriod(validity), WithHosts(hosts...))
+}
+
+type certOptions struct {
+	validity time.Duration
+	hosts    []string
+	newKey   func() (crypto.Signer, error)
+}
+
+type CertOption func(*certOptions)
+
+// WithValidityPeriod makes a certificate expire after a given period of time.
+func WithValidityPeriod(validity time.Duration) CertOption {
+	return func(opts *certOptions) {
+		opts.validity = validity
+	}
+}
+
+// WithHosts adds DNS names or IP addresses to Subject Alternative Names of a certificate.
+func WithHosts(hosts ...string) CertOption {
+	return func(opts *certOptions) {
+		opts.hosts = append(opts.hosts, hosts...)
+	}
+}
+
+// WithRSAKey makes a certificate use an RSA key of a given size, e.g. 2048 or 4096 bits.
+func WithRSAKey(bits int) CertOption {
+	return func(opts *certOptions) {
+		opts.newKey = func() (crypto.Signer, error) {
+			if bits < 2048 {
+				return nil, errors.Errorf("RSA key must be at least 2048 bits, got %d", bits)
+			}
+			return rsa.GenerateKey(rand.Reader, bits)
+		}
+	}
+}
+
+// WithECDSAKey makes a certificate use an ECDSA key on a given curve, e.g. elliptic.P256().
+func WithECDSAKey(curve elliptic.Curve) CertOption {
+	return func(opts *certOptions) {
+		opts.newKey = func() (crypto.Signer, error) {
+			return ecdsa.GenerateKey(curve, rand.Reader)
+		}
+	}
+}
+
+// NewSelfSignedCertWithOptions generates a self-signed certificate.
+// Unless stated otherwise, the certificate uses an RSA key of DefaultRsaBits and expires after DefaultValidityPeriod.
+func NewSelfSignedCertWithOptions(commonName string, opts ...CertOption) (KeyPair, error) {
+	options := certOptions{
+		validity: DefaultValidityPeriod,
+		newKey: func() (crypto.Signer, error) {
+			return rsa.GenerateKey(rand.Reader, DefaultRsaBits)
+		},
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	key, err := options.newKey()
 	if err != nil {
 		return KeyPair{}, errors.Wrap(err, "failed to generate TLS key")
 	}
 
-	certBytes, err := generateCert(key, commonName, hosts...)
+	certBytes, err := generateCert(key, options.validity, commonName, options.hosts...)
 	if err != nil {
 		return KeyPair{}, err
 	}
@@ -47,11 +113,15 @@ func NewSelfSignedCert(commonName string, hosts ...string) (KeyPair, error) {
 	}, nil
 }
 
-func generateCert(signer crypto.Signer, commonName string, hosts ...string) ([]byte, error) {
-	csr, err := newCert(commonName, hosts...)
+func generateCert(signer crypto.Signer, validity time.Duration, commonName string, hosts ...string) ([]byte, error) {
+	csr, err := newCert(validity, commonName, hosts...)
 	if err != nil {
 		return nil, err
 	}
+	if _, ok := signer.(*rsa.PrivateKey); !ok {
+		// key encipherment only applies to RSA keys
+		csr.KeyUsage &^= x509.KeyUsageKeyEncipherment
+	}
 	certDerBytes, err := x509.CreateCertificate(rand.Reader, &csr, &csr, signer.Public(), signer)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to generate TLS certificate")
@@ -63,9 +133,9 @@ func generateCert(signer crypto.Signer, commonName string, hosts ...string) ([]b
 	return certBuf.Bytes(), nil
 }
 
-func newCert(commonName string, hosts ...string) (x509.Certificate, error) {
+func newCert(validity time.Duration, commonName string, hosts ...string) (x509.Certificate, error) {
 	notBefore := time.Now()
-	notAfter := notBefore.Add(DefaultValidityPeriod)
+	notAfter := notBefore.Add(validity)
 	serialNumberLimit := new(big.Int).Lsh(big.NewInt(1), 128)
 	serialNumber, err := rand.Int(rand.Reader, serialNumberLimit)
 	if err != nil {
@@ -98,6 +168,12 @@ func marshalKey(priv interface{}) ([]byte, error) {
 	switch k := priv.(type) {
 	case *rsa.PrivateKey:
 		block = &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(k)}
+	case *ecdsa.PrivateKey:
+		der, err := x509.MarshalECPrivateKey(k)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal ECDSA key")
+		}
+		block = &pem.Block{Type: "EC PRIVATE KEY", Bytes: der}
 	default:
 		return nil, errors.Errorf("unsupported private key type %T", priv)
 	}
@@ -0,0 +1,92 @@
+package tls_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	gotls "crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kong/kuma/pkg/tls"
+)
+
+var _ = Describe("NewSelfSignedCertWithOptions", func() {
+
+	parseCert := func(certPEM []byte) *x509.Certificate {
+		block, _ := pem.Decode(certPEM)
+		Expect(block).ToNot(BeNil())
+		cert, err := x509.ParseCertificate(block.Bytes)
+		Expect(err).ToNot(HaveOccurred())
+		return cert
+	}
+
+	It("should use an RSA key of the default size by default", func() {
+		// when
+		pair, err := tls.NewSelfSignedCertWithOptions("kuma")
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		key, ok := parseCert(pair.CertPEM).PublicKey.(*rsa.PublicKey)
+		Expect(ok).To(BeTrue())
+		Expect(key.N.BitLen()).To(Equal(tls.DefaultRsaBits))
+	})
+
+	It("should use an RSA key of a given size", func() {
+		// when
+		pair, err := tls.NewSelfSignedCertWithOptions("kuma", tls.WithRSAKey(4096))
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		key, ok := parseCert(pair.CertPEM).PublicKey.(*rsa.PublicKey)
+		Expect(ok).To(BeTrue())
+		Expect(key.N.BitLen()).To(Equal(4096))
+		// and
+		_, err = gotls.X509KeyPair(pair.CertPEM, pair.KeyPEM)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should use an ECDSA key on a given curve", func() {
+		// when
+		pair, err := tls.NewSelfSignedCertWithOptions("kuma", tls.WithECDSAKey(elliptic.P384()))
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		cert := parseCert(pair.CertPEM)
+		key, ok := cert.PublicKey.(*ecdsa.PublicKey)
+		Expect(ok).To(BeTrue())
+		Expect(key.Curve).To(Equal(elliptic.P384()))
+		Expect(cert.KeyUsage & x509.KeyUsageKeyEncipherment).To(BeZero())
+		// and
+		_, err = gotls.X509KeyPair(pair.CertPEM, pair.KeyPEM)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should refuse RSA keys that are too small", func() {
+		// when
+		_, err := tls.NewSelfSignedCertWithOptions("kuma", tls.WithRSAKey(1024))
+
+		// then
+		Expect(err).To(MatchError("failed to generate TLS key: RSA key must be at least 2048 bits, got 1024"))
+	})
+
+	It("should set validity period and Subject Alternative Names", func() {
+		// when
+		pair, err := tls.NewSelfSignedCertWithOptions("kuma",
+			tls.WithValidityPeriod(time.Hour),
+			tls.WithHosts("kuma-control-plane.kuma-system", "10.0.0.1"),
+		)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		cert := parseCert(pair.CertPEM)
+		Expect(cert.NotAfter.Sub(cert.NotBefore)).To(Equal(time.Hour))
+		Expect(cert.DNSNames).To(Equal([]string{"kuma-control-plane.kuma-system"}))
+		Expect(cert.IPAddresses).To(HaveLen(1))
+		Expect(cert.IPAddresses[0].String()).To(Equal("10.0.0.1"))
+	})
+})
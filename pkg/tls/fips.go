@@ -0,0 +1,103 @@
+package tls
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+
+	"github.com/pkg/errors"
+)
+
+// FIPSTlsMinVersion is the lowest TLS version approved by FIPS 140-2.
+const FIPSTlsMinVersion = "TLSv1_2"
+
+// fipsCipherSuites lists cipher suites approved by FIPS 140-2, in order of preference.
+var fipsCipherSuites = []string{
+	"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+	"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
+	"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+	"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+	"TLS_RSA_WITH_AES_128_GCM_SHA256",
+	"TLS_RSA_WITH_AES_256_GCM_SHA384",
+}
+
+// FIPSCipherSuites returns IANA names of cipher suites approved by FIPS 140-2.
+func FIPSCipherSuites() []string {
+	return append([]string{}, fipsCipherSuites...)
+}
+
+// ValidateFIPS returns an error if a given TLS version or cipher suites are not approved by FIPS 140-2.
+//
+// Empty values are refused, since defaults of Go and Envoy allow algorithms that are not approved.
+func ValidateFIPS(minVersion string, cipherSuites []string) error {
+	version, err := ParseTlsVersion(minVersion)
+	if err != nil {
+		return err
+	}
+	if version < tlsVersions[FIPSTlsMinVersion] {
+		return errors.Errorf("TLS version %q is not FIPS-approved, must be at least %s", minVersion, FIPSTlsMinVersion)
+	}
+	if len(cipherSuites) == 0 {
+		return errors.New("cipher suites must be set explicitly in FIPS mode")
+	}
+	for _, name := range cipherSuites {
+		if !isFIPSCipherSuite(name) {
+			return errors.Errorf("cipher suite %q is not FIPS-approved, must be one of %v", name, fipsCipherSuites)
+		}
+	}
+	return nil
+}
+
+func isFIPSCipherSuite(name string) bool {
+	for _, suite := range fipsCipherSuites {
+		if suite == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateFIPSPublicKey returns an error if a given key is not RSA of at least 2048 bits or ECDSA on P-256, P-384 or P-521.
+func ValidateFIPSPublicKey(key crypto.PublicKey) error {
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		if bits := key.N.BitLen(); bits < 2048 {
+			return errors.Errorf("RSA key of %d bits is not FIPS-approved, must be at least 2048 bits", bits)
+		}
+		return nil
+	case *ecdsa.PublicKey:
+		if key.Curve != elliptic.P256() && key.Curve != elliptic.P384() && key.Curve != elliptic.P521() {
+			return errors.Errorf("ECDSA key on curve %s is not FIPS-approved, must be one of P-256, P-384, P-521", key.Curve.Params().Name)
+		}
+		return nil
+	default:
+		return errors.Errorf("key of type %T is not FIPS-approved", key)
+	}
+}
+
+// ValidateFIPSCert returns an error if any certificate of a given PEM chain has a key that is not approved by FIPS 140-2.
+func ValidateFIPSCert(certPEM []byte) error {
+	found := false
+	for {
+		var block *pem.Block
+		block, certPEM = pem.Decode(certPEM)
+		if block == nil {
+			break
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse a certificate")
+		}
+		if err := ValidateFIPSPublicKey(cert.PublicKey); err != nil {
+			return errors.Wrapf(err, "certificate %q", cert.Subject.CommonName)
+		}
+		found = true
+	}
+	if !found {
+		return errors.New("no certificate found in PEM data")
+	}
+	return nil
+}
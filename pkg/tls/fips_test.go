@@ -0,0 +1,58 @@
+package tls_test
+
+import (
+	"crypto/elliptic"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kong/kuma/pkg/tls"
+)
+
+var _ = Describe("FIPS", func() {
+
+	It("should accept FIPS-approved TLS settings", func() {
+		// when
+		err := tls.ValidateFIPS("TLSv1_3", tls.FIPSCipherSuites())
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should refuse TLS settings that are not FIPS-approved", func() {
+		// when
+		err := tls.ValidateFIPS("TLSv1_1", tls.FIPSCipherSuites())
+
+		// then
+		Expect(err).To(MatchError(`TLS version "TLSv1_1" is not FIPS-approved, must be at least TLSv1_2`))
+
+		// when
+		err = tls.ValidateFIPS("TLSv1_2", []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305"})
+
+		// then
+		Expect(err).To(MatchError(HavePrefix(`cipher suite "TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305" is not FIPS-approved`)))
+
+		// when
+		err = tls.ValidateFIPS("TLSv1_2", nil)
+
+		// then
+		Expect(err).To(MatchError("cipher suites must be set explicitly in FIPS mode"))
+	})
+
+	It("should validate keys of certificates", func() {
+		// given
+		rsaCert, err := tls.NewSelfSignedCert("kuma-sds")
+		Expect(err).ToNot(HaveOccurred())
+
+		// expect
+		Expect(tls.ValidateFIPSCert(rsaCert.CertPEM)).To(Succeed())
+
+		// given
+		ecdsaCert, err := tls.NewSelfSignedCertWithOptions("kuma-sds", tls.WithECDSAKey(elliptic.P224()))
+		Expect(err).ToNot(HaveOccurred())
+
+		// expect
+		Expect(tls.ValidateFIPSCert(ecdsaCert.CertPEM)).To(MatchError(`certificate "kuma-sds": ECDSA key on curve P-224 is not FIPS-approved, must be one of P-256, P-384, P-521`))
+		Expect(tls.ValidateFIPSCert([]byte("not a certificate"))).To(MatchError("no certificate found in PEM data"))
+	})
+})
@@ -0,0 +1,6 @@
+// +build !boringcrypto
+
+package tls
+
+// BoringCrypto tells whether the binary is built with the FIPS-validated BoringCrypto module.
+const BoringCrypto = false
@@ -0,0 +1,95 @@
+package tls
+
+import (
+	"crypto/tls"
+
+	"github.com/pkg/errors"
+)
+
+// tlsVersions maps names of TLS versions, as used by Envoy, to their identifiers.
+var tlsVersions = map[string]uint16{
+	"TLSv1_0": tls.VersionTLS10,
+	"TLSv1_1": tls.VersionTLS11,
+	"TLSv1_2": tls.VersionTLS12,
+	"TLSv1_3": tls.VersionTLS13,
+}
+
+type cipherSuite struct {
+	id uint16
+	// name of the cipher suite in OpenSSL format, as expected by Envoy
+	openSSLName string
+}
+
+// cipherSuites maps IANA names of cipher suites to their identifiers.
+//
+// Only cipher suites that both Go and Envoy support are listed.
+// Cipher suites of TLS 1.3 cannot be configured.
+var cipherSuites = map[string]cipherSuite{
+	"TLS_RSA_WITH_AES_128_CBC_SHA":            {tls.TLS_RSA_WITH_AES_128_CBC_SHA, "AES128-SHA"},
+	"TLS_RSA_WITH_AES_256_CBC_SHA":            {tls.TLS_RSA_WITH_AES_256_CBC_SHA, "AES256-SHA"},
+	"TLS_RSA_WITH_AES_128_GCM_SHA256":         {tls.TLS_RSA_WITH_AES_128_GCM_SHA256, "AES128-GCM-SHA256"},
+	"TLS_RSA_WITH_AES_256_GCM_SHA384":         {tls.TLS_RSA_WITH_AES_256_GCM_SHA384, "AES256-GCM-SHA384"},
+	"TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA":    {tls.TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA, "ECDHE-ECDSA-AES128-SHA"},
+	"TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA":    {tls.TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA, "ECDHE-ECDSA-AES256-SHA"},
+	"TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA":      {tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA, "ECDHE-RSA-AES128-SHA"},
+	"TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA":      {tls.TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA, "ECDHE-RSA-AES256-SHA"},
+	"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256": {tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256, "ECDHE-ECDSA-AES128-GCM-SHA256"},
+	"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256":   {tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, "ECDHE-RSA-AES128-GCM-SHA256"},
+	"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384": {tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384, "ECDHE-ECDSA-AES256-GCM-SHA384"},
+	"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384":   {tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384, "ECDHE-RSA-AES256-GCM-SHA384"},
+	"TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305":  {tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305, "ECDHE-ECDSA-CHACHA20-POLY1305"},
+	"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305":    {tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305, "ECDHE-RSA-CHACHA20-POLY1305"},
+}
+
+// ParseTlsVersion returns an identifier of a TLS version of a given name, e.g. TLSv1_2.
+func ParseTlsVersion(name string) (uint16, error) {
+	version, ok := tlsVersions[name]
+	if !ok {
+		return 0, errors.Errorf("unsupported TLS version %q, must be one of TLSv1_0, TLSv1_1, TLSv1_2, TLSv1_3", name)
+	}
+	return version, nil
+}
+
+// ParseCipherSuites returns identifiers of cipher suites of given IANA names, e.g. TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256.
+func ParseCipherSuites(names []string) ([]uint16, error) {
+	var ids []uint16
+	for _, name := range names {
+		suite, ok := cipherSuites[name]
+		if !ok {
+			return nil, errors.Errorf("unsupported cipher suite %q", name)
+		}
+		ids = append(ids, suite.id)
+	}
+	return ids, nil
+}
+
+// ApplyParams restricts a TLS configuration to a given minimum TLS version and cipher suites.
+// Go's defaults are kept for settings that are empty.
+func ApplyParams(tlsConfig *tls.Config, minVersion string, cipherSuites []string) error {
+	if minVersion != "" {
+		version, err := ParseTlsVersion(minVersion)
+		if err != nil {
+			return err
+		}
+		tlsConfig.MinVersion = version
+	}
+	ids, err := ParseCipherSuites(cipherSuites)
+	if err != nil {
+		return err
+	}
+	tlsConfig.CipherSuites = ids
+	return nil
+}
+
+// OpenSSLCipherSuiteNames converts IANA names of cipher suites to OpenSSL format, e.g. ECDHE-RSA-AES128-GCM-SHA256.
+func OpenSSLCipherSuiteNames(names []string) ([]string, error) {
+	var openSSLNames []string
+	for _, name := range names {
+		suite, ok := cipherSuites[name]
+		if !ok {
+			return nil, errors.Errorf("unsupported cipher suite %q", name)
+		}
+		openSSLNames = append(openSSLNames, suite.openSSLName)
+	}
+	return openSSLNames, nil
+}
@@ -0,0 +1,68 @@
+package tls_test
+
+import (
+	gotls "crypto/tls"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kong/kuma/pkg/tls"
+)
+
+var _ = Describe("TLS parameters", func() {
+
+	It("should parse TLS versions", func() {
+		// when
+		version, err := tls.ParseTlsVersion("TLSv1_2")
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(version).To(Equal(uint16(gotls.VersionTLS12)))
+
+		// when
+		_, err = tls.ParseTlsVersion("SSLv3")
+
+		// then
+		Expect(err).To(MatchError(`unsupported TLS version "SSLv3", must be one of TLSv1_0, TLSv1_1, TLSv1_2, TLSv1_3`))
+	})
+
+	It("should convert cipher suites", func() {
+		// given
+		names := []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305"}
+
+		// when
+		ids, err := tls.ParseCipherSuites(names)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ids).To(Equal([]uint16{gotls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, gotls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305}))
+
+		// when
+		openSSLNames, err := tls.OpenSSLCipherSuiteNames(names)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(openSSLNames).To(Equal([]string{"ECDHE-RSA-AES128-GCM-SHA256", "ECDHE-ECDSA-CHACHA20-POLY1305"}))
+	})
+
+	It("should refuse unknown cipher suites", func() {
+		// when
+		_, err := tls.ParseCipherSuites([]string{"TLS_RSA_WITH_RC4_128_SHA"})
+
+		// then
+		Expect(err).To(MatchError(`unsupported cipher suite "TLS_RSA_WITH_RC4_128_SHA"`))
+	})
+
+	It("should restrict a TLS configuration", func() {
+		// given
+		cfg := &gotls.Config{}
+
+		// when
+		err := tls.ApplyParams(cfg, "TLSv1_2", []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"})
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cfg.MinVersion).To(Equal(uint16(gotls.VersionTLS12)))
+		Expect(cfg.CipherSuites).To(Equal([]uint16{gotls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256}))
+	})
+})
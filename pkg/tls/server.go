@@ -0,0 +1,117 @@
+package tls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ServerConfig returns TLS configuration of a server that presents a certificate loaded from given files.
+// The certificate is loaded again once the files change, so that a renewed certificate is presented without a restart.
+//
+// Connections are restricted to a given minimum TLS version and cipher suites, see ApplyParams.
+// If clientCaFile is not empty, clients have to present a certificate signed by one of the CA certs
+// from that file, i.e. mutual TLS is enforced.
+func ServerConfig(certFile, keyFile, clientCaFile, minVersion string, cipherSuites []string) (*tls.Config, error) {
+	reloader, err := NewKeyPairReloader(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{
+		GetCertificate: reloader.GetCertificate,
+	}
+	if err := ApplyParams(tlsConfig, minVersion, cipherSuites); err != nil {
+		return nil, err
+	}
+	if clientCaFile != "" {
+		pool, err := LoadCertPool(clientCaFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tlsConfig, nil
+}
+
+// KeyPairReloader presents a certificate loaded from given files and loads it again
+// on the next handshake after either of the files has changed.
+type KeyPairReloader struct {
+	certFile string
+	keyFile  string
+
+	mu      sync.Mutex // protects access to the fields below
+	cert    *tls.Certificate
+	modTime time.Time
+}
+
+func NewKeyPairReloader(certFile, keyFile string) (*KeyPairReloader, error) {
+	r := &KeyPairReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+	}
+	modTime, err := r.lastModified()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load TLS certificate")
+	}
+	if err := r.load(modTime); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetCertificate is meant to be used as tls.Config.GetCertificate.
+//
+// If a changed certificate cannot be loaded, e.g. because only one of the files has been replaced so far,
+// the previous certificate is presented and loading is retried on the next handshake.
+func (r *KeyPairReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if modTime, err := r.lastModified(); err == nil && !modTime.Equal(r.modTime) {
+		_ = r.load(modTime)
+	}
+	return r.cert, nil
+}
+
+func (r *KeyPairReloader) load(modTime time.Time) error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to load TLS certificate")
+	}
+	r.cert = &cert
+	r.modTime = modTime
+	return nil
+}
+
+// lastModified returns the time either of the files has been modified at last.
+func (r *KeyPairReloader) lastModified() (time.Time, error) {
+	var modTime time.Time
+	for _, file := range []string{r.certFile, r.keyFile} {
+		info, err := os.Stat(file)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if info.ModTime().After(modTime) {
+			modTime = info.ModTime()
+		}
+	}
+	return modTime, nil
+}
+
+// LoadCertPool returns a pool of PEM-encoded certificates from a given file.
+func LoadCertPool(file string) (*x509.CertPool, error) {
+	certsPEM, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read CA certs from %q", file)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(certsPEM) {
+		return nil, errors.Errorf("file %q does not contain any PEM-encoded certificate", file)
+	}
+	return pool, nil
+}
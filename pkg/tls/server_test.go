@@ -0,0 +1,142 @@
+package tls_test
+
+import (
+	gotls "crypto/tls"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kong/kuma/pkg/tls"
+)
+
+var _ = Describe("ServerConfig", func() {
+
+	var dir string
+	var certFile, keyFile string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "kuma-tls")
+		Expect(err).ToNot(HaveOccurred())
+
+		pair, err := tls.NewSelfSignedCert("kuma-server", "localhost")
+		Expect(err).ToNot(HaveOccurred())
+		certFile = filepath.Join(dir, "tls.crt")
+		keyFile = filepath.Join(dir, "tls.key")
+		Expect(ioutil.WriteFile(certFile, pair.CertPEM, 0600)).To(Succeed())
+		Expect(ioutil.WriteFile(keyFile, pair.KeyPEM, 0600)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	It("should not require client certificates without client CA", func() {
+		// when
+		cfg, err := tls.ServerConfig(certFile, keyFile, "", "", nil)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cfg.GetCertificate).ToNot(BeNil())
+		Expect(cfg.ClientAuth).To(Equal(gotls.NoClientCert))
+		Expect(cfg.ClientCAs).To(BeNil())
+	})
+
+	It("should require client certificates signed by client CA", func() {
+		// given
+		ca, err := tls.NewSelfSignedCert("client-ca")
+		Expect(err).ToNot(HaveOccurred())
+		caFile := filepath.Join(dir, "ca.crt")
+		Expect(ioutil.WriteFile(caFile, ca.CertPEM, 0600)).To(Succeed())
+
+		// when
+		cfg, err := tls.ServerConfig(certFile, keyFile, caFile, "", nil)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cfg.ClientAuth).To(Equal(gotls.RequireAndVerifyClientCert))
+		Expect(cfg.ClientCAs).ToNot(BeNil())
+	})
+
+	It("should restrict TLS version and cipher suites", func() {
+		// when
+		cfg, err := tls.ServerConfig(certFile, keyFile, "", "TLSv1_2", []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"})
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cfg.MinVersion).To(Equal(uint16(gotls.VersionTLS12)))
+		Expect(cfg.CipherSuites).To(Equal([]uint16{gotls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256}))
+	})
+
+	It("should refuse an unsupported TLS version", func() {
+		// when
+		_, err := tls.ServerConfig(certFile, keyFile, "", "TLSv9", nil)
+
+		// then
+		Expect(err).To(MatchError(`unsupported TLS version "TLSv9", must be one of TLSv1_0, TLSv1_1, TLSv1_2, TLSv1_3`))
+	})
+
+	It("should refuse a client CA file without certificates", func() {
+		// given
+		caFile := filepath.Join(dir, "ca.crt")
+		Expect(ioutil.WriteFile(caFile, []byte("not a cert"), 0600)).To(Succeed())
+
+		// when
+		_, err := tls.ServerConfig(certFile, keyFile, caFile, "", nil)
+
+		// then
+		Expect(err).To(MatchError(`file "` + caFile + `" does not contain any PEM-encoded certificate`))
+	})
+
+	It("should refuse a missing certificate", func() {
+		// when
+		_, err := tls.ServerConfig(filepath.Join(dir, "missing.crt"), keyFile, "", "", nil)
+
+		// then
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(HavePrefix("failed to load TLS certificate"))
+	})
+
+	It("should present a renewed certificate once the files change", func() {
+		// given
+		cfg, err := tls.ServerConfig(certFile, keyFile, "", "", nil)
+		Expect(err).ToNot(HaveOccurred())
+		before, err := cfg.GetCertificate(nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		renewed, err := tls.NewSelfSignedCert("kuma-server", "localhost")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ioutil.WriteFile(certFile, renewed.CertPEM, 0600)).To(Succeed())
+		Expect(ioutil.WriteFile(keyFile, renewed.KeyPEM, 0600)).To(Succeed())
+		later := time.Now().Add(time.Minute)
+		Expect(os.Chtimes(certFile, later, later)).To(Succeed())
+
+		// then
+		after, err := cfg.GetCertificate(nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(after.Certificate[0]).ToNot(Equal(before.Certificate[0]))
+	})
+
+	It("should keep presenting the previous certificate if the changed one cannot be loaded", func() {
+		// given
+		cfg, err := tls.ServerConfig(certFile, keyFile, "", "", nil)
+		Expect(err).ToNot(HaveOccurred())
+		before, err := cfg.GetCertificate(nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		Expect(ioutil.WriteFile(certFile, []byte("not a cert"), 0600)).To(Succeed())
+		later := time.Now().Add(time.Minute)
+		Expect(os.Chtimes(certFile, later, later)).To(Succeed())
+
+		// then
+		after, err := cfg.GetCertificate(nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(after).To(Equal(before))
+	})
+})
@@ -0,0 +1,31 @@
+package issuer
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	core_errors "github.com/Kong/kuma/pkg/core/errors"
+)
+
+// Authenticate validates a token presented by a Dataplane and makes sure
+// that it has been issued for the same mesh and name as the Dataplane.
+//
+// It is shared by all components that let Dataplanes in with a Dataplane token,
+// so that a Dataplane is authenticated the same way everywhere.
+func Authenticate(ctx context.Context, tokenIssuer DataplaneTokenIssuer, token Token, mesh string, name string) error {
+	if token == "" {
+		return core_errors.WithCode(errors.New("authentication failed: dataplane has not presented a token"), core_errors.TokenMissing)
+	}
+	identity, err := tokenIssuer.Validate(ctx, token)
+	if err != nil {
+		return errors.Wrap(err, "authentication failed")
+	}
+	if identity.Mesh != mesh {
+		return errors.Errorf("authentication failed: token belongs to a mesh (%q) different from Dataplane (%q)", identity.Mesh, mesh)
+	}
+	if identity.Name != name {
+		return errors.Errorf("authentication failed: token belongs to a dataplane (%q) different from Dataplane (%q)", identity.Name, name)
+	}
+	return nil
+}
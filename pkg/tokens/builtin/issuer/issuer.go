@@ -0,0 +1,164 @@
+package issuer
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/Kong/kuma/pkg/core"
+	core_errors "github.com/Kong/kuma/pkg/core/errors"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+)
+
+// DataplaneIdentity is an identity that a Dataplane token is issued for.
+type DataplaneIdentity struct {
+	Mesh string
+	Name string
+}
+
+// DataplaneTokenIssuer issues Dataplane tokens signed with the active signing key of a Mesh
+// and validates them against all signing keys that are still accepted.
+type DataplaneTokenIssuer interface {
+	// Generate issues a token. Zero validFor means that the token never expires.
+	Generate(ctx context.Context, identity DataplaneIdentity, validFor time.Duration) (Token, error)
+	Validate(ctx context.Context, token Token) (DataplaneIdentity, error)
+	// Revoke makes a token of a given Mesh rejected even though its signing key is still accepted.
+	Revoke(ctx context.Context, mesh string, token Token) error
+}
+
+func NewDataplaneTokenIssuer(keyManager SigningKeyManager) DataplaneTokenIssuer {
+	return NewDataplaneTokenIssuerWithAllowedClockSkew(keyManager, 0)
+}
+
+// NewDataplaneTokenIssuerWithAllowedClockSkew returns an issuer that accepts tokens that expired
+// or have been issued in the future by no more than allowedClockSkew, since clocks of machines drift apart.
+func NewDataplaneTokenIssuerWithAllowedClockSkew(keyManager SigningKeyManager, allowedClockSkew time.Duration) DataplaneTokenIssuer {
+	return &dataplaneTokenIssuer{
+		keyManager:       keyManager,
+		allowedClockSkew: allowedClockSkew,
+	}
+}
+
+var _ DataplaneTokenIssuer = &dataplaneTokenIssuer{}
+
+type dataplaneTokenIssuer struct {
+	keyManager       SigningKeyManager
+	allowedClockSkew time.Duration
+}
+
+func (i *dataplaneTokenIssuer) Generate(ctx context.Context, identity DataplaneIdentity, validFor time.Duration) (Token, error) {
+	signingKey, err := i.activeSigningKey(ctx, identity.Mesh)
+	if err != nil {
+		return "", err
+	}
+	privateKey, err := signingKey.PrivateKey()
+	if err != nil {
+		return "", err
+	}
+	now := core.Now()
+	claims := tokenClaims{
+		ID:       core.NewUUID(),
+		Mesh:     identity.Mesh,
+		Name:     identity.Name,
+		IssuedAt: now.Unix(),
+	}
+	if validFor != 0 {
+		claims.ExpiresAt = now.Add(validFor).Unix()
+	}
+	return signToken(privateKey, signingKey.ID, claims)
+}
+
+// activeSigningKey returns the key that new tokens are signed with, generating one if there is none.
+func (i *dataplaneTokenIssuer) activeSigningKey(ctx context.Context, mesh string) (*SigningKey, error) {
+	active, err := i.getActiveSigningKey(ctx, mesh)
+	if err != nil || active != nil {
+		return active, err
+	}
+	signingKey, err := i.keyManager.RotateSigningKey(ctx, mesh, DefaultSigningKeyGracePeriod)
+	if err == nil {
+		return signingKey, nil
+	}
+	// tokens of a Mesh are issued concurrently for the first time, e.g. by several instances of Control Plane,
+	// and the key that another request has generated in the meantime is just as good
+	if core_store.IsResourceAlreadyExists(errors.Cause(err)) {
+		if active, err := i.getActiveSigningKey(ctx, mesh); err != nil || active != nil {
+			return active, err
+		}
+	}
+	return nil, errors.Wrapf(err, "could not generate a signing key for Mesh %q", mesh)
+}
+
+// getActiveSigningKey returns nil if a Mesh has no active signing key.
+func (i *dataplaneTokenIssuer) getActiveSigningKey(ctx context.Context, mesh string) (*SigningKey, error) {
+	signingKeys, err := i.keyManager.GetSigningKeys(ctx, mesh)
+	if err != nil {
+		if core_store.IsResourceNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "could not retrieve signing keys of Mesh %q", mesh)
+	}
+	return signingKeys.Active(), nil
+}
+
+func (i *dataplaneTokenIssuer) Validate(ctx context.Context, token Token) (DataplaneIdentity, error) {
+	parsed, err := parseToken(token)
+	if err != nil {
+		return DataplaneIdentity{}, core_errors.WithCode(errors.Wrap(err, "token is malformed"), core_errors.TokenMalformed)
+	}
+	mesh := parsed.claims.Mesh
+	signingKeys, err := i.keyManager.GetSigningKeys(ctx, mesh)
+	if err != nil {
+		if core_store.IsResourceNotFound(err) {
+			return DataplaneIdentity{}, core_errors.WithCode(errors.Errorf("there are no signing keys in Mesh %q", mesh), core_errors.TokenInvalid)
+		}
+		return DataplaneIdentity{}, errors.Wrapf(err, "could not retrieve signing keys of Mesh %q", mesh)
+	}
+	now := core.Now()
+	signingKey := signingKeys.Get(parsed.header.KeyID)
+	if signingKey == nil {
+		return DataplaneIdentity{}, core_errors.WithCode(errors.Errorf("token is signed with a key %q that is unknown or has been revoked", parsed.header.KeyID), core_errors.TokenInvalid)
+	}
+	if signingKey.IsExpired(now) {
+		return DataplaneIdentity{}, core_errors.WithCode(errors.Errorf("token is signed with a key %q that has been rotated and its grace period is over", parsed.header.KeyID), core_errors.TokenInvalid)
+	}
+	privateKey, err := signingKey.PrivateKey()
+	if err != nil {
+		return DataplaneIdentity{}, err
+	}
+	if err := parsed.verify(&privateKey.PublicKey); err != nil {
+		return DataplaneIdentity{}, core_errors.WithCode(errors.New("token signature is invalid"), core_errors.TokenInvalid)
+	}
+	if parsed.claims.ExpiresAt != 0 && !now.Before(time.Unix(parsed.claims.ExpiresAt, 0).Add(i.allowedClockSkew)) {
+		return DataplaneIdentity{}, core_errors.WithCode(errors.New("token has expired"), core_errors.TokenExpired)
+	}
+	if now.Add(i.allowedClockSkew).Before(time.Unix(parsed.claims.IssuedAt, 0)) {
+		return DataplaneIdentity{}, core_errors.WithCode(errors.New("token is issued in the future"), core_errors.TokenInvalid)
+	}
+	if signingKeys.IsRevoked(parsed.claims.ID) {
+		return DataplaneIdentity{}, core_errors.WithCode(errors.Errorf("token %q has been revoked", parsed.claims.ID), core_errors.TokenRevoked)
+	}
+	return DataplaneIdentity{
+		Mesh: mesh,
+		Name: parsed.claims.Name,
+	}, nil
+}
+
+func (i *dataplaneTokenIssuer) Revoke(ctx context.Context, mesh string, token Token) error {
+	parsed, err := parseToken(token)
+	if err != nil {
+		return core_errors.WithCode(errors.Wrap(err, "token is malformed"), core_errors.TokenMalformed)
+	}
+	if parsed.claims.Mesh != mesh {
+		return core_errors.WithCode(errors.Errorf("token belongs to a Mesh %q different from %q", parsed.claims.Mesh, mesh), core_errors.InvalidRequest)
+	}
+	revoked := RevokedToken{
+		ID: parsed.claims.ID,
+	}
+	if parsed.claims.ExpiresAt != 0 {
+		// the token is accepted until it expires plus the allowed clock skew, so it has to be remembered until then
+		expiresAt := time.Unix(parsed.claims.ExpiresAt, 0).Add(i.allowedClockSkew)
+		revoked.ExpiresAt = &expiresAt
+	}
+	return i.keyManager.RevokeToken(ctx, mesh, revoked)
+}
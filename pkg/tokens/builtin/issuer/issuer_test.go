@@ -0,0 +1,284 @@
+package issuer_test
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kong/kuma/pkg/core"
+	core_errors "github.com/Kong/kuma/pkg/core/errors"
+	"github.com/Kong/kuma/pkg/core/resources/apis/system"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	secret_cipher "github.com/Kong/kuma/pkg/core/secrets/cipher"
+	secret_manager "github.com/Kong/kuma/pkg/core/secrets/manager"
+	secret_store "github.com/Kong/kuma/pkg/core/secrets/store"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+	"github.com/Kong/kuma/pkg/tokens/builtin/issuer"
+)
+
+var _ = Describe("DataplaneTokenIssuer", func() {
+
+	var secretManager secret_manager.SecretManager
+	var keyManager issuer.SigningKeyManager
+	var tokenIssuer issuer.DataplaneTokenIssuer
+	var now time.Time
+
+	identity := issuer.DataplaneIdentity{
+		Mesh: "demo",
+		Name: "backend-01",
+	}
+
+	BeforeEach(func() {
+		now = time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC)
+		core.Now = func() time.Time {
+			return now
+		}
+
+		secretManager = secret_manager.NewSecretManager(secret_store.NewSecretStore(memory.NewStore()), secret_cipher.None())
+		keyManager = issuer.NewSigningKeyManager(secretManager)
+		tokenIssuer = issuer.NewDataplaneTokenIssuer(keyManager)
+	})
+
+	AfterEach(func() {
+		core.Now = time.Now
+	})
+
+	It("should generate a signing key on first use and validate issued tokens", func() {
+		// when
+		token, err := tokenIssuer.Generate(context.Background(), identity, 0)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+
+		// and
+		signingKeys, err := keyManager.GetSigningKeys(context.Background(), "demo")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(signingKeys.Keys).To(HaveLen(1))
+		Expect(signingKeys.Active()).ToNot(BeNil())
+
+		// when
+		validated, err := tokenIssuer.Validate(context.Background(), token)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(validated).To(Equal(identity))
+	})
+
+	It("should reject a token that was tampered with", func() {
+		// given
+		token, err := tokenIssuer.Generate(context.Background(), identity, 0)
+		Expect(err).ToNot(HaveOccurred())
+		otherToken, err := tokenIssuer.Generate(context.Background(), issuer.DataplaneIdentity{Mesh: "demo", Name: "web-01"}, 0)
+		Expect(err).ToNot(HaveOccurred())
+
+		// and claims of one token with the signature of the other
+		segments := strings.Split(token, ".")
+		otherSegments := strings.Split(otherToken, ".")
+		forged := segments[0] + "." + otherSegments[1] + "." + segments[2]
+
+		// when
+		_, err = tokenIssuer.Validate(context.Background(), forged)
+
+		// then
+		Expect(err).To(MatchError("token signature is invalid"))
+		Expect(core_errors.CodeOf(err)).To(Equal(core_errors.TokenInvalid))
+	})
+
+	It("should reject an expired token", func() {
+		// given
+		token, err := tokenIssuer.Generate(context.Background(), identity, time.Hour)
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		now = now.Add(time.Hour)
+		_, err = tokenIssuer.Validate(context.Background(), token)
+
+		// then
+		Expect(err).To(MatchError("token has expired"))
+		Expect(core_errors.CodeOf(err)).To(Equal(core_errors.TokenExpired))
+	})
+
+	It("should tolerate clock skew", func() {
+		// given
+		tolerantIssuer := issuer.NewDataplaneTokenIssuerWithAllowedClockSkew(keyManager, time.Minute)
+		token, err := tolerantIssuer.Generate(context.Background(), identity, time.Hour)
+		Expect(err).ToNot(HaveOccurred())
+
+		// when clock of the validator is behind the one of the issuer
+		now = now.Add(-30 * time.Second)
+		_, err = tolerantIssuer.Validate(context.Background(), token)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		now = now.Add(-time.Minute)
+		_, err = tolerantIssuer.Validate(context.Background(), token)
+
+		// then
+		Expect(err).To(MatchError("token is issued in the future"))
+
+		// when clock of the validator is ahead of the one of the issuer
+		now = now.Add(time.Minute + 30*time.Second + time.Hour + 30*time.Second)
+		_, err = tolerantIssuer.Validate(context.Background(), token)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		now = now.Add(time.Minute)
+		_, err = tolerantIssuer.Validate(context.Background(), token)
+
+		// then
+		Expect(err).To(MatchError("token has expired"))
+	})
+
+	It("should accept tokens signed with a rotated key only within the grace period", func() {
+		// given
+		token, err := tokenIssuer.Generate(context.Background(), identity, 0)
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		newKey, err := keyManager.RotateSigningKey(context.Background(), "demo", time.Hour)
+		Expect(err).ToNot(HaveOccurred())
+
+		// then
+		_, err = tokenIssuer.Validate(context.Background(), token)
+		Expect(err).ToNot(HaveOccurred())
+
+		// and new tokens are signed with the new key
+		newToken, err := tokenIssuer.Generate(context.Background(), identity, 0)
+		Expect(err).ToNot(HaveOccurred())
+		signingKeys, err := keyManager.GetSigningKeys(context.Background(), "demo")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(signingKeys.Active().ID).To(Equal(newKey.ID))
+
+		// when grace period is over
+		now = now.Add(time.Hour)
+
+		// then
+		_, err = tokenIssuer.Validate(context.Background(), token)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("has been rotated and its grace period is over"))
+
+		// and
+		_, err = tokenIssuer.Validate(context.Background(), newToken)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should reject tokens signed with a revoked key", func() {
+		// given
+		token, err := tokenIssuer.Generate(context.Background(), identity, 0)
+		Expect(err).ToNot(HaveOccurred())
+		signingKeys, err := keyManager.GetSigningKeys(context.Background(), "demo")
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		err = keyManager.RevokeSigningKey(context.Background(), "demo", signingKeys.Active().ID)
+		Expect(err).ToNot(HaveOccurred())
+
+		// then
+		_, err = tokenIssuer.Validate(context.Background(), token)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("is unknown or has been revoked"))
+
+		// and
+		err = keyManager.RevokeSigningKey(context.Background(), "demo", signingKeys.Active().ID)
+		Expect(issuer.IsSigningKeyNotFound(err)).To(BeTrue())
+	})
+
+	It("should reject a revoked token", func() {
+		// given
+		token, err := tokenIssuer.Generate(context.Background(), identity, time.Hour)
+		Expect(err).ToNot(HaveOccurred())
+		otherToken, err := tokenIssuer.Generate(context.Background(), identity, time.Hour)
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		err = tokenIssuer.Revoke(context.Background(), "demo", token)
+		Expect(err).ToNot(HaveOccurred())
+
+		// then
+		_, err = tokenIssuer.Validate(context.Background(), token)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("has been revoked"))
+		Expect(core_errors.CodeOf(err)).To(Equal(core_errors.TokenRevoked))
+
+		// and
+		_, err = tokenIssuer.Validate(context.Background(), otherToken)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should not revoke a token from a different mesh", func() {
+		// given
+		token, err := tokenIssuer.Generate(context.Background(), identity, 0)
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		err = tokenIssuer.Revoke(context.Background(), "other", token)
+
+		// then
+		Expect(err).To(MatchError(`token belongs to a Mesh "demo" different from "other"`))
+	})
+
+	It("should forget revoked tokens once they expire", func() {
+		// given
+		token, err := tokenIssuer.Generate(context.Background(), identity, time.Hour)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tokenIssuer.Revoke(context.Background(), "demo", token)).To(Succeed())
+
+		// when
+		now = now.Add(2 * time.Hour)
+		_, err = keyManager.RotateSigningKey(context.Background(), "demo", time.Hour)
+		Expect(err).ToNot(HaveOccurred())
+
+		// then
+		signingKeys, err := keyManager.GetSigningKeys(context.Background(), "demo")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(signingKeys.RevokedTokens).To(BeEmpty())
+		Expect(signingKeys.Keys).To(HaveLen(2))
+	})
+
+	It("should use a signing key generated by a concurrent request", func() {
+		// given a key generated by a concurrent request
+		_, err := tokenIssuer.Generate(context.Background(), identity, 0)
+		Expect(err).ToNot(HaveOccurred())
+		// and a request that has not seen it yet
+		racingIssuer := issuer.NewDataplaneTokenIssuer(issuer.NewSigningKeyManager(&staleSecretManager{
+			SecretManager: secretManager,
+			staleReads:    2,
+		}))
+
+		// when
+		token, err := racingIssuer.Generate(context.Background(), identity, 0)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		// and
+		signingKeys, err := keyManager.GetSigningKeys(context.Background(), "demo")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(signingKeys.Keys).To(HaveLen(1))
+		// and
+		validated, err := tokenIssuer.Validate(context.Background(), token)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(validated).To(Equal(identity))
+	})
+})
+
+// staleSecretManager pretends that Secrets do not exist for a number of reads.
+type staleSecretManager struct {
+	secret_manager.SecretManager
+	staleReads int
+}
+
+func (m *staleSecretManager) Get(ctx context.Context, secret *system.SecretResource, fs ...core_store.GetOptionsFunc) error {
+	if m.staleReads > 0 {
+		m.staleReads--
+		opts := core_store.NewGetOptions(fs...)
+		return core_store.ErrorResourceNotFound(secret.GetType(), opts.Namespace, opts.Name, opts.Mesh)
+	}
+	return m.SecretManager.Get(ctx, secret, fs...)
+}
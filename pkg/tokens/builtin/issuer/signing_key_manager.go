@@ -0,0 +1,277 @@
+package issuer
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/pkg/errors"
+
+	"github.com/Kong/kuma/pkg/core"
+	core_system "github.com/Kong/kuma/pkg/core/resources/apis/system"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	secret_manager "github.com/Kong/kuma/pkg/core/secrets/manager"
+)
+
+const (
+	// DefaultSigningKeyGracePeriod is how long tokens signed with a rotated key are still accepted
+	// unless a different grace period was requested at rotation time.
+	DefaultSigningKeyGracePeriod = 24 * time.Hour
+
+	signingKeyBits = 2048
+)
+
+// SigningKey is a private key used to sign Dataplane tokens.
+type SigningKey struct {
+	ID        string    `json:"id"`
+	Key       []byte    `json:"key"`
+	CreatedAt time.Time `json:"createdAt"`
+	// ExpiresAt is set once a key has been rotated.
+	// Tokens signed with a rotated key are accepted only until that moment.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+func (k *SigningKey) IsActive() bool {
+	return k.ExpiresAt == nil
+}
+
+func (k *SigningKey) IsExpired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
+
+func (k *SigningKey) PrivateKey() (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(k.Key)
+	if block == nil {
+		return nil, errors.Errorf("signing key %q is not a valid PEM", k.ID)
+	}
+	return x509.ParsePKCS1PrivateKey(block.Bytes)
+}
+
+// RevokedToken is a Dataplane token that must not be accepted anymore.
+type RevokedToken struct {
+	ID string `json:"id"`
+	// ExpiresAt is a moment when a token expires on its own and no longer has to be remembered.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// SigningKeys of a Mesh. The first key is the one used for signing new tokens.
+type SigningKeys struct {
+	Keys          []SigningKey   `json:"keys"`
+	RevokedTokens []RevokedToken `json:"revokedTokens,omitempty"`
+}
+
+func (s *SigningKeys) Active() *SigningKey {
+	if len(s.Keys) == 0 || !s.Keys[0].IsActive() {
+		return nil
+	}
+	return &s.Keys[0]
+}
+
+func (s *SigningKeys) Get(id string) *SigningKey {
+	for i := range s.Keys {
+		if s.Keys[i].ID == id {
+			return &s.Keys[i]
+		}
+	}
+	return nil
+}
+
+func (s *SigningKeys) IsRevoked(tokenID string) bool {
+	for _, token := range s.RevokedTokens {
+		if token.ID == tokenID {
+			return true
+		}
+	}
+	return false
+}
+
+// prune removes keys and revoked tokens that no longer affect validation.
+func (s *SigningKeys) prune(now time.Time) {
+	var keys []SigningKey
+	for _, key := range s.Keys {
+		if !key.IsExpired(now) {
+			keys = append(keys, key)
+		}
+	}
+	s.Keys = keys
+	var revokedTokens []RevokedToken
+	for _, token := range s.RevokedTokens {
+		if token.ExpiresAt == nil || now.Before(*token.ExpiresAt) {
+			revokedTokens = append(revokedTokens, token)
+		}
+	}
+	s.RevokedTokens = revokedTokens
+}
+
+func ErrorSigningKeyNotFound(mesh string, keyID string) error {
+	return errors.Errorf("Signing key not found: id=%q mesh=%q", keyID, mesh)
+}
+
+func IsSigningKeyNotFound(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), "Signing key not found")
+}
+
+// SigningKeyManager manages keys that are used to sign Dataplane tokens.
+// Keys are stored as a Secret of a Mesh.
+type SigningKeyManager interface {
+	GetSigningKeys(ctx context.Context, mesh string) (*SigningKeys, error)
+	// RotateSigningKey generates a new signing key. Tokens signed with the previous key
+	// are still accepted for the duration of the grace period.
+	RotateSigningKey(ctx context.Context, mesh string, gracePeriod time.Duration) (*SigningKey, error)
+	// RevokeSigningKey removes a key, so all tokens signed with it are rejected immediately.
+	RevokeSigningKey(ctx context.Context, mesh string, keyID string) error
+	RevokeToken(ctx context.Context, mesh string, token RevokedToken) error
+}
+
+func NewSigningKeyManager(secretManager secret_manager.SecretManager) SigningKeyManager {
+	return &signingKeyManager{
+		secretManager: secretManager,
+	}
+}
+
+var _ SigningKeyManager = &signingKeyManager{}
+
+type signingKeyManager struct {
+	secretManager secret_manager.SecretManager
+}
+
+func (m *signingKeyManager) GetSigningKeys(ctx context.Context, mesh string) (*SigningKeys, error) {
+	_, signingKeys, err := m.get(ctx, mesh)
+	if err != nil {
+		return nil, err
+	}
+	return signingKeys, nil
+}
+
+func (m *signingKeyManager) RotateSigningKey(ctx context.Context, mesh string, gracePeriod time.Duration) (*SigningKey, error) {
+	newKey, err := newSigningKey()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to generate a signing key for Mesh %q", mesh)
+	}
+	secret, signingKeys, err := m.get(ctx, mesh)
+	if err != nil {
+		if !core_store.IsResourceNotFound(err) {
+			return nil, err
+		}
+		signingKeys := &SigningKeys{Keys: []SigningKey{*newKey}}
+		if err := m.create(ctx, mesh, signingKeys); err != nil {
+			return nil, err
+		}
+		return newKey, nil
+	}
+	now := core.Now()
+	if active := signingKeys.Active(); active != nil {
+		expiresAt := now.Add(gracePeriod)
+		active.ExpiresAt = &expiresAt
+	}
+	signingKeys.Keys = append([]SigningKey{*newKey}, signingKeys.Keys...)
+	signingKeys.prune(now)
+	if err := m.update(ctx, mesh, secret, signingKeys); err != nil {
+		return nil, err
+	}
+	return newKey, nil
+}
+
+func (m *signingKeyManager) RevokeSigningKey(ctx context.Context, mesh string, keyID string) error {
+	secret, signingKeys, err := m.get(ctx, mesh)
+	if err != nil {
+		return err
+	}
+	var keys []SigningKey
+	for _, key := range signingKeys.Keys {
+		if key.ID != keyID {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == len(signingKeys.Keys) {
+		return ErrorSigningKeyNotFound(mesh, keyID)
+	}
+	signingKeys.Keys = keys
+	signingKeys.prune(core.Now())
+	return m.update(ctx, mesh, secret, signingKeys)
+}
+
+func (m *signingKeyManager) RevokeToken(ctx context.Context, mesh string, token RevokedToken) error {
+	secret, signingKeys, err := m.get(ctx, mesh)
+	if err != nil {
+		return err
+	}
+	if !signingKeys.IsRevoked(token.ID) {
+		signingKeys.RevokedTokens = append(signingKeys.RevokedTokens, token)
+	}
+	signingKeys.prune(core.Now())
+	return m.update(ctx, mesh, secret, signingKeys)
+}
+
+func (m *signingKeyManager) get(ctx context.Context, mesh string) (*core_system.SecretResource, *SigningKeys, error) {
+	secret := &core_system.SecretResource{}
+	if err := m.secretManager.Get(ctx, secret, core_store.GetBy(signingKeysSecretKey(mesh))); err != nil {
+		return nil, nil, err
+	}
+	signingKeys := &SigningKeys{}
+	if err := json.Unmarshal(secret.Spec.Value, signingKeys); err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to deserialize signing keys of Mesh %q", mesh)
+	}
+	return secret, signingKeys, nil
+}
+
+func (m *signingKeyManager) create(ctx context.Context, mesh string, signingKeys *SigningKeys) error {
+	data, err := json.Marshal(signingKeys)
+	if err != nil {
+		return errors.Wrapf(err, "failed to serialize signing keys of Mesh %q", mesh)
+	}
+	secret := &core_system.SecretResource{
+		Spec: types.BytesValue{
+			Value: data,
+		},
+	}
+	if err := m.secretManager.Create(ctx, secret, core_store.CreateBy(signingKeysSecretKey(mesh))); err != nil {
+		return errors.Wrapf(err, "failed to create signing keys of Mesh %q", mesh)
+	}
+	return nil
+}
+
+func (m *signingKeyManager) update(ctx context.Context, mesh string, secret *core_system.SecretResource, signingKeys *SigningKeys) error {
+	data, err := json.Marshal(signingKeys)
+	if err != nil {
+		return errors.Wrapf(err, "failed to serialize signing keys of Mesh %q", mesh)
+	}
+	secret.Spec.Value = data
+	if err := m.secretManager.Update(ctx, secret); err != nil {
+		return errors.Wrapf(err, "failed to update signing keys of Mesh %q", mesh)
+	}
+	return nil
+}
+
+func newSigningKey() (*SigningKey, error) {
+	key, err := rsa.GenerateKey(rand.Reader, signingKeyBits)
+	if err != nil {
+		return nil, err
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	return &SigningKey{
+		ID:        core.NewUUID(),
+		Key:       keyPEM,
+		CreatedAt: core.Now(),
+	}, nil
+}
+
+func signingKeysSecretKey(mesh string) core_model.ResourceKey {
+	return core_model.ResourceKey{
+		Mesh:      mesh,
+		Namespace: core_model.DefaultNamespace,
+		Name:      signingKeysSecretName(mesh),
+	}
+}
+
+func signingKeysSecretName(mesh string) string {
+	return fmt.Sprintf("dataplane-token-signing-keys.%s", mesh)
+}
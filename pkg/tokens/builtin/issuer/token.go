@@ -0,0 +1,108 @@
+package issuer
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Token is a Dataplane token in the JWT compact serialization format signed with RS256.
+type Token = string
+
+const signingAlgorithm = "RS256"
+
+type tokenHeader struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ"`
+	KeyID     string `json:"kid"`
+}
+
+type tokenClaims struct {
+	ID        string `json:"jti"`
+	Mesh      string `json:"mesh"`
+	Name      string `json:"name"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+}
+
+type parsedToken struct {
+	header    tokenHeader
+	claims    tokenClaims
+	payload   []byte
+	signature []byte
+}
+
+func signToken(key *rsa.PrivateKey, keyID string, claims tokenClaims) (Token, error) {
+	header, err := encodeSegment(tokenHeader{
+		Algorithm: signingAlgorithm,
+		Type:      "JWT",
+		KeyID:     keyID,
+	})
+	if err != nil {
+		return "", err
+	}
+	body, err := encodeSegment(claims)
+	if err != nil {
+		return "", err
+	}
+	payload := header + "." + body
+	digest := sha256.Sum256([]byte(payload))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", errors.Wrap(err, "could not sign the token")
+	}
+	return payload + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// parseToken decodes a token without verifying its signature.
+func parseToken(token Token) (*parsedToken, error) {
+	segments := strings.Split(token, ".")
+	if len(segments) != 3 {
+		return nil, errors.New("token must consist of 3 segments")
+	}
+	parsed := &parsedToken{
+		payload: []byte(segments[0] + "." + segments[1]),
+	}
+	if err := decodeSegment(segments[0], &parsed.header); err != nil {
+		return nil, errors.Wrap(err, "could not decode the token header")
+	}
+	if err := decodeSegment(segments[1], &parsed.claims); err != nil {
+		return nil, errors.Wrap(err, "could not decode the token claims")
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(segments[2])
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode the token signature")
+	}
+	parsed.signature = signature
+	if parsed.header.Algorithm != signingAlgorithm {
+		return nil, errors.Errorf("token is signed with unsupported algorithm %q", parsed.header.Algorithm)
+	}
+	return parsed, nil
+}
+
+func (t *parsedToken) verify(key *rsa.PublicKey) error {
+	digest := sha256.Sum256(t.payload)
+	return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], t.signature)
+}
+
+func encodeSegment(value interface{}) (string, error) {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+func decodeSegment(segment string, value interface{}) error {
+	bytes, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(bytes, value)
+}
@@ -0,0 +1,35 @@
+package grpc
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	health_v1 "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// HealthServer implements gRPC Health Checking Protocol (grpc.health.v1),
+// so that load balancers and Kubernetes gRPC probes can health-check a gRPC server natively.
+type HealthServer struct {
+	server *health.Server
+}
+
+// RegisterHealthServer registers gRPC Health Checking service on a given gRPC server.
+//
+// Both the server as a whole (empty service name) and every given service
+// are reported as serving until Shutdown is called.
+func RegisterHealthServer(server *grpc.Server, services ...string) *HealthServer {
+	h := &HealthServer{
+		server: health.NewServer(),
+	}
+	h.server.SetServingStatus("", health_v1.HealthCheckResponse_SERVING)
+	for _, service := range services {
+		h.server.SetServingStatus(service, health_v1.HealthCheckResponse_SERVING)
+	}
+	health_v1.RegisterHealthServer(server, h.server)
+	return h
+}
+
+// Shutdown reports all services as not serving, e.g. to let load balancers drain connections
+// before a gRPC server is stopped.
+func (h *HealthServer) Shutdown() {
+	h.server.Shutdown()
+}
@@ -0,0 +1,71 @@
+package grpc_test
+
+import (
+	"context"
+	"net"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"google.golang.org/grpc"
+	health_v1 "google.golang.org/grpc/health/grpc_health_v1"
+
+	util_grpc "github.com/Kong/kuma/pkg/util/grpc"
+)
+
+var _ = Describe("HealthServer", func() {
+
+	var server *grpc.Server
+	var healthServer *util_grpc.HealthServer
+	var client health_v1.HealthClient
+	var conn *grpc.ClientConn
+
+	BeforeEach(func() {
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).ToNot(HaveOccurred())
+
+		server = grpc.NewServer()
+		healthServer = util_grpc.RegisterHealthServer(server, "envoy.service.discovery.v2.AggregatedDiscoveryService")
+		go func() {
+			_ = server.Serve(lis)
+		}()
+
+		conn, err = grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+		Expect(err).ToNot(HaveOccurred())
+		client = health_v1.NewHealthClient(conn)
+	})
+
+	AfterEach(func() {
+		Expect(conn.Close()).To(Succeed())
+		server.Stop()
+	})
+
+	check := func(service string) health_v1.HealthCheckResponse_ServingStatus {
+		resp, err := client.Check(context.Background(), &health_v1.HealthCheckRequest{Service: service})
+		Expect(err).ToNot(HaveOccurred())
+		return resp.Status
+	}
+
+	It("should report the server and registered services as serving", func() {
+		// expect
+		Expect(check("")).To(Equal(health_v1.HealthCheckResponse_SERVING))
+		Expect(check("envoy.service.discovery.v2.AggregatedDiscoveryService")).To(Equal(health_v1.HealthCheckResponse_SERVING))
+	})
+
+	It("should not know about other services", func() {
+		// when
+		_, err := client.Check(context.Background(), &health_v1.HealthCheckRequest{Service: "unknown"})
+
+		// then
+		Expect(err).To(MatchError(ContainSubstring("NotFound")))
+	})
+
+	It("should report all services as not serving after shutdown", func() {
+		// when
+		healthServer.Shutdown()
+
+		// then
+		Expect(check("")).To(Equal(health_v1.HealthCheckResponse_NOT_SERVING))
+		Expect(check("envoy.service.discovery.v2.AggregatedDiscoveryService")).To(Equal(health_v1.HealthCheckResponse_NOT_SERVING))
+	})
+})
@@ -1,17 +1,28 @@
 package http_test
 
 import (
+	"errors"
+	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 
 	util_http "github.com/Kong/kuma/pkg/util/http"
 )
 
 var _ = Describe("Http Util", func() {
+
+	response := func(statusCode int) *http.Response {
+		return &http.Response{StatusCode: statusCode, Body: ioutil.NopCloser(strings.NewReader(""))}
+	}
+
 	Describe("ClientWithBaseURL(..)", func() {
 		type testCase struct {
 			baseURL     string
@@ -107,4 +118,188 @@ var _ = Describe("Http Util", func() {
 			Expect(actualURL).To(BeNil())
 		})
 	})
+
+	Describe("ClientWithRetries(..)", func() {
+
+		It("should retry requests that have failed temporarily", func() {
+			// setup
+			var bodies []string
+			responses := []*http.Response{response(503), response(502), response(200)}
+			delegate := util_http.ClientFunc(func(req *http.Request) (*http.Response, error) {
+				body, err := ioutil.ReadAll(req.Body)
+				Expect(err).ToNot(HaveOccurred())
+				bodies = append(bodies, string(body))
+				resp := responses[0]
+				responses = responses[1:]
+				return resp, nil
+			})
+
+			// when
+			client := util_http.ClientWithRetries(delegate, 3, time.Millisecond)
+			// and
+			req, err := http.NewRequest("PUT", "/meshes/default", strings.NewReader("{}"))
+			Expect(err).ToNot(HaveOccurred())
+			resp, err := client.Do(req)
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(200))
+			// and
+			Expect(bodies).To(Equal([]string{"{}", "{}", "{}"}))
+		})
+
+		It("should give up after a given number of retries", func() {
+			// setup
+			attempts := 0
+			delegate := util_http.ClientFunc(func(req *http.Request) (*http.Response, error) {
+				attempts++
+				return nil, errors.New("connection refused")
+			})
+
+			// when
+			client := util_http.ClientWithRetries(delegate, 2, time.Millisecond)
+			// and
+			req, err := http.NewRequest("GET", "/meshes", nil)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = client.Do(req)
+
+			// then
+			Expect(err).To(MatchError("connection refused"))
+			Expect(attempts).To(Equal(3))
+		})
+
+		It("should not retry requests that have been rejected", func() {
+			// setup
+			attempts := 0
+			delegate := util_http.ClientFunc(func(req *http.Request) (*http.Response, error) {
+				attempts++
+				return response(400), nil
+			})
+
+			// when
+			client := util_http.ClientWithRetries(delegate, 2, time.Millisecond)
+			// and
+			req, err := http.NewRequest("GET", "/meshes", nil)
+			Expect(err).ToNot(HaveOccurred())
+			resp, err := client.Do(req)
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(400))
+			Expect(attempts).To(Equal(1))
+		})
+	})
+
+	Describe("ClientWithRetryPolicy(..)", func() {
+
+		It("should report retries with jittered and capped backoff", func() {
+			// setup
+			delegate := util_http.ClientFunc(func(req *http.Request) (*http.Response, error) {
+				return nil, errors.New("connection refused")
+			})
+			var events []util_http.RetryEvent
+
+			// when
+			client := util_http.ClientWithRetryPolicy(delegate, util_http.RetryPolicy{
+				MaxRetries: 4,
+				Backoff:    time.Millisecond,
+				MaxBackoff: 2 * time.Millisecond,
+				Jitter:     0.5,
+				OnRetry: func(event util_http.RetryEvent) {
+					events = append(events, event)
+				},
+			})
+			// and
+			req, err := http.NewRequest("GET", "/bootstrap", nil)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = client.Do(req)
+
+			// then
+			Expect(err).To(MatchError("connection refused"))
+			// and
+			Expect(events).To(HaveLen(4))
+			for i, event := range events {
+				Expect(event.Attempt).To(Equal(i + 1))
+				Expect(event.Cause).To(Equal("connection refused"))
+				Expect(event.Throttled).To(BeFalse())
+			}
+			Expect(events[0].Wait).To(BeNumerically("~", time.Millisecond, 500*time.Microsecond))
+			Expect(events[3].Wait).To(BeNumerically("~", 2*time.Millisecond, time.Millisecond))
+		})
+
+		It("should stop retrying once the budget is exhausted", func() {
+			// setup
+			attempts := 0
+			delegate := util_http.ClientFunc(func(req *http.Request) (*http.Response, error) {
+				attempts++
+				return response(503), nil
+			})
+			var throttled []util_http.RetryEvent
+
+			// when
+			client := util_http.ClientWithRetryPolicy(delegate, util_http.RetryPolicy{
+				MaxRetries: 10,
+				Backoff:    time.Millisecond,
+				Budget:     util_http.NewRetryBudget(4, 0.5),
+				OnRetry: func(event util_http.RetryEvent) {
+					if event.Throttled {
+						throttled = append(throttled, event)
+					}
+				},
+			})
+			// and
+			req, err := http.NewRequest("GET", "/bootstrap", nil)
+			Expect(err).ToNot(HaveOccurred())
+			resp, err := client.Do(req)
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(503))
+			// and
+			Expect(attempts).To(Equal(2))
+			Expect(throttled).To(HaveLen(1))
+			Expect(throttled[0].Cause).To(Equal("status code 503"))
+
+			// when budget is still exhausted
+			_, err = client.Do(req)
+
+			// then a request is not retried at all
+			Expect(err).ToNot(HaveOccurred())
+			Expect(attempts).To(Equal(3))
+		})
+
+		It("should count retries", func() {
+			// setup
+			delegate := util_http.ClientFunc(func(req *http.Request) (*http.Response, error) {
+				return response(503), nil
+			})
+			metrics := util_http.NewRetryMetrics()
+			registry := prometheus.NewRegistry()
+			Expect(registry.Register(metrics)).To(Succeed())
+
+			// when
+			client := util_http.ClientWithRetryPolicy(delegate, util_http.RetryPolicy{
+				MaxRetries: 10,
+				Backoff:    time.Millisecond,
+				Budget:     util_http.NewRetryBudget(6, 0.5),
+				OnRetry:    metrics.OnRetry,
+			})
+			// and
+			req, err := http.NewRequest("GET", "/bootstrap", nil)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = client.Do(req)
+			Expect(err).ToNot(HaveOccurred())
+
+			// then
+			expected := `
+# HELP http_client_retries_throttled_total Number of requests that have not been retried because the retry budget is exhausted
+# TYPE http_client_retries_throttled_total counter
+http_client_retries_throttled_total{path="/bootstrap"} 1
+# HELP http_client_retries_total Number of retries of requests that have failed temporarily
+# TYPE http_client_retries_total counter
+http_client_retries_total{path="/bootstrap"} 2
+`
+			Expect(testutil.GatherAndCompare(registry, strings.NewReader(expected))).To(Succeed())
+		})
+	})
 })
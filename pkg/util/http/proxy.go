@@ -0,0 +1,43 @@
+package http
+
+import (
+	"net"
+	nethttp "net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// ProxyFunc returns a function that picks a proxy for a request, as expected by http.Transport.
+// Settings that are left empty are taken from HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables.
+func ProxyFunc(proxyURL string, noProxy []string) func(*nethttp.Request) (*url.URL, error) {
+	cfg := httpproxy.FromEnvironment()
+	if proxyURL != "" {
+		cfg.HTTPProxy = proxyURL
+		cfg.HTTPSProxy = proxyURL
+	}
+	if len(noProxy) > 0 {
+		cfg.NoProxy = strings.Join(noProxy, ",")
+	}
+	proxy := cfg.ProxyFunc()
+	return func(req *nethttp.Request) (*url.URL, error) {
+		return proxy(req.URL)
+	}
+}
+
+// ProxyTransport returns a transport with the settings of http.DefaultTransport that goes through a proxy picked by ProxyFunc.
+func ProxyTransport(proxyURL string, noProxy []string) *nethttp.Transport {
+	return &nethttp.Transport{
+		Proxy: ProxyFunc(proxyURL, noProxy),
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+}
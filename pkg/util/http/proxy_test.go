@@ -0,0 +1,80 @@
+package http_test
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	util_http "github.com/Kong/kuma/pkg/util/http"
+)
+
+var _ = Describe("ProxyFunc(..)", func() {
+
+	type testCase struct {
+		requestURL    string
+		expectedProxy string
+	}
+
+	DescribeTable("should pick a proxy for a destination",
+		func(given testCase) {
+			// given
+			proxy := util_http.ProxyFunc("socks5://proxy.example.com:1080", []string{".internal", "10.0.0.0/8", "vault.example.com:8200"})
+			req, err := http.NewRequest("GET", given.requestURL, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			// when
+			proxyURL, err := proxy(req)
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			if given.expectedProxy == "" {
+				Expect(proxyURL).To(BeNil())
+			} else {
+				Expect(proxyURL.String()).To(Equal(given.expectedProxy))
+			}
+		},
+		Entry("external https destination", testCase{
+			requestURL:    "https://issuer.example.com/.well-known/openid-configuration",
+			expectedProxy: "socks5://proxy.example.com:1080",
+		}),
+		Entry("external http destination", testCase{
+			requestURL:    "http://logs.example.com:8080/",
+			expectedProxy: "socks5://proxy.example.com:1080",
+		}),
+		Entry("destination in a domain without proxy", testCase{
+			requestURL: "http://consul.service.internal:8500/v1/catalog/services",
+		}),
+		Entry("destination in a CIDR without proxy", testCase{
+			requestURL: "https://10.1.2.3/",
+		}),
+		Entry("destination with a port without proxy", testCase{
+			requestURL: "https://vault.example.com:8200/v1/pki",
+		}),
+		Entry("destination with other port than the one without proxy", testCase{
+			requestURL:    "https://vault.example.com/v1/pki",
+			expectedProxy: "socks5://proxy.example.com:1080",
+		}),
+	)
+})
+
+var _ = Describe("ProxyTransport(..)", func() {
+
+	It("should return a transport of its own that goes through a proxy", func() {
+		// when
+		transport := util_http.ProxyTransport("http://proxy.example.com:3128", nil)
+
+		// then
+		Expect(transport).ToNot(BeIdenticalTo(http.DefaultTransport))
+
+		// when
+		req, err := http.NewRequest("GET", "https://issuer.example.com/", nil)
+		Expect(err).ToNot(HaveOccurred())
+		proxyURL, err := transport.Proxy(req)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(proxyURL.String()).To(Equal("http://proxy.example.com:3128"))
+	})
+})
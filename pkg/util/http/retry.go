@@ -0,0 +1,168 @@
+package http
+
+import (
+	"fmt"
+	"math/rand"
+	nethttp "net/http"
+	"sync"
+	"time"
+)
+
+// RetryPolicy defines how requests that have failed temporarily are retried.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of retries of a single request.
+	MaxRetries int
+	// Backoff is how long to wait before the first retry. Every next retry waits twice as long.
+	Backoff time.Duration
+	// MaxBackoff caps the time between retries. Zero means no cap.
+	MaxBackoff time.Duration
+	// Jitter is a fraction of the wait, e.g. 0.2, by which every wait is randomly shortened or lengthened,
+	// so that clients that have failed at the same time don't retry at the same time either.
+	Jitter float64
+	// Budget, if set, is shared by all clients of the same process to stop retrying once most of requests fail.
+	Budget *RetryBudget
+	// OnRetry, if set, is called before every retry, e.g. to count retries.
+	OnRetry func(event RetryEvent)
+}
+
+// RetryEvent describes a retry of a request.
+type RetryEvent struct {
+	Request *nethttp.Request
+	// Attempt is the number of the retry, starting with 1.
+	Attempt int
+	// Wait is how long the client waits before the retry.
+	Wait time.Duration
+	// Cause is either an error or a status code of a failed attempt.
+	Cause string
+	// Throttled is true if the request is not retried because the budget is exhausted.
+	Throttled bool
+}
+
+// RetryBudget limits retries across many requests, so that retries don't amplify an outage of the server.
+//
+// It follows the token bucket scheme of gRPC retry throttling: every failed attempt takes one token,
+// every successful one gives back a fraction of a token, and requests are only retried
+// while there are more than half of the tokens left.
+type RetryBudget struct {
+	mutex      sync.Mutex
+	maxTokens  float64
+	tokenRatio float64
+	tokens     float64
+}
+
+func NewRetryBudget(maxTokens int, tokenRatio float64) *RetryBudget {
+	return &RetryBudget{
+		maxTokens:  float64(maxTokens),
+		tokenRatio: tokenRatio,
+		tokens:     float64(maxTokens),
+	}
+}
+
+func (b *RetryBudget) onSuccess() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.tokens += b.tokenRatio
+	if b.tokens > b.maxTokens {
+		b.tokens = b.maxTokens
+	}
+}
+
+// onFailure records a failed attempt and returns true if it can be retried.
+func (b *RetryBudget) onFailure() bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.tokens--
+	if b.tokens < 0 {
+		b.tokens = 0
+	}
+	return b.tokens > b.maxTokens/2
+}
+
+// ClientWithRetries retries requests that have failed because of a network error
+// or a temporary unavailability of the server, waiting twice as long before every next attempt.
+//
+// It must only be used for idempotent requests.
+func ClientWithRetries(delegate Client, maxRetries int, backoff time.Duration) Client {
+	return ClientWithRetryPolicy(delegate, RetryPolicy{MaxRetries: maxRetries, Backoff: backoff})
+}
+
+// ClientWithRetryPolicy retries requests that have failed because of a network error
+// or a temporary unavailability of the server according to a given policy.
+//
+// It must only be used for idempotent requests.
+func ClientWithRetryPolicy(delegate Client, policy RetryPolicy) Client {
+	return ClientFunc(func(req *nethttp.Request) (*nethttp.Response, error) {
+		backoff := policy.Backoff
+		for attempt := 0; ; attempt++ {
+			resp, err := delegate.Do(req)
+			if !isRetriable(resp, err) {
+				if policy.Budget != nil {
+					policy.Budget.onSuccess()
+				}
+				return resp, err
+			}
+			throttled := policy.Budget != nil && !policy.Budget.onFailure()
+			if attempt == policy.MaxRetries {
+				return resp, err
+			}
+			event := RetryEvent{
+				Request:   req,
+				Attempt:   attempt + 1,
+				Wait:      policy.jittered(backoff),
+				Cause:     retryCause(resp, err),
+				Throttled: throttled,
+			}
+			if policy.OnRetry != nil {
+				policy.OnRetry(event)
+			}
+			if throttled {
+				return resp, err
+			}
+			if resp != nil {
+				resp.Body.Close()
+			}
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				req.Body = body
+			}
+			select {
+			case <-time.After(event.Wait):
+			case <-req.Context().Done():
+				return nil, req.Context().Err()
+			}
+			backoff *= 2
+			if policy.MaxBackoff > 0 && backoff > policy.MaxBackoff {
+				backoff = policy.MaxBackoff
+			}
+		}
+	})
+}
+
+func (p RetryPolicy) jittered(backoff time.Duration) time.Duration {
+	if p.Jitter <= 0 {
+		return backoff
+	}
+	return time.Duration(float64(backoff) * (1 + p.Jitter*(2*rand.Float64()-1)))
+}
+
+func isRetriable(resp *nethttp.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case nethttp.StatusTooManyRequests, nethttp.StatusBadGateway, nethttp.StatusServiceUnavailable, nethttp.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+func retryCause(resp *nethttp.Response, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return fmt.Sprintf("status code %d", resp.StatusCode)
+}
@@ -0,0 +1,47 @@
+package http
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// RetryMetrics is a collector of metrics that count retries of requests,
+// e.g. to spot a server that is flapping before clients give up on it.
+type RetryMetrics struct {
+	retries   *prometheus.CounterVec
+	throttled *prometheus.CounterVec
+}
+
+func NewRetryMetrics() *RetryMetrics {
+	return &RetryMetrics{
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_client_retries_total",
+			Help: "Number of retries of requests that have failed temporarily",
+		}, []string{"path"}),
+		throttled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_client_retries_throttled_total",
+			Help: "Number of requests that have not been retried because the retry budget is exhausted",
+		}, []string{"path"}),
+	}
+}
+
+var _ prometheus.Collector = &RetryMetrics{}
+
+func (m *RetryMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.retries.Describe(ch)
+	m.throttled.Describe(ch)
+}
+
+func (m *RetryMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.retries.Collect(ch)
+	m.throttled.Collect(ch)
+}
+
+// OnRetry counts a retry. It is meant to be called from RetryPolicy.OnRetry.
+func (m *RetryMetrics) OnRetry(event RetryEvent) {
+	path := event.Request.URL.Path
+	if event.Throttled {
+		m.throttled.WithLabelValues(path).Inc()
+		return
+	}
+	m.retries.WithLabelValues(path).Inc()
+}
@@ -10,22 +10,74 @@ type Watchdog interface {
 
 type SimpleWatchdog struct {
 	NewTicker func() *time.Ticker
-	OnTick    func() error
-	OnError   func(error)
+	// NewTrigger is optional. If set, OnTick() is also called every time the returned channel fires,
+	// e.g. to react to a change immediately instead of waiting for the next tick.
+	NewTrigger func(stop <-chan struct{}) <-chan struct{}
+	// DebounceWindow is optional. If set, OnTick() is called only once the trigger has not fired for the window,
+	// so that a burst of changes is handled once. Ticks are skipped while the window is open.
+	DebounceWindow time.Duration
+	// DebounceMaxDelay is optional. If set, OnTick() is called no later than DebounceMaxDelay
+	// after the trigger has first fired, even if it keeps firing.
+	DebounceMaxDelay time.Duration
+	OnTick           func() error
+	OnError          func(error)
 }
 
 func (w *SimpleWatchdog) Start(stop <-chan struct{}) {
 	ticker := w.NewTicker()
 	defer ticker.Stop()
 
+	// a nil channel never fires
+	var trigger <-chan struct{}
+	if w.NewTrigger != nil {
+		trigger = w.NewTrigger(stop)
+	}
+
+	// debounced fires once the debounce window is over, it is nil while the window is closed
+	var debounced <-chan time.Time
+	var debounceTimer *time.Timer
+	var debounceDeadline time.Time
+	defer func() {
+		if debounceTimer != nil {
+			debounceTimer.Stop()
+		}
+	}()
+
 	for {
 		select {
 		case <-ticker.C:
-			if err := w.OnTick(); err != nil {
-				w.OnError(err)
+			if debounced == nil {
+				w.tick()
+			}
+		case <-trigger:
+			if w.DebounceWindow == 0 {
+				w.tick()
+				continue
+			}
+			now := time.Now()
+			if debounced == nil {
+				debounceDeadline = now.Add(w.DebounceMaxDelay)
 			}
+			delay := w.DebounceWindow
+			if w.DebounceMaxDelay > 0 && now.Add(delay).After(debounceDeadline) {
+				delay = debounceDeadline.Sub(now)
+			}
+			if debounceTimer != nil {
+				debounceTimer.Stop()
+			}
+			debounceTimer = time.NewTimer(delay)
+			debounced = debounceTimer.C
+		case <-debounced:
+			debounced = nil
+			w.tick()
 		case <-stop:
 			return
 		}
 	}
 }
+
+func (w *SimpleWatchdog) tick() {
+	if err := w.OnTick(); err != nil {
+		w.OnError(err)
+	}
+}
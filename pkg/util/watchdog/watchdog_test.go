@@ -126,4 +126,149 @@ var _ = Describe("SimpleWatchdog", func() {
 
 		close(done)
 	}, 5)
+
+	It("should call OnTick() when triggered", func(done Done) {
+		// given
+		triggers := make(chan struct{})
+		// and
+		watchdog := SimpleWatchdog{
+			NewTicker: func() *time.Ticker {
+				return &time.Ticker{
+					C: timeTicks,
+				}
+			},
+			NewTrigger: func(<-chan struct{}) <-chan struct{} {
+				return triggers
+			},
+			OnTick: func() error {
+				onTickCalls <- struct{}{}
+				return nil
+			},
+		}
+
+		// setup
+		go func() {
+			watchdog.Start(stopCh)
+
+			close(doneCh)
+		}()
+
+		By("simulating a trigger")
+		// when
+		triggers <- struct{}{}
+
+		// then
+		select {
+		case <-onTickCalls:
+		}
+
+		By("simulating a tick")
+		// when
+		timeTicks <- time.Time{}
+
+		// then
+		select {
+		case <-onTickCalls:
+		}
+
+		By("simulating Dataplane disconnect")
+		// when
+		close(stopCh)
+
+		// then
+		select {
+		case <-doneCh:
+		}
+
+		close(done)
+	}, 5)
+
+	Context("with debounce window", func() {
+
+		var triggers chan struct{}
+		var ticks chan struct{}
+
+		BeforeEach(func() {
+			triggers = make(chan struct{})
+			ticks = make(chan struct{}, 10)
+		})
+
+		start := func(watchdog SimpleWatchdog) {
+			watchdog.NewTicker = func() *time.Ticker {
+				return &time.Ticker{
+					C: timeTicks,
+				}
+			}
+			watchdog.NewTrigger = func(<-chan struct{}) <-chan struct{} {
+				return triggers
+			}
+			watchdog.OnTick = func() error {
+				ticks <- struct{}{}
+				return nil
+			}
+			go func() {
+				watchdog.Start(stopCh)
+
+				close(doneCh)
+			}()
+		}
+
+		AfterEach(func() {
+			close(stopCh)
+			Eventually(doneCh).Should(BeClosed())
+		})
+
+		It("should call OnTick() once the trigger has not fired for the window", func() {
+			// setup
+			start(SimpleWatchdog{
+				DebounceWindow: 500 * time.Millisecond,
+			})
+
+			By("simulating a burst of changes")
+			// when
+			for i := 0; i < 3; i++ {
+				triggers <- struct{}{}
+				timeTicks <- time.Time{}
+				time.Sleep(100 * time.Millisecond)
+			}
+
+			// then
+			Consistently(ticks, "300ms").ShouldNot(Receive())
+			// and
+			Eventually(ticks, "1s").Should(Receive())
+			Consistently(ticks, "600ms").ShouldNot(Receive())
+
+			By("simulating a tick once the window is over")
+			// when
+			timeTicks <- time.Time{}
+
+			// then
+			Eventually(ticks).Should(Receive())
+		})
+
+		It("should not delay OnTick() beyond the max delay", func() {
+			// setup
+			start(SimpleWatchdog{
+				DebounceWindow:   300 * time.Millisecond,
+				DebounceMaxDelay: 500 * time.Millisecond,
+			})
+
+			// when
+			stopTriggers := make(chan struct{})
+			defer close(stopTriggers)
+			go func() {
+				for {
+					select {
+					case triggers <- struct{}{}:
+						time.Sleep(50 * time.Millisecond)
+					case <-stopTriggers:
+						return
+					}
+				}
+			}()
+
+			// then
+			Eventually(ticks, "2s").Should(Receive())
+		})
+	})
 })
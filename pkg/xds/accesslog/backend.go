@@ -0,0 +1,176 @@
+package accesslog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	core_system "github.com/Kong/kuma/pkg/core/resources/apis/system"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	secret_manager "github.com/Kong/kuma/pkg/core/secrets/manager"
+	util_http "github.com/Kong/kuma/pkg/util/http"
+)
+
+// Backend sends batches of access log entries to an external system.
+type Backend interface {
+	Send(ctx context.Context, entries []Entry) error
+}
+
+// SecretResolver returns the value of a Secret of a given name.
+type SecretResolver func(name string) ([]byte, error)
+
+// MeshSecrets returns a SecretResolver of Secrets that belong to a given Mesh.
+func MeshSecrets(ctx context.Context, secretManager secret_manager.SecretManager, mesh string) SecretResolver {
+	return func(name string) ([]byte, error) {
+		secret := &core_system.SecretResource{}
+		if err := secretManager.Get(ctx, secret, core_store.GetByKey(core_model.DefaultNamespace, name, mesh)); err != nil {
+			return nil, errors.Wrapf(err, "could not get Secret %q", name)
+		}
+		// on Kubernetes Secrets are looked up by name only
+		if secret.GetMeta().GetMesh() != mesh {
+			return nil, errors.Errorf("Secret %q does not belong to Mesh %q", name, mesh)
+		}
+		return secret.Spec.Value, nil
+	}
+}
+
+// NewBackend returns a client of a logging backend whose credentials are resolved from Secrets.
+func NewBackend(spec *mesh_proto.Logging_Backend, secrets SecretResolver, client util_http.Client) (Backend, error) {
+	switch spec.GetType().(type) {
+	case *mesh_proto.Logging_Backend_Splunk:
+		token, err := secrets(spec.GetSplunk().GetTokenSecret())
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not resolve HEC token of backend %q", spec.GetName())
+		}
+		return &splunkBackend{spec: spec.GetSplunk(), token: string(token), client: client}, nil
+	case *mesh_proto.Logging_Backend_Elasticsearch:
+		var password []byte
+		if name := spec.GetElasticsearch().GetPasswordSecret(); name != "" {
+			var err error
+			if password, err = secrets(name); err != nil {
+				return nil, errors.Wrapf(err, "could not resolve password of backend %q", spec.GetName())
+			}
+		}
+		return &elasticsearchBackend{spec: spec.GetElasticsearch(), password: string(password), client: client}, nil
+	default:
+		return nil, errors.Errorf("backend %q has unsupported type", spec.GetName())
+	}
+}
+
+// splunkBackend sends entries to Splunk HTTP Event Collector,
+// see https://docs.splunk.com/Documentation/Splunk/latest/Data/FormateventsforHTTPEventCollector
+type splunkBackend struct {
+	spec   *mesh_proto.Logging_SplunkHec
+	token  string
+	client util_http.Client
+}
+
+type splunkEvent struct {
+	Time       float64 `json:"time,omitempty"`
+	Event      Entry   `json:"event"`
+	Index      string  `json:"index,omitempty"`
+	Sourcetype string  `json:"sourcetype,omitempty"`
+}
+
+func (s *splunkBackend) Send(ctx context.Context, entries []Entry) error {
+	body := &bytes.Buffer{}
+	encoder := json.NewEncoder(body)
+	for _, entry := range entries {
+		event := splunkEvent{
+			Event:      entry,
+			Index:      s.spec.GetIndex(),
+			Sourcetype: s.spec.GetSourcetype(),
+		}
+		if t := entry.Time(); !t.IsZero() {
+			event.Time = float64(t.UnixNano()) / 1e9
+		}
+		if err := encoder.Encode(event); err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequest("POST", s.spec.GetUrl(), bytes.NewReader(body.Bytes()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Splunk %s", s.token))
+	req.Header.Set("Content-Type", "application/json")
+	_, err = do(ctx, s.client, req)
+	return err
+}
+
+// elasticsearchBackend indexes entries with Elasticsearch Bulk API,
+// see https://www.elastic.co/guide/en/elasticsearch/reference/current/docs-bulk.html
+type elasticsearchBackend struct {
+	spec     *mesh_proto.Logging_Elasticsearch
+	password string
+	client   util_http.Client
+}
+
+type elasticsearchAction struct {
+	Index struct {
+		Index string `json:"_index"`
+	} `json:"index"`
+}
+
+type elasticsearchBulkResponse struct {
+	Errors bool `json:"errors"`
+}
+
+func (e *elasticsearchBackend) Send(ctx context.Context, entries []Entry) error {
+	body := &bytes.Buffer{}
+	encoder := json.NewEncoder(body)
+	action := elasticsearchAction{}
+	action.Index.Index = e.spec.GetIndex()
+	for _, entry := range entries {
+		if err := encoder.Encode(action); err != nil {
+			return err
+		}
+		if err := encoder.Encode(entry); err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequest("POST", strings.TrimSuffix(e.spec.GetUrl(), "/")+"/_bulk", bytes.NewReader(body.Bytes()))
+	if err != nil {
+		return err
+	}
+	if e.spec.GetUsername() != "" {
+		req.SetBasicAuth(e.spec.GetUsername(), e.password)
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	respBody, err := do(ctx, e.client, req)
+	if err != nil {
+		return err
+	}
+	resp := elasticsearchBulkResponse{}
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return errors.Wrap(err, "could not parse response of the Bulk API")
+	}
+	if resp.Errors {
+		return errors.New("some of the entries have not been indexed")
+	}
+	return nil
+}
+
+func do(ctx context.Context, client util_http.Client, req *http.Request) ([]byte, error) {
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode/100 != 2 {
+		return nil, errors.Errorf("unexpected status code %d: %s", resp.StatusCode, body)
+	}
+	return body, nil
+}
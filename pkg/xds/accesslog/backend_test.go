@@ -0,0 +1,178 @@
+package accesslog_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	core_system "github.com/Kong/kuma/pkg/core/resources/apis/system"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	secret_cipher "github.com/Kong/kuma/pkg/core/secrets/cipher"
+	secret_manager "github.com/Kong/kuma/pkg/core/secrets/manager"
+	secret_store "github.com/Kong/kuma/pkg/core/secrets/store"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+	"github.com/Kong/kuma/pkg/xds/accesslog"
+)
+
+var _ = Describe("Backend", func() {
+
+	type request struct {
+		method string
+		path   string
+		header http.Header
+		body   string
+	}
+
+	var requests chan request
+	var responseBody string
+	var server *httptest.Server
+	var secrets accesslog.SecretResolver
+
+	BeforeEach(func() {
+		secretManager := secret_manager.NewSecretManager(secret_store.NewSecretStore(memory.NewStore()), secret_cipher.None())
+		for mesh, value := range map[string]string{"demo": "secret", "other": "other-secret"} {
+			secret := &core_system.SecretResource{}
+			secret.Spec.Value = []byte(value)
+			Expect(secretManager.Create(context.Background(), secret, core_store.CreateByKey("default", "logging."+mesh, mesh))).To(Succeed())
+		}
+		secrets = accesslog.MeshSecrets(context.Background(), secretManager, "demo")
+
+		requests = make(chan request, 1)
+		responseBody = ""
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			defer GinkgoRecover()
+			body, err := ioutil.ReadAll(req.Body)
+			Expect(err).ToNot(HaveOccurred())
+			requests <- request{method: req.Method, path: req.URL.Path, header: req.Header, body: string(body)}
+			_, err = w.Write([]byte(responseBody))
+			Expect(err).ToNot(HaveOccurred())
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	entries := []accesslog.Entry{
+		{
+			"mesh":       "demo",
+			"dataplane":  "backend-01",
+			"start_time": time.Unix(1570000000, 500000000).UTC(),
+		},
+	}
+
+	It("should send events to Splunk HEC", func() {
+		// given
+		backend, err := accesslog.NewBackend(&mesh_proto.Logging_Backend{
+			Name: "splunk",
+			Type: &mesh_proto.Logging_Backend_Splunk{
+				Splunk: &mesh_proto.Logging_SplunkHec{
+					Url:         server.URL + "/services/collector/event",
+					TokenSecret: "logging.demo",
+					Index:       "access-logs",
+					Sourcetype:  "kuma",
+				},
+			},
+		}, secrets, http.DefaultClient)
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		err = backend.Send(context.Background(), entries)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		req := <-requests
+		Expect(req.method).To(Equal("POST"))
+		Expect(req.path).To(Equal("/services/collector/event"))
+		Expect(req.header.Get("Authorization")).To(Equal("Splunk secret"))
+		Expect(req.body).To(MatchJSON(`
+		{
+			"time": 1570000000.5,
+			"event": {"mesh": "demo", "dataplane": "backend-01", "start_time": "2019-10-02T07:06:40.5Z"},
+			"index": "access-logs",
+			"sourcetype": "kuma"
+		}`))
+	})
+
+	Describe("Elasticsearch", func() {
+
+		var backend accesslog.Backend
+
+		BeforeEach(func() {
+			var err error
+			backend, err = accesslog.NewBackend(&mesh_proto.Logging_Backend{
+				Name: "elasticsearch",
+				Type: &mesh_proto.Logging_Backend_Elasticsearch{
+					Elasticsearch: &mesh_proto.Logging_Elasticsearch{
+						Url:            server.URL + "/",
+						Index:          "access-logs",
+						Username:       "kuma",
+						PasswordSecret: "logging.demo",
+					},
+				},
+			}, secrets, http.DefaultClient)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should index entries with the Bulk API", func() {
+			// given
+			responseBody = `{"errors": false}`
+
+			// when
+			err := backend.Send(context.Background(), entries)
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			req := <-requests
+			Expect(req.method).To(Equal("POST"))
+			Expect(req.path).To(Equal("/_bulk"))
+			Expect(req.header.Get("Content-Type")).To(Equal("application/x-ndjson"))
+			Expect(req.header.Get("Authorization")).To(Equal("Basic a3VtYTpzZWNyZXQ="))
+			Expect(req.body).To(Equal(
+				`{"index":{"_index":"access-logs"}}` + "\n" +
+					`{"dataplane":"backend-01","mesh":"demo","start_time":"2019-10-02T07:06:40.5Z"}` + "\n"))
+		})
+
+		It("should fail when some of the entries have not been indexed", func() {
+			// given
+			responseBody = `{"errors": true}`
+
+			// when
+			err := backend.Send(context.Background(), entries)
+
+			// then
+			Expect(err).To(MatchError("some of the entries have not been indexed"))
+		})
+	})
+
+	It("should refuse a backend without a type", func() {
+		// when
+		_, err := accesslog.NewBackend(&mesh_proto.Logging_Backend{Name: "unknown"}, secrets, http.DefaultClient)
+
+		// then
+		Expect(err).To(MatchError(`backend "unknown" has unsupported type`))
+	})
+
+	It("should refuse a Secret of another Mesh", func() {
+		// when
+		_, err := accesslog.NewBackend(&mesh_proto.Logging_Backend{
+			Name: "splunk",
+			Type: &mesh_proto.Logging_Backend_Splunk{
+				Splunk: &mesh_proto.Logging_SplunkHec{
+					Url:         server.URL,
+					TokenSecret: "logging.other",
+				},
+			},
+		}, secrets, http.DefaultClient)
+
+		// then
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(HavePrefix(`could not resolve HEC token of backend "splunk": could not get Secret "logging.other"`))
+	})
+})
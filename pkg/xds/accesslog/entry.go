@@ -0,0 +1,72 @@
+package accesslog
+
+import (
+	"net"
+	"strconv"
+	"time"
+
+	envoy_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	envoy_data "github.com/envoyproxy/go-control-plane/envoy/data/accesslog/v2"
+)
+
+// Entry is an access log entry in a form that is sent to backends.
+type Entry map[string]interface{}
+
+// Time returns the moment a connection (or a request) has started at.
+func (e Entry) Time() time.Time {
+	if t, ok := e["start_time"].(time.Time); ok {
+		return t
+	}
+	return time.Time{}
+}
+
+func TCPEntry(mesh, dataplane string, entry *envoy_data.TCPAccessLogEntry) Entry {
+	return commonEntry(mesh, dataplane, entry.GetCommonProperties())
+}
+
+func HTTPEntry(mesh, dataplane string, entry *envoy_data.HTTPAccessLogEntry) Entry {
+	e := commonEntry(mesh, dataplane, entry.GetCommonProperties())
+	if request := entry.GetRequest(); request != nil {
+		e["method"] = request.GetRequestMethod().String()
+		e["authority"] = request.GetAuthority()
+		e["path"] = request.GetPath()
+	}
+	if code := entry.GetResponse().GetResponseCode(); code != nil {
+		e["response_code"] = code.GetValue()
+	}
+	return e
+}
+
+func commonEntry(mesh, dataplane string, common *envoy_data.AccessLogCommon) Entry {
+	e := Entry{
+		"mesh":      mesh,
+		"dataplane": dataplane,
+	}
+	if common == nil {
+		return e
+	}
+	if common.StartTime != nil {
+		e["start_time"] = *common.StartTime
+	}
+	if common.TimeToLastDownstreamTxByte != nil {
+		e["duration_ms"] = common.TimeToLastDownstreamTxByte.Nanoseconds() / int64(time.Millisecond)
+	}
+	if address := socketAddress(common.GetDownstreamRemoteAddress()); address != "" {
+		e["downstream_remote_address"] = address
+	}
+	if address := socketAddress(common.GetUpstreamRemoteAddress()); address != "" {
+		e["upstream_remote_address"] = address
+	}
+	if cluster := common.GetUpstreamCluster(); cluster != "" {
+		e["upstream_cluster"] = cluster
+	}
+	return e
+}
+
+func socketAddress(address *envoy_core.Address) string {
+	socket := address.GetSocketAddress()
+	if socket == nil {
+		return ""
+	}
+	return net.JoinHostPort(socket.GetAddress(), strconv.Itoa(int(socket.GetPortValue())))
+}
@@ -0,0 +1,208 @@
+package accesslog
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	xds_config "github.com/Kong/kuma/pkg/config/xds"
+	"github.com/Kong/kuma/pkg/core"
+	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/manager"
+	"github.com/Kong/kuma/pkg/core/resources/store"
+	core_runtime "github.com/Kong/kuma/pkg/core/runtime"
+	secret_manager "github.com/Kong/kuma/pkg/core/secrets/manager"
+	util_http "github.com/Kong/kuma/pkg/util/http"
+)
+
+var (
+	forwarderLog = core.Log.WithName("xds-server").WithName("access-log-forwarder")
+)
+
+// Forwarder sends access log entries of Dataplanes to logging backends of their Mesh.
+//
+// Entries are sent in the background until the Forwarder is stopped.
+type Forwarder interface {
+	core_runtime.Component
+	// Forward queues entries to be sent to a given backend.
+	// Entries are dropped if the queue of the backend is full.
+	Forward(mesh string, backend string, entries ...Entry)
+}
+
+// BackendFactory builds a client of a logging backend of a given Mesh.
+type BackendFactory func(mesh string, spec *mesh_proto.Logging_Backend) (Backend, error)
+
+type forwarder struct {
+	resManager manager.ResourceManager
+	config     xds_config.AccessLogForwardingConfig
+	newBackend BackendFactory
+
+	mu       sync.Mutex
+	batchers map[backendKey]*batcher
+	stopped  bool
+	wg       sync.WaitGroup
+}
+
+type backendKey struct {
+	mesh string
+	name string
+}
+
+// Make sure that forwarder implements all relevant interfaces
+var (
+	_ Forwarder = &forwarder{}
+)
+
+func NewForwarder(resManager manager.ResourceManager, secretManager secret_manager.SecretManager, config xds_config.AccessLogForwardingConfig, transport http.RoundTripper) Forwarder {
+	client := util_http.ClientWithRetries(&http.Client{Transport: transport, Timeout: config.Timeout}, config.MaxRetries, config.RetryBackoff)
+	return NewForwarderWithBackends(resManager, config, func(mesh string, spec *mesh_proto.Logging_Backend) (Backend, error) {
+		return NewBackend(spec, MeshSecrets(context.Background(), secretManager, mesh), client)
+	})
+}
+
+func NewForwarderWithBackends(resManager manager.ResourceManager, config xds_config.AccessLogForwardingConfig, newBackend BackendFactory) Forwarder {
+	return &forwarder{
+		resManager: resManager,
+		config:     config,
+		newBackend: newBackend,
+		batchers:   map[backendKey]*batcher{},
+	}
+}
+
+func (f *forwarder) Forward(mesh string, backend string, entries ...Entry) {
+	b := f.batcher(backendKey{mesh: mesh, name: backend})
+	if b == nil {
+		return
+	}
+	dropped := 0
+	for _, entry := range entries {
+		select {
+		case b.entries <- entry:
+		default:
+			dropped++
+		}
+	}
+	if dropped > 0 {
+		forwarderLog.Info("queue of the backend is full, dropping entries", "mesh", mesh, "backend", backend, "dropped", dropped)
+	}
+}
+
+func (f *forwarder) batcher(key backendKey) *batcher {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.stopped {
+		return nil
+	}
+	if b, ok := f.batchers[key]; ok {
+		return b
+	}
+	b := &batcher{
+		key:     key,
+		entries: make(chan Entry, f.config.BufferSize),
+		done:    make(chan struct{}),
+		send:    f.send,
+	}
+	f.batchers[key] = b
+	f.wg.Add(1)
+	go func() {
+		defer f.wg.Done()
+		b.run(f.config.BatchSize, f.config.FlushInterval)
+	}()
+	return b
+}
+
+// Start blocks until the Control Plane is stopped and then sends entries that are still queued.
+func (f *forwarder) Start(stop <-chan struct{}) error {
+	<-stop
+	f.mu.Lock()
+	f.stopped = true
+	for _, b := range f.batchers {
+		close(b.done)
+	}
+	f.mu.Unlock()
+	f.wg.Wait()
+	return nil
+}
+
+// send resolves a backend on every batch, so that changes to the Mesh and to its Secrets take effect
+// without Dataplanes having to reconnect.
+func (f *forwarder) send(key backendKey, entries []Entry) error {
+	ctx := context.Background()
+	spec, err := findBackend(ctx, f.resManager, key.mesh, key.name)
+	if err != nil {
+		return err
+	}
+	backend, err := f.newBackend(key.mesh, spec)
+	if err != nil {
+		return err
+	}
+	return backend.Send(ctx, entries)
+}
+
+func findBackend(ctx context.Context, resManager manager.ResourceManager, mesh string, name string) (*mesh_proto.Logging_Backend, error) {
+	meshes := &mesh_core.MeshResourceList{}
+	if err := resManager.List(ctx, meshes, store.ListByMesh(mesh)); err != nil {
+		return nil, errors.Wrapf(err, "failed to find a Mesh %q", mesh)
+	}
+	if len(meshes.Items) != 1 {
+		return nil, errors.Errorf("there is no Mesh %q", mesh)
+	}
+	for _, backend := range meshes.Items[0].Spec.GetLogging().GetAccessLogs().GetBackends() {
+		if backend.GetName() == name {
+			return backend, nil
+		}
+	}
+	return nil, errors.Errorf("backend %q is not defined in Mesh %q", name, mesh)
+}
+
+// batcher collects entries of a single backend and sends them either once a batch is full
+// or once the flush interval has elapsed.
+type batcher struct {
+	key     backendKey
+	entries chan Entry
+	done    chan struct{}
+	send    func(backendKey, []Entry) error
+}
+
+func (b *batcher) run(batchSize int, flushInterval time.Duration) {
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+	batch := make([]Entry, 0, batchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := b.send(b.key, batch); err != nil {
+			forwarderLog.Error(err, "could not send entries to the backend", "mesh", b.key.mesh, "backend", b.key.name, "dropped", len(batch))
+		}
+		batch = make([]Entry, 0, batchSize)
+	}
+	for {
+		select {
+		case entry := <-b.entries:
+			batch = append(batch, entry)
+			if len(batch) == batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-b.done:
+			for {
+				select {
+				case entry := <-b.entries:
+					batch = append(batch, entry)
+					if len(batch) == batchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
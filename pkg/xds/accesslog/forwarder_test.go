@@ -0,0 +1,135 @@
+package accesslog_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	xds_config "github.com/Kong/kuma/pkg/config/xds"
+	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/manager"
+	"github.com/Kong/kuma/pkg/core/resources/store"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+	"github.com/Kong/kuma/pkg/xds/accesslog"
+)
+
+type batchRecorder struct {
+	batches chan []accesslog.Entry
+}
+
+func (r *batchRecorder) Send(_ context.Context, entries []accesslog.Entry) error {
+	r.batches <- entries
+	return nil
+}
+
+var _ = Describe("Forwarder", func() {
+
+	var recorder *batchRecorder
+	var resManager manager.ResourceManager
+	var stop chan struct{}
+	var done chan struct{}
+
+	start := func(config xds_config.AccessLogForwardingConfig) accesslog.Forwarder {
+		forwarder := accesslog.NewForwarderWithBackends(resManager, config, func(mesh string, spec *mesh_proto.Logging_Backend) (accesslog.Backend, error) {
+			Expect(mesh).To(Equal("demo"))
+			Expect(spec.GetName()).To(Equal("splunk"))
+			return recorder, nil
+		})
+		go func() {
+			defer GinkgoRecover()
+			defer close(done)
+			Expect(forwarder.Start(stop)).To(Succeed())
+		}()
+		return forwarder
+	}
+
+	BeforeEach(func() {
+		recorder = &batchRecorder{batches: make(chan []accesslog.Entry, 10)}
+		resManager = manager.NewResourceManager(memory.NewStore())
+		stop = make(chan struct{})
+		done = make(chan struct{})
+
+		mesh := &mesh_core.MeshResource{
+			Spec: mesh_proto.Mesh{
+				Logging: &mesh_proto.Logging{
+					AccessLogs: &mesh_proto.Logging_AccessLogs{
+						Enabled: true,
+						Backends: []*mesh_proto.Logging_Backend{
+							{
+								Name: "splunk",
+								Type: &mesh_proto.Logging_Backend_Splunk{
+									Splunk: &mesh_proto.Logging_SplunkHec{Url: "http://splunk:8088"},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		err := resManager.Create(context.Background(), mesh, store.CreateByKey("default", "demo", "demo"))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	entry := func(dataplane string) accesslog.Entry {
+		return accesslog.Entry{"mesh": "demo", "dataplane": dataplane}
+	}
+
+	It("should send entries in batches", func() {
+		// given
+		forwarder := start(xds_config.AccessLogForwardingConfig{
+			BatchSize:     2,
+			FlushInterval: time.Hour,
+			BufferSize:    10,
+		})
+
+		// when
+		forwarder.Forward("demo", "splunk", entry("dp-1"), entry("dp-2"), entry("dp-3"))
+
+		// then
+		Eventually(recorder.batches).Should(Receive(Equal([]accesslog.Entry{entry("dp-1"), entry("dp-2")})))
+		Consistently(recorder.batches).ShouldNot(Receive())
+
+		// when
+		close(stop)
+
+		// then remaining entries are sent on shutdown
+		Eventually(recorder.batches).Should(Receive(Equal([]accesslog.Entry{entry("dp-3")})))
+		Eventually(done).Should(BeClosed())
+	})
+
+	It("should send a partial batch once the flush interval has elapsed", func() {
+		// given
+		start(xds_config.AccessLogForwardingConfig{
+			BatchSize:     100,
+			FlushInterval: 10 * time.Millisecond,
+			BufferSize:    100,
+		}).Forward("demo", "splunk", entry("dp-1"))
+
+		// expect
+		Eventually(recorder.batches).Should(Receive(Equal([]accesslog.Entry{entry("dp-1")})))
+
+		// cleanup
+		close(stop)
+		Eventually(done).Should(BeClosed())
+	})
+
+	It("should drop entries of a backend that is not defined in the Mesh", func() {
+		// given
+		forwarder := start(xds_config.AccessLogForwardingConfig{
+			BatchSize:     1,
+			FlushInterval: time.Hour,
+			BufferSize:    10,
+		})
+
+		// when
+		forwarder.Forward("demo", "elasticsearch", entry("dp-1"))
+		close(stop)
+
+		// then
+		Eventually(done).Should(BeClosed())
+		Expect(recorder.batches).ToNot(Receive())
+	})
+})
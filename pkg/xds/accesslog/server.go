@@ -0,0 +1,76 @@
+package accesslog
+
+import (
+	"io"
+
+	envoy_accesslog "github.com/envoyproxy/go-control-plane/envoy/service/accesslog/v2"
+	"github.com/pkg/errors"
+
+	"github.com/Kong/kuma/pkg/core/resources/manager"
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
+	sds_auth "github.com/Kong/kuma/pkg/sds/auth"
+	xds_auth "github.com/Kong/kuma/pkg/xds/auth"
+)
+
+// server receives access log entries that Dataplanes stream over gRPC
+// and hands them over to a Forwarder.
+//
+// Streams are authenticated the same way as xDS streams, so that a Dataplane cannot
+// send entries on behalf of another one.
+type server struct {
+	resManager    manager.ResourceManager
+	forwarder     Forwarder
+	authenticator sds_auth.Authenticator
+}
+
+var _ envoy_accesslog.AccessLogServiceServer = &server{}
+
+// NewAccessLogServer returns a server of access logs. A nil authenticator means that authentication is turned off.
+func NewAccessLogServer(resManager manager.ResourceManager, forwarder Forwarder, authenticator sds_auth.Authenticator) envoy_accesslog.AccessLogServiceServer {
+	return &server{
+		resManager:    resManager,
+		forwarder:     forwarder,
+		authenticator: authenticator,
+	}
+}
+
+func (s *server) StreamAccessLogs(stream envoy_accesslog.AccessLogService_StreamAccessLogsServer) error {
+	var proxyId *core_xds.ProxyId
+	var backend string
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(&envoy_accesslog.StreamAccessLogsResponse{})
+		}
+		if err != nil {
+			return err
+		}
+		// identifier is only sent in the first message on the stream
+		if identifier := msg.GetIdentifier(); identifier != nil {
+			id, err := core_xds.ParseProxyId(identifier.GetNode())
+			if err != nil {
+				return errors.Wrap(err, "could not parse id of the Dataplane")
+			}
+			if s.authenticator != nil {
+				if err := xds_auth.AuthenticateNode(stream.Context(), s.resManager, s.authenticator, identifier.GetNode()); err != nil {
+					return err
+				}
+			}
+			if _, err := findBackend(stream.Context(), s.resManager, id.Mesh, identifier.GetLogName()); err != nil {
+				return err
+			}
+			proxyId, backend = id, identifier.GetLogName()
+		}
+		if proxyId == nil {
+			return errors.New("first message on the stream must identify the Dataplane")
+		}
+		var entries []Entry
+		for _, entry := range msg.GetTcpLogs().GetLogEntry() {
+			entries = append(entries, TCPEntry(proxyId.Mesh, proxyId.Name, entry))
+		}
+		for _, entry := range msg.GetHttpLogs().GetLogEntry() {
+			entries = append(entries, HTTPEntry(proxyId.Mesh, proxyId.Name, entry))
+		}
+		s.forwarder.Forward(proxyId.Mesh, backend, entries...)
+	}
+}
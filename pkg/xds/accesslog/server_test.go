@@ -0,0 +1,298 @@
+package accesslog_test
+
+import (
+	"context"
+	"io"
+	"time"
+
+	envoy_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	envoy_data "github.com/envoyproxy/go-control-plane/envoy/data/accesslog/v2"
+	envoy_accesslog "github.com/envoyproxy/go-control-plane/envoy/service/accesslog/v2"
+	"github.com/gogo/protobuf/types"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/manager"
+	"github.com/Kong/kuma/pkg/core/resources/store"
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+	sds_auth "github.com/Kong/kuma/pkg/sds/auth"
+	"github.com/Kong/kuma/pkg/xds/accesslog"
+)
+
+type forwardedEntries struct {
+	mesh    string
+	backend string
+	entries []accesslog.Entry
+}
+
+type recordingForwarder struct {
+	forwarded []forwardedEntries
+}
+
+func (f *recordingForwarder) Forward(mesh string, backend string, entries ...accesslog.Entry) {
+	f.forwarded = append(f.forwarded, forwardedEntries{mesh: mesh, backend: backend, entries: entries})
+}
+
+func (f *recordingForwarder) Start(<-chan struct{}) error {
+	return nil
+}
+
+type fakeStream struct {
+	grpc.ServerStream
+	messages []*envoy_accesslog.StreamAccessLogsMessage
+	closed   bool
+}
+
+func (s *fakeStream) Recv() (*envoy_accesslog.StreamAccessLogsMessage, error) {
+	if len(s.messages) == 0 {
+		return nil, io.EOF
+	}
+	msg := s.messages[0]
+	s.messages = s.messages[1:]
+	return msg, nil
+}
+
+func (s *fakeStream) SendAndClose(*envoy_accesslog.StreamAccessLogsResponse) error {
+	s.closed = true
+	return nil
+}
+
+func (s *fakeStream) Context() context.Context {
+	return context.Background()
+}
+
+// tokenAuthenticator accepts Dataplanes that present a given token.
+type tokenAuthenticator string
+
+func (t tokenAuthenticator) Authenticate(_ context.Context, proxyId core_xds.ProxyId, credential sds_auth.Credential) (sds_auth.Identity, error) {
+	if string(credential) != string(t) {
+		return sds_auth.Identity{}, errors.New("invalid token")
+	}
+	return sds_auth.Identity{Mesh: proxyId.Mesh}, nil
+}
+
+var _ = Describe("AccessLogServer", func() {
+
+	var resManager manager.ResourceManager
+	var forwarder *recordingForwarder
+	var server envoy_accesslog.AccessLogServiceServer
+
+	BeforeEach(func() {
+		resManager = manager.NewResourceManager(memory.NewStore())
+		mesh := &mesh_core.MeshResource{
+			Spec: mesh_proto.Mesh{
+				Logging: &mesh_proto.Logging{
+					AccessLogs: &mesh_proto.Logging_AccessLogs{
+						Enabled: true,
+						Backends: []*mesh_proto.Logging_Backend{
+							{Name: "splunk"},
+						},
+					},
+				},
+			},
+		}
+		err := resManager.Create(context.Background(), mesh, store.CreateByKey("default", "demo", "demo"))
+		Expect(err).ToNot(HaveOccurred())
+
+		forwarder = &recordingForwarder{}
+		server = accesslog.NewAccessLogServer(resManager, forwarder, nil)
+	})
+
+	identifier := func(logName string) *envoy_accesslog.StreamAccessLogsMessage_Identifier {
+		return &envoy_accesslog.StreamAccessLogsMessage_Identifier{
+			Node:    &envoy_core.Node{Id: "demo.backend-01"},
+			LogName: logName,
+		}
+	}
+
+	It("should forward entries to the backend named by the identifier", func() {
+		// given
+		startTime := time.Unix(1570000000, 0)
+		duration := 150 * time.Millisecond
+		stream := &fakeStream{
+			messages: []*envoy_accesslog.StreamAccessLogsMessage{
+				{
+					Identifier: identifier("splunk"),
+					LogEntries: &envoy_accesslog.StreamAccessLogsMessage_TcpLogs{
+						TcpLogs: &envoy_accesslog.StreamAccessLogsMessage_TCPAccessLogEntries{
+							LogEntry: []*envoy_data.TCPAccessLogEntry{
+								{
+									CommonProperties: &envoy_data.AccessLogCommon{
+										StartTime:                  &startTime,
+										TimeToLastDownstreamTxByte: &duration,
+										DownstreamRemoteAddress: &envoy_core.Address{
+											Address: &envoy_core.Address_SocketAddress{
+												SocketAddress: &envoy_core.SocketAddress{
+													Address:       "192.168.0.2",
+													PortSpecifier: &envoy_core.SocketAddress_PortValue{PortValue: 43210},
+												},
+											},
+										},
+										UpstreamCluster: "localhost:8080",
+									},
+								},
+							},
+						},
+					},
+				},
+				{
+					LogEntries: &envoy_accesslog.StreamAccessLogsMessage_HttpLogs{
+						HttpLogs: &envoy_accesslog.StreamAccessLogsMessage_HTTPAccessLogEntries{
+							LogEntry: []*envoy_data.HTTPAccessLogEntry{
+								{
+									Request: &envoy_data.HTTPRequestProperties{
+										RequestMethod: envoy_core.GET,
+										Authority:     "backend",
+										Path:          "/version",
+									},
+									Response: &envoy_data.HTTPResponseProperties{
+										ResponseCode: &types.UInt32Value{Value: 200},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		// when
+		err := server.StreamAccessLogs(stream)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stream.closed).To(BeTrue())
+		// and
+		Expect(forwarder.forwarded).To(Equal([]forwardedEntries{
+			{
+				mesh:    "demo",
+				backend: "splunk",
+				entries: []accesslog.Entry{
+					{
+						"mesh":                      "demo",
+						"dataplane":                 "backend-01",
+						"start_time":                startTime,
+						"duration_ms":               int64(150),
+						"downstream_remote_address": "192.168.0.2:43210",
+						"upstream_cluster":          "localhost:8080",
+					},
+				},
+			},
+			{
+				mesh:    "demo",
+				backend: "splunk",
+				entries: []accesslog.Entry{
+					{
+						"mesh":          "demo",
+						"dataplane":     "backend-01",
+						"method":        "GET",
+						"authority":     "backend",
+						"path":          "/version",
+						"response_code": uint32(200),
+					},
+				},
+			},
+		}))
+	})
+
+	It("should refuse a stream to a backend that is not defined in the Mesh", func() {
+		// given
+		stream := &fakeStream{
+			messages: []*envoy_accesslog.StreamAccessLogsMessage{
+				{Identifier: identifier("elasticsearch")},
+			},
+		}
+
+		// when
+		err := server.StreamAccessLogs(stream)
+
+		// then
+		Expect(err).To(MatchError(`backend "elasticsearch" is not defined in Mesh "demo"`))
+		Expect(forwarder.forwarded).To(BeEmpty())
+	})
+
+	It("should refuse a stream that doesn't start with an identifier", func() {
+		// given
+		stream := &fakeStream{
+			messages: []*envoy_accesslog.StreamAccessLogsMessage{
+				{},
+			},
+		}
+
+		// when
+		err := server.StreamAccessLogs(stream)
+
+		// then
+		Expect(err).To(MatchError("first message on the stream must identify the Dataplane"))
+	})
+
+	Describe("with authentication", func() {
+
+		BeforeEach(func() {
+			dataplane := &mesh_core.DataplaneResource{
+				Spec: mesh_proto.Dataplane{
+					Networking: &mesh_proto.Dataplane_Networking{
+						Inbound: []*mesh_proto.Dataplane_Networking_Inbound{{
+							Interface: "192.168.0.1:80:8080",
+							Tags:      map[string]string{"service": "backend"},
+						}},
+					},
+				},
+			}
+			err := resManager.Create(context.Background(), dataplane, store.CreateByKey("default", "backend-01", "demo"))
+			Expect(err).ToNot(HaveOccurred())
+
+			server = accesslog.NewAccessLogServer(resManager, forwarder, tokenAuthenticator("valid-token"))
+		})
+
+		withToken := func(token string) *envoy_accesslog.StreamAccessLogsMessage_Identifier {
+			id := identifier("splunk")
+			metadata := &core_xds.DataplaneMetadata{
+				Version: core_xds.MetadataVersion,
+				Mesh:    "demo",
+				Name:    "backend-01",
+				Token:   token,
+			}
+			id.Node.Metadata = metadata.ToStruct()
+			return id
+		}
+
+		It("should forward entries of an authenticated Dataplane", func() {
+			// given
+			stream := &fakeStream{
+				messages: []*envoy_accesslog.StreamAccessLogsMessage{
+					{Identifier: withToken("valid-token")},
+				},
+			}
+
+			// when
+			err := server.StreamAccessLogs(stream)
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(forwarder.forwarded).To(HaveLen(1))
+		})
+
+		It("should refuse a Dataplane with an invalid token", func() {
+			// given
+			stream := &fakeStream{
+				messages: []*envoy_accesslog.StreamAccessLogsMessage{
+					{Identifier: withToken("stolen-token")},
+				},
+			}
+
+			// when
+			err := server.StreamAccessLogs(stream)
+
+			// then
+			Expect(err).To(MatchError("invalid token"))
+			Expect(forwarder.forwarded).To(BeEmpty())
+		})
+	})
+})
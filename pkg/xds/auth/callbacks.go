@@ -0,0 +1,290 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/pkg/errors"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	"github.com/Kong/kuma/pkg/core"
+	core_errors "github.com/Kong/kuma/pkg/core/errors"
+	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
+	sds_auth "github.com/Kong/kuma/pkg/sds/auth"
+
+	envoy "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	envoy_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	envoy_xds "github.com/envoyproxy/go-control-plane/pkg/server"
+)
+
+var (
+	authLog = core.Log.WithName("xds-server").WithName("authentication")
+)
+
+const (
+	// rejectionRefreshInterval is how often the same rejection of a Dataplane is saved again.
+	rejectionRefreshInterval = time.Minute
+	// minRejectionInterval is how often a rejection of a Dataplane is saved at most, even if its code changes.
+	minRejectionInterval = 5 * time.Second
+	// maxPendingRejections is how many rejections are saved concurrently at most. Rejections beyond that are dropped.
+	maxPendingRejections = 10
+	// maxInsightUpdateAttempts defines how many times a rejection is saved if DataplaneInsight is changed concurrently.
+	maxInsightUpdateAttempts = 5
+)
+
+// rejectionMessages describe codes of rejections to a human.
+//
+// Messages of errors are not saved, since they may include text chosen by a client, e.g. a key ID of a token.
+var rejectionMessages = map[core_errors.Code]string{
+	core_errors.AuthenticationFailed: "credential presented by the Dataplane doesn't match its identity",
+	core_errors.TokenMissing:         "Dataplane has presented no token",
+	core_errors.TokenMalformed:       "token of the Dataplane is malformed",
+	core_errors.TokenInvalid:         "token of the Dataplane is not signed with a key that is accepted by its Mesh",
+	core_errors.TokenExpired:         "token of the Dataplane has expired",
+	core_errors.TokenRevoked:         "token of the Dataplane has been revoked",
+}
+
+// NewCallbacks returns xDS callbacks that authenticate every dataplane
+// before any configuration is sent to it.
+func NewCallbacks(resManager core_manager.ResourceManager, authenticator sds_auth.Authenticator) envoy_xds.Callbacks {
+	return &authCallbacks{
+		resManager:    resManager,
+		authenticator: authenticator,
+		pending:       make(chan struct{}, maxPendingRejections),
+		streams:       make(map[int64]*streamState),
+		rejections:    make(map[core_model.ResourceKey]savedRejection),
+	}
+}
+
+var _ envoy_xds.Callbacks = &authCallbacks{}
+
+type authCallbacks struct {
+	resManager    core_manager.ResourceManager
+	authenticator sds_auth.Authenticator
+	pending       chan struct{} // limits rejections that are being saved
+
+	mu         sync.Mutex // protects access to the fields below
+	streams    map[int64]*streamState
+	rejections map[core_model.ResourceKey]savedRejection
+}
+
+type savedRejection struct {
+	code core_errors.Code
+	time time.Time
+}
+
+type streamState struct {
+	ctx           context.Context
+	authenticated string // Node Id a stream has been authenticated for
+}
+
+// OnStreamOpen is called once an xDS stream is open with a stream ID and the type URL (or "" for ADS).
+// Returning an error will end processing and close the stream. OnStreamClosed will still be called.
+func (a *authCallbacks) OnStreamOpen(ctx context.Context, streamID int64, typ string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.streams[streamID] = &streamState{ctx: ctx}
+	return nil
+}
+
+// OnStreamClosed is called immediately prior to closing an xDS stream with a stream ID.
+func (a *authCallbacks) OnStreamClosed(streamID int64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	delete(a.streams, streamID)
+}
+
+// OnStreamRequest is called once a request is received on a stream.
+// Returning an error will end processing and close the stream. OnStreamClosed will still be called.
+func (a *authCallbacks) OnStreamRequest(streamID int64, req *envoy.DiscoveryRequest) error {
+	nodeId := req.GetNode().GetId()
+
+	a.mu.Lock()
+	state, ok := a.streams[streamID]
+	authenticated := ok && state.authenticated != "" && state.authenticated == nodeId
+	a.mu.Unlock()
+	if !ok {
+		return errors.Errorf("xDS stream %d has not been open", streamID)
+	}
+	if authenticated {
+		return nil
+	}
+	if err := a.authenticate(state.ctx, req); err != nil {
+		authLog.Error(err, "authentication failed", "streamID", streamID, "nodeId", nodeId, "code", core_errors.CodeOf(err))
+		a.recordRejection(req, err)
+		return err
+	}
+
+	a.mu.Lock()
+	state.authenticated = nodeId
+	a.mu.Unlock()
+	return nil
+}
+
+// OnStreamResponse is called immediately prior to sending a response on a stream.
+func (a *authCallbacks) OnStreamResponse(streamID int64, req *envoy.DiscoveryRequest, resp *envoy.DiscoveryResponse) {
+}
+
+// OnFetchRequest is called for each Fetch request. Returning an error will end processing of the
+// request and respond with an error.
+func (a *authCallbacks) OnFetchRequest(ctx context.Context, req *envoy.DiscoveryRequest) error {
+	if err := a.authenticate(ctx, req); err != nil {
+		authLog.Error(err, "authentication failed", "nodeId", req.GetNode().GetId(), "code", core_errors.CodeOf(err))
+		a.recordRejection(req, err)
+		return err
+	}
+	return nil
+}
+
+// OnFetchResponse is called immediately prior to sending a response.
+func (a *authCallbacks) OnFetchResponse(*envoy.DiscoveryRequest, *envoy.DiscoveryResponse) {
+}
+
+// authenticate returns an error annotated with a code of the reason why a Dataplane has been rejected.
+func (a *authCallbacks) authenticate(ctx context.Context, req *envoy.DiscoveryRequest) error {
+	return AuthenticateNode(ctx, a.resManager, a.authenticator, req.Node)
+}
+
+// AuthenticateNode verifies that Envoy is entitled to the identity of a Dataplane it claims in its node,
+// given a credential either in node metadata or in gRPC headers of a stream.
+//
+// The returned error is annotated with a code of the reason why a Dataplane has been rejected.
+func AuthenticateNode(ctx context.Context, resManager core_manager.ResourceManager, authenticator sds_auth.Authenticator, node *envoy_core.Node) error {
+	proxyId, err := core_xds.ParseProxyId(node)
+	if err != nil {
+		return core_errors.WithCode(errors.Wrap(err, "authentication failed: xDS request must have a valid Proxy Id"), core_errors.InvalidProxyId)
+	}
+	credential, err := ExtractCredential(ctx)
+	if err != nil {
+		return core_errors.WithCode(errors.Wrap(err, "authentication failed"), core_errors.AuthenticationFailed)
+	}
+	// a token in node metadata takes precedence over the one in gRPC headers,
+	// which is still presented by Envoys launched by older versions of kuma-dp
+	metadata, err := core_xds.ParseDataplaneMetadata(node)
+	if err != nil {
+		return core_errors.WithCode(errors.Wrap(err, "authentication failed"), core_errors.AuthenticationFailed)
+	}
+	if metadata != nil && metadata.Token != "" {
+		credential = sds_auth.Credential(metadata.Token)
+	}
+	// a missing Dataplane is told apart from an invalid credential, since there is nothing to record a rejection for
+	dataplane := &core_mesh.DataplaneResource{}
+	if err := resManager.Get(ctx, dataplane, core_store.GetBy(proxyId.ToResourceKey())); err != nil {
+		code := core_errors.Internal
+		if core_store.IsResourceNotFound(err) {
+			code = core_errors.DataplaneNotFound
+		}
+		return core_errors.WithCode(errors.Wrapf(err, "authentication failed: unable to find Dataplane for proxy %q", proxyId), code)
+	}
+	// the most specific code, e.g. TOKEN_EXPIRED, is kept by CodeOf()
+	_, err = authenticator.Authenticate(ctx, *proxyId, credential)
+	return core_errors.WithCode(err, core_errors.AuthenticationFailed)
+}
+
+// recordRejection saves the reason why a Dataplane has been rejected in its DataplaneInsight,
+// so that it can be seen without access to logs of Control Plane.
+//
+// Envoy retries a rejected stream every few seconds and anyone can claim to be a Dataplane,
+// so a rejection is saved in the background, at most once per minRejectionInterval,
+// and the same code is saved again only once per rejectionRefreshInterval.
+func (a *authCallbacks) recordRejection(req *envoy.DiscoveryRequest, rejection error) {
+	code := core_errors.CodeOf(rejection)
+	switch code {
+	case core_errors.InvalidProxyId, core_errors.DataplaneNotFound, core_errors.Internal:
+		// there is no Dataplane to record the rejection for
+		return
+	}
+	proxyId, err := core_xds.ParseProxyId(req.Node)
+	if err != nil {
+		return
+	}
+	key := proxyId.ToResourceKey()
+	now := core.Now()
+	if !a.reserveRejection(key, code, now) {
+		return
+	}
+	select {
+	case a.pending <- struct{}{}:
+	default:
+		authLog.Info("too many rejections are being saved, dropping a rejection", "nodeId", req.Node.Id, "code", code)
+		return
+	}
+	go func() {
+		defer func() { <-a.pending }()
+		if err := a.saveRejection(key, code, now); err != nil {
+			authLog.Error(err, "unable to record a rejection", "nodeId", req.Node.Id)
+		}
+	}()
+}
+
+// reserveRejection returns true if a rejection of a Dataplane with a given code is due to be saved.
+func (a *authCallbacks) reserveRejection(key core_model.ResourceKey, code core_errors.Code, now time.Time) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if last, ok := a.rejections[key]; ok {
+		interval := minRejectionInterval
+		if last.code == code {
+			interval = rejectionRefreshInterval
+		}
+		if now.Sub(last.time) < interval {
+			return false
+		}
+	}
+	// forget Dataplanes that have not been rejected lately, e.g. deleted ones
+	for k, last := range a.rejections {
+		if now.Sub(last.time) >= rejectionRefreshInterval {
+			delete(a.rejections, k)
+		}
+	}
+	a.rejections[key] = savedRejection{code: code, time: now}
+	return true
+}
+
+func (a *authCallbacks) saveRejection(key core_model.ResourceKey, code core_errors.Code, now time.Time) error {
+	timestamp, err := types.TimestampProto(now)
+	if err != nil {
+		return err
+	}
+	message, ok := rejectionMessages[code]
+	if !ok {
+		message = "Dataplane has been rejected"
+	}
+	rejection := &mesh_proto.XdsRejection{
+		Code:    string(code),
+		Message: message,
+		Time:    timestamp,
+	}
+	for attempt := 1; ; attempt++ {
+		err := a.updateInsight(key, rejection)
+		// DataplaneInsight has been created or modified in the meantime, e.g. by the status sink, apply the rejection to the latest version
+		if (core_store.IsResourceConflict(err) || core_store.IsResourceAlreadyExists(err)) && attempt < maxInsightUpdateAttempts {
+			continue
+		}
+		return err
+	}
+}
+
+func (a *authCallbacks) updateInsight(key core_model.ResourceKey, rejection *mesh_proto.XdsRejection) error {
+	ctx := context.Background()
+	create := false
+	insight := &core_mesh.DataplaneInsightResource{}
+	if err := a.resManager.Get(ctx, insight, core_store.GetBy(key)); err != nil {
+		if !core_store.IsResourceNotFound(err) {
+			return err
+		}
+		create = true
+	}
+	insight.Spec.LastRejection = rejection
+	if create {
+		return a.resManager.Create(ctx, insight, core_store.CreateBy(key))
+	}
+	return a.resManager.Update(ctx, insight)
+}
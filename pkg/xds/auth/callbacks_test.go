@@ -0,0 +1,240 @@
+package auth_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"google.golang.org/grpc/metadata"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	"github.com/Kong/kuma/pkg/core"
+	core_errors "github.com/Kong/kuma/pkg/core/errors"
+	core_mesh "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
+	memory_resources "github.com/Kong/kuma/pkg/plugins/resources/memory"
+	sds_auth "github.com/Kong/kuma/pkg/sds/auth"
+
+	. "github.com/Kong/kuma/pkg/xds/auth"
+
+	envoy "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	envoy_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	envoy_xds "github.com/envoyproxy/go-control-plane/pkg/server"
+)
+
+type testAuthenticator struct {
+	calls    int
+	expected sds_auth.Credential
+	err      error
+}
+
+func (t *testAuthenticator) Authenticate(ctx context.Context, proxyId core_xds.ProxyId, credential sds_auth.Credential) (sds_auth.Identity, error) {
+	t.calls++
+	if t.err != nil {
+		return sds_auth.Identity{}, t.err
+	}
+	if credential != t.expected {
+		return sds_auth.Identity{}, errors.New("invalid credential")
+	}
+	return sds_auth.Identity{Mesh: proxyId.Mesh}, nil
+}
+
+var _ = Describe("Auth Callbacks", func() {
+
+	var authenticator *testAuthenticator
+	var callbacks envoy_xds.Callbacks
+	var resManager core_manager.ResourceManager
+
+	req := &envoy.DiscoveryRequest{
+		Node: &envoy_core.Node{
+			Id: "default.web-01",
+		},
+	}
+
+	BeforeEach(func() {
+		resManager = core_manager.NewResourceManager(memory_resources.NewStore())
+		err := resManager.Create(context.Background(), &core_mesh.MeshResource{}, core_store.CreateByKey("default", "default", "default"))
+		Expect(err).ToNot(HaveOccurred())
+		dataplane := &core_mesh.DataplaneResource{
+			Spec: mesh_proto.Dataplane{
+				Networking: &mesh_proto.Dataplane_Networking{
+					Inbound: []*mesh_proto.Dataplane_Networking_Inbound{
+						{
+							Interface: "192.168.0.1:80:8080",
+							Tags: map[string]string{
+								"service": "web",
+							},
+						},
+					},
+				},
+			},
+		}
+		err = resManager.Create(context.Background(), dataplane, core_store.CreateByKey("default", "web-01", "default"))
+		Expect(err).ToNot(HaveOccurred())
+
+		authenticator = &testAuthenticator{expected: "pass"}
+		callbacks = NewCallbacks(resManager, authenticator)
+	})
+
+	withCredential := func(credential string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", credential))
+	}
+
+	It("should authenticate a stream only once", func() {
+		// given
+		streamID := int64(1)
+		Expect(callbacks.OnStreamOpen(withCredential("pass"), streamID, "")).To(Succeed())
+
+		// when
+		err := callbacks.OnStreamRequest(streamID, req)
+		// then
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		err = callbacks.OnStreamRequest(streamID, req)
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(authenticator.calls).To(Equal(1))
+	})
+
+	It("should reject a stream with invalid credential", func() {
+		// given
+		streamID := int64(1)
+		Expect(callbacks.OnStreamOpen(withCredential("fail"), streamID, "")).To(Succeed())
+
+		// when
+		err := callbacks.OnStreamRequest(streamID, req)
+
+		// then
+		Expect(err).To(MatchError("invalid credential"))
+	})
+
+	It("should reject a stream of unknown Dataplane", func() {
+		// given
+		streamID := int64(1)
+		Expect(callbacks.OnStreamOpen(withCredential("pass"), streamID, "")).To(Succeed())
+
+		// when
+		err := callbacks.OnStreamRequest(streamID, &envoy.DiscoveryRequest{
+			Node: &envoy_core.Node{
+				Id: "default.unknown",
+			},
+		})
+
+		// then
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(`unable to find Dataplane for proxy`))
+		Expect(core_errors.CodeOf(err)).To(Equal(core_errors.DataplaneNotFound))
+		Expect(authenticator.calls).To(Equal(0))
+	})
+
+	It("should prefer a token from node metadata", func() {
+		// given
+		streamID := int64(1)
+		Expect(callbacks.OnStreamOpen(withCredential("fail"), streamID, "")).To(Succeed())
+		metadata := &core_xds.DataplaneMetadata{
+			Version: core_xds.MetadataVersion,
+			Mesh:    "default",
+			Name:    "web-01",
+			Token:   "pass",
+		}
+
+		// when
+		err := callbacks.OnStreamRequest(streamID, &envoy.DiscoveryRequest{
+			Node: &envoy_core.Node{
+				Id:       "default.web-01",
+				Metadata: metadata.ToStruct(),
+			},
+		})
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should reject a Fetch request with invalid credential", func() {
+		// when
+		err := callbacks.OnFetchRequest(withCredential("fail"), req)
+
+		// then
+		Expect(err).To(MatchError("invalid credential"))
+	})
+
+	Describe("rejections", func() {
+
+		var now time.Time
+
+		BeforeEach(func() {
+			now = time.Now()
+			core.Now = func() time.Time {
+				return now
+			}
+		})
+
+		AfterEach(func() {
+			core.Now = time.Now
+		})
+
+		lastRejection := func() *mesh_proto.XdsRejection {
+			insight := &core_mesh.DataplaneInsightResource{}
+			if err := resManager.Get(context.Background(), insight, core_store.GetByKey("default", "web-01", "default")); err != nil {
+				return nil
+			}
+			return insight.Spec.LastRejection
+		}
+
+		It("should record rejections in DataplaneInsight", func() {
+			// given
+			streamID := int64(1)
+			Expect(callbacks.OnStreamOpen(withCredential("fail"), streamID, "")).To(Succeed())
+
+			// when
+			err := callbacks.OnStreamRequest(streamID, req)
+
+			// then
+			Expect(core_errors.CodeOf(err)).To(Equal(core_errors.AuthenticationFailed))
+			Eventually(lastRejection).ShouldNot(BeNil())
+			Expect(lastRejection().Code).To(Equal("AUTHENTICATION_FAILED"))
+			Expect(lastRejection().Message).To(Equal("credential presented by the Dataplane doesn't match its identity"))
+			Expect(lastRejection().Time).ToNot(BeNil())
+			insight := &core_mesh.DataplaneInsightResource{}
+			Expect(resManager.Get(context.Background(), insight, core_store.GetByKey("default", "web-01", "default"))).To(Succeed())
+			version := insight.Meta.GetVersion()
+
+			// when Envoy retries
+			Expect(callbacks.OnStreamRequest(streamID, req)).ToNot(Succeed())
+
+			// then the same rejection is not saved again
+			Consistently(func() string {
+				Expect(resManager.Get(context.Background(), insight, core_store.GetByKey("default", "web-01", "default"))).To(Succeed())
+				return insight.Meta.GetVersion()
+			}, "100ms").Should(Equal(version))
+
+			// when a token chosen by a client is rejected for another reason
+			authenticator.err = core_errors.WithCode(errors.New("token is signed with an unknown key <key-id-chosen-by-client>"), core_errors.TokenInvalid)
+			err = callbacks.OnStreamRequest(streamID, req)
+
+			// then it is not saved right away
+			Expect(core_errors.CodeOf(err)).To(Equal(core_errors.TokenInvalid))
+			Consistently(func() string {
+				return lastRejection().Code
+			}, "100ms").Should(Equal("AUTHENTICATION_FAILED"))
+
+			// when Envoy retries later
+			now = now.Add(5 * time.Second)
+			Expect(callbacks.OnStreamRequest(streamID, req)).ToNot(Succeed())
+
+			// then
+			Eventually(func() string {
+				return lastRejection().Code
+			}).Should(Equal("TOKEN_INVALID"))
+			// and text chosen by a client is not saved
+			Expect(lastRejection().Message).To(Equal("token of the Dataplane is not signed with a key that is accepted by its Mesh"))
+		})
+	})
+})
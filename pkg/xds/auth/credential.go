@@ -0,0 +1,30 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"google.golang.org/grpc/metadata"
+
+	sds_auth "github.com/Kong/kuma/pkg/sds/auth"
+)
+
+const (
+	authorization = "authorization"
+)
+
+// ExtractCredential returns a credential from gRPC headers of an xDS stream, or an empty one if there is none.
+func ExtractCredential(ctx context.Context) (sds_auth.Credential, error) {
+	metadata, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", nil
+	}
+	if values, ok := metadata[authorization]; ok {
+		if len(values) != 1 {
+			return "", errors.Errorf("xDS request must have exactly 1 %q header, got %d", authorization, len(values))
+		}
+		return sds_auth.Credential(values[0]), nil
+	}
+	return "", nil
+}
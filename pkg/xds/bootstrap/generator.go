@@ -3,44 +3,103 @@ package bootstrap
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"github.com/Kong/kuma/pkg/xds/bootstrap/rest"
+	"io/ioutil"
+	"net"
+	"sort"
+	"strings"
 	"text/template"
+	"time"
 
 	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
 	xds_config "github.com/Kong/kuma/pkg/config/xds"
+	dataplane_managers "github.com/Kong/kuma/pkg/core/managers/apis/dataplane"
 	"github.com/Kong/kuma/pkg/core/resources/apis/mesh"
 	"github.com/Kong/kuma/pkg/core/resources/manager"
 	"github.com/Kong/kuma/pkg/core/resources/store"
 	"github.com/Kong/kuma/pkg/core/xds"
+	"github.com/Kong/kuma/pkg/tokens/builtin/issuer"
 	util_proto "github.com/Kong/kuma/pkg/util/proto"
+	envoy_api "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	envoy_auth "github.com/envoyproxy/go-control-plane/envoy/api/v2/auth"
+	envoy_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	envoy_endpoint "github.com/envoyproxy/go-control-plane/envoy/api/v2/endpoint"
 	envoy_bootstrap "github.com/envoyproxy/go-control-plane/envoy/config/bootstrap/v2"
+	envoy_metrics "github.com/envoyproxy/go-control-plane/envoy/config/metrics/v2"
+	envoy_trace "github.com/envoyproxy/go-control-plane/envoy/config/trace/v2"
+	"github.com/envoyproxy/go-control-plane/pkg/util"
 	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
 	"github.com/pkg/errors"
 )
 
 type BootstrapGenerator interface {
-	Generate(ctx context.Context, request rest.BootstrapRequest) (proto.Message, error)
+	// Generate returns a bootstrap config of a dataplane.
+	// The token is only required if the dataplane registers its inbound interfaces.
+	Generate(ctx context.Context, request rest.BootstrapRequest, token issuer.Token) (proto.Message, error)
 }
 
+// XdsClientCert defines how dataplanes present a client certificate to XDS Server.
+type XdsClientCert struct {
+	// Required means that XDS Server doesn't accept dataplanes without a client certificate.
+	Required bool
+	// Sds is a certificate served by SDS Server, e.g. an identity of a dataplane in its Mesh.
+	// It is presented by dataplanes that don't have a certificate of their own.
+	Sds *envoy_auth.SdsSecretConfig
+}
+
+// NewDefaultBootstrapGenerator returns a generator of bootstrap configs.
+//
+// Meshes are looked up in meshNamespace, which is the system namespace on Kubernetes.
+// Dataplanes can only register their inbound interfaces if tokenIssuer is not nil,
+// which should never be the case on Kubernetes, where Dataplanes are created out of Pods.
 func NewDefaultBootstrapGenerator(
 	resManager manager.ResourceManager,
-	config *xds_config.BootstrapParamsConfig) BootstrapGenerator {
+	config *xds_config.BootstrapParamsConfig,
+	meshNamespace string,
+	tokenIssuer issuer.DataplaneTokenIssuer,
+	xdsClientCert XdsClientCert) BootstrapGenerator {
 	return &bootstrapGenerator{
-		resManager: resManager,
-		config:     config,
+		resManager:    resManager,
+		registry:      dataplane_managers.NewRegistry(resManager),
+		config:        config,
+		meshNamespace: meshNamespace,
+		tokenIssuer:   tokenIssuer,
+		xdsClientCert: xdsClientCert,
 	}
 }
 
 type bootstrapGenerator struct {
-	resManager manager.ResourceManager
-	config     *xds_config.BootstrapParamsConfig
+	resManager    manager.ResourceManager
+	registry      *dataplane_managers.Registry
+	config        *xds_config.BootstrapParamsConfig
+	meshNamespace string
+	// tokenIssuer validates tokens of dataplanes that register their inbound interfaces
+	tokenIssuer   issuer.DataplaneTokenIssuer
+	xdsClientCert XdsClientCert
 }
 
-func (b *bootstrapGenerator) Generate(ctx context.Context, request rest.BootstrapRequest) (proto.Message, error) {
+func (b *bootstrapGenerator) Generate(ctx context.Context, request rest.BootstrapRequest, token issuer.Token) (proto.Message, error) {
 	proxyId, err := xds.BuildProxyId(request.Mesh, request.Name)
 	if err != nil {
 		return nil, err
 	}
+	if (request.ClientCertFile == "") != (request.ClientKeyFile == "") {
+		return nil, &InvalidRequestError{Reason: "clientCertFile and clientKeyFile must be set together"}
+	}
+	if len(request.Inbounds) > 0 {
+		if b.tokenIssuer == nil {
+			return nil, &ForbiddenRequestError{Reason: "registration of inbound interfaces is turned off"}
+		}
+		// a dataplane can only register its own inbound interfaces
+		if err := issuer.Authenticate(ctx, b.tokenIssuer, token, proxyId.Mesh, proxyId.Name); err != nil {
+			return nil, &UnauthorizedRequestError{Reason: err.Error()}
+		}
+		if err := b.registerInbounds(ctx, proxyId, request.Inbounds); err != nil {
+			return nil, err
+		}
+	}
 	dataplane, err := b.fetchDataplane(ctx, proxyId)
 	if err != nil {
 		return nil, err
@@ -63,9 +122,279 @@ func (b *bootstrapGenerator) Generate(ctx context.Context, request rest.Bootstra
 		AdminPort: adminPort,
 		XdsHost:   b.config.XdsHost,
 		XdsPort:   b.config.XdsPort,
+		XdsDelta:  b.config.XdsDelta,
 	}
 	log.WithValues("params", params).Info("Generating bootstrap config")
-	return b.ConfigForParameters(params)
+	config, err := b.ConfigForParameters(params)
+	if err != nil {
+		return nil, err
+	}
+	meshRes, err := b.fetchMesh(ctx, proxyId.Mesh)
+	if err != nil {
+		return nil, err
+	}
+	sinks, err := statsSinks(meshRes)
+	if err != nil {
+		return nil, err
+	}
+	config.StatsSinks = append(config.StatsSinks, sinks...)
+	if b.config.XdsCaFile != "" {
+		tlsContext, err := b.adsTlsContext(request)
+		if err != nil {
+			return nil, err
+		}
+		for i := range config.StaticResources.Clusters {
+			if config.StaticResources.Clusters[i].Name == AdsClusterName {
+				config.StaticResources.Clusters[i].TlsContext = tlsContext
+			}
+		}
+	}
+	tracing, collector, err := tracing(meshRes, service)
+	if err != nil {
+		return nil, err
+	}
+	if tracing != nil {
+		config.Tracing = tracing
+		config.StaticResources.Clusters = append(config.StaticResources.Clusters, *collector)
+	}
+	if tags := statsTags(dataplane.GetMeta().GetLabels()); len(tags) > 0 {
+		config.StatsConfig = &envoy_metrics.StatsConfig{
+			StatsTags: tags,
+		}
+	}
+	if err := config.Validate(); err != nil {
+		return nil, errors.Wrap(err, "Envoy bootstrap config is not valid")
+	}
+	return config, nil
+}
+
+// fetchMesh returns a Mesh of a given name or nil if there is no such Mesh yet.
+func (b *bootstrapGenerator) fetchMesh(ctx context.Context, meshName string) (*mesh.MeshResource, error) {
+	meshRes := mesh.MeshResource{}
+	if err := b.resManager.Get(ctx, &meshRes, store.GetByKey(b.meshNamespace, meshName, meshName)); err != nil {
+		if store.IsResourceNotFound(err) {
+			// bootstrap config can still be generated, settings of the Mesh will be picked up on restart of the dataplane
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &meshRes, nil
+}
+
+// adsTlsContext returns TLS settings that Envoy connects to XDS Server with.
+//
+// A dataplane presents its own client certificate if it has one, otherwise the one served by SDS, if any.
+func (b *bootstrapGenerator) adsTlsContext(request rest.BootstrapRequest) (*envoy_auth.UpstreamTlsContext, error) {
+	caPEM, err := ioutil.ReadFile(b.config.XdsCaFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read CA certs of XDS Server from %q", b.config.XdsCaFile)
+	}
+	tlsContext := &envoy_auth.UpstreamTlsContext{
+		CommonTlsContext: &envoy_auth.CommonTlsContext{
+			ValidationContextType: &envoy_auth.CommonTlsContext_ValidationContext{
+				ValidationContext: &envoy_auth.CertificateValidationContext{
+					TrustedCa: &envoy_core.DataSource{
+						Specifier: &envoy_core.DataSource_InlineBytes{InlineBytes: caPEM},
+					},
+				},
+			},
+		},
+	}
+	if net.ParseIP(b.config.XdsHost) == nil {
+		// SNI must not be an IP address
+		tlsContext.Sni = b.config.XdsHost
+	}
+	switch {
+	case request.ClientCertFile != "":
+		tlsContext.CommonTlsContext.TlsCertificates = []*envoy_auth.TlsCertificate{{
+			CertificateChain: &envoy_core.DataSource{
+				Specifier: &envoy_core.DataSource_Filename{Filename: request.ClientCertFile},
+			},
+			PrivateKey: &envoy_core.DataSource{
+				Specifier: &envoy_core.DataSource_Filename{Filename: request.ClientKeyFile},
+			},
+		}}
+	case b.xdsClientCert.Sds != nil:
+		tlsContext.CommonTlsContext.TlsCertificateSdsSecretConfigs = []*envoy_auth.SdsSecretConfig{b.xdsClientCert.Sds}
+	case b.xdsClientCert.Required:
+		return nil, &InvalidRequestError{Reason: "XDS Server requires a client certificate, set a certificate of the dataplane with --client-cert-path and --client-key-path"}
+	}
+	return tlsContext, nil
+}
+
+// statsSinks returns sinks that Envoy sends its metrics to according to metrics settings of a given Mesh.
+func statsSinks(meshRes *mesh.MeshResource) ([]*envoy_metrics.StatsSink, error) {
+	if meshRes == nil {
+		return nil, nil
+	}
+	meshName := meshRes.Meta.GetName()
+	metrics := meshRes.Spec.GetMetrics()
+	var sinks []*envoy_metrics.StatsSink
+	if statsd := metrics.GetStatsd(); statsd != nil {
+		address, err := udpAddress(statsd.Address)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid address of StatsD sink in Mesh %q", meshName)
+		}
+		sink, err := statsSink(util.Statsd, &envoy_metrics.StatsdSink{
+			StatsdSpecifier: &envoy_metrics.StatsdSink_Address{Address: address},
+			Prefix:          statsd.Prefix,
+		})
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	if dogstatsd := metrics.GetDogstatsd(); dogstatsd != nil {
+		address, err := udpAddress(dogstatsd.Address)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid address of DogStatsD sink in Mesh %q", meshName)
+		}
+		sink, err := statsSink(util.DogStatsd, &envoy_metrics.DogStatsdSink{
+			DogStatsdSpecifier: &envoy_metrics.DogStatsdSink_Address{Address: address},
+			Prefix:             dogstatsd.Prefix,
+		})
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	return sinks, nil
+}
+
+// tracing returns configuration of a tracer according to tracing settings of a given Mesh
+// along with a static cluster that traces are sent to.
+func tracing(meshRes *mesh.MeshResource, service string) (*envoy_trace.Tracing, *envoy_api.Cluster, error) {
+	if meshRes == nil {
+		return nil, nil, nil
+	}
+	datadog := meshRes.Spec.GetTracing().GetDatadog()
+	if datadog == nil {
+		return nil, nil, nil
+	}
+	collector, err := tcpCluster(DatadogCollectorClusterName, datadog.Address)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "invalid address of Datadog agent in Mesh %q", meshRes.Meta.GetName())
+	}
+	serviceName := datadog.Service
+	if serviceName == "" {
+		serviceName = service
+	}
+	typedConfig, err := types.MarshalAny(&envoy_trace.DatadogConfig{
+		CollectorCluster: DatadogCollectorClusterName,
+		ServiceName:      serviceName,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return &envoy_trace.Tracing{
+		Http: &envoy_trace.Tracing_Http{
+			Name:       DatadogTracerName,
+			ConfigType: &envoy_trace.Tracing_Http_TypedConfig{TypedConfig: typedConfig},
+		},
+	}, collector, nil
+}
+
+// tcpCluster returns a cluster that resolves an address in the format <HOST>:<PORT>.
+func tcpCluster(name string, address string) (*envoy_api.Cluster, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	portValue, err := mesh_proto.ParsePort(port)
+	if err != nil {
+		return nil, err
+	}
+	return &envoy_api.Cluster{
+		Name:                 name,
+		ConnectTimeout:       250 * time.Millisecond,
+		ClusterDiscoveryType: &envoy_api.Cluster_Type{Type: envoy_api.Cluster_STRICT_DNS},
+		LoadAssignment: &envoy_api.ClusterLoadAssignment{
+			ClusterName: name,
+			Endpoints: []envoy_endpoint.LocalityLbEndpoints{{
+				LbEndpoints: []envoy_endpoint.LbEndpoint{{
+					HostIdentifier: &envoy_endpoint.LbEndpoint_Endpoint{
+						Endpoint: &envoy_endpoint.Endpoint{
+							Address: &envoy_core.Address{
+								Address: &envoy_core.Address_SocketAddress{
+									SocketAddress: &envoy_core.SocketAddress{
+										Protocol:      envoy_core.TCP,
+										Address:       host,
+										PortSpecifier: &envoy_core.SocketAddress_PortValue{PortValue: portValue},
+									},
+								},
+							},
+						},
+					},
+				}},
+			}},
+		},
+	}, nil
+}
+
+// statsTags turns labels of a Dataplane into tags of all metrics of its Envoy,
+// so that metrics can be sliced e.g. by team or cost center.
+func statsTags(labels map[string]string) []*envoy_metrics.TagSpecifier {
+	var keys []string
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var tags []*envoy_metrics.TagSpecifier
+	for _, key := range keys {
+		tags = append(tags, &envoy_metrics.TagSpecifier{
+			TagName: tagName(key),
+			TagValue: &envoy_metrics.TagSpecifier_FixedValue{
+				FixedValue: labels[key],
+			},
+		})
+	}
+	return tags
+}
+
+// tagName turns a label key like `kuma.io/team` into a valid name of Prometheus label, i.e. `kuma_io_team`.
+func tagName(key string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') {
+			return r
+		}
+		return '_'
+	}, key)
+}
+
+func statsSink(name string, config proto.Message) (*envoy_metrics.StatsSink, error) {
+	typedConfig, err := types.MarshalAny(config)
+	if err != nil {
+		return nil, err
+	}
+	return &envoy_metrics.StatsSink{
+		Name:       name,
+		ConfigType: &envoy_metrics.StatsSink_TypedConfig{TypedConfig: typedConfig},
+	}, nil
+}
+
+// udpAddress parses an address in the format <IP>:<PORT>.
+// Envoy doesn't resolve hostnames of StatsD servers.
+func udpAddress(text string) (*envoy_core.Address, error) {
+	host, port, err := net.SplitHostPort(text)
+	if err != nil {
+		return nil, err
+	}
+	if net.ParseIP(host) == nil {
+		return nil, errors.Errorf("%q is not a valid IP address", host)
+	}
+	portValue, err := mesh_proto.ParsePort(port)
+	if err != nil {
+		return nil, err
+	}
+	return &envoy_core.Address{
+		Address: &envoy_core.Address_SocketAddress{
+			SocketAddress: &envoy_core.SocketAddress{
+				Protocol:      envoy_core.UDP,
+				Address:       host,
+				PortSpecifier: &envoy_core.SocketAddress_PortValue{PortValue: portValue},
+			},
+		},
+	}, nil
 }
 
 func (b *bootstrapGenerator) fetchDataplane(ctx context.Context, proxyId *xds.ProxyId) (*mesh.DataplaneResource, error) {
@@ -76,7 +405,63 @@ func (b *bootstrapGenerator) fetchDataplane(ctx context.Context, proxyId *xds.Pr
 	return &res, nil
 }
 
-func (b *bootstrapGenerator) ConfigForParameters(params configParameters) (proto.Message, error) {
+// registerInbounds makes sure that a Dataplane resource has inbound interfaces discovered by the dataplane itself.
+// A Dataplane resource that doesn't exist yet gets created.
+func (b *bootstrapGenerator) registerInbounds(ctx context.Context, proxyId *xds.ProxyId, inbounds []rest.Inbound) error {
+	networkingInbounds := make([]*mesh_proto.Dataplane_Networking_Inbound, len(inbounds))
+	for i, inbound := range inbounds {
+		if _, err := mesh_proto.ParseInboundInterface(inbound.Interface); err != nil {
+			return &InvalidRequestError{Reason: err.Error()}
+		}
+		if inbound.Tags[mesh_proto.ServiceTag] == "" {
+			return &InvalidRequestError{Reason: fmt.Sprintf("inbound interface %q has no %q tag", inbound.Interface, mesh_proto.ServiceTag)}
+		}
+		networkingInbounds[i] = &mesh_proto.Dataplane_Networking_Inbound{
+			Interface: inbound.Interface,
+			Tags:      inbound.Tags,
+		}
+	}
+
+	_, err := b.registry.Register(ctx, proxyId.ToResourceKey(), func(current *mesh_proto.Dataplane_Networking) *mesh_proto.Dataplane_Networking {
+		// other parts of networking, e.g. outbound interfaces, are kept as they are
+		networking := &mesh_proto.Dataplane_Networking{}
+		if current != nil {
+			networking = proto.Clone(current).(*mesh_proto.Dataplane_Networking)
+		}
+		networking.Inbound = networkingInbounds
+		return networking
+	})
+	return err
+}
+
+// InvalidRequestError means that a bootstrap request has been rejected because of invalid data.
+type InvalidRequestError struct {
+	Reason string
+}
+
+func (e *InvalidRequestError) Error() string {
+	return "invalid bootstrap request: " + e.Reason
+}
+
+// UnauthorizedRequestError means that a bootstrap request has been rejected because a dataplane failed to authenticate.
+type UnauthorizedRequestError struct {
+	Reason string
+}
+
+func (e *UnauthorizedRequestError) Error() string {
+	return "unauthorized bootstrap request: " + e.Reason
+}
+
+// ForbiddenRequestError means that a bootstrap request has been rejected because it asks for something that is not allowed.
+type ForbiddenRequestError struct {
+	Reason string
+}
+
+func (e *ForbiddenRequestError) Error() string {
+	return "forbidden bootstrap request: " + e.Reason
+}
+
+func (b *bootstrapGenerator) ConfigForParameters(params configParameters) (*envoy_bootstrap.Bootstrap, error) {
 	tmpl, err := template.New("bootstrap").Parse(configTemplate)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to parse config template")
@@ -0,0 +1,111 @@
+package bootstrap
+
+import (
+	"context"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	"github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/manager"
+	"github.com/Kong/kuma/pkg/core/resources/model"
+	"github.com/Kong/kuma/pkg/core/resources/store"
+	"github.com/Kong/kuma/pkg/core/xds"
+	sds_auth "github.com/Kong/kuma/pkg/sds/auth"
+	"github.com/Kong/kuma/pkg/xds/bootstrap/rest"
+	"github.com/gogo/protobuf/types"
+)
+
+const (
+	// maxOutlierEjections is the number of the most recent ejections kept in DataplaneInsight.
+	maxOutlierEjections = 50
+	// maxInsightUpdateAttempts limits how many times ejections are re-applied when DataplaneInsight
+	// is being modified concurrently, e.g. by the sink of Dataplane statuses.
+	maxInsightUpdateAttempts = 5
+)
+
+// OutlierEjectionRecorder saves ejections of endpoints reported by a dataplane into its DataplaneInsight.
+//
+// HTTP responses reported along are passed to a rollout of policies,
+// activity of circuit breakers and retry budgets is exposed as metrics.
+type OutlierEjectionRecorder interface {
+	Record(ctx context.Context, request rest.OutlierEjectionsRequest, credential sds_auth.Credential) error
+}
+
+// NewOutlierEjectionRecorder returns a recorder that only accepts reports of dataplanes
+// that present the same credential as on xDS streams. A nil authenticator means that authentication is turned off.
+func NewOutlierEjectionRecorder(resManager manager.ResourceManager, rollout xds.PolicyRollout, metrics *ResiliencyMetrics, authenticator sds_auth.Authenticator) OutlierEjectionRecorder {
+	return &outlierEjectionRecorder{
+		resManager:    resManager,
+		rollout:       rollout,
+		metrics:       metrics,
+		authenticator: authenticator,
+	}
+}
+
+type outlierEjectionRecorder struct {
+	resManager    manager.ResourceManager
+	rollout       xds.PolicyRollout
+	metrics       *ResiliencyMetrics
+	authenticator sds_auth.Authenticator
+}
+
+func (r *outlierEjectionRecorder) Record(ctx context.Context, request rest.OutlierEjectionsRequest, credential sds_auth.Credential) error {
+	proxyId, err := xds.BuildProxyId(request.Mesh, request.Name)
+	if err != nil {
+		return &InvalidRequestError{Reason: err.Error()}
+	}
+	var ejections []*mesh_proto.OutlierEjection
+	for _, ejection := range request.Ejections {
+		ts, err := types.TimestampProto(ejection.Time)
+		if err != nil {
+			return &InvalidRequestError{Reason: err.Error()}
+		}
+		ejections = append(ejections, &mesh_proto.OutlierEjection{
+			Cluster: ejection.Cluster,
+			Host:    ejection.Host,
+			Ejected: ejection.Ejected,
+			Time:    ts,
+		})
+	}
+	// ejections are only accepted from known dataplanes
+	dataplane := &mesh.DataplaneResource{}
+	if err := r.resManager.Get(ctx, dataplane, store.GetBy(proxyId.ToResourceKey())); err != nil {
+		return err
+	}
+	if r.authenticator != nil {
+		if _, err := r.authenticator.Authenticate(ctx, *proxyId, credential); err != nil {
+			return &UnauthorizedRequestError{Reason: err.Error()}
+		}
+	}
+	if request.Responses != nil {
+		r.rollout.RecordResponses(*proxyId, request.Responses.Total, request.Responses.ServerErrors)
+	}
+	r.metrics.Record(proxyId.ToResourceKey(), request)
+	if len(ejections) == 0 {
+		return nil
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := r.recordEjections(ctx, proxyId.ToResourceKey(), ejections)
+		// DataplaneInsight has been created or modified in the meantime, apply ejections to the latest version
+		if (store.IsResourceConflict(err) || store.IsResourceAlreadyExists(err)) && attempt < maxInsightUpdateAttempts {
+			continue
+		}
+		return err
+	}
+}
+
+func (r *outlierEjectionRecorder) recordEjections(ctx context.Context, key model.ResourceKey, ejections []*mesh_proto.OutlierEjection) error {
+	create := false
+	insight := &mesh.DataplaneInsightResource{}
+	if err := r.resManager.Get(ctx, insight, store.GetBy(key)); err != nil {
+		if !store.IsResourceNotFound(err) {
+			return err
+		}
+		create = true
+	}
+	insight.Spec.RecordOutlierEjections(maxOutlierEjections, ejections...)
+	if create {
+		return r.resManager.Create(ctx, insight, store.CreateBy(key))
+	}
+	return r.resManager.Update(ctx, insight)
+}
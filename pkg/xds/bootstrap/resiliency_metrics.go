@@ -0,0 +1,109 @@
+package bootstrap
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	"github.com/Kong/kuma/pkg/xds/bootstrap/rest"
+)
+
+var (
+	// circuitBreakerPriorities are routing priorities of Envoy that circuit breakers are configured for.
+	circuitBreakerPriorities = map[string]bool{"default": true, "high": true}
+	// circuitBreakers are names of circuit breakers of Envoy.
+	circuitBreakers = map[string]bool{"cx_open": true, "cx_pool_open": true, "rq_open": true, "rq_pending_open": true, "rq_retry_open": true}
+	// rejectionCounters are names of Envoy counters of traffic rejected by circuit breakers and retry budgets.
+	rejectionCounters = map[string]bool{"upstream_cx_overflow": true, "upstream_rq_pending_overflow": true, "upstream_rq_retry_overflow": true}
+)
+
+// ResiliencyMetrics exposes activity of circuit breakers and retry budgets reported by dataplanes,
+// so that operators learn when resiliency policies are shedding traffic.
+//
+// Names of circuit breakers and counters are chosen by dataplanes, that is why only the ones known to Envoy are accepted.
+// Series of a Dataplane are kept until the Dataplane is forgotten.
+type ResiliencyMetrics struct {
+	circuitBreakerOpen *prometheus.GaugeVec
+	trafficRejected    *prometheus.CounterVec
+
+	mu     sync.Mutex // protects access to series
+	series map[core_model.ResourceKey]*dataplaneSeries
+}
+
+// dataplaneSeries are label values of series of a single Dataplane.
+type dataplaneSeries struct {
+	circuitBreakers map[[5]string]struct{}
+	rejections      map[[4]string]struct{}
+}
+
+func NewResiliencyMetrics(registerer prometheus.Registerer) (*ResiliencyMetrics, error) {
+	metrics := &ResiliencyMetrics{
+		circuitBreakerOpen: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "dataplane_circuit_breaker_open",
+			Help: "Whether a circuit breaker of a cluster of a dataplane is open (1) or closed (0)",
+		}, []string{"mesh", "dataplane", "cluster", "priority", "breaker"}),
+		trafficRejected: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "dataplane_traffic_rejected_total",
+			Help: "Number of connections, requests or retries to a cluster rejected by a dataplane, e.g. because a retry budget has been exhausted",
+		}, []string{"mesh", "dataplane", "cluster", "counter"}),
+		series: map[core_model.ResourceKey]*dataplaneSeries{},
+	}
+	for _, collector := range []prometheus.Collector{metrics.circuitBreakerOpen, metrics.trafficRejected} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+	return metrics, nil
+}
+
+// Record updates metrics of a Dataplane with a given key. Unknown circuit breakers and counters are ignored.
+func (m *ResiliencyMetrics) Record(key core_model.ResourceKey, request rest.OutlierEjectionsRequest) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	series, ok := m.series[key]
+	if !ok {
+		series = &dataplaneSeries{
+			circuitBreakers: map[[5]string]struct{}{},
+			rejections:      map[[4]string]struct{}{},
+		}
+		m.series[key] = series
+	}
+	for _, breaker := range request.CircuitBreakers {
+		if !circuitBreakerPriorities[breaker.Priority] || !circuitBreakers[breaker.Breaker] {
+			continue
+		}
+		open := 0.0
+		if breaker.Open {
+			open = 1
+		}
+		labels := [5]string{request.Mesh, request.Name, breaker.Cluster, breaker.Priority, breaker.Breaker}
+		series.circuitBreakers[labels] = struct{}{}
+		m.circuitBreakerOpen.WithLabelValues(labels[:]...).Set(open)
+	}
+	for _, rejection := range request.Rejections {
+		if !rejectionCounters[rejection.Counter] {
+			continue
+		}
+		labels := [4]string{request.Mesh, request.Name, rejection.Cluster, rejection.Counter}
+		series.rejections[labels] = struct{}{}
+		m.trafficRejected.WithLabelValues(labels[:]...).Add(float64(rejection.Rejected))
+	}
+}
+
+// Forget deletes all series of a Dataplane with a given key, e.g. once the Dataplane has been deleted.
+func (m *ResiliencyMetrics) Forget(key core_model.ResourceKey) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	series, ok := m.series[key]
+	if !ok {
+		return
+	}
+	for labels := range series.circuitBreakers {
+		m.circuitBreakerOpen.DeleteLabelValues(labels[:]...)
+	}
+	for labels := range series.rejections {
+		m.trafficRejected.DeleteLabelValues(labels[:]...)
+	}
+	delete(m.series, key)
+}
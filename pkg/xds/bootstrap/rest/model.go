@@ -1,7 +1,63 @@
 package rest
 
+import "time"
+
 type BootstrapRequest struct {
 	Mesh      string `json:"mesh"`
 	Name      string `json:"name"`
 	AdminPort uint32 `json:"adminPort,omitempty"`
+	// Inbounds are inbound interfaces discovered by the dataplane itself.
+	// If set, the Dataplane resource is created or its inbound interfaces are replaced.
+	Inbounds []Inbound `json:"inbounds,omitempty"`
+	// ClientCertFile and ClientKeyFile are paths to files on the host of the dataplane
+	// with a certificate that it presents to the XDS Server.
+	ClientCertFile string `json:"clientCertFile,omitempty"`
+	ClientKeyFile  string `json:"clientKeyFile,omitempty"`
+}
+
+type Inbound struct {
+	Interface string            `json:"interface"`
+	Tags      map[string]string `json:"tags"`
+}
+
+// OutlierEjectionsRequest reports endpoints that outlier detection of a dataplane
+// has ejected from or returned to load balancing pools.
+type OutlierEjectionsRequest struct {
+	Mesh      string            `json:"mesh"`
+	Name      string            `json:"name"`
+	Ejections []OutlierEjection `json:"ejections"`
+	// Responses summarizes HTTP responses that a dataplane has received since the previous request.
+	Responses *ResponseStats `json:"responses,omitempty"`
+	// CircuitBreakers are circuit breakers of a dataplane that have opened or closed since the previous request.
+	CircuitBreakers []CircuitBreaker `json:"circuitBreakers,omitempty"`
+	// Rejections tell how much traffic a dataplane has rejected since the previous request,
+	// e.g. because a retry budget has been exhausted.
+	Rejections []TrafficRejection `json:"rejections,omitempty"`
+}
+
+type CircuitBreaker struct {
+	Cluster string `json:"cluster"`
+	// Priority and Breaker identify a circuit breaker of a cluster, e.g. "default" and "rq_open".
+	Priority string `json:"priority"`
+	Breaker  string `json:"breaker"`
+	Open     bool   `json:"open"`
+}
+
+type TrafficRejection struct {
+	Cluster string `json:"cluster"`
+	// Counter tells what kind of traffic has been rejected, e.g. "upstream_rq_retry_overflow".
+	Counter  string `json:"counter"`
+	Rejected uint64 `json:"rejected"`
+}
+
+type ResponseStats struct {
+	Total        uint64 `json:"total"`
+	ServerErrors uint64 `json:"serverErrors"`
+}
+
+type OutlierEjection struct {
+	Cluster string    `json:"cluster"`
+	Host    string    `json:"host"`
+	Ejected bool      `json:"ejected"`
+	Time    time.Time `json:"time"`
 }
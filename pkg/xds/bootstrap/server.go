@@ -7,6 +7,8 @@ import (
 	"github.com/Kong/kuma/pkg/core"
 	"github.com/Kong/kuma/pkg/core/resources/store"
 	core_runtime "github.com/Kong/kuma/pkg/core/runtime"
+	sds_auth "github.com/Kong/kuma/pkg/sds/auth"
+	"github.com/Kong/kuma/pkg/tokens/builtin/issuer"
 	"github.com/Kong/kuma/pkg/util/proto"
 	"github.com/Kong/kuma/pkg/xds/bootstrap/rest"
 	"io/ioutil"
@@ -18,6 +20,8 @@ var log = core.Log.WithName("bootstrap-server")
 type BootstrapServer struct {
 	Port      int
 	Generator BootstrapGenerator
+	// Ejections records ejections of endpoints reported by dataplanes.
+	Ejections OutlierEjectionRecorder
 }
 
 var _ core_runtime.Component = &BootstrapServer{}
@@ -25,6 +29,9 @@ var _ core_runtime.Component = &BootstrapServer{}
 func (b *BootstrapServer) Start(stop <-chan struct{}) error {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/bootstrap", b.handleBootstrapRequest)
+	if b.Ejections != nil {
+		mux.HandleFunc("/outlier-ejections", b.handleOutlierEjectionsRequest)
+	}
 
 	bootstrapServer := &http.Server{
 		Addr:    fmt.Sprintf(":%d", b.Port),
@@ -69,12 +76,26 @@ func (b *BootstrapServer) handleBootstrapRequest(resp http.ResponseWriter, req *
 		return
 	}
 
-	config, err := b.Generator.Generate(req.Context(), reqParams)
+	config, err := b.Generator.Generate(req.Context(), reqParams, issuer.Token(req.Header.Get("Authorization")))
 	if err != nil {
 		if store.IsResourceNotFound(err) {
 			resp.WriteHeader(http.StatusNotFound)
 			return
 		}
+		switch err.(type) {
+		case *InvalidRequestError:
+			resp.WriteHeader(http.StatusBadRequest)
+			_, _ = resp.Write([]byte(err.Error()))
+			return
+		case *UnauthorizedRequestError:
+			resp.WriteHeader(http.StatusUnauthorized)
+			_, _ = resp.Write([]byte(err.Error()))
+			return
+		case *ForbiddenRequestError:
+			resp.WriteHeader(http.StatusForbidden)
+			_, _ = resp.Write([]byte(err.Error()))
+			return
+		}
 		log.WithValues("params", reqParams).Error(err, "Could not generate a bootstrap configuration")
 		resp.WriteHeader(http.StatusInternalServerError)
 		return
@@ -95,3 +116,37 @@ func (b *BootstrapServer) handleBootstrapRequest(resp http.ResponseWriter, req *
 		return
 	}
 }
+
+func (b *BootstrapServer) handleOutlierEjectionsRequest(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		resp.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	reqParams := rest.OutlierEjectionsRequest{}
+	if err := json.NewDecoder(req.Body).Decode(&reqParams); err != nil {
+		log.Error(err, "Could not parse a request")
+		resp.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if err := b.Ejections.Record(req.Context(), reqParams, sds_auth.Credential(req.Header.Get("Authorization"))); err != nil {
+		if store.IsResourceNotFound(err) {
+			resp.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch err.(type) {
+		case *InvalidRequestError:
+			resp.WriteHeader(http.StatusBadRequest)
+			_, _ = resp.Write([]byte(err.Error()))
+			return
+		case *UnauthorizedRequestError:
+			resp.WriteHeader(http.StatusUnauthorized)
+			_, _ = resp.Write([]byte(err.Error()))
+			return
+		}
+		log.WithValues("mesh", reqParams.Mesh, "name", reqParams.Name).Error(err, "Could not record outlier ejections")
+		resp.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp.WriteHeader(http.StatusNoContent)
+}
@@ -6,12 +6,26 @@ import (
 	xds_config "github.com/Kong/kuma/pkg/config/xds"
 	"github.com/Kong/kuma/pkg/core/resources/apis/mesh"
 	"github.com/Kong/kuma/pkg/core/resources/manager"
+	"github.com/Kong/kuma/pkg/core/resources/model"
 	"github.com/Kong/kuma/pkg/core/resources/store"
+	secret_cipher "github.com/Kong/kuma/pkg/core/secrets/cipher"
+	secret_manager "github.com/Kong/kuma/pkg/core/secrets/manager"
+	secret_store "github.com/Kong/kuma/pkg/core/secrets/store"
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
 	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+	universal_sds_auth "github.com/Kong/kuma/pkg/sds/auth/universal"
+	sds_server "github.com/Kong/kuma/pkg/sds/server"
 	"github.com/Kong/kuma/pkg/test"
+	"github.com/Kong/kuma/pkg/tokens/builtin/issuer"
+	util_proto "github.com/Kong/kuma/pkg/util/proto"
+	"github.com/Kong/kuma/pkg/xds/bootstrap/rest"
+	envoy_auth "github.com/envoyproxy/go-control-plane/envoy/api/v2/auth"
+	envoy_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"io/ioutil"
 	"net/http"
 	"path/filepath"
@@ -25,17 +39,30 @@ var _ = Describe("Bootstrap Server", func() {
 	var resManager manager.ResourceManager
 	var config *xds_config.BootstrapParamsConfig
 	var baseUrl string
+	var rollout *fakeRollout
+	var metrics *prometheus.Registry
+	var tokenIssuer issuer.DataplaneTokenIssuer
 
 	BeforeEach(func() {
-		resManager = manager.NewResourceManager(memory.NewStore())
+		rollout = &fakeRollout{}
+		resStore := memory.NewStore()
+		resManager = manager.NewResourceManager(resStore)
 		config = xds_config.DefaultBootstrapParamsConfig()
+		keyManager := issuer.NewSigningKeyManager(secret_manager.NewSecretManager(secret_store.NewSecretStore(resStore), secret_cipher.None()))
+		_, err := keyManager.RotateSigningKey(context.Background(), "default", 0)
+		Expect(err).ToNot(HaveOccurred())
+		tokenIssuer = issuer.NewDataplaneTokenIssuer(keyManager)
+		metrics = prometheus.NewRegistry()
+		resiliencyMetrics, err := NewResiliencyMetrics(metrics)
+		Expect(err).ToNot(HaveOccurred())
 
 		port, err := test.GetFreePort()
 		baseUrl = "http://localhost:" + strconv.Itoa(port)
 		Expect(err).ToNot(HaveOccurred())
 		server := BootstrapServer{
 			Port:      port,
-			Generator: NewDefaultBootstrapGenerator(resManager, config),
+			Generator: NewDefaultBootstrapGenerator(resManager, config, "default", tokenIssuer, XdsClientCert{}),
+			Ejections: NewOutlierEjectionRecorder(resManager, rollout, resiliencyMetrics, universal_sds_auth.NewDataplaneTokenAuthenticator(sds_server.DefaultDataplaneResolver(resManager), tokenIssuer)),
 		}
 		stop = make(chan struct{})
 		go func() {
@@ -114,6 +141,309 @@ var _ = Describe("Bootstrap Server", func() {
 		}),
 	)
 
+	It("should configure stats sinks according to metrics settings of the Mesh", func() {
+		// given
+		meshRes := mesh.MeshResource{}
+		Expect(resManager.Get(context.Background(), &meshRes, store.GetByKey("default", "default", "default"))).To(Succeed())
+		meshRes.Spec.Metrics = &mesh_proto.Metrics{
+			Statsd: &mesh_proto.Metrics_StatsD{
+				Address: "127.0.0.1:8125",
+				Prefix:  "kuma",
+			},
+			Dogstatsd: &mesh_proto.Metrics_DogStatsD{
+				Address: "10.0.0.1:8125",
+			},
+		}
+		Expect(resManager.Update(context.Background(), &meshRes)).To(Succeed())
+
+		// and
+		res := mesh.DataplaneResource{
+			Spec: mesh_proto.Dataplane{
+				Networking: &mesh_proto.Dataplane_Networking{
+					Inbound: []*mesh_proto.Dataplane_Networking_Inbound{
+						{Interface: "8.8.8.8:443:8443", Tags: map[string]string{"service": "backend"}},
+					},
+				},
+			},
+		}
+		Expect(resManager.Create(context.Background(), &res, store.CreateByKey("default", "dp-1", "default"))).To(Succeed())
+
+		// when
+		resp, err := http.Post(baseUrl+"/bootstrap", "application/json", strings.NewReader(`{ "mesh": "default", "name": "dp-1" }`))
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		received, err := ioutil.ReadAll(resp.Body)
+		Expect(resp.Body.Close()).To(Succeed())
+		Expect(err).ToNot(HaveOccurred())
+
+		expected, err := ioutil.ReadFile(filepath.Join("testdata", "bootstrap.stats-sinks.golden.yaml"))
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(received).To(MatchYAML(expected))
+	})
+
+	It("should connect to XDS Server over TLS when CA certs of XDS Server are configured", func() {
+		// given
+		config.XdsHost = "kuma-control-plane"
+		config.XdsCaFile = filepath.Join("testdata", "xds-ca.crt")
+		res := mesh.DataplaneResource{
+			Spec: mesh_proto.Dataplane{
+				Networking: &mesh_proto.Dataplane_Networking{
+					Inbound: []*mesh_proto.Dataplane_Networking_Inbound{
+						{Interface: "8.8.8.8:443:8443", Tags: map[string]string{"service": "backend"}},
+					},
+				},
+			},
+		}
+		Expect(resManager.Create(context.Background(), &res, store.CreateByKey("default", "dp-1", "default"))).To(Succeed())
+
+		// when
+		resp, err := http.Post(baseUrl+"/bootstrap", "application/json", strings.NewReader(`{ "mesh": "default", "name": "dp-1" }`))
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		received, err := ioutil.ReadAll(resp.Body)
+		Expect(resp.Body.Close()).To(Succeed())
+		Expect(err).ToNot(HaveOccurred())
+
+		expected, err := ioutil.ReadFile(filepath.Join("testdata", "bootstrap.xds-tls.golden.yaml"))
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(received).To(MatchYAML(expected))
+	})
+
+	It("should present a client certificate of the dataplane to XDS Server", func() {
+		// given
+		config.XdsHost = "kuma-control-plane"
+		config.XdsCaFile = filepath.Join("testdata", "xds-ca.crt")
+		res := mesh.DataplaneResource{
+			Spec: mesh_proto.Dataplane{
+				Networking: &mesh_proto.Dataplane_Networking{
+					Inbound: []*mesh_proto.Dataplane_Networking_Inbound{
+						{Interface: "8.8.8.8:443:8443", Tags: map[string]string{"service": "backend"}},
+					},
+				},
+			},
+		}
+		Expect(resManager.Create(context.Background(), &res, store.CreateByKey("default", "dp-1", "default"))).To(Succeed())
+
+		// when
+		resp, err := http.Post(baseUrl+"/bootstrap", "application/json", strings.NewReader(`{ "mesh": "default", "name": "dp-1", "clientCertFile": "/etc/kuma-dp/tls.crt", "clientKeyFile": "/etc/kuma-dp/tls.key" }`))
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		received, err := ioutil.ReadAll(resp.Body)
+		Expect(resp.Body.Close()).To(Succeed())
+		Expect(err).ToNot(HaveOccurred())
+
+		expected, err := ioutil.ReadFile(filepath.Join("testdata", "bootstrap.xds-client-cert.golden.yaml"))
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(received).To(MatchYAML(expected))
+	})
+
+	Describe("client certificate required by XDS Server", func() {
+
+		BeforeEach(func() {
+			config.XdsHost = "kuma-control-plane"
+			config.XdsCaFile = filepath.Join("testdata", "xds-ca.crt")
+			res := mesh.DataplaneResource{
+				Spec: mesh_proto.Dataplane{
+					Networking: &mesh_proto.Dataplane_Networking{
+						Inbound: []*mesh_proto.Dataplane_Networking_Inbound{
+							{Interface: "8.8.8.8:443:8443", Tags: map[string]string{"service": "backend"}},
+						},
+					},
+				},
+			}
+			Expect(resManager.Create(context.Background(), &res, store.CreateByKey("default", "dp-1", "default"))).To(Succeed())
+		})
+
+		It("should present the identity certificate served by SDS", func() {
+			// given
+			generator := NewDefaultBootstrapGenerator(resManager, config, "default", nil, XdsClientCert{
+				Required: true,
+				Sds: &envoy_auth.SdsSecretConfig{
+					Name: "identity_cert",
+					SdsConfig: &envoy_core.ConfigSource{
+						ConfigSourceSpecifier: &envoy_core.ConfigSource_Ads{
+							Ads: &envoy_core.AggregatedConfigSource{},
+						},
+					},
+				},
+			})
+
+			// when
+			bootstrap, err := generator.Generate(context.Background(), rest.BootstrapRequest{Mesh: "default", Name: "dp-1"}, "")
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			actual, err := util_proto.ToYAML(bootstrap)
+			Expect(err).ToNot(HaveOccurred())
+
+			expected, err := ioutil.ReadFile(filepath.Join("testdata", "bootstrap.xds-sds-client-cert.golden.yaml"))
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(actual).To(MatchYAML(expected))
+		})
+
+		It("should refuse a dataplane without a client certificate", func() {
+			// given
+			generator := NewDefaultBootstrapGenerator(resManager, config, "default", nil, XdsClientCert{Required: true})
+
+			// when
+			_, err := generator.Generate(context.Background(), rest.BootstrapRequest{Mesh: "default", Name: "dp-1"}, "")
+
+			// then
+			Expect(err).To(MatchError("invalid bootstrap request: XDS Server requires a client certificate, set a certificate of the dataplane with --client-cert-path and --client-key-path"))
+		})
+
+		It("should refuse a client certificate without a key", func() {
+			// given
+			generator := NewDefaultBootstrapGenerator(resManager, config, "default", nil, XdsClientCert{Required: true})
+
+			// when
+			_, err := generator.Generate(context.Background(), rest.BootstrapRequest{Mesh: "default", Name: "dp-1", ClientCertFile: "/etc/kuma-dp/tls.crt"}, "")
+
+			// then
+			Expect(err).To(MatchError("invalid bootstrap request: clientCertFile and clientKeyFile must be set together"))
+		})
+	})
+
+	It("should subscribe to Clusters with delta xDS when enabled", func() {
+		// given
+		config.XdsDelta = true
+		res := mesh.DataplaneResource{
+			Spec: mesh_proto.Dataplane{
+				Networking: &mesh_proto.Dataplane_Networking{
+					Inbound: []*mesh_proto.Dataplane_Networking_Inbound{
+						{Interface: "8.8.8.8:443:8443", Tags: map[string]string{"service": "backend"}},
+					},
+				},
+			},
+		}
+		Expect(resManager.Create(context.Background(), &res, store.CreateByKey("default", "dp-1", "default"))).To(Succeed())
+
+		// when
+		resp, err := http.Post(baseUrl+"/bootstrap", "application/json", strings.NewReader(`{ "mesh": "default", "name": "dp-1" }`))
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		received, err := ioutil.ReadAll(resp.Body)
+		Expect(resp.Body.Close()).To(Succeed())
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(string(received)).To(ContainSubstring(`
+  adsConfig:
+    apiType: GRPC
+    grpcServices:
+    - envoyGrpc:
+        clusterName: ads_cluster
+  cdsConfig:
+    apiConfigSource:
+      apiType: DELTA_GRPC
+      grpcServices:
+      - envoyGrpc:
+          clusterName: ads_cluster
+  ldsConfig:
+    ads: {}
+`))
+	})
+
+	It("should configure Datadog tracer according to tracing settings of the Mesh", func() {
+		// given
+		meshRes := mesh.MeshResource{}
+		Expect(resManager.Get(context.Background(), &meshRes, store.GetByKey("default", "default", "default"))).To(Succeed())
+		meshRes.Spec.Tracing = &mesh_proto.Tracing{
+			Type: &mesh_proto.Tracing_Datadog_{
+				Datadog: &mesh_proto.Tracing_Datadog{
+					Address: "datadog-agent:8126",
+				},
+			},
+		}
+		Expect(resManager.Update(context.Background(), &meshRes)).To(Succeed())
+
+		// and
+		res := mesh.DataplaneResource{
+			Spec: mesh_proto.Dataplane{
+				Networking: &mesh_proto.Dataplane_Networking{
+					Inbound: []*mesh_proto.Dataplane_Networking_Inbound{
+						{Interface: "8.8.8.8:443:8443", Tags: map[string]string{"service": "backend"}},
+					},
+				},
+			},
+		}
+		Expect(resManager.Create(context.Background(), &res, store.CreateByKey("default", "dp-1", "default"))).To(Succeed())
+
+		// when
+		resp, err := http.Post(baseUrl+"/bootstrap", "application/json", strings.NewReader(`{ "mesh": "default", "name": "dp-1" }`))
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		received, err := ioutil.ReadAll(resp.Body)
+		Expect(resp.Body.Close()).To(Succeed())
+		Expect(err).ToNot(HaveOccurred())
+
+		expected, err := ioutil.ReadFile(filepath.Join("testdata", "bootstrap.tracing-datadog.golden.yaml"))
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(received).To(MatchYAML(expected))
+	})
+
+	It("should tag metrics with labels of the Dataplane", func() {
+		// given
+		res := mesh.DataplaneResource{
+			Spec: mesh_proto.Dataplane{
+				Networking: &mesh_proto.Dataplane_Networking{
+					Inbound: []*mesh_proto.Dataplane_Networking_Inbound{
+						{Interface: "8.8.8.8:443:8443", Tags: map[string]string{"service": "backend"}},
+					},
+				},
+			},
+		}
+		labels := map[string]string{"team": "payments", "kuma.io/cost-center": "42"}
+		Expect(resManager.Create(context.Background(), &res, store.CreateByKey("default", "dp-1", "default"), store.CreateWithLabels(labels))).To(Succeed())
+
+		// when
+		resp, err := http.Post(baseUrl+"/bootstrap", "application/json", strings.NewReader(`{ "mesh": "default", "name": "dp-1" }`))
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		received, err := ioutil.ReadAll(resp.Body)
+		Expect(resp.Body.Close()).To(Succeed())
+		Expect(err).ToNot(HaveOccurred())
+
+		expected, err := ioutil.ReadFile(filepath.Join("testdata", "bootstrap.stats-tags.golden.yaml"))
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(received).To(MatchYAML(expected))
+	})
+
+	It("should fail on invalid address of a stats sink", func() {
+		// given
+		meshRes := mesh.MeshResource{}
+		Expect(resManager.Get(context.Background(), &meshRes, store.GetByKey("default", "default", "default"))).To(Succeed())
+		meshRes.Spec.Metrics = &mesh_proto.Metrics{
+			Statsd: &mesh_proto.Metrics_StatsD{
+				Address: "statsd.local:8125",
+			},
+		}
+		Expect(resManager.Update(context.Background(), &meshRes)).To(Succeed())
+
+		// and
+		res := mesh.DataplaneResource{}
+		Expect(resManager.Create(context.Background(), &res, store.CreateByKey("default", "dp-1", "default"))).To(Succeed())
+
+		// when
+		resp, err := http.Post(baseUrl+"/bootstrap", "application/json", strings.NewReader(`{ "mesh": "default", "name": "dp-1" }`))
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.Body.Close()).To(Succeed())
+		Expect(resp.StatusCode).To(Equal(500))
+	})
+
 	It("should return 404 for unknown dataplane", func() {
 		// when
 		json := `
@@ -129,4 +459,336 @@ var _ = Describe("Bootstrap Server", func() {
 		Expect(resp.Body.Close()).To(Succeed())
 		Expect(resp.StatusCode).To(Equal(404))
 	})
+
+	Describe("with inbound interfaces discovered by the dataplane", func() {
+
+		postWithToken := func(body string, token issuer.Token) int {
+			req, err := http.NewRequest("POST", baseUrl+"/bootstrap", strings.NewReader(body))
+			Expect(err).ToNot(HaveOccurred())
+			req.Header.Set("Content-Type", "application/json")
+			if token != "" {
+				req.Header.Set("Authorization", string(token))
+			}
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.Body.Close()).To(Succeed())
+			return resp.StatusCode
+		}
+
+		tokenOf := func(mesh, name string) issuer.Token {
+			token, err := tokenIssuer.Generate(context.Background(), issuer.DataplaneIdentity{Mesh: mesh, Name: name}, 0)
+			Expect(err).ToNot(HaveOccurred())
+			return token
+		}
+
+		post := func(body string) int {
+			return postWithToken(body, tokenOf("default", "dp-2"))
+		}
+
+		It("should register a new Dataplane", func() {
+			// when
+			status := post(`{ "mesh": "default", "name": "dp-2", "inbounds": [ { "interface": "192.168.0.1:18080:8080", "tags": { "service": "web" } } ] }`)
+
+			// then
+			Expect(status).To(Equal(200))
+
+			// and
+			dataplane := mesh.DataplaneResource{}
+			Expect(resManager.Get(context.Background(), &dataplane, store.GetByKey("default", "dp-2", "default"))).To(Succeed())
+			Expect(dataplane.Spec.Networking.Inbound).To(HaveLen(1))
+			Expect(dataplane.Spec.Networking.Inbound[0].Interface).To(Equal("192.168.0.1:18080:8080"))
+			Expect(dataplane.Spec.Networking.Inbound[0].Tags).To(Equal(map[string]string{"service": "web"}))
+		})
+
+		It("should replace inbound interfaces of an existing Dataplane", func() {
+			// given
+			res := mesh.DataplaneResource{
+				Spec: mesh_proto.Dataplane{
+					Networking: &mesh_proto.Dataplane_Networking{
+						Inbound: []*mesh_proto.Dataplane_Networking_Inbound{
+							{Interface: "192.168.0.1:18080:8080", Tags: map[string]string{"service": "web"}},
+						},
+						Outbound: []*mesh_proto.Dataplane_Networking_Outbound{
+							{Interface: ":10001", Service: "backend"},
+						},
+					},
+				},
+			}
+			Expect(resManager.Create(context.Background(), &res, store.CreateByKey("default", "dp-2", "default"))).To(Succeed())
+
+			// when
+			status := post(`{ "mesh": "default", "name": "dp-2", "inbounds": [ { "interface": "192.168.0.1:19090:9090", "tags": { "service": "web" } } ] }`)
+
+			// then
+			Expect(status).To(Equal(200))
+
+			// and
+			dataplane := mesh.DataplaneResource{}
+			Expect(resManager.Get(context.Background(), &dataplane, store.GetByKey("default", "dp-2", "default"))).To(Succeed())
+			Expect(dataplane.Spec.Networking.Inbound).To(HaveLen(1))
+			Expect(dataplane.Spec.Networking.Inbound[0].Interface).To(Equal("192.168.0.1:19090:9090"))
+			Expect(dataplane.Spec.Networking.Outbound).To(HaveLen(1))
+		})
+
+		It("should reject inbound interfaces without service tag", func() {
+			// when
+			status := post(`{ "mesh": "default", "name": "dp-2", "inbounds": [ { "interface": "192.168.0.1:18080:8080", "tags": { "version": "v1" } } ] }`)
+
+			// then
+			Expect(status).To(Equal(400))
+		})
+
+		It("should reject invalid inbound interfaces", func() {
+			// when
+			status := post(`{ "mesh": "default", "name": "dp-2", "inbounds": [ { "interface": "192.168.0.1:8080", "tags": { "service": "web" } } ] }`)
+
+			// then
+			Expect(status).To(Equal(400))
+		})
+
+		It("should reject a dataplane without a token", func() {
+			// when
+			status := postWithToken(`{ "mesh": "default", "name": "dp-2", "inbounds": [ { "interface": "192.168.0.1:18080:8080", "tags": { "service": "web" } } ] }`, "")
+
+			// then
+			Expect(status).To(Equal(401))
+
+			// and
+			err := resManager.Get(context.Background(), &mesh.DataplaneResource{}, store.GetByKey("default", "dp-2", "default"))
+			Expect(store.IsResourceNotFound(err)).To(BeTrue())
+		})
+
+		It("should refuse inbound interfaces when their registration is turned off", func() {
+			// given
+			generator := NewDefaultBootstrapGenerator(resManager, config, "default", nil, XdsClientCert{})
+			request := rest.BootstrapRequest{
+				Mesh:     "default",
+				Name:     "dp-2",
+				Inbounds: []rest.Inbound{{Interface: "192.168.0.1:18080:8080", Tags: map[string]string{"service": "web"}}},
+			}
+
+			// when
+			_, err := generator.Generate(context.Background(), request, tokenOf("default", "dp-2"))
+
+			// then
+			Expect(err).To(MatchError("forbidden bootstrap request: registration of inbound interfaces is turned off"))
+		})
+
+		It("should reject a token of another dataplane", func() {
+			// when
+			status := postWithToken(`{ "mesh": "default", "name": "dp-2", "inbounds": [ { "interface": "192.168.0.1:18080:8080", "tags": { "service": "web" } } ] }`, tokenOf("default", "dp-3"))
+
+			// then
+			Expect(status).To(Equal(401))
+
+			// and
+			err := resManager.Get(context.Background(), &mesh.DataplaneResource{}, store.GetByKey("default", "dp-2", "default"))
+			Expect(store.IsResourceNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Describe("outlier ejections", func() {
+
+		postWithToken := func(body string, token issuer.Token) int {
+			req, err := http.NewRequest("POST", baseUrl+"/outlier-ejections", strings.NewReader(body))
+			Expect(err).ToNot(HaveOccurred())
+			req.Header.Set("Content-Type", "application/json")
+			if token != "" {
+				req.Header.Set("Authorization", string(token))
+			}
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.Body.Close()).To(Succeed())
+			return resp.StatusCode
+		}
+
+		tokenOf := func(mesh, name string) issuer.Token {
+			token, err := tokenIssuer.Generate(context.Background(), issuer.DataplaneIdentity{Mesh: mesh, Name: name}, 0)
+			Expect(err).ToNot(HaveOccurred())
+			return token
+		}
+
+		post := func(body string) int {
+			return postWithToken(body, tokenOf("default", "dp-1"))
+		}
+
+		BeforeEach(func() {
+			res := mesh.DataplaneResource{
+				Spec: mesh_proto.Dataplane{
+					Networking: &mesh_proto.Dataplane_Networking{
+						Inbound: []*mesh_proto.Dataplane_Networking_Inbound{
+							{Interface: "8.8.8.8:443:8443", Tags: map[string]string{"service": "web"}},
+						},
+					},
+				},
+			}
+			Expect(resManager.Create(context.Background(), &res, store.CreateByKey("default", "dp-1", "default"))).To(Succeed())
+		})
+
+		It("should record ejections in DataplaneInsight", func() {
+			// when
+			status := post(`{ "mesh": "default", "name": "dp-1", "ejections": [ { "cluster": "backend", "host": "192.168.0.1:8080", "ejected": true, "time": "2019-10-01T10:00:00Z" } ] }`)
+
+			// then
+			Expect(status).To(Equal(204))
+
+			// when
+			status = post(`{ "mesh": "default", "name": "dp-1", "ejections": [ { "cluster": "backend", "host": "192.168.0.1:8080", "ejected": false, "time": "2019-10-01T10:00:30Z" } ] }`)
+
+			// then
+			Expect(status).To(Equal(204))
+
+			// and
+			insight := mesh.DataplaneInsightResource{}
+			Expect(resManager.Get(context.Background(), &insight, store.GetByKey("default", "dp-1", "default"))).To(Succeed())
+			Expect(util_proto.ToYAML(&insight.Spec)).To(MatchYAML(`
+            outlierEjections:
+            - cluster: backend
+              host: 192.168.0.1:8080
+              ejected: true
+              time: "2019-10-01T10:00:00Z"
+            - cluster: backend
+              host: 192.168.0.1:8080
+              time: "2019-10-01T10:00:30Z"
+`))
+		})
+
+		It("should pass HTTP responses to a rollout of policies", func() {
+			// when
+			status := post(`{ "mesh": "default", "name": "dp-1", "responses": { "total": 100, "serverErrors": 7 } }`)
+
+			// then
+			Expect(status).To(Equal(204))
+			// and
+			Expect(rollout.responses).To(Equal(uint64(100)))
+			Expect(rollout.serverErrors).To(Equal(uint64(7)))
+		})
+
+		It("should expose activity of circuit breakers and retry budgets as metrics", func() {
+			// when
+			status := post(`{ "mesh": "default", "name": "dp-1", "circuitBreakers": [ { "cluster": "backend", "priority": "default", "breaker": "rq_open", "open": true } ], "rejections": [ { "cluster": "backend", "counter": "upstream_rq_retry_overflow", "rejected": 3 } ] }`)
+
+			// then
+			Expect(status).To(Equal(204))
+			// and
+			Expect(testutil.GatherAndCompare(metrics, strings.NewReader(`
+# HELP dataplane_circuit_breaker_open Whether a circuit breaker of a cluster of a dataplane is open (1) or closed (0)
+# TYPE dataplane_circuit_breaker_open gauge
+dataplane_circuit_breaker_open{breaker="rq_open",cluster="backend",dataplane="dp-1",mesh="default",priority="default"} 1
+# HELP dataplane_traffic_rejected_total Number of connections, requests or retries to a cluster rejected by a dataplane, e.g. because a retry budget has been exhausted
+# TYPE dataplane_traffic_rejected_total counter
+dataplane_traffic_rejected_total{cluster="backend",counter="upstream_rq_retry_overflow",dataplane="dp-1",mesh="default"} 3
+`))).To(Succeed())
+
+			// when
+			status = post(`{ "mesh": "default", "name": "dp-1", "circuitBreakers": [ { "cluster": "backend", "priority": "default", "breaker": "rq_open", "open": false } ], "rejections": [ { "cluster": "backend", "counter": "upstream_rq_retry_overflow", "rejected": 2 } ] }`)
+
+			// then
+			Expect(status).To(Equal(204))
+			// and
+			Expect(testutil.GatherAndCompare(metrics, strings.NewReader(`
+# HELP dataplane_circuit_breaker_open Whether a circuit breaker of a cluster of a dataplane is open (1) or closed (0)
+# TYPE dataplane_circuit_breaker_open gauge
+dataplane_circuit_breaker_open{breaker="rq_open",cluster="backend",dataplane="dp-1",mesh="default",priority="default"} 0
+# HELP dataplane_traffic_rejected_total Number of connections, requests or retries to a cluster rejected by a dataplane, e.g. because a retry budget has been exhausted
+# TYPE dataplane_traffic_rejected_total counter
+dataplane_traffic_rejected_total{cluster="backend",counter="upstream_rq_retry_overflow",dataplane="dp-1",mesh="default"} 5
+`))).To(Succeed())
+		})
+
+		It("should ignore circuit breakers and counters unknown to Envoy", func() {
+			// when
+			status := post(`{ "mesh": "default", "name": "dp-1", "circuitBreakers": [ { "cluster": "backend", "priority": "default", "breaker": "made_up_open", "open": true }, { "cluster": "backend", "priority": "made-up", "breaker": "rq_open", "open": true } ], "rejections": [ { "cluster": "backend", "counter": "made_up_overflow", "rejected": 3 } ] }`)
+
+			// then
+			Expect(status).To(Equal(204))
+			// and
+			Expect(testutil.GatherAndCompare(metrics, strings.NewReader(""), "dataplane_circuit_breaker_open", "dataplane_traffic_rejected_total")).To(Succeed())
+		})
+
+		It("should reject ejections of a Dataplane that doesn't present its token", func() {
+			// given
+			body := `{ "mesh": "default", "name": "dp-1", "ejections": [ { "cluster": "backend", "host": "192.168.0.1:8080", "ejected": true, "time": "2019-10-01T10:00:00Z" } ] }`
+
+			// expect
+			Expect(postWithToken(body, "")).To(Equal(401))
+			Expect(postWithToken(body, tokenOf("default", "dp-2"))).To(Equal(401))
+
+			// and
+			err := resManager.Get(context.Background(), &mesh.DataplaneInsightResource{}, store.GetByKey("default", "dp-1", "default"))
+			Expect(store.IsResourceNotFound(err)).To(BeTrue())
+		})
+
+		It("should not lose ejections when DataplaneInsight is modified concurrently", func() {
+			// given
+			Expect(resManager.Create(context.Background(), &mesh.DataplaneInsightResource{}, store.CreateByKey("default", "dp-1", "default"))).To(Succeed())
+			resiliencyMetrics, err := NewResiliencyMetrics(prometheus.NewRegistry())
+			Expect(err).ToNot(HaveOccurred())
+			recorder := NewOutlierEjectionRecorder(&concurrentlyModifyingManager{ResourceManager: resManager}, rollout, resiliencyMetrics, nil)
+
+			// when
+			err = recorder.Record(context.Background(), rest.OutlierEjectionsRequest{
+				Mesh:      "default",
+				Name:      "dp-1",
+				Ejections: []rest.OutlierEjection{{Cluster: "backend", Host: "192.168.0.1:8080", Ejected: true}},
+			}, "")
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			// and
+			insight := mesh.DataplaneInsightResource{}
+			Expect(resManager.Get(context.Background(), &insight, store.GetByKey("default", "dp-1", "default"))).To(Succeed())
+			Expect(insight.Spec.OutlierEjections).To(HaveLen(1))
+			Expect(insight.Spec.Subscriptions).To(HaveLen(1))
+		})
+
+		It("should reject ejections of unknown Dataplanes", func() {
+			// when
+			status := post(`{ "mesh": "default", "name": "dp-2", "ejections": [ { "cluster": "backend", "host": "192.168.0.1:8080", "ejected": true, "time": "2019-10-01T10:00:00Z" } ] }`)
+
+			// then
+			Expect(status).To(Equal(404))
+		})
+
+		It("should reject malformed requests", func() {
+			// when
+			status := post(`{ "mesh": "default", "name": "dp-1", "ejections": "backend" }`)
+
+			// then
+			Expect(status).To(Equal(400))
+		})
+	})
 })
+
+type fakeRollout struct {
+	core_xds.PolicyRollout
+	responses    uint64
+	serverErrors uint64
+}
+
+func (f *fakeRollout) RecordResponses(_ core_xds.ProxyId, total uint64, errors uint64) {
+	f.responses += total
+	f.serverErrors += errors
+}
+
+// concurrentlyModifyingManager modifies a resource right before its first update, as if it were modified concurrently.
+type concurrentlyModifyingManager struct {
+	manager.ResourceManager
+	modified bool
+}
+
+func (m *concurrentlyModifyingManager) Update(ctx context.Context, resource model.Resource, fs ...store.UpdateOptionsFunc) error {
+	if !m.modified {
+		m.modified = true
+		insight := &mesh.DataplaneInsightResource{}
+		meta := resource.GetMeta()
+		if err := m.ResourceManager.Get(ctx, insight, store.GetByKey(meta.GetNamespace(), meta.GetName(), meta.GetMesh())); err != nil {
+			return err
+		}
+		insight.Spec.Subscriptions = append(insight.Spec.Subscriptions, &mesh_proto.DiscoverySubscription{Id: "1"})
+		if err := m.ResourceManager.Update(ctx, insight); err != nil {
+			return err
+		}
+	}
+	return m.ResourceManager.Update(ctx, resource, fs...)
+}
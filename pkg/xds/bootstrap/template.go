@@ -1,11 +1,26 @@
 package bootstrap
 
+// AdminClusterName is the name of a static cluster that points to Envoy Admin interface,
+// e.g. to expose metrics of Envoy to Prometheus.
+const AdminClusterName = "kuma:envoy:admin"
+
+// AdsClusterName is the name of a static cluster that points to the Control Plane,
+// e.g. to stream access logs to it.
+const AdsClusterName = "ads_cluster"
+
+// DatadogCollectorClusterName is the name of a static cluster that points to Datadog agent.
+const DatadogCollectorClusterName = "kuma:tracing:datadog"
+
+// DatadogTracerName is the name of Envoy's HTTP trace driver that reports traces to Datadog agent.
+const DatadogTracerName = "envoy.tracers.datadog"
+
 type configParameters struct {
 	Id        string
 	Service   string
 	AdminPort uint32
 	XdsHost   string
 	XdsPort   uint32
+	XdsDelta  bool
 }
 
 const configTemplate string = `
@@ -25,7 +40,17 @@ admin:
 
 dynamic_resources:
   lds_config: {ads: {}}
+{{if .XdsDelta}}
+  # Envoy supports the incremental (delta) xDS for Clusters only, which are subscribed to outside of ADS
+  cds_config:
+    api_config_source:
+      api_type: DELTA_GRPC
+      grpc_services:
+      - envoy_grpc:
+          cluster_name: ads_cluster
+{{else}}
   cds_config: {ads: {}}
+{{end}}
   ads_config:
     api_type: GRPC
     grpc_services:
@@ -52,4 +77,19 @@ static_resources:
               socket_address:
                 address: {{ .XdsHost }}
                 port_value: {{ .XdsPort }}
+{{if .AdminPort }}
+  - name: kuma:envoy:admin
+    connect_timeout: 0.25s
+    type: STATIC
+    lb_policy: ROUND_ROBIN
+    load_assignment:
+      cluster_name: kuma:envoy:admin
+      endpoints:
+      - lb_endpoints:
+        - endpoint:
+            address:
+              socket_address:
+                address: 127.0.0.1
+                port_value: {{ .AdminPort }}
+{{ end }}
 `
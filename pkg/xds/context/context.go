@@ -1,10 +1,13 @@
 package context
 
 import (
-	"fmt"
 	"io/ioutil"
+	"net"
+	"strconv"
 
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
 	kuma_cp "github.com/Kong/kuma/pkg/config/app/kuma-cp"
+	util_tls "github.com/Kong/kuma/pkg/tls"
 )
 
 type Context struct {
@@ -17,12 +20,35 @@ type ControlPlaneContext struct {
 	SdsTlsCert  []byte
 
 	DataplaneTokenFile string
+
+	// SingleOutboundListener means that in transparent proxying mode outbound traffic must be handled
+	// by the catch-all listener rather than by a listener per outbound interface.
+	SingleOutboundListener bool
+
+	// MtlsMinVersion is the minimum TLS version of mTLS connections between Dataplanes, e.g. TLSv1_2.
+	// Envoy's default is used if empty.
+	MtlsMinVersion string
+	// MtlsCipherSuites are cipher suites, in OpenSSL format, allowed in mTLS connections between Dataplanes.
+	// Envoy's defaults are used if empty.
+	MtlsCipherSuites []string
 }
 
 type MeshContext struct {
 	LoggingEnabled bool
 	LoggingPath    string
-	TlsEnabled     bool
+	// LoggingBackends are names of backends that Dataplanes stream access logs to
+	// through the Control Plane.
+	LoggingBackends []string
+	TlsEnabled      bool
+	// Mtls holds mTLS settings of the mesh, e.g. ports that accept plaintext connections even though TLS is enabled.
+	Mtls *mesh_proto.Mesh_Mtls
+	// PassthroughDisabled means that traffic to destinations unknown to the mesh must be blocked
+	// rather than passed through.
+	PassthroughDisabled bool
+	// Outbound holds settings of outbound traffic, e.g. limits of connections and requests to destination services.
+	Outbound *mesh_proto.Networking_Outbound
+	// Prometheus tells how Dataplanes expose their metrics to Prometheus, if they do.
+	Prometheus *mesh_proto.Metrics_Prometheus
 }
 
 func BuildControlPlaneContext(config kuma_cp.Config) (*ControlPlaneContext, error) {
@@ -34,7 +60,11 @@ func BuildControlPlaneContext(config kuma_cp.Config) (*ControlPlaneContext, erro
 		}
 		cert = c
 	}
-	sdsLocation := fmt.Sprintf("%s:%d", config.BootstrapServer.Params.XdsHost, config.SdsServer.GrpcPort)
+	sdsLocation := net.JoinHostPort(config.BootstrapServer.Params.XdsHost, strconv.Itoa(config.SdsServer.GrpcPort))
+	cipherSuites, err := util_tls.OpenSSLCipherSuiteNames(config.XdsServer.MeshMtls.TlsCipherSuites)
+	if err != nil {
+		return nil, err
+	}
 	dataplaneTokenFile := ""
 	if config.Environment == kuma_cp.KubernetesEnvironment {
 		dataplaneTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
@@ -45,5 +75,10 @@ func BuildControlPlaneContext(config kuma_cp.Config) (*ControlPlaneContext, erro
 		SdsTlsCert:  cert,
 
 		DataplaneTokenFile: dataplaneTokenFile,
+
+		SingleOutboundListener: config.XdsServer.SingleOutboundListenerEnabled,
+
+		MtlsMinVersion:   config.XdsServer.MeshMtls.TlsMinVersion,
+		MtlsCipherSuites: cipherSuites,
 	}, nil
 }
@@ -9,15 +9,20 @@ import (
 
 	"github.com/gogo/protobuf/types"
 
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
 	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
 	util_error "github.com/Kong/kuma/pkg/util/error"
+	"github.com/Kong/kuma/pkg/xds/bootstrap"
 	xds_context "github.com/Kong/kuma/pkg/xds/context"
 	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	envoy_cluster "github.com/envoyproxy/go-control-plane/envoy/api/v2/cluster"
 	"github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
 	"github.com/envoyproxy/go-control-plane/envoy/api/v2/endpoint"
 	envoy_listener "github.com/envoyproxy/go-control-plane/envoy/api/v2/listener"
+	envoy_route "github.com/envoyproxy/go-control-plane/envoy/api/v2/route"
 	accesslog "github.com/envoyproxy/go-control-plane/envoy/config/accesslog/v2"
 	filter_accesslog "github.com/envoyproxy/go-control-plane/envoy/config/filter/accesslog/v2"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/config/filter/network/http_connection_manager/v2"
 	tcp "github.com/envoyproxy/go-control-plane/envoy/config/filter/network/tcp_proxy/v2"
 	grpc_credential "github.com/envoyproxy/go-control-plane/envoy/config/grpc_credential/v2alpha"
 	"github.com/envoyproxy/go-control-plane/pkg/util"
@@ -48,10 +53,17 @@ func CreateStaticEndpoint(clusterName string, address string, port uint32) *v2.C
 	}
 }
 
+// CreateClusterLoadAssignment returns endpoints of a cluster. Endpoints with a weight, e.g. of a service with slow start,
+// get their share of traffic according to it.
 func CreateClusterLoadAssignment(clusterName string, endpoints []net.SRV) *v2.ClusterLoadAssignment {
 	lbEndpoints := make([]endpoint.LbEndpoint, 0, len(endpoints))
 	for _, ep := range endpoints {
+		var weight *types.UInt32Value
+		if ep.Weight > 0 {
+			weight = &types.UInt32Value{Value: uint32(ep.Weight)}
+		}
 		lbEndpoints = append(lbEndpoints, endpoint.LbEndpoint{
+			LoadBalancingWeight: weight,
 			HostIdentifier: &endpoint.LbEndpoint_Endpoint{
 				Endpoint: &endpoint.Endpoint{
 					Address: &core.Address{
@@ -85,8 +97,8 @@ func CreateLocalCluster(clusterName string, address string, port uint32) *v2.Clu
 	}
 }
 
-func CreateEdsCluster(ctx xds_context.Context, clusterName string) *v2.Cluster {
-	return &v2.Cluster{
+func CreateEdsCluster(ctx xds_context.Context, clusterName string, service string) *v2.Cluster {
+	cluster := &v2.Cluster{
 		Name:                 clusterName,
 		ConnectTimeout:       5 * time.Second,
 		ClusterDiscoveryType: &v2.Cluster_Type{Type: v2.Cluster_EDS},
@@ -99,6 +111,46 @@ func CreateEdsCluster(ctx xds_context.Context, clusterName string) *v2.Cluster {
 		},
 		TlsContext: CreateUpstreamTlsContext(ctx),
 	}
+	if pool := ctx.Mesh.Outbound.GetConnectionPoolFor(service); pool != nil {
+		applyConnectionPool(cluster, pool)
+	}
+	return cluster
+}
+
+// applyConnectionPool maps limits of a connection pool onto circuit breakers and HTTP protocol options of a cluster.
+//
+// HTTP limits only take effect if traffic to the cluster is proxied as HTTP, e.g. by a listener of a ProxyTemplate.
+func applyConnectionPool(cluster *v2.Cluster, pool *mesh_proto.ConnectionPool) {
+	thresholds := envoy_cluster.CircuitBreakers_Thresholds{
+		Priority:           core.RoutingPriority_DEFAULT,
+		MaxConnections:     pool.GetTcp().GetMaxConnections(),
+		MaxPendingRequests: pool.GetHttp().GetMaxPendingRequests(),
+		MaxRequests:        pool.GetHttp().GetMaxRequests(),
+	}
+	if thresholds.MaxConnections != nil || thresholds.MaxPendingRequests != nil || thresholds.MaxRequests != nil {
+		cluster.CircuitBreakers = &envoy_cluster.CircuitBreakers{
+			Thresholds: []*envoy_cluster.CircuitBreakers_Thresholds{&thresholds},
+		}
+	}
+	cluster.MaxRequestsPerConnection = pool.GetHttp().GetMaxRequestsPerConnection()
+	if idleTimeout := pool.GetHttp().GetIdleTimeout(); idleTimeout != nil {
+		timeout, err := types.DurationFromProto(idleTimeout)
+		util_error.MustNot(err)
+		cluster.CommonHttpProtocolOptions = &core.HttpProtocolOptions{
+			IdleTimeout: &timeout,
+		}
+	}
+}
+
+// tcpIdleTimeout returns the idle timeout of connections to a given service or nil if Envoy's default applies.
+func tcpIdleTimeout(ctx xds_context.Context, service string) *time.Duration {
+	idleTimeout := ctx.Mesh.Outbound.GetConnectionPoolFor(service).GetTcp().GetIdleTimeout()
+	if idleTimeout == nil {
+		return nil
+	}
+	timeout, err := types.DurationFromProto(idleTimeout)
+	util_error.MustNot(err)
+	return &timeout
 }
 
 func CreatePassThroughCluster(clusterName string) *v2.Cluster {
@@ -110,12 +162,22 @@ func CreatePassThroughCluster(clusterName string) *v2.Cluster {
 	}
 }
 
-func CreateOutboundListener(ctx xds_context.Context, listenerName string, address string, port uint32, clusterName string, virtual bool) *v2.Listener {
+// CreateBlackholeCluster creates a cluster without endpoints, so that all connections to it get closed.
+func CreateBlackholeCluster(clusterName string) *v2.Cluster {
+	return &v2.Cluster{
+		Name:                 clusterName,
+		ConnectTimeout:       5 * time.Second,
+		ClusterDiscoveryType: &v2.Cluster_Type{Type: v2.Cluster_STATIC},
+	}
+}
+
+func CreateOutboundListener(ctx xds_context.Context, listenerName string, address string, port uint32, clusterName string, service string, virtual bool) *v2.Listener {
 	config := &tcp.TcpProxy{
 		StatPrefix: clusterName,
 		ClusterSpecifier: &tcp.TcpProxy_Cluster{
 			Cluster: clusterName,
 		},
+		IdleTimeout: tcpIdleTimeout(ctx, service),
 	}
 	pbst, err := types.MarshalAny(config)
 	util_error.MustNot(err)
@@ -150,7 +212,11 @@ func CreateOutboundListener(ctx xds_context.Context, listenerName string, addres
 	return listener
 }
 
-func CreateInboundListener(ctx xds_context.Context, listenerName string, address string, port uint32, clusterName string, virtual bool, permissions *mesh_core.TrafficPermissionResourceList) *v2.Listener {
+// CreateInboundListener generates a listener that passes connections to a service next to a Dataplane.
+//
+// If permissive, then the listener accepts plaintext connections in addition to mTLS ones.
+// Plaintext connections are not subject to TrafficPermissions, since their source cannot be identified.
+func CreateInboundListener(ctx xds_context.Context, listenerName string, address string, port uint32, clusterName string, virtual bool, permissive bool, permissions *mesh_core.TrafficPermissionResourceList) *v2.Listener {
 	config := &tcp.TcpProxy{
 		StatPrefix: clusterName,
 		ClusterSpecifier: &tcp.TcpProxy_Cluster{
@@ -160,6 +226,12 @@ func CreateInboundListener(ctx xds_context.Context, listenerName string, address
 	}
 	pbst, err := types.MarshalAny(config)
 	util_error.MustNot(err)
+	tcpProxy := envoy_listener.Filter{
+		Name: util.TCPProxy,
+		ConfigType: &envoy_listener.Filter_TypedConfig{
+			TypedConfig: pbst,
+		},
+	}
 	listener := &v2.Listener{
 		Name: listenerName,
 		Address: core.Address{
@@ -175,12 +247,7 @@ func CreateInboundListener(ctx xds_context.Context, listenerName string, address
 		},
 		FilterChains: []envoy_listener.FilterChain{{
 			TlsContext: CreateDownstreamTlsContext(ctx),
-			Filters: []envoy_listener.Filter{{
-				Name: util.TCPProxy,
-				ConfigType: &envoy_listener.Filter_TypedConfig{
-					TypedConfig: pbst,
-				},
-			}},
+			Filters:    []envoy_listener.Filter{tcpProxy},
 		}},
 	}
 
@@ -190,6 +257,19 @@ func CreateInboundListener(ctx xds_context.Context, listenerName string, address
 		listener.FilterChains[0].Filters = append([]envoy_listener.Filter{filter}, listener.FilterChains[0].Filters...)
 	}
 
+	if ctx.Mesh.TlsEnabled && permissive {
+		// connections that don't start with a TLS handshake fall through to the plaintext filter chain
+		listener.ListenerFilters = []envoy_listener.ListenerFilter{{
+			Name: util.TlsInspector,
+		}}
+		listener.FilterChains[0].FilterChainMatch = &envoy_listener.FilterChainMatch{
+			TransportProtocol: "tls",
+		}
+		listener.FilterChains = append(listener.FilterChains, envoy_listener.FilterChain{
+			Filters: []envoy_listener.Filter{tcpProxy},
+		})
+	}
+
 	if virtual {
 		// TODO(yskopets): What is the up-to-date alternative ?
 		listener.DeprecatedV1 = &v2.Listener_DeprecatedV1{
@@ -199,25 +279,55 @@ func CreateInboundListener(ctx xds_context.Context, listenerName string, address
 	return listener
 }
 
+// TCPGRPCAccessLog is the name of a sink that streams TCP access log entries
+// to a gRPC access log service.
+const TCPGRPCAccessLog = "envoy.tcp_grpc_access_log"
+
 func accessLog(ctx xds_context.Context) []*filter_accesslog.AccessLog {
 	if !ctx.Mesh.LoggingEnabled {
 		return []*filter_accesslog.AccessLog{}
 	}
-	fileAccessLog := &accesslog.FileAccessLog{
-		AccessLogFormat: &accesslog.FileAccessLog_Format{
-			Format: "[%START_TIME%] %DOWNSTREAM_REMOTE_ADDRESS%->%UPSTREAM_HOST% took %DURATION%ms, sent %BYTES_SENT% bytes, received: %BYTES_RECEIVED% bytes\n",
-		},
-		Path: ctx.Mesh.LoggingPath,
+	logs := []*filter_accesslog.AccessLog{}
+	if ctx.Mesh.LoggingPath != "" {
+		fileAccessLog := &accesslog.FileAccessLog{
+			AccessLogFormat: &accesslog.FileAccessLog_Format{
+				Format: "[%START_TIME%] %DOWNSTREAM_REMOTE_ADDRESS%->%UPSTREAM_HOST% took %DURATION%ms, sent %BYTES_SENT% bytes, received: %BYTES_RECEIVED% bytes\n",
+			},
+			Path: ctx.Mesh.LoggingPath,
+		}
+		pbst, err := types.MarshalAny(fileAccessLog)
+		util_error.MustNot(err)
+		logs = append(logs, &filter_accesslog.AccessLog{
+			Name: util.FileAccessLog,
+			ConfigType: &filter_accesslog.AccessLog_TypedConfig{
+				TypedConfig: pbst,
+			},
+		})
 	}
-	pbst, err := types.MarshalAny(fileAccessLog)
-	util_error.MustNot(err)
-	logs := &filter_accesslog.AccessLog{
-		Name: util.FileAccessLog,
-		ConfigType: &filter_accesslog.AccessLog_TypedConfig{
-			TypedConfig: pbst,
-		},
+	for _, backend := range ctx.Mesh.LoggingBackends {
+		// entries are streamed to the Control Plane, which forwards them to the backend
+		grpcAccessLog := &accesslog.TcpGrpcAccessLogConfig{
+			CommonConfig: &accesslog.CommonGrpcAccessLogConfig{
+				LogName: backend,
+				GrpcService: &core.GrpcService{
+					TargetSpecifier: &core.GrpcService_EnvoyGrpc_{
+						EnvoyGrpc: &core.GrpcService_EnvoyGrpc{
+							ClusterName: bootstrap.AdsClusterName,
+						},
+					},
+				},
+			},
+		}
+		pbst, err := types.MarshalAny(grpcAccessLog)
+		util_error.MustNot(err)
+		logs = append(logs, &filter_accesslog.AccessLog{
+			Name: TCPGRPCAccessLog,
+			ConfigType: &filter_accesslog.AccessLog_TypedConfig{
+				TypedConfig: pbst,
+			},
+		})
 	}
-	return []*filter_accesslog.AccessLog{logs}
+	return logs
 }
 
 func CreateDownstreamTlsContext(ctx xds_context.Context) *auth.DownstreamTlsContext {
@@ -241,6 +351,7 @@ func CreateUpstreamTlsContext(ctx xds_context.Context) *auth.UpstreamTlsContext
 
 func CreateCommonTlsContext(ctx xds_context.Context) *auth.CommonTlsContext {
 	return &auth.CommonTlsContext{
+		TlsParams: tlsParams(ctx),
 		ValidationContextType: &auth.CommonTlsContext_ValidationContextSdsSecretConfig{
 			ValidationContextSdsSecretConfig: sdsSecretConfig(ctx, server.MeshCaResource),
 		},
@@ -250,6 +361,27 @@ func CreateCommonTlsContext(ctx xds_context.Context) *auth.CommonTlsContext {
 	}
 }
 
+func tlsParams(ctx xds_context.Context) *auth.TlsParameters {
+	if ctx.ControlPlane.MtlsMinVersion == "" && len(ctx.ControlPlane.MtlsCipherSuites) == 0 {
+		return nil
+	}
+	params := &auth.TlsParameters{
+		CipherSuites: ctx.ControlPlane.MtlsCipherSuites,
+	}
+	if ctx.ControlPlane.MtlsMinVersion != "" {
+		params.TlsMinimumProtocolVersion = auth.TlsParameters_TlsProtocol(auth.TlsParameters_TlsProtocol_value[ctx.ControlPlane.MtlsMinVersion])
+		// Envoy's default maximum of client connections is TLSv1_2, which must not end up below the minimum
+		params.TlsMaximumProtocolVersion = auth.TlsParameters_TLSv1_3
+	}
+	return params
+}
+
+// IdentityCertSdsSecretConfig returns a config of the certificate that identifies a dataplane in its Mesh,
+// as it is served by SDS Server.
+func IdentityCertSdsSecretConfig(ctx xds_context.Context) *auth.SdsSecretConfig {
+	return sdsSecretConfig(ctx, server.IdentityCertResource)
+}
+
 func sdsSecretConfig(context xds_context.Context, name string) *auth.SdsSecretConfig {
 	withCallCredentials := func(grpc *core.GrpcService_GoogleGrpc) *core.GrpcService_GoogleGrpc {
 		// TODO(yskopets): credentials should be determined based on properties of a Dataplane rather than global Control Plane settings
@@ -353,3 +485,135 @@ func CreateCatchAllListener(ctx xds_context.Context, listenerName string, addres
 		//}},
 	}
 }
+
+// OutboundDestination is a destination that traffic intercepted in transparent proxying mode is sent to.
+type OutboundDestination struct {
+	Address     string
+	Port        uint32
+	ClusterName string
+	// Service is a value of the `service` tag of the destination.
+	Service string
+}
+
+// CreateTransparentOutboundListener generates a listener that handles all traffic intercepted in transparent proxying mode.
+//
+// Unlike CreateCatchAllListener, it doesn't hand over connections to other listeners. Instead, the original destination
+// of a connection is restored and then used to select a filter chain. Connections to a destination that isn't known
+// are sent to a default cluster.
+func CreateTransparentOutboundListener(ctx xds_context.Context, listenerName string, address string, port uint32, destinations []OutboundDestination, defaultClusterName string) *v2.Listener {
+	filterChains := make([]envoy_listener.FilterChain, 0, len(destinations)+1)
+	for _, destination := range destinations {
+		prefixLen := uint32(32)
+		if ip := net.ParseIP(destination.Address); ip != nil && ip.To4() == nil {
+			prefixLen = 128
+		}
+		filterChain := tcpProxyFilterChain(destination.ClusterName, tcpIdleTimeout(ctx, destination.Service), nil)
+		filterChain.FilterChainMatch = &envoy_listener.FilterChainMatch{
+			DestinationPort: &types.UInt32Value{Value: destination.Port},
+			PrefixRanges: []*core.CidrRange{{
+				AddressPrefix: destination.Address,
+				PrefixLen:     &types.UInt32Value{Value: prefixLen},
+			}},
+		}
+		filterChains = append(filterChains, filterChain)
+	}
+	filterChains = append(filterChains, tcpProxyFilterChain(defaultClusterName, nil, accessLog(ctx)))
+	return &v2.Listener{
+		Name: listenerName,
+		Address: core.Address{
+			Address: &core.Address_SocketAddress{
+				SocketAddress: &core.SocketAddress{
+					Protocol: core.TCP,
+					Address:  address,
+					PortSpecifier: &core.SocketAddress_PortValue{
+						PortValue: port,
+					},
+				},
+			},
+		},
+		FilterChains: filterChains,
+		ListenerFilters: []envoy_listener.ListenerFilter{{
+			Name: util.OriginalDestination,
+		}},
+	}
+}
+
+// CreatePrometheusListener returns a listener that exposes metrics of Envoy in the Prometheus format
+// on a given path by forwarding requests to /stats/prometheus of Envoy Admin interface.
+func CreatePrometheusListener(listenerName string, address string, port uint32, path string, clusterName string) *v2.Listener {
+	config := &hcm.HttpConnectionManager{
+		StatPrefix: listenerName,
+		CodecType:  hcm.AUTO,
+		HttpFilters: []*hcm.HttpFilter{{
+			Name: util.Router,
+		}},
+		RouteSpecifier: &hcm.HttpConnectionManager_RouteConfig{
+			RouteConfig: &v2.RouteConfiguration{
+				VirtualHosts: []envoy_route.VirtualHost{{
+					Name:    listenerName,
+					Domains: []string{"*"},
+					Routes: []envoy_route.Route{{
+						Match: envoy_route.RouteMatch{
+							PathSpecifier: &envoy_route.RouteMatch_Path{
+								Path: path,
+							},
+						},
+						Action: &envoy_route.Route_Route{
+							Route: &envoy_route.RouteAction{
+								ClusterSpecifier: &envoy_route.RouteAction_Cluster{
+									Cluster: clusterName,
+								},
+								PrefixRewrite: "/stats/prometheus",
+							},
+						},
+					}},
+				}},
+			},
+		},
+	}
+	pbst, err := types.MarshalAny(config)
+	util_error.MustNot(err)
+	return &v2.Listener{
+		Name: listenerName,
+		Address: core.Address{
+			Address: &core.Address_SocketAddress{
+				SocketAddress: &core.SocketAddress{
+					Protocol: core.TCP,
+					Address:  address,
+					PortSpecifier: &core.SocketAddress_PortValue{
+						PortValue: port,
+					},
+				},
+			},
+		},
+		FilterChains: []envoy_listener.FilterChain{{
+			Filters: []envoy_listener.Filter{{
+				Name: util.HTTPConnectionManager,
+				ConfigType: &envoy_listener.Filter_TypedConfig{
+					TypedConfig: pbst,
+				},
+			}},
+		}},
+	}
+}
+
+func tcpProxyFilterChain(clusterName string, idleTimeout *time.Duration, accessLog []*filter_accesslog.AccessLog) envoy_listener.FilterChain {
+	config := &tcp.TcpProxy{
+		StatPrefix: clusterName,
+		ClusterSpecifier: &tcp.TcpProxy_Cluster{
+			Cluster: clusterName,
+		},
+		IdleTimeout: idleTimeout,
+		AccessLog:   accessLog,
+	}
+	pbst, err := types.MarshalAny(config)
+	util_error.MustNot(err)
+	return envoy_listener.FilterChain{
+		Filters: []envoy_listener.Filter{{
+			Name: util.TCPProxy,
+			ConfigType: &envoy_listener.Filter_TypedConfig{
+				TypedConfig: pbst,
+			},
+		}},
+	}
+}
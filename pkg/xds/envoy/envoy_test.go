@@ -2,11 +2,14 @@ package envoy_test
 
 import (
 	"net"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 
+	"github.com/gogo/protobuf/types"
+
 	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
 	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
 	test_model "github.com/Kong/kuma/pkg/test/resources/model"
@@ -93,7 +96,7 @@ var _ = Describe("Envoy", func() {
 		DescribeTable("should generate 'EDS' Cluster",
 			func(given testCase) {
 				// when
-				resource := envoy.CreateEdsCluster(given.ctx, "192.168.0.1:8080")
+				resource := envoy.CreateEdsCluster(given.ctx, "192.168.0.1:8080", "backend")
 
 				// then
 				actual, err := util_proto.ToYAML(resource)
@@ -211,6 +214,61 @@ var _ = Describe("Envoy", func() {
                               statPrefix: sds_mesh_ca
                               targetUri: kuma-control-plane:5677
                 type: EDS
+`,
+			}),
+			Entry("with mTLS and TLS constraints", testCase{
+				ctx: xds_context.Context{
+					ControlPlane: &xds_context.ControlPlaneContext{
+						SdsLocation:      "kuma-control-plane:5677",
+						SdsTlsCert:       []byte("CERTIFICATE"),
+						MtlsMinVersion:   "TLSv1_2",
+						MtlsCipherSuites: []string{"ECDHE-RSA-AES128-GCM-SHA256", "ECDHE-RSA-AES256-GCM-SHA384"},
+					},
+					Mesh: xds_context.MeshContext{
+						TlsEnabled: true,
+					},
+				},
+				expected: `
+                connectTimeout: 5s
+                edsClusterConfig:
+                  edsConfig:
+                    ads: {}
+                name: 192.168.0.1:8080
+                tlsContext:
+                  commonTlsContext:
+                    tlsParams:
+                      tlsMinimumProtocolVersion: TLSv1_2
+                      tlsMaximumProtocolVersion: TLSv1_3
+                      cipherSuites:
+                      - ECDHE-RSA-AES128-GCM-SHA256
+                      - ECDHE-RSA-AES256-GCM-SHA384
+                    tlsCertificateSdsSecretConfigs:
+                    - name: identity_cert
+                      sdsConfig:
+                        apiConfigSource:
+                          apiType: GRPC
+                          grpcServices:
+                          - googleGrpc:
+                              channelCredentials:
+                                sslCredentials:
+                                  rootCerts:
+                                    inlineBytes: Q0VSVElGSUNBVEU=
+                              statPrefix: sds_identity_cert
+                              targetUri: kuma-control-plane:5677
+                    validationContextSdsSecretConfig:
+                      name: mesh_ca
+                      sdsConfig:
+                        apiConfigSource:
+                          apiType: GRPC
+                          grpcServices:
+                          - googleGrpc:
+                              channelCredentials:
+                                sslCredentials:
+                                  rootCerts:
+                                    inlineBytes: Q0VSVElGSUNBVEU=
+                              statPrefix: sds_mesh_ca
+                              targetUri: kuma-control-plane:5677
+                type: EDS
 `,
 			}),
 			Entry("with mTLS and Dataplane credentials", testCase{
@@ -275,6 +333,51 @@ var _ = Describe("Envoy", func() {
                               statPrefix: sds_mesh_ca
                               targetUri: kuma-control-plane:5677
                 type: EDS
+`,
+			}),
+			Entry("with connection pool", testCase{
+				ctx: xds_context.Context{
+					ControlPlane: &xds_context.ControlPlaneContext{},
+					Mesh: xds_context.MeshContext{
+						Outbound: &mesh_proto.Networking_Outbound{
+							ConnectionPools: []*mesh_proto.ConnectionPool{
+								{
+									Service: "web",
+									Tcp: &mesh_proto.ConnectionPool_Tcp{
+										MaxConnections: &types.UInt32Value{Value: 1},
+									},
+								},
+								{
+									Service: "backend",
+									Tcp: &mesh_proto.ConnectionPool_Tcp{
+										MaxConnections: &types.UInt32Value{Value: 100},
+									},
+									Http: &mesh_proto.ConnectionPool_Http{
+										MaxPendingRequests:       &types.UInt32Value{Value: 200},
+										MaxRequests:              &types.UInt32Value{Value: 300},
+										MaxRequestsPerConnection: &types.UInt32Value{Value: 50},
+										IdleTimeout:              types.DurationProto(30 * time.Second),
+									},
+								},
+							},
+						},
+					},
+				},
+				expected: `
+                circuitBreakers:
+                  thresholds:
+                  - maxConnections: 100
+                    maxPendingRequests: 200
+                    maxRequests: 300
+                commonHttpProtocolOptions:
+                  idleTimeout: 30s
+                connectTimeout: 5s
+                edsClusterConfig:
+                  edsConfig:
+                    ads: {}
+                maxRequestsPerConnection: 50
+                name: 192.168.0.1:8080
+                type: EDS
 `,
 			}),
 		)
@@ -311,12 +414,46 @@ var _ = Describe("Envoy", func() {
 		Expect(actual).To(MatchYAML(expected))
 	})
 
+	It("should generate ClusterLoadAssignment with weighted endpoints", func() {
+		// given
+		expected := `
+        clusterName: 127.0.0.1:8080
+        endpoints:
+        - lbEndpoints:
+          - endpoint:
+              address:
+                socketAddress:
+                  address: 192.168.0.1
+                  portValue: 8081
+            loadBalancingWeight: 100
+          - endpoint:
+              address:
+                socketAddress:
+                  address: 192.168.0.2
+                  portValue: 8082
+            loadBalancingWeight: 10
+`
+		// when
+		resource := envoy.CreateClusterLoadAssignment("127.0.0.1:8080",
+			[]net.SRV{
+				{Target: "192.168.0.1", Port: 8081, Weight: 100},
+				{Target: "192.168.0.2", Port: 8082, Weight: 10},
+			})
+
+		// then
+		actual, err := util_proto.ToYAML(resource)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(actual).To(MatchYAML(expected))
+	})
+
 	Describe("'inbound' listener", func() {
 
 		type testCase struct {
-			ctx      xds_context.Context
-			virtual  bool
-			expected string
+			ctx        xds_context.Context
+			virtual    bool
+			permissive bool
+			expected   string
 		}
 
 		DescribeTable("should generate 'inbound' Listener",
@@ -357,7 +494,7 @@ var _ = Describe("Envoy", func() {
 				}
 
 				// when
-				resource := envoy.CreateInboundListener(given.ctx, "inbound:192.168.0.1:8080", "192.168.0.1", 8080, "localhost:8080", given.virtual, permissions)
+				resource := envoy.CreateInboundListener(given.ctx, "inbound:192.168.0.1:8080", "192.168.0.1", 8080, "localhost:8080", given.virtual, given.permissive, permissions)
 
 				// then
 				actual, err := util_proto.ToYAML(resource)
@@ -418,6 +555,40 @@ filterChains:
       cluster: localhost:8080
       statPrefix: localhost:8080
 name: inbound:192.168.0.1:8080
+`,
+			}),
+			Entry("with access logs forwarded to backends", testCase{
+				ctx: xds_context.Context{
+					ControlPlane: &xds_context.ControlPlaneContext{},
+					Mesh: xds_context.MeshContext{
+						TlsEnabled:      false,
+						LoggingEnabled:  true,
+						LoggingBackends: []string{"splunk"},
+					},
+				},
+				virtual: false,
+				expected: `
+address:
+  socketAddress:
+    address: 192.168.0.1
+    portValue: 8080
+filterChains:
+- filters:
+  - name: envoy.tcp_proxy
+    typedConfig:
+      '@type': type.googleapis.com/envoy.config.filter.network.tcp_proxy.v2.TcpProxy
+      accessLog:
+      - name: envoy.tcp_grpc_access_log
+        typedConfig:
+          '@type': type.googleapis.com/envoy.config.accesslog.v2.TcpGrpcAccessLogConfig
+          commonConfig:
+            grpcService:
+              envoyGrpc:
+                clusterName: ads_cluster
+            logName: splunk
+      cluster: localhost:8080
+      statPrefix: localhost:8080
+name: inbound:192.168.0.1:8080
 `,
 			}),
 			Entry("with transparent proxying", testCase{
@@ -613,7 +784,7 @@ name: inbound:192.168.0.1:8080
 		DescribeTable("should generate 'outbound' Listener",
 			func(given testCase) {
 				// when
-				resource := envoy.CreateOutboundListener(given.ctx, "outbound:127.0.0.1:18080", "127.0.0.1", 18080, "outbound:127.0.0.1:18080", given.virtual)
+				resource := envoy.CreateOutboundListener(given.ctx, "outbound:127.0.0.1:18080", "127.0.0.1", 18080, "outbound:127.0.0.1:18080", "backend", given.virtual)
 
 				// then
 				actual, err := util_proto.ToYAML(resource)
@@ -667,6 +838,37 @@ name: inbound:192.168.0.1:8080
                       cluster: outbound:127.0.0.1:18080
                       statPrefix: outbound:127.0.0.1:18080
                 name: outbound:127.0.0.1:18080
+`,
+			}),
+			Entry("with idle timeout of connections to the service", testCase{
+				ctx: xds_context.Context{
+					ControlPlane: &xds_context.ControlPlaneContext{},
+					Mesh: xds_context.MeshContext{
+						Outbound: &mesh_proto.Networking_Outbound{
+							ConnectionPools: []*mesh_proto.ConnectionPool{{
+								Service: "backend",
+								Tcp: &mesh_proto.ConnectionPool_Tcp{
+									IdleTimeout: types.DurationProto(5 * time.Minute),
+								},
+							}},
+						},
+					},
+				},
+				virtual: false,
+				expected: `
+                address:
+                  socketAddress:
+                    address: 127.0.0.1
+                    portValue: 18080
+                filterChains:
+                - filters:
+                  - name: envoy.tcp_proxy
+                    typedConfig:
+                      '@type': type.googleapis.com/envoy.config.filter.network.tcp_proxy.v2.TcpProxy
+                      cluster: outbound:127.0.0.1:18080
+                      idleTimeout: 300s
+                      statPrefix: outbound:127.0.0.1:18080
+                name: outbound:127.0.0.1:18080
 `,
 			}),
 			Entry("with mTLS", testCase{
@@ -754,4 +956,43 @@ name: inbound:192.168.0.1:8080
 		Expect(err).ToNot(HaveOccurred())
 		Expect(actual).To(MatchYAML(expected))
 	})
+
+	It("should generate 'prometheus' Listener", func() {
+		// given
+		expected := `
+        name: kuma:metrics:prometheus
+        address:
+          socketAddress:
+            address: 192.168.0.1
+            portValue: 5670
+        filterChains:
+        - filters:
+          - name: envoy.http_connection_manager
+            typedConfig:
+              '@type': type.googleapis.com/envoy.config.filter.network.http_connection_manager.v2.HttpConnectionManager
+              httpFilters:
+              - name: envoy.router
+              routeConfig:
+                virtualHosts:
+                - domains:
+                  - '*'
+                  name: kuma:metrics:prometheus
+                  routes:
+                  - match:
+                      path: /metrics
+                    route:
+                      cluster: kuma:envoy:admin
+                      prefixRewrite: /stats/prometheus
+              statPrefix: kuma:metrics:prometheus
+`
+
+		// when
+		resource := envoy.CreatePrometheusListener("kuma:metrics:prometheus", "192.168.0.1", 5670, "/metrics", "kuma:envoy:admin")
+
+		// then
+		actual, err := util_proto.ToYAML(resource)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(actual).To(MatchYAML(expected))
+	})
 })
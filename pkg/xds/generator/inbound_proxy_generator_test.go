@@ -36,6 +36,12 @@ var _ = Describe("InboundProxyGenerator", func() {
 				},
 				Mesh: xds_context.MeshContext{
 					TlsEnabled: true,
+					Mtls: &mesh_proto.Mesh_Mtls{
+						Enabled: true,
+						PermissivePorts: []*mesh_proto.Mesh_Mtls_PermissivePort{
+							{Service: "legacy", Port: 80},
+						},
+					},
 				},
 			}
 
@@ -132,5 +138,13 @@ var _ = Describe("InboundProxyGenerator", func() {
 			dataplaneFile:   "8-dataplane.input.yaml",
 			envoyConfigFile: "8-envoy-config.golden.yaml",
 		}),
+		Entry("09. bind, advertised and service addresses are overridden", testCase{
+			dataplaneFile:   "9-dataplane.input.yaml",
+			envoyConfigFile: "9-envoy-config.golden.yaml",
+		}),
+		Entry("10. permissive port accepts plaintext connections", testCase{
+			dataplaneFile:   "10-dataplane.input.yaml",
+			envoyConfigFile: "10-envoy-config.golden.yaml",
+		}),
 	)
 })
@@ -4,11 +4,14 @@ import (
 	"io/ioutil"
 	"net"
 	"path/filepath"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 
+	"github.com/gogo/protobuf/types"
+
 	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
 	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
 	model "github.com/Kong/kuma/pkg/core/xds"
@@ -38,6 +41,35 @@ var _ = Describe("OutboundProxyGenerator", func() {
 		},
 	}
 
+	singleListenerCtx := xds_context.Context{
+		ControlPlane: &xds_context.ControlPlaneContext{
+			SingleOutboundListener: true,
+		},
+		Mesh: xds_context.MeshContext{
+			TlsEnabled: false,
+		},
+	}
+
+	connectionPoolCtx := xds_context.Context{
+		ControlPlane: &xds_context.ControlPlaneContext{},
+		Mesh: xds_context.MeshContext{
+			Outbound: &mesh_proto.Networking_Outbound{
+				ConnectionPools: []*mesh_proto.ConnectionPool{
+					{
+						Service: "backend",
+						Tcp: &mesh_proto.ConnectionPool_Tcp{
+							MaxConnections: &types.UInt32Value{Value: 100},
+							IdleTimeout:    types.DurationProto(10 * time.Minute),
+						},
+						Http: &mesh_proto.ConnectionPool_Http{
+							MaxRequests: &types.UInt32Value{Value: 1000},
+						},
+					},
+				},
+			},
+		},
+	}
+
 	type testCase struct {
 		ctx       xds_context.Context
 		dataplane string
@@ -128,5 +160,20 @@ var _ = Describe("OutboundProxyGenerator", func() {
 			dataplane: "dataplane.2.transparent.input.yaml",
 			expected:  "08.envoy.golden.yaml",
 		}),
+		Entry("09. transparent_proxying=true, single outbound listener, outbound=2", testCase{
+			ctx:       singleListenerCtx,
+			dataplane: "dataplane.2.transparent-vip.input.yaml",
+			expected:  "09.envoy.golden.yaml",
+		}),
+		Entry("10. transparent_proxying=false, single outbound listener, outbound=2", testCase{
+			ctx:       singleListenerCtx,
+			dataplane: "dataplane.2.non-transparent.input.yaml",
+			expected:  "07.envoy.golden.yaml",
+		}),
+		Entry("11. transparent_proxying=false, connection pool, outbound=2", testCase{
+			ctx:       connectionPoolCtx,
+			dataplane: "dataplane.2.non-transparent.input.yaml",
+			expected:  "11.envoy.golden.yaml",
+		}),
 	)
 })
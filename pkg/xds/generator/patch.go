@@ -0,0 +1,109 @@
+package generator
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/pkg/errors"
+
+	util_proto "github.com/Kong/kuma/pkg/util/proto"
+)
+
+const (
+	nameField = "name"
+	typeField = "@type"
+)
+
+// patchResource returns a copy of a resource with a patch of the same type applied on top of it.
+//
+// Fields set in the patch override those of the resource, nested messages are patched recursively,
+// lists of messages with a name are patched element by element matched by name, other lists are replaced.
+//
+// A patch is converted to JSON, which omits fields with default values, so it cannot reset a field to its default value.
+func patchResource(resource ResourcePayload, patch ResourcePayload) (ResourcePayload, error) {
+	if reflect.TypeOf(resource) != reflect.TypeOf(patch) {
+		return nil, errors.Errorf("patch of type %T cannot be applied to a resource of type %T", patch, resource)
+	}
+	original, err := util_proto.ToMap(resource)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := util_proto.ToMap(patch)
+	if err != nil {
+		return nil, err
+	}
+	content, err := json.Marshal(patchValue(original, changes))
+	if err != nil {
+		return nil, err
+	}
+	patched := reflect.New(reflect.TypeOf(resource).Elem()).Interface().(ResourcePayload)
+	if err := util_proto.FromJSON(content, patched); err != nil {
+		return nil, errors.Wrap(err, "patched resource is not valid")
+	}
+	if v, ok := patched.(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return nil, errors.Wrap(err, "patched resource is not valid")
+		}
+	}
+	return patched, nil
+}
+
+func patchValue(original, patch interface{}) interface{} {
+	switch patch := patch.(type) {
+	case map[string]interface{}:
+		original, ok := original.(map[string]interface{})
+		if !ok || original[typeField] != patch[typeField] {
+			return patch
+		}
+		result := make(map[string]interface{}, len(original))
+		for key, value := range original {
+			result[key] = value
+		}
+		for key, value := range patch {
+			result[key] = patchValue(original[key], value)
+		}
+		return result
+	case []interface{}:
+		original, ok := original.([]interface{})
+		if !ok || !allNamed(original) || !allNamed(patch) {
+			return patch
+		}
+		result := make([]interface{}, len(original))
+		copy(result, original)
+		for _, element := range patch {
+			name := element.(map[string]interface{})[nameField]
+			idx := indexOfNamed(result, name)
+			if idx < 0 {
+				result = append(result, element)
+			} else {
+				result[idx] = patchValue(result[idx], element)
+			}
+		}
+		return result
+	default:
+		return patch
+	}
+}
+
+// allNamed returns true if all elements of a list are messages with a name.
+func allNamed(list []interface{}) bool {
+	for _, element := range list {
+		message, ok := element.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		if name, ok := message[nameField].(string); !ok || name == "" {
+			return false
+		}
+	}
+	return true
+}
+
+func indexOfNamed(list []interface{}, name interface{}) int {
+	for i, element := range list {
+		if element.(map[string]interface{})[nameField] == name {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,190 @@
+package generator_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	model "github.com/Kong/kuma/pkg/core/xds"
+	test_model "github.com/Kong/kuma/pkg/test/resources/model"
+	util_proto "github.com/Kong/kuma/pkg/util/proto"
+	xds_context "github.com/Kong/kuma/pkg/xds/context"
+	"github.com/Kong/kuma/pkg/xds/generator"
+)
+
+var _ = Describe("PrometheusEndpointGenerator", func() {
+
+	dataplaneWithInbounds := func(inbounds ...string) *model.Proxy {
+		dataplane := mesh_proto.Dataplane{
+			Networking: &mesh_proto.Dataplane_Networking{},
+		}
+		for _, inbound := range inbounds {
+			dataplane.Networking.Inbound = append(dataplane.Networking.Inbound, &mesh_proto.Dataplane_Networking_Inbound{
+				Interface: inbound,
+				Tags:      map[string]string{"service": "backend"},
+			})
+		}
+		return &model.Proxy{
+			Id: model.ProxyId{Name: "side-car", Namespace: "default"},
+			Dataplane: &mesh_core.DataplaneResource{
+				Meta: &test_model.ResourceMeta{
+					Version: "1",
+				},
+				Spec: dataplane,
+			},
+			Metadata: &model.DataplaneMetadata{
+				AdminPort: 9901,
+			},
+		}
+	}
+
+	It("should not generate anything unless Prometheus is enabled in the Mesh", func() {
+		// given
+		gen := generator.PrometheusEndpointGenerator{}
+		ctx := xds_context.Context{}
+
+		// when
+		rs, err := gen.Generate(ctx, dataplaneWithInbounds("192.168.0.1:80:8080"))
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rs).To(BeEmpty())
+	})
+
+	It("should not generate anything if Envoy Admin interface is turned off", func() {
+		// given
+		gen := generator.PrometheusEndpointGenerator{}
+		ctx := xds_context.Context{
+			Mesh: xds_context.MeshContext{
+				Prometheus: &mesh_proto.Metrics_Prometheus{},
+			},
+		}
+		proxy := dataplaneWithInbounds("192.168.0.1:80:8080")
+		proxy.Metadata = nil
+
+		// when
+		rs, err := gen.Generate(ctx, proxy)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rs).To(BeEmpty())
+	})
+
+	It("should expose metrics of a gateway on all IP addresses", func() {
+		// given
+		gen := generator.PrometheusEndpointGenerator{}
+		ctx := xds_context.Context{
+			Mesh: xds_context.MeshContext{
+				Prometheus: &mesh_proto.Metrics_Prometheus{},
+			},
+		}
+		proxy := dataplaneWithInbounds()
+		proxy.Dataplane.Spec.Networking.Gateway = &mesh_proto.Dataplane_Networking_Gateway{
+			Tags: map[string]string{"service": "edge"},
+		}
+
+		// when
+		rs, err := gen.Generate(ctx, proxy)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rs).To(HaveLen(1))
+		actual, err := util_proto.ToYAML(rs[0].Resource)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(actual).To(MatchYAML(`
+        name: kuma:metrics:prometheus
+        address:
+          socketAddress:
+            address: 0.0.0.0
+            portValue: 5670
+        filterChains:
+        - filters:
+          - name: envoy.http_connection_manager
+            typedConfig:
+              '@type': type.googleapis.com/envoy.config.filter.network.http_connection_manager.v2.HttpConnectionManager
+              httpFilters:
+              - name: envoy.router
+              routeConfig:
+                virtualHosts:
+                - domains:
+                  - '*'
+                  name: kuma:metrics:prometheus
+                  routes:
+                  - match:
+                      path: /metrics
+                    route:
+                      cluster: kuma:envoy:admin
+                      prefixRewrite: /stats/prometheus
+              statPrefix: kuma:metrics:prometheus
+`))
+	})
+
+	It("should expose metrics on the IP address of the first inbound interface", func() {
+		// given
+		gen := generator.PrometheusEndpointGenerator{}
+		ctx := xds_context.Context{
+			Mesh: xds_context.MeshContext{
+				Prometheus: &mesh_proto.Metrics_Prometheus{
+					Path: "/non-standard-path",
+				},
+			},
+		}
+
+		// and
+		expected := `
+        name: kuma:metrics:prometheus
+        address:
+          socketAddress:
+            address: 192.168.0.1
+            portValue: 5670
+        filterChains:
+        - filters:
+          - name: envoy.http_connection_manager
+            typedConfig:
+              '@type': type.googleapis.com/envoy.config.filter.network.http_connection_manager.v2.HttpConnectionManager
+              httpFilters:
+              - name: envoy.router
+              routeConfig:
+                virtualHosts:
+                - domains:
+                  - '*'
+                  name: kuma:metrics:prometheus
+                  routes:
+                  - match:
+                      path: /non-standard-path
+                    route:
+                      cluster: kuma:envoy:admin
+                      prefixRewrite: /stats/prometheus
+              statPrefix: kuma:metrics:prometheus
+`
+
+		// when
+		rs, err := gen.Generate(ctx, dataplaneWithInbounds("192.168.0.1:80:8080", "192.168.0.2:80:8080"))
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rs).To(HaveLen(1))
+		actual, err := util_proto.ToYAML(rs[0].Resource)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(actual).To(MatchYAML(expected))
+	})
+
+	It("should refuse a port that is already used by an inbound interface", func() {
+		// given
+		gen := generator.PrometheusEndpointGenerator{}
+		ctx := xds_context.Context{
+			Mesh: xds_context.MeshContext{
+				Prometheus: &mesh_proto.Metrics_Prometheus{
+					Port: 8080,
+				},
+			},
+		}
+
+		// when
+		_, err := gen.Generate(ctx, dataplaneWithInbounds("192.168.0.1:8080:80"))
+
+		// then
+		Expect(err).To(MatchError("port 8080 of Prometheus endpoint is already used by inbound interface 192.168.0.1:8080:80"))
+	})
+})
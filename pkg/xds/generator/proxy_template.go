@@ -3,12 +3,17 @@ package generator
 import (
 	"bytes"
 	"fmt"
+	"net"
+	"reflect"
+	"strconv"
 
 	kuma_mesh "github.com/Kong/kuma/api/mesh/v1alpha1"
 	model "github.com/Kong/kuma/pkg/core/xds"
+	"github.com/Kong/kuma/pkg/xds/bootstrap"
 	xds_context "github.com/Kong/kuma/pkg/xds/context"
 	"github.com/Kong/kuma/pkg/xds/envoy"
 	"github.com/Kong/kuma/pkg/xds/template"
+	"github.com/envoyproxy/go-control-plane/pkg/cache"
 	"github.com/ghodss/yaml"
 	"github.com/gogo/protobuf/jsonpb"
 	"github.com/gogo/protobuf/types"
@@ -29,14 +34,51 @@ func (g *TemplateProxyGenerator) Generate(ctx xds_context.Context, proxy *model.
 		}
 	}
 	generator := &ProxyTemplateRawSource{Resources: g.ProxyTemplate.Resources}
-	if rs, err := generator.Generate(ctx, proxy); err != nil {
+	rs, err := generator.Generate(ctx, proxy)
+	if err != nil {
 		return nil, fmt.Errorf("resources: %s", err)
-	} else {
-		resources = append(resources, rs...)
+	}
+	// raw resources take precedence over generated ones of the same type and name
+	for i, r := range rs {
+		patch := g.ProxyTemplate.Resources[i].GetStrategy() == kuma_mesh.ProxyTemplateRawResource_PATCH
+		idx := indexOfResource(resources, r)
+		if idx < 0 {
+			if patch {
+				// a patch is usually incomplete, so it cannot stand on its own
+				return nil, fmt.Errorf("resources[%d]{name=%q}: there is no generated resource of the same type and name to patch", i, r.Name)
+			}
+			resources = append(resources, r)
+			continue
+		}
+		switch {
+		case patch:
+			patched, err := patchResource(resources[idx].Resource, r.Resource)
+			if err != nil {
+				return nil, fmt.Errorf("resources[%d]{name=%q}: %s", i, r.Name, err)
+			}
+			resources[idx] = &Resource{
+				Name:     r.Name,
+				Version:  r.Version,
+				Resource: patched,
+			}
+		default:
+			resources[idx] = r
+		}
 	}
 	return resources, nil
 }
 
+// indexOfResource returns the position of a resource of the same type and name as a given one or -1 if there is none.
+func indexOfResource(resources []*Resource, r *Resource) int {
+	name := cache.GetResourceName(r.Resource)
+	for i, other := range resources {
+		if reflect.TypeOf(other.Resource) == reflect.TypeOf(r.Resource) && cache.GetResourceName(other.Resource) == name {
+			return i
+		}
+	}
+	return -1
+}
+
 type ProxyTemplateRawSource struct {
 	Resources []*kuma_mesh.ProxyTemplateRawResource
 }
@@ -61,7 +103,8 @@ func (s *ProxyTemplateRawSource) Generate(_ xds_context.Context, proxy *model.Pr
 		if !ok {
 			return nil, fmt.Errorf("raw.resources[%d]{name=%q}.resource: xDS resource doesn't implement all required interfaces", i, r.Name)
 		}
-		if v, ok := p.(interface{ Validate() error }); ok {
+		// a patch is validated once it is applied to a generated resource
+		if v, ok := p.(interface{ Validate() error }); ok && r.GetStrategy() != kuma_mesh.ProxyTemplateRawResource_PATCH {
 			if err := v.Validate(); err != nil {
 				return nil, fmt.Errorf("raw.resources[%d]{name=%q}.resource: %s", i, r.Name, err)
 			}
@@ -79,7 +122,7 @@ func (s *ProxyTemplateRawSource) Generate(_ xds_context.Context, proxy *model.Pr
 var predefinedProfiles = make(map[string]ResourceGenerator)
 
 func NewDefaultProxyProfile() ResourceGenerator {
-	return CompositeResourceGenerator{TransparentProxyGenerator{}, InboundProxyGenerator{}, OutboundProxyGenerator{}}
+	return CompositeResourceGenerator{TransparentProxyGenerator{}, InboundProxyGenerator{}, OutboundProxyGenerator{}, PrometheusEndpointGenerator{}}
 }
 
 func init() {
@@ -102,33 +145,35 @@ type InboundProxyGenerator struct {
 }
 
 func (_ InboundProxyGenerator) Generate(ctx xds_context.Context, proxy *model.Proxy) ([]*Resource, error) {
-	endpoints, err := proxy.Dataplane.Spec.Networking.GetInboundInterfaces()
-	if err != nil {
-		return nil, err
-	}
-	if len(endpoints) == 0 {
+	inbounds := proxy.Dataplane.Spec.Networking.GetInbound()
+	if len(inbounds) == 0 {
 		return nil, nil
 	}
 	virtual := proxy.Dataplane.Spec.Networking.GetTransparentProxying().GetRedirectPort() != 0
-	resources := make([]*Resource, 0, len(endpoints))
+	resources := make([]*Resource, 0, len(inbounds))
 	names := make(map[string]bool)
-	for _, endpoint := range endpoints {
-		localClusterName := fmt.Sprintf("localhost:%d", endpoint.WorkloadPort)
+	for _, inbound := range inbounds {
+		endpoint, err := inbound.GetInboundInterface()
+		if err != nil {
+			return nil, err
+		}
+		localClusterName := workloadClusterName(endpoint.WorkloadIP, endpoint.WorkloadPort)
 		if used := names[localClusterName]; !used {
 			resources = append(resources, &Resource{
 				Name:     localClusterName,
 				Version:  "",
-				Resource: envoy.CreateLocalCluster(localClusterName, "127.0.0.1", endpoint.WorkloadPort),
+				Resource: envoy.CreateLocalCluster(localClusterName, endpoint.WorkloadIP, endpoint.WorkloadPort),
 			})
 			names[localClusterName] = true
 		}
 
-		inboundListenerName := fmt.Sprintf("inbound:%s:%d", endpoint.DataplaneIP, endpoint.DataplanePort)
+		inboundListenerName := listenerName("inbound", endpoint.BindIP, endpoint.DataplanePort)
 		if used := names[inboundListenerName]; !used {
+			permissive := ctx.Mesh.Mtls.IsPermissive(inbound.GetTags()[kuma_mesh.ServiceTag], endpoint.DataplanePort)
 			resources = append(resources, &Resource{
 				Name:     inboundListenerName,
 				Version:  "",
-				Resource: envoy.CreateInboundListener(ctx, inboundListenerName, endpoint.DataplaneIP, endpoint.DataplanePort, localClusterName, virtual, proxy.TrafficPermissions),
+				Resource: envoy.CreateInboundListener(ctx, inboundListenerName, endpoint.BindIP, endpoint.DataplanePort, localClusterName, virtual, permissive, proxy.TrafficPermissions),
 			})
 			names[inboundListenerName] = true
 		}
@@ -158,7 +203,7 @@ func (_ OutboundProxyGenerator) Generate(ctx xds_context.Context, proxy *model.P
 		if used := names[edsClusterName]; !used {
 			resources = append(resources, &Resource{
 				Name:     edsClusterName,
-				Resource: envoy.CreateEdsCluster(ctx, edsClusterName),
+				Resource: envoy.CreateEdsCluster(ctx, edsClusterName, string(serviceTag)),
 			})
 			resources = append(resources, &Resource{
 				Name:     edsClusterName,
@@ -167,11 +212,14 @@ func (_ OutboundProxyGenerator) Generate(ctx xds_context.Context, proxy *model.P
 			names[edsClusterName] = true
 		}
 
-		outboundListenerName := fmt.Sprintf("outbound:%s:%d", endpoint.DataplaneIP, endpoint.DataplanePort)
+		if virtual && handledByCatchAll(ctx, endpoint) {
+			continue
+		}
+		outboundListenerName := listenerName("outbound", endpoint.DataplaneIP, endpoint.DataplanePort)
 		if used := names[outboundListenerName]; !used {
 			resources = append(resources, &Resource{
 				Name:     outboundListenerName,
-				Resource: envoy.CreateOutboundListener(ctx, outboundListenerName, endpoint.DataplaneIP, endpoint.DataplanePort, edsClusterName, virtual),
+				Resource: envoy.CreateOutboundListener(ctx, outboundListenerName, endpoint.DataplaneIP, endpoint.DataplanePort, edsClusterName, string(serviceTag), virtual),
 			})
 			names[outboundListenerName] = true
 		}
@@ -187,18 +235,151 @@ func (_ TransparentProxyGenerator) Generate(ctx xds_context.Context, proxy *mode
 	if redirectPort == 0 {
 		return nil, nil
 	}
-	return []*Resource{
-		&Resource{
-			Name:     "catch_all",
-			Version:  proxy.Dataplane.Meta.GetVersion(),
-			Resource: envoy.CreateCatchAllListener(ctx, "catch_all", "0.0.0.0", redirectPort, "pass_through"),
-		},
-		&Resource{
-			Name:     "pass_through",
-			Version:  proxy.Dataplane.Meta.GetVersion(),
-			Resource: envoy.CreatePassThroughCluster("pass_through"),
-		},
-	}, nil
+	clusterName := "pass_through"
+	cluster := envoy.CreatePassThroughCluster(clusterName)
+	if ctx.Mesh.PassthroughDisabled {
+		// traffic to destinations that are not known to the mesh ends up in a cluster without endpoints
+		clusterName = "blackhole"
+		cluster = envoy.CreateBlackholeCluster(clusterName)
+	}
+	destinations, err := outboundDestinations(ctx, proxy)
+	if err != nil {
+		return nil, err
+	}
+	catchAllListener := func(name string, address string) *Resource {
+		resource := &Resource{
+			Name:    name,
+			Version: proxy.Dataplane.Meta.GetVersion(),
+		}
+		if destinations != nil {
+			resource.Resource = envoy.CreateTransparentOutboundListener(ctx, name, address, redirectPort, destinations, clusterName)
+		} else {
+			resource.Resource = envoy.CreateCatchAllListener(ctx, name, address, redirectPort, clusterName)
+		}
+		return resource
+	}
+	resources := []*Resource{
+		catchAllListener("catch_all", "0.0.0.0"),
+	}
+	if hasIPv6Inbound(proxy.Dataplane.Spec.Networking) {
+		// on dual-stack hosts IPv6 traffic gets redirected by ip6tables to the same port
+		resources = append(resources, catchAllListener("catch_all_ipv6", "::"))
+	}
+	return append(resources, &Resource{
+		Name:     clusterName,
+		Version:  proxy.Dataplane.Meta.GetVersion(),
+		Resource: cluster,
+	}), nil
+}
+
+// PrometheusEndpointGenerator exposes metrics of Envoy to Prometheus on the IP address of the first inbound interface
+// if it's enabled in the Mesh. Gateways, which have no inbound interfaces, expose metrics on all IP addresses.
+//
+// Metrics are served by Envoy Admin interface, so nothing is exposed if Envoy has been launched without it.
+type PrometheusEndpointGenerator struct {
+}
+
+func (_ PrometheusEndpointGenerator) Generate(ctx xds_context.Context, proxy *model.Proxy) ([]*Resource, error) {
+	prometheus := ctx.Mesh.Prometheus
+	if prometheus == nil {
+		return nil, nil
+	}
+	if proxy.Metadata.GetAdminPort() == 0 {
+		// bootstrap config has no kuma:envoy:admin cluster, so Envoy would reject a listener that refers to it
+		return nil, nil
+	}
+	endpoints, err := proxy.Dataplane.Spec.Networking.GetInboundInterfaces()
+	if err != nil {
+		return nil, err
+	}
+	address := "0.0.0.0"
+	if len(endpoints) > 0 {
+		address = endpoints[0].BindIP
+	}
+	port := prometheus.GetPortOrDefault()
+	for _, endpoint := range endpoints {
+		if endpoint.BindIP == address && endpoint.DataplanePort == port {
+			return nil, fmt.Errorf("port %d of Prometheus endpoint is already used by inbound interface %s", port, endpoint)
+		}
+	}
+	return []*Resource{{
+		Name:     prometheusListenerName,
+		Version:  proxy.Dataplane.Meta.GetVersion(),
+		Resource: envoy.CreatePrometheusListener(prometheusListenerName, address, port, prometheus.GetPathOrDefault(), bootstrap.AdminClusterName),
+	}}, nil
+}
+
+const prometheusListenerName = "kuma:metrics:prometheus"
+
+// outboundDestinations returns outbound interfaces that must be handled by the catch-all listener
+// or nil if every outbound interface gets a listener of its own.
+func outboundDestinations(ctx xds_context.Context, proxy *model.Proxy) ([]envoy.OutboundDestination, error) {
+	if ctx.ControlPlane == nil || !ctx.ControlPlane.SingleOutboundListener {
+		return nil, nil
+	}
+	destinations := []envoy.OutboundDestination{}
+	names := make(map[string]bool)
+	for _, oface := range proxy.Dataplane.Spec.Networking.GetOutbound() {
+		endpoint, err := kuma_mesh.ParseOutboundInterface(oface.Interface)
+		if err != nil {
+			return nil, err
+		}
+		if !handledByCatchAll(ctx, endpoint) {
+			continue
+		}
+		// the first outbound interface wins, just like with a listener per outbound interface
+		name := listenerName("outbound", endpoint.DataplaneIP, endpoint.DataplanePort)
+		if names[name] {
+			continue
+		}
+		names[name] = true
+		destinations = append(destinations, envoy.OutboundDestination{
+			Address:     endpoint.DataplaneIP,
+			Port:        endpoint.DataplanePort,
+			ClusterName: outboundClusterName(kuma_mesh.ServiceTagValue(oface.Service), oface.ServicePort),
+			Service:     oface.Service,
+		})
+	}
+	return destinations, nil
+}
+
+// handledByCatchAll returns true if traffic to a given outbound interface must be handled by the catch-all listener.
+//
+// Traffic to loopback addresses is never redirected, so such outbound interfaces still need a listener of their own.
+func handledByCatchAll(ctx xds_context.Context, endpoint kuma_mesh.OutboundInterface) bool {
+	if ctx.ControlPlane == nil || !ctx.ControlPlane.SingleOutboundListener {
+		return false
+	}
+	ip := net.ParseIP(endpoint.DataplaneIP)
+	return ip != nil && !ip.IsLoopback()
+}
+
+func hasIPv6Inbound(networking *kuma_mesh.Dataplane_Networking) bool {
+	ifaces, err := networking.GetInboundInterfaces()
+	if err != nil {
+		return false
+	}
+	for _, iface := range ifaces {
+		if ip := net.ParseIP(iface.BindIP); ip != nil && ip.To4() == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// workloadClusterName generates a name for a Cluster of a workload,
+// e.g. "localhost:8080" or "192.168.0.2:8080" if the workload doesn't listen on 127.0.0.1.
+func workloadClusterName(ip string, port uint32) string {
+	if ip == "127.0.0.1" {
+		return fmt.Sprintf("localhost:%d", port)
+	}
+	return net.JoinHostPort(ip, strconv.FormatUint(uint64(port), 10))
+}
+
+// listenerName generates a name for a Listener out of its address,
+// e.g. "inbound:192.168.0.1:8080" or "inbound:[fd00::1]:8080".
+func listenerName(prefix string, ip string, port uint32) string {
+	return fmt.Sprintf("%s:%s", prefix, net.JoinHostPort(ip, strconv.FormatUint(uint64(port), 10)))
 }
 
 // outboundClusterName generates a proper name for a Cluster,
@@ -112,6 +112,39 @@ var _ = Describe("TemplateProxyGenerator", func() {
 				},
 				err: "resources: raw.resources[0]{name=\"raw-name\"}.resource: unexpected EOF",
 			}),
+			Entry("should fail when there is no generated resource to patch", testCase{
+				proxy: &model.Proxy{
+					Id: model.ProxyId{Name: "side-car", Namespace: "default"},
+					Dataplane: &mesh_core.DataplaneResource{
+						Meta: &test_model.ResourceMeta{
+							Version: "v1",
+						},
+						Spec: mesh_proto.Dataplane{
+							Networking: &mesh_proto.Dataplane_Networking{
+								Inbound: []*mesh_proto.Dataplane_Networking_Inbound{
+									{Interface: "192.168.0.1:80:8080"},
+								},
+							},
+						},
+					},
+				},
+				template: &mesh_proto.ProxyTemplate{
+					Imports: []string{
+						template.ProfileDefaultProxy,
+					},
+					Resources: []*mesh_proto.ProxyTemplateRawResource{{
+						Name:     "raw-name",
+						Version:  "raw-version",
+						Strategy: mesh_proto.ProxyTemplateRawResource_PATCH,
+						Resource: `
+                          '@type': type.googleapis.com/envoy.api.v2.Cluster
+                          name: unknown
+                          connectTimeout: 1s
+`,
+					}},
+				},
+				err: "resources[0]{name=\"raw-name\"}: there is no generated resource of the same type and name to patch",
+			}),
 		)
 	})
 
@@ -211,6 +244,11 @@ var _ = Describe("TemplateProxyGenerator", func() {
 				proxyTemplateFile: "1-proxy-template.input.yaml",
 				envoyConfigFile:   "1-envoy-config.golden.yaml",
 			}),
+			Entry("should let raw xDS resources replace and patch generated ones", testCase{
+				dataplaneFile:     "2-dataplane.input.yaml",
+				proxyTemplateFile: "2-proxy-template.input.yaml",
+				envoyConfigFile:   "2-envoy-config.golden.yaml",
+			}),
 		)
 
 	})
@@ -1,10 +1,14 @@
 package generator_test
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 
+	"github.com/gogo/protobuf/types"
+
 	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
 	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
 	model "github.com/Kong/kuma/pkg/core/xds"
@@ -18,6 +22,7 @@ import (
 var _ = Describe("TransparentProxyGenerator", func() {
 
 	type testCase struct {
+		ctx      xds_context.Context
 		proxy    *model.Proxy
 		expected string
 	}
@@ -26,10 +31,9 @@ var _ = Describe("TransparentProxyGenerator", func() {
 		func(given testCase) {
 			// setup
 			gen := &generator.TransparentProxyGenerator{}
-			ctx := xds_context.Context{}
 
 			// when
-			rs, err := gen.Generate(ctx, given.proxy)
+			rs, err := gen.Generate(given.ctx, given.proxy)
 
 			// then
 			Expect(err).ToNot(HaveOccurred())
@@ -97,6 +101,209 @@ var _ = Describe("TransparentProxyGenerator", func() {
             name: pass_through
             type: ORIGINAL_DST
           version: v1
+`,
+		}),
+		Entry("transparent_proxying=true, passthrough=false", testCase{
+			ctx: xds_context.Context{
+				Mesh: xds_context.MeshContext{
+					PassthroughDisabled: true,
+				},
+			},
+			proxy: &model.Proxy{
+				Id: model.ProxyId{Name: "side-car", Namespace: "default"},
+				Dataplane: &mesh_core.DataplaneResource{
+					Meta: &test_model.ResourceMeta{
+						Version: "v1",
+					},
+					Spec: mesh_proto.Dataplane{
+						Networking: &mesh_proto.Dataplane_Networking{
+							TransparentProxying: &mesh_proto.Dataplane_Networking_TransparentProxying{
+								RedirectPort: 15001,
+							},
+						},
+					},
+				},
+			},
+			expected: `
+        resources:
+        - name: catch_all
+          resource:
+            '@type': type.googleapis.com/envoy.api.v2.Listener
+            address:
+              socketAddress:
+                address: 0.0.0.0
+                portValue: 15001
+            filterChains:
+            - filters:
+              - name: envoy.tcp_proxy
+                typedConfig:
+                  '@type': type.googleapis.com/envoy.config.filter.network.tcp_proxy.v2.TcpProxy
+                  cluster: blackhole
+                  statPrefix: blackhole
+            name: catch_all
+            useOriginalDst: true
+          version: v1
+        - name: blackhole
+          resource:
+            '@type': type.googleapis.com/envoy.api.v2.Cluster
+            connectTimeout: 5s
+            name: blackhole
+            type: STATIC
+          version: v1
+`,
+		}),
+		Entry("transparent_proxying=true, IPv6 inbound", testCase{
+			proxy: &model.Proxy{
+				Id: model.ProxyId{Name: "side-car", Namespace: "default"},
+				Dataplane: &mesh_core.DataplaneResource{
+					Meta: &test_model.ResourceMeta{
+						Version: "v1",
+					},
+					Spec: mesh_proto.Dataplane{
+						Networking: &mesh_proto.Dataplane_Networking{
+							Inbound: []*mesh_proto.Dataplane_Networking_Inbound{
+								{Interface: "[fd00::1]:8080:8080"},
+							},
+							TransparentProxying: &mesh_proto.Dataplane_Networking_TransparentProxying{
+								RedirectPort: 15001,
+							},
+						},
+					},
+				},
+			},
+			expected: `
+        resources:
+        - name: catch_all
+          resource:
+            '@type': type.googleapis.com/envoy.api.v2.Listener
+            address:
+              socketAddress:
+                address: 0.0.0.0
+                portValue: 15001
+            filterChains:
+            - filters:
+              - name: envoy.tcp_proxy
+                typedConfig:
+                  '@type': type.googleapis.com/envoy.config.filter.network.tcp_proxy.v2.TcpProxy
+                  cluster: pass_through
+                  statPrefix: pass_through
+            name: catch_all
+            useOriginalDst: true
+          version: v1
+        - name: catch_all_ipv6
+          resource:
+            '@type': type.googleapis.com/envoy.api.v2.Listener
+            address:
+              socketAddress:
+                address: '::'
+                portValue: 15001
+            filterChains:
+            - filters:
+              - name: envoy.tcp_proxy
+                typedConfig:
+                  '@type': type.googleapis.com/envoy.config.filter.network.tcp_proxy.v2.TcpProxy
+                  cluster: pass_through
+                  statPrefix: pass_through
+            name: catch_all_ipv6
+            useOriginalDst: true
+          version: v1
+        - name: pass_through
+          resource:
+            '@type': type.googleapis.com/envoy.api.v2.Cluster
+            connectTimeout: 5s
+            lbPolicy: ORIGINAL_DST_LB
+            name: pass_through
+            type: ORIGINAL_DST
+          version: v1
+`,
+		}),
+		Entry("transparent_proxying=true, single outbound listener", testCase{
+			ctx: xds_context.Context{
+				ControlPlane: &xds_context.ControlPlaneContext{
+					SingleOutboundListener: true,
+				},
+				Mesh: xds_context.MeshContext{
+					Outbound: &mesh_proto.Networking_Outbound{
+						ConnectionPools: []*mesh_proto.ConnectionPool{{
+							Service: "db",
+							Tcp: &mesh_proto.ConnectionPool_Tcp{
+								IdleTimeout: types.DurationProto(time.Hour),
+							},
+						}},
+					},
+				},
+			},
+			proxy: &model.Proxy{
+				Id: model.ProxyId{Name: "side-car", Namespace: "default"},
+				Dataplane: &mesh_core.DataplaneResource{
+					Meta: &test_model.ResourceMeta{
+						Version: "v1",
+					},
+					Spec: mesh_proto.Dataplane{
+						Networking: &mesh_proto.Dataplane_Networking{
+							Outbound: []*mesh_proto.Dataplane_Networking_Outbound{
+								{Interface: "10.0.0.1:80", Service: "backend"},
+								{Interface: "10.0.0.2:5432", Service: "db"},
+								{Interface: ":54321", Service: "db"},
+							},
+							TransparentProxying: &mesh_proto.Dataplane_Networking_TransparentProxying{
+								RedirectPort: 15001,
+							},
+						},
+					},
+				},
+			},
+			expected: `
+        resources:
+        - name: catch_all
+          resource:
+            '@type': type.googleapis.com/envoy.api.v2.Listener
+            address:
+              socketAddress:
+                address: 0.0.0.0
+                portValue: 15001
+            filterChains:
+            - filterChainMatch:
+                destinationPort: 80
+                prefixRanges:
+                - addressPrefix: 10.0.0.1
+                  prefixLen: 32
+              filters:
+              - name: envoy.tcp_proxy
+                typedConfig:
+                  '@type': type.googleapis.com/envoy.config.filter.network.tcp_proxy.v2.TcpProxy
+                  cluster: backend
+                  statPrefix: backend
+            - filterChainMatch:
+                destinationPort: 5432
+                prefixRanges:
+                - addressPrefix: 10.0.0.2
+                  prefixLen: 32
+              filters:
+              - name: envoy.tcp_proxy
+                typedConfig:
+                  '@type': type.googleapis.com/envoy.config.filter.network.tcp_proxy.v2.TcpProxy
+                  cluster: db
+                  idleTimeout: 3600s
+                  statPrefix: db
+            - filters:
+              - name: envoy.tcp_proxy
+                typedConfig:
+                  '@type': type.googleapis.com/envoy.config.filter.network.tcp_proxy.v2.TcpProxy
+                  cluster: pass_through
+                  statPrefix: pass_through
+            listenerFilters:
+            - name: envoy.listener.original_dst
+            name: catch_all
+          version: v1
+        - name: pass_through
+          resource:
+            '@type': type.googleapis.com/envoy.api.v2.Cluster
+            connectTimeout: 5s
+            lbPolicy: ORIGINAL_DST_LB
+            name: pass_through
+            type: ORIGINAL_DST
+          version: v1
 `,
 		}),
 	)
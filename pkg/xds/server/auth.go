@@ -0,0 +1,41 @@
+package server
+
+import (
+	"github.com/pkg/errors"
+
+	xds_config "github.com/Kong/kuma/pkg/config/xds"
+	core_runtime "github.com/Kong/kuma/pkg/core/runtime"
+	sds_auth "github.com/Kong/kuma/pkg/sds/auth"
+	clientcert_sds_auth "github.com/Kong/kuma/pkg/sds/auth/clientcert"
+	universal_sds_auth "github.com/Kong/kuma/pkg/sds/auth/universal"
+	sds_server "github.com/Kong/kuma/pkg/sds/server"
+	"github.com/Kong/kuma/pkg/tokens/builtin/issuer"
+)
+
+// DefaultDataplaneAuthenticator returns an authenticator of Dataplanes according to the Control Plane configuration.
+// A nil authenticator means that authentication is turned off.
+//
+// Authenticators are shared with SDS Server, so that a Dataplane is verified the same way by both.
+func DefaultDataplaneAuthenticator(rt core_runtime.Runtime) (sds_auth.Authenticator, error) {
+	switch typ := rt.Config().XdsServer.DataplaneAuth.Type; typ {
+	case xds_config.NoneDataplaneAuth:
+		return nil, nil
+	case xds_config.ServiceAccountTokenDataplaneAuth:
+		// Dataplanes outside of Kubernetes have no ServiceAccount tokens to present, they present Dataplane tokens instead
+		if rt.Config().Discovery.Kubernetes.UniversalDataplanesEnabled {
+			return sds_server.NewHybridAuthenticator(rt)
+		}
+		return sds_server.NewKubeAuthenticator(rt)
+	case xds_config.ClientCertDataplaneAuth:
+		return clientcert_sds_auth.New(sds_server.DefaultDataplaneResolver(rt.ResourceManager())), nil
+	case xds_config.DataplaneTokenDataplaneAuth:
+		return universal_sds_auth.NewDataplaneTokenAuthenticator(sds_server.DefaultDataplaneResolver(rt.ResourceManager()), NewDataplaneTokenIssuer(rt)), nil
+	default:
+		return nil, errors.Errorf("unable to choose Dataplane authenticator of type %q", typ)
+	}
+}
+
+// NewDataplaneTokenIssuer returns an issuer that validates Dataplane tokens against signing keys of their Mesh.
+func NewDataplaneTokenIssuer(rt core_runtime.Runtime) issuer.DataplaneTokenIssuer {
+	return issuer.NewDataplaneTokenIssuerWithAllowedClockSkew(issuer.NewSigningKeyManager(rt.SecretManager()), rt.Config().SdsServer.CertRotation.AllowedClockSkew)
+}
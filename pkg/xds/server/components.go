@@ -4,9 +4,7 @@ import (
 	"context"
 	"time"
 
-	"github.com/Kong/kuma/pkg/core/permissions"
 	"github.com/Kong/kuma/pkg/core/xds"
-	"github.com/pkg/errors"
 
 	core_model "github.com/Kong/kuma/pkg/core/resources/model"
 	core_store "github.com/Kong/kuma/pkg/core/resources/store"
@@ -15,7 +13,6 @@ import (
 	xds_context "github.com/Kong/kuma/pkg/xds/context"
 	xds_sync "github.com/Kong/kuma/pkg/xds/sync"
 	xds_template "github.com/Kong/kuma/pkg/xds/template"
-	xds_topology "github.com/Kong/kuma/pkg/xds/topology"
 
 	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
 
@@ -23,74 +20,72 @@ import (
 )
 
 func DefaultReconciler(rt core_runtime.Runtime) SnapshotReconciler {
+	templates := &simpleProxyTemplateResolver{
+		ResourceManager:      rt.ResourceManager(),
+		DefaultProxyTemplate: xds_template.DefaultProxyTemplate,
+	}
 	return &reconciler{
 		&templateSnapshotGenerator{
-			ProxyTemplateResolver: &simpleProxyTemplateResolver{
-				ResourceManager:      rt.ResourceManager(),
-				DefaultProxyTemplate: xds_template.DefaultProxyTemplate,
-			},
+			ProxyTemplateResolver: templates,
 		},
 		&simpleSnapshotCacher{rt.XDS().Hasher(), rt.XDS().Cache()},
+		rt.XDS().ConfigHistory(),
+		rt.XDS().ConfigPropagationTracker(),
+		rt.XDS().PolicyRollout(),
+		templates,
+		newHeldPolicies(),
 	}
 }
 
-func DefaultDataplaneSyncTracker(rt core_runtime.Runtime, reconciler SnapshotReconciler) (envoy_xds.Callbacks, error) {
-	permissionsMatcher := permissions.TrafficPermissionsMatcher{ResourceManager: rt.ResourceManager()}
+// maxDebounceWindows limits how long changes of config of a Dataplane can be held back by a debounce window.
+const maxDebounceWindows = 10
+
+// DefaultDataplaneSyncTracker refreshes config of Dataplanes periodically and,
+// unless changes is nil, as soon as resources in their Mesh change.
+func DefaultDataplaneSyncTracker(rt core_runtime.Runtime, reconciler SnapshotReconciler, changes *ResourceChangeNotifier) (envoy_xds.Callbacks, error) {
 	envoyCpCtx, err := xds_context.BuildControlPlaneContext(rt.Config())
 	if err != nil {
 		return nil, err
 	}
-	return xds_sync.NewDataplaneSyncTracker(func(key core_model.ResourceKey) util_watchdog.Watchdog {
+	// Dataplanes outside of Kubernetes have no ServiceAccount token to present to SDS server
+	universalCpCtx := *envoyCpCtx
+	universalCpCtx.DataplaneTokenFile = ""
+	universalDataplanesEnabled := rt.Config().Discovery.Kubernetes.UniversalDataplanesEnabled
+	debounceWindow := rt.Config().XdsServer.DataplaneConfigurationDebounceWindow
+	builder := newProxyBuilder(rt.ResourceManager(), func(dataplane *mesh_core.DataplaneResource) *xds_context.ControlPlaneContext {
+		if universalDataplanesEnabled && !dataplane.IsKubernetesPod() {
+			return &universalCpCtx
+		}
+		return envoyCpCtx
+	})
+	return xds_sync.NewDataplaneSyncTracker(func(key core_model.ResourceKey, metadata *xds.DataplaneMetadata) util_watchdog.Watchdog {
 		log := xdsServerLog.WithName("dataplane-sync-watchdog").WithValues("dataplaneKey", key)
+		var newTrigger func(stop <-chan struct{}) <-chan struct{}
+		if changes != nil {
+			newTrigger = func(stop <-chan struct{}) <-chan struct{} {
+				return changes.Subscribe(key, stop)
+			}
+		}
 		return &util_watchdog.SimpleWatchdog{
 			NewTicker: func() *time.Ticker {
 				return time.NewTicker(rt.Config().XdsServer.DataplaneConfigurationRefreshInterval)
 			},
+			NewTrigger: newTrigger,
+			// a burst of changes, e.g. during a rollout of a Deployment, results in a single config,
+			// yet a steady stream of changes cannot hold config back for long
+			DebounceWindow:   debounceWindow,
+			DebounceMaxDelay: maxDebounceWindows * debounceWindow,
 			OnTick: func() error {
-				ctx := context.Background()
-				dataplane := &mesh_core.DataplaneResource{}
-				proxyID := xds.FromResourceKey(key)
-
-				if err := rt.ResourceManager().Get(ctx, dataplane, core_store.GetBy(key)); err != nil {
+				envoyCtx, proxy, err := builder.Build(context.Background(), key)
+				if err != nil {
 					if core_store.IsResourceNotFound(err) {
+						proxyID := xds.FromResourceKey(key)
 						return reconciler.Clear(&proxyID)
 					}
 					return err
 				}
-
-				meshList := mesh_core.MeshResourceList{}
-				if err := rt.ResourceManager().List(ctx, &meshList, core_store.ListByMesh(proxyID.Mesh)); err != nil {
-					return err
-				}
-				if len(meshList.Items) != 1 {
-					return errors.Errorf("there should be a mesh of name %s. Found %d meshes of given name", proxyID.Mesh, len(meshList.Items))
-				}
-				envoyCtx := xds_context.Context{
-					ControlPlane: envoyCpCtx,
-					Mesh: xds_context.MeshContext{
-						TlsEnabled:     meshList.Items[0].Spec.GetMtls().GetEnabled(),
-						LoggingEnabled: meshList.Items[0].Spec.Logging.GetAccessLogs().GetEnabled(),
-						LoggingPath:    meshList.Items[0].Spec.Logging.GetAccessLogs().GetFilePath(),
-					},
-				}
-
-				outbound, err := xds_topology.GetOutboundTargets(ctx, dataplane, rt.ResourceManager())
-				if err != nil {
-					return err
-				}
-
-				matchedPermissions, err := permissionsMatcher.Match(ctx, dataplane)
-				if err != nil {
-					return err
-				}
-
-				proxy := xds.Proxy{
-					Id:                 proxyID,
-					Dataplane:          dataplane,
-					TrafficPermissions: matchedPermissions,
-					OutboundTargets:    outbound,
-				}
-				return reconciler.Reconcile(envoyCtx, &proxy)
+				proxy.Metadata = metadata
+				return reconciler.Reconcile(envoyCtx, proxy)
 			},
 			OnError: func(err error) {
 				log.Error(err, "OnTick() failed")
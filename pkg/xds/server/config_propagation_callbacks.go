@@ -0,0 +1,71 @@
+package server
+
+import (
+	"context"
+	"sync"
+
+	envoy "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	envoy_xds "github.com/envoyproxy/go-control-plane/pkg/server"
+
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
+)
+
+// NewConfigPropagationCallbacks returns xDS callbacks that let a given tracker know
+// which config has been ACKed by Envoy.
+func NewConfigPropagationCallbacks(tracker core_xds.ConfigPropagationTracker) envoy_xds.Callbacks {
+	return &configPropagationCallbacks{
+		tracker: tracker,
+		streams: make(map[int64]*core_xds.ProxyId),
+	}
+}
+
+var _ envoy_xds.Callbacks = &configPropagationCallbacks{}
+
+type configPropagationCallbacks struct {
+	tracker core_xds.ConfigPropagationTracker
+
+	mu      sync.Mutex // protects access to the fields below
+	streams map[int64]*core_xds.ProxyId
+}
+
+func (c *configPropagationCallbacks) OnStreamOpen(context.Context, int64, string) error {
+	return nil
+}
+
+func (c *configPropagationCallbacks) OnStreamClosed(streamID int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if proxyId := c.streams[streamID]; proxyId != nil {
+		c.tracker.Forget(*proxyId)
+	}
+	delete(c.streams, streamID)
+}
+
+func (c *configPropagationCallbacks) OnStreamRequest(streamID int64, req *envoy.DiscoveryRequest) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	proxyId := c.streams[streamID]
+	if proxyId == nil {
+		id, err := core_xds.ParseProxyId(req.Node)
+		if err != nil {
+			// it's not up to this callback to reject the request
+			return nil
+		}
+		proxyId = id
+		c.streams[streamID] = proxyId
+	}
+	if req.ResponseNonce != "" && req.ErrorDetail == nil {
+		c.tracker.RecordAck(*proxyId, req.TypeUrl, req.VersionInfo)
+	}
+	return nil
+}
+
+func (c *configPropagationCallbacks) OnStreamResponse(int64, *envoy.DiscoveryRequest, *envoy.DiscoveryResponse) {
+}
+
+func (c *configPropagationCallbacks) OnFetchRequest(context.Context, *envoy.DiscoveryRequest) error {
+	return nil
+}
+
+func (c *configPropagationCallbacks) OnFetchResponse(*envoy.DiscoveryRequest, *envoy.DiscoveryResponse) {
+}
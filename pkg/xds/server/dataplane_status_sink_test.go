@@ -166,6 +166,7 @@ var _ = Describe("DataplaneInsightSink", func() {
 			}, "1s", "1ms").Should(BeTrue())
 			// and
 			Expect(util_proto.ToYAML(dataplaneInsight.GetSpec())).To(MatchYAML(`
+            firstConnectTime: "2019-07-01T00:00:00Z"
             subscriptions:
             - connectTime: "2019-07-01T00:00:00Z"
               controlPlaneInstanceId: control-plane-01
@@ -200,6 +201,7 @@ var _ = Describe("DataplaneInsightSink", func() {
 			}, "1s", "1ms").Should(BeTrue())
 			// and
 			Expect(util_proto.ToYAML(dataplaneInsight.GetSpec())).To(MatchYAML(`
+            firstConnectTime: "2019-07-01T00:00:00Z"
             subscriptions:
             - connectTime: "2019-07-01T00:00:00Z"
               controlPlaneInstanceId: control-plane-01
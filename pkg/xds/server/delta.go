@@ -0,0 +1,349 @@
+package server
+
+import (
+	"sort"
+	"strconv"
+	"sync/atomic"
+
+	envoy "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	envoy_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	envoy_cache "github.com/envoyproxy/go-control-plane/pkg/cache"
+	envoy_xds "github.com/envoyproxy/go-control-plane/pkg/server"
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// deltaStreamIdOffset keeps IDs of delta xDS streams apart from IDs of state-of-the-world streams,
+// which are assigned by go-control-plane starting from 1, since both kinds of streams are reported to the same callbacks.
+const deltaStreamIdOffset = int64(1) << 62
+
+type deltaServer struct {
+	envoy_xds.Server
+	cache       envoy_cache.ConfigWatcher
+	callbacks   envoy_xds.Callbacks
+	streamCount int64
+}
+
+// NewDeltaServer returns an xDS server that serves the state-of-the-world xDS and the incremental (delta) CDS.
+//
+// Delta is limited to Clusters, since Envoy versions that Kuma supports implement the incremental protocol for CDS only.
+// Clusters are served out of the same snapshots as in case of the state-of-the-world ADS, but only Clusters
+// that have changed since the previous response and names of Clusters that have been removed are sent,
+// so that e.g. a new service doesn't push all Clusters to every Dataplane.
+// Delta streams are reported to callbacks as if they were state-of-the-world ones.
+func NewDeltaServer(sotw envoy_xds.Server, cache envoy_cache.ConfigWatcher, callbacks envoy_xds.Callbacks) envoy_xds.Server {
+	return &deltaServer{
+		Server:    sotw,
+		cache:     cache,
+		callbacks: callbacks,
+	}
+}
+
+func (s *deltaServer) DeltaClusters(stream envoy.ClusterDiscoveryService_DeltaClustersServer) error {
+	streamID := deltaStreamIdOffset + atomic.AddInt64(&s.streamCount, 1)
+	ds := &deltaStream{
+		id:            streamID,
+		stream:        stream,
+		typeUrl:       envoy_cache.ClusterType,
+		cache:         s.cache,
+		callbacks:     s.callbacks,
+		subscriptions: map[string]*deltaSubscription{},
+		responses:     make(chan deltaWatchResponse),
+		done:          make(chan struct{}),
+	}
+	defer ds.close()
+	if err := s.callbacks.OnStreamOpen(stream.Context(), streamID, envoy_cache.ClusterType); err != nil {
+		return err
+	}
+	defer s.callbacks.OnStreamClosed(streamID)
+	return ds.process()
+}
+
+// deltaDiscoveryStream is a delta xDS stream of any type, e.g. of the ClusterDiscoveryService.
+type deltaDiscoveryStream interface {
+	grpc.ServerStream
+	Send(*envoy.DeltaDiscoveryResponse) error
+	Recv() (*envoy.DeltaDiscoveryRequest, error)
+}
+
+type deltaStream struct {
+	id     int64
+	stream deltaDiscoveryStream
+	// type of resources served by the stream
+	typeUrl   string
+	cache     envoy_cache.ConfigWatcher
+	callbacks envoy_xds.Callbacks
+
+	node          *envoy_core.Node
+	nonce         int64
+	subscriptions map[string]*deltaSubscription
+	responses     chan deltaWatchResponse
+	done          chan struct{}
+}
+
+// deltaSubscription is the state of a subscription to resources of a single type.
+type deltaSubscription struct {
+	typeUrl string
+	// wildcard is true if Envoy is subscribed to all resources of the type, e.g. to all Clusters.
+	wildcard bool
+	// names of resources that Envoy is subscribed to.
+	names map[string]bool
+	// versions of resources that Envoy has, by name.
+	sent map[string]string
+	// versions of snapshots that have been sent but not yet acknowledged, by nonce.
+	pending map[string]string
+	// version of the snapshot that Envoy has acknowledged last.
+	acked string
+	// request that Envoy has sent last, in the form of a state-of-the-world one.
+	request *envoy.DiscoveryRequest
+
+	// version and resources of the latest snapshot.
+	version   string
+	resources map[string]envoy_cache.Resource
+	received  bool
+	responded bool
+
+	// generation of the watch that is open on the cache.
+	generation int
+	cancel     func()
+}
+
+func (s *deltaSubscription) subscribed(name string) bool {
+	return s.wildcard || s.names[name]
+}
+
+type deltaWatchResponse struct {
+	subscription *deltaSubscription
+	generation   int
+	response     envoy_cache.Response
+	more         bool
+}
+
+func (s *deltaStream) process() error {
+	requests := make(chan *envoy.DeltaDiscoveryRequest)
+	go func() {
+		defer close(requests)
+		for {
+			req, err := s.stream.Recv()
+			if err != nil {
+				return
+			}
+			select {
+			case requests <- req:
+			case <-s.done:
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-s.stream.Context().Done():
+			return nil
+		case req, more := <-requests:
+			// input stream ended or errored out
+			if !more {
+				return nil
+			}
+			if err := s.onRequest(req); err != nil {
+				return err
+			}
+		case resp := <-s.responses:
+			if err := s.onWatchResponse(resp); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *deltaStream) onRequest(req *envoy.DeltaDiscoveryRequest) error {
+	// type URL is optional outside of ADS
+	if req.TypeUrl == "" {
+		req.TypeUrl = s.typeUrl
+	}
+	if req.TypeUrl != s.typeUrl {
+		return status.Errorf(codes.InvalidArgument, "type URL %q is not supported by a stream of %q", req.TypeUrl, s.typeUrl)
+	}
+	// Envoy might only send node in the first request of a stream
+	if req.Node != nil {
+		s.node = req.Node
+	}
+
+	sub, exists := s.subscriptions[req.TypeUrl]
+	if !exists {
+		sub = &deltaSubscription{
+			typeUrl: req.TypeUrl,
+			// the first request without resource names subscribes to all resources, e.g. to all Listeners
+			wildcard: len(req.ResourceNamesSubscribe) == 0,
+			names:    map[string]bool{},
+			sent:     map[string]string{},
+			pending:  map[string]string{},
+		}
+		// resources that Envoy already has, e.g. after a reconnect, are only sent if they have changed
+		for name, version := range req.InitialResourceVersions {
+			sub.sent[name] = version
+		}
+		s.subscriptions[req.TypeUrl] = sub
+	}
+	for _, name := range req.ResourceNamesSubscribe {
+		sub.names[name] = true
+	}
+	for _, name := range req.ResourceNamesUnsubscribe {
+		delete(sub.names, name)
+		delete(sub.sent, name)
+	}
+	if version, ok := sub.pending[req.ResponseNonce]; ok {
+		delete(sub.pending, req.ResponseNonce)
+		if req.ErrorDetail == nil {
+			sub.acked = version
+		}
+	}
+
+	sub.request = s.toDiscoveryRequest(sub, req)
+	if err := s.callbacks.OnStreamRequest(s.id, sub.request); err != nil {
+		return err
+	}
+
+	if !exists {
+		s.watch(sub)
+		return nil
+	}
+	if sub.received && len(req.ResourceNamesSubscribe)+len(req.ResourceNamesUnsubscribe) > 0 {
+		return s.respond(sub)
+	}
+	return nil
+}
+
+func (s *deltaStream) toDiscoveryRequest(sub *deltaSubscription, req *envoy.DeltaDiscoveryRequest) *envoy.DiscoveryRequest {
+	names := make([]string, 0, len(sub.names))
+	for name := range sub.names {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return &envoy.DiscoveryRequest{
+		VersionInfo:   sub.acked,
+		Node:          s.node,
+		ResourceNames: names,
+		TypeUrl:       req.TypeUrl,
+		ResponseNonce: req.ResponseNonce,
+		ErrorDetail:   req.ErrorDetail,
+	}
+}
+
+// watch opens a watch on the cache for a snapshot that is newer than the latest one.
+// All resources of the type are watched, and those that Envoy hasn't subscribed to are filtered out afterwards.
+func (s *deltaStream) watch(sub *deltaSubscription) {
+	sub.generation++
+	generation := sub.generation
+	values, cancel := s.cache.CreateWatch(envoy_cache.Request{
+		Node:        s.node,
+		TypeUrl:     sub.typeUrl,
+		VersionInfo: sub.version,
+	})
+	sub.cancel = cancel
+	go func() {
+		select {
+		case resp, more := <-values:
+			select {
+			case s.responses <- deltaWatchResponse{subscription: sub, generation: generation, response: resp, more: more}:
+			case <-s.done:
+			}
+		case <-s.done:
+		}
+	}()
+}
+
+func (s *deltaStream) onWatchResponse(resp deltaWatchResponse) error {
+	sub := resp.subscription
+	if resp.generation != sub.generation {
+		return nil
+	}
+	if !resp.more {
+		return status.Errorf(codes.Unavailable, "watch of %s failed", sub.typeUrl)
+	}
+	sub.version = resp.response.Version
+	sub.resources = map[string]envoy_cache.Resource{}
+	for _, resource := range resp.response.Resources {
+		sub.resources[envoy_cache.GetResourceName(resource)] = resource
+	}
+	sub.received = true
+	s.watch(sub)
+	return s.respond(sub)
+}
+
+// respond sends resources that have changed since the previous response and names of those that have been removed.
+func (s *deltaStream) respond(sub *deltaSubscription) error {
+	names := make([]string, 0, len(sub.resources))
+	for name := range sub.resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	out := &envoy.DeltaDiscoveryResponse{
+		SystemVersionInfo: sub.version,
+		TypeUrl:           sub.typeUrl,
+	}
+	sent := map[string]string{}
+	for _, name := range names {
+		if !sub.subscribed(name) {
+			continue
+		}
+		bytes, err := proto.Marshal(sub.resources[name])
+		if err != nil {
+			return err
+		}
+		version, err := resourceVersion(sub.resources[name])
+		if err != nil {
+			return err
+		}
+		sent[name] = version
+		if sub.sent[name] == version {
+			continue
+		}
+		out.Resources = append(out.Resources, envoy.Resource{
+			Name:     name,
+			Version:  version,
+			Resource: &types.Any{TypeUrl: sub.typeUrl, Value: bytes},
+		})
+	}
+	for name := range sub.sent {
+		if _, exists := sent[name]; !exists {
+			out.RemovedResources = append(out.RemovedResources, name)
+		}
+	}
+	sort.Strings(out.RemovedResources)
+	// Envoy waits for the first response to finish its initialization, even if there are no resources
+	if sub.responded && len(out.Resources) == 0 && len(out.RemovedResources) == 0 {
+		return nil
+	}
+
+	s.nonce++
+	out.Nonce = strconv.FormatInt(s.nonce, 10)
+	sub.sent = sent
+	sub.pending[out.Nonce] = sub.version
+	sub.responded = true
+
+	resources := make([]types.Any, len(out.Resources))
+	for i, resource := range out.Resources {
+		resources[i] = *resource.Resource
+	}
+	s.callbacks.OnStreamResponse(s.id, sub.request, &envoy.DiscoveryResponse{
+		VersionInfo: sub.version,
+		Resources:   resources,
+		TypeUrl:     sub.typeUrl,
+		Nonce:       out.Nonce,
+	})
+	return s.stream.Send(out)
+}
+
+func (s *deltaStream) close() {
+	close(s.done)
+	for _, sub := range s.subscriptions {
+		if sub.cancel != nil {
+			sub.cancel()
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package server_test
+
+import (
+	"context"
+	"io"
+	"time"
+
+	envoy "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	envoy_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	envoy_cache "github.com/envoyproxy/go-control-plane/pkg/cache"
+	envoy_xds "github.com/envoyproxy/go-control-plane/pkg/server"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+
+	util_xds "github.com/Kong/kuma/pkg/util/xds"
+	"github.com/Kong/kuma/pkg/xds/server"
+)
+
+type fakeDeltaStream struct {
+	grpc.ServerStream
+	ctx       context.Context
+	requests  chan *envoy.DeltaDiscoveryRequest
+	responses chan *envoy.DeltaDiscoveryResponse
+}
+
+func (s *fakeDeltaStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeDeltaStream) Send(resp *envoy.DeltaDiscoveryResponse) error {
+	s.responses <- resp
+	return nil
+}
+
+func (s *fakeDeltaStream) Recv() (*envoy.DeltaDiscoveryRequest, error) {
+	req, more := <-s.requests
+	if !more {
+		return nil, io.EOF
+	}
+	return req, nil
+}
+
+type nodeIdHash struct{}
+
+func (nodeIdHash) ID(node *envoy_core.Node) string {
+	return node.Id
+}
+
+type recordingCallbacks struct {
+	util_xds.CallbacksChain
+	requests chan *envoy.DiscoveryRequest
+}
+
+func (c *recordingCallbacks) OnStreamRequest(_ int64, req *envoy.DiscoveryRequest) error {
+	c.requests <- req
+	return nil
+}
+
+var _ = Describe("DeltaServer", func() {
+
+	node := &envoy_core.Node{Id: "demo.backend-01"}
+
+	cluster := func(name string, timeout time.Duration) *envoy.Cluster {
+		return &envoy.Cluster{Name: name, ConnectTimeout: timeout}
+	}
+	snapshot := func(version string, clusters ...envoy_cache.Resource) envoy_cache.Snapshot {
+		return envoy_cache.Snapshot{
+			Clusters: envoy_cache.NewResources(version, clusters),
+		}
+	}
+	names := func(resp *envoy.DeltaDiscoveryResponse) []string {
+		var names []string
+		for _, resource := range resp.Resources {
+			names = append(names, resource.Name)
+		}
+		return names
+	}
+
+	var cache envoy_cache.SnapshotCache
+	var callbacks *recordingCallbacks
+	var cancel context.CancelFunc
+	var done chan error
+
+	open := func() *fakeDeltaStream {
+		stream := &fakeDeltaStream{
+			ctx:       context.Background(),
+			requests:  make(chan *envoy.DeltaDiscoveryRequest),
+			responses: make(chan *envoy.DeltaDiscoveryResponse, 10),
+		}
+		stream.ctx, cancel = context.WithCancel(stream.ctx)
+		done = make(chan error, 1)
+		srv := server.NewDeltaServer(envoy_xds.NewServer(cache, callbacks), cache, callbacks)
+		go func() {
+			done <- srv.DeltaClusters(stream)
+		}()
+		return stream
+	}
+	closeStream := func() {
+		cancel()
+		Eventually(done).Should(Receive())
+	}
+
+	var stream *fakeDeltaStream
+
+	BeforeEach(func() {
+		cache = envoy_cache.NewSnapshotCache(true, nodeIdHash{}, nil)
+		callbacks = &recordingCallbacks{requests: make(chan *envoy.DiscoveryRequest, 10)}
+		stream = open()
+	})
+
+	AfterEach(func() {
+		closeStream()
+	})
+
+	It("should send only resources that have changed", func() {
+		// given
+		Expect(cache.SetSnapshot(node.Id, snapshot("1", cluster("a", time.Second), cluster("b", time.Second)))).To(Succeed())
+
+		// when
+		stream.requests <- &envoy.DeltaDiscoveryRequest{Node: node, TypeUrl: envoy_cache.ClusterType}
+
+		// then
+		var resp *envoy.DeltaDiscoveryResponse
+		Eventually(stream.responses).Should(Receive(&resp))
+		Expect(resp.SystemVersionInfo).To(Equal("1"))
+		Expect(names(resp)).To(Equal([]string{"a", "b"}))
+		Expect(resp.RemovedResources).To(BeEmpty())
+
+		// when
+		stream.requests <- &envoy.DeltaDiscoveryRequest{TypeUrl: envoy_cache.ClusterType, ResponseNonce: resp.Nonce}
+		// then
+		Eventually(callbacks.requests).Should(Receive()) // initial request
+		var ack *envoy.DiscoveryRequest
+		Eventually(callbacks.requests).Should(Receive(&ack))
+		Expect(ack.Node).To(Equal(node))
+		Expect(ack.VersionInfo).To(Equal("1"))
+
+		// when
+		Expect(cache.SetSnapshot(node.Id, snapshot("2", cluster("a", 2*time.Second), cluster("b", time.Second), cluster("c", time.Second)))).To(Succeed())
+
+		// then
+		Eventually(stream.responses).Should(Receive(&resp))
+		Expect(resp.SystemVersionInfo).To(Equal("2"))
+		Expect(names(resp)).To(Equal([]string{"a", "c"}))
+		Expect(resp.RemovedResources).To(BeEmpty())
+
+		// when
+		Expect(cache.SetSnapshot(node.Id, snapshot("3", cluster("a", 2*time.Second), cluster("c", time.Second)))).To(Succeed())
+
+		// then
+		Eventually(stream.responses).Should(Receive(&resp))
+		Expect(resp.SystemVersionInfo).To(Equal("3"))
+		Expect(resp.Resources).To(BeEmpty())
+		Expect(resp.RemovedResources).To(Equal([]string{"b"}))
+	})
+
+	It("should not resend resources that Envoy already has", func() {
+		// given
+		Expect(cache.SetSnapshot(node.Id, snapshot("1", cluster("a", time.Second), cluster("b", time.Second)))).To(Succeed())
+		// and
+		stream.requests <- &envoy.DeltaDiscoveryRequest{Node: node, TypeUrl: envoy_cache.ClusterType}
+		var resp *envoy.DeltaDiscoveryResponse
+		Eventually(stream.responses).Should(Receive(&resp))
+		Expect(names(resp)).To(Equal([]string{"a", "b"}))
+		versions := map[string]string{}
+		for _, resource := range resp.Resources {
+			versions[resource.Name] = resource.Version
+		}
+
+		// when Envoy reconnects
+		closeStream()
+		Expect(cache.SetSnapshot(node.Id, snapshot("2", cluster("a", time.Second), cluster("b", 2*time.Second)))).To(Succeed())
+		stream = open()
+		stream.requests <- &envoy.DeltaDiscoveryRequest{Node: node, TypeUrl: envoy_cache.ClusterType, InitialResourceVersions: versions}
+
+		// then
+		Eventually(stream.responses).Should(Receive(&resp))
+		Expect(names(resp)).To(Equal([]string{"b"}))
+	})
+
+	It("should send only resources that Envoy has subscribed to", func() {
+		// given
+		Expect(cache.SetSnapshot(node.Id, snapshot("1", cluster("a", time.Second), cluster("b", time.Second)))).To(Succeed())
+
+		// when
+		stream.requests <- &envoy.DeltaDiscoveryRequest{Node: node, TypeUrl: envoy_cache.ClusterType, ResourceNamesSubscribe: []string{"a"}}
+
+		// then
+		var resp *envoy.DeltaDiscoveryResponse
+		Eventually(stream.responses).Should(Receive(&resp))
+		Expect(names(resp)).To(Equal([]string{"a"}))
+
+		// when
+		stream.requests <- &envoy.DeltaDiscoveryRequest{TypeUrl: envoy_cache.ClusterType, ResponseNonce: resp.Nonce, ResourceNamesSubscribe: []string{"b"}}
+
+		// then
+		Eventually(stream.responses).Should(Receive(&resp))
+		Expect(names(resp)).To(Equal([]string{"b"}))
+
+		// when
+		stream.requests <- &envoy.DeltaDiscoveryRequest{TypeUrl: envoy_cache.ClusterType, ResponseNonce: resp.Nonce, ResourceNamesUnsubscribe: []string{"a"}}
+		// and
+		var req *envoy.DiscoveryRequest
+		for i := 0; i < 3; i++ {
+			Eventually(callbacks.requests).Should(Receive(&req))
+		}
+		Expect(req.ResourceNames).To(Equal([]string{"b"}))
+		// and
+		Expect(cache.SetSnapshot(node.Id, snapshot("2", cluster("b", time.Second)))).To(Succeed())
+
+		// then
+		Consistently(stream.responses).ShouldNot(Receive())
+	})
+
+	It("should accept requests without a type URL", func() {
+		// given
+		Expect(cache.SetSnapshot(node.Id, snapshot("1", cluster("a", time.Second)))).To(Succeed())
+
+		// when
+		stream.requests <- &envoy.DeltaDiscoveryRequest{Node: node}
+
+		// then
+		var resp *envoy.DeltaDiscoveryResponse
+		Eventually(stream.responses).Should(Receive(&resp))
+		Expect(resp.TypeUrl).To(Equal(envoy_cache.ClusterType))
+		Expect(names(resp)).To(Equal([]string{"a"}))
+	})
+
+	It("should refuse resources other than Clusters", func() {
+		// when
+		stream.requests <- &envoy.DeltaDiscoveryRequest{Node: node, TypeUrl: envoy_cache.EndpointType}
+
+		// then
+		var err error
+		Eventually(done).Should(Receive(&err))
+		Expect(err).To(MatchError(`rpc error: code = InvalidArgument desc = type URL "type.googleapis.com/envoy.api.v2.ClusterLoadAssignment" is not supported by a stream of "type.googleapis.com/envoy.api.v2.Cluster"`))
+		// and
+		done <- err
+	})
+})
@@ -2,19 +2,42 @@ package server
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 
+	"github.com/Kong/kuma/pkg/api-server/filters"
 	"github.com/Kong/kuma/pkg/core"
 	core_runtime "github.com/Kong/kuma/pkg/core/runtime"
+	"github.com/Kong/kuma/pkg/metrics"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 var (
 	diagnosticsServerLog = core.Log.WithName("xds-server").WithName("diagnostics")
 )
 
+// SetupDiagnosticsServer adds a server with health checks, metrics and a dump of internal state of Control Plane.
+// It is run by every role of Control Plane.
+//
+// Control Plane is reported as not ready while any of its components is stuck or Resource Store is unreachable.
+//
+// The dump of internal state requires the admin token of API Server.
+// It is also written into a file in the work directory on SIGQUIT.
+func SetupDiagnosticsServer(rt core_runtime.Runtime) error {
+	return core_runtime.Add(
+		rt,
+		&diagnosticsServer{rt.Config().XdsServer.DiagnosticsPort, rt.Metrics(), rt},
+		&stateDumper{rt: rt, dir: rt.Config().WorkDirOrTemp()},
+		rt.Supervisor().StoreProbe(rt.ResourceManager()),
+	)
+}
+
 type diagnosticsServer struct {
-	port int
+	port    int
+	metrics metrics.Metrics
+	rt      core_runtime.Runtime
 }
 
 // Make sure that grpcServer implements all relevant interfaces
@@ -25,11 +48,18 @@ var (
 func (s *diagnosticsServer) Start(stop <-chan struct{}) error {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/ready", func(resp http.ResponseWriter, _ *http.Request) {
+		if err := s.rt.Supervisor().Ready(); err != nil {
+			resp.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = resp.Write([]byte(err.Error()))
+			return
+		}
 		resp.WriteHeader(http.StatusOK)
 	})
 	mux.HandleFunc("/healthy", func(resp http.ResponseWriter, _ *http.Request) {
 		resp.WriteHeader(http.StatusOK)
 	})
+	mux.Handle("/metrics", promhttp.HandlerFor(s.metrics, promhttp.HandlerOpts{}))
+	mux.Handle("/state", StateDumpHandler(s.rt))
 
 	httpServer := &http.Server{Addr: fmt.Sprintf(":%d", s.port), Handler: mux}
 
@@ -55,3 +85,36 @@ func (s *diagnosticsServer) Start(stop <-chan struct{}) error {
 		return err
 	}
 }
+
+// StateDumpHandler returns a handler that responds with a dump of internal state of a given runtime.
+func StateDumpHandler(rt core_runtime.Runtime) http.HandlerFunc {
+	return func(resp http.ResponseWriter, req *http.Request) {
+		// internal state reveals e.g. which Dataplanes are connected, so it's an admin operation
+		adminToken, err := rt.Config().ApiServer.AdminTokenSecret().Value()
+		if err != nil {
+			diagnosticsServerLog.Error(err, "unable to read the admin token")
+			resp.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if adminToken == "" {
+			resp.WriteHeader(http.StatusForbidden)
+			_, _ = resp.Write([]byte("Dump of internal state is disabled, set apiServer.adminToken to enable it"))
+			return
+		}
+		if !filters.HasBearerToken(req, adminToken) {
+			resp.WriteHeader(http.StatusUnauthorized)
+			_, _ = resp.Write([]byte("Request has to present a valid admin token"))
+			return
+		}
+		dump, err := DumpState(rt)
+		if err != nil {
+			diagnosticsServerLog.Error(err, "unable to dump internal state")
+			resp.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		resp.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(resp).Encode(dump); err != nil {
+			diagnosticsServerLog.Error(err, "unable to write internal state")
+		}
+	}
+}
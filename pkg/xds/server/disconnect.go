@@ -0,0 +1,95 @@
+package server
+
+import (
+	"sync"
+
+	envoy "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	envoy_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
+)
+
+// trackStreams returns a gRPC interceptor that lets StreamTracker terminate xDS streams of Dataplanes.
+//
+// A stream is tracked once the first DiscoveryRequest reveals which proxy it belongs to.
+// Once disconnected, the stream is closed as if the proxy stopped sending requests,
+// which makes the proxy reconnect.
+func trackStreams(tracker core_xds.StreamTracker) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		stream := &disconnectableStream{
+			ServerStream: ss,
+			tracker:      tracker,
+			disconnected: make(chan struct{}),
+		}
+		defer stream.close()
+		return handler(srv, stream)
+	}
+}
+
+type disconnectableStream struct {
+	grpc.ServerStream
+	tracker      core_xds.StreamTracker
+	disconnected chan struct{}
+	once         sync.Once
+
+	mu      sync.Mutex // protects access to the fields below
+	closed  bool
+	untrack func()
+}
+
+func (s *disconnectableStream) RecvMsg(m interface{}) error {
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- s.ServerStream.RecvMsg(m)
+	}()
+	select {
+	case err := <-errCh:
+		if err == nil {
+			s.track(m)
+		}
+		return err
+	case <-s.disconnected:
+		return status.Error(codes.Unavailable, "xDS stream has been disconnected")
+	}
+}
+
+func (s *disconnectableStream) track(m interface{}) {
+	var node *envoy_core.Node
+	switch req := m.(type) {
+	case *envoy.DiscoveryRequest:
+		node = req.Node
+	case *envoy.DeltaDiscoveryRequest:
+		node = req.Node
+	default:
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed || s.untrack != nil {
+		return
+	}
+	proxyId, err := core_xds.ParseProxyId(node)
+	if err != nil {
+		// requests without a valid Proxy Id are rejected by other callbacks
+		return
+	}
+	s.untrack = s.tracker.Track(*proxyId, s.disconnect)
+}
+
+func (s *disconnectableStream) disconnect() {
+	s.once.Do(func() {
+		close(s.disconnected)
+	})
+}
+
+func (s *disconnectableStream) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	if s.untrack != nil {
+		s.untrack()
+	}
+}
@@ -0,0 +1,86 @@
+package server
+
+import (
+	"io"
+
+	envoy "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	envoy_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
+)
+
+type channelServerStream struct {
+	grpc.ServerStream
+	requests chan *envoy.DiscoveryRequest
+}
+
+func (s *channelServerStream) RecvMsg(m interface{}) error {
+	req, more := <-s.requests
+	if !more {
+		return io.EOF
+	}
+	*(m.(*envoy.DiscoveryRequest)) = *req
+	return nil
+}
+
+var _ = Describe("trackStreams()", func() {
+
+	proxyId := core_xds.ProxyId{Mesh: "demo", Name: "backend-01", Namespace: "default"}
+
+	var tracker core_xds.StreamTracker
+	var stream *channelServerStream
+	var received chan error
+
+	BeforeEach(func() {
+		tracker = core_xds.NewStreamTracker()
+		stream = &channelServerStream{requests: make(chan *envoy.DiscoveryRequest)}
+		received = make(chan error)
+		handler := func(srv interface{}, ss grpc.ServerStream) error {
+			for {
+				err := ss.RecvMsg(&envoy.DiscoveryRequest{})
+				received <- err
+				if err != nil {
+					return err
+				}
+			}
+		}
+		go func() {
+			_ = trackStreams(tracker)(nil, stream, &grpc.StreamServerInfo{}, handler)
+			close(received)
+		}()
+	})
+
+	It("should terminate a stream of a disconnected proxy", func() {
+		// given
+		stream.requests <- &envoy.DiscoveryRequest{Node: &envoy_core.Node{Id: "demo.backend-01"}}
+		Expect(<-received).To(Succeed())
+
+		// when
+		count := tracker.Disconnect(proxyId)
+
+		// then
+		Expect(count).To(Equal(1))
+		err := <-received
+		Expect(status.Code(err)).To(Equal(codes.Unavailable))
+		Eventually(received).Should(BeClosed())
+	})
+
+	It("should stop tracking a closed stream", func() {
+		// given
+		stream.requests <- &envoy.DiscoveryRequest{Node: &envoy_core.Node{Id: "demo.backend-01"}}
+		Expect(<-received).To(Succeed())
+
+		// when
+		close(stream.requests)
+
+		// then
+		Expect(<-received).To(Equal(io.EOF))
+		Eventually(received).Should(BeClosed())
+		Expect(tracker.Disconnect(proxyId)).To(Equal(0))
+	})
+})
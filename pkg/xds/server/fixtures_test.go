@@ -0,0 +1,144 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/gogo/protobuf/jsonpb"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/manager"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	"github.com/Kong/kuma/pkg/core/resources/model/rest"
+	"github.com/Kong/kuma/pkg/core/resources/registry"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	"github.com/Kong/kuma/pkg/core/xds"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+	util_cache "github.com/Kong/kuma/pkg/util/cache"
+	util_proto "github.com/Kong/kuma/pkg/util/proto"
+	xds_context "github.com/Kong/kuma/pkg/xds/context"
+	"github.com/Kong/kuma/pkg/xds/template"
+)
+
+// Every `testdata/fixtures/<name>.input.yaml` describes resources in the universal format
+// (Meshes, Dataplanes and policies), separated by `---`.
+// Envoy config is generated for the first Dataplane of a fixture and compared to
+// `testdata/fixtures/<name>.golden.yaml`.
+//
+// To regenerate golden files after an intended change of the generated config, run
+//
+//	make golden_files
+//
+// and review the diff.
+var _ = Describe("Envoy config generated for fixtures", func() {
+
+	inputFiles, err := filepath.Glob(filepath.Join("testdata", "fixtures", "*.input.yaml"))
+	if err != nil {
+		panic(err)
+	}
+	var entries []TableEntry
+	for _, inputFile := range inputFiles {
+		entries = append(entries, Entry(filepath.Base(inputFile), inputFile))
+	}
+
+	DescribeTable("should match golden files",
+		func(inputFile string) {
+			// given
+			resManager := manager.NewResourceManager(memory.NewStore())
+			dataplaneKey := loadFixture(resManager, inputFile)
+
+			// and
+			cpCtx := &xds_context.ControlPlaneContext{
+				SdsLocation: "kuma-system:5677",
+				SdsTlsCert:  []byte("12345"),
+			}
+			builder := newProxyBuilder(resManager, func(*mesh_core.DataplaneResource) *xds_context.ControlPlaneContext {
+				return cpCtx
+			})
+			gen := templateSnapshotGenerator{
+				ProxyTemplateResolver: &simpleProxyTemplateResolver{
+					ResourceManager:      resManager,
+					DefaultProxyTemplate: template.DefaultProxyTemplate,
+				},
+			}
+
+			// when
+			envoyCtx, proxy, err := builder.Build(context.Background(), dataplaneKey)
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			// and Envoy has been launched by kuma-dp with Admin interface turned on
+			proxy.Metadata = &xds.DataplaneMetadata{AdminPort: 9901}
+
+			// when
+			snapshot, err := gen.GenerateSnapshot(envoyCtx, proxy)
+			// then
+			Expect(err).ToNot(HaveOccurred())
+
+			// when
+			actual, err := util_proto.ToYAML(util_cache.ToDeltaDiscoveryResponse(snapshot))
+			// then
+			Expect(err).ToNot(HaveOccurred())
+
+			// and
+			goldenFile := strings.TrimSuffix(inputFile, ".input.yaml") + ".golden.yaml"
+			if os.Getenv("UPDATE_GOLDEN_FILES") == "true" {
+				Expect(ioutil.WriteFile(goldenFile, actual, 0644)).To(Succeed())
+			}
+			expected, err := ioutil.ReadFile(goldenFile)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actual).To(MatchYAML(expected))
+		},
+		entries...,
+	)
+})
+
+var fixtureSeparator = regexp.MustCompile(`(?m)^---\s*$`)
+
+// loadFixture creates resources of a given fixture and returns the key of its first Dataplane.
+func loadFixture(resManager manager.ResourceManager, file string) core_model.ResourceKey {
+	content, err := ioutil.ReadFile(file)
+	Expect(err).ToNot(HaveOccurred())
+
+	var dataplaneKey *core_model.ResourceKey
+	for _, doc := range fixtureSeparator.Split(string(content), -1) {
+		if strings.TrimSpace(doc) == "" {
+			continue
+		}
+		meta := rest.ResourceMeta{}
+		Expect(yaml.Unmarshal([]byte(doc), &meta)).To(Succeed())
+		resource, err := registry.Global().NewObject(core_model.ResourceType(meta.Type))
+		Expect(err).ToNot(HaveOccurred())
+		// unlike `kumactl apply`, reject unknown fields to catch typos in fixtures
+		fields := map[string]interface{}{}
+		Expect(yaml.Unmarshal([]byte(doc), &fields)).To(Succeed())
+		for _, field := range []string{"type", "name", "mesh", "labels"} {
+			delete(fields, field)
+		}
+		spec, err := json.Marshal(fields)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(jsonpb.Unmarshal(bytes.NewReader(spec), resource.GetSpec())).To(Succeed(), "invalid resource in fixture %q", file)
+
+		mesh := meta.Mesh
+		if resource.GetType() == mesh_core.MeshType {
+			mesh = meta.Name
+		}
+		key := core_model.ResourceKey{Mesh: mesh, Namespace: "default", Name: meta.Name}
+		Expect(resManager.Create(context.Background(), resource, core_store.CreateBy(key), core_store.CreateWithLabels(meta.Labels))).To(Succeed())
+
+		if resource.GetType() == mesh_core.DataplaneType && dataplaneKey == nil {
+			dataplaneKey = &key
+		}
+	}
+	Expect(dataplaneKey).ToNot(BeNil(), "fixture %q must define at least one Dataplane", file)
+	return *dataplaneKey
+}
@@ -1,26 +1,40 @@
 package server
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net"
 
+	envoy "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	envoy_accesslog "github.com/envoyproxy/go-control-plane/envoy/service/accesslog/v2"
 	envoy_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v2"
 	envoy_xds "github.com/envoyproxy/go-control-plane/pkg/server"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
+	xds_config "github.com/Kong/kuma/pkg/config/xds"
 	"github.com/Kong/kuma/pkg/core"
 	core_runtime "github.com/Kong/kuma/pkg/core/runtime"
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
+	util_tls "github.com/Kong/kuma/pkg/tls"
+	util_grpc "github.com/Kong/kuma/pkg/util/grpc"
 )
 
-const grpcMaxConcurrentStreams = 1000000
+const (
+	grpcMaxConcurrentStreams = 1000000
+	// grpcServiceName is a name of the service reported by gRPC Health Checking service.
+	grpcServiceName = "envoy.service.discovery.v2.AggregatedDiscoveryService"
+)
 
 var (
 	grpcServerLog = core.Log.WithName("xds-server").WithName("grpc")
 )
 
 type grpcServer struct {
-	server envoy_xds.Server
-	port   int
+	server    envoy_xds.Server
+	accessLog envoy_accesslog.AccessLogServiceServer
+	config    xds_config.XdsServerConfig
+	streams   core_xds.StreamTracker
 }
 
 // Make sure that grpcServer implements all relevant interfaces
@@ -31,15 +45,31 @@ var (
 func (s *grpcServer) Start(stop <-chan struct{}) error {
 	var grpcOptions []grpc.ServerOption
 	grpcOptions = append(grpcOptions, grpc.MaxConcurrentStreams(grpcMaxConcurrentStreams))
+	grpcOptions = append(grpcOptions,
+		grpc.MaxRecvMsgSize(s.config.GrpcMaxMessageSize),
+		grpc.MaxSendMsgSize(s.config.GrpcMaxMessageSize),
+		grpc.StreamInterceptor(trackStreams(s.streams)),
+	)
+	if s.config.Tls.Enabled {
+		tlsConfig, err := s.tlsConfig()
+		if err != nil {
+			return err
+		}
+		grpcOptions = append(grpcOptions, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
 	grpcServer := grpc.NewServer(grpcOptions...)
 
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", s.port))
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", s.config.GrpcPort))
 	if err != nil {
 		return err
 	}
 
 	// register services
 	envoy_discovery.RegisterAggregatedDiscoveryServiceServer(grpcServer, s.server)
+	// Dataplanes that use the incremental (delta) xDS get Clusters over a stream of CDS, outside of ADS
+	envoy.RegisterClusterDiscoveryServiceServer(grpcServer, s.server)
+	envoy_accesslog.RegisterAccessLogServiceServer(grpcServer, s.accessLog)
+	healthServer := util_grpc.RegisterHealthServer(grpcServer, grpcServiceName)
 
 	errChan := make(chan error)
 	go func() {
@@ -51,14 +81,27 @@ func (s *grpcServer) Start(stop <-chan struct{}) error {
 			grpcServerLog.Info("terminated normally")
 		}
 	}()
-	grpcServerLog.Info("starting", "port", s.port)
+	grpcServerLog.Info("starting", "port", s.config.GrpcPort, "maxMessageSize", s.config.GrpcMaxMessageSize, "tls", s.config.Tls.Enabled)
 
 	select {
 	case <-stop:
 		grpcServerLog.Info("stopping gracefully")
+		healthServer.Shutdown()
 		grpcServer.GracefulStop()
 		return nil
 	case err := <-errChan:
 		return err
 	}
 }
+
+func (s *grpcServer) tlsConfig() (*tls.Config, error) {
+	tlsConfig, err := util_tls.ServerConfig(s.config.Tls.CertFile, s.config.Tls.KeyFile, s.config.Tls.ClientCaFile, s.config.Tls.TlsMinVersion, s.config.Tls.TlsCipherSuites)
+	if err != nil {
+		return nil, err
+	}
+	if s.config.Tls.ClientCaFile == "" && s.config.DataplaneAuth.Type == xds_config.ClientCertDataplaneAuth {
+		// certs of Dataplanes are verified by the authenticator against CAs of their Meshes
+		tlsConfig.ClientAuth = tls.RequestClientCert
+	}
+	return tlsConfig, nil
+}
@@ -0,0 +1,50 @@
+package server
+
+import (
+	"context"
+
+	envoy "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	envoy_xds "github.com/envoyproxy/go-control-plane/pkg/server"
+
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
+)
+
+// NewNodeMetadataCallbacks returns xDS callbacks that reject requests of Envoys
+// whose node metadata doesn't follow the schema of DataplaneMetadata.
+func NewNodeMetadataCallbacks() envoy_xds.Callbacks {
+	return &nodeMetadataCallbacks{}
+}
+
+var _ envoy_xds.Callbacks = &nodeMetadataCallbacks{}
+
+type nodeMetadataCallbacks struct {
+}
+
+func (c *nodeMetadataCallbacks) OnStreamOpen(context.Context, int64, string) error {
+	return nil
+}
+
+func (c *nodeMetadataCallbacks) OnStreamClosed(int64) {
+}
+
+func (c *nodeMetadataCallbacks) OnStreamRequest(streamID int64, req *envoy.DiscoveryRequest) error {
+	if _, err := core_xds.ParseDataplaneMetadata(req.Node); err != nil {
+		xdsServerLog.Error(err, "rejecting xDS request", "streamid", streamID, "nodeId", req.GetNode().GetId())
+		return err
+	}
+	return nil
+}
+
+func (c *nodeMetadataCallbacks) OnStreamResponse(int64, *envoy.DiscoveryRequest, *envoy.DiscoveryResponse) {
+}
+
+func (c *nodeMetadataCallbacks) OnFetchRequest(_ context.Context, req *envoy.DiscoveryRequest) error {
+	if _, err := core_xds.ParseDataplaneMetadata(req.Node); err != nil {
+		xdsServerLog.Error(err, "rejecting xDS request", "nodeId", req.GetNode().GetId())
+		return err
+	}
+	return nil
+}
+
+func (c *nodeMetadataCallbacks) OnFetchResponse(*envoy.DiscoveryRequest, *envoy.DiscoveryResponse) {
+}
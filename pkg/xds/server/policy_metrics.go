@@ -0,0 +1,225 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Kong/kuma/pkg/core/permissions"
+	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	core_runtime "github.com/Kong/kuma/pkg/core/runtime"
+	model "github.com/Kong/kuma/pkg/core/xds"
+	xds_topology "github.com/Kong/kuma/pkg/xds/topology"
+)
+
+// policyMatchResyncInterval is how often matches of all policies are recomputed
+// regardless of changes, since change events are delivered on a best-effort basis.
+const policyMatchResyncInterval = 1 * time.Minute
+
+// PolicyMatchCollector is a collector of metrics that show how many Dataplanes are matched by every policy,
+// e.g. to detect policies that match nothing because of a typo in tags.
+//
+// Matches are computed in the background whenever resources of a Mesh change and are cached in between,
+// so that a scrape doesn't have to evaluate policies against Dataplanes.
+//
+// Selectors are plain comparisons of tags and cannot fail, so the only errors reported are the ones
+// of reading resources from the store.
+type PolicyMatchCollector struct {
+	resManager core_manager.ResourceManager
+	watcher    core_store.ResourceWatcher
+	matched    *prometheus.Desc
+	errors     *prometheus.CounterVec
+
+	mu      sync.RWMutex // protects access to the fields below
+	matches map[string]map[policyKey]int
+}
+
+type policyKey struct {
+	Type core_model.ResourceType
+	Name string
+}
+
+// DefaultPolicyMatchCollector returns a collector that only recomputes matches periodically
+// if the ResourceStore of Control Plane cannot notify about changes.
+func DefaultPolicyMatchCollector(rt core_runtime.Runtime) *PolicyMatchCollector {
+	watcher, _ := rt.ResourceStore().(core_store.ResourceWatcher)
+	return NewPolicyMatchCollector(rt.ResourceManager(), watcher)
+}
+
+func NewPolicyMatchCollector(resManager core_manager.ResourceManager, watcher core_store.ResourceWatcher) *PolicyMatchCollector {
+	return &PolicyMatchCollector{
+		resManager: resManager,
+		watcher:    watcher,
+		matched: prometheus.NewDesc(
+			"policy_matched_dataplanes",
+			"Number of Dataplanes matched by a policy",
+			[]string{"mesh", "type", "name"}, nil,
+		),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "policy_match_store_errors_total",
+			Help: "Number of times Dataplanes matched by policies of a Mesh could not be recomputed because resources could not be read from the store",
+		}, []string{"mesh"}),
+		matches: map[string]map[policyKey]int{},
+	}
+}
+
+var _ prometheus.Collector = &PolicyMatchCollector{}
+
+func (c *PolicyMatchCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.matched
+	c.errors.Describe(ch)
+}
+
+func (c *PolicyMatchCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for mesh, matches := range c.matches {
+		for policy, count := range matches {
+			ch <- prometheus.MustNewConstMetric(c.matched, prometheus.GaugeValue, float64(count), mesh, string(policy.Type), policy.Name)
+		}
+	}
+	c.errors.Collect(ch)
+}
+
+var _ core_runtime.Component = &PolicyMatchCollector{}
+
+func (c *PolicyMatchCollector) Start(stop <-chan struct{}) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var events <-chan core_store.Event
+	if c.watcher != nil {
+		var err error
+		if events, err = watchAll(ctx, c.watcher, watchedResourceTypes()); err != nil {
+			return err
+		}
+	}
+
+	c.refreshAll(ctx)
+	ticker := time.NewTicker(policyMatchResyncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case event := <-events:
+			// coalesce changes that are already waiting, e.g. when many Dataplanes are created at once
+			meshes := map[string]bool{meshOf(event): true}
+			resync := event.Type == core_store.ResyncEvent
+			for pending := true; pending; {
+				select {
+				case event := <-events:
+					meshes[meshOf(event)] = true
+					resync = resync || event.Type == core_store.ResyncEvent
+				default:
+					pending = false
+				}
+			}
+			if resync {
+				c.refreshAll(ctx)
+				continue
+			}
+			for mesh := range meshes {
+				c.refreshMesh(ctx, mesh)
+			}
+		case <-ticker.C:
+			c.refreshAll(ctx)
+		case <-stop:
+			return nil
+		}
+	}
+}
+
+func (c *PolicyMatchCollector) refreshAll(ctx context.Context) {
+	meshes := &mesh_core.MeshResourceList{}
+	if err := c.resManager.List(ctx, meshes); err != nil {
+		xdsServerLog.Error(err, "unable to list Meshes to compute Dataplanes matched by policies")
+		c.errors.WithLabelValues("").Inc()
+		return
+	}
+	existing := map[string]bool{}
+	for _, mesh := range meshes.Items {
+		existing[mesh.GetMeta().GetName()] = true
+		c.refreshMesh(ctx, mesh.GetMeta().GetName())
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for mesh := range c.matches {
+		if !existing[mesh] {
+			delete(c.matches, mesh)
+		}
+	}
+}
+
+// refreshMesh recomputes matches of policies in a given Mesh.
+// If they cannot be recomputed, the previous ones are kept.
+func (c *PolicyMatchCollector) refreshMesh(ctx context.Context, mesh string) {
+	matches, err := c.computeMatches(ctx, mesh)
+	if err != nil {
+		xdsServerLog.Error(err, "unable to compute Dataplanes matched by policies", "mesh", mesh)
+		c.errors.WithLabelValues(mesh).Inc()
+		return
+	}
+	c.mu.Lock()
+	c.matches[mesh] = matches
+	c.mu.Unlock()
+}
+
+func (c *PolicyMatchCollector) computeMatches(ctx context.Context, mesh string) (map[policyKey]int, error) {
+	dataplanes := &mesh_core.DataplaneResourceList{}
+	if err := c.resManager.List(ctx, dataplanes, core_store.ListByMesh(mesh)); err != nil {
+		return nil, err
+	}
+	trafficPermissions := &mesh_core.TrafficPermissionResourceList{}
+	if err := c.resManager.List(ctx, trafficPermissions, core_store.ListByMesh(mesh)); err != nil {
+		return nil, err
+	}
+	proxyTemplates := &mesh_core.ProxyTemplateResourceList{}
+	if err := c.resManager.List(ctx, proxyTemplates, core_store.ListByMesh(mesh)); err != nil {
+		return nil, err
+	}
+	slowStarts := &mesh_core.SlowStartResourceList{}
+	if err := c.resManager.List(ctx, slowStarts, core_store.ListByMesh(mesh)); err != nil {
+		return nil, err
+	}
+	// expired policies are not enforced even before they are removed
+	trafficPermissions = permissions.ActiveTrafficPermissions(trafficPermissions)
+	proxyTemplates.Items = ActiveProxyTemplates(proxyTemplates.Items)
+
+	// policies that match nothing must be reported too
+	matches := make(map[policyKey]int, len(trafficPermissions.Items)+len(proxyTemplates.Items)+len(slowStarts.Items))
+	for _, permission := range trafficPermissions.Items {
+		matches[policyKey{Type: mesh_core.TrafficPermissionType, Name: permission.GetMeta().GetName()}] = 0
+	}
+	for _, template := range proxyTemplates.Items {
+		matches[policyKey{Type: mesh_core.ProxyTemplateType, Name: template.GetMeta().GetName()}] = 0
+	}
+	for _, slowStart := range slowStarts.Items {
+		matches[policyKey{Type: mesh_core.SlowStartType, Name: slowStart.GetMeta().GetName()}] = 0
+	}
+
+	for _, dataplane := range dataplanes.Items {
+		for _, permission := range permissions.MatchDataplaneTrafficPermissions(&dataplane.Spec, trafficPermissions).Items {
+			matches[policyKey{Type: mesh_core.TrafficPermissionType, Name: permission.GetMeta().GetName()}]++
+		}
+		// only the best matching ProxyTemplate is applied to a Dataplane
+		templates := append([]*mesh_core.ProxyTemplateResource{}, proxyTemplates.Items...)
+		if template := FindBestMatch(&model.Proxy{Dataplane: dataplane}, templates); template != nil {
+			matches[policyKey{Type: mesh_core.ProxyTemplateType, Name: template.GetMeta().GetName()}]++
+		}
+		// only the best matching SlowStart is applied to an endpoint, a Dataplane is counted once per SlowStart
+		selected := map[string]bool{}
+		for _, inbound := range dataplane.Spec.Networking.GetInbound() {
+			if slowStart := xds_topology.SelectSlowStart(slowStarts.Items, inbound.Tags); slowStart != nil {
+				selected[slowStart.GetMeta().GetName()] = true
+			}
+		}
+		for name := range selected {
+			matches[policyKey{Type: mesh_core.SlowStartType, Name: name}]++
+		}
+	}
+	return matches, nil
+}
@@ -0,0 +1,207 @@
+package server_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+	xds_server "github.com/Kong/kuma/pkg/xds/server"
+)
+
+var _ = Describe("PolicyMatchCollector", func() {
+
+	var store core_store.ResourceStore
+	var collector *xds_server.PolicyMatchCollector
+	var stop chan struct{}
+
+	BeforeEach(func() {
+		store = memory.NewStore()
+		collector = xds_server.NewPolicyMatchCollector(core_manager.NewResourceManager(store), store.(core_store.ResourceWatcher))
+
+		Expect(store.Create(context.Background(), &mesh_core.MeshResource{}, core_store.CreateByKey("default", "demo", "demo"))).To(Succeed())
+	})
+
+	AfterEach(func() {
+		if stop != nil {
+			close(stop)
+			stop = nil
+		}
+	})
+
+	start := func() {
+		stop = make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			Expect(collector.Start(stop)).To(Succeed())
+		}()
+	}
+
+	matched := func(expected string) func() error {
+		return func() error {
+			return testutil.CollectAndCompare(collector, strings.NewReader(expected), "policy_matched_dataplanes")
+		}
+	}
+
+	create := func(resource mesh_core.DataplaneResource, name string) {
+		Expect(store.Create(context.Background(), &resource, core_store.CreateByKey("default", name, "demo"))).To(Succeed())
+	}
+
+	dataplane := func(service string) mesh_core.DataplaneResource {
+		return mesh_core.DataplaneResource{
+			Spec: mesh_proto.Dataplane{
+				Networking: &mesh_proto.Dataplane_Networking{
+					Inbound: []*mesh_proto.Dataplane_Networking_Inbound{
+						{
+							Interface: "127.0.0.1:8080:80",
+							Tags:      map[string]string{"service": service},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	It("should report number of Dataplanes matched by every policy", func() {
+		// given
+		create(dataplane("backend"), "backend-1")
+		create(dataplane("backend"), "backend-2")
+		create(dataplane("web"), "web-1")
+
+		// and
+		permissions := map[string]string{
+			"backend-access": "backend",
+			"typo":           "bakend",
+		}
+		for name, service := range permissions {
+			permission := &mesh_core.TrafficPermissionResource{
+				Spec: mesh_proto.TrafficPermission{
+					Rules: []*mesh_proto.TrafficPermission_Rule{
+						{
+							Sources: []*mesh_proto.TrafficPermission_Rule_Selector{
+								{Match: map[string]string{"service": "*"}},
+							},
+							Destinations: []*mesh_proto.TrafficPermission_Rule_Selector{
+								{Match: map[string]string{"service": service}},
+							},
+						},
+					},
+				},
+			}
+			Expect(store.Create(context.Background(), permission, core_store.CreateByKey("default", name, "demo"))).To(Succeed())
+		}
+
+		// and
+		template := &mesh_core.ProxyTemplateResource{
+			Spec: mesh_proto.ProxyTemplate{
+				Selectors: []*mesh_proto.ProxyTemplate_Selector{
+					{Match: map[string]string{"service": "web"}},
+				},
+			},
+		}
+		Expect(store.Create(context.Background(), template, core_store.CreateByKey("default", "web-template", "demo"))).To(Succeed())
+
+		// and
+		slowStart := &mesh_core.SlowStartResource{
+			Spec: mesh_proto.SlowStart{
+				Destinations: []*mesh_proto.SlowStart_Selector{
+					{Match: map[string]string{"service": "backend"}},
+				},
+			},
+		}
+		Expect(store.Create(context.Background(), slowStart, core_store.CreateByKey("default", "backend-warm-up", "demo"))).To(Succeed())
+
+		// when
+		start()
+
+		// then
+		Eventually(matched(`
+		# HELP policy_matched_dataplanes Number of Dataplanes matched by a policy
+		# TYPE policy_matched_dataplanes gauge
+		policy_matched_dataplanes{mesh="demo",name="backend-access",type="TrafficPermission"} 2
+		policy_matched_dataplanes{mesh="demo",name="backend-warm-up",type="SlowStart"} 2
+		policy_matched_dataplanes{mesh="demo",name="typo",type="TrafficPermission"} 0
+		policy_matched_dataplanes{mesh="demo",name="web-template",type="ProxyTemplate"} 1
+		`)).ShouldNot(HaveOccurred())
+	})
+
+	It("should recompute matches when resources of a Mesh change", func() {
+		// given
+		template := &mesh_core.ProxyTemplateResource{
+			Spec: mesh_proto.ProxyTemplate{
+				Selectors: []*mesh_proto.ProxyTemplate_Selector{
+					{Match: map[string]string{"service": "web"}},
+				},
+			},
+		}
+		Expect(store.Create(context.Background(), template, core_store.CreateByKey("default", "web-template", "demo"))).To(Succeed())
+
+		// and
+		start()
+		Eventually(matched(`
+		# HELP policy_matched_dataplanes Number of Dataplanes matched by a policy
+		# TYPE policy_matched_dataplanes gauge
+		policy_matched_dataplanes{mesh="demo",name="web-template",type="ProxyTemplate"} 0
+		`)).ShouldNot(HaveOccurred())
+
+		// when
+		create(dataplane("web"), "web-1")
+
+		// then
+		Eventually(matched(`
+		# HELP policy_matched_dataplanes Number of Dataplanes matched by a policy
+		# TYPE policy_matched_dataplanes gauge
+		policy_matched_dataplanes{mesh="demo",name="web-template",type="ProxyTemplate"} 1
+		`)).ShouldNot(HaveOccurred())
+	})
+
+	It("should not report anything when there are no policies", func() {
+		// given
+		create(dataplane("backend"), "backend-1")
+
+		// when
+		start()
+
+		// then
+		Consistently(matched("")).ShouldNot(HaveOccurred())
+	})
+
+	It("should count errors of reading resources from the store", func() {
+		// given
+		collector = xds_server.NewPolicyMatchCollector(&failingResourceManager{ResourceManager: core_manager.NewResourceManager(store)}, nil)
+
+		// when
+		start()
+
+		// then
+		Eventually(func() error {
+			return testutil.CollectAndCompare(collector, strings.NewReader(`
+			# HELP policy_match_store_errors_total Number of times Dataplanes matched by policies of a Mesh could not be recomputed because resources could not be read from the store
+			# TYPE policy_match_store_errors_total counter
+			policy_match_store_errors_total{mesh="demo"} 1
+			`), "policy_match_store_errors_total")
+		}).ShouldNot(HaveOccurred())
+	})
+})
+
+// failingResourceManager fails to list anything but Meshes.
+type failingResourceManager struct {
+	core_manager.ResourceManager
+}
+
+func (m *failingResourceManager) List(ctx context.Context, list core_model.ResourceList, fs ...core_store.ListOptionsFunc) error {
+	if list.GetItemType() == mesh_core.MeshType {
+		return m.ResourceManager.List(ctx, list, fs...)
+	}
+	return errors.New("store is unavailable")
+}
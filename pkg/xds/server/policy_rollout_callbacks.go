@@ -0,0 +1,78 @@
+package server
+
+import (
+	"context"
+	"sync"
+
+	envoy "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	envoy_xds "github.com/envoyproxy/go-control-plane/pkg/server"
+
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
+)
+
+// NewPolicyRolloutCallbacks returns xDS callbacks that let a given rollout know
+// whether Envoy accepts or rejects its config of policies.
+func NewPolicyRolloutCallbacks(rollout core_xds.PolicyRollout) envoy_xds.Callbacks {
+	return &policyRolloutCallbacks{
+		rollout: rollout,
+		streams: make(map[int64]*core_xds.ProxyId),
+	}
+}
+
+var _ envoy_xds.Callbacks = &policyRolloutCallbacks{}
+
+type policyRolloutCallbacks struct {
+	rollout core_xds.PolicyRollout
+
+	mu      sync.Mutex // protects access to the fields below
+	streams map[int64]*core_xds.ProxyId
+}
+
+func (c *policyRolloutCallbacks) OnStreamOpen(context.Context, int64, string) error {
+	return nil
+}
+
+func (c *policyRolloutCallbacks) OnStreamClosed(streamID int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.streams, streamID)
+}
+
+func (c *policyRolloutCallbacks) OnStreamRequest(streamID int64, req *envoy.DiscoveryRequest) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	proxyId := c.streams[streamID]
+	if proxyId == nil {
+		id, err := core_xds.ParseProxyId(req.Node)
+		if err != nil {
+			// it's not up to this callback to reject the request
+			return nil
+		}
+		proxyId = id
+		c.streams[streamID] = proxyId
+	}
+	if req.ResponseNonce == "" {
+		// initial request is neither ACK nor NACK
+		return nil
+	}
+	if !core_xds.IsPolicyDerived(req.TypeUrl) {
+		// e.g. a rejected endpoint says nothing about a change of policies
+		return nil
+	}
+	if req.ErrorDetail != nil {
+		c.rollout.RecordNack(*proxyId)
+	} else {
+		c.rollout.RecordAck(*proxyId)
+	}
+	return nil
+}
+
+func (c *policyRolloutCallbacks) OnStreamResponse(int64, *envoy.DiscoveryRequest, *envoy.DiscoveryResponse) {
+}
+
+func (c *policyRolloutCallbacks) OnFetchRequest(context.Context, *envoy.DiscoveryRequest) error {
+	return nil
+}
+
+func (c *policyRolloutCallbacks) OnFetchResponse(*envoy.DiscoveryRequest, *envoy.DiscoveryResponse) {
+}
@@ -0,0 +1,93 @@
+package server
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	"github.com/Kong/kuma/pkg/core"
+	"github.com/Kong/kuma/pkg/core/permissions"
+	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/manager"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	"github.com/Kong/kuma/pkg/core/xds"
+	xds_context "github.com/Kong/kuma/pkg/xds/context"
+	xds_topology "github.com/Kong/kuma/pkg/xds/topology"
+)
+
+// proxyBuilder fetches everything that Envoy config of a Dataplane is generated from.
+type proxyBuilder struct {
+	resManager         manager.ResourceManager
+	permissionsMatcher permissions.TrafficPermissionsMatcher
+	// controlPlaneContext picks the context of the Control Plane for a given Dataplane.
+	controlPlaneContext func(*mesh_core.DataplaneResource) *xds_context.ControlPlaneContext
+}
+
+func newProxyBuilder(resManager manager.ResourceManager, controlPlaneContext func(*mesh_core.DataplaneResource) *xds_context.ControlPlaneContext) *proxyBuilder {
+	return &proxyBuilder{
+		resManager:          resManager,
+		permissionsMatcher:  permissions.TrafficPermissionsMatcher{ResourceManager: resManager},
+		controlPlaneContext: controlPlaneContext,
+	}
+}
+
+// Build returns an error that satisfies core_store.IsResourceNotFound() if a given Dataplane doesn't exist.
+func (b *proxyBuilder) Build(ctx context.Context, key core_model.ResourceKey) (xds_context.Context, *xds.Proxy, error) {
+	dataplane := &mesh_core.DataplaneResource{}
+	proxyID := xds.FromResourceKey(key)
+
+	if err := b.resManager.Get(ctx, dataplane, core_store.GetBy(key)); err != nil {
+		return xds_context.Context{}, nil, err
+	}
+
+	meshList := mesh_core.MeshResourceList{}
+	if err := b.resManager.List(ctx, &meshList, core_store.ListByMesh(proxyID.Mesh)); err != nil {
+		return xds_context.Context{}, nil, err
+	}
+	if len(meshList.Items) != 1 {
+		return xds_context.Context{}, nil, errors.Errorf("there should be a mesh of name %s. Found %d meshes of given name", proxyID.Mesh, len(meshList.Items))
+	}
+	// passthrough is enabled unless explicitly disabled
+	passthrough := meshList.Items[0].Spec.GetNetworking().GetOutbound().GetPassthrough()
+	envoyCtx := xds_context.Context{
+		ControlPlane: b.controlPlaneContext(dataplane),
+		Mesh: xds_context.MeshContext{
+			TlsEnabled:          meshList.Items[0].Spec.GetMtls().GetEnabled(),
+			Mtls:                meshList.Items[0].Spec.GetMtls(),
+			LoggingEnabled:      meshList.Items[0].Spec.Logging.GetAccessLogs().GetEnabled(),
+			LoggingPath:         meshList.Items[0].Spec.Logging.GetAccessLogs().GetFilePath(),
+			LoggingBackends:     loggingBackends(meshList.Items[0].Spec.Logging.GetAccessLogs()),
+			PassthroughDisabled: passthrough != nil && !passthrough.GetValue(),
+			Prometheus:          meshList.Items[0].Spec.GetMetrics().GetPrometheus(),
+			Outbound:            meshList.Items[0].Spec.GetNetworking().GetOutbound(),
+		},
+	}
+
+	outbound, err := xds_topology.GetOutboundTargets(ctx, dataplane, b.resManager, core.Now())
+	if err != nil {
+		return xds_context.Context{}, nil, err
+	}
+
+	matchedPermissions, err := b.permissionsMatcher.Match(ctx, dataplane)
+	if err != nil {
+		return xds_context.Context{}, nil, err
+	}
+
+	proxy := &xds.Proxy{
+		Id:                 proxyID,
+		Dataplane:          dataplane,
+		TrafficPermissions: matchedPermissions,
+		OutboundTargets:    outbound,
+	}
+	return envoyCtx, proxy, nil
+}
+
+func loggingBackends(accessLogs *mesh_proto.Logging_AccessLogs) []string {
+	var names []string
+	for _, backend := range accessLogs.GetBackends() {
+		names = append(names, backend.GetName())
+	}
+	return names
+}
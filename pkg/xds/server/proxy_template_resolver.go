@@ -7,6 +7,7 @@ import (
 
 	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
 	"github.com/Kong/kuma/pkg/core"
+	"github.com/Kong/kuma/pkg/core/expiry"
 	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
 	core_model "github.com/Kong/kuma/pkg/core/resources/model"
 	core_store "github.com/Kong/kuma/pkg/core/resources/store"
@@ -33,7 +34,7 @@ func (r *simpleProxyTemplateResolver) GetTemplate(proxy *model.Proxy) *mesh_prot
 	if err := r.ResourceManager.List(ctx, templateList, core_store.ListByMesh(proxy.Dataplane.Meta.GetMesh())); err != nil {
 		templateResolverLog.Error(err, "failed to list ProxyTemplates")
 	}
-	if bestMatchTemplate := FindBestMatch(proxy, templateList.Items); bestMatchTemplate != nil {
+	if bestMatchTemplate := FindBestMatch(proxy, ActiveProxyTemplates(templateList.Items)); bestMatchTemplate != nil {
 		log.V(2).Info("found the best matching ProxyTemplate", "proxytemplate", core_model.MetaToResourceKey(bestMatchTemplate.Meta))
 		return &bestMatchTemplate.Spec
 	}
@@ -41,6 +42,18 @@ func (r *simpleProxyTemplateResolver) GetTemplate(proxy *model.Proxy) *mesh_prot
 	return r.DefaultProxyTemplate
 }
 
+// ActiveProxyTemplates returns ProxyTemplates that have not expired yet.
+func ActiveProxyTemplates(templates []*mesh_core.ProxyTemplateResource) []*mesh_core.ProxyTemplateResource {
+	now := core.Now()
+	var active []*mesh_core.ProxyTemplateResource
+	for _, template := range templates {
+		if !expiry.IsExpired(template, now) {
+			active = append(active, template)
+		}
+	}
+	return active
+}
+
 // FindBestMatch given a Dataplane definition and a list of ProxyTemplates returns the "best matching" ProxyTemplate.
 // A ProxyTemplate is considered a match if one of the inbound interfaces of a Dataplane has all tags of ProxyTemplate's selector.
 // Every matching ProxyTemplate gets a rank (score) defined as a maximum number of tags in a matching selector.
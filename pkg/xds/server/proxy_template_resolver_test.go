@@ -3,6 +3,9 @@ package server
 import (
 	"context"
 	"sort"
+	"time"
+
+	"github.com/gogo/protobuf/types"
 
 	"github.com/Kong/kuma/pkg/core/resources/manager"
 
@@ -131,6 +134,40 @@ var _ = Describe("Reconcile", func() {
 			Expect(actual).To(BeIdenticalTo(resolver.DefaultProxyTemplate))
 		})
 
+		It("should ignore ProxyTemplates that have expired", func() {
+			// given
+			proxy := &model.Proxy{
+				Dataplane: &mesh_core.DataplaneResource{
+					Meta: &test_model.ResourceMeta{
+						Mesh: "pilot",
+					},
+				},
+			}
+
+			// setup
+			expiresAt, err := types.TimestampProto(time.Now().Add(-time.Minute))
+			Expect(err).ToNot(HaveOccurred())
+			expired := &mesh_core.ProxyTemplateResource{
+				Spec: mesh_proto.ProxyTemplate{
+					Imports:   []string{"custom-template"},
+					ExpiresAt: expiresAt,
+				},
+			}
+			memStore := memory.NewStore()
+			err = memStore.Create(context.Background(), expired, store.CreateByKey("default", "expired", "pilot"))
+			Expect(err).ToNot(HaveOccurred())
+
+			resolver := &simpleProxyTemplateResolver{
+				ResourceManager:      manager.NewResourceManager(memStore),
+				DefaultProxyTemplate: &mesh_proto.ProxyTemplate{},
+			}
+
+			// when
+			actual := resolver.GetTemplate(proxy)
+
+			// then
+			Expect(actual).To(BeIdenticalTo(resolver.DefaultProxyTemplate))
+		})
 	})
 
 	Describe("ProxyTemplatesByNamespacedName", func() {
@@ -1,8 +1,19 @@
 package server
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
 	"github.com/Kong/kuma/pkg/core"
+	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
 	model "github.com/Kong/kuma/pkg/core/xds"
+	util_proto "github.com/Kong/kuma/pkg/util/proto"
 	xds_context "github.com/Kong/kuma/pkg/xds/context"
 	"github.com/Kong/kuma/pkg/xds/generator"
 
@@ -16,6 +27,8 @@ var (
 	reconcileLog = core.Log.WithName("xds-server").WithName("reconcile")
 )
 
+const versionLength = 16
+
 type SnapshotReconciler interface {
 	Reconcile(ctx xds_context.Context, proxy *model.Proxy) error
 	Clear(proxyId *model.ProxyId) error
@@ -26,17 +39,30 @@ var _ SnapshotReconciler = &reconciler{}
 type reconciler struct {
 	generator snapshotGenerator
 	cacher    snapshotCacher
+	history   model.ConfigHistory
+	tracker   model.ConfigPropagationTracker
+	rollout   model.PolicyRollout
+	templates proxyTemplateResolver
+	policies  *heldPolicies
 }
 
 func (r *reconciler) Clear(proxyId *model.ProxyId) error {
 	// cache.Clear() operation does not push a new (empty) configuration to Envoy.
 	// That is why instead of calling cache.Clear() we set configuration to an empty Snapshot.
 	// This fake value will be removed from cache on Envoy disconnect.
+	r.history.Clear(proxyId.String())
+	r.tracker.Forget(*proxyId)
+	r.rollout.Forget(*proxyId)
+	r.policies.Forget(proxyId.String())
 	return r.cacher.Cache(&envoy_core.Node{Id: proxyId.String()}, envoy_cache.Snapshot{})
 }
 
 func (r *reconciler) Reconcile(ctx xds_context.Context, proxy *model.Proxy) error {
 	node := &envoy_core.Node{Id: proxy.Id.String()}
+	proxy.ProxyTemplate = r.templates.GetTemplate(proxy)
+	// until the proxy becomes part of a rollout of policies, its config is generated out of the policies
+	// it has been configured with before, while changes of the Dataplane itself and of endpoints reach it without delay
+	ctx, proxy = r.policies.Apply(ctx, proxy, r.rollout.Admitted(proxy))
 	snapshot, err := r.generator.GenerateSnapshot(ctx, proxy)
 	if err != nil {
 		reconcileLog.Error(err, "failed to generate a snapshot", "node", node, "proxy", proxy)
@@ -47,15 +73,21 @@ func (r *reconciler) Reconcile(ctx xds_context.Context, proxy *model.Proxy) erro
 	}
 	// to avoid assigning a new version every time,
 	// compare with the previous snapshot and reuse its version whenever possible,
-	// fallback to UUID otherwise
+	// fallback to a hash of the config otherwise
 	previous, err := r.cacher.Get(node)
 	if err != nil {
 		previous = envoy_cache.Snapshot{}
 	}
 	snapshot = r.autoVersion(previous, snapshot)
+	changed := changedVersions(previous, snapshot)
 	if err := r.cacher.Cache(node, snapshot); err != nil {
 		reconcileLog.Error(err, "failed to store snapshot", "snapshot", snapshot, "proxy", proxy)
+		return nil
 	}
+	if len(changed) > 0 {
+		r.history.Record(proxy.Id.String(), snapshot)
+	}
+	r.tracker.RecordReconcile(proxy.Id, changed)
 	return nil
 }
 
@@ -68,14 +100,114 @@ func (r *reconciler) autoVersion(old envoy_cache.Snapshot, new envoy_cache.Snaps
 	return new
 }
 
+// heldPolicies remembers policies that every proxy has last been configured with.
+//
+// Since the whole config of a proxy is generated out of the same policies, resources of all types stay consistent
+// with each other while a change of policies is being rolled out, e.g. listeners never refer to clusters
+// that are gone and TLS settings of listeners and clusters are always in step.
+type heldPolicies struct {
+	mu       sync.Mutex // protects access to the fields below
+	policies map[string]policyInputs
+}
+
+// policyInputs are the parts of config of a proxy that come from policies of a Mesh.
+type policyInputs struct {
+	mesh               xds_context.MeshContext
+	trafficPermissions *mesh_core.TrafficPermissionResourceList
+	proxyTemplate      *mesh_proto.ProxyTemplate
+}
+
+func newHeldPolicies() *heldPolicies {
+	return &heldPolicies{
+		policies: map[string]policyInputs{},
+	}
+}
+
+// Apply returns a context and a proxy with policies the proxy has been configured with before,
+// unless it has been admitted to get the latest ones. Policies of a proxy that is configured for the first time
+// are never held.
+func (h *heldPolicies) Apply(ctx xds_context.Context, proxy *model.Proxy, admitted bool) (xds_context.Context, *model.Proxy) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	held, ok := h.policies[proxy.Id.String()]
+	if !ok || admitted {
+		h.policies[proxy.Id.String()] = policyInputs{
+			mesh:               ctx.Mesh,
+			trafficPermissions: proxy.TrafficPermissions,
+			proxyTemplate:      proxy.ProxyTemplate,
+		}
+		return ctx, proxy
+	}
+	heldProxy := *proxy
+	heldProxy.TrafficPermissions = held.trafficPermissions
+	heldProxy.ProxyTemplate = held.proxyTemplate
+	ctx.Mesh = held.mesh
+	return ctx, &heldProxy
+}
+
+func (h *heldPolicies) Forget(proxyId string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.policies, proxyId)
+}
+
 func reuseVersion(old, new envoy_cache.Resources) envoy_cache.Resources {
 	new.Version = old.Version
 	if !equalSnapshots(old.Items, new.Items) {
-		new.Version = newUUID()
+		new.Version = resourcesVersion(new.Items)
 	}
 	return new
 }
 
+// resourcesVersion derives a version from the content of resources,
+// so that the same config always gets the same version, e.g. after a restart of the Control Plane.
+func resourcesVersion(items map[string]envoy_cache.Resource) string {
+	names := make([]string, 0, len(items))
+	for name := range items {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	hash := sha256.New()
+	for _, name := range names {
+		version, err := resourceVersion(items[name])
+		if err != nil {
+			// fallback to a random version, which only means that Envoy will receive the same config once again
+			return newUUID()
+		}
+		_, _ = fmt.Fprintf(hash, "%s:%s;", name, version)
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:versionLength]
+}
+
+// resourceVersion derives a version of a single resource from its content.
+func resourceVersion(resource proto.Message) (string, error) {
+	bytes, err := marshalDeterministically(resource)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(bytes)
+	return hex.EncodeToString(hash[:])[:versionLength], nil
+}
+
+// marshalDeterministically encodes a resource the same way as long as its content doesn't change.
+//
+// Binary encoding doesn't order entries of maps, e.g. of RBAC policies or Struct metadata,
+// while JSON encoding orders them by key, including inside of messages packed into Any.
+func marshalDeterministically(resource proto.Message) ([]byte, error) {
+	return util_proto.ToJSON(resource)
+}
+
+// changedVersions returns versions of resources that have changed, by type URL.
+func changedVersions(old, new envoy_cache.Snapshot) map[string]string {
+	changed := map[string]string{}
+	for _, typeUrl := range envoy_cache.ResponseTypes {
+		if version := new.GetVersion(typeUrl); version != old.GetVersion(typeUrl) {
+			changed[typeUrl] = version
+		}
+	}
+	return changed
+}
+
 func equalSnapshots(old, new map[string]envoy_cache.Resource) bool {
 	if len(new) != len(old) {
 		return false
@@ -97,7 +229,10 @@ type templateSnapshotGenerator struct {
 }
 
 func (s *templateSnapshotGenerator) GenerateSnapshot(ctx xds_context.Context, proxy *model.Proxy) (envoy_cache.Snapshot, error) {
-	template := s.ProxyTemplateResolver.GetTemplate(proxy)
+	template := proxy.ProxyTemplate
+	if template == nil {
+		template = s.ProxyTemplateResolver.GetTemplate(proxy)
+	}
 
 	gen := generator.TemplateProxyGenerator{ProxyTemplate: template}
 
@@ -2,11 +2,14 @@ package server
 
 import (
 	"fmt"
-	"sync/atomic"
+	"net"
+
+	pbtypes "github.com/gogo/protobuf/types"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
 	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
 	core_xds "github.com/Kong/kuma/pkg/core/xds"
 	xds_model "github.com/Kong/kuma/pkg/core/xds"
@@ -14,34 +17,17 @@ import (
 	xds_context "github.com/Kong/kuma/pkg/xds/context"
 	envoy "github.com/envoyproxy/go-control-plane/envoy/api/v2"
 	envoy_auth "github.com/envoyproxy/go-control-plane/envoy/api/v2/auth"
+	envoy_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
 	envoy_cache "github.com/envoyproxy/go-control-plane/pkg/cache"
 )
 
 var _ = Describe("Reconcile", func() {
 	Describe("reconciler", func() {
 
-		var backupNewUUID func() string
-
-		BeforeEach(func() {
-			backupNewUUID = newUUID
-		})
-		AfterEach(func() {
-			newUUID = backupNewUUID
-		})
-
-		var serial uint64
-
-		BeforeEach(func() {
-			newUUID = func() string {
-				uuid := atomic.AddUint64(&serial, 1)
-				return fmt.Sprintf("v%d", uuid)
-			}
-		})
-
 		var xdsContext core_xds.XdsContext
 
 		BeforeEach(func() {
-			xdsContext = core_xds.NewXdsContext()
+			xdsContext = core_xds.NewXdsContext(core_xds.WithConfigHistory(core_xds.NewConfigHistory(10)))
 		})
 
 		snapshot := envoy_cache.Snapshot{
@@ -85,6 +71,13 @@ var _ = Describe("Reconcile", func() {
 					return <-snapshots, nil
 				}),
 				&simpleSnapshotCacher{xdsContext.Hasher(), xdsContext.Cache()},
+				xdsContext.ConfigHistory(),
+				xdsContext.ConfigPropagationTracker(),
+				xdsContext.PolicyRollout(),
+				proxyTemplateResolverFunc(func(*xds_model.Proxy) *mesh_proto.ProxyTemplate {
+					return &mesh_proto.ProxyTemplate{}
+				}),
+				newHeldPolicies(),
 			}
 
 			// given
@@ -117,11 +110,12 @@ var _ = Describe("Reconcile", func() {
 			// then
 			Expect(snapshot).ToNot(BeZero())
 			// and
-			Expect(snapshot.Listeners.Version).To(Equal("v1"))
-			Expect(snapshot.Routes.Version).To(Equal("v2"))
-			Expect(snapshot.Clusters.Version).To(Equal("v3"))
-			Expect(snapshot.Endpoints.Version).To(Equal("v4"))
-			Expect(snapshot.Secrets.Version).To(Equal("v5"))
+			Expect(snapshot.Listeners.Version).To(HaveLen(16))
+			Expect(snapshot.Routes.Version).To(HaveLen(16))
+			Expect(snapshot.Clusters.Version).To(HaveLen(16))
+			Expect(snapshot.Endpoints.Version).To(HaveLen(16))
+			Expect(snapshot.Secrets.Version).To(HaveLen(16))
+			initial := snapshot
 
 			By("simulating discovery event (Dataplane watchdog triggers refresh)")
 			// when
@@ -135,11 +129,11 @@ var _ = Describe("Reconcile", func() {
 			// then
 			Expect(snapshot).ToNot(BeZero())
 			// and
-			Expect(snapshot.Listeners.Version).To(Equal("v1"))
-			Expect(snapshot.Routes.Version).To(Equal("v2"))
-			Expect(snapshot.Clusters.Version).To(Equal("v3"))
-			Expect(snapshot.Endpoints.Version).To(Equal("v4"))
-			Expect(snapshot.Secrets.Version).To(Equal("v5"))
+			Expect(snapshot.Listeners.Version).To(Equal(initial.Listeners.Version))
+			Expect(snapshot.Routes.Version).To(Equal(initial.Routes.Version))
+			Expect(snapshot.Clusters.Version).To(Equal(initial.Clusters.Version))
+			Expect(snapshot.Endpoints.Version).To(Equal(initial.Endpoints.Version))
+			Expect(snapshot.Secrets.Version).To(Equal(initial.Secrets.Version))
 
 			By("simulating discovery event (Dataplane gets changed)")
 			// when
@@ -153,11 +147,187 @@ var _ = Describe("Reconcile", func() {
 			// then
 			Expect(snapshot).ToNot(BeZero())
 			// and
-			Expect(snapshot.Listeners.Version).To(Equal("v6"))
-			Expect(snapshot.Routes.Version).To(Equal("v7"))
-			Expect(snapshot.Clusters.Version).To(Equal("v8"))
-			Expect(snapshot.Endpoints.Version).To(Equal("v9"))
-			Expect(snapshot.Secrets.Version).To(Equal("v10"))
+			Expect(snapshot.Listeners.Version).ToNot(Equal(initial.Listeners.Version))
+			Expect(snapshot.Routes.Version).ToNot(Equal(initial.Routes.Version))
+			Expect(snapshot.Clusters.Version).ToNot(Equal(initial.Clusters.Version))
+			Expect(snapshot.Endpoints.Version).ToNot(Equal(initial.Endpoints.Version))
+			Expect(snapshot.Secrets.Version).ToNot(Equal(initial.Secrets.Version))
+
+			By("verifying that only config changes were recorded in the history")
+			// when
+			generations := xdsContext.ConfigHistory().Get("pilot.demo.example")
+			// then
+			Expect(generations).To(HaveLen(2))
+			Expect(generations[0].Generation).To(Equal(uint64(1)))
+			Expect(generations[0].Snapshot).To(Equal(initial))
+			Expect(generations[1].Generation).To(Equal(uint64(2)))
+			Expect(generations[1].Snapshot).To(Equal(snapshot))
+
+			By("simulating Dataplane disconnect")
+			// when
+			err = r.Clear(&proxy.Id)
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			// and
+			Expect(xdsContext.ConfigHistory().Get("pilot.demo.example")).To(BeEmpty())
+		})
+
+		It("should generate the same versions for the same config independently of the Control Plane instance", func() {
+			// given
+			reconcile := func() envoy_cache.Snapshot {
+				xdsContext := core_xds.NewXdsContext()
+				r := &reconciler{
+					snapshotGeneratorFunc(func(ctx xds_context.Context, proxy *xds_model.Proxy) (envoy_cache.Snapshot, error) {
+						return snapshot, nil
+					}),
+					&simpleSnapshotCacher{xdsContext.Hasher(), xdsContext.Cache()},
+					xdsContext.ConfigHistory(),
+					xdsContext.ConfigPropagationTracker(),
+					xdsContext.PolicyRollout(),
+					proxyTemplateResolverFunc(func(*xds_model.Proxy) *mesh_proto.ProxyTemplate {
+						return &mesh_proto.ProxyTemplate{}
+					}),
+					newHeldPolicies(),
+				}
+				proxy := &xds_model.Proxy{
+					Id: xds_model.ProxyId{
+						Mesh:      "pilot",
+						Namespace: "example",
+						Name:      "demo",
+					},
+				}
+				Expect(r.Reconcile(xds_context.Context{}, proxy)).To(Succeed())
+				snapshot, err := xdsContext.Cache().GetSnapshot("pilot.demo.example")
+				Expect(err).ToNot(HaveOccurred())
+				return snapshot
+			}
+
+			// when
+			first := reconcile()
+			second := reconcile()
+
+			// then
+			Expect(first.Listeners.Version).To(Equal(second.Listeners.Version))
+			Expect(first.Routes.Version).To(Equal(second.Routes.Version))
+			Expect(first.Clusters.Version).To(Equal(second.Clusters.Version))
+			Expect(first.Endpoints.Version).To(Equal(second.Endpoints.Version))
+			Expect(first.Secrets.Version).To(Equal(second.Secrets.Version))
+		})
+
+		It("should hold back policies of a proxy that is not part of a rollout yet", func() {
+			// given
+			generator := snapshotGeneratorFunc(func(ctx xds_context.Context, proxy *xds_model.Proxy) (envoy_cache.Snapshot, error) {
+				// listeners depend on policies, clusters depend on the Dataplane
+				snapshot := envoy_cache.Snapshot{
+					Listeners: envoy_cache.Resources{Items: map[string]envoy_cache.Resource{
+						ctx.Mesh.LoggingPath: &envoy.Listener{Name: ctx.Mesh.LoggingPath},
+					}},
+					Clusters: envoy_cache.Resources{Items: map[string]envoy_cache.Resource{}},
+				}
+				for name := range proxy.OutboundTargets {
+					snapshot.Clusters.Items[name] = &envoy.Cluster{Name: name}
+				}
+				return snapshot, nil
+			})
+
+			// setup
+			r := &reconciler{
+				generator,
+				&simpleSnapshotCacher{xdsContext.Hasher(), xdsContext.Cache()},
+				xdsContext.ConfigHistory(),
+				xdsContext.ConfigPropagationTracker(),
+				&notAdmittingRollout{},
+				proxyTemplateResolverFunc(func(*xds_model.Proxy) *mesh_proto.ProxyTemplate {
+					return &mesh_proto.ProxyTemplate{}
+				}),
+				newHeldPolicies(),
+			}
+			proxy := func(outbound ...string) *xds_model.Proxy {
+				proxy := &xds_model.Proxy{
+					Id: xds_model.ProxyId{
+						Mesh:      "pilot",
+						Namespace: "example",
+						Name:      "demo",
+					},
+					OutboundTargets: map[string][]net.SRV{},
+				}
+				for _, name := range outbound {
+					proxy.OutboundTargets[name] = nil
+				}
+				return proxy
+			}
+			policies := func(path string) xds_context.Context {
+				return xds_context.Context{Mesh: xds_context.MeshContext{LoggingPath: path}}
+			}
+
+			// when initial Dataplane configuration is generated
+			Expect(r.Reconcile(policies("/v1"), proxy("backend"))).To(Succeed())
+			// and both policies and the Dataplane get changed
+			Expect(r.Reconcile(policies("/v2"), proxy("backend", "redis"))).To(Succeed())
+
+			// then
+			snapshot, err := xdsContext.Cache().GetSnapshot("pilot.demo.example")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(snapshot.Listeners.Items).To(HaveKey("/v1"))
+			Expect(snapshot.Listeners.Items).ToNot(HaveKey("/v2"))
+			Expect(snapshot.Clusters.Items).To(HaveKey("redis"))
+		})
+	})
+
+	Describe("resourcesVersion()", func() {
+		It("should not depend on the order of map entries", func() {
+			// given
+			metadata := &pbtypes.Struct{Fields: map[string]*pbtypes.Value{}}
+			for i := 0; i < 20; i++ {
+				metadata.Fields[fmt.Sprintf("key-%d", i)] = &pbtypes.Value{Kind: &pbtypes.Value_StringValue{StringValue: "value"}}
+			}
+			items := map[string]envoy_cache.Resource{
+				"backend": &envoy.Cluster{
+					Name: "backend",
+					Metadata: &envoy_core.Metadata{
+						FilterMetadata: map[string]*pbtypes.Struct{
+							"envoy.lb":        metadata,
+							"envoy.transport": metadata,
+						},
+					},
+				},
+			}
+
+			// when
+			version := resourcesVersion(items)
+
+			// then
+			for i := 0; i < 20; i++ {
+				Expect(resourcesVersion(items)).To(Equal(version))
+			}
+		})
+	})
+
+	Describe("resourceVersion()", func() {
+		It("should not depend on the order of map entries", func() {
+			// given
+			metadata := &pbtypes.Struct{Fields: map[string]*pbtypes.Value{}}
+			for i := 0; i < 20; i++ {
+				metadata.Fields[fmt.Sprintf("key-%d", i)] = &pbtypes.Value{Kind: &pbtypes.Value_StringValue{StringValue: "value"}}
+			}
+			cluster := &envoy.Cluster{
+				Name: "backend",
+				Metadata: &envoy_core.Metadata{
+					FilterMetadata: map[string]*pbtypes.Struct{
+						"envoy.lb":        metadata,
+						"envoy.transport": metadata,
+					},
+				},
+			}
+
+			// when
+			version, err := resourceVersion(cluster)
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			for i := 0; i < 20; i++ {
+				Expect(resourceVersion(cluster)).To(Equal(version))
+			}
 		})
 	})
 })
@@ -167,3 +337,20 @@ type snapshotGeneratorFunc func(ctx xds_context.Context, proxy *xds_model.Proxy)
 func (f snapshotGeneratorFunc) GenerateSnapshot(ctx xds_context.Context, proxy *xds_model.Proxy) (envoy_cache.Snapshot, error) {
 	return f(ctx, proxy)
 }
+
+type proxyTemplateResolverFunc func(proxy *xds_model.Proxy) *mesh_proto.ProxyTemplate
+
+func (f proxyTemplateResolverFunc) GetTemplate(proxy *xds_model.Proxy) *mesh_proto.ProxyTemplate {
+	return f(proxy)
+}
+
+type notAdmittingRollout struct {
+	xds_model.PolicyRollout
+}
+
+func (notAdmittingRollout) Admitted(*xds_model.Proxy) bool {
+	return false
+}
+
+func (notAdmittingRollout) Forget(xds_model.ProxyId) {
+}
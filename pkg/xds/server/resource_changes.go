@@ -0,0 +1,215 @@
+package server
+
+import (
+	"context"
+	"sync"
+
+	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/apis/system"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	core_registry "github.com/Kong/kuma/pkg/core/resources/registry"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	core_runtime "github.com/Kong/kuma/pkg/core/runtime"
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
+	"github.com/Kong/kuma/pkg/xds/bootstrap"
+)
+
+// watchedResourceTypes returns types of resources in the ResourceStore that config of Dataplanes is generated from.
+//
+// DataplaneInsights are not watched on purpose, since they change on every xDS request
+// and have no effect on config. DataplaneOverviews are not stored at all.
+// Secrets are kept by a SecretStore, which is watched on its own.
+func watchedResourceTypes() []core_model.ResourceType {
+	var types []core_model.ResourceType
+	for _, typ := range core_registry.Global().ListTypes() {
+		switch typ {
+		case mesh_core.DataplaneInsightType, mesh_core.DataplaneOverviewType, system.SecretType:
+			continue
+		}
+		types = append(types, typ)
+	}
+	return types
+}
+
+// ResourceChangeNotifier lets Dataplane watchdogs know about changes of resources in their Mesh,
+// so that config of Dataplanes can be regenerated right away instead of on the next periodic refresh.
+type ResourceChangeNotifier struct {
+	watcher core_store.ResourceWatcher
+	// secretWatcher notifies about changes of Secrets, e.g. when a CA is rotated. It is optional.
+	secretWatcher core_store.ResourceWatcher
+	observers     []ResourceChangeObserver
+
+	mu          sync.Mutex // protects access to the fields below
+	subscribers map[*changeSubscriber]struct{}
+}
+
+// ResourceChangeObserver learns about every change of resources before Dataplane watchdogs do.
+//
+// Since changes are observed in the ResourceStore, changes made past the ResourceManager,
+// e.g. with kubectl or by another instance of Control Plane, are observed as well.
+type ResourceChangeObserver func(event core_store.Event)
+
+// PropagationObserver lets a tracker of config propagation know about changes of resources.
+func PropagationObserver(tracker core_xds.ConfigPropagationTracker) ResourceChangeObserver {
+	return func(event core_store.Event) {
+		if event.Type == core_store.ResyncEvent {
+			// nothing has been written
+			return
+		}
+		tracker.RecordWrite(meshOf(event))
+	}
+}
+
+// RolloutObserver starts a rollout of every change of policies in a Mesh.
+func RolloutObserver(rollout core_xds.PolicyRollout) ResourceChangeObserver {
+	return func(event core_store.Event) {
+		switch {
+		case event.Type == core_store.ResyncEvent:
+			// nothing has been written
+		case event.ResourceType == mesh_core.DataplaneType || event.ResourceType == system.SecretType:
+			// changes of Dataplanes must reach the rest of a Mesh without delay and Secrets carry no policies
+		default:
+			rollout.Start(meshOf(event))
+		}
+	}
+}
+
+// ResiliencyMetricsObserver deletes metrics of resiliency of Dataplanes that have been deleted.
+//
+// Dataplanes deleted while the ResourceStore could not be watched are not observed,
+// their metrics are kept until Control Plane restarts.
+func ResiliencyMetricsObserver(metrics *bootstrap.ResiliencyMetrics) ResourceChangeObserver {
+	return func(event core_store.Event) {
+		if event.Type == core_store.DeletedEvent && event.ResourceType == mesh_core.DataplaneType {
+			metrics.Forget(core_model.ResourceKey{Mesh: event.Mesh, Namespace: event.Namespace, Name: event.Name})
+		}
+	}
+}
+
+type changeSubscriber struct {
+	mesh    string
+	changes chan struct{}
+}
+
+// DefaultResourceChangeNotifier returns nil if the ResourceStore of Control Plane cannot notify about changes.
+func DefaultResourceChangeNotifier(rt core_runtime.Runtime, resiliencyMetrics *bootstrap.ResiliencyMetrics) *ResourceChangeNotifier {
+	watcher, ok := rt.ResourceStore().(core_store.ResourceWatcher)
+	if !ok {
+		xdsServerLog.Info("resource store does not support watching changes, config of Dataplanes will only be refreshed periodically")
+		return nil
+	}
+	secretWatcher, _ := rt.SecretManager().(core_store.ResourceWatcher)
+	return NewResourceChangeNotifier(watcher, secretWatcher,
+		PropagationObserver(rt.XDS().ConfigPropagationTracker()),
+		RolloutObserver(rt.XDS().PolicyRollout()),
+		ResiliencyMetricsObserver(resiliencyMetrics),
+	)
+}
+
+func NewResourceChangeNotifier(watcher core_store.ResourceWatcher, secretWatcher core_store.ResourceWatcher, observers ...ResourceChangeObserver) *ResourceChangeNotifier {
+	return &ResourceChangeNotifier{
+		watcher:       watcher,
+		secretWatcher: secretWatcher,
+		observers:     observers,
+		subscribers:   map[*changeSubscriber]struct{}{},
+	}
+}
+
+var _ core_runtime.Component = &ResourceChangeNotifier{}
+
+func (n *ResourceChangeNotifier) Start(stop <-chan struct{}) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := watchAll(ctx, n.watcher, watchedResourceTypes())
+	if err != nil {
+		return err
+	}
+	var secretEvents <-chan core_store.Event
+	if n.secretWatcher != nil {
+		if secretEvents, err = n.secretWatcher.Watch(ctx, system.SecretType, ""); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case event := <-events:
+			n.notify(event)
+		case event := <-secretEvents:
+			n.notify(event)
+		case <-stop:
+			return nil
+		}
+	}
+}
+
+// watchAll returns a single channel of events about resources of given types in all namespaces.
+func watchAll(ctx context.Context, watcher core_store.ResourceWatcher, types []core_model.ResourceType) (<-chan core_store.Event, error) {
+	events := make(chan core_store.Event)
+	for _, typ := range types {
+		typeEvents, err := watcher.Watch(ctx, typ, "")
+		if err != nil {
+			return nil, err
+		}
+		go func() {
+			for event := range typeEvents {
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	return events, nil
+}
+
+// meshOf returns a name of the Mesh a changed resource belongs to.
+func meshOf(event core_store.Event) string {
+	if event.ResourceType == mesh_core.MeshType {
+		return event.Name
+	}
+	return event.Mesh
+}
+
+// Subscribe returns a channel that fires whenever a resource in the Mesh of a given Dataplane changes.
+// Changes that happen while the previous one has not been handled yet are coalesced.
+//
+// The signature matches util_watchdog.SimpleWatchdog.NewTrigger.
+func (n *ResourceChangeNotifier) Subscribe(key core_model.ResourceKey, stop <-chan struct{}) <-chan struct{} {
+	sub := &changeSubscriber{
+		mesh:    key.Mesh,
+		changes: make(chan struct{}, 1),
+	}
+	n.mu.Lock()
+	n.subscribers[sub] = struct{}{}
+	n.mu.Unlock()
+	go func() {
+		<-stop
+		n.mu.Lock()
+		defer n.mu.Unlock()
+		delete(n.subscribers, sub)
+	}()
+	return sub.changes
+}
+
+func (n *ResourceChangeNotifier) notify(event core_store.Event) {
+	for _, observe := range n.observers {
+		observe(event)
+	}
+	mesh := meshOf(event)
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for sub := range n.subscribers {
+		// a resync concerns all Meshes
+		if event.Type != core_store.ResyncEvent && sub.mesh != mesh {
+			continue
+		}
+		select {
+		case sub.changes <- struct{}{}:
+		default:
+			// there is a change pending already
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package server_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	"github.com/Kong/kuma/pkg/core/resources/apis/system"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	secret_cipher "github.com/Kong/kuma/pkg/core/secrets/cipher"
+	secret_manager "github.com/Kong/kuma/pkg/core/secrets/manager"
+	secret_store "github.com/Kong/kuma/pkg/core/secrets/store"
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
+	resources_memory "github.com/Kong/kuma/pkg/plugins/resources/memory"
+	"github.com/Kong/kuma/pkg/xds/bootstrap"
+	"github.com/Kong/kuma/pkg/xds/bootstrap/rest"
+	. "github.com/Kong/kuma/pkg/xds/server"
+)
+
+var _ = Describe("ResourceChangeNotifier", func() {
+
+	var store core_store.ResourceStore
+	var stopCh chan struct{}
+	var changes <-chan struct{}
+
+	BeforeEach(func() {
+		store = resources_memory.NewStore()
+		secretManager := secret_manager.NewSecretManager(secret_store.NewSecretStore(store), secret_cipher.None())
+		notifier := NewResourceChangeNotifier(store.(core_store.ResourceWatcher), secretManager.(core_store.ResourceWatcher))
+
+		stopCh = make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			Expect(notifier.Start(stopCh)).To(Succeed())
+		}()
+
+		changes = notifier.Subscribe(core_model.ResourceKey{Mesh: "demo", Namespace: "default", Name: "web-01"}, stopCh)
+	})
+
+	AfterEach(func() {
+		close(stopCh)
+	})
+
+	// changed keeps on creating TrafficPermissions until a change is noticed,
+	// since the notifier starts watching the store asynchronously
+	changed := func(mesh string) func() bool {
+		i := 0
+		return func() bool {
+			i++
+			err := store.Create(context.Background(), &mesh_core.TrafficPermissionResource{}, core_store.CreateByKey("default", fmt.Sprintf("tp-%d", i), mesh))
+			Expect(err).ToNot(HaveOccurred())
+			select {
+			case <-changes:
+				return true
+			case <-time.After(10 * time.Millisecond):
+				return false
+			}
+		}
+	}
+
+	It("should notify about changes of resources in the Mesh of a Dataplane", func() {
+		// expect
+		Eventually(changed("demo"), "5s", "1ms").Should(BeTrue())
+	})
+
+	It("should notify about changes of the Mesh of a Dataplane", func() {
+		// given
+		Eventually(changed("demo"), "5s", "1ms").Should(BeTrue())
+
+		// when
+		err := store.Create(context.Background(), &mesh_core.MeshResource{}, core_store.CreateByKey("default", "demo", "demo"))
+		Expect(err).ToNot(HaveOccurred())
+
+		// then
+		Eventually(changes).Should(Receive())
+	})
+
+	It("should notify about changes of Secrets of the Mesh of a Dataplane", func() {
+		// given
+		Eventually(changed("demo"), "5s", "1ms").Should(BeTrue())
+
+		// when
+		err := store.Create(context.Background(), &system.SecretResource{}, core_store.CreateByKey("default", "builtinca.demo", "demo"))
+		Expect(err).ToNot(HaveOccurred())
+
+		// then
+		Eventually(changes).Should(Receive())
+	})
+
+	It("should not notify about changes of resources in other Meshes", func() {
+		// given
+		Eventually(changed("demo"), "5s", "1ms").Should(BeTrue())
+
+		// when
+		err := store.Create(context.Background(), &mesh_core.TrafficPermissionResource{}, core_store.CreateByKey("default", "tp", "other"))
+		Expect(err).ToNot(HaveOccurred())
+
+		// then
+		Consistently(changes, "100ms").ShouldNot(Receive())
+	})
+
+	It("should notify about a resync of resources", func() {
+		// given
+		events := core_store.NewEventBroadcaster()
+		notifier := NewResourceChangeNotifier(broadcastingWatcher{events}, nil)
+		go func() {
+			defer GinkgoRecover()
+			Expect(notifier.Start(stopCh)).To(Succeed())
+		}()
+		changes := notifier.Subscribe(core_model.ResourceKey{Mesh: "demo", Namespace: "default", Name: "web-01"}, stopCh)
+
+		// expect the notifier, which starts watching asynchronously, to notice a resync eventually
+		Eventually(func() bool {
+			events.SendResync()
+			select {
+			case <-changes:
+				return true
+			case <-time.After(10 * time.Millisecond):
+				return false
+			}
+		}, "5s", "1ms").Should(BeTrue())
+	})
+
+	It("should let a tracker of config propagation know about changes made in the store", func() {
+		// given
+		tracker := &recordingPropagationTracker{writes: make(chan string, 100)}
+		notifier := NewResourceChangeNotifier(store.(core_store.ResourceWatcher), nil, PropagationObserver(tracker))
+		go func() {
+			defer GinkgoRecover()
+			Expect(notifier.Start(stopCh)).To(Succeed())
+		}()
+
+		// expect the notifier, which starts watching asynchronously, to notice a change eventually
+		i := 0
+		Eventually(func() bool {
+			i++
+			err := store.Create(context.Background(), &mesh_core.TrafficPermissionResource{}, core_store.CreateByKey("default", fmt.Sprintf("tp-%d", i), "demo"))
+			Expect(err).ToNot(HaveOccurred())
+			select {
+			case mesh := <-tracker.writes:
+				return mesh == "demo"
+			case <-time.After(10 * time.Millisecond):
+				return false
+			}
+		}, "5s", "1ms").Should(BeTrue())
+	})
+})
+
+var _ = Describe("RolloutObserver", func() {
+
+	DescribeTable("should start a rollout only of changes of policies",
+		func(event core_store.Event, expected []string) {
+			// given
+			rollout := &recordingRollout{}
+
+			// when
+			RolloutObserver(rollout)(event)
+
+			// then
+			Expect(rollout.started).To(Equal(expected))
+		},
+		Entry("change of a policy", core_store.Event{Type: core_store.UpdatedEvent, ResourceType: mesh_core.TrafficPermissionType, Mesh: "demo"}, []string{"demo"}),
+		Entry("change of a Mesh", core_store.Event{Type: core_store.UpdatedEvent, ResourceType: mesh_core.MeshType, Name: "demo"}, []string{"demo"}),
+		Entry("change of a Dataplane", core_store.Event{Type: core_store.CreatedEvent, ResourceType: mesh_core.DataplaneType, Mesh: "demo"}, nil),
+		Entry("change of a Secret", core_store.Event{Type: core_store.UpdatedEvent, ResourceType: system.SecretType, Mesh: "demo"}, nil),
+		Entry("resync", core_store.Event{Type: core_store.ResyncEvent}, nil),
+	)
+})
+
+var _ = Describe("ResiliencyMetricsObserver", func() {
+
+	It("should delete metrics of a deleted Dataplane only", func() {
+		// given
+		registry := prometheus.NewRegistry()
+		metrics, err := bootstrap.NewResiliencyMetrics(registry)
+		Expect(err).ToNot(HaveOccurred())
+		for _, name := range []string{"web-01", "web-02"} {
+			metrics.Record(core_model.ResourceKey{Mesh: "demo", Namespace: "default", Name: name}, rest.OutlierEjectionsRequest{
+				Mesh:       "demo",
+				Name:       name,
+				Rejections: []rest.TrafficRejection{{Cluster: "backend", Counter: "upstream_rq_retry_overflow", Rejected: 1}},
+			})
+		}
+		observe := ResiliencyMetricsObserver(metrics)
+
+		// when
+		observe(core_store.Event{Type: core_store.UpdatedEvent, ResourceType: mesh_core.DataplaneType, Mesh: "demo", Namespace: "default", Name: "web-02"})
+		observe(core_store.Event{Type: core_store.DeletedEvent, ResourceType: mesh_core.DataplaneType, Mesh: "demo", Namespace: "default", Name: "web-01"})
+
+		// then
+		Expect(testutil.GatherAndCompare(registry, strings.NewReader(`
+# HELP dataplane_traffic_rejected_total Number of connections, requests or retries to a cluster rejected by a dataplane, e.g. because a retry budget has been exhausted
+# TYPE dataplane_traffic_rejected_total counter
+dataplane_traffic_rejected_total{cluster="backend",counter="upstream_rq_retry_overflow",dataplane="web-02",mesh="demo"} 1
+`))).To(Succeed())
+	})
+})
+
+type recordingRollout struct {
+	core_xds.PolicyRollout
+	started []string
+}
+
+func (r *recordingRollout) Start(mesh string) {
+	r.started = append(r.started, mesh)
+}
+
+type recordingPropagationTracker struct {
+	core_xds.ConfigPropagationTracker
+	writes chan string
+}
+
+func (t *recordingPropagationTracker) RecordWrite(mesh string) {
+	t.writes <- mesh
+}
+
+type broadcastingWatcher struct {
+	*core_store.EventBroadcaster
+}
+
+func (w broadcastingWatcher) Watch(ctx context.Context, resourceType core_model.ResourceType, namespace string) (<-chan core_store.Event, error) {
+	return w.Subscribe(ctx, resourceType, namespace), nil
+}
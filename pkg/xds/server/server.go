@@ -1,10 +1,18 @@
 package server
 
 import (
+	kuma_cp "github.com/Kong/kuma/pkg/config/app/kuma-cp"
+	xds_config "github.com/Kong/kuma/pkg/config/xds"
 	"github.com/Kong/kuma/pkg/core"
+	core_model "github.com/Kong/kuma/pkg/core/resources/model"
 	core_runtime "github.com/Kong/kuma/pkg/core/runtime"
+	"github.com/Kong/kuma/pkg/tokens/builtin/issuer"
 	util_xds "github.com/Kong/kuma/pkg/util/xds"
+	"github.com/Kong/kuma/pkg/xds/accesslog"
+	xds_auth "github.com/Kong/kuma/pkg/xds/auth"
 	"github.com/Kong/kuma/pkg/xds/bootstrap"
+	xds_context "github.com/Kong/kuma/pkg/xds/context"
+	xds_envoy "github.com/Kong/kuma/pkg/xds/envoy"
 	envoy_xds "github.com/envoyproxy/go-control-plane/pkg/server"
 )
 
@@ -15,26 +23,102 @@ var (
 func SetupServer(rt core_runtime.Runtime) error {
 	reconciler := DefaultReconciler(rt)
 
-	tracker, err := DefaultDataplaneSyncTracker(rt, reconciler)
+	// metrics are created up front, so that series of deleted Dataplanes are dropped on changes of resources
+	resiliencyMetrics, err := bootstrap.NewResiliencyMetrics(rt.Metrics())
+	if err != nil {
+		return err
+	}
+	changes := DefaultResourceChangeNotifier(rt, resiliencyMetrics)
+	tracker, err := DefaultDataplaneSyncTracker(rt, reconciler, changes)
+	if err != nil {
+		return err
+	}
+	authenticator, err := DefaultDataplaneAuthenticator(rt)
 	if err != nil {
 		return err
 	}
 	callbacks := util_xds.CallbacksChain{
+		// node metadata must be validated before it's relied upon
+		NewNodeMetadataCallbacks(),
+	}
+	if authenticator != nil {
+		// authentication must precede all other callbacks
+		callbacks = append(callbacks, xds_auth.NewCallbacks(rt.ResourceManager(), authenticator))
+	}
+	callbacks = append(callbacks,
 		tracker,
 		DefaultDataplaneStatusTracker(rt),
+		NewConfigPropagationCallbacks(rt.XDS().ConfigPropagationTracker()),
+		NewPolicyRolloutCallbacks(rt.XDS().PolicyRollout()),
+	)
+	if err := rt.Metrics().Register(rt.XDS().ConfigPropagationTracker()); err != nil {
+		return err
+	}
+	policyMatches := DefaultPolicyMatchCollector(rt)
+	if err := rt.Metrics().Register(policyMatches); err != nil {
+		return err
+	}
+	if err := rt.Add(policyMatches); err != nil {
+		return err
 	}
 
-	srv := envoy_xds.NewServer(rt.XDS().Cache(), callbacks)
-	return core_runtime.Add(
+	if changes != nil {
+		// lets Dataplane watchdogs react to changes of resources
+		if err := rt.Add(changes); err != nil {
+			return err
+		}
+	}
+
+	srv := NewDeltaServer(envoy_xds.NewServer(rt.XDS().Cache(), callbacks), rt.XDS().Cache(), callbacks)
+	forwarder := accesslog.NewForwarder(rt.ResourceManager(), rt.SecretManager(), *rt.Config().XdsServer.AccessLogForwarding, rt.EgressTransport())
+	if err := core_runtime.Add(
 		rt,
 		// xDS gRPC API
-		&grpcServer{srv, rt.Config().XdsServer.GrpcPort},
-		// diagnostics server
-		&diagnosticsServer{rt.Config().XdsServer.DiagnosticsPort},
-		// bootstrap server
+		&grpcServer{srv, accesslog.NewAccessLogServer(rt.ResourceManager(), forwarder, authenticator), *rt.Config().XdsServer, rt.XDS().StreamTracker()},
+		// forwards access logs streamed by Dataplanes to the backends of their Mesh
+		forwarder,
+	); err != nil {
+		return err
+	}
+	if !rt.Config().BootstrapServer.Enabled {
+		xdsServerLog.Info("bootstrap server is disabled")
+		return nil
+	}
+	// on Kubernetes Dataplanes are created out of Pods, not registered by dataplanes themselves
+	var inboundsTokenIssuer issuer.DataplaneTokenIssuer
+	meshNamespace := rt.Config().Store.Kubernetes.SystemNamespace
+	if rt.Config().Environment != kuma_cp.KubernetesEnvironment {
+		inboundsTokenIssuer = NewDataplaneTokenIssuer(rt)
+		meshNamespace = core_model.DefaultNamespace
+	}
+	xdsClientCert, err := DefaultXdsClientCert(rt)
+	if err != nil {
+		return err
+	}
+	return rt.Add(
 		&bootstrap.BootstrapServer{
 			Port:      rt.Config().BootstrapServer.Port,
-			Generator: bootstrap.NewDefaultBootstrapGenerator(rt.ResourceManager(), rt.Config().BootstrapServer.Params),
+			Generator: bootstrap.NewDefaultBootstrapGenerator(rt.ResourceManager(), rt.Config().BootstrapServer.Params, meshNamespace, inboundsTokenIssuer, xdsClientCert),
+			Ejections: bootstrap.NewOutlierEjectionRecorder(rt.ResourceManager(), rt.XDS().PolicyRollout(), resiliencyMetrics, authenticator),
 		},
 	)
 }
+
+// DefaultXdsClientCert returns the client certificate that dataplanes present to XDS Server.
+//
+// Dataplanes that are authenticated by their client certificates present the identity served by SDS Server
+// unless they have a certificate of their own.
+func DefaultXdsClientCert(rt core_runtime.Runtime) (bootstrap.XdsClientCert, error) {
+	config := rt.Config().XdsServer
+	clientCert := bootstrap.XdsClientCert{
+		Required: config.Tls.ClientCaFile != "" || config.DataplaneAuth.Type == xds_config.ClientCertDataplaneAuth,
+	}
+	if config.DataplaneAuth.Type == xds_config.ClientCertDataplaneAuth {
+		controlPlane, err := xds_context.BuildControlPlaneContext(rt.Config())
+		if err != nil {
+			return bootstrap.XdsClientCert{}, err
+		}
+		clientCert.Sds = xds_envoy.IdentityCertSdsSecretConfig(xds_context.Context{ControlPlane: controlPlane})
+	}
+	return clientCert, nil
+}
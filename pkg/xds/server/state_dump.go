@@ -0,0 +1,198 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	envoy_cache "github.com/envoyproxy/go-control-plane/pkg/cache"
+	"github.com/pkg/errors"
+	io_prometheus_client "github.com/prometheus/client_model/go"
+
+	kuma_cp "github.com/Kong/kuma/pkg/config/app/kuma-cp"
+	"github.com/Kong/kuma/pkg/core"
+	freeze_managers "github.com/Kong/kuma/pkg/core/managers/freeze"
+	core_runtime "github.com/Kong/kuma/pkg/core/runtime"
+)
+
+var (
+	stateDumpLog = core.Log.WithName("xds-server").WithName("state-dump")
+
+	snapshotTypes = []string{
+		envoy_cache.EndpointType,
+		envoy_cache.ClusterType,
+		envoy_cache.RouteType,
+		envoy_cache.ListenerType,
+		envoy_cache.SecretType,
+	}
+)
+
+// freezeStatusTimeout bounds the time spent on reading status of the configuration freeze,
+// so that a store that doesn't respond can't keep the state, e.g. on SIGQUIT, from being dumped.
+const freezeStatusTimeout = 5 * time.Second
+
+// StateDump is a snapshot of internal state of Control Plane
+// that helps to debug a stuck instance offline.
+type StateDump struct {
+	Time       time.Time `json:"time"`
+	InstanceId string    `json:"instanceId"`
+	Role       string    `json:"role"`
+	// Controllers is true if this instance runs discovery of Dataplanes and maintenance of default resources,
+	// i.e. the work that must be done by a single instance.
+	Controllers  bool                  `json:"controllers"`
+	ConfigFreeze ConfigFreezeStateDump `json:"configFreeze"`
+	Nodes        []NodeStateDump       `json:"nodes"`
+	// Gauges contain current values of all gauges, e.g. depths of queues.
+	Gauges map[string]float64 `json:"gauges"`
+}
+
+type ConfigFreezeStateDump struct {
+	Frozen bool      `json:"frozen"`
+	By     string    `json:"by,omitempty"`
+	Reason string    `json:"reason,omitempty"`
+	Since  time.Time `json:"since,omitempty"`
+	// Error explains why status of the freeze is unknown, e.g. because the store hasn't responded in time.
+	Error string `json:"error,omitempty"`
+}
+
+// NodeStateDump describes an Envoy that is watching config.
+type NodeStateDump struct {
+	Id                   string            `json:"id"`
+	Watches              int               `json:"watches"`
+	LastWatchRequestTime time.Time         `json:"lastWatchRequestTime"`
+	SnapshotVersions     map[string]string `json:"snapshotVersions,omitempty"`
+}
+
+// DumpState captures internal state of a given runtime.
+func DumpState(rt core_runtime.Runtime) (*StateDump, error) {
+	cfg := rt.Config()
+	dump := &StateDump{
+		Time:         core.Now(),
+		InstanceId:   rt.GetInstanceId(),
+		Role:         cfg.Role,
+		Controllers:  cfg.HasRole(kuma_cp.ControllersOnlyRole),
+		ConfigFreeze: dumpConfigFreeze(rt),
+		Nodes:        dumpNodes(rt.XDS().Cache()),
+		Gauges:       map[string]float64{},
+	}
+	families, err := rt.Metrics().Gather()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not gather metrics")
+	}
+	for _, family := range families {
+		if family.GetType() != io_prometheus_client.MetricType_GAUGE {
+			continue
+		}
+		for _, metric := range family.Metric {
+			dump.Gauges[gaugeName(family.GetName(), metric.Label)] = metric.GetGauge().GetValue()
+		}
+	}
+	return dump, nil
+}
+
+// dumpConfigFreeze describes status of the configuration freeze on a best-effort basis,
+// since the rest of the state is worth dumping even if the store is stuck.
+func dumpConfigFreeze(rt core_runtime.Runtime) ConfigFreezeStateDump {
+	ctx, cancel := context.WithTimeout(context.Background(), freezeStatusTimeout)
+	defer cancel()
+	freeze, err := freeze_managers.NewConfigFreeze(rt.SecretManager()).Status(ctx)
+	if err != nil {
+		stateDumpLog.Error(err, "could not retrieve status of the configuration freeze")
+		return ConfigFreezeStateDump{Error: err.Error()}
+	}
+	return ConfigFreezeStateDump{
+		Frozen: freeze.Frozen,
+		By:     freeze.By,
+		Reason: freeze.Reason,
+		Since:  freeze.Since,
+	}
+}
+
+func dumpNodes(cache envoy_cache.SnapshotCache) []NodeStateDump {
+	ids := cache.GetStatusKeys()
+	sort.Strings(ids)
+	nodes := make([]NodeStateDump, 0, len(ids))
+	for _, id := range ids {
+		node := NodeStateDump{Id: id}
+		if info := cache.GetStatusInfo(id); info != nil {
+			node.Watches = info.GetNumWatches()
+			node.LastWatchRequestTime = info.GetLastWatchRequestTime()
+		}
+		if snapshot, err := cache.GetSnapshot(id); err == nil {
+			node.SnapshotVersions = map[string]string{}
+			for _, typ := range snapshotTypes {
+				if version := snapshot.GetVersion(typ); version != "" {
+					node.SnapshotVersions[typ] = version
+				}
+			}
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+func gaugeName(name string, labels []*io_prometheus_client.LabelPair) string {
+	if len(labels) == 0 {
+		return name
+	}
+	pairs := make([]string, 0, len(labels))
+	for _, label := range labels {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", label.GetName(), label.GetValue()))
+	}
+	return fmt.Sprintf("%s{%s}", name, strings.Join(pairs, ","))
+}
+
+// WriteStateDump writes a dump of internal state of a given runtime into a new file in a given directory
+// and returns a path to that file.
+func WriteStateDump(rt core_runtime.Runtime, dir string) (string, error) {
+	dump, err := DumpState(rt)
+	if err != nil {
+		return "", err
+	}
+	content, err := json.MarshalIndent(dump, "", "  ")
+	if err != nil {
+		return "", errors.Wrap(err, "could not marshal state dump")
+	}
+	path := filepath.Join(dir, fmt.Sprintf("kuma-cp-state-%s.json", dump.Time.UTC().Format("20060102T150405.000000000")))
+	if err := ioutil.WriteFile(path, content, 0600); err != nil {
+		return "", errors.Wrapf(err, "could not write file %q", path)
+	}
+	return path, nil
+}
+
+// stateDumper writes a dump of internal state into a file when Control Plane receives SIGQUIT.
+//
+// Afterwards, SIGQUIT is handled the default way, i.e. Go runtime prints stacks of all goroutines and exits.
+type stateDumper struct {
+	rt  core_runtime.Runtime
+	dir string
+}
+
+var _ core_runtime.Component = &stateDumper{}
+
+func (d *stateDumper) Start(stop <-chan struct{}) error {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGQUIT)
+	defer signal.Stop(signals)
+	select {
+	case <-signals:
+		if path, err := WriteStateDump(d.rt, d.dir); err != nil {
+			stateDumpLog.Error(err, "unable to dump internal state")
+		} else {
+			stateDumpLog.Info("internal state has been dumped", "path", path)
+		}
+		// re-raise the signal to get a dump of goroutines from Go runtime as well
+		signal.Reset(syscall.SIGQUIT)
+		return syscall.Kill(os.Getpid(), syscall.SIGQUIT)
+	case <-stop:
+		return nil
+	}
+}
@@ -0,0 +1,189 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	envoy "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	envoy_core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	envoy_cache "github.com/envoyproxy/go-control-plane/pkg/cache"
+
+	kuma_cp "github.com/Kong/kuma/pkg/config/app/kuma-cp"
+	"github.com/Kong/kuma/pkg/core"
+	freeze_managers "github.com/Kong/kuma/pkg/core/managers/freeze"
+	core_system "github.com/Kong/kuma/pkg/core/resources/apis/system"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	core_runtime "github.com/Kong/kuma/pkg/core/runtime"
+	secret_manager "github.com/Kong/kuma/pkg/core/secrets/manager"
+	test_runtime "github.com/Kong/kuma/pkg/test/runtime"
+	. "github.com/Kong/kuma/pkg/xds/server"
+)
+
+var _ = Describe("StateDump", func() {
+
+	var now time.Time
+	var rt core_runtime.Runtime
+
+	BeforeEach(func() {
+		now = time.Unix(1568000000, 0)
+		core.Now = func() time.Time {
+			return now
+		}
+
+		cfg := kuma_cp.DefaultConfig()
+		cfg.Role = kuma_cp.XdsOnlyRole
+		runtime, err := test_runtime.BuilderFor(cfg).Build()
+		Expect(err).ToNot(HaveOccurred())
+		rt = runtime
+	})
+
+	AfterEach(func() {
+		core.Now = time.Now
+	})
+
+	It("should dump internal state", func() {
+		// given
+		snapshot := envoy_cache.NewSnapshot("v1", nil, nil, nil, nil)
+		Expect(rt.XDS().Cache().SetSnapshot("demo.backend.default", snapshot)).To(Succeed())
+		_, cancel := rt.XDS().Cache().CreateWatch(envoy.DiscoveryRequest{
+			Node:        &envoy_core.Node{Id: "demo.backend.default"},
+			TypeUrl:     envoy_cache.ClusterType,
+			VersionInfo: "v1",
+		})
+		defer cancel()
+		// and
+		_, err := freeze_managers.NewConfigFreeze(rt.SecretManager()).Set(context.Background(), true, "john", "incident")
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		dump, err := DumpState(rt)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dump.Time).To(Equal(now))
+		Expect(dump.Role).To(Equal(kuma_cp.XdsOnlyRole))
+		Expect(dump.Controllers).To(BeFalse())
+		Expect(dump.ConfigFreeze.Frozen).To(BeTrue())
+		Expect(dump.ConfigFreeze.By).To(Equal("john"))
+		Expect(dump.ConfigFreeze.Reason).To(Equal("incident"))
+		Expect(dump.Nodes).To(Equal([]NodeStateDump{{
+			Id:                   "demo.backend.default",
+			Watches:              1,
+			LastWatchRequestTime: dump.Nodes[0].LastWatchRequestTime,
+			SnapshotVersions: map[string]string{
+				envoy_cache.EndpointType: "v1",
+				envoy_cache.ClusterType:  "v1",
+				envoy_cache.RouteType:    "v1",
+				envoy_cache.ListenerType: "v1",
+			},
+		}}))
+		Expect(dump.Gauges).To(HaveKey("go_goroutines"))
+	})
+
+	It("should dump the rest of internal state when status of the freeze cannot be retrieved", func() {
+		// given
+		runtime, err := test_runtime.BuilderFor(kuma_cp.DefaultConfig()).
+			WithSecretManager(&failingSecretManager{}).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+
+		// when
+		dump, err := DumpState(runtime)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dump.ConfigFreeze.Frozen).To(BeFalse())
+		Expect(dump.ConfigFreeze.Error).To(Equal("store is unavailable"))
+		Expect(dump.Gauges).To(HaveKey("go_goroutines"))
+	})
+
+	It("should write internal state into a file", func() {
+		// given
+		dir, err := ioutil.TempDir("", "state-dump")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+
+		// when
+		path, err := WriteStateDump(rt, dir)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		content, err := ioutil.ReadFile(path)
+		Expect(err).ToNot(HaveOccurred())
+		dump := &StateDump{}
+		Expect(json.Unmarshal(content, dump)).To(Succeed())
+		Expect(dump.Role).To(Equal(kuma_cp.XdsOnlyRole))
+		Expect(dump.Time.Equal(now)).To(BeTrue())
+	})
+
+	Describe("StateDumpHandler", func() {
+
+		handlerWithAdminToken := func(adminToken string) http.Handler {
+			cfg := kuma_cp.DefaultConfig()
+			cfg.ApiServer.AdminToken = adminToken
+			runtime, err := test_runtime.BuilderFor(cfg).Build()
+			Expect(err).ToNot(HaveOccurred())
+			return StateDumpHandler(runtime)
+		}
+
+		serve := func(handler http.Handler, authorization string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest("GET", "/state", nil)
+			if authorization != "" {
+				req.Header.Set("Authorization", authorization)
+			}
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+			return resp
+		}
+
+		It("should dump internal state to a request with the admin token", func() {
+			// when
+			resp := serve(handlerWithAdminToken("s3cr3t"), "Bearer s3cr3t")
+
+			// then
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			dump := &StateDump{}
+			Expect(json.Unmarshal(resp.Body.Bytes(), dump)).To(Succeed())
+			Expect(dump.Time.Equal(now)).To(BeTrue())
+		})
+
+		It("should reject a request without a valid admin token", func() {
+			// given
+			handler := handlerWithAdminToken("s3cr3t")
+
+			// expect
+			Expect(serve(handler, "").Code).To(Equal(http.StatusUnauthorized))
+			Expect(serve(handler, "Bearer other").Code).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should be disabled when the admin token is not set", func() {
+			// when
+			resp := serve(handlerWithAdminToken(""), "Bearer ")
+
+			// then
+			Expect(resp.Code).To(Equal(http.StatusForbidden))
+			Expect(resp.Body.String()).To(Equal("Dump of internal state is disabled, set apiServer.adminToken to enable it"))
+		})
+	})
+})
+
+// failingSecretManager fails to read any Secret, e.g. because the store is unavailable.
+type failingSecretManager struct {
+	secret_manager.SecretManager
+}
+
+func (s *failingSecretManager) Get(ctx context.Context, _ *core_system.SecretResource, _ ...core_store.GetOptionsFunc) error {
+	if _, ok := ctx.Deadline(); !ok {
+		return errors.New("request has no deadline")
+	}
+	return errors.New("store is unavailable")
+}
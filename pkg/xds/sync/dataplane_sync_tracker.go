@@ -17,7 +17,8 @@ var (
 	dataplaneSyncTrackerLog = core.Log.WithName("xds-server").WithName("dataplane-sync-tracker")
 )
 
-type NewDataplaneWatchdogFunc func(dataplaneId core_model.ResourceKey) util_watchdog.Watchdog
+// NewDataplaneWatchdogFunc returns a watchdog of a Dataplane given metadata of its Envoy, which might be nil.
+type NewDataplaneWatchdogFunc func(dataplaneId core_model.ResourceKey, metadata *core_xds.DataplaneMetadata) util_watchdog.Watchdog
 
 func NewDataplaneSyncTracker(factoryFunc NewDataplaneWatchdogFunc) envoy_xds.Callbacks {
 	return &dataplaneSyncTracker{
@@ -69,13 +70,15 @@ func (t *dataplaneSyncTracker) OnStreamRequest(streamID int64, req *envoy.Discov
 
 	if id, err := core_xds.ParseProxyId(req.Node); err == nil {
 		dataplaneKey := core_model.ResourceKey{Mesh: id.Mesh, Namespace: id.Namespace, Name: id.Name}
+		// streams with invalid metadata are rejected by other callbacks
+		metadata, _ := core_xds.ParseDataplaneMetadata(req.Node)
 
 		// kick off watchdag for that Dataplane
 		stopCh := make(chan struct{})
 		t.streams[streamID] = context.CancelFunc(func() {
 			close(stopCh)
 		})
-		go t.newDataplaneWatchdog(dataplaneKey).Start(stopCh)
+		go t.newDataplaneWatchdog(dataplaneKey, metadata).Start(stopCh)
 		dataplaneSyncTrackerLog.V(1).Info("started Watchdog for a Dataplane", "streamid", streamID, "proxyId", id, "dataplaneKey", dataplaneKey)
 	} else {
 		dataplaneSyncTrackerLog.Error(err, "failed to parse Dataplane Id out of DiscoveryRequest", "streamid", streamID, "req", req)
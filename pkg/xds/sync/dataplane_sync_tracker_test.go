@@ -7,6 +7,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	core_model "github.com/Kong/kuma/pkg/core/resources/model"
+	core_xds "github.com/Kong/kuma/pkg/core/xds"
 	util_watchdog "github.com/Kong/kuma/pkg/util/watchdog"
 
 	. "github.com/Kong/kuma/pkg/xds/sync"
@@ -70,7 +71,7 @@ var _ = Describe("Sync", func() {
 			watchdogCh := make(chan core_model.ResourceKey)
 
 			// setup
-			tracker := NewDataplaneSyncTracker(NewDataplaneWatchdogFunc(func(dataplaneId core_model.ResourceKey) util_watchdog.Watchdog {
+			tracker := NewDataplaneSyncTracker(NewDataplaneWatchdogFunc(func(dataplaneId core_model.ResourceKey, _ *core_xds.DataplaneMetadata) util_watchdog.Watchdog {
 				return WatchdogFunc(func(stop <-chan struct{}) {
 					watchdogCh <- dataplaneId
 					<-stop
@@ -122,6 +123,42 @@ var _ = Describe("Sync", func() {
 
 			close(done)
 		}, 5)
+
+		It("should pass metadata of Envoy to a Watchdog", func() {
+			metadataCh := make(chan *core_xds.DataplaneMetadata, 1)
+
+			// setup
+			tracker := NewDataplaneSyncTracker(NewDataplaneWatchdogFunc(func(_ core_model.ResourceKey, metadata *core_xds.DataplaneMetadata) util_watchdog.Watchdog {
+				return WatchdogFunc(func(stop <-chan struct{}) {
+					metadataCh <- metadata
+					<-stop
+				})
+			}))
+
+			// given
+			streamID := int64(1)
+			metadata := &core_xds.DataplaneMetadata{
+				Version:   core_xds.MetadataVersion,
+				Mesh:      "pilot",
+				Name:      "example.demo",
+				AdminPort: 9901,
+			}
+			req := &envoy.DiscoveryRequest{
+				Node: &envoy_core.Node{
+					Id:       "pilot.example.demo",
+					Metadata: metadata.ToStruct(),
+				},
+			}
+
+			// when
+			Expect(tracker.OnStreamRequest(streamID, req)).To(Succeed())
+
+			// then
+			Eventually(metadataCh).Should(Receive(Equal(metadata)))
+
+			// cleanup
+			tracker.OnStreamClosed(streamID)
+		})
 	})
 })
 
@@ -3,6 +3,8 @@ package topology
 import (
 	"context"
 	"net"
+	"sort"
+	"time"
 
 	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
 	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
@@ -10,16 +12,40 @@ import (
 	core_store "github.com/Kong/kuma/pkg/core/resources/store"
 )
 
-func GetOutboundTargets(ctx context.Context, dataplane *mesh_core.DataplaneResource, manager core_manager.ResourceManager) (map[string][]net.SRV, error) {
+const (
+	// FullWeight is a load balancing weight of an endpoint of a service with slow start that has been warmed up.
+	FullWeight = 100
+	// slowStartSteps limits how many times a weight of a new endpoint changes over the window of slow start,
+	// so that Envoy doesn't receive new endpoints on every refresh of its configuration.
+	slowStartSteps = 10
+)
+
+// GetOutboundTargets returns endpoints of services that a given Dataplane connects to.
+//
+// Endpoints selected by a SlowStart are weighted by time since their Dataplanes have connected to the Control Plane
+// for the first time, so that reconnects of Dataplanes that are warmed up already don't reduce their share of traffic.
+// Other endpoints of the same service get the full weight, endpoints of services without slow start have no weight.
+func GetOutboundTargets(ctx context.Context, dataplane *mesh_core.DataplaneResource, manager core_manager.ResourceManager, now time.Time) (map[string][]net.SRV, error) {
 	outbound := make(map[string][]net.SRV)
 	if len(dataplane.Spec.Networking.GetOutbound()) > 0 {
 		dataplanes := &mesh_core.DataplaneResourceList{}
 		if err := manager.List(ctx, dataplanes, core_store.ListByMesh(dataplane.Meta.GetMesh())); err != nil {
 			return nil, err
 		}
+		slowStarts := &mesh_core.SlowStartResourceList{}
+		if err := manager.List(ctx, slowStarts, core_store.ListByMesh(dataplane.Meta.GetMesh())); err != nil {
+			return nil, err
+		}
 		for _, oface := range dataplane.Spec.Networking.GetOutbound() {
 			outbound[oface.Service] = make([]net.SRV, 0)
 		}
+		var connectTimes map[string]time.Time
+		if len(slowStarts.Items) > 0 {
+			var err error
+			if connectTimes, err = getConnectTimes(ctx, dataplane.Meta.GetMesh(), manager); err != nil {
+				return nil, err
+			}
+		}
 		for _, dataplane := range dataplanes.Items {
 			for _, inbound := range dataplane.Spec.Networking.GetInbound() {
 				service := inbound.Tags[mesh_proto.ServiceTag]
@@ -27,13 +53,60 @@ func GetOutboundTargets(ctx context.Context, dataplane *mesh_core.DataplaneResou
 				if !ok {
 					continue
 				}
-				iface, err := mesh_proto.ParseInboundInterface(inbound.Interface)
+				iface, err := inbound.GetInboundInterface()
 				if err != nil {
 					return nil, err
 				}
-				outbound[service] = append(endpoints, net.SRV{Target: iface.DataplaneIP, Port: uint16(iface.DataplanePort)})
+				endpoint := net.SRV{Target: iface.AdvertisedIP, Port: uint16(iface.DataplanePort)}
+				if slowStart := SelectSlowStart(slowStarts.Items, inbound.Tags); slowStart != nil {
+					endpoint.Weight = slowStartWeight(&slowStart.Spec, connectTimes[dataplane.Meta.GetName()], now)
+				}
+				outbound[service] = append(endpoints, endpoint)
+			}
+		}
+		for _, endpoints := range outbound {
+			// order of Dataplanes returned by a store is not guaranteed,
+			// sort endpoints to generate the same config for the same set of Dataplanes
+			sort.Slice(endpoints, func(i, j int) bool {
+				if endpoints[i].Target != endpoints[j].Target {
+					return endpoints[i].Target < endpoints[j].Target
+				}
+				return endpoints[i].Port < endpoints[j].Port
+			})
+			// weights are relative to other endpoints of the same service,
+			// so endpoints that are not selected by any SlowStart must not be left out
+			if isWeighted(endpoints) {
+				for i := range endpoints {
+					if endpoints[i].Weight == 0 {
+						endpoints[i].Weight = FullWeight
+					}
+				}
 			}
 		}
 	}
 	return outbound, nil
 }
+
+func isWeighted(endpoints []net.SRV) bool {
+	for _, endpoint := range endpoints {
+		if endpoint.Weight != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// getConnectTimes returns when Dataplanes of a given Mesh have connected to the Control Plane for the first time, by name.
+func getConnectTimes(ctx context.Context, mesh string, manager core_manager.ResourceManager) (map[string]time.Time, error) {
+	insights := &mesh_core.DataplaneInsightResourceList{}
+	if err := manager.List(ctx, insights, core_store.ListByMesh(mesh)); err != nil {
+		return nil, err
+	}
+	connectTimes := make(map[string]time.Time, len(insights.Items))
+	for _, insight := range insights.Items {
+		if connectTime := insight.Spec.GetEarliestConnectTime(); connectTime != nil {
+			connectTimes[insight.Meta.GetName()] = *connectTime
+		}
+	}
+	return connectTimes, nil
+}
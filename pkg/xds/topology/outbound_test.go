@@ -0,0 +1,166 @@
+package topology_test
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	core_manager "github.com/Kong/kuma/pkg/core/resources/manager"
+	core_store "github.com/Kong/kuma/pkg/core/resources/store"
+	"github.com/Kong/kuma/pkg/plugins/resources/memory"
+	"github.com/Kong/kuma/pkg/xds/topology"
+)
+
+var _ = Describe("GetOutboundTargets()", func() {
+
+	now := time.Date(2019, 12, 1, 12, 0, 0, 0, time.UTC)
+
+	var resManager core_manager.ResourceManager
+	var web *mesh_core.DataplaneResource
+
+	createDataplane := func(name string, ip string, tags map[string]string) *mesh_core.DataplaneResource {
+		dataplane := &mesh_core.DataplaneResource{
+			Spec: mesh_proto.Dataplane{
+				Networking: &mesh_proto.Dataplane_Networking{
+					Inbound: []*mesh_proto.Dataplane_Networking_Inbound{
+						{Interface: ip + ":8080:8081", Tags: tags},
+					},
+					Outbound: []*mesh_proto.Dataplane_Networking_Outbound{
+						{Interface: ":3000", Service: "backend"},
+					},
+				},
+			},
+		}
+		err := resManager.Create(context.Background(), dataplane, core_store.CreateByKey("default", name, "demo"))
+		Expect(err).ToNot(HaveOccurred())
+		return dataplane
+	}
+	createInsight := func(name string, insight mesh_proto.DataplaneInsight) {
+		err := resManager.Create(context.Background(), &mesh_core.DataplaneInsightResource{Spec: insight}, core_store.CreateByKey("default", name, "demo"))
+		Expect(err).ToNot(HaveOccurred())
+	}
+	connect := func(name string, connectTime time.Time) {
+		createInsight(name, mesh_proto.DataplaneInsight{
+			Subscriptions: []*mesh_proto.DiscoverySubscription{
+				{Id: "1", ConnectTime: &types.Timestamp{Seconds: connectTime.Unix()}},
+			},
+		})
+	}
+	createSlowStart := func(name string, window time.Duration, destinations ...map[string]string) {
+		slowStart := &mesh_core.SlowStartResource{
+			Spec: mesh_proto.SlowStart{
+				Window: types.DurationProto(window),
+			},
+		}
+		for _, destination := range destinations {
+			slowStart.Spec.Destinations = append(slowStart.Spec.Destinations, &mesh_proto.SlowStart_Selector{Match: destination})
+		}
+		err := resManager.Create(context.Background(), slowStart, core_store.CreateByKey("default", name, "demo"))
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		resManager = core_manager.NewResourceManager(memory.NewStore())
+		err := resManager.Create(context.Background(), &mesh_core.MeshResource{}, core_store.CreateByKey("default", "demo", "demo"))
+		Expect(err).ToNot(HaveOccurred())
+
+		web = createDataplane("web-01", "192.168.0.1", map[string]string{"service": "web"})
+		createDataplane("backend-01", "192.168.0.2", map[string]string{"service": "backend", "version": "v1"})
+		createDataplane("backend-02", "192.168.0.3", map[string]string{"service": "backend", "version": "v2"})
+	})
+
+	It("should return endpoints without weights", func() {
+		// when
+		targets, err := topology.GetOutboundTargets(context.Background(), web, resManager, now)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(targets).To(Equal(map[string][]net.SRV{
+			"backend": {
+				{Target: "192.168.0.2", Port: 8080},
+				{Target: "192.168.0.3", Port: 8080},
+			},
+		}))
+	})
+
+	It("should weight endpoints selected by a SlowStart", func() {
+		// given
+		createSlowStart("backend", 100*time.Second, map[string]string{"service": "backend"})
+		// and
+		connect("backend-01", now.Add(-time.Hour))
+		connect("backend-02", now.Add(-35*time.Second))
+
+		// when
+		targets, err := topology.GetOutboundTargets(context.Background(), web, resManager, now)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(targets).To(Equal(map[string][]net.SRV{
+			"backend": {
+				{Target: "192.168.0.2", Port: 8080, Weight: topology.FullWeight},
+				{Target: "192.168.0.3", Port: 8080, Weight: 40},
+			},
+		}))
+	})
+
+	It("should keep the full weight of an endpoint whose Dataplane has reconnected", func() {
+		// given
+		createSlowStart("backend", 100*time.Second, map[string]string{"service": "backend"})
+		// and
+		createInsight("backend-01", mesh_proto.DataplaneInsight{
+			FirstConnectTime: &types.Timestamp{Seconds: now.Add(-time.Hour).Unix()},
+			Subscriptions: []*mesh_proto.DiscoverySubscription{
+				{Id: "2", ConnectTime: &types.Timestamp{Seconds: now.Add(-5 * time.Second).Unix()}},
+			},
+		})
+		connect("backend-02", now.Add(-time.Hour))
+
+		// when
+		targets, err := topology.GetOutboundTargets(context.Background(), web, resManager, now)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(targets["backend"]).To(Equal([]net.SRV{
+			{Target: "192.168.0.2", Port: 8080, Weight: topology.FullWeight},
+			{Target: "192.168.0.3", Port: 8080, Weight: topology.FullWeight},
+		}))
+	})
+
+	It("should consider endpoints whose Dataplanes haven't connected yet as new", func() {
+		// given
+		createSlowStart("backend", 100*time.Second, map[string]string{"service": "backend"})
+		// and
+		connect("backend-01", now.Add(-time.Hour))
+
+		// when
+		targets, err := topology.GetOutboundTargets(context.Background(), web, resManager, now)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(targets["backend"]).To(Equal([]net.SRV{
+			{Target: "192.168.0.2", Port: 8080, Weight: topology.FullWeight},
+			{Target: "192.168.0.3", Port: 8080, Weight: 10},
+		}))
+	})
+
+	It("should give the full weight to other endpoints of a service", func() {
+		// given
+		createSlowStart("backend-v2", 100*time.Second, map[string]string{"service": "backend", "version": "v2"})
+
+		// when
+		targets, err := topology.GetOutboundTargets(context.Background(), web, resManager, now)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(targets["backend"]).To(Equal([]net.SRV{
+			{Target: "192.168.0.2", Port: 8080, Weight: topology.FullWeight},
+			{Target: "192.168.0.3", Port: 8080, Weight: 10},
+		}))
+	})
+})
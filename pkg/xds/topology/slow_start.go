@@ -0,0 +1,51 @@
+package topology
+
+import (
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+)
+
+// SelectSlowStart returns the SlowStart that applies to an endpoint with given tags or nil if there is none.
+//
+// If several SlowStarts match the endpoint, the one whose matching selector has the most tags is chosen.
+// SlowStarts that are equally specific are chosen alphabetically by name.
+func SelectSlowStart(slowStarts []*mesh_core.SlowStartResource, tags map[string]string) *mesh_core.SlowStartResource {
+	var best *mesh_core.SlowStartResource
+	bestScore := 0
+	for _, slowStart := range slowStarts {
+		for _, destination := range slowStart.Spec.GetDestinations() {
+			selector := mesh_proto.TagSelector(destination.GetMatch())
+			if !selector.Matches(tags) {
+				continue
+			}
+			score := len(selector)
+			if best == nil || bestScore < score || (bestScore == score && slowStart.GetMeta().GetName() < best.GetMeta().GetName()) {
+				best = slowStart
+				bestScore = score
+			}
+		}
+	}
+	return best
+}
+
+// slowStartWeight returns a weight of an endpoint whose Dataplane has connected for the first time at a given time.
+// An endpoint whose Dataplane hasn't connected yet is considered new.
+func slowStartWeight(slowStart *mesh_proto.SlowStart, connectTime time.Time, now time.Time) uint16 {
+	window, err := types.DurationFromProto(slowStart.GetWindow())
+	if err != nil || window <= 0 {
+		return FullWeight
+	}
+	age := time.Duration(0)
+	if !connectTime.IsZero() && now.After(connectTime) {
+		age = now.Sub(connectTime)
+	}
+	if age >= window {
+		return FullWeight
+	}
+	step := int64(age)*slowStartSteps/int64(window) + 1
+	return uint16(step * FullWeight / slowStartSteps)
+}
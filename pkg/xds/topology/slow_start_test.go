@@ -0,0 +1,57 @@
+package topology_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	mesh_core "github.com/Kong/kuma/pkg/core/resources/apis/mesh"
+	test_model "github.com/Kong/kuma/pkg/test/resources/model"
+	"github.com/Kong/kuma/pkg/xds/topology"
+)
+
+var _ = Describe("SelectSlowStart()", func() {
+
+	slowStart := func(name string, destinations ...map[string]string) *mesh_core.SlowStartResource {
+		slowStart := &mesh_core.SlowStartResource{
+			Meta: &test_model.ResourceMeta{Mesh: "demo", Name: name},
+		}
+		for _, destination := range destinations {
+			slowStart.Spec.Destinations = append(slowStart.Spec.Destinations, &mesh_proto.SlowStart_Selector{Match: destination})
+		}
+		return slowStart
+	}
+
+	It("should return nil if no SlowStart matches an endpoint", func() {
+		// given
+		slowStarts := []*mesh_core.SlowStartResource{
+			slowStart("web", map[string]string{"service": "web"}),
+		}
+
+		// expect
+		Expect(topology.SelectSlowStart(slowStarts, map[string]string{"service": "backend"})).To(BeNil())
+	})
+
+	It("should choose the most specific SlowStart", func() {
+		// given
+		slowStarts := []*mesh_core.SlowStartResource{
+			slowStart("b-v2", map[string]string{"service": "web"}, map[string]string{"service": "backend", "version": "v2"}),
+			slowStart("a-backend", map[string]string{"service": "backend"}),
+		}
+
+		// expect
+		Expect(topology.SelectSlowStart(slowStarts, map[string]string{"service": "backend", "version": "v2"}).GetMeta().GetName()).To(Equal("b-v2"))
+		Expect(topology.SelectSlowStart(slowStarts, map[string]string{"service": "backend", "version": "v1"}).GetMeta().GetName()).To(Equal("a-backend"))
+	})
+
+	It("should choose equally specific SlowStarts by name", func() {
+		// given
+		slowStarts := []*mesh_core.SlowStartResource{
+			slowStart("b", map[string]string{"service": "backend"}),
+			slowStart("a", map[string]string{"service": "backend"}),
+		}
+
+		// expect
+		Expect(topology.SelectSlowStart(slowStarts, map[string]string{"service": "backend"}).GetMeta().GetName()).To(Equal("a"))
+	})
+})
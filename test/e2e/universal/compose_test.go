@@ -0,0 +1,44 @@
+// +build e2e
+
+package universal_test
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/pkg/errors"
+)
+
+const composeProject = "kuma-e2e-universal"
+
+// compose runs `docker-compose` against the setup of the suite and returns its stdout.
+func compose(args ...string) (string, error) {
+	cmd := exec.Command("docker-compose", append([]string{"--project-name", composeProject, "--file", "docker-compose.yaml"}, args...)...)
+	cmd.Env = os.Environ()
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		return stdout.String(), errors.Wrapf(err, "docker-compose %s failed: %s", strings.Join(args, " "), stderr.String())
+	}
+	return stdout.String(), nil
+}
+
+// execIn runs a shell command inside a given container of the setup.
+func execIn(service string, command string) (string, error) {
+	return compose("exec", "-T", service, "sh", "-c", command)
+}
+
+// dumpLogs prints logs of all containers to help understand why a test has failed.
+func dumpLogs() {
+	logs, err := compose("logs", "--no-color")
+	if err != nil {
+		_, _ = GinkgoWriter.Write([]byte(err.Error()))
+		return
+	}
+	_, _ = GinkgoWriter.Write([]byte(logs))
+}
@@ -0,0 +1,15 @@
+// +build e2e
+
+package universal_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestE2EUniversal(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "E2E Universal Suite")
+}
@@ -0,0 +1,156 @@
+// +build e2e
+
+package universal_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	mesh_proto "github.com/Kong/kuma/api/mesh/v1alpha1"
+	api_client "github.com/Kong/kuma/pkg/api-client"
+)
+
+var client api_client.Client
+
+var _ = BeforeSuite(func() {
+	// given a Control Plane
+	_, err := compose("up", "--detach", "--build", "kuma-cp")
+	Expect(err).ToNot(HaveOccurred())
+
+	apiServerPort := os.Getenv("KUMA_E2E_API_SERVER_PORT")
+	if apiServerPort == "" {
+		apiServerPort = "15681"
+	}
+	apiServerUrl := fmt.Sprintf("http://localhost:%s", apiServerPort)
+	Eventually(func() error {
+		resp, err := http.Get(apiServerUrl)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}, "1m", "1s").Should(Succeed())
+	client, err = api_client.NewClient(apiServerUrl)
+	Expect(err).ToNot(HaveOccurred())
+
+	// and a Mesh with mTLS
+	_, err = client.Meshes().Create(context.Background(), "default", mesh_proto.Mesh{
+		Mtls: &mesh_proto.Mesh_Mtls{
+			Enabled: true,
+			Ca: &mesh_proto.CertificateAuthority{
+				Type: &mesh_proto.CertificateAuthority_Builtin_{
+					Builtin: &mesh_proto.CertificateAuthority_Builtin{},
+				},
+			},
+		},
+	})
+	Expect(err).ToNot(HaveOccurred())
+
+	// and Dataplanes
+	_, err = client.Dataplanes().Create(context.Background(), "default", "backend-01", mesh_proto.Dataplane{
+		Networking: &mesh_proto.Dataplane_Networking{
+			Inbound: []*mesh_proto.Dataplane_Networking_Inbound{
+				{Interface: "172.28.1.2:10000:8000", Tags: map[string]string{"service": "backend"}},
+			},
+		},
+	})
+	Expect(err).ToNot(HaveOccurred())
+	_, err = client.Dataplanes().Create(context.Background(), "default", "web-01", mesh_proto.Dataplane{
+		Networking: &mesh_proto.Dataplane_Networking{
+			Inbound: []*mesh_proto.Dataplane_Networking_Inbound{
+				{Interface: "172.28.1.3:10000:8000", Tags: map[string]string{"service": "web"}},
+			},
+			Outbound: []*mesh_proto.Dataplane_Networking_Outbound{
+				{Interface: ":10001", Service: "backend"},
+			},
+		},
+	})
+	Expect(err).ToNot(HaveOccurred())
+
+	// and Envoys with echo servers next to them
+	_, err = compose("up", "--detach", "--build", "backend", "backend-app", "web", "web-app")
+	Expect(err).ToNot(HaveOccurred())
+	for _, service := range []string{"backend", "web"} {
+		Eventually(func() (string, error) {
+			return execIn(service, "wget -qO- http://127.0.0.1:9901/ready")
+		}, "1m", "1s").Should(ContainSubstring("LIVE"))
+	}
+}, 600)
+
+var _ = AfterSuite(func() {
+	if CurrentGinkgoTestDescription().Failed || os.Getenv("KUMA_E2E_DUMP_LOGS") == "true" {
+		dumpLogs()
+	}
+	if os.Getenv("KUMA_E2E_KEEP_SETUP") == "true" {
+		return
+	}
+	_, err := compose("down", "--volumes")
+	Expect(err).ToNot(HaveOccurred())
+})
+
+// echo sends a message from web to backend through the outbound listener of the web dataplane.
+func echo(message string) (string, error) {
+	return execIn("web", fmt.Sprintf("echo -n %s | nc -w 3 127.0.0.1 10001", message))
+}
+
+// statValue returns a value of a counter of a given Envoy, summed across all stats matching a pattern.
+func statValue(service string, pattern string) (int, error) {
+	stats, err := execIn(service, "wget -qO- http://127.0.0.1:9901/stats")
+	if err != nil {
+		return 0, err
+	}
+	sum := 0
+	for _, match := range regexp.MustCompile(`(?m)^`+pattern+`: (\d+)$`).FindAllStringSubmatch(stats, -1) {
+		value, err := strconv.Atoi(match[1])
+		if err != nil {
+			return 0, err
+		}
+		sum += value
+	}
+	return sum, nil
+}
+
+var _ = Describe("Traffic between dataplanes", func() {
+
+	It("should flow over mTLS once permitted", func() {
+		// when
+		_, err := client.TrafficPermissions().Create(context.Background(), "default", "web-to-backend", mesh_proto.TrafficPermission{
+			Rules: []*mesh_proto.TrafficPermission_Rule{
+				{
+					Sources:      []*mesh_proto.TrafficPermission_Rule_Selector{{Match: map[string]string{"service": "web"}}},
+					Destinations: []*mesh_proto.TrafficPermission_Rule_Selector{{Match: map[string]string{"service": "backend"}}},
+				},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		// then
+		Eventually(func() (string, error) {
+			return echo("hello")
+		}, "30s", "1s").Should(Equal("hello"))
+
+		// and connections to backend have been encrypted
+		Expect(statValue("backend", `listener\.\S*10000\.ssl\.handshake`)).To(BeNumerically(">", 0))
+	})
+
+	It("should be rejected once the permission is revoked", func() {
+		// when
+		err := client.TrafficPermissions().Delete(context.Background(), "default", "web-to-backend")
+		Expect(err).ToNot(HaveOccurred())
+
+		// then
+		Eventually(func() string {
+			response, _ := echo("hello")
+			return response
+		}, "30s", "1s").ShouldNot(Equal("hello"))
+
+		// and backend has denied the connection
+		Expect(statValue("backend", `\S*rbac\.denied`)).To(BeNumerically(">", 0))
+	})
+})
@@ -26,6 +26,14 @@ function gen_help() {
 
 gen_help kumactl
 gen_help kumactl apply
+gen_help kumactl delete
+gen_help kumactl convert
+gen_help kumactl convert consul-intentions
+gen_help kumactl export
+gen_help kumactl generate
+gen_help kumactl generate dataplane
+gen_help kumactl generate mesh
+gen_help kumactl generate traffic-permission
 gen_help kumactl config
 gen_help kumactl config view
 gen_help kumactl config control-planes
@@ -42,4 +50,5 @@ gen_help kumactl get proxytemplates
 gen_help kumactl get traffic-permissions
 gen_help kumactl inspect
 gen_help kumactl inspect dataplanes
+gen_help kumactl inspect dataplane
 gen_help kumactl version
\ No newline at end of file